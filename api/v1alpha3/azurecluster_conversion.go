@@ -52,6 +52,16 @@ func (src *AzureCluster) ConvertTo(dstRaw conversion.Hub) error {
 
 	dst.Spec.NetworkSpec.APIServerLB.FrontendIPsCount = restored.Spec.NetworkSpec.APIServerLB.FrontendIPsCount
 	dst.Spec.NetworkSpec.APIServerLB.IdleTimeoutInMinutes = restored.Spec.NetworkSpec.APIServerLB.IdleTimeoutInMinutes
+	dst.Spec.NetworkSpec.APIServerLB.HealthProbe = restored.Spec.NetworkSpec.APIServerLB.HealthProbe
+	dst.Spec.NetworkSpec.APIServerLB.HAPorts = restored.Spec.NetworkSpec.APIServerLB.HAPorts
+	dst.Spec.NetworkSpec.APIServerLB.SSHNATRule = restored.Spec.NetworkSpec.APIServerLB.SSHNATRule
+	dst.Spec.NetworkSpec.APIServerLB.Tier = restored.Spec.NetworkSpec.APIServerLB.Tier
+	dst.Spec.NetworkSpec.APIServerLB.InternalFrontendIP = restored.Spec.NetworkSpec.APIServerLB.InternalFrontendIP
+	dst.Spec.NetworkSpec.APIServerLB.DiagnosticSettings = restored.Spec.NetworkSpec.APIServerLB.DiagnosticSettings
+	dst.Spec.NetworkSpec.APIServerLB.Shared = restored.Spec.NetworkSpec.APIServerLB.Shared
+	dst.Spec.NetworkSpec.APIServerLB.ConnectionDraining = restored.Spec.NetworkSpec.APIServerLB.ConnectionDraining
+	dst.Spec.NetworkSpec.APIServerLB.ForceRecreate = restored.Spec.NetworkSpec.APIServerLB.ForceRecreate
+	restoreFrontendIPZones(dst.Spec.NetworkSpec.APIServerLB.FrontendIPs, restored.Spec.NetworkSpec.APIServerLB.FrontendIPs)
 	dst.Spec.CloudProviderConfigOverrides = restored.Spec.CloudProviderConfigOverrides
 	dst.Spec.BastionSpec = restored.Spec.BastionSpec
 
@@ -89,8 +99,15 @@ func (src *AzureCluster) ConvertTo(dstRaw conversion.Hub) error {
 						restoredOutboundRules = append(restoredOutboundRules, restoredSecurityRule)
 					}
 				}
+				restoreSecurityRules(dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.SecurityRules, restoredSubnet.SecurityGroup.SecurityRules)
+				dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.AllowInboundFrom = restoredSubnet.SecurityGroup.AllowInboundFrom
+				dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.EgressPolicy = restoredSubnet.SecurityGroup.EgressPolicy
+				dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.AllowOutboundTo = restoredSubnet.SecurityGroup.AllowOutboundTo
+				dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.DiagnosticSettings = restoredSubnet.SecurityGroup.DiagnosticSettings
 				dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.SecurityRules = append(dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.SecurityRules, restoredOutboundRules...)
 				dst.Spec.NetworkSpec.Subnets[i].NatGateway = restoredSubnet.NatGateway
+				dst.Spec.NetworkSpec.Subnets[i].FreeIPsThreshold = restoredSubnet.FreeIPsThreshold
+				dst.Spec.NetworkSpec.Subnets[i].FirewallRoute = restoredSubnet.FirewallRoute
 
 				break
 			}
@@ -102,9 +119,124 @@ func (src *AzureCluster) ConvertTo(dstRaw conversion.Hub) error {
 	// Restore list of virtual network peerings
 	dst.Spec.NetworkSpec.Vnet.Peerings = restored.Spec.NetworkSpec.Vnet.Peerings
 
+	// Restore Traffic Manager configuration
+	dst.Spec.NetworkSpec.TrafficManager = restored.Spec.NetworkSpec.TrafficManager
+	dst.Spec.NetworkSpec.GlobalLB = restored.Spec.NetworkSpec.GlobalLB
+	dst.Spec.NetworkSpec.DNSPrivateResolver = restored.Spec.NetworkSpec.DNSPrivateResolver
+	dst.Spec.ResourceGroupLocation = restored.Spec.ResourceGroupLocation
+	dst.Spec.NetworkSpec.OutboundConnectivityCheck = restored.Spec.NetworkSpec.OutboundConnectivityCheck
+	dst.Spec.NetworkSpec.PrivateEndpoints = restored.Spec.NetworkSpec.PrivateEndpoints
+	dst.Spec.NetworkSpec.Ingress = restored.Spec.NetworkSpec.Ingress
+	dst.Spec.NetworkSpec.PodSubnet = restored.Spec.NetworkSpec.PodSubnet
+	dst.Spec.NetworkSpec.InternalLoadBalancers = restored.Spec.NetworkSpec.InternalLoadBalancers
+	dst.Spec.NetworkSpec.NetworkInterfaceSecurityGroups = restored.Spec.NetworkSpec.NetworkInterfaceSecurityGroups
+	dst.Spec.NetworkSpec.RequireSecurityRuleDescriptions = restored.Spec.NetworkSpec.RequireSecurityRuleDescriptions
+	dst.Spec.NetworkSpec.IntraClusterTraffic = restored.Spec.NetworkSpec.IntraClusterTraffic
+
+	// Restore application security groups
+	dst.Spec.NetworkSpec.ApplicationSecurityGroups = restored.Spec.NetworkSpec.ApplicationSecurityGroups
+
+	// Restore list of control plane endpoints
+	dst.Status.ControlPlaneEndpoints = restored.Status.ControlPlaneEndpoints
+
+	// Restore jumpbox IP
+	dst.Status.JumpboxIP = restored.Status.JumpboxIP
+
+	// Restore custom DNS servers
+	dst.Spec.NetworkSpec.Vnet.DNSServers = restored.Spec.NetworkSpec.Vnet.DNSServers
+	dst.Status.DNSServers = restored.Status.DNSServers
+
+	dst.Spec.DeleteGracePeriod = restored.Spec.DeleteGracePeriod
+	dst.Status.DeletionRequestedAt = restored.Status.DeletionRequestedAt
+
+	dst.Status.NatGatewayIPPrefixes = restored.Status.NatGatewayIPPrefixes
+
+	dst.Spec.LogAnalyticsWorkspace = restored.Spec.LogAnalyticsWorkspace
+	dst.Status.LogAnalyticsWorkspace = restored.Status.LogAnalyticsWorkspace
+	dst.Spec.DiagnosticsResourceGroup = restored.Spec.DiagnosticsResourceGroup
+	dst.Status.DiagnosticsResourceGroupLocation = restored.Status.DiagnosticsResourceGroupLocation
+
+	dst.Spec.NamingConvention = restored.Spec.NamingConvention
+	dst.Spec.ReconcileMode = restored.Spec.ReconcileMode
+	dst.Spec.DefaultSpotPolicy = restored.Spec.DefaultSpotPolicy
+	dst.Spec.AutoShutdown = restored.Spec.AutoShutdown
+	dst.Spec.InheritResourceGroupTags = restored.Spec.InheritResourceGroupTags
+	dst.Spec.PolicyAssignments = restored.Spec.PolicyAssignments
+	dst.Spec.RoleAssignments = restored.Spec.RoleAssignments
+	dst.Spec.Budget = restored.Spec.Budget
+	dst.Spec.Gallery = restored.Spec.Gallery
+	dst.Spec.InventoryConfigMapName = restored.Spec.InventoryConfigMapName
+	dst.Spec.SecondaryRegion = restored.Spec.SecondaryRegion
+	dst.Spec.ControlPlaneAvailabilitySet = restored.Spec.ControlPlaneAvailabilitySet
+	dst.Spec.ControlPlaneEtcdDisks = restored.Spec.ControlPlaneEtcdDisks
+	dst.Spec.RequiredFeatures = restored.Spec.RequiredFeatures
+
+	dst.Status.PairedRegion = restored.Status.PairedRegion
+	dst.Status.Location = restored.Status.Location
+	dst.Status.ResourceGroupLocation = restored.Status.ResourceGroupLocation
+	dst.Status.DefaultSpotPolicy = restored.Status.DefaultSpotPolicy
+	dst.Status.AutoShutdown = restored.Status.AutoShutdown
+	dst.Status.FailedReconcileAttempts = restored.Status.FailedReconcileAttempts
+	dst.Status.FailureReason = restored.Status.FailureReason
+	dst.Status.FailureMessage = restored.Status.FailureMessage
+	dst.Status.ClusterUID = restored.Status.ClusterUID
+	dst.Status.PublicIPZones = restored.Status.PublicIPZones
+	dst.Status.SubnetAvailableIPs = restored.Status.SubnetAvailableIPs
+	dst.Status.PolicyAssignmentIDs = restored.Status.PolicyAssignmentIDs
+	dst.Status.RoleAssignmentIDs = restored.Status.RoleAssignmentIDs
+	dst.Status.BudgetID = restored.Status.BudgetID
+	dst.Status.PodSubnetID = restored.Status.PodSubnetID
+	dst.Status.EstimatedCost = restored.Status.EstimatedCost
+	dst.Status.ControlPlaneAvailabilitySetID = restored.Status.ControlPlaneAvailabilitySetID
+	dst.Status.ControlPlaneEtcdDiskZones = restored.Status.ControlPlaneEtcdDiskZones
+	dst.Status.NetworkInterfaceSecurityGroupIDs = restored.Status.NetworkInterfaceSecurityGroupIDs
+	dst.Status.LoadBalancerTiers = restored.Status.LoadBalancerTiers
+	dst.Status.GlobalLoadBalancerBackends = restored.Status.GlobalLoadBalancerBackends
+	dst.Status.GalleryImageID = restored.Status.GalleryImageID
+	dst.Status.PrivateEndpointIPs = restored.Status.PrivateEndpointIPs
+	dst.Status.SecondaryNetwork = restored.Status.SecondaryNetwork
+	dst.Status.GeneratedSecurityRules = restored.Status.GeneratedSecurityRules
+	dst.Status.ControlPlaneEgressIPs = restored.Status.ControlPlaneEgressIPs
+	dst.Status.PublicIPPrefixAllocations = restored.Status.PublicIPPrefixAllocations
+
 	return nil
 }
 
+// restoreSecurityRules restores the application security group references and the action of the security rules,
+// matching the rules by name.
+func restoreSecurityRules(dst, restored infrav1beta1.SecurityRules) {
+	for _, restoredRule := range restored {
+		for i := range dst {
+			if dst[i].Name == restoredRule.Name {
+				dst[i].SourceApplicationSecurityGroups = restoredRule.SourceApplicationSecurityGroups
+				dst[i].DestinationApplicationSecurityGroups = restoredRule.DestinationApplicationSecurityGroups
+				dst[i].Action = restoredRule.Action
+				break
+			}
+		}
+	}
+}
+
+// restoreFrontendIPZones restores the availability zones, tiers, IP tags, routing preferences, IP prefixes and diagnostic settings of the public IPs of the frontend IPs, matching the frontend IPs by name.
+func restoreFrontendIPZones(dst, restored []infrav1beta1.FrontendIP) {
+	for _, restoredFrontendIP := range restored {
+		if restoredFrontendIP.PublicIP == nil {
+			continue
+		}
+		for i, dstFrontendIP := range dst {
+			if dstFrontendIP.Name == restoredFrontendIP.Name && dstFrontendIP.PublicIP != nil {
+				dst[i].PublicIP.Zones = restoredFrontendIP.PublicIP.Zones
+				dst[i].PublicIP.Tier = restoredFrontendIP.PublicIP.Tier
+				dst[i].PublicIP.IPTags = restoredFrontendIP.PublicIP.IPTags
+				dst[i].PublicIP.RoutingPreference = restoredFrontendIP.PublicIP.RoutingPreference
+				dst[i].PublicIP.IPPrefixID = restoredFrontendIP.PublicIP.IPPrefixID
+				dst[i].PublicIP.DiagnosticSettings = restoredFrontendIP.PublicIP.DiagnosticSettings
+				break
+			}
+		}
+	}
+}
+
 // ConvertFrom converts from the Hub version (v1beta1) to this version.
 func (dst *AzureCluster) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*infrav1beta1.AzureCluster)
@@ -406,3 +538,13 @@ func Convert_v1beta1_FrontendIP_To_v1alpha3_FrontendIP(in *infrav1beta1.Frontend
 
 	return nil
 }
+
+// Convert_v1beta1_PublicIPSpec_To_v1alpha3_PublicIPSpec is an autogenerated conversion function.
+func Convert_v1beta1_PublicIPSpec_To_v1alpha3_PublicIPSpec(in *infrav1beta1.PublicIPSpec, out *PublicIPSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_PublicIPSpec_To_v1alpha3_PublicIPSpec(in, out, s)
+}
+
+// Convert_v1beta1_BuildParams_To_v1alpha3_BuildParams converts from the Hub version (v1beta1) of the BuildParams to this version.
+func Convert_v1beta1_BuildParams_To_v1alpha3_BuildParams(in *infrav1beta1.BuildParams, out *BuildParams, s apiconversion.Scope) error { //nolint
+	return autoConvert_v1beta1_BuildParams_To_v1alpha3_BuildParams(in, out, s)
+}
@@ -53,6 +53,7 @@ func (src *AzureMachine) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.SubnetName = restored.Spec.SubnetName
 
 	dst.Status.LongRunningOperationStates = restored.Status.LongRunningOperationStates
+	dst.Status.DrainStartedAt = restored.Status.DrainStartedAt
 
 	return nil
 }
@@ -176,11 +176,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.BuildParams)(nil), (*BuildParams)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_BuildParams_To_v1alpha3_BuildParams(a.(*v1beta1.BuildParams), b.(*BuildParams), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*DataDisk)(nil), (*v1beta1.DataDisk)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_DataDisk_To_v1beta1_DataDisk(a.(*DataDisk), b.(*v1beta1.DataDisk), scope)
 	}); err != nil {
@@ -226,11 +221,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.PublicIPSpec)(nil), (*PublicIPSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_PublicIPSpec_To_v1alpha3_PublicIPSpec(a.(*v1beta1.PublicIPSpec), b.(*PublicIPSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*RouteTable)(nil), (*v1beta1.RouteTable)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_RouteTable_To_v1beta1_RouteTable(a.(*RouteTable), b.(*v1beta1.RouteTable), scope)
 	}); err != nil {
@@ -381,6 +371,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.BuildParams)(nil), (*BuildParams)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_BuildParams_To_v1alpha3_BuildParams(a.(*v1beta1.BuildParams), b.(*BuildParams), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.FrontendIP)(nil), (*FrontendIP)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_FrontendIP_To_v1alpha3_FrontendIP(a.(*v1beta1.FrontendIP), b.(*FrontendIP), scope)
 	}); err != nil {
@@ -411,6 +406,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.PublicIPSpec)(nil), (*PublicIPSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PublicIPSpec_To_v1alpha3_PublicIPSpec(a.(*v1beta1.PublicIPSpec), b.(*PublicIPSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.SecurityGroup)(nil), (*SecurityGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_SecurityGroup_To_v1alpha3_SecurityGroup(a.(*v1beta1.SecurityGroup), b.(*SecurityGroup), scope)
 	}); err != nil {
@@ -685,10 +685,28 @@ func autoConvert_v1beta1_AzureClusterSpec_To_v1alpha3_AzureClusterSpec(in *v1bet
 		return err
 	}
 	out.ResourceGroup = in.ResourceGroup
+	// WARNING: in.ResourceGroupLocation requires manual conversion: does not exist in peer-type
 	// WARNING: in.BastionSpec requires manual conversion: does not exist in peer-type
 	if err := apiv1alpha3.Convert_v1beta1_APIEndpoint_To_v1alpha3_APIEndpoint(&in.ControlPlaneEndpoint, &out.ControlPlaneEndpoint, s); err != nil {
 		return err
 	}
+	// WARNING: in.DeleteGracePeriod requires manual conversion: does not exist in peer-type
+	// WARNING: in.LogAnalyticsWorkspace requires manual conversion: does not exist in peer-type
+	// WARNING: in.DiagnosticsResourceGroup requires manual conversion: does not exist in peer-type
+	// WARNING: in.NamingConvention requires manual conversion: does not exist in peer-type
+	// WARNING: in.ReconcileMode requires manual conversion: does not exist in peer-type
+	// WARNING: in.DefaultSpotPolicy requires manual conversion: does not exist in peer-type
+	// WARNING: in.AutoShutdown requires manual conversion: does not exist in peer-type
+	// WARNING: in.InheritResourceGroupTags requires manual conversion: does not exist in peer-type
+	// WARNING: in.PolicyAssignments requires manual conversion: does not exist in peer-type
+	// WARNING: in.RoleAssignments requires manual conversion: does not exist in peer-type
+	// WARNING: in.Budget requires manual conversion: does not exist in peer-type
+	// WARNING: in.Gallery requires manual conversion: does not exist in peer-type
+	// WARNING: in.ControlPlaneAvailabilitySet requires manual conversion: does not exist in peer-type
+	// WARNING: in.ControlPlaneEtcdDisks requires manual conversion: does not exist in peer-type
+	// WARNING: in.RequiredFeatures requires manual conversion: does not exist in peer-type
+	// WARNING: in.InventoryConfigMapName requires manual conversion: does not exist in peer-type
+	// WARNING: in.SecondaryRegion requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -748,6 +766,40 @@ func autoConvert_v1beta1_AzureClusterStatus_To_v1alpha3_AzureClusterStatus(in *v
 		out.Conditions = nil
 	}
 	// WARNING: in.LongRunningOperationStates requires manual conversion: does not exist in peer-type
+	// WARNING: in.ControlPlaneEndpoints requires manual conversion: does not exist in peer-type
+	// WARNING: in.JumpboxIP requires manual conversion: does not exist in peer-type
+	// WARNING: in.DNSServers requires manual conversion: does not exist in peer-type
+	// WARNING: in.DeletionRequestedAt requires manual conversion: does not exist in peer-type
+	// WARNING: in.ControlPlaneEgressIPs requires manual conversion: does not exist in peer-type
+	// WARNING: in.PublicIPPrefixAllocations requires manual conversion: does not exist in peer-type
+	// WARNING: in.NatGatewayIPPrefixes requires manual conversion: does not exist in peer-type
+	// WARNING: in.PublicIPZones requires manual conversion: does not exist in peer-type
+	// WARNING: in.SubnetAvailableIPs requires manual conversion: does not exist in peer-type
+	// WARNING: in.LogAnalyticsWorkspace requires manual conversion: does not exist in peer-type
+	// WARNING: in.PairedRegion requires manual conversion: does not exist in peer-type
+	// WARNING: in.Location requires manual conversion: does not exist in peer-type
+	// WARNING: in.ResourceGroupLocation requires manual conversion: does not exist in peer-type
+	// WARNING: in.DiagnosticsResourceGroupLocation requires manual conversion: does not exist in peer-type
+	// WARNING: in.DefaultSpotPolicy requires manual conversion: does not exist in peer-type
+	// WARNING: in.AutoShutdown requires manual conversion: does not exist in peer-type
+	// WARNING: in.FailedReconcileAttempts requires manual conversion: does not exist in peer-type
+	// WARNING: in.GeneratedSecurityRules requires manual conversion: does not exist in peer-type
+	// WARNING: in.LoadBalancerTiers requires manual conversion: does not exist in peer-type
+	// WARNING: in.GlobalLoadBalancerBackends requires manual conversion: does not exist in peer-type
+	// WARNING: in.NetworkInterfaceSecurityGroupIDs requires manual conversion: does not exist in peer-type
+	// WARNING: in.PolicyAssignmentIDs requires manual conversion: does not exist in peer-type
+	// WARNING: in.RoleAssignmentIDs requires manual conversion: does not exist in peer-type
+	// WARNING: in.BudgetID requires manual conversion: does not exist in peer-type
+	// WARNING: in.PodSubnetID requires manual conversion: does not exist in peer-type
+	// WARNING: in.EstimatedCost requires manual conversion: does not exist in peer-type
+	// WARNING: in.ControlPlaneAvailabilitySetID requires manual conversion: does not exist in peer-type
+	// WARNING: in.ControlPlaneEtcdDiskZones requires manual conversion: does not exist in peer-type
+	// WARNING: in.GalleryImageID requires manual conversion: does not exist in peer-type
+	// WARNING: in.PrivateEndpointIPs requires manual conversion: does not exist in peer-type
+	// WARNING: in.SecondaryNetwork requires manual conversion: does not exist in peer-type
+	// WARNING: in.FailureReason requires manual conversion: does not exist in peer-type
+	// WARNING: in.FailureMessage requires manual conversion: does not exist in peer-type
+	// WARNING: in.ClusterUID requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -946,6 +998,7 @@ func autoConvert_v1beta1_AzureMachineStatus_To_v1alpha3_AzureMachineStatus(in *v
 		out.Conditions = nil
 	}
 	// WARNING: in.LongRunningOperationStates requires manual conversion: does not exist in peer-type
+	// WARNING: in.DrainStartedAt requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1136,15 +1189,12 @@ func autoConvert_v1beta1_BuildParams_To_v1alpha3_BuildParams(in *v1beta1.BuildPa
 	out.ResourceID = in.ResourceID
 	out.Name = (*string)(unsafe.Pointer(in.Name))
 	out.Role = (*string)(unsafe.Pointer(in.Role))
+	// WARNING: in.ProviderVersion requires manual conversion: does not exist in peer-type
+	// WARNING: in.ClusterUID requires manual conversion: does not exist in peer-type
 	out.Additional = *(*Tags)(unsafe.Pointer(&in.Additional))
 	return nil
 }
 
-// Convert_v1beta1_BuildParams_To_v1alpha3_BuildParams is an autogenerated conversion function.
-func Convert_v1beta1_BuildParams_To_v1alpha3_BuildParams(in *v1beta1.BuildParams, out *BuildParams, s conversion.Scope) error {
-	return autoConvert_v1beta1_BuildParams_To_v1alpha3_BuildParams(in, out, s)
-}
-
 func autoConvert_v1alpha3_DataDisk_To_v1beta1_DataDisk(in *DataDisk, out *v1beta1.DataDisk, s conversion.Scope) error {
 	out.NameSuffix = in.NameSuffix
 	out.DiskSizeGB = in.DiskSizeGB
@@ -1232,13 +1282,29 @@ func Convert_v1beta1_DiskEncryptionSetParameters_To_v1alpha3_DiskEncryptionSetPa
 func autoConvert_v1alpha3_FrontendIP_To_v1beta1_FrontendIP(in *FrontendIP, out *v1beta1.FrontendIP, s conversion.Scope) error {
 	out.Name = in.Name
 	// WARNING: in.PrivateIPAddress requires manual conversion: does not exist in peer-type
-	out.PublicIP = (*v1beta1.PublicIPSpec)(unsafe.Pointer(in.PublicIP))
+	if in.PublicIP != nil {
+		in, out := &in.PublicIP, &out.PublicIP
+		*out = new(v1beta1.PublicIPSpec)
+		if err := Convert_v1alpha3_PublicIPSpec_To_v1beta1_PublicIPSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PublicIP = nil
+	}
 	return nil
 }
 
 func autoConvert_v1beta1_FrontendIP_To_v1alpha3_FrontendIP(in *v1beta1.FrontendIP, out *FrontendIP, s conversion.Scope) error {
 	out.Name = in.Name
-	out.PublicIP = (*PublicIPSpec)(unsafe.Pointer(in.PublicIP))
+	if in.PublicIP != nil {
+		in, out := &in.PublicIP, &out.PublicIP
+		*out = new(PublicIPSpec)
+		if err := Convert_v1beta1_PublicIPSpec_To_v1alpha3_PublicIPSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PublicIP = nil
+	}
 	// WARNING: in.FrontendIPClass requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -1312,6 +1378,7 @@ func autoConvert_v1alpha3_LoadBalancerSpec_To_v1beta1_LoadBalancerSpec(in *LoadB
 func autoConvert_v1beta1_LoadBalancerSpec_To_v1alpha3_LoadBalancerSpec(in *v1beta1.LoadBalancerSpec, out *LoadBalancerSpec, s conversion.Scope) error {
 	out.ID = in.ID
 	out.Name = in.Name
+	// WARNING: in.Shared requires manual conversion: does not exist in peer-type
 	// WARNING: in.LoadBalancerClassSpec requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -1357,6 +1424,18 @@ func autoConvert_v1beta1_NetworkSpec_To_v1alpha3_NetworkSpec(in *v1beta1.Network
 	}
 	// WARNING: in.NodeOutboundLB requires manual conversion: does not exist in peer-type
 	// WARNING: in.ControlPlaneOutboundLB requires manual conversion: does not exist in peer-type
+	// WARNING: in.TrafficManager requires manual conversion: does not exist in peer-type
+	// WARNING: in.GlobalLB requires manual conversion: does not exist in peer-type
+	// WARNING: in.DNSPrivateResolver requires manual conversion: does not exist in peer-type
+	// WARNING: in.ApplicationSecurityGroups requires manual conversion: does not exist in peer-type
+	// WARNING: in.NetworkInterfaceSecurityGroups requires manual conversion: does not exist in peer-type
+	// WARNING: in.RequireSecurityRuleDescriptions requires manual conversion: does not exist in peer-type
+	// WARNING: in.IntraClusterTraffic requires manual conversion: does not exist in peer-type
+	// WARNING: in.OutboundConnectivityCheck requires manual conversion: does not exist in peer-type
+	// WARNING: in.PrivateEndpoints requires manual conversion: does not exist in peer-type
+	// WARNING: in.Ingress requires manual conversion: does not exist in peer-type
+	// WARNING: in.PodSubnet requires manual conversion: does not exist in peer-type
+	// WARNING: in.InternalLoadBalancers requires manual conversion: does not exist in peer-type
 	// WARNING: in.NetworkClassSpec requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -1375,14 +1454,15 @@ func Convert_v1alpha3_PublicIPSpec_To_v1beta1_PublicIPSpec(in *PublicIPSpec, out
 func autoConvert_v1beta1_PublicIPSpec_To_v1alpha3_PublicIPSpec(in *v1beta1.PublicIPSpec, out *PublicIPSpec, s conversion.Scope) error {
 	out.Name = in.Name
 	out.DNSName = in.DNSName
+	// WARNING: in.Zones requires manual conversion: does not exist in peer-type
+	// WARNING: in.Tier requires manual conversion: does not exist in peer-type
+	// WARNING: in.IPTags requires manual conversion: does not exist in peer-type
+	// WARNING: in.RoutingPreference requires manual conversion: does not exist in peer-type
+	// WARNING: in.IPPrefixID requires manual conversion: does not exist in peer-type
+	// WARNING: in.DiagnosticSettings requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_PublicIPSpec_To_v1alpha3_PublicIPSpec is an autogenerated conversion function.
-func Convert_v1beta1_PublicIPSpec_To_v1alpha3_PublicIPSpec(in *v1beta1.PublicIPSpec, out *PublicIPSpec, s conversion.Scope) error {
-	return autoConvert_v1beta1_PublicIPSpec_To_v1alpha3_PublicIPSpec(in, out, s)
-}
-
 func autoConvert_v1alpha3_RouteTable_To_v1beta1_RouteTable(in *RouteTable, out *v1beta1.RouteTable, s conversion.Scope) error {
 	out.ID = in.ID
 	out.Name = in.Name
@@ -1486,6 +1566,8 @@ func autoConvert_v1beta1_SubnetSpec_To_v1alpha3_SubnetSpec(in *v1beta1.SubnetSpe
 		return err
 	}
 	// WARNING: in.NatGateway requires manual conversion: does not exist in peer-type
+	// WARNING: in.FreeIPsThreshold requires manual conversion: does not exist in peer-type
+	// WARNING: in.FirewallRoute requires manual conversion: does not exist in peer-type
 	// WARNING: in.SubnetClassSpec requires manual conversion: does not exist in peer-type
 	return nil
 }
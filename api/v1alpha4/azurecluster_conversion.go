@@ -42,9 +42,207 @@ func (src *AzureCluster) ConvertTo(dstRaw conversion.Hub) error {
 	// Restore list of virtual network peerings
 	dst.Spec.NetworkSpec.Vnet.Peerings = restored.Spec.NetworkSpec.Vnet.Peerings
 
+	// Restore the health probes, HA ports, SSH NAT rules, tiers, internal frontends, diagnostic settings, sharing, connection draining and forced recreation of the load balancers
+	dst.Spec.NetworkSpec.APIServerLB.HealthProbe = restored.Spec.NetworkSpec.APIServerLB.HealthProbe
+	dst.Spec.NetworkSpec.APIServerLB.HAPorts = restored.Spec.NetworkSpec.APIServerLB.HAPorts
+	dst.Spec.NetworkSpec.APIServerLB.SSHNATRule = restored.Spec.NetworkSpec.APIServerLB.SSHNATRule
+	dst.Spec.NetworkSpec.APIServerLB.Tier = restored.Spec.NetworkSpec.APIServerLB.Tier
+	dst.Spec.NetworkSpec.APIServerLB.InternalFrontendIP = restored.Spec.NetworkSpec.APIServerLB.InternalFrontendIP
+	dst.Spec.NetworkSpec.APIServerLB.DiagnosticSettings = restored.Spec.NetworkSpec.APIServerLB.DiagnosticSettings
+	dst.Spec.NetworkSpec.APIServerLB.Shared = restored.Spec.NetworkSpec.APIServerLB.Shared
+	dst.Spec.NetworkSpec.APIServerLB.ConnectionDraining = restored.Spec.NetworkSpec.APIServerLB.ConnectionDraining
+	dst.Spec.NetworkSpec.APIServerLB.ForceRecreate = restored.Spec.NetworkSpec.APIServerLB.ForceRecreate
+	restoreFrontendIPZones(dst.Spec.NetworkSpec.APIServerLB.FrontendIPs, restored.Spec.NetworkSpec.APIServerLB.FrontendIPs)
+	if dst.Spec.NetworkSpec.NodeOutboundLB != nil && restored.Spec.NetworkSpec.NodeOutboundLB != nil {
+		dst.Spec.NetworkSpec.NodeOutboundLB.HealthProbe = restored.Spec.NetworkSpec.NodeOutboundLB.HealthProbe
+		dst.Spec.NetworkSpec.NodeOutboundLB.HAPorts = restored.Spec.NetworkSpec.NodeOutboundLB.HAPorts
+		dst.Spec.NetworkSpec.NodeOutboundLB.SSHNATRule = restored.Spec.NetworkSpec.NodeOutboundLB.SSHNATRule
+		dst.Spec.NetworkSpec.NodeOutboundLB.Tier = restored.Spec.NetworkSpec.NodeOutboundLB.Tier
+		dst.Spec.NetworkSpec.NodeOutboundLB.InternalFrontendIP = restored.Spec.NetworkSpec.NodeOutboundLB.InternalFrontendIP
+		dst.Spec.NetworkSpec.NodeOutboundLB.DiagnosticSettings = restored.Spec.NetworkSpec.NodeOutboundLB.DiagnosticSettings
+		dst.Spec.NetworkSpec.NodeOutboundLB.Shared = restored.Spec.NetworkSpec.NodeOutboundLB.Shared
+		dst.Spec.NetworkSpec.NodeOutboundLB.ConnectionDraining = restored.Spec.NetworkSpec.NodeOutboundLB.ConnectionDraining
+		dst.Spec.NetworkSpec.NodeOutboundLB.ForceRecreate = restored.Spec.NetworkSpec.NodeOutboundLB.ForceRecreate
+		restoreFrontendIPZones(dst.Spec.NetworkSpec.NodeOutboundLB.FrontendIPs, restored.Spec.NetworkSpec.NodeOutboundLB.FrontendIPs)
+	}
+	if dst.Spec.NetworkSpec.ControlPlaneOutboundLB != nil && restored.Spec.NetworkSpec.ControlPlaneOutboundLB != nil {
+		dst.Spec.NetworkSpec.ControlPlaneOutboundLB.HealthProbe = restored.Spec.NetworkSpec.ControlPlaneOutboundLB.HealthProbe
+		dst.Spec.NetworkSpec.ControlPlaneOutboundLB.HAPorts = restored.Spec.NetworkSpec.ControlPlaneOutboundLB.HAPorts
+		dst.Spec.NetworkSpec.ControlPlaneOutboundLB.SSHNATRule = restored.Spec.NetworkSpec.ControlPlaneOutboundLB.SSHNATRule
+		dst.Spec.NetworkSpec.ControlPlaneOutboundLB.Tier = restored.Spec.NetworkSpec.ControlPlaneOutboundLB.Tier
+		dst.Spec.NetworkSpec.ControlPlaneOutboundLB.InternalFrontendIP = restored.Spec.NetworkSpec.ControlPlaneOutboundLB.InternalFrontendIP
+		dst.Spec.NetworkSpec.ControlPlaneOutboundLB.DiagnosticSettings = restored.Spec.NetworkSpec.ControlPlaneOutboundLB.DiagnosticSettings
+		dst.Spec.NetworkSpec.ControlPlaneOutboundLB.Shared = restored.Spec.NetworkSpec.ControlPlaneOutboundLB.Shared
+		dst.Spec.NetworkSpec.ControlPlaneOutboundLB.ConnectionDraining = restored.Spec.NetworkSpec.ControlPlaneOutboundLB.ConnectionDraining
+		dst.Spec.NetworkSpec.ControlPlaneOutboundLB.ForceRecreate = restored.Spec.NetworkSpec.ControlPlaneOutboundLB.ForceRecreate
+		restoreFrontendIPZones(dst.Spec.NetworkSpec.ControlPlaneOutboundLB.FrontendIPs, restored.Spec.NetworkSpec.ControlPlaneOutboundLB.FrontendIPs)
+	}
+
+	// Restore Traffic Manager configuration
+	dst.Spec.NetworkSpec.TrafficManager = restored.Spec.NetworkSpec.TrafficManager
+	dst.Spec.NetworkSpec.GlobalLB = restored.Spec.NetworkSpec.GlobalLB
+	dst.Spec.NetworkSpec.DNSPrivateResolver = restored.Spec.NetworkSpec.DNSPrivateResolver
+	dst.Spec.ResourceGroupLocation = restored.Spec.ResourceGroupLocation
+	dst.Spec.NetworkSpec.OutboundConnectivityCheck = restored.Spec.NetworkSpec.OutboundConnectivityCheck
+	dst.Spec.NetworkSpec.PrivateEndpoints = restored.Spec.NetworkSpec.PrivateEndpoints
+	dst.Spec.NetworkSpec.Ingress = restored.Spec.NetworkSpec.Ingress
+	dst.Spec.NetworkSpec.PodSubnet = restored.Spec.NetworkSpec.PodSubnet
+	dst.Spec.NetworkSpec.InternalLoadBalancers = restored.Spec.NetworkSpec.InternalLoadBalancers
+	dst.Spec.NetworkSpec.NetworkInterfaceSecurityGroups = restored.Spec.NetworkSpec.NetworkInterfaceSecurityGroups
+	dst.Spec.NetworkSpec.RequireSecurityRuleDescriptions = restored.Spec.NetworkSpec.RequireSecurityRuleDescriptions
+	dst.Spec.NetworkSpec.IntraClusterTraffic = restored.Spec.NetworkSpec.IntraClusterTraffic
+
+	// Restore application security groups, the security rules references to them and the NAT gateway settings of the subnets
+	dst.Spec.NetworkSpec.ApplicationSecurityGroups = restored.Spec.NetworkSpec.ApplicationSecurityGroups
+	for _, restoredSubnet := range restored.Spec.NetworkSpec.Subnets {
+		for i, dstSubnet := range dst.Spec.NetworkSpec.Subnets {
+			if dstSubnet.Name == restoredSubnet.Name {
+				restoreSecurityRules(dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.SecurityRules, restoredSubnet.SecurityGroup.SecurityRules)
+				dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.AllowInboundFrom = restoredSubnet.SecurityGroup.AllowInboundFrom
+				dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.EgressPolicy = restoredSubnet.SecurityGroup.EgressPolicy
+				dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.AllowOutboundTo = restoredSubnet.SecurityGroup.AllowOutboundTo
+				dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.DiagnosticSettings = restoredSubnet.SecurityGroup.DiagnosticSettings
+				restoreNatGateway(&dst.Spec.NetworkSpec.Subnets[i].NatGateway, restoredSubnet.NatGateway)
+				dst.Spec.NetworkSpec.Subnets[i].FreeIPsThreshold = restoredSubnet.FreeIPsThreshold
+				dst.Spec.NetworkSpec.Subnets[i].FirewallRoute = restoredSubnet.FirewallRoute
+				break
+			}
+		}
+	}
+	if dst.Spec.BastionSpec.AzureBastion != nil && restored.Spec.BastionSpec.AzureBastion != nil {
+		restoreSecurityRules(dst.Spec.BastionSpec.AzureBastion.Subnet.SecurityGroup.SecurityRules, restored.Spec.BastionSpec.AzureBastion.Subnet.SecurityGroup.SecurityRules)
+		dst.Spec.BastionSpec.AzureBastion.Subnet.SecurityGroup.AllowInboundFrom = restored.Spec.BastionSpec.AzureBastion.Subnet.SecurityGroup.AllowInboundFrom
+		dst.Spec.BastionSpec.AzureBastion.Subnet.SecurityGroup.EgressPolicy = restored.Spec.BastionSpec.AzureBastion.Subnet.SecurityGroup.EgressPolicy
+		dst.Spec.BastionSpec.AzureBastion.Subnet.SecurityGroup.AllowOutboundTo = restored.Spec.BastionSpec.AzureBastion.Subnet.SecurityGroup.AllowOutboundTo
+		dst.Spec.BastionSpec.AzureBastion.Subnet.SecurityGroup.DiagnosticSettings = restored.Spec.BastionSpec.AzureBastion.Subnet.SecurityGroup.DiagnosticSettings
+		restoreNatGateway(&dst.Spec.BastionSpec.AzureBastion.Subnet.NatGateway, restored.Spec.BastionSpec.AzureBastion.Subnet.NatGateway)
+		dst.Spec.BastionSpec.AzureBastion.PublicIP.Zones = restored.Spec.BastionSpec.AzureBastion.PublicIP.Zones
+		dst.Spec.BastionSpec.AzureBastion.PublicIP.Tier = restored.Spec.BastionSpec.AzureBastion.PublicIP.Tier
+		dst.Spec.BastionSpec.AzureBastion.PublicIP.IPTags = restored.Spec.BastionSpec.AzureBastion.PublicIP.IPTags
+		dst.Spec.BastionSpec.AzureBastion.PublicIP.RoutingPreference = restored.Spec.BastionSpec.AzureBastion.PublicIP.RoutingPreference
+		dst.Spec.BastionSpec.AzureBastion.PublicIP.IPPrefixID = restored.Spec.BastionSpec.AzureBastion.PublicIP.IPPrefixID
+		dst.Spec.BastionSpec.AzureBastion.PublicIP.DiagnosticSettings = restored.Spec.BastionSpec.AzureBastion.PublicIP.DiagnosticSettings
+		dst.Spec.BastionSpec.AzureBastion.Subnet.FreeIPsThreshold = restored.Spec.BastionSpec.AzureBastion.Subnet.FreeIPsThreshold
+		dst.Spec.BastionSpec.AzureBastion.Subnet.FirewallRoute = restored.Spec.BastionSpec.AzureBastion.Subnet.FirewallRoute
+	}
+
+	// Restore jumpbox
+	dst.Spec.BastionSpec.Jumpbox = restored.Spec.BastionSpec.Jumpbox
+
+	// Restore list of control plane endpoints
+	dst.Status.ControlPlaneEndpoints = restored.Status.ControlPlaneEndpoints
+
+	// Restore jumpbox IP
+	dst.Status.JumpboxIP = restored.Status.JumpboxIP
+
+	// Restore custom DNS servers
+	dst.Spec.NetworkSpec.Vnet.DNSServers = restored.Spec.NetworkSpec.Vnet.DNSServers
+	dst.Status.DNSServers = restored.Status.DNSServers
+
+	dst.Spec.DeleteGracePeriod = restored.Spec.DeleteGracePeriod
+	dst.Status.DeletionRequestedAt = restored.Status.DeletionRequestedAt
+
+	dst.Status.NatGatewayIPPrefixes = restored.Status.NatGatewayIPPrefixes
+
+	dst.Spec.LogAnalyticsWorkspace = restored.Spec.LogAnalyticsWorkspace
+	dst.Status.LogAnalyticsWorkspace = restored.Status.LogAnalyticsWorkspace
+	dst.Spec.DiagnosticsResourceGroup = restored.Spec.DiagnosticsResourceGroup
+	dst.Status.DiagnosticsResourceGroupLocation = restored.Status.DiagnosticsResourceGroupLocation
+
+	dst.Spec.NamingConvention = restored.Spec.NamingConvention
+	dst.Spec.ReconcileMode = restored.Spec.ReconcileMode
+	dst.Spec.DefaultSpotPolicy = restored.Spec.DefaultSpotPolicy
+	dst.Spec.AutoShutdown = restored.Spec.AutoShutdown
+	dst.Spec.InheritResourceGroupTags = restored.Spec.InheritResourceGroupTags
+	dst.Spec.PolicyAssignments = restored.Spec.PolicyAssignments
+	dst.Spec.RoleAssignments = restored.Spec.RoleAssignments
+	dst.Spec.Budget = restored.Spec.Budget
+	dst.Spec.Gallery = restored.Spec.Gallery
+	dst.Spec.InventoryConfigMapName = restored.Spec.InventoryConfigMapName
+	dst.Spec.SecondaryRegion = restored.Spec.SecondaryRegion
+	dst.Spec.ControlPlaneAvailabilitySet = restored.Spec.ControlPlaneAvailabilitySet
+	dst.Spec.ControlPlaneEtcdDisks = restored.Spec.ControlPlaneEtcdDisks
+	dst.Spec.RequiredFeatures = restored.Spec.RequiredFeatures
+
+	dst.Status.PairedRegion = restored.Status.PairedRegion
+	dst.Status.Location = restored.Status.Location
+	dst.Status.ResourceGroupLocation = restored.Status.ResourceGroupLocation
+	dst.Status.DefaultSpotPolicy = restored.Status.DefaultSpotPolicy
+	dst.Status.AutoShutdown = restored.Status.AutoShutdown
+	dst.Status.FailedReconcileAttempts = restored.Status.FailedReconcileAttempts
+	dst.Status.FailureReason = restored.Status.FailureReason
+	dst.Status.FailureMessage = restored.Status.FailureMessage
+	dst.Status.ClusterUID = restored.Status.ClusterUID
+	dst.Status.PublicIPZones = restored.Status.PublicIPZones
+	dst.Status.SubnetAvailableIPs = restored.Status.SubnetAvailableIPs
+	dst.Status.PolicyAssignmentIDs = restored.Status.PolicyAssignmentIDs
+	dst.Status.RoleAssignmentIDs = restored.Status.RoleAssignmentIDs
+	dst.Status.BudgetID = restored.Status.BudgetID
+	dst.Status.PodSubnetID = restored.Status.PodSubnetID
+	dst.Status.EstimatedCost = restored.Status.EstimatedCost
+	dst.Status.ControlPlaneAvailabilitySetID = restored.Status.ControlPlaneAvailabilitySetID
+	dst.Status.ControlPlaneEtcdDiskZones = restored.Status.ControlPlaneEtcdDiskZones
+	dst.Status.NetworkInterfaceSecurityGroupIDs = restored.Status.NetworkInterfaceSecurityGroupIDs
+	dst.Status.LoadBalancerTiers = restored.Status.LoadBalancerTiers
+	dst.Status.GlobalLoadBalancerBackends = restored.Status.GlobalLoadBalancerBackends
+	dst.Status.GalleryImageID = restored.Status.GalleryImageID
+	dst.Status.PrivateEndpointIPs = restored.Status.PrivateEndpointIPs
+	dst.Status.SecondaryNetwork = restored.Status.SecondaryNetwork
+	dst.Status.GeneratedSecurityRules = restored.Status.GeneratedSecurityRules
+	dst.Status.ControlPlaneEgressIPs = restored.Status.ControlPlaneEgressIPs
+	dst.Status.PublicIPPrefixAllocations = restored.Status.PublicIPPrefixAllocations
+
 	return nil
 }
 
+// restoreSecurityRules restores the application security group references and the action of the security rules,
+// matching the rules by name.
+func restoreSecurityRules(dst, restored infrav1beta1.SecurityRules) {
+	for _, restoredRule := range restored {
+		for i := range dst {
+			if dst[i].Name == restoredRule.Name {
+				dst[i].SourceApplicationSecurityGroups = restoredRule.SourceApplicationSecurityGroups
+				dst[i].DestinationApplicationSecurityGroups = restoredRule.DestinationApplicationSecurityGroups
+				dst[i].Action = restoredRule.Action
+				break
+			}
+		}
+	}
+}
+
+// restoreNatGateway restores the NAT gateway fields that don't exist in v1alpha4.
+func restoreNatGateway(dst *infrav1beta1.NatGateway, restored infrav1beta1.NatGateway) {
+	dst.NatGatewayIPPrefix = restored.NatGatewayIPPrefix
+	dst.NatGatewayIPCount = restored.NatGatewayIPCount
+	dst.IdleTimeoutInMinutes = restored.IdleTimeoutInMinutes
+	dst.NatGatewayIP.Zones = restored.NatGatewayIP.Zones
+	dst.NatGatewayIP.Tier = restored.NatGatewayIP.Tier
+	dst.NatGatewayIP.IPTags = restored.NatGatewayIP.IPTags
+	dst.NatGatewayIP.RoutingPreference = restored.NatGatewayIP.RoutingPreference
+	dst.NatGatewayIP.IPPrefixID = restored.NatGatewayIP.IPPrefixID
+	dst.NatGatewayIP.DiagnosticSettings = restored.NatGatewayIP.DiagnosticSettings
+}
+
+// restoreFrontendIPZones restores the availability zones, tiers, IP tags, routing preferences, IP prefixes and diagnostic settings of the public IPs of the frontend IPs, matching the frontend IPs by name.
+func restoreFrontendIPZones(dst, restored []infrav1beta1.FrontendIP) {
+	for _, restoredFrontendIP := range restored {
+		if restoredFrontendIP.PublicIP == nil {
+			continue
+		}
+		for i, dstFrontendIP := range dst {
+			if dstFrontendIP.Name == restoredFrontendIP.Name && dstFrontendIP.PublicIP != nil {
+				dst[i].PublicIP.Zones = restoredFrontendIP.PublicIP.Zones
+				dst[i].PublicIP.Tier = restoredFrontendIP.PublicIP.Tier
+				dst[i].PublicIP.IPTags = restoredFrontendIP.PublicIP.IPTags
+				dst[i].PublicIP.RoutingPreference = restoredFrontendIP.PublicIP.RoutingPreference
+				dst[i].PublicIP.IPPrefixID = restoredFrontendIP.PublicIP.IPPrefixID
+				dst[i].PublicIP.DiagnosticSettings = restoredFrontendIP.PublicIP.DiagnosticSettings
+				break
+			}
+		}
+	}
+}
+
 // ConvertFrom converts from the Hub version (v1beta1) to this version.
 func (dst *AzureCluster) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*infrav1beta1.AzureCluster)
@@ -152,6 +350,11 @@ func Convert_v1beta1_FrontendIP_To_v1alpha4_FrontendIP(in *infrav1beta1.Frontend
 	return nil
 }
 
+// Convert_v1beta1_PublicIPSpec_To_v1alpha4_PublicIPSpec is an autogenerated conversion function.
+func Convert_v1beta1_PublicIPSpec_To_v1alpha4_PublicIPSpec(in *infrav1beta1.PublicIPSpec, out *PublicIPSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_PublicIPSpec_To_v1alpha4_PublicIPSpec(in, out, s)
+}
+
 // Convert_v1alpha4_LoadBalancerSpec_To_v1beta1_LoadBalancerSpec is an autogenerated conversion function.
 func Convert_v1alpha4_LoadBalancerSpec_To_v1beta1_LoadBalancerSpec(in *LoadBalancerSpec, out *infrav1beta1.LoadBalancerSpec, s apiconversion.Scope) error { //nolint
 	if err := autoConvert_v1alpha4_LoadBalancerSpec_To_v1beta1_LoadBalancerSpec(in, out, s); err != nil {
@@ -261,7 +464,14 @@ func Convert_v1alpha4_SecurityGroup_To_v1beta1_SecurityGroup(in *SecurityGroup,
 	}
 
 	// Convert SecurityGroupClass fields
-	out.SecurityRules = *(*infrav1beta1.SecurityRules)(unsafe.Pointer(&in.SecurityRules))
+	if in.SecurityRules != nil {
+		out.SecurityRules = make(infrav1beta1.SecurityRules, len(in.SecurityRules))
+		for i := range in.SecurityRules {
+			if err := Convert_v1alpha4_SecurityRule_To_v1beta1_SecurityRule(&in.SecurityRules[i], &out.SecurityRules[i], s); err != nil {
+				return err
+			}
+		}
+	}
 	out.Tags = *(*infrav1beta1.Tags)(&in.Tags)
 
 	return nil
@@ -274,8 +484,40 @@ func Convert_v1beta1_SecurityGroup_To_v1alpha4_SecurityGroup(in *infrav1beta1.Se
 	}
 
 	// Convert SecurityGroupClass fields
-	out.SecurityRules = *(*SecurityRules)(unsafe.Pointer(&in.SecurityRules))
+	if in.SecurityRules != nil {
+		out.SecurityRules = make(SecurityRules, len(in.SecurityRules))
+		for i := range in.SecurityRules {
+			if err := Convert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule(&in.SecurityRules[i], &out.SecurityRules[i], s); err != nil {
+				return err
+			}
+		}
+	}
 	out.Tags = *(*Tags)(&in.Tags)
 
 	return nil
 }
+
+// Convert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus converts AzureCluster.Status from v1beta1 to v1alpha4.
+func Convert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus(in *infrav1beta1.AzureClusterStatus, out *AzureClusterStatus, s apiconversion.Scope) error { //nolint
+	return autoConvert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus(in, out, s)
+}
+
+// Convert_v1beta1_NatGateway_To_v1alpha4_NatGateway converts from the Hub version (v1beta1) of the NatGateway to this version.
+func Convert_v1beta1_NatGateway_To_v1alpha4_NatGateway(in *infrav1beta1.NatGateway, out *NatGateway, s apiconversion.Scope) error { //nolint
+	return autoConvert_v1beta1_NatGateway_To_v1alpha4_NatGateway(in, out, s)
+}
+
+// Convert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule converts from the Hub version (v1beta1) of the SecurityRule to this version.
+func Convert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule(in *infrav1beta1.SecurityRule, out *SecurityRule, s apiconversion.Scope) error { //nolint
+	return autoConvert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule(in, out, s)
+}
+
+// Convert_v1beta1_BastionSpec_To_v1alpha4_BastionSpec converts from the Hub version (v1beta1) of the BastionSpec to this version.
+func Convert_v1beta1_BastionSpec_To_v1alpha4_BastionSpec(in *infrav1beta1.BastionSpec, out *BastionSpec, s apiconversion.Scope) error { //nolint
+	return autoConvert_v1beta1_BastionSpec_To_v1alpha4_BastionSpec(in, out, s)
+}
+
+// Convert_v1beta1_BuildParams_To_v1alpha4_BuildParams converts from the Hub version (v1beta1) of the BuildParams to this version.
+func Convert_v1beta1_BuildParams_To_v1alpha4_BuildParams(in *infrav1beta1.BuildParams, out *BuildParams, s apiconversion.Scope) error { //nolint
+	return autoConvert_v1beta1_BuildParams_To_v1alpha4_BuildParams(in, out, s)
+}
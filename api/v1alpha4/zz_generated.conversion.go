@@ -137,11 +137,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.AzureClusterStatus)(nil), (*AzureClusterStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus(a.(*v1beta1.AzureClusterStatus), b.(*AzureClusterStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*AzureMachine)(nil), (*v1beta1.AzureMachine)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_AzureMachine_To_v1beta1_AzureMachine(a.(*AzureMachine), b.(*v1beta1.AzureMachine), scope)
 	}); err != nil {
@@ -177,11 +172,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.AzureMachineStatus)(nil), (*AzureMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_AzureMachineStatus_To_v1alpha4_AzureMachineStatus(a.(*v1beta1.AzureMachineStatus), b.(*AzureMachineStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*AzureMachineTemplate)(nil), (*v1beta1.AzureMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_AzureMachineTemplate_To_v1beta1_AzureMachineTemplate(a.(*AzureMachineTemplate), b.(*v1beta1.AzureMachineTemplate), scope)
 	}); err != nil {
@@ -252,21 +242,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.BastionSpec)(nil), (*BastionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_BastionSpec_To_v1alpha4_BastionSpec(a.(*v1beta1.BastionSpec), b.(*BastionSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*BuildParams)(nil), (*v1beta1.BuildParams)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_BuildParams_To_v1beta1_BuildParams(a.(*BuildParams), b.(*v1beta1.BuildParams), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.BuildParams)(nil), (*BuildParams)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_BuildParams_To_v1alpha4_BuildParams(a.(*v1beta1.BuildParams), b.(*BuildParams), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*CloudProviderConfigOverrides)(nil), (*v1beta1.CloudProviderConfigOverrides)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_CloudProviderConfigOverrides_To_v1beta1_CloudProviderConfigOverrides(a.(*CloudProviderConfigOverrides), b.(*v1beta1.CloudProviderConfigOverrides), scope)
 	}); err != nil {
@@ -342,11 +322,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.NatGateway)(nil), (*NatGateway)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_NatGateway_To_v1alpha4_NatGateway(a.(*v1beta1.NatGateway), b.(*NatGateway), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*OSDisk)(nil), (*v1beta1.OSDisk)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_OSDisk_To_v1beta1_OSDisk(a.(*OSDisk), b.(*v1beta1.OSDisk), scope)
 	}); err != nil {
@@ -362,11 +337,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.PublicIPSpec)(nil), (*PublicIPSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_PublicIPSpec_To_v1alpha4_PublicIPSpec(a.(*v1beta1.PublicIPSpec), b.(*PublicIPSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*RateLimitConfig)(nil), (*v1beta1.RateLimitConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_RateLimitConfig_To_v1beta1_RateLimitConfig(a.(*RateLimitConfig), b.(*v1beta1.RateLimitConfig), scope)
 	}); err != nil {
@@ -412,11 +382,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.SecurityRule)(nil), (*SecurityRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule(a.(*v1beta1.SecurityRule), b.(*SecurityRule), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*SpotVMOptions)(nil), (*v1beta1.SpotVMOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_SpotVMOptions_To_v1beta1_SpotVMOptions(a.(*SpotVMOptions), b.(*v1beta1.SpotVMOptions), scope)
 	}); err != nil {
@@ -477,11 +442,31 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.AzureClusterStatus)(nil), (*AzureClusterStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus(a.(*v1beta1.AzureClusterStatus), b.(*AzureClusterStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.AzureMachineStatus)(nil), (*AzureMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AzureMachineStatus_To_v1alpha4_AzureMachineStatus(a.(*v1beta1.AzureMachineStatus), b.(*AzureMachineStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.AzureMachineTemplateResource)(nil), (*AzureMachineTemplateResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_AzureMachineTemplateResource_To_v1alpha4_AzureMachineTemplateResource(a.(*v1beta1.AzureMachineTemplateResource), b.(*AzureMachineTemplateResource), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.BastionSpec)(nil), (*BastionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_BastionSpec_To_v1alpha4_BastionSpec(a.(*v1beta1.BastionSpec), b.(*BastionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.BuildParams)(nil), (*BuildParams)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_BuildParams_To_v1alpha4_BuildParams(a.(*v1beta1.BuildParams), b.(*BuildParams), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.FrontendIP)(nil), (*FrontendIP)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_FrontendIP_To_v1alpha4_FrontendIP(a.(*v1beta1.FrontendIP), b.(*FrontendIP), scope)
 	}); err != nil {
@@ -492,16 +477,31 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.NatGateway)(nil), (*NatGateway)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NatGateway_To_v1alpha4_NatGateway(a.(*v1beta1.NatGateway), b.(*NatGateway), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.NetworkSpec)(nil), (*NetworkSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_NetworkSpec_To_v1alpha4_NetworkSpec(a.(*v1beta1.NetworkSpec), b.(*NetworkSpec), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.PublicIPSpec)(nil), (*PublicIPSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PublicIPSpec_To_v1alpha4_PublicIPSpec(a.(*v1beta1.PublicIPSpec), b.(*PublicIPSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.SecurityGroup)(nil), (*SecurityGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_SecurityGroup_To_v1alpha4_SecurityGroup(a.(*v1beta1.SecurityGroup), b.(*SecurityGroup), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.SecurityRule)(nil), (*SecurityRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule(a.(*v1beta1.SecurityRule), b.(*SecurityRule), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.SubnetSpec)(nil), (*SubnetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_SubnetSpec_To_v1alpha4_SubnetSpec(a.(*v1beta1.SubnetSpec), b.(*SubnetSpec), scope)
 	}); err != nil {
@@ -835,12 +835,30 @@ func autoConvert_v1beta1_AzureClusterSpec_To_v1alpha4_AzureClusterSpec(in *v1bet
 		return err
 	}
 	out.ResourceGroup = in.ResourceGroup
+	// WARNING: in.ResourceGroupLocation requires manual conversion: does not exist in peer-type
 	if err := Convert_v1beta1_BastionSpec_To_v1alpha4_BastionSpec(&in.BastionSpec, &out.BastionSpec, s); err != nil {
 		return err
 	}
 	if err := apiv1alpha4.Convert_v1beta1_APIEndpoint_To_v1alpha4_APIEndpoint(&in.ControlPlaneEndpoint, &out.ControlPlaneEndpoint, s); err != nil {
 		return err
 	}
+	// WARNING: in.DeleteGracePeriod requires manual conversion: does not exist in peer-type
+	// WARNING: in.LogAnalyticsWorkspace requires manual conversion: does not exist in peer-type
+	// WARNING: in.DiagnosticsResourceGroup requires manual conversion: does not exist in peer-type
+	// WARNING: in.NamingConvention requires manual conversion: does not exist in peer-type
+	// WARNING: in.ReconcileMode requires manual conversion: does not exist in peer-type
+	// WARNING: in.DefaultSpotPolicy requires manual conversion: does not exist in peer-type
+	// WARNING: in.AutoShutdown requires manual conversion: does not exist in peer-type
+	// WARNING: in.InheritResourceGroupTags requires manual conversion: does not exist in peer-type
+	// WARNING: in.PolicyAssignments requires manual conversion: does not exist in peer-type
+	// WARNING: in.RoleAssignments requires manual conversion: does not exist in peer-type
+	// WARNING: in.Budget requires manual conversion: does not exist in peer-type
+	// WARNING: in.Gallery requires manual conversion: does not exist in peer-type
+	// WARNING: in.ControlPlaneAvailabilitySet requires manual conversion: does not exist in peer-type
+	// WARNING: in.ControlPlaneEtcdDisks requires manual conversion: does not exist in peer-type
+	// WARNING: in.RequiredFeatures requires manual conversion: does not exist in peer-type
+	// WARNING: in.InventoryConfigMapName requires manual conversion: does not exist in peer-type
+	// WARNING: in.SecondaryRegion requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -906,14 +924,43 @@ func autoConvert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus(in *v
 		out.Conditions = nil
 	}
 	out.LongRunningOperationStates = *(*Futures)(unsafe.Pointer(&in.LongRunningOperationStates))
+	// WARNING: in.ControlPlaneEndpoints requires manual conversion: does not exist in peer-type
+	// WARNING: in.JumpboxIP requires manual conversion: does not exist in peer-type
+	// WARNING: in.DNSServers requires manual conversion: does not exist in peer-type
+	// WARNING: in.DeletionRequestedAt requires manual conversion: does not exist in peer-type
+	// WARNING: in.ControlPlaneEgressIPs requires manual conversion: does not exist in peer-type
+	// WARNING: in.PublicIPPrefixAllocations requires manual conversion: does not exist in peer-type
+	// WARNING: in.NatGatewayIPPrefixes requires manual conversion: does not exist in peer-type
+	// WARNING: in.PublicIPZones requires manual conversion: does not exist in peer-type
+	// WARNING: in.SubnetAvailableIPs requires manual conversion: does not exist in peer-type
+	// WARNING: in.LogAnalyticsWorkspace requires manual conversion: does not exist in peer-type
+	// WARNING: in.PairedRegion requires manual conversion: does not exist in peer-type
+	// WARNING: in.Location requires manual conversion: does not exist in peer-type
+	// WARNING: in.ResourceGroupLocation requires manual conversion: does not exist in peer-type
+	// WARNING: in.DiagnosticsResourceGroupLocation requires manual conversion: does not exist in peer-type
+	// WARNING: in.DefaultSpotPolicy requires manual conversion: does not exist in peer-type
+	// WARNING: in.AutoShutdown requires manual conversion: does not exist in peer-type
+	// WARNING: in.FailedReconcileAttempts requires manual conversion: does not exist in peer-type
+	// WARNING: in.GeneratedSecurityRules requires manual conversion: does not exist in peer-type
+	// WARNING: in.LoadBalancerTiers requires manual conversion: does not exist in peer-type
+	// WARNING: in.GlobalLoadBalancerBackends requires manual conversion: does not exist in peer-type
+	// WARNING: in.NetworkInterfaceSecurityGroupIDs requires manual conversion: does not exist in peer-type
+	// WARNING: in.PolicyAssignmentIDs requires manual conversion: does not exist in peer-type
+	// WARNING: in.RoleAssignmentIDs requires manual conversion: does not exist in peer-type
+	// WARNING: in.BudgetID requires manual conversion: does not exist in peer-type
+	// WARNING: in.PodSubnetID requires manual conversion: does not exist in peer-type
+	// WARNING: in.EstimatedCost requires manual conversion: does not exist in peer-type
+	// WARNING: in.ControlPlaneAvailabilitySetID requires manual conversion: does not exist in peer-type
+	// WARNING: in.ControlPlaneEtcdDiskZones requires manual conversion: does not exist in peer-type
+	// WARNING: in.GalleryImageID requires manual conversion: does not exist in peer-type
+	// WARNING: in.PrivateEndpointIPs requires manual conversion: does not exist in peer-type
+	// WARNING: in.SecondaryNetwork requires manual conversion: does not exist in peer-type
+	// WARNING: in.FailureReason requires manual conversion: does not exist in peer-type
+	// WARNING: in.FailureMessage requires manual conversion: does not exist in peer-type
+	// WARNING: in.ClusterUID requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus is an autogenerated conversion function.
-func Convert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus(in *v1beta1.AzureClusterStatus, out *AzureClusterStatus, s conversion.Scope) error {
-	return autoConvert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus(in, out, s)
-}
-
 func autoConvert_v1alpha4_AzureMachine_To_v1beta1_AzureMachine(in *AzureMachine, out *v1beta1.AzureMachine, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha4_AzureMachineSpec_To_v1beta1_AzureMachineSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1088,14 +1135,10 @@ func autoConvert_v1beta1_AzureMachineStatus_To_v1alpha4_AzureMachineStatus(in *v
 		out.Conditions = nil
 	}
 	out.LongRunningOperationStates = *(*Futures)(unsafe.Pointer(&in.LongRunningOperationStates))
+	// WARNING: in.DrainStartedAt requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_AzureMachineStatus_To_v1alpha4_AzureMachineStatus is an autogenerated conversion function.
-func Convert_v1beta1_AzureMachineStatus_To_v1alpha4_AzureMachineStatus(in *v1beta1.AzureMachineStatus, out *AzureMachineStatus, s conversion.Scope) error {
-	return autoConvert_v1beta1_AzureMachineStatus_To_v1alpha4_AzureMachineStatus(in, out, s)
-}
-
 func autoConvert_v1alpha4_AzureMachineTemplate_To_v1beta1_AzureMachineTemplate(in *AzureMachineTemplate, out *v1beta1.AzureMachineTemplate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha4_AzureMachineTemplateSpec_To_v1beta1_AzureMachineTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1326,14 +1369,10 @@ func autoConvert_v1beta1_BastionSpec_To_v1alpha4_BastionSpec(in *v1beta1.Bastion
 	} else {
 		out.AzureBastion = nil
 	}
+	// WARNING: in.Jumpbox requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_BastionSpec_To_v1alpha4_BastionSpec is an autogenerated conversion function.
-func Convert_v1beta1_BastionSpec_To_v1alpha4_BastionSpec(in *v1beta1.BastionSpec, out *BastionSpec, s conversion.Scope) error {
-	return autoConvert_v1beta1_BastionSpec_To_v1alpha4_BastionSpec(in, out, s)
-}
-
 func autoConvert_v1alpha4_BuildParams_To_v1beta1_BuildParams(in *BuildParams, out *v1beta1.BuildParams, s conversion.Scope) error {
 	out.Lifecycle = v1beta1.ResourceLifecycle(in.Lifecycle)
 	out.ClusterName = in.ClusterName
@@ -1355,15 +1394,12 @@ func autoConvert_v1beta1_BuildParams_To_v1alpha4_BuildParams(in *v1beta1.BuildPa
 	out.ResourceID = in.ResourceID
 	out.Name = (*string)(unsafe.Pointer(in.Name))
 	out.Role = (*string)(unsafe.Pointer(in.Role))
+	// WARNING: in.ProviderVersion requires manual conversion: does not exist in peer-type
+	// WARNING: in.ClusterUID requires manual conversion: does not exist in peer-type
 	out.Additional = *(*Tags)(unsafe.Pointer(&in.Additional))
 	return nil
 }
 
-// Convert_v1beta1_BuildParams_To_v1alpha4_BuildParams is an autogenerated conversion function.
-func Convert_v1beta1_BuildParams_To_v1alpha4_BuildParams(in *v1beta1.BuildParams, out *BuildParams, s conversion.Scope) error {
-	return autoConvert_v1beta1_BuildParams_To_v1alpha4_BuildParams(in, out, s)
-}
-
 func autoConvert_v1alpha4_CloudProviderConfigOverrides_To_v1beta1_CloudProviderConfigOverrides(in *CloudProviderConfigOverrides, out *v1beta1.CloudProviderConfigOverrides, s conversion.Scope) error {
 	out.RateLimits = *(*[]v1beta1.RateLimitSpec)(unsafe.Pointer(&in.RateLimits))
 	if err := Convert_v1alpha4_BackOffConfig_To_v1beta1_BackOffConfig(&in.BackOffs, &out.BackOffs, s); err != nil {
@@ -1461,13 +1497,29 @@ func Convert_v1beta1_DiskEncryptionSetParameters_To_v1alpha4_DiskEncryptionSetPa
 func autoConvert_v1alpha4_FrontendIP_To_v1beta1_FrontendIP(in *FrontendIP, out *v1beta1.FrontendIP, s conversion.Scope) error {
 	out.Name = in.Name
 	// WARNING: in.PrivateIPAddress requires manual conversion: does not exist in peer-type
-	out.PublicIP = (*v1beta1.PublicIPSpec)(unsafe.Pointer(in.PublicIP))
+	if in.PublicIP != nil {
+		in, out := &in.PublicIP, &out.PublicIP
+		*out = new(v1beta1.PublicIPSpec)
+		if err := Convert_v1alpha4_PublicIPSpec_To_v1beta1_PublicIPSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PublicIP = nil
+	}
 	return nil
 }
 
 func autoConvert_v1beta1_FrontendIP_To_v1alpha4_FrontendIP(in *v1beta1.FrontendIP, out *FrontendIP, s conversion.Scope) error {
 	out.Name = in.Name
-	out.PublicIP = (*PublicIPSpec)(unsafe.Pointer(in.PublicIP))
+	if in.PublicIP != nil {
+		in, out := &in.PublicIP, &out.PublicIP
+		*out = new(PublicIPSpec)
+		if err := Convert_v1beta1_PublicIPSpec_To_v1alpha4_PublicIPSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PublicIP = nil
+	}
 	// WARNING: in.FrontendIPClass requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -1538,6 +1590,7 @@ func autoConvert_v1alpha4_LoadBalancerSpec_To_v1beta1_LoadBalancerSpec(in *LoadB
 func autoConvert_v1beta1_LoadBalancerSpec_To_v1alpha4_LoadBalancerSpec(in *v1beta1.LoadBalancerSpec, out *LoadBalancerSpec, s conversion.Scope) error {
 	out.ID = in.ID
 	out.Name = in.Name
+	// WARNING: in.Shared requires manual conversion: does not exist in peer-type
 	// WARNING: in.LoadBalancerClassSpec requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -1584,14 +1637,12 @@ func autoConvert_v1beta1_NatGateway_To_v1alpha4_NatGateway(in *v1beta1.NatGatewa
 	if err := Convert_v1beta1_PublicIPSpec_To_v1alpha4_PublicIPSpec(&in.NatGatewayIP, &out.NatGatewayIP, s); err != nil {
 		return err
 	}
+	// WARNING: in.NatGatewayIPCount requires manual conversion: does not exist in peer-type
+	// WARNING: in.IdleTimeoutInMinutes requires manual conversion: does not exist in peer-type
+	// WARNING: in.NatGatewayIPPrefix requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_NatGateway_To_v1alpha4_NatGateway is an autogenerated conversion function.
-func Convert_v1beta1_NatGateway_To_v1alpha4_NatGateway(in *v1beta1.NatGateway, out *NatGateway, s conversion.Scope) error {
-	return autoConvert_v1beta1_NatGateway_To_v1alpha4_NatGateway(in, out, s)
-}
-
 func autoConvert_v1alpha4_NetworkSpec_To_v1beta1_NetworkSpec(in *NetworkSpec, out *v1beta1.NetworkSpec, s conversion.Scope) error {
 	if err := Convert_v1alpha4_VnetSpec_To_v1beta1_VnetSpec(&in.Vnet, &out.Vnet, s); err != nil {
 		return err
@@ -1668,6 +1719,18 @@ func autoConvert_v1beta1_NetworkSpec_To_v1alpha4_NetworkSpec(in *v1beta1.Network
 	} else {
 		out.ControlPlaneOutboundLB = nil
 	}
+	// WARNING: in.TrafficManager requires manual conversion: does not exist in peer-type
+	// WARNING: in.GlobalLB requires manual conversion: does not exist in peer-type
+	// WARNING: in.DNSPrivateResolver requires manual conversion: does not exist in peer-type
+	// WARNING: in.ApplicationSecurityGroups requires manual conversion: does not exist in peer-type
+	// WARNING: in.NetworkInterfaceSecurityGroups requires manual conversion: does not exist in peer-type
+	// WARNING: in.RequireSecurityRuleDescriptions requires manual conversion: does not exist in peer-type
+	// WARNING: in.IntraClusterTraffic requires manual conversion: does not exist in peer-type
+	// WARNING: in.OutboundConnectivityCheck requires manual conversion: does not exist in peer-type
+	// WARNING: in.PrivateEndpoints requires manual conversion: does not exist in peer-type
+	// WARNING: in.Ingress requires manual conversion: does not exist in peer-type
+	// WARNING: in.PodSubnet requires manual conversion: does not exist in peer-type
+	// WARNING: in.InternalLoadBalancers requires manual conversion: does not exist in peer-type
 	// WARNING: in.NetworkClassSpec requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -1714,14 +1777,15 @@ func Convert_v1alpha4_PublicIPSpec_To_v1beta1_PublicIPSpec(in *PublicIPSpec, out
 func autoConvert_v1beta1_PublicIPSpec_To_v1alpha4_PublicIPSpec(in *v1beta1.PublicIPSpec, out *PublicIPSpec, s conversion.Scope) error {
 	out.Name = in.Name
 	out.DNSName = in.DNSName
+	// WARNING: in.Zones requires manual conversion: does not exist in peer-type
+	// WARNING: in.Tier requires manual conversion: does not exist in peer-type
+	// WARNING: in.IPTags requires manual conversion: does not exist in peer-type
+	// WARNING: in.RoutingPreference requires manual conversion: does not exist in peer-type
+	// WARNING: in.IPPrefixID requires manual conversion: does not exist in peer-type
+	// WARNING: in.DiagnosticSettings requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_PublicIPSpec_To_v1alpha4_PublicIPSpec is an autogenerated conversion function.
-func Convert_v1beta1_PublicIPSpec_To_v1alpha4_PublicIPSpec(in *v1beta1.PublicIPSpec, out *PublicIPSpec, s conversion.Scope) error {
-	return autoConvert_v1beta1_PublicIPSpec_To_v1alpha4_PublicIPSpec(in, out, s)
-}
-
 func autoConvert_v1alpha4_RateLimitConfig_To_v1beta1_RateLimitConfig(in *RateLimitConfig, out *v1beta1.RateLimitConfig, s conversion.Scope) error {
 	out.CloudProviderRateLimit = in.CloudProviderRateLimit
 	out.CloudProviderRateLimitQPS = (*resource.Quantity)(unsafe.Pointer(in.CloudProviderRateLimitQPS))
@@ -1861,14 +1925,12 @@ func autoConvert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule(in *v1beta1.Secur
 	out.DestinationPorts = (*string)(unsafe.Pointer(in.DestinationPorts))
 	out.Source = (*string)(unsafe.Pointer(in.Source))
 	out.Destination = (*string)(unsafe.Pointer(in.Destination))
+	// WARNING: in.SourceApplicationSecurityGroups requires manual conversion: does not exist in peer-type
+	// WARNING: in.DestinationApplicationSecurityGroups requires manual conversion: does not exist in peer-type
+	// WARNING: in.Action requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule is an autogenerated conversion function.
-func Convert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule(in *v1beta1.SecurityRule, out *SecurityRule, s conversion.Scope) error {
-	return autoConvert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule(in, out, s)
-}
-
 func autoConvert_v1alpha4_SpotVMOptions_To_v1beta1_SpotVMOptions(in *SpotVMOptions, out *v1beta1.SpotVMOptions, s conversion.Scope) error {
 	out.MaxPrice = (*resource.Quantity)(unsafe.Pointer(in.MaxPrice))
 	return nil
@@ -1918,6 +1980,8 @@ func autoConvert_v1beta1_SubnetSpec_To_v1alpha4_SubnetSpec(in *v1beta1.SubnetSpe
 	if err := Convert_v1beta1_NatGateway_To_v1alpha4_NatGateway(&in.NatGateway, &out.NatGateway, s); err != nil {
 		return err
 	}
+	// WARNING: in.FreeIPsThreshold requires manual conversion: does not exist in peer-type
+	// WARNING: in.FirewallRoute requires manual conversion: does not exist in peer-type
 	// WARNING: in.SubnetClassSpec requires manual conversion: does not exist in peer-type
 	return nil
 }
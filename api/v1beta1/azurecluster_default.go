@@ -18,6 +18,8 @@ package v1beta1
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"k8s.io/utils/pointer"
 )
@@ -37,33 +39,203 @@ const (
 	DefaultAzureBastionSubnetName = "AzureBastionSubnet"
 	// DefaultAzureBastionSubnetRole is the default Subnet role for AzureBastion.
 	DefaultAzureBastionSubnetRole = SubnetBastion
+	// DefaultJumpboxSubnetCIDR is the default Subnet CIDR for the jumpbox.
+	DefaultJumpboxSubnetCIDR = "10.255.255.192/27"
+	// DefaultJumpboxSubnetRole is the default Subnet role for the jumpbox.
+	DefaultJumpboxSubnetRole = SubnetBastion
+	// DefaultJumpboxVMSize is the default VM size for the jumpbox.
+	DefaultJumpboxVMSize = "Standard_B2s"
+	// DefaultIngressSubnetCIDR is the default Subnet CIDR for the ingress subnet.
+	DefaultIngressSubnetCIDR = "10.255.254.0/24"
+	// DefaultIngressSubnetRole is the default Subnet role for the ingress subnet.
+	DefaultIngressSubnetRole = SubnetIngress
+	// DefaultPodSubnetCIDR is the default Subnet CIDR for the pod subnet.
+	DefaultPodSubnetCIDR = "10.128.0.0/16"
+	// DefaultMaxPodsPerNode is the default maximum number of pods of a node, the default of Azure CNI.
+	DefaultMaxPodsPerNode = 30
+	// DefaultPodSubnetServiceDelegation is the default service the pod subnet is delegated to.
+	DefaultPodSubnetServiceDelegation = "Microsoft.ContainerService/managedClusters"
 	// DefaultInternalLBIPAddress is the default internal load balancer ip address.
 	DefaultInternalLBIPAddress = "10.0.0.100"
 	// DefaultOutboundRuleIdleTimeoutInMinutes is the default for IdleTimeoutInMinutes for the load balancer.
 	DefaultOutboundRuleIdleTimeoutInMinutes = 4
+	// DefaultAPIServerProbeRequestPath is the default path requested by the Https health probe of the API server load balancer.
+	DefaultAPIServerProbeRequestPath = "/readyz"
+	// DefaultProbeIntervalInSeconds is the default interval between two health probes of a backend of a load balancer.
+	DefaultProbeIntervalInSeconds = 15
+	// DefaultNumberOfProbes is the default number of failed health probes taking a backend of a load balancer out of rotation.
+	DefaultNumberOfProbes = 4
+	// DefaultNatGatewayIPCount is the default number of public IPs of a NAT gateway.
+	DefaultNatGatewayIPCount = 1
+	// DefaultNatGatewayIdleTimeoutInMinutes is the default idle timeout of the outbound connections of a NAT gateway.
+	DefaultNatGatewayIdleTimeoutInMinutes = 4
+	// DefaultNatGatewayIPPrefixLength is the default length of the public IP prefix created for a NAT gateway.
+	DefaultNatGatewayIPPrefixLength = 31
+	// DefaultNamingSeparator is the default separator of the parts of the generated resource names.
+	DefaultNamingSeparator = "-"
+	// DefaultOutboundConnectivityCheckProtocol is the default protocol of an outbound connectivity check.
+	DefaultOutboundConnectivityCheckProtocol = "TCP"
+	// DefaultNetworkWatcherResourceGroup is the resource group of the Network Watchers Azure creates in each region.
+	DefaultNetworkWatcherResourceGroup = "NetworkWatcherRG"
+	// DefaultAvailabilitySetUpdateDomainCount is the default number of update domains of an availability set, as in
+	// Azure.
+	DefaultAvailabilitySetUpdateDomainCount = 5
 	// DefaultAzureCloud is the public cloud that will be used by most users.
 	DefaultAzureCloud = "AzurePublicCloud"
 )
 
+// setDefaults sets the default values of the AzureCluster spec in a single place.
+// It is idempotent and only sets fields left empty by the user.
 func (c *AzureCluster) setDefaults() {
 	c.Spec.AzureClusterClassSpec.setDefaults()
 	c.setResourceGroupDefault()
 	c.setNetworkSpecDefaults()
+	c.setLogAnalyticsWorkspaceDefaults()
+	c.setDiagnosticsResourceGroupDefaults()
+	c.setGalleryDefaults()
+	c.setControlPlaneAvailabilitySetDefaults()
+	c.setSecondaryRegionDefaults()
+}
+
+// setSecondaryRegionDefaults sets the virtual network and the subnets of the secondary region, when there is one, in the
+// resource group of the cluster. Its location is only known once the paired region of the cluster is reconciled.
+func (c *AzureCluster) setSecondaryRegionDefaults() {
+	secondary := c.Spec.SecondaryRegion
+	if secondary == nil {
+		return
+	}
+	if secondary.Vnet.ResourceGroup == "" {
+		secondary.Vnet.ResourceGroup = c.Spec.ResourceGroup
+	}
+	if secondary.Vnet.Name == "" {
+		secondary.Vnet.Name = generateSecondaryVnetName(c.namingStrategy(), c.ObjectMeta.Name)
+	}
+	secondary.Vnet.VnetClassSpec.setDefaults()
+
+	if len(secondary.Subnets) == 0 {
+		secondary.Subnets = []SecondarySubnetSpec{
+			{SubnetClassSpec: SubnetClassSpec{Role: SubnetControlPlane}},
+			{SubnetClassSpec: SubnetClassSpec{Role: SubnetNode}},
+		}
+	}
+	for i := range secondary.Subnets {
+		subnet := &secondary.Subnets[i]
+		if subnet.Name == "" {
+			subnet.Name = generateSecondarySubnetName(c.namingStrategy(), c.ObjectMeta.Name, subnet.Role)
+		}
+		if subnet.SecurityGroupName == "" {
+			subnet.SecurityGroupName = generateSecondarySecurityGroupName(c.namingStrategy(), c.ObjectMeta.Name, subnet.Role)
+		}
+		if len(subnet.CIDRBlocks) == 0 {
+			switch subnet.Role {
+			case SubnetControlPlane:
+				subnet.CIDRBlocks = []string{DefaultControlPlaneSubnetCIDR}
+			case SubnetNode:
+				subnet.CIDRBlocks = []string{DefaultNodeSubnetCIDR}
+			}
+		}
+	}
+}
+
+// setControlPlaneAvailabilitySetDefaults sets the name and the update domain count of the availability set of the
+// control plane when none are given. The fault domain count defaults to the maximum of the location, only known once
+// reconciled.
+func (c *AzureCluster) setControlPlaneAvailabilitySetDefaults() {
+	availabilitySet := c.Spec.ControlPlaneAvailabilitySet
+	if availabilitySet == nil {
+		return
+	}
+	if availabilitySet.Name == "" {
+		availabilitySet.Name = generateControlPlaneAvailabilitySetName(c.namingStrategy(), c.ObjectMeta.Name)
+	}
+	if availabilitySet.UpdateDomainCount == nil {
+		availabilitySet.UpdateDomainCount = pointer.Int32(DefaultAvailabilitySetUpdateDomainCount)
+	}
+}
+
+// setGalleryDefaults sets the version of the gallery image to latest when none is given.
+func (c *AzureCluster) setGalleryDefaults() {
+	if c.Spec.Gallery != nil && c.Spec.Gallery.Version == "" {
+		c.Spec.Gallery.Version = LatestGalleryImageVersion
+	}
+}
+
+// setLogAnalyticsWorkspaceDefaults sets the name of the Log Analytics workspace to create when none is referenced.
+func (c *AzureCluster) setLogAnalyticsWorkspaceDefaults() {
+	if c.Spec.LogAnalyticsWorkspace == nil || c.Spec.LogAnalyticsWorkspace.ID != "" {
+		return
+	}
+	if c.Spec.LogAnalyticsWorkspace.Name == "" {
+		c.Spec.LogAnalyticsWorkspace.Name = generateLogAnalyticsWorkspaceName(c.namingStrategy(), c.ObjectMeta.Name)
+	}
+}
+
+// setDiagnosticsResourceGroupDefaults sets the location of the diagnostics resource group to the location of the
+// cluster.
+func (c *AzureCluster) setDiagnosticsResourceGroupDefaults() {
+	if c.Spec.DiagnosticsResourceGroup != nil && c.Spec.DiagnosticsResourceGroup.Location == "" {
+		c.Spec.DiagnosticsResourceGroup.Location = c.Spec.Location
+	}
+}
+
+// NamingStrategy derives the names of the Azure resources of a cluster that aren't named in its spec.
+// +kubebuilder:object:generate=false
+type NamingStrategy interface {
+	// Name returns the name of a resource made of the given parts, e.g. the cluster name and the kind of resource.
+	Name(parts ...string) string
+}
+
+// Name joins the prefix, the parts of the name and the suffix of the naming convention with its separator.
+func (n NamingConvention) Name(parts ...string) string {
+	separator := n.Separator
+	if separator == "" {
+		separator = DefaultNamingSeparator
+	}
+	name := make([]string, 0, len(parts)+2)
+	if n.Prefix != "" {
+		name = append(name, n.Prefix)
+	}
+	name = append(name, parts...)
+	if n.Suffix != "" {
+		name = append(name, n.Suffix)
+	}
+	return strings.Join(name, separator)
+}
+
+// namingStrategy returns the naming strategy used to generate the names of the Azure resources of the cluster.
+// Without a naming convention, the names are the parts joined with the default separator, e.g. "<cluster>-vnet".
+func (c *AzureCluster) namingStrategy() NamingStrategy {
+	if c.Spec.NamingConvention == nil {
+		return NamingConvention{}
+	}
+	return *c.Spec.NamingConvention
 }
 
 func (c *AzureCluster) setNetworkSpecDefaults() {
 	c.setVnetDefaults()
 	c.setBastionDefaults()
+	c.setJumpboxDefaults()
+	c.setIngressDefaults()
+	c.setPodSubnetDefaults()
 	c.setSubnetDefaults()
+	c.setNetworkInterfaceSecurityGroupDefaults()
 	c.setVnetPeeringDefaults()
+	c.setPrivateEndpointDefaults()
+	c.setInternalLoadBalancerDefaults()
 	c.setAPIServerLBDefaults()
 	c.setNodeOutboundLBDefaults()
 	c.setControlPlaneOutboundLBDefaults()
+	c.setTrafficManagerDefaults()
+	c.setGlobalLBDefaults()
+	c.setOutboundConnectivityCheckDefaults()
 }
 
 func (c *AzureCluster) setResourceGroupDefault() {
 	if c.Spec.ResourceGroup == "" {
-		c.Spec.ResourceGroup = c.Name
+		c.Spec.ResourceGroup = c.namingStrategy().Name(c.Name)
+	}
+	if c.Spec.ResourceGroupLocation == "" {
+		c.Spec.ResourceGroupLocation = c.Spec.Location
 	}
 }
 
@@ -78,7 +250,7 @@ func (c *AzureCluster) setVnetDefaults() {
 		c.Spec.NetworkSpec.Vnet.ResourceGroup = c.Spec.ResourceGroup
 	}
 	if c.Spec.NetworkSpec.Vnet.Name == "" {
-		c.Spec.NetworkSpec.Vnet.Name = generateVnetName(c.ObjectMeta.Name)
+		c.Spec.NetworkSpec.Vnet.Name = generateVnetName(c.namingStrategy(), c.ObjectMeta.Name)
 	}
 	c.Spec.NetworkSpec.Vnet.VnetClassSpec.setDefaults()
 }
@@ -91,15 +263,16 @@ func (c *AzureCluster) setSubnetDefaults() {
 	}
 
 	if cpSubnet.Name == "" {
-		cpSubnet.Name = generateControlPlaneSubnetName(c.ObjectMeta.Name)
+		cpSubnet.Name = generateControlPlaneSubnetName(c.namingStrategy(), c.ObjectMeta.Name)
 	}
 
 	cpSubnet.SubnetClassSpec.setDefaults(DefaultControlPlaneSubnetCIDR)
 
 	if cpSubnet.SecurityGroup.Name == "" {
-		cpSubnet.SecurityGroup.Name = generateControlPlaneSecurityGroupName(c.ObjectMeta.Name)
+		cpSubnet.SecurityGroup.Name = generateControlPlaneSecurityGroupName(c.namingStrategy(), c.ObjectMeta.Name)
 	}
 	cpSubnet.SecurityGroup.SecurityGroupClass.setDefaults(SecurityRuleDirectionInbound)
+	c.setNatGatewayDefaults(&cpSubnet)
 
 	c.Spec.NetworkSpec.UpdateControlPlaneSubnet(cpSubnet)
 
@@ -110,23 +283,19 @@ func (c *AzureCluster) setSubnetDefaults() {
 			nodeSubnetCounter++
 			nodeSubnetFound = true
 			if subnet.Name == "" {
-				subnet.Name = withIndex(generateNodeSubnetName(c.ObjectMeta.Name), nodeSubnetCounter)
+				subnet.Name = generateNodeSubnetName(withIndex(c.namingStrategy(), nodeSubnetCounter), c.ObjectMeta.Name)
 			}
 			subnet.SubnetClassSpec.setDefaults(fmt.Sprintf(DefaultNodeSubnetCIDRPattern, nodeSubnetCounter))
 
 			if subnet.SecurityGroup.Name == "" {
-				subnet.SecurityGroup.Name = generateNodeSecurityGroupName(c.ObjectMeta.Name)
+				subnet.SecurityGroup.Name = generateNodeSecurityGroupName(c.namingStrategy(), c.ObjectMeta.Name)
 			}
 			cpSubnet.SecurityGroup.SecurityGroupClass.setDefaults(SecurityRuleDirectionInbound)
 
 			if subnet.RouteTable.Name == "" {
-				subnet.RouteTable.Name = generateNodeRouteTableName(c.ObjectMeta.Name)
-			}
-			if subnet.IsNatGatewayEnabled() {
-				if subnet.NatGateway.NatGatewayIP.Name == "" {
-					subnet.NatGateway.NatGatewayIP.Name = generateNatGatewayIPName(c.ObjectMeta.Name, subnet.Name)
-				}
+				subnet.RouteTable.Name = generateNodeRouteTableName(c.namingStrategy(), c.ObjectMeta.Name)
 			}
+			c.setNatGatewayDefaults(&subnet)
 
 			c.Spec.NetworkSpec.Subnets[i] = subnet
 		}
@@ -138,12 +307,12 @@ func (c *AzureCluster) setSubnetDefaults() {
 				Role:       SubnetNode,
 				CIDRBlocks: []string{DefaultNodeSubnetCIDR},
 			},
-			Name: generateNodeSubnetName(c.ObjectMeta.Name),
+			Name: generateNodeSubnetName(c.namingStrategy(), c.ObjectMeta.Name),
 			SecurityGroup: SecurityGroup{
-				Name: generateNodeSecurityGroupName(c.ObjectMeta.Name),
+				Name: generateNodeSecurityGroupName(c.namingStrategy(), c.ObjectMeta.Name),
 			},
 			RouteTable: RouteTable{
-				Name: generateNodeRouteTableName(c.ObjectMeta.Name),
+				Name: generateNodeRouteTableName(c.namingStrategy(), c.ObjectMeta.Name),
 			},
 		}
 		c.Spec.NetworkSpec.Subnets = append(c.Spec.NetworkSpec.Subnets, nodeSubnet)
@@ -158,6 +327,105 @@ func (c *AzureCluster) setVnetPeeringDefaults() {
 	}
 }
 
+// setPrivateEndpointDefaults sets the group ID of the private endpoints of storage accounts and Key Vaults, and places
+// the private endpoints in the node subnet when there's only one.
+func (c *AzureCluster) setPrivateEndpointDefaults() {
+	var nodeSubnets []string
+	for _, subnet := range c.Spec.NetworkSpec.Subnets {
+		if subnet.Role == SubnetNode {
+			nodeSubnets = append(nodeSubnets, subnet.Name)
+		}
+	}
+	for i := range c.Spec.NetworkSpec.PrivateEndpoints {
+		endpoint := &c.Spec.NetworkSpec.PrivateEndpoints[i]
+		if endpoint.GroupID == "" {
+			endpoint.GroupID = defaultPrivateEndpointGroupID(endpoint.ResourceID)
+		}
+		if endpoint.SubnetName == "" && len(nodeSubnets) == 1 {
+			endpoint.SubnetName = nodeSubnets[0]
+		}
+	}
+}
+
+func (c *AzureCluster) setInternalLoadBalancerDefaults() {
+	var nodeSubnet string
+	for _, subnet := range c.Spec.NetworkSpec.Subnets {
+		if subnet.Role == SubnetNode {
+			nodeSubnet = subnet.Name
+			break
+		}
+	}
+	for i := range c.Spec.NetworkSpec.InternalLoadBalancers {
+		lb := &c.Spec.NetworkSpec.InternalLoadBalancers[i]
+		if lb.SubnetName == "" {
+			lb.SubnetName = nodeSubnet
+		}
+		for j := range lb.Rules {
+			rule := &lb.Rules[j]
+			if rule.Protocol == "" {
+				rule.Protocol = LoadBalancerRuleProtocolTCP
+			}
+			if rule.BackendPort == 0 {
+				rule.BackendPort = rule.FrontendPort
+			}
+		}
+		if len(lb.Rules) > 0 {
+			setInternalLoadBalancerProbeDefaults(&lb.Probe, lb.Rules[0].BackendPort)
+		}
+		for j := range lb.Probes {
+			probe := &lb.Probes[j]
+			// A named probe is defaulted to the backend port of the first rule bound to it.
+			for _, rule := range lb.Rules {
+				if rule.ProbeName == probe.Name || (rule.ProbeName == "" && j == 0) {
+					setInternalLoadBalancerProbeDefaults(probe, rule.BackendPort)
+					break
+				}
+			}
+			setInternalLoadBalancerProbeDefaults(probe, 0)
+		}
+		if len(lb.Probes) > 0 {
+			for j := range lb.Rules {
+				if lb.Rules[j].ProbeName == "" {
+					lb.Rules[j].ProbeName = lb.Probes[0].Name
+				}
+			}
+		}
+	}
+}
+
+// setInternalLoadBalancerProbeDefaults sets the default protocol, port, request path, interval and number of probes of
+// a health probe of an internal load balancer fronting a service.
+func setInternalLoadBalancerProbeDefaults(probe *InternalLoadBalancerProbe, port int32) {
+	if probe.Protocol == "" {
+		probe.Protocol = ProbeProtocolTCP
+	}
+	if probe.Port == 0 {
+		probe.Port = port
+	}
+	if probe.RequestPath == "" && probe.Protocol != ProbeProtocolTCP {
+		probe.RequestPath = "/"
+	}
+	if probe.IntervalInSeconds == 0 {
+		probe.IntervalInSeconds = DefaultProbeIntervalInSeconds
+	}
+	if probe.NumberOfProbes == 0 {
+		probe.NumberOfProbes = DefaultNumberOfProbes
+	}
+}
+
+// defaultPrivateEndpointGroupID returns the default sub-resource to connect to for the type of the resource, if any.
+func defaultPrivateEndpointGroupID(resourceID string) string {
+	lower := strings.ToLower(resourceID)
+	switch {
+	case strings.Contains(lower, "/providers/microsoft.storage/storageaccounts/"):
+		return "blob"
+	case strings.Contains(lower, "/providers/microsoft.keyvault/vaults/"):
+		return "vault"
+	default:
+		return ""
+	}
+}
+
 func (c *AzureCluster) setAPIServerLBDefaults() {
 	lb := &c.Spec.NetworkSpec.APIServerLB
 	if lb.Type == "" {
@@ -166,27 +434,46 @@ func (c *AzureCluster) setAPIServerLBDefaults() {
 	if lb.SKU == "" {
 		lb.SKU = SKUStandard
 	}
+	if lb.Tier == "" {
+		lb.Tier = LoadBalancerTierRegional
+	}
 	if lb.IdleTimeoutInMinutes == nil {
 		lb.IdleTimeoutInMinutes = pointer.Int32Ptr(DefaultOutboundRuleIdleTimeoutInMinutes)
 	}
+	if lb.HealthProbe != nil {
+		if lb.HealthProbe.Protocol == "" {
+			lb.HealthProbe.Protocol = ProbeProtocolTCP
+		}
+		if lb.HealthProbe.Protocol == ProbeProtocolHTTPS && lb.HealthProbe.RequestPath == "" {
+			lb.HealthProbe.RequestPath = DefaultAPIServerProbeRequestPath
+		}
+	}
 
 	if lb.Type == Public {
 		if lb.Name == "" {
-			lb.Name = generatePublicLBName(c.ObjectMeta.Name)
+			lb.Name = generatePublicLBName(c.namingStrategy(), c.ObjectMeta.Name)
 		}
 		if len(lb.FrontendIPs) == 0 {
 			lb.FrontendIPs = []FrontendIP{
 				{
 					Name: generateFrontendIPConfigName(lb.Name),
 					PublicIP: &PublicIPSpec{
-						Name: generatePublicIPName(c.ObjectMeta.Name),
+						Name: generatePublicIPName(c.namingStrategy(), c.ObjectMeta.Name),
 					},
 				},
 			}
 		}
+		if lb.InternalFrontendIP != nil {
+			if lb.InternalFrontendIP.Name == "" {
+				lb.InternalFrontendIP.Name = generateFrontendIPConfigName(GenerateInternalFrontendLBName(lb.Name))
+			}
+			if lb.InternalFrontendIP.PrivateIPAddress == "" {
+				lb.InternalFrontendIP.PrivateIPAddress = DefaultInternalLBIPAddress
+			}
+		}
 	} else if lb.Type == Internal {
 		if lb.Name == "" {
-			lb.Name = generateInternalLBName(c.ObjectMeta.Name)
+			lb.Name = generateInternalLBName(c.namingStrategy(), c.ObjectMeta.Name)
 		}
 		if len(lb.FrontendIPs) == 0 {
 			lb.FrontendIPs = []FrontendIP{
@@ -201,6 +488,25 @@ func (c *AzureCluster) setAPIServerLBDefaults() {
 	}
 }
 
+// setNatGatewayDefaults sets the defaults of the NAT gateway of a subnet, if it has one.
+func (c *AzureCluster) setNatGatewayDefaults(subnet *SubnetSpec) {
+	if !subnet.IsNatGatewayEnabled() {
+		return
+	}
+	if subnet.NatGateway.NatGatewayIP.Name == "" {
+		subnet.NatGateway.NatGatewayIP.Name = generateNatGatewayIPName(c.namingStrategy(), c.ObjectMeta.Name, subnet.Name)
+	}
+	if subnet.NatGateway.NatGatewayIPCount == nil {
+		subnet.NatGateway.NatGatewayIPCount = pointer.Int32(DefaultNatGatewayIPCount)
+	}
+	if subnet.NatGateway.IdleTimeoutInMinutes == nil {
+		subnet.NatGateway.IdleTimeoutInMinutes = pointer.Int32(DefaultNatGatewayIdleTimeoutInMinutes)
+	}
+	if subnet.NatGateway.NatGatewayIPPrefix != nil && subnet.NatGateway.NatGatewayIPPrefix.PrefixLength == nil {
+		subnet.NatGateway.NatGatewayIPPrefix.PrefixLength = pointer.Int32(DefaultNatGatewayIPPrefixLength)
+	}
+}
+
 func (c *AzureCluster) setNodeOutboundLBDefaults() {
 	if c.Spec.NetworkSpec.NodeOutboundLB == nil {
 		if c.Spec.NetworkSpec.APIServerLB.Type == Internal {
@@ -228,7 +534,12 @@ func (c *AzureCluster) setNodeOutboundLBDefaults() {
 	lb := c.Spec.NetworkSpec.NodeOutboundLB
 	lb.Type = Public
 	lb.SKU = SKUStandard
-	lb.Name = c.ObjectMeta.Name
+	if lb.Tier == "" {
+		lb.Tier = LoadBalancerTierRegional
+	}
+	if lb.Name == "" {
+		lb.Name = generateNodeOutboundLBName(c.namingStrategy(), c.ObjectMeta.Name)
+	}
 
 	if lb.IdleTimeoutInMinutes == nil {
 		lb.IdleTimeoutInMinutes = pointer.Int32Ptr(DefaultOutboundRuleIdleTimeoutInMinutes)
@@ -255,9 +566,12 @@ func (c *AzureCluster) setControlPlaneOutboundLBDefaults() {
 	lb := c.Spec.NetworkSpec.ControlPlaneOutboundLB
 	lb.Type = Public
 	lb.SKU = SKUStandard
+	if lb.Tier == "" {
+		lb.Tier = LoadBalancerTierRegional
+	}
 
 	if lb.Name == "" {
-		lb.Name = generateControlPlaneOutboundLBName(c.ObjectMeta.Name)
+		lb.Name = generateControlPlaneOutboundLBName(c.namingStrategy(), c.ObjectMeta.Name)
 	}
 
 	if lb.IdleTimeoutInMinutes == nil {
@@ -273,7 +587,15 @@ func (c *AzureCluster) setControlPlaneOutboundLBDefaults() {
 
 // setOutboundLBFrontendIPs sets the frontend ips for the given load balancer.
 // The name of the frontend ip is generated using generatePublicIPName function.
-func (c *AzureCluster) setOutboundLBFrontendIPs(lb *LoadBalancerSpec, generatePublicIPName func(string) string) {
+func (c *AzureCluster) setOutboundLBFrontendIPs(lb *LoadBalancerSpec, generatePublicIPName func(NamingStrategy, string) string) {
+	// the frontend IPs are generated from the count, only the zones of their public IPs are user-defined.
+	zones := func(i int) []string {
+		if i >= len(lb.FrontendIPs) || lb.FrontendIPs[i].PublicIP == nil {
+			return nil
+		}
+		return lb.FrontendIPs[i].PublicIP.Zones
+	}
+
 	switch *lb.FrontendIPsCount {
 	case 0:
 		lb.FrontendIPs = []FrontendIP{}
@@ -282,27 +604,84 @@ func (c *AzureCluster) setOutboundLBFrontendIPs(lb *LoadBalancerSpec, generatePu
 			{
 				Name: generateFrontendIPConfigName(lb.Name),
 				PublicIP: &PublicIPSpec{
-					Name: generatePublicIPName(c.ObjectMeta.Name),
+					Name:  generatePublicIPName(c.namingStrategy(), c.ObjectMeta.Name),
+					Zones: zones(0),
 				},
 			},
 		}
 	default:
-		lb.FrontendIPs = make([]FrontendIP, *lb.FrontendIPsCount)
+		frontendIPs := make([]FrontendIP, *lb.FrontendIPsCount)
 		for i := 0; i < int(*lb.FrontendIPsCount); i++ {
-			lb.FrontendIPs[i] = FrontendIP{
-				Name: withIndex(generateFrontendIPConfigName(lb.Name), i+1),
+			frontendIPs[i] = FrontendIP{
+				Name: generateIndexedFrontendIPConfigName(lb.Name, i+1),
 				PublicIP: &PublicIPSpec{
-					Name: withIndex(generatePublicIPName(c.ObjectMeta.Name), i+1),
+					Name:  generatePublicIPName(withIndex(c.namingStrategy(), i+1), c.ObjectMeta.Name),
+					Zones: zones(i),
 				},
 			}
 		}
+		lb.FrontendIPs = frontendIPs
+	}
+}
+
+func (c *AzureCluster) setTrafficManagerDefaults() {
+	tm := c.Spec.NetworkSpec.TrafficManager
+	if tm == nil {
+		return
+	}
+	if tm.Name == "" {
+		tm.Name = generateTrafficManagerName(c.namingStrategy(), c.ObjectMeta.Name)
+	}
+	if tm.DNSPrefix == "" {
+		tm.DNSPrefix = c.ObjectMeta.Name
+	}
+	if tm.RoutingMethod == "" {
+		tm.RoutingMethod = TrafficRoutingMethodPriority
+	}
+}
+
+func (c *AzureCluster) setGlobalLBDefaults() {
+	glb := c.Spec.NetworkSpec.GlobalLB
+	if glb == nil {
+		return
+	}
+	if glb.Name == "" {
+		glb.Name = generateGlobalLBName(c.namingStrategy(), c.ObjectMeta.Name)
+	}
+	if glb.Location == "" {
+		glb.Location = c.Spec.Location
+	}
+	if glb.PublicIP == nil {
+		glb.PublicIP = &PublicIPSpec{}
+	}
+	if glb.PublicIP.Name == "" {
+		glb.PublicIP.Name = generateGlobalLBPublicIPName(c.namingStrategy(), c.ObjectMeta.Name)
+	}
+	if glb.PublicIP.Tier == "" {
+		glb.PublicIP.Tier = PublicIPTierGlobal
+	}
+}
+
+func (c *AzureCluster) setOutboundConnectivityCheckDefaults() {
+	check := c.Spec.NetworkSpec.OutboundConnectivityCheck
+	if check == nil {
+		return
+	}
+	if check.Protocol == "" {
+		check.Protocol = DefaultOutboundConnectivityCheckProtocol
+	}
+	if check.NetworkWatcherName == "" {
+		check.NetworkWatcherName = generateNetworkWatcherName(c.Spec.Location)
+	}
+	if check.NetworkWatcherResourceGroup == "" {
+		check.NetworkWatcherResourceGroup = DefaultNetworkWatcherResourceGroup
 	}
 }
 
 func (c *AzureCluster) setBastionDefaults() {
 	if c.Spec.BastionSpec.AzureBastion != nil {
 		if c.Spec.BastionSpec.AzureBastion.Name == "" {
-			c.Spec.BastionSpec.AzureBastion.Name = generateAzureBastionName(c.ObjectMeta.Name)
+			c.Spec.BastionSpec.AzureBastion.Name = generateAzureBastionName(c.namingStrategy(), c.ObjectMeta.Name)
 		}
 		// Ensure defaults for the Subnet settings.
 		if c.Spec.BastionSpec.AzureBastion.Subnet.Name == "" {
@@ -316,92 +695,341 @@ func (c *AzureCluster) setBastionDefaults() {
 		}
 		// Ensure defaults for the PublicIP settings.
 		if c.Spec.BastionSpec.AzureBastion.PublicIP.Name == "" {
-			c.Spec.BastionSpec.AzureBastion.PublicIP.Name = generateAzureBastionPublicIPName(c.ObjectMeta.Name)
+			c.Spec.BastionSpec.AzureBastion.PublicIP.Name = generateAzureBastionPublicIPName(c.namingStrategy(), c.ObjectMeta.Name)
 		}
 	}
 }
 
+func (c *AzureCluster) setJumpboxDefaults() {
+	jumpbox := c.Spec.BastionSpec.Jumpbox
+	if jumpbox == nil {
+		return
+	}
+	if jumpbox.Name == "" {
+		jumpbox.Name = generateJumpboxName(c.namingStrategy(), c.ObjectMeta.Name)
+	}
+	if jumpbox.VMSize == "" {
+		jumpbox.VMSize = DefaultJumpboxVMSize
+	}
+	if jumpbox.Image == nil {
+		jumpbox.Image = &Image{
+			Marketplace: &AzureMarketplaceImage{
+				Publisher: "Canonical",
+				Offer:     "0001-com-ubuntu-server-focal",
+				SKU:       "20_04-lts",
+				Version:   "latest",
+			},
+		}
+	}
+	// Ensure defaults for the Subnet settings.
+	if jumpbox.Subnet.Name == "" {
+		jumpbox.Subnet.Name = generateJumpboxSubnetName(c.namingStrategy(), c.ObjectMeta.Name)
+	}
+	if len(jumpbox.Subnet.CIDRBlocks) == 0 {
+		jumpbox.Subnet.CIDRBlocks = []string{DefaultJumpboxSubnetCIDR}
+	}
+	if jumpbox.Subnet.Role == "" {
+		jumpbox.Subnet.Role = DefaultJumpboxSubnetRole
+	}
+	if jumpbox.Subnet.SecurityGroup.Name == "" {
+		jumpbox.Subnet.SecurityGroup.Name = generateJumpboxSecurityGroupName(c.namingStrategy(), c.ObjectMeta.Name)
+	}
+	// Ensure defaults for the PublicIP settings.
+	if jumpbox.PublicIP.Name == "" {
+		jumpbox.PublicIP.Name = generateJumpboxPublicIPName(c.namingStrategy(), c.ObjectMeta.Name)
+	}
+}
+
+func (c *AzureCluster) setIngressDefaults() {
+	ingress := c.Spec.NetworkSpec.Ingress
+	if ingress == nil {
+		return
+	}
+	if len(ingress.Ports) == 0 {
+		ingress.Ports = []int32{80, 443}
+	}
+	// Ensure defaults for the Subnet settings.
+	if ingress.Subnet.Name == "" {
+		ingress.Subnet.Name = generateIngressSubnetName(c.namingStrategy(), c.ObjectMeta.Name)
+	}
+	if len(ingress.Subnet.CIDRBlocks) == 0 {
+		ingress.Subnet.CIDRBlocks = []string{DefaultIngressSubnetCIDR}
+	}
+	if ingress.Subnet.Role == "" {
+		ingress.Subnet.Role = DefaultIngressSubnetRole
+	}
+	if ingress.Subnet.SecurityGroup.Name == "" {
+		ingress.Subnet.SecurityGroup.Name = generateIngressSecurityGroupName(c.namingStrategy(), c.ObjectMeta.Name)
+	}
+	// Ensure defaults for the PublicIP settings.
+	if ingress.PublicIP != nil && ingress.PublicIP.Name == "" {
+		ingress.PublicIP.Name = generateIngressPublicIPName(c.namingStrategy(), c.ObjectMeta.Name)
+	}
+}
+
+func (c *AzureCluster) setPodSubnetDefaults() {
+	podSubnet := c.Spec.NetworkSpec.PodSubnet
+	if podSubnet == nil {
+		return
+	}
+	if podSubnet.Name == "" {
+		podSubnet.Name = generatePodSubnetName(c.namingStrategy(), c.ObjectMeta.Name)
+	}
+	if len(podSubnet.CIDRBlocks) == 0 {
+		podSubnet.CIDRBlocks = []string{DefaultPodSubnetCIDR}
+	}
+	if podSubnet.MaxPodsPerNode == 0 {
+		podSubnet.MaxPodsPerNode = DefaultMaxPodsPerNode
+	}
+	if podSubnet.ServiceDelegation == "" {
+		podSubnet.ServiceDelegation = DefaultPodSubnetServiceDelegation
+	}
+}
+
+func (c *AzureCluster) setNetworkInterfaceSecurityGroupDefaults() {
+	nicSecurityGroups := c.Spec.NetworkSpec.NetworkInterfaceSecurityGroups
+	if nicSecurityGroups == nil {
+		return
+	}
+	if nicSecurityGroups.Attachment == "" {
+		nicSecurityGroups.Attachment = SecurityGroupAttachmentNetworkInterface
+	}
+	if nicSecurityGroups.ControlPlane.Name == "" {
+		nicSecurityGroups.ControlPlane.Name = generateControlPlaneNICSecurityGroupName(c.namingStrategy(), c.ObjectMeta.Name)
+	}
+	nicSecurityGroups.ControlPlane.SecurityGroupClass.setDefaults(SecurityRuleDirectionInbound)
+	if nicSecurityGroups.Node.Name == "" {
+		nicSecurityGroups.Node.Name = generateNodeNICSecurityGroupName(c.namingStrategy(), c.ObjectMeta.Name)
+	}
+	nicSecurityGroups.Node.SecurityGroupClass.setDefaults(SecurityRuleDirectionInbound)
+}
+
+// generateControlPlaneAvailabilitySetName generates the name of the availability set of the control plane. Without a
+// naming convention, it is the name of the availability set the control plane machines create without one, so that it
+// is adopted by existing clusters.
+func generateControlPlaneAvailabilitySetName(n NamingStrategy, clusterName string) string {
+	if n == NamingStrategy(NamingConvention{}) {
+		return fmt.Sprintf("%s_control-plane-as", clusterName)
+	}
+	return n.Name(clusterName, "control-plane", "as")
+}
+
 // generateVnetName generates a virtual network name, based on the cluster name.
-func generateVnetName(clusterName string) string {
-	return fmt.Sprintf("%s-%s", clusterName, "vnet")
+func generateVnetName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "vnet")
+}
+
+// generateSecondaryVnetName generates the name of the virtual network of the secondary region, based on the cluster
+// name.
+func generateSecondaryVnetName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "secondary", "vnet")
+}
+
+// generateSecondarySubnetName generates the name of a subnet of the secondary region, based on the cluster name and the
+// role of the subnet.
+func generateSecondarySubnetName(n NamingStrategy, clusterName string, role SubnetRole) string {
+	return n.Name(clusterName, "secondary", secondaryRoleNamePart(role), "subnet")
+}
+
+// generateSecondarySecurityGroupName generates the name of the security group of a subnet of the secondary region,
+// based on the cluster name and the role of the subnet.
+func generateSecondarySecurityGroupName(n NamingStrategy, clusterName string, role SubnetRole) string {
+	return n.Name(clusterName, "secondary", secondaryRoleNamePart(role), "nsg")
+}
+
+// secondaryRoleNamePart returns the part of the names of the resources of the secondary region for a subnet role, as
+// in the names of the resources of the cluster.
+func secondaryRoleNamePart(role SubnetRole) string {
+	if role == SubnetControlPlane {
+		return "controlplane"
+	}
+	return string(role)
 }
 
 // generateControlPlaneSubnetName generates a node subnet name, based on the cluster name.
-func generateControlPlaneSubnetName(clusterName string) string {
-	return fmt.Sprintf("%s-%s", clusterName, "controlplane-subnet")
+func generateControlPlaneSubnetName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "controlplane", "subnet")
 }
 
 // generateNodeSubnetName generates a node subnet name, based on the cluster name.
-func generateNodeSubnetName(clusterName string) string {
-	return fmt.Sprintf("%s-%s", clusterName, "node-subnet")
+func generateNodeSubnetName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "node", "subnet")
 }
 
 // generateAzureBastionName generates an azure bastion name.
-func generateAzureBastionName(clusterName string) string {
-	return fmt.Sprintf("%s-azure-bastion", clusterName)
+func generateAzureBastionName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "azure", "bastion")
 }
 
 // generateAzureBastionPublicIPName generates an azure bastion public ip name.
-func generateAzureBastionPublicIPName(clusterName string) string {
-	return fmt.Sprintf("%s-azure-bastion-pip", clusterName)
+func generateAzureBastionPublicIPName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "azure", "bastion", "pip")
 }
 
 // generateControlPlaneSecurityGroupName generates a control plane security group name, based on the cluster name.
-func generateControlPlaneSecurityGroupName(clusterName string) string {
-	return fmt.Sprintf("%s-%s", clusterName, "controlplane-nsg")
+func generateControlPlaneSecurityGroupName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "controlplane", "nsg")
 }
 
 // generateNodeSecurityGroupName generates a node security group name, based on the cluster name.
-func generateNodeSecurityGroupName(clusterName string) string {
-	return fmt.Sprintf("%s-%s", clusterName, "node-nsg")
+func generateNodeSecurityGroupName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "node", "nsg")
+}
+
+// generateControlPlaneNICSecurityGroupName generates the name of the security group of the control plane network
+// interfaces, based on the cluster name.
+func generateControlPlaneNICSecurityGroupName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "controlplane", "nic", "nsg")
+}
+
+// generateNodeNICSecurityGroupName generates the name of the security group of the node network interfaces, based on
+// the cluster name.
+func generateNodeNICSecurityGroupName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "node", "nic", "nsg")
 }
 
 // generateNodeRouteTableName generates a node route table name, based on the cluster name.
-func generateNodeRouteTableName(clusterName string) string {
-	return fmt.Sprintf("%s-%s", clusterName, "node-routetable")
+func generateNodeRouteTableName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "node", "routetable")
 }
 
 // generateInternalLBName generates a internal load balancer name, based on the cluster name.
-func generateInternalLBName(clusterName string) string {
-	return fmt.Sprintf("%s-%s", clusterName, "internal-lb")
+func generateInternalLBName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "internal", "lb")
 }
 
 // generatePublicLBName generates a public load balancer name, based on the cluster name.
-func generatePublicLBName(clusterName string) string {
-	return fmt.Sprintf("%s-%s", clusterName, "public-lb")
+func generatePublicLBName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "public", "lb")
+}
+
+// generateNodeOutboundLBName generates the name of the node outbound LB. Without a naming convention, it is the cluster
+// name.
+func generateNodeOutboundLBName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName)
 }
 
 // generateControlPlaneOutboundLBName generates the name of the control plane outbound LB.
-func generateControlPlaneOutboundLBName(clusterName string) string {
-	return fmt.Sprintf("%s-outbound-lb", clusterName)
+func generateControlPlaneOutboundLBName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "outbound", "lb")
 }
 
 // generatePublicIPName generates a public IP name, based on the cluster name and a hash.
-func generatePublicIPName(clusterName string) string {
-	return fmt.Sprintf("pip-%s-apiserver", clusterName)
+func generatePublicIPName(n NamingStrategy, clusterName string) string {
+	return n.Name("pip", clusterName, "apiserver")
 }
 
-// generateFrontendIPConfigName generates a load balancer frontend IP config name.
+// generateFrontendIPConfigName generates a load balancer frontend IP config name. Like the names of the other child
+// resources of a load balancer, it is derived from the name of the load balancer rather than generated from the naming
+// convention.
 func generateFrontendIPConfigName(lbName string) string {
 	return fmt.Sprintf("%s-%s", lbName, "frontEnd")
 }
 
+// generateIndexedFrontendIPConfigName generates the name of a frontend IP config of a load balancer with several ones.
+func generateIndexedFrontendIPConfigName(lbName string, n int) string {
+	return fmt.Sprintf("%s-%d", generateFrontendIPConfigName(lbName), n)
+}
+
+// GenerateInternalFrontendLBName generates the name of the internal load balancer holding the internal frontend of a
+// public load balancer.
+func GenerateInternalFrontendLBName(lbName string) string {
+	return fmt.Sprintf("%s-%s", lbName, "internal")
+}
+
 // generateNodeOutboundIPName generates a public IP name, based on the cluster name.
-func generateNodeOutboundIPName(clusterName string) string {
-	return fmt.Sprintf("pip-%s-node-outbound", clusterName)
+func generateNodeOutboundIPName(n NamingStrategy, clusterName string) string {
+	return n.Name("pip", clusterName, "node", "outbound")
 }
 
 // generateControlPlaneOutboundIPName generates a public IP name, based on the cluster name.
-func generateControlPlaneOutboundIPName(clusterName string) string {
-	return fmt.Sprintf("pip-%s-controlplane-outbound", clusterName)
+func generateControlPlaneOutboundIPName(n NamingStrategy, clusterName string) string {
+	return n.Name("pip", clusterName, "controlplane", "outbound")
 }
 
 // generateNatGatewayIPName generates a NAT gateway IP name.
-func generateNatGatewayIPName(clusterName, subnetName string) string {
-	return fmt.Sprintf("pip-%s-%s-natgw", clusterName, subnetName)
+func generateNatGatewayIPName(n NamingStrategy, clusterName, subnetName string) string {
+	return n.Name("pip", clusterName, subnetName, "natgw")
+}
+
+// generateLogAnalyticsWorkspaceName generates the name of the Log Analytics workspace based on the cluster name.
+func generateLogAnalyticsWorkspaceName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "workspace")
+}
+
+// generateTrafficManagerName generates the name of the Traffic Manager profile based on the cluster name.
+func generateTrafficManagerName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "tm")
+}
+
+// generateGlobalLBName generates the name of the cross-region load balancer based on the cluster name.
+func generateGlobalLBName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "global", "lb")
+}
+
+// generateGlobalLBPublicIPName generates the name of the global public IP of the cross-region load balancer.
+func generateGlobalLBPublicIPName(n NamingStrategy, clusterName string) string {
+	return n.Name("pip", clusterName, "global")
+}
+
+// generateNetworkWatcherName generates the name of the Network Watcher Azure creates in a location.
+func generateNetworkWatcherName(location string) string {
+	return fmt.Sprintf("NetworkWatcher_%s", location)
+}
+
+// indexedNamingStrategy is a naming strategy appending an index to the parts of the generated names, before the suffix
+// of the naming convention, to tell apart the resources of the same kind.
+type indexedNamingStrategy struct {
+	NamingStrategy
+	index int
+}
+
+// Name returns the name made of the given parts followed by the index.
+func (n indexedNamingStrategy) Name(parts ...string) string {
+	return n.NamingStrategy.Name(append(parts, strconv.Itoa(n.index))...)
+}
+
+// withIndex returns a naming strategy appending the index to the names generated by the given naming strategy.
+func withIndex(n NamingStrategy, index int) NamingStrategy {
+	return indexedNamingStrategy{NamingStrategy: n, index: index}
+}
+
+// generateJumpboxName generates a jumpbox virtual machine name.
+func generateJumpboxName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "jumpbox")
+}
+
+// generateJumpboxSubnetName generates a jumpbox subnet name.
+func generateJumpboxSubnetName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "jumpbox", "subnet")
+}
+
+// generateJumpboxSecurityGroupName generates a jumpbox security group name.
+func generateJumpboxSecurityGroupName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "jumpbox", "nsg")
+}
+
+// generateJumpboxPublicIPName generates a jumpbox public ip name.
+func generateJumpboxPublicIPName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "jumpbox", "pip")
+}
+
+// generateIngressSubnetName generates an ingress subnet name.
+func generateIngressSubnetName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "ingress", "subnet")
+}
+
+// generateIngressSecurityGroupName generates an ingress security group name.
+func generateIngressSecurityGroupName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "ingress", "nsg")
+}
+
+// generateIngressPublicIPName generates an ingress public ip name.
+func generateIngressPublicIPName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "ingress", "pip")
 }
 
-// withIndex appends the index as suffix to a generated name.
-func withIndex(name string, n int) string {
-	return fmt.Sprintf("%s-%d", name, n)
+// generatePodSubnetName generates a pod subnet name.
+func generatePodSubnetName(n NamingStrategy, clusterName string) string {
+	return n.Name(clusterName, "pod", "subnet")
 }
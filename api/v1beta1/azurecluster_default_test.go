@@ -46,6 +46,30 @@ func TestResourceGroupDefault(t *testing.T) {
 				},
 			},
 		},
+		"default rg location to the cluster location": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					AzureClusterClassSpec: AzureClusterClassSpec{
+						Location: "eastus",
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					AzureClusterClassSpec: AzureClusterClassSpec{
+						Location: "eastus",
+					},
+					ResourceGroup:         "foo",
+					ResourceGroupLocation: "eastus",
+				},
+			},
+		},
 		"don't change if mismatched": {
 			cluster: &AzureCluster{
 				ObjectMeta: metav1.ObjectMeta{
@@ -80,6 +104,108 @@ func TestResourceGroupDefault(t *testing.T) {
 	}
 }
 
+func TestNamingConventionDefaults(t *testing.T) {
+	cases := map[string]struct {
+		convention *NamingConvention
+		expected   map[string]string
+	}{
+		"default naming": {
+			convention: nil,
+			expected: map[string]string{
+				"resourceGroup":   "foo",
+				"vnet":            "foo-vnet",
+				"subnet":          "foo-controlplane-subnet",
+				"securityGroup":   "foo-controlplane-nsg",
+				"loadBalancer":    "foo-public-lb",
+				"publicIP":        "pip-foo-apiserver",
+				"nodeSubnet":      "foo-node-subnet-2",
+				"natGatewayIP":    "pip-foo-foo-node-subnet-2-natgw",
+				"outboundLB":      "foo",
+				"outboundIP":      "pip-foo-node-outbound-2",
+				"availabilitySet": "foo_control-plane-as",
+			},
+		},
+		"prefix and suffix": {
+			convention: &NamingConvention{Prefix: "corp", Suffix: "prod"},
+			expected: map[string]string{
+				"resourceGroup":   "corp-foo-prod",
+				"vnet":            "corp-foo-vnet-prod",
+				"subnet":          "corp-foo-controlplane-subnet-prod",
+				"securityGroup":   "corp-foo-controlplane-nsg-prod",
+				"loadBalancer":    "corp-foo-public-lb-prod",
+				"publicIP":        "corp-pip-foo-apiserver-prod",
+				"nodeSubnet":      "corp-foo-node-subnet-2-prod",
+				"natGatewayIP":    "corp-pip-foo-corp-foo-node-subnet-2-prod-natgw-prod",
+				"outboundLB":      "corp-foo-prod",
+				"outboundIP":      "corp-pip-foo-node-outbound-2-prod",
+				"availabilitySet": "corp-foo-control-plane-as-prod",
+			},
+		},
+		"custom separator": {
+			convention: &NamingConvention{Prefix: "corp", Separator: "_"},
+			expected: map[string]string{
+				"resourceGroup":   "corp_foo",
+				"vnet":            "corp_foo_vnet",
+				"subnet":          "corp_foo_controlplane_subnet",
+				"securityGroup":   "corp_foo_controlplane_nsg",
+				"loadBalancer":    "corp_foo_public_lb",
+				"publicIP":        "corp_pip_foo_apiserver",
+				"nodeSubnet":      "corp_foo_node_subnet_2",
+				"natGatewayIP":    "corp_pip_foo_corp_foo_node_subnet_2_natgw",
+				"outboundLB":      "corp_foo",
+				"outboundIP":      "corp_pip_foo_node_outbound_2",
+				"availabilitySet": "corp_foo_control-plane_as",
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cluster := &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NamingConvention:            c.convention,
+					ControlPlaneAvailabilitySet: &AvailabilitySet{},
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{SubnetClassSpec: SubnetClassSpec{Role: SubnetControlPlane}},
+							{SubnetClassSpec: SubnetClassSpec{Role: SubnetNode}},
+							{SubnetClassSpec: SubnetClassSpec{Role: SubnetNode}, NatGateway: NatGateway{Name: "foo-natgw"}},
+						},
+						NodeOutboundLB: &LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{FrontendIPsCount: to.Int32Ptr(2)}},
+					},
+				},
+			}
+			cluster.setDefaults()
+
+			controlPlaneSubnet, err := cluster.Spec.NetworkSpec.GetControlPlaneSubnet()
+			if err != nil {
+				t.Fatal(err)
+			}
+			names := map[string]string{
+				"resourceGroup":   cluster.Spec.ResourceGroup,
+				"vnet":            cluster.Spec.NetworkSpec.Vnet.Name,
+				"subnet":          controlPlaneSubnet.Name,
+				"securityGroup":   controlPlaneSubnet.SecurityGroup.Name,
+				"loadBalancer":    cluster.Spec.NetworkSpec.APIServerLB.Name,
+				"publicIP":        cluster.Spec.NetworkSpec.APIServerLB.FrontendIPs[0].PublicIP.Name,
+				"nodeSubnet":      cluster.Spec.NetworkSpec.Subnets[2].Name,
+				"natGatewayIP":    cluster.Spec.NetworkSpec.Subnets[2].NatGateway.NatGatewayIP.Name,
+				"outboundLB":      cluster.Spec.NetworkSpec.NodeOutboundLB.Name,
+				"outboundIP":      cluster.Spec.NetworkSpec.NodeOutboundLB.FrontendIPs[1].PublicIP.Name,
+				"availabilitySet": cluster.Spec.ControlPlaneAvailabilitySet.Name,
+			}
+			if !reflect.DeepEqual(names, c.expected) {
+				t.Errorf("Expected %v, got %v", c.expected, names)
+			}
+		})
+	}
+}
+
 func TestVnetDefaults(t *testing.T) {
 	cases := []struct {
 		name    string
@@ -362,7 +488,149 @@ func TestSubnetDefaults(t *testing.T) {
 									NatGatewayIP: PublicIPSpec{
 										Name: "pip-cluster-test-my-node-subnet-natgw",
 									},
+									NatGatewayIPCount:    to.Int32Ptr(DefaultNatGatewayIPCount),
+									IdleTimeoutInMinutes: to.Int32Ptr(DefaultNatGatewayIdleTimeoutInMinutes),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "subnet with NAT gateway IP prefix",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       SubnetControlPlane,
+									CIDRBlocks: []string{"10.0.0.16/24"},
+								},
+								Name: "my-controlplane-subnet",
+							},
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       SubnetNode,
+									CIDRBlocks: []string{"10.1.0.16/24"},
+								},
+								Name: "my-node-subnet",
+								NatGateway: NatGateway{
+									Name:                 "foo-natgw",
+									NatGatewayIPPrefix:   &PublicIPPrefixSpec{Name: "foo-natgw-prefix"},
+									IdleTimeoutInMinutes: to.Int32Ptr(10),
+								},
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       SubnetControlPlane,
+									CIDRBlocks: []string{"10.0.0.16/24"},
+								},
+								Name:          "my-controlplane-subnet",
+								SecurityGroup: SecurityGroup{Name: "cluster-test-controlplane-nsg"},
+								RouteTable:    RouteTable{},
+							},
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       SubnetNode,
+									CIDRBlocks: []string{"10.1.0.16/24"},
+								},
+								Name:          "my-node-subnet",
+								SecurityGroup: SecurityGroup{Name: "cluster-test-node-nsg"},
+								RouteTable:    RouteTable{Name: "cluster-test-node-routetable"},
+								NatGateway: NatGateway{
+									Name: "foo-natgw",
+									NatGatewayIP: PublicIPSpec{
+										Name: "pip-cluster-test-my-node-subnet-natgw",
+									},
+									NatGatewayIPCount:    to.Int32Ptr(DefaultNatGatewayIPCount),
+									IdleTimeoutInMinutes: to.Int32Ptr(10),
+									NatGatewayIPPrefix: &PublicIPPrefixSpec{
+										Name:         "foo-natgw-prefix",
+										PrefixLength: to.Int32Ptr(DefaultNatGatewayIPPrefixLength),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "control plane subnet with NAT gateway",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       SubnetControlPlane,
+									CIDRBlocks: []string{"10.0.0.16/24"},
+								},
+								Name:       "my-controlplane-subnet",
+								NatGateway: NatGateway{Name: "cp-natgw"},
+							},
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       SubnetNode,
+									CIDRBlocks: []string{"10.1.0.16/24"},
+								},
+								Name: "my-node-subnet",
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       SubnetControlPlane,
+									CIDRBlocks: []string{"10.0.0.16/24"},
+								},
+								Name:          "my-controlplane-subnet",
+								SecurityGroup: SecurityGroup{Name: "cluster-test-controlplane-nsg"},
+								RouteTable:    RouteTable{},
+								NatGateway: NatGateway{
+									Name: "cp-natgw",
+									NatGatewayIP: PublicIPSpec{
+										Name: "pip-cluster-test-my-controlplane-subnet-natgw",
+									},
+									NatGatewayIPCount:    to.Int32Ptr(DefaultNatGatewayIPCount),
+									IdleTimeoutInMinutes: to.Int32Ptr(DefaultNatGatewayIdleTimeoutInMinutes),
+								},
+							},
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       SubnetNode,
+									CIDRBlocks: []string{"10.1.0.16/24"},
 								},
+								Name:          "my-node-subnet",
+								SecurityGroup: SecurityGroup{Name: "cluster-test-node-nsg"},
+								RouteTable:    RouteTable{Name: "cluster-test-node-routetable"},
 							},
 						},
 					},
@@ -815,6 +1083,112 @@ func TestVnetPeeringDefaults(t *testing.T) {
 	}
 }
 
+func TestPrivateEndpointDefaults(t *testing.T) {
+	cluster := &AzureCluster{
+		Spec: AzureClusterSpec{
+			NetworkSpec: NetworkSpec{
+				Subnets: Subnets{
+					{Name: "cp-subnet", SubnetClassSpec: SubnetClassSpec{Role: SubnetControlPlane}},
+					{Name: "node-subnet", SubnetClassSpec: SubnetClassSpec{Role: SubnetNode}},
+				},
+				PrivateEndpoints: []PrivateEndpointSpec{
+					{Name: "storage-pe", ResourceID: "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.Storage/storageAccounts/mystorage"},
+					{Name: "vault-pe", ResourceID: "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.KeyVault/vaults/myvault", SubnetName: "cp-subnet"},
+					{Name: "registry-pe", ResourceID: "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.ContainerRegistry/registries/myregistry"},
+				},
+			},
+		},
+	}
+	cluster.setPrivateEndpointDefaults()
+
+	expected := []PrivateEndpointSpec{
+		{Name: "storage-pe", ResourceID: "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.Storage/storageAccounts/mystorage", GroupID: "blob", SubnetName: "node-subnet"},
+		{Name: "vault-pe", ResourceID: "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.KeyVault/vaults/myvault", GroupID: "vault", SubnetName: "cp-subnet"},
+		{Name: "registry-pe", ResourceID: "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.ContainerRegistry/registries/myregistry", SubnetName: "node-subnet"},
+	}
+	if !reflect.DeepEqual(cluster.Spec.NetworkSpec.PrivateEndpoints, expected) {
+		t.Errorf("Expected %v, got %v", expected, cluster.Spec.NetworkSpec.PrivateEndpoints)
+	}
+}
+
+func TestInternalLoadBalancerDefaults(t *testing.T) {
+	cluster := &AzureCluster{
+		Spec: AzureClusterSpec{
+			NetworkSpec: NetworkSpec{
+				Subnets: Subnets{
+					{Name: "cp-subnet", SubnetClassSpec: SubnetClassSpec{Role: SubnetControlPlane}},
+					{Name: "node-subnet", SubnetClassSpec: SubnetClassSpec{Role: SubnetNode}},
+					{Name: "other-node-subnet", SubnetClassSpec: SubnetClassSpec{Role: SubnetNode}},
+				},
+				InternalLoadBalancers: []InternalLoadBalancerSpec{
+					{
+						Name:      "ingress-lb",
+						PrivateIP: "10.1.0.10",
+						Rules:     []InternalLoadBalancerRule{{Name: "http", FrontendPort: 80}, {Name: "https", FrontendPort: 443, BackendPort: 30443}},
+					},
+					{
+						Name:       "dns-lb",
+						SubnetName: "other-node-subnet",
+						PrivateIP:  "10.2.0.10",
+						Rules:      []InternalLoadBalancerRule{{Name: "dns", Protocol: LoadBalancerRuleProtocolUDP, FrontendPort: 53}},
+						Probe:      InternalLoadBalancerProbe{Protocol: ProbeProtocolHTTP, Port: 8080},
+					},
+					{
+						Name:      "app-lb",
+						PrivateIP: "10.1.0.20",
+						Rules: []InternalLoadBalancerRule{
+							{Name: "grpc", FrontendPort: 9000},
+							{Name: "web", FrontendPort: 443, BackendPort: 30443, ProbeName: "readiness"},
+						},
+						Probes: []InternalLoadBalancerProbe{
+							{Name: "liveness"},
+							{Name: "readiness", Protocol: ProbeProtocolHTTPS, IntervalInSeconds: 5, NumberOfProbes: 2},
+						},
+					},
+				},
+			},
+		},
+	}
+	cluster.setInternalLoadBalancerDefaults()
+
+	expected := []InternalLoadBalancerSpec{
+		{
+			Name:       "ingress-lb",
+			SubnetName: "node-subnet",
+			PrivateIP:  "10.1.0.10",
+			Rules: []InternalLoadBalancerRule{
+				{Name: "http", Protocol: LoadBalancerRuleProtocolTCP, FrontendPort: 80, BackendPort: 80},
+				{Name: "https", Protocol: LoadBalancerRuleProtocolTCP, FrontendPort: 443, BackendPort: 30443},
+			},
+			Probe: InternalLoadBalancerProbe{Protocol: ProbeProtocolTCP, Port: 80, IntervalInSeconds: 15, NumberOfProbes: 4},
+		},
+		{
+			Name:       "dns-lb",
+			SubnetName: "other-node-subnet",
+			PrivateIP:  "10.2.0.10",
+			Rules:      []InternalLoadBalancerRule{{Name: "dns", Protocol: LoadBalancerRuleProtocolUDP, FrontendPort: 53, BackendPort: 53}},
+			Probe:      InternalLoadBalancerProbe{Protocol: ProbeProtocolHTTP, Port: 8080, RequestPath: "/", IntervalInSeconds: 15, NumberOfProbes: 4},
+		},
+		{
+			Name:       "app-lb",
+			SubnetName: "node-subnet",
+			PrivateIP:  "10.1.0.20",
+			Rules: []InternalLoadBalancerRule{
+				{Name: "grpc", Protocol: LoadBalancerRuleProtocolTCP, FrontendPort: 9000, BackendPort: 9000, ProbeName: "liveness"},
+				{Name: "web", Protocol: LoadBalancerRuleProtocolTCP, FrontendPort: 443, BackendPort: 30443, ProbeName: "readiness"},
+			},
+			Probe: InternalLoadBalancerProbe{Protocol: ProbeProtocolTCP, Port: 9000, IntervalInSeconds: 15, NumberOfProbes: 4},
+			Probes: []InternalLoadBalancerProbe{
+				{Name: "liveness", Protocol: ProbeProtocolTCP, Port: 9000, IntervalInSeconds: 15, NumberOfProbes: 4},
+				{Name: "readiness", Protocol: ProbeProtocolHTTPS, Port: 30443, RequestPath: "/", IntervalInSeconds: 5, NumberOfProbes: 2},
+			},
+		},
+	}
+	if !reflect.DeepEqual(cluster.Spec.NetworkSpec.InternalLoadBalancers, expected) {
+		t.Errorf("Expected %v, got %v", expected, cluster.Spec.NetworkSpec.InternalLoadBalancers)
+	}
+}
+
 func TestAPIServerLBDefaults(t *testing.T) {
 	cases := []struct {
 		name    string
@@ -840,7 +1214,8 @@ func TestAPIServerLBDefaults(t *testing.T) {
 						APIServerLB: LoadBalancerSpec{
 							Name: "cluster-test-public-lb",
 							LoadBalancerClassSpec: LoadBalancerClassSpec{
-								SKU: SKUStandard,
+								SKU:  SKUStandard,
+								Tier: LoadBalancerTierRegional,
 								FrontendIPs: []FrontendIP{
 									{
 										Name: "cluster-test-public-lb-frontEnd",
@@ -882,7 +1257,8 @@ func TestAPIServerLBDefaults(t *testing.T) {
 					NetworkSpec: NetworkSpec{
 						APIServerLB: LoadBalancerSpec{
 							LoadBalancerClassSpec: LoadBalancerClassSpec{
-								SKU: SKUStandard,
+								SKU:  SKUStandard,
+								Tier: LoadBalancerTierRegional,
 								FrontendIPs: []FrontendIP{
 									{
 										Name: "cluster-test-internal-lb-frontEnd",
@@ -900,6 +1276,54 @@ func TestAPIServerLBDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "lb with https health probe",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								HealthProbe: &HealthProbe{Protocol: ProbeProtocolHTTPS},
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{
+							Name: "cluster-test-public-lb",
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								SKU:  SKUStandard,
+								Tier: LoadBalancerTierRegional,
+								FrontendIPs: []FrontendIP{
+									{
+										Name: "cluster-test-public-lb-frontEnd",
+										PublicIP: &PublicIPSpec{
+											Name:    "pip-cluster-test-apiserver",
+											DNSName: "",
+										},
+									},
+								},
+								Type:                 Public,
+								IdleTimeoutInMinutes: to.Int32Ptr(DefaultOutboundRuleIdleTimeoutInMinutes),
+								HealthProbe: &HealthProbe{
+									Protocol:    ProbeProtocolHTTPS,
+									RequestPath: DefaultAPIServerProbeRequestPath,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -1067,7 +1491,8 @@ func TestNodeOutboundLBDefaults(t *testing.T) {
 						NodeOutboundLB: &LoadBalancerSpec{
 							Name: "cluster-test",
 							LoadBalancerClassSpec: LoadBalancerClassSpec{
-								SKU: SKUStandard,
+								SKU:  SKUStandard,
+								Tier: LoadBalancerTierRegional,
 								FrontendIPs: []FrontendIP{{
 									Name: "cluster-test-frontEnd",
 									PublicIP: &PublicIPSpec{
@@ -1236,7 +1661,8 @@ func TestNodeOutboundLBDefaults(t *testing.T) {
 						NodeOutboundLB: &LoadBalancerSpec{
 							Name: "cluster-test",
 							LoadBalancerClassSpec: LoadBalancerClassSpec{
-								SKU: SKUStandard,
+								SKU:  SKUStandard,
+								Tier: LoadBalancerTierRegional,
 								FrontendIPs: []FrontendIP{{
 									Name: "cluster-test-frontEnd",
 									PublicIP: &PublicIPSpec{
@@ -1345,7 +1771,8 @@ func TestNodeOutboundLBDefaults(t *testing.T) {
 						},
 						NodeOutboundLB: &LoadBalancerSpec{
 							LoadBalancerClassSpec: LoadBalancerClassSpec{
-								SKU: SKUStandard,
+								SKU:  SKUStandard,
+								Tier: LoadBalancerTierRegional,
 								FrontendIPs: []FrontendIP{{
 									Name: "cluster-test-frontEnd",
 									PublicIP: &PublicIPSpec{
@@ -1533,7 +1960,8 @@ func TestNodeOutboundLBDefaults(t *testing.T) {
 						},
 						NodeOutboundLB: &LoadBalancerSpec{
 							LoadBalancerClassSpec: LoadBalancerClassSpec{
-								SKU: SKUStandard,
+								SKU:  SKUStandard,
+								Tier: LoadBalancerTierRegional,
 								FrontendIPs: []FrontendIP{
 									{
 										Name: "cluster-test-frontEnd-1",
@@ -1666,7 +2094,8 @@ func TestControlPlaneOutboundLBDefaults(t *testing.T) {
 						ControlPlaneOutboundLB: &LoadBalancerSpec{
 							Name: "cluster-test-outbound-lb",
 							LoadBalancerClassSpec: LoadBalancerClassSpec{
-								SKU: SKUStandard,
+								SKU:  SKUStandard,
+								Tier: LoadBalancerTierRegional,
 								FrontendIPs: []FrontendIP{
 									{
 										Name: "cluster-test-outbound-lb-frontEnd-1",
@@ -1925,3 +2354,1081 @@ func TestBastionDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestJumpboxDefault(t *testing.T) {
+	cases := map[string]struct {
+		cluster *AzureCluster
+		output  *AzureCluster
+	}{
+		"no jumpbox set": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+		},
+		"jumpbox enabled with only required settings": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					BastionSpec: BastionSpec{
+						Jumpbox: &Jumpbox{
+							SSHPublicKey:       "c3NoLXJzYSBBQUFB",
+							AllowedSourceCIDRs: []string{"203.0.113.0/24"},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					BastionSpec: BastionSpec{
+						Jumpbox: &Jumpbox{
+							Name:   "foo-jumpbox",
+							VMSize: DefaultJumpboxVMSize,
+							Image: &Image{
+								Marketplace: &AzureMarketplaceImage{
+									Publisher: "Canonical",
+									Offer:     "0001-com-ubuntu-server-focal",
+									SKU:       "20_04-lts",
+									Version:   "latest",
+								},
+							},
+							SSHPublicKey:       "c3NoLXJzYSBBQUFB",
+							AllowedSourceCIDRs: []string{"203.0.113.0/24"},
+							Subnet: SubnetSpec{
+								Name: "foo-jumpbox-subnet",
+								SubnetClassSpec: SubnetClassSpec{
+									CIDRBlocks: []string{DefaultJumpboxSubnetCIDR},
+									Role:       DefaultJumpboxSubnetRole,
+								},
+								SecurityGroup: SecurityGroup{
+									Name: "foo-jumpbox-nsg",
+								},
+							},
+							PublicIP: PublicIPSpec{
+								Name: "foo-jumpbox-pip",
+							},
+						},
+					},
+				},
+			},
+		},
+		"jumpbox enabled with user settings": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					BastionSpec: BastionSpec{
+						Jumpbox: &Jumpbox{
+							Name:   "my-jumpbox",
+							VMSize: "Standard_D2s_v3",
+							Image: &Image{
+								ID: to.StringPtr("my-image-id"),
+							},
+							SSHPublicKey:       "c3NoLXJzYSBBQUFB",
+							AllowedSourceCIDRs: []string{"203.0.113.0/24"},
+							Subnet: SubnetSpec{
+								Name: "my-jumpbox-subnet",
+								SubnetClassSpec: SubnetClassSpec{
+									CIDRBlocks: []string{"10.10.0.0/28"},
+								},
+							},
+							PublicIP: PublicIPSpec{
+								Name:    "my-jumpbox-pip",
+								DNSName: "my-jumpbox.example.com",
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					BastionSpec: BastionSpec{
+						Jumpbox: &Jumpbox{
+							Name:   "my-jumpbox",
+							VMSize: "Standard_D2s_v3",
+							Image: &Image{
+								ID: to.StringPtr("my-image-id"),
+							},
+							SSHPublicKey:       "c3NoLXJzYSBBQUFB",
+							AllowedSourceCIDRs: []string{"203.0.113.0/24"},
+							Subnet: SubnetSpec{
+								Name: "my-jumpbox-subnet",
+								SubnetClassSpec: SubnetClassSpec{
+									CIDRBlocks: []string{"10.10.0.0/28"},
+									Role:       DefaultJumpboxSubnetRole,
+								},
+								SecurityGroup: SecurityGroup{
+									Name: "foo-jumpbox-nsg",
+								},
+							},
+							PublicIP: PublicIPSpec{
+								Name:    "my-jumpbox-pip",
+								DNSName: "my-jumpbox.example.com",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			c.cluster.setJumpboxDefaults()
+			if !reflect.DeepEqual(c.cluster, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(c.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestIngressDefaults(t *testing.T) {
+	cases := map[string]struct {
+		cluster *AzureCluster
+		output  *AzureCluster
+	}{
+		"no ingress set": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+			},
+		},
+		"ingress enabled with defaults": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Ingress: &IngressSpec{
+							PublicIP: &PublicIPSpec{},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Ingress: &IngressSpec{
+							Subnet: SubnetSpec{
+								Name: "foo-ingress-subnet",
+								SubnetClassSpec: SubnetClassSpec{
+									CIDRBlocks: []string{DefaultIngressSubnetCIDR},
+									Role:       DefaultIngressSubnetRole,
+								},
+								SecurityGroup: SecurityGroup{
+									Name: "foo-ingress-nsg",
+								},
+							},
+							Ports: []int32{80, 443},
+							PublicIP: &PublicIPSpec{
+								Name: "foo-ingress-pip",
+							},
+						},
+					},
+				},
+			},
+		},
+		"ingress enabled with user settings": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Ingress: &IngressSpec{
+							Subnet: SubnetSpec{
+								Name: "my-ingress-subnet",
+								SubnetClassSpec: SubnetClassSpec{
+									CIDRBlocks: []string{"10.10.0.0/24"},
+								},
+								SecurityGroup: SecurityGroup{
+									Name: "my-ingress-nsg",
+								},
+							},
+							Ports:              []int32{8443},
+							AllowedSourceCIDRs: []string{"203.0.113.0/24"},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Ingress: &IngressSpec{
+							Subnet: SubnetSpec{
+								Name: "my-ingress-subnet",
+								SubnetClassSpec: SubnetClassSpec{
+									CIDRBlocks: []string{"10.10.0.0/24"},
+									Role:       DefaultIngressSubnetRole,
+								},
+								SecurityGroup: SecurityGroup{
+									Name: "my-ingress-nsg",
+								},
+							},
+							Ports:              []int32{8443},
+							AllowedSourceCIDRs: []string{"203.0.113.0/24"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			c.cluster.setIngressDefaults()
+			if !reflect.DeepEqual(c.cluster, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(c.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestPodSubnetDefaults(t *testing.T) {
+	cases := map[string]struct {
+		cluster *AzureCluster
+		output  *AzureCluster
+	}{
+		"no pod subnet set": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+			},
+		},
+		"pod subnet with defaults": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						PodSubnet: &PodSubnetSpec{
+							MaxNodes: 100,
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						PodSubnet: &PodSubnetSpec{
+							Name:              "foo-pod-subnet",
+							CIDRBlocks:        []string{DefaultPodSubnetCIDR},
+							MaxPodsPerNode:    DefaultMaxPodsPerNode,
+							MaxNodes:          100,
+							ServiceDelegation: DefaultPodSubnetServiceDelegation,
+						},
+					},
+				},
+			},
+		},
+		"pod subnet with user settings": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						PodSubnet: &PodSubnetSpec{
+							Name:              "my-pod-subnet",
+							CIDRBlocks:        []string{"10.64.0.0/14"},
+							MaxPodsPerNode:    110,
+							MaxNodes:          500,
+							ServiceDelegation: "Microsoft.Network/dnsResolvers",
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						PodSubnet: &PodSubnetSpec{
+							Name:              "my-pod-subnet",
+							CIDRBlocks:        []string{"10.64.0.0/14"},
+							MaxPodsPerNode:    110,
+							MaxNodes:          500,
+							ServiceDelegation: "Microsoft.Network/dnsResolvers",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			c.cluster.setPodSubnetDefaults()
+			if !reflect.DeepEqual(c.cluster, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(c.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestNetworkInterfaceSecurityGroupDefaults(t *testing.T) {
+	cases := map[string]struct {
+		cluster *AzureCluster
+		output  *AzureCluster
+	}{
+		"no network interface security groups set": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+			},
+		},
+		"network interface security groups with defaults": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						NetworkInterfaceSecurityGroups: &NetworkInterfaceSecurityGroups{},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						NetworkInterfaceSecurityGroups: &NetworkInterfaceSecurityGroups{
+							Attachment: SecurityGroupAttachmentNetworkInterface,
+							ControlPlane: SecurityGroup{
+								Name: "foo-controlplane-nic-nsg",
+							},
+							Node: SecurityGroup{
+								Name: "foo-node-nic-nsg",
+							},
+						},
+					},
+				},
+			},
+		},
+		"network interface security groups with user settings": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						NetworkInterfaceSecurityGroups: &NetworkInterfaceSecurityGroups{
+							Attachment: SecurityGroupAttachmentSubnetAndNetworkInterface,
+							ControlPlane: SecurityGroup{
+								Name: "my-controlplane-nic-nsg",
+							},
+							Node: SecurityGroup{
+								SecurityGroupClass: SecurityGroupClass{
+									SecurityRules: SecurityRules{
+										{
+											Name:     "allow_http",
+											Protocol: SecurityGroupProtocolTCP,
+											Priority: 2300,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						NetworkInterfaceSecurityGroups: &NetworkInterfaceSecurityGroups{
+							Attachment: SecurityGroupAttachmentSubnetAndNetworkInterface,
+							ControlPlane: SecurityGroup{
+								Name: "my-controlplane-nic-nsg",
+							},
+							Node: SecurityGroup{
+								Name: "foo-node-nic-nsg",
+								SecurityGroupClass: SecurityGroupClass{
+									SecurityRules: SecurityRules{
+										{
+											Name:      "allow_http",
+											Protocol:  SecurityGroupProtocolTCP,
+											Priority:  2300,
+											Direction: SecurityRuleDirectionInbound,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			c.cluster.setNetworkInterfaceSecurityGroupDefaults()
+			if !reflect.DeepEqual(c.cluster, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(c.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestTrafficManagerDefaults(t *testing.T) {
+	cases := map[string]struct {
+		cluster *AzureCluster
+		output  *AzureCluster
+	}{
+		"no traffic manager set": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+		},
+		"traffic manager enabled with no settings": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						TrafficManager: &TrafficManagerSpec{},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						TrafficManager: &TrafficManagerSpec{
+							Name:          "foo-tm",
+							DNSPrefix:     "foo",
+							RoutingMethod: TrafficRoutingMethodPriority,
+						},
+					},
+				},
+			},
+		},
+		"traffic manager with user-defined values": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						TrafficManager: &TrafficManagerSpec{
+							Name:          "my-tm",
+							DNSPrefix:     "my-apiserver",
+							RoutingMethod: TrafficRoutingMethodPerformance,
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						TrafficManager: &TrafficManagerSpec{
+							Name:          "my-tm",
+							DNSPrefix:     "my-apiserver",
+							RoutingMethod: TrafficRoutingMethodPerformance,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			c.cluster.setTrafficManagerDefaults()
+			if !reflect.DeepEqual(c.cluster, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(c.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestGlobalLBDefaults(t *testing.T) {
+	cases := map[string]struct {
+		cluster *AzureCluster
+		output  *AzureCluster
+	}{
+		"no cross-region load balancer set": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+		},
+		"cross-region load balancer enabled with no settings": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					AzureClusterClassSpec: AzureClusterClassSpec{
+						Location: "eastus",
+					},
+					NetworkSpec: NetworkSpec{
+						GlobalLB: &GlobalLoadBalancerSpec{},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					AzureClusterClassSpec: AzureClusterClassSpec{
+						Location: "eastus",
+					},
+					NetworkSpec: NetworkSpec{
+						GlobalLB: &GlobalLoadBalancerSpec{
+							Name:     "foo-global-lb",
+							Location: "eastus",
+							PublicIP: &PublicIPSpec{
+								Name: "pip-foo-global",
+								Tier: PublicIPTierGlobal,
+							},
+						},
+					},
+				},
+			},
+		},
+		"cross-region load balancer with user-defined values": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					AzureClusterClassSpec: AzureClusterClassSpec{
+						Location: "westus3",
+					},
+					NetworkSpec: NetworkSpec{
+						GlobalLB: &GlobalLoadBalancerSpec{
+							Name:     "my-global-lb",
+							Location: "westus",
+							PublicIP: &PublicIPSpec{
+								Name:    "my-global-pip",
+								DNSName: "my-apiserver.westus.cloudapp.azure.com",
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					AzureClusterClassSpec: AzureClusterClassSpec{
+						Location: "westus3",
+					},
+					NetworkSpec: NetworkSpec{
+						GlobalLB: &GlobalLoadBalancerSpec{
+							Name:     "my-global-lb",
+							Location: "westus",
+							PublicIP: &PublicIPSpec{
+								Name:    "my-global-pip",
+								DNSName: "my-apiserver.westus.cloudapp.azure.com",
+								Tier:    PublicIPTierGlobal,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			c.cluster.setGlobalLBDefaults()
+			if !reflect.DeepEqual(c.cluster, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(c.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestOutboundConnectivityCheckDefaults(t *testing.T) {
+	cases := map[string]struct {
+		cluster *AzureCluster
+		output  *AzureCluster
+	}{
+		"no outbound connectivity check set": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+		},
+		"outbound connectivity check with destination only": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					AzureClusterClassSpec: AzureClusterClassSpec{
+						Location: "westus2",
+					},
+					NetworkSpec: NetworkSpec{
+						OutboundConnectivityCheck: &OutboundConnectivityCheck{
+							DestinationIP: "20.37.158.0",
+							Port:          443,
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					AzureClusterClassSpec: AzureClusterClassSpec{
+						Location: "westus2",
+					},
+					NetworkSpec: NetworkSpec{
+						OutboundConnectivityCheck: &OutboundConnectivityCheck{
+							DestinationIP:               "20.37.158.0",
+							Port:                        443,
+							Protocol:                    "TCP",
+							NetworkWatcherName:          "NetworkWatcher_westus2",
+							NetworkWatcherResourceGroup: "NetworkWatcherRG",
+						},
+					},
+				},
+			},
+		},
+		"outbound connectivity check with user-defined values": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					AzureClusterClassSpec: AzureClusterClassSpec{
+						Location: "westus2",
+					},
+					NetworkSpec: NetworkSpec{
+						OutboundConnectivityCheck: &OutboundConnectivityCheck{
+							DestinationIP:               "20.37.158.0",
+							Port:                        53,
+							Protocol:                    "UDP",
+							NetworkWatcherName:          "my-watcher",
+							NetworkWatcherResourceGroup: "my-rg",
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					AzureClusterClassSpec: AzureClusterClassSpec{
+						Location: "westus2",
+					},
+					NetworkSpec: NetworkSpec{
+						OutboundConnectivityCheck: &OutboundConnectivityCheck{
+							DestinationIP:               "20.37.158.0",
+							Port:                        53,
+							Protocol:                    "UDP",
+							NetworkWatcherName:          "my-watcher",
+							NetworkWatcherResourceGroup: "my-rg",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			c.cluster.setOutboundConnectivityCheckDefaults()
+			if !reflect.DeepEqual(c.cluster, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(c.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestLogAnalyticsWorkspaceDefaults(t *testing.T) {
+	cases := map[string]struct {
+		cluster *AzureCluster
+		output  *AzureCluster
+	}{
+		"no workspace set": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec:       AzureClusterSpec{},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec:       AzureClusterSpec{},
+			},
+		},
+		"workspace enabled with no settings": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: AzureClusterSpec{
+					LogAnalyticsWorkspace: &LogAnalyticsWorkspace{},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: AzureClusterSpec{
+					LogAnalyticsWorkspace: &LogAnalyticsWorkspace{Name: "foo-workspace"},
+				},
+			},
+		},
+		"existing workspace referenced by ID": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: AzureClusterSpec{
+					LogAnalyticsWorkspace: &LogAnalyticsWorkspace{ID: "/subscriptions/123/resourceGroups/shared/providers/Microsoft.OperationalInsights/workspaces/shared"},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: AzureClusterSpec{
+					LogAnalyticsWorkspace: &LogAnalyticsWorkspace{ID: "/subscriptions/123/resourceGroups/shared/providers/Microsoft.OperationalInsights/workspaces/shared"},
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			c.cluster.setLogAnalyticsWorkspaceDefaults()
+			if !reflect.DeepEqual(c.cluster, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(c.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestControlPlaneAvailabilitySetDefaults(t *testing.T) {
+	cases := map[string]struct {
+		cluster *AzureCluster
+		output  *AzureCluster
+	}{
+		"no availability set": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec:       AzureClusterSpec{},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec:       AzureClusterSpec{},
+			},
+		},
+		"availability set with no settings": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: AzureClusterSpec{
+					ControlPlaneAvailabilitySet: &AvailabilitySet{},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: AzureClusterSpec{
+					ControlPlaneAvailabilitySet: &AvailabilitySet{Name: "foo_control-plane-as", UpdateDomainCount: to.Int32Ptr(5)},
+				},
+			},
+		},
+		"availability set with all settings": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: AzureClusterSpec{
+					ControlPlaneAvailabilitySet: &AvailabilitySet{Name: "my-as", FaultDomainCount: to.Int32Ptr(2), UpdateDomainCount: to.Int32Ptr(10)},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: AzureClusterSpec{
+					ControlPlaneAvailabilitySet: &AvailabilitySet{Name: "my-as", FaultDomainCount: to.Int32Ptr(2), UpdateDomainCount: to.Int32Ptr(10)},
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			c.cluster.setControlPlaneAvailabilitySetDefaults()
+			if !reflect.DeepEqual(c.cluster, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(c.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestSecondaryRegionDefaults(t *testing.T) {
+	cases := map[string]struct {
+		cluster *AzureCluster
+		output  *AzureCluster
+	}{
+		"no secondary region": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec:       AzureClusterSpec{ResourceGroup: "foo-rg"},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec:       AzureClusterSpec{ResourceGroup: "foo-rg"},
+			},
+		},
+		"secondary region with no settings": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: AzureClusterSpec{
+					ResourceGroup:   "foo-rg",
+					SecondaryRegion: &SecondaryRegionSpec{},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: AzureClusterSpec{
+					ResourceGroup: "foo-rg",
+					SecondaryRegion: &SecondaryRegionSpec{
+						Vnet: VnetSpec{
+							ResourceGroup: "foo-rg",
+							Name:          "foo-secondary-vnet",
+							VnetClassSpec: VnetClassSpec{CIDRBlocks: []string{DefaultVnetCIDR}},
+						},
+						Subnets: []SecondarySubnetSpec{
+							{
+								Name:              "foo-secondary-controlplane-subnet",
+								SecurityGroupName: "foo-secondary-controlplane-nsg",
+								SubnetClassSpec:   SubnetClassSpec{Role: SubnetControlPlane, CIDRBlocks: []string{DefaultControlPlaneSubnetCIDR}},
+							},
+							{
+								Name:              "foo-secondary-node-subnet",
+								SecurityGroupName: "foo-secondary-node-nsg",
+								SubnetClassSpec:   SubnetClassSpec{Role: SubnetNode, CIDRBlocks: []string{DefaultNodeSubnetCIDR}},
+							},
+						},
+					},
+				},
+			},
+		},
+		"secondary region with all settings": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: AzureClusterSpec{
+					ResourceGroup: "foo-rg",
+					SecondaryRegion: &SecondaryRegionSpec{
+						Location: "westus",
+						Vnet: VnetSpec{
+							ResourceGroup: "dr-rg",
+							Name:          "dr-vnet",
+							VnetClassSpec: VnetClassSpec{CIDRBlocks: []string{"172.16.0.0/16"}},
+						},
+						Subnets: []SecondarySubnetSpec{
+							{
+								Name:              "dr-node-subnet",
+								SecurityGroupName: "dr-node-nsg",
+								SubnetClassSpec:   SubnetClassSpec{Role: SubnetNode, CIDRBlocks: []string{"172.16.1.0/24"}},
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: AzureClusterSpec{
+					ResourceGroup: "foo-rg",
+					SecondaryRegion: &SecondaryRegionSpec{
+						Location: "westus",
+						Vnet: VnetSpec{
+							ResourceGroup: "dr-rg",
+							Name:          "dr-vnet",
+							VnetClassSpec: VnetClassSpec{CIDRBlocks: []string{"172.16.0.0/16"}},
+						},
+						Subnets: []SecondarySubnetSpec{
+							{
+								Name:              "dr-node-subnet",
+								SecurityGroupName: "dr-node-nsg",
+								SubnetClassSpec:   SubnetClassSpec{Role: SubnetNode, CIDRBlocks: []string{"172.16.1.0/24"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			c.cluster.setSecondaryRegionDefaults()
+			if !reflect.DeepEqual(c.cluster, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(c.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestSetDefaultsIsIdempotent(t *testing.T) {
+	cases := map[string]struct {
+		cluster *AzureCluster
+	}{
+		"empty spec": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+			},
+		},
+		"custom network spec": {
+			cluster: createValidCluster(),
+		},
+		"private cluster with bastion and traffic manager": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					BastionSpec: BastionSpec{
+						AzureBastion: &AzureBastion{},
+					},
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								Type: Internal,
+							},
+						},
+						TrafficManager: &TrafficManagerSpec{},
+					},
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			c.cluster.setDefaults()
+			once := c.cluster.DeepCopy()
+			c.cluster.setDefaults()
+			if !reflect.DeepEqual(c.cluster, once) {
+				expected, _ := json.MarshalIndent(once, "", "\t")
+				actual, _ := json.MarshalIndent(c.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package v1beta1
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/errors"
 )
 
 const (
@@ -41,6 +42,12 @@ type AzureClusterSpec struct {
 	// +optional
 	ResourceGroup string `json:"resourceGroup,omitempty"`
 
+	// ResourceGroupLocation is the location of the resource group, which stores its metadata, when it differs from the
+	// location the resources of the cluster are deployed to, e.g. for policies that pin resource groups to a home region.
+	// Defaults to the location of the cluster. Immutable.
+	// +optional
+	ResourceGroupLocation string `json:"resourceGroupLocation,omitempty"`
+
 	// BastionSpec encapsulates all things related to the Bastions in the cluster.
 	// +optional
 	BastionSpec BastionSpec `json:"bastionSpec,omitempty"`
@@ -49,8 +56,123 @@ type AzureClusterSpec struct {
 	// this when creating an AzureCluster as CAPZ will set this for you. However, if it is set, CAPZ will not change it.
 	// +optional
 	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint,omitempty"`
+
+	// DeleteGracePeriod is the time to wait after the AzureCluster is deleted before its Azure resources are deleted.
+	// It gives operators a window to prevent an accidental deletion, e.g. by removing the ownership tags of the
+	// resource group. Defaults to zero, which deletes the Azure resources immediately.
+	// +optional
+	DeleteGracePeriod *metav1.Duration `json:"deleteGracePeriod,omitempty"`
+
+	// LogAnalyticsWorkspace is the Log Analytics workspace used by the monitoring add-ons of the cluster.
+	// +optional
+	LogAnalyticsWorkspace *LogAnalyticsWorkspace `json:"logAnalyticsWorkspace,omitempty"`
+
+	// DiagnosticsResourceGroup is a resource group, distinct from the resource group of the cluster, in which the
+	// diagnostics resources created by CAPZ are placed, i.e. the Log Analytics workspace. It is created when it doesn't
+	// exist and adopted otherwise, and only deleted with the cluster if CAPZ created it. Immutable.
+	// +optional
+	DiagnosticsResourceGroup *DiagnosticsResourceGroup `json:"diagnosticsResourceGroup,omitempty"`
+
+	// NamingConvention customizes the names generated for the Azure resources of the cluster that aren't named in the
+	// spec, i.e. the resource group, virtual network, subnets, security groups, route tables, load balancers, public IPs
+	// and the control plane availability set. The child resources of a load balancer, e.g. its frontend IP
+	// configurations, are named after it. Defaults to names made of the cluster name and the kind of resource, e.g.
+	// "<cluster name>-vnet".
+	// +optional
+	NamingConvention *NamingConvention `json:"namingConvention,omitempty"`
+
+	// ReconcileMode defines which Azure resources of the cluster are managed. Full, the default, manages all of them.
+	// NetworkOnly manages only the networking layer of the cluster, i.e. the virtual network and its subnets, security
+	// groups, route tables, NAT gateways, public IPs and load balancers, for clusters whose compute is managed by
+	// another system: the resource group must already exist and is never created, tagged or deleted.
+	// Immutable.
+	// +kubebuilder:validation:Enum=Full;NetworkOnly
+	// +optional
+	ReconcileMode ReconcileMode `json:"reconcileMode,omitempty"`
+
+	// DefaultSpotPolicy is the Spot VM settings the machines of the cluster that run on Spot VMs default to, e.g. to
+	// apply a cost policy to the whole cluster. It doesn't make any machine run on Spot VMs.
+	// +optional
+	DefaultSpotPolicy *SpotPolicy `json:"defaultSpotPolicy,omitempty"`
+
+	// AutoShutdown is the daily shutdown schedule of the machines of the cluster, e.g. to save the costs of a dev
+	// cluster overnight. It is published in the status for the machine actuators and in a tag of the resources of the
+	// cluster; it doesn't shut anything down by itself.
+	// +optional
+	AutoShutdown *AutoShutdownSchedule `json:"autoShutdown,omitempty"`
+
+	// InheritResourceGroupTags makes the virtual network, load balancers and public IPs of the cluster inherit the tags
+	// of the resource group they are in, the way the Azure Policy "Inherit a tag from the resource group" does. The
+	// tags of the resource group only fill the gaps: the AdditionalTags and the tags set on the resources themselves
+	// take precedence. Not supported in NetworkOnly mode.
+	// +optional
+	InheritResourceGroupTags bool `json:"inheritResourceGroupTags,omitempty"`
+
+	// PolicyAssignments are the Azure Policy definitions and initiatives assigned to the resource group of the
+	// cluster, e.g. a baseline set of governance policies. An assignment that already exists in the resource group,
+	// with the same name or of the same definition, is adopted as is: it is never modified nor removed. Requires the
+	// PolicyAssignments feature gate, and the identity of the cluster to be allowed to assign policies, e.g. with the
+	// Resource Policy Contributor role. Not supported in NetworkOnly mode.
+	// +optional
+	PolicyAssignments []PolicyAssignment `json:"policyAssignments,omitempty"`
+
+	// RoleAssignments are the Azure roles assigned to principals on the resource group of the cluster, e.g.
+	// Contributor for the user-assigned identity of the cloud provider. An identical assignment that already exists is
+	// adopted as is: it is never removed. Requires the identity of the cluster to be allowed to assign roles, e.g. with
+	// the User Access Administrator role. Not supported in NetworkOnly mode.
+	// +optional
+	RoleAssignments []RoleAssignment `json:"roleAssignments,omitempty"`
+
+	// Budget is an Azure Cost Management budget scoped to the resource group of the cluster, which notifies its
+	// contacts when the cost of the resources of the resource group reaches its thresholds, e.g. to catch runaway
+	// spending. The budget is deleted with the cluster, or when unset. Requires the Budgets feature gate, and the
+	// identity of the cluster to be allowed to manage budgets, e.g. with the Cost Management Contributor role. Not
+	// supported in NetworkOnly mode.
+	// +optional
+	Budget *BudgetSpec `json:"budget,omitempty"`
+
+	// Gallery references the Azure Compute Gallery image version the machines of the cluster are built from. It is
+	// checked to exist and to be replicated to the location of the cluster on every reconciliation, and the resource ID
+	// of the resolved image version is published in the status.
+	// +optional
+	Gallery *GalleryImage `json:"gallery,omitempty"`
+
+	// ControlPlaneAvailabilitySet is the availability set the control plane machines of the cluster are placed in
+	// when its location has no availability zones. It is reconciled with the cluster, and its resource ID published in
+	// the status for the machine actuator. It is only deleted with the cluster, once empty, when created by CAPZ.
+	// +optional
+	ControlPlaneAvailabilitySet *AvailabilitySet `json:"controlPlaneAvailabilitySet,omitempty"`
+
+	// ControlPlaneEtcdDisks are the requirements of the etcd data disks of the control plane machines, e.g. ultra
+	// disks. They are checked against the location and the availability zones of the control plane before the
+	// control plane machines are provisioned, and their compatibility is reported in the ControlPlaneEtcdDisksCompatible
+	// condition. Nothing is checked when unset.
+	// +optional
+	ControlPlaneEtcdDisks *EtcdDisks `json:"controlPlaneEtcdDisks,omitempty"`
+
+	// RequiredFeatures are the preview features of the Azure resource providers the cluster relies on, e.g.
+	// Microsoft.Network/AllowBringYourOwnPublicIpAddress. They are checked to be registered in the subscription, along
+	// with the resource providers of the Azure resources of the cluster, before any resource is reconciled.
+	// +optional
+	RequiredFeatures []ProviderFeature `json:"requiredFeatures,omitempty"`
+
+	// InventoryConfigMapName is the name of a ConfigMap, in the namespace of the AzureCluster, to which the inventory
+	// of the Azure resources of the cluster is written on every reconciliation: their resource IDs, types, and whether
+	// they are managed or adopted by CAPZ. No ConfigMap is written when empty.
+	// +optional
+	InventoryConfigMapName string `json:"inventoryConfigMapName,omitempty"`
+
+	// SecondaryRegion is the region of a warm standby of the cluster, whose network is reconciled with the network of
+	// the cluster so that machines can be created there quickly on failover. No load balancer nor public IP is created
+	// in the secondary region. The cluster is single-region when unset.
+	// +optional
+	SecondaryRegion *SecondaryRegionSpec `json:"secondaryRegion,omitempty"`
 }
 
+// InventoryConfigMapKey is the key of the inventory of the Azure resources of a cluster, as a JSON list, in the
+// ConfigMap named by AzureClusterSpec.InventoryConfigMapName.
+const InventoryConfigMapKey = "inventory.json"
+
 // AzureClusterStatus defines the observed state of AzureCluster.
 type AzureClusterStatus struct {
 	// FailureDomains specifies the list of unique failure domains for the location/region of the cluster.
@@ -74,6 +196,175 @@ type AzureClusterStatus struct {
 	// next reconciliation loop.
 	// +optional
 	LongRunningOperationStates Futures `json:"longRunningOperationStates,omitempty"`
+
+	// ControlPlaneEndpoints is the list of endpoints that can be used to reach the control plane, in order of
+	// preference: the endpoint of the Traffic Manager profile or of the cross-region load balancer, if any, then the
+	// endpoints of the frontends of the API server load balancer and its internal endpoint. For single-region clusters
+	// this contains only the endpoints of the API server load balancer.
+	// +optional
+	ControlPlaneEndpoints []clusterv1.APIEndpoint `json:"controlPlaneEndpoints,omitempty"`
+
+	// JumpboxIP is the public IP address of the jumpbox, if one is configured.
+	// +optional
+	JumpboxIP string `json:"jumpboxIP,omitempty"`
+
+	// DNSServers is the list of custom DNS servers currently configured on the virtual network. When set, name
+	// resolution in the cluster goes through these servers instead of the Azure-provided DNS.
+	// +optional
+	DNSServers []string `json:"dnsServers,omitempty"`
+
+	// DeletionRequestedAt is the time the deletion of the Azure resources of the cluster was first attempted.
+	// The DeleteGracePeriod is counted from this time.
+	// +optional
+	DeletionRequestedAt *metav1.Time `json:"deletionRequestedAt,omitempty"`
+
+	// ControlPlaneEgressIPs are the public IP addresses of the NAT gateway of the control plane subnet, which the
+	// egress traffic of the control plane originates from.
+	// +optional
+	ControlPlaneEgressIPs []string `json:"controlPlaneEgressIPs,omitempty"`
+
+	// PublicIPPrefixAllocations maps the name of each public IP of the load balancer frontends allocated from a
+	// public IP prefix to its address.
+	// +optional
+	PublicIPPrefixAllocations map[string]string `json:"publicIPPrefixAllocations,omitempty"`
+
+	// NatGatewayIPPrefixes maps the name of each public IP prefix used by the NAT gateways of the cluster to the
+	// range of addresses allocated to it.
+	// +optional
+	NatGatewayIPPrefixes map[string]string `json:"natGatewayIPPrefixes,omitempty"`
+
+	// PublicIPZones maps the name of each public IP of the cluster to the availability zones it was created in. It is
+	// empty for the public IPs of a location without availability zones.
+	// +optional
+	PublicIPZones map[string][]string `json:"publicIPZones,omitempty"`
+
+	// SubnetAvailableIPs maps the name of each subnet of the cluster to the number of IP addresses still available in
+	// it, i.e. the size of its address prefixes minus the 5 addresses Azure reserves in each one and the addresses
+	// already allocated.
+	// +optional
+	SubnetAvailableIPs map[string]int32 `json:"subnetAvailableIPs,omitempty"`
+
+	// LogAnalyticsWorkspace is the observed state of the Log Analytics workspace of the cluster.
+	// +optional
+	LogAnalyticsWorkspace *LogAnalyticsWorkspaceStatus `json:"logAnalyticsWorkspace,omitempty"`
+
+	// PairedRegion is the Azure region paired with the location of the cluster for disaster recovery, as reported by
+	// Azure. It is empty for regions without a pair.
+	// See: https://docs.microsoft.com/en-us/azure/availability-zones/cross-region-replication-azure
+	// +optional
+	PairedRegion string `json:"pairedRegion,omitempty"`
+
+	// Location is the location the resources of the cluster are deployed to.
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// ResourceGroupLocation is the location of the resource group of the cluster, as reported by Azure.
+	// +optional
+	ResourceGroupLocation string `json:"resourceGroupLocation,omitempty"`
+
+	// DiagnosticsResourceGroupLocation is the location of the diagnostics resource group of the cluster, as reported
+	// by Azure.
+	// +optional
+	DiagnosticsResourceGroupLocation string `json:"diagnosticsResourceGroupLocation,omitempty"`
+
+	// DefaultSpotPolicy is the default Spot VM policy of the cluster, as last validated. It is what machine actuators
+	// apply to the machines of the cluster that run on Spot VMs for the settings they don't set.
+	// +optional
+	DefaultSpotPolicy *SpotPolicy `json:"defaultSpotPolicy,omitempty"`
+
+	// AutoShutdown is the auto-shutdown schedule of the cluster, as last validated. It is what machine actuators apply
+	// to the virtual machines of the cluster.
+	// +optional
+	AutoShutdown *AutoShutdownSchedule `json:"autoShutdown,omitempty"`
+
+	// FailedReconcileAttempts is the number of consecutive reconciliations of the cluster that failed with an error
+	// that isn't expected to resolve itself. It is reset whenever a phase of the reconciliation, e.g. the network or
+	// the load balancers, completes.
+	// +optional
+	FailedReconcileAttempts int32 `json:"failedReconcileAttempts,omitempty"`
+
+	// GeneratedSecurityRules maps the name of each security group with inbound traffic intents or a DenyByDefault
+	// egress policy to the security rules generated from them.
+	// +optional
+	GeneratedSecurityRules map[string]SecurityRules `json:"generatedSecurityRules,omitempty"`
+
+	// LoadBalancerTiers maps the name of each load balancer of the cluster to the tier Azure reports for it.
+	// +optional
+	LoadBalancerTiers map[string]LoadBalancerTier `json:"loadBalancerTiers,omitempty"`
+
+	// GlobalLoadBalancerBackends maps the resource ID of each regional frontend behind the cross-region load balancer
+	// to the health Azure Resource Health reports for its load balancer.
+	// +optional
+	GlobalLoadBalancerBackends map[string]BackendHealthState `json:"globalLoadBalancerBackends,omitempty"`
+
+	// NetworkInterfaceSecurityGroupIDs maps the role of the machines, control-plane or node, to the Azure resource ID
+	// of the security group attached to their network interfaces.
+	// +optional
+	NetworkInterfaceSecurityGroupIDs map[string]string `json:"networkInterfaceSecurityGroupIDs,omitempty"`
+
+	// PolicyAssignmentIDs maps the name of each policy assignment of the spec to the Azure resource ID of the
+	// assignment in effect for it, created by CAPZ or adopted.
+	// +optional
+	PolicyAssignmentIDs map[string]string `json:"policyAssignmentIDs,omitempty"`
+
+	// RoleAssignmentIDs maps the name of each role assignment of the spec to the Azure resource ID of the assignment
+	// in effect for it, created by CAPZ or adopted.
+	// +optional
+	RoleAssignmentIDs map[string]string `json:"roleAssignmentIDs,omitempty"`
+
+	// BudgetID is the Azure resource ID of the Cost Management budget of the resource group of the cluster.
+	// +optional
+	BudgetID string `json:"budgetID,omitempty"`
+
+	// PodSubnetID is the Azure resource ID of the pod subnet of the cluster, for the configuration of Azure CNI.
+	// +optional
+	PodSubnetID string `json:"podSubnetID,omitempty"`
+
+	// EstimatedCost is an approximate monthly cost of the network resources of the cluster, from the Azure Retail
+	// Prices API. It is omitted when the prices of the region can't be retrieved.
+	// +optional
+	EstimatedCost *CostEstimate `json:"estimatedCost,omitempty"`
+
+	// ControlPlaneAvailabilitySetID is the Azure resource ID of the availability set of the control plane, reconciled
+	// from ControlPlaneAvailabilitySet, for the machine actuator to place the control plane machines in.
+	// +optional
+	ControlPlaneAvailabilitySetID string `json:"controlPlaneAvailabilitySetID,omitempty"`
+
+	// ControlPlaneEtcdDiskZones are the availability zones of the control plane the etcd data disks of
+	// ControlPlaneEtcdDisks are supported in.
+	// +optional
+	ControlPlaneEtcdDiskZones []string `json:"controlPlaneEtcdDiskZones,omitempty"`
+
+	// GalleryImageID is the Azure resource ID of the gallery image version, resolved from the gallery of the spec, for
+	// the machine actuators to build machines from.
+	// +optional
+	GalleryImageID string `json:"galleryImageID,omitempty"`
+
+	// PrivateEndpointIPs maps the name of each private endpoint of the network spec to its private IP.
+	// +optional
+	PrivateEndpointIPs map[string]string `json:"privateEndpointIPs,omitempty"`
+
+	// SecondaryNetwork is the observed state of the network of the secondary region of the cluster.
+	// +optional
+	SecondaryNetwork *SecondaryNetworkStatus `json:"secondaryNetwork,omitempty"`
+
+	// FailureReason will be set in the event that the reconciliation of the cluster failed more times in a row than
+	// the maximum number of reconcile attempts the controller is configured with, and will contain a succinct value
+	// suitable for machine interpretation. The cluster is no longer requeued until it changes.
+	// +optional
+	FailureReason *errors.ClusterStatusError `json:"failureReason,omitempty"`
+
+	// FailureMessage will be set in the event that the reconciliation of the cluster failed more times in a row than
+	// the maximum number of reconcile attempts the controller is configured with, and will contain a more verbose
+	// string suitable for logging and human consumption.
+	// +optional
+	FailureMessage *string `json:"failureMessage,omitempty"`
+
+	// ClusterUID is the UID the Azure resources of the cluster are tagged with: the UID of the Cluster when its
+	// resources were first reconciled. It is kept when the Cluster is moved or restored along with its status, so that
+	// the resources of the cluster aren't mistaken for those of another cluster of the same name.
+	// +optional
+	ClusterUID string `json:"clusterUID,omitempty"`
 }
 
 // +kubebuilder:object:root=true
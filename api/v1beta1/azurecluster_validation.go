@@ -17,14 +17,25 @@ limitations under the License.
 package v1beta1
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"math"
 	"net"
+	"net/url"
 	"reflect"
 	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-04-01/compute"
 	valid "github.com/asaskevich/govalidator"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
 )
@@ -41,18 +52,122 @@ const (
 	// described in https://docs.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules.
 	subnetRegex       = `^[-\w\._]+$`
 	loadBalancerRegex = `^[-\w\._]+$`
+	// described in https://docs.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules.
+	applicationSecurityGroupRegex = `^[-\w\._]+$`
+	// described in https://docs.microsoft.com/en-us/azure/traffic-manager/traffic-manager-manage-profiles.
+	trafficManagerDNSPrefixRegex = `^[a-zA-Z0-9]([-a-zA-Z0-9]{0,61}[a-zA-Z0-9])?$`
+	// the backends of a cross-region load balancer are frontend IP configurations of regional load balancers.
+	frontendIPConfigIDRegex = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft.Network/loadBalancers/[^/]+/frontendIPConfigurations/[^/]+$`
+	// a DNS Private Resolver and its forwarding rulesets can be in any resource group or subscription.
+	dnsPrivateResolverIDRegex   = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft.Network/dnsResolvers/[^/]+$`
+	dnsForwardingRulesetIDRegex = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft.Network/dnsForwardingRulesets/[^/]+$`
+	// availability zones are numbered from 1 in each location.
+	availabilityZoneRegex = `^[1-9][0-9]*$`
+	// described in https://docs.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules.
+	networkWatcherRegex = `^[a-zA-Z0-9]([-\w\.]{0,78}\w)?$`
+	// described in https://docs.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules#microsoftoperationalinsights.
+	logAnalyticsWorkspaceNameRegex = `^[a-zA-Z0-9][-a-zA-Z0-9]{2,61}[a-zA-Z0-9]$`
+	logAnalyticsWorkspaceIDRegex   = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft.OperationalInsights/workspaces/[^/]+$`
+	storageAccountIDRegex          = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft.Storage/storageAccounts/[^/]+$`
+	// diagnostic settings stream to an event hub through an authorization rule of its namespace.
+	eventHubAuthorizationRuleIDRegex = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft.EventHub/namespaces/[^/]+/authorizationRules/[^/]+$`
+	// described in https://docs.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules#microsofteventhub.
+	eventHubNameRegex = `^[a-zA-Z0-9]([-\w\.]{0,254}[a-zA-Z0-9])?$`
+	// service tags name groups of IP addresses of Azure services, optionally in a region, e.g. "AzureCloud.EastUS".
+	serviceTagRegex = `^[a-zA-Z][a-zA-Z0-9]*(\.[a-zA-Z0-9]+)?$`
+	// policy definitions and initiatives are built in, or defined in a subscription or a management group.
+	policyDefinitionIDRegex = `(?i)^(/subscriptions/[^/]+|/providers/Microsoft.Management/managementGroups/[^/]+)?/providers/Microsoft.Authorization/(policyDefinitions|policySetDefinitions)/[^/]+$`
+	// private endpoints connect to a resource of any type, in a resource group.
+	privateEndpointResourceIDRegex = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/[^/]+/[^/]+/[^/]+$`
+	privateDNSZoneIDRegex          = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft.Network/privateDnsZones/[^/]+$`
+	// gallery image versions are named after their semantic version.
+	galleryIDRegex           = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft.Compute/galleries/[^/]+$`
+	galleryImageVersionRegex = `^[0-9]+\.[0-9]+\.[0-9]+$`
+	// described in https://docs.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules#microsoftconsumption.
+	budgetNameRegex    = `^[-\w]{1,63}$`
+	actionGroupIDRegex = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft.Insights/actionGroups/[^/]+$`
+	// described in https://docs.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules#microsoftauthorization.
+	policyAssignmentNameRegex = `^[^<>*%&:\\?.+/]*[^<>*%&:\\?.+/ ]$`
+	// the objects of Azure Active Directory and the role definitions are identified by a GUID.
+	guidRegex = `(?i)^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`
+	// role definitions are built in, identified by their name only, or defined in a subscription.
+	roleDefinitionIDRegex = `(?i)^((/subscriptions/[^/]+)?/providers/Microsoft.Authorization/roleDefinitions/)?[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`
+	publicIPPrefixIDRegex = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Network/publicIPPrefixes/[^/]+$`
+	// resource provider namespaces are made of dot-separated alphanumeric segments, e.g. Microsoft.Network.
+	providerNamespaceRegex = `^[a-zA-Z0-9]+(\.[a-zA-Z0-9]+)+$`
+	// subnets are delegated to a resource type of a resource provider, e.g. Microsoft.ContainerService/managedClusters.
+	serviceDelegationRegex = `^[a-zA-Z0-9]+(\.[a-zA-Z0-9]+)+/[a-zA-Z0-9]+(/[a-zA-Z0-9]+)*$`
+	// the prefix and suffix of a naming convention start and end the generated names, they can only contain the
+	// characters allowed in most network resource names.
+	namingConventionAffixRegex = `^[a-zA-Z0-9]([-\w\.]*[a-zA-Z0-9])?$`
+	// described in https://docs.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules#microsoftnetwork.
+	generatedNameRegex = `^[a-zA-Z0-9]([-\w\.]*\w)?$`
+	// maximum lengths described in https://docs.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules.
+	resourceGroupNameMaxLength = 90
+	vnetNameMaxLength          = 64
+	networkResourceMaxLength   = 80
 	// MaxLoadBalancerOutboundIPs is the maximum number of outbound IPs in a Standard LoadBalancer frontend configuration.
 	MaxLoadBalancerOutboundIPs = 16
 	// MinLBIdleTimeoutInMinutes is the minimum number of minutes for the LB idle timeout.
 	MinLBIdleTimeoutInMinutes = 4
 	// MaxLBIdleTimeoutInMinutes is the maximum number of minutes for the LB idle timeout.
 	MaxLBIdleTimeoutInMinutes = 30
+	// MaxLBConnectionDrainTimeout is the maximum time the deletion of a control plane machine waits for the
+	// connections of the API server load balancer to drain.
+	MaxLBConnectionDrainTimeout = time.Hour
+	// MaxNatGatewayOutboundIPs is the maximum number of public IP addresses of a NAT gateway.
+	MaxNatGatewayOutboundIPs = 16
+	// MinNatGatewayIdleTimeoutInMinutes is the minimum number of minutes for the NAT gateway idle timeout.
+	MinNatGatewayIdleTimeoutInMinutes = 4
+	// MaxNatGatewayIdleTimeoutInMinutes is the maximum number of minutes for the NAT gateway idle timeout.
+	MaxNatGatewayIdleTimeoutInMinutes = 120
+	// MaxSSHNATRulePort is the maximum frontend port of an SSH NAT rule.
+	MaxSSHNATRulePort = 65534
+	// MinSSHNATRulePorts is the minimum number of frontend ports of an SSH NAT rule, for a control plane machine and
+	// its replacement during a rolling update.
+	MinSSHNATRulePorts = 2
+	// MaxIngressSecurityRules is the maximum number of security rules allowing traffic into the ingress subnet, one per
+	// port and allowed source CIDR.
+	MaxIngressSecurityRules = 100
+	// MaxPodsPerNode is the maximum number of pods of a node supported by Azure CNI.
+	MaxPodsPerNode = 250
+	// azureReservedSubnetIPs is the number of IP addresses Azure reserves in each address prefix of a subnet.
+	azureReservedSubnetIPs = 5
 	// Network security rules should be a number between 100 and 4096.
 	// https://docs.microsoft.com/en-us/azure/virtual-network/network-security-groups-overview#security-rules
 	minRulePriority = 100
 	maxRulePriority = 4096
+	// egressRequiredSecurityRuleCount is the maximum number of outbound security rules generated from the
+	// DenyByDefault egress policy of a security group for the traffic the cluster requires.
+	egressRequiredSecurityRuleCount = 4
+	// the max price of Spot VMs is in US dollars with up to 5 decimal places, as described in
+	// https://docs.microsoft.com/en-us/azure/virtual-machines/spot-vms#pricing.
+	maxSpotPriceDecimalPlaces = 5
+	// the time of an auto-shutdown schedule is a time of the day in the 24-hour format.
+	autoShutdownTimeLayout = "15:04"
+	// the domain counts of an availability set are bounded as described in
+	// https://docs.microsoft.com/en-us/azure/virtual-machines/availability-set-overview.
+	maxAvailabilitySetFaultDomainCount  = 3
+	maxAvailabilitySetUpdateDomainCount = 20
 )
 
+// globalLBHomeRegions are the regions a cross-region load balancer can be deployed to, as described in
+// https://docs.microsoft.com/en-us/azure/load-balancer/cross-region-overview#home-regions.
+var globalLBHomeRegions = []string{
+	"centralus",
+	"eastasia",
+	"eastus",
+	"eastus2",
+	"japaneast",
+	"northeurope",
+	"southcentralus",
+	"southeastasia",
+	"uksouth",
+	"usgovvirginia",
+	"westeurope",
+	"westus",
+}
+
 // validateCluster validates a cluster.
 func (c *AzureCluster) validateCluster(old *AzureCluster) error {
 	var allErrs field.ErrorList
@@ -76,6 +191,38 @@ func (c *AzureCluster) validateClusterSpec(old *AzureCluster) field.ErrorList {
 	}
 	allErrs = append(allErrs, validateNetworkSpec(c.Spec.NetworkSpec, oldNetworkSpec, field.NewPath("spec").Child("networkSpec"))...)
 
+	allErrs = append(allErrs, validateBastionSpec(c.Spec.BastionSpec, field.NewPath("spec").Child("bastionSpec"))...)
+
+	allErrs = append(allErrs, validateDeleteGracePeriod(c.Spec.DeleteGracePeriod, field.NewPath("spec").Child("deleteGracePeriod"))...)
+
+	allErrs = append(allErrs, validateLogAnalyticsWorkspace(c.Spec.LogAnalyticsWorkspace, field.NewPath("spec").Child("logAnalyticsWorkspace"))...)
+
+	allErrs = append(allErrs, c.validateDiagnosticsResourceGroup(field.NewPath("spec").Child("diagnosticsResourceGroup"))...)
+
+	allErrs = append(allErrs, c.validateNamingConvention(field.NewPath("spec"))...)
+
+	allErrs = append(allErrs, validatePolicyAssignments(c.Spec.PolicyAssignments, field.NewPath("spec").Child("policyAssignments"))...)
+
+	allErrs = append(allErrs, validateRoleAssignments(c.Spec.RoleAssignments, field.NewPath("spec").Child("roleAssignments"))...)
+
+	allErrs = append(allErrs, validateBudget(c.Spec.Budget, field.NewPath("spec").Child("budget"))...)
+
+	allErrs = append(allErrs, validateGalleryImage(c.Spec.Gallery, field.NewPath("spec").Child("gallery"))...)
+
+	allErrs = append(allErrs, ValidateSpotPolicy(c.Spec.DefaultSpotPolicy, field.NewPath("spec").Child("defaultSpotPolicy"))...)
+
+	allErrs = append(allErrs, ValidateAutoShutdownSchedule(c.Spec.AutoShutdown, field.NewPath("spec").Child("autoShutdown"))...)
+
+	allErrs = append(allErrs, validateAvailabilitySet(c.Spec.ControlPlaneAvailabilitySet, field.NewPath("spec").Child("controlPlaneAvailabilitySet"))...)
+
+	allErrs = append(allErrs, validateEtcdDisks(c.Spec.ControlPlaneEtcdDisks, field.NewPath("spec").Child("controlPlaneEtcdDisks"))...)
+
+	allErrs = append(allErrs, validateRequiredFeatures(c.Spec.RequiredFeatures, field.NewPath("spec").Child("requiredFeatures"))...)
+
+	allErrs = append(allErrs, c.validateSecondaryRegion(field.NewPath("spec").Child("secondaryRegion"))...)
+
+	allErrs = append(allErrs, c.validateReconcileMode(field.NewPath("spec"))...)
+
 	var oldCloudProviderConfigOverrides *CloudProviderConfigOverrides
 	if old != nil {
 		oldCloudProviderConfigOverrides = old.Spec.CloudProviderConfigOverrides
@@ -86,406 +233,2320 @@ func (c *AzureCluster) validateClusterSpec(old *AzureCluster) field.ErrorList {
 	return allErrs
 }
 
-// validateClusterName validates ClusterName.
-func (c *AzureCluster) validateClusterName() field.ErrorList {
+// validateReconcileMode validates that the spec of a cluster in NetworkOnly mode doesn't include resources other than
+// networking ones, as they would not be reconciled.
+func (c *AzureCluster) validateReconcileMode(fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
-	if len(c.Name) > clusterNameMaxLength {
-		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata").Child("Name"), c.Name,
-			fmt.Sprintf("Cluster Name longer than allowed length of %d characters", clusterNameMaxLength)))
+
+	if c.Spec.ReconcileMode != ReconcileModeNetworkOnly {
+		return allErrs
 	}
-	if success, _ := regexp.MatchString(clusterNameRegex, c.Name); !success {
-		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata").Child("Name"), c.Name,
-			fmt.Sprintf("Cluster Name doesn't match regex %s, can contain only lowercase alphanumeric characters and '-', must start/end with an alphanumeric character",
-				clusterNameRegex)))
+
+	if c.Spec.BastionSpec.Jumpbox != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("bastionSpec", "jumpbox"), "a jumpbox is not reconciled in NetworkOnly mode"))
 	}
-	if len(allErrs) == 0 {
-		return nil
+
+	if c.Spec.LogAnalyticsWorkspace != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("logAnalyticsWorkspace"), "a Log Analytics workspace is not reconciled in NetworkOnly mode"))
 	}
-	return allErrs
-}
 
-// validateNetworkSpec validates a NetworkSpec.
-func validateNetworkSpec(networkSpec NetworkSpec, old NetworkSpec, fldPath *field.Path) field.ErrorList {
-	var allErrs field.ErrorList
-	// If the user specifies a resourceGroup for vnet, it means
-	// that she intends to use a pre-existing vnet. In this case,
-	// we need to verify the information she provides
-	if networkSpec.Vnet.ResourceGroup != "" {
-		if err := validateResourceGroup(networkSpec.Vnet.ResourceGroup,
-			fldPath.Child("vnet").Child("resourceGroup")); err != nil {
-			allErrs = append(allErrs, err)
-		}
+	if c.Spec.DiagnosticsResourceGroup != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("diagnosticsResourceGroup"), "a diagnostics resource group is not reconciled in NetworkOnly mode"))
+	}
 
-		allErrs = append(allErrs, validateVnetCIDR(networkSpec.Vnet.CIDRBlocks, fldPath.Child("cidrBlocks"))...)
+	if c.Spec.InheritResourceGroupTags {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("inheritResourceGroupTags"), "the tags of the resource group are not read in NetworkOnly mode"))
+	}
 
-		allErrs = append(allErrs, validateSubnets(networkSpec.Subnets, networkSpec.Vnet, fldPath.Child("subnets"))...)
+	if len(c.Spec.PolicyAssignments) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("policyAssignments"), "the policy assignments of the resource group are not reconciled in NetworkOnly mode"))
+	}
 
-		allErrs = append(allErrs, validateVnetPeerings(networkSpec.Vnet.Peerings, fldPath.Child("peerings"))...)
+	if len(c.Spec.RoleAssignments) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("roleAssignments"), "the role assignments of the resource group are not reconciled in NetworkOnly mode"))
 	}
 
-	var cidrBlocks []string
-	controlPlaneSubnet, err := networkSpec.GetControlPlaneSubnet()
-	if err != nil {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("subnets"), networkSpec.Subnets, "ControlPlaneSubnet invalid"))
+	if c.Spec.Budget != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("budget"), "the budget of the resource group is not reconciled in NetworkOnly mode"))
 	}
 
-	cidrBlocks = controlPlaneSubnet.CIDRBlocks
+	if c.Spec.Gallery != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("gallery"), "the gallery image is not resolved in NetworkOnly mode"))
+	}
 
-	allErrs = append(allErrs, validateAPIServerLB(networkSpec.APIServerLB, old.APIServerLB, cidrBlocks, fldPath.Child("apiServerLB"))...)
+	if c.Spec.ControlPlaneAvailabilitySet != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("controlPlaneAvailabilitySet"), "the availability set of the control plane is not reconciled in NetworkOnly mode"))
+	}
 
-	var oneSubnetWithoutNatGateway bool
-	for _, subnet := range networkSpec.Subnets {
-		if subnet.Role == SubnetNode && !subnet.IsNatGatewayEnabled() {
-			oneSubnetWithoutNatGateway = true
-			break
+	if c.Spec.ControlPlaneEtcdDisks != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("controlPlaneEtcdDisks"), "the etcd disks of the control plane are not checked in NetworkOnly mode"))
+	}
+
+	return allErrs
+}
+
+// validateNamingConvention validates the naming convention of the cluster and the names of its network resources, so
+// that a generated name that Azure would reject is reported before any resource is created.
+func (c *AzureCluster) validateNamingConvention(fldPath *field.Path) field.ErrorList {
+	convention := c.Spec.NamingConvention
+	if convention == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	if convention.Prefix != "" {
+		if success, _ := regexp.MatchString(namingConventionAffixRegex, convention.Prefix); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namingConvention", "prefix"), convention.Prefix,
+				fmt.Sprintf("prefix of naming convention doesn't match regex %s", namingConventionAffixRegex)))
 		}
 	}
-	if oneSubnetWithoutNatGateway {
-		allErrs = append(allErrs, validateNodeOutboundLB(networkSpec.NodeOutboundLB, old.NodeOutboundLB, networkSpec.APIServerLB, fldPath.Child("nodeOutboundLB"))...)
+	if convention.Suffix != "" {
+		if success, _ := regexp.MatchString(namingConventionAffixRegex, convention.Suffix); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namingConvention", "suffix"), convention.Suffix,
+				fmt.Sprintf("suffix of naming convention doesn't match regex %s", namingConventionAffixRegex)))
+		}
+	}
+	if len(allErrs) > 0 {
+		return allErrs
 	}
 
-	allErrs = append(allErrs, validateControlPlaneOutboundLB(networkSpec.ControlPlaneOutboundLB, networkSpec.APIServerLB, fldPath.Child("controlPlaneOutboundLB"))...)
+	if len(c.Spec.ResourceGroup) > resourceGroupNameMaxLength {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("resourceGroup"), c.Spec.ResourceGroup,
+			fmt.Sprintf("resourceGroup should not be longer than %d characters", resourceGroupNameMaxLength)))
+	}
 
-	allErrs = append(allErrs, validatePrivateDNSZoneName(networkSpec, fldPath)...)
+	networkPath := fldPath.Child("networkSpec")
+	allErrs = append(allErrs, validateGeneratedName(c.Spec.NetworkSpec.Vnet.Name, vnetNameMaxLength, networkPath.Child("vnet", "name"))...)
+	for i, subnet := range c.Spec.NetworkSpec.Subnets {
+		subnetPath := networkPath.Child("subnets").Index(i)
+		allErrs = append(allErrs, validateGeneratedName(subnet.Name, networkResourceMaxLength, subnetPath.Child("name"))...)
+		allErrs = append(allErrs, validateGeneratedName(subnet.SecurityGroup.Name, networkResourceMaxLength, subnetPath.Child("securityGroup", "name"))...)
+		allErrs = append(allErrs, validateGeneratedName(subnet.RouteTable.Name, networkResourceMaxLength, subnetPath.Child("routeTable", "name"))...)
+		allErrs = append(allErrs, validateGeneratedName(subnet.NatGateway.NatGatewayIP.Name, networkResourceMaxLength, subnetPath.Child("natGateway", "ip", "name"))...)
+	}
 
-	if len(allErrs) == 0 {
+	lbs := map[string]*LoadBalancerSpec{
+		"apiServerLB":            &c.Spec.NetworkSpec.APIServerLB,
+		"nodeOutboundLB":         c.Spec.NetworkSpec.NodeOutboundLB,
+		"controlPlaneOutboundLB": c.Spec.NetworkSpec.ControlPlaneOutboundLB,
+	}
+	for _, lbField := range []string{"apiServerLB", "nodeOutboundLB", "controlPlaneOutboundLB"} {
+		lb := lbs[lbField]
+		if lb == nil {
+			continue
+		}
+		lbPath := networkPath.Child(lbField)
+		allErrs = append(allErrs, validateGeneratedName(lb.Name, networkResourceMaxLength, lbPath.Child("name"))...)
+		for i, frontendIP := range lb.FrontendIPs {
+			if frontendIP.PublicIP != nil {
+				allErrs = append(allErrs, validateGeneratedName(frontendIP.PublicIP.Name, networkResourceMaxLength, lbPath.Child("frontendIPs").Index(i).Child("publicIP", "name"))...)
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateGeneratedName validates the name of a network resource against the length and characters accepted by Azure.
+// Empty names are left to the resource specific validations.
+func validateGeneratedName(name string, maxLength int, fldPath *field.Path) field.ErrorList {
+	if name == "" {
 		return nil
 	}
+	var allErrs field.ErrorList
+	if len(name) > maxLength {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, fmt.Sprintf("name should not be longer than %d characters", maxLength)))
+	}
+	if success, _ := regexp.MatchString(generatedNameRegex, name); !success {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, fmt.Sprintf("name doesn't match regex %s", generatedNameRegex)))
+	}
 	return allErrs
 }
 
-// validateResourceGroup validates a ResourceGroup.
-func validateResourceGroup(resourceGroup string, fldPath *field.Path) *field.Error {
-	if success, _ := regexp.MatchString(resourceGroupRegex, resourceGroup); !success {
-		return field.Invalid(fldPath, resourceGroup,
-			fmt.Sprintf("resourceGroup doesn't match regex %s", resourceGroupRegex))
+// validateLogAnalyticsWorkspace validates the Log Analytics workspace of the cluster.
+func validateLogAnalyticsWorkspace(workspace *LogAnalyticsWorkspace, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if workspace == nil {
+		return allErrs
 	}
-	return nil
+	if workspace.ID != "" {
+		if success, _ := regexp.MatchString(logAnalyticsWorkspaceIDRegex, workspace.ID); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("id"), workspace.ID, "must be the resource ID of a Log Analytics workspace"))
+		}
+		return allErrs
+	}
+	if success, _ := regexp.MatchString(logAnalyticsWorkspaceNameRegex, workspace.Name); !success {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), workspace.Name,
+			fmt.Sprintf("name of Log Analytics workspace doesn't match regex %s", logAnalyticsWorkspaceNameRegex)))
+	}
+	return allErrs
 }
 
-// validateSubnets validates a list of Subnets.
-func validateSubnets(subnets Subnets, vnet VnetSpec, fldPath *field.Path) field.ErrorList {
+// validateDiagnosticsResourceGroup validates the diagnostics resource group of the cluster, which must be another
+// resource group than the one of the cluster: it is deleted separately.
+func (c *AzureCluster) validateDiagnosticsResourceGroup(fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
-	subnetNames := make(map[string]bool, len(subnets))
-	requiredSubnetRoles := map[string]bool{
-		"control-plane": false,
-		"node":          false,
+	group := c.Spec.DiagnosticsResourceGroup
+	if group == nil {
+		return allErrs
+	}
+	if err := validateResourceGroup(group.Name, fldPath.Child("name")); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if len(group.Name) > resourceGroupNameMaxLength {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), group.Name,
+			fmt.Sprintf("name should not be longer than %d characters", resourceGroupNameMaxLength)))
 	}
+	if strings.EqualFold(group.Name, c.Spec.ResourceGroup) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), group.Name, "must differ from the resource group of the cluster"))
+	}
+	return allErrs
+}
 
-	for i, subnet := range subnets {
-		if err := validateSubnetName(subnet.Name, fldPath.Index(i).Child("name")); err != nil {
-			allErrs = append(allErrs, err)
+// validatePolicyAssignments validates the policy assignments of the resource group of the cluster.
+func validatePolicyAssignments(assignments []PolicyAssignment, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	names := sets.NewString()
+	for i, assignment := range assignments {
+		assignmentPath := fldPath.Index(i)
+		if names.Has(assignment.Name) {
+			allErrs = append(allErrs, field.Duplicate(assignmentPath.Child("name"), assignment.Name))
 		}
-		if _, ok := subnetNames[subnet.Name]; ok {
-			allErrs = append(allErrs, field.Duplicate(fldPath, subnet.Name))
+		names.Insert(assignment.Name)
+		if success, _ := regexp.MatchString(policyAssignmentNameRegex, assignment.Name); !success {
+			allErrs = append(allErrs, field.Invalid(assignmentPath.Child("name"), assignment.Name,
+				fmt.Sprintf("name of policy assignment doesn't match regex %s", policyAssignmentNameRegex)))
 		}
-		subnetNames[subnet.Name] = true
-		for role := range requiredSubnetRoles {
-			if role == string(subnet.Role) {
-				requiredSubnetRoles[role] = true
-			}
+		if success, _ := regexp.MatchString(policyDefinitionIDRegex, assignment.PolicyDefinitionID); !success {
+			allErrs = append(allErrs, field.Invalid(assignmentPath.Child("policyDefinitionID"), assignment.PolicyDefinitionID,
+				"must be the resource ID of a policy definition or initiative"))
 		}
-		for _, rule := range subnet.SecurityGroup.SecurityRules {
-			if err := validateSecurityRule(
-				rule,
-				fldPath.Index(i).Child("securityGroup").Child("securityRules").Index(i),
-			); err != nil {
-				allErrs = append(allErrs, err)
+		for name, value := range assignment.Parameters {
+			if !json.Valid([]byte(value)) {
+				allErrs = append(allErrs, field.Invalid(assignmentPath.Child("parameters").Key(name), value, "must be a JSON-encoded value"))
 			}
 		}
-		allErrs = append(allErrs, validateSubnetCIDR(subnet.CIDRBlocks, vnet.CIDRBlocks, fldPath.Index(i).Child("cidrBlocks"))...)
-	}
-	for k, v := range requiredSubnetRoles {
-		if !v {
-			allErrs = append(allErrs, field.Required(fldPath,
-				fmt.Sprintf("required role %s not included in provided subnets", k)))
-		}
 	}
 	return allErrs
 }
 
-// validateSubnetName validates the Name of a Subnet.
-func validateSubnetName(name string, fldPath *field.Path) *field.Error {
-	if success, _ := regexp.Match(subnetRegex, []byte(name)); !success {
-		return field.Invalid(fldPath, name,
-			fmt.Sprintf("name of subnet doesn't match regex %s", subnetRegex))
-	}
-	return nil
-}
-
-// validateSubnetCIDR validates the CIDR blocks of a Subnet.
-func validateSubnetCIDR(subnetCidrBlocks []string, vnetCidrBlocks []string, fldPath *field.Path) field.ErrorList {
+// validateBudget validates the budget of the resource group of the cluster. Azure requires a contact email or action
+// group for each notification of a budget scoped to a resource group.
+func validateBudget(budget *BudgetSpec, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
-	var vnetNws []*net.IPNet
-
-	for _, vnetCidr := range vnetCidrBlocks {
-		if _, vnetNw, err := net.ParseCIDR(vnetCidr); err == nil {
-			vnetNws = append(vnetNws, vnetNw)
+	if budget == nil {
+		return allErrs
+	}
+	if budget.Name != "" {
+		if success, _ := regexp.MatchString(budgetNameRegex, budget.Name); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), budget.Name, fmt.Sprintf("name of budget doesn't match regex %s", budgetNameRegex)))
 		}
 	}
-
-	for _, subnetCidr := range subnetCidrBlocks {
-		subnetCidrIP, _, err := net.ParseCIDR(subnetCidr)
-		if err != nil {
-			allErrs = append(allErrs, field.Invalid(fldPath, subnetCidr, "invalid CIDR format"))
+	if budget.Amount < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("amount"), budget.Amount, "must be greater than 0"))
+	}
+	if len(budget.Notifications) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("notifications"), "at least one notification is required"))
+	}
+	thresholds := sets.NewInt32()
+	for i, notification := range budget.Notifications {
+		notificationPath := fldPath.Child("notifications").Index(i)
+		if notification.Threshold < 1 || notification.Threshold > 1000 {
+			allErrs = append(allErrs, field.Invalid(notificationPath.Child("threshold"), notification.Threshold, "must be between 1 and 1000"))
 		}
-
-		var found bool
-		for _, vnetNw := range vnetNws {
-			if vnetNw.Contains(subnetCidrIP) {
-				found = true
-				break
+		if thresholds.Has(notification.Threshold) {
+			allErrs = append(allErrs, field.Duplicate(notificationPath.Child("threshold"), notification.Threshold))
+		}
+		thresholds.Insert(notification.Threshold)
+		if len(notification.ContactEmails) == 0 && len(notification.ContactGroups) == 0 {
+			allErrs = append(allErrs, field.Required(notificationPath, "a contact email or action group is required"))
+		}
+		for j, email := range notification.ContactEmails {
+			if !valid.IsEmail(email) {
+				allErrs = append(allErrs, field.Invalid(notificationPath.Child("contactEmails").Index(j), email, "must be an email address"))
 			}
 		}
-
-		if !found {
-			allErrs = append(allErrs, field.Invalid(fldPath, subnetCidr, fmt.Sprintf("subnet CIDR not in vnet address space: %s", vnetCidrBlocks)))
+		for j, group := range notification.ContactGroups {
+			if success, _ := regexp.MatchString(actionGroupIDRegex, group); !success {
+				allErrs = append(allErrs, field.Invalid(notificationPath.Child("contactGroups").Index(j), group, "must be the resource ID of an action group"))
+			}
 		}
 	}
-
 	return allErrs
 }
 
-// validateVnetCIDR validates the CIDR blocks of a Vnet.
-func validateVnetCIDR(vnetCIDRBlocks []string, fldPath *field.Path) field.ErrorList {
+// validateGalleryImage validates the reference to the gallery image version of the cluster.
+func validateGalleryImage(image *GalleryImage, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
-	for _, vnetCidr := range vnetCIDRBlocks {
-		if _, _, err := net.ParseCIDR(vnetCidr); err != nil {
-			allErrs = append(allErrs, field.Invalid(fldPath, vnetCidr, "invalid CIDR format"))
+	if image == nil {
+		return allErrs
+	}
+	if success, _ := regexp.MatchString(galleryIDRegex, image.ID); !success {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("id"), image.ID, "must be the resource ID of an Azure Compute Gallery"))
+	}
+	if image.ImageDefinition == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("imageDefinition"), "the name of the image definition is required"))
+	}
+	if image.Version != "" && image.Version != LatestGalleryImageVersion {
+		if success, _ := regexp.MatchString(galleryImageVersionRegex, image.Version); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("version"), image.Version, "must be in the Major.Minor.Patch format or latest"))
 		}
 	}
 	return allErrs
 }
 
-// validateVnetPeerings validates a list of virtual network peerings.
-func validateVnetPeerings(peerings VnetPeerings, fldPath *field.Path) field.ErrorList {
+// validateAvailabilitySet validates the domain counts of the availability set of the control plane against the limits
+// of Azure. The fault domain count is checked against the maximum of the location of the cluster once reconciled.
+func validateAvailabilitySet(availabilitySet *AvailabilitySet, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
-	vnetIdentifiers := make(map[string]bool, len(peerings))
-
-	for _, peering := range peerings {
-		vnetIdentifier := peering.ResourceGroup + "/" + peering.RemoteVnetName
-		if _, ok := vnetIdentifiers[vnetIdentifier]; ok {
-			allErrs = append(allErrs, field.Duplicate(fldPath, vnetIdentifier))
-		}
-		vnetIdentifiers[vnetIdentifier] = true
+	if availabilitySet == nil {
+		return allErrs
+	}
+	if count := availabilitySet.FaultDomainCount; count != nil && (*count < 1 || *count > maxAvailabilitySetFaultDomainCount) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("faultDomainCount"), *count,
+			fmt.Sprintf("must be between 1 and %d", maxAvailabilitySetFaultDomainCount)))
+	}
+	if count := availabilitySet.UpdateDomainCount; count != nil && (*count < 1 || *count > maxAvailabilitySetUpdateDomainCount) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("updateDomainCount"), *count,
+			fmt.Sprintf("must be between 1 and %d", maxAvailabilitySetUpdateDomainCount)))
 	}
 	return allErrs
 }
 
-// validateLoadBalancerName validates the Name of a Load Balancer.
-func validateLoadBalancerName(name string, fldPath *field.Path) *field.Error {
-	if success, _ := regexp.Match(loadBalancerRegex, []byte(name)); !success {
-		return field.Invalid(fldPath, name,
-			fmt.Sprintf("name of load balancer doesn't match regex %s", loadBalancerRegex))
+// validateEtcdDisks validates the requirements of the etcd data disks of the control plane machines.
+func validateEtcdDisks(etcdDisks *EtcdDisks, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if etcdDisks == nil {
+		return allErrs
 	}
-	return nil
+	if etcdDisks.StorageAccountType != string(compute.StorageAccountTypesUltraSSDLRS) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("storageAccountType"), etcdDisks.StorageAccountType,
+			[]string{string(compute.StorageAccountTypesUltraSSDLRS)}))
+	}
+	if etcdDisks.VMSize == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("vmSize"), "the VM size of the control plane machines is required to check the etcd disks"))
+	}
+	return allErrs
 }
 
-// validateInternalLBIPAddress validates a InternalLBIPAddress.
-func validateInternalLBIPAddress(address string, cidrs []string, fldPath *field.Path) *field.Error {
-	ip := net.ParseIP(address)
-	if ip == nil {
-		return field.Invalid(fldPath, address,
-			"Internal LB IP address isn't a valid IPv4 or IPv6 address")
-	}
-	for _, cidr := range cidrs {
-		_, subnet, _ := net.ParseCIDR(cidr)
-		if subnet.Contains(ip) {
-			return nil
+// validateRequiredFeatures validates the features of the resource providers the cluster relies on.
+func validateRequiredFeatures(features []ProviderFeature, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	seen := sets.NewString()
+	for i, feature := range features {
+		featurePath := fldPath.Index(i)
+		if success, _ := regexp.MatchString(providerNamespaceRegex, feature.Namespace); !success {
+			allErrs = append(allErrs, field.Invalid(featurePath.Child("namespace"), feature.Namespace,
+				"must be the namespace of a resource provider, e.g. Microsoft.Network"))
+		}
+		if feature.Name == "" || strings.ContainsAny(feature.Name, "/ ") {
+			allErrs = append(allErrs, field.Invalid(featurePath.Child("name"), feature.Name, "must be the name of a feature"))
 		}
+		key := strings.ToLower(feature.Namespace + "/" + feature.Name)
+		if seen.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(featurePath, feature.Namespace+"/"+feature.Name))
+		}
+		seen.Insert(key)
 	}
-	return field.Invalid(fldPath, address,
-		fmt.Sprintf("Internal LB IP address needs to be in control plane subnet range (%s)", cidrs))
+	return allErrs
 }
 
-// validateSecurityRule validates a SecurityRule.
-func validateSecurityRule(rule SecurityRule, fldPath *field.Path) *field.Error {
-	if rule.Priority < minRulePriority || rule.Priority > maxRulePriority {
-		return field.Invalid(fldPath, rule.Priority, fmt.Sprintf("security rule priorities should be between %d and %d", minRulePriority, maxRulePriority))
+// validateSecondaryRegion validates the network of the secondary region of the cluster, which must not overlap with the
+// network of the cluster in Azure.
+func (c *AzureCluster) validateSecondaryRegion(fldPath *field.Path) field.ErrorList {
+	secondary := c.Spec.SecondaryRegion
+	if secondary == nil {
+		return nil
 	}
 
-	return nil
-}
-
-func validateAPIServerLB(lb LoadBalancerSpec, old LoadBalancerSpec, cidrs []string, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
-	// SKU should be Standard and is immutable.
-	if lb.SKU != SKUStandard {
-		allErrs = append(allErrs, field.NotSupported(fldPath.Child("sku"), lb.SKU, []string{string(SKUStandard)}))
+	if secondary.Location != "" && strings.EqualFold(secondary.Location, c.Spec.Location) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("location"), secondary.Location, "must differ from the location of the cluster"))
 	}
-	if old.SKU != "" && old.SKU != lb.SKU {
-		allErrs = append(allErrs, field.Forbidden(fldPath.Child("sku"), "API Server load balancer SKU should not be modified after AzureCluster creation."))
+
+	vnetPath := fldPath.Child("vnet")
+	primaryVnet := c.Spec.NetworkSpec.Vnet
+	if strings.EqualFold(secondary.Vnet.Name, primaryVnet.Name) {
+		allErrs = append(allErrs, field.Invalid(vnetPath.Child("name"), secondary.Vnet.Name, "must differ from the name of the virtual network of the cluster"))
+	}
+	if len(secondary.Vnet.Peerings) > 0 {
+		allErrs = append(allErrs, field.Forbidden(vnetPath.Child("peerings"), "the virtual network of the secondary region can't be peered"))
 	}
+	allErrs = append(allErrs, validateVnetCIDR(secondary.Vnet.CIDRBlocks, vnetPath.Child("cidrBlocks"))...)
 
-	// Type should be Public or Internal.
-	if lb.Type != Internal && lb.Type != Public {
-		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), lb.Type,
-			[]string{string(Public), string(Internal)}))
+	subnetsPath := fldPath.Child("subnets")
+	if len(secondary.Subnets) == 0 {
+		allErrs = append(allErrs, field.Required(subnetsPath, "the secondary region must have at least one subnet"))
+	}
+	// The subnets of the secondary region are tracked by name in the status of the cluster, along the subnets of the
+	// cluster.
+	names := sets.NewString()
+	for _, subnet := range c.Spec.NetworkSpec.Subnets {
+		names.Insert(subnet.Name)
+	}
+	for i, subnet := range secondary.Subnets {
+		subnetPath := subnetsPath.Index(i)
+		if subnet.Role != SubnetControlPlane && subnet.Role != SubnetNode {
+			allErrs = append(allErrs, field.NotSupported(subnetPath.Child("role"), subnet.Role, []string{string(SubnetControlPlane), string(SubnetNode)}))
+		}
+		if err := validateSubnetName(subnet.Name, subnetPath.Child("name")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		if names.Has(subnet.Name) {
+			allErrs = append(allErrs, field.Duplicate(subnetPath.Child("name"), subnet.Name))
+		}
+		names.Insert(subnet.Name)
+		allErrs = append(allErrs, validateSubnetCIDR(subnet.CIDRBlocks, secondary.Vnet.CIDRBlocks, subnetPath.Child("cidrBlocks"))...)
+	}
+
+	return allErrs
+}
+
+// validateRoleAssignments validates the role assignments of the resource group of the cluster.
+func validateRoleAssignments(assignments []RoleAssignment, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	names := sets.NewString()
+	for i, assignment := range assignments {
+		assignmentPath := fldPath.Index(i)
+		if names.Has(assignment.Name) {
+			allErrs = append(allErrs, field.Duplicate(assignmentPath.Child("name"), assignment.Name))
+		}
+		names.Insert(assignment.Name)
+		if success, _ := regexp.MatchString(guidRegex, assignment.PrincipalID); !success {
+			allErrs = append(allErrs, field.Invalid(assignmentPath.Child("principalID"), assignment.PrincipalID,
+				"must be the object ID of a principal"))
+		}
+		if success, _ := regexp.MatchString(roleDefinitionIDRegex, assignment.RoleDefinitionID); !success {
+			allErrs = append(allErrs, field.Invalid(assignmentPath.Child("roleDefinitionID"), assignment.RoleDefinitionID,
+				"must be the name or the resource ID of a role definition"))
+		}
+	}
+	return allErrs
+}
+
+// validateDiagnosticSettings validates the diagnostic settings of a resource.
+func validateDiagnosticSettings(settings *DiagnosticSettings, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if settings == nil {
+		return allErrs
+	}
+	if settings.WorkspaceID == "" && settings.StorageAccountID == "" && settings.EventHubAuthorizationRuleID == "" {
+		allErrs = append(allErrs, field.Required(fldPath, "one of workspaceID, storageAccountID or eventHubAuthorizationRuleID must be set"))
+	}
+	if settings.WorkspaceID != "" {
+		if success, _ := regexp.MatchString(logAnalyticsWorkspaceIDRegex, settings.WorkspaceID); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("workspaceID"), settings.WorkspaceID, "must be the resource ID of a Log Analytics workspace"))
+		}
+	}
+	if settings.StorageAccountID != "" {
+		if success, _ := regexp.MatchString(storageAccountIDRegex, settings.StorageAccountID); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("storageAccountID"), settings.StorageAccountID, "must be the resource ID of a storage account"))
+		}
+	}
+	if settings.EventHubAuthorizationRuleID != "" {
+		if success, _ := regexp.MatchString(eventHubAuthorizationRuleIDRegex, settings.EventHubAuthorizationRuleID); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("eventHubAuthorizationRuleID"), settings.EventHubAuthorizationRuleID,
+				"must be the resource ID of an authorization rule of an Event Hub namespace"))
+		}
+	}
+	if settings.EventHubName != "" {
+		if settings.EventHubAuthorizationRuleID == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("eventHubAuthorizationRuleID"), "must be set when eventHubName is set"))
+		}
+		if success, _ := regexp.MatchString(eventHubNameRegex, settings.EventHubName); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("eventHubName"), settings.EventHubName, "must be a valid event hub name"))
+		}
+	}
+	if len(settings.LogCategories) == 0 && len(settings.MetricCategories) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one log or metric category must be enabled"))
+	}
+	return allErrs
+}
+
+// validateDeleteGracePeriod validates the delete grace period of the cluster.
+func validateDeleteGracePeriod(gracePeriod *metav1.Duration, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if gracePeriod != nil && gracePeriod.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, gracePeriod.Duration.String(), "delete grace period must not be negative"))
+	}
+	return allErrs
+}
+
+// ValidateSpotPolicy validates the default Spot VM policy of a cluster.
+func ValidateSpotPolicy(policy *SpotPolicy, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if policy == nil {
+		return allErrs
+	}
+	if policy.MaxPrice != nil {
+		maxPrice := policy.MaxPrice.AsDec()
+		switch {
+		case policy.MaxPrice.Cmp(resource.MustParse("-1")) == 0:
+		case policy.MaxPrice.Sign() <= 0:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxPrice"), policy.MaxPrice.String(), "max price must be -1 or greater than 0"))
+		case maxPrice.Scale() > maxSpotPriceDecimalPlaces:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxPrice"), policy.MaxPrice.String(),
+				fmt.Sprintf("max price must not have more than %d decimal places", maxSpotPriceDecimalPlaces)))
+		}
+	}
+	switch policy.EvictionPolicy {
+	case "", SpotEvictionPolicyDeallocate, SpotEvictionPolicyDelete:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("evictionPolicy"), policy.EvictionPolicy,
+			[]string{string(SpotEvictionPolicyDeallocate), string(SpotEvictionPolicyDelete)}))
+	}
+	return allErrs
+}
+
+// ValidateAutoShutdownSchedule validates the auto-shutdown schedule of a cluster.
+func ValidateAutoShutdownSchedule(schedule *AutoShutdownSchedule, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if schedule == nil {
+		return allErrs
+	}
+	if _, err := time.Parse(autoShutdownTimeLayout, schedule.Time); err != nil || len(schedule.Time) != len(autoShutdownTimeLayout) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("time"), schedule.Time, "time must be in the 24-hour HH:MM format"))
+	}
+	if schedule.TimeZone != "" {
+		if _, err := time.LoadLocation(schedule.TimeZone); err != nil || schedule.TimeZone == "Local" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("timeZone"), schedule.TimeZone, "time zone must be an IANA time zone, e.g. Europe/Paris"))
+		}
+	}
+	if notification := schedule.Notification; notification != nil {
+		notificationPath := fldPath.Child("notification")
+		if notification.MinutesBefore != nil && (*notification.MinutesBefore < 5 || *notification.MinutesBefore > 120) {
+			allErrs = append(allErrs, field.Invalid(notificationPath.Child("minutesBefore"), *notification.MinutesBefore, "must be between 5 and 120"))
+		}
+		if notification.Email == "" && notification.WebhookURL == "" {
+			allErrs = append(allErrs, field.Required(notificationPath, "an email or a webhook URL is required"))
+		}
+		if notification.Email != "" {
+			if !valid.IsEmail(notification.Email) {
+				allErrs = append(allErrs, field.Invalid(notificationPath.Child("email"), notification.Email, "must be an email address"))
+			}
+		}
+		if notification.WebhookURL != "" {
+			if u, err := url.Parse(notification.WebhookURL); err != nil || u.Scheme != "https" || u.Host == "" {
+				allErrs = append(allErrs, field.Invalid(notificationPath.Child("webhookURL"), notification.WebhookURL, "must be an HTTPS URL"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateClusterName validates ClusterName.
+func (c *AzureCluster) validateClusterName() field.ErrorList {
+	var allErrs field.ErrorList
+	if len(c.Name) > clusterNameMaxLength {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata").Child("Name"), c.Name,
+			fmt.Sprintf("Cluster Name longer than allowed length of %d characters", clusterNameMaxLength)))
+	}
+	if success, _ := regexp.MatchString(clusterNameRegex, c.Name); !success {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata").Child("Name"), c.Name,
+			fmt.Sprintf("Cluster Name doesn't match regex %s, can contain only lowercase alphanumeric characters and '-', must start/end with an alphanumeric character",
+				clusterNameRegex)))
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs
+}
+
+// validateNetworkSpec validates a NetworkSpec.
+func validateNetworkSpec(networkSpec NetworkSpec, old NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	// If the user specifies a resourceGroup for vnet, it means
+	// that she intends to use a pre-existing vnet. In this case,
+	// we need to verify the information she provides
+	if networkSpec.Vnet.ResourceGroup != "" {
+		if err := validateResourceGroup(networkSpec.Vnet.ResourceGroup,
+			fldPath.Child("vnet").Child("resourceGroup")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+
+		allErrs = append(allErrs, validateVnetCIDR(networkSpec.Vnet.CIDRBlocks, fldPath.Child("cidrBlocks"))...)
+
+		allErrs = append(allErrs, validateSubnets(networkSpec.Subnets, networkSpec.Vnet, fldPath.Child("subnets"))...)
+
+		allErrs = append(allErrs, validateVnetPeerings(networkSpec.Vnet.Peerings, fldPath.Child("peerings"))...)
+	}
+
+	allErrs = append(allErrs, validateVnetDNSServers(networkSpec.Vnet.DNSServers, fldPath.Child("vnet").Child("dnsServers"))...)
+
+	allErrs = append(allErrs, validateNatGateways(networkSpec.Subnets, fldPath.Child("subnets"))...)
+
+	allErrs = append(allErrs, validateFirewallRoutes(networkSpec.Subnets, fldPath.Child("subnets"))...)
+
+	var cidrBlocks []string
+	controlPlaneSubnet, err := networkSpec.GetControlPlaneSubnet()
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("subnets"), networkSpec.Subnets, "ControlPlaneSubnet invalid"))
+	}
+
+	cidrBlocks = controlPlaneSubnet.CIDRBlocks
+
+	allErrs = append(allErrs, validateAPIServerLB(networkSpec.APIServerLB, old.APIServerLB, cidrBlocks, fldPath.Child("apiServerLB"))...)
+
+	var oneSubnetWithoutNatGateway bool
+	for _, subnet := range networkSpec.Subnets {
+		if subnet.Role == SubnetNode && !subnet.IsNatGatewayEnabled() {
+			oneSubnetWithoutNatGateway = true
+			break
+		}
+	}
+	if oneSubnetWithoutNatGateway {
+		allErrs = append(allErrs, validateNodeOutboundLB(networkSpec.NodeOutboundLB, old.NodeOutboundLB, networkSpec.APIServerLB, fldPath.Child("nodeOutboundLB"))...)
+	}
+
+	allErrs = append(allErrs, validateControlPlaneOutboundLB(networkSpec.ControlPlaneOutboundLB, networkSpec.APIServerLB, fldPath.Child("controlPlaneOutboundLB"))...)
+
+	allErrs = append(allErrs, validateControlPlaneNatGateway(networkSpec, fldPath)...)
+
+	allErrs = append(allErrs, validatePrivateDNSZoneName(networkSpec, fldPath)...)
+
+	allErrs = append(allErrs, validateTrafficManager(networkSpec.TrafficManager, old.TrafficManager, networkSpec.APIServerLB, fldPath.Child("trafficManager"))...)
+
+	allErrs = append(allErrs, validateGlobalLB(networkSpec.GlobalLB, old.GlobalLB, networkSpec, fldPath.Child("globalLB"))...)
+
+	allErrs = append(allErrs, validateDNSPrivateResolver(networkSpec.DNSPrivateResolver, fldPath.Child("dnsPrivateResolver"))...)
+
+	allErrs = append(allErrs, validateApplicationSecurityGroups(networkSpec.ApplicationSecurityGroups, networkSpec.Subnets, fldPath)...)
+
+	allErrs = append(allErrs, validateOutboundConnectivityCheck(networkSpec.OutboundConnectivityCheck, fldPath.Child("outboundConnectivityCheck"))...)
+
+	allErrs = append(allErrs, validatePrivateEndpoints(networkSpec.PrivateEndpoints, networkSpec.Subnets, fldPath.Child("privateEndpoints"))...)
+
+	allErrs = append(allErrs, validateIngress(networkSpec.Ingress, networkSpec.Subnets, fldPath.Child("ingress"))...)
+
+	allErrs = append(allErrs, validatePodSubnet(networkSpec, fldPath.Child("podSubnet"))...)
+
+	allErrs = append(allErrs, validateInternalLoadBalancers(networkSpec, old.InternalLoadBalancers, fldPath.Child("internalLoadBalancers"))...)
+
+	allErrs = append(allErrs, validateNetworkInterfaceSecurityGroups(networkSpec.NetworkInterfaceSecurityGroups, networkSpec.Subnets, fldPath.Child("networkInterfaceSecurityGroups"))...)
+
+	allErrs = append(allErrs, validateIntraClusterTraffic(networkSpec, fldPath)...)
+
+	if networkSpec.RequireSecurityRuleDescriptions {
+		allErrs = append(allErrs, validateSecurityRuleDescriptions(networkSpec, fldPath)...)
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs
+}
+
+// validateIntraClusterTraffic validates the address ranges between which the security groups of the cluster allow
+// all the traffic, and that the security rules of the spec don't use the names of the rules allowing it.
+func validateIntraClusterTraffic(networkSpec NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	reserveNames := func(rules SecurityRules, rulesPath *field.Path) {
+		for i, rule := range rules {
+			if strings.HasPrefix(rule.Name, IntraClusterSecurityRuleNamePrefix) {
+				allErrs = append(allErrs, field.Invalid(rulesPath.Index(i).Child("name"), rule.Name,
+					fmt.Sprintf("the %s prefix is reserved for the security rules generated from intraClusterTraffic", IntraClusterSecurityRuleNamePrefix)))
+			}
+		}
+	}
+	for i, subnet := range networkSpec.Subnets {
+		reserveNames(subnet.SecurityGroup.SecurityRules, fldPath.Child("subnets").Index(i).Child("securityGroup", "securityRules"))
+	}
+	if nicSecurityGroups := networkSpec.NetworkInterfaceSecurityGroups; nicSecurityGroups != nil {
+		nicPath := fldPath.Child("networkInterfaceSecurityGroups")
+		reserveNames(nicSecurityGroups.ControlPlane.SecurityRules, nicPath.Child("controlPlane", "securityRules"))
+		reserveNames(nicSecurityGroups.Node.SecurityRules, nicPath.Child("node", "securityRules"))
+	}
+
+	if networkSpec.IntraClusterTraffic == nil {
+		return allErrs
+	}
+	cidrsPath := fldPath.Child("intraClusterTraffic", "cidrs")
+	cidrs := sets.NewString()
+	for i, cidr := range networkSpec.IntraClusterTraffic.CIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(cidrsPath.Index(i), cidr, "invalid CIDR format"))
+			continue
+		}
+		if cidrs.Has(cidr) {
+			allErrs = append(allErrs, field.Duplicate(cidrsPath.Index(i), cidr))
+		}
+		cidrs.Insert(cidr)
+	}
+	return allErrs
+}
+
+// validateSecurityRuleDescriptions validates that the security rules of the security groups of the network spec all
+// have a description.
+func validateSecurityRuleDescriptions(networkSpec NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	requireDescriptions := func(rules SecurityRules, rulesPath *field.Path) {
+		for i, rule := range rules {
+			if strings.TrimSpace(rule.Description) == "" {
+				allErrs = append(allErrs, field.Required(rulesPath.Index(i).Child("description"),
+					fmt.Sprintf("security rule %s must have a description", rule.Name)))
+			}
+		}
+	}
+
+	for i, subnet := range networkSpec.Subnets {
+		requireDescriptions(subnet.SecurityGroup.SecurityRules, fldPath.Child("subnets").Index(i).Child("securityGroup", "securityRules"))
+	}
+	if nicSecurityGroups := networkSpec.NetworkInterfaceSecurityGroups; nicSecurityGroups != nil {
+		nicPath := fldPath.Child("networkInterfaceSecurityGroups")
+		requireDescriptions(nicSecurityGroups.ControlPlane.SecurityRules, nicPath.Child("controlPlane", "securityRules"))
+		requireDescriptions(nicSecurityGroups.Node.SecurityRules, nicPath.Child("node", "securityRules"))
+	}
+	if networkSpec.Ingress != nil {
+		requireDescriptions(networkSpec.Ingress.Subnet.SecurityGroup.SecurityRules, fldPath.Child("ingress", "subnet", "securityGroup", "securityRules"))
+	}
+	return allErrs
+}
+
+// validateResourceGroup validates a ResourceGroup.
+func validateResourceGroup(resourceGroup string, fldPath *field.Path) *field.Error {
+	if success, _ := regexp.MatchString(resourceGroupRegex, resourceGroup); !success {
+		return field.Invalid(fldPath, resourceGroup,
+			fmt.Sprintf("resourceGroup doesn't match regex %s", resourceGroupRegex))
+	}
+	return nil
+}
+
+// validateSubnets validates a list of Subnets.
+func validateSubnets(subnets Subnets, vnet VnetSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	subnetNames := make(map[string]bool, len(subnets))
+	requiredSubnetRoles := map[string]bool{
+		"control-plane": false,
+		"node":          false,
+	}
+
+	for i, subnet := range subnets {
+		if err := validateSubnetName(subnet.Name, fldPath.Index(i).Child("name")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		if _, ok := subnetNames[subnet.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath, subnet.Name))
+		}
+		subnetNames[subnet.Name] = true
+		for role := range requiredSubnetRoles {
+			if role == string(subnet.Role) {
+				requiredSubnetRoles[role] = true
+			}
+		}
+		for _, rule := range subnet.SecurityGroup.SecurityRules {
+			if err := validateSecurityRule(
+				rule,
+				fldPath.Index(i).Child("securityGroup").Child("securityRules").Index(i),
+			); err != nil {
+				allErrs = append(allErrs, err)
+			}
+		}
+		allErrs = append(allErrs, validateInboundTrafficIntents(subnet.SecurityGroup, fldPath.Index(i).Child("securityGroup"))...)
+		allErrs = append(allErrs, validateEgressPolicy(subnet.SecurityGroup, fldPath.Index(i).Child("securityGroup"))...)
+		allErrs = append(allErrs, validateDiagnosticSettings(subnet.SecurityGroup.DiagnosticSettings, fldPath.Index(i).Child("securityGroup", "diagnosticSettings"))...)
+		allErrs = append(allErrs, validateSubnetCIDR(subnet.CIDRBlocks, vnet.CIDRBlocks, fldPath.Index(i).Child("cidrBlocks"))...)
+	}
+	for k, v := range requiredSubnetRoles {
+		if !v {
+			allErrs = append(allErrs, field.Required(fldPath,
+				fmt.Sprintf("required role %s not included in provided subnets", k)))
+		}
+	}
+	return allErrs
+}
+
+// validateSubnetName validates the Name of a Subnet.
+func validateSubnetName(name string, fldPath *field.Path) *field.Error {
+	if success, _ := regexp.Match(subnetRegex, []byte(name)); !success {
+		return field.Invalid(fldPath, name,
+			fmt.Sprintf("name of subnet doesn't match regex %s", subnetRegex))
+	}
+	return nil
+}
+
+// validateSubnetCIDR validates the CIDR blocks of a Subnet.
+func validateSubnetCIDR(subnetCidrBlocks []string, vnetCidrBlocks []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	var vnetNws []*net.IPNet
+
+	for _, vnetCidr := range vnetCidrBlocks {
+		if _, vnetNw, err := net.ParseCIDR(vnetCidr); err == nil {
+			vnetNws = append(vnetNws, vnetNw)
+		}
+	}
+
+	for _, subnetCidr := range subnetCidrBlocks {
+		subnetCidrIP, _, err := net.ParseCIDR(subnetCidr)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, subnetCidr, "invalid CIDR format"))
+		}
+
+		var found bool
+		for _, vnetNw := range vnetNws {
+			if vnetNw.Contains(subnetCidrIP) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			allErrs = append(allErrs, field.Invalid(fldPath, subnetCidr, fmt.Sprintf("subnet CIDR not in vnet address space: %s", vnetCidrBlocks)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateVnetCIDR validates the CIDR blocks of a Vnet.
+func validateVnetCIDR(vnetCIDRBlocks []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, vnetCidr := range vnetCIDRBlocks {
+		if _, _, err := net.ParseCIDR(vnetCidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, vnetCidr, "invalid CIDR format"))
+		}
+	}
+	return allErrs
+}
+
+// validateVnetDNSServers validates the custom DNS servers of a virtual network.
+func validateVnetDNSServers(dnsServers []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, server := range dnsServers {
+		if net.ParseIP(server) == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), server, "DNS server must be an IP address"))
+		}
+	}
+	return allErrs
+}
+
+// validateFirewallRoutes validates the firewall routes of a list of Subnets.
+func validateFirewallRoutes(subnets Subnets, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, subnet := range subnets {
+		if subnet.FirewallRoute == nil {
+			continue
+		}
+		firewallRoutePath := fldPath.Index(i).Child("firewallRoute")
+		if ip := net.ParseIP(subnet.FirewallRoute.PrivateIP); ip == nil || ip.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(firewallRoutePath.Child("privateIP"), subnet.FirewallRoute.PrivateIP,
+				"firewall private IP must be an IPv4 address"))
+		}
+		if subnet.Role != SubnetNode {
+			allErrs = append(allErrs, field.Forbidden(firewallRoutePath, "only the egress of node subnets can be routed to a firewall"))
+		}
+		if subnet.IsNatGatewayEnabled() {
+			allErrs = append(allErrs, field.Forbidden(firewallRoutePath,
+				"the default route to a firewall takes precedence over the NAT gateway of the subnet"))
+		}
+	}
+	return allErrs
+}
+
+// validateNatGateways validates the NAT gateways of a list of Subnets.
+func validateNatGateways(subnets Subnets, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, subnet := range subnets {
+		if !subnet.IsNatGatewayEnabled() {
+			continue
+		}
+		natGateway := subnet.NatGateway
+		natGatewayPath := fldPath.Index(i).Child("natGateway")
+		allErrs = append(allErrs, validateRegionalPublicIP(natGateway.NatGatewayIP, natGatewayPath.Child("ip"))...)
+		allErrs = append(allErrs, forbidPublicIPPrefix(natGateway.NatGatewayIP, natGatewayPath.Child("ip"))...)
+
+		if natGateway.IdleTimeoutInMinutes != nil &&
+			(*natGateway.IdleTimeoutInMinutes < MinNatGatewayIdleTimeoutInMinutes || *natGateway.IdleTimeoutInMinutes > MaxNatGatewayIdleTimeoutInMinutes) {
+			allErrs = append(allErrs, field.Invalid(natGatewayPath.Child("idleTimeoutInMinutes"), *natGateway.IdleTimeoutInMinutes,
+				fmt.Sprintf("NAT gateway idle timeout should be between %d and %d minutes", MinNatGatewayIdleTimeoutInMinutes, MaxNatGatewayIdleTimeoutInMinutes)))
+		}
+
+		ipCount := int32(1)
+		if natGateway.NatGatewayIPCount != nil {
+			ipCount = *natGateway.NatGatewayIPCount
+		}
+		if ipCount == 0 && natGateway.NatGatewayIPPrefix == nil {
+			allErrs = append(allErrs, field.Invalid(natGatewayPath.Child("ipCount"), ipCount,
+				"NAT gateway needs at least one public IP or a public IP prefix"))
+			continue
+		}
+
+		prefixAddresses := int32(0)
+		if natGateway.NatGatewayIPPrefix != nil && natGateway.NatGatewayIPPrefix.PrefixLength != nil {
+			prefixAddresses = 1 << (32 - *natGateway.NatGatewayIPPrefix.PrefixLength)
+		}
+		if ipCount+prefixAddresses > MaxNatGatewayOutboundIPs {
+			allErrs = append(allErrs, field.Invalid(natGatewayPath.Child("ipCount"), ipCount,
+				fmt.Sprintf("NAT gateway public IPs and public IP prefix addresses should not exceed %d", MaxNatGatewayOutboundIPs)))
+		}
+	}
+	return allErrs
+}
+
+// validateControlPlaneNatGateway validates that the NAT gateway of the control plane subnet is dedicated to the
+// control plane, and that no load balancer outbound rule competes with it for the egress of the control plane.
+func validateControlPlaneNatGateway(networkSpec NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	cpSubnetIndex := -1
+	for i, subnet := range networkSpec.Subnets {
+		if subnet.Role == SubnetControlPlane {
+			cpSubnetIndex = i
+			break
+		}
+	}
+	if cpSubnetIndex < 0 || !networkSpec.Subnets[cpSubnetIndex].IsNatGatewayEnabled() {
+		return nil
+	}
+	cpNatGateway := networkSpec.Subnets[cpSubnetIndex].NatGateway
+	natGatewayPath := fldPath.Child("subnets").Index(cpSubnetIndex).Child("natGateway")
+
+	for _, subnet := range networkSpec.Subnets {
+		if subnet.Role != SubnetNode || !subnet.IsNatGatewayEnabled() {
+			continue
+		}
+		if subnet.NatGateway.Name == cpNatGateway.Name {
+			allErrs = append(allErrs, field.Invalid(natGatewayPath.Child("name"), cpNatGateway.Name,
+				fmt.Sprintf("the NAT gateway of the control plane subnet can't be shared with node subnet %s", subnet.Name)))
+		}
+		if cpNatGateway.NatGatewayIPPrefix != nil && subnet.NatGateway.NatGatewayIPPrefix != nil &&
+			subnet.NatGateway.NatGatewayIPPrefix.Name == cpNatGateway.NatGatewayIPPrefix.Name {
+			allErrs = append(allErrs, field.Invalid(natGatewayPath.Child("ipPrefix", "name"), cpNatGateway.NatGatewayIPPrefix.Name,
+				fmt.Sprintf("the public IP prefix of the control plane NAT gateway can't be shared with node subnet %s", subnet.Name)))
+		}
+	}
+
+	if networkSpec.ControlPlaneOutboundLB != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("controlPlaneOutboundLB"),
+			"the control plane outbound load balancer conflicts with the NAT gateway of the control plane subnet, which takes precedence over its outbound rule"))
+	}
+	return allErrs
+}
+
+// validateVnetPeerings validates a list of virtual network peerings.
+func validateVnetPeerings(peerings VnetPeerings, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	vnetIdentifiers := make(map[string]bool, len(peerings))
+
+	for i, peering := range peerings {
+		vnetIdentifier := peering.ResourceGroup + "/" + peering.RemoteVnetName
+		if _, ok := vnetIdentifiers[vnetIdentifier]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath, vnetIdentifier))
+		}
+		vnetIdentifiers[vnetIdentifier] = true
+		allErrs = append(allErrs, validateCrossTenantVnetPeering(peering, fldPath.Index(i))...)
+	}
+	return allErrs
+}
+
+// validateCrossTenantVnetPeering validates the tenant, subscription and identity of a peering with a virtual network
+// of another tenant.
+func validateCrossTenantVnetPeering(peering VnetPeeringSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if !peering.IsCrossTenant() {
+		if peering.SubscriptionID != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("subscriptionID"), "the subscription of the remote virtual network can only be set with its tenant"))
+		}
+		if peering.IdentityRef != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("identityRef"), "the identity of the remote virtual network can only be set with its tenant"))
+		}
+		return allErrs
+	}
+
+	if success, _ := regexp.MatchString(guidRegex, peering.TenantID); !success {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("tenantID"), peering.TenantID, "must be the ID of an Azure AD tenant"))
+	}
+	if peering.SubscriptionID == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("subscriptionID"), "the subscription of a virtual network of another tenant is required"))
+	}
+	if peering.IdentityRef == nil || peering.IdentityRef.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("identityRef"),
+			"an AzureClusterIdentity of the tenant of the remote virtual network is required to create the peering from it"))
+	}
+	return allErrs
+}
+
+// validateLoadBalancerName validates the Name of a Load Balancer.
+func validateLoadBalancerName(name string, fldPath *field.Path) *field.Error {
+	if success, _ := regexp.Match(loadBalancerRegex, []byte(name)); !success {
+		return field.Invalid(fldPath, name,
+			fmt.Sprintf("name of load balancer doesn't match regex %s", loadBalancerRegex))
+	}
+	return nil
+}
+
+// validateInternalLBIPAddress validates a InternalLBIPAddress.
+func validateInternalLBIPAddress(address string, cidrs []string, fldPath *field.Path) *field.Error {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return field.Invalid(fldPath, address,
+			"Internal LB IP address isn't a valid IPv4 or IPv6 address")
+	}
+	for _, cidr := range cidrs {
+		_, subnet, _ := net.ParseCIDR(cidr)
+		if subnet.Contains(ip) {
+			return nil
+		}
+	}
+	return field.Invalid(fldPath, address,
+		fmt.Sprintf("Internal LB IP address needs to be in control plane subnet range (%s)", cidrs))
+}
+
+// validateSecurityRule validates a SecurityRule.
+func validateSecurityRule(rule SecurityRule, fldPath *field.Path) *field.Error {
+	if rule.Priority < minRulePriority || rule.Priority > maxRulePriority {
+		return field.Invalid(fldPath, rule.Priority, fmt.Sprintf("security rule priorities should be between %d and %d", minRulePriority, maxRulePriority))
+	}
+
+	return nil
+}
+
+// validateInboundTrafficIntents validates the inbound traffic a security group allows, and that the security rules
+// generated from it fit in the priorities the other inbound rules leave from IntentSecurityRulePriority.
+func validateInboundTrafficIntents(sg SecurityGroup, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, rule := range sg.SecurityRules {
+		if strings.HasPrefix(rule.Name, IntentSecurityRuleNamePrefix) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("securityRules").Index(i).Child("name"), rule.Name,
+				fmt.Sprintf("the %s prefix is reserved for the security rules generated from allowInboundFrom", IntentSecurityRuleNamePrefix)))
+		}
+	}
+	if len(sg.AllowInboundFrom) == 0 {
+		return allErrs
+	}
+
+	intentsPath := fldPath.Child("allowInboundFrom")
+	allowed := make(map[string]bool)
+	generated := 0
+	for i, intent := range sg.AllowInboundFrom {
+		intentPath := intentsPath.Index(i)
+		if !isValidSecurityRuleAddress(intent.Source) {
+			allErrs = append(allErrs, field.Invalid(intentPath.Child("source"), intent.Source, "must be a CIDR, an IP address, a service tag or *"))
+		}
+		protocol := intent.Protocol
+		if protocol == "" {
+			protocol = SecurityGroupProtocolTCP
+		}
+		for j, port := range intent.Ports {
+			if !isValidSecurityRulePortRange(port) {
+				allErrs = append(allErrs, field.Invalid(intentPath.Child("ports").Index(j), port, "must be a port, a port range or *"))
+			}
+			key := strings.ToLower(fmt.Sprintf("%s/%s/%s", intent.Source, protocol, port))
+			if allowed[key] {
+				allErrs = append(allErrs, field.Duplicate(intentPath.Child("ports").Index(j), port))
+			}
+			allowed[key] = true
+			generated++
+		}
+	}
+
+	available := maxRulePriority - IntentSecurityRulePriority + 1
+	for _, rule := range sg.SecurityRules {
+		if rule.Direction == SecurityRuleDirectionInbound && rule.Priority >= IntentSecurityRulePriority {
+			available--
+		}
+	}
+	if generated > available {
+		allErrs = append(allErrs, field.TooMany(intentsPath, generated, available))
+	}
+	return allErrs
+}
+
+// validateEgressPolicy validates the outbound traffic a security group allows, and that the security rules generated
+// from its DenyByDefault egress policy fit in the priorities the other outbound rules leave from
+// EgressSecurityRulePriority.
+func validateEgressPolicy(sg SecurityGroup, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, rule := range sg.SecurityRules {
+		if strings.HasPrefix(rule.Name, EgressSecurityRuleNamePrefix) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("securityRules").Index(i).Child("name"), rule.Name,
+				fmt.Sprintf("the %s prefix is reserved for the security rules generated from egressPolicy", EgressSecurityRuleNamePrefix)))
+		}
+	}
+	intentsPath := fldPath.Child("allowOutboundTo")
+	if sg.EgressPolicy != EgressPolicyDenyByDefault {
+		if len(sg.AllowOutboundTo) > 0 {
+			allErrs = append(allErrs, field.Forbidden(intentsPath, fmt.Sprintf("is only supported with the %s egress policy", EgressPolicyDenyByDefault)))
+		}
+		return allErrs
+	}
+
+	allowed := make(map[string]bool)
+	generated := egressRequiredSecurityRuleCount
+	for i, intent := range sg.AllowOutboundTo {
+		intentPath := intentsPath.Index(i)
+		if !isValidSecurityRuleAddress(intent.Destination) {
+			allErrs = append(allErrs, field.Invalid(intentPath.Child("destination"), intent.Destination, "must be a CIDR, an IP address, a service tag or *"))
+		}
+		protocol := intent.Protocol
+		if protocol == "" {
+			protocol = SecurityGroupProtocolTCP
+		}
+		for j, port := range intent.Ports {
+			if !isValidSecurityRulePortRange(port) {
+				allErrs = append(allErrs, field.Invalid(intentPath.Child("ports").Index(j), port, "must be a port, a port range or *"))
+			}
+			key := strings.ToLower(fmt.Sprintf("%s/%s/%s", intent.Destination, protocol, port))
+			if allowed[key] {
+				allErrs = append(allErrs, field.Duplicate(intentPath.Child("ports").Index(j), port))
+			}
+			allowed[key] = true
+			generated++
+		}
+	}
+
+	available := EgressDenySecurityRulePriority - EgressSecurityRulePriority
+	for i, rule := range sg.SecurityRules {
+		if rule.Direction != SecurityRuleDirectionOutbound {
+			continue
+		}
+		if rule.Priority == EgressDenySecurityRulePriority {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("securityRules").Index(i).Child("priority"), rule.Priority,
+				fmt.Sprintf("the priority is reserved for the outbound rule denying the traffic of the %s egress policy", EgressPolicyDenyByDefault)))
+		}
+		if rule.Priority >= EgressSecurityRulePriority && rule.Priority < EgressDenySecurityRulePriority {
+			available--
+		}
+	}
+	if generated > available {
+		allErrs = append(allErrs, field.TooMany(intentsPath, generated-egressRequiredSecurityRuleCount, available-egressRequiredSecurityRuleCount))
+	}
+	return allErrs
+}
+
+// isValidSecurityRuleAddress returns true if the address is a CIDR, an IP address, a service tag, e.g.
+// "AzureCloud.EastUS", or "*".
+func isValidSecurityRuleAddress(address string) bool {
+	if address == "*" || net.ParseIP(address) != nil {
+		return true
+	}
+	if _, _, err := net.ParseCIDR(address); err == nil {
+		return true
+	}
+	success, _ := regexp.MatchString(serviceTagRegex, address)
+	return success
+}
+
+// isValidSecurityRulePortRange returns true if the port range is a port, a range of ports, e.g. "8000-8080", or "*".
+func isValidSecurityRulePortRange(portRange string) bool {
+	if portRange == "*" {
+		return true
+	}
+	bounds := strings.SplitN(portRange, "-", 2)
+	previous := -1
+	for _, bound := range bounds {
+		port, err := strconv.Atoi(bound)
+		if err != nil || port < 0 || port > 65535 || port < previous {
+			return false
+		}
+		previous = port
+	}
+	return true
+}
+
+// validateApplicationSecurityGroups validates the application security groups and the security rules referencing them.
+func validateApplicationSecurityGroups(asgs []ApplicationSecurityGroup, subnets Subnets, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	asgNames := make(map[string]bool, len(asgs))
+	for i, asg := range asgs {
+		if success, _ := regexp.MatchString(applicationSecurityGroupRegex, asg.Name); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("applicationSecurityGroups").Index(i).Child("name"), asg.Name,
+				fmt.Sprintf("name of application security group doesn't match regex %s", applicationSecurityGroupRegex)))
+		}
+		if asgNames[asg.Name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("applicationSecurityGroups").Index(i).Child("name"), asg.Name))
+		}
+		asgNames[asg.Name] = true
+		if asg.Role != "" && asg.Role != SubnetNode && asg.Role != SubnetControlPlane {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("applicationSecurityGroups").Index(i).Child("role"), asg.Role,
+				[]string{string(SubnetNode), string(SubnetControlPlane)}))
+		}
+	}
+
+	for i, subnet := range subnets {
+		for j, rule := range subnet.SecurityGroup.SecurityRules {
+			rulePath := fldPath.Child("subnets").Index(i).Child("securityGroup").Child("securityRules").Index(j)
+			if rule.Source != nil && len(rule.SourceApplicationSecurityGroups) > 0 {
+				allErrs = append(allErrs, field.Forbidden(rulePath.Child("sourceApplicationSecurityGroups"), "source and sourceApplicationSecurityGroups are mutually exclusive"))
+			}
+			if rule.Destination != nil && len(rule.DestinationApplicationSecurityGroups) > 0 {
+				allErrs = append(allErrs, field.Forbidden(rulePath.Child("destinationApplicationSecurityGroups"), "destination and destinationApplicationSecurityGroups are mutually exclusive"))
+			}
+			for k, name := range rule.SourceApplicationSecurityGroups {
+				if !asgNames[name] {
+					allErrs = append(allErrs, field.NotFound(rulePath.Child("sourceApplicationSecurityGroups").Index(k), name))
+				}
+			}
+			for k, name := range rule.DestinationApplicationSecurityGroups {
+				if !asgNames[name] {
+					allErrs = append(allErrs, field.NotFound(rulePath.Child("destinationApplicationSecurityGroups").Index(k), name))
+				}
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateAPIServerLB(lb LoadBalancerSpec, old LoadBalancerSpec, cidrs []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	// SKU should be Standard and is immutable.
+	if lb.SKU != SKUStandard {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("sku"), lb.SKU, []string{string(SKUStandard)}))
+	}
+	if old.SKU != "" && old.SKU != lb.SKU {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("sku"), "API Server load balancer SKU should not be modified after AzureCluster creation."))
+	}
+
+	allErrs = append(allErrs, validateLoadBalancerTier(lb, &old, fldPath.Child("tier"))...)
+
+	// Type should be Public or Internal.
+	if lb.Type != Internal && lb.Type != Public {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), lb.Type,
+			[]string{string(Public), string(Internal)}))
 	}
 	if old.Type != "" && old.Type != lb.Type {
 		allErrs = append(allErrs, field.Forbidden(fldPath.Child("type"), "API Server load balancer type should not be modified after AzureCluster creation."))
 	}
 
-	// Name should be valid.
-	if err := validateLoadBalancerName(lb.Name, fldPath.Child("name")); err != nil {
+	// Name should be valid.
+	if err := validateLoadBalancerName(lb.Name, fldPath.Child("name")); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if old.Name != "" && old.Name != lb.Name {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "API Server load balancer name should not be modified after AzureCluster creation."))
+	}
+
+	if old.IdleTimeoutInMinutes != nil && !pointer.Int32Equal(old.IdleTimeoutInMinutes, lb.IdleTimeoutInMinutes) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("idleTimeoutInMinutes"), "API Server load balancer idle timeout cannot be modified after AzureCluster creation."))
+	}
+
+	if lb.HealthProbe != nil && lb.HealthProbe.RequestPath != "" && !strings.HasPrefix(lb.HealthProbe.RequestPath, "/") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("healthProbe", "requestPath"), lb.HealthProbe.RequestPath,
+			"health probe request path should start with /"))
+	}
+
+	if lb.InternalFrontendIP != nil {
+		internalFrontendPath := fldPath.Child("internalFrontendIP")
+		if lb.Type != Public {
+			allErrs = append(allErrs, field.Forbidden(internalFrontendPath,
+				"an internal frontend IP can only be added to a public load balancer, internal load balancers are already reachable from the virtual network"))
+		}
+		if lb.InternalFrontendIP.PublicIP != nil {
+			allErrs = append(allErrs, field.Forbidden(internalFrontendPath.Child("publicIP"), "Internal Load Balancers cannot have a Public IP"))
+		}
+		if lb.InternalFrontendIP.PrivateIPAddress != "" {
+			if err := validateInternalLBIPAddress(lb.InternalFrontendIP.PrivateIPAddress, cidrs, internalFrontendPath.Child("privateIP")); err != nil {
+				allErrs = append(allErrs, err)
+			}
+		}
+		if old.InternalFrontendIP != nil && old.InternalFrontendIP.PrivateIPAddress != lb.InternalFrontendIP.PrivateIPAddress {
+			allErrs = append(allErrs, field.Forbidden(internalFrontendPath.Child("privateIP"), "API Server load balancer internal frontend private IP should not be modified after AzureCluster creation."))
+		}
+	}
+
+	if lb.HAPorts != nil && lb.HAPorts.Enabled && lb.Type != Internal {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("haPorts", "enabled"), "HA ports are only supported by internal load balancers"))
+	}
+
+	allErrs = append(allErrs, validateSSHNATRule(lb, fldPath.Child("sshNATRule"))...)
+
+	allErrs = append(allErrs, validateConnectionDraining(lb.ConnectionDraining, fldPath.Child("connectionDraining"))...)
+
+	allErrs = append(allErrs, validateSharedLB(lb, old, fldPath)...)
+
+	if lb.ForceRecreate && lb.Shared != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("forceRecreate"), "a shared load balancer is managed outside of the cluster, it can't be recreated"))
+	}
+
+	allErrs = append(allErrs, validateDiagnosticSettings(lb.DiagnosticSettings, fldPath.Child("diagnosticSettings"))...)
+
+	allErrs = append(allErrs, validateFrontendIPZones(lb.FrontendIPs, fldPath.Child("frontendIPs"))...)
+
+	// There should only be one IP config.
+	if len(lb.FrontendIPs) != 1 || pointer.Int32Deref(lb.FrontendIPsCount, 1) != 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("frontendIPConfigs"), lb.FrontendIPs,
+			"API Server Load balancer should have 1 Frontend IP"))
+	} else {
+		// if Internal, IP config should not have a public IP.
+		if lb.Type == Internal {
+			if lb.FrontendIPs[0].PublicIP != nil {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPConfigs").Index(0).Child("publicIP"),
+					"Internal Load Balancers cannot have a Public IP"))
+			}
+			if lb.FrontendIPs[0].PrivateIPAddress != "" {
+				if err := validateInternalLBIPAddress(lb.FrontendIPs[0].PrivateIPAddress, cidrs,
+					fldPath.Child("frontendIPConfigs").Index(0).Child("privateIP")); err != nil {
+					allErrs = append(allErrs, err)
+				}
+				if len(old.FrontendIPs) != 0 && old.FrontendIPs[0].PrivateIPAddress != lb.FrontendIPs[0].PrivateIPAddress {
+					allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "API Server load balancer private IP should not be modified after AzureCluster creation."))
+				}
+			}
+		}
+
+		// if Public, IP config should not have a private IP.
+		if lb.Type == Public {
+			if lb.FrontendIPs[0].PrivateIPAddress != "" {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPConfigs").Index(0).Child("privateIP"),
+					"Public Load Balancers cannot have a Private IP"))
+			}
+			if len(old.FrontendIPs) != 0 && old.FrontendIPs[0].PublicIP != nil && lb.FrontendIPs[0].PublicIP != nil {
+				publicIPPath := fldPath.Child("frontendIPs").Index(0).Child("publicIP")
+				if !reflect.DeepEqual(old.FrontendIPs[0].PublicIP.Zones, lb.FrontendIPs[0].PublicIP.Zones) {
+					allErrs = append(allErrs, field.Forbidden(publicIPPath.Child("zones"),
+						"API Server load balancer public IP zones should not be modified after AzureCluster creation."))
+				}
+				allErrs = append(allErrs, validatePublicIPUpdate(*lb.FrontendIPs[0].PublicIP, *old.FrontendIPs[0].PublicIP, publicIPPath)...)
+			}
+		}
+
+		if lb.IdleTimeoutInMinutes != nil && (*lb.IdleTimeoutInMinutes < MinLBIdleTimeoutInMinutes || *lb.IdleTimeoutInMinutes > MaxLBIdleTimeoutInMinutes) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("idleTimeoutInMinutes"), *lb.IdleTimeoutInMinutes,
+				fmt.Sprintf("Node outbound idle timeout should be between %d and %d minutes", MinLBIdleTimeoutInMinutes, MaxLoadBalancerOutboundIPs)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateLoadBalancerTier validates the tier of a load balancer with machines in its backend pool, which must be
+// Regional: the backends of a Global tier load balancer can only be the frontends of regional load balancers, and
+// its frontends the Global tier public IPs that the frontends of the regional load balancers reject. The tier of a load
+// balancer can't be changed once it is created.
+func validateLoadBalancerTier(lb LoadBalancerSpec, old *LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if lb.Tier == LoadBalancerTierGlobal {
+		allErrs = append(allErrs, field.Forbidden(fldPath,
+			"the Global tier is only supported by cross-region load balancers, use globalLB to front the API server load balancer with one"))
+	}
+	if old != nil && old.Tier != "" && old.Tier != lb.Tier {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "load balancer tier should not be modified after AzureCluster creation."))
+	}
+	return allErrs
+}
+
+// validateSharedLB validates the sharing of the API server load balancer with other clusters. Only the frontend of a
+// public load balancer can be shared, and its frontend port and public IP must be known in advance.
+func validateSharedLB(lb LoadBalancerSpec, old LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	sharedPath := fldPath.Child("shared")
+
+	if old.Name != "" && !reflect.DeepEqual(old.Shared, lb.Shared) {
+		allErrs = append(allErrs, field.Forbidden(sharedPath, "API Server load balancer sharing should not be modified after AzureCluster creation."))
+	}
+
+	if lb.Shared == nil {
+		return allErrs
+	}
+
+	if lb.Type != Public {
+		allErrs = append(allErrs, field.Forbidden(sharedPath, "only public load balancers can be shared"))
+	}
+	if lb.Shared.FrontendPort < 1 || lb.Shared.FrontendPort > 65535 {
+		allErrs = append(allErrs, field.Invalid(sharedPath.Child("frontendPort"), lb.Shared.FrontendPort, "frontend port should be between 1 and 65535"))
+	}
+	if lb.InternalFrontendIP != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("internalFrontendIP"), "a shared load balancer can't have an internal frontend IP"))
+	}
+	for i, frontend := range lb.FrontendIPs {
+		if frontend.PublicIP != nil && frontend.PublicIP.DNSName == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("frontendIPs").Index(i).Child("publicIP", "dnsName"),
+				"the DNS name of the public IP of a shared load balancer can't be generated"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateNodeOutboundLB(lb *LoadBalancerSpec, old *LoadBalancerSpec, apiserverLB LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// LB can be nil when disabled for private clusters.
+	if lb == nil && apiserverLB.Type == Internal {
+		return allErrs
+	}
+
+	if lb == nil {
+		allErrs = append(allErrs, field.Required(fldPath, "Node outbound load balancer cannot be nil for public clusters."))
+		return allErrs
+	}
+
+	if lb.Shared != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("shared"), "only the API server load balancer can be shared"))
+	}
+
+	if old != nil && old.ID != lb.ID {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("id"), "Node outbound load balancer ID should not be modified after AzureCluster creation."))
+	}
+
+	if old != nil && old.Name != lb.Name {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "Node outbound load balancer Name should not be modified after AzureCluster creation."))
+	}
+
+	if old != nil && old.SKU != lb.SKU {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("sku"), "Node outbound load balancer SKU should not be modified after AzureCluster creation."))
+	}
+
+	if old != nil && old.Type != lb.Type {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("type"), "Node outbound load balancer Type cannot be modified after AzureCluster creation."))
+	}
+
+	allErrs = append(allErrs, validateLoadBalancerTier(*lb, old, fldPath.Child("tier"))...)
+
+	if old != nil && !pointer.Int32Equal(old.IdleTimeoutInMinutes, lb.IdleTimeoutInMinutes) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("idleTimeoutInMinutes"), "Node outbound load balancer idle timeout cannot be modified after AzureCluster creation."))
+	}
+
+	if old != nil && old.FrontendIPsCount == lb.FrontendIPsCount {
+		if len(old.FrontendIPs) != len(lb.FrontendIPs) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPs"), "Node outbound load balancer FrontendIPs cannot be modified after AzureCluster creation."))
+		}
+
+		if len(old.FrontendIPs) == len(lb.FrontendIPs) {
+			for i, frontEndIP := range lb.FrontendIPs {
+				oldFrontendIP := old.FrontendIPs[i]
+				if oldFrontendIP.Name != frontEndIP.Name || !reflect.DeepEqual(oldFrontendIP.PublicIP, frontEndIP.PublicIP) {
+					allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPs").Index(i),
+						"Node outbound load balancer FrontendIPs cannot be modified after AzureCluster creation."))
+				}
+			}
+		}
+	}
+
+	if lb.FrontendIPsCount != nil && *lb.FrontendIPsCount > MaxLoadBalancerOutboundIPs {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("frontendIPsCount"), *lb.FrontendIPsCount,
+			fmt.Sprintf("Max front end ips allowed is %d", MaxLoadBalancerOutboundIPs)))
+	}
+
+	if lb.IdleTimeoutInMinutes != nil && (*lb.IdleTimeoutInMinutes < MinLBIdleTimeoutInMinutes || *lb.IdleTimeoutInMinutes > MaxLBIdleTimeoutInMinutes) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("idleTimeoutInMinutes"), *lb.IdleTimeoutInMinutes,
+			fmt.Sprintf("Node outbound idle timeout should be between %d and %d minutes", MinLBIdleTimeoutInMinutes, MaxLoadBalancerOutboundIPs)))
+	}
+
+	if lb.HAPorts != nil && lb.HAPorts.Enabled {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("haPorts", "enabled"), "HA ports are only supported by internal load balancers"))
+	}
+
+	if lb.InternalFrontendIP != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("internalFrontendIP"), "an internal frontend IP can only be added to the API server load balancer"))
+	}
+
+	if lb.SSHNATRule != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("sshNATRule"), "an SSH NAT rule can only be added to the API server load balancer"))
+	}
+
+	if lb.ConnectionDraining != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("connectionDraining"), "connection draining is only supported by the API server load balancer"))
+	}
+
+	allErrs = append(allErrs, validateDiagnosticSettings(lb.DiagnosticSettings, fldPath.Child("diagnosticSettings"))...)
+
+	allErrs = append(allErrs, validateFrontendIPZones(lb.FrontendIPs, fldPath.Child("frontendIPs"))...)
+
+	return allErrs
+}
+
+// validateConnectionDraining validates the connection draining of the API server load balancer, whose timeout must be
+// positive and bounded as it delays the deletion of the control plane machines.
+func validateConnectionDraining(draining *ConnectionDraining, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if draining == nil {
+		return allErrs
+	}
+	if timeout := draining.Timeout.Duration; timeout <= 0 || timeout > MaxLBConnectionDrainTimeout {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), timeout.String(),
+			fmt.Sprintf("connection drain timeout should be greater than 0 and at most %s", MaxLBConnectionDrainTimeout)))
+	}
+	return allErrs
+}
+
+// validateSSHNATRule validates the SSH NAT rule of the API server load balancer.
+func validateSSHNATRule(lb LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	rule := lb.SSHNATRule
+	if rule == nil {
+		return nil
+	}
+
+	if lb.Type != Public {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "an SSH NAT rule is only supported by public load balancers"))
+	}
+	if lb.Shared != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "an SSH NAT rule can't be added to a shared load balancer"))
+	}
+
+	if rule.FrontendPortRangeStart < 1 || rule.FrontendPortRangeStart > MaxSSHNATRulePort {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("frontendPortRangeStart"), rule.FrontendPortRangeStart,
+			fmt.Sprintf("frontend port must be between 1 and %d", MaxSSHNATRulePort)))
+	}
+	if rule.FrontendPortRangeEnd < 1 || rule.FrontendPortRangeEnd > MaxSSHNATRulePort {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("frontendPortRangeEnd"), rule.FrontendPortRangeEnd,
+			fmt.Sprintf("frontend port must be between 1 and %d", MaxSSHNATRulePort)))
+	}
+	if rule.FrontendPortRangeEnd < rule.FrontendPortRangeStart {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("frontendPortRangeEnd"), rule.FrontendPortRangeEnd,
+			"the end of the frontend port range must not be lower than its start"))
+	} else if size := rule.FrontendPortRangeEnd - rule.FrontendPortRangeStart + 1; size < MinSSHNATRulePorts {
+		allErrs = append(allErrs, field.Invalid(fldPath, size,
+			fmt.Sprintf("the frontend port range must hold at least %d ports, for a control plane machine and its replacement during a rolling update", MinSSHNATRulePorts)))
+	}
+
+	// The rules created for each control plane machine are kept until the machines are deleted, their frontend ports
+	// can't be reused by the range.
+	if rule.FrontendPortRangeStart <= 22 && rule.FrontendPortRangeEnd >= 22 ||
+		rule.FrontendPortRangeStart <= 2219 && rule.FrontendPortRangeEnd >= 2201 {
+		allErrs = append(allErrs, field.Invalid(fldPath, fmt.Sprintf("%d-%d", rule.FrontendPortRangeStart, rule.FrontendPortRangeEnd),
+			"the frontend port range must not overlap the ports 22 and 2201-2219 of the NAT rules of each control plane machine"))
+	}
+
+	return allErrs
+}
+
+func validateControlPlaneOutboundLB(lb *LoadBalancerSpec, apiserverLB LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch apiserverLB.Type {
+	case Public:
+		if lb != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "Control plane outbound load balancer cannot be set for public clusters."))
+		}
+	case Internal:
+		// Control plane outbound lb can be nil when it's disabled for private clusters.
+		if lb == nil {
+			return nil
+		}
+
+		if lb.Shared != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("shared"), "only the API server load balancer can be shared"))
+		}
+
+		allErrs = append(allErrs, validateLoadBalancerTier(*lb, nil, fldPath.Child("tier"))...)
+
+		if lb.FrontendIPsCount != nil && *lb.FrontendIPsCount > MaxLoadBalancerOutboundIPs {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("frontendIPsCount"), *lb.FrontendIPsCount,
+				fmt.Sprintf("Max front end ips allowed is %d", MaxLoadBalancerOutboundIPs)))
+		}
+
+		if lb.IdleTimeoutInMinutes != nil && (*lb.IdleTimeoutInMinutes < MinLBIdleTimeoutInMinutes || *lb.IdleTimeoutInMinutes > MaxLBIdleTimeoutInMinutes) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("idleTimeoutInMinutes"), *lb.IdleTimeoutInMinutes,
+				fmt.Sprintf("Control plane outbound idle timeout should be between %d and %d minutes", MinLBIdleTimeoutInMinutes, MaxLoadBalancerOutboundIPs)))
+		}
+
+		if lb.HAPorts != nil && lb.HAPorts.Enabled {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("haPorts", "enabled"), "HA ports are only supported by internal load balancers"))
+		}
+
+		if lb.InternalFrontendIP != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("internalFrontendIP"), "an internal frontend IP can only be added to the API server load balancer"))
+		}
+
+		if lb.SSHNATRule != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("sshNATRule"), "an SSH NAT rule can only be added to the API server load balancer"))
+		}
+
+		if lb.ConnectionDraining != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("connectionDraining"), "connection draining is only supported by the API server load balancer"))
+		}
+
+		allErrs = append(allErrs, validateDiagnosticSettings(lb.DiagnosticSettings, fldPath.Child("diagnosticSettings"))...)
+
+		allErrs = append(allErrs, validateFrontendIPZones(lb.FrontendIPs, fldPath.Child("frontendIPs"))...)
+	}
+
+	return allErrs
+}
+
+// validatePrivateDNSZoneName validate the PrivateDNSZoneName.
+func validatePrivateDNSZoneName(networkSpec NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(networkSpec.PrivateDNSZoneName) > 0 {
+		if networkSpec.APIServerLB.Type != Internal {
+			allErrs = append(allErrs, field.Invalid(fldPath, networkSpec.APIServerLB.Type,
+				"PrivateDNSZoneName is available only if APIServerLB.Type is Internal"))
+		}
+		if !valid.IsDNSName(networkSpec.PrivateDNSZoneName) {
+			allErrs = append(allErrs, field.Invalid(fldPath, networkSpec.PrivateDNSZoneName,
+				"PrivateDNSZoneName can only contain alphanumeric characters, underscores and dashes, must end with an alphanumeric character",
+			))
+		}
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return allErrs
+}
+
+// validateFrontendIPZones validates the zones and tiers of the public IPs of load balancer frontend IPs.
+func validateFrontendIPZones(frontendIPs []FrontendIP, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, frontendIP := range frontendIPs {
+		if frontendIP.PublicIP == nil {
+			continue
+		}
+		allErrs = append(allErrs, validateRegionalPublicIP(*frontendIP.PublicIP, fldPath.Index(i).Child("publicIP"))...)
+		if ipPrefixID := frontendIP.PublicIP.IPPrefixID; ipPrefixID != "" {
+			if success, _ := regexp.MatchString(publicIPPrefixIDRegex, ipPrefixID); !success {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("publicIP", "ipPrefixID"), ipPrefixID,
+					"must be the resource ID of a public IP prefix"))
+			}
+		}
+		zonesPath := fldPath.Index(i).Child("publicIP", "zones")
+		seen := make(map[string]struct{}, len(frontendIP.PublicIP.Zones))
+		for j, zone := range frontendIP.PublicIP.Zones {
+			if success, _ := regexp.MatchString(availabilityZoneRegex, zone); !success {
+				allErrs = append(allErrs, field.Invalid(zonesPath.Index(j), zone, fmt.Sprintf("zone doesn't match regex %s", availabilityZoneRegex)))
+			}
+			if _, ok := seen[zone]; ok {
+				allErrs = append(allErrs, field.Duplicate(zonesPath.Index(j), zone))
+			}
+			seen[zone] = struct{}{}
+		}
+	}
+
+	return allErrs
+}
+
+// validateRegionalPublicIP validates that a public IP, which is not the public IP of a cross-region load balancer, is
+// of the Regional tier.
+func validateRegionalPublicIP(ip PublicIPSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if ip.IsGlobal() {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("tier"), "the Global tier is only supported for the public IP of a cross-region load balancer"))
+	}
+
+	allErrs = append(allErrs, validateIPTags(ip.IPTags, fldPath.Child("ipTags"))...)
+	allErrs = append(allErrs, validateDiagnosticSettings(ip.DiagnosticSettings, fldPath.Child("diagnosticSettings"))...)
+
+	return allErrs
+}
+
+// forbidPublicIPPrefix validates that a public IP, which is not the public IP of the frontend of a regional load
+// balancer, isn't allocated from a public IP prefix.
+func forbidPublicIPPrefix(ip PublicIPSpec, fldPath *field.Path) field.ErrorList {
+	if ip.IPPrefixID == "" {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(fldPath.Child("ipPrefixID"), "public IP prefixes are only supported for the public IPs of the frontends of the load balancers")}
+}
+
+// validateIPTags validates the IP tags of a public IP.
+func validateIPTags(ipTags []IPTag, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	seen := make(map[IPTag]struct{}, len(ipTags))
+	for i, ipTag := range ipTags {
+		switch ipTag.Type {
+		case IPTagTypeFirstPartyUsage:
+		case IPTagTypeRoutingPreference:
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("type"), "the routing preference of a public IP is set with its routingPreference"))
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Index(i).Child("type"), ipTag.Type, []string{string(IPTagTypeFirstPartyUsage)}))
+		}
+		if ipTag.Tag == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("tag"), "the value of an IP tag is required"))
+		}
+		if _, ok := seen[ipTag]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), ipTag))
+		}
+		seen[ipTag] = struct{}{}
+	}
+
+	return allErrs
+}
+
+// validatePublicIPUpdate validates that the settings of a public IP Azure doesn't allow to change after its creation
+// are not modified.
+func validatePublicIPUpdate(ip PublicIPSpec, old PublicIPSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !reflect.DeepEqual(ip.IPTags, old.IPTags) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ipTags"), ip.IPTags, "field is immutable"))
+	}
+	if ip.RoutingPreference != old.RoutingPreference {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("routingPreference"), ip.RoutingPreference, "field is immutable"))
+	}
+	if !strings.EqualFold(ip.IPPrefixID, old.IPPrefixID) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ipPrefixID"), ip.IPPrefixID, "field is immutable"))
+	}
+
+	return allErrs
+}
+
+// validateTrafficManager validates a TrafficManagerSpec.
+func validateTrafficManager(tm *TrafficManagerSpec, old *TrafficManagerSpec, apiserverLB LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if tm == nil {
+		return allErrs
+	}
+
+	if apiserverLB.Type != Public {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "Traffic Manager is only supported with a public API server load balancer"))
+	}
+	if apiserverLB.Shared != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "Traffic Manager is not supported with a shared API server load balancer"))
+	}
+
+	if success, _ := regexp.MatchString(trafficManagerDNSPrefixRegex, tm.DNSPrefix); !success {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("dnsPrefix"), tm.DNSPrefix,
+			fmt.Sprintf("dnsPrefix doesn't match regex %s, can contain only alphanumeric characters and '-', must start/end with an alphanumeric character", trafficManagerDNSPrefixRegex)))
+	}
+
+	if old != nil && old.DNSPrefix != "" && tm.DNSPrefix != old.DNSPrefix {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("dnsPrefix"), tm.DNSPrefix, "field is immutable"))
+	}
+
+	return allErrs
+}
+
+// validateGlobalLB validates a GlobalLoadBalancerSpec.
+func validateGlobalLB(glb *GlobalLoadBalancerSpec, old *GlobalLoadBalancerSpec, networkSpec NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if glb == nil {
+		return allErrs
+	}
+
+	if networkSpec.APIServerLB.Type != Public || networkSpec.APIServerLB.SKU != SKUStandard {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "a cross-region load balancer is only supported with a public Standard API server load balancer"))
+	}
+	if networkSpec.APIServerLB.Shared != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "a cross-region load balancer is not supported with a shared API server load balancer"))
+	}
+
+	if networkSpec.TrafficManager != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "a cross-region load balancer and a Traffic Manager can't both front the API server"))
+	}
+
+	if !sets.NewString(globalLBHomeRegions...).Has(glb.Location) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("location"), glb.Location, globalLBHomeRegions))
+	}
+
+	if glb.PublicIP != nil {
+		publicIPPath := fldPath.Child("publicIP")
+		if glb.PublicIP.Tier != "" && !glb.PublicIP.IsGlobal() {
+			allErrs = append(allErrs, field.NotSupported(publicIPPath.Child("tier"), glb.PublicIP.Tier, []string{string(PublicIPTierGlobal)}))
+		}
+		if len(glb.PublicIP.Zones) != 0 {
+			allErrs = append(allErrs, field.Forbidden(publicIPPath.Child("zones"), "a Global tier public IP can't be zonal"))
+		}
+		if glb.PublicIP.RoutingPreference == RoutingPreferenceInternet {
+			allErrs = append(allErrs, field.Forbidden(publicIPPath.Child("routingPreference"), "the Internet routing preference is not supported for a Global tier public IP"))
+		}
+		allErrs = append(allErrs, validateIPTags(glb.PublicIP.IPTags, publicIPPath.Child("ipTags"))...)
+		allErrs = append(allErrs, forbidPublicIPPrefix(*glb.PublicIP, publicIPPath)...)
+		allErrs = append(allErrs, validateDiagnosticSettings(glb.PublicIP.DiagnosticSettings, publicIPPath.Child("diagnosticSettings"))...)
+	}
+
+	seen := sets.NewString()
+	for i, backend := range glb.AdditionalBackends {
+		if success, _ := regexp.MatchString(frontendIPConfigIDRegex, backend); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("additionalBackends").Index(i), backend,
+				"additional backend must be the resource ID of the frontend IP configuration of a load balancer"))
+		}
+		if seen.Has(strings.ToLower(backend)) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("additionalBackends").Index(i), backend))
+		}
+		seen.Insert(strings.ToLower(backend))
+	}
+
+	if old != nil {
+		if old.Name != "" && glb.Name != old.Name {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), glb.Name, "field is immutable"))
+		}
+		if old.Location != "" && glb.Location != old.Location {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("location"), glb.Location, "field is immutable"))
+		}
+		if old.PublicIP != nil && glb.PublicIP != nil {
+			if old.PublicIP.Tier != "" && glb.PublicIP.Tier != old.PublicIP.Tier {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("publicIP", "tier"), glb.PublicIP.Tier, "field is immutable"))
+			}
+			allErrs = append(allErrs, validatePublicIPUpdate(*glb.PublicIP, *old.PublicIP, fldPath.Child("publicIP"))...)
+		}
+	}
+
+	return allErrs
+}
+
+// validateDNSPrivateResolver validates a DNSPrivateResolverSpec.
+func validateDNSPrivateResolver(resolver *DNSPrivateResolverSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if resolver == nil {
+		return allErrs
+	}
+
+	if success, _ := regexp.MatchString(dnsPrivateResolverIDRegex, resolver.ID); !success {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("id"), resolver.ID, "id must be the resource ID of a DNS Private Resolver"))
+	}
+
+	seen := sets.NewString()
+	for i, id := range resolver.ForwardingRulesetIDs {
+		if success, _ := regexp.MatchString(dnsForwardingRulesetIDRegex, id); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("forwardingRulesetIDs").Index(i), id, "forwarding ruleset ID must be the resource ID of a DNS forwarding ruleset"))
+		}
+		if seen.Has(strings.ToLower(id)) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("forwardingRulesetIDs").Index(i), id))
+		}
+		seen.Insert(strings.ToLower(id))
+	}
+
+	return allErrs
+}
+
+// validatePrivateEndpoints validates the private endpoints of the cluster and that they are in one of its subnets.
+func validatePrivateEndpoints(endpoints []PrivateEndpointSpec, subnets Subnets, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	subnetNames := sets.NewString()
+	for _, subnet := range subnets {
+		subnetNames.Insert(subnet.Name)
+	}
+
+	names := sets.NewString()
+	for i, endpoint := range endpoints {
+		endpointPath := fldPath.Index(i)
+		if success, _ := regexp.MatchString(generatedNameRegex, endpoint.Name); !success {
+			allErrs = append(allErrs, field.Invalid(endpointPath.Child("name"), endpoint.Name,
+				fmt.Sprintf("name of private endpoint doesn't match regex %s", generatedNameRegex)))
+		}
+		if names.Has(endpoint.Name) {
+			allErrs = append(allErrs, field.Duplicate(endpointPath.Child("name"), endpoint.Name))
+		}
+		names.Insert(endpoint.Name)
+		if success, _ := regexp.MatchString(privateEndpointResourceIDRegex, endpoint.ResourceID); !success {
+			allErrs = append(allErrs, field.Invalid(endpointPath.Child("resourceID"), endpoint.ResourceID, "must be the resource ID of an Azure resource"))
+		}
+		if endpoint.GroupID == "" {
+			allErrs = append(allErrs, field.Required(endpointPath.Child("groupID"), "the group ID can only be defaulted for storage accounts and Key Vaults"))
+		}
+		if endpoint.SubnetName == "" {
+			allErrs = append(allErrs, field.Required(endpointPath.Child("subnetName"), "the subnet can only be defaulted when the cluster has a single node subnet"))
+		} else if !subnetNames.Has(endpoint.SubnetName) {
+			allErrs = append(allErrs, field.NotFound(endpointPath.Child("subnetName"), endpoint.SubnetName))
+		}
+		if endpoint.PrivateDNSZoneID != "" {
+			if success, _ := regexp.MatchString(privateDNSZoneIDRegex, endpoint.PrivateDNSZoneID); !success {
+				allErrs = append(allErrs, field.Invalid(endpointPath.Child("privateDNSZoneID"), endpoint.PrivateDNSZoneID, "must be the resource ID of a private DNS zone"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateIngress validates an IngressSpec and that its subnet doesn't clash with the subnets of the cluster.
+func validateIngress(ingress *IngressSpec, subnets Subnets, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if ingress == nil {
+		return allErrs
+	}
+
+	if err := validateSubnetName(ingress.Subnet.Name, fldPath.Child("subnet").Child("name")); err != nil {
 		allErrs = append(allErrs, err)
 	}
-	if old.Name != "" && old.Name != lb.Name {
-		allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "API Server load balancer name should not be modified after AzureCluster creation."))
+	for _, subnet := range subnets {
+		if subnet.Name == ingress.Subnet.Name {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("subnet").Child("name"), ingress.Subnet.Name))
+		}
 	}
 
-	if old.IdleTimeoutInMinutes != nil && !pointer.Int32Equal(old.IdleTimeoutInMinutes, lb.IdleTimeoutInMinutes) {
-		allErrs = append(allErrs, field.Forbidden(fldPath.Child("idleTimeoutInMinutes"), "API Server load balancer idle timeout cannot be modified after AzureCluster creation."))
+	ports := sets.NewInt32()
+	for i, port := range ingress.Ports {
+		if port < 1 || port > 65535 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ports").Index(i), port, "port must be between 1 and 65535"))
+		}
+		if ports.Has(port) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("ports").Index(i), port))
+		}
+		ports.Insert(port)
 	}
 
-	// There should only be one IP config.
-	if len(lb.FrontendIPs) != 1 || pointer.Int32Deref(lb.FrontendIPsCount, 1) != 1 {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("frontendIPConfigs"), lb.FrontendIPs,
-			"API Server Load balancer should have 1 Frontend IP"))
-	} else {
-		// if Internal, IP config should not have a public IP.
-		if lb.Type == Internal {
-			if lb.FrontendIPs[0].PublicIP != nil {
-				allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPConfigs").Index(0).Child("publicIP"),
-					"Internal Load Balancers cannot have a Public IP"))
-			}
-			if lb.FrontendIPs[0].PrivateIPAddress != "" {
-				if err := validateInternalLBIPAddress(lb.FrontendIPs[0].PrivateIPAddress, cidrs,
-					fldPath.Child("frontendIPConfigs").Index(0).Child("privateIP")); err != nil {
-					allErrs = append(allErrs, err)
-				}
-				if len(old.FrontendIPs) != 0 && old.FrontendIPs[0].PrivateIPAddress != lb.FrontendIPs[0].PrivateIPAddress {
-					allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "API Server load balancer private IP should not be modified after AzureCluster creation."))
-				}
-			}
+	for i, cidr := range ingress.AllowedSourceCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("allowedSourceCIDRs").Index(i), cidr, "invalid CIDR format"))
 		}
+	}
 
-		// if Public, IP config should not have a private IP.
-		if lb.Type == Public {
-			if lb.FrontendIPs[0].PrivateIPAddress != "" {
-				allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPConfigs").Index(0).Child("privateIP"),
-					"Public Load Balancers cannot have a Private IP"))
-			}
-		}
+	sources := len(ingress.AllowedSourceCIDRs)
+	if sources == 0 {
+		sources = 1
+	}
+	if rules := sources * len(ingress.Ports); rules > MaxIngressSecurityRules {
+		allErrs = append(allErrs, field.TooMany(fldPath.Child("allowedSourceCIDRs"), rules, MaxIngressSecurityRules))
+	}
 
-		if lb.IdleTimeoutInMinutes != nil && (*lb.IdleTimeoutInMinutes < MinLBIdleTimeoutInMinutes || *lb.IdleTimeoutInMinutes > MaxLBIdleTimeoutInMinutes) {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("idleTimeoutInMinutes"), *lb.IdleTimeoutInMinutes,
-				fmt.Sprintf("Node outbound idle timeout should be between %d and %d minutes", MinLBIdleTimeoutInMinutes, MaxLoadBalancerOutboundIPs)))
-		}
+	allErrs = append(allErrs, validateDiagnosticSettings(ingress.Subnet.SecurityGroup.DiagnosticSettings, fldPath.Child("subnet", "securityGroup", "diagnosticSettings"))...)
+
+	if ingress.PublicIP != nil {
+		allErrs = append(allErrs, validateRegionalPublicIP(*ingress.PublicIP, fldPath.Child("publicIP"))...)
+		allErrs = append(allErrs, forbidPublicIPPrefix(*ingress.PublicIP, fldPath.Child("publicIP"))...)
 	}
 
 	return allErrs
 }
 
-func validateNodeOutboundLB(lb *LoadBalancerSpec, old *LoadBalancerSpec, apiserverLB LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+// validatePodSubnet validates a PodSubnetSpec, that its subnet doesn't clash with the subnets of the cluster, and that
+// its address space holds the addresses Azure CNI allocates for the pods of all the nodes.
+func validatePodSubnet(networkSpec NetworkSpec, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
-	// LB can be nil when disabled for private clusters.
-	if lb == nil && apiserverLB.Type == Internal {
+	podSubnet := networkSpec.PodSubnet
+	if podSubnet == nil {
 		return allErrs
 	}
 
-	if lb == nil {
-		allErrs = append(allErrs, field.Required(fldPath, "Node outbound load balancer cannot be nil for public clusters."))
+	if err := validateSubnetName(podSubnet.Name, fldPath.Child("name")); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	for _, subnet := range networkSpec.Subnets {
+		if subnet.Name == podSubnet.Name {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("name"), podSubnet.Name))
+		}
+	}
+	if networkSpec.Ingress != nil && networkSpec.Ingress.Subnet.Name == podSubnet.Name {
+		allErrs = append(allErrs, field.Duplicate(fldPath.Child("name"), podSubnet.Name))
+	}
+
+	if success, _ := regexp.MatchString(serviceDelegationRegex, podSubnet.ServiceDelegation); !success {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceDelegation"), podSubnet.ServiceDelegation,
+			fmt.Sprintf("service delegation doesn't match regex %s", serviceDelegationRegex)))
+	}
+
+	if podSubnet.MaxPodsPerNode < 1 || podSubnet.MaxPodsPerNode > MaxPodsPerNode {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxPodsPerNode"), podSubnet.MaxPodsPerNode,
+			fmt.Sprintf("max pods per node must be between 1 and %d", MaxPodsPerNode)))
+	}
+	if podSubnet.MaxNodes < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxNodes"), podSubnet.MaxNodes, "max nodes must be greater than 0"))
+	}
+
+	if len(podSubnet.CIDRBlocks) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("cidrBlocks"), "the pod subnet requires an address space"))
+		return allErrs
+	}
+	cidrErrs := validateSubnetCIDR(podSubnet.CIDRBlocks, networkSpec.Vnet.CIDRBlocks, fldPath.Child("cidrBlocks"))
+	allErrs = append(allErrs, cidrErrs...)
+	if len(cidrErrs) > 0 {
 		return allErrs
 	}
 
-	if old != nil && old.ID != lb.ID {
-		allErrs = append(allErrs, field.Forbidden(fldPath.Child("id"), "Node outbound load balancer ID should not be modified after AzureCluster creation."))
+	// Azure rejects a subnet whose address space overlaps the address space of another subnet of the virtual network.
+	otherSubnets := append([]SubnetSpec{}, networkSpec.Subnets...)
+	if networkSpec.Ingress != nil {
+		otherSubnets = append(otherSubnets, networkSpec.Ingress.Subnet)
+	}
+	for i, cidr := range podSubnet.CIDRBlocks {
+		for _, subnet := range otherSubnets {
+			for _, otherCIDR := range subnet.CIDRBlocks {
+				if cidrsOverlap(cidr, otherCIDR) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("cidrBlocks").Index(i), cidr,
+						fmt.Sprintf("the pod subnet overlaps the address prefix %s of subnet %s", otherCIDR, subnet.Name)))
+				}
+			}
+		}
 	}
 
-	if old != nil && old.Name != lb.Name {
-		allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "Node outbound load balancer Name should not be modified after AzureCluster creation."))
+	// the subnet is only sized for numbers of pods and nodes that are valid.
+	if podSubnet.MaxPodsPerNode < 1 || podSubnet.MaxNodes < 1 {
+		return allErrs
 	}
 
-	if old != nil && old.SKU != lb.SKU {
-		allErrs = append(allErrs, field.Forbidden(fldPath.Child("sku"), "Node outbound load balancer SKU should not be modified after AzureCluster creation."))
+	required := int64(podSubnet.MaxPodsPerNode) * int64(podSubnet.MaxNodes)
+	if available := subnetCapacity(podSubnet.CIDRBlocks); available < required {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cidrBlocks"), podSubnet.CIDRBlocks,
+			fmt.Sprintf("the pod subnet has %d usable addresses, fewer than the %d required for %d pods on each of %d nodes",
+				available, required, podSubnet.MaxPodsPerNode, podSubnet.MaxNodes)))
 	}
 
-	if old != nil && old.Type != lb.Type {
-		allErrs = append(allErrs, field.Forbidden(fldPath.Child("type"), "Node outbound load balancer Type cannot be modified after AzureCluster creation."))
+	return allErrs
+}
+
+// cidrsOverlap returns true if two address prefixes in CIDR notation share addresses, i.e. one of them contains the
+// first address of the other. Address prefixes that can't be parsed don't overlap.
+func cidrsOverlap(a, b string) bool {
+	_, aNet, err := net.ParseCIDR(a)
+	if err != nil {
+		return false
+	}
+	_, bNet, err := net.ParseCIDR(b)
+	if err != nil {
+		return false
 	}
+	return aNet.Contains(bNet.IP) || bNet.Contains(aNet.IP)
+}
 
-	if old != nil && !pointer.Int32Equal(old.IdleTimeoutInMinutes, lb.IdleTimeoutInMinutes) {
-		allErrs = append(allErrs, field.Forbidden(fldPath.Child("idleTimeoutInMinutes"), "Node outbound load balancer idle timeout cannot be modified after AzureCluster creation."))
+// subnetCapacity returns the number of addresses of the address prefixes of a subnet that Azure doesn't reserve,
+// capped to the maximum of an int64 for the address prefixes too large to run out of addresses, e.g. IPv6 ones.
+func subnetCapacity(cidrBlocks []string) int64 {
+	var total int64
+	for _, cidr := range cidrBlocks {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		ones, bits := ipNet.Mask.Size()
+		if bits-ones >= 62 {
+			return math.MaxInt64
+		}
+		if size := int64(1) << uint(bits-ones); size > azureReservedSubnetIPs {
+			total += size - azureReservedSubnetIPs
+		}
 	}
+	return total
+}
 
-	if old != nil && old.FrontendIPsCount == lb.FrontendIPsCount {
-		if len(old.FrontendIPs) != len(lb.FrontendIPs) {
-			allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPs"), "Node outbound load balancer FrontendIPs cannot be modified after AzureCluster creation."))
+// validateInternalLoadBalancers validates the internal load balancers fronting services of the cluster, and that they
+// don't clash with the load balancers of the API server and of the outbound traffic.
+func validateInternalLoadBalancers(networkSpec NetworkSpec, old []InternalLoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(networkSpec.InternalLoadBalancers) == 0 {
+		return allErrs
+	}
+
+	reservedNames := sets.NewString(networkSpec.APIServerLB.Name, fmt.Sprintf("%s-internal", networkSpec.APIServerLB.Name))
+	if networkSpec.NodeOutboundLB != nil {
+		reservedNames.Insert(networkSpec.NodeOutboundLB.Name)
+	}
+	if networkSpec.ControlPlaneOutboundLB != nil {
+		reservedNames.Insert(networkSpec.ControlPlaneOutboundLB.Name)
+	}
+	reservedIPs := sets.NewString()
+	for _, ip := range networkSpec.APIServerLB.FrontendIPs {
+		reservedIPs.Insert(ip.PrivateIPAddress)
+	}
+	if networkSpec.APIServerLB.InternalFrontendIP != nil {
+		reservedIPs.Insert(networkSpec.APIServerLB.InternalFrontendIP.PrivateIPAddress)
+	}
+	reservedIPs.Delete("")
+
+	oldIPs := make(map[string]string, len(old))
+	for _, lb := range old {
+		oldIPs[lb.Name] = lb.PrivateIP
+	}
+
+	names := sets.NewString()
+	ips := sets.NewString()
+	for i, lb := range networkSpec.InternalLoadBalancers {
+		lbPath := fldPath.Index(i)
+		if err := validateLoadBalancerName(lb.Name, lbPath.Child("name")); err != nil {
+			allErrs = append(allErrs, err)
 		}
+		if reservedNames.Has(lb.Name) {
+			allErrs = append(allErrs, field.Invalid(lbPath.Child("name"), lb.Name, "name is used by another load balancer of the cluster"))
+		}
+		if names.Has(lb.Name) {
+			allErrs = append(allErrs, field.Duplicate(lbPath.Child("name"), lb.Name))
+		}
+		names.Insert(lb.Name)
 
-		if len(old.FrontendIPs) == len(lb.FrontendIPs) {
-			for i, frontEndIP := range lb.FrontendIPs {
-				oldFrontendIP := old.FrontendIPs[i]
-				if oldFrontendIP.Name != frontEndIP.Name || *oldFrontendIP.PublicIP != *frontEndIP.PublicIP {
-					allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPs").Index(i),
-						"Node outbound load balancer FrontendIPs cannot be modified after AzureCluster creation."))
-				}
+		var subnet *SubnetSpec
+		for j := range networkSpec.Subnets {
+			if networkSpec.Subnets[j].Name == lb.SubnetName {
+				subnet = &networkSpec.Subnets[j]
+				break
 			}
 		}
+		switch {
+		case lb.SubnetName == "":
+			allErrs = append(allErrs, field.Required(lbPath.Child("subnetName"), "the subnet can only be defaulted when the cluster has a node subnet"))
+		case subnet == nil:
+			allErrs = append(allErrs, field.NotFound(lbPath.Child("subnetName"), lb.SubnetName))
+		case subnet.Role != SubnetNode:
+			allErrs = append(allErrs, field.Invalid(lbPath.Child("subnetName"), lb.SubnetName, "the backends of the load balancer are the nodes of a node subnet"))
+		}
+
+		ipPath := lbPath.Child("privateIP")
+		if ip := net.ParseIP(lb.PrivateIP); ip == nil {
+			allErrs = append(allErrs, field.Invalid(ipPath, lb.PrivateIP, "private IP isn't a valid IPv4 or IPv6 address"))
+		} else if subnet != nil && !cidrsContain(subnet.CIDRBlocks, ip) {
+			allErrs = append(allErrs, field.Invalid(ipPath, lb.PrivateIP, fmt.Sprintf("private IP needs to be in the subnet range (%s)", subnet.CIDRBlocks)))
+		}
+		if reservedIPs.Has(lb.PrivateIP) {
+			allErrs = append(allErrs, field.Invalid(ipPath, lb.PrivateIP, "private IP is used by the API server load balancer"))
+		}
+		if ips.Has(lb.PrivateIP) {
+			allErrs = append(allErrs, field.Duplicate(ipPath, lb.PrivateIP))
+		}
+		ips.Insert(lb.PrivateIP)
+		if oldIP, ok := oldIPs[lb.Name]; ok && oldIP != lb.PrivateIP {
+			allErrs = append(allErrs, field.Forbidden(ipPath, "the private IP of a load balancer is immutable"))
+		}
+
+		allErrs = append(allErrs, validateInternalLoadBalancerRules(lb.Rules, lbPath.Child("rules"))...)
+		if len(lb.Probes) == 0 {
+			allErrs = append(allErrs, validateInternalLoadBalancerProbe(lb.Probe, lbPath.Child("probe"))...)
+		}
+		allErrs = append(allErrs, validateInternalLoadBalancerProbes(lb, lbPath)...)
 	}
 
-	if lb.FrontendIPsCount != nil && *lb.FrontendIPsCount > MaxLoadBalancerOutboundIPs {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("frontendIPsCount"), *lb.FrontendIPsCount,
-			fmt.Sprintf("Max front end ips allowed is %d", MaxLoadBalancerOutboundIPs)))
+	return allErrs
+}
+
+// validateInternalLoadBalancerRules validates the load balancing rules of an internal load balancer fronting a service.
+func validateInternalLoadBalancerRules(rules []InternalLoadBalancerRule, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(rules) == 0 {
+		return append(allErrs, field.Required(fldPath, "the load balancer needs at least one rule"))
 	}
 
-	if lb.IdleTimeoutInMinutes != nil && (*lb.IdleTimeoutInMinutes < MinLBIdleTimeoutInMinutes || *lb.IdleTimeoutInMinutes > MaxLBIdleTimeoutInMinutes) {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("idleTimeoutInMinutes"), *lb.IdleTimeoutInMinutes,
-			fmt.Sprintf("Node outbound idle timeout should be between %d and %d minutes", MinLBIdleTimeoutInMinutes, MaxLoadBalancerOutboundIPs)))
+	names := sets.NewString()
+	frontendPorts := sets.NewString()
+	for i, rule := range rules {
+		rulePath := fldPath.Index(i)
+		if success, _ := regexp.MatchString(generatedNameRegex, rule.Name); !success {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("name"), rule.Name,
+				fmt.Sprintf("name of load balancing rule doesn't match regex %s", generatedNameRegex)))
+		}
+		if names.Has(rule.Name) {
+			allErrs = append(allErrs, field.Duplicate(rulePath.Child("name"), rule.Name))
+		}
+		names.Insert(rule.Name)
+		if rule.Protocol != LoadBalancerRuleProtocolTCP && rule.Protocol != LoadBalancerRuleProtocolUDP {
+			allErrs = append(allErrs, field.NotSupported(rulePath.Child("protocol"), rule.Protocol,
+				[]string{string(LoadBalancerRuleProtocolTCP), string(LoadBalancerRuleProtocolUDP)}))
+		}
+		if rule.FrontendPort < 1 || rule.FrontendPort > 65535 {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("frontendPort"), rule.FrontendPort, "port must be between 1 and 65535"))
+		}
+		if rule.BackendPort < 1 || rule.BackendPort > 65535 {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("backendPort"), rule.BackendPort, "port must be between 1 and 65535"))
+		}
+		frontendPort := fmt.Sprintf("%s/%d", rule.Protocol, rule.FrontendPort)
+		if frontendPorts.Has(frontendPort) {
+			allErrs = append(allErrs, field.Duplicate(rulePath.Child("frontendPort"), rule.FrontendPort))
+		}
+		frontendPorts.Insert(frontendPort)
 	}
 
 	return allErrs
 }
 
-func validateControlPlaneOutboundLB(lb *LoadBalancerSpec, apiserverLB LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+// validateInternalLoadBalancerProbes validates the named health probes of an internal load balancer fronting a service,
+// and that each rule is bound to one of them.
+func validateInternalLoadBalancerProbes(lb InternalLoadBalancerSpec, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
-	switch apiserverLB.Type {
-	case Public:
-		if lb != nil {
-			allErrs = append(allErrs, field.Forbidden(fldPath, "Control plane outbound load balancer cannot be set for public clusters."))
+	names := sets.NewString()
+	for i, probe := range lb.Probes {
+		probePath := fldPath.Child("probes").Index(i)
+		if success, _ := regexp.MatchString(generatedNameRegex, probe.Name); !success {
+			allErrs = append(allErrs, field.Invalid(probePath.Child("name"), probe.Name,
+				fmt.Sprintf("name of health probe doesn't match regex %s", generatedNameRegex)))
 		}
-	case Internal:
-		// Control plane outbound lb can be nil when it's disabled for private clusters.
-		if lb == nil {
-			return nil
+		if names.Has(probe.Name) {
+			allErrs = append(allErrs, field.Duplicate(probePath.Child("name"), probe.Name))
 		}
+		names.Insert(probe.Name)
+		allErrs = append(allErrs, validateInternalLoadBalancerProbe(probe, probePath)...)
+	}
 
-		if lb.FrontendIPsCount != nil && *lb.FrontendIPsCount > MaxLoadBalancerOutboundIPs {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("frontendIPsCount"), *lb.FrontendIPsCount,
-				fmt.Sprintf("Max front end ips allowed is %d", MaxLoadBalancerOutboundIPs)))
+	for i, rule := range lb.Rules {
+		if rule.ProbeName == "" {
+			continue
+		}
+		probeNamePath := fldPath.Child("rules").Index(i).Child("probeName")
+		switch {
+		case len(lb.Probes) == 0:
+			allErrs = append(allErrs, field.Invalid(probeNamePath, rule.ProbeName, "the load balancer has no named probes, the rules are bound to its probe"))
+		case !names.Has(rule.ProbeName):
+			allErrs = append(allErrs, field.NotFound(probeNamePath, rule.ProbeName))
 		}
+	}
 
-		if lb.IdleTimeoutInMinutes != nil && (*lb.IdleTimeoutInMinutes < MinLBIdleTimeoutInMinutes || *lb.IdleTimeoutInMinutes > MaxLBIdleTimeoutInMinutes) {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("idleTimeoutInMinutes"), *lb.IdleTimeoutInMinutes,
-				fmt.Sprintf("Control plane outbound idle timeout should be between %d and %d minutes", MinLBIdleTimeoutInMinutes, MaxLoadBalancerOutboundIPs)))
+	return allErrs
+}
+
+// validateInternalLoadBalancerProbe validates a health probe of an internal load balancer fronting a service.
+func validateInternalLoadBalancerProbe(probe InternalLoadBalancerProbe, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch probe.Protocol {
+	case ProbeProtocolTCP:
+	case ProbeProtocolHTTP, ProbeProtocolHTTPS:
+		if !strings.HasPrefix(probe.RequestPath, "/") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("requestPath"), probe.RequestPath, "request path must start with /"))
 		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("protocol"), probe.Protocol,
+			[]string{string(ProbeProtocolTCP), string(ProbeProtocolHTTP), string(ProbeProtocolHTTPS)}))
+	}
+	if probe.Port < 1 || probe.Port > 65535 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("port"), probe.Port, "port must be between 1 and 65535"))
+	}
+	if probe.IntervalInSeconds != 0 && probe.IntervalInSeconds < 5 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("intervalInSeconds"), probe.IntervalInSeconds, "interval must be at least 5 seconds"))
+	}
+	if probe.NumberOfProbes < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("numberOfProbes"), probe.NumberOfProbes, "number of probes must be at least 1"))
 	}
 
 	return allErrs
 }
 
-// validatePrivateDNSZoneName validate the PrivateDNSZoneName.
-func validatePrivateDNSZoneName(networkSpec NetworkSpec, fldPath *field.Path) field.ErrorList {
+// cidrsContain returns true if one of the CIDRs contains the IP.
+func cidrsContain(cidrs []string, ip net.IP) bool {
+	for _, cidr := range cidrs {
+		if _, subnet, err := net.ParseCIDR(cidr); err == nil && subnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateNetworkInterfaceSecurityGroups validates the security groups of the network interfaces of the machines. When
+// they are attached along with the security groups of the subnets, a rule can't have the same name as a rule of a
+// subnet of the same role with a different definition.
+func validateNetworkInterfaceSecurityGroups(nicSecurityGroups *NetworkInterfaceSecurityGroups, subnets Subnets, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
-	if len(networkSpec.PrivateDNSZoneName) > 0 {
-		if networkSpec.APIServerLB.Type != Internal {
-			allErrs = append(allErrs, field.Invalid(fldPath, networkSpec.APIServerLB.Type,
-				"PrivateDNSZoneName is available only if APIServerLB.Type is Internal"))
+	if nicSecurityGroups == nil {
+		return allErrs
+	}
+
+	subnetSecurityGroupNames := sets.NewString()
+	for _, subnet := range subnets {
+		subnetSecurityGroupNames.Insert(subnet.SecurityGroup.Name)
+	}
+
+	for _, role := range []SubnetRole{SubnetControlPlane, SubnetNode} {
+		sg, sgPath := nicSecurityGroups.ControlPlane, fldPath.Child("controlPlane")
+		if role == SubnetNode {
+			sg, sgPath = nicSecurityGroups.Node, fldPath.Child("node")
 		}
-		if !valid.IsDNSName(networkSpec.PrivateDNSZoneName) {
-			allErrs = append(allErrs, field.Invalid(fldPath, networkSpec.PrivateDNSZoneName,
-				"PrivateDNSZoneName can only contain alphanumeric characters, underscores and dashes, must end with an alphanumeric character",
-			))
+
+		if sg.Name == "" {
+			allErrs = append(allErrs, field.Required(sgPath.Child("name"), "name of the security group is required"))
+		}
+		allErrs = append(allErrs, validateGeneratedName(sg.Name, networkResourceMaxLength, sgPath.Child("name"))...)
+		if subnetSecurityGroupNames.Has(sg.Name) {
+			allErrs = append(allErrs, field.Duplicate(sgPath.Child("name"), sg.Name))
+		}
+
+		ruleNames := sets.NewString()
+		for i, rule := range sg.SecurityRules {
+			rulePath := sgPath.Child("securityRules").Index(i)
+			if err := validateSecurityRule(rule, rulePath); err != nil {
+				allErrs = append(allErrs, err)
+			}
+			if ruleNames.Has(rule.Name) {
+				allErrs = append(allErrs, field.Duplicate(rulePath.Child("name"), rule.Name))
+			}
+			ruleNames.Insert(rule.Name)
+
+			if nicSecurityGroups.Attachment != SecurityGroupAttachmentSubnetAndNetworkInterface {
+				continue
+			}
+			for _, subnet := range subnets {
+				if subnet.Role != role {
+					continue
+				}
+				for _, subnetRule := range subnet.SecurityGroup.SecurityRules {
+					if subnetRule.Name == rule.Name && !reflect.DeepEqual(subnetRule, rule) {
+						allErrs = append(allErrs, field.Invalid(rulePath, rule.Name,
+							fmt.Sprintf("security rule contradicts the rule of the same name of the security group of subnet %s", subnet.Name)))
+					}
+				}
+			}
 		}
+		allErrs = append(allErrs, validateInboundTrafficIntents(sg, sgPath)...)
+		allErrs = append(allErrs, validateEgressPolicy(sg, sgPath)...)
+		allErrs = append(allErrs, validateDiagnosticSettings(sg.DiagnosticSettings, sgPath.Child("diagnosticSettings"))...)
 	}
-	if len(allErrs) == 0 {
-		return nil
+	if nicSecurityGroups.ControlPlane.Name != "" && nicSecurityGroups.ControlPlane.Name == nicSecurityGroups.Node.Name {
+		allErrs = append(allErrs, field.Duplicate(fldPath.Child("node", "name"), nicSecurityGroups.Node.Name))
+	}
+
+	return allErrs
+}
+
+// validateOutboundConnectivityCheck validates an OutboundConnectivityCheck.
+func validateOutboundConnectivityCheck(check *OutboundConnectivityCheck, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if check == nil {
+		return allErrs
+	}
+
+	if ip := net.ParseIP(check.DestinationIP); ip == nil || ip.To4() == nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("destinationIP"), check.DestinationIP, "destinationIP must be a valid IPv4 address"))
+	}
+
+	if check.Port < 1 || check.Port > 65535 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("port"), check.Port, "port must be between 1 and 65535"))
+	}
+
+	if check.NetworkWatcherName != "" {
+		if success, _ := regexp.MatchString(networkWatcherRegex, check.NetworkWatcherName); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("networkWatcherName"), check.NetworkWatcherName,
+				fmt.Sprintf("networkWatcherName doesn't match regex %s", networkWatcherRegex)))
+		}
+	}
+
+	if check.NetworkWatcherResourceGroup != "" {
+		if success, _ := regexp.MatchString(resourceGroupRegex, check.NetworkWatcherResourceGroup); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("networkWatcherResourceGroup"), check.NetworkWatcherResourceGroup,
+				fmt.Sprintf("networkWatcherResourceGroup doesn't match regex %s", resourceGroupRegex)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateBastionSpec validates a BastionSpec.
+func validateBastionSpec(bastion BastionSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if bastion.AzureBastion != nil {
+		allErrs = append(allErrs, validateRegionalPublicIP(bastion.AzureBastion.PublicIP, fldPath.Child("azureBastion", "publicIP"))...)
+		allErrs = append(allErrs, forbidPublicIPPrefix(bastion.AzureBastion.PublicIP, fldPath.Child("azureBastion", "publicIP"))...)
+	}
+
+	if bastion.Jumpbox == nil {
+		return allErrs
+	}
+
+	if bastion.AzureBastion != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("jumpbox"), "jumpbox and azureBastion are mutually exclusive"))
 	}
 
+	allErrs = append(allErrs, validateJumpbox(*bastion.Jumpbox, fldPath.Child("jumpbox"))...)
+
+	return allErrs
+}
+
+// validateJumpbox validates a Jumpbox.
+func validateJumpbox(jumpbox Jumpbox, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if jumpbox.SSHPublicKey == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("sshPublicKey"), "an SSH public key is required to log in to the jumpbox"))
+	} else if _, err := base64.StdEncoding.DecodeString(jumpbox.SSHPublicKey); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("sshPublicKey"), jumpbox.SSHPublicKey, "sshPublicKey must be base64 encoded"))
+	}
+
+	if len(jumpbox.AllowedSourceCIDRs) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("allowedSourceCIDRs"), "at least one source CIDR must be allowed to reach the jumpbox"))
+	}
+	for i, cidr := range jumpbox.AllowedSourceCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("allowedSourceCIDRs").Index(i), cidr, "invalid CIDR format"))
+		}
+	}
+
+	if err := validateSubnetName(jumpbox.Subnet.Name, fldPath.Child("subnet").Child("name")); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, validateDiagnosticSettings(jumpbox.Subnet.SecurityGroup.DiagnosticSettings, fldPath.Child("subnet", "securityGroup", "diagnosticSettings"))...)
+	allErrs = append(allErrs, validateRegionalPublicIP(jumpbox.PublicIP, fldPath.Child("publicIP"))...)
+	allErrs = append(allErrs, forbidPublicIPPrefix(jumpbox.PublicIP, fldPath.Child("publicIP"))...)
+
 	return allErrs
 }
 
@@ -17,9 +17,15 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
@@ -409,112 +415,1448 @@ func TestValidateVnetCIDR(t *testing.T) {
 	}
 }
 
-func TestSubnetsValid(t *testing.T) {
+func TestValidateVnetDNSServers(t *testing.T) {
 	g := NewWithT(t)
 
-	type test struct {
-		name    string
-		subnets Subnets
+	tests := []struct {
+		name        string
+		dnsServers  []string
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name:    "no dns servers",
+			wantErr: false,
+		},
+		{
+			name:       "valid dns servers",
+			dnsServers: []string{"10.0.0.4", "2001:db8::53"},
+			wantErr:    false,
+		},
+		{
+			name:       "dns server is not an ip address",
+			dnsServers: []string{"10.0.0.4", "dns.example.com"},
+			wantErr:    true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "vnet.dnsServers[1]",
+				BadValue: "dns.example.com",
+				Detail:   "DNS server must be an IP address",
+			},
+		},
 	}
-
-	testCase := test{
-		name:    "subnets - valid",
-		subnets: createValidSubnets(),
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateVnetDNSServers(testCase.dnsServers, field.NewPath("vnet", "dnsServers"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
 	}
-
-	t.Run(testCase.name, func(t *testing.T) {
-		errs := validateSubnets(testCase.subnets, createValidVnet(),
-			field.NewPath("spec").Child("networkSpec").Child("subnets"))
-		g.Expect(errs).To(BeNil())
-	})
 }
 
-func TestSubnetsInvalidSubnetName(t *testing.T) {
+func TestValidateFirewallRoutes(t *testing.T) {
 	g := NewWithT(t)
 
-	type test struct {
-		name    string
-		subnets Subnets
+	tests := []struct {
+		name        string
+		subnet      SubnetSpec
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name:    "node subnet without a firewall route",
+			subnet:  SubnetSpec{SubnetClassSpec: SubnetClassSpec{Role: SubnetNode}},
+			wantErr: false,
+		},
+		{
+			name: "node subnet with a firewall route",
+			subnet: SubnetSpec{
+				SubnetClassSpec: SubnetClassSpec{Role: SubnetNode},
+				FirewallRoute:   &FirewallRoute{PrivateIP: "10.100.0.4"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "firewall private IP is not an IPv4 address",
+			subnet: SubnetSpec{
+				SubnetClassSpec: SubnetClassSpec{Role: SubnetNode},
+				FirewallRoute:   &FirewallRoute{PrivateIP: "2001:db8::4"},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[0].firewallRoute.privateIP",
+				BadValue: "2001:db8::4",
+				Detail:   "firewall private IP must be an IPv4 address",
+			},
+		},
+		{
+			name: "control plane subnet with a firewall route",
+			subnet: SubnetSpec{
+				SubnetClassSpec: SubnetClassSpec{Role: SubnetControlPlane},
+				FirewallRoute:   &FirewallRoute{PrivateIP: "10.100.0.4"},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "subnets[0].firewallRoute",
+				Detail: "only the egress of node subnets can be routed to a firewall",
+			},
+		},
+		{
+			name: "node subnet with a firewall route and a NAT gateway",
+			subnet: SubnetSpec{
+				SubnetClassSpec: SubnetClassSpec{Role: SubnetNode},
+				NatGateway:      NatGateway{Name: "nat-gw"},
+				FirewallRoute:   &FirewallRoute{PrivateIP: "10.100.0.4"},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "subnets[0].firewallRoute",
+				Detail: "the default route to a firewall takes precedence over the NAT gateway of the subnet",
+			},
+		},
 	}
-
-	testCase := test{
-		name:    "subnets - invalid subnet name",
-		subnets: createValidSubnets(),
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateFirewallRoutes(Subnets{testCase.subnet}, field.NewPath("subnets"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
 	}
-
-	testCase.subnets[0].Name = "invalid-subnet-name-due-to-bracket)"
-
-	t.Run(testCase.name, func(t *testing.T) {
-		errs := validateSubnets(testCase.subnets, createValidVnet(),
-			field.NewPath("spec").Child("networkSpec").Child("subnets"))
-		g.Expect(errs).To(HaveLen(1))
-		g.Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
-		g.Expect(errs[0].Field).To(Equal("spec.networkSpec.subnets[0].name"))
-		g.Expect(errs[0].BadValue).To(BeEquivalentTo("invalid-subnet-name-due-to-bracket)"))
-	})
 }
 
-func TestSubnetsInvalidLackRequiredSubnet(t *testing.T) {
+func TestValidateNatGateways(t *testing.T) {
 	g := NewWithT(t)
 
-	type test struct {
-		name    string
-		subnets Subnets
+	tests := []struct {
+		name        string
+		natGateway  NatGateway
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "default public IP",
+			natGateway: NatGateway{
+				Name: "nat-gw",
+			},
+			wantErr: false,
+		},
+		{
+			name: "public IPs and prefix",
+			natGateway: NatGateway{
+				Name:                 "nat-gw",
+				NatGatewayIPCount:    pointer.Int32(2),
+				NatGatewayIPPrefix:   &PublicIPPrefixSpec{Name: "nat-gw-prefix", PrefixLength: pointer.Int32(30)},
+				IdleTimeoutInMinutes: pointer.Int32(120),
+			},
+			wantErr: false,
+		},
+		{
+			name: "prefix only",
+			natGateway: NatGateway{
+				Name:               "nat-gw",
+				NatGatewayIPCount:  pointer.Int32(0),
+				NatGatewayIPPrefix: &PublicIPPrefixSpec{Name: "nat-gw-prefix", PrefixLength: pointer.Int32(28)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "idle timeout out of range",
+			natGateway: NatGateway{
+				Name:                 "nat-gw",
+				IdleTimeoutInMinutes: pointer.Int32(121),
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[0].natGateway.idleTimeoutInMinutes",
+				BadValue: int32(121),
+				Detail:   "NAT gateway idle timeout should be between 4 and 120 minutes",
+			},
+		},
+		{
+			name: "no public IP nor prefix",
+			natGateway: NatGateway{
+				Name:              "nat-gw",
+				NatGatewayIPCount: pointer.Int32(0),
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[0].natGateway.ipCount",
+				BadValue: int32(0),
+				Detail:   "NAT gateway needs at least one public IP or a public IP prefix",
+			},
+		},
+		{
+			name: "too many public IPs",
+			natGateway: NatGateway{
+				Name:               "nat-gw",
+				NatGatewayIPCount:  pointer.Int32(1),
+				NatGatewayIPPrefix: &PublicIPPrefixSpec{Name: "nat-gw-prefix", PrefixLength: pointer.Int32(28)},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[0].natGateway.ipCount",
+				BadValue: int32(1),
+				Detail:   "NAT gateway public IPs and public IP prefix addresses should not exceed 16",
+			},
+		},
 	}
-
-	testCase := test{
-		name:    "subnets - lack required subnet",
-		subnets: createValidSubnets(),
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			subnets := Subnets{{SubnetClassSpec: SubnetClassSpec{Role: SubnetNode}, Name: "node-subnet", NatGateway: testCase.natGateway}}
+			err := validateNatGateways(subnets, field.NewPath("subnets"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
 	}
-
-	testCase.subnets[0].Role = "random-role"
-
-	t.Run(testCase.name, func(t *testing.T) {
-		errs := validateSubnets(testCase.subnets, createValidVnet(),
-			field.NewPath("spec").Child("networkSpec").Child("subnets"))
-		g.Expect(errs).To(HaveLen(1))
-		g.Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
-		g.Expect(errs[0].Field).To(Equal("spec.networkSpec.subnets"))
-		g.Expect(errs[0].Detail).To(ContainSubstring("required role control-plane not included"))
-	})
 }
 
-func TestSubnetNamesNotUnique(t *testing.T) {
+func TestValidateControlPlaneNatGateway(t *testing.T) {
 	g := NewWithT(t)
 
-	type test struct {
-		name    string
-		subnets Subnets
+	cpSubnet := func(natGateway NatGateway) SubnetSpec {
+		return SubnetSpec{SubnetClassSpec: SubnetClassSpec{Role: SubnetControlPlane}, Name: "cp-subnet", NatGateway: natGateway}
 	}
-
-	testCase := test{
-		name:    "subnets - names not unique",
-		subnets: createValidSubnets(),
+	nodeSubnet := func(natGateway NatGateway) SubnetSpec {
+		return SubnetSpec{SubnetClassSpec: SubnetClassSpec{Role: SubnetNode}, Name: "node-subnet", NatGateway: natGateway}
 	}
 
-	testCase.subnets[0].Name = "subnet-name"
-	testCase.subnets[1].Name = "subnet-name"
-
-	t.Run(testCase.name, func(t *testing.T) {
-		errs := validateSubnets(testCase.subnets, createValidVnet(),
-			field.NewPath("spec").Child("networkSpec").Child("subnets"))
-		g.Expect(errs).To(HaveLen(1))
-		g.Expect(errs[0].Type).To(Equal(field.ErrorTypeDuplicate))
-		g.Expect(errs[0].Field).To(Equal("spec.networkSpec.subnets"))
-	})
+	tests := []struct {
+		name        string
+		networkSpec NetworkSpec
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "no control plane NAT gateway",
+			networkSpec: NetworkSpec{
+				Subnets:                Subnets{cpSubnet(NatGateway{}), nodeSubnet(NatGateway{Name: "node-natgw"})},
+				ControlPlaneOutboundLB: &LoadBalancerSpec{Name: "cp-outbound"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "control plane NAT gateway independent of the node NAT gateway",
+			networkSpec: NetworkSpec{
+				Subnets: Subnets{
+					cpSubnet(NatGateway{Name: "cp-natgw", NatGatewayIPPrefix: &PublicIPPrefixSpec{Name: "cp-prefix"}}),
+					nodeSubnet(NatGateway{Name: "node-natgw", NatGatewayIPPrefix: &PublicIPPrefixSpec{Name: "node-prefix"}}),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "control plane NAT gateway shared with a node subnet",
+			networkSpec: NetworkSpec{
+				Subnets: Subnets{cpSubnet(NatGateway{Name: "natgw"}), nodeSubnet(NatGateway{Name: "natgw"})},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[0].natGateway.name",
+				BadValue: "natgw",
+				Detail:   "the NAT gateway of the control plane subnet can't be shared with node subnet node-subnet",
+			},
+		},
+		{
+			name: "control plane NAT gateway prefix shared with a node subnet",
+			networkSpec: NetworkSpec{
+				Subnets: Subnets{
+					cpSubnet(NatGateway{Name: "cp-natgw", NatGatewayIPPrefix: &PublicIPPrefixSpec{Name: "prefix"}}),
+					nodeSubnet(NatGateway{Name: "node-natgw", NatGatewayIPPrefix: &PublicIPPrefixSpec{Name: "prefix"}}),
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[0].natGateway.ipPrefix.name",
+				BadValue: "prefix",
+				Detail:   "the public IP prefix of the control plane NAT gateway can't be shared with node subnet node-subnet",
+			},
+		},
+		{
+			name: "control plane NAT gateway with a control plane outbound load balancer",
+			networkSpec: NetworkSpec{
+				Subnets:                Subnets{cpSubnet(NatGateway{Name: "cp-natgw"})},
+				ControlPlaneOutboundLB: &LoadBalancerSpec{Name: "cp-outbound"},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "controlPlaneOutboundLB",
+				Detail: "the control plane outbound load balancer conflicts with the NAT gateway of the control plane subnet, which takes precedence over its outbound rule",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateControlPlaneNatGateway(testCase.networkSpec, nil)
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
 }
 
-func TestSubnetNameValid(t *testing.T) {
+func TestValidateDeleteGracePeriod(t *testing.T) {
 	g := NewWithT(t)
 
-	type test struct {
-		name       string
-		subnetName string
+	tests := []struct {
+		name        string
+		gracePeriod *metav1.Duration
+		wantErr     bool
+	}{
+		{
+			name:    "no grace period",
+			wantErr: false,
+		},
+		{
+			name:        "positive grace period",
+			gracePeriod: &metav1.Duration{Duration: 30 * time.Minute},
+			wantErr:     false,
+		},
+		{
+			name:        "negative grace period",
+			gracePeriod: &metav1.Duration{Duration: -time.Minute},
+			wantErr:     true,
+		},
 	}
-
-	testCase := test{
-		name:       "subnet name - valid",
-		subnetName: "control-plane-subnet",
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateDeleteGracePeriod(testCase.gracePeriod, field.NewPath("spec", "deleteGracePeriod"))
+			if testCase.wantErr {
+				g.Expect(err).To(HaveLen(1))
+				g.Expect(err[0].Detail).To(Equal("delete grace period must not be negative"))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestValidateConnectionDraining(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name     string
+		draining *ConnectionDraining
+		wantErr  bool
+	}{
+		{
+			name:    "no connection draining",
+			wantErr: false,
+		},
+		{
+			name:     "drain timeout",
+			draining: &ConnectionDraining{Timeout: metav1.Duration{Duration: 2 * time.Minute}, EnableTCPReset: pointer.Bool(true)},
+			wantErr:  false,
+		},
+		{
+			name:     "drain timeout of an hour",
+			draining: &ConnectionDraining{Timeout: metav1.Duration{Duration: time.Hour}},
+			wantErr:  false,
+		},
+		{
+			name:     "no drain timeout",
+			draining: &ConnectionDraining{EnableTCPReset: pointer.Bool(true)},
+			wantErr:  true,
+		},
+		{
+			name:     "drain timeout too long",
+			draining: &ConnectionDraining{Timeout: metav1.Duration{Duration: 2 * time.Hour}},
+			wantErr:  true,
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateConnectionDraining(testCase.draining, field.NewPath("apiServerLB", "connectionDraining"))
+			if testCase.wantErr {
+				g.Expect(err).To(HaveLen(1))
+				g.Expect(err[0].Field).To(Equal("apiServerLB.connectionDraining.timeout"))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestValidateSpotPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name    string
+		policy  *SpotPolicy
+		wantErr bool
+	}{
+		{
+			name:    "no policy",
+			wantErr: false,
+		},
+		{
+			name:    "max price capped at the pay-as-you-go price",
+			policy:  &SpotPolicy{MaxPrice: resource.NewQuantity(-1, resource.DecimalSI), EvictionPolicy: SpotEvictionPolicyDelete},
+			wantErr: false,
+		},
+		{
+			name:    "max price with 5 decimal places",
+			policy:  &SpotPolicy{MaxPrice: resourceQuantityPtr("0.00015"), EvictionPolicy: SpotEvictionPolicyDeallocate},
+			wantErr: false,
+		},
+		{
+			name:    "zero max price",
+			policy:  &SpotPolicy{MaxPrice: resourceQuantityPtr("0")},
+			wantErr: true,
+		},
+		{
+			name:    "negative max price",
+			policy:  &SpotPolicy{MaxPrice: resourceQuantityPtr("-0.5")},
+			wantErr: true,
+		},
+		{
+			name:    "max price with more than 5 decimal places",
+			policy:  &SpotPolicy{MaxPrice: resourceQuantityPtr("0.000001")},
+			wantErr: true,
+		},
+		{
+			name:    "unknown eviction policy",
+			policy:  &SpotPolicy{EvictionPolicy: "Hibernate"},
+			wantErr: true,
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := ValidateSpotPolicy(testCase.policy, field.NewPath("spec", "defaultSpotPolicy"))
+			if testCase.wantErr {
+				g.Expect(err).To(HaveLen(1))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestValidateAutoShutdownSchedule(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name     string
+		schedule *AutoShutdownSchedule
+		wantErr  string
+	}{
+		{
+			name: "no schedule",
+		},
+		{
+			name:     "schedule in UTC",
+			schedule: &AutoShutdownSchedule{Time: "19:30"},
+		},
+		{
+			name: "schedule with a time zone and notifications",
+			schedule: &AutoShutdownSchedule{
+				Time:     "00:00",
+				TimeZone: "Europe/Paris",
+				Notification: &AutoShutdownNotification{
+					MinutesBefore: pointer.Int32(15),
+					Email:         "dev-team@example.com",
+					WebhookURL:    "https://example.com/hooks/shutdown",
+				},
+			},
+		},
+		{
+			name:     "time in the 12-hour format",
+			schedule: &AutoShutdownSchedule{Time: "7:30PM"},
+			wantErr:  "time must be in the 24-hour HH:MM format",
+		},
+		{
+			name:     "time without leading zero",
+			schedule: &AutoShutdownSchedule{Time: "7:30"},
+			wantErr:  "time must be in the 24-hour HH:MM format",
+		},
+		{
+			name:     "out of range time",
+			schedule: &AutoShutdownSchedule{Time: "24:00"},
+			wantErr:  "time must be in the 24-hour HH:MM format",
+		},
+		{
+			name:     "unknown time zone",
+			schedule: &AutoShutdownSchedule{Time: "19:30", TimeZone: "Pacific Standard Time"},
+			wantErr:  "time zone must be an IANA time zone",
+		},
+		{
+			name:     "local time zone",
+			schedule: &AutoShutdownSchedule{Time: "19:30", TimeZone: "Local"},
+			wantErr:  "time zone must be an IANA time zone",
+		},
+		{
+			name:     "notification without recipient",
+			schedule: &AutoShutdownSchedule{Time: "19:30", Notification: &AutoShutdownNotification{MinutesBefore: pointer.Int32(30)}},
+			wantErr:  "an email or a webhook URL is required",
+		},
+		{
+			name:     "notification too early",
+			schedule: &AutoShutdownSchedule{Time: "19:30", Notification: &AutoShutdownNotification{MinutesBefore: pointer.Int32(180), Email: "dev-team@example.com"}},
+			wantErr:  "must be between 5 and 120",
+		},
+		{
+			name:     "invalid email",
+			schedule: &AutoShutdownSchedule{Time: "19:30", Notification: &AutoShutdownNotification{Email: "dev-team"}},
+			wantErr:  "must be an email address",
+		},
+		{
+			name:     "plain HTTP webhook",
+			schedule: &AutoShutdownSchedule{Time: "19:30", Notification: &AutoShutdownNotification{WebhookURL: "http://example.com/hooks/shutdown"}},
+			wantErr:  "must be an HTTPS URL",
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := ValidateAutoShutdownSchedule(testCase.schedule, field.NewPath("spec", "autoShutdown"))
+			if testCase.wantErr != "" {
+				g.Expect(err).To(HaveLen(1))
+				g.Expect(err.ToAggregate().Error()).To(ContainSubstring(testCase.wantErr))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func resourceQuantityPtr(value string) *resource.Quantity {
+	quantity := resource.MustParse(value)
+	return &quantity
+}
+
+func TestValidateLogAnalyticsWorkspace(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name      string
+		workspace *LogAnalyticsWorkspace
+		wantErr   string
+	}{
+		{
+			name: "no workspace",
+		},
+		{
+			name:      "valid workspace name",
+			workspace: &LogAnalyticsWorkspace{Name: "my-cluster-workspace"},
+		},
+		{
+			name:      "invalid workspace name",
+			workspace: &LogAnalyticsWorkspace{Name: "my_workspace"},
+			wantErr:   "name of Log Analytics workspace doesn't match regex ^[a-zA-Z0-9][-a-zA-Z0-9]{2,61}[a-zA-Z0-9]$",
+		},
+		{
+			name:      "valid workspace ID",
+			workspace: &LogAnalyticsWorkspace{ID: "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.OperationalInsights/workspaces/shared-workspace"},
+		},
+		{
+			name:      "invalid workspace ID",
+			workspace: &LogAnalyticsWorkspace{ID: "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"},
+			wantErr:   "must be the resource ID of a Log Analytics workspace",
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateLogAnalyticsWorkspace(testCase.workspace, field.NewPath("spec", "logAnalyticsWorkspace"))
+			if testCase.wantErr != "" {
+				g.Expect(err).To(HaveLen(1))
+				g.Expect(err[0].Detail).To(Equal(testCase.wantErr))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestValidateInboundTrafficIntents(t *testing.T) {
+	g := NewWithT(t)
+
+	manyPorts := make([]string, 1097)
+	for i := range manyPorts {
+		manyPorts[i] = strconv.Itoa(10000 + i)
+	}
+
+	tests := []struct {
+		name    string
+		sg      SecurityGroup
+		wantErr string
+	}{
+		{
+			name: "no intents",
+			sg:   SecurityGroup{Name: "my-nsg"},
+		},
+		{
+			name: "valid intents",
+			sg: SecurityGroup{Name: "my-nsg", SecurityGroupClass: SecurityGroupClass{AllowInboundFrom: []InboundTrafficIntent{
+				{Source: "192.168.0.0/16", Ports: []string{"80", "443"}},
+				{Source: "AzureCloud.EastUS", Ports: []string{"30000-32767"}, Protocol: SecurityGroupProtocolUDP},
+				{Source: "*", Ports: []string{"*"}, Protocol: SecurityGroupProtocolICMP},
+				{Source: "10.0.0.4", Ports: []string{"22"}},
+			}}},
+		},
+		{
+			name: "invalid source",
+			sg: SecurityGroup{Name: "my-nsg", SecurityGroupClass: SecurityGroupClass{AllowInboundFrom: []InboundTrafficIntent{
+				{Source: "10.0.0.0/33", Ports: []string{"443"}},
+			}}},
+			wantErr: "must be a CIDR, an IP address, a service tag or *",
+		},
+		{
+			name: "invalid port range",
+			sg: SecurityGroup{Name: "my-nsg", SecurityGroupClass: SecurityGroupClass{AllowInboundFrom: []InboundTrafficIntent{
+				{Source: "10.0.0.0/8", Ports: []string{"8080-8000"}},
+			}}},
+			wantErr: "must be a port, a port range or *",
+		},
+		{
+			name: "duplicate intent",
+			sg: SecurityGroup{Name: "my-nsg", SecurityGroupClass: SecurityGroupClass{AllowInboundFrom: []InboundTrafficIntent{
+				{Source: "10.0.0.0/8", Ports: []string{"443"}},
+				{Source: "10.0.0.0/8", Ports: []string{"443"}, Protocol: SecurityGroupProtocolTCP},
+			}}},
+			wantErr: "Duplicate value",
+		},
+		{
+			name: "reserved security rule name",
+			sg: SecurityGroup{Name: "my-nsg", SecurityGroupClass: SecurityGroupClass{SecurityRules: SecurityRules{
+				{Name: "allow_inbound_from_0_0", Priority: 200, Direction: SecurityRuleDirectionInbound},
+			}}},
+			wantErr: "the allow_inbound_from_ prefix is reserved for the security rules generated from allowInboundFrom",
+		},
+		{
+			name: "generated rules don't fit in the remaining priorities",
+			sg: SecurityGroup{Name: "my-nsg", SecurityGroupClass: SecurityGroupClass{
+				SecurityRules: SecurityRules{
+					{Name: "deny_all", Priority: 4096, Direction: SecurityRuleDirectionInbound},
+				},
+				AllowInboundFrom: []InboundTrafficIntent{
+					{Source: "10.0.0.0/8", Ports: manyPorts},
+				},
+			}},
+			wantErr: "Too many: 1097: must have at most 1096 items",
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateInboundTrafficIntents(testCase.sg, field.NewPath("spec", "networkSpec", "subnets").Index(0).Child("securityGroup"))
+			if testCase.wantErr != "" {
+				g.Expect(err).To(HaveLen(1))
+				g.Expect(err.ToAggregate().Error()).To(ContainSubstring(testCase.wantErr))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestValidateEgressPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	manyPorts := make([]string, 1093)
+	for i := range manyPorts {
+		manyPorts[i] = strconv.Itoa(10000 + i)
+	}
+
+	tests := []struct {
+		name    string
+		sg      SecurityGroup
+		wantErr string
+	}{
+		{
+			name: "no egress policy",
+			sg:   SecurityGroup{Name: "my-nsg"},
+		},
+		{
+			name: "valid allowlist",
+			sg: SecurityGroup{Name: "my-nsg", SecurityGroupClass: SecurityGroupClass{
+				EgressPolicy: EgressPolicyDenyByDefault,
+				AllowOutboundTo: []OutboundTrafficIntent{
+					{Destination: "MicrosoftContainerRegistry", Ports: []string{"443"}},
+					{Destination: "Storage.EastUS", Ports: []string{"443"}},
+					{Destination: "203.0.113.0/24", Ports: []string{"123"}, Protocol: SecurityGroupProtocolUDP},
+				},
+			}},
+		},
+		{
+			name: "allowlist without the DenyByDefault policy",
+			sg: SecurityGroup{Name: "my-nsg", SecurityGroupClass: SecurityGroupClass{
+				EgressPolicy: EgressPolicyAllowAll,
+				AllowOutboundTo: []OutboundTrafficIntent{
+					{Destination: "MicrosoftContainerRegistry", Ports: []string{"443"}},
+				},
+			}},
+			wantErr: "is only supported with the DenyByDefault egress policy",
+		},
+		{
+			name: "invalid destination",
+			sg: SecurityGroup{Name: "my-nsg", SecurityGroupClass: SecurityGroupClass{
+				EgressPolicy: EgressPolicyDenyByDefault,
+				AllowOutboundTo: []OutboundTrafficIntent{
+					{Destination: "https://mcr.microsoft.com", Ports: []string{"443"}},
+				},
+			}},
+			wantErr: "must be a CIDR, an IP address, a service tag or *",
+		},
+		{
+			name: "invalid port",
+			sg: SecurityGroup{Name: "my-nsg", SecurityGroupClass: SecurityGroupClass{
+				EgressPolicy: EgressPolicyDenyByDefault,
+				AllowOutboundTo: []OutboundTrafficIntent{
+					{Destination: "AzureMonitor", Ports: []string{"https"}},
+				},
+			}},
+			wantErr: "must be a port, a port range or *",
+		},
+		{
+			name: "duplicate destination",
+			sg: SecurityGroup{Name: "my-nsg", SecurityGroupClass: SecurityGroupClass{
+				EgressPolicy: EgressPolicyDenyByDefault,
+				AllowOutboundTo: []OutboundTrafficIntent{
+					{Destination: "AzureMonitor", Ports: []string{"443"}},
+					{Destination: "azuremonitor", Ports: []string{"443"}, Protocol: SecurityGroupProtocolTCP},
+				},
+			}},
+			wantErr: "Duplicate value",
+		},
+		{
+			name: "reserved security rule name",
+			sg: SecurityGroup{Name: "my-nsg", SecurityGroupClass: SecurityGroupClass{SecurityRules: SecurityRules{
+				{Name: "egress_deny_all", Priority: 4000, Direction: SecurityRuleDirectionOutbound},
+			}}},
+			wantErr: "the egress_ prefix is reserved for the security rules generated from egressPolicy",
+		},
+		{
+			name: "reserved deny priority",
+			sg: SecurityGroup{Name: "my-nsg", SecurityGroupClass: SecurityGroupClass{
+				EgressPolicy: EgressPolicyDenyByDefault,
+				SecurityRules: SecurityRules{
+					{Name: "deny_internet", Priority: 4096, Direction: SecurityRuleDirectionOutbound, Action: SecurityRuleActionDeny},
+				},
+			}},
+			wantErr: "the priority is reserved for the outbound rule denying the traffic of the DenyByDefault egress policy",
+		},
+		{
+			name: "generated rules don't fit in the remaining priorities",
+			sg: SecurityGroup{Name: "my-nsg", SecurityGroupClass: SecurityGroupClass{
+				EgressPolicy: EgressPolicyDenyByDefault,
+				SecurityRules: SecurityRules{
+					{Name: "allow_proxy", Priority: 3000, Direction: SecurityRuleDirectionOutbound},
+				},
+				AllowOutboundTo: []OutboundTrafficIntent{
+					{Destination: "10.0.0.0/8", Ports: manyPorts},
+				},
+			}},
+			wantErr: "Too many: 1093: must have at most 1091 items",
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateEgressPolicy(testCase.sg, field.NewPath("spec", "networkSpec", "subnets").Index(0).Child("securityGroup"))
+			if testCase.wantErr != "" {
+				g.Expect(err).To(HaveLen(1))
+				g.Expect(err.ToAggregate().Error()).To(ContainSubstring(testCase.wantErr))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestValidatePolicyAssignments(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name        string
+		assignments []PolicyAssignment
+		wantErr     string
+	}{
+		{
+			name: "no assignments",
+		},
+		{
+			name: "valid assignments",
+			assignments: []PolicyAssignment{
+				{
+					Name:               "allowed-locations",
+					PolicyDefinitionID: "/providers/Microsoft.Authorization/policyDefinitions/e56962a6-4747-49cd-b67b-bf8b01975c4c",
+					Parameters:         map[string]string{"listOfAllowedLocations": `["eastus", "westus2"]`},
+				},
+				{
+					Name:               "baseline",
+					PolicyDefinitionID: "/providers/Microsoft.Management/managementGroups/platform/providers/Microsoft.Authorization/policySetDefinitions/baseline",
+				},
+			},
+		},
+		{
+			name: "duplicate names",
+			assignments: []PolicyAssignment{
+				{Name: "baseline", PolicyDefinitionID: "/subscriptions/123/providers/Microsoft.Authorization/policySetDefinitions/baseline"},
+				{Name: "baseline", PolicyDefinitionID: "/subscriptions/123/providers/Microsoft.Authorization/policySetDefinitions/other"},
+			},
+			wantErr: "Duplicate value",
+		},
+		{
+			name:        "invalid name",
+			assignments: []PolicyAssignment{{Name: "base/line", PolicyDefinitionID: "/subscriptions/123/providers/Microsoft.Authorization/policySetDefinitions/baseline"}},
+			wantErr:     "name of policy assignment doesn't match regex",
+		},
+		{
+			name:        "invalid policy definition ID",
+			assignments: []PolicyAssignment{{Name: "baseline", PolicyDefinitionID: "/subscriptions/123/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet"}},
+			wantErr:     "must be the resource ID of a policy definition or initiative",
+		},
+		{
+			name: "invalid parameter value",
+			assignments: []PolicyAssignment{
+				{
+					Name:               "allowed-locations",
+					PolicyDefinitionID: "/providers/Microsoft.Authorization/policyDefinitions/e56962a6-4747-49cd-b67b-bf8b01975c4c",
+					Parameters:         map[string]string{"listOfAllowedLocations": "eastus"},
+				},
+			},
+			wantErr: "must be a JSON-encoded value",
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validatePolicyAssignments(testCase.assignments, field.NewPath("spec", "policyAssignments"))
+			if testCase.wantErr != "" {
+				g.Expect(err).To(HaveLen(1))
+				g.Expect(err.ToAggregate().Error()).To(ContainSubstring(testCase.wantErr))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestValidateBudget(t *testing.T) {
+	g := NewWithT(t)
+
+	validNotification := BudgetNotification{Threshold: 80, ContactEmails: []string{"finops@example.com"}}
+	tests := []struct {
+		name    string
+		budget  *BudgetSpec
+		wantErr string
+	}{
+		{
+			name: "no budget",
+		},
+		{
+			name: "valid budget",
+			budget: &BudgetSpec{
+				Name:      "my-cluster-budget",
+				Amount:    1000,
+				TimeGrain: BudgetTimeGrainQuarterly,
+				Notifications: []BudgetNotification{
+					validNotification,
+					{
+						Threshold:     120,
+						ContactRoles:  []string{"Owner"},
+						ContactGroups: []string{"/subscriptions/123/resourceGroups/ops/providers/microsoft.insights/actionGroups/oncall"},
+					},
+				},
+			},
+		},
+		{
+			name:    "invalid name",
+			budget:  &BudgetSpec{Name: "my budget", Amount: 1000, Notifications: []BudgetNotification{validNotification}},
+			wantErr: "name of budget doesn't match regex",
+		},
+		{
+			name:    "no amount",
+			budget:  &BudgetSpec{Notifications: []BudgetNotification{validNotification}},
+			wantErr: "must be greater than 0",
+		},
+		{
+			name:    "no notification",
+			budget:  &BudgetSpec{Amount: 1000},
+			wantErr: "at least one notification is required",
+		},
+		{
+			name:    "duplicate thresholds",
+			budget:  &BudgetSpec{Amount: 1000, Notifications: []BudgetNotification{validNotification, validNotification}},
+			wantErr: "Duplicate value",
+		},
+		{
+			name:    "threshold out of range",
+			budget:  &BudgetSpec{Amount: 1000, Notifications: []BudgetNotification{{Threshold: 1001, ContactEmails: []string{"finops@example.com"}}}},
+			wantErr: "must be between 1 and 1000",
+		},
+		{
+			name:    "notification without contact email nor action group",
+			budget:  &BudgetSpec{Amount: 1000, Notifications: []BudgetNotification{{Threshold: 80, ContactRoles: []string{"Owner"}}}},
+			wantErr: "a contact email or action group is required",
+		},
+		{
+			name:    "invalid contact email",
+			budget:  &BudgetSpec{Amount: 1000, Notifications: []BudgetNotification{{Threshold: 80, ContactEmails: []string{"finops"}}}},
+			wantErr: "must be an email address",
+		},
+		{
+			name: "invalid action group",
+			budget: &BudgetSpec{Amount: 1000, Notifications: []BudgetNotification{
+				{Threshold: 80, ContactGroups: []string{"/subscriptions/123/resourceGroups/ops/providers/Microsoft.Network/virtualNetworks/vnet"}},
+			}},
+			wantErr: "must be the resource ID of an action group",
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateBudget(testCase.budget, field.NewPath("spec", "budget"))
+			if testCase.wantErr != "" {
+				g.Expect(err).To(HaveLen(1))
+				g.Expect(err.ToAggregate().Error()).To(ContainSubstring(testCase.wantErr))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestValidateRequiredFeatures(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name     string
+		features []ProviderFeature
+		wantErr  string
+	}{
+		{
+			name: "no features",
+		},
+		{
+			name: "valid features",
+			features: []ProviderFeature{
+				{Namespace: "Microsoft.Compute", Name: "EncryptionAtHost"},
+				{Namespace: "Microsoft.Network", Name: "AllowGatewayLoadBalancer"},
+			},
+		},
+		{
+			name: "duplicate features",
+			features: []ProviderFeature{
+				{Namespace: "Microsoft.Compute", Name: "EncryptionAtHost"},
+				{Namespace: "microsoft.compute", Name: "encryptionathost"},
+			},
+			wantErr: "Duplicate value",
+		},
+		{
+			name:     "invalid namespace",
+			features: []ProviderFeature{{Namespace: "Compute", Name: "EncryptionAtHost"}},
+			wantErr:  "must be the namespace of a resource provider",
+		},
+		{
+			name:     "invalid name",
+			features: []ProviderFeature{{Namespace: "Microsoft.Compute", Name: "Microsoft.Compute/EncryptionAtHost"}},
+			wantErr:  "must be the name of a feature",
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateRequiredFeatures(testCase.features, field.NewPath("spec", "requiredFeatures"))
+			if testCase.wantErr != "" {
+				g.Expect(err).To(HaveLen(1))
+				g.Expect(err.ToAggregate().Error()).To(ContainSubstring(testCase.wantErr))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestValidateSecondaryRegion(t *testing.T) {
+	g := NewWithT(t)
+
+	validSecondaryRegion := func() *SecondaryRegionSpec {
+		return &SecondaryRegionSpec{
+			Location: "westus",
+			Vnet: VnetSpec{
+				ResourceGroup: "my-rg",
+				Name:          "my-secondary-vnet",
+				VnetClassSpec: VnetClassSpec{CIDRBlocks: []string{"10.0.0.0/8"}},
+			},
+			Subnets: []SecondarySubnetSpec{
+				{Name: "my-secondary-cp-subnet", SubnetClassSpec: SubnetClassSpec{Role: SubnetControlPlane, CIDRBlocks: []string{"10.0.0.0/16"}}},
+				{Name: "my-secondary-node-subnet", SubnetClassSpec: SubnetClassSpec{Role: SubnetNode, CIDRBlocks: []string{"10.1.0.0/16"}}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*SecondaryRegionSpec)
+		wantErr string
+	}{
+		{
+			name:   "valid secondary region",
+			mutate: func(*SecondaryRegionSpec) {},
+		},
+		{
+			name:   "location defaulted to the paired region",
+			mutate: func(s *SecondaryRegionSpec) { s.Location = "" },
+		},
+		{
+			name:    "location of the cluster",
+			mutate:  func(s *SecondaryRegionSpec) { s.Location = "EastUS" },
+			wantErr: "must differ from the location of the cluster",
+		},
+		{
+			name:    "virtual network of the cluster",
+			mutate:  func(s *SecondaryRegionSpec) { s.Vnet.Name = "my-vnet" },
+			wantErr: "must differ from the name of the virtual network of the cluster",
+		},
+		{
+			name:    "peered virtual network",
+			mutate:  func(s *SecondaryRegionSpec) { s.Vnet.Peerings = VnetPeerings{{RemoteVnetName: "hub-vnet"}} },
+			wantErr: "the virtual network of the secondary region can't be peered",
+		},
+		{
+			name:    "no subnets",
+			mutate:  func(s *SecondaryRegionSpec) { s.Subnets = nil },
+			wantErr: "the secondary region must have at least one subnet",
+		},
+		{
+			name:    "bastion subnet",
+			mutate:  func(s *SecondaryRegionSpec) { s.Subnets[1].Role = SubnetBastion },
+			wantErr: "Unsupported value",
+		},
+		{
+			name:    "subnet of the cluster",
+			mutate:  func(s *SecondaryRegionSpec) { s.Subnets[1].Name = "my-node-subnet" },
+			wantErr: "Duplicate value",
+		},
+		{
+			name:    "subnet out of the virtual network",
+			mutate:  func(s *SecondaryRegionSpec) { s.Subnets[1].CIDRBlocks = []string{"192.168.0.0/16"} },
+			wantErr: "subnet CIDR not in vnet address space",
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			secondaryRegion := validSecondaryRegion()
+			testCase.mutate(secondaryRegion)
+			cluster := &AzureCluster{
+				Spec: AzureClusterSpec{
+					AzureClusterClassSpec: AzureClusterClassSpec{Location: "eastus"},
+					NetworkSpec: NetworkSpec{
+						Vnet: VnetSpec{ResourceGroup: "my-rg", Name: "my-vnet"},
+						Subnets: Subnets{
+							{Name: "my-node-subnet", SubnetClassSpec: SubnetClassSpec{Role: SubnetNode}},
+						},
+					},
+					SecondaryRegion: secondaryRegion,
+				},
+			}
+			err := cluster.validateSecondaryRegion(field.NewPath("spec", "secondaryRegion"))
+			if testCase.wantErr != "" {
+				g.Expect(err).To(HaveLen(1))
+				g.Expect(err.ToAggregate().Error()).To(ContainSubstring(testCase.wantErr))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestValidateRoleAssignments(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name        string
+		assignments []RoleAssignment
+		wantErr     string
+	}{
+		{
+			name: "no assignments",
+		},
+		{
+			name: "valid assignments",
+			assignments: []RoleAssignment{
+				{
+					Name:             "cloud-provider",
+					PrincipalID:      "5d4c9a3e-6b9f-4a1e-9c4d-3f2b1a0e9d8c",
+					RoleDefinitionID: "b24988ac-6180-42a0-ab88-20f7382dd24c",
+				},
+				{
+					Name:             "operators",
+					PrincipalID:      "0f1e2d3c-4b5a-6978-8a9b-0c1d2e3f4a5b",
+					RoleDefinitionID: "/subscriptions/123/providers/Microsoft.Authorization/roleDefinitions/acdd72a7-3385-48ef-bd42-f606fba81ae7",
+				},
+			},
+		},
+		{
+			name: "duplicate names",
+			assignments: []RoleAssignment{
+				{Name: "cloud-provider", PrincipalID: "5d4c9a3e-6b9f-4a1e-9c4d-3f2b1a0e9d8c", RoleDefinitionID: "b24988ac-6180-42a0-ab88-20f7382dd24c"},
+				{Name: "cloud-provider", PrincipalID: "5d4c9a3e-6b9f-4a1e-9c4d-3f2b1a0e9d8c", RoleDefinitionID: "acdd72a7-3385-48ef-bd42-f606fba81ae7"},
+			},
+			wantErr: "Duplicate value",
+		},
+		{
+			name:        "invalid principal ID",
+			assignments: []RoleAssignment{{Name: "cloud-provider", PrincipalID: "cloud-provider-identity", RoleDefinitionID: "b24988ac-6180-42a0-ab88-20f7382dd24c"}},
+			wantErr:     "must be the object ID of a principal",
+		},
+		{
+			name:        "invalid role definition ID",
+			assignments: []RoleAssignment{{Name: "cloud-provider", PrincipalID: "5d4c9a3e-6b9f-4a1e-9c4d-3f2b1a0e9d8c", RoleDefinitionID: "Contributor"}},
+			wantErr:     "must be the name or the resource ID of a role definition",
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateRoleAssignments(testCase.assignments, field.NewPath("spec", "roleAssignments"))
+			if testCase.wantErr != "" {
+				g.Expect(err).To(HaveLen(1))
+				g.Expect(err.ToAggregate().Error()).To(ContainSubstring(testCase.wantErr))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestValidateGalleryImage(t *testing.T) {
+	g := NewWithT(t)
+
+	galleryID := "/subscriptions/123/resourceGroups/images/providers/Microsoft.Compute/galleries/capz"
+	tests := []struct {
+		name    string
+		image   *GalleryImage
+		wantErr string
+	}{
+		{
+			name: "no gallery image",
+		},
+		{
+			name:  "latest version",
+			image: &GalleryImage{ID: galleryID, ImageDefinition: "ubuntu-2004", Version: LatestGalleryImageVersion},
+		},
+		{
+			name:  "fixed version",
+			image: &GalleryImage{ID: galleryID, ImageDefinition: "ubuntu-2004", Version: "1.22.4"},
+		},
+		{
+			name:    "invalid gallery ID",
+			image:   &GalleryImage{ID: "/subscriptions/123/resourceGroups/images/providers/Microsoft.Compute/images/capz", ImageDefinition: "ubuntu-2004"},
+			wantErr: "must be the resource ID of an Azure Compute Gallery",
+		},
+		{
+			name:    "missing image definition",
+			image:   &GalleryImage{ID: galleryID, Version: "1.22.4"},
+			wantErr: "the name of the image definition is required",
+		},
+		{
+			name:    "invalid version",
+			image:   &GalleryImage{ID: galleryID, ImageDefinition: "ubuntu-2004", Version: "v1.22"},
+			wantErr: "must be in the Major.Minor.Patch format or latest",
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateGalleryImage(testCase.image, field.NewPath("spec", "gallery"))
+			if testCase.wantErr != "" {
+				g.Expect(err).To(HaveLen(1))
+				g.Expect(err.ToAggregate().Error()).To(ContainSubstring(testCase.wantErr))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestValidateAvailabilitySet(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name            string
+		availabilitySet *AvailabilitySet
+		wantErr         string
+	}{
+		{
+			name: "no availability set",
+		},
+		{
+			name:            "default domain counts",
+			availabilitySet: &AvailabilitySet{Name: "my-as"},
+		},
+		{
+			name:            "valid domain counts",
+			availabilitySet: &AvailabilitySet{Name: "my-as", FaultDomainCount: pointer.Int32(3), UpdateDomainCount: pointer.Int32(20)},
+		},
+		{
+			name:            "too many fault domains",
+			availabilitySet: &AvailabilitySet{Name: "my-as", FaultDomainCount: pointer.Int32(4)},
+			wantErr:         "must be between 1 and 3",
+		},
+		{
+			name:            "no update domain",
+			availabilitySet: &AvailabilitySet{Name: "my-as", UpdateDomainCount: pointer.Int32(0)},
+			wantErr:         "must be between 1 and 20",
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateAvailabilitySet(testCase.availabilitySet, field.NewPath("spec", "controlPlaneAvailabilitySet"))
+			if testCase.wantErr != "" {
+				g.Expect(err).To(HaveLen(1))
+				g.Expect(err.ToAggregate().Error()).To(ContainSubstring(testCase.wantErr))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestValidateEtcdDisks(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name      string
+		etcdDisks *EtcdDisks
+		wantErr   string
+	}{
+		{
+			name: "etcd disks not checked",
+		},
+		{
+			name:      "ultra disks",
+			etcdDisks: &EtcdDisks{StorageAccountType: "UltraSSD_LRS", VMSize: "Standard_D4s_v3"},
+		},
+		{
+			name:      "unsupported storage account type",
+			etcdDisks: &EtcdDisks{StorageAccountType: "Premium_LRS", VMSize: "Standard_D4s_v3"},
+			wantErr:   `Unsupported value: "Premium_LRS"`,
+		},
+		{
+			name:      "no VM size",
+			etcdDisks: &EtcdDisks{StorageAccountType: "UltraSSD_LRS"},
+			wantErr:   "the VM size of the control plane machines is required",
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateEtcdDisks(testCase.etcdDisks, field.NewPath("spec", "controlPlaneEtcdDisks"))
+			if testCase.wantErr != "" {
+				g.Expect(err).To(HaveLen(1))
+				g.Expect(err.ToAggregate().Error()).To(ContainSubstring(testCase.wantErr))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestValidateDiagnosticSettings(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name     string
+		settings *DiagnosticSettings
+		wantErr  string
+	}{
+		{
+			name: "no diagnostic settings",
+		},
+		{
+			name: "valid workspace and storage account",
+			settings: &DiagnosticSettings{
+				WorkspaceID:      "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.OperationalInsights/workspaces/shared-workspace",
+				StorageAccountID: "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.Storage/storageAccounts/sharedlogs",
+				MetricCategories: []string{"AllMetrics"},
+			},
+		},
+		{
+			name:     "no destination",
+			settings: &DiagnosticSettings{MetricCategories: []string{"AllMetrics"}},
+			wantErr:  "one of workspaceID, storageAccountID or eventHubAuthorizationRuleID must be set",
+		},
+		{
+			name: "valid event hub",
+			settings: &DiagnosticSettings{
+				EventHubAuthorizationRuleID: "/subscriptions/123/resourceGroups/siem-rg/providers/Microsoft.EventHub/namespaces/siem/authorizationRules/RootManageSharedAccessKey",
+				EventHubName:                "nsg-logs",
+				LogCategories:               []string{"NetworkSecurityGroupEvent"},
+			},
+		},
+		{
+			name: "authorization rule of an event hub",
+			settings: &DiagnosticSettings{
+				EventHubAuthorizationRuleID: "/subscriptions/123/resourceGroups/siem-rg/providers/Microsoft.EventHub/namespaces/siem/eventhubs/nsg-logs/authorizationRules/send",
+				LogCategories:               []string{"NetworkSecurityGroupEvent"},
+			},
+			wantErr: "must be the resource ID of an authorization rule of an Event Hub namespace",
+		},
+		{
+			name: "event hub name without authorization rule",
+			settings: &DiagnosticSettings{
+				WorkspaceID:   "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.OperationalInsights/workspaces/shared-workspace",
+				EventHubName:  "nsg-logs",
+				LogCategories: []string{"NetworkSecurityGroupEvent"},
+			},
+			wantErr: "must be set when eventHubName is set",
+		},
+		{
+			name: "invalid event hub name",
+			settings: &DiagnosticSettings{
+				EventHubAuthorizationRuleID: "/subscriptions/123/resourceGroups/siem-rg/providers/Microsoft.EventHub/namespaces/siem/authorizationRules/RootManageSharedAccessKey",
+				EventHubName:                "nsg-logs-",
+				LogCategories:               []string{"NetworkSecurityGroupEvent"},
+			},
+			wantErr: "must be a valid event hub name",
+		},
+		{
+			name: "invalid storage account ID",
+			settings: &DiagnosticSettings{
+				StorageAccountID: "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.OperationalInsights/workspaces/shared-workspace",
+				LogCategories:    []string{"LoadBalancerAlertEvent"},
+			},
+			wantErr: "must be the resource ID of a storage account",
+		},
+		{
+			name: "no category",
+			settings: &DiagnosticSettings{
+				WorkspaceID: "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.OperationalInsights/workspaces/shared-workspace",
+			},
+			wantErr: "at least one log or metric category must be enabled",
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateDiagnosticSettings(testCase.settings, field.NewPath("spec", "networkSpec", "apiServerLB", "diagnosticSettings"))
+			if testCase.wantErr != "" {
+				g.Expect(err).To(HaveLen(1))
+				g.Expect(err[0].Detail).To(Equal(testCase.wantErr))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestSubnetsValid(t *testing.T) {
+	g := NewWithT(t)
+
+	type test struct {
+		name    string
+		subnets Subnets
+	}
+
+	testCase := test{
+		name:    "subnets - valid",
+		subnets: createValidSubnets(),
+	}
+
+	t.Run(testCase.name, func(t *testing.T) {
+		errs := validateSubnets(testCase.subnets, createValidVnet(),
+			field.NewPath("spec").Child("networkSpec").Child("subnets"))
+		g.Expect(errs).To(BeNil())
+	})
+}
+
+func TestSubnetsInvalidSubnetName(t *testing.T) {
+	g := NewWithT(t)
+
+	type test struct {
+		name    string
+		subnets Subnets
+	}
+
+	testCase := test{
+		name:    "subnets - invalid subnet name",
+		subnets: createValidSubnets(),
+	}
+
+	testCase.subnets[0].Name = "invalid-subnet-name-due-to-bracket)"
+
+	t.Run(testCase.name, func(t *testing.T) {
+		errs := validateSubnets(testCase.subnets, createValidVnet(),
+			field.NewPath("spec").Child("networkSpec").Child("subnets"))
+		g.Expect(errs).To(HaveLen(1))
+		g.Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+		g.Expect(errs[0].Field).To(Equal("spec.networkSpec.subnets[0].name"))
+		g.Expect(errs[0].BadValue).To(BeEquivalentTo("invalid-subnet-name-due-to-bracket)"))
+	})
+}
+
+func TestSubnetsInvalidLackRequiredSubnet(t *testing.T) {
+	g := NewWithT(t)
+
+	type test struct {
+		name    string
+		subnets Subnets
+	}
+
+	testCase := test{
+		name:    "subnets - lack required subnet",
+		subnets: createValidSubnets(),
+	}
+
+	testCase.subnets[0].Role = "random-role"
+
+	t.Run(testCase.name, func(t *testing.T) {
+		errs := validateSubnets(testCase.subnets, createValidVnet(),
+			field.NewPath("spec").Child("networkSpec").Child("subnets"))
+		g.Expect(errs).To(HaveLen(1))
+		g.Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+		g.Expect(errs[0].Field).To(Equal("spec.networkSpec.subnets"))
+		g.Expect(errs[0].Detail).To(ContainSubstring("required role control-plane not included"))
+	})
+}
+
+func TestSubnetNamesNotUnique(t *testing.T) {
+	g := NewWithT(t)
+
+	type test struct {
+		name    string
+		subnets Subnets
+	}
+
+	testCase := test{
+		name:    "subnets - names not unique",
+		subnets: createValidSubnets(),
+	}
+
+	testCase.subnets[0].Name = "subnet-name"
+	testCase.subnets[1].Name = "subnet-name"
+
+	t.Run(testCase.name, func(t *testing.T) {
+		errs := validateSubnets(testCase.subnets, createValidVnet(),
+			field.NewPath("spec").Child("networkSpec").Child("subnets"))
+		g.Expect(errs).To(HaveLen(1))
+		g.Expect(errs[0].Type).To(Equal(field.ErrorTypeDuplicate))
+		g.Expect(errs[0].Field).To(Equal("spec.networkSpec.subnets"))
+	})
+}
+
+func TestSubnetNameValid(t *testing.T) {
+	g := NewWithT(t)
+
+	type test struct {
+		name       string
+		subnetName string
+	}
+
+	testCase := test{
+		name:       "subnet name - valid",
+		subnetName: "control-plane-subnet",
 	}
 
 	t.Run(testCase.name, func(t *testing.T) {
@@ -524,343 +1866,2016 @@ func TestSubnetNameValid(t *testing.T) {
 	})
 }
 
-func TestSubnetNameInvalid(t *testing.T) {
-	g := NewWithT(t)
+func TestSubnetNameInvalid(t *testing.T) {
+	g := NewWithT(t)
+
+	type test struct {
+		name       string
+		subnetName string
+	}
+
+	testCase := test{
+		name:       "subnet name - invalid",
+		subnetName: "inv@lid-subnet-name",
+	}
+
+	t.Run(testCase.name, func(t *testing.T) {
+		err := validateSubnetName(testCase.subnetName,
+			field.NewPath("spec").Child("networkSpec").Child("subnets").Index(0).Child("name"))
+		g.Expect(err).NotTo(BeNil())
+		g.Expect(err.Type).To(Equal(field.ErrorTypeInvalid))
+		g.Expect(err.Field).To(Equal("spec.networkSpec.subnets[0].name"))
+		g.Expect(err.BadValue).To(BeEquivalentTo(testCase.subnetName))
+	})
+}
+
+func TestValidateSubnetCIDR(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name             string
+		vnetCidrBlocks   []string
+		subnetCidrBlocks []string
+		wantErr          bool
+		expectedErr      field.Error
+	}{
+		{
+			name:             "valid subnet cidr",
+			vnetCidrBlocks:   []string{"10.0.0.0/8"},
+			subnetCidrBlocks: []string{"10.1.0.0/16", "10.0.0.0/16"},
+			wantErr:          false,
+		},
+		{
+			name:             "invalid subnet cidr not in the right format",
+			vnetCidrBlocks:   []string{"10.0.0.0/8"},
+			subnetCidrBlocks: []string{"10.1.0.0/16", "10.0.0.0/16", "foo/bar"},
+			wantErr:          true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets.cidrBlocks",
+				BadValue: "foo/bar",
+				Detail:   "invalid CIDR format",
+			},
+		},
+		{
+			name:             "subnet cidr not in vnet range",
+			vnetCidrBlocks:   []string{"10.0.0.0/8"},
+			subnetCidrBlocks: []string{"10.1.0.0/16", "10.0.0.0/16", "11.1.0.0/16"},
+			wantErr:          true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets.cidrBlocks",
+				BadValue: "11.1.0.0/16",
+				Detail:   "subnet CIDR not in vnet address space: [10.0.0.0/8]",
+			},
+		},
+		{
+			name:             "subnet cidr in atleast one vnet's range in case of multiple vnet cidr blocks",
+			vnetCidrBlocks:   []string{"10.0.0.0/8", "11.0.0.0/8"},
+			subnetCidrBlocks: []string{"10.1.0.0/16", "10.0.0.0/16", "11.1.0.0/16"},
+			wantErr:          false,
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateSubnetCIDR(testCase.subnetCidrBlocks, testCase.vnetCidrBlocks, field.NewPath("subnets.cidrBlocks"))
+			if testCase.wantErr {
+				// Searches for expected error in list of thrown errors
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestValidateSecurityRule(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name      string
+		validRule SecurityRule
+		wantErr   bool
+	}{
+		{
+			name: "security rule - valid priority",
+			validRule: SecurityRule{
+				Name:        "allow_apiserver",
+				Description: "Allow K8s API Server",
+				Priority:    101,
+			},
+			wantErr: false,
+		},
+		{
+			name: "security rule - invalid low priority",
+			validRule: SecurityRule{
+				Name:        "allow_apiserver",
+				Description: "Allow K8s API Server",
+				Priority:    99,
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule - invalid high priority",
+			validRule: SecurityRule{
+				Name:        "allow_apiserver",
+				Description: "Allow K8s API Server",
+				Priority:    5000,
+			},
+			wantErr: true,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateSecurityRule(
+				testCase.validRule,
+				field.NewPath("spec").Child("networkSpec").Child("subnets").Index(0).Child("securityGroup").Child("securityRules").Index(0),
+			)
+			if testCase.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestValidateAPIServerLB(t *testing.T) {
+	g := NewWithT(t)
+
+	testcases := []struct {
+		name        string
+		lb          LoadBalancerSpec
+		old         LoadBalancerSpec
+		cpCIDRS     []string
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "invalid SKU",
+			lb: LoadBalancerSpec{
+				Name: "my-awesome-lb",
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+
+					SKU: "Awesome",
+					FrontendIPs: []FrontendIP{
+						{
+							Name: "ip-config",
+						},
+					},
+					Type: Public,
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueNotSupported",
+				Field:    "apiServerLB.sku",
+				BadValue: "Awesome",
+				Detail:   "supported values: \"Standard\"",
+			},
+		},
+		{
+			name: "invalid Type",
+			lb: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: "Foo",
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueNotSupported",
+				Field:    "apiServerLB.type",
+				BadValue: "Foo",
+				Detail:   "supported values: \"Public\", \"Internal\"",
+			},
+		},
+		{
+			name: "invalid health probe request path",
+			lb: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					HealthProbe: &HealthProbe{
+						Protocol:    ProbeProtocolHTTPS,
+						RequestPath: "readyz",
+					},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "apiServerLB.healthProbe.requestPath",
+				BadValue: "readyz",
+				Detail:   "health probe request path should start with /",
+			},
+		},
+		{
+			name: "internal frontend IP on internal LB",
+			lb: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type:               Internal,
+					InternalFrontendIP: &FrontendIP{Name: "ip-internal"},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "apiServerLB.internalFrontendIP",
+				Detail: "an internal frontend IP can only be added to a public load balancer, internal load balancers are already reachable from the virtual network",
+			},
+		},
+		{
+			name: "HA ports on public LB",
+			lb: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type:    Public,
+					HAPorts: &HAPorts{Enabled: true},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "apiServerLB.haPorts.enabled",
+				Detail: "HA ports are only supported by internal load balancers",
+			},
+		},
+		{
+			name: "invalid Name",
+			lb: LoadBalancerSpec{
+				Name: "***",
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "apiServerLB.name",
+				BadValue: "***",
+				Detail:   "name of load balancer doesn't match regex ^[-\\w\\._]+$",
+			},
+		},
+		{
+			name: "too many IP configs",
+			lb: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					FrontendIPs: []FrontendIP{
+						{
+							Name: "ip-1",
+						},
+						{
+							Name: "ip-2",
+						},
+					},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:  "FieldValueInvalid",
+				Field: "apiServerLB.frontendIPConfigs",
+				BadValue: []FrontendIP{
+					{
+						Name: "ip-1",
+					},
+					{
+						Name: "ip-2",
+					},
+				},
+				Detail: "API Server Load balancer should have 1 Frontend IP",
+			},
+		},
+		{
+			name: "public LB with private IP",
+			lb: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					FrontendIPs: []FrontendIP{
+						{
+							Name: "ip-1",
+							FrontendIPClass: FrontendIPClass{
+								PrivateIPAddress: "10.0.0.4",
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "apiServerLB.frontendIPConfigs[0].privateIP",
+				Detail: "Public Load Balancers cannot have a Private IP",
+			},
+		},
+		{
+			name: "internal LB with public IP",
+			lb: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Internal,
+					FrontendIPs: []FrontendIP{
+						{
+							Name: "ip-1",
+							PublicIP: &PublicIPSpec{
+								Name: "my-invalid-ip",
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "apiServerLB.frontendIPConfigs[0].publicIP",
+				Detail: "Internal Load Balancers cannot have a Public IP",
+			},
+		},
+		{
+			name: "internal LB with invalid private IP",
+			lb: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Internal,
+					FrontendIPs: []FrontendIP{
+						{
+							Name: "ip-1",
+							FrontendIPClass: FrontendIPClass{
+								PrivateIPAddress: "NAIP",
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "apiServerLB.frontendIPConfigs[0].privateIP",
+				BadValue: "NAIP",
+				Detail:   "Internal LB IP address isn't a valid IPv4 or IPv6 address",
+			},
+		},
+		{
+			name: "internal LB with out of range private IP",
+			lb: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Internal,
+					FrontendIPs: []FrontendIP{
+						{
+							Name: "ip-1",
+							FrontendIPClass: FrontendIPClass{
+								PrivateIPAddress: "20.1.2.3",
+							},
+						},
+					},
+				},
+			},
+			cpCIDRS: []string{"10.0.0.0/24", "10.1.0.0/24"},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "apiServerLB.frontendIPConfigs[0].privateIP",
+				BadValue: "20.1.2.3",
+				Detail:   "Internal LB IP address needs to be in control plane subnet range ([10.0.0.0/24 10.1.0.0/24])",
+			},
+		},
+		{
+			name: "internal LB with in range private IP",
+			lb: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Internal,
+					SKU:  SKUStandard,
+					FrontendIPs: []FrontendIP{
+						{
+							Name: "ip-1",
+							FrontendIPClass: FrontendIPClass{
+								PrivateIPAddress: "10.1.0.3",
+							},
+						},
+					},
+				},
+				Name: "my-private-lb",
+			},
+			cpCIDRS: []string{"10.0.0.0/24", "10.1.0.0/24"},
+			wantErr: false,
+		},
+		{
+			name: "shared public LB",
+			lb: LoadBalancerSpec{
+				Name:   "my-shared-lb",
+				Shared: &SharedLoadBalancer{FrontendPort: 6444},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+					FrontendIPs: []FrontendIP{
+						{
+							Name: "ip-1",
+							PublicIP: &PublicIPSpec{
+								Name:    "my-shared-ip",
+								DNSName: "my-shared-ip.eastus.cloudapp.azure.com",
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "shared internal LB",
+			lb: LoadBalancerSpec{
+				Name:   "my-private-lb",
+				Shared: &SharedLoadBalancer{FrontendPort: 6444},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Internal,
+					SKU:  SKUStandard,
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueForbidden",
+				Field:    "apiServerLB.shared",
+				BadValue: "",
+				Detail:   "only public load balancers can be shared",
+			},
+		},
+		{
+			name: "shared LB public IP without DNS name",
+			lb: LoadBalancerSpec{
+				Name:   "my-shared-lb",
+				Shared: &SharedLoadBalancer{FrontendPort: 6444},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+					FrontendIPs: []FrontendIP{
+						{
+							Name:     "ip-1",
+							PublicIP: &PublicIPSpec{Name: "my-shared-ip"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueRequired",
+				Field:    "apiServerLB.frontendIPs[0].publicIP.dnsName",
+				BadValue: "",
+				Detail:   "the DNS name of the public IP of a shared load balancer can't be generated",
+			},
+		},
+		{
+			name: "shared LB frontend port modified",
+			lb: LoadBalancerSpec{
+				Name:   "my-shared-lb",
+				Shared: &SharedLoadBalancer{FrontendPort: 6445},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+			},
+			old: LoadBalancerSpec{
+				Name:   "my-shared-lb",
+				Shared: &SharedLoadBalancer{FrontendPort: 6444},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueForbidden",
+				Field:    "apiServerLB.shared",
+				BadValue: "",
+				Detail:   "API Server load balancer sharing should not be modified after AzureCluster creation.",
+			},
+		},
+		{
+			name: "shared LB force recreate",
+			lb: LoadBalancerSpec{
+				Name:   "my-shared-lb",
+				Shared: &SharedLoadBalancer{FrontendPort: 6444},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type:          Public,
+					SKU:           SKUStandard,
+					ForceRecreate: true,
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueForbidden",
+				Field:    "apiServerLB.forceRecreate",
+				BadValue: "",
+				Detail:   "a shared load balancer is managed outside of the cluster, it can't be recreated",
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateAPIServerLB(test.lb, test.old, test.cpCIDRS, field.NewPath("apiServerLB"))
+			if test.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(test.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+func TestValidateSSHNATRule(t *testing.T) {
+	fldPath := field.NewPath("apiServerLB", "sshNATRule")
+	lbWithRule := func(lbType LBType, start, end int32) LoadBalancerSpec {
+		return LoadBalancerSpec{
+			Name: "my-lb",
+			LoadBalancerClassSpec: LoadBalancerClassSpec{
+				Type:       lbType,
+				SSHNATRule: &SSHNATRule{FrontendPortRangeStart: start, FrontendPortRangeEnd: end},
+			},
+		}
+	}
+	sharedLB := lbWithRule(Public, 50000, 50100)
+	sharedLB.Shared = &SharedLoadBalancer{FrontendPort: 6443}
+
+	tests := []struct {
+		name         string
+		lb           LoadBalancerSpec
+		expectedErrs field.ErrorList
+	}{
+		{
+			name: "no SSH NAT rule",
+			lb:   LoadBalancerSpec{Name: "my-lb", LoadBalancerClassSpec: LoadBalancerClassSpec{Type: Public}},
+		},
+		{
+			name: "valid SSH NAT rule",
+			lb:   lbWithRule(Public, 50000, 50100),
+		},
+		{
+			name: "SSH NAT rule of an internal load balancer",
+			lb:   lbWithRule(Internal, 50000, 50100),
+			expectedErrs: field.ErrorList{
+				field.Forbidden(fldPath, "an SSH NAT rule is only supported by public load balancers"),
+			},
+		},
+		{
+			name: "SSH NAT rule of a shared load balancer",
+			lb:   sharedLB,
+			expectedErrs: field.ErrorList{
+				field.Forbidden(fldPath, "an SSH NAT rule can't be added to a shared load balancer"),
+			},
+		},
+		{
+			name: "frontend port out of range",
+			lb:   lbWithRule(Public, 65000, 65535),
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath.Child("frontendPortRangeEnd"), int32(65535), "frontend port must be between 1 and 65534"),
+			},
+		},
+		{
+			name: "frontend port range end lower than its start",
+			lb:   lbWithRule(Public, 50100, 50000),
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath.Child("frontendPortRangeEnd"), int32(50000), "the end of the frontend port range must not be lower than its start"),
+			},
+		},
+		{
+			name: "frontend port range of a single port",
+			lb:   lbWithRule(Public, 50000, 50000),
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath, int32(1), "the frontend port range must hold at least 2 ports, for a control plane machine and its replacement during a rolling update"),
+			},
+		},
+		{
+			name: "frontend port range overlapping the NAT rules of each control plane machine",
+			lb:   lbWithRule(Public, 2210, 2300),
+			expectedErrs: field.ErrorList{
+				field.Invalid(fldPath, "2210-2300", "the frontend port range must not overlap the ports 22 and 2201-2219 of the NAT rules of each control plane machine"),
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateSSHNATRule(test.lb, fldPath)
+			if len(test.expectedErrs) == 0 {
+				g.Expect(errs).To(BeEmpty())
+			} else {
+				g.Expect(errs).To(Equal(test.expectedErrs))
+			}
+		})
+	}
+}
+
+func TestPrivateDNSZoneName(t *testing.T) {
+	g := NewWithT(t)
+
+	testcases := []struct {
+		name        string
+		network     NetworkSpec
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "testInvalidPrivateDNSZoneName",
+			network: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					PrivateDNSZoneName: "wrong@d_ns.io",
+				},
+				APIServerLB: createValidAPIServerInternalLB(),
+			},
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "spec.networkSpec.privateDNSZoneName",
+				BadValue: "wrong@d_ns.io",
+				Detail:   "PrivateDNSZoneName can only contain alphanumeric characters, underscores and dashes, must end with an alphanumeric character",
+			},
+			wantErr: true,
+		},
+		{
+			name: "testValidPrivateDNSZoneName",
+			network: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					PrivateDNSZoneName: "good.dns.io",
+				},
+				APIServerLB: createValidAPIServerInternalLB(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "testValidPrivateDNSZoneNameWithUnderscore",
+			network: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					PrivateDNSZoneName: "_good.__dns.io",
+				},
+				APIServerLB: createValidAPIServerInternalLB(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "testBadAPIServerLBType",
+			network: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					PrivateDNSZoneName: "good.dns.io",
+				},
+				APIServerLB: LoadBalancerSpec{
+					Name: "my-lb",
+					LoadBalancerClassSpec: LoadBalancerClassSpec{
+						Type: Public,
+					},
+				},
+			},
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "spec.networkSpec.privateDNSZoneName",
+				BadValue: "Public",
+				Detail:   "PrivateDNSZoneName is available only if APIServerLB.Type is Internal",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := validatePrivateDNSZoneName(test.network, field.NewPath("spec", "networkSpec", "privateDNSZoneName"))
+			if test.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(test.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestValidateFrontendIPZones(t *testing.T) {
+	tests := []struct {
+		name         string
+		frontendIPs  []FrontendIP
+		expectedErrs field.ErrorList
+	}{
+		{
+			name: "zone-redundant and private frontends",
+			frontendIPs: []FrontendIP{
+				{Name: "public", PublicIP: &PublicIPSpec{Name: "pip"}},
+				{Name: "private", FrontendIPClass: FrontendIPClass{PrivateIPAddress: "10.0.0.100"}},
+			},
+		},
+		{
+			name: "zonal public IP",
+			frontendIPs: []FrontendIP{
+				{Name: "public", PublicIP: &PublicIPSpec{Name: "pip", Zones: []string{"2"}}},
+			},
+		},
+		{
+			name: "invalid and duplicate zones",
+			frontendIPs: []FrontendIP{
+				{Name: "public", PublicIP: &PublicIPSpec{Name: "pip", Zones: []string{"1", "one", "1"}}},
+			},
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("frontendIPs").Index(0).Child("publicIP", "zones").Index(1), "one", fmt.Sprintf("zone doesn't match regex %s", availabilityZoneRegex)),
+				field.Duplicate(field.NewPath("frontendIPs").Index(0).Child("publicIP", "zones").Index(2), "1"),
+			},
+		},
+		{
+			name: "regional tier public IP",
+			frontendIPs: []FrontendIP{
+				{Name: "public", PublicIP: &PublicIPSpec{Name: "pip", Tier: PublicIPTierRegional}},
+			},
+		},
+		{
+			name: "global tier public IP",
+			frontendIPs: []FrontendIP{
+				{Name: "public", PublicIP: &PublicIPSpec{Name: "pip", Tier: PublicIPTierGlobal}},
+			},
+			expectedErrs: field.ErrorList{
+				field.Forbidden(field.NewPath("frontendIPs").Index(0).Child("publicIP", "tier"), "the Global tier is only supported for the public IP of a cross-region load balancer"),
+			},
+		},
+		{
+			name: "public IP with IP tags and the internet routing preference",
+			frontendIPs: []FrontendIP{
+				{Name: "public", PublicIP: &PublicIPSpec{
+					Name:              "pip",
+					IPTags:            []IPTag{{Type: IPTagTypeFirstPartyUsage, Tag: "/NonProd"}},
+					RoutingPreference: RoutingPreferenceInternet,
+				}},
+			},
+		},
+		{
+			name: "invalid IP tags",
+			frontendIPs: []FrontendIP{
+				{Name: "public", PublicIP: &PublicIPSpec{
+					Name: "pip",
+					IPTags: []IPTag{
+						{Type: IPTagTypeRoutingPreference, Tag: "Internet"},
+						{Type: "Custom", Tag: "value"},
+						{Type: IPTagTypeFirstPartyUsage},
+						{Type: IPTagTypeFirstPartyUsage},
+					},
+				}},
+			},
+			expectedErrs: field.ErrorList{
+				field.Forbidden(field.NewPath("frontendIPs").Index(0).Child("publicIP", "ipTags").Index(0).Child("type"), "the routing preference of a public IP is set with its routingPreference"),
+				field.NotSupported(field.NewPath("frontendIPs").Index(0).Child("publicIP", "ipTags").Index(1).Child("type"), IPTagType("Custom"), []string{"FirstPartyUsage"}),
+				field.Required(field.NewPath("frontendIPs").Index(0).Child("publicIP", "ipTags").Index(2).Child("tag"), "the value of an IP tag is required"),
+				field.Required(field.NewPath("frontendIPs").Index(0).Child("publicIP", "ipTags").Index(3).Child("tag"), "the value of an IP tag is required"),
+				field.Duplicate(field.NewPath("frontendIPs").Index(0).Child("publicIP", "ipTags").Index(3), IPTag{Type: IPTagTypeFirstPartyUsage}),
+			},
+		},
+		{
+			name: "public IP allocated from a public IP prefix",
+			frontendIPs: []FrontendIP{
+				{Name: "public", PublicIP: &PublicIPSpec{
+					Name:       "pip",
+					IPPrefixID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix",
+				}},
+			},
+		},
+		{
+			name: "invalid public IP prefix ID",
+			frontendIPs: []FrontendIP{
+				{Name: "public", PublicIP: &PublicIPSpec{
+					Name:       "pip",
+					IPPrefixID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-pip",
+				}},
+			},
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("frontendIPs").Index(0).Child("publicIP", "ipPrefixID"), "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-pip", "must be the resource ID of a public IP prefix"),
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateFrontendIPZones(test.frontendIPs, field.NewPath("frontendIPs"))
+			if len(test.expectedErrs) == 0 {
+				g.Expect(errs).To(BeEmpty())
+			} else {
+				g.Expect(errs).To(Equal(test.expectedErrs))
+			}
+		})
+	}
+}
+
+func TestValidatePublicIPUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	old := PublicIPSpec{Name: "pip", IPTags: []IPTag{{Type: IPTagTypeFirstPartyUsage, Tag: "/NonProd"}}}
+	g.Expect(validatePublicIPUpdate(old, old, field.NewPath("publicIP"))).To(BeEmpty())
+
+	updated := PublicIPSpec{Name: "pip", DNSName: "my-cluster.example.com", RoutingPreference: RoutingPreferenceInternet, IPPrefixID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix"}
+	g.Expect(validatePublicIPUpdate(updated, old, field.NewPath("publicIP"))).To(Equal(field.ErrorList{
+		field.Invalid(field.NewPath("publicIP", "ipTags"), []IPTag(nil), "field is immutable"),
+		field.Invalid(field.NewPath("publicIP", "routingPreference"), RoutingPreferenceInternet, "field is immutable"),
+		field.Invalid(field.NewPath("publicIP", "ipPrefixID"), updated.IPPrefixID, "field is immutable"),
+	}))
+}
+
+func TestForbidPublicIPPrefix(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(forbidPublicIPPrefix(PublicIPSpec{Name: "pip"}, field.NewPath("publicIP"))).To(BeEmpty())
+	g.Expect(forbidPublicIPPrefix(PublicIPSpec{Name: "pip", IPPrefixID: "prefix"}, field.NewPath("publicIP"))).To(Equal(field.ErrorList{
+		field.Forbidden(field.NewPath("publicIP", "ipPrefixID"), "public IP prefixes are only supported for the public IPs of the frontends of the load balancers"),
+	}))
+}
+
+func TestValidateTrafficManager(t *testing.T) {
+	g := NewWithT(t)
+
+	testcases := []struct {
+		name        string
+		tm          *TrafficManagerSpec
+		old         *TrafficManagerSpec
+		apiServerLB LoadBalancerSpec
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name:        "no traffic manager",
+			apiServerLB: createValidAPIServerLB(),
+			wantErr:     false,
+		},
+		{
+			name:        "valid traffic manager",
+			tm:          &TrafficManagerSpec{Name: "my-tm", DNSPrefix: "my-cluster", RoutingMethod: TrafficRoutingMethodPriority},
+			apiServerLB: createValidAPIServerLB(),
+			wantErr:     false,
+		},
+		{
+			name:        "invalid dns prefix",
+			tm:          &TrafficManagerSpec{Name: "my-tm", DNSPrefix: "-my_cluster", RoutingMethod: TrafficRoutingMethodPriority},
+			apiServerLB: createValidAPIServerLB(),
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "trafficManager.dnsPrefix",
+				BadValue: "-my_cluster",
+				Detail:   "dnsPrefix doesn't match regex ^[a-zA-Z0-9]([-a-zA-Z0-9]{0,61}[a-zA-Z0-9])?$, can contain only alphanumeric characters and '-', must start/end with an alphanumeric character",
+			},
+		},
+		{
+			name:        "dns prefix is immutable",
+			tm:          &TrafficManagerSpec{Name: "my-tm", DNSPrefix: "my-other-cluster", RoutingMethod: TrafficRoutingMethodPriority},
+			old:         &TrafficManagerSpec{Name: "my-tm", DNSPrefix: "my-cluster", RoutingMethod: TrafficRoutingMethodPriority},
+			apiServerLB: createValidAPIServerLB(),
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "trafficManager.dnsPrefix",
+				BadValue: "my-other-cluster",
+				Detail:   "field is immutable",
+			},
+		},
+		{
+			name:        "internal api server lb",
+			tm:          &TrafficManagerSpec{Name: "my-tm", DNSPrefix: "my-cluster", RoutingMethod: TrafficRoutingMethodPriority},
+			apiServerLB: createValidAPIServerInternalLB(),
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "trafficManager",
+				Detail: "Traffic Manager is only supported with a public API server load balancer",
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateTrafficManager(test.tm, test.old, test.apiServerLB, field.NewPath("trafficManager"))
+			if test.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(test.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestValidateGlobalLB(t *testing.T) {
+	g := NewWithT(t)
+
+	validGlobalLB := func() *GlobalLoadBalancerSpec {
+		return &GlobalLoadBalancerSpec{
+			Name:     "my-cluster-global-lb",
+			Location: "eastus2",
+			PublicIP: &PublicIPSpec{Name: "pip-my-cluster-global", Tier: PublicIPTierGlobal},
+			AdditionalBackends: []string{
+				"/subscriptions/123/resourceGroups/other-rg/providers/Microsoft.Network/loadBalancers/other-lb/frontendIPConfigurations/other-lb-frontEnd",
+			},
+		}
+	}
+
+	testcases := []struct {
+		name        string
+		glb         *GlobalLoadBalancerSpec
+		old         *GlobalLoadBalancerSpec
+		networkSpec NetworkSpec
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name:        "no cross-region load balancer",
+			networkSpec: NetworkSpec{APIServerLB: createValidAPIServerLB()},
+			wantErr:     false,
+		},
+		{
+			name:        "valid cross-region load balancer",
+			glb:         validGlobalLB(),
+			networkSpec: NetworkSpec{APIServerLB: createValidAPIServerLB()},
+			wantErr:     false,
+		},
+		{
+			name:        "internal api server lb",
+			glb:         validGlobalLB(),
+			networkSpec: NetworkSpec{APIServerLB: createValidAPIServerInternalLB()},
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "globalLB",
+				Detail: "a cross-region load balancer is only supported with a public Standard API server load balancer",
+			},
+		},
+		{
+			name: "traffic manager is also configured",
+			glb:  validGlobalLB(),
+			networkSpec: NetworkSpec{
+				APIServerLB:    createValidAPIServerLB(),
+				TrafficManager: &TrafficManagerSpec{Name: "my-tm", DNSPrefix: "my-cluster", RoutingMethod: TrafficRoutingMethodPriority},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "globalLB",
+				Detail: "a cross-region load balancer and a Traffic Manager can't both front the API server",
+			},
+		},
+		{
+			name: "location is not a home region",
+			glb: func() *GlobalLoadBalancerSpec {
+				glb := validGlobalLB()
+				glb.Location = "westus3"
+				return glb
+			}(),
+			networkSpec: NetworkSpec{APIServerLB: createValidAPIServerLB()},
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:     "FieldValueNotSupported",
+				Field:    "globalLB.location",
+				BadValue: "westus3",
+				Detail:   `supported values: "centralus", "eastasia", "eastus", "eastus2", "japaneast", "northeurope", "southcentralus", "southeastasia", "uksouth", "usgovvirginia", "westeurope", "westus"`,
+			},
+		},
+		{
+			name: "additional backend is not a load balancer frontend",
+			glb: func() *GlobalLoadBalancerSpec {
+				glb := validGlobalLB()
+				glb.AdditionalBackends = []string{"/subscriptions/123/resourceGroups/other-rg/providers/Microsoft.Network/publicIPAddresses/other-pip"}
+				return glb
+			}(),
+			networkSpec: NetworkSpec{APIServerLB: createValidAPIServerLB()},
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "globalLB.additionalBackends[0]",
+				BadValue: "/subscriptions/123/resourceGroups/other-rg/providers/Microsoft.Network/publicIPAddresses/other-pip",
+				Detail:   "additional backend must be the resource ID of the frontend IP configuration of a load balancer",
+			},
+		},
+		{
+			name: "duplicate additional backend",
+			glb: func() *GlobalLoadBalancerSpec {
+				glb := validGlobalLB()
+				glb.AdditionalBackends = append(glb.AdditionalBackends, strings.ToUpper(glb.AdditionalBackends[0]))
+				return glb
+			}(),
+			networkSpec: NetworkSpec{APIServerLB: createValidAPIServerLB()},
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "globalLB.additionalBackends[1]",
+				BadValue: "/SUBSCRIPTIONS/123/RESOURCEGROUPS/OTHER-RG/PROVIDERS/MICROSOFT.NETWORK/LOADBALANCERS/OTHER-LB/FRONTENDIPCONFIGURATIONS/OTHER-LB-FRONTEND",
+			},
+		},
+		{
+			name: "location is immutable",
+			glb: func() *GlobalLoadBalancerSpec {
+				glb := validGlobalLB()
+				glb.Location = "westeurope"
+				return glb
+			}(),
+			old:         validGlobalLB(),
+			networkSpec: NetworkSpec{APIServerLB: createValidAPIServerLB()},
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "globalLB.location",
+				BadValue: "westeurope",
+				Detail:   "field is immutable",
+			},
+		},
+		{
+			name: "internet routing preference",
+			glb: func() *GlobalLoadBalancerSpec {
+				glb := validGlobalLB()
+				glb.PublicIP.RoutingPreference = RoutingPreferenceInternet
+				return glb
+			}(),
+			networkSpec: NetworkSpec{APIServerLB: createValidAPIServerLB()},
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "globalLB.publicIP.routingPreference",
+				Detail: "the Internet routing preference is not supported for a Global tier public IP",
+			},
+		},
+		{
+			name: "regional tier public IP",
+			glb: func() *GlobalLoadBalancerSpec {
+				glb := validGlobalLB()
+				glb.PublicIP.Tier = PublicIPTierRegional
+				return glb
+			}(),
+			networkSpec: NetworkSpec{APIServerLB: createValidAPIServerLB()},
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:     "FieldValueNotSupported",
+				Field:    "globalLB.publicIP.tier",
+				BadValue: PublicIPTierRegional,
+				Detail:   `supported values: "Global"`,
+			},
+		},
+		{
+			name: "zonal global tier public IP",
+			glb: func() *GlobalLoadBalancerSpec {
+				glb := validGlobalLB()
+				glb.PublicIP.Zones = []string{"1"}
+				return glb
+			}(),
+			networkSpec: NetworkSpec{APIServerLB: createValidAPIServerLB()},
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "globalLB.publicIP.zones",
+				Detail: "a Global tier public IP can't be zonal",
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateGlobalLB(test.glb, test.old, test.networkSpec, field.NewPath("globalLB"))
+			if test.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(test.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestValidateDNSPrivateResolver(t *testing.T) {
+	const (
+		resolverID = "/subscriptions/456/resourceGroups/hub-rg/providers/Microsoft.Network/dnsResolvers/hub-resolver"
+		rulesetID  = "/subscriptions/456/resourceGroups/hub-rg/providers/Microsoft.Network/dnsForwardingRulesets/onprem"
+	)
+
+	tests := []struct {
+		name         string
+		resolver     *DNSPrivateResolverSpec
+		expectedErrs field.ErrorList
+	}{
+		{
+			name: "no DNS private resolver",
+		},
+		{
+			name:     "DNS private resolver with a forwarding ruleset",
+			resolver: &DNSPrivateResolverSpec{ID: resolverID, ForwardingRulesetIDs: []string{rulesetID}},
+		},
+		{
+			name:     "invalid DNS private resolver ID",
+			resolver: &DNSPrivateResolverSpec{ID: rulesetID},
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("dnsPrivateResolver", "id"), rulesetID, "id must be the resource ID of a DNS Private Resolver"),
+			},
+		},
+		{
+			name:     "invalid and duplicate forwarding ruleset IDs",
+			resolver: &DNSPrivateResolverSpec{ID: resolverID, ForwardingRulesetIDs: []string{resolverID, rulesetID, strings.ToUpper(rulesetID)}},
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("dnsPrivateResolver", "forwardingRulesetIDs").Index(0), resolverID, "forwarding ruleset ID must be the resource ID of a DNS forwarding ruleset"),
+				field.Duplicate(field.NewPath("dnsPrivateResolver", "forwardingRulesetIDs").Index(2), strings.ToUpper(rulesetID)),
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateDNSPrivateResolver(test.resolver, field.NewPath("dnsPrivateResolver"))
+			if len(test.expectedErrs) == 0 {
+				g.Expect(errs).To(BeEmpty())
+			} else {
+				g.Expect(errs).To(Equal(test.expectedErrs))
+			}
+		})
+	}
+}
+
+func TestValidatePrivateEndpoints(t *testing.T) {
+	const (
+		storageID = "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.Storage/storageAccounts/mystorage"
+		zoneID    = "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net"
+	)
+	subnets := Subnets{{Name: "node-subnet", SubnetClassSpec: SubnetClassSpec{Role: SubnetNode}}}
+
+	tests := []struct {
+		name         string
+		endpoints    []PrivateEndpointSpec
+		expectedErrs field.ErrorList
+	}{
+		{
+			name: "no private endpoints",
+		},
+		{
+			name:      "private endpoint with a private DNS zone",
+			endpoints: []PrivateEndpointSpec{{Name: "storage-pe", ResourceID: storageID, GroupID: "blob", SubnetName: "node-subnet", PrivateDNSZoneID: zoneID}},
+		},
+		{
+			name: "duplicate private endpoint in an unknown subnet",
+			endpoints: []PrivateEndpointSpec{
+				{Name: "storage-pe", ResourceID: storageID, GroupID: "blob", SubnetName: "node-subnet"},
+				{Name: "storage-pe", ResourceID: storageID, GroupID: "file", SubnetName: "other-subnet"},
+			},
+			expectedErrs: field.ErrorList{
+				field.Duplicate(field.NewPath("privateEndpoints").Index(1).Child("name"), "storage-pe"),
+				field.NotFound(field.NewPath("privateEndpoints").Index(1).Child("subnetName"), "other-subnet"),
+			},
+		},
+		{
+			name:      "invalid resource and private DNS zone IDs",
+			endpoints: []PrivateEndpointSpec{{Name: "storage-pe", ResourceID: "mystorage", GroupID: "blob", SubnetName: "node-subnet", PrivateDNSZoneID: storageID}},
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("privateEndpoints").Index(0).Child("resourceID"), "mystorage", "must be the resource ID of an Azure resource"),
+				field.Invalid(field.NewPath("privateEndpoints").Index(0).Child("privateDNSZoneID"), storageID, "must be the resource ID of a private DNS zone"),
+			},
+		},
+		{
+			name:      "missing group ID and subnet",
+			endpoints: []PrivateEndpointSpec{{Name: "storage-pe", ResourceID: storageID}},
+			expectedErrs: field.ErrorList{
+				field.Required(field.NewPath("privateEndpoints").Index(0).Child("groupID"), "the group ID can only be defaulted for storage accounts and Key Vaults"),
+				field.Required(field.NewPath("privateEndpoints").Index(0).Child("subnetName"), "the subnet can only be defaulted when the cluster has a single node subnet"),
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validatePrivateEndpoints(test.endpoints, subnets, field.NewPath("privateEndpoints"))
+			if len(test.expectedErrs) == 0 {
+				g.Expect(errs).To(BeEmpty())
+			} else {
+				g.Expect(errs).To(Equal(test.expectedErrs))
+			}
+		})
+	}
+}
+
+func TestValidateIngress(t *testing.T) {
+	subnet := SubnetSpec{Name: "ingress-subnet"}
+	manyCIDRs := make([]string, 51)
+	for i := range manyCIDRs {
+		manyCIDRs[i] = fmt.Sprintf("10.0.%d.0/24", i)
+	}
+
+	tests := []struct {
+		name         string
+		ingress      *IngressSpec
+		expectedErrs field.ErrorList
+	}{
+		{
+			name: "no ingress",
+		},
+		{
+			name:    "ingress with a public IP",
+			ingress: &IngressSpec{Subnet: subnet, Ports: []int32{80, 443}, AllowedSourceCIDRs: []string{"203.0.113.0/24"}, PublicIP: &PublicIPSpec{Name: "ingress-pip"}},
+		},
+		{
+			name:    "invalid and duplicate ports",
+			ingress: &IngressSpec{Subnet: subnet, Ports: []int32{443, 0, 443}},
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("ingress").Child("ports").Index(1), int32(0), "port must be between 1 and 65535"),
+				field.Duplicate(field.NewPath("ingress").Child("ports").Index(2), int32(443)),
+			},
+		},
+		{
+			name:    "invalid CIDR and subnet clashing with a cluster subnet",
+			ingress: &IngressSpec{Subnet: SubnetSpec{Name: "node-subnet"}, Ports: []int32{443}, AllowedSourceCIDRs: []string{"203.0.113.0"}},
+			expectedErrs: field.ErrorList{
+				field.Duplicate(field.NewPath("ingress").Child("subnet").Child("name"), "node-subnet"),
+				field.Invalid(field.NewPath("ingress").Child("allowedSourceCIDRs").Index(0), "203.0.113.0", "invalid CIDR format"),
+			},
+		},
+		{
+			name:    "too many security rules",
+			ingress: &IngressSpec{Subnet: subnet, Ports: []int32{80, 443}, AllowedSourceCIDRs: manyCIDRs},
+			expectedErrs: field.ErrorList{
+				field.TooMany(field.NewPath("ingress").Child("allowedSourceCIDRs"), 102, MaxIngressSecurityRules),
+			},
+		},
+		{
+			name:    "global public IP",
+			ingress: &IngressSpec{Subnet: subnet, Ports: []int32{443}, PublicIP: &PublicIPSpec{Name: "ingress-pip", Tier: PublicIPTierGlobal}},
+			expectedErrs: field.ErrorList{
+				field.Forbidden(field.NewPath("ingress").Child("publicIP").Child("tier"), "the Global tier is only supported for the public IP of a cross-region load balancer"),
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateIngress(test.ingress, Subnets{{Name: "node-subnet"}}, field.NewPath("ingress"))
+			if len(test.expectedErrs) == 0 {
+				g.Expect(errs).To(BeEmpty())
+			} else {
+				g.Expect(errs).To(Equal(test.expectedErrs))
+			}
+		})
+	}
+}
+
+func TestValidatePodSubnet(t *testing.T) {
+	podSubnet := func(cidrs []string, maxPodsPerNode, maxNodes int32) *PodSubnetSpec {
+		return &PodSubnetSpec{
+			Name:              "pod-subnet",
+			CIDRBlocks:        cidrs,
+			MaxPodsPerNode:    maxPodsPerNode,
+			MaxNodes:          maxNodes,
+			ServiceDelegation: DefaultPodSubnetServiceDelegation,
+		}
+	}
+
+	tests := []struct {
+		name         string
+		podSubnet    *PodSubnetSpec
+		expectedErrs field.ErrorList
+	}{
+		{
+			name: "no pod subnet",
+		},
+		{
+			name:      "pod subnet large enough for all the nodes",
+			podSubnet: podSubnet([]string{"10.128.0.0/16"}, 30, 2184),
+		},
+		{
+			name:      "pod subnet with several address prefixes large enough for all the nodes",
+			podSubnet: podSubnet([]string{"10.128.0.0/24", "10.129.0.0/24"}, 10, 50),
+		},
+		{
+			name:      "pod subnet too small for all the nodes",
+			podSubnet: podSubnet([]string{"10.128.0.0/16"}, 30, 2185),
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("podSubnet").Child("cidrBlocks"), []string{"10.128.0.0/16"},
+					"the pod subnet has 65531 usable addresses, fewer than the 65550 required for 30 pods on each of 2185 nodes"),
+			},
+		},
+		{
+			name:      "Azure reserved addresses of each address prefix aren't usable",
+			podSubnet: podSubnet([]string{"10.128.0.0/24", "10.129.0.0/24"}, 10, 51),
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("podSubnet").Child("cidrBlocks"), []string{"10.128.0.0/24", "10.129.0.0/24"},
+					"the pod subnet has 502 usable addresses, fewer than the 510 required for 10 pods on each of 51 nodes"),
+			},
+		},
+		{
+			name:      "pod subnet outside of the vnet",
+			podSubnet: podSubnet([]string{"192.168.0.0/16"}, 30, 10),
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("podSubnet").Child("cidrBlocks"), "192.168.0.0/16", "subnet CIDR not in vnet address space: [10.0.0.0/8]"),
+			},
+		},
+		{
+			name:      "invalid pods and nodes",
+			podSubnet: podSubnet([]string{"10.128.0.0/16"}, 251, 0),
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("podSubnet").Child("maxPodsPerNode"), int32(251), "max pods per node must be between 1 and 250"),
+				field.Invalid(field.NewPath("podSubnet").Child("maxNodes"), int32(0), "max nodes must be greater than 0"),
+			},
+		},
+		{
+			name:      "pod subnet overlapping a node subnet",
+			podSubnet: podSubnet([]string{"10.128.0.0/16", "10.1.128.0/17"}, 30, 10),
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("podSubnet").Child("cidrBlocks").Index(1), "10.1.128.0/17",
+					"the pod subnet overlaps the address prefix 10.1.0.0/16 of subnet node-subnet"),
+			},
+		},
+		{
+			name:      "pod subnet overlapping the ingress subnet",
+			podSubnet: podSubnet([]string{"10.2.0.0/16"}, 30, 10),
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("podSubnet").Child("cidrBlocks").Index(0), "10.2.0.0/16",
+					"the pod subnet overlaps the address prefix 10.2.0.0/24 of subnet ingress-subnet"),
+			},
+		},
+		{
+			name: "pod subnet clashing with a cluster subnet",
+			podSubnet: &PodSubnetSpec{
+				Name:              "node-subnet",
+				CIDRBlocks:        []string{"10.128.0.0/16"},
+				MaxPodsPerNode:    30,
+				MaxNodes:          10,
+				ServiceDelegation: "Microsoft.ContainerService",
+			},
+			expectedErrs: field.ErrorList{
+				field.Duplicate(field.NewPath("podSubnet").Child("name"), "node-subnet"),
+				field.Invalid(field.NewPath("podSubnet").Child("serviceDelegation"), "Microsoft.ContainerService",
+					fmt.Sprintf("service delegation doesn't match regex %s", serviceDelegationRegex)),
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			networkSpec := NetworkSpec{
+				Vnet:      VnetSpec{VnetClassSpec: VnetClassSpec{CIDRBlocks: []string{DefaultVnetCIDR}}},
+				Subnets:   Subnets{{Name: "node-subnet", SubnetClassSpec: SubnetClassSpec{CIDRBlocks: []string{"10.1.0.0/16"}}}},
+				Ingress:   &IngressSpec{Subnet: SubnetSpec{Name: "ingress-subnet", SubnetClassSpec: SubnetClassSpec{CIDRBlocks: []string{"10.2.0.0/24"}}}},
+				PodSubnet: test.podSubnet,
+			}
+			errs := validatePodSubnet(networkSpec, field.NewPath("podSubnet"))
+			if len(test.expectedErrs) == 0 {
+				g.Expect(errs).To(BeEmpty())
+			} else {
+				g.Expect(errs).To(Equal(test.expectedErrs))
+			}
+		})
+	}
+}
+
+func TestValidateInternalLoadBalancers(t *testing.T) {
+	networkSpec := NetworkSpec{
+		Subnets: Subnets{
+			{Name: "cp-subnet", SubnetClassSpec: SubnetClassSpec{Role: SubnetControlPlane, CIDRBlocks: []string{"10.0.0.0/16"}}},
+			{Name: "node-subnet", SubnetClassSpec: SubnetClassSpec{Role: SubnetNode, CIDRBlocks: []string{"10.1.0.0/16"}}},
+		},
+		APIServerLB: LoadBalancerSpec{
+			Name: "my-cluster-internal-lb",
+			LoadBalancerClassSpec: LoadBalancerClassSpec{
+				FrontendIPs: []FrontendIP{
+					{Name: "my-cluster-internal-lb-frontEnd", FrontendIPClass: FrontendIPClass{PrivateIPAddress: "10.1.0.100"}},
+				},
+			},
+		},
+	}
+	rules := []InternalLoadBalancerRule{{Name: "https", Protocol: LoadBalancerRuleProtocolTCP, FrontendPort: 443, BackendPort: 30443}}
+	probe := InternalLoadBalancerProbe{Protocol: ProbeProtocolTCP, Port: 30443}
+	lbPath := field.NewPath("internalLoadBalancers").Index(0)
+
+	tests := []struct {
+		name         string
+		lbs          []InternalLoadBalancerSpec
+		old          []InternalLoadBalancerSpec
+		expectedErrs field.ErrorList
+	}{
+		{
+			name: "no internal load balancers",
+		},
+		{
+			name: "internal load balancer in the node subnet",
+			lbs:  []InternalLoadBalancerSpec{{Name: "ingress-lb", SubnetName: "node-subnet", PrivateIP: "10.1.0.10", Rules: rules, Probe: probe}},
+			old:  []InternalLoadBalancerSpec{{Name: "ingress-lb", SubnetName: "node-subnet", PrivateIP: "10.1.0.10", Rules: rules, Probe: probe}},
+		},
+		{
+			name: "internal load balancer clashing with the API server load balancer",
+			lbs:  []InternalLoadBalancerSpec{{Name: "my-cluster-internal-lb", SubnetName: "node-subnet", PrivateIP: "10.1.0.100", Rules: rules, Probe: probe}},
+			expectedErrs: field.ErrorList{
+				field.Invalid(lbPath.Child("name"), "my-cluster-internal-lb", "name is used by another load balancer of the cluster"),
+				field.Invalid(lbPath.Child("privateIP"), "10.1.0.100", "private IP is used by the API server load balancer"),
+			},
+		},
+		{
+			name: "internal load balancer outside of a node subnet",
+			lbs: []InternalLoadBalancerSpec{
+				{Name: "ingress-lb", SubnetName: "cp-subnet", PrivateIP: "10.0.0.10", Rules: rules, Probe: probe},
+				{Name: "other-lb", SubnetName: "missing-subnet", PrivateIP: "10.2.0.10", Rules: rules, Probe: probe},
+			},
+			expectedErrs: field.ErrorList{
+				field.Invalid(lbPath.Child("subnetName"), "cp-subnet", "the backends of the load balancer are the nodes of a node subnet"),
+				field.NotFound(field.NewPath("internalLoadBalancers").Index(1).Child("subnetName"), "missing-subnet"),
+			},
+		},
+		{
+			name: "private IP out of the subnet range and changed",
+			lbs:  []InternalLoadBalancerSpec{{Name: "ingress-lb", SubnetName: "node-subnet", PrivateIP: "10.2.0.10", Rules: rules, Probe: probe}},
+			old:  []InternalLoadBalancerSpec{{Name: "ingress-lb", SubnetName: "node-subnet", PrivateIP: "10.1.0.10", Rules: rules, Probe: probe}},
+			expectedErrs: field.ErrorList{
+				field.Invalid(lbPath.Child("privateIP"), "10.2.0.10", "private IP needs to be in the subnet range ([10.1.0.0/16])"),
+				field.Forbidden(lbPath.Child("privateIP"), "the private IP of a load balancer is immutable"),
+			},
+		},
+		{
+			name: "duplicate load balancers",
+			lbs: []InternalLoadBalancerSpec{
+				{Name: "ingress-lb", SubnetName: "node-subnet", PrivateIP: "10.1.0.10", Rules: rules, Probe: probe},
+				{Name: "ingress-lb", SubnetName: "node-subnet", PrivateIP: "10.1.0.10", Rules: rules, Probe: probe},
+			},
+			expectedErrs: field.ErrorList{
+				field.Duplicate(field.NewPath("internalLoadBalancers").Index(1).Child("name"), "ingress-lb"),
+				field.Duplicate(field.NewPath("internalLoadBalancers").Index(1).Child("privateIP"), "10.1.0.10"),
+			},
+		},
+		{
+			name: "invalid rules and probe",
+			lbs: []InternalLoadBalancerSpec{{
+				Name:       "ingress-lb",
+				SubnetName: "node-subnet",
+				PrivateIP:  "10.1.0.10",
+				Rules: []InternalLoadBalancerRule{
+					{Name: "https", Protocol: LoadBalancerRuleProtocolTCP, FrontendPort: 443, BackendPort: 30443},
+					{Name: "https", Protocol: LoadBalancerRuleProtocolTCP, FrontendPort: 443, BackendPort: 0},
+				},
+				Probe: InternalLoadBalancerProbe{Protocol: ProbeProtocolHTTP, Port: 30443, RequestPath: "healthz"},
+			}},
+			expectedErrs: field.ErrorList{
+				field.Duplicate(lbPath.Child("rules").Index(1).Child("name"), "https"),
+				field.Invalid(lbPath.Child("rules").Index(1).Child("backendPort"), int32(0), "port must be between 1 and 65535"),
+				field.Duplicate(lbPath.Child("rules").Index(1).Child("frontendPort"), int32(443)),
+				field.Invalid(lbPath.Child("probe").Child("requestPath"), "healthz", "request path must start with /"),
+			},
+		},
+		{
+			name: "rules bound to named probes",
+			lbs: []InternalLoadBalancerSpec{{
+				Name:       "app-lb",
+				SubnetName: "node-subnet",
+				PrivateIP:  "10.1.0.10",
+				Rules: []InternalLoadBalancerRule{
+					{Name: "grpc", Protocol: LoadBalancerRuleProtocolTCP, FrontendPort: 9000, BackendPort: 9000, ProbeName: "liveness"},
+					{Name: "web", Protocol: LoadBalancerRuleProtocolTCP, FrontendPort: 443, BackendPort: 30443, ProbeName: "readiness"},
+				},
+				Probe: probe,
+				Probes: []InternalLoadBalancerProbe{
+					{Name: "liveness", Protocol: ProbeProtocolTCP, Port: 9000, IntervalInSeconds: 15, NumberOfProbes: 4},
+					{Name: "readiness", Protocol: ProbeProtocolHTTPS, Port: 30443, RequestPath: "/ready", IntervalInSeconds: 5, NumberOfProbes: 2},
+				},
+			}},
+		},
+		{
+			name: "rules bound to missing and invalid named probes",
+			lbs: []InternalLoadBalancerSpec{{
+				Name:       "app-lb",
+				SubnetName: "node-subnet",
+				PrivateIP:  "10.1.0.10",
+				Rules: []InternalLoadBalancerRule{
+					{Name: "grpc", Protocol: LoadBalancerRuleProtocolTCP, FrontendPort: 9000, BackendPort: 9000, ProbeName: "liveness"},
+					{Name: "web", Protocol: LoadBalancerRuleProtocolTCP, FrontendPort: 443, BackendPort: 30443, ProbeName: "readiness"},
+				},
+				Probes: []InternalLoadBalancerProbe{
+					{Name: "liveness", Protocol: ProbeProtocolTCP, Port: 9000, IntervalInSeconds: 2},
+					{Name: "liveness", Protocol: ProbeProtocolTCP, Port: 9000},
+				},
+			}},
+			expectedErrs: field.ErrorList{
+				field.Invalid(lbPath.Child("probes").Index(0).Child("intervalInSeconds"), int32(2), "interval must be at least 5 seconds"),
+				field.Duplicate(lbPath.Child("probes").Index(1).Child("name"), "liveness"),
+				field.NotFound(lbPath.Child("rules").Index(1).Child("probeName"), "readiness"),
+			},
+		},
+		{
+			name: "rule bound to a named probe without named probes",
+			lbs: []InternalLoadBalancerSpec{{
+				Name:       "ingress-lb",
+				SubnetName: "node-subnet",
+				PrivateIP:  "10.1.0.10",
+				Rules:      []InternalLoadBalancerRule{{Name: "https", Protocol: LoadBalancerRuleProtocolTCP, FrontendPort: 443, BackendPort: 30443, ProbeName: "readiness"}},
+				Probe:      probe,
+			}},
+			expectedErrs: field.ErrorList{
+				field.Invalid(lbPath.Child("rules").Index(0).Child("probeName"), "readiness", "the load balancer has no named probes, the rules are bound to its probe"),
+			},
+		},
+		{
+			name: "no rules",
+			lbs:  []InternalLoadBalancerSpec{{Name: "ingress-lb", SubnetName: "node-subnet", PrivateIP: "10.1.0.10", Probe: probe}},
+			expectedErrs: field.ErrorList{
+				field.Required(lbPath.Child("rules"), "the load balancer needs at least one rule"),
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			spec := networkSpec
+			spec.InternalLoadBalancers = test.lbs
+			errs := validateInternalLoadBalancers(spec, test.old, field.NewPath("internalLoadBalancers"))
+			if len(test.expectedErrs) == 0 {
+				g.Expect(errs).To(BeEmpty())
+			} else {
+				g.Expect(errs).To(Equal(test.expectedErrs))
+			}
+		})
+	}
+}
+
+func TestValidateNetworkInterfaceSecurityGroups(t *testing.T) {
+	sshRule := SecurityRule{
+		Name:             "allow_ssh",
+		Protocol:         SecurityGroupProtocolTCP,
+		Direction:        SecurityRuleDirectionInbound,
+		Priority:         2200,
+		DestinationPorts: pointer.String("22"),
+	}
+	contradictingSSHRule := sshRule
+	contradictingSSHRule.Source = pointer.String("10.0.0.0/8")
+	subnets := Subnets{
+		{
+			SubnetClassSpec: SubnetClassSpec{Role: SubnetControlPlane},
+			Name:            "cp-subnet",
+			SecurityGroup: SecurityGroup{
+				Name:               "cp-nsg",
+				SecurityGroupClass: SecurityGroupClass{SecurityRules: SecurityRules{sshRule}},
+			},
+		},
+		{
+			SubnetClassSpec: SubnetClassSpec{Role: SubnetNode},
+			Name:            "node-subnet",
+			SecurityGroup:   SecurityGroup{Name: "node-nsg"},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		nicSecurityGroups *NetworkInterfaceSecurityGroups
+		expectedErrs      field.ErrorList
+	}{
+		{
+			name: "no network interface security groups",
+		},
+		{
+			name: "network interface security groups with the rules of the subnets",
+			nicSecurityGroups: &NetworkInterfaceSecurityGroups{
+				Attachment: SecurityGroupAttachmentSubnetAndNetworkInterface,
+				ControlPlane: SecurityGroup{
+					Name:               "cp-nic-nsg",
+					SecurityGroupClass: SecurityGroupClass{SecurityRules: SecurityRules{sshRule}},
+				},
+				Node: SecurityGroup{Name: "node-nic-nsg"},
+			},
+		},
+		{
+			name: "contradicting rules are allowed when the subnets have no security group",
+			nicSecurityGroups: &NetworkInterfaceSecurityGroups{
+				Attachment: SecurityGroupAttachmentNetworkInterface,
+				ControlPlane: SecurityGroup{
+					Name:               "cp-nic-nsg",
+					SecurityGroupClass: SecurityGroupClass{SecurityRules: SecurityRules{contradictingSSHRule}},
+				},
+				Node: SecurityGroup{Name: "node-nic-nsg"},
+			},
+		},
+		{
+			name: "contradicting rule",
+			nicSecurityGroups: &NetworkInterfaceSecurityGroups{
+				Attachment: SecurityGroupAttachmentSubnetAndNetworkInterface,
+				ControlPlane: SecurityGroup{
+					Name:               "cp-nic-nsg",
+					SecurityGroupClass: SecurityGroupClass{SecurityRules: SecurityRules{contradictingSSHRule}},
+				},
+				Node: SecurityGroup{Name: "node-nic-nsg"},
+			},
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("networkInterfaceSecurityGroups").Child("controlPlane").Child("securityRules").Index(0), "allow_ssh",
+					"security rule contradicts the rule of the same name of the security group of subnet cp-subnet"),
+			},
+		},
+		{
+			name: "duplicate names and rules",
+			nicSecurityGroups: &NetworkInterfaceSecurityGroups{
+				Attachment: SecurityGroupAttachmentNetworkInterface,
+				ControlPlane: SecurityGroup{
+					Name: "node-nsg",
+				},
+				Node: SecurityGroup{
+					Name:               "node-nsg",
+					SecurityGroupClass: SecurityGroupClass{SecurityRules: SecurityRules{sshRule, sshRule}},
+				},
+			},
+			expectedErrs: field.ErrorList{
+				field.Duplicate(field.NewPath("networkInterfaceSecurityGroups").Child("controlPlane").Child("name"), "node-nsg"),
+				field.Duplicate(field.NewPath("networkInterfaceSecurityGroups").Child("node").Child("name"), "node-nsg"),
+				field.Duplicate(field.NewPath("networkInterfaceSecurityGroups").Child("node").Child("securityRules").Index(1).Child("name"), "allow_ssh"),
+				field.Duplicate(field.NewPath("networkInterfaceSecurityGroups").Child("node", "name"), "node-nsg"),
+			},
+		},
+		{
+			name: "missing name and invalid priority",
+			nicSecurityGroups: &NetworkInterfaceSecurityGroups{
+				Attachment: SecurityGroupAttachmentNetworkInterface,
+				ControlPlane: SecurityGroup{
+					SecurityGroupClass: SecurityGroupClass{SecurityRules: SecurityRules{{Name: "allow_all", Priority: 50}}},
+				},
+				Node: SecurityGroup{Name: "node-nic-nsg"},
+			},
+			expectedErrs: field.ErrorList{
+				field.Required(field.NewPath("networkInterfaceSecurityGroups").Child("controlPlane").Child("name"), "name of the security group is required"),
+				field.Invalid(field.NewPath("networkInterfaceSecurityGroups").Child("controlPlane").Child("securityRules").Index(0), int32(50),
+					fmt.Sprintf("security rule priorities should be between %d and %d", minRulePriority, maxRulePriority)),
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateNetworkInterfaceSecurityGroups(test.nicSecurityGroups, subnets, field.NewPath("networkInterfaceSecurityGroups"))
+			if len(test.expectedErrs) == 0 {
+				g.Expect(errs).To(BeEmpty())
+			} else {
+				g.Expect(errs).To(Equal(test.expectedErrs))
+			}
+		})
+	}
+}
+
+func TestValidateSecurityRuleDescriptions(t *testing.T) {
+	describedRule := SecurityRule{Name: "allow_ssh", Description: "Allow SSH from the bastion"}
+	undescribedRule := SecurityRule{Name: "allow_https"}
 
-	type test struct {
-		name       string
-		subnetName string
+	tests := []struct {
+		name         string
+		networkSpec  NetworkSpec
+		expectedErrs field.ErrorList
+	}{
+		{
+			name: "all security rules have a description",
+			networkSpec: NetworkSpec{
+				Subnets: Subnets{
+					{
+						Name: "cp-subnet",
+						SecurityGroup: SecurityGroup{
+							SecurityGroupClass: SecurityGroupClass{SecurityRules: SecurityRules{describedRule}},
+						},
+					},
+				},
+				NetworkInterfaceSecurityGroups: &NetworkInterfaceSecurityGroups{
+					Node: SecurityGroup{
+						SecurityGroupClass: SecurityGroupClass{SecurityRules: SecurityRules{describedRule}},
+					},
+				},
+			},
+		},
+		{
+			name: "security rules without a description",
+			networkSpec: NetworkSpec{
+				Subnets: Subnets{
+					{
+						Name: "cp-subnet",
+						SecurityGroup: SecurityGroup{
+							SecurityGroupClass: SecurityGroupClass{SecurityRules: SecurityRules{describedRule, undescribedRule}},
+						},
+					},
+				},
+				NetworkInterfaceSecurityGroups: &NetworkInterfaceSecurityGroups{
+					ControlPlane: SecurityGroup{
+						SecurityGroupClass: SecurityGroupClass{SecurityRules: SecurityRules{{Name: "allow_kubelet", Description: " "}}},
+					},
+				},
+				Ingress: &IngressSpec{
+					Subnet: SubnetSpec{
+						SecurityGroup: SecurityGroup{
+							SecurityGroupClass: SecurityGroupClass{SecurityRules: SecurityRules{undescribedRule}},
+						},
+					},
+				},
+			},
+			expectedErrs: field.ErrorList{
+				field.Required(field.NewPath("networkSpec", "subnets").Index(0).Child("securityGroup", "securityRules").Index(1).Child("description"),
+					"security rule allow_https must have a description"),
+				field.Required(field.NewPath("networkSpec", "networkInterfaceSecurityGroups", "controlPlane", "securityRules").Index(0).Child("description"),
+					"security rule allow_kubelet must have a description"),
+				field.Required(field.NewPath("networkSpec", "ingress", "subnet", "securityGroup", "securityRules").Index(0).Child("description"),
+					"security rule allow_https must have a description"),
+			},
+		},
 	}
-
-	testCase := test{
-		name:       "subnet name - invalid",
-		subnetName: "inv@lid-subnet-name",
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateSecurityRuleDescriptions(test.networkSpec, field.NewPath("networkSpec"))
+			if len(test.expectedErrs) == 0 {
+				g.Expect(errs).To(BeEmpty())
+			} else {
+				g.Expect(errs).To(Equal(test.expectedErrs))
+			}
+		})
 	}
-
-	t.Run(testCase.name, func(t *testing.T) {
-		err := validateSubnetName(testCase.subnetName,
-			field.NewPath("spec").Child("networkSpec").Child("subnets").Index(0).Child("name"))
-		g.Expect(err).NotTo(BeNil())
-		g.Expect(err.Type).To(Equal(field.ErrorTypeInvalid))
-		g.Expect(err.Field).To(Equal("spec.networkSpec.subnets[0].name"))
-		g.Expect(err.BadValue).To(BeEquivalentTo(testCase.subnetName))
-	})
 }
 
-func TestValidateSubnetCIDR(t *testing.T) {
-	g := NewWithT(t)
-
+func TestValidateIntraClusterTraffic(t *testing.T) {
 	tests := []struct {
-		name             string
-		vnetCidrBlocks   []string
-		subnetCidrBlocks []string
-		wantErr          bool
-		expectedErr      field.Error
+		name         string
+		networkSpec  NetworkSpec
+		expectedErrs field.ErrorList
 	}{
 		{
-			name:             "valid subnet cidr",
-			vnetCidrBlocks:   []string{"10.0.0.0/8"},
-			subnetCidrBlocks: []string{"10.1.0.0/16", "10.0.0.0/16"},
-			wantErr:          false,
+			name:        "intra-cluster traffic not allowed",
+			networkSpec: NetworkSpec{},
 		},
 		{
-			name:             "invalid subnet cidr not in the right format",
-			vnetCidrBlocks:   []string{"10.0.0.0/8"},
-			subnetCidrBlocks: []string{"10.1.0.0/16", "10.0.0.0/16", "foo/bar"},
-			wantErr:          true,
-			expectedErr: field.Error{
-				Type:     "FieldValueInvalid",
-				Field:    "subnets.cidrBlocks",
-				BadValue: "foo/bar",
-				Detail:   "invalid CIDR format",
+			name: "default address ranges",
+			networkSpec: NetworkSpec{
+				IntraClusterTraffic: &IntraClusterTraffic{},
 			},
 		},
 		{
-			name:             "subnet cidr not in vnet range",
-			vnetCidrBlocks:   []string{"10.0.0.0/8"},
-			subnetCidrBlocks: []string{"10.1.0.0/16", "10.0.0.0/16", "11.1.0.0/16"},
-			wantErr:          true,
-			expectedErr: field.Error{
-				Type:     "FieldValueInvalid",
-				Field:    "subnets.cidrBlocks",
-				BadValue: "11.1.0.0/16",
-				Detail:   "subnet CIDR not in vnet address space: [10.0.0.0/8]",
+			name: "valid address ranges",
+			networkSpec: NetworkSpec{
+				IntraClusterTraffic: &IntraClusterTraffic{CIDRs: []string{"10.0.0.0/8", "192.168.0.0/16", "fd00::/48"}},
 			},
 		},
 		{
-			name:             "subnet cidr in atleast one vnet's range in case of multiple vnet cidr blocks",
-			vnetCidrBlocks:   []string{"10.0.0.0/8", "11.0.0.0/8"},
-			subnetCidrBlocks: []string{"10.1.0.0/16", "10.0.0.0/16", "11.1.0.0/16"},
-			wantErr:          false,
+			name: "invalid and duplicate address ranges",
+			networkSpec: NetworkSpec{
+				IntraClusterTraffic: &IntraClusterTraffic{CIDRs: []string{"10.0.0.0/8", "10.0.0.0", "10.0.0.0/8"}},
+			},
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("networkSpec", "intraClusterTraffic", "cidrs").Index(1), "10.0.0.0", "invalid CIDR format"),
+				field.Duplicate(field.NewPath("networkSpec", "intraClusterTraffic", "cidrs").Index(2), "10.0.0.0/8"),
+			},
+		},
+		{
+			name: "security rules with the reserved prefix",
+			networkSpec: NetworkSpec{
+				Subnets: Subnets{
+					{
+						Name: "node-subnet",
+						SecurityGroup: SecurityGroup{
+							SecurityGroupClass: SecurityGroupClass{SecurityRules: SecurityRules{{Name: "allow_intra_cluster_inbound_0"}}},
+						},
+					},
+				},
+				NetworkInterfaceSecurityGroups: &NetworkInterfaceSecurityGroups{
+					Node: SecurityGroup{
+						SecurityGroupClass: SecurityGroupClass{SecurityRules: SecurityRules{{Name: "allow_ssh"}, {Name: "allow_intra_cluster_pods"}}},
+					},
+				},
+			},
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("networkSpec", "subnets").Index(0).Child("securityGroup", "securityRules").Index(0).Child("name"),
+					"allow_intra_cluster_inbound_0", "the allow_intra_cluster_ prefix is reserved for the security rules generated from intraClusterTraffic"),
+				field.Invalid(field.NewPath("networkSpec", "networkInterfaceSecurityGroups", "node", "securityRules").Index(1).Child("name"),
+					"allow_intra_cluster_pods", "the allow_intra_cluster_ prefix is reserved for the security rules generated from intraClusterTraffic"),
+			},
 		},
 	}
-	for _, testCase := range tests {
-		t.Run(testCase.name, func(t *testing.T) {
-			err := validateSubnetCIDR(testCase.subnetCidrBlocks, testCase.vnetCidrBlocks, field.NewPath("subnets.cidrBlocks"))
-			if testCase.wantErr {
-				// Searches for expected error in list of thrown errors
-				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateIntraClusterTraffic(test.networkSpec, field.NewPath("networkSpec"))
+			if len(test.expectedErrs) == 0 {
+				g.Expect(errs).To(BeEmpty())
 			} else {
-				g.Expect(err).To(HaveLen(0))
+				g.Expect(errs).To(Equal(test.expectedErrs))
 			}
 		})
 	}
 }
 
-func TestValidateSecurityRule(t *testing.T) {
-	g := NewWithT(t)
-
+func TestValidateCrossTenantVnetPeering(t *testing.T) {
 	tests := []struct {
-		name      string
-		validRule SecurityRule
-		wantErr   bool
+		name         string
+		peering      VnetPeeringSpec
+		expectedErrs field.ErrorList
 	}{
 		{
-			name: "security rule - valid priority",
-			validRule: SecurityRule{
-				Name:        "allow_apiserver",
-				Description: "Allow K8s API Server",
-				Priority:    101,
+			name:    "peering in the tenant of the cluster",
+			peering: VnetPeeringSpec{RemoteVnetName: "hub-vnet", ResourceGroup: "hub-rg"},
+		},
+		{
+			name: "peering with another tenant",
+			peering: VnetPeeringSpec{
+				RemoteVnetName: "hub-vnet",
+				ResourceGroup:  "hub-rg",
+				TenantID:       "72f988bf-86f1-41af-91ab-2d7cd011db47",
+				SubscriptionID: "00000000-0000-0000-0000-000000000000",
+				IdentityRef:    &corev1.ObjectReference{Name: "hub-identity"},
 			},
-			wantErr: false,
 		},
 		{
-			name: "security rule - invalid low priority",
-			validRule: SecurityRule{
-				Name:        "allow_apiserver",
-				Description: "Allow K8s API Server",
-				Priority:    99,
+			name: "peering with another tenant without its subscription and identity",
+			peering: VnetPeeringSpec{
+				RemoteVnetName: "hub-vnet",
+				TenantID:       "contoso",
+			},
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("peerings").Index(0).Child("tenantID"), "contoso", "must be the ID of an Azure AD tenant"),
+				field.Required(field.NewPath("peerings").Index(0).Child("subscriptionID"), "the subscription of a virtual network of another tenant is required"),
+				field.Required(field.NewPath("peerings").Index(0).Child("identityRef"),
+					"an AzureClusterIdentity of the tenant of the remote virtual network is required to create the peering from it"),
 			},
-			wantErr: true,
 		},
 		{
-			name: "security rule - invalid high priority",
-			validRule: SecurityRule{
-				Name:        "allow_apiserver",
-				Description: "Allow K8s API Server",
-				Priority:    5000,
+			name: "subscription and identity without the tenant",
+			peering: VnetPeeringSpec{
+				RemoteVnetName: "hub-vnet",
+				SubscriptionID: "00000000-0000-0000-0000-000000000000",
+				IdentityRef:    &corev1.ObjectReference{Name: "hub-identity"},
+			},
+			expectedErrs: field.ErrorList{
+				field.Forbidden(field.NewPath("peerings").Index(0).Child("subscriptionID"), "the subscription of the remote virtual network can only be set with its tenant"),
+				field.Forbidden(field.NewPath("peerings").Index(0).Child("identityRef"), "the identity of the remote virtual network can only be set with its tenant"),
 			},
-			wantErr: true,
 		},
 	}
-	for _, testCase := range tests {
-		testCase := testCase
-		t.Run(testCase.name, func(t *testing.T) {
-			t.Parallel()
-			err := validateSecurityRule(
-				testCase.validRule,
-				field.NewPath("spec").Child("networkSpec").Child("subnets").Index(0).Child("securityGroup").Child("securityRules").Index(0),
-			)
-			if testCase.wantErr {
-				g.Expect(err).To(HaveOccurred())
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateVnetPeerings(VnetPeerings{test.peering}, field.NewPath("peerings"))
+			if len(test.expectedErrs) == 0 {
+				g.Expect(errs).To(BeEmpty())
 			} else {
-				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(errs).To(Equal(test.expectedErrs))
 			}
 		})
 	}
 }
 
-func TestValidateAPIServerLB(t *testing.T) {
+func TestValidateOutboundConnectivityCheck(t *testing.T) {
 	g := NewWithT(t)
 
 	testcases := []struct {
 		name        string
-		lb          LoadBalancerSpec
-		old         LoadBalancerSpec
-		cpCIDRS     []string
+		check       *OutboundConnectivityCheck
 		wantErr     bool
 		expectedErr field.Error
 	}{
 		{
-			name: "invalid SKU",
-			lb: LoadBalancerSpec{
-				Name: "my-awesome-lb",
-				LoadBalancerClassSpec: LoadBalancerClassSpec{
-
-					SKU: "Awesome",
-					FrontendIPs: []FrontendIP{
-						{
-							Name: "ip-config",
-						},
-					},
-					Type: Public,
-				},
-			},
-			wantErr: true,
-			expectedErr: field.Error{
-				Type:     "FieldValueNotSupported",
-				Field:    "apiServerLB.sku",
-				BadValue: "Awesome",
-				Detail:   "supported values: \"Standard\"",
-			},
+			name:    "no outbound connectivity check",
+			wantErr: false,
 		},
 		{
-			name: "invalid Type",
-			lb: LoadBalancerSpec{
-				LoadBalancerClassSpec: LoadBalancerClassSpec{
-					Type: "Foo",
-				},
+			name: "valid outbound connectivity check",
+			check: &OutboundConnectivityCheck{
+				DestinationIP:               "20.37.158.0",
+				Port:                        443,
+				Protocol:                    "TCP",
+				NetworkWatcherName:          "NetworkWatcher_westus2",
+				NetworkWatcherResourceGroup: "NetworkWatcherRG",
 			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid destination ip",
+			check:   &OutboundConnectivityCheck{DestinationIP: "2001:db8::1", Port: 443},
 			wantErr: true,
 			expectedErr: field.Error{
-				Type:     "FieldValueNotSupported",
-				Field:    "apiServerLB.type",
-				BadValue: "Foo",
-				Detail:   "supported values: \"Public\", \"Internal\"",
+				Type:     "FieldValueInvalid",
+				Field:    "outboundConnectivityCheck.destinationIP",
+				BadValue: "2001:db8::1",
+				Detail:   "destinationIP must be a valid IPv4 address",
 			},
 		},
 		{
-			name: "invalid Name",
-			lb: LoadBalancerSpec{
-				Name: "***",
-			},
+			name:    "invalid port",
+			check:   &OutboundConnectivityCheck{DestinationIP: "20.37.158.0", Port: 0},
 			wantErr: true,
 			expectedErr: field.Error{
 				Type:     "FieldValueInvalid",
-				Field:    "apiServerLB.name",
-				BadValue: "***",
-				Detail:   "name of load balancer doesn't match regex ^[-\\w\\._]+$",
+				Field:    "outboundConnectivityCheck.port",
+				BadValue: int32(0),
+				Detail:   "port must be between 1 and 65535",
 			},
 		},
 		{
-			name: "too many IP configs",
-			lb: LoadBalancerSpec{
-				LoadBalancerClassSpec: LoadBalancerClassSpec{
-					FrontendIPs: []FrontendIP{
-						{
-							Name: "ip-1",
-						},
-						{
-							Name: "ip-2",
-						},
-					},
-				},
-			},
+			name:    "invalid network watcher name",
+			check:   &OutboundConnectivityCheck{DestinationIP: "20.37.158.0", Port: 443, NetworkWatcherName: "watcher."},
 			wantErr: true,
 			expectedErr: field.Error{
-				Type:  "FieldValueInvalid",
-				Field: "apiServerLB.frontendIPConfigs",
-				BadValue: []FrontendIP{
-					{
-						Name: "ip-1",
-					},
-					{
-						Name: "ip-2",
-					},
-				},
-				Detail: "API Server Load balancer should have 1 Frontend IP",
+				Type:     "FieldValueInvalid",
+				Field:    "outboundConnectivityCheck.networkWatcherName",
+				BadValue: "watcher.",
+				Detail:   "networkWatcherName doesn't match regex ^[a-zA-Z0-9]([-\\w\\.]{0,78}\\w)?$",
 			},
 		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateOutboundConnectivityCheck(test.check, field.NewPath("outboundConnectivityCheck"))
+			if test.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(test.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(HaveLen(0))
+			}
+		})
+	}
+}
+
+func TestValidateBastionSpec(t *testing.T) {
+	g := NewWithT(t)
+
+	validJumpbox := func() *Jumpbox {
+		return &Jumpbox{
+			Name:               "my-jumpbox",
+			SSHPublicKey:       "c3NoLXJzYSBBQUFB",
+			AllowedSourceCIDRs: []string{"203.0.113.0/24"},
+			Subnet:             SubnetSpec{Name: "my-jumpbox-subnet"},
+		}
+	}
+
+	testcases := []struct {
+		name        string
+		bastion     BastionSpec
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name:    "no jumpbox",
+			bastion: BastionSpec{AzureBastion: &AzureBastion{}},
+			wantErr: false,
+		},
 		{
-			name: "public LB with private IP",
-			lb: LoadBalancerSpec{
-				LoadBalancerClassSpec: LoadBalancerClassSpec{
-					Type: Public,
-					FrontendIPs: []FrontendIP{
-						{
-							Name: "ip-1",
-							FrontendIPClass: FrontendIPClass{
-								PrivateIPAddress: "10.0.0.4",
-							},
-						},
-					},
-				},
-			},
+			name:    "valid jumpbox",
+			bastion: BastionSpec{Jumpbox: validJumpbox()},
+			wantErr: false,
+		},
+		{
+			name:    "jumpbox and azure bastion",
+			bastion: BastionSpec{Jumpbox: validJumpbox(), AzureBastion: &AzureBastion{}},
 			wantErr: true,
 			expectedErr: field.Error{
 				Type:   "FieldValueForbidden",
-				Field:  "apiServerLB.frontendIPConfigs[0].privateIP",
-				Detail: "Public Load Balancers cannot have a Private IP",
+				Field:  "bastionSpec.jumpbox",
+				Detail: "jumpbox and azureBastion are mutually exclusive",
 			},
 		},
 		{
-			name: "internal LB with public IP",
-			lb: LoadBalancerSpec{
-				LoadBalancerClassSpec: LoadBalancerClassSpec{
-					Type: Internal,
-					FrontendIPs: []FrontendIP{
-						{
-							Name: "ip-1",
-							PublicIP: &PublicIPSpec{
-								Name: "my-invalid-ip",
-							},
-						},
-					},
-				},
-			},
+			name: "missing ssh public key",
+			bastion: BastionSpec{Jumpbox: func() *Jumpbox {
+				j := validJumpbox()
+				j.SSHPublicKey = ""
+				return j
+			}()},
 			wantErr: true,
 			expectedErr: field.Error{
-				Type:   "FieldValueForbidden",
-				Field:  "apiServerLB.frontendIPConfigs[0].publicIP",
-				Detail: "Internal Load Balancers cannot have a Public IP",
+				Type:   "FieldValueRequired",
+				Field:  "bastionSpec.jumpbox.sshPublicKey",
+				Detail: "an SSH public key is required to log in to the jumpbox",
 			},
 		},
 		{
-			name: "internal LB with invalid private IP",
-			lb: LoadBalancerSpec{
-				LoadBalancerClassSpec: LoadBalancerClassSpec{
-					Type: Internal,
-					FrontendIPs: []FrontendIP{
-						{
-							Name: "ip-1",
-							FrontendIPClass: FrontendIPClass{
-								PrivateIPAddress: "NAIP",
-							},
-						},
-					},
-				},
-			},
+			name: "ssh public key not base64 encoded",
+			bastion: BastionSpec{Jumpbox: func() *Jumpbox {
+				j := validJumpbox()
+				j.SSHPublicKey = "ssh-rsa AAAA"
+				return j
+			}()},
 			wantErr: true,
 			expectedErr: field.Error{
 				Type:     "FieldValueInvalid",
-				Field:    "apiServerLB.frontendIPConfigs[0].privateIP",
-				BadValue: "NAIP",
-				Detail:   "Internal LB IP address isn't a valid IPv4 or IPv6 address",
+				Field:    "bastionSpec.jumpbox.sshPublicKey",
+				BadValue: "ssh-rsa AAAA",
+				Detail:   "sshPublicKey must be base64 encoded",
 			},
 		},
 		{
-			name: "internal LB with out of range private IP",
-			lb: LoadBalancerSpec{
-				LoadBalancerClassSpec: LoadBalancerClassSpec{
-					Type: Internal,
-					FrontendIPs: []FrontendIP{
-						{
-							Name: "ip-1",
-							FrontendIPClass: FrontendIPClass{
-								PrivateIPAddress: "20.1.2.3",
-							},
-						},
-					},
-				},
-			},
-			cpCIDRS: []string{"10.0.0.0/24", "10.1.0.0/24"},
+			name: "no allowed source CIDRs",
+			bastion: BastionSpec{Jumpbox: func() *Jumpbox {
+				j := validJumpbox()
+				j.AllowedSourceCIDRs = nil
+				return j
+			}()},
 			wantErr: true,
 			expectedErr: field.Error{
-				Type:     "FieldValueInvalid",
-				Field:    "apiServerLB.frontendIPConfigs[0].privateIP",
-				BadValue: "20.1.2.3",
-				Detail:   "Internal LB IP address needs to be in control plane subnet range ([10.0.0.0/24 10.1.0.0/24])",
+				Type:   "FieldValueRequired",
+				Field:  "bastionSpec.jumpbox.allowedSourceCIDRs",
+				Detail: "at least one source CIDR must be allowed to reach the jumpbox",
 			},
 		},
 		{
-			name: "internal LB with in range private IP",
-			lb: LoadBalancerSpec{
-				LoadBalancerClassSpec: LoadBalancerClassSpec{
-					Type: Internal,
-					SKU:  SKUStandard,
-					FrontendIPs: []FrontendIP{
-						{
-							Name: "ip-1",
-							FrontendIPClass: FrontendIPClass{
-								PrivateIPAddress: "10.1.0.3",
-							},
-						},
-					},
-				},
-				Name: "my-private-lb",
+			name: "invalid allowed source CIDR",
+			bastion: BastionSpec{Jumpbox: func() *Jumpbox {
+				j := validJumpbox()
+				j.AllowedSourceCIDRs = []string{"203.0.113.0/24", "*"}
+				return j
+			}()},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "bastionSpec.jumpbox.allowedSourceCIDRs[1]",
+				BadValue: "*",
+				Detail:   "invalid CIDR format",
 			},
-			cpCIDRS: []string{"10.0.0.0/24", "10.1.0.0/24"},
-			wantErr: false,
 		},
 	}
 
@@ -868,7 +3883,7 @@ func TestValidateAPIServerLB(t *testing.T) {
 		test := test
 		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
-			err := validateAPIServerLB(test.lb, test.old, test.cpCIDRS, field.NewPath("apiServerLB"))
+			err := validateBastionSpec(test.bastion, field.NewPath("bastionSpec"))
 			if test.wantErr {
 				g.Expect(err).To(ContainElement(MatchError(test.expectedErr.Error())))
 			} else {
@@ -877,71 +3892,125 @@ func TestValidateAPIServerLB(t *testing.T) {
 		})
 	}
 }
-func TestPrivateDNSZoneName(t *testing.T) {
+
+func TestValidateApplicationSecurityGroups(t *testing.T) {
 	g := NewWithT(t)
 
+	subnetWithRule := func(rule SecurityRule) Subnets {
+		return Subnets{
+			{
+				SubnetClassSpec: SubnetClassSpec{
+					Role: SubnetControlPlane,
+				},
+				Name: "control-plane-subnet",
+				SecurityGroup: SecurityGroup{
+					Name: "control-plane-nsg",
+					SecurityGroupClass: SecurityGroupClass{
+						SecurityRules: SecurityRules{rule},
+					},
+				},
+			},
+		}
+	}
+
 	testcases := []struct {
 		name        string
-		network     NetworkSpec
+		asgs        []ApplicationSecurityGroup
+		subnets     Subnets
 		wantErr     bool
 		expectedErr field.Error
 	}{
 		{
-			name: "testInvalidPrivateDNSZoneName",
-			network: NetworkSpec{
-				NetworkClassSpec: NetworkClassSpec{
-					PrivateDNSZoneName: "wrong@d_ns.io",
-				},
-				APIServerLB: createValidAPIServerInternalLB(),
-			},
+			name:    "no application security groups",
+			subnets: createValidSubnets(),
+			wantErr: false,
+		},
+		{
+			name: "rule referencing application security groups",
+			asgs: []ApplicationSecurityGroup{{Name: "control-plane-asg", Role: SubnetControlPlane}, {Name: "node-asg", Role: SubnetNode}},
+			subnets: subnetWithRule(SecurityRule{
+				Name:                                 "allow-kubelet",
+				SourceApplicationSecurityGroups:      []string{"control-plane-asg"},
+				DestinationApplicationSecurityGroups: []string{"node-asg"},
+				DestinationPorts:                     pointer.StringPtr("10250"),
+			}),
+			wantErr: false,
+		},
+		{
+			name:    "invalid application security group name",
+			asgs:    []ApplicationSecurityGroup{{Name: "my asg"}},
+			wantErr: true,
 			expectedErr: field.Error{
 				Type:     "FieldValueInvalid",
-				Field:    "spec.networkSpec.privateDNSZoneName",
-				BadValue: "wrong@d_ns.io",
-				Detail:   "PrivateDNSZoneName can only contain alphanumeric characters, underscores and dashes, must end with an alphanumeric character",
+				Field:    "networkSpec.applicationSecurityGroups[0].name",
+				BadValue: "my asg",
+				Detail:   "name of application security group doesn't match regex ^[-\\w\\._]+$",
 			},
-			wantErr: true,
 		},
 		{
-			name: "testValidPrivateDNSZoneName",
-			network: NetworkSpec{
-				NetworkClassSpec: NetworkClassSpec{
-					PrivateDNSZoneName: "good.dns.io",
-				},
-				APIServerLB: createValidAPIServerInternalLB(),
+			name:    "duplicate application security group name",
+			asgs:    []ApplicationSecurityGroup{{Name: "my-asg"}, {Name: "my-asg"}},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "networkSpec.applicationSecurityGroups[1].name",
+				BadValue: "my-asg",
 			},
-			wantErr: false,
 		},
 		{
-			name: "testValidPrivateDNSZoneNameWithUnderscore",
-			network: NetworkSpec{
-				NetworkClassSpec: NetworkClassSpec{
-					PrivateDNSZoneName: "_good.__dns.io",
-				},
-				APIServerLB: createValidAPIServerInternalLB(),
+			name:    "unsupported application security group role",
+			asgs:    []ApplicationSecurityGroup{{Name: "my-asg", Role: SubnetBastion}},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueNotSupported",
+				Field:    "networkSpec.applicationSecurityGroups[0].role",
+				BadValue: SubnetBastion,
+				Detail:   "supported values: \"node\", \"control-plane\"",
 			},
-			wantErr: false,
 		},
 		{
-			name: "testBadAPIServerLBType",
-			network: NetworkSpec{
-				NetworkClassSpec: NetworkClassSpec{
-					PrivateDNSZoneName: "good.dns.io",
-				},
-				APIServerLB: LoadBalancerSpec{
-					Name: "my-lb",
-					LoadBalancerClassSpec: LoadBalancerClassSpec{
-						Type: Public,
-					},
-				},
+			name: "source mixes cidr and application security groups",
+			asgs: []ApplicationSecurityGroup{{Name: "node-asg"}},
+			subnets: subnetWithRule(SecurityRule{
+				Name:                            "allow-ssh",
+				Source:                          pointer.StringPtr("10.0.0.0/16"),
+				SourceApplicationSecurityGroups: []string{"node-asg"},
+			}),
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "networkSpec.subnets[0].securityGroup.securityRules[0].sourceApplicationSecurityGroups",
+				Detail: "source and sourceApplicationSecurityGroups are mutually exclusive",
 			},
+		},
+		{
+			name: "destination mixes cidr and application security groups",
+			asgs: []ApplicationSecurityGroup{{Name: "node-asg"}},
+			subnets: subnetWithRule(SecurityRule{
+				Name:                                 "allow-ssh",
+				Destination:                          pointer.StringPtr("*"),
+				DestinationApplicationSecurityGroups: []string{"node-asg"},
+			}),
+			wantErr: true,
 			expectedErr: field.Error{
-				Type:     "FieldValueInvalid",
-				Field:    "spec.networkSpec.privateDNSZoneName",
-				BadValue: "Public",
-				Detail:   "PrivateDNSZoneName is available only if APIServerLB.Type is Internal",
+				Type:   "FieldValueForbidden",
+				Field:  "networkSpec.subnets[0].securityGroup.securityRules[0].destinationApplicationSecurityGroups",
+				Detail: "destination and destinationApplicationSecurityGroups are mutually exclusive",
 			},
+		},
+		{
+			name: "rule references unknown application security group",
+			asgs: []ApplicationSecurityGroup{{Name: "node-asg"}},
+			subnets: subnetWithRule(SecurityRule{
+				Name:                            "allow-ssh",
+				SourceApplicationSecurityGroups: []string{"bastion-asg"},
+			}),
 			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueNotFound",
+				Field:    "networkSpec.subnets[0].securityGroup.securityRules[0].sourceApplicationSecurityGroups[0]",
+				BadValue: "bastion-asg",
+			},
 		},
 	}
 
@@ -949,7 +4018,7 @@ func TestPrivateDNSZoneName(t *testing.T) {
 		test := test
 		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
-			err := validatePrivateDNSZoneName(test.network, field.NewPath("spec", "networkSpec", "privateDNSZoneName"))
+			err := validateApplicationSecurityGroups(test.asgs, test.subnets, field.NewPath("networkSpec"))
 			if test.wantErr {
 				g.Expect(err).To(ContainElement(MatchError(test.expectedErr.Error())))
 			} else {
@@ -1209,6 +4278,245 @@ func TestValidateControlPlaneNodeOutboundLB(t *testing.T) {
 	}
 }
 
+func TestValidateLoadBalancerTier(t *testing.T) {
+	fldPath := field.NewPath("spec", "networkSpec", "apiServerLB")
+	tests := []struct {
+		name         string
+		lb           LoadBalancerSpec
+		old          *LoadBalancerSpec
+		expectedErrs field.ErrorList
+	}{
+		{
+			name: "regional tier",
+			lb:   LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Tier: LoadBalancerTierRegional}},
+		},
+		{
+			name: "global tier",
+			lb:   LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Tier: LoadBalancerTierGlobal}},
+			expectedErrs: field.ErrorList{
+				field.Forbidden(fldPath, "the Global tier is only supported by cross-region load balancers, use globalLB to front the API server load balancer with one"),
+			},
+		},
+		{
+			name: "tier set on update of a load balancer without one",
+			lb:   LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Tier: LoadBalancerTierRegional}},
+			old:  &LoadBalancerSpec{},
+		},
+		{
+			name: "tier modified",
+			lb:   LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Tier: LoadBalancerTierGlobal}},
+			old:  &LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Tier: LoadBalancerTierRegional}},
+			expectedErrs: field.ErrorList{
+				field.Forbidden(fldPath, "the Global tier is only supported by cross-region load balancers, use globalLB to front the API server load balancer with one"),
+				field.Forbidden(fldPath, "load balancer tier should not be modified after AzureCluster creation."),
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateLoadBalancerTier(test.lb, test.old, fldPath)
+			if len(test.expectedErrs) == 0 {
+				g.Expect(errs).To(BeEmpty())
+			} else {
+				g.Expect(errs).To(Equal(test.expectedErrs))
+			}
+		})
+	}
+}
+
+func TestValidateNamingConvention(t *testing.T) {
+	tests := []struct {
+		name         string
+		convention   *NamingConvention
+		expectedErrs field.ErrorList
+	}{
+		{
+			name:       "no naming convention",
+			convention: nil,
+		},
+		{
+			name:       "valid naming convention",
+			convention: &NamingConvention{Prefix: "corp", Suffix: "prod", Separator: "_"},
+		},
+		{
+			name:       "invalid prefix",
+			convention: &NamingConvention{Prefix: "-corp"},
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "namingConvention", "prefix"), "-corp",
+					fmt.Sprintf("prefix of naming convention doesn't match regex %s", namingConventionAffixRegex)),
+			},
+		},
+		{
+			name:       "invalid suffix",
+			convention: &NamingConvention{Suffix: "prod."},
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "namingConvention", "suffix"), "prod.",
+					fmt.Sprintf("suffix of naming convention doesn't match regex %s", namingConventionAffixRegex)),
+			},
+		},
+		{
+			name:       "generated vnet name too long",
+			convention: &NamingConvention{Prefix: strings.Repeat("a", 56)},
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "networkSpec", "vnet", "name"), strings.Repeat("a", 56)+"-foo-vnet",
+					"name should not be longer than 64 characters"),
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NamingConvention: test.convention,
+				},
+			}
+			cluster.setDefaults()
+			errs := cluster.validateNamingConvention(field.NewPath("spec"))
+			if len(test.expectedErrs) == 0 {
+				g.Expect(errs).To(BeEmpty())
+			} else {
+				g.Expect(errs).To(Equal(test.expectedErrs))
+			}
+		})
+	}
+}
+
+func TestValidateReconcileMode(t *testing.T) {
+	tests := []struct {
+		name             string
+		mode             ReconcileMode
+		jumpbox          *Jumpbox
+		workspace        *LogAnalyticsWorkspace
+		diagnosticsGroup *DiagnosticsResourceGroup
+		inheritTags      bool
+		expectedErrs     field.ErrorList
+	}{
+		{
+			name:        "full mode with jumpbox, Log Analytics workspace and inherited tags",
+			mode:        ReconcileModeFull,
+			jumpbox:     &Jumpbox{Name: "my-jumpbox"},
+			workspace:   &LogAnalyticsWorkspace{Name: "my-workspace"},
+			inheritTags: true,
+		},
+		{
+			name: "network only mode",
+			mode: ReconcileModeNetworkOnly,
+		},
+		{
+			name:      "network only mode with jumpbox and Log Analytics workspace",
+			mode:      ReconcileModeNetworkOnly,
+			jumpbox:   &Jumpbox{Name: "my-jumpbox"},
+			workspace: &LogAnalyticsWorkspace{Name: "my-workspace"},
+			expectedErrs: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "bastionSpec", "jumpbox"), "a jumpbox is not reconciled in NetworkOnly mode"),
+				field.Forbidden(field.NewPath("spec", "logAnalyticsWorkspace"), "a Log Analytics workspace is not reconciled in NetworkOnly mode"),
+			},
+		},
+		{
+			name:             "network only mode with diagnostics resource group",
+			mode:             ReconcileModeNetworkOnly,
+			diagnosticsGroup: &DiagnosticsResourceGroup{Name: "my-diagnostics-rg"},
+			expectedErrs: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "diagnosticsResourceGroup"), "a diagnostics resource group is not reconciled in NetworkOnly mode"),
+			},
+		},
+		{
+			name:        "network only mode with inherited tags",
+			mode:        ReconcileModeNetworkOnly,
+			inheritTags: true,
+			expectedErrs: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "inheritResourceGroupTags"), "the tags of the resource group are not read in NetworkOnly mode"),
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &AzureCluster{
+				Spec: AzureClusterSpec{
+					ReconcileMode:            test.mode,
+					BastionSpec:              BastionSpec{Jumpbox: test.jumpbox},
+					LogAnalyticsWorkspace:    test.workspace,
+					DiagnosticsResourceGroup: test.diagnosticsGroup,
+					InheritResourceGroupTags: test.inheritTags,
+				},
+			}
+			errs := cluster.validateReconcileMode(field.NewPath("spec"))
+			if len(test.expectedErrs) == 0 {
+				g.Expect(errs).To(BeEmpty())
+			} else {
+				g.Expect(errs).To(Equal(test.expectedErrs))
+			}
+		})
+	}
+}
+
+func TestValidateDiagnosticsResourceGroup(t *testing.T) {
+	tests := []struct {
+		name             string
+		diagnosticsGroup *DiagnosticsResourceGroup
+		expectedErrs     field.ErrorList
+	}{
+		{
+			name: "no diagnostics resource group",
+		},
+		{
+			name:             "valid diagnostics resource group",
+			diagnosticsGroup: &DiagnosticsResourceGroup{Name: "my-diagnostics-rg", Location: "westeurope"},
+		},
+		{
+			name:             "invalid name",
+			diagnosticsGroup: &DiagnosticsResourceGroup{Name: "my diagnostics rg"},
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "diagnosticsResourceGroup", "name"), "my diagnostics rg",
+					fmt.Sprintf("resourceGroup doesn't match regex %s", resourceGroupRegex)),
+			},
+		},
+		{
+			name:             "name too long",
+			diagnosticsGroup: &DiagnosticsResourceGroup{Name: strings.Repeat("a", 91)},
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "diagnosticsResourceGroup", "name"), strings.Repeat("a", 91),
+					"name should not be longer than 90 characters"),
+			},
+		},
+		{
+			name:             "resource group of the cluster",
+			diagnosticsGroup: &DiagnosticsResourceGroup{Name: "My-RG"},
+			expectedErrs: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "diagnosticsResourceGroup", "name"), "My-RG",
+					"must differ from the resource group of the cluster"),
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &AzureCluster{
+				Spec: AzureClusterSpec{
+					ResourceGroup:            "my-rg",
+					DiagnosticsResourceGroup: test.diagnosticsGroup,
+				},
+			}
+			errs := cluster.validateDiagnosticsResourceGroup(field.NewPath("spec", "diagnosticsResourceGroup"))
+			if len(test.expectedErrs) == 0 {
+				g.Expect(errs).To(BeEmpty())
+			} else {
+				g.Expect(errs).To(Equal(test.expectedErrs))
+			}
+		})
+	}
+}
+
 func TestValidateCloudProviderConfigOverrides(t *testing.T) {
 	g := NewWithT(t)
 
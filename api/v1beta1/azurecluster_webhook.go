@@ -61,6 +61,20 @@ func (c *AzureCluster) ValidateUpdate(oldRaw runtime.Object) error {
 		)
 	}
 
+	if old.Spec.ResourceGroupLocation != "" && c.Spec.ResourceGroupLocation != old.Spec.ResourceGroupLocation {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "ResourceGroupLocation"),
+				c.Spec.ResourceGroupLocation, "field is immutable"),
+		)
+	}
+
+	if !reflect.DeepEqual(c.Spec.DiagnosticsResourceGroup, old.Spec.DiagnosticsResourceGroup) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "diagnosticsResourceGroup"),
+				c.Spec.DiagnosticsResourceGroup, "field is immutable"),
+		)
+	}
+
 	if !reflect.DeepEqual(c.Spec.SubscriptionID, old.Spec.SubscriptionID) {
 		allErrs = append(allErrs,
 			field.Invalid(field.NewPath("spec", "SubscriptionID"),
@@ -113,6 +127,43 @@ func (c *AzureCluster) ValidateUpdate(oldRaw runtime.Object) error {
 		)
 	}
 
+	// Moving the security groups between the subnets and the network interfaces of running machines is not supported,
+	// their security rules can be updated.
+	if oldNICSecurityGroups, nicSecurityGroups := old.Spec.NetworkSpec.NetworkInterfaceSecurityGroups, c.Spec.NetworkSpec.NetworkInterfaceSecurityGroups; (oldNICSecurityGroups == nil) != (nicSecurityGroups == nil) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "networkSpec", "networkInterfaceSecurityGroups"),
+				nicSecurityGroups, "network interface security groups cannot be added to or removed from a cluster"),
+		)
+	} else if nicSecurityGroups != nil {
+		if nicSecurityGroups.Attachment != oldNICSecurityGroups.Attachment {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "networkSpec", "networkInterfaceSecurityGroups", "attachment"),
+					nicSecurityGroups.Attachment, "field is immutable"),
+			)
+		}
+		if nicSecurityGroups.ControlPlane.Name != oldNICSecurityGroups.ControlPlane.Name {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "networkSpec", "networkInterfaceSecurityGroups", "controlPlane", "name"),
+					nicSecurityGroups.ControlPlane.Name, "field is immutable"),
+			)
+		}
+		if nicSecurityGroups.Node.Name != oldNICSecurityGroups.Node.Name {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "networkSpec", "networkInterfaceSecurityGroups", "node", "name"),
+					nicSecurityGroups.Node.Name, "field is immutable"),
+			)
+		}
+	}
+
+	// The availability set of the control plane can be added to a cluster, e.g. to adopt the one its control plane
+	// machines are in, but Azure doesn't allow changing its domain counts.
+	if old.Spec.ControlPlaneAvailabilitySet != nil && !reflect.DeepEqual(old.Spec.ControlPlaneAvailabilitySet, c.Spec.ControlPlaneAvailabilitySet) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "controlPlaneAvailabilitySet"),
+				c.Spec.ControlPlaneAvailabilitySet, "field is immutable"),
+		)
+	}
+
 	// Allow enabling azure bastion but avoid disabling it.
 	if old.Spec.BastionSpec.AzureBastion != nil && !reflect.DeepEqual(old.Spec.BastionSpec.AzureBastion, c.Spec.BastionSpec.AzureBastion) {
 		allErrs = append(allErrs,
@@ -121,6 +172,21 @@ func (c *AzureCluster) ValidateUpdate(oldRaw runtime.Object) error {
 		)
 	}
 
+	// Allow enabling the jumpbox but avoid modifying or disabling it.
+	if old.Spec.BastionSpec.Jumpbox != nil && !reflect.DeepEqual(old.Spec.BastionSpec.Jumpbox, c.Spec.BastionSpec.Jumpbox) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "BastionSpec", "Jumpbox"),
+				c.Spec.BastionSpec.Jumpbox, "jumpbox cannot be modified or removed from a cluster"),
+		)
+	}
+
+	if c.Spec.ReconcileMode != old.Spec.ReconcileMode {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "reconcileMode"),
+				c.Spec.ReconcileMode, "field is immutable"),
+		)
+	}
+
 	if !reflect.DeepEqual(c.Spec.NetworkSpec.ControlPlaneOutboundLB, old.Spec.NetworkSpec.ControlPlaneOutboundLB) {
 		allErrs = append(allErrs,
 			field.Invalid(field.NewPath("spec", "networkSpec", "controlPlaneOutboundLB"),
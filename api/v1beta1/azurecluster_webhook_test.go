@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
@@ -224,6 +225,34 @@ func TestAzureCluster_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "azurecluster resource group location is immutable",
+			oldCluster: &AzureCluster{
+				Spec: AzureClusterSpec{
+					ResourceGroupLocation: "eastus",
+				},
+			},
+			cluster: &AzureCluster{
+				Spec: AzureClusterSpec{
+					ResourceGroupLocation: "westus",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "azurecluster diagnostics resource group is immutable",
+			oldCluster: &AzureCluster{
+				Spec: AzureClusterSpec{
+					DiagnosticsResourceGroup: &DiagnosticsResourceGroup{Name: "my-diagnostics-rg"},
+				},
+			},
+			cluster: &AzureCluster{
+				Spec: AzureClusterSpec{
+					DiagnosticsResourceGroup: &DiagnosticsResourceGroup{Name: "my-other-diagnostics-rg"},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "azurecluster subscription ID is immutable",
 			oldCluster: &AzureCluster{
@@ -306,6 +335,76 @@ func TestAzureCluster_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:       "reconcile mode is immutable",
+			oldCluster: createValidCluster(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.ReconcileMode = ReconcileModeNetworkOnly
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name:       "network interface security groups can't be added",
+			oldCluster: createValidCluster(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.NetworkInterfaceSecurityGroups = &NetworkInterfaceSecurityGroups{
+					Attachment:   SecurityGroupAttachmentNetworkInterface,
+					ControlPlane: SecurityGroup{Name: "cp-nic-nsg"},
+					Node:         SecurityGroup{Name: "node-nic-nsg"},
+				}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "network interface security group attachment is immutable",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.NetworkInterfaceSecurityGroups = &NetworkInterfaceSecurityGroups{
+					Attachment:   SecurityGroupAttachmentNetworkInterface,
+					ControlPlane: SecurityGroup{Name: "cp-nic-nsg"},
+					Node:         SecurityGroup{Name: "node-nic-nsg"},
+				}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.NetworkInterfaceSecurityGroups = &NetworkInterfaceSecurityGroups{
+					Attachment:   SecurityGroupAttachmentSubnetAndNetworkInterface,
+					ControlPlane: SecurityGroup{Name: "cp-nic-nsg"},
+					Node:         SecurityGroup{Name: "node-nic-nsg"},
+				}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name:       "control plane availability set can be added",
+			oldCluster: createValidCluster(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.ControlPlaneAvailabilitySet = &AvailabilitySet{Name: "my-as", UpdateDomainCount: pointer.Int32(5)}
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "control plane availability set is immutable",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.ControlPlaneAvailabilitySet = &AvailabilitySet{Name: "my-as", UpdateDomainCount: pointer.Int32(5)}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.ControlPlaneAvailabilitySet = &AvailabilitySet{Name: "my-as", UpdateDomainCount: pointer.Int32(10)}
+				return cluster
+			}(),
+			wantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
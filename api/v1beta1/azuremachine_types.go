@@ -183,6 +183,11 @@ type AzureMachineStatus struct {
 	// next reconciliation loop.
 	// +optional
 	LongRunningOperationStates Futures `json:"longRunningOperationStates,omitempty"`
+
+	// DrainStartedAt is the time the control plane machine was removed from the backend pools of the API server load
+	// balancer to drain its connections before being deleted.
+	// +optional
+	DrainStartedAt *metav1.Time `json:"drainStartedAt,omitempty"`
 }
 
 // +kubebuilder:object:root=true
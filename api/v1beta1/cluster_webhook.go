@@ -0,0 +1,71 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// DeleteProtectionAnnotation is the key of the Cluster object annotation which, when present, prevents the deletion of
+// the cluster until it is removed.
+const DeleteProtectionAnnotation = "azure.cluster.x-k8s.io/delete-protection"
+
+// The Cluster objects are owned by Cluster API, the webhook only denies their deletion. The failure policy is Ignore so
+// that the deletion of the clusters of every provider doesn't depend on CAPZ being available.
+// +kubebuilder:webhook:verbs=delete,path=/validate-cluster-x-k8s-io-v1beta1-cluster,mutating=false,failurePolicy=ignore,matchPolicy=Equivalent,groups=cluster.x-k8s.io,resources=clusters,versions=v1beta1,name=deleteprotection.cluster.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// NewClusterDeleteProtectionWebhook creates a Webhook denying the deletion of the Cluster objects with the
+// delete-protection annotation, before Cluster API deletes any of their machines.
+func NewClusterDeleteProtectionWebhook() *admission.Webhook {
+	return &admission.Webhook{Handler: &clusterDeleteProtectionHandler{}}
+}
+
+type clusterDeleteProtectionHandler struct {
+	decoder *admission.Decoder
+}
+
+var _ admission.DecoderInjector = &clusterDeleteProtectionHandler{}
+
+// InjectDecoder injects the decoder into a clusterDeleteProtectionHandler.
+func (h *clusterDeleteProtectionHandler) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
+
+// Handle denies the deletion of a Cluster with the delete-protection annotation.
+func (h *clusterDeleteProtectionHandler) Handle(_ context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Delete {
+		return admission.Allowed("")
+	}
+
+	// The Cluster is decoded as an unstructured object, only its annotations are needed.
+	cluster := &unstructured.Unstructured{}
+	if err := h.decoder.DecodeRaw(req.OldObject, cluster); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if _, ok := cluster.GetAnnotations()[DeleteProtectionAnnotation]; ok {
+		return admission.Denied(fmt.Sprintf("cluster %s is protected from deletion by the %s annotation, remove the annotation to delete it",
+			cluster.GetName(), DeleteProtectionAnnotation))
+	}
+	return admission.Allowed("")
+}
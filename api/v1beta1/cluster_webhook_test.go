@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestClusterDeleteProtection(t *testing.T) {
+	tests := []struct {
+		name        string
+		operation   admissionv1.Operation
+		cluster     string
+		wantAllowed bool
+	}{
+		{
+			name:        "delete a cluster without the annotation",
+			operation:   admissionv1.Delete,
+			cluster:     `{"apiVersion":"cluster.x-k8s.io/v1beta1","kind":"Cluster","metadata":{"name":"my-cluster"}}`,
+			wantAllowed: true,
+		},
+		{
+			name:        "delete a cluster with the annotation",
+			operation:   admissionv1.Delete,
+			cluster:     `{"apiVersion":"cluster.x-k8s.io/v1beta1","kind":"Cluster","metadata":{"name":"my-cluster","annotations":{"azure.cluster.x-k8s.io/delete-protection":""}}}`,
+			wantAllowed: false,
+		},
+		{
+			name:        "update a cluster with the annotation",
+			operation:   admissionv1.Update,
+			cluster:     `{"apiVersion":"cluster.x-k8s.io/v1beta1","kind":"Cluster","metadata":{"name":"my-cluster","annotations":{"azure.cluster.x-k8s.io/delete-protection":""}}}`,
+			wantAllowed: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			decoder, err := admission.NewDecoder(runtime.NewScheme())
+			g.Expect(err).NotTo(HaveOccurred())
+			handler := &clusterDeleteProtectionHandler{}
+			g.Expect(handler.InjectDecoder(decoder)).To(Succeed())
+
+			resp := handler.Handle(context.TODO(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: tc.operation,
+				OldObject: runtime.RawExtension{Raw: []byte(tc.cluster)},
+			}})
+			g.Expect(resp.Allowed).To(Equal(tc.wantAllowed))
+			if !tc.wantAllowed {
+				g.Expect(string(resp.Result.Reason)).To(Equal("cluster my-cluster is protected from deletion by the azure.cluster.x-k8s.io/delete-protection annotation, remove the annotation to delete it"))
+			}
+		})
+	}
+}
@@ -98,16 +98,42 @@ const (
 	RouteTablesReadyCondition clusterv1.ConditionType = "RouteTablesReady"
 	// PublicIPsReadyCondition means the public IPs exist and are ready to be used.
 	PublicIPsReadyCondition clusterv1.ConditionType = "PublicIPsReady"
+	// PublicIPPrefixesReadyCondition means the public IP prefixes exist and are ready to be used.
+	PublicIPPrefixesReadyCondition clusterv1.ConditionType = "PublicIPPrefixesReady"
 	// NATGatewaysReadyCondition means the NAT gateways exist and are ready to be used.
 	NATGatewaysReadyCondition clusterv1.ConditionType = "NATGatewaysReady"
 	// SubnetsReadyCondition means the subnets exist and are ready to be used.
 	SubnetsReadyCondition clusterv1.ConditionType = "SubnetsReady"
+	// SecondaryNetworkReadyCondition means the virtual network, subnets and security groups of the secondary region
+	// exist and are ready to be used.
+	SecondaryNetworkReadyCondition clusterv1.ConditionType = "SecondaryNetworkReady"
 	// LoadBalancersReadyCondition means the load balancers exist and are ready to be used.
 	LoadBalancersReadyCondition clusterv1.ConditionType = "LoadBalancersReady"
+	// InternalLoadBalancersReadyCondition means the internal load balancers fronting services of the cluster exist and
+	// are ready to be used.
+	InternalLoadBalancersReadyCondition clusterv1.ConditionType = "InternalLoadBalancersReady"
 	// PrivateDNSReadyCondition means the private DNS exists and is ready to be used.
 	PrivateDNSReadyCondition clusterv1.ConditionType = "PrivateDNSReady"
 	// BastionHostReadyCondition means the bastion host exists and is ready to be used.
 	BastionHostReadyCondition clusterv1.ConditionType = "BastionHostReady"
+	// JumpboxReadyCondition means the jumpbox virtual machine exists and is ready to be used.
+	JumpboxReadyCondition clusterv1.ConditionType = "JumpboxReady"
+	// DNSPrivateResolverReadyCondition means the DNS Private Resolver exists and its forwarding rulesets are linked to the
+	// virtual network.
+	DNSPrivateResolverReadyCondition clusterv1.ConditionType = "DNSPrivateResolverReady"
+	// TrafficManagerReadyCondition means the Traffic Manager profile exists and is ready to be used.
+	TrafficManagerReadyCondition clusterv1.ConditionType = "TrafficManagerReady"
+	// GlobalLoadBalancerReadyCondition means the cross-region load balancer exists and is ready to be used.
+	GlobalLoadBalancerReadyCondition clusterv1.ConditionType = "GlobalLoadBalancerReady"
+	// GlobalLoadBalancerBackendsHealthyCondition means Azure Resource Health doesn't report any of the regional load
+	// balancers behind the cross-region load balancer as unavailable.
+	GlobalLoadBalancerBackendsHealthyCondition clusterv1.ConditionType = "GlobalLoadBalancerBackendsHealthy"
+	// ApplicationSecurityGroupsReadyCondition means the application security groups exist and are ready to be used.
+	ApplicationSecurityGroupsReadyCondition clusterv1.ConditionType = "ApplicationSecurityGroupsReady"
+	// LogAnalyticsWorkspaceReadyCondition means the Log Analytics workspace exists and is ready to be used.
+	LogAnalyticsWorkspaceReadyCondition clusterv1.ConditionType = "LogAnalyticsWorkspaceReady"
+	// DiagnosticsResourceGroupReadyCondition means the diagnostics resource group exists and is ready to be used.
+	DiagnosticsResourceGroupReadyCondition clusterv1.ConditionType = "DiagnosticsResourceGroupReady"
 	// InboundNATRulesReadyCondition means the inbound NAT rules exist and are ready to be used.
 	InboundNATRulesReadyCondition clusterv1.ConditionType = "InboundNATRulesReady"
 	// AvailabilitySetReadyCondition means the availability set exists and is ready to be used.
@@ -118,6 +144,28 @@ const (
 	DisksReadyCondition clusterv1.ConditionType = "DisksReady"
 	// NetworkInterfaceReadyCondition means the network interfaces exist and are ready to be used.
 	NetworkInterfaceReadyCondition clusterv1.ConditionType = "NetworkInterfacesReady"
+	// OutboundConnectivityCondition means the node subnets are allowed to reach the destination of the outbound
+	// connectivity check.
+	OutboundConnectivityCondition clusterv1.ConditionType = "OutboundConnectivityVerified"
+	// ResourcesHealthyCondition means Azure Resource Health doesn't report any issue with the key resources of the
+	// cluster, e.g. its load balancers.
+	ResourcesHealthyCondition clusterv1.ConditionType = "ResourcesHealthy"
+	// PolicyAssignmentsReadyCondition means the Azure Policy assignments of the resource group of the cluster are
+	// in effect.
+	PolicyAssignmentsReadyCondition clusterv1.ConditionType = "PolicyAssignmentsReady"
+	// RoleAssignmentsReadyCondition means the Azure role assignments of the resource group of the cluster are in
+	// effect.
+	RoleAssignmentsReadyCondition clusterv1.ConditionType = "RoleAssignmentsReady"
+	// BudgetReadyCondition means the Cost Management budget of the resource group of the cluster is in effect.
+	BudgetReadyCondition clusterv1.ConditionType = "BudgetReady"
+	// SubnetIPsAvailableCondition means the subnets of the cluster have more available IP addresses than their free IPs
+	// threshold.
+	SubnetIPsAvailableCondition clusterv1.ConditionType = "SubnetIPsAvailable"
+	// PrivateEndpointsReadyCondition means the private endpoints of the cluster exist and are ready to be used.
+	PrivateEndpointsReadyCondition clusterv1.ConditionType = "PrivateEndpointsReady"
+	// ControlPlaneEtcdDisksCompatibleCondition means the etcd data disks of the control plane machines are supported in
+	// every availability zone of the control plane.
+	ControlPlaneEtcdDisksCompatibleCondition clusterv1.ConditionType = "ControlPlaneEtcdDisksCompatible"
 
 	// CreatingReason means the resource is being created.
 	CreatingReason = "Creating"
@@ -131,4 +179,40 @@ const (
 	DeletionFailedReason = "DeletionFailed"
 	// UpdatingReason means the resource is being updated.
 	UpdatingReason = "Updating"
+	// OutboundConnectivityDeniedReason means a network security rule denies the traffic to the destination of the
+	// outbound connectivity check.
+	OutboundConnectivityDeniedReason = "OutboundConnectivityDenied"
+	// OutboundConnectivityCheckFailedReason means the outbound connectivity check could not be run.
+	OutboundConnectivityCheckFailedReason = "OutboundConnectivityCheckFailed"
+	// WaitingForNodesReason means there is no network interface in the node subnets to run the outbound connectivity
+	// check from yet.
+	WaitingForNodesReason = "WaitingForNodes"
+	// UnhealthyResourcesReason means Azure Resource Health reports some resources of the cluster as unavailable or
+	// degraded.
+	UnhealthyResourcesReason = "UnhealthyResources"
+	// UnhealthyBackendsReason means some regional load balancers behind the cross-region load balancer are
+	// unavailable, the traffic of the global frontend goes to the other regions.
+	UnhealthyBackendsReason = "UnhealthyBackends"
+	// AllBackendsUnhealthyReason means all the regional load balancers behind the cross-region load balancer are
+	// unavailable, the global frontend has no region to send traffic to.
+	AllBackendsUnhealthyReason = "AllBackendsUnhealthy"
+	// ResourceHealthCheckFailedReason means the health of the resources could not be retrieved from Azure Resource Health.
+	ResourceHealthCheckFailedReason = "ResourceHealthCheckFailed"
+	// PolicyAssignmentForbiddenReason means the identity of the cluster isn't allowed to manage the policy
+	// assignments of the resource group.
+	PolicyAssignmentForbiddenReason = "PolicyAssignmentForbidden"
+	// RoleAssignmentForbiddenReason means the identity of the cluster isn't allowed to manage the role assignments of
+	// the resource group.
+	RoleAssignmentForbiddenReason = "RoleAssignmentForbidden"
+	// BudgetForbiddenReason means the identity of the cluster isn't allowed to manage the budgets of the resource
+	// group.
+	BudgetForbiddenReason = "BudgetForbidden"
+	// SubnetIPsLowReason means a subnet has fewer available IP addresses than its free IPs threshold.
+	SubnetIPsLowReason = "SubnetIPsLow"
+	// VnetPeeringPartialReason means only one side of a peering with a virtual network of another tenant could be
+	// created, as the identity of the other side isn't allowed to create it.
+	VnetPeeringPartialReason = "VnetPeeringPartial"
+	// EtcdDisksNotSupportedReason means the VM size of the control plane machines doesn't support their etcd data disks
+	// in some availability zones of the control plane.
+	EtcdDisksNotSupportedReason = "EtcdDisksNotSupported"
 )
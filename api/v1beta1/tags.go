@@ -35,6 +35,12 @@ func (t Tags) HasMatchingSpecVersionHash(hash string) bool {
 	return ok && value == hash
 }
 
+// HasProviderVersion returns true if the resource has been tagged with the given provider version.
+// An empty version, from a build without version information, always matches so that it never triggers an update.
+func (t Tags) HasProviderVersion(version string) bool {
+	return version == "" || t[ProviderVersionTagKey()] == version
+}
+
 // HasOwned returns true if the tags contains a tag that marks the resource as owned by the cluster from the perspective of this management tooling.
 func (t Tags) HasOwned(cluster string) bool {
 	value, ok := t[ClusterTagKey(cluster)]
@@ -125,6 +131,12 @@ const (
 	// ControlPlaneOutboundRole describes the value for the control plane outbound LB role.
 	ControlPlaneOutboundRole = "controlPlaneOutbound"
 
+	// InternalServiceRole describes the value for the role of the internal LBs fronting services of the cluster.
+	InternalServiceRole = "internalService"
+
+	// ControlPlaneEgressRole describes the value for the role of the public IPs of the control plane NAT gateway.
+	ControlPlaneEgressRole = "controlPlaneEgress"
+
 	// BastionRole describes the value for the bastion role.
 	BastionRole = Bastion
 
@@ -151,6 +163,27 @@ func SpecVersionHashTagKey() string {
 	return fmt.Sprintf("%s%s", NameAzureProviderPrefix, "spec-version-hash")
 }
 
+// ProviderVersionTagKey is the key for the version of the provider that last reconciled the resource.
+func ProviderVersionTagKey() string {
+	return fmt.Sprintf("%s%s", NameAzureProviderPrefix, "provider-version")
+}
+
+// ClusterUIDTagKey is the key for the UID of the cluster that created the resource, which tells a resource of a
+// deleted cluster apart from the resource of a cluster recreated with the same name.
+func ClusterUIDTagKey() string {
+	return fmt.Sprintf("%s%s", NameAzureProviderPrefix, "cluster-uid")
+}
+
+// PodCIDRsTagKey is the key for the pod CIDRs of the cluster, recorded on its virtual network.
+func PodCIDRsTagKey() string {
+	return fmt.Sprintf("%s%s", NameAzureProviderPrefix, "pod-cidrs")
+}
+
+// ServiceCIDRsTagKey is the key for the service CIDRs of the cluster, recorded on its virtual network.
+func ServiceCIDRsTagKey() string {
+	return fmt.Sprintf("%s%s", NameAzureProviderPrefix, "service-cidrs")
+}
+
 // ClusterTagKey generates the key for resources associated with a cluster.
 func ClusterTagKey(name string) string {
 	return fmt.Sprintf("%s%s", NameAzureProviderOwned, name)
@@ -180,6 +213,15 @@ type BuildParams struct {
 	// +optional
 	Role *string
 
+	// ProviderVersion is the version of the provider reconciling the resource, it's applied as the
+	// provider version tag when set.
+	// +optional
+	ProviderVersion string
+
+	// ClusterUID is the UID of the cluster associated with the resource.
+	// +optional
+	ClusterUID string
+
 	// Any additional tags to be added to the resource.
 	// +optional
 	Additional Tags
@@ -201,5 +243,13 @@ func Build(params BuildParams) Tags {
 		tags["Name"] = *params.Name
 	}
 
+	if params.ProviderVersion != "" {
+		tags[ProviderVersionTagKey()] = params.ProviderVersion
+	}
+
+	if params.ClusterUID != "" {
+		tags[ClusterUIDTagKey()] = params.ClusterUID
+	}
+
 	return tags
 }
@@ -17,8 +17,12 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
+
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -28,6 +32,10 @@ const (
 	Node string = "node"
 	// Bastion subnet label.
 	Bastion string = "bastion"
+	// Ingress subnet label.
+	Ingress string = "ingress"
+	// Pod subnet label.
+	Pod string = "pod"
 )
 
 // Futures is a slice of Future.
@@ -86,9 +94,266 @@ type NetworkSpec struct {
 	// +optional
 	ControlPlaneOutboundLB *LoadBalancerSpec `json:"controlPlaneOutboundLB,omitempty"`
 
+	// TrafficManager is the configuration for an Azure Traffic Manager profile that fronts the regional API server
+	// load balancers. Only supported with a public API server load balancer.
+	// +optional
+	TrafficManager *TrafficManagerSpec `json:"trafficManager,omitempty"`
+
+	// GlobalLB is the configuration for an Azure cross-region load balancer that fronts the regional API server load
+	// balancers with a global anycast IP. Only supported with a public Standard API server load balancer, and
+	// mutually exclusive with TrafficManager.
+	// +optional
+	GlobalLB *GlobalLoadBalancerSpec `json:"globalLB,omitempty"`
+
+	// DNSPrivateResolver references an existing Azure DNS Private Resolver providing hybrid DNS to the cluster. The
+	// resolver is shared and never created nor deleted by capz, only the links of its forwarding rulesets to the
+	// virtual network of the cluster are.
+	// +optional
+	DNSPrivateResolver *DNSPrivateResolverSpec `json:"dnsPrivateResolver,omitempty"`
+
+	// ApplicationSecurityGroups is the list of application security groups of the cluster. Security rules can reference
+	// them by name instead of using CIDRs, and the network interfaces of the machines matching their role join them.
+	// +optional
+	ApplicationSecurityGroups []ApplicationSecurityGroup `json:"applicationSecurityGroups,omitempty"`
+
+	// NetworkInterfaceSecurityGroups attaches a security group per role to the network interfaces of the machines,
+	// instead of or in addition to the security groups of the subnets. The security groups are only attached to the
+	// subnets when nil.
+	// +optional
+	NetworkInterfaceSecurityGroups *NetworkInterfaceSecurityGroups `json:"networkInterfaceSecurityGroups,omitempty"`
+
+	// RequireSecurityRuleDescriptions rejects the security rules of the security groups of the spec that have no
+	// description, so that every rule of the security groups of the cluster can be audited. The rules generated by
+	// CAPZ always have one.
+	// +optional
+	RequireSecurityRuleDescriptions bool `json:"requireSecurityRuleDescriptions,omitempty"`
+
+	// IntraClusterTraffic adds security rules allowing all the traffic between the address ranges of the cluster to the
+	// security groups of the control plane and node subnets, and of the network interfaces of the machines, so that
+	// rules denying traffic of lower priority, e.g. a DenyByDefault egress policy, don't break the communication
+	// between the nodes and with the control plane, nor pod networking. The rules are removed when unset.
+	// +optional
+	IntraClusterTraffic *IntraClusterTraffic `json:"intraClusterTraffic,omitempty"`
+
+	// OutboundConnectivityCheck verifies with Azure Network Watcher that the node subnets are allowed to reach a
+	// destination, e.g. an Azure management endpoint, once the network of the cluster is reconciled. The result is
+	// reported in the OutboundConnectivityVerified condition. Requires the OutboundConnectivityCheck feature flag.
+	// +optional
+	OutboundConnectivityCheck *OutboundConnectivityCheck `json:"outboundConnectivityCheck,omitempty"`
+
+	// PrivateEndpoints are the private endpoints, in the subnets of the cluster, of the Azure resources the cluster
+	// depends on, e.g. the storage account of the boot diagnostics or a Key Vault, so that they can be reached without
+	// going through their public endpoint. The private endpoints are deleted with the cluster.
+	// +optional
+	PrivateEndpoints []PrivateEndpointSpec `json:"privateEndpoints,omitempty"`
+
+	// Ingress is the configuration for a dedicated subnet exposing the services of the cluster, e.g. through an ingress
+	// controller or an API Management gateway, independently of the API server load balancer.
+	// +optional
+	Ingress *IngressSpec `json:"ingress,omitempty"`
+
+	// PodSubnet is a dedicated subnet from which Azure CNI assigns the IP addresses of the pods, so that they don't
+	// exhaust the node subnets. It is only relevant when the cluster runs Azure CNI.
+	// +optional
+	PodSubnet *PodSubnetSpec `json:"podSubnet,omitempty"`
+
+	// InternalLoadBalancers are additional internal Standard load balancers fronting services of the cluster other
+	// than the API server, e.g. an internal ingress controller, so that their private IPs are provisioned with the
+	// cluster. The load balancers removed from the list are deleted.
+	// +optional
+	InternalLoadBalancers []InternalLoadBalancerSpec `json:"internalLoadBalancers,omitempty"`
+
 	NetworkClassSpec `json:",inline"`
 }
 
+// IntraClusterTraffic defines the address ranges between which the security groups of the cluster allow all the
+// traffic.
+type IntraClusterTraffic struct {
+	// CIDRs are the address ranges of the cluster, e.g. of an overlay pod network. Defaults to the address space of the
+	// virtual network and the pod CIDR blocks of the cluster network.
+	// +optional
+	CIDRs []string `json:"cidrs,omitempty"`
+}
+
+// IngressSpec defines a subnet, with a security group allowing HTTP and HTTPS traffic in, for the frontend of an
+// ingress controller or for an API Management gateway.
+type IngressSpec struct {
+	// Subnet is the configuration of the ingress subnet. Its security rules are added to the rules allowing the
+	// ingress ports.
+	// +optional
+	Subnet SubnetSpec `json:"subnet,omitempty"`
+	// Ports are the TCP ports allowed into the ingress subnet. Defaults to 80 and 443.
+	// +optional
+	Ports []int32 `json:"ports,omitempty"`
+	// AllowedSourceCIDRs is the list of address ranges allowed to reach the ingress ports. Any source is allowed
+	// when empty.
+	// +optional
+	AllowedSourceCIDRs []string `json:"allowedSourceCIDRs,omitempty"`
+	// PublicIP is an optional static public IP for the frontend of the ingress load balancer, created by the cloud
+	// provider for the service of the ingress controller, e.g. with the
+	// service.beta.kubernetes.io/azure-pip-name annotation.
+	// +optional
+	PublicIP *PublicIPSpec `json:"publicIP,omitempty"`
+}
+
+// InternalLoadBalancerSpec defines an internal Standard load balancer fronting a service of the cluster with a static
+// private IP. The nodes of its subnet are added to its backend pool when they are created.
+type InternalLoadBalancerSpec struct {
+	// Name is the name of the load balancer.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// SubnetName is the name of the node subnet of the frontend and of the nodes of the backend pool. Defaults to
+	// the first node subnet of the cluster.
+	// +optional
+	SubnetName string `json:"subnetName,omitempty"`
+	// PrivateIP is the static private IP of the frontend, in the address range of the subnet.
+	PrivateIP string `json:"privateIP"`
+	// Rules are the load balancing rules forwarding the frontend ports to the nodes.
+	// +kubebuilder:validation:MinItems=1
+	Rules []InternalLoadBalancerRule `json:"rules"`
+	// Probe is the health probe of the nodes, shared by all the rules. It is ignored when Probes is set.
+	// +optional
+	Probe InternalLoadBalancerProbe `json:"probe,omitempty"`
+	// Probes are named health probes of the nodes, each rule being bound to one of them by name, e.g. a Tcp probe
+	// checking the liveness of a port for a rule and an Http probe checking the readiness of a service for another.
+	// +optional
+	Probes []InternalLoadBalancerProbe `json:"probes,omitempty"`
+}
+
+// InternalLoadBalancerRule defines a load balancing rule of an internal load balancer fronting a service.
+type InternalLoadBalancerRule struct {
+	// Name is the name of the rule.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Protocol is the transport protocol of the rule. Defaults to Tcp.
+	// +kubebuilder:validation:Enum=Tcp;Udp
+	// +optional
+	Protocol LoadBalancerRuleProtocol `json:"protocol,omitempty"`
+	// FrontendPort is the port of the frontend.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	FrontendPort int32 `json:"frontendPort"`
+	// BackendPort is the port of the service on the nodes, e.g. the node port of a Kubernetes service. Defaults to
+	// the frontend port.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	BackendPort int32 `json:"backendPort,omitempty"`
+	// ProbeName is the name of the probe of Probes the rule is bound to. Defaults to the first probe of Probes.
+	// +optional
+	ProbeName string `json:"probeName,omitempty"`
+}
+
+// InternalLoadBalancerProbe defines the health probe of the nodes of an internal load balancer fronting a service.
+type InternalLoadBalancerProbe struct {
+	// Name is the name of the probe, referenced by the rules. It is required for the probes of Probes.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Protocol is the protocol of the health probe. Defaults to Tcp.
+	// +kubebuilder:validation:Enum=Tcp;Http;Https
+	// +optional
+	Protocol ProbeProtocol `json:"protocol,omitempty"`
+	// Port is the port probed on the nodes. Defaults to the backend port of the first rule.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
+	// RequestPath is the path requested by Http and Https health probes. Defaults to /.
+	// +optional
+	RequestPath string `json:"requestPath,omitempty"`
+	// IntervalInSeconds is the interval between two probes of a node. Defaults to 15.
+	// +kubebuilder:validation:Minimum=5
+	// +optional
+	IntervalInSeconds int32 `json:"intervalInSeconds,omitempty"`
+	// NumberOfProbes is the number of consecutive failed probes after which a node is taken out of rotation.
+	// Defaults to 4.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	NumberOfProbes int32 `json:"numberOfProbes,omitempty"`
+}
+
+// PodSubnetSpec defines a subnet, delegated to Azure CNI, for the IP addresses of the pods of the cluster.
+type PodSubnetSpec struct {
+	// Name is the name of the pod subnet. Defaults to "<cluster name>-pod-subnet".
+	// +optional
+	Name string `json:"name,omitempty"`
+	// CIDRBlocks defines the address space of the pod subnet, specified as one or more address prefixes in CIDR
+	// notation. It must hold MaxPodsPerNode addresses for each of the MaxNodes nodes. Defaults to 10.128.0.0/16.
+	// +optional
+	CIDRBlocks []string `json:"cidrBlocks,omitempty"`
+	// MaxPodsPerNode is the maximum number of pods of a node, i.e. the --max-pods flag of the kubelet, for which Azure
+	// CNI allocates addresses. Defaults to 30, the default of Azure CNI.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=250
+	// +optional
+	MaxPodsPerNode int32 `json:"maxPodsPerNode,omitempty"`
+	// MaxNodes is the number of nodes the pod subnet is sized for, including the machines added during rolling
+	// updates. Required, as it has no default: the pod subnet can't be sized without it.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	MaxNodes int32 `json:"maxNodes"`
+	// ServiceDelegation is the service the pod subnet is delegated to, which allows it to assign the addresses of
+	// the subnet to the pods. Defaults to Microsoft.ContainerService/managedClusters.
+	// +optional
+	ServiceDelegation string `json:"serviceDelegation,omitempty"`
+}
+
+// PrivateEndpointSpec defines a private endpoint of an Azure resource in a subnet of the cluster.
+type PrivateEndpointSpec struct {
+	// Name is the name of the private endpoint.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// ResourceID is the resource ID of the Azure resource the private endpoint connects to, e.g. a storage account
+	// or a Key Vault.
+	ResourceID string `json:"resourceID"`
+	// GroupID is the sub-resource of the resource the private endpoint connects to, e.g. blob for the blob service of
+	// a storage account. Defaults to blob for a storage account and to vault for a Key Vault.
+	// +optional
+	GroupID string `json:"groupID,omitempty"`
+	// SubnetName is the name of the subnet of the cluster the private endpoint is created in. Its private endpoint
+	// network policies must be disabled. Defaults to the node subnet when the cluster has only one.
+	// +optional
+	SubnetName string `json:"subnetName,omitempty"`
+	// PrivateDNSZoneID is the resource ID of the private DNS zone in which the name of the resource resolves to the
+	// private IP of the private endpoint, e.g. a privatelink.blob.core.windows.net zone linked to the virtual network.
+	// The DNS record is managed by Azure along with the private endpoint.
+	// +optional
+	PrivateDNSZoneID string `json:"privateDNSZoneID,omitempty"`
+}
+
+// DNSPrivateResolverSpec references an existing Azure DNS Private Resolver and its DNS forwarding rulesets.
+type DNSPrivateResolverSpec struct {
+	// ID is the resource ID of the DNS Private Resolver. It can be in another resource group or subscription than the
+	// cluster, as long as the identity of the cluster can read it.
+	ID string `json:"id"`
+	// ForwardingRulesetIDs are the resource IDs of the DNS forwarding rulesets of the resolver to link to the virtual
+	// network of the cluster, so that its DNS queries matching the rules are forwarded, e.g. to on-premises DNS servers.
+	// The rulesets must be in the location of the virtual network. The links are deleted with the cluster.
+	// +optional
+	ForwardingRulesetIDs []string `json:"forwardingRulesetIDs,omitempty"`
+}
+
+// OutboundConnectivityCheck defines a destination the nodes of a cluster must be allowed to reach.
+type OutboundConnectivityCheck struct {
+	// DestinationIP is the IPv4 address of the destination.
+	DestinationIP string `json:"destinationIP"`
+	// Port is the port of the destination.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+	// Protocol is the protocol of the connection, either TCP or UDP. Defaults to TCP.
+	// +kubebuilder:validation:Enum=TCP;UDP
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+	// NetworkWatcherName is the name of the Network Watcher running the check. It must be in the location of the
+	// cluster. Defaults to NetworkWatcher_<location>, the Network Watcher Azure creates in each region.
+	// +optional
+	NetworkWatcherName string `json:"networkWatcherName,omitempty"`
+	// NetworkWatcherResourceGroup is the resource group of the Network Watcher. Defaults to NetworkWatcherRG.
+	// +optional
+	NetworkWatcherResourceGroup string `json:"networkWatcherResourceGroup,omitempty"`
+}
+
 // VnetSpec configures an Azure virtual network.
 type VnetSpec struct {
 	// ResourceGroup is the name of the resource group of the existing virtual network
@@ -119,6 +384,26 @@ type VnetPeeringSpec struct {
 
 	// RemoteVnetName defines name of the remote virtual network.
 	RemoteVnetName string `json:"remoteVnetName"`
+
+	// TenantID is the Azure AD tenant of the remote virtual network, when it belongs to another tenant than the
+	// cluster. SubscriptionID and IdentityRef are then required.
+	// +optional
+	TenantID string `json:"tenantID,omitempty"`
+
+	// SubscriptionID is the subscription of the remote virtual network in the tenant given by TenantID.
+	// +optional
+	SubscriptionID string `json:"subscriptionID,omitempty"`
+
+	// IdentityRef is a reference to an AzureClusterIdentity of the tenant given by TenantID, used to create the
+	// peering from the remote virtual network. The peering to the remote virtual network is created with the identity
+	// of the cluster, which needs guest access to the remote tenant.
+	// +optional
+	IdentityRef *corev1.ObjectReference `json:"identityRef,omitempty"`
+}
+
+// IsCrossTenant returns true if the remote virtual network belongs to another Azure AD tenant than the cluster.
+func (p VnetPeeringSpec) IsCrossTenant() bool {
+	return p.TenantID != ""
 }
 
 // VnetPeerings is a slice of VnetPeering.
@@ -143,6 +428,47 @@ type SecurityGroup struct {
 	SecurityGroupClass `json:",inline"`
 }
 
+// ApplicationSecurityGroup defines an Azure application security group.
+type ApplicationSecurityGroup struct {
+	// Name is the name of the application security group.
+	Name string `json:"name"`
+	// Role is the role of the machines whose network interfaces should join the application security group.
+	// If empty, no network interface is added to the group automatically.
+	// +kubebuilder:validation:Enum=node;control-plane
+	// +optional
+	Role SubnetRole `json:"role,omitempty"`
+}
+
+// SecurityGroupAttachment defines the resources the security groups of the machines are attached to.
+type SecurityGroupAttachment string
+
+const (
+	// SecurityGroupAttachmentNetworkInterface attaches the security groups to the network interfaces of the
+	// machines only. The control plane and node subnets have no security group.
+	SecurityGroupAttachmentNetworkInterface SecurityGroupAttachment = "NetworkInterface"
+	// SecurityGroupAttachmentSubnetAndNetworkInterface attaches security groups to both the subnets and the network
+	// interfaces of the machines. Traffic must then be allowed by both.
+	SecurityGroupAttachmentSubnetAndNetworkInterface SecurityGroupAttachment = "SubnetAndNetworkInterface"
+)
+
+// NetworkInterfaceSecurityGroups defines the security groups attached to the network interfaces of the machines,
+// per role.
+type NetworkInterfaceSecurityGroups struct {
+	// Attachment defines whether the security groups of the control plane and node subnets are kept along with the
+	// security groups of the network interfaces. Defaults to NetworkInterface.
+	// +kubebuilder:validation:Enum=NetworkInterface;SubnetAndNetworkInterface
+	// +optional
+	Attachment SecurityGroupAttachment `json:"attachment,omitempty"`
+	// ControlPlane is the security group of the network interfaces of the control plane machines. Without security
+	// rules nor allowInboundFrom, it gets the security rules of the control plane subnet.
+	// +optional
+	ControlPlane SecurityGroup `json:"controlPlane,omitempty"`
+	// Node is the security group of the network interfaces of the nodes. Without security rules nor allowInboundFrom,
+	// it gets the security rules of the node subnets.
+	// +optional
+	Node SecurityGroup `json:"node,omitempty"`
+}
+
 // RouteTable defines an Azure route table.
 type RouteTable struct {
 	// ID is the Azure resource ID of the route table.
@@ -162,6 +488,23 @@ type NatGateway struct {
 	Name string `json:"name"`
 	// +optional
 	NatGatewayIP PublicIPSpec `json:"ip,omitempty"`
+	// NatGatewayIPCount is the number of public IPs of the NAT gateway, each of them providing 64,512 SNAT ports. The
+	// first public IP is named after NatGatewayIP, the others get an index suffix. It can be set to 0 when
+	// NatGatewayIPPrefix is set, to only use the addresses of the prefix. Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=16
+	// +optional
+	NatGatewayIPCount *int32 `json:"ipCount,omitempty"`
+	// IdleTimeoutInMinutes is the idle timeout of the outbound connections of the NAT gateway. Defaults to 4 minutes.
+	// +kubebuilder:validation:Minimum=4
+	// +kubebuilder:validation:Maximum=120
+	// +optional
+	IdleTimeoutInMinutes *int32 `json:"idleTimeoutInMinutes,omitempty"`
+	// NatGatewayIPPrefix is a public IP prefix used by the NAT gateway for outbound traffic, in addition to its
+	// public IP. A new prefix is created unless a prefix with this name already exists in the resource group, in
+	// which case that prefix is attached as is.
+	// +optional
+	NatGatewayIPPrefix *PublicIPPrefixSpec `json:"ipPrefix,omitempty"`
 }
 
 // SecurityGroupProtocol defines the protocol type for a security group rule.
@@ -189,6 +532,17 @@ const (
 	SecurityRuleDirectionOutbound = SecurityRuleDirection("Outbound")
 )
 
+// SecurityRuleAction defines whether a security rule allows or denies the traffic it matches.
+type SecurityRuleAction string
+
+const (
+	// SecurityRuleActionAllow allows the traffic matching the security rule.
+	SecurityRuleActionAllow = SecurityRuleAction("Allow")
+
+	// SecurityRuleActionDeny denies the traffic matching the security rule.
+	SecurityRuleActionDeny = SecurityRuleAction("Deny")
+)
+
 // SecurityRule defines an Azure security rule for security groups.
 type SecurityRule struct {
 	// Name is a unique name within the network security group.
@@ -216,11 +570,97 @@ type SecurityRule struct {
 	// Destination is the destination address prefix. CIDR or destination IP range. Asterix '*' can also be used to match all source IPs. Default tags such as 'VirtualNetwork', 'AzureLoadBalancer' and 'Internet' can also be used.
 	// +optional
 	Destination *string `json:"destination,omitempty"`
+	// SourceApplicationSecurityGroups is the list of names of the application security groups the rule applies to as source.
+	// It cannot be combined with Source.
+	// +optional
+	SourceApplicationSecurityGroups []string `json:"sourceApplicationSecurityGroups,omitempty"`
+	// DestinationApplicationSecurityGroups is the list of names of the application security groups the rule applies to as destination.
+	// It cannot be combined with Destination.
+	// +optional
+	DestinationApplicationSecurityGroups []string `json:"destinationApplicationSecurityGroups,omitempty"`
+	// Action is whether the rule allows or denies the traffic it matches. Defaults to Allow.
+	// +kubebuilder:validation:Enum=Allow;Deny
+	// +optional
+	Action SecurityRuleAction `json:"action,omitempty"`
 }
 
 // SecurityRules is a slice of Azure security rules for security groups.
 type SecurityRules []SecurityRule
 
+const (
+	// IntentSecurityRuleNamePrefix is the prefix of the names of the security rules generated from the inbound
+	// traffic a security group allows.
+	IntentSecurityRuleNamePrefix = "allow_inbound_from_"
+	// IntentSecurityRulePriority is the priority of the first security rule generated from the inbound traffic a
+	// security group allows, after the rules CAPZ requires.
+	IntentSecurityRulePriority = 3000
+	// EgressSecurityRuleNamePrefix is the prefix of the names of the security rules generated from the egress policy
+	// of a security group.
+	EgressSecurityRuleNamePrefix = "egress_"
+	// EgressSecurityRulePriority is the priority of the first outbound security rule generated from the DenyByDefault
+	// egress policy of a security group.
+	EgressSecurityRulePriority = 3000
+	// EgressDenySecurityRulePriority is the priority of the outbound security rule denying the traffic the
+	// DenyByDefault egress policy of a security group doesn't allow.
+	EgressDenySecurityRulePriority = 4096
+	// IntraClusterSecurityRuleNamePrefix is the prefix of the names of the security rules allowing the traffic between
+	// the address ranges of the cluster.
+	IntraClusterSecurityRuleNamePrefix = "allow_intra_cluster_"
+	// IntraClusterSecurityRulePriority is the priority of the first security rule allowing the traffic between the
+	// address ranges of the cluster, after the rules CAPZ requires and before the rules generated from the inbound
+	// traffic intents and the egress policy.
+	IntraClusterSecurityRulePriority = 2300
+)
+
+// EgressPolicy defines the outbound traffic a security group allows.
+type EgressPolicy string
+
+const (
+	// EgressPolicyAllowAll leaves the outbound traffic to the security rules and to the default rules of Azure, which
+	// allow it.
+	EgressPolicyAllowAll = EgressPolicy("AllowAll")
+	// EgressPolicyDenyByDefault denies the outbound traffic other than the traffic the cluster requires and the
+	// traffic the security group allows explicitly.
+	EgressPolicyDenyByDefault = EgressPolicy("DenyByDefault")
+)
+
+// InboundTrafficIntent defines inbound traffic a security group allows.
+type InboundTrafficIntent struct {
+	// Source is the CIDR, IP address or service tag, e.g. "AzureCloud", the traffic comes from. "*" allows the
+	// traffic from any source.
+	Source string `json:"source"`
+	// Ports are the destination ports or port ranges of the traffic, e.g. "443" or "30000-32767". "*" allows the
+	// traffic to any port. A security rule is generated for each of them.
+	// +kubebuilder:validation:MinItems=1
+	Ports []string `json:"ports"`
+	// Protocol is the protocol of the traffic. Defaults to Tcp.
+	// +kubebuilder:validation:Enum=Tcp;Udp;Icmp;*
+	// +optional
+	Protocol SecurityGroupProtocol `json:"protocol,omitempty"`
+	// Description is the description of the generated security rules. Restricted to 140 chars.
+	// +kubebuilder:validation:MaxLength=140
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// OutboundTrafficIntent defines outbound traffic a security group allows.
+type OutboundTrafficIntent struct {
+	// Destination is the CIDR, IP address or service tag, e.g. "Storage.EastUS", the traffic goes to.
+	Destination string `json:"destination"`
+	// Ports are the destination ports or port ranges of the traffic, e.g. "443" or "8000-8100". "*" allows the
+	// traffic to any port. A security rule is generated for each of them.
+	// +kubebuilder:validation:MinItems=1
+	Ports []string `json:"ports"`
+	// Protocol is the protocol of the traffic. Defaults to Tcp.
+	// +kubebuilder:validation:Enum=Tcp;Udp;Icmp;*
+	// +optional
+	Protocol SecurityGroupProtocol `json:"protocol,omitempty"`
+	// Description is the description of the generated security rules. Restricted to 140 chars.
+	// +kubebuilder:validation:MaxLength=140
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
 // LoadBalancerSpec defines an Azure load balancer.
 type LoadBalancerSpec struct {
 	// ID is the Azure resource ID of the load balancer.
@@ -229,10 +669,26 @@ type LoadBalancerSpec struct {
 	ID string `json:"id,omitempty"`
 	// +optional
 	Name string `json:"name,omitempty"`
+	// Shared adopts an existing public load balancer, and the public IP of its frontend, managed outside of the
+	// cluster and possibly shared with other clusters, instead of creating them. Only the load balancing rule, probe
+	// and backend pool of the cluster are added to the load balancer, and only they are removed when the cluster is
+	// deleted. It is only supported by the API server load balancer. Immutable.
+	// +optional
+	Shared *SharedLoadBalancer `json:"shared,omitempty"`
 
 	LoadBalancerClassSpec `json:",inline"`
 }
 
+// SharedLoadBalancer defines how a cluster reaches its API server through a load balancer shared with other clusters.
+type SharedLoadBalancer struct {
+	// FrontendPort is the port of the shared frontend the API server of the cluster is reached on, forwarded to the
+	// API server port of the control plane machines. It must not be used by the rules of the other clusters sharing
+	// the load balancer.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	FrontendPort int32 `json:"frontendPort"`
+}
+
 // SKU defines an Azure load balancer SKU.
 type SKU string
 
@@ -241,6 +697,118 @@ const (
 	SKUStandard = SKU("Standard")
 )
 
+// LoadBalancerTier defines the tier of an Azure Standard load balancer.
+type LoadBalancerTier string
+
+const (
+	// LoadBalancerTierRegional is the tier of a load balancer that is available in a single region and whose backends
+	// are machines.
+	LoadBalancerTierRegional = LoadBalancerTier("Regional")
+	// LoadBalancerTierGlobal is the tier of a cross-region load balancer, whose backends are the frontends of regional
+	// load balancers.
+	LoadBalancerTierGlobal = LoadBalancerTier("Global")
+)
+
+// ProbeProtocol defines the protocol of a load balancer health probe.
+type ProbeProtocol string
+
+const (
+	// ProbeProtocolTCP is the value for health probes checking that the backend port accepts TCP connections.
+	ProbeProtocolTCP = ProbeProtocol("Tcp")
+	// ProbeProtocolHTTPS is the value for health probes checking that the backend answers an HTTPS request with a 200 status.
+	ProbeProtocolHTTPS = ProbeProtocol("Https")
+	// ProbeProtocolHTTP is the value for health probes checking that the backend answers an HTTP request with a 200 status.
+	ProbeProtocolHTTP = ProbeProtocol("Http")
+)
+
+// LoadBalancerRuleProtocol defines the transport protocol of a load balancing rule.
+type LoadBalancerRuleProtocol string
+
+const (
+	// LoadBalancerRuleProtocolTCP is the value for load balancing rules forwarding TCP traffic.
+	LoadBalancerRuleProtocolTCP = LoadBalancerRuleProtocol("Tcp")
+	// LoadBalancerRuleProtocolUDP is the value for load balancing rules forwarding UDP traffic.
+	LoadBalancerRuleProtocolUDP = LoadBalancerRuleProtocol("Udp")
+)
+
+// HealthProbe defines the health probe of a load balancer.
+type HealthProbe struct {
+	// Protocol is the protocol of the health probe. A Tcp probe marks the API server healthy as soon as its port
+	// accepts connections, while an Https probe waits for it to answer RequestPath with a 200 status. Https probes
+	// don't validate the certificate of the API server, so its self-signed certificate is accepted. They are only
+	// supported by the Standard SKU; Tcp is used for other SKUs. Defaults to Tcp.
+	// +kubebuilder:validation:Enum=Tcp;Https
+	// +optional
+	Protocol ProbeProtocol `json:"protocol,omitempty"`
+	// RequestPath is the path requested by Https health probes on the API server port. Defaults to /readyz.
+	// +optional
+	RequestPath string `json:"requestPath,omitempty"`
+}
+
+// HAPorts defines the HA ports load balancing rule of an internal load balancer.
+type HAPorts struct {
+	// Enabled replaces the API server port load balancing rule with an HA ports rule forwarding the flows of all the
+	// ports and protocols to the backend, e.g. to use the load balancer as the gateway of a network virtual appliance.
+	// An HA ports rule can't share its frontend with rules for specific ports, so the API server is reached through
+	// the HA ports rule and keeps being probed on its port.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// SSHNATRule defines an inbound NAT rule of version 2, which maps a range of frontend ports to the SSH port of the
+// machines of a backend pool.
+type SSHNATRule struct {
+	// FrontendPortRangeStart is the first frontend port of the range.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65534
+	FrontendPortRangeStart int32 `json:"frontendPortRangeStart"`
+	// FrontendPortRangeEnd is the last frontend port of the range. The range must hold a port for each control plane
+	// machine, including the machines created during a rolling update.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65534
+	FrontendPortRangeEnd int32 `json:"frontendPortRangeEnd"`
+}
+
+// ConnectionDraining defines how the connections of the API server load balancer to a control plane machine are
+// drained before the machine is deleted.
+type ConnectionDraining struct {
+	// Timeout is how long the deletion of a control plane machine waits, once the machine is removed from the backend
+	// pools of the API server load balancer, before its VM is deleted, so that the established connections complete.
+	// The load balancer sends no new connections to the machine in the meantime. At most 1 hour.
+	Timeout metav1.Duration `json:"timeout"`
+	// EnableTCPReset sends a TCP reset to both ends of the connections of the load balancing rule of the API server
+	// when they are idle for longer than the idle timeout, so that the clients reconnect right away instead of waiting
+	// on a dropped connection. The setting of the rule is left as is when unset.
+	// +optional
+	EnableTCPReset *bool `json:"enableTCPReset,omitempty"`
+}
+
+// DiagnosticSettings defines an Azure Monitor diagnostic setting exporting the platform logs and metrics of a resource.
+type DiagnosticSettings struct {
+	// WorkspaceID is the resource ID of the Log Analytics workspace the logs and metrics are sent to.
+	// +optional
+	WorkspaceID string `json:"workspaceID,omitempty"`
+	// StorageAccountID is the resource ID of the storage account the logs and metrics are archived to.
+	// +optional
+	StorageAccountID string `json:"storageAccountID,omitempty"`
+	// EventHubAuthorizationRuleID is the resource ID of the authorization rule of the Event Hub namespace the logs and
+	// metrics are streamed to, e.g. for a SIEM to consume them. The rule must belong to the namespace, not to an event
+	// hub, and have the Manage, Send and Listen rights.
+	// +optional
+	EventHubAuthorizationRuleID string `json:"eventHubAuthorizationRuleID,omitempty"`
+	// EventHubName is the name of the event hub of the namespace the logs and metrics are streamed to. Azure creates
+	// an event hub for each log category when unset.
+	// +optional
+	EventHubName string `json:"eventHubName,omitempty"`
+	// LogCategories are the diagnostic log categories to enable, e.g. LoadBalancerAlertEvent. The categories available
+	// depend on the resource type and SKU.
+	// +optional
+	LogCategories []string `json:"logCategories,omitempty"`
+	// MetricCategories are the metric categories to enable, e.g. AllMetrics.
+	// +optional
+	MetricCategories []string `json:"metricCategories,omitempty"`
+}
+
 // LBType defines an Azure load balancer Type.
 type LBType string
 
@@ -266,6 +834,442 @@ type PublicIPSpec struct {
 	Name string `json:"name"`
 	// +optional
 	DNSName string `json:"dnsName,omitempty"`
+	// Zones are the availability zones the public IP is created in, e.g. a single zone to co-locate the public IP
+	// with a zonal control plane. The zones must be available in the location of the cluster. Defaults to all the
+	// availability zones of the location, i.e. a zone-redundant public IP. Immutable.
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+	// Tier is the tier of the public IP. Public IPs are always created with the Standard SKU and a static
+	// allocation. The Global tier is only supported for the public IP of a cross-region load balancer, which it
+	// defaults to. Defaults to Regional for all other public IPs.
+	// +kubebuilder:validation:Enum=Regional;Global
+	// +optional
+	Tier PublicIPTier `json:"tier,omitempty"`
+	// IPTags are the IP tags of the public IP, e.g. to mark it as used by a first party service. Immutable.
+	// +optional
+	IPTags []IPTag `json:"ipTags,omitempty"`
+	// RoutingPreference is how the traffic between the public IP and the internet is routed. MicrosoftNetwork, the
+	// default, routes it through the Microsoft global network up to the edge closest to the user. Internet routes it
+	// through the network of the internet service provider, which is cheaper but can add latency. Internet is not
+	// supported for the Global tier. Immutable.
+	// See: https://docs.microsoft.com/en-us/azure/virtual-network/ip-services/routing-preference-overview
+	// +kubebuilder:validation:Enum=MicrosoftNetwork;Internet
+	// +optional
+	RoutingPreference RoutingPreference `json:"routingPreference,omitempty"`
+	// IPPrefixID is the resource ID of an existing public IP prefix the address of the public IP is allocated from,
+	// for contiguous addressing with the other addresses of the prefix. The prefix must be of the Standard SKU and
+	// of the tier of the public IP, and have an address available. It is never modified nor deleted by CAPZ:
+	// deleting the public IP releases its address back to the prefix. Only supported for the public IPs of the
+	// frontends of the load balancers. Immutable.
+	// +optional
+	IPPrefixID string `json:"ipPrefixID,omitempty"`
+	// DiagnosticSettings is the Azure Monitor diagnostic setting exporting the platform logs and metrics of the public
+	// IP, e.g. the DDoSProtectionNotifications logs. The diagnostic setting is removed when unset.
+	// +optional
+	DiagnosticSettings *DiagnosticSettings `json:"diagnosticSettings,omitempty"`
+}
+
+// IPTag is a tag of an Azure public IP address.
+type IPTag struct {
+	// Type is the type of the IP tag.
+	// +kubebuilder:validation:Enum=FirstPartyUsage
+	Type IPTagType `json:"type"`
+	// Tag is the value of the IP tag, e.g. /Sql for a FirstPartyUsage tag.
+	Tag string `json:"tag"`
+}
+
+// IPTagType defines the type of an IP tag.
+type IPTagType string
+
+const (
+	// IPTagTypeFirstPartyUsage is the type of the IP tags that mark a public IP as used by a first party service.
+	IPTagTypeFirstPartyUsage = IPTagType("FirstPartyUsage")
+	// IPTagTypeRoutingPreference is the type of the IP tag Azure represents the routing preference of a public IP
+	// with. It is set from the RoutingPreference of the public IP rather than its IPTags.
+	IPTagTypeRoutingPreference = IPTagType("RoutingPreference")
+)
+
+// RoutingPreference defines how the traffic of an Azure public IP address is routed.
+type RoutingPreference string
+
+const (
+	// RoutingPreferenceMicrosoftNetwork routes the traffic through the Microsoft global network.
+	RoutingPreferenceMicrosoftNetwork = RoutingPreference("MicrosoftNetwork")
+	// RoutingPreferenceInternet routes the traffic through the network of the internet service provider.
+	RoutingPreferenceInternet = RoutingPreference("Internet")
+)
+
+// PublicIPTier defines the tier of an Azure public IP address.
+type PublicIPTier string
+
+const (
+	// PublicIPTierRegional is the tier of a public IP that is available in a single region.
+	PublicIPTierRegional = PublicIPTier("Regional")
+	// PublicIPTierGlobal is the tier of an anycast public IP that is available across regions.
+	PublicIPTierGlobal = PublicIPTier("Global")
+)
+
+// IsGlobal returns true if the public IP is of the Global tier.
+func (ip PublicIPSpec) IsGlobal() bool {
+	return ip.Tier == PublicIPTierGlobal
+}
+
+// PublicIPPrefixSpec defines the inputs to create or reference an Azure public IP prefix.
+type PublicIPPrefixSpec struct {
+	Name string `json:"name"`
+	// PrefixLength is the length of the prefix to create, which determines the number of public IP addresses it
+	// holds (31 for 2 addresses, down to 28 for 16 addresses). Ignored when referencing an existing prefix.
+	// +kubebuilder:validation:Minimum=28
+	// +kubebuilder:validation:Maximum=31
+	// +optional
+	PrefixLength *int32 `json:"prefixLength,omitempty"`
+}
+
+// NamingConvention defines how the names of the Azure resources of a cluster are generated from the cluster name.
+type NamingConvention struct {
+	// Prefix is prepended to the generated names.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Suffix is appended to the generated names.
+	// +optional
+	Suffix string `json:"suffix,omitempty"`
+
+	// Separator joins the prefix, the parts of the generated names and the suffix.
+	// +kubebuilder:validation:Enum=-;_;.
+	// +optional
+	Separator string `json:"separator,omitempty"`
+}
+
+// LogAnalyticsWorkspace defines the Log Analytics workspace of a cluster.
+type LogAnalyticsWorkspace struct {
+	// ID is the Azure resource ID of an existing workspace to use, in the subscription of the cluster. A workspace
+	// referenced by ID is considered shared and externally managed: it is never modified nor deleted.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// Name is the name of the workspace to create in the cluster resource group. Ignored when ID is set.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// RetentionInDays is the number of days data is retained in a workspace created by CAPZ.
+	// +kubebuilder:validation:Minimum=30
+	// +kubebuilder:validation:Maximum=730
+	// +optional
+	RetentionInDays *int32 `json:"retentionInDays,omitempty"`
+	// SharedKeySecretName is the name of a secret, in the namespace of the AzureCluster, in which the primary shared
+	// key of the workspace is stored for the monitoring agents. The key is not retrieved when empty.
+	// +optional
+	SharedKeySecretName string `json:"sharedKeySecretName,omitempty"`
+}
+
+// LogAnalyticsSharedKeySecretKey is the key of the primary shared key of a Log Analytics workspace in the secret
+// referenced by LogAnalyticsWorkspaceStatus.SharedKeySecretRef.
+const LogAnalyticsSharedKeySecretKey = "sharedKey"
+
+// LogAnalyticsWorkspaceStatus defines the observed state of a Log Analytics workspace.
+type LogAnalyticsWorkspaceStatus struct {
+	// ID is the Azure resource ID of the workspace.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// WorkspaceID is the ID the monitoring agents use to send data to the workspace.
+	// +optional
+	WorkspaceID string `json:"workspaceID,omitempty"`
+	// SharedKeySecretRef is a reference to the secret holding the primary shared key of the workspace, under the
+	// LogAnalyticsSharedKeySecretKey key.
+	// +optional
+	SharedKeySecretRef *corev1.SecretReference `json:"sharedKeySecretRef,omitempty"`
+}
+
+// DiagnosticsResourceGroup defines the resource group of the diagnostics resources of a cluster.
+type DiagnosticsResourceGroup struct {
+	// Name is the name of the resource group. It must differ from the resource group of the cluster.
+	Name string `json:"name"`
+	// Location is the location of the resource group, an existing resource group must be in this location. Defaults
+	// to the location of the cluster.
+	// +optional
+	Location string `json:"location,omitempty"`
+}
+
+// PolicyEnforcementMode defines whether the effect of a policy is enforced.
+type PolicyEnforcementMode string
+
+const (
+	// PolicyEnforcementModeDefault enforces the effect of the policy, e.g. resources that aren't compliant are
+	// denied.
+	PolicyEnforcementModeDefault PolicyEnforcementMode = "Default"
+	// PolicyEnforcementModeDoNotEnforce only evaluates the compliance of the resources with the policy.
+	PolicyEnforcementModeDoNotEnforce PolicyEnforcementMode = "DoNotEnforce"
+)
+
+// PolicyAssignment defines the assignment of an Azure Policy definition or initiative to the resource group of a
+// cluster.
+type PolicyAssignment struct {
+	// Name is the name of the assignment, unique in the resource group.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=64
+	Name string `json:"name"`
+	// PolicyDefinitionID is the Azure resource ID of the policy definition or initiative, i.e. policy set
+	// definition, to assign, e.g.
+	// "/providers/Microsoft.Authorization/policyDefinitions/<definition name>".
+	PolicyDefinitionID string `json:"policyDefinitionID"`
+	// DisplayName is the display name of the assignment.
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+	// Parameters maps the names of the parameters of the policy definition to their JSON-encoded value, e.g.
+	// "\"eastus\"" for a string or "[\"eastus\", \"westus\"]" for an array.
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+	// EnforcementMode defines whether the effect of the policy is enforced. Defaults to Default.
+	// +kubebuilder:validation:Enum=Default;DoNotEnforce
+	// +optional
+	EnforcementMode PolicyEnforcementMode `json:"enforcementMode,omitempty"`
+}
+
+// GalleryImage references an image version of an Azure Compute Gallery, formerly Shared Image Gallery, that the
+// machines of a cluster are built from. It is purely advisory: no Azure resource is created for it, it is published in
+// the AzureCluster status once resolved for the machine actuators.
+type GalleryImage struct {
+	// ID is the Azure resource ID of the gallery. The gallery may be in another subscription than the cluster, as long
+	// as the identity of the cluster is allowed to read it.
+	ID string `json:"id"`
+	// ImageDefinition is the name of the image definition in the gallery.
+	// +kubebuilder:validation:MinLength=1
+	ImageDefinition string `json:"imageDefinition"`
+	// Version is the version of the image, in the Major.Minor.Patch format, or 'latest' for the highest version of the
+	// image definition that isn't excluded from latest. Defaults to latest.
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+// LatestGalleryImageVersion is the version of a gallery image that resolves to its highest version not excluded from
+// latest.
+const LatestGalleryImageVersion = "latest"
+
+// EtcdDisks defines the requirements of the etcd data disks of the control plane machines of a cluster.
+type EtcdDisks struct {
+	// StorageAccountType is the storage account type of the etcd data disks. UltraSSD_LRS ultra disks are only
+	// supported by some VM sizes, in some availability zones of some locations.
+	// +kubebuilder:validation:Enum=UltraSSD_LRS
+	StorageAccountType string `json:"storageAccountType"`
+	// VMSize is the size of the control plane machines, e.g. Standard_D4s_v3, which must support the etcd data disks
+	// in every availability zone of the control plane.
+	VMSize string `json:"vmSize"`
+}
+
+// AvailabilitySet defines the availability set the control plane machines of a cluster are placed in, for the high
+// availability of the control plane in regions without availability zones.
+type AvailabilitySet struct {
+	// Name is the name of the availability set, in the resource group of the cluster. An existing availability set of
+	// that name is adopted as is, and never deleted. Defaults to "<cluster name>_control-plane-as", the availability
+	// set the control plane machines are placed in without one. Immutable.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// FaultDomainCount is the number of fault domains, i.e. of groups of machines that share a power source and a
+	// network switch, the machines are spread across. It must not exceed the maximum of the location of the cluster,
+	// 2 or 3 depending on the region. Defaults to that maximum. Immutable.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3
+	// +optional
+	FaultDomainCount *int32 `json:"faultDomainCount,omitempty"`
+	// UpdateDomainCount is the number of update domains, i.e. of groups of machines that are rebooted together during
+	// the planned maintenance of Azure, the machines are spread across. Defaults to 5. Immutable.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=20
+	// +optional
+	UpdateDomainCount *int32 `json:"updateDomainCount,omitempty"`
+}
+
+// ProviderFeature defines a feature of an Azure resource provider, which is registered per subscription.
+type ProviderFeature struct {
+	// Namespace is the namespace of the resource provider, e.g. Microsoft.Network.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+	// Name is the name of the feature.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// SecondaryRegionSpec defines the network of the secondary region of a cluster.
+type SecondaryRegionSpec struct {
+	// Location is the secondary region. Defaults to the region paired with the location of the cluster, as reported
+	// in the status of the cluster.
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// Vnet is the virtual network of the secondary region. It can't be peered.
+	// +optional
+	Vnet VnetSpec `json:"vnet,omitempty"`
+
+	// Subnets are the subnets of the virtual network of the secondary region, with the control-plane or node role.
+	// Defaults to a control plane and a node subnet.
+	// +optional
+	Subnets []SecondarySubnetSpec `json:"subnets,omitempty"`
+}
+
+// SecondarySubnetSpec defines a subnet of the secondary region of a cluster. Its security group gets the security
+// rules of the subnets of the same role of the cluster, except the ones referencing application security groups, which
+// can't be used across regions.
+type SecondarySubnetSpec struct {
+	// Name is the name of the subnet.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// SecurityGroupName is the name of the security group of the subnet.
+	// +optional
+	SecurityGroupName string `json:"securityGroupName,omitempty"`
+
+	SubnetClassSpec `json:",inline"`
+}
+
+// SecondaryNetworkStatus defines the observed state of the network of the secondary region of a cluster.
+type SecondaryNetworkStatus struct {
+	// Location is the secondary region the network is reconciled in.
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// VnetID is the Azure resource ID of the virtual network of the secondary region.
+	// +optional
+	VnetID string `json:"vnetID,omitempty"`
+
+	// SubnetIDs maps the name of each subnet of the secondary region to its Azure resource ID.
+	// +optional
+	SubnetIDs map[string]string `json:"subnetIDs,omitempty"`
+}
+
+// RoleAssignment defines the assignment of an Azure role to a principal, scoped to the resource group of a cluster.
+type RoleAssignment struct {
+	// Name identifies the role assignment in the spec and in the status of the cluster.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=64
+	Name string `json:"name"`
+	// PrincipalID is the object ID of the principal the role is assigned to, e.g. a user-assigned identity, a group
+	// or a service principal.
+	PrincipalID string `json:"principalID"`
+	// RoleDefinitionID is the ID of the role to assign, either the name of a built-in role definition, e.g.
+	// "b24988ac-6180-42a0-ab88-20f7382dd24c" for Contributor, or the resource ID of a role definition.
+	RoleDefinitionID string `json:"roleDefinitionID"`
+}
+
+// BudgetTimeGrain defines the period after which the cost tracked by a budget is reset.
+type BudgetTimeGrain string
+
+const (
+	// BudgetTimeGrainMonthly resets the cost tracked by the budget every month.
+	BudgetTimeGrainMonthly BudgetTimeGrain = "Monthly"
+	// BudgetTimeGrainQuarterly resets the cost tracked by the budget every quarter.
+	BudgetTimeGrainQuarterly BudgetTimeGrain = "Quarterly"
+	// BudgetTimeGrainAnnually resets the cost tracked by the budget every year.
+	BudgetTimeGrainAnnually BudgetTimeGrain = "Annually"
+)
+
+// BudgetSpec defines an Azure Cost Management budget tracking the cost of the resource group of a cluster.
+type BudgetSpec struct {
+	// Name is the name of the budget, unique in the resource group. Defaults to the name of the cluster.
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Amount is the cost tracked by the budget over each period, in the billing currency of the subscription.
+	// +kubebuilder:validation:Minimum=1
+	Amount int64 `json:"amount"`
+	// TimeGrain is the period after which the cost tracked by the budget is reset. Defaults to Monthly.
+	// +kubebuilder:validation:Enum=Monthly;Quarterly;Annually
+	// +optional
+	TimeGrain BudgetTimeGrain `json:"timeGrain,omitempty"`
+	// Notifications are sent to their contacts when the actual cost of the period exceeds their threshold.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=5
+	Notifications []BudgetNotification `json:"notifications"`
+}
+
+// BudgetNotification defines a notification of a budget, sent when the actual cost exceeds a percentage of its amount.
+type BudgetNotification struct {
+	// Threshold is the percentage of the amount of the budget above which the notification is sent.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	Threshold int32 `json:"threshold"`
+	// ContactEmails are the email addresses the notification is sent to.
+	// +optional
+	ContactEmails []string `json:"contactEmails,omitempty"`
+	// ContactRoles are the roles on the resource group, e.g. Owner, whose members the notification is sent to.
+	// +optional
+	ContactRoles []string `json:"contactRoles,omitempty"`
+	// ContactGroups are the Azure resource IDs of the Azure Monitor action groups the notification triggers.
+	// +optional
+	ContactGroups []string `json:"contactGroups,omitempty"`
+}
+
+// CostEstimate is an approximate monthly cost of the network resources of a cluster, computed from the retail prices of
+// its region. It only accounts for the hourly charges of the resources, not for the data they process, nor for
+// discounts or negotiated prices.
+type CostEstimate struct {
+	// MonthlyAmount is the estimated cost of a month of 730 hours, with two decimals, e.g. "53.29".
+	MonthlyAmount string `json:"monthlyAmount"`
+	// Currency is the ISO 4217 code of the currency of the amounts, e.g. USD.
+	Currency string `json:"currency"`
+	// Resources is the breakdown of the estimate by kind of resource.
+	// +optional
+	Resources []ResourceCostEstimate `json:"resources,omitempty"`
+}
+
+// ResourceCostEstimate is the estimated monthly cost of the resources of a kind.
+type ResourceCostEstimate struct {
+	// Kind is the kind of the resources, e.g. "Standard Load Balancer".
+	Kind string `json:"kind"`
+	// Count is the number of resources of the kind.
+	Count int32 `json:"count"`
+	// MonthlyAmount is the estimated cost of a month of the resources of the kind, with two decimals.
+	MonthlyAmount string `json:"monthlyAmount"`
+}
+
+// SpotEvictionPolicy defines what happens to a Spot VM when Azure evicts it.
+type SpotEvictionPolicy string
+
+const (
+	// SpotEvictionPolicyDeallocate stops and deallocates evicted Spot VMs, their disks are kept.
+	SpotEvictionPolicyDeallocate SpotEvictionPolicy = "Deallocate"
+	// SpotEvictionPolicyDelete deletes evicted Spot VMs along with their disks.
+	SpotEvictionPolicyDelete SpotEvictionPolicy = "Delete"
+)
+
+// SpotPolicy defines the Spot VM settings the machines of a cluster default to. It is purely advisory: no Azure
+// resource is created for it, it is published in the AzureCluster status once validated for the machine actuators.
+type SpotPolicy struct {
+	// MaxPrice is the maximum price, in US dollars per hour, to pay for a Spot VM. It has at most 5 decimal places.
+	// -1 caps the price at the pay-as-you-go price of the VM size, VMs are then only evicted for capacity.
+	// +optional
+	MaxPrice *resource.Quantity `json:"maxPrice,omitempty"`
+	// EvictionPolicy defines what happens to a Spot VM when it is evicted. Defaults to Deallocate.
+	// +kubebuilder:validation:Enum=Deallocate;Delete
+	// +optional
+	EvictionPolicy SpotEvictionPolicy `json:"evictionPolicy,omitempty"`
+}
+
+// AutoShutdownSchedule defines the daily auto-shutdown schedule of the machines of a cluster. It is purely advisory:
+// no Azure resource is created for it, it is published in the AzureCluster status once validated for the machine
+// actuators.
+type AutoShutdownSchedule struct {
+	// Time is the time of the day the machines are shut down at, in the 24-hour HH:MM format, e.g. 19:30.
+	Time string `json:"time"`
+	// TimeZone is the IANA time zone of the time, e.g. Europe/Paris. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// Notification notifies of the shutdown before it happens. No notification is sent when nil.
+	// +optional
+	Notification *AutoShutdownNotification `json:"notification,omitempty"`
+}
+
+// AutoShutdownNotification defines the notification sent before an auto-shutdown.
+type AutoShutdownNotification struct {
+	// MinutesBefore is the number of minutes before the shutdown the notification is sent. Defaults to 30.
+	// +kubebuilder:validation:Minimum=5
+	// +kubebuilder:validation:Maximum=120
+	// +optional
+	MinutesBefore *int32 `json:"minutesBefore,omitempty"`
+	// Email is the email address the notification is sent to.
+	// +optional
+	Email string `json:"email,omitempty"`
+	// WebhookURL is the HTTPS URL the notification is posted to.
+	// +optional
+	WebhookURL string `json:"webhookURL,omitempty"`
 }
 
 // VMState describes the state of an Azure virtual machine.
@@ -499,6 +1503,12 @@ const (
 
 	// SubnetBastion defines a Bastion subnet role.
 	SubnetBastion = SubnetRole(Bastion)
+
+	// SubnetIngress defines the role of the subnet of an ingress controller or an API Management gateway.
+	SubnetIngress = SubnetRole(Ingress)
+
+	// SubnetPod defines the role of the subnet of the IP addresses of the pods assigned by Azure CNI.
+	SubnetPod = SubnetRole(Pod)
 )
 
 // SubnetSpec configures an Azure subnet.
@@ -520,12 +1530,34 @@ type SubnetSpec struct {
 	RouteTable RouteTable `json:"routeTable,omitempty"`
 
 	// NatGateway associated with this subnet.
+	// The NAT gateway of the control plane subnet gives the control plane a dedicated egress IP, independent of the
+	// NAT gateways of the node subnets.
 	// +optional
 	NatGateway NatGateway `json:"natGateway,omitempty"`
 
+	// FreeIPsThreshold is the number of available IP addresses in the subnet below which the SubnetIPsAvailable
+	// condition of the cluster is marked false as a warning, as the subnet is about to run out of addresses for new
+	// machines. The subnet capacity isn't checked if unset.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FreeIPsThreshold *int32 `json:"freeIPsThreshold,omitempty"`
+
+	// FirewallRoute sends the egress traffic of the node subnet to an existing Azure Firewall, through a default route
+	// in the route table of the subnet. It is only reconciled when the virtual network is managed.
+	// +optional
+	FirewallRoute *FirewallRoute `json:"firewallRoute,omitempty"`
+
 	SubnetClassSpec `json:",inline"`
 }
 
+// FirewallRoute defines the route of the egress traffic of a subnet to an Azure Firewall.
+type FirewallRoute struct {
+	// PrivateIP is the private IP address of the firewall, the next hop of the default route 0.0.0.0/0 of the subnet.
+	// The firewall itself isn't managed by CAPZ: it must be reachable from the virtual network of the cluster, e.g.
+	// through a peering to a hub network.
+	PrivateIP string `json:"privateIP"`
+}
+
 // GetControlPlaneSubnet returns the cluster control plane subnet.
 func (n *NetworkSpec) GetControlPlaneSubnet() (SubnetSpec, error) {
 	for _, sn := range n.Subnets {
@@ -554,11 +1586,48 @@ func (n *NetworkSpec) UpdateNodeSubnet(subnet SubnetSpec) {
 	}
 }
 
+// IsFirewallRouteEnabled returns whether or not the egress traffic of the subnet is routed to a firewall.
+func (s SubnetSpec) IsFirewallRouteEnabled() bool {
+	return s.FirewallRoute != nil && s.FirewallRoute.PrivateIP != ""
+}
+
 // IsNatGatewayEnabled returns whether or not a NAT gateway is enabled on the subnet.
 func (s SubnetSpec) IsNatGatewayEnabled() bool {
 	return s.NatGateway.Name != ""
 }
 
+// NatGatewayIPNames returns the names of the public IPs of the NAT gateway.
+func (n NatGateway) NatGatewayIPNames() []string {
+	count := 1
+	if n.NatGatewayIPCount != nil {
+		count = int(*n.NatGatewayIPCount)
+	}
+	names := make([]string, count)
+	for i := range names {
+		if i == 0 {
+			names[i] = n.NatGatewayIP.Name
+		} else {
+			names[i] = fmt.Sprintf("%s-%d", n.NatGatewayIP.Name, i)
+		}
+	}
+	return names
+}
+
+// StaleNatGatewayIPNames returns the names the public IPs of the NAT gateway have when its public IP count is higher than
+// it is now, i.e. the public IPs left behind when the count is lowered.
+func (n NatGateway) StaleNatGatewayIPNames() []string {
+	count := len(n.NatGatewayIPNames())
+	var names []string
+	if count == 0 {
+		names = append(names, n.NatGatewayIP.Name)
+		count = 1
+	}
+	for i := count; i < MaxNatGatewayOutboundIPs; i++ {
+		names = append(names, fmt.Sprintf("%s-%d", n.NatGatewayIP.Name, i))
+	}
+	return names
+}
+
 // SecurityProfile specifies the Security profile settings for a
 // virtual machine or virtual machine scale set.
 type SecurityProfile struct {
@@ -662,6 +1731,10 @@ const (
 type BastionSpec struct {
 	// +optional
 	AzureBastion *AzureBastion `json:"azureBastion,omitempty"`
+	// Jumpbox is a virtual machine with a public IP that can be used as an SSH jump host instead of Azure Bastion.
+	// It cannot be combined with AzureBastion.
+	// +optional
+	Jumpbox *Jumpbox `json:"jumpbox,omitempty"`
 }
 
 // AzureBastion specifies how the Azure Bastion cloud component should be configured.
@@ -674,6 +1747,95 @@ type AzureBastion struct {
 	PublicIP PublicIPSpec `json:"publicIP,omitempty"`
 }
 
+// Jumpbox specifies how the jumpbox virtual machine should be configured.
+type Jumpbox struct {
+	// +optional
+	Name string `json:"name,omitempty"`
+	// VMSize is the size of the jumpbox virtual machine.
+	// +optional
+	VMSize string `json:"vmSize,omitempty"`
+	// Image is the image of the jumpbox virtual machine. Defaults to an Ubuntu 20.04 LTS marketplace image.
+	// +optional
+	Image *Image `json:"image,omitempty"`
+	// SSHPublicKey is the base64 encoded SSH public key authorized to log in to the jumpbox.
+	SSHPublicKey string `json:"sshPublicKey"`
+	// AllowedSourceCIDRs is the list of address ranges allowed to reach the jumpbox over SSH.
+	// +kubebuilder:validation:MinItems=1
+	AllowedSourceCIDRs []string `json:"allowedSourceCIDRs"`
+	// +optional
+	Subnet SubnetSpec `json:"subnet,omitempty"`
+	// +optional
+	PublicIP PublicIPSpec `json:"publicIP,omitempty"`
+}
+
+// ReconcileMode defines which Azure resources of a cluster are managed.
+type ReconcileMode string
+
+const (
+	// ReconcileModeFull manages all the Azure resources of the cluster.
+	ReconcileModeFull ReconcileMode = "Full"
+	// ReconcileModeNetworkOnly manages only the networking resources of the cluster, in an existing resource group.
+	ReconcileModeNetworkOnly ReconcileMode = "NetworkOnly"
+)
+
+// TrafficRoutingMethod defines how Traffic Manager routes DNS queries across API server endpoints.
+type TrafficRoutingMethod string
+
+const (
+	// TrafficRoutingMethodPriority routes traffic to the healthy endpoint with the highest priority.
+	TrafficRoutingMethodPriority TrafficRoutingMethod = "Priority"
+	// TrafficRoutingMethodPerformance routes traffic to the healthy endpoint with the lowest latency.
+	TrafficRoutingMethodPerformance TrafficRoutingMethod = "Performance"
+)
+
+// TrafficManagerSpec defines an Azure Traffic Manager profile that provides a global endpoint for the API server.
+type TrafficManagerSpec struct {
+	// Name is the name of the Traffic Manager profile.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// DNSPrefix is the relative DNS name of the profile. The resulting FQDN, <DNSPrefix>.trafficmanager.net,
+	// must be globally unique and is used as the control plane endpoint of the cluster.
+	// +optional
+	DNSPrefix string `json:"dnsPrefix,omitempty"`
+	// RoutingMethod is the method used to route traffic across the regional API server endpoints.
+	// +kubebuilder:validation:Enum=Priority;Performance
+	// +optional
+	RoutingMethod TrafficRoutingMethod `json:"routingMethod,omitempty"`
+}
+
+// GlobalLoadBalancerSpec defines an Azure cross-region load balancer that provides a global endpoint for the API server.
+type GlobalLoadBalancerSpec struct {
+	// Name is the name of the cross-region load balancer.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Location is the home region of the cross-region load balancer, which must be one of the regions Azure supports
+	// as home regions of cross-region load balancers. Defaults to the location of the cluster. Immutable.
+	// +optional
+	Location string `json:"location,omitempty"`
+	// PublicIP is the global public IP of the frontend of the load balancer. Its DNS name is the control plane
+	// endpoint of the cluster.
+	// +optional
+	PublicIP *PublicIPSpec `json:"publicIP,omitempty"`
+	// AdditionalBackends are the resource IDs of the frontend IP configurations of other regional Standard public
+	// load balancers to add to the backend pool, e.g. the API server load balancers of the clusters of other
+	// regions. The frontend of the API server load balancer of the cluster is always part of the backend pool.
+	// +optional
+	AdditionalBackends []string `json:"additionalBackends,omitempty"`
+}
+
+// BackendHealthState is the health of a regional load balancer behind a cross-region load balancer.
+type BackendHealthState string
+
+const (
+	// BackendHealthy means the regional load balancer is available.
+	BackendHealthy = BackendHealthState("Healthy")
+	// BackendUnhealthy means the regional load balancer is unavailable, the cross-region load balancer takes it out
+	// of rotation.
+	BackendUnhealthy = BackendHealthState("Unhealthy")
+	// BackendHealthUnknown means the health of the regional load balancer could not be retrieved.
+	BackendHealthUnknown = BackendHealthState("Unknown")
+)
+
 // IsTerminalProvisioningState returns true if the ProvisioningState is a terminal state for an Azure resource.
 func IsTerminalProvisioningState(state ProvisioningState) bool {
 	return state == Failed || state == Succeeded
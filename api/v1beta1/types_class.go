@@ -65,6 +65,11 @@ type VnetClassSpec struct {
 	// +optional
 	CIDRBlocks []string `json:"cidrBlocks,omitempty"`
 
+	// DNSServers is a list of IP addresses of custom DNS servers used by the virtual network.
+	// The Azure-provided DNS is used when the list is empty.
+	// +optional
+	DNSServers []string `json:"dnsServers,omitempty"`
+
 	// Tags is a collection of tags describing the resource.
 	// +optional
 	Tags Tags `json:"tags,omitempty"`
@@ -73,7 +78,7 @@ type VnetClassSpec struct {
 // SubnetClassSpec defines the SubnetSpec properties that may be shared across several Azure clusters.
 type SubnetClassSpec struct {
 	// Role defines the subnet role (eg. Node, ControlPlane)
-	// +kubebuilder:validation:Enum=node;control-plane;bastion
+	// +kubebuilder:validation:Enum=node;control-plane;bastion;ingress
 	Role SubnetRole `json:"role"`
 
 	// CIDRBlocks defines the subnet's address space, specified as one or more address prefixes in CIDR notation.
@@ -85,8 +90,20 @@ type SubnetClassSpec struct {
 type LoadBalancerClassSpec struct {
 	// +optional
 	SKU SKU `json:"sku,omitempty"`
+	// Tier is the tier of the load balancer. Only the Regional tier supports machines in the backend pool; the
+	// cross-region load balancer of the API server, of the Global tier, is configured with globalLB. Defaults to
+	// Regional. Immutable.
+	// +kubebuilder:validation:Enum=Regional;Global
+	// +optional
+	Tier LoadBalancerTier `json:"tier,omitempty"`
 	// +optional
 	FrontendIPs []FrontendIP `json:"frontendIPs,omitempty"`
+	// InternalFrontendIP adds a private frontend to a public API server load balancer, so that the API server can also
+	// be reached from the virtual network on a private IP. An Azure load balancer can't have both public and private
+	// frontends, so the private frontend is reconciled as a companion internal load balancer named "<name>-internal"
+	// in the control plane subnet, with the same rule, probe and backend as the public load balancer.
+	// +optional
+	InternalFrontendIP *FrontendIP `json:"internalFrontendIP,omitempty"`
 	// +optional
 	Type LBType `json:"type,omitempty"`
 	// FrontendIPsCount specifies the number of frontend IP addresses for the load balancer.
@@ -95,12 +112,67 @@ type LoadBalancerClassSpec struct {
 	// IdleTimeoutInMinutes specifies the timeout for the TCP idle connection.
 	// +optional
 	IdleTimeoutInMinutes *int32 `json:"idleTimeoutInMinutes,omitempty"`
+	// HealthProbe configures the health probe of the API server load balancer.
+	// +optional
+	HealthProbe *HealthProbe `json:"healthProbe,omitempty"`
+	// HAPorts configures the load balancer to forward all the ports and protocols to the backend instead of the API
+	// server port only. It is only supported by internal Standard load balancers.
+	// +optional
+	HAPorts *HAPorts `json:"haPorts,omitempty"`
+	// DiagnosticSettings sends the platform logs and metrics of the load balancer to a Log Analytics workspace or a
+	// storage account through an Azure Monitor diagnostic setting. The diagnostic setting is removed when unset.
+	// +optional
+	DiagnosticSettings *DiagnosticSettings `json:"diagnosticSettings,omitempty"`
+	// SSHNATRule replaces the inbound NAT rule created for each control plane machine with a single rule, which
+	// forwards a range of frontend ports to the SSH port of the control plane machines of the backend pool. Azure
+	// assigns a port of the range to each machine. It is only supported by public API server load balancers that
+	// aren't shared. The rules of the existing machines are kept until the machines are deleted.
+	// +optional
+	SSHNATRule *SSHNATRule `json:"sshNATRule,omitempty"`
+	// ConnectionDraining drains the connections of the API server load balancer to a control plane machine before
+	// the machine is deleted, e.g. when it is replaced during an upgrade. It is only supported by the API server load
+	// balancer.
+	// +optional
+	ConnectionDraining *ConnectionDraining `json:"connectionDraining,omitempty"`
+	// ForceRecreate allows CAPZ to delete the load balancer and the public IPs of its frontends when they exist with
+	// the Basic SKU, which Azure can't upgrade to the Standard SKU in place, so that they are recreated with the
+	// Standard SKU. The load balancer doesn't serve traffic until it is recreated, and its public IPs get new
+	// addresses. Without it, a load balancer or public IP of the Basic SKU is reported as an error and never
+	// modified. It is not supported by shared load balancers.
+	// +optional
+	ForceRecreate bool `json:"forceRecreate,omitempty"`
 }
 
 // SecurityGroupClass defines the SecurityGroup properties that may be shared across several Azure clusters.
 type SecurityGroupClass struct {
 	// +optional
 	SecurityRules SecurityRules `json:"securityRules,omitempty"`
+	// AllowInboundFrom is the inbound traffic the security group allows, from which CAPZ generates security rules
+	// along with the rules of SecurityRules and the rules CAPZ requires. The generated rules get the first
+	// priorities from IntentSecurityRulePriority not used by other inbound rules, in the order of the list, and are
+	// regenerated when the list changes.
+	// +optional
+	AllowInboundFrom []InboundTrafficIntent `json:"allowInboundFrom,omitempty"`
+	// EgressPolicy is the outbound traffic the security group allows. AllowAll, the default, leaves it to the rules of
+	// SecurityRules and to the default rules of Azure. DenyByDefault denies the outbound traffic other than the
+	// traffic the cluster requires, to the virtual network, to DNS servers and to the Azure services (AzureCloud), and
+	// the traffic of AllowOutboundTo. The generated rules get the first priorities from EgressSecurityRulePriority
+	// not used by other outbound rules, and the rule denying the rest of the traffic gets
+	// EgressDenySecurityRulePriority.
+	// +kubebuilder:validation:Enum=AllowAll;DenyByDefault
+	// +optional
+	EgressPolicy EgressPolicy `json:"egressPolicy,omitempty"`
+	// AllowOutboundTo is the outbound traffic a security group with the DenyByDefault egress policy allows on top of
+	// the traffic the cluster requires, in the order of the list.
+	// +optional
+	AllowOutboundTo []OutboundTrafficIntent `json:"allowOutboundTo,omitempty"`
+	// DiagnosticSettings is the Azure Monitor diagnostic setting exporting the logs of the security group, i.e. the
+	// NetworkSecurityGroupEvent logs of the rules applied and the NetworkSecurityGroupRuleCounter logs of the number
+	// of times each rule is hit. The diagnostic setting is removed when unset.
+	// +optional
+	DiagnosticSettings *DiagnosticSettings `json:"diagnosticSettings,omitempty"`
+	// Tags are added to the tags of the security group, e.g. the data classification or the compliance scope of its
+	// subnet that Azure Policy relies on. The other tags of the security group are kept.
 	// +optional
 	Tags Tags `json:"tags,omitempty"`
 }
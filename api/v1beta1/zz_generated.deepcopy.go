@@ -69,11 +69,91 @@ func (in *AllowedNamespaces) DeepCopy() *AllowedNamespaces {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationSecurityGroup) DeepCopyInto(out *ApplicationSecurityGroup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSecurityGroup.
+func (in *ApplicationSecurityGroup) DeepCopy() *ApplicationSecurityGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationSecurityGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoShutdownNotification) DeepCopyInto(out *AutoShutdownNotification) {
+	*out = *in
+	if in.MinutesBefore != nil {
+		in, out := &in.MinutesBefore, &out.MinutesBefore
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoShutdownNotification.
+func (in *AutoShutdownNotification) DeepCopy() *AutoShutdownNotification {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoShutdownNotification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoShutdownSchedule) DeepCopyInto(out *AutoShutdownSchedule) {
+	*out = *in
+	if in.Notification != nil {
+		in, out := &in.Notification, &out.Notification
+		*out = new(AutoShutdownNotification)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoShutdownSchedule.
+func (in *AutoShutdownSchedule) DeepCopy() *AutoShutdownSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoShutdownSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AvailabilitySet) DeepCopyInto(out *AvailabilitySet) {
+	*out = *in
+	if in.FaultDomainCount != nil {
+		in, out := &in.FaultDomainCount, &out.FaultDomainCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UpdateDomainCount != nil {
+		in, out := &in.UpdateDomainCount, &out.UpdateDomainCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AvailabilitySet.
+func (in *AvailabilitySet) DeepCopy() *AvailabilitySet {
+	if in == nil {
+		return nil
+	}
+	out := new(AvailabilitySet)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureBastion) DeepCopyInto(out *AzureBastion) {
 	*out = *in
 	in.Subnet.DeepCopyInto(&out.Subnet)
-	out.PublicIP = in.PublicIP
+	in.PublicIP.DeepCopyInto(&out.PublicIP)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureBastion.
@@ -286,6 +366,78 @@ func (in *AzureClusterSpec) DeepCopyInto(out *AzureClusterSpec) {
 	in.NetworkSpec.DeepCopyInto(&out.NetworkSpec)
 	in.BastionSpec.DeepCopyInto(&out.BastionSpec)
 	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
+	if in.DeleteGracePeriod != nil {
+		in, out := &in.DeleteGracePeriod, &out.DeleteGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LogAnalyticsWorkspace != nil {
+		in, out := &in.LogAnalyticsWorkspace, &out.LogAnalyticsWorkspace
+		*out = new(LogAnalyticsWorkspace)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiagnosticsResourceGroup != nil {
+		in, out := &in.DiagnosticsResourceGroup, &out.DiagnosticsResourceGroup
+		*out = new(DiagnosticsResourceGroup)
+		**out = **in
+	}
+	if in.NamingConvention != nil {
+		in, out := &in.NamingConvention, &out.NamingConvention
+		*out = new(NamingConvention)
+		**out = **in
+	}
+	if in.DefaultSpotPolicy != nil {
+		in, out := &in.DefaultSpotPolicy, &out.DefaultSpotPolicy
+		*out = new(SpotPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoShutdown != nil {
+		in, out := &in.AutoShutdown, &out.AutoShutdown
+		*out = new(AutoShutdownSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PolicyAssignments != nil {
+		in, out := &in.PolicyAssignments, &out.PolicyAssignments
+		*out = make([]PolicyAssignment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RoleAssignments != nil {
+		in, out := &in.RoleAssignments, &out.RoleAssignments
+		*out = make([]RoleAssignment, len(*in))
+		copy(*out, *in)
+	}
+	if in.Budget != nil {
+		in, out := &in.Budget, &out.Budget
+		*out = new(BudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gallery != nil {
+		in, out := &in.Gallery, &out.Gallery
+		*out = new(GalleryImage)
+		**out = **in
+	}
+	if in.ControlPlaneAvailabilitySet != nil {
+		in, out := &in.ControlPlaneAvailabilitySet, &out.ControlPlaneAvailabilitySet
+		*out = new(AvailabilitySet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControlPlaneEtcdDisks != nil {
+		in, out := &in.ControlPlaneEtcdDisks, &out.ControlPlaneEtcdDisks
+		*out = new(EtcdDisks)
+		**out = **in
+	}
+	if in.RequiredFeatures != nil {
+		in, out := &in.RequiredFeatures, &out.RequiredFeatures
+		*out = make([]ProviderFeature, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecondaryRegion != nil {
+		in, out := &in.SecondaryRegion, &out.SecondaryRegion
+		*out = new(SecondaryRegionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureClusterSpec.
@@ -320,6 +472,160 @@ func (in *AzureClusterStatus) DeepCopyInto(out *AzureClusterStatus) {
 		*out = make(Futures, len(*in))
 		copy(*out, *in)
 	}
+	if in.ControlPlaneEndpoints != nil {
+		in, out := &in.ControlPlaneEndpoints, &out.ControlPlaneEndpoints
+		*out = make([]apiv1beta1.APIEndpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.DNSServers != nil {
+		in, out := &in.DNSServers, &out.DNSServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeletionRequestedAt != nil {
+		in, out := &in.DeletionRequestedAt, &out.DeletionRequestedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ControlPlaneEgressIPs != nil {
+		in, out := &in.ControlPlaneEgressIPs, &out.ControlPlaneEgressIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PublicIPPrefixAllocations != nil {
+		in, out := &in.PublicIPPrefixAllocations, &out.PublicIPPrefixAllocations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NatGatewayIPPrefixes != nil {
+		in, out := &in.NatGatewayIPPrefixes, &out.NatGatewayIPPrefixes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PublicIPZones != nil {
+		in, out := &in.PublicIPZones, &out.PublicIPZones
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.SubnetAvailableIPs != nil {
+		in, out := &in.SubnetAvailableIPs, &out.SubnetAvailableIPs
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LogAnalyticsWorkspace != nil {
+		in, out := &in.LogAnalyticsWorkspace, &out.LogAnalyticsWorkspace
+		*out = new(LogAnalyticsWorkspaceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultSpotPolicy != nil {
+		in, out := &in.DefaultSpotPolicy, &out.DefaultSpotPolicy
+		*out = new(SpotPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoShutdown != nil {
+		in, out := &in.AutoShutdown, &out.AutoShutdown
+		*out = new(AutoShutdownSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GeneratedSecurityRules != nil {
+		in, out := &in.GeneratedSecurityRules, &out.GeneratedSecurityRules
+		*out = make(map[string]SecurityRules, len(*in))
+		for key, val := range *in {
+			var outVal []SecurityRule
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(SecurityRules, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.LoadBalancerTiers != nil {
+		in, out := &in.LoadBalancerTiers, &out.LoadBalancerTiers
+		*out = make(map[string]LoadBalancerTier, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.GlobalLoadBalancerBackends != nil {
+		in, out := &in.GlobalLoadBalancerBackends, &out.GlobalLoadBalancerBackends
+		*out = make(map[string]BackendHealthState, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NetworkInterfaceSecurityGroupIDs != nil {
+		in, out := &in.NetworkInterfaceSecurityGroupIDs, &out.NetworkInterfaceSecurityGroupIDs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PolicyAssignmentIDs != nil {
+		in, out := &in.PolicyAssignmentIDs, &out.PolicyAssignmentIDs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RoleAssignmentIDs != nil {
+		in, out := &in.RoleAssignmentIDs, &out.RoleAssignmentIDs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EstimatedCost != nil {
+		in, out := &in.EstimatedCost, &out.EstimatedCost
+		*out = new(CostEstimate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControlPlaneEtcdDiskZones != nil {
+		in, out := &in.ControlPlaneEtcdDiskZones, &out.ControlPlaneEtcdDiskZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrivateEndpointIPs != nil {
+		in, out := &in.PrivateEndpointIPs, &out.PrivateEndpointIPs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SecondaryNetwork != nil {
+		in, out := &in.SecondaryNetwork, &out.SecondaryNetwork
+		*out = new(SecondaryNetworkStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailureReason != nil {
+		in, out := &in.FailureReason, &out.FailureReason
+		*out = new(errors.ClusterStatusError)
+		**out = **in
+	}
+	if in.FailureMessage != nil {
+		in, out := &in.FailureMessage, &out.FailureMessage
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureClusterStatus.
@@ -491,6 +797,10 @@ func (in *AzureMachineStatus) DeepCopyInto(out *AzureMachineStatus) {
 		*out = make(Futures, len(*in))
 		copy(*out, *in)
 	}
+	if in.DrainStartedAt != nil {
+		in, out := &in.DrainStartedAt, &out.DrainStartedAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachineStatus.
@@ -672,6 +982,11 @@ func (in *BastionSpec) DeepCopyInto(out *BastionSpec) {
 		*out = new(AzureBastion)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Jumpbox != nil {
+		in, out := &in.Jumpbox, &out.Jumpbox
+		*out = new(Jumpbox)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BastionSpec.
@@ -684,6 +999,58 @@ func (in *BastionSpec) DeepCopy() *BastionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BudgetNotification) DeepCopyInto(out *BudgetNotification) {
+	*out = *in
+	if in.ContactEmails != nil {
+		in, out := &in.ContactEmails, &out.ContactEmails
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ContactRoles != nil {
+		in, out := &in.ContactRoles, &out.ContactRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ContactGroups != nil {
+		in, out := &in.ContactGroups, &out.ContactGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BudgetNotification.
+func (in *BudgetNotification) DeepCopy() *BudgetNotification {
+	if in == nil {
+		return nil
+	}
+	out := new(BudgetNotification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BudgetSpec) DeepCopyInto(out *BudgetSpec) {
+	*out = *in
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]BudgetNotification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BudgetSpec.
+func (in *BudgetSpec) DeepCopy() *BudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildParams) DeepCopyInto(out *BuildParams) {
 	*out = *in
@@ -740,107 +1107,238 @@ func (in *CloudProviderConfigOverrides) DeepCopy() *CloudProviderConfigOverrides
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataDisk) DeepCopyInto(out *DataDisk) {
+func (in *ConnectionDraining) DeepCopyInto(out *ConnectionDraining) {
 	*out = *in
-	if in.ManagedDisk != nil {
-		in, out := &in.ManagedDisk, &out.ManagedDisk
-		*out = new(ManagedDiskParameters)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Lun != nil {
-		in, out := &in.Lun, &out.Lun
-		*out = new(int32)
+	out.Timeout = in.Timeout
+	if in.EnableTCPReset != nil {
+		in, out := &in.EnableTCPReset, &out.EnableTCPReset
+		*out = new(bool)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataDisk.
-func (in *DataDisk) DeepCopy() *DataDisk {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionDraining.
+func (in *ConnectionDraining) DeepCopy() *ConnectionDraining {
 	if in == nil {
 		return nil
 	}
-	out := new(DataDisk)
+	out := new(ConnectionDraining)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DiffDiskSettings) DeepCopyInto(out *DiffDiskSettings) {
+func (in *CostEstimate) DeepCopyInto(out *CostEstimate) {
 	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceCostEstimate, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiffDiskSettings.
-func (in *DiffDiskSettings) DeepCopy() *DiffDiskSettings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CostEstimate.
+func (in *CostEstimate) DeepCopy() *CostEstimate {
 	if in == nil {
 		return nil
 	}
-	out := new(DiffDiskSettings)
+	out := new(CostEstimate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DiskEncryptionSetParameters) DeepCopyInto(out *DiskEncryptionSetParameters) {
+func (in *DNSPrivateResolverSpec) DeepCopyInto(out *DNSPrivateResolverSpec) {
 	*out = *in
+	if in.ForwardingRulesetIDs != nil {
+		in, out := &in.ForwardingRulesetIDs, &out.ForwardingRulesetIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskEncryptionSetParameters.
-func (in *DiskEncryptionSetParameters) DeepCopy() *DiskEncryptionSetParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSPrivateResolverSpec.
+func (in *DNSPrivateResolverSpec) DeepCopy() *DNSPrivateResolverSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DiskEncryptionSetParameters)
+	out := new(DNSPrivateResolverSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FrontendIP) DeepCopyInto(out *FrontendIP) {
+func (in *DataDisk) DeepCopyInto(out *DataDisk) {
 	*out = *in
-	if in.PublicIP != nil {
-		in, out := &in.PublicIP, &out.PublicIP
-		*out = new(PublicIPSpec)
+	if in.ManagedDisk != nil {
+		in, out := &in.ManagedDisk, &out.ManagedDisk
+		*out = new(ManagedDiskParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Lun != nil {
+		in, out := &in.Lun, &out.Lun
+		*out = new(int32)
 		**out = **in
 	}
-	out.FrontendIPClass = in.FrontendIPClass
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendIP.
-func (in *FrontendIP) DeepCopy() *FrontendIP {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataDisk.
+func (in *DataDisk) DeepCopy() *DataDisk {
 	if in == nil {
 		return nil
 	}
-	out := new(FrontendIP)
+	out := new(DataDisk)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FrontendIPClass) DeepCopyInto(out *FrontendIPClass) {
+func (in *DiagnosticSettings) DeepCopyInto(out *DiagnosticSettings) {
 	*out = *in
+	if in.LogCategories != nil {
+		in, out := &in.LogCategories, &out.LogCategories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MetricCategories != nil {
+		in, out := &in.MetricCategories, &out.MetricCategories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendIPClass.
-func (in *FrontendIPClass) DeepCopy() *FrontendIPClass {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiagnosticSettings.
+func (in *DiagnosticSettings) DeepCopy() *DiagnosticSettings {
 	if in == nil {
 		return nil
 	}
-	out := new(FrontendIPClass)
+	out := new(DiagnosticSettings)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Future) DeepCopyInto(out *Future) {
+func (in *DiagnosticsResourceGroup) DeepCopyInto(out *DiagnosticsResourceGroup) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Future.
-func (in *Future) DeepCopy() *Future {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiagnosticsResourceGroup.
+func (in *DiagnosticsResourceGroup) DeepCopy() *DiagnosticsResourceGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(Future)
+	out := new(DiagnosticsResourceGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiffDiskSettings) DeepCopyInto(out *DiffDiskSettings) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiffDiskSettings.
+func (in *DiffDiskSettings) DeepCopy() *DiffDiskSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(DiffDiskSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskEncryptionSetParameters) DeepCopyInto(out *DiskEncryptionSetParameters) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskEncryptionSetParameters.
+func (in *DiskEncryptionSetParameters) DeepCopy() *DiskEncryptionSetParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskEncryptionSetParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdDisks) DeepCopyInto(out *EtcdDisks) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdDisks.
+func (in *EtcdDisks) DeepCopy() *EtcdDisks {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdDisks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallRoute) DeepCopyInto(out *FirewallRoute) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallRoute.
+func (in *FirewallRoute) DeepCopy() *FirewallRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrontendIP) DeepCopyInto(out *FrontendIP) {
+	*out = *in
+	if in.PublicIP != nil {
+		in, out := &in.PublicIP, &out.PublicIP
+		*out = new(PublicIPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	out.FrontendIPClass = in.FrontendIPClass
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendIP.
+func (in *FrontendIP) DeepCopy() *FrontendIP {
+	if in == nil {
+		return nil
+	}
+	out := new(FrontendIP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrontendIPClass) DeepCopyInto(out *FrontendIPClass) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendIPClass.
+func (in *FrontendIPClass) DeepCopy() *FrontendIPClass {
+	if in == nil {
+		return nil
+	}
+	out := new(FrontendIPClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Future) DeepCopyInto(out *Future) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Future.
+func (in *Future) DeepCopy() *Future {
+	if in == nil {
+		return nil
+	}
+	out := new(Future)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -864,6 +1362,91 @@ func (in Futures) DeepCopy() Futures {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GalleryImage) DeepCopyInto(out *GalleryImage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GalleryImage.
+func (in *GalleryImage) DeepCopy() *GalleryImage {
+	if in == nil {
+		return nil
+	}
+	out := new(GalleryImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalLoadBalancerSpec) DeepCopyInto(out *GlobalLoadBalancerSpec) {
+	*out = *in
+	if in.PublicIP != nil {
+		in, out := &in.PublicIP, &out.PublicIP
+		*out = new(PublicIPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalBackends != nil {
+		in, out := &in.AdditionalBackends, &out.AdditionalBackends
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalLoadBalancerSpec.
+func (in *GlobalLoadBalancerSpec) DeepCopy() *GlobalLoadBalancerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalLoadBalancerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HAPorts) DeepCopyInto(out *HAPorts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HAPorts.
+func (in *HAPorts) DeepCopy() *HAPorts {
+	if in == nil {
+		return nil
+	}
+	out := new(HAPorts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthProbe) DeepCopyInto(out *HealthProbe) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthProbe.
+func (in *HealthProbe) DeepCopy() *HealthProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPTag) DeepCopyInto(out *IPTag) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPTag.
+func (in *IPTag) DeepCopy() *IPTag {
+	if in == nil {
+		return nil
+	}
+	out := new(IPTag)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Image) DeepCopyInto(out *Image) {
 	*out = *in
@@ -889,171 +1472,631 @@ func (in *Image) DeepCopy() *Image {
 	if in == nil {
 		return nil
 	}
-	out := new(Image)
+	out := new(Image)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InboundTrafficIntent) DeepCopyInto(out *InboundTrafficIntent) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InboundTrafficIntent.
+func (in *InboundTrafficIntent) DeepCopy() *InboundTrafficIntent {
+	if in == nil {
+		return nil
+	}
+	out := new(InboundTrafficIntent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressSpec) DeepCopyInto(out *IngressSpec) {
+	*out = *in
+	in.Subnet.DeepCopyInto(&out.Subnet)
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedSourceCIDRs != nil {
+		in, out := &in.AllowedSourceCIDRs, &out.AllowedSourceCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PublicIP != nil {
+		in, out := &in.PublicIP, &out.PublicIP
+		*out = new(PublicIPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressSpec.
+func (in *IngressSpec) DeepCopy() *IngressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalLoadBalancerProbe) DeepCopyInto(out *InternalLoadBalancerProbe) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalLoadBalancerProbe.
+func (in *InternalLoadBalancerProbe) DeepCopy() *InternalLoadBalancerProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalLoadBalancerProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalLoadBalancerRule) DeepCopyInto(out *InternalLoadBalancerRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalLoadBalancerRule.
+func (in *InternalLoadBalancerRule) DeepCopy() *InternalLoadBalancerRule {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalLoadBalancerRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalLoadBalancerSpec) DeepCopyInto(out *InternalLoadBalancerSpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]InternalLoadBalancerRule, len(*in))
+		copy(*out, *in)
+	}
+	out.Probe = in.Probe
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]InternalLoadBalancerProbe, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalLoadBalancerSpec.
+func (in *InternalLoadBalancerSpec) DeepCopy() *InternalLoadBalancerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalLoadBalancerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntraClusterTraffic) DeepCopyInto(out *IntraClusterTraffic) {
+	*out = *in
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntraClusterTraffic.
+func (in *IntraClusterTraffic) DeepCopy() *IntraClusterTraffic {
+	if in == nil {
+		return nil
+	}
+	out := new(IntraClusterTraffic)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Jumpbox) DeepCopyInto(out *Jumpbox) {
+	*out = *in
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(Image)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedSourceCIDRs != nil {
+		in, out := &in.AllowedSourceCIDRs, &out.AllowedSourceCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Subnet.DeepCopyInto(&out.Subnet)
+	in.PublicIP.DeepCopyInto(&out.PublicIP)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Jumpbox.
+func (in *Jumpbox) DeepCopy() *Jumpbox {
+	if in == nil {
+		return nil
+	}
+	out := new(Jumpbox)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerClassSpec) DeepCopyInto(out *LoadBalancerClassSpec) {
+	*out = *in
+	if in.FrontendIPs != nil {
+		in, out := &in.FrontendIPs, &out.FrontendIPs
+		*out = make([]FrontendIP, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InternalFrontendIP != nil {
+		in, out := &in.InternalFrontendIP, &out.InternalFrontendIP
+		*out = new(FrontendIP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FrontendIPsCount != nil {
+		in, out := &in.FrontendIPsCount, &out.FrontendIPsCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IdleTimeoutInMinutes != nil {
+		in, out := &in.IdleTimeoutInMinutes, &out.IdleTimeoutInMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HealthProbe != nil {
+		in, out := &in.HealthProbe, &out.HealthProbe
+		*out = new(HealthProbe)
+		**out = **in
+	}
+	if in.HAPorts != nil {
+		in, out := &in.HAPorts, &out.HAPorts
+		*out = new(HAPorts)
+		**out = **in
+	}
+	if in.DiagnosticSettings != nil {
+		in, out := &in.DiagnosticSettings, &out.DiagnosticSettings
+		*out = new(DiagnosticSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SSHNATRule != nil {
+		in, out := &in.SSHNATRule, &out.SSHNATRule
+		*out = new(SSHNATRule)
+		**out = **in
+	}
+	if in.ConnectionDraining != nil {
+		in, out := &in.ConnectionDraining, &out.ConnectionDraining
+		*out = new(ConnectionDraining)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerClassSpec.
+func (in *LoadBalancerClassSpec) DeepCopy() *LoadBalancerClassSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerClassSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
+	*out = *in
+	if in.Shared != nil {
+		in, out := &in.Shared, &out.Shared
+		*out = new(SharedLoadBalancer)
+		**out = **in
+	}
+	in.LoadBalancerClassSpec.DeepCopyInto(&out.LoadBalancerClassSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerSpec.
+func (in *LoadBalancerSpec) DeepCopy() *LoadBalancerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogAnalyticsWorkspace) DeepCopyInto(out *LogAnalyticsWorkspace) {
+	*out = *in
+	if in.RetentionInDays != nil {
+		in, out := &in.RetentionInDays, &out.RetentionInDays
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogAnalyticsWorkspace.
+func (in *LogAnalyticsWorkspace) DeepCopy() *LogAnalyticsWorkspace {
+	if in == nil {
+		return nil
+	}
+	out := new(LogAnalyticsWorkspace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogAnalyticsWorkspaceStatus) DeepCopyInto(out *LogAnalyticsWorkspaceStatus) {
+	*out = *in
+	if in.SharedKeySecretRef != nil {
+		in, out := &in.SharedKeySecretRef, &out.SharedKeySecretRef
+		*out = new(corev1.SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogAnalyticsWorkspaceStatus.
+func (in *LogAnalyticsWorkspaceStatus) DeepCopy() *LogAnalyticsWorkspaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LogAnalyticsWorkspaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedDiskParameters) DeepCopyInto(out *ManagedDiskParameters) {
+	*out = *in
+	if in.DiskEncryptionSet != nil {
+		in, out := &in.DiskEncryptionSet, &out.DiskEncryptionSet
+		*out = new(DiskEncryptionSetParameters)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedDiskParameters.
+func (in *ManagedDiskParameters) DeepCopy() *ManagedDiskParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedDiskParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamingConvention) DeepCopyInto(out *NamingConvention) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamingConvention.
+func (in *NamingConvention) DeepCopy() *NamingConvention {
+	if in == nil {
+		return nil
+	}
+	out := new(NamingConvention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NatGateway) DeepCopyInto(out *NatGateway) {
+	*out = *in
+	in.NatGatewayIP.DeepCopyInto(&out.NatGatewayIP)
+	if in.NatGatewayIPCount != nil {
+		in, out := &in.NatGatewayIPCount, &out.NatGatewayIPCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IdleTimeoutInMinutes != nil {
+		in, out := &in.IdleTimeoutInMinutes, &out.IdleTimeoutInMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NatGatewayIPPrefix != nil {
+		in, out := &in.NatGatewayIPPrefix, &out.NatGatewayIPPrefix
+		*out = new(PublicIPPrefixSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatGateway.
+func (in *NatGateway) DeepCopy() *NatGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(NatGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkClassSpec) DeepCopyInto(out *NetworkClassSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkClassSpec.
+func (in *NetworkClassSpec) DeepCopy() *NetworkClassSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkClassSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkInterfaceSecurityGroups) DeepCopyInto(out *NetworkInterfaceSecurityGroups) {
+	*out = *in
+	in.ControlPlane.DeepCopyInto(&out.ControlPlane)
+	in.Node.DeepCopyInto(&out.Node)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterfaceSecurityGroups.
+func (in *NetworkInterfaceSecurityGroups) DeepCopy() *NetworkInterfaceSecurityGroups {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkInterfaceSecurityGroups)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
+	*out = *in
+	in.Vnet.DeepCopyInto(&out.Vnet)
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make(Subnets, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.APIServerLB.DeepCopyInto(&out.APIServerLB)
+	if in.NodeOutboundLB != nil {
+		in, out := &in.NodeOutboundLB, &out.NodeOutboundLB
+		*out = new(LoadBalancerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControlPlaneOutboundLB != nil {
+		in, out := &in.ControlPlaneOutboundLB, &out.ControlPlaneOutboundLB
+		*out = new(LoadBalancerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrafficManager != nil {
+		in, out := &in.TrafficManager, &out.TrafficManager
+		*out = new(TrafficManagerSpec)
+		**out = **in
+	}
+	if in.GlobalLB != nil {
+		in, out := &in.GlobalLB, &out.GlobalLB
+		*out = new(GlobalLoadBalancerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNSPrivateResolver != nil {
+		in, out := &in.DNSPrivateResolver, &out.DNSPrivateResolver
+		*out = new(DNSPrivateResolverSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApplicationSecurityGroups != nil {
+		in, out := &in.ApplicationSecurityGroups, &out.ApplicationSecurityGroups
+		*out = make([]ApplicationSecurityGroup, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkInterfaceSecurityGroups != nil {
+		in, out := &in.NetworkInterfaceSecurityGroups, &out.NetworkInterfaceSecurityGroups
+		*out = new(NetworkInterfaceSecurityGroups)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IntraClusterTraffic != nil {
+		in, out := &in.IntraClusterTraffic, &out.IntraClusterTraffic
+		*out = new(IntraClusterTraffic)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OutboundConnectivityCheck != nil {
+		in, out := &in.OutboundConnectivityCheck, &out.OutboundConnectivityCheck
+		*out = new(OutboundConnectivityCheck)
+		**out = **in
+	}
+	if in.PrivateEndpoints != nil {
+		in, out := &in.PrivateEndpoints, &out.PrivateEndpoints
+		*out = make([]PrivateEndpointSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(IngressSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSubnet != nil {
+		in, out := &in.PodSubnet, &out.PodSubnet
+		*out = new(PodSubnetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InternalLoadBalancers != nil {
+		in, out := &in.InternalLoadBalancers, &out.InternalLoadBalancers
+		*out = make([]InternalLoadBalancerSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.NetworkClassSpec = in.NetworkClassSpec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
+func (in *NetworkSpec) DeepCopy() *NetworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSDisk) DeepCopyInto(out *OSDisk) {
+	*out = *in
+	if in.DiskSizeGB != nil {
+		in, out := &in.DiskSizeGB, &out.DiskSizeGB
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ManagedDisk != nil {
+		in, out := &in.ManagedDisk, &out.ManagedDisk
+		*out = new(ManagedDiskParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiffDiskSettings != nil {
+		in, out := &in.DiffDiskSettings, &out.DiffDiskSettings
+		*out = new(DiffDiskSettings)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDisk.
+func (in *OSDisk) DeepCopy() *OSDisk {
+	if in == nil {
+		return nil
+	}
+	out := new(OSDisk)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LoadBalancerClassSpec) DeepCopyInto(out *LoadBalancerClassSpec) {
+func (in *OutboundConnectivityCheck) DeepCopyInto(out *OutboundConnectivityCheck) {
 	*out = *in
-	if in.FrontendIPs != nil {
-		in, out := &in.FrontendIPs, &out.FrontendIPs
-		*out = make([]FrontendIP, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.FrontendIPsCount != nil {
-		in, out := &in.FrontendIPsCount, &out.FrontendIPsCount
-		*out = new(int32)
-		**out = **in
-	}
-	if in.IdleTimeoutInMinutes != nil {
-		in, out := &in.IdleTimeoutInMinutes, &out.IdleTimeoutInMinutes
-		*out = new(int32)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerClassSpec.
-func (in *LoadBalancerClassSpec) DeepCopy() *LoadBalancerClassSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutboundConnectivityCheck.
+func (in *OutboundConnectivityCheck) DeepCopy() *OutboundConnectivityCheck {
 	if in == nil {
 		return nil
 	}
-	out := new(LoadBalancerClassSpec)
+	out := new(OutboundConnectivityCheck)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
+func (in *OutboundTrafficIntent) DeepCopyInto(out *OutboundTrafficIntent) {
 	*out = *in
-	in.LoadBalancerClassSpec.DeepCopyInto(&out.LoadBalancerClassSpec)
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerSpec.
-func (in *LoadBalancerSpec) DeepCopy() *LoadBalancerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutboundTrafficIntent.
+func (in *OutboundTrafficIntent) DeepCopy() *OutboundTrafficIntent {
 	if in == nil {
 		return nil
 	}
-	out := new(LoadBalancerSpec)
+	out := new(OutboundTrafficIntent)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagedDiskParameters) DeepCopyInto(out *ManagedDiskParameters) {
+func (in *PodSubnetSpec) DeepCopyInto(out *PodSubnetSpec) {
 	*out = *in
-	if in.DiskEncryptionSet != nil {
-		in, out := &in.DiskEncryptionSet, &out.DiskEncryptionSet
-		*out = new(DiskEncryptionSetParameters)
-		**out = **in
+	if in.CIDRBlocks != nil {
+		in, out := &in.CIDRBlocks, &out.CIDRBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedDiskParameters.
-func (in *ManagedDiskParameters) DeepCopy() *ManagedDiskParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSubnetSpec.
+func (in *PodSubnetSpec) DeepCopy() *PodSubnetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagedDiskParameters)
+	out := new(PodSubnetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NatGateway) DeepCopyInto(out *NatGateway) {
+func (in *PolicyAssignment) DeepCopyInto(out *PolicyAssignment) {
 	*out = *in
-	out.NatGatewayIP = in.NatGatewayIP
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatGateway.
-func (in *NatGateway) DeepCopy() *NatGateway {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyAssignment.
+func (in *PolicyAssignment) DeepCopy() *PolicyAssignment {
 	if in == nil {
 		return nil
 	}
-	out := new(NatGateway)
+	out := new(PolicyAssignment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkClassSpec) DeepCopyInto(out *NetworkClassSpec) {
+func (in *PrivateEndpointSpec) DeepCopyInto(out *PrivateEndpointSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkClassSpec.
-func (in *NetworkClassSpec) DeepCopy() *NetworkClassSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateEndpointSpec.
+func (in *PrivateEndpointSpec) DeepCopy() *PrivateEndpointSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkClassSpec)
+	out := new(PrivateEndpointSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
+func (in *ProviderFeature) DeepCopyInto(out *ProviderFeature) {
 	*out = *in
-	in.Vnet.DeepCopyInto(&out.Vnet)
-	if in.Subnets != nil {
-		in, out := &in.Subnets, &out.Subnets
-		*out = make(Subnets, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	in.APIServerLB.DeepCopyInto(&out.APIServerLB)
-	if in.NodeOutboundLB != nil {
-		in, out := &in.NodeOutboundLB, &out.NodeOutboundLB
-		*out = new(LoadBalancerSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ControlPlaneOutboundLB != nil {
-		in, out := &in.ControlPlaneOutboundLB, &out.ControlPlaneOutboundLB
-		*out = new(LoadBalancerSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	out.NetworkClassSpec = in.NetworkClassSpec
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
-func (in *NetworkSpec) DeepCopy() *NetworkSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderFeature.
+func (in *ProviderFeature) DeepCopy() *ProviderFeature {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkSpec)
+	out := new(ProviderFeature)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OSDisk) DeepCopyInto(out *OSDisk) {
+func (in *PublicIPPrefixSpec) DeepCopyInto(out *PublicIPPrefixSpec) {
 	*out = *in
-	if in.DiskSizeGB != nil {
-		in, out := &in.DiskSizeGB, &out.DiskSizeGB
+	if in.PrefixLength != nil {
+		in, out := &in.PrefixLength, &out.PrefixLength
 		*out = new(int32)
 		**out = **in
 	}
-	if in.ManagedDisk != nil {
-		in, out := &in.ManagedDisk, &out.ManagedDisk
-		*out = new(ManagedDiskParameters)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.DiffDiskSettings != nil {
-		in, out := &in.DiffDiskSettings, &out.DiffDiskSettings
-		*out = new(DiffDiskSettings)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDisk.
-func (in *OSDisk) DeepCopy() *OSDisk {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIPPrefixSpec.
+func (in *PublicIPPrefixSpec) DeepCopy() *PublicIPPrefixSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OSDisk)
+	out := new(PublicIPPrefixSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1061,6 +2104,21 @@ func (in *OSDisk) DeepCopy() *OSDisk {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PublicIPSpec) DeepCopyInto(out *PublicIPSpec) {
 	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPTags != nil {
+		in, out := &in.IPTags, &out.IPTags
+		*out = make([]IPTag, len(*in))
+		copy(*out, *in)
+	}
+	if in.DiagnosticSettings != nil {
+		in, out := &in.DiagnosticSettings, &out.DiagnosticSettings
+		*out = new(DiagnosticSettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIPSpec.
@@ -1114,6 +2172,36 @@ func (in *RateLimitSpec) DeepCopy() *RateLimitSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceCostEstimate) DeepCopyInto(out *ResourceCostEstimate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceCostEstimate.
+func (in *ResourceCostEstimate) DeepCopy() *ResourceCostEstimate {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceCostEstimate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleAssignment) DeepCopyInto(out *RoleAssignment) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleAssignment.
+func (in *RoleAssignment) DeepCopy() *RoleAssignment {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleAssignment)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteTable) DeepCopyInto(out *RouteTable) {
 	*out = *in
@@ -1129,6 +2217,82 @@ func (in *RouteTable) DeepCopy() *RouteTable {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHNATRule) DeepCopyInto(out *SSHNATRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHNATRule.
+func (in *SSHNATRule) DeepCopy() *SSHNATRule {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHNATRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecondaryNetworkStatus) DeepCopyInto(out *SecondaryNetworkStatus) {
+	*out = *in
+	if in.SubnetIDs != nil {
+		in, out := &in.SubnetIDs, &out.SubnetIDs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecondaryNetworkStatus.
+func (in *SecondaryNetworkStatus) DeepCopy() *SecondaryNetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecondaryNetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecondaryRegionSpec) DeepCopyInto(out *SecondaryRegionSpec) {
+	*out = *in
+	in.Vnet.DeepCopyInto(&out.Vnet)
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]SecondarySubnetSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecondaryRegionSpec.
+func (in *SecondaryRegionSpec) DeepCopy() *SecondaryRegionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecondaryRegionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecondarySubnetSpec) DeepCopyInto(out *SecondarySubnetSpec) {
+	*out = *in
+	in.SubnetClassSpec.DeepCopyInto(&out.SubnetClassSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecondarySubnetSpec.
+func (in *SecondarySubnetSpec) DeepCopy() *SecondarySubnetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecondarySubnetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityGroup) DeepCopyInto(out *SecurityGroup) {
 	*out = *in
@@ -1155,6 +2319,25 @@ func (in *SecurityGroupClass) DeepCopyInto(out *SecurityGroupClass) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AllowInboundFrom != nil {
+		in, out := &in.AllowInboundFrom, &out.AllowInboundFrom
+		*out = make([]InboundTrafficIntent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AllowOutboundTo != nil {
+		in, out := &in.AllowOutboundTo, &out.AllowOutboundTo
+		*out = make([]OutboundTrafficIntent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DiagnosticSettings != nil {
+		in, out := &in.DiagnosticSettings, &out.DiagnosticSettings
+		*out = new(DiagnosticSettings)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make(Tags, len(*in))
@@ -1217,6 +2400,16 @@ func (in *SecurityRule) DeepCopyInto(out *SecurityRule) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.SourceApplicationSecurityGroups != nil {
+		in, out := &in.SourceApplicationSecurityGroups, &out.SourceApplicationSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DestinationApplicationSecurityGroups != nil {
+		in, out := &in.DestinationApplicationSecurityGroups, &out.DestinationApplicationSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityRule.
@@ -1250,6 +2443,41 @@ func (in SecurityRules) DeepCopy() SecurityRules {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SharedLoadBalancer) DeepCopyInto(out *SharedLoadBalancer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedLoadBalancer.
+func (in *SharedLoadBalancer) DeepCopy() *SharedLoadBalancer {
+	if in == nil {
+		return nil
+	}
+	out := new(SharedLoadBalancer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpotPolicy) DeepCopyInto(out *SpotPolicy) {
+	*out = *in
+	if in.MaxPrice != nil {
+		in, out := &in.MaxPrice, &out.MaxPrice
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpotPolicy.
+func (in *SpotPolicy) DeepCopy() *SpotPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SpotPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpotVMOptions) DeepCopyInto(out *SpotVMOptions) {
 	*out = *in
@@ -1295,7 +2523,17 @@ func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 	*out = *in
 	in.SecurityGroup.DeepCopyInto(&out.SecurityGroup)
 	out.RouteTable = in.RouteTable
-	out.NatGateway = in.NatGateway
+	in.NatGateway.DeepCopyInto(&out.NatGateway)
+	if in.FreeIPsThreshold != nil {
+		in, out := &in.FreeIPsThreshold, &out.FreeIPsThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FirewallRoute != nil {
+		in, out := &in.FirewallRoute, &out.FirewallRoute
+		*out = new(FirewallRoute)
+		**out = **in
+	}
 	in.SubnetClassSpec.DeepCopyInto(&out.SubnetClassSpec)
 }
 
@@ -1351,6 +2589,21 @@ func (in Tags) DeepCopy() Tags {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficManagerSpec) DeepCopyInto(out *TrafficManagerSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficManagerSpec.
+func (in *TrafficManagerSpec) DeepCopy() *TrafficManagerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficManagerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserAssignedIdentity) DeepCopyInto(out *UserAssignedIdentity) {
 	*out = *in
@@ -1374,6 +2627,11 @@ func (in *VnetClassSpec) DeepCopyInto(out *VnetClassSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DNSServers != nil {
+		in, out := &in.DNSServers, &out.DNSServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make(Tags, len(*in))
@@ -1396,6 +2654,11 @@ func (in *VnetClassSpec) DeepCopy() *VnetClassSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VnetPeeringSpec) DeepCopyInto(out *VnetPeeringSpec) {
 	*out = *in
+	if in.IdentityRef != nil {
+		in, out := &in.IdentityRef, &out.IdentityRef
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VnetPeeringSpec.
@@ -1413,7 +2676,9 @@ func (in VnetPeerings) DeepCopyInto(out *VnetPeerings) {
 	{
 		in := &in
 		*out = make(VnetPeerings, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -1433,7 +2698,9 @@ func (in *VnetSpec) DeepCopyInto(out *VnetSpec) {
 	if in.Peerings != nil {
 		in, out := &in.Peerings, &out.Peerings
 		*out = make(VnetPeerings, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	in.VnetClassSpec.DeepCopyInto(&out.VnetClassSpec)
 }
@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DriftType describes how the live configuration of an Azure resource differs from the desired one.
+type DriftType string
+
+const (
+	// DriftMissing means the resource is part of the spec but doesn't exist in Azure.
+	DriftMissing DriftType = "Missing"
+	// DriftModified means the resource exists in Azure but its configuration differs from the spec.
+	DriftModified DriftType = "Modified"
+)
+
+// Drift is a single difference between the live Azure configuration and the desired spec.
+type Drift struct {
+	Type         DriftType
+	ResourceType string
+	Name         string
+	Detail       string
+}
+
+// String returns a human readable description of the drift.
+func (d Drift) String() string {
+	if d.Detail == "" {
+		return fmt.Sprintf("%s %s %s", d.Type, d.ResourceType, d.Name)
+	}
+	return fmt.Sprintf("%s %s %s: %s", d.Type, d.ResourceType, d.Name, d.Detail)
+}
+
+// NetworkAuditReport is the result of comparing the live network configuration of a cluster against its spec.
+type NetworkAuditReport struct {
+	Drifts []Drift
+}
+
+// HasDrift returns true if the live network configuration differs from the spec.
+func (r *NetworkAuditReport) HasDrift() bool {
+	return len(r.Drifts) > 0
+}
+
+// String returns the drifts of the report, one per line.
+func (r *NetworkAuditReport) String() string {
+	if !r.HasDrift() {
+		return "no drift detected"
+	}
+	lines := make([]string, len(r.Drifts))
+	for i, drift := range r.Drifts {
+		lines[i] = drift.String()
+	}
+	return strings.Join(lines, "\n")
+}
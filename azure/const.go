@@ -16,6 +16,8 @@ limitations under the License.
 
 package azure
 
+import infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+
 const (
 	// VMTagsLastAppliedAnnotation is the key for the machine object annotation
 	// which tracks the AdditionalTags in the Machine Provider Config.
@@ -28,4 +30,46 @@ const (
 	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
 	// for annotation formatting rules.
 	RGTagsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-tags-rg"
+
+	// InheritedTagsLastAppliedAnnotation is the key for the Azure Cluster object annotation
+	// which tracks the tags the resources of the cluster last inherited from the Resource Group.
+	InheritedTagsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-tags-inherited"
+
+	// SubnetsLastAppliedAnnotation is the key for the Azure Cluster object annotation
+	// which tracks the subnets last reconciled in a managed virtual network, so that the ones removed from the spec
+	// can be deleted.
+	SubnetsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-subnets"
+
+	// SecondarySubnetsLastAppliedAnnotation is the key for the Azure Cluster object annotation
+	// which tracks the subnets last reconciled in the virtual network of the secondary region of the cluster.
+	SecondarySubnetsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-secondary-subnets"
+
+	// SecondaryRegionLastAppliedAnnotation is the key for the Azure Cluster object annotation
+	// which tracks the secondary region last reconciled, so that its network can be deleted once the secondary region
+	// is removed from the spec.
+	SecondaryRegionLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-secondary-region"
+
+	// InternalLoadBalancersLastAppliedAnnotation is the key for the Azure Cluster object annotation
+	// which tracks the internal load balancers fronting services of the cluster last reconciled, so that the ones
+	// removed from the spec can be deleted.
+	InternalLoadBalancersLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-internal-lbs"
+
+	// DeleteProtectionAnnotation is the key of the Cluster object annotation which, when present, prevents the deletion
+	// of the Azure resources of the cluster until it is removed.
+	DeleteProtectionAnnotation = infrav1.DeleteProtectionAnnotation
+
+	// PreviousClusterUIDAnnotation is the key of the Cluster object annotation holding the UID the cluster had before
+	// it was moved to another management cluster or restored from a backup, so that the load balancers tagged with it
+	// are adopted by the cluster rather than reported as belonging to another cluster.
+	PreviousClusterUIDAnnotation = "azure.cluster.x-k8s.io/previous-cluster-uid"
+
+	// EnvironmentTagKey is the key of the tag identifying the environment (e.g. dev or prod) an Azure resource belongs to.
+	EnvironmentTagKey = "environment"
+
+	// CostCenterTagKey is the key of the tag identifying the cost center an Azure resource is billed to.
+	CostCenterTagKey = "costCenter"
+
+	// AutoShutdownTagKey is the key of the tag carrying the auto-shutdown schedule of the virtual machines of a
+	// cluster, as the time and the time zone of the shutdown, e.g. "19:30 Europe/Paris".
+	AutoShutdownTagKey = "autoShutdown"
 )
@@ -48,6 +48,10 @@ func SecurityRuleToSDK(rule infrav1.SecurityRule) network.SecurityRule {
 		secRule.Protocol = network.SecurityRuleProtocolIcmp
 	}
 
+	if rule.Action == infrav1.SecurityRuleActionDeny {
+		secRule.Access = network.SecurityRuleAccessDeny
+	}
+
 	switch rule.Direction {
 	case infrav1.SecurityRuleDirectionOutbound:
 		secRule.Direction = network.SecurityRuleDirectionOutbound
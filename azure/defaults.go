@@ -62,6 +62,29 @@ const (
 	PrivateAPIServerHostname = "apiserver"
 )
 
+const (
+	// DefaultTrafficManagerDNSSuffix is the Traffic Manager DNS suffix of the Azure public cloud.
+	DefaultTrafficManagerDNSSuffix = "trafficmanager.net"
+)
+
+const (
+	// AzureLoadBalancerServiceTag is the service tag of the source of the health probes of Azure load balancers.
+	AzureLoadBalancerServiceTag = "AzureLoadBalancer"
+	// LoadBalancerProbeSecurityRuleName is the name of the security rule allowing the load balancer health probes.
+	LoadBalancerProbeSecurityRuleName = "allow_lb_health_probes"
+	// LoadBalancerProbeSecurityRulePriority is the default priority of the security rule allowing the load balancer
+	// health probes, after the default control plane rules.
+	LoadBalancerProbeSecurityRulePriority = 2202
+	// FirewallRouteName is the name of the default route of a subnet whose egress is routed to a firewall.
+	FirewallRouteName = "default_to_firewall"
+	// FirewallSecurityRuleName is the name of the security rule allowing the traffic coming back from the firewall a
+	// subnet routes its egress to.
+	FirewallSecurityRuleName = "allow_firewall_inbound"
+	// FirewallSecurityRulePriority is the default priority of the security rule allowing the traffic coming back from
+	// the firewall.
+	FirewallSecurityRulePriority = 2203
+)
+
 const (
 	// ControlPlaneNodeGroup will be used to create availability set for control plane machines.
 	ControlPlaneNodeGroup = "control-plane"
@@ -115,31 +138,11 @@ func GenerateFrontendIPConfigName(lbName string) string {
 	return fmt.Sprintf("%s-%s", lbName, "frontEnd")
 }
 
-// GenerateNatGatewayIPName generates a NAT gateway IP name.
-func GenerateNatGatewayIPName(clusterName, subnetName string) string {
-	return fmt.Sprintf("pip-%s-%s-natgw", clusterName, subnetName)
-}
-
-// GenerateNodeOutboundIPName generates a public IP name, based on the cluster name.
-func GenerateNodeOutboundIPName(clusterName string) string {
-	return fmt.Sprintf("pip-%s-node-outbound", clusterName)
-}
-
 // GenerateNodePublicIPName generates a node public IP name, based on the machine name.
 func GenerateNodePublicIPName(machineName string) string {
 	return fmt.Sprintf("pip-%s", machineName)
 }
 
-// GenerateControlPlaneOutboundLBName generates the name of the control plane outbound LB.
-func GenerateControlPlaneOutboundLBName(clusterName string) string {
-	return fmt.Sprintf("%s-outbound-lb", clusterName)
-}
-
-// GenerateControlPlaneOutboundIPName generates a public IP name, based on the cluster name.
-func GenerateControlPlaneOutboundIPName(clusterName string) string {
-	return fmt.Sprintf("pip-%s-controlplane-outbound", clusterName)
-}
-
 // GeneratePrivateDNSZoneName generates the name of a private DNS zone based on the cluster name.
 func GeneratePrivateDNSZoneName(clusterName string) string {
 	return fmt.Sprintf("%s.capz.io", clusterName)
@@ -150,6 +153,15 @@ func GeneratePrivateFQDN(zoneName string) string {
 	return fmt.Sprintf("%s.%s", PrivateAPIServerHostname, zoneName)
 }
 
+// GenerateTrafficManagerFQDN generates the FQDN of a Traffic Manager profile based on its DNS prefix and
+// the Traffic Manager DNS suffix of the Azure environment.
+func GenerateTrafficManagerFQDN(dnsPrefix, dnsSuffix string) string {
+	if dnsSuffix == "" {
+		dnsSuffix = DefaultTrafficManagerDNSSuffix
+	}
+	return fmt.Sprintf("%s.%s", dnsPrefix, dnsSuffix)
+}
+
 // GenerateVNetLinkName generates the name of a virtual network link name based on the vnet name.
 func GenerateVNetLinkName(vnetName string) string {
 	return fmt.Sprintf("%s-link", vnetName)
@@ -180,6 +192,11 @@ func GenerateVnetPeeringName(sourceVnetName string, remoteVnetName string) strin
 	return fmt.Sprintf("%s-To-%s", sourceVnetName, remoteVnetName)
 }
 
+// GenerateDiagnosticSettingName generates the name of the diagnostic setting a cluster manages on its resources.
+func GenerateDiagnosticSettingName(clusterName string) string {
+	return fmt.Sprintf("%s-diagnostics", clusterName)
+}
+
 // GenerateAvailabilitySetName generates the name of a availability set based on the cluster name and the node group.
 // node group identifies the set of nodes that belong to this availability set:
 // For control plane nodes, this will be `control-plane`.
@@ -188,11 +205,6 @@ func GenerateAvailabilitySetName(clusterName, nodeGroup string) string {
 	return fmt.Sprintf("%s_%s-as", clusterName, nodeGroup)
 }
 
-// WithIndex appends the index as suffix to a generated name.
-func WithIndex(name string, n int) string {
-	return fmt.Sprintf("%s-%d", name, n)
-}
-
 // ResourceGroupID returns the azure resource ID for a given resource group.
 func ResourceGroupID(subscriptionID, resourceGroup string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", subscriptionID, resourceGroup)
@@ -218,6 +230,11 @@ func PublicIPID(subscriptionID, resourceGroup, ipName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/publicIPAddresses/%s", subscriptionID, resourceGroup, ipName)
 }
 
+// PublicIPPrefixID returns the azure resource ID for a given public IP prefix.
+func PublicIPPrefixID(subscriptionID, resourceGroup, prefixName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/publicIPPrefixes/%s", subscriptionID, resourceGroup, prefixName)
+}
+
 // RouteTableID returns the azure resource ID for a given route table.
 func RouteTableID(subscriptionID, resourceGroup, routeTableName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/routeTables/%s", subscriptionID, resourceGroup, routeTableName)
@@ -228,6 +245,11 @@ func SecurityGroupID(subscriptionID, resourceGroup, nsgName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/networkSecurityGroups/%s", subscriptionID, resourceGroup, nsgName)
 }
 
+// ApplicationSecurityGroupID returns the azure resource ID for a given application security group.
+func ApplicationSecurityGroupID(subscriptionID, resourceGroup, asgName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/applicationSecurityGroups/%s", subscriptionID, resourceGroup, asgName)
+}
+
 // NatGatewayID returns the azure resource ID for a given NAT gateway.
 func NatGatewayID(subscriptionID, resourceGroup, natgatewayName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/natGateways/%s", subscriptionID, resourceGroup, natgatewayName)
@@ -238,6 +260,11 @@ func NetworkInterfaceID(subscriptionID, resourceGroup, nicName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/networkInterfaces/%s", subscriptionID, resourceGroup, nicName)
 }
 
+// LoadBalancerID returns the azure resource ID for a given load balancer.
+func LoadBalancerID(subscriptionID, resourceGroup, loadBalancerName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/loadBalancers/%s", subscriptionID, resourceGroup, loadBalancerName)
+}
+
 // FrontendIPConfigID returns the azure resource ID for a given frontend IP config.
 func FrontendIPConfigID(subscriptionID, resourceGroup, loadBalancerName, configName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/loadBalancers/%s/frontendIPConfigurations/%s", subscriptionID, resourceGroup, loadBalancerName, configName)
@@ -383,10 +410,14 @@ func UserAgent() string {
 // SetAutoRestClientDefaults set authorizer and user agent for autorest client.
 func SetAutoRestClientDefaults(c *autorest.Client, auth autorest.Authorizer) {
 	c.Authorizer = auth
-	// Wrap the original Sender on the autorest.Client c.
+	// Wrap the shared Sender enforcing the minimum TLS version.
 	// The wrapped Sender should set the x-ms-correlation-request-id on the given
 	// request, then pass the new request to the underlying Sender.
-	c.Sender = autorest.DecorateSender(c.Sender, msCorrelationIDSendDecorator)
+	c.Sender = autorest.DecorateSender(defaultSender(), msCorrelationIDSendDecorator)
+	if requestLoggingEnabled {
+		// The logging Sender is wrapped by the correlation ID Sender, so that it logs the correlation ID sent to Azure.
+		c.Sender = autorest.DecorateSender(defaultSender(), requestLoggingSendDecorator, msCorrelationIDSendDecorator)
+	}
 	// The default number of retries is 3. This means the client will attempt to retry operation results like resource
 	// conflicts (HTTP 409). For a reconciling controller, this is undesirable behavior since if the controller runs
 	// into an error reconciling, the controller would be better off to end with an error and try again later.
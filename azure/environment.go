@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"encoding/json"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+// EnvironmentScope is a scope that only reconciles the Azure resources of an environment.
+// When the scope of a service implements it, the service refuses to adopt, update or delete any existing resource
+// whose environment tag doesn't match the expected environment. The scope tags the resources it creates or updates
+// with it through its additional tags.
+type EnvironmentScope interface {
+	// ExpectedEnvironment returns the expected value of the environment tag, or an empty string to disable the check.
+	ExpectedEnvironment() string
+	// ClusterName returns the name of the cluster whose resources the scope reconciles.
+	ClusterName() string
+}
+
+// ExpectedEnvironment returns the environment the resources of a scope must belong to, or an empty string if the scope
+// doesn't expect any.
+func ExpectedEnvironment(scope interface{}) string {
+	if envScope, ok := scope.(EnvironmentScope); ok {
+		return envScope.ExpectedEnvironment()
+	}
+	return ""
+}
+
+// CheckEnvironment returns a terminal error if the scope expects an environment and an existing Azure SDK resource,
+// about to be acted on, is tagged with another one. Resources that don't support tags, such as subnets, are not
+// checked.
+func CheckEnvironment(scope interface{}, existing interface{}, action, resourceName, rgName, serviceName string) error {
+	if ExpectedEnvironment(scope) == "" {
+		return nil
+	}
+	tags, ok, err := resourceTags(existing)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the tags of resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+	} else if !ok {
+		return nil
+	}
+	return CheckEnvironmentTags(scope, tags, action, resourceName, rgName, serviceName)
+}
+
+// CheckEnvironmentTags returns a terminal error if the scope expects an environment and the tags of an existing
+// resource, about to be acted on, don't match it. A resource owned by the cluster without the environment tag was
+// created before the environment was expected: it is accepted, and tagged when it is next updated.
+func CheckEnvironmentTags(scope interface{}, tags map[string]*string, action, resourceName, rgName, serviceName string) error {
+	envScope, ok := scope.(EnvironmentScope)
+	if !ok || envScope.ExpectedEnvironment() == "" {
+		return nil
+	}
+	expected := envScope.ExpectedEnvironment()
+	actual, ok := tags[EnvironmentTagKey]
+	if !ok || actual == nil {
+		if to.String(tags[infrav1.ClusterTagKey(envScope.ClusterName())]) == string(infrav1.ResourceLifecycleOwned) {
+			return nil
+		}
+	} else if *actual == expected {
+		return nil
+	}
+	return WithTerminalError(errors.Errorf("refusing to %s resource %s/%s (service: %s): its %s tag is %q, expected %q",
+		action, rgName, resourceName, serviceName, EnvironmentTagKey, to.String(actual), expected))
+}
+
+// resourceTags returns the tags of an Azure SDK resource, and false if the resource doesn't support tags. Only the
+// tracked resources of Azure Resource Manager, which have a location, support tags: the other resources, such as
+// subnets or security rules, are part of a tracked resource.
+func resourceTags(resource interface{}) (map[string]*string, bool, error) {
+	if resource == nil {
+		return nil, false, nil
+	}
+	jsonData, err := json.Marshal(resource)
+	if err != nil {
+		return nil, false, err
+	}
+	var tracked struct {
+		Location *string            `json:"location"`
+		Tags     map[string]*string `json:"tags"`
+	}
+	if err := json.Unmarshal(jsonData, &tracked); err != nil {
+		return nil, false, err
+	}
+	return tracked.Tags, tracked.Location != nil, nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+type fakeEnvironmentScope struct {
+	environment string
+}
+
+func (s fakeEnvironmentScope) ExpectedEnvironment() string {
+	return s.environment
+}
+
+func (s fakeEnvironmentScope) ClusterName() string {
+	return "my-cluster"
+}
+
+type fakeTrackedResource struct {
+	Location *string            `json:"location,omitempty"`
+	Tags     map[string]*string `json:"tags,omitempty"`
+}
+
+func TestCheckEnvironment(t *testing.T) {
+	tests := []struct {
+		name          string
+		scope         interface{}
+		existing      interface{}
+		expectedError string
+	}{
+		{
+			name:     "scope without environment",
+			scope:    struct{}{},
+			existing: fakeTrackedResource{Location: to.StringPtr("westus"), Tags: map[string]*string{EnvironmentTagKey: to.StringPtr("prod")}},
+		},
+		{
+			name:     "no expected environment",
+			scope:    fakeEnvironmentScope{},
+			existing: fakeTrackedResource{Location: to.StringPtr("westus"), Tags: map[string]*string{EnvironmentTagKey: to.StringPtr("prod")}},
+		},
+		{
+			name:     "resource of the expected environment",
+			scope:    fakeEnvironmentScope{environment: "dev"},
+			existing: fakeTrackedResource{Location: to.StringPtr("westus"), Tags: map[string]*string{EnvironmentTagKey: to.StringPtr("dev")}},
+		},
+		{
+			name:     "resource without tags support",
+			scope:    fakeEnvironmentScope{environment: "dev"},
+			existing: fakeTrackedResource{},
+		},
+		{
+			name:  "untagged resource owned by the cluster",
+			scope: fakeEnvironmentScope{environment: "dev"},
+			existing: fakeTrackedResource{Location: to.StringPtr("westus"), Tags: map[string]*string{
+				"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+			}},
+		},
+		{
+			name:          "untagged resource not owned by the cluster",
+			scope:         fakeEnvironmentScope{environment: "dev"},
+			existing:      fakeTrackedResource{Location: to.StringPtr("westus")},
+			expectedError: `refusing to delete resource my-rg/my-resource (service: my-service): its environment tag is "", expected "dev"`,
+		},
+		{
+			name:          "resource of another environment",
+			scope:         fakeEnvironmentScope{environment: "dev"},
+			existing:      fakeTrackedResource{Location: to.StringPtr("westus"), Tags: map[string]*string{EnvironmentTagKey: to.StringPtr("prod")}},
+			expectedError: `refusing to delete resource my-rg/my-resource (service: my-service): its environment tag is "prod", expected "dev"`,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := CheckEnvironment(tc.scope, tc.existing, "delete", "my-resource", "my-rg", "my-service")
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedError)))
+				var reconcileErr ReconcileError
+				g.Expect(errors.As(err, &reconcileErr)).To(BeTrue())
+				g.Expect(reconcileErr.IsTerminal()).To(BeTrue())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
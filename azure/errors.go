@@ -50,6 +50,31 @@ func ResourceConflict(err error) bool {
 	return errors.As(err, &derr) && derr.StatusCode == 409
 }
 
+// ResourceForbidden parses the error to check if the identity isn't allowed to perform the operation (403).
+func ResourceForbidden(err error) bool {
+	derr := autorest.DetailedError{}
+	return errors.As(err, &derr) && derr.StatusCode == 403
+}
+
+// ResourceTransientError parses the error to check if it's worth retrying: a throttled request, a server error, or a
+// request that got no response at all, e.g. because the endpoint isn't reachable yet.
+func ResourceTransientError(err error) bool {
+	derr := autorest.DetailedError{}
+	if !errors.As(err, &derr) {
+		return false
+	}
+	statusCode, ok := derr.StatusCode.(int)
+	if !ok || statusCode == 0 {
+		return true
+	}
+	for _, code := range autorest.StatusCodesForRetry {
+		if statusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
 // VMDeletedError is returned when a virtual machine is deleted outside of capz.
 type VMDeletedError struct {
 	ProviderID string
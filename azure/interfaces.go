@@ -68,6 +68,9 @@ type NetworkDescriber interface {
 	GetPrivateDNSZoneName() string
 	OutboundLBName(string) string
 	OutboundPoolName(string) string
+	ApplicationSecurityGroups() []infrav1.ApplicationSecurityGroup
+	NetworkInterfaceSecurityGroupName(string) string
+	InternalLBAddressPoolIDs(string) []string
 }
 
 // ClusterDescriber is an interface which can get common Azure Cluster information.
@@ -78,6 +81,7 @@ type ClusterDescriber interface {
 	Location() string
 	AdditionalTags() infrav1.Tags
 	AvailabilitySetEnabled() bool
+	ControlPlaneAvailabilitySetID() string
 	CloudProviderConfigOverrides() *infrav1.CloudProviderConfigOverrides
 	FailureDomains() []string
 }
@@ -347,6 +347,20 @@ func (mr *MockNetworkDescriberMockRecorder) APIServerLBPoolName(arg0 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIServerLBPoolName", reflect.TypeOf((*MockNetworkDescriber)(nil).APIServerLBPoolName), arg0)
 }
 
+// ApplicationSecurityGroups mocks base method.
+func (m *MockNetworkDescriber) ApplicationSecurityGroups() []v1beta1.ApplicationSecurityGroup {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroups")
+	ret0, _ := ret[0].([]v1beta1.ApplicationSecurityGroup)
+	return ret0
+}
+
+// ApplicationSecurityGroups indicates an expected call of ApplicationSecurityGroups.
+func (mr *MockNetworkDescriberMockRecorder) ApplicationSecurityGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroups", reflect.TypeOf((*MockNetworkDescriber)(nil).ApplicationSecurityGroups))
+}
+
 // ControlPlaneRouteTable mocks base method.
 func (m *MockNetworkDescriber) ControlPlaneRouteTable() v1beta1.RouteTable {
 	m.ctrl.T.Helper()
@@ -389,6 +403,20 @@ func (mr *MockNetworkDescriberMockRecorder) GetPrivateDNSZoneName() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateDNSZoneName", reflect.TypeOf((*MockNetworkDescriber)(nil).GetPrivateDNSZoneName))
 }
 
+// InternalLBAddressPoolIDs mocks base method.
+func (m *MockNetworkDescriber) InternalLBAddressPoolIDs(arg0 string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InternalLBAddressPoolIDs", arg0)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// InternalLBAddressPoolIDs indicates an expected call of InternalLBAddressPoolIDs.
+func (mr *MockNetworkDescriberMockRecorder) InternalLBAddressPoolIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InternalLBAddressPoolIDs", reflect.TypeOf((*MockNetworkDescriber)(nil).InternalLBAddressPoolIDs), arg0)
+}
+
 // IsAPIServerPrivate mocks base method.
 func (m *MockNetworkDescriber) IsAPIServerPrivate() bool {
 	m.ctrl.T.Helper()
@@ -431,6 +459,20 @@ func (mr *MockNetworkDescriberMockRecorder) IsVnetManaged() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsVnetManaged", reflect.TypeOf((*MockNetworkDescriber)(nil).IsVnetManaged))
 }
 
+// NetworkInterfaceSecurityGroupName mocks base method.
+func (m *MockNetworkDescriber) NetworkInterfaceSecurityGroupName(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkInterfaceSecurityGroupName", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// NetworkInterfaceSecurityGroupName indicates an expected call of NetworkInterfaceSecurityGroupName.
+func (mr *MockNetworkDescriberMockRecorder) NetworkInterfaceSecurityGroupName(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkInterfaceSecurityGroupName", reflect.TypeOf((*MockNetworkDescriber)(nil).NetworkInterfaceSecurityGroupName), arg0)
+}
+
 // NodeSubnets mocks base method.
 func (m *MockNetworkDescriber) NodeSubnets() []v1beta1.SubnetSpec {
 	m.ctrl.T.Helper()
@@ -676,6 +718,20 @@ func (mr *MockClusterDescriberMockRecorder) ClusterName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterName", reflect.TypeOf((*MockClusterDescriber)(nil).ClusterName))
 }
 
+// ControlPlaneAvailabilitySetID mocks base method.
+func (m *MockClusterDescriber) ControlPlaneAvailabilitySetID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ControlPlaneAvailabilitySetID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ControlPlaneAvailabilitySetID indicates an expected call of ControlPlaneAvailabilitySetID.
+func (mr *MockClusterDescriberMockRecorder) ControlPlaneAvailabilitySetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControlPlaneAvailabilitySetID", reflect.TypeOf((*MockClusterDescriber)(nil).ControlPlaneAvailabilitySetID))
+}
+
 // FailureDomains mocks base method.
 func (m *MockClusterDescriber) FailureDomains() []string {
 	m.ctrl.T.Helper()
@@ -936,6 +992,20 @@ func (mr *MockClusterScoperMockRecorder) AdditionalTags() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdditionalTags", reflect.TypeOf((*MockClusterScoper)(nil).AdditionalTags))
 }
 
+// ApplicationSecurityGroups mocks base method.
+func (m *MockClusterScoper) ApplicationSecurityGroups() []v1beta1.ApplicationSecurityGroup {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroups")
+	ret0, _ := ret[0].([]v1beta1.ApplicationSecurityGroup)
+	return ret0
+}
+
+// ApplicationSecurityGroups indicates an expected call of ApplicationSecurityGroups.
+func (mr *MockClusterScoperMockRecorder) ApplicationSecurityGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroups", reflect.TypeOf((*MockClusterScoper)(nil).ApplicationSecurityGroups))
+}
+
 // Authorizer mocks base method.
 func (m *MockClusterScoper) Authorizer() autorest.Authorizer {
 	m.ctrl.T.Helper()
@@ -1048,6 +1118,20 @@ func (mr *MockClusterScoperMockRecorder) ClusterName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterName", reflect.TypeOf((*MockClusterScoper)(nil).ClusterName))
 }
 
+// ControlPlaneAvailabilitySetID mocks base method.
+func (m *MockClusterScoper) ControlPlaneAvailabilitySetID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ControlPlaneAvailabilitySetID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ControlPlaneAvailabilitySetID indicates an expected call of ControlPlaneAvailabilitySetID.
+func (mr *MockClusterScoperMockRecorder) ControlPlaneAvailabilitySetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControlPlaneAvailabilitySetID", reflect.TypeOf((*MockClusterScoper)(nil).ControlPlaneAvailabilitySetID))
+}
+
 // ControlPlaneRouteTable mocks base method.
 func (m *MockClusterScoper) ControlPlaneRouteTable() v1beta1.RouteTable {
 	m.ctrl.T.Helper()
@@ -1118,6 +1202,20 @@ func (mr *MockClusterScoperMockRecorder) HashKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockClusterScoper)(nil).HashKey))
 }
 
+// InternalLBAddressPoolIDs mocks base method.
+func (m *MockClusterScoper) InternalLBAddressPoolIDs(arg0 string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InternalLBAddressPoolIDs", arg0)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// InternalLBAddressPoolIDs indicates an expected call of InternalLBAddressPoolIDs.
+func (mr *MockClusterScoperMockRecorder) InternalLBAddressPoolIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InternalLBAddressPoolIDs", reflect.TypeOf((*MockClusterScoper)(nil).InternalLBAddressPoolIDs), arg0)
+}
+
 // IsAPIServerPrivate mocks base method.
 func (m *MockClusterScoper) IsAPIServerPrivate() bool {
 	m.ctrl.T.Helper()
@@ -1174,6 +1272,20 @@ func (mr *MockClusterScoperMockRecorder) Location() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Location", reflect.TypeOf((*MockClusterScoper)(nil).Location))
 }
 
+// NetworkInterfaceSecurityGroupName mocks base method.
+func (m *MockClusterScoper) NetworkInterfaceSecurityGroupName(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkInterfaceSecurityGroupName", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// NetworkInterfaceSecurityGroupName indicates an expected call of NetworkInterfaceSecurityGroupName.
+func (mr *MockClusterScoperMockRecorder) NetworkInterfaceSecurityGroupName(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkInterfaceSecurityGroupName", reflect.TypeOf((*MockClusterScoper)(nil).NetworkInterfaceSecurityGroupName), arg0)
+}
+
 // NodeSubnets mocks base method.
 func (m *MockClusterScoper) NodeSubnets() []v1beta1.SubnetSpec {
 	m.ctrl.T.Helper()
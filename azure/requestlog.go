@@ -0,0 +1,170 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+)
+
+// DefaultRequestLogVerbosity is the default klog verbosity at which the requests to the Azure APIs are logged.
+const DefaultRequestLogVerbosity = 4
+
+const redacted = "REDACTED"
+
+var (
+	requestLoggingEnabled bool
+	requestLogVerbosity   klog.Level = DefaultRequestLogVerbosity
+
+	// loggedQueryParams are the query parameters of the Azure API requests whose value is logged. The value of any
+	// other query parameter, such as a SAS signature, is redacted.
+	loggedQueryParams = map[string]bool{
+		"api-version": true,
+		"$expand":     true,
+		"$filter":     true,
+		"$select":     true,
+		"$skiptoken":  true,
+		"$top":        true,
+	}
+
+	// requestIDHeaders are the headers identifying an Azure API request, which Azure support asks for to investigate it.
+	requestIDHeaders = []string{
+		"x-ms-correlation-request-id",
+		"x-ms-client-request-id",
+		"x-ms-request-id",
+		"x-ms-routing-request-id",
+	}
+
+	// sensitiveHeaderWords are the words identifying the headers whose value must never be logged.
+	sensitiveHeaderWords = []string{"authorization", "cookie", "key", "secret", "signature", "token"}
+)
+
+// SetRequestLogging enables the logging of the requests to the Azure APIs at the given klog verbosity. Each request
+// is logged with its method, URL, status and request IDs at that verbosity, and with its headers one level above.
+// The bodies are never logged, and neither are the credentials in the headers and the URL.
+// It must be called before any Azure client is created.
+func SetRequestLogging(enabled bool, verbosity int) error {
+	if verbosity < 0 {
+		return errors.Errorf("invalid Azure request log verbosity %d, expected a non-negative value", verbosity)
+	}
+	requestLoggingEnabled = enabled
+	requestLogVerbosity = klog.Level(verbosity)
+	return nil
+}
+
+// requestLoggingSendDecorator logs the requests sent to the Azure APIs and their responses.
+func requestLoggingSendDecorator(snd autorest.Sender) autorest.Sender {
+	return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		if !klog.V(requestLogVerbosity).Enabled() {
+			return snd.Do(r)
+		}
+		start := time.Now()
+		resp, err := snd.Do(r)
+		detailed := klog.V(requestLogVerbosity + 1).Enabled()
+		klog.V(requestLogVerbosity).InfoS("Azure API request", requestLogValues(r, resp, err, time.Since(start), detailed)...)
+		return resp, err
+	})
+}
+
+// requestLogValues returns the key/value pairs logged for a request to the Azure APIs, including its headers if
+// detailed is true.
+func requestLogValues(r *http.Request, resp *http.Response, err error, duration time.Duration, detailed bool) []interface{} {
+	values := []interface{}{
+		"method", r.Method,
+		"url", redactURL(r.URL),
+		"duration", duration.String(),
+	}
+	if resp != nil {
+		values = append(values, "status", resp.StatusCode)
+	}
+	for _, h := range requestIDHeaders {
+		if v := requestID(h, r, resp); v != "" {
+			values = append(values, h, v)
+		}
+	}
+	if err != nil {
+		values = append(values, "error", err.Error())
+	}
+	if detailed {
+		values = append(values, "requestHeaders", redactHeaders(r.Header))
+		if resp != nil {
+			values = append(values, "responseHeaders", redactHeaders(resp.Header))
+		}
+	}
+	return values
+}
+
+// requestID returns the value of the given request ID header, preferring the one returned by Azure in the response.
+func requestID(header string, r *http.Request, resp *http.Response) string {
+	if resp != nil {
+		if v := resp.Header.Get(header); v != "" {
+			return v
+		}
+	}
+	return r.Header.Get(header)
+}
+
+// redactURL returns the given URL without its user info, and with the values of its query parameters redacted unless
+// they are known not to hold secrets.
+func redactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	redactedURL := *u
+	redactedURL.User = nil
+	query := redactedURL.Query()
+	for k, v := range query {
+		if loggedQueryParams[strings.ToLower(k)] {
+			continue
+		}
+		for i := range v {
+			v[i] = redacted
+		}
+	}
+	redactedURL.RawQuery = query.Encode()
+	return redactedURL.String()
+}
+
+// redactHeaders returns the given headers with the values of the ones that may hold credentials redacted.
+func redactHeaders(headers http.Header) map[string]string {
+	redactedHeaders := make(map[string]string, len(headers))
+	for k, v := range headers {
+		if isSensitiveHeader(k) {
+			redactedHeaders[k] = redacted
+			continue
+		}
+		redactedHeaders[k] = strings.Join(v, ", ")
+	}
+	return redactedHeaders
+}
+
+// isSensitiveHeader returns true if the value of the given header may hold credentials.
+func isSensitiveHeader(header string) bool {
+	lower := strings.ToLower(header)
+	for _, w := range sensitiveHeaderWords {
+		if strings.Contains(lower, w) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+// RetryClassification is the outcome of the classification of a reconcile error.
+type RetryClassification string
+
+const (
+	// RetryClassificationRetryable errors are retried. A transient ReconcileError is requeued after its delay, other
+	// errors with a backoff.
+	RetryClassificationRetryable RetryClassification = "Retryable"
+	// RetryClassificationTerminal errors fail the object, which isn't reconciled again.
+	RetryClassificationTerminal RetryClassification = "Terminal"
+	// RetryClassificationIgnore errors are ignored, the reconciliation carries on as successful.
+	RetryClassificationIgnore RetryClassification = "Ignore"
+)
+
+// RetryClassifier classifies the error returned by the reconciliation of an object. It allows operators to handle
+// the quirks of their environment, e.g. to ignore an error Azure returns for a policy they can't change.
+type RetryClassifier func(err error) RetryClassification
+
+// terminalErrorCodes are the codes of the Azure errors caused by an invalid spec, which retrying doesn't recover.
+var terminalErrorCodes = []string{
+	"InvalidParameter",
+	"InvalidRequestFormat",
+	"InvalidResourceName",
+	"InvalidResourceReference",
+	"LinkedInvalidPropertyId",
+}
+
+// DefaultRetryClassifier is the RetryClassifier used when none is configured. It keeps the type of a ReconcileError,
+// fails on the bad requests caused by an invalid spec, and retries every other error.
+func DefaultRetryClassifier(err error) RetryClassification {
+	var reconcileError ReconcileError
+	if errors.As(err, &reconcileError) {
+		if reconcileError.IsTerminal() {
+			return RetryClassificationTerminal
+		}
+		return RetryClassificationRetryable
+	}
+
+	derr := autorest.DetailedError{}
+	if !errors.As(err, &derr) || derr.StatusCode != http.StatusBadRequest {
+		return RetryClassificationRetryable
+	}
+	code := serviceErrorCode(err)
+	for _, terminalCode := range terminalErrorCodes {
+		if strings.EqualFold(code, terminalCode) {
+			return RetryClassificationTerminal
+		}
+	}
+	return RetryClassificationRetryable
+}
+
+// serviceErrorCode returns the code of the Azure service error wrapped by the error, if any.
+func serviceErrorCode(err error) string {
+	reqErr := &azure.RequestError{}
+	if errors.As(err, &reqErr) && reqErr.ServiceError != nil {
+		return reqErr.ServiceError.Code
+	}
+	serr := &azure.ServiceError{}
+	if errors.As(err, &serr) {
+		return serr.Code
+	}
+	return ""
+}
+
+const (
+	// DefaultNotFoundRetryAttempts is the default number of reads of a resource that was just created before a 404 is
+	// considered genuine.
+	DefaultNotFoundRetryAttempts = 3
+	// DefaultNotFoundRetryInterval is the default wait between two reads of a resource that was just created.
+	DefaultNotFoundRetryInterval = time.Second
+)
+
+// NotFoundRetry bounds the retries of the reads that immediately follow the successful creation of a resource. ARM is
+// eventually consistent, and such a read can return a 404 while the new resource isn't visible yet.
+type NotFoundRetry struct {
+	// Attempts is the number of reads, including the first one, before a 404 is returned as is.
+	Attempts int
+	// Interval is the wait between two reads.
+	Interval time.Duration
+}
+
+var notFoundRetry = NotFoundRetry{
+	Attempts: DefaultNotFoundRetryAttempts,
+	Interval: DefaultNotFoundRetryInterval,
+}
+
+// SetNotFoundRetry sets the number of reads of a resource that was just created, and the wait between them, before a
+// 404 is considered genuine. A single attempt disables the retries.
+// It must be called before any service is created.
+func SetNotFoundRetry(attempts int, interval time.Duration) error {
+	if attempts < 1 {
+		return fmt.Errorf("invalid number of not found retry attempts %d, expected a positive value", attempts)
+	}
+	if interval < 0 {
+		return fmt.Errorf("invalid not found retry interval %s, expected a non-negative duration", interval)
+	}
+	notFoundRetry = NotFoundRetry{Attempts: attempts, Interval: interval}
+	return nil
+}
+
+// GetNotFoundRetry returns the retries of the reads that follow the creation of a resource set with SetNotFoundRetry.
+func GetNotFoundRetry() NotFoundRetry {
+	return notFoundRetry
+}
+
+// Do calls read until it returns anything but a 404, for at most r.Attempts calls. The error of the last call is
+// returned, so that a resource that is still not found afterwards is handled as genuinely missing.
+func (r NotFoundRetry) Do(ctx context.Context, read func(ctx context.Context) error) error {
+	for attempt := 1; ; attempt++ {
+		err := read(ctx)
+		if !ResourceNotFound(err) || attempt >= r.Attempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(r.Interval):
+		}
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	. "github.com/onsi/gomega"
+)
+
+func TestDefaultRetryClassifier(t *testing.T) {
+	requestError := func(statusCode int, code string) error {
+		return autorest.DetailedError{
+			StatusCode: statusCode,
+			Original: &azure.RequestError{
+				ServiceError: &azure.ServiceError{Code: code},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected RetryClassification
+	}{
+		{
+			name:     "plain error",
+			err:      errors.New("some error"),
+			expected: RetryClassificationRetryable,
+		},
+		{
+			name:     "transient reconcile error",
+			err:      WithTransientError(errors.New("some error"), time.Minute),
+			expected: RetryClassificationRetryable,
+		},
+		{
+			name:     "terminal reconcile error",
+			err:      WithTerminalError(errors.New("some error")),
+			expected: RetryClassificationTerminal,
+		},
+		{
+			name:     "throttled request",
+			err:      requestError(http.StatusTooManyRequests, "TooManyRequests"),
+			expected: RetryClassificationRetryable,
+		},
+		{
+			name:     "internal server error",
+			err:      requestError(http.StatusInternalServerError, "InternalServerError"),
+			expected: RetryClassificationRetryable,
+		},
+		{
+			name:     "conflict",
+			err:      requestError(http.StatusConflict, "AnotherOperationInProgress"),
+			expected: RetryClassificationRetryable,
+		},
+		{
+			name:     "resource group not found",
+			err:      requestError(http.StatusNotFound, "ResourceGroupNotFound"),
+			expected: RetryClassificationRetryable,
+		},
+		{
+			name:     "invalid parameter",
+			err:      requestError(http.StatusBadRequest, "InvalidParameter"),
+			expected: RetryClassificationTerminal,
+		},
+		{
+			name:     "invalid resource reference",
+			err:      requestError(http.StatusBadRequest, "InvalidResourceReference"),
+			expected: RetryClassificationTerminal,
+		},
+		{
+			name: "invalid parameter of a long-running operation",
+			err: autorest.DetailedError{
+				StatusCode: http.StatusBadRequest,
+				Original:   &azure.ServiceError{Code: "InvalidParameter"},
+			},
+			expected: RetryClassificationTerminal,
+		},
+		{
+			name:     "quota exceeded",
+			err:      requestError(http.StatusBadRequest, "QuotaExceeded"),
+			expected: RetryClassificationRetryable,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(DefaultRetryClassifier(tc.err)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestNotFoundRetry(t *testing.T) {
+	notFound := autorest.DetailedError{StatusCode: http.StatusNotFound}
+
+	tests := []struct {
+		name          string
+		errs          []error
+		expectedCalls int
+		expectedErr   error
+	}{
+		{
+			name:          "found at the first read",
+			errs:          []error{nil},
+			expectedCalls: 1,
+		},
+		{
+			name:          "not visible yet after the creation",
+			errs:          []error{notFound, notFound, nil},
+			expectedCalls: 3,
+		},
+		{
+			name:          "still not found after the last attempt",
+			errs:          []error{notFound, notFound, notFound, nil},
+			expectedCalls: 3,
+			expectedErr:   notFound,
+		},
+		{
+			name:          "other errors are not retried",
+			errs:          []error{autorest.DetailedError{StatusCode: http.StatusInternalServerError}, nil},
+			expectedCalls: 1,
+			expectedErr:   autorest.DetailedError{StatusCode: http.StatusInternalServerError},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var calls int
+			err := NotFoundRetry{Attempts: 3}.Do(context.TODO(), func(ctx context.Context) error {
+				err := tc.errs[calls]
+				calls++
+				return err
+			})
+			g.Expect(calls).To(Equal(tc.expectedCalls))
+			if tc.expectedErr != nil {
+				g.Expect(err).To(Equal(tc.expectedErr))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestNotFoundRetryCanceled(t *testing.T) {
+	g := NewWithT(t)
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	var calls int
+	err := NotFoundRetry{Attempts: 3, Interval: time.Hour}.Do(ctx, func(ctx context.Context) error {
+		calls++
+		return autorest.DetailedError{StatusCode: http.StatusNotFound}
+	})
+	g.Expect(calls).To(Equal(1))
+	g.Expect(ResourceNotFound(err)).To(BeTrue())
+}
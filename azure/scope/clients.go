@@ -72,6 +72,22 @@ func (c *AzureClients) HashKey() string {
 	return base64.URLEncoding.EncodeToString(hasher.Sum(nil))
 }
 
+// tenantAuthorizer authorizes the requests to Azure with the credentials of an AzureClusterIdentity other than the
+// identity of the cluster, e.g. to reach a virtual network of another tenant.
+type tenantAuthorizer struct {
+	AzureClients
+}
+
+// BaseURI returns the Azure ResourceManagerEndpoint.
+func (a *tenantAuthorizer) BaseURI() string {
+	return a.ResourceManagerEndpoint
+}
+
+// Authorizer returns the Azure client Authorizer.
+func (a *tenantAuthorizer) Authorizer() autorest.Authorizer {
+	return a.AzureClients.Authorizer
+}
+
 func (c *AzureClients) setCredentials(subscriptionID, environmentName string) error {
 	settings, err := c.getSettingsFromEnvironment(environmentName)
 	if err != nil {
@@ -23,23 +23,39 @@ import (
 	"hash/fnv"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/net"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/availabilitysets"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loganalytics"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicipprefixes"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/registrations"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/trafficmanager"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachines"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
 	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	"sigs.k8s.io/cluster-api-provider-azure/version"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
@@ -52,6 +68,22 @@ type ClusterScopeParams struct {
 	Client       client.Client
 	Cluster      *clusterv1.Cluster
 	AzureCluster *infrav1.AzureCluster
+	// ExpectedEnvironment is the value of the environment tag the existing Azure resources must have to be adopted or
+	// deleted, if any.
+	ExpectedEnvironment string
+	// AllowedCostCenters are the values the costCenter tag of the cluster must have one of, if any.
+	AllowedCostCenters []string
+	// RequiredSecurityGroupTags are the tags the network security groups of the cluster must have a value for, if any.
+	RequiredSecurityGroupTags []string
+	// RegisterResourceProviders registers the resource providers and features the cluster requires in its
+	// subscription when they aren't registered yet.
+	RegisterResourceProviders bool
+	// PhaseTimeouts are the maximum durations of the phases of the reconciliation of the cluster, defaulted when
+	// zero-valued.
+	PhaseTimeouts reconciler.PhaseTimeouts
+	// DefaultTags are applied to all the Azure resources of the cluster, beneath the tags of the cluster and of the
+	// resources. They default to the tags of the DefaultTagsEnvVar environment variable.
+	DefaultTags infrav1.Tags
 }
 
 // NewClusterScope creates a new Scope from the supplied parameters.
@@ -83,6 +115,11 @@ func NewClusterScope(ctx context.Context, params ClusterScopeParams) (*ClusterSc
 		}
 	}
 
+	tags, err := defaultTags(params.DefaultTags)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load default tags")
+	}
+
 	helper, err := patch.NewHelper(params.AzureCluster, params.Client)
 	if err != nil {
 		return nil, errors.Errorf("failed to init patch helper: %v", err)
@@ -94,6 +131,13 @@ func NewClusterScope(ctx context.Context, params ClusterScopeParams) (*ClusterSc
 		Cluster:      params.Cluster,
 		AzureCluster: params.AzureCluster,
 		patchHelper:  helper,
+
+		expectedEnvironment: params.ExpectedEnvironment,
+		allowedCostCenters:  params.AllowedCostCenters,
+		requiredNSGTags:     params.RequiredSecurityGroupTags,
+		registerProviders:   params.RegisterResourceProviders,
+		phaseTimeouts:       params.PhaseTimeouts.Defaulted(),
+		defaultTags:         tags,
 	}, nil
 }
 
@@ -105,6 +149,16 @@ type ClusterScope struct {
 	AzureClients
 	Cluster      *clusterv1.Cluster
 	AzureCluster *infrav1.AzureCluster
+
+	logAnalyticsSharedKey string
+	expectedEnvironment   string
+	allowedCostCenters    []string
+	requiredNSGTags       []string
+	registerProviders     bool
+	phaseTimeouts         reconciler.PhaseTimeouts
+	defaultTags           infrav1.Tags
+	resourceGroupTags     infrav1.Tags
+	availabilitySetSKU    *resourceskus.SKU
 }
 
 // BaseURI returns the Azure ResourceManagerEndpoint.
@@ -126,44 +180,105 @@ func (s *ClusterScope) PublicIPSpecs() []azure.PublicIPSpec {
 	if s.IsAPIServerPrivate() {
 		// Public IP specs for control plane outbound lb
 		if s.ControlPlaneOutboundLB() != nil {
-			controlPlaneOutboundIPSpecs = s.getOutboundLBPublicIPSpecs(s.ControlPlaneOutboundLB(), azure.GenerateControlPlaneOutboundIPName)
+			controlPlaneOutboundIPSpecs = s.getOutboundLBPublicIPSpecs(s.ControlPlaneOutboundLB())
 		}
-	} else {
+	} else if s.APIServerLB().Shared == nil {
+		// Public IP spec for the api server lb, unless it's shared: its public IP is then managed outside of the cluster.
 		controlPlaneOutboundIPSpecs = []azure.PublicIPSpec{{
-			Name:    s.APIServerPublicIP().Name,
-			DNSName: s.APIServerPublicIP().DNSName,
-			IsIPv6:  false, // currently azure requires a ipv4 lb rule to enable ipv6
+			Name:               s.APIServerPublicIP().Name,
+			DNSName:            s.APIServerPublicIP().DNSName,
+			IsIPv6:             false, // currently azure requires a ipv4 lb rule to enable ipv6
+			Role:               infrav1.APIServerRole,
+			Zones:              s.APIServerPublicIP().Zones,
+			IPTags:             s.APIServerPublicIP().IPTags,
+			RoutingPreference:  s.APIServerPublicIP().RoutingPreference,
+			IPPrefixID:         s.APIServerPublicIP().IPPrefixID,
+			DiagnosticSettings: s.APIServerPublicIP().DiagnosticSettings,
+			ForceRecreate:      s.APIServerLB().ForceRecreate,
 		}}
 	}
 	publicIPSpecs = append(publicIPSpecs, controlPlaneOutboundIPSpecs...)
 
 	// Public IP specs for node outbound lb
 	if s.NodeOutboundLB() != nil {
-		nodeOutboundIPSpecs := s.getOutboundLBPublicIPSpecs(s.NodeOutboundLB(), azure.GenerateNodeOutboundIPName)
+		nodeOutboundIPSpecs := s.getOutboundLBPublicIPSpecs(s.NodeOutboundLB())
 		publicIPSpecs = append(publicIPSpecs, nodeOutboundIPSpecs...)
 	}
 
-	// Public IP specs for node NAT gateways
-	var nodeNatGatewayIPSpecs []azure.PublicIPSpec
-	for _, subnet := range s.NodeSubnets() {
-		if subnet.IsNatGatewayEnabled() {
-			nodeNatGatewayIPSpecs = append(nodeNatGatewayIPSpecs, azure.PublicIPSpec{
-				Name:    subnet.NatGateway.NatGatewayIP.Name,
-				DNSName: subnet.NatGateway.NatGatewayIP.DNSName,
-			})
+	// Public IP specs for NAT gateways
+	var natGatewayIPSpecs []azure.PublicIPSpec
+	for _, subnet := range s.natGatewaySubnets() {
+		// the public IPs of the control plane NAT gateway are tagged so that their addresses can be reported
+		var role string
+		if subnet.Role == infrav1.SubnetControlPlane {
+			role = infrav1.ControlPlaneEgressRole
+		}
+		for i, name := range subnet.NatGateway.NatGatewayIPNames() {
+			natGatewayIPSpec := azure.PublicIPSpec{
+				Name:               name,
+				Role:               role,
+				Zones:              subnet.NatGateway.NatGatewayIP.Zones,
+				IPTags:             subnet.NatGateway.NatGatewayIP.IPTags,
+				RoutingPreference:  subnet.NatGateway.NatGatewayIP.RoutingPreference,
+				DiagnosticSettings: subnet.NatGateway.NatGatewayIP.DiagnosticSettings,
+			}
+			if i == 0 {
+				natGatewayIPSpec.DNSName = subnet.NatGateway.NatGatewayIP.DNSName
+			}
+			natGatewayIPSpecs = append(natGatewayIPSpecs, natGatewayIPSpec)
 		}
-		publicIPSpecs = append(publicIPSpecs, nodeNatGatewayIPSpecs...)
 	}
+	publicIPSpecs = append(publicIPSpecs, natGatewayIPSpecs...)
 
 	if s.AzureCluster.Spec.BastionSpec.AzureBastion != nil {
 		// public IP for Azure Bastion.
 		azureBastionPublicIP := azure.PublicIPSpec{
-			Name:    s.AzureCluster.Spec.BastionSpec.AzureBastion.PublicIP.Name,
-			DNSName: s.AzureCluster.Spec.BastionSpec.AzureBastion.PublicIP.DNSName,
+			Name:               s.AzureCluster.Spec.BastionSpec.AzureBastion.PublicIP.Name,
+			DNSName:            s.AzureCluster.Spec.BastionSpec.AzureBastion.PublicIP.DNSName,
+			Zones:              s.AzureCluster.Spec.BastionSpec.AzureBastion.PublicIP.Zones,
+			IPTags:             s.AzureCluster.Spec.BastionSpec.AzureBastion.PublicIP.IPTags,
+			RoutingPreference:  s.AzureCluster.Spec.BastionSpec.AzureBastion.PublicIP.RoutingPreference,
+			DiagnosticSettings: s.AzureCluster.Spec.BastionSpec.AzureBastion.PublicIP.DiagnosticSettings,
 		}
 		publicIPSpecs = append(publicIPSpecs, azureBastionPublicIP)
 	}
 
+	if glb := s.GlobalLB(); glb != nil {
+		// global public IP of the cross-region load balancer.
+		publicIPSpecs = append(publicIPSpecs, azure.PublicIPSpec{
+			Name:               glb.PublicIP.Name,
+			DNSName:            glb.PublicIP.DNSName,
+			Location:           glb.Location,
+			IsGlobal:           glb.PublicIP.IsGlobal(),
+			IPTags:             glb.PublicIP.IPTags,
+			DiagnosticSettings: glb.PublicIP.DiagnosticSettings,
+		})
+	}
+
+	if s.IsJumpboxEnabled() {
+		// public IP for the jumpbox.
+		publicIPSpecs = append(publicIPSpecs, azure.PublicIPSpec{
+			Name:               s.Jumpbox().PublicIP.Name,
+			DNSName:            s.Jumpbox().PublicIP.DNSName,
+			Zones:              s.Jumpbox().PublicIP.Zones,
+			IPTags:             s.Jumpbox().PublicIP.IPTags,
+			RoutingPreference:  s.Jumpbox().PublicIP.RoutingPreference,
+			DiagnosticSettings: s.Jumpbox().PublicIP.DiagnosticSettings,
+		})
+	}
+
+	if s.IsIngressEnabled() && s.Ingress().PublicIP != nil {
+		// public IP for the frontend of the ingress load balancer.
+		publicIPSpecs = append(publicIPSpecs, azure.PublicIPSpec{
+			Name:               s.Ingress().PublicIP.Name,
+			DNSName:            s.Ingress().PublicIP.DNSName,
+			Zones:              s.Ingress().PublicIP.Zones,
+			IPTags:             s.Ingress().PublicIP.IPTags,
+			RoutingPreference:  s.Ingress().PublicIP.RoutingPreference,
+			DiagnosticSettings: s.Ingress().PublicIP.DiagnosticSettings,
+		})
+	}
+
 	return publicIPSpecs
 }
 
@@ -176,6 +291,8 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 			ResourceGroup:        s.ResourceGroup(),
 			SubscriptionID:       s.SubscriptionID(),
 			ClusterName:          s.ClusterName(),
+			ClusterUID:           s.ClusterUID(),
+			PreviousClusterUID:   s.PreviousClusterUID(),
 			Location:             s.Location(),
 			VNetName:             s.Vnet().Name,
 			VNetResourceGroup:    s.Vnet().ResourceGroup,
@@ -184,13 +301,50 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 			APIServerPort:        s.APIServerPort(),
 			Type:                 s.APIServerLB().Type,
 			SKU:                  infrav1.SKUStandard,
+			Tier:                 s.APIServerLB().Tier,
 			Role:                 infrav1.APIServerRole,
 			BackendPoolName:      s.APIServerLBPoolName(s.APIServerLB().Name),
 			IdleTimeoutInMinutes: s.APIServerLB().IdleTimeoutInMinutes,
+			EnableTCPReset:       s.apiServerLBTCPReset(),
+			HealthProbe:          s.APIServerLB().HealthProbe,
+			HAPorts:              s.APIServerLB().HAPorts,
+			Shared:               s.APIServerLB().Shared,
+			SSHNATRule:           s.APIServerLB().SSHNATRule,
+			ForceRecreate:        s.APIServerLB().ForceRecreate,
+			DisableOutboundRule:  s.ControlPlaneSubnet().IsNatGatewayEnabled(),
 			AdditionalTags:       s.AdditionalTags(),
 		},
 	}
 
+	// Internal frontend of a public API Server LB
+	if internalFrontendIP := s.APIServerLB().InternalFrontendIP; internalFrontendIP != nil && !s.IsAPIServerPrivate() {
+		lbName := infrav1.GenerateInternalFrontendLBName(s.APIServerLB().Name)
+		specs = append(specs, &loadbalancers.LBSpec{
+			Name:                 lbName,
+			ResourceGroup:        s.ResourceGroup(),
+			SubscriptionID:       s.SubscriptionID(),
+			ClusterName:          s.ClusterName(),
+			ClusterUID:           s.ClusterUID(),
+			PreviousClusterUID:   s.PreviousClusterUID(),
+			Location:             s.Location(),
+			VNetName:             s.Vnet().Name,
+			VNetResourceGroup:    s.Vnet().ResourceGroup,
+			SubnetName:           s.ControlPlaneSubnet().Name,
+			FrontendIPConfigs:    []infrav1.FrontendIP{*internalFrontendIP},
+			APIServerPort:        s.APIServerPort(),
+			Type:                 infrav1.Internal,
+			SKU:                  infrav1.SKUStandard,
+			Tier:                 s.APIServerLB().Tier,
+			Role:                 infrav1.APIServerRole,
+			BackendPoolName:      s.APIServerLBPoolName(lbName),
+			IdleTimeoutInMinutes: s.APIServerLB().IdleTimeoutInMinutes,
+			EnableTCPReset:       s.apiServerLBTCPReset(),
+			HealthProbe:          s.APIServerLB().HealthProbe,
+			ForceRecreate:        s.APIServerLB().ForceRecreate,
+			AdditionalTags:       s.AdditionalTags(),
+		})
+	}
+
 	// Node outbound LB
 	if s.NodeOutboundLB() != nil {
 		specs = append(specs, &loadbalancers.LBSpec{
@@ -198,15 +352,19 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 			ResourceGroup:        s.ResourceGroup(),
 			SubscriptionID:       s.SubscriptionID(),
 			ClusterName:          s.ClusterName(),
+			ClusterUID:           s.ClusterUID(),
+			PreviousClusterUID:   s.PreviousClusterUID(),
 			Location:             s.Location(),
 			VNetName:             s.Vnet().Name,
 			VNetResourceGroup:    s.Vnet().ResourceGroup,
 			FrontendIPConfigs:    s.NodeOutboundLB().FrontendIPs,
 			Type:                 s.NodeOutboundLB().Type,
 			SKU:                  s.NodeOutboundLB().SKU,
+			Tier:                 s.NodeOutboundLB().Tier,
 			BackendPoolName:      s.OutboundPoolName(s.NodeOutboundLBName()),
 			IdleTimeoutInMinutes: s.NodeOutboundLB().IdleTimeoutInMinutes,
 			Role:                 infrav1.NodeOutboundRole,
+			ForceRecreate:        s.NodeOutboundLB().ForceRecreate,
 			AdditionalTags:       s.AdditionalTags(),
 		})
 	}
@@ -218,15 +376,19 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 			ResourceGroup:        s.ResourceGroup(),
 			SubscriptionID:       s.SubscriptionID(),
 			ClusterName:          s.ClusterName(),
+			ClusterUID:           s.ClusterUID(),
+			PreviousClusterUID:   s.PreviousClusterUID(),
 			Location:             s.Location(),
 			VNetName:             s.Vnet().Name,
 			VNetResourceGroup:    s.Vnet().ResourceGroup,
 			FrontendIPConfigs:    s.ControlPlaneOutboundLB().FrontendIPs,
 			Type:                 s.ControlPlaneOutboundLB().Type,
 			SKU:                  s.ControlPlaneOutboundLB().SKU,
-			BackendPoolName:      s.OutboundPoolName(azure.GenerateControlPlaneOutboundLBName(s.ClusterName())),
+			Tier:                 s.ControlPlaneOutboundLB().Tier,
+			BackendPoolName:      s.OutboundPoolName(s.ControlPlaneOutboundLB().Name),
 			IdleTimeoutInMinutes: s.NodeOutboundLB().IdleTimeoutInMinutes,
 			Role:                 infrav1.ControlPlaneOutboundRole,
+			ForceRecreate:        s.ControlPlaneOutboundLB().ForceRecreate,
 			AdditionalTags:       s.AdditionalTags(),
 		})
 	}
@@ -239,62 +401,351 @@ func (s *ClusterScope) RouteTableSpecs() []azure.ResourceSpecGetter {
 	var specs []azure.ResourceSpecGetter
 	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
 		if subnet.RouteTable.Name != "" {
-			specs = append(specs, &routetables.RouteTableSpec{
+			spec := &routetables.RouteTableSpec{
 				Name:          subnet.RouteTable.Name,
 				Location:      s.Location(),
 				ResourceGroup: s.ResourceGroup(),
-			})
+			}
+			if subnet.IsFirewallRouteEnabled() {
+				spec.FirewallPrivateIP = subnet.FirewallRoute.PrivateIP
+			}
+			specs = append(specs, spec)
 		}
 	}
 
 	return specs
 }
 
-// NatGatewaySpecs returns the node NAT gateway.
+// NatGatewaySpecs returns the NAT gateways of the node subnets and of the control plane subnet.
 func (s *ClusterScope) NatGatewaySpecs() []azure.ResourceSpecGetter {
 	natGatewaySet := make(map[string]struct{})
 	var natGateways []azure.ResourceSpecGetter
 
-	// We ignore the control plane NAT gateway, as we will always use a LB to enable egress on the control plane.
+	for _, subnet := range s.natGatewaySubnets() {
+		if _, ok := natGatewaySet[subnet.NatGateway.Name]; !ok {
+			natGatewaySet[subnet.NatGateway.Name] = struct{}{} // empty struct to represent hash set
+			natGateways = append(natGateways, &natgateways.NatGatewaySpec{
+				Name:                   subnet.NatGateway.Name,
+				ResourceGroup:          s.ResourceGroup(),
+				SubscriptionID:         s.SubscriptionID(),
+				Location:               s.Location(),
+				NatGatewayIPNames:      subnet.NatGateway.NatGatewayIPNames(),
+				NatGatewayIPPrefixName: natGatewayIPPrefixName(subnet.NatGateway),
+				IdleTimeoutInMinutes:   subnet.NatGateway.IdleTimeoutInMinutes,
+			})
+		}
+	}
+
+	return natGateways
+}
+
+// natGatewaySubnets returns the subnets with a NAT gateway: the node subnets, then the control plane subnet whose
+// NAT gateway gives the control plane a dedicated egress IP.
+func (s *ClusterScope) natGatewaySubnets() []infrav1.SubnetSpec {
+	var natGatewaySubnets []infrav1.SubnetSpec
 	for _, subnet := range s.NodeSubnets() {
 		if subnet.IsNatGatewayEnabled() {
-			if _, ok := natGatewaySet[subnet.NatGateway.Name]; !ok {
-				natGatewaySet[subnet.NatGateway.Name] = struct{}{} // empty struct to represent hash set
-				natGateways = append(natGateways, &natgateways.NatGatewaySpec{
-					Name:           subnet.NatGateway.Name,
-					ResourceGroup:  s.ResourceGroup(),
-					SubscriptionID: s.SubscriptionID(),
-					Location:       s.Location(),
-					NatGatewayIP: infrav1.PublicIPSpec{
-						Name: subnet.NatGateway.NatGatewayIP.Name,
-					},
-				})
+			natGatewaySubnets = append(natGatewaySubnets, subnet)
+		}
+	}
+	if cpSubnet := s.ControlPlaneSubnet(); cpSubnet.IsNatGatewayEnabled() {
+		natGatewaySubnets = append(natGatewaySubnets, cpSubnet)
+	}
+	return natGatewaySubnets
+}
+
+// StalePublicIPNames returns the names of the public IPs of the NAT gateways that are no longer in the spec since their
+// public IP count was lowered.
+func (s *ClusterScope) StalePublicIPNames() []string {
+	inSpec := make(map[string]bool)
+	for _, ip := range s.PublicIPSpecs() {
+		inSpec[ip.Name] = true
+	}
+	var names []string
+	for _, subnet := range s.natGatewaySubnets() {
+		for _, name := range subnet.NatGateway.StaleNatGatewayIPNames() {
+			if !inSpec[name] {
+				names = append(names, name)
 			}
 		}
 	}
+	return names
+}
 
-	return natGateways
+// SetControlPlaneEgressIPs stores the public IP addresses of the control plane NAT gateway in the status.
+func (s *ClusterScope) SetControlPlaneEgressIPs(ips []string) {
+	s.AzureCluster.Status.ControlPlaneEgressIPs = ips
+}
+
+// SetPublicIPPrefixAllocations stores the addresses of the public IPs allocated from public IP prefixes in the status.
+func (s *ClusterScope) SetPublicIPPrefixAllocations(allocations map[string]string) {
+	s.AzureCluster.Status.PublicIPPrefixAllocations = allocations
+}
+
+// PublicIPPrefixSpecs returns the specs of the public IP prefixes used by the NAT gateways of the cluster.
+func (s *ClusterScope) PublicIPPrefixSpecs() []azure.ResourceSpecGetter {
+	prefixSet := make(map[string]struct{})
+	var prefixSpecs []azure.ResourceSpecGetter
+
+	for _, subnet := range s.natGatewaySubnets() {
+		if subnet.NatGateway.NatGatewayIPPrefix == nil {
+			continue
+		}
+		prefix := subnet.NatGateway.NatGatewayIPPrefix
+		if _, ok := prefixSet[prefix.Name]; ok {
+			continue
+		}
+		prefixSet[prefix.Name] = struct{}{}
+		prefixLength := int32(infrav1.DefaultNatGatewayIPPrefixLength)
+		if prefix.PrefixLength != nil {
+			prefixLength = *prefix.PrefixLength
+		}
+		prefixSpecs = append(prefixSpecs, &publicipprefixes.PublicIPPrefixSpec{
+			Name:           prefix.Name,
+			ResourceGroup:  s.ResourceGroup(),
+			Location:       s.Location(),
+			ClusterName:    s.ClusterName(),
+			PrefixLength:   prefixLength,
+			AdditionalTags: s.AdditionalTags(),
+		})
+	}
+
+	return prefixSpecs
+}
+
+// SetNatGatewayIPPrefix stores the range of addresses allocated to a NAT gateway public IP prefix in the
+// AzureCluster status.
+func (s *ClusterScope) SetNatGatewayIPPrefix(name, ipPrefix string) {
+	if s.AzureCluster.Status.NatGatewayIPPrefixes == nil {
+		s.AzureCluster.Status.NatGatewayIPPrefixes = make(map[string]string)
+	}
+	s.AzureCluster.Status.NatGatewayIPPrefixes[name] = ipPrefix
+}
+
+// SetPublicIPZones stores the availability zones of a public IP in the AzureCluster status.
+func (s *ClusterScope) SetPublicIPZones(name string, zones []string) {
+	if s.AzureCluster.Status.PublicIPZones == nil {
+		s.AzureCluster.Status.PublicIPZones = make(map[string][]string)
+	}
+	s.AzureCluster.Status.PublicIPZones[name] = zones
+}
+
+// natGatewayIPPrefixName returns the name of the public IP prefix of the NAT gateway, or an empty string if it has none.
+func natGatewayIPPrefixName(natGateway infrav1.NatGateway) string {
+	if natGateway.NatGatewayIPPrefix == nil {
+		return ""
+	}
+	return natGateway.NatGatewayIPPrefix.Name
 }
 
 // NSGSpecs returns the security group specs.
 func (s *ClusterScope) NSGSpecs() []azure.NSGSpec {
-	nsgspecs := make([]azure.NSGSpec, len(s.AzureCluster.Spec.NetworkSpec.Subnets))
-	for i, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
-		nsgspecs[i] = azure.NSGSpec{
-			Name:          subnet.SecurityGroup.Name,
-			SecurityRules: subnet.SecurityGroup.SecurityRules,
+	nsgspecs := make([]azure.NSGSpec, 0, len(s.AzureCluster.Spec.NetworkSpec.Subnets))
+	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+		if !s.isSubnetSecurityGroupAttached(subnet) {
+			continue
 		}
+		nsgspecs = append(nsgspecs, azure.NSGSpec{
+			Name:               subnet.SecurityGroup.Name,
+			SecurityRules:      s.subnetSecurityRules(subnet),
+			DiagnosticSettings: subnet.SecurityGroup.DiagnosticSettings,
+			Tags:               subnet.SecurityGroup.Tags,
+		})
+	}
+
+	nsgspecs = append(nsgspecs, s.networkInterfaceNSGSpecs()...)
+
+	if s.IsJumpboxEnabled() {
+		nsgspecs = append(nsgspecs, azure.NSGSpec{
+			Name:               s.Jumpbox().Subnet.SecurityGroup.Name,
+			SecurityRules:      s.jumpboxSecurityRules(),
+			DiagnosticSettings: s.Jumpbox().Subnet.SecurityGroup.DiagnosticSettings,
+			Tags:               s.Jumpbox().Subnet.SecurityGroup.Tags,
+		})
+	}
+
+	if s.IsIngressEnabled() {
+		ingressSubnet := s.Ingress().Subnet
+		securityRules := append(ingressSubnet.SecurityGroup.SecurityRules.DeepCopy(), s.ingressSecurityRules()...)
+		nsgspecs = append(nsgspecs, azure.NSGSpec{
+			Name:               ingressSubnet.SecurityGroup.Name,
+			SecurityRules:      withIntentSecurityRules(securityRules, ingressSubnet.SecurityGroup.AllowInboundFrom),
+			DiagnosticSettings: ingressSubnet.SecurityGroup.DiagnosticSettings,
+			Tags:               ingressSubnet.SecurityGroup.Tags,
+		})
 	}
 
 	return nsgspecs
 }
 
+// ApplicationSecurityGroupSpecs returns the application security group specs.
+func (s *ClusterScope) ApplicationSecurityGroupSpecs() []azure.ResourceSpecGetter {
+	asgSpecs := make([]azure.ResourceSpecGetter, len(s.ApplicationSecurityGroups()))
+	for i, asg := range s.ApplicationSecurityGroups() {
+		asgSpecs[i] = &applicationsecuritygroups.ASGSpec{
+			Name:           asg.Name,
+			ResourceGroup:  s.ResourceGroup(),
+			Location:       s.Location(),
+			ClusterName:    s.ClusterName(),
+			AdditionalTags: s.AdditionalTags(),
+		}
+	}
+
+	return asgSpecs
+}
+
+// LogAnalyticsWorkspaceSpec returns the Log Analytics workspace spec, or nil if the cluster has no workspace.
+func (s *ClusterScope) LogAnalyticsWorkspaceSpec() (azure.ResourceSpecGetter, error) {
+	workspace := s.AzureCluster.Spec.LogAnalyticsWorkspace
+	if workspace == nil {
+		return nil, nil
+	}
+
+	if workspace.ID != "" {
+		resource, err := azureautorest.ParseResourceID(workspace.ID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse Log Analytics workspace ID %s", workspace.ID)
+		}
+		return &loganalytics.WorkspaceSpec{
+			Name:          resource.ResourceName,
+			ResourceGroup: resource.ResourceGroup,
+			ClusterName:   s.ClusterName(),
+			External:      true,
+		}, nil
+	}
+
+	resourceGroup := s.ResourceGroup()
+	if diagnosticsGroup := s.DiagnosticsResourceGroup(); diagnosticsGroup != nil {
+		resourceGroup = diagnosticsGroup.Name
+	}
+	return &loganalytics.WorkspaceSpec{
+		Name:            workspace.Name,
+		ResourceGroup:   resourceGroup,
+		Location:        s.Location(),
+		ClusterName:     s.ClusterName(),
+		RetentionInDays: workspace.RetentionInDays,
+		AdditionalTags:  s.AdditionalTags(),
+	}, nil
+}
+
+// DiagnosticsResourceGroup returns the resource group of the diagnostics resources of the cluster, or nil if they are
+// in the resource group of the cluster.
+func (s *ClusterScope) DiagnosticsResourceGroup() *infrav1.DiagnosticsResourceGroup {
+	return s.AzureCluster.Spec.DiagnosticsResourceGroup
+}
+
+// ReportedDiagnosticsResourceGroupLocation returns the location of the diagnostics resource group reported by Azure.
+func (s *ClusterScope) ReportedDiagnosticsResourceGroupLocation() string {
+	return s.AzureCluster.Status.DiagnosticsResourceGroupLocation
+}
+
+// LogAnalyticsSharedKeyRequired returns true if the shared key of the Log Analytics workspace must be stored in a secret.
+func (s *ClusterScope) LogAnalyticsSharedKeyRequired() bool {
+	return s.AzureCluster.Spec.LogAnalyticsWorkspace != nil && s.AzureCluster.Spec.LogAnalyticsWorkspace.SharedKeySecretName != ""
+}
+
+// SetLogAnalyticsWorkspaceStatus stores the IDs of the Log Analytics workspace in the AzureCluster status.
+func (s *ClusterScope) SetLogAnalyticsWorkspaceStatus(id, workspaceID string) {
+	if s.AzureCluster.Status.LogAnalyticsWorkspace == nil {
+		s.AzureCluster.Status.LogAnalyticsWorkspace = &infrav1.LogAnalyticsWorkspaceStatus{}
+	}
+	s.AzureCluster.Status.LogAnalyticsWorkspace.ID = id
+	s.AzureCluster.Status.LogAnalyticsWorkspace.WorkspaceID = workspaceID
+}
+
+// LogAnalyticsSharedKey returns the primary shared key of the Log Analytics workspace retrieved during this reconcile.
+func (s *ClusterScope) LogAnalyticsSharedKey() string {
+	return s.logAnalyticsSharedKey
+}
+
+// SetLogAnalyticsSharedKey sets the primary shared key of the Log Analytics workspace. It is never persisted in the
+// AzureCluster, only in the secret referenced from its status.
+func (s *ClusterScope) SetLogAnalyticsSharedKey(key string) {
+	s.logAnalyticsSharedKey = key
+}
+
+// MakeEmptyLogAnalyticsSharedKeySecret creates an empty secret object that is used for storing the shared key of the
+// Log Analytics workspace.
+func (s *ClusterScope) MakeEmptyLogAnalyticsSharedKeySecret() corev1.Secret {
+	return corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.AzureCluster.Spec.LogAnalyticsWorkspace.SharedKeySecretName,
+			Namespace: s.AzureCluster.Namespace,
+			Labels: map[string]string{
+				s.ClusterName(): string(infrav1.ResourceLifecycleOwned),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(s.AzureCluster, infrav1.GroupVersion.WithKind("AzureCluster")),
+			},
+		},
+	}
+}
+
+// SetLogAnalyticsSharedKeySecretRef stores the reference to the secret holding the shared key of the Log Analytics
+// workspace in the AzureCluster status.
+func (s *ClusterScope) SetLogAnalyticsSharedKeySecretRef(ref *corev1.SecretReference) {
+	if s.AzureCluster.Status.LogAnalyticsWorkspace == nil {
+		s.AzureCluster.Status.LogAnalyticsWorkspace = &infrav1.LogAnalyticsWorkspaceStatus{}
+	}
+	s.AzureCluster.Status.LogAnalyticsWorkspace.SharedKeySecretRef = ref
+}
+
+// InventoryConfigMapName returns the name of the ConfigMap the inventory of the Azure resources of the cluster is
+// written to, or an empty string when it isn't written.
+func (s *ClusterScope) InventoryConfigMapName() string {
+	return s.AzureCluster.Spec.InventoryConfigMapName
+}
+
+// MakeEmptyInventoryConfigMap creates an empty ConfigMap object that is used for storing the inventory of the Azure
+// resources of the cluster.
+func (s *ClusterScope) MakeEmptyInventoryConfigMap() corev1.ConfigMap {
+	return corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.AzureCluster.Spec.InventoryConfigMapName,
+			Namespace: s.AzureCluster.Namespace,
+			Labels: map[string]string{
+				s.ClusterName(): string(infrav1.ResourceLifecycleOwned),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(s.AzureCluster, infrav1.GroupVersion.WithKind("AzureCluster")),
+			},
+		},
+	}
+}
+
+// AdoptedResourceIDs returns the IDs of the existing Azure resources referenced by the AzureCluster, i.e. the custom
+// virtual network and its subnets, as last reconciled.
+func (s *ClusterScope) AdoptedResourceIDs() []string {
+	if s.IsVnetManaged() {
+		return nil
+	}
+	var ids []string
+	if s.Vnet().ID != "" {
+		ids = append(ids, s.Vnet().ID)
+	}
+	for _, subnet := range s.Subnets() {
+		if subnet.ID != "" {
+			ids = append(ids, subnet.ID)
+		}
+	}
+	return ids
+}
+
 // SubnetSpecs returns the subnets specs.
 func (s *ClusterScope) SubnetSpecs() []azure.ResourceSpecGetter {
 	numberOfSubnets := len(s.AzureCluster.Spec.NetworkSpec.Subnets)
 	if s.IsAzureBastionEnabled() {
 		numberOfSubnets++
 	}
+	if s.IsJumpboxEnabled() {
+		numberOfSubnets++
+	}
+	if s.IsIngressEnabled() {
+		numberOfSubnets++
+	}
+	if s.IsPodSubnetEnabled() {
+		numberOfSubnets++
+	}
 
 	subnetSpecs := make([]azure.ResourceSpecGetter, 0, numberOfSubnets)
 
@@ -308,9 +759,14 @@ func (s *ClusterScope) SubnetSpecs() []azure.ResourceSpecGetter {
 			VNetResourceGroup: s.Vnet().ResourceGroup,
 			IsVNetManaged:     s.IsVnetManaged(),
 			RouteTableName:    subnet.RouteTable.Name,
-			SecurityGroupName: subnet.SecurityGroup.Name,
 			Role:              subnet.Role,
 			NatGatewayName:    subnet.NatGateway.Name,
+			FreeIPsThreshold:  subnet.FreeIPsThreshold,
+
+			DisablePrivateEndpointNetworkPolicies: s.hostsPrivateEndpoints(subnet.Name),
+		}
+		if s.isSubnetSecurityGroupAttached(subnet) {
+			subnetSpec.SecurityGroupName = subnet.SecurityGroup.Name
 		}
 		subnetSpecs = append(subnetSpecs, subnetSpec)
 	}
@@ -331,16 +787,80 @@ func (s *ClusterScope) SubnetSpecs() []azure.ResourceSpecGetter {
 		})
 	}
 
+	if s.IsJumpboxEnabled() {
+		jumpboxSubnet := s.Jumpbox().Subnet
+		subnetSpecs = append(subnetSpecs, &subnets.SubnetSpec{
+			Name:              jumpboxSubnet.Name,
+			ResourceGroup:     s.ResourceGroup(),
+			SubscriptionID:    s.SubscriptionID(),
+			CIDRs:             jumpboxSubnet.CIDRBlocks,
+			VNetName:          s.Vnet().Name,
+			VNetResourceGroup: s.Vnet().ResourceGroup,
+			IsVNetManaged:     s.IsVnetManaged(),
+			SecurityGroupName: jumpboxSubnet.SecurityGroup.Name,
+			RouteTableName:    jumpboxSubnet.RouteTable.Name,
+			Role:              jumpboxSubnet.Role,
+		})
+	}
+
+	if s.IsIngressEnabled() {
+		ingressSubnet := s.Ingress().Subnet
+		subnetSpecs = append(subnetSpecs, &subnets.SubnetSpec{
+			Name:              ingressSubnet.Name,
+			ResourceGroup:     s.ResourceGroup(),
+			SubscriptionID:    s.SubscriptionID(),
+			CIDRs:             ingressSubnet.CIDRBlocks,
+			VNetName:          s.Vnet().Name,
+			VNetResourceGroup: s.Vnet().ResourceGroup,
+			IsVNetManaged:     s.IsVnetManaged(),
+			SecurityGroupName: ingressSubnet.SecurityGroup.Name,
+			RouteTableName:    ingressSubnet.RouteTable.Name,
+			Role:              ingressSubnet.Role,
+		})
+	}
+
+	if s.IsPodSubnetEnabled() {
+		subnetSpecs = append(subnetSpecs, s.podSubnetSpec())
+	}
+
 	return subnetSpecs
 }
 
+// podSubnetSpec returns the spec of the pod subnet, delegated to Azure CNI. The pods share the security group, the
+// route table and the NAT gateway of the first node subnet, so that their traffic follows the same rules and routes as
+// the traffic of the nodes.
+func (s *ClusterScope) podSubnetSpec() *subnets.SubnetSpec {
+	podSubnet := s.PodSubnet()
+	subnetSpec := &subnets.SubnetSpec{
+		Name:              podSubnet.Name,
+		ResourceGroup:     s.ResourceGroup(),
+		SubscriptionID:    s.SubscriptionID(),
+		CIDRs:             podSubnet.CIDRBlocks,
+		VNetName:          s.Vnet().Name,
+		VNetResourceGroup: s.Vnet().ResourceGroup,
+		IsVNetManaged:     s.IsVnetManaged(),
+		Role:              infrav1.SubnetPod,
+		ServiceDelegation: podSubnet.ServiceDelegation,
+	}
+	if nodeSubnets := s.NodeSubnets(); len(nodeSubnets) > 0 {
+		nodeSubnet := nodeSubnets[0]
+		subnetSpec.RouteTableName = nodeSubnet.RouteTable.Name
+		subnetSpec.NatGatewayName = nodeSubnet.NatGateway.Name
+		if s.isSubnetSecurityGroupAttached(nodeSubnet) {
+			subnetSpec.SecurityGroupName = nodeSubnet.SecurityGroup.Name
+		}
+	}
+	return subnetSpec
+}
+
 // GroupSpec returns the resource group spec.
 func (s *ClusterScope) GroupSpec() azure.ResourceSpecGetter {
 	return &groups.GroupSpec{
-		Name:           s.ResourceGroup(),
-		Location:       s.Location(),
-		ClusterName:    s.ClusterName(),
-		AdditionalTags: s.AdditionalTags(),
+		Name:            s.ResourceGroup(),
+		Location:        s.ResourceGroupLocation(),
+		ClusterName:     s.ClusterName(),
+		ProviderVersion: version.Get().Marker(),
+		AdditionalTags:  s.AdditionalTags(),
 	}
 }
 
@@ -364,6 +884,15 @@ func (s *ClusterScope) VnetPeeringSpecs() []azure.ResourceSpecGetter {
 			RemoteResourceGroup: s.Vnet().ResourceGroup,
 			SubscriptionID:      s.SubscriptionID(),
 		}
+		if peering.IsCrossTenant() {
+			// The peering from the remote virtual network is created in its subscription with the identity of its tenant.
+			forwardPeering.SubscriptionID = peering.SubscriptionID
+			forwardPeering.CrossTenant = true
+			reversePeering.SourceTenantID = peering.TenantID
+			reversePeering.SourceSubscriptionID = peering.SubscriptionID
+			reversePeering.IdentityRef = peering.IdentityRef
+			reversePeering.CrossTenant = true
+		}
 		peeringSpecs[i*2] = forwardPeering
 		peeringSpecs[i*2+1] = reversePeering
 	}
@@ -371,16 +900,112 @@ func (s *ClusterScope) VnetPeeringSpecs() []azure.ResourceSpecGetter {
 	return peeringSpecs
 }
 
+// TenantAuthorizer returns the authorizer of an AzureClusterIdentity of another tenant, in a subscription of this tenant.
+func (s *ClusterScope) TenantAuthorizer(ctx context.Context, tenantID, subscriptionID string, identityRef *corev1.ObjectReference) (azure.Authorizer, error) {
+	credentialsProvider, err := NewAzureClusterIdentityCredentialsProvider(ctx, s.Client, s.AzureCluster, identityRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init credentials provider")
+	}
+	if !strings.EqualFold(credentialsProvider.GetTenantID(), tenantID) {
+		return nil, errors.Errorf("AzureClusterIdentity %s belongs to tenant %s, not to tenant %s", identityRef.Name, credentialsProvider.GetTenantID(), tenantID)
+	}
+	authorizer := &tenantAuthorizer{}
+	if err := authorizer.setCredentialsWithProvider(ctx, subscriptionID, s.AzureCluster.Spec.AzureEnvironment, credentialsProvider); err != nil {
+		return nil, errors.Wrapf(err, "failed to configure azure settings and credentials for Identity %s", identityRef.Name)
+	}
+	return authorizer, nil
+}
+
+// SetVnetPeeringNotReady sets the VnetPeeringReady condition to False, e.g. when only one side of a peering with another
+// tenant could be created.
+func (s *ClusterScope) SetVnetPeeringNotReady(reason string, severity clusterv1.ConditionSeverity, messageFormat string, messageArgs ...interface{}) {
+	conditions.MarkFalse(s.AzureCluster, infrav1.VnetPeeringReadyCondition, reason, severity, messageFormat, messageArgs...)
+}
+
 // VNetSpec returns the virtual network spec.
 func (s *ClusterScope) VNetSpec() azure.ResourceSpecGetter {
 	return &virtualnetworks.VNetSpec{
-		ResourceGroup:  s.Vnet().ResourceGroup,
-		Name:           s.Vnet().Name,
-		CIDRs:          s.Vnet().CIDRBlocks,
-		Location:       s.Location(),
-		ClusterName:    s.ClusterName(),
-		AdditionalTags: s.AdditionalTags(),
+		ResourceGroup:   s.Vnet().ResourceGroup,
+		Name:            s.Vnet().Name,
+		CIDRs:           s.Vnet().CIDRBlocks,
+		DNSServers:      s.Vnet().DNSServers,
+		Location:        s.Location(),
+		ClusterName:     s.ClusterName(),
+		ProviderVersion: version.Get().Marker(),
+		AdditionalTags:  s.AdditionalTags(),
+		PodCIDRs:        s.PodCIDRs(),
+		ServiceCIDRs:    s.ServiceCIDRs(),
+	}
+}
+
+// PodCIDRs returns the pod CIDRs of the cluster networking spec.
+func (s *ClusterScope) PodCIDRs() []string {
+	if s.Cluster.Spec.ClusterNetwork != nil && s.Cluster.Spec.ClusterNetwork.Pods != nil {
+		return s.Cluster.Spec.ClusterNetwork.Pods.CIDRBlocks
+	}
+	return nil
+}
+
+// IntraClusterCIDRs returns the address ranges between which the security groups of the cluster allow all the traffic:
+// the ones of the spec, or the address space of the virtual network and the pod CIDRs of the cluster. It returns nil
+// if the intra-cluster traffic isn't allowed.
+func (s *ClusterScope) IntraClusterCIDRs() []string {
+	intraClusterTraffic := s.AzureCluster.Spec.NetworkSpec.IntraClusterTraffic
+	if intraClusterTraffic == nil {
+		return nil
+	}
+	if len(intraClusterTraffic.CIDRs) > 0 {
+		return intraClusterTraffic.CIDRs
+	}
+	var cidrs []string
+	seen := make(map[string]bool)
+	for _, cidr := range append(append([]string{}, s.Vnet().CIDRBlocks...), s.PodCIDRs()...) {
+		if !seen[cidr] {
+			seen[cidr] = true
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	return cidrs
+}
+
+// ServiceCIDRs returns the service CIDRs of the cluster networking spec.
+func (s *ClusterScope) ServiceCIDRs() []string {
+	if s.Cluster.Spec.ClusterNetwork != nil && s.Cluster.Spec.ClusterNetwork.Services != nil {
+		return s.Cluster.Spec.ClusterNetwork.Services.CIDRBlocks
+	}
+	return nil
+}
+
+// IsDeleteProtected returns true if the Cluster has the delete-protection annotation.
+func (s *ClusterScope) IsDeleteProtected() bool {
+	_, ok := s.Cluster.GetAnnotations()[azure.DeleteProtectionAnnotation]
+	return ok
+}
+
+// DeleteGracePeriod returns the time to wait before deleting the Azure resources of the cluster.
+func (s *ClusterScope) DeleteGracePeriod() time.Duration {
+	if s.AzureCluster.Spec.DeleteGracePeriod == nil {
+		return 0
 	}
+	return s.AzureCluster.Spec.DeleteGracePeriod.Duration
+}
+
+// DeletionRequestedAt returns the time the deletion of the Azure resources of the cluster was first attempted, if any.
+func (s *ClusterScope) DeletionRequestedAt() *metav1.Time {
+	return s.AzureCluster.Status.DeletionRequestedAt
+}
+
+// SetDeletionRequestedAt stores the time the deletion of the Azure resources of the cluster was first attempted.
+func (s *ClusterScope) SetDeletionRequestedAt(requestedAt metav1.Time) {
+	s.AzureCluster.Status.DeletionRequestedAt = &requestedAt
+}
+
+// SetDNSServers stores the custom DNS servers configured on the virtual network in the AzureCluster status.
+func (s *ClusterScope) SetDNSServers(dnsServers []string) {
+	if len(dnsServers) == 0 {
+		dnsServers = nil
+	}
+	s.AzureCluster.Status.DNSServers = dnsServers
 }
 
 // PrivateDNSSpec returns the private dns zone spec.
@@ -415,6 +1040,21 @@ func (s *ClusterScope) PrivateDNSSpec() *azure.PrivateDNSSpec {
 	return specs
 }
 
+// DNSPrivateResolverSpec returns the specification of the links of the forwarding rulesets of the DNS Private Resolver
+// of the cluster to its virtual network, or nil when the cluster doesn't use a DNS Private Resolver.
+func (s *ClusterScope) DNSPrivateResolverSpec() *azure.DNSPrivateResolverSpec {
+	resolver := s.AzureCluster.Spec.NetworkSpec.DNSPrivateResolver
+	if resolver == nil {
+		return nil
+	}
+	return &azure.DNSPrivateResolverSpec{
+		ResolverID:           resolver.ID,
+		ForwardingRulesetIDs: resolver.ForwardingRulesetIDs,
+		VNetID:               azure.VNetID(s.SubscriptionID(), s.Vnet().ResourceGroup, s.Vnet().Name),
+		LinkName:             azure.GenerateVNetLinkName(s.Vnet().Name),
+	}
+}
+
 // IsAzureBastionEnabled returns true if the azure bastion is enabled.
 func (s *ClusterScope) IsAzureBastionEnabled() bool {
 	return s.AzureCluster.Spec.BastionSpec.AzureBastion != nil
@@ -444,6 +1084,653 @@ func (s *ClusterScope) AzureBastionSpec() azure.ResourceSpecGetter {
 	return nil
 }
 
+// IsJumpboxEnabled returns true if the jumpbox is enabled.
+func (s *ClusterScope) IsJumpboxEnabled() bool {
+	return s.AzureCluster.Spec.BastionSpec.Jumpbox != nil
+}
+
+// Jumpbox returns the cluster Jumpbox.
+func (s *ClusterScope) Jumpbox() *infrav1.Jumpbox {
+	return s.AzureCluster.Spec.BastionSpec.Jumpbox
+}
+
+// JumpboxNICSpec returns the jumpbox network interface spec.
+func (s *ClusterScope) JumpboxNICSpec() azure.ResourceSpecGetter {
+	if !s.IsJumpboxEnabled() {
+		return nil
+	}
+
+	return &networkinterfaces.NICSpec{
+		Name:                  azure.GenerateNICName(s.Jumpbox().Name),
+		ResourceGroup:         s.ResourceGroup(),
+		Location:              s.Location(),
+		SubscriptionID:        s.SubscriptionID(),
+		MachineName:           s.Jumpbox().Name,
+		SubnetName:            s.Jumpbox().Subnet.Name,
+		VNetName:              s.Vnet().Name,
+		VNetResourceGroup:     s.Vnet().ResourceGroup,
+		PublicIPName:          s.Jumpbox().PublicIP.Name,
+		AcceleratedNetworking: to.BoolPtr(false),
+	}
+}
+
+// JumpboxVMSpec returns the jumpbox virtual machine spec.
+func (s *ClusterScope) JumpboxVMSpec() *virtualmachines.VMSpec {
+	if !s.IsJumpboxEnabled() {
+		return nil
+	}
+
+	return &virtualmachines.VMSpec{
+		Name:          s.Jumpbox().Name,
+		ResourceGroup: s.ResourceGroup(),
+		Location:      s.Location(),
+		ClusterName:   s.ClusterName(),
+		Role:          infrav1.Bastion,
+		NICIDs:        []string{azure.NetworkInterfaceID(s.SubscriptionID(), s.ResourceGroup(), azure.GenerateNICName(s.Jumpbox().Name))},
+		SSHKeyData:    s.Jumpbox().SSHPublicKey,
+		Size:          s.Jumpbox().VMSize,
+		OSDisk: infrav1.OSDisk{
+			OSType:      "Linux",
+			DiskSizeGB:  to.Int32Ptr(30),
+			CachingType: "ReadWrite",
+			ManagedDisk: &infrav1.ManagedDiskParameters{
+				StorageAccountType: "Standard_LRS",
+			},
+		},
+		Image:          s.Jumpbox().Image,
+		AdditionalTags: s.AdditionalTags(),
+	}
+}
+
+// JumpboxPublicIPName returns the name of the jumpbox public IP.
+func (s *ClusterScope) JumpboxPublicIPName() string {
+	if !s.IsJumpboxEnabled() {
+		return ""
+	}
+	return s.Jumpbox().PublicIP.Name
+}
+
+// SetJumpboxIP stores the public IP address of the jumpbox in the AzureCluster status.
+func (s *ClusterScope) SetJumpboxIP(ip string) {
+	s.AzureCluster.Status.JumpboxIP = ip
+}
+
+// withLoadBalancerProbeRule returns the security rules of the control plane subnet with a rule allowing the health
+// probes of the API server load balancer, which come from the AzureLoadBalancer service tag and target the API server
+// subnetSecurityRules returns the security rules of the security group of a subnet, with the rules CAPZ requires and the
+// rules generated from its inbound traffic intents.
+func (s *ClusterScope) subnetSecurityRules(subnet infrav1.SubnetSpec) infrav1.SecurityRules {
+	securityRules := subnet.SecurityGroup.SecurityRules
+	if subnet.Role == infrav1.SubnetControlPlane {
+		securityRules = s.withLoadBalancerProbeRule(securityRules)
+	}
+	if subnet.IsFirewallRouteEnabled() {
+		securityRules = withFirewallRule(securityRules, subnet.FirewallRoute.PrivateIP)
+	}
+	if subnet.Role == infrav1.SubnetControlPlane || subnet.Role == infrav1.SubnetNode {
+		securityRules = s.withIntraClusterSecurityRules(securityRules)
+	}
+	securityRules = withIntentSecurityRules(securityRules, subnet.SecurityGroup.AllowInboundFrom)
+	return s.withEgressSecurityRules(securityRules, subnet.SecurityGroup.SecurityGroupClass)
+}
+
+// isSubnetSecurityGroupAttached returns false if the security group of the subnet is replaced by the security groups
+// of the network interfaces of the machines.
+func (s *ClusterScope) isSubnetSecurityGroupAttached(subnet infrav1.SubnetSpec) bool {
+	nicSecurityGroups := s.AzureCluster.Spec.NetworkSpec.NetworkInterfaceSecurityGroups
+	if nicSecurityGroups == nil || nicSecurityGroups.Attachment == infrav1.SecurityGroupAttachmentSubnetAndNetworkInterface {
+		return true
+	}
+	return subnet.Role != infrav1.SubnetControlPlane && subnet.Role != infrav1.SubnetNode
+}
+
+// networkInterfaceSecurityGroup returns the security group of the network interfaces of the machines of a role, or
+// nil if the security groups are attached to the subnets only.
+func (s *ClusterScope) networkInterfaceSecurityGroup(role infrav1.SubnetRole) *infrav1.SecurityGroup {
+	nicSecurityGroups := s.AzureCluster.Spec.NetworkSpec.NetworkInterfaceSecurityGroups
+	switch {
+	case nicSecurityGroups == nil:
+		return nil
+	case role == infrav1.SubnetControlPlane:
+		return &nicSecurityGroups.ControlPlane
+	case role == infrav1.SubnetNode:
+		return &nicSecurityGroups.Node
+	default:
+		return nil
+	}
+}
+
+// NetworkInterfaceSecurityGroupName returns the name of the security group of the network interfaces of the machines
+// of a role, or an empty string if the security groups are attached to the subnets only. Like the security groups of
+// the subnets, they are only reconciled in a managed virtual network.
+func (s *ClusterScope) NetworkInterfaceSecurityGroupName(role string) string {
+	if sg := s.networkInterfaceSecurityGroup(infrav1.SubnetRole(role)); sg != nil && s.IsVnetManaged() {
+		return sg.Name
+	}
+	return ""
+}
+
+// networkInterfaceNSGSpecs returns the specs of the security groups of the network interfaces of the machines. A
+// security group with neither security rules nor inbound traffic intents gets the rules of the subnets of its role.
+func (s *ClusterScope) networkInterfaceNSGSpecs() []azure.NSGSpec {
+	var nsgspecs []azure.NSGSpec
+	for _, role := range []infrav1.SubnetRole{infrav1.SubnetControlPlane, infrav1.SubnetNode} {
+		sg := s.networkInterfaceSecurityGroup(role)
+		if sg == nil {
+			continue
+		}
+		inherited := len(sg.SecurityRules) == 0 && len(sg.AllowInboundFrom) == 0 && sg.EgressPolicy != infrav1.EgressPolicyDenyByDefault
+		securityRules := sg.SecurityRules
+		if role == infrav1.SubnetControlPlane {
+			securityRules = s.withLoadBalancerProbeRule(securityRules)
+		}
+		for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+			if subnet.Role != role {
+				continue
+			}
+			if !inherited {
+				if subnet.IsFirewallRouteEnabled() {
+					securityRules = withFirewallRule(securityRules, subnet.FirewallRoute.PrivateIP)
+				}
+				continue
+			}
+			for _, rule := range s.subnetSecurityRules(subnet) {
+				securityRules = withSecurityRule(securityRules, rule)
+			}
+		}
+		nsgspecs = append(nsgspecs, azure.NSGSpec{
+			Name:                 sg.Name,
+			SecurityRules:        s.withEgressSecurityRules(withIntentSecurityRules(s.withIntraClusterSecurityRules(securityRules), sg.AllowInboundFrom), sg.SecurityGroupClass),
+			NetworkInterfaceRole: string(role),
+			DiagnosticSettings:   sg.DiagnosticSettings,
+			Tags:                 sg.Tags,
+		})
+	}
+	return nsgspecs
+}
+
+// withSecurityRule returns the security rules with the added rule, unless a rule of the same name already exists.
+// An inbound rule gets the first priority from its own not used by the other inbound rules.
+func withSecurityRule(rules infrav1.SecurityRules, added infrav1.SecurityRule) infrav1.SecurityRules {
+	if added.Direction == infrav1.SecurityRuleDirectionInbound {
+		return withPrioritizedSecurityRule(rules, added)
+	}
+	for _, rule := range rules {
+		if rule.Name == added.Name {
+			return rules
+		}
+	}
+	withRule := make(infrav1.SecurityRules, len(rules), len(rules)+1)
+	copy(withRule, rules)
+	return append(withRule, added)
+}
+
+// port. A security group without it marks all the API servers unhealthy.
+func (s *ClusterScope) withLoadBalancerProbeRule(rules infrav1.SecurityRules) infrav1.SecurityRules {
+	return withPrioritizedSecurityRule(rules, infrav1.SecurityRule{
+		Name:             azure.LoadBalancerProbeSecurityRuleName,
+		Description:      "Allow Azure Load Balancer health probes",
+		Priority:         azure.LoadBalancerProbeSecurityRulePriority,
+		Protocol:         infrav1.SecurityGroupProtocolTCP,
+		Direction:        infrav1.SecurityRuleDirectionInbound,
+		Source:           to.StringPtr(azure.AzureLoadBalancerServiceTag),
+		SourcePorts:      to.StringPtr("*"),
+		Destination:      to.StringPtr("*"),
+		DestinationPorts: to.StringPtr(strconv.Itoa(int(s.APIServerPort()))),
+	})
+}
+
+// withFirewallRule returns the security rules of a subnet whose egress is routed to a firewall with a rule allowing
+// the traffic coming from the firewall. The firewall translates the source address of the traffic it forwards to the
+// subnet, e.g. the responses to the egress connections and the DNAT inbound connections, to its private IP.
+func withFirewallRule(rules infrav1.SecurityRules, firewallPrivateIP string) infrav1.SecurityRules {
+	return withPrioritizedSecurityRule(rules, infrav1.SecurityRule{
+		Name:             azure.FirewallSecurityRuleName,
+		Description:      "Allow traffic from the egress firewall",
+		Priority:         azure.FirewallSecurityRulePriority,
+		Protocol:         infrav1.SecurityGroupProtocolAll,
+		Direction:        infrav1.SecurityRuleDirectionInbound,
+		Source:           to.StringPtr(firewallPrivateIP),
+		SourcePorts:      to.StringPtr("*"),
+		Destination:      to.StringPtr("*"),
+		DestinationPorts: to.StringPtr("*"),
+	})
+}
+
+// withPrioritizedSecurityRule returns the security rules with a rule CAPZ adds to them. The rule isn't added when the
+// rules already have one with the same name, and gets the first priority from its own not used by the other rules of
+// its direction.
+func withPrioritizedSecurityRule(rules infrav1.SecurityRules, added infrav1.SecurityRule) infrav1.SecurityRules {
+	usedPriorities := make(map[int32]bool, len(rules))
+	for _, rule := range rules {
+		if rule.Name == added.Name {
+			return rules
+		}
+		if rule.Direction == added.Direction {
+			usedPriorities[rule.Priority] = true
+		}
+	}
+
+	for usedPriorities[added.Priority] {
+		added.Priority++
+	}
+
+	withRule := make(infrav1.SecurityRules, len(rules), len(rules)+1)
+	copy(withRule, rules)
+	return append(withRule, added)
+}
+
+// withIntraClusterSecurityRules returns the security rules with, for each address range of the cluster, an inbound rule
+// and an outbound rule allowing all the traffic from and to it. They get the first priorities from
+// IntraClusterSecurityRulePriority not used by the other rules of their direction, before the rules generated from the
+// inbound traffic intents and the egress policy.
+func (s *ClusterScope) withIntraClusterSecurityRules(rules infrav1.SecurityRules) infrav1.SecurityRules {
+	for i, cidr := range s.IntraClusterCIDRs() {
+		rules = withPrioritizedSecurityRule(rules, infrav1.SecurityRule{
+			Name:             fmt.Sprintf("%sinbound_%d", infrav1.IntraClusterSecurityRuleNamePrefix, i),
+			Description:      fmt.Sprintf("Allow intra-cluster traffic from %s", cidr),
+			Priority:         infrav1.IntraClusterSecurityRulePriority,
+			Protocol:         infrav1.SecurityGroupProtocolAll,
+			Direction:        infrav1.SecurityRuleDirectionInbound,
+			Source:           to.StringPtr(cidr),
+			SourcePorts:      to.StringPtr("*"),
+			Destination:      to.StringPtr("*"),
+			DestinationPorts: to.StringPtr("*"),
+		})
+		rules = withPrioritizedSecurityRule(rules, infrav1.SecurityRule{
+			Name:             fmt.Sprintf("%soutbound_%d", infrav1.IntraClusterSecurityRuleNamePrefix, i),
+			Description:      fmt.Sprintf("Allow intra-cluster traffic to %s", cidr),
+			Priority:         infrav1.IntraClusterSecurityRulePriority,
+			Protocol:         infrav1.SecurityGroupProtocolAll,
+			Direction:        infrav1.SecurityRuleDirectionOutbound,
+			Source:           to.StringPtr("*"),
+			SourcePorts:      to.StringPtr("*"),
+			Destination:      to.StringPtr(cidr),
+			DestinationPorts: to.StringPtr("*"),
+		})
+	}
+	return rules
+}
+
+// withIntentSecurityRules returns the security rules with the rules generated from the inbound traffic the security
+// group allows: a rule for each port of each intent, with the first priorities from IntentSecurityRulePriority not used
+// by the other inbound rules. They come after the rules CAPZ requires, which thus keep their priorities.
+func withIntentSecurityRules(rules infrav1.SecurityRules, intents []infrav1.InboundTrafficIntent) infrav1.SecurityRules {
+	if len(intents) == 0 {
+		return rules
+	}
+
+	usedPriorities := make(map[int32]bool, len(rules))
+	for _, rule := range rules {
+		if rule.Direction == infrav1.SecurityRuleDirectionInbound {
+			usedPriorities[rule.Priority] = true
+		}
+	}
+
+	withRules := make(infrav1.SecurityRules, len(rules), len(rules)+len(intents))
+	copy(withRules, rules)
+	priority := int32(infrav1.IntentSecurityRulePriority)
+	for i, intent := range intents {
+		protocol := intent.Protocol
+		if protocol == "" {
+			protocol = infrav1.SecurityGroupProtocolTCP
+		}
+		description := intent.Description
+		if description == "" {
+			description = fmt.Sprintf("Allow inbound traffic from %s", intent.Source)
+		}
+		for j, port := range intent.Ports {
+			for usedPriorities[priority] {
+				priority++
+			}
+			withRules = append(withRules, infrav1.SecurityRule{
+				Name:             fmt.Sprintf("%s%d_%d", infrav1.IntentSecurityRuleNamePrefix, i, j),
+				Description:      description,
+				Priority:         priority,
+				Protocol:         protocol,
+				Direction:        infrav1.SecurityRuleDirectionInbound,
+				Source:           to.StringPtr(intent.Source),
+				SourcePorts:      to.StringPtr("*"),
+				Destination:      to.StringPtr("*"),
+				DestinationPorts: to.StringPtr(port),
+			})
+			priority++
+		}
+	}
+	return withRules
+}
+
+// requiredEgressRuleNames are the names of the outbound security rules generated from the DenyByDefault egress policy
+// of a security group for the traffic the cluster requires.
+var requiredEgressRuleNames = map[string]bool{
+	"egress_allow_virtual_network": true,
+	"egress_allow_dns":             true,
+	"egress_allow_azure_cloud":     true,
+	"egress_allow_api_server":      true,
+}
+
+// withEgressSecurityRules returns the security rules with the outbound rules generated from the DenyByDefault egress
+// policy of a security group: the rules allowing the traffic the cluster requires, then the traffic of
+// AllowOutboundTo, get the first priorities from EgressSecurityRulePriority not used by the other outbound rules, and
+// the rule denying the rest of the traffic gets EgressDenySecurityRulePriority.
+func (s *ClusterScope) withEgressSecurityRules(rules infrav1.SecurityRules, sg infrav1.SecurityGroupClass) infrav1.SecurityRules {
+	if sg.EgressPolicy != infrav1.EgressPolicyDenyByDefault {
+		return rules
+	}
+
+	usedPriorities := make(map[int32]bool, len(rules))
+	for _, rule := range rules {
+		if rule.Direction == infrav1.SecurityRuleDirectionOutbound {
+			usedPriorities[rule.Priority] = true
+		}
+	}
+
+	allowed := []infrav1.SecurityRule{
+		{
+			Name:             "egress_allow_virtual_network",
+			Description:      "Allow outbound traffic to the virtual network",
+			Protocol:         infrav1.SecurityGroupProtocolAll,
+			Destination:      to.StringPtr("VirtualNetwork"),
+			DestinationPorts: to.StringPtr("*"),
+		},
+		{
+			Name:             "egress_allow_dns",
+			Description:      "Allow outbound DNS queries",
+			Protocol:         infrav1.SecurityGroupProtocolAll,
+			Destination:      to.StringPtr("*"),
+			DestinationPorts: to.StringPtr("53"),
+		},
+		{
+			Name:             "egress_allow_azure_cloud",
+			Description:      "Allow outbound traffic to the Azure services",
+			Protocol:         infrav1.SecurityGroupProtocolTCP,
+			Destination:      to.StringPtr("AzureCloud"),
+			DestinationPorts: to.StringPtr("443"),
+		},
+	}
+	if port := s.APIServerLBPort(); !s.IsAPIServerPrivate() && port != 443 {
+		allowed = append(allowed, infrav1.SecurityRule{
+			Name:             "egress_allow_api_server",
+			Description:      "Allow outbound traffic to the public API server endpoint",
+			Protocol:         infrav1.SecurityGroupProtocolTCP,
+			Destination:      to.StringPtr("AzureCloud"),
+			DestinationPorts: to.StringPtr(strconv.Itoa(int(port))),
+		})
+	}
+	for i, intent := range sg.AllowOutboundTo {
+		protocol := intent.Protocol
+		if protocol == "" {
+			protocol = infrav1.SecurityGroupProtocolTCP
+		}
+		description := intent.Description
+		if description == "" {
+			description = fmt.Sprintf("Allow outbound traffic to %s", intent.Destination)
+		}
+		for j, port := range intent.Ports {
+			allowed = append(allowed, infrav1.SecurityRule{
+				Name:             fmt.Sprintf("%sallow_%d_%d", infrav1.EgressSecurityRuleNamePrefix, i, j),
+				Description:      description,
+				Protocol:         protocol,
+				Destination:      to.StringPtr(intent.Destination),
+				DestinationPorts: to.StringPtr(port),
+			})
+		}
+	}
+
+	withRules := make(infrav1.SecurityRules, len(rules), len(rules)+len(allowed)+1)
+	copy(withRules, rules)
+	priority := int32(infrav1.EgressSecurityRulePriority)
+	for _, rule := range allowed {
+		for usedPriorities[priority] {
+			priority++
+		}
+		rule.Priority = priority
+		rule.Direction = infrav1.SecurityRuleDirectionOutbound
+		rule.Source = to.StringPtr("*")
+		rule.SourcePorts = to.StringPtr("*")
+		withRules = append(withRules, rule)
+		priority++
+	}
+	return append(withRules, infrav1.SecurityRule{
+		Name:             infrav1.EgressSecurityRuleNamePrefix + "deny_all",
+		Description:      "Deny the outbound traffic not allowed explicitly",
+		Priority:         infrav1.EgressDenySecurityRulePriority,
+		Protocol:         infrav1.SecurityGroupProtocolAll,
+		Direction:        infrav1.SecurityRuleDirectionOutbound,
+		Source:           to.StringPtr("*"),
+		SourcePorts:      to.StringPtr("*"),
+		Destination:      to.StringPtr("*"),
+		DestinationPorts: to.StringPtr("*"),
+		Action:           infrav1.SecurityRuleActionDeny,
+	})
+}
+
+// EgressPolicyWarnings returns a warning for each outbound security rule denying traffic before the rules a
+// DenyByDefault egress policy generates for the traffic the cluster requires, which it may block.
+func (s *ClusterScope) EgressPolicyWarnings() []string {
+	var warnings []string
+	for _, nsgSpec := range s.NSGSpecs() {
+		var lastRequired int32
+		for _, rule := range nsgSpec.SecurityRules {
+			if requiredEgressRuleNames[rule.Name] && rule.Priority > lastRequired {
+				lastRequired = rule.Priority
+			}
+		}
+		for _, rule := range nsgSpec.SecurityRules {
+			if rule.Direction != infrav1.SecurityRuleDirectionOutbound || rule.Action != infrav1.SecurityRuleActionDeny ||
+				strings.HasPrefix(rule.Name, infrav1.EgressSecurityRuleNamePrefix) || rule.Priority >= lastRequired {
+				continue
+			}
+			warnings = append(warnings, fmt.Sprintf("security rule %s of security group %s denies outbound traffic before the rules allowing the egress the cluster requires, which it may block", rule.Name, nsgSpec.Name))
+		}
+	}
+	return warnings
+}
+
+// jumpboxSecurityRules returns a rule allowing SSH to the jumpbox for each of the allowed source CIDRs.
+func (s *ClusterScope) jumpboxSecurityRules() infrav1.SecurityRules {
+	rules := make(infrav1.SecurityRules, len(s.Jumpbox().AllowedSourceCIDRs))
+	for i, cidr := range s.Jumpbox().AllowedSourceCIDRs {
+		rules[i] = infrav1.SecurityRule{
+			Name:             fmt.Sprintf("allow_ssh_%d", i),
+			Description:      fmt.Sprintf("Allow SSH from %s", cidr),
+			Priority:         int32(2200 + i),
+			Protocol:         infrav1.SecurityGroupProtocolTCP,
+			Direction:        infrav1.SecurityRuleDirectionInbound,
+			Source:           to.StringPtr(cidr),
+			SourcePorts:      to.StringPtr("*"),
+			Destination:      to.StringPtr("*"),
+			DestinationPorts: to.StringPtr("22"),
+		}
+	}
+	return rules
+}
+
+// IsIngressEnabled returns true if the cluster has an ingress subnet.
+func (s *ClusterScope) IsIngressEnabled() bool {
+	return s.AzureCluster.Spec.NetworkSpec.Ingress != nil
+}
+
+// Ingress returns the cluster ingress configuration.
+func (s *ClusterScope) Ingress() *infrav1.IngressSpec {
+	return s.AzureCluster.Spec.NetworkSpec.Ingress
+}
+
+// IsPodSubnetEnabled returns true if the cluster has a pod subnet for Azure CNI.
+func (s *ClusterScope) IsPodSubnetEnabled() bool {
+	return s.AzureCluster.Spec.NetworkSpec.PodSubnet != nil
+}
+
+// PodSubnet returns the cluster pod subnet configuration.
+func (s *ClusterScope) PodSubnet() *infrav1.PodSubnetSpec {
+	return s.AzureCluster.Spec.NetworkSpec.PodSubnet
+}
+
+// InternalLoadBalancers returns the internal load balancers fronting services of the cluster.
+func (s *ClusterScope) InternalLoadBalancers() []infrav1.InternalLoadBalancerSpec {
+	return s.AzureCluster.Spec.NetworkSpec.InternalLoadBalancers
+}
+
+// HasInternalLoadBalancers returns true if the cluster has internal load balancers fronting services, or had some
+// when they were last reconciled.
+func (s *ClusterScope) HasInternalLoadBalancers() bool {
+	tracked, err := s.AnnotationJSON(azure.InternalLoadBalancersLastAppliedAnnotation)
+	return len(s.InternalLoadBalancers()) != 0 || len(tracked) != 0 || err != nil
+}
+
+// InternalLBSpecs returns the specs of the internal load balancers fronting services of the cluster.
+func (s *ClusterScope) InternalLBSpecs() []azure.ResourceSpecGetter {
+	specs := make([]azure.ResourceSpecGetter, 0, len(s.InternalLoadBalancers()))
+	for _, lb := range s.InternalLoadBalancers() {
+		probe := lb.Probe
+		specs = append(specs, &loadbalancers.LBSpec{
+			Name:               lb.Name,
+			ResourceGroup:      s.ResourceGroup(),
+			SubscriptionID:     s.SubscriptionID(),
+			ClusterName:        s.ClusterName(),
+			ClusterUID:         s.ClusterUID(),
+			PreviousClusterUID: s.PreviousClusterUID(),
+			Location:           s.Location(),
+			VNetName:           s.Vnet().Name,
+			VNetResourceGroup:  s.Vnet().ResourceGroup,
+			SubnetName:         lb.SubnetName,
+			FrontendIPConfigs: []infrav1.FrontendIP{
+				{
+					Name: azure.GenerateFrontendIPConfigName(lb.Name),
+					FrontendIPClass: infrav1.FrontendIPClass{
+						PrivateIPAddress: lb.PrivateIP,
+					},
+				},
+			},
+			Type:            infrav1.Internal,
+			SKU:             infrav1.SKUStandard,
+			Role:            infrav1.InternalServiceRole,
+			BackendPoolName: azure.GenerateBackendAddressPoolName(lb.Name),
+			ServiceRules:    lb.Rules,
+			ServiceProbe:    &probe,
+			ServiceProbes:   lb.Probes,
+			AdditionalTags:  s.AdditionalTags(),
+		})
+	}
+	return specs
+}
+
+// InternalLBAddressPoolIDs returns the IDs of the backend pools of the internal load balancers fronting services of
+// the cluster that the nodes of a subnet are added to.
+func (s *ClusterScope) InternalLBAddressPoolIDs(subnetName string) []string {
+	var ids []string
+	for _, lb := range s.InternalLoadBalancers() {
+		if lb.SubnetName == subnetName {
+			ids = append(ids, azure.AddressPoolID(s.SubscriptionID(), s.ResourceGroup(), lb.Name, azure.GenerateBackendAddressPoolName(lb.Name)))
+		}
+	}
+	return ids
+}
+
+// ingressSecurityRules returns a rule allowing each of the ingress ports for each of the allowed source CIDRs, or from
+// any source when none is set.
+func (s *ClusterScope) ingressSecurityRules() infrav1.SecurityRules {
+	sources := s.Ingress().AllowedSourceCIDRs
+	if len(sources) == 0 {
+		sources = []string{"*"}
+	}
+	rules := make(infrav1.SecurityRules, 0, len(sources)*len(s.Ingress().Ports))
+	for _, port := range s.Ingress().Ports {
+		for i, source := range sources {
+			name := fmt.Sprintf("allow_ingress_%d", port)
+			description := fmt.Sprintf("Allow port %d", port)
+			if source != "*" {
+				name = fmt.Sprintf("%s_%d", name, i)
+				description = fmt.Sprintf("%s from %s", description, source)
+			}
+			rules = append(rules, infrav1.SecurityRule{
+				Name:             name,
+				Description:      description,
+				Priority:         int32(2100 + len(rules)),
+				Protocol:         infrav1.SecurityGroupProtocolTCP,
+				Direction:        infrav1.SecurityRuleDirectionInbound,
+				Source:           to.StringPtr(source),
+				SourcePorts:      to.StringPtr("*"),
+				Destination:      to.StringPtr("*"),
+				DestinationPorts: to.StringPtr(strconv.Itoa(int(port))),
+			})
+		}
+	}
+	return rules
+}
+
+// TrafficManager returns the cluster Traffic Manager configuration.
+func (s *ClusterScope) TrafficManager() *infrav1.TrafficManagerSpec {
+	return s.AzureCluster.Spec.NetworkSpec.TrafficManager
+}
+
+// TrafficManagerSpec returns the Traffic Manager profile spec, with an endpoint for each regional API server endpoint.
+func (s *ClusterScope) TrafficManagerSpec() azure.ResourceSpecGetter {
+	tm := s.TrafficManager()
+	if tm == nil {
+		return nil
+	}
+
+	return &trafficmanager.TrafficManagerSpec{
+		Name:           tm.Name,
+		ResourceGroup:  s.ResourceGroup(),
+		ClusterName:    s.ClusterName(),
+		DNSPrefix:      tm.DNSPrefix,
+		RoutingMethod:  tm.RoutingMethod,
+		Location:       s.Location(),
+		MonitorPort:    s.APIServerPort(),
+		Endpoints:      s.APIServerRegionalEndpoints(),
+		AdditionalTags: s.AdditionalTags(),
+	}
+}
+
+// GlobalLB returns the cluster cross-region load balancer configuration.
+func (s *ClusterScope) GlobalLB() *infrav1.GlobalLoadBalancerSpec {
+	return s.AzureCluster.Spec.NetworkSpec.GlobalLB
+}
+
+// StaleLBSpecs returns the specs of the load balancers of the cluster that are no longer in the spec: the internal load
+// balancer of the internal frontend of the API server load balancer once the internal frontend is removed.
+func (s *ClusterScope) StaleLBSpecs() []azure.ResourceSpecGetter {
+	if s.APIServerLB().InternalFrontendIP != nil && !s.IsAPIServerPrivate() {
+		return nil
+	}
+	return []azure.ResourceSpecGetter{
+		&loadbalancers.LBSpec{
+			Name:           infrav1.GenerateInternalFrontendLBName(s.APIServerLB().Name),
+			ResourceGroup:  s.ResourceGroup(),
+			SubscriptionID: s.SubscriptionID(),
+			ClusterName:    s.ClusterName(),
+		},
+	}
+}
+
+// GlobalLBSpec returns the cross-region load balancer spec, whose backends are the frontend of the API server load
+// balancer and the additional regional frontends.
+func (s *ClusterScope) GlobalLBSpec() azure.ResourceSpecGetter {
+	glb := s.GlobalLB()
+	if glb == nil {
+		return nil
+	}
+
+	backends := []string{azure.FrontendIPConfigID(s.SubscriptionID(), s.ResourceGroup(), s.APIServerLB().Name, s.APIServerLB().FrontendIPs[0].Name)}
+	backends = append(backends, glb.AdditionalBackends...)
+
+	return &loadbalancers.GlobalLBSpec{
+		Name:            glb.Name,
+		ResourceGroup:   s.ResourceGroup(),
+		SubscriptionID:  s.SubscriptionID(),
+		ClusterName:     s.ClusterName(),
+		Location:        glb.Location,
+		FrontendName:    azure.GenerateFrontendIPConfigName(glb.Name),
+		PublicIPName:    glb.PublicIP.Name,
+		BackendPoolName: azure.GenerateBackendAddressPoolName(glb.Name),
+		Backends:        backends,
+		APIServerPort:   s.APIServerPort(),
+		AdditionalTags:  s.AdditionalTags(),
+	}
+}
+
 // Vnet returns the cluster Vnet.
 func (s *ClusterScope) Vnet() *infrav1.VnetSpec {
 	return &s.AzureCluster.Spec.NetworkSpec.Vnet
@@ -524,8 +1811,31 @@ func (s *ClusterScope) UpdateSubnetCIDRs(name string, cidrBlocks []string) {
 	s.SetSubnet(subnetSpecInfra)
 }
 
-// UpdateSubnetIDs updates the subnet IDs for the subnet with the same name.
+// UpdateSubnetAvailableIPs stores the number of available IP addresses of the subnet with the same name in the AzureCluster status.
+func (s *ClusterScope) UpdateSubnetAvailableIPs(name string, count int32) {
+	if s.AzureCluster.Status.SubnetAvailableIPs == nil {
+		s.AzureCluster.Status.SubnetAvailableIPs = make(map[string]int32)
+	}
+	s.AzureCluster.Status.SubnetAvailableIPs[name] = count
+}
+
+// SetSubnetIPsAvailable marks the subnets as having enough available IP addresses.
+func (s *ClusterScope) SetSubnetIPsAvailable() {
+	conditions.MarkTrue(s.AzureCluster, infrav1.SubnetIPsAvailableCondition)
+}
+
+// SetSubnetIPsNotAvailable marks the subnets as running out of available IP addresses.
+func (s *ClusterScope) SetSubnetIPsNotAvailable(reason string, severity clusterv1.ConditionSeverity, messageFormat string, messageArgs ...interface{}) {
+	conditions.MarkFalse(s.AzureCluster, infrav1.SubnetIPsAvailableCondition, reason, severity, messageFormat, messageArgs...)
+}
+
+// UpdateSubnetIDs updates the subnet IDs for the subnet with the same name. The ID of the pod subnet is stored in the
+// AzureCluster status.
 func (s *ClusterScope) UpdateSubnetID(name string, id string) {
+	if s.IsPodSubnetEnabled() && s.PodSubnet().Name == name {
+		s.AzureCluster.Status.PodSubnetID = id
+		return
+	}
 	subnetSpecInfra := s.Subnet(name)
 	subnetSpecInfra.ID = id
 	s.SetSubnet(subnetSpecInfra)
@@ -557,6 +1867,11 @@ func (s *ClusterScope) APIServerLBName() string {
 	return s.APIServerLB().Name
 }
 
+// IsNetworkOnly returns true if only the networking resources of the cluster are managed.
+func (s *ClusterScope) IsNetworkOnly() bool {
+	return s.AzureCluster.Spec.ReconcileMode == infrav1.ReconcileModeNetworkOnly
+}
+
 // IsAPIServerPrivate returns true if the API Server LB is of type Internal.
 func (s *ClusterScope) IsAPIServerPrivate() bool {
 	return s.APIServerLB().Type == infrav1.Internal
@@ -580,39 +1895,141 @@ func (s *ClusterScope) GetPrivateDNSZoneName() string {
 	return azure.GeneratePrivateDNSZoneName(s.ClusterName())
 }
 
-// APIServerLBPoolName returns the API Server LB backend pool name.
-func (s *ClusterScope) APIServerLBPoolName(loadBalancerName string) string {
-	return azure.GenerateBackendAddressPoolName(loadBalancerName)
+// APIServerLBPoolName returns the API Server LB backend pool name.
+// The backend pool of a cluster on a shared API Server LB is named after the cluster.
+func (s *ClusterScope) APIServerLBPoolName(loadBalancerName string) string {
+	if s.APIServerLB().Shared != nil && loadBalancerName == s.APIServerLB().Name {
+		return azure.GenerateBackendAddressPoolName(s.ClusterName())
+	}
+	return azure.GenerateBackendAddressPoolName(loadBalancerName)
+}
+
+// apiServerLBTCPReset returns the TCP reset setting of the API server LB rule, if any.
+func (s *ClusterScope) apiServerLBTCPReset() *bool {
+	if s.APIServerLB().ConnectionDraining == nil {
+		return nil
+	}
+	return s.APIServerLB().ConnectionDraining.EnableTCPReset
+}
+
+// NodeOutboundLBName returns the name of the node outbound LB.
+func (s *ClusterScope) NodeOutboundLBName() string {
+	return s.ClusterName()
+}
+
+// OutboundLBName returns the name of the outbound LB.
+func (s *ClusterScope) OutboundLBName(role string) string {
+	if role == infrav1.Node {
+		if s.NodeOutboundLB() == nil {
+			return ""
+		}
+		return s.NodeOutboundLB().Name
+	}
+	if s.IsAPIServerPrivate() {
+		if s.ControlPlaneOutboundLB() == nil {
+			return ""
+		}
+		return s.ControlPlaneOutboundLB().Name
+	}
+	return s.APIServerLBName()
+}
+
+// OutboundPoolName returns the outbound LB backend pool name.
+func (s *ClusterScope) OutboundPoolName(loadBalancerName string) string {
+	if loadBalancerName == "" {
+		return ""
+	}
+	return azure.GenerateOutboundBackendAddressPoolName(loadBalancerName)
+}
+
+// ApplicationSecurityGroups returns the cluster application security groups.
+func (s *ClusterScope) ApplicationSecurityGroups() []infrav1.ApplicationSecurityGroup {
+	return s.AzureCluster.Spec.NetworkSpec.ApplicationSecurityGroups
+}
+
+// ClusterUID returns the UID the Azure resources of the cluster are tagged with: the one recorded in the AzureCluster
+// status, or the UID of the Cluster until it is recorded.
+func (s *ClusterScope) ClusterUID() string {
+	if s.AzureCluster.Status.ClusterUID != "" {
+		return s.AzureCluster.Status.ClusterUID
+	}
+	return string(s.Cluster.UID)
+}
+
+// SetClusterUID records the UID of the Cluster in the AzureCluster status as the UID its Azure resources are tagged
+// with, unless one was already recorded, e.g. before the Cluster was moved or restored.
+func (s *ClusterScope) SetClusterUID() {
+	if s.AzureCluster.Status.ClusterUID == "" {
+		s.AzureCluster.Status.ClusterUID = string(s.Cluster.UID)
+	}
+}
+
+// PreviousClusterUID returns the UID the cluster had before it was moved or restored, if any.
+func (s *ClusterScope) PreviousClusterUID() string {
+	return s.Cluster.GetAnnotations()[azure.PreviousClusterUIDAnnotation]
+}
+
+// SetLoadBalancerTier records in the AzureCluster status the tier Azure reports for a load balancer of the cluster.
+func (s *ClusterScope) SetLoadBalancerTier(name string, tier infrav1.LoadBalancerTier) {
+	if s.AzureCluster.Status.LoadBalancerTiers == nil {
+		s.AzureCluster.Status.LoadBalancerTiers = make(map[string]infrav1.LoadBalancerTier)
+	}
+	s.AzureCluster.Status.LoadBalancerTiers[name] = tier
+}
+
+// SetGlobalLBBackendHealth records in the AzureCluster status the health of the regional frontends behind the
+// cross-region load balancer.
+func (s *ClusterScope) SetGlobalLBBackendHealth(health map[string]infrav1.BackendHealthState) {
+	s.AzureCluster.Status.GlobalLoadBalancerBackends = health
+}
+
+// SetGlobalLBBackendsHealthy marks the regional load balancers behind the cross-region load balancer as healthy.
+func (s *ClusterScope) SetGlobalLBBackendsHealthy() {
+	conditions.MarkTrue(s.AzureCluster, infrav1.GlobalLoadBalancerBackendsHealthyCondition)
+}
+
+// SetGlobalLBBackendsNotHealthy marks the regional load balancers behind the cross-region load balancer as not
+// healthy.
+func (s *ClusterScope) SetGlobalLBBackendsNotHealthy(reason string, severity clusterv1.ConditionSeverity, messageFormat string, messageArgs ...interface{}) {
+	conditions.MarkFalse(s.AzureCluster, infrav1.GlobalLoadBalancerBackendsHealthyCondition, reason, severity, messageFormat, messageArgs...)
+}
+
+// SetNetworkInterfaceSecurityGroupID records in the AzureCluster status the ID of the security group of the network
+// interfaces of the machines of a role.
+func (s *ClusterScope) SetNetworkInterfaceSecurityGroupID(role, id string) {
+	if s.AzureCluster.Status.NetworkInterfaceSecurityGroupIDs == nil {
+		s.AzureCluster.Status.NetworkInterfaceSecurityGroupIDs = make(map[string]string)
+	}
+	s.AzureCluster.Status.NetworkInterfaceSecurityGroupIDs[role] = id
+}
+
+// SecondaryRegion returns the secondary region of the cluster, nil for a single-region cluster.
+func (s *ClusterScope) SecondaryRegion() *infrav1.SecondaryRegionSpec {
+	return s.AzureCluster.Spec.SecondaryRegion
 }
 
-// NodeOutboundLBName returns the name of the node outbound LB.
-func (s *ClusterScope) NodeOutboundLBName() string {
-	return s.ClusterName()
+// ClearSecondaryNetworkStatus removes the network of the secondary region from the status of the cluster.
+func (s *ClusterScope) ClearSecondaryNetworkStatus() {
+	s.AzureCluster.Status.SecondaryNetwork = nil
+	conditions.Delete(s.AzureCluster, infrav1.SecondaryNetworkReadyCondition)
 }
 
-// OutboundLBName returns the name of the outbound LB.
-func (s *ClusterScope) OutboundLBName(role string) string {
-	if role == infrav1.Node {
-		if s.NodeOutboundLB() == nil {
-			return ""
-		}
-		return s.NodeOutboundLB().Name
+// SecondaryRegionLastApplied returns the secondary region last reconciled, nil if none was.
+func (s *ClusterScope) SecondaryRegionLastApplied() (*infrav1.SecondaryRegionSpec, error) {
+	jsonAnnotation := s.AzureCluster.GetAnnotations()[azure.SecondaryRegionLastAppliedAnnotation]
+	if jsonAnnotation == "" {
+		return nil, nil
 	}
-	if s.IsAPIServerPrivate() {
-		if s.ControlPlaneOutboundLB() == nil {
-			return ""
-		}
-		return s.ControlPlaneOutboundLB().Name
+	region := &infrav1.SecondaryRegionSpec{}
+	if err := json.Unmarshal([]byte(jsonAnnotation), region); err != nil {
+		return nil, err
 	}
-	return s.APIServerLBName()
+	return region, nil
 }
 
-// OutboundPoolName returns the outbound LB backend pool name.
-func (s *ClusterScope) OutboundPoolName(loadBalancerName string) string {
-	if loadBalancerName == "" {
-		return ""
-	}
-	return azure.GenerateOutboundBackendAddressPoolName(loadBalancerName)
+// ClearSecondaryRegionLastApplied forgets the secondary region last reconciled, once its network is deleted.
+func (s *ClusterScope) ClearSecondaryRegionLastApplied() {
+	delete(s.AzureCluster.Annotations, azure.SecondaryRegionLastAppliedAnnotation)
 }
 
 // ResourceGroup returns the cluster resource group.
@@ -640,6 +2057,73 @@ func (s *ClusterScope) AvailabilitySetEnabled() bool {
 	return len(s.AzureCluster.Status.FailureDomains) == 0
 }
 
+// ControlPlaneAvailabilitySet returns the availability set of the control plane of the cluster.
+func (s *ClusterScope) ControlPlaneAvailabilitySet() *infrav1.AvailabilitySet {
+	return s.AzureCluster.Spec.ControlPlaneAvailabilitySet
+}
+
+// SetAvailabilitySetSKU sets the SKU of the aligned availability sets in the location of the cluster, retrieved during
+// this reconcile.
+func (s *ClusterScope) SetAvailabilitySetSKU(sku *resourceskus.SKU) {
+	s.availabilitySetSKU = sku
+}
+
+// AvailabilitySetSpec returns the spec of the availability set of the control plane, or nil when the cluster has
+// none, or its location has availability zones.
+func (s *ClusterScope) AvailabilitySetSpec() azure.ResourceSpecGetter {
+	availabilitySet := s.ControlPlaneAvailabilitySet()
+	if availabilitySet == nil || !s.AvailabilitySetEnabled() {
+		return nil
+	}
+	return &availabilitysets.AvailabilitySetSpec{
+		Name:              availabilitySet.Name,
+		ResourceGroup:     s.ResourceGroup(),
+		ClusterName:       s.ClusterName(),
+		Location:          s.Location(),
+		SKU:               s.availabilitySetSKU,
+		AdditionalTags:    s.AdditionalTags(),
+		FaultDomainCount:  availabilitySet.FaultDomainCount,
+		UpdateDomainCount: availabilitySet.UpdateDomainCount,
+	}
+}
+
+// ControlPlaneAvailabilitySetID returns the ID of the availability set of the control plane, as last reconciled.
+func (s *ClusterScope) ControlPlaneAvailabilitySetID() string {
+	return s.AzureCluster.Status.ControlPlaneAvailabilitySetID
+}
+
+// SetControlPlaneAvailabilitySetID records the ID of the availability set of the control plane in the AzureCluster
+// status.
+func (s *ClusterScope) SetControlPlaneAvailabilitySetID(id string) {
+	s.AzureCluster.Status.ControlPlaneAvailabilitySetID = id
+}
+
+// ControlPlaneEtcdDisks returns the requirements of the etcd data disks of the control plane machines, or nil if they
+// aren't checked.
+func (s *ClusterScope) ControlPlaneEtcdDisks() *infrav1.EtcdDisks {
+	return s.AzureCluster.Spec.ControlPlaneEtcdDisks
+}
+
+// SetControlPlaneEtcdDisksCompatible records the availability zones of the control plane the etcd data disks are
+// supported in, and marks the etcd data disks as compatible with the control plane.
+func (s *ClusterScope) SetControlPlaneEtcdDisksCompatible(zones []string) {
+	s.AzureCluster.Status.ControlPlaneEtcdDiskZones = zones
+	conditions.MarkTrue(s.AzureCluster, infrav1.ControlPlaneEtcdDisksCompatibleCondition)
+}
+
+// SetControlPlaneEtcdDisksNotCompatible marks the etcd data disks as not supported by the control plane.
+func (s *ClusterScope) SetControlPlaneEtcdDisksNotCompatible(reason string, severity clusterv1.ConditionSeverity, messageFormat string, messageArgs ...interface{}) {
+	s.AzureCluster.Status.ControlPlaneEtcdDiskZones = nil
+	conditions.MarkFalse(s.AzureCluster, infrav1.ControlPlaneEtcdDisksCompatibleCondition, reason, severity, messageFormat, messageArgs...)
+}
+
+// ClearControlPlaneEtcdDisksStatus removes the compatibility of the etcd data disks from the AzureCluster status, when
+// they are no longer checked.
+func (s *ClusterScope) ClearControlPlaneEtcdDisksStatus() {
+	s.AzureCluster.Status.ControlPlaneEtcdDiskZones = nil
+	conditions.Delete(s.AzureCluster, infrav1.ControlPlaneEtcdDisksCompatibleCondition)
+}
+
 // CloudProviderConfigOverrides returns the cloud provider config overrides for the cluster.
 func (s *ClusterScope) CloudProviderConfigOverrides() *infrav1.CloudProviderConfigOverrides {
 	return s.AzureCluster.Spec.CloudProviderConfigOverrides
@@ -647,12 +2131,17 @@ func (s *ClusterScope) CloudProviderConfigOverrides() *infrav1.CloudProviderConf
 
 // GenerateFQDN generates a fully qualified domain name, based on a hash, cluster name and cluster location.
 func (s *ClusterScope) GenerateFQDN(ipName string) string {
+	return s.generateFQDN(ipName, s.Location())
+}
+
+// generateFQDN generates a fully qualified domain name, based on a hash, cluster name and the location of the public IP.
+func (s *ClusterScope) generateFQDN(ipName, location string) string {
 	h := fnv.New32a()
 	if _, err := h.Write([]byte(fmt.Sprintf("%s/%s/%s", s.SubscriptionID(), s.ResourceGroup(), ipName))); err != nil {
 		return ""
 	}
 	hash := fmt.Sprintf("%x", h.Sum32())
-	return strings.ToLower(fmt.Sprintf("%s-%s.%s.%s", s.ClusterName(), hash, s.Location(), s.AzureClients.ResourceManagerVMDNSSuffix))
+	return strings.ToLower(fmt.Sprintf("%s-%s.%s.%s", s.ClusterName(), hash, location, s.AzureClients.ResourceManagerVMDNSSuffix))
 }
 
 // GenerateLegacyFQDN generates an IP name and a fully qualified domain name, based on a hash, cluster name and cluster location.
@@ -686,11 +2175,16 @@ func (s *ClusterScope) PatchObject(ctx context.Context) error {
 			infrav1.NetworkInfrastructureReadyCondition,
 			infrav1.VnetPeeringReadyCondition,
 			infrav1.DisksReadyCondition,
+			infrav1.PublicIPPrefixesReadyCondition,
 			infrav1.NATGatewaysReadyCondition,
+			infrav1.LogAnalyticsWorkspaceReadyCondition,
+			infrav1.DiagnosticsResourceGroupReadyCondition,
 			infrav1.LoadBalancersReadyCondition,
 			infrav1.BastionHostReadyCondition,
+			infrav1.JumpboxReadyCondition,
 			infrav1.VNetReadyCondition,
 			infrav1.SubnetsReadyCondition,
+			infrav1.AvailabilitySetReadyCondition,
 		),
 	)
 
@@ -704,11 +2198,16 @@ func (s *ClusterScope) PatchObject(ctx context.Context) error {
 			infrav1.NetworkInfrastructureReadyCondition,
 			infrav1.VnetPeeringReadyCondition,
 			infrav1.DisksReadyCondition,
+			infrav1.PublicIPPrefixesReadyCondition,
 			infrav1.NATGatewaysReadyCondition,
+			infrav1.LogAnalyticsWorkspaceReadyCondition,
+			infrav1.DiagnosticsResourceGroupReadyCondition,
 			infrav1.LoadBalancersReadyCondition,
 			infrav1.BastionHostReadyCondition,
+			infrav1.JumpboxReadyCondition,
 			infrav1.VNetReadyCondition,
 			infrav1.SubnetsReadyCondition,
+			infrav1.AvailabilitySetReadyCondition,
 		}})
 }
 
@@ -717,15 +2216,115 @@ func (s *ClusterScope) Close(ctx context.Context) error {
 	return s.PatchObject(ctx)
 }
 
-// AdditionalTags returns AdditionalTags from the scope's AzureCluster.
+// AdditionalTags returns AdditionalTags from the scope's AzureCluster, merged over the default tags.
 func (s *ClusterScope) AdditionalTags() infrav1.Tags {
 	tags := make(infrav1.Tags)
-	if s.AzureCluster.Spec.AdditionalTags != nil {
-		tags = s.AzureCluster.Spec.AdditionalTags.DeepCopy()
+	// Start with the default tags...
+	tags.Merge(s.defaultTags)
+	// ... and merge in the cluster's
+	tags.Merge(s.AzureCluster.Spec.AdditionalTags)
+	if s.expectedEnvironment != "" {
+		tags[azure.EnvironmentTagKey] = s.expectedEnvironment
+	}
+	if schedule := s.AutoShutdownSchedule(); schedule != nil {
+		tags[azure.AutoShutdownTagKey] = autoShutdownTagValue(schedule)
 	}
 	return tags
 }
 
+// autoShutdownTagValue returns the value of the auto-shutdown tag for a schedule.
+func autoShutdownTagValue(schedule *infrav1.AutoShutdownSchedule) string {
+	timeZone := schedule.TimeZone
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	return schedule.Time + " " + timeZone
+}
+
+// ExpectedEnvironment returns the value of the environment tag the existing Azure resources must have to be adopted
+// or deleted, if any.
+func (s *ClusterScope) ExpectedEnvironment() string {
+	return s.expectedEnvironment
+}
+
+// ValidateCostCenter checks the costCenter tag applied to the resources of the cluster is one of the allowed cost
+// centers. Any value is accepted when no cost center is allowed explicitly.
+func (s *ClusterScope) ValidateCostCenter() error {
+	if len(s.allowedCostCenters) == 0 {
+		return nil
+	}
+
+	costCenter, ok := s.AdditionalTags()[azure.CostCenterTagKey]
+	if !ok || costCenter == "" {
+		return errors.Errorf("the %q tag is required and must be one of %s", azure.CostCenterTagKey, strings.Join(s.allowedCostCenters, ", "))
+	}
+	for _, allowed := range s.allowedCostCenters {
+		if costCenter == allowed {
+			return nil
+		}
+	}
+
+	return errors.Errorf("the %q tag %q is not one of the allowed cost centers %s", azure.CostCenterTagKey, costCenter, strings.Join(s.allowedCostCenters, ", "))
+}
+
+// ValidateSecurityGroupTags checks the network security groups of the cluster have a value for each of the required
+// tags, e.g. the data classification their compliance policies rely on.
+func (s *ClusterScope) ValidateSecurityGroupTags() error {
+	if len(s.requiredNSGTags) == 0 {
+		return nil
+	}
+
+	var missing []string
+	for _, nsgSpec := range s.NSGSpecs() {
+		var missingTags []string
+		for _, tag := range s.requiredNSGTags {
+			if nsgSpec.Tags[tag] == "" {
+				missingTags = append(missingTags, tag)
+			}
+		}
+		if len(missingTags) > 0 {
+			missing = append(missing, fmt.Sprintf("%s (%s)", nsgSpec.Name, strings.Join(missingTags, ", ")))
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Errorf("the security groups %s are missing required tags, the tags %s are required", strings.Join(missing, ", "), strings.Join(s.requiredNSGTags, ", "))
+	}
+	return nil
+}
+
+// RequiredRegistrations returns the resource providers the resources of the cluster belong to, followed by the
+// features of the resource providers the cluster requires.
+func (s *ClusterScope) RequiredRegistrations() []registrations.Requirement {
+	requirements := []registrations.Requirement{{Namespace: "Microsoft.Network"}}
+	if !s.IsNetworkOnly() {
+		requirements = append(requirements, registrations.Requirement{Namespace: "Microsoft.Compute"})
+	}
+	if s.AzureCluster.Spec.LogAnalyticsWorkspace != nil {
+		requirements = append(requirements, registrations.Requirement{Namespace: "Microsoft.OperationalInsights"})
+	}
+	for _, spec := range s.DiagnosticSettingsSpecs() {
+		if spec.Settings != nil {
+			requirements = append(requirements, registrations.Requirement{Namespace: "Microsoft.Insights"})
+			break
+		}
+	}
+	for _, feature := range s.AzureCluster.Spec.RequiredFeatures {
+		requirements = append(requirements, registrations.Requirement{Namespace: feature.Namespace, Feature: feature.Name})
+	}
+	return requirements
+}
+
+// RegisterResourceProviders returns true if the required resource providers and features that aren't registered in
+// the subscription of the cluster must be registered.
+func (s *ClusterScope) RegisterResourceProviders() bool {
+	return s.registerProviders
+}
+
+// PhaseTimeouts returns the maximum durations of the phases of the reconciliation of the cluster.
+func (s *ClusterScope) PhaseTimeouts() reconciler.PhaseTimeouts {
+	return s.phaseTimeouts.Defaulted()
+}
+
 // APIServerPort returns the APIServerPort to use when creating the load balancer.
 func (s *ClusterScope) APIServerPort() int32 {
 	if s.Cluster.Spec.ClusterNetwork != nil && s.Cluster.Spec.ClusterNetwork.APIServerPort != nil {
@@ -734,14 +2333,116 @@ func (s *ClusterScope) APIServerPort() int32 {
 	return 6443
 }
 
+// APIServerLBPort returns the port the API server is reached on through the API server load balancer: the frontend
+// port of the cluster on a shared load balancer, and the API server port otherwise.
+func (s *ClusterScope) APIServerLBPort() int32 {
+	if shared := s.APIServerLB().Shared; shared != nil {
+		return shared.FrontendPort
+	}
+	return s.APIServerPort()
+}
+
 // APIServerHost returns the hostname used to reach the API server.
+// When a Traffic Manager is configured, this is the FQDN of the Traffic Manager profile, and when a cross-region load
+// balancer is configured, the FQDN of its global public IP.
 func (s *ClusterScope) APIServerHost() string {
+	if tm := s.TrafficManager(); tm != nil {
+		return azure.GenerateTrafficManagerFQDN(tm.DNSPrefix, s.Environment.TrafficManagerDNSSuffix)
+	}
+	if glb := s.GlobalLB(); glb != nil {
+		return glb.PublicIP.DNSName
+	}
+	return s.APIServerLBHost()
+}
+
+// APIServerLBHost returns the hostname of the API server load balancer.
+func (s *ClusterScope) APIServerLBHost() string {
 	if s.IsAPIServerPrivate() {
 		return azure.GeneratePrivateFQDN(s.GetPrivateDNSZoneName())
 	}
 	return s.APIServerPublicIP().DNSName
 }
 
+// APIServerEndpoints returns the endpoints that can be used to reach the control plane, in order of preference: the
+// FQDN of the Traffic Manager profile or of the global public IP of the cross-region load balancer, if configured,
+// then the regional endpoints of the API server load balancer, and its internal endpoint.
+func (s *ClusterScope) APIServerEndpoints() []clusterv1.APIEndpoint {
+	var endpoints []clusterv1.APIEndpoint
+	if tm := s.TrafficManager(); tm != nil {
+		endpoints = append(endpoints, clusterv1.APIEndpoint{
+			Host: azure.GenerateTrafficManagerFQDN(tm.DNSPrefix, s.Environment.TrafficManagerDNSSuffix),
+			Port: s.APIServerPort(),
+		})
+	}
+	if glb := s.GlobalLB(); glb != nil && glb.PublicIP != nil {
+		endpoints = append(endpoints, clusterv1.APIEndpoint{Host: glb.PublicIP.DNSName, Port: s.APIServerPort()})
+	}
+	endpoints = append(endpoints, s.APIServerRegionalEndpoints()...)
+	return append(endpoints, s.APIServerInternalEndpoint())
+}
+
+// APIServerRegionalEndpoints returns the endpoints of the API server load balancer in the region of the cluster: its
+// host, then the DNS names of the public IPs of its other frontends. They are the endpoints a Traffic Manager profile
+// routes to.
+func (s *ClusterScope) APIServerRegionalEndpoints() []clusterv1.APIEndpoint {
+	endpoints := []clusterv1.APIEndpoint{{Host: s.APIServerLBHost(), Port: s.APIServerLBPort()}}
+	if s.IsAPIServerPrivate() || len(s.APIServerLB().FrontendIPs) == 0 {
+		return endpoints
+	}
+	for _, frontendIP := range s.APIServerLB().FrontendIPs[1:] {
+		if frontendIP.PublicIP != nil && frontendIP.PublicIP.DNSName != "" {
+			endpoints = append(endpoints, clusterv1.APIEndpoint{Host: frontendIP.PublicIP.DNSName, Port: s.APIServerLBPort()})
+		}
+	}
+	return endpoints
+}
+
+// APIServerInternalEndpoint returns the endpoint of the static private IP of the API server: the private IP of an
+// internal API server load balancer, or of the internal frontend of a public one, to be preferred over the public
+// endpoint by clients running in the virtual network. It is empty for a public API server load balancer without an
+// internal frontend.
+func (s *ClusterScope) APIServerInternalEndpoint() clusterv1.APIEndpoint {
+	if s.IsAPIServerPrivate() {
+		if len(s.APIServerLB().FrontendIPs) == 0 || s.APIServerLB().FrontendIPs[0].PrivateIPAddress == "" {
+			return clusterv1.APIEndpoint{}
+		}
+		return clusterv1.APIEndpoint{Host: s.APIServerLB().FrontendIPs[0].PrivateIPAddress, Port: s.APIServerPort()}
+	}
+	if s.APIServerLB().InternalFrontendIP == nil {
+		return clusterv1.APIEndpoint{}
+	}
+	return clusterv1.APIEndpoint{Host: s.APIServerLB().InternalFrontendIP.PrivateIPAddress, Port: s.APIServerPort()}
+}
+
+// SetControlPlaneEndpoints sets the endpoints that can be used to reach the control plane, in order. Empty and
+// duplicate endpoints are skipped, and the endpoints previously reported but no longer registered, e.g. because their
+// load balancer frontend was removed from the spec, are pruned.
+func (s *ClusterScope) SetControlPlaneEndpoints(endpoints ...clusterv1.APIEndpoint) {
+	var registered []clusterv1.APIEndpoint
+	for _, endpoint := range endpoints {
+		if endpoint.IsZero() || containsEndpoint(registered, endpoint) {
+			continue
+		}
+		registered = append(registered, endpoint)
+	}
+	s.AzureCluster.Status.ControlPlaneEndpoints = registered
+}
+
+// containsEndpoint returns true if the endpoint is in the list.
+func containsEndpoint(endpoints []clusterv1.APIEndpoint, endpoint clusterv1.APIEndpoint) bool {
+	for _, existing := range endpoints {
+		if existing == endpoint {
+			return true
+		}
+	}
+	return false
+}
+
+// ControlPlaneEndpoints returns the endpoints that can be used to reach the control plane.
+func (s *ClusterScope) ControlPlaneEndpoints() []clusterv1.APIEndpoint {
+	return s.AzureCluster.Status.ControlPlaneEndpoints
+}
+
 // SetFailureDomain will set the spec for a for a given key.
 func (s *ClusterScope) SetFailureDomain(id string, spec clusterv1.FailureDomainSpec) {
 	if s.AzureCluster.Status.FailureDomains == nil {
@@ -750,6 +2451,250 @@ func (s *ClusterScope) SetFailureDomain(id string, spec clusterv1.FailureDomainS
 	s.AzureCluster.Status.FailureDomains[id] = spec
 }
 
+// ResourceGroupLocation returns the location of the resource group, which defaults to the location of the cluster.
+func (s *ClusterScope) ResourceGroupLocation() string {
+	if s.AzureCluster.Spec.ResourceGroupLocation == "" {
+		return s.Location()
+	}
+	return s.AzureCluster.Spec.ResourceGroupLocation
+}
+
+// SetResourceGroupLocation records the location of the resource group reported by Azure in the AzureCluster status,
+// along with the location the resources of the cluster are deployed to.
+func (s *ClusterScope) SetResourceGroupLocation(location string) {
+	s.AzureCluster.Status.ResourceGroupLocation = location
+	s.AzureCluster.Status.Location = s.Location()
+}
+
+// DefaultSpotPolicy returns the default Spot VM policy of the cluster.
+func (s *ClusterScope) DefaultSpotPolicy() *infrav1.SpotPolicy {
+	return s.AzureCluster.Spec.DefaultSpotPolicy
+}
+
+// SetDefaultSpotPolicy records the default Spot VM policy of the cluster in the AzureCluster status.
+func (s *ClusterScope) SetDefaultSpotPolicy(policy *infrav1.SpotPolicy) {
+	s.AzureCluster.Status.DefaultSpotPolicy = policy.DeepCopy()
+}
+
+// AutoShutdownSchedule returns the auto-shutdown schedule of the cluster.
+func (s *ClusterScope) AutoShutdownSchedule() *infrav1.AutoShutdownSchedule {
+	return s.AzureCluster.Spec.AutoShutdown
+}
+
+// SetAutoShutdownSchedule records the auto-shutdown schedule of the cluster in the AzureCluster status.
+func (s *ClusterScope) SetAutoShutdownSchedule(schedule *infrav1.AutoShutdownSchedule) {
+	s.AzureCluster.Status.AutoShutdown = schedule.DeepCopy()
+}
+
+// SetResourceGroupTags records the tags of the resource group reported by Azure, for the resources of the cluster to
+// inherit them.
+func (s *ClusterScope) SetResourceGroupTags(tags infrav1.Tags) {
+	s.resourceGroupTags = tags
+}
+
+// SetPairedRegion records the region paired with the location of the cluster in the AzureCluster status.
+func (s *ClusterScope) SetPairedRegion(region string) {
+	s.AzureCluster.Status.PairedRegion = region
+}
+
+// OutboundConnectivityCheckSpecs returns the outbound connectivity check specs of the node subnets.
+func (s *ClusterScope) OutboundConnectivityCheckSpecs() []azure.OutboundConnectivityCheckSpec {
+	check := s.AzureCluster.Spec.NetworkSpec.OutboundConnectivityCheck
+	if check == nil {
+		return nil
+	}
+
+	specs := make([]azure.OutboundConnectivityCheckSpec, 0, len(s.NodeSubnets()))
+	for _, subnet := range s.NodeSubnets() {
+		specs = append(specs, azure.OutboundConnectivityCheckSpec{
+			VNetResourceGroup:           s.Vnet().ResourceGroup,
+			VNetName:                    s.Vnet().Name,
+			SubnetName:                  subnet.Name,
+			DestinationIP:               check.DestinationIP,
+			Port:                        check.Port,
+			Protocol:                    check.Protocol,
+			NetworkWatcherName:          check.NetworkWatcherName,
+			NetworkWatcherResourceGroup: check.NetworkWatcherResourceGroup,
+		})
+	}
+	return specs
+}
+
+// SetOutboundConnectivityVerified marks the outbound connectivity of the node subnets as verified.
+func (s *ClusterScope) SetOutboundConnectivityVerified() {
+	conditions.MarkTrue(s.AzureCluster, infrav1.OutboundConnectivityCondition)
+}
+
+// SetOutboundConnectivityNotVerified marks the outbound connectivity of the node subnets as not verified.
+func (s *ClusterScope) SetOutboundConnectivityNotVerified(reason string, severity clusterv1.ConditionSeverity, messageFormat string, messageArgs ...interface{}) {
+	conditions.MarkFalse(s.AzureCluster, infrav1.OutboundConnectivityCondition, reason, severity, messageFormat, messageArgs...)
+}
+
+// ResourceHealthResourceIDs returns the resource IDs of the key resources of the cluster whose health is reported by
+// Azure Resource Health: its load balancers.
+func (s *ClusterScope) ResourceHealthResourceIDs() []string {
+	lbs := []*infrav1.LoadBalancerSpec{s.APIServerLB(), s.NodeOutboundLB(), s.ControlPlaneOutboundLB()}
+	ids := make([]string, 0, len(lbs))
+	for _, lb := range lbs {
+		if lb != nil && lb.Name != "" {
+			ids = append(ids, azure.LoadBalancerID(s.SubscriptionID(), s.ResourceGroup(), lb.Name))
+		}
+	}
+	return ids
+}
+
+// SetResourcesHealthy marks the key resources of the cluster as healthy.
+func (s *ClusterScope) SetResourcesHealthy() {
+	conditions.MarkTrue(s.AzureCluster, infrav1.ResourcesHealthyCondition)
+}
+
+// SetResourcesNotHealthy marks the key resources of the cluster as not healthy.
+func (s *ClusterScope) SetResourcesNotHealthy(reason string, severity clusterv1.ConditionSeverity, messageFormat string, messageArgs ...interface{}) {
+	conditions.MarkFalse(s.AzureCluster, infrav1.ResourcesHealthyCondition, reason, severity, messageFormat, messageArgs...)
+}
+
+// PrivateEndpointSpecs returns the private endpoint specs.
+func (s *ClusterScope) PrivateEndpointSpecs() []azure.ResourceSpecGetter {
+	endpoints := s.AzureCluster.Spec.NetworkSpec.PrivateEndpoints
+	specs := make([]azure.ResourceSpecGetter, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		specs = append(specs, &privateendpoints.PrivateEndpointSpec{
+			Name:              endpoint.Name,
+			ResourceGroup:     s.ResourceGroup(),
+			SubscriptionID:    s.SubscriptionID(),
+			Location:          s.Location(),
+			ClusterName:       s.ClusterName(),
+			VNetName:          s.Vnet().Name,
+			VNetResourceGroup: s.Vnet().ResourceGroup,
+			SubnetName:        endpoint.SubnetName,
+			ResourceID:        endpoint.ResourceID,
+			GroupID:           endpoint.GroupID,
+			PrivateDNSZoneID:  endpoint.PrivateDNSZoneID,
+			AdditionalTags:    s.AdditionalTags(),
+		})
+	}
+	return specs
+}
+
+// PrivateEndpointIPs returns the private IPs of the private endpoints, by name, as last reconciled.
+func (s *ClusterScope) PrivateEndpointIPs() map[string]string {
+	return s.AzureCluster.Status.PrivateEndpointIPs
+}
+
+// SetPrivateEndpointIPs sets the private IPs of the private endpoints, by name.
+func (s *ClusterScope) SetPrivateEndpointIPs(ips map[string]string) {
+	if len(ips) == 0 {
+		ips = nil
+	}
+	s.AzureCluster.Status.PrivateEndpointIPs = ips
+}
+
+// hostsPrivateEndpoints returns true if a private endpoint of the cluster is in the subnet.
+func (s *ClusterScope) hostsPrivateEndpoints(subnetName string) bool {
+	for _, endpoint := range s.AzureCluster.Spec.NetworkSpec.PrivateEndpoints {
+		if endpoint.SubnetName == subnetName {
+			return true
+		}
+	}
+	return false
+}
+
+// GalleryImage returns the gallery image version the machines of the cluster are built from.
+func (s *ClusterScope) GalleryImage() *infrav1.GalleryImage {
+	return s.AzureCluster.Spec.Gallery
+}
+
+// SetGalleryImageID records the resource ID of the resolved gallery image version in the AzureCluster status.
+func (s *ClusterScope) SetGalleryImageID(id string) {
+	s.AzureCluster.Status.GalleryImageID = id
+}
+
+// PolicyAssignments returns the Azure Policy assignments of the resource group of the cluster.
+func (s *ClusterScope) PolicyAssignments() []infrav1.PolicyAssignment {
+	return s.AzureCluster.Spec.PolicyAssignments
+}
+
+// PolicyAssignmentIDs returns the IDs of the policy assignments in effect, by name, as last reconciled.
+func (s *ClusterScope) PolicyAssignmentIDs() map[string]string {
+	return s.AzureCluster.Status.PolicyAssignmentIDs
+}
+
+// SetPolicyAssignmentIDs sets the IDs of the policy assignments in effect, by name.
+func (s *ClusterScope) SetPolicyAssignmentIDs(ids map[string]string) {
+	if len(ids) == 0 {
+		ids = nil
+	}
+	s.AzureCluster.Status.PolicyAssignmentIDs = ids
+}
+
+// SetPolicyAssignmentsReady marks the policy assignments of the resource group as ready.
+func (s *ClusterScope) SetPolicyAssignmentsReady() {
+	conditions.MarkTrue(s.AzureCluster, infrav1.PolicyAssignmentsReadyCondition)
+}
+
+// SetPolicyAssignmentsNotReady marks the policy assignments of the resource group as not ready.
+func (s *ClusterScope) SetPolicyAssignmentsNotReady(reason string, severity clusterv1.ConditionSeverity, messageFormat string, messageArgs ...interface{}) {
+	conditions.MarkFalse(s.AzureCluster, infrav1.PolicyAssignmentsReadyCondition, reason, severity, messageFormat, messageArgs...)
+}
+
+// RoleAssignments returns the Azure role assignments of the resource group of the cluster.
+func (s *ClusterScope) RoleAssignments() []infrav1.RoleAssignment {
+	return s.AzureCluster.Spec.RoleAssignments
+}
+
+// RoleAssignmentIDs returns the IDs of the role assignments in effect, by name, as last reconciled.
+func (s *ClusterScope) RoleAssignmentIDs() map[string]string {
+	return s.AzureCluster.Status.RoleAssignmentIDs
+}
+
+// SetRoleAssignmentIDs sets the IDs of the role assignments in effect, by name.
+func (s *ClusterScope) SetRoleAssignmentIDs(ids map[string]string) {
+	if len(ids) == 0 {
+		ids = nil
+	}
+	s.AzureCluster.Status.RoleAssignmentIDs = ids
+}
+
+// SetRoleAssignmentsReady marks the role assignments of the resource group as ready.
+func (s *ClusterScope) SetRoleAssignmentsReady() {
+	conditions.MarkTrue(s.AzureCluster, infrav1.RoleAssignmentsReadyCondition)
+}
+
+// SetRoleAssignmentsNotReady marks the role assignments of the resource group as not ready.
+func (s *ClusterScope) SetRoleAssignmentsNotReady(reason string, severity clusterv1.ConditionSeverity, messageFormat string, messageArgs ...interface{}) {
+	conditions.MarkFalse(s.AzureCluster, infrav1.RoleAssignmentsReadyCondition, reason, severity, messageFormat, messageArgs...)
+}
+
+// Budget returns the Cost Management budget of the resource group of the cluster.
+func (s *ClusterScope) Budget() *infrav1.BudgetSpec {
+	return s.AzureCluster.Spec.Budget
+}
+
+// BudgetID returns the ID of the budget of the resource group, as last reconciled.
+func (s *ClusterScope) BudgetID() string {
+	return s.AzureCluster.Status.BudgetID
+}
+
+// SetBudgetID sets the ID of the budget of the resource group.
+func (s *ClusterScope) SetBudgetID(id string) {
+	s.AzureCluster.Status.BudgetID = id
+}
+
+// SetBudgetReady marks the budget of the resource group as ready.
+func (s *ClusterScope) SetBudgetReady() {
+	conditions.MarkTrue(s.AzureCluster, infrav1.BudgetReadyCondition)
+}
+
+// SetBudgetNotReady marks the budget of the resource group as not ready.
+func (s *ClusterScope) SetBudgetNotReady(reason string, severity clusterv1.ConditionSeverity, messageFormat string, messageArgs ...interface{}) {
+	conditions.MarkFalse(s.AzureCluster, infrav1.BudgetReadyCondition, reason, severity, messageFormat, messageArgs...)
+}
+
+// SetCostEstimate sets the estimated monthly cost of the network resources of the cluster, or removes it when nil.
+func (s *ClusterScope) SetCostEstimate(estimate *infrav1.CostEstimate) {
+	s.AzureCluster.Status.EstimatedCost = estimate
+}
+
 // FailureDomains returns the failure domains for the cluster.
 func (s *ClusterScope) FailureDomains() []string {
 	fds := make([]string, len(s.AzureCluster.Status.FailureDomains))
@@ -794,6 +2739,26 @@ func (s *ClusterScope) SetControlPlaneSecurityRules() {
 	}
 }
 
+// SetGeneratedSecurityRules records in the AzureCluster status the security rules generated from the inbound traffic
+// intents and the egress policy of each security group, for the user to review.
+func (s *ClusterScope) SetGeneratedSecurityRules() {
+	var generated map[string]infrav1.SecurityRules
+	if s.IsVnetManaged() {
+		for _, nsg := range s.NSGSpecs() {
+			for _, rule := range nsg.SecurityRules {
+				if !strings.HasPrefix(rule.Name, infrav1.IntentSecurityRuleNamePrefix) && !strings.HasPrefix(rule.Name, infrav1.EgressSecurityRuleNamePrefix) {
+					continue
+				}
+				if generated == nil {
+					generated = make(map[string]infrav1.SecurityRules)
+				}
+				generated[nsg.Name] = append(generated[nsg.Name], rule)
+			}
+		}
+	}
+	s.AzureCluster.Status.GeneratedSecurityRules = generated
+}
+
 // SetDNSName sets the API Server public IP DNS name.
 // Note: this logic exists only for purposes of ensuring backwards compatibility for old clusters created without an APIServerLB, and should be removed in the future.
 func (s *ClusterScope) SetDNSName() {
@@ -825,29 +2790,38 @@ func (s *ClusterScope) SetDNSName() {
 	if !s.IsAPIServerPrivate() && s.APIServerPublicIP().DNSName == "" {
 		s.APIServerPublicIP().DNSName = s.GenerateFQDN(s.APIServerPublicIP().Name)
 	}
+	if glb := s.GlobalLB(); glb != nil && glb.PublicIP != nil && glb.PublicIP.DNSName == "" {
+		glb.PublicIP.DNSName = s.generateFQDN(glb.PublicIP.Name, glb.Location)
+	}
 }
 
-// getOutboundLBPublicIPSpecs returns the public ip specs for a LoadBalancerSpec based on the number of frontend ips configured.
-func (s *ClusterScope) getOutboundLBPublicIPSpecs(outboundLB *infrav1.LoadBalancerSpec, generateOutboundIPName func(string) string) []azure.PublicIPSpec {
+// getOutboundLBPublicIPSpecs returns the public ip specs for the frontend ips of an outbound LoadBalancerSpec, named
+// after the public IPs of the frontend ips generated from the number of frontend ips configured.
+func (s *ClusterScope) getOutboundLBPublicIPSpecs(outboundLB *infrav1.LoadBalancerSpec) []azure.PublicIPSpec {
 	var outboundIPSpecs []azure.PublicIPSpec
-	loadBalancerNodeOutboundIPs := outboundLB.FrontendIPsCount
-	switch {
-	case loadBalancerNodeOutboundIPs == nil || *loadBalancerNodeOutboundIPs == 0:
-		// do nothing
-	case *loadBalancerNodeOutboundIPs == 1:
-		outboundIPSpecs = append(outboundIPSpecs, azure.PublicIPSpec{
-			Name: generateOutboundIPName(s.ClusterName()),
-		})
-	default:
-		for i := 0; i < int(*loadBalancerNodeOutboundIPs); i++ {
-			outboundIPSpecs = append(outboundIPSpecs, azure.PublicIPSpec{
-				Name: azure.WithIndex(generateOutboundIPName(s.ClusterName()), i+1),
-			})
+	for _, frontendIP := range outboundLB.FrontendIPs {
+		if frontendIP.PublicIP == nil {
+			continue
 		}
+		outboundIPSpecs = append(outboundIPSpecs, frontendPublicIPSpec(outboundLB, frontendIP.PublicIP))
 	}
 	return outboundIPSpecs
 }
 
+// frontendPublicIPSpec returns the spec of the public IP of a frontend IP of a load balancer, with its name, zones, IP
+// tags, routing preference, public IP prefix and diagnostic setting.
+func frontendPublicIPSpec(lb *infrav1.LoadBalancerSpec, publicIP *infrav1.PublicIPSpec) azure.PublicIPSpec {
+	return azure.PublicIPSpec{
+		Name:               publicIP.Name,
+		Zones:              publicIP.Zones,
+		IPTags:             publicIP.IPTags,
+		RoutingPreference:  publicIP.RoutingPreference,
+		IPPrefixID:         publicIP.IPPrefixID,
+		DiagnosticSettings: publicIP.DiagnosticSettings,
+		ForceRecreate:      lb.ForceRecreate,
+	}
+}
+
 // SetLongRunningOperationState will set the future on the AzureCluster status to allow the resource to continue
 // in the next reconciliation.
 func (s *ClusterScope) SetLongRunningOperationState(future *infrav1.Future) {
@@ -941,13 +2915,92 @@ func (s *ClusterScope) SetAnnotation(key, value string) {
 	s.AzureCluster.Annotations[key] = value
 }
 
+// DiagnosticSettingsSpecs returns the diagnostic setting specs of the load balancers, security groups and public IPs
+// of the AzureCluster.
+func (s *ClusterScope) DiagnosticSettingsSpecs() []azure.DiagnosticSettingsSpec {
+	lbID := func(lbName string) string {
+		return azure.LoadBalancerID(s.SubscriptionID(), s.ResourceGroup(), lbName)
+	}
+
+	specs := []azure.DiagnosticSettingsSpec{s.diagnosticSettingsSpec(lbID(s.APIServerLB().Name), s.APIServerLB().DiagnosticSettings)}
+	if s.APIServerLB().InternalFrontendIP != nil && !s.IsAPIServerPrivate() {
+		specs = append(specs, s.diagnosticSettingsSpec(lbID(infrav1.GenerateInternalFrontendLBName(s.APIServerLB().Name)), s.APIServerLB().DiagnosticSettings))
+	}
+	if s.NodeOutboundLB() != nil {
+		specs = append(specs, s.diagnosticSettingsSpec(lbID(s.NodeOutboundLBName()), s.NodeOutboundLB().DiagnosticSettings))
+	}
+	if s.ControlPlaneOutboundLB() != nil {
+		specs = append(specs, s.diagnosticSettingsSpec(lbID(s.ControlPlaneOutboundLB().Name), s.ControlPlaneOutboundLB().DiagnosticSettings))
+	}
+	// The security groups are only managed with the virtual network.
+	if s.IsVnetManaged() {
+		for _, nsg := range s.NSGSpecs() {
+			specs = append(specs, s.diagnosticSettingsSpec(azure.SecurityGroupID(s.SubscriptionID(), s.ResourceGroup(), nsg.Name), nsg.DiagnosticSettings))
+		}
+	}
+	for _, ip := range s.PublicIPSpecs() {
+		specs = append(specs, s.diagnosticSettingsSpec(azure.PublicIPID(s.SubscriptionID(), s.ResourceGroup(), ip.Name), ip.DiagnosticSettings))
+	}
+	return specs
+}
+
+// diagnosticSettingsSpec returns the spec of the diagnostic setting of the cluster on a resource.
+func (s *ClusterScope) diagnosticSettingsSpec(resourceID string, settings *infrav1.DiagnosticSettings) azure.DiagnosticSettingsSpec {
+	return azure.DiagnosticSettingsSpec{
+		Name:       azure.GenerateDiagnosticSettingName(s.ClusterName()),
+		ResourceID: resourceID,
+		Settings:   settings,
+	}
+}
+
 // TagsSpecs returns the tag specs for the AzureCluster.
 func (s *ClusterScope) TagsSpecs() []azure.TagsSpec {
-	return []azure.TagsSpec{
+	specs := []azure.TagsSpec{
 		{
 			Scope:      azure.ResourceGroupID(s.SubscriptionID(), s.ResourceGroup()),
 			Tags:       s.AdditionalTags(),
 			Annotation: azure.RGTagsLastAppliedAnnotation,
 		},
 	}
+	if !s.AzureCluster.Spec.InheritResourceGroupTags || s.resourceGroupTags == nil {
+		return specs
+	}
+
+	inheritedTags := s.inheritedTags()
+	var scopes []string
+	if s.IsVnetManaged() {
+		scopes = append(scopes, azure.VNetID(s.SubscriptionID(), s.Vnet().ResourceGroup, s.Vnet().Name))
+	}
+	for _, lbSpec := range s.LBSpecs() {
+		scopes = append(scopes, azure.LoadBalancerID(s.SubscriptionID(), lbSpec.ResourceGroupName(), lbSpec.ResourceName()))
+	}
+	for _, ipSpec := range s.PublicIPSpecs() {
+		scopes = append(scopes, azure.PublicIPID(s.SubscriptionID(), s.ResourceGroup(), ipSpec.Name))
+	}
+	for _, scope := range scopes {
+		specs = append(specs, azure.TagsSpec{
+			Scope:      scope,
+			Tags:       inheritedTags,
+			Annotation: azure.InheritedTagsLastAppliedAnnotation,
+			Inherited:  true,
+		})
+	}
+	return specs
+}
+
+// inheritedTags returns the tags of the resource group the resources of the cluster inherit: the tags CAPZ sets on the
+// resource group itself, i.e. its additional tags and the tags identifying the cluster, aren't inherited.
+func (s *ClusterScope) inheritedTags() infrav1.Tags {
+	additionalTags := s.AdditionalTags()
+	tags := make(infrav1.Tags, len(s.resourceGroupTags))
+	for k, v := range s.resourceGroupTags {
+		if _, ok := additionalTags[k]; ok {
+			continue
+		}
+		if k == "Name" || strings.HasPrefix(k, infrav1.NameAzureProviderPrefix) || strings.HasPrefix(k, infrav1.NameKubernetesAzureCloudProviderPrefix) {
+			continue
+		}
+		tags[k] = v
+	}
+	return tags
 }
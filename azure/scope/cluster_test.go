@@ -18,13 +18,23 @@ package scope
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/Azure/go-autorest/autorest/to"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/registrations"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -108,6 +118,65 @@ func TestAPIServerHost(t *testing.T) {
 			},
 			want: "apiserver.example.private",
 		},
+		{
+			name: "public apiserver lb behind traffic manager",
+			azureCluster: infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						SubscriptionID: fakeSubscriptionID,
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						APIServerLB: infrav1.LoadBalancerSpec{
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type: infrav1.Public,
+								FrontendIPs: []infrav1.FrontendIP{
+									{
+										PublicIP: &infrav1.PublicIPSpec{
+											DNSName: "my-cluster-apiserver.example.com",
+										},
+									},
+								},
+							},
+						},
+						TrafficManager: &infrav1.TrafficManagerSpec{
+							DNSPrefix: "my-apiserver",
+						},
+					},
+				},
+			},
+			want: "my-apiserver.trafficmanager.net",
+		},
+		{
+			name: "public apiserver lb behind cross-region load balancer",
+			azureCluster: infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						SubscriptionID: fakeSubscriptionID,
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						APIServerLB: infrav1.LoadBalancerSpec{
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type: infrav1.Public,
+								FrontendIPs: []infrav1.FrontendIP{
+									{
+										PublicIP: &infrav1.PublicIPSpec{
+											DNSName: "my-cluster-apiserver.example.com",
+										},
+									},
+								},
+							},
+						},
+						GlobalLB: &infrav1.GlobalLoadBalancerSpec{
+							Location: "eastus2",
+							PublicIP: &infrav1.PublicIPSpec{
+								DNSName: "my-cluster-global.example.com",
+							},
+						},
+					},
+				},
+			},
+			want: "my-cluster-global.example.com",
+		},
 	}
 
 	for _, tc := range tests {
@@ -216,6 +285,576 @@ func TestGettingSecurityRules(t *testing.T) {
 	g.Expect(len(subnet.SecurityGroup.SecurityRules)).To(Equal(2))
 }
 
+func TestNSGSpecsLoadBalancerProbeRule(t *testing.T) {
+	newClusterScope := func(rules infrav1.SecurityRules) *ClusterScope {
+		return &ClusterScope{
+			Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"}},
+			AzureCluster: &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						APIServerLB: infrav1.LoadBalancerSpec{
+							Name: "my-lb",
+						},
+						Subnets: infrav1.Subnets{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetControlPlane},
+								SecurityGroup:   infrav1.SecurityGroup{Name: "my-cp-nsg", SecurityGroupClass: infrav1.SecurityGroupClass{SecurityRules: rules}},
+							},
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetNode},
+								SecurityGroup:   infrav1.SecurityGroup{Name: "my-node-nsg"},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("probe rule is added to the control plane security group", func(t *testing.T) {
+		g := NewWithT(t)
+		clusterScope := newClusterScope(nil)
+		clusterScope.SetControlPlaneSecurityRules()
+
+		nsgSpecs := clusterScope.NSGSpecs()
+		g.Expect(nsgSpecs).To(HaveLen(2))
+		g.Expect(nsgSpecs[0].SecurityRules).To(HaveLen(3))
+		probeRule := nsgSpecs[0].SecurityRules[2]
+		g.Expect(probeRule.Name).To(Equal("allow_lb_health_probes"))
+		g.Expect(probeRule.Priority).To(Equal(int32(2202)))
+		g.Expect(*probeRule.Source).To(Equal("AzureLoadBalancer"))
+		g.Expect(*probeRule.DestinationPorts).To(Equal("6443"))
+		g.Expect(nsgSpecs[1].SecurityRules).To(BeEmpty())
+
+		// the spec of the cluster is left as is.
+		g.Expect(clusterScope.ControlPlaneSubnet().SecurityGroup.SecurityRules).To(HaveLen(2))
+	})
+
+	t.Run("probe rule gets a priority not used by other inbound rules", func(t *testing.T) {
+		g := NewWithT(t)
+		clusterScope := newClusterScope(infrav1.SecurityRules{
+			{Name: "custom_1", Priority: 2202, Direction: infrav1.SecurityRuleDirectionInbound},
+			{Name: "custom_2", Priority: 2203, Direction: infrav1.SecurityRuleDirectionInbound},
+			{Name: "custom_3", Priority: 2204, Direction: infrav1.SecurityRuleDirectionOutbound},
+		})
+
+		rules := clusterScope.NSGSpecs()[0].SecurityRules
+		g.Expect(rules).To(HaveLen(4))
+		g.Expect(rules[3].Priority).To(Equal(int32(2204)))
+	})
+
+	t.Run("user-defined probe rule is kept", func(t *testing.T) {
+		g := NewWithT(t)
+		userRule := infrav1.SecurityRule{Name: "allow_lb_health_probes", Priority: 100, Direction: infrav1.SecurityRuleDirectionInbound}
+		clusterScope := newClusterScope(infrav1.SecurityRules{userRule})
+
+		g.Expect(clusterScope.NSGSpecs()[0].SecurityRules).To(Equal(infrav1.SecurityRules{userRule}))
+	})
+}
+
+func TestNSGSpecsInboundTrafficIntents(t *testing.T) {
+	newClusterScope := func(rules infrav1.SecurityRules, intents []infrav1.InboundTrafficIntent) *ClusterScope {
+		return &ClusterScope{
+			Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"}},
+			AzureCluster: &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetNode},
+								SecurityGroup: infrav1.SecurityGroup{
+									Name:               "my-node-nsg",
+									SecurityGroupClass: infrav1.SecurityGroupClass{SecurityRules: rules, AllowInboundFrom: intents},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("a rule is generated for each port of each intent", func(t *testing.T) {
+		g := NewWithT(t)
+		clusterScope := newClusterScope(nil, []infrav1.InboundTrafficIntent{
+			{Source: "192.168.0.0/16", Ports: []string{"80", "443"}, Description: "Allow HTTP(S) from the office"},
+			{Source: "AzureCloud", Ports: []string{"30000-32767"}, Protocol: infrav1.SecurityGroupProtocolUDP},
+		})
+
+		g.Expect(clusterScope.NSGSpecs()[0].SecurityRules).To(Equal(infrav1.SecurityRules{
+			{
+				Name:             "allow_inbound_from_0_0",
+				Description:      "Allow HTTP(S) from the office",
+				Priority:         3000,
+				Protocol:         infrav1.SecurityGroupProtocolTCP,
+				Direction:        infrav1.SecurityRuleDirectionInbound,
+				Source:           to.StringPtr("192.168.0.0/16"),
+				SourcePorts:      to.StringPtr("*"),
+				Destination:      to.StringPtr("*"),
+				DestinationPorts: to.StringPtr("80"),
+			},
+			{
+				Name:             "allow_inbound_from_0_1",
+				Description:      "Allow HTTP(S) from the office",
+				Priority:         3001,
+				Protocol:         infrav1.SecurityGroupProtocolTCP,
+				Direction:        infrav1.SecurityRuleDirectionInbound,
+				Source:           to.StringPtr("192.168.0.0/16"),
+				SourcePorts:      to.StringPtr("*"),
+				Destination:      to.StringPtr("*"),
+				DestinationPorts: to.StringPtr("443"),
+			},
+			{
+				Name:             "allow_inbound_from_1_0",
+				Description:      "Allow inbound traffic from AzureCloud",
+				Priority:         3002,
+				Protocol:         infrav1.SecurityGroupProtocolUDP,
+				Direction:        infrav1.SecurityRuleDirectionInbound,
+				Source:           to.StringPtr("AzureCloud"),
+				SourcePorts:      to.StringPtr("*"),
+				Destination:      to.StringPtr("*"),
+				DestinationPorts: to.StringPtr("30000-32767"),
+			},
+		}))
+	})
+
+	t.Run("generated rules skip the priorities of other inbound rules", func(t *testing.T) {
+		g := NewWithT(t)
+		clusterScope := newClusterScope(infrav1.SecurityRules{
+			{Name: "custom_1", Priority: 3000, Direction: infrav1.SecurityRuleDirectionInbound},
+			{Name: "custom_2", Priority: 3002, Direction: infrav1.SecurityRuleDirectionInbound},
+			{Name: "custom_3", Priority: 3001, Direction: infrav1.SecurityRuleDirectionOutbound},
+		}, []infrav1.InboundTrafficIntent{
+			{Source: "10.1.0.0/16", Ports: []string{"22", "443", "8443"}},
+		})
+
+		rules := clusterScope.NSGSpecs()[0].SecurityRules
+		g.Expect(rules).To(HaveLen(6))
+		priorities := []int32{rules[3].Priority, rules[4].Priority, rules[5].Priority}
+		g.Expect(priorities).To(Equal([]int32{3001, 3003, 3004}))
+	})
+
+	t.Run("generated rules are recorded in the status", func(t *testing.T) {
+		g := NewWithT(t)
+		clusterScope := newClusterScope(infrav1.SecurityRules{
+			{Name: "custom_1", Priority: 200, Direction: infrav1.SecurityRuleDirectionInbound},
+		}, []infrav1.InboundTrafficIntent{
+			{Source: "10.1.0.0/16", Ports: []string{"443"}},
+		})
+
+		clusterScope.SetGeneratedSecurityRules()
+		g.Expect(clusterScope.AzureCluster.Status.GeneratedSecurityRules).To(HaveLen(1))
+		g.Expect(clusterScope.AzureCluster.Status.GeneratedSecurityRules["my-node-nsg"]).To(HaveLen(1))
+		g.Expect(clusterScope.AzureCluster.Status.GeneratedSecurityRules["my-node-nsg"][0].Name).To(Equal("allow_inbound_from_0_0"))
+
+		clusterScope.AzureCluster.Spec.NetworkSpec.Subnets[0].SecurityGroup.AllowInboundFrom = nil
+		clusterScope.SetGeneratedSecurityRules()
+		g.Expect(clusterScope.AzureCluster.Status.GeneratedSecurityRules).To(BeNil())
+	})
+}
+
+func TestNSGSpecsEgressPolicy(t *testing.T) {
+	newClusterScope := func(rules infrav1.SecurityRules, intents []infrav1.OutboundTrafficIntent) *ClusterScope {
+		return &ClusterScope{
+			Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"}},
+			AzureCluster: &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						APIServerLB: infrav1.LoadBalancerSpec{
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{Type: infrav1.Internal},
+						},
+						Subnets: infrav1.Subnets{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetNode},
+								SecurityGroup: infrav1.SecurityGroup{
+									Name: "my-node-nsg",
+									SecurityGroupClass: infrav1.SecurityGroupClass{
+										SecurityRules:   rules,
+										EgressPolicy:    infrav1.EgressPolicyDenyByDefault,
+										AllowOutboundTo: intents,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("the required egress and the allowlist are allowed before the rest is denied", func(t *testing.T) {
+		g := NewWithT(t)
+		clusterScope := newClusterScope(nil, []infrav1.OutboundTrafficIntent{
+			{Destination: "MicrosoftContainerRegistry", Ports: []string{"443"}, Description: "Allow pulling images from MCR"},
+		})
+
+		g.Expect(clusterScope.NSGSpecs()[0].SecurityRules).To(Equal(infrav1.SecurityRules{
+			{
+				Name:             "egress_allow_virtual_network",
+				Description:      "Allow outbound traffic to the virtual network",
+				Priority:         3000,
+				Protocol:         infrav1.SecurityGroupProtocolAll,
+				Direction:        infrav1.SecurityRuleDirectionOutbound,
+				Source:           to.StringPtr("*"),
+				SourcePorts:      to.StringPtr("*"),
+				Destination:      to.StringPtr("VirtualNetwork"),
+				DestinationPorts: to.StringPtr("*"),
+			},
+			{
+				Name:             "egress_allow_dns",
+				Description:      "Allow outbound DNS queries",
+				Priority:         3001,
+				Protocol:         infrav1.SecurityGroupProtocolAll,
+				Direction:        infrav1.SecurityRuleDirectionOutbound,
+				Source:           to.StringPtr("*"),
+				SourcePorts:      to.StringPtr("*"),
+				Destination:      to.StringPtr("*"),
+				DestinationPorts: to.StringPtr("53"),
+			},
+			{
+				Name:             "egress_allow_azure_cloud",
+				Description:      "Allow outbound traffic to the Azure services",
+				Priority:         3002,
+				Protocol:         infrav1.SecurityGroupProtocolTCP,
+				Direction:        infrav1.SecurityRuleDirectionOutbound,
+				Source:           to.StringPtr("*"),
+				SourcePorts:      to.StringPtr("*"),
+				Destination:      to.StringPtr("AzureCloud"),
+				DestinationPorts: to.StringPtr("443"),
+			},
+			{
+				Name:             "egress_allow_0_0",
+				Description:      "Allow pulling images from MCR",
+				Priority:         3003,
+				Protocol:         infrav1.SecurityGroupProtocolTCP,
+				Direction:        infrav1.SecurityRuleDirectionOutbound,
+				Source:           to.StringPtr("*"),
+				SourcePorts:      to.StringPtr("*"),
+				Destination:      to.StringPtr("MicrosoftContainerRegistry"),
+				DestinationPorts: to.StringPtr("443"),
+			},
+			{
+				Name:             "egress_deny_all",
+				Description:      "Deny the outbound traffic not allowed explicitly",
+				Priority:         4096,
+				Protocol:         infrav1.SecurityGroupProtocolAll,
+				Direction:        infrav1.SecurityRuleDirectionOutbound,
+				Source:           to.StringPtr("*"),
+				SourcePorts:      to.StringPtr("*"),
+				Destination:      to.StringPtr("*"),
+				DestinationPorts: to.StringPtr("*"),
+				Action:           infrav1.SecurityRuleActionDeny,
+			},
+		}))
+		g.Expect(clusterScope.EgressPolicyWarnings()).To(BeEmpty())
+	})
+
+	t.Run("the public API server endpoint is allowed", func(t *testing.T) {
+		g := NewWithT(t)
+		clusterScope := newClusterScope(nil, nil)
+		clusterScope.AzureCluster.Spec.NetworkSpec.APIServerLB.Type = infrav1.Public
+
+		rules := clusterScope.NSGSpecs()[0].SecurityRules
+		g.Expect(rules).To(HaveLen(5))
+		g.Expect(rules[3].Name).To(Equal("egress_allow_api_server"))
+		g.Expect(rules[3].Destination).To(Equal(to.StringPtr("AzureCloud")))
+		g.Expect(rules[3].DestinationPorts).To(Equal(to.StringPtr("6443")))
+	})
+
+	t.Run("generated rules skip the priorities of other outbound rules", func(t *testing.T) {
+		g := NewWithT(t)
+		clusterScope := newClusterScope(infrav1.SecurityRules{
+			{Name: "custom_1", Priority: 3000, Direction: infrav1.SecurityRuleDirectionOutbound},
+			{Name: "custom_2", Priority: 3001, Direction: infrav1.SecurityRuleDirectionInbound},
+		}, nil)
+
+		rules := clusterScope.NSGSpecs()[0].SecurityRules
+		g.Expect(rules).To(HaveLen(6))
+		priorities := []int32{rules[2].Priority, rules[3].Priority, rules[4].Priority, rules[5].Priority}
+		g.Expect(priorities).To(Equal([]int32{3001, 3002, 3003, 4096}))
+	})
+
+	t.Run("deny rules before the required egress are reported", func(t *testing.T) {
+		g := NewWithT(t)
+		clusterScope := newClusterScope(infrav1.SecurityRules{
+			{Name: "deny_dns", Priority: 200, Direction: infrav1.SecurityRuleDirectionOutbound, Action: infrav1.SecurityRuleActionDeny},
+			{Name: "deny_internet", Priority: 3500, Direction: infrav1.SecurityRuleDirectionOutbound, Action: infrav1.SecurityRuleActionDeny},
+			{Name: "allow_proxy", Priority: 300, Direction: infrav1.SecurityRuleDirectionOutbound},
+		}, nil)
+
+		g.Expect(clusterScope.EgressPolicyWarnings()).To(ConsistOf(
+			"security rule deny_dns of security group my-node-nsg denies outbound traffic before the rules allowing the egress the cluster requires, which it may block",
+		))
+	})
+
+	t.Run("no rules are generated with the AllowAll policy", func(t *testing.T) {
+		g := NewWithT(t)
+		clusterScope := newClusterScope(nil, nil)
+		clusterScope.AzureCluster.Spec.NetworkSpec.Subnets[0].SecurityGroup.EgressPolicy = infrav1.EgressPolicyAllowAll
+
+		g.Expect(clusterScope.NSGSpecs()[0].SecurityRules).To(BeEmpty())
+	})
+}
+
+func TestNSGSpecsIntraClusterTraffic(t *testing.T) {
+	newClusterScope := func(intraClusterTraffic *infrav1.IntraClusterTraffic) *ClusterScope {
+		return &ClusterScope{
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: &clusterv1.ClusterNetwork{
+						Pods: &clusterv1.NetworkRanges{CIDRBlocks: []string{"192.168.0.0/16", "10.0.0.0/8"}},
+					},
+				},
+			},
+			AzureCluster: &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Vnet: infrav1.VnetSpec{
+							VnetClassSpec: infrav1.VnetClassSpec{CIDRBlocks: []string{"10.0.0.0/8"}},
+						},
+						Subnets: infrav1.Subnets{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetNode},
+								SecurityGroup: infrav1.SecurityGroup{
+									Name: "my-node-nsg",
+									SecurityGroupClass: infrav1.SecurityGroupClass{
+										SecurityRules: infrav1.SecurityRules{
+											{Name: "custom_inbound", Priority: 2300, Direction: infrav1.SecurityRuleDirectionInbound},
+										},
+									},
+								},
+							},
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetBastion},
+								SecurityGroup:   infrav1.SecurityGroup{Name: "my-bastion-nsg"},
+							},
+						},
+						IntraClusterTraffic: intraClusterTraffic,
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("the virtual network and the pod CIDRs are allowed by default", func(t *testing.T) {
+		g := NewWithT(t)
+		clusterScope := newClusterScope(&infrav1.IntraClusterTraffic{})
+
+		nsgSpecs := clusterScope.NSGSpecs()
+		g.Expect(nsgSpecs[0].SecurityRules).To(Equal(infrav1.SecurityRules{
+			{Name: "custom_inbound", Priority: 2300, Direction: infrav1.SecurityRuleDirectionInbound},
+			{
+				Name:             "allow_intra_cluster_inbound_0",
+				Description:      "Allow intra-cluster traffic from 10.0.0.0/8",
+				Priority:         2301,
+				Protocol:         infrav1.SecurityGroupProtocolAll,
+				Direction:        infrav1.SecurityRuleDirectionInbound,
+				Source:           to.StringPtr("10.0.0.0/8"),
+				SourcePorts:      to.StringPtr("*"),
+				Destination:      to.StringPtr("*"),
+				DestinationPorts: to.StringPtr("*"),
+			},
+			{
+				Name:             "allow_intra_cluster_outbound_0",
+				Description:      "Allow intra-cluster traffic to 10.0.0.0/8",
+				Priority:         2300,
+				Protocol:         infrav1.SecurityGroupProtocolAll,
+				Direction:        infrav1.SecurityRuleDirectionOutbound,
+				Source:           to.StringPtr("*"),
+				SourcePorts:      to.StringPtr("*"),
+				Destination:      to.StringPtr("10.0.0.0/8"),
+				DestinationPorts: to.StringPtr("*"),
+			},
+			{
+				Name:             "allow_intra_cluster_inbound_1",
+				Description:      "Allow intra-cluster traffic from 192.168.0.0/16",
+				Priority:         2302,
+				Protocol:         infrav1.SecurityGroupProtocolAll,
+				Direction:        infrav1.SecurityRuleDirectionInbound,
+				Source:           to.StringPtr("192.168.0.0/16"),
+				SourcePorts:      to.StringPtr("*"),
+				Destination:      to.StringPtr("*"),
+				DestinationPorts: to.StringPtr("*"),
+			},
+			{
+				Name:             "allow_intra_cluster_outbound_1",
+				Description:      "Allow intra-cluster traffic to 192.168.0.0/16",
+				Priority:         2301,
+				Protocol:         infrav1.SecurityGroupProtocolAll,
+				Direction:        infrav1.SecurityRuleDirectionOutbound,
+				Source:           to.StringPtr("*"),
+				SourcePorts:      to.StringPtr("*"),
+				Destination:      to.StringPtr("192.168.0.0/16"),
+				DestinationPorts: to.StringPtr("*"),
+			},
+		}))
+		g.Expect(nsgSpecs[1].SecurityRules).To(BeEmpty())
+	})
+
+	t.Run("the CIDRs of the spec replace the defaults", func(t *testing.T) {
+		g := NewWithT(t)
+		clusterScope := newClusterScope(&infrav1.IntraClusterTraffic{CIDRs: []string{"100.64.0.0/10"}})
+
+		rules := clusterScope.NSGSpecs()[0].SecurityRules
+		g.Expect(rules).To(HaveLen(3))
+		g.Expect(rules[1].Source).To(Equal(to.StringPtr("100.64.0.0/10")))
+		g.Expect(rules[2].Destination).To(Equal(to.StringPtr("100.64.0.0/10")))
+	})
+
+	t.Run("the rules are added to the security groups of the network interfaces", func(t *testing.T) {
+		g := NewWithT(t)
+		clusterScope := newClusterScope(&infrav1.IntraClusterTraffic{CIDRs: []string{"100.64.0.0/10"}})
+		clusterScope.AzureCluster.Spec.NetworkSpec.NetworkInterfaceSecurityGroups = &infrav1.NetworkInterfaceSecurityGroups{
+			Node: infrav1.SecurityGroup{
+				Name: "my-node-nic-nsg",
+				SecurityGroupClass: infrav1.SecurityGroupClass{
+					SecurityRules: infrav1.SecurityRules{{Name: "allow_ssh", Priority: 100, Direction: infrav1.SecurityRuleDirectionInbound}},
+				},
+			},
+		}
+
+		var nicRules infrav1.SecurityRules
+		for _, nsgSpec := range clusterScope.NSGSpecs() {
+			if nsgSpec.Name == "my-node-nic-nsg" {
+				nicRules = nsgSpec.SecurityRules
+			}
+		}
+		g.Expect(nicRules).To(HaveLen(3))
+		g.Expect(nicRules[1].Name).To(Equal("allow_intra_cluster_inbound_0"))
+		g.Expect(nicRules[1].Priority).To(Equal(int32(2300)))
+		g.Expect(nicRules[2].Name).To(Equal("allow_intra_cluster_outbound_0"))
+	})
+
+	t.Run("no rule is added unless enabled", func(t *testing.T) {
+		g := NewWithT(t)
+		clusterScope := newClusterScope(nil)
+
+		g.Expect(clusterScope.NSGSpecs()[0].SecurityRules).To(HaveLen(1))
+	})
+}
+
+func TestFirewallRoute(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"}},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				AzureClusterClassSpec: infrav1.AzureClusterClassSpec{Location: "westus"},
+				ResourceGroup:         "my-rg",
+				NetworkSpec: infrav1.NetworkSpec{
+					Subnets: infrav1.Subnets{
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetControlPlane},
+							SecurityGroup:   infrav1.SecurityGroup{Name: "my-cp-nsg"},
+						},
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetNode},
+							SecurityGroup: infrav1.SecurityGroup{Name: "my-node-nsg", SecurityGroupClass: infrav1.SecurityGroupClass{SecurityRules: infrav1.SecurityRules{
+								{Name: "custom", Priority: 2203, Direction: infrav1.SecurityRuleDirectionInbound},
+							}}},
+							RouteTable:    infrav1.RouteTable{Name: "my-node-routetable"},
+							FirewallRoute: &infrav1.FirewallRoute{PrivateIP: "10.100.0.4"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(clusterScope.RouteTableSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&routetables.RouteTableSpec{
+			Name:              "my-node-routetable",
+			ResourceGroup:     "my-rg",
+			Location:          "westus",
+			FirewallPrivateIP: "10.100.0.4",
+		},
+	}))
+
+	nsgSpecs := clusterScope.NSGSpecs()
+	g.Expect(nsgSpecs[0].SecurityRules).To(HaveLen(1))
+	g.Expect(nsgSpecs[1].SecurityRules).To(HaveLen(2))
+	firewallRule := nsgSpecs[1].SecurityRules[1]
+	g.Expect(firewallRule.Name).To(Equal("allow_firewall_inbound"))
+	g.Expect(firewallRule.Priority).To(Equal(int32(2204)))
+	g.Expect(firewallRule.Protocol).To(Equal(infrav1.SecurityGroupProtocolAll))
+	g.Expect(*firewallRule.Source).To(Equal("10.100.0.4"))
+}
+
+func TestControlPlaneNatGateway(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"}},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				AzureClusterClassSpec: infrav1.AzureClusterClassSpec{Location: "westus"},
+				ResourceGroup:         "my-rg",
+				NetworkSpec: infrav1.NetworkSpec{
+					APIServerLB: infrav1.LoadBalancerSpec{
+						Name: "my-api-lb",
+						LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+							Type: infrav1.Public,
+							FrontendIPs: []infrav1.FrontendIP{
+								{Name: "my-api-lb-frontend", PublicIP: &infrav1.PublicIPSpec{Name: "my-api-lb-ip"}},
+							},
+						},
+					},
+					Subnets: infrav1.Subnets{
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetControlPlane},
+							Name:            "my-cp-subnet",
+							NatGateway: infrav1.NatGateway{
+								Name:              "my-cp-natgw",
+								NatGatewayIP:      infrav1.PublicIPSpec{Name: "my-cp-natgw-ip"},
+								NatGatewayIPCount: pointer.Int32(2),
+							},
+						},
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetNode},
+							Name:            "my-node-subnet",
+							NatGateway: infrav1.NatGateway{
+								Name:         "my-node-natgw",
+								NatGatewayIP: infrav1.PublicIPSpec{Name: "my-node-natgw-ip"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	natGatewaySpecs := clusterScope.NatGatewaySpecs()
+	g.Expect(natGatewaySpecs).To(HaveLen(2))
+	g.Expect(natGatewaySpecs[0].ResourceName()).To(Equal("my-node-natgw"))
+	g.Expect(natGatewaySpecs[1].ResourceName()).To(Equal("my-cp-natgw"))
+	g.Expect(natGatewaySpecs[1].(*natgateways.NatGatewaySpec).NatGatewayIPNames).To(Equal([]string{"my-cp-natgw-ip", "my-cp-natgw-ip-1"}))
+
+	roles := map[string]string{}
+	for _, ip := range clusterScope.PublicIPSpecs() {
+		roles[ip.Name] = ip.Role
+	}
+	g.Expect(roles).To(HaveKeyWithValue("my-node-natgw-ip", ""))
+	g.Expect(roles).To(HaveKeyWithValue("my-cp-natgw-ip", infrav1.ControlPlaneEgressRole))
+	g.Expect(roles).To(HaveKeyWithValue("my-cp-natgw-ip-1", infrav1.ControlPlaneEgressRole))
+
+	// the public IPs above the public IP count of a NAT gateway are left behind when the count is lowered
+	staleIPNames := clusterScope.StalePublicIPNames()
+	g.Expect(staleIPNames).To(ContainElements("my-node-natgw-ip-1", "my-cp-natgw-ip-2", "my-cp-natgw-ip-15"))
+	for _, name := range []string{"my-node-natgw-ip", "my-cp-natgw-ip", "my-cp-natgw-ip-1"} {
+		g.Expect(staleIPNames).NotTo(ContainElement(name))
+	}
+
+	// the egress of the control plane goes through its NAT gateway rather than an outbound rule of the API server LB
+	apiServerLBSpec := clusterScope.LBSpecs()[0].(*loadbalancers.LBSpec)
+	g.Expect(apiServerLBSpec.Name).To(Equal("my-api-lb"))
+	g.Expect(apiServerLBSpec.DisableOutboundRule).To(BeTrue())
+
+	clusterScope.SetControlPlaneEgressIPs([]string{"20.1.2.3", "20.1.2.4"})
+	g.Expect(clusterScope.AzureCluster.Status.ControlPlaneEgressIPs).To(Equal([]string{"20.1.2.3", "20.1.2.4"}))
+}
+
 func TestOutboundLBName(t *testing.T) {
 	tests := []struct {
 		clusterName            string
@@ -356,3 +995,1143 @@ func TestOutboundLBName(t *testing.T) {
 		})
 	}
 }
+
+func TestSetControlPlaneEndpoints(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		AzureCluster: &infrav1.AzureCluster{},
+	}
+
+	eastus := clusterv1.APIEndpoint{Host: "my-cluster-eastus.eastus.cloudapp.azure.com", Port: 6443}
+	westus := clusterv1.APIEndpoint{Host: "my-cluster-westus.westus.cloudapp.azure.com", Port: 6443}
+	internal := clusterv1.APIEndpoint{Host: "10.0.0.100", Port: 6443}
+
+	clusterScope.SetControlPlaneEndpoints(clusterv1.APIEndpoint{})
+	g.Expect(clusterScope.ControlPlaneEndpoints()).To(BeEmpty())
+
+	clusterScope.SetControlPlaneEndpoints(eastus, westus, eastus, internal)
+	g.Expect(clusterScope.ControlPlaneEndpoints()).To(Equal([]clusterv1.APIEndpoint{eastus, westus, internal}))
+
+	// the endpoints no longer registered, e.g. of an internal frontend removed from the spec, are pruned.
+	clusterScope.SetControlPlaneEndpoints(eastus, clusterv1.APIEndpoint{})
+	g.Expect(clusterScope.ControlPlaneEndpoints()).To(Equal([]clusterv1.APIEndpoint{eastus}))
+}
+
+func TestAPIServerEndpoints(t *testing.T) {
+	fakeSubscriptionID := "123"
+
+	publicLB := func(dnsNames ...string) infrav1.LoadBalancerSpec {
+		lb := infrav1.LoadBalancerSpec{
+			LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+				Type: infrav1.Public,
+			},
+		}
+		for i, dnsName := range dnsNames {
+			lb.FrontendIPs = append(lb.FrontendIPs, infrav1.FrontendIP{
+				Name:     fmt.Sprintf("frontend-%d", i),
+				PublicIP: &infrav1.PublicIPSpec{Name: fmt.Sprintf("pip-%d", i), DNSName: dnsName},
+			})
+		}
+		return lb
+	}
+	withInternalFrontend := func(lb infrav1.LoadBalancerSpec) infrav1.LoadBalancerSpec {
+		lb.InternalFrontendIP = &infrav1.FrontendIP{FrontendIPClass: infrav1.FrontendIPClass{PrivateIPAddress: "10.0.0.100"}}
+		return lb
+	}
+
+	tests := []struct {
+		name        string
+		networkSpec infrav1.NetworkSpec
+		want        []clusterv1.APIEndpoint
+	}{
+		{
+			name:        "single-region public apiserver lb",
+			networkSpec: infrav1.NetworkSpec{APIServerLB: publicLB("my-cluster-apiserver.example.com")},
+			want: []clusterv1.APIEndpoint{
+				{Host: "my-cluster-apiserver.example.com", Port: 6443},
+			},
+		},
+		{
+			name:        "public apiserver lb with an internal frontend",
+			networkSpec: infrav1.NetworkSpec{APIServerLB: withInternalFrontend(publicLB("my-cluster-apiserver.example.com"))},
+			want: []clusterv1.APIEndpoint{
+				{Host: "my-cluster-apiserver.example.com", Port: 6443},
+				{Host: "10.0.0.100", Port: 6443},
+			},
+		},
+		{
+			name: "private apiserver lb",
+			networkSpec: infrav1.NetworkSpec{
+				NetworkClassSpec: infrav1.NetworkClassSpec{
+					PrivateDNSZoneName: "example.private",
+				},
+				APIServerLB: infrav1.LoadBalancerSpec{
+					LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+						Type: infrav1.Internal,
+						FrontendIPs: []infrav1.FrontendIP{
+							{Name: "frontend", FrontendIPClass: infrav1.FrontendIPClass{PrivateIPAddress: "10.0.0.100"}},
+						},
+					},
+				},
+			},
+			want: []clusterv1.APIEndpoint{
+				{Host: "apiserver.example.private", Port: 6443},
+				{Host: "10.0.0.100", Port: 6443},
+			},
+		},
+		{
+			name:        "public apiserver lb with regional frontends",
+			networkSpec: infrav1.NetworkSpec{APIServerLB: publicLB("my-cluster-apiserver.example.com", "my-cluster-apiserver-2.example.com", "")},
+			want: []clusterv1.APIEndpoint{
+				{Host: "my-cluster-apiserver.example.com", Port: 6443},
+				{Host: "my-cluster-apiserver-2.example.com", Port: 6443},
+			},
+		},
+		{
+			name: "public apiserver lb behind traffic manager",
+			networkSpec: infrav1.NetworkSpec{
+				APIServerLB: withInternalFrontend(publicLB("my-cluster-apiserver.example.com", "my-cluster-apiserver-2.example.com")),
+				TrafficManager: &infrav1.TrafficManagerSpec{
+					DNSPrefix: "my-apiserver",
+				},
+			},
+			want: []clusterv1.APIEndpoint{
+				{Host: "my-apiserver.trafficmanager.net", Port: 6443},
+				{Host: "my-cluster-apiserver.example.com", Port: 6443},
+				{Host: "my-cluster-apiserver-2.example.com", Port: 6443},
+				{Host: "10.0.0.100", Port: 6443},
+			},
+		},
+		{
+			name: "public apiserver lb behind cross-region load balancer",
+			networkSpec: infrav1.NetworkSpec{
+				APIServerLB: publicLB("my-cluster-apiserver.example.com"),
+				GlobalLB: &infrav1.GlobalLoadBalancerSpec{
+					Location: "eastus2",
+					PublicIP: &infrav1.PublicIPSpec{
+						DNSName: "my-cluster-global.example.com",
+					},
+				},
+			},
+			want: []clusterv1.APIEndpoint{
+				{Host: "my-cluster-global.example.com", Port: 6443},
+				{Host: "my-cluster-apiserver.example.com", Port: 6443},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			_ = clusterv1.AddToScheme(scheme)
+			_ = infrav1.AddToScheme(scheme)
+
+			cluster := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-cluster",
+					Namespace: "default",
+				},
+			}
+			cluster.Default()
+
+			azureCluster := &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cluster.Name,
+					Namespace: "default",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						SubscriptionID: fakeSubscriptionID,
+					},
+					NetworkSpec: tc.networkSpec,
+				},
+			}
+			azureCluster.Default()
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cluster, azureCluster).Build()
+			clusterScope, err := NewClusterScope(context.TODO(), ClusterScopeParams{
+				AzureClients: AzureClients{
+					Authorizer: autorest.NullAuthorizer{},
+				},
+				Cluster:      cluster,
+				AzureCluster: azureCluster,
+				Client:       fakeClient,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			clusterScope.SetControlPlaneEndpoints(clusterScope.APIServerEndpoints()...)
+			g.Expect(clusterScope.ControlPlaneEndpoints()).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestTagsSpecsInheritedTags(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+		},
+		AzureClients: AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Values: map[string]string{
+					auth.SubscriptionID: "123",
+				},
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+					Location:       "westus",
+					AdditionalTags: infrav1.Tags{"team": "capz"},
+				},
+				InheritResourceGroupTags: true,
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{Name: "my-vnet", ResourceGroup: "my-rg"},
+					Subnets: infrav1.Subnets{
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetControlPlane},
+							Name:            "my-cp-subnet",
+						},
+					},
+					APIServerLB: infrav1.LoadBalancerSpec{
+						Name: "my-public-lb",
+						LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+							Type: infrav1.Public,
+							SKU:  infrav1.SKUStandard,
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									Name:     "my-public-lb-frontEnd",
+									PublicIP: &infrav1.PublicIPSpec{Name: "pip-my-cluster-apiserver"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// the tags of the resource group are only known once it is reconciled.
+	g.Expect(clusterScope.TagsSpecs()).To(HaveLen(1))
+
+	clusterScope.SetResourceGroupTags(infrav1.Tags{
+		"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned",
+		"Name":       "my-rg",
+		"team":       "another-team",
+		"costCenter": "1234",
+	})
+	specs := clusterScope.TagsSpecs()
+	g.Expect(specs).To(HaveLen(4))
+	g.Expect(specs[0].Inherited).To(BeFalse())
+	var scopes []string
+	for _, spec := range specs[1:] {
+		g.Expect(spec.Inherited).To(BeTrue())
+		g.Expect(spec.Annotation).To(Equal(azure.InheritedTagsLastAppliedAnnotation))
+		// the additional tags take precedence over the tags of the resource group.
+		g.Expect(spec.Tags).To(Equal(infrav1.Tags{"costCenter": "1234"}))
+		scopes = append(scopes, spec.Scope)
+	}
+	g.Expect(scopes).To(Equal([]string{
+		"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet",
+		"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-public-lb",
+		"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/pip-my-cluster-apiserver",
+	}))
+
+	clusterScope.AzureCluster.Spec.InheritResourceGroupTags = false
+	g.Expect(clusterScope.TagsSpecs()).To(HaveLen(1))
+}
+
+func TestValidateCostCenter(t *testing.T) {
+	tests := []struct {
+		name               string
+		allowedCostCenters []string
+		defaultTags        infrav1.Tags
+		additionalTags     infrav1.Tags
+		wantErr            string
+	}{
+		{
+			name:           "no allowed cost centers",
+			additionalTags: infrav1.Tags{"costCenter": "anything"},
+		},
+		{
+			name:               "allowed cost center",
+			allowedCostCenters: []string{"1234", "5678"},
+			additionalTags:     infrav1.Tags{"costCenter": "5678"},
+		},
+		{
+			name:               "allowed cost center from the default tags",
+			allowedCostCenters: []string{"1234"},
+			defaultTags:        infrav1.Tags{"costCenter": "1234"},
+		},
+		{
+			name:               "missing cost center",
+			allowedCostCenters: []string{"1234", "5678"},
+			additionalTags:     infrav1.Tags{"team": "capz"},
+			wantErr:            `the "costCenter" tag is required and must be one of 1234, 5678`,
+		},
+		{
+			name:               "empty cost center",
+			allowedCostCenters: []string{"1234"},
+			additionalTags:     infrav1.Tags{"costCenter": ""},
+			wantErr:            `the "costCenter" tag is required`,
+		},
+		{
+			name:               "invalid cost center",
+			allowedCostCenters: []string{"1234", "5678"},
+			additionalTags:     infrav1.Tags{"costCenter": "9999"},
+			wantErr:            `the "costCenter" tag "9999" is not one of the allowed cost centers 1234, 5678`,
+		},
+		{
+			name:               "invalid cost center overriding the default tags",
+			allowedCostCenters: []string{"1234"},
+			defaultTags:        infrav1.Tags{"costCenter": "1234"},
+			additionalTags:     infrav1.Tags{"costCenter": "9999"},
+			wantErr:            `the "costCenter" tag "9999" is not one of the allowed cost centers 1234`,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterScope := &ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{AdditionalTags: tc.additionalTags},
+					},
+				},
+				allowedCostCenters: tc.allowedCostCenters,
+				defaultTags:        tc.defaultTags,
+			}
+
+			err := clusterScope.ValidateCostCenter()
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(clusterScope.AdditionalTags()).To(HaveKey(azure.CostCenterTagKey))
+			}
+		})
+	}
+}
+
+func TestValidateSecurityGroupTags(t *testing.T) {
+	tests := []struct {
+		name         string
+		requiredTags []string
+		cpTags       infrav1.Tags
+		nodeTags     infrav1.Tags
+		wantErr      string
+	}{
+		{
+			name:   "no required tags",
+			cpTags: infrav1.Tags{"team": "capz"},
+		},
+		{
+			name:         "required tags are present",
+			requiredTags: []string{"dataClassification", "complianceScope"},
+			cpTags:       infrav1.Tags{"dataClassification": "confidential", "complianceScope": "pci"},
+			nodeTags:     infrav1.Tags{"dataClassification": "internal", "complianceScope": "none", "team": "capz"},
+		},
+		{
+			name:         "required tag omitted",
+			requiredTags: []string{"dataClassification", "complianceScope"},
+			cpTags:       infrav1.Tags{"dataClassification": "confidential", "complianceScope": "pci"},
+			nodeTags:     infrav1.Tags{"dataClassification": "internal"},
+			wantErr:      "the security groups node-nsg (complianceScope) are missing required tags, the tags dataClassification, complianceScope are required",
+		},
+		{
+			name:         "required tags empty or missing",
+			requiredTags: []string{"dataClassification"},
+			cpTags:       infrav1.Tags{"dataClassification": ""},
+			wantErr:      "the security groups cp-nsg (dataClassification), node-nsg (dataClassification) are missing required tags",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterScope := &ClusterScope{
+				Cluster: &clusterv1.Cluster{},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets: infrav1.Subnets{
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetControlPlane},
+									Name:            "cp-subnet",
+									SecurityGroup:   infrav1.SecurityGroup{Name: "cp-nsg", SecurityGroupClass: infrav1.SecurityGroupClass{Tags: tc.cpTags}},
+								},
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetNode},
+									Name:            "node-subnet",
+									SecurityGroup:   infrav1.SecurityGroup{Name: "node-nsg", SecurityGroupClass: infrav1.SecurityGroupClass{Tags: tc.nodeTags}},
+								},
+							},
+						},
+					},
+				},
+				requiredNSGTags: tc.requiredTags,
+			}
+
+			err := clusterScope.ValidateSecurityGroupTags()
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestAutoShutdownTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule *infrav1.AutoShutdownSchedule
+		want     string
+	}{
+		{
+			name: "no auto-shutdown schedule",
+		},
+		{
+			name:     "schedule in UTC",
+			schedule: &infrav1.AutoShutdownSchedule{Time: "19:30"},
+			want:     "19:30 UTC",
+		},
+		{
+			name:     "schedule with a time zone",
+			schedule: &infrav1.AutoShutdownSchedule{Time: "20:00", TimeZone: "Europe/Paris"},
+			want:     "20:00 Europe/Paris",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterScope := &ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						AutoShutdown: tc.schedule,
+					},
+				},
+			}
+
+			if tc.want == "" {
+				g.Expect(clusterScope.AdditionalTags()).NotTo(HaveKey(azure.AutoShutdownTagKey))
+			} else {
+				g.Expect(clusterScope.AdditionalTags()).To(HaveKeyWithValue(azure.AutoShutdownTagKey, tc.want))
+			}
+		})
+	}
+}
+
+func TestRequiredRegistrations(t *testing.T) {
+	tests := []struct {
+		name string
+		spec infrav1.AzureClusterSpec
+		want []registrations.Requirement
+	}{
+		{
+			name: "default cluster",
+			want: []registrations.Requirement{{Namespace: "Microsoft.Network"}, {Namespace: "Microsoft.Compute"}},
+		},
+		{
+			name: "network only cluster",
+			spec: infrav1.AzureClusterSpec{ReconcileMode: infrav1.ReconcileModeNetworkOnly},
+			want: []registrations.Requirement{{Namespace: "Microsoft.Network"}},
+		},
+		{
+			name: "cluster with monitoring and features",
+			spec: infrav1.AzureClusterSpec{
+				LogAnalyticsWorkspace: &infrav1.LogAnalyticsWorkspace{Name: "my-workspace"},
+				NetworkSpec: infrav1.NetworkSpec{
+					APIServerLB: infrav1.LoadBalancerSpec{
+						LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+							DiagnosticSettings: &infrav1.DiagnosticSettings{WorkspaceID: "my-workspace-id"},
+						},
+					},
+				},
+				RequiredFeatures: []infrav1.ProviderFeature{{Namespace: "Microsoft.Compute", Name: "EncryptionAtHost"}},
+			},
+			want: []registrations.Requirement{
+				{Namespace: "Microsoft.Network"},
+				{Namespace: "Microsoft.Compute"},
+				{Namespace: "Microsoft.OperationalInsights"},
+				{Namespace: "Microsoft.Insights"},
+				{Namespace: "Microsoft.Compute", Feature: "EncryptionAtHost"},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterScope := &ClusterScope{
+				Cluster:      &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"}},
+				AzureCluster: &infrav1.AzureCluster{Spec: tc.spec},
+			}
+			clusterScope.AzureCluster.Default()
+
+			g.Expect(clusterScope.RequiredRegistrations()).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestAPIServerInternalFrontend(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+		},
+		AzureClients: AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Values: map[string]string{
+					auth.SubscriptionID: "123",
+				},
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+					Location: "westus",
+				},
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{Name: "my-vnet", ResourceGroup: "my-rg"},
+					Subnets: infrav1.Subnets{
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetControlPlane},
+							Name:            "my-cp-subnet",
+						},
+					},
+					APIServerLB: infrav1.LoadBalancerSpec{
+						Name: "my-public-lb",
+						LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+							Type: infrav1.Public,
+							SKU:  infrav1.SKUStandard,
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									Name:     "my-public-lb-frontEnd",
+									PublicIP: &infrav1.PublicIPSpec{Name: "pip-my-cluster-apiserver", DNSName: "my-cluster.westus.cloudapp.azure.com"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(clusterScope.LBSpecs()).To(HaveLen(1))
+	g.Expect(clusterScope.APIServerInternalEndpoint().IsZero()).To(BeTrue())
+	staleSpecs := clusterScope.StaleLBSpecs()
+	g.Expect(staleSpecs).To(HaveLen(1))
+	g.Expect(staleSpecs[0].ResourceName()).To(Equal("my-public-lb-internal"))
+
+	internalFrontendIP := infrav1.FrontendIP{
+		Name:            "my-public-lb-internal-frontEnd",
+		FrontendIPClass: infrav1.FrontendIPClass{PrivateIPAddress: "10.0.0.100"},
+	}
+	clusterScope.AzureCluster.Spec.NetworkSpec.APIServerLB.InternalFrontendIP = &internalFrontendIP
+
+	specs := clusterScope.LBSpecs()
+	g.Expect(specs).To(HaveLen(2))
+	g.Expect(specs[0].ResourceName()).To(Equal("my-public-lb"))
+	internalLB, ok := specs[1].(*loadbalancers.LBSpec)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(internalLB.Name).To(Equal("my-public-lb-internal"))
+	g.Expect(internalLB.Type).To(Equal(infrav1.Internal))
+	g.Expect(internalLB.Role).To(Equal(infrav1.APIServerRole))
+	g.Expect(internalLB.SubnetName).To(Equal("my-cp-subnet"))
+	g.Expect(internalLB.BackendPoolName).To(Equal("my-public-lb-internal-backendPool"))
+	g.Expect(internalLB.FrontendIPConfigs).To(Equal([]infrav1.FrontendIP{internalFrontendIP}))
+	g.Expect(clusterScope.StaleLBSpecs()).To(BeEmpty())
+
+	g.Expect(clusterScope.APIServerInternalEndpoint()).To(Equal(clusterv1.APIEndpoint{Host: "10.0.0.100", Port: 6443}))
+
+	clusterScope.AzureCluster.Spec.NetworkSpec.APIServerLB = infrav1.LoadBalancerSpec{
+		Name: "my-private-lb",
+		LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+			Type: infrav1.Internal,
+			SKU:  infrav1.SKUStandard,
+			FrontendIPs: []infrav1.FrontendIP{
+				{
+					Name:            "my-private-lb-frontEnd",
+					FrontendIPClass: infrav1.FrontendIPClass{PrivateIPAddress: "10.0.0.50"},
+				},
+			},
+		},
+	}
+	g.Expect(clusterScope.APIServerInternalEndpoint()).To(Equal(clusterv1.APIEndpoint{Host: "10.0.0.50", Port: 6443}))
+}
+
+func TestSharedAPIServerLB(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+		},
+		AzureClients: AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Values: map[string]string{
+					auth.SubscriptionID: "123",
+				},
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+					Location: "westus",
+				},
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{Name: "my-vnet", ResourceGroup: "my-rg"},
+					Subnets: infrav1.Subnets{
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetControlPlane},
+							Name:            "my-cp-subnet",
+						},
+					},
+					APIServerLB: infrav1.LoadBalancerSpec{
+						Name: "shared-lb",
+						LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+							Type: infrav1.Public,
+							SKU:  infrav1.SKUStandard,
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									Name:     "shared-lb-frontEnd",
+									PublicIP: &infrav1.PublicIPSpec{Name: "shared-ip", DNSName: "shared.westus.cloudapp.azure.com"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(clusterScope.APIServerLBPoolName("shared-lb")).To(Equal("shared-lb-backendPool"))
+	g.Expect(clusterScope.APIServerLBPort()).To(Equal(int32(6443)))
+	g.Expect(clusterScope.PublicIPSpecs()).To(HaveLen(1))
+
+	clusterScope.AzureCluster.Spec.NetworkSpec.APIServerLB.Shared = &infrav1.SharedLoadBalancer{FrontendPort: 6444}
+
+	g.Expect(clusterScope.APIServerLBPoolName("shared-lb")).To(Equal("my-cluster-backendPool"))
+	g.Expect(clusterScope.APIServerLBPort()).To(Equal(int32(6444)))
+	g.Expect(clusterScope.PublicIPSpecs()).To(BeEmpty())
+	specs := clusterScope.LBSpecs()
+	g.Expect(specs).To(HaveLen(1))
+	lbSpec, ok := specs[0].(*loadbalancers.LBSpec)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(lbSpec.Shared).To(Equal(&infrav1.SharedLoadBalancer{FrontendPort: 6444}))
+	g.Expect(lbSpec.BackendPoolName).To(Equal("my-cluster-backendPool"))
+	g.Expect(lbSpec.APIServerPort).To(Equal(int32(6443)))
+}
+
+func TestPublicIPPrefixAllocations(t *testing.T) {
+	g := NewWithT(t)
+
+	prefixID := "/subscriptions/123/resourceGroups/prefix-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix"
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				NetworkSpec: infrav1.NetworkSpec{
+					APIServerLB: infrav1.LoadBalancerSpec{
+						Name: "my-lb",
+						LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+							Type: infrav1.Public,
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									Name:     "my-lb-frontEnd",
+									PublicIP: &infrav1.PublicIPSpec{Name: "my-ip", DNSName: "my-cluster.westus.cloudapp.azure.com", IPPrefixID: prefixID},
+								},
+							},
+						},
+					},
+					NodeOutboundLB: &infrav1.LoadBalancerSpec{
+						Name: "my-cluster",
+						LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									Name:     "my-cluster-frontEnd",
+									PublicIP: &infrav1.PublicIPSpec{Name: "pip-my-cluster-node-outbound", IPPrefixID: prefixID},
+								},
+							},
+							FrontendIPsCount: to.Int32Ptr(1),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	specs := clusterScope.PublicIPSpecs()
+	g.Expect(specs).To(HaveLen(2))
+	g.Expect(specs[0].IPPrefixID).To(Equal(prefixID))
+	g.Expect(specs[1].IPPrefixID).To(Equal(prefixID))
+
+	clusterScope.SetPublicIPPrefixAllocations(map[string]string{"my-ip": "20.1.2.3"})
+	g.Expect(clusterScope.AzureCluster.Status.PublicIPPrefixAllocations).To(Equal(map[string]string{"my-ip": "20.1.2.3"}))
+}
+
+func TestPreviousClusterUID(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default", UID: "uid-2"},
+		},
+	}
+	g.Expect(clusterScope.PreviousClusterUID()).To(BeEmpty())
+
+	clusterScope.Cluster.Annotations = map[string]string{azure.PreviousClusterUIDAnnotation: "uid-1"}
+	g.Expect(clusterScope.PreviousClusterUID()).To(Equal("uid-1"))
+}
+
+func TestClusterUID(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default", UID: "uid-1"},
+		},
+		AzureCluster: &infrav1.AzureCluster{},
+	}
+	g.Expect(clusterScope.ClusterUID()).To(Equal("uid-1"))
+
+	clusterScope.SetClusterUID()
+	g.Expect(clusterScope.AzureCluster.Status.ClusterUID).To(Equal("uid-1"))
+
+	// the UID of a moved cluster changes, its resources are still tagged with the recorded UID.
+	clusterScope.Cluster.UID = "uid-2"
+	clusterScope.SetClusterUID()
+	g.Expect(clusterScope.ClusterUID()).To(Equal("uid-1"))
+}
+
+func TestDiagnosticSettingsSpecs(t *testing.T) {
+	g := NewWithT(t)
+
+	settings := &infrav1.DiagnosticSettings{
+		WorkspaceID:      "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.OperationalInsights/workspaces/shared-workspace",
+		MetricCategories: []string{"AllMetrics"},
+	}
+	nsgSettings := &infrav1.DiagnosticSettings{
+		WorkspaceID:   "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.OperationalInsights/workspaces/shared-workspace",
+		LogCategories: []string{"NetworkSecurityGroupEvent", "NetworkSecurityGroupRuleCounter"},
+	}
+	ipSettings := &infrav1.DiagnosticSettings{
+		StorageAccountID: "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.Storage/storageAccounts/sharedlogs",
+		LogCategories:    []string{"DDoSProtectionNotifications"},
+	}
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+		},
+		AzureClients: AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Values: map[string]string{
+					auth.SubscriptionID: "123",
+				},
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				NetworkSpec: infrav1.NetworkSpec{
+					APIServerLB: infrav1.LoadBalancerSpec{
+						Name: "my-public-lb",
+						LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+							Type:               infrav1.Public,
+							DiagnosticSettings: settings,
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									Name:     "my-public-lb-frontEnd",
+									PublicIP: &infrav1.PublicIPSpec{Name: "my-public-ip", DiagnosticSettings: ipSettings},
+								},
+							},
+						},
+					},
+					NodeOutboundLB: &infrav1.LoadBalancerSpec{
+						Name: "my-cluster",
+					},
+					Subnets: infrav1.Subnets{
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetNode},
+							Name:            "node-subnet",
+							SecurityGroup: infrav1.SecurityGroup{
+								Name:               "node-nsg",
+								SecurityGroupClass: infrav1.SecurityGroupClass{DiagnosticSettings: nsgSettings},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(clusterScope.DiagnosticSettingsSpecs()).To(Equal([]azure.DiagnosticSettingsSpec{
+		{
+			Name:       "my-cluster-diagnostics",
+			ResourceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-public-lb",
+			Settings:   settings,
+		},
+		{
+			Name:       "my-cluster-diagnostics",
+			ResourceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster",
+		},
+		{
+			Name:       "my-cluster-diagnostics",
+			ResourceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/node-nsg",
+			Settings:   nsgSettings,
+		},
+		{
+			Name:       "my-cluster-diagnostics",
+			ResourceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-public-ip",
+			Settings:   ipSettings,
+		},
+	}))
+
+	// The security groups of an unmanaged virtual network are left alone.
+	clusterScope.AzureCluster.Spec.NetworkSpec.Vnet.ID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"
+	for _, spec := range clusterScope.DiagnosticSettingsSpecs() {
+		g.Expect(spec.ResourceID).NotTo(ContainSubstring("networkSecurityGroups"))
+	}
+}
+
+func TestOutboundConnectivityCheckSpecs(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{Name: "my-vnet", ResourceGroup: "my-vnet-rg"},
+					Subnets: infrav1.Subnets{
+						{SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetControlPlane}, Name: "cp-subnet"},
+						{SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetNode}, Name: "node-subnet-1"},
+						{SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetNode}, Name: "node-subnet-2"},
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(clusterScope.OutboundConnectivityCheckSpecs()).To(BeNil())
+
+	clusterScope.AzureCluster.Spec.NetworkSpec.OutboundConnectivityCheck = &infrav1.OutboundConnectivityCheck{
+		DestinationIP:               "20.37.158.0",
+		Port:                        443,
+		Protocol:                    "TCP",
+		NetworkWatcherName:          "NetworkWatcher_westus2",
+		NetworkWatcherResourceGroup: "NetworkWatcherRG",
+	}
+	spec := func(subnet string) azure.OutboundConnectivityCheckSpec {
+		return azure.OutboundConnectivityCheckSpec{
+			VNetResourceGroup:           "my-vnet-rg",
+			VNetName:                    "my-vnet",
+			SubnetName:                  subnet,
+			DestinationIP:               "20.37.158.0",
+			Port:                        443,
+			Protocol:                    "TCP",
+			NetworkWatcherName:          "NetworkWatcher_westus2",
+			NetworkWatcherResourceGroup: "NetworkWatcherRG",
+		}
+	}
+	g.Expect(clusterScope.OutboundConnectivityCheckSpecs()).To(Equal([]azure.OutboundConnectivityCheckSpec{
+		spec("node-subnet-1"),
+		spec("node-subnet-2"),
+	}))
+}
+
+func TestJumpboxSpecs(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+		},
+		AzureClients: AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Values: map[string]string{
+					auth.SubscriptionID: "123",
+				},
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{Name: "my-vnet", ResourceGroup: "my-rg"},
+				},
+			},
+		},
+	}
+
+	g.Expect(clusterScope.JumpboxNICSpec()).To(BeNil())
+	g.Expect(clusterScope.JumpboxVMSpec()).To(BeNil())
+
+	clusterScope.AzureCluster.Spec.BastionSpec.Jumpbox = &infrav1.Jumpbox{
+		Name:               "my-jumpbox",
+		VMSize:             "Standard_B2s",
+		SSHPublicKey:       "c3NoLXJzYSBBQUFB",
+		AllowedSourceCIDRs: []string{"203.0.113.0/24", "198.51.100.0/24"},
+		Subnet: infrav1.SubnetSpec{
+			Name:          "my-jumpbox-subnet",
+			SecurityGroup: infrav1.SecurityGroup{Name: "my-jumpbox-nsg"},
+		},
+		PublicIP: infrav1.PublicIPSpec{Name: "my-jumpbox-pip"},
+	}
+
+	g.Expect(clusterScope.JumpboxVMSpec().NICIDs).To(Equal([]string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkInterfaces/my-jumpbox-nic"}))
+	g.Expect(clusterScope.JumpboxPublicIPName()).To(Equal("my-jumpbox-pip"))
+
+	nsgSpecs := clusterScope.NSGSpecs()
+	g.Expect(nsgSpecs).To(HaveLen(1))
+	g.Expect(nsgSpecs[0].Name).To(Equal("my-jumpbox-nsg"))
+	g.Expect(nsgSpecs[0].SecurityRules).To(HaveLen(2))
+	g.Expect(*nsgSpecs[0].SecurityRules[1].Source).To(Equal("198.51.100.0/24"))
+	g.Expect(*nsgSpecs[0].SecurityRules[1].DestinationPorts).To(Equal("22"))
+}
+
+func TestIngressSpecs(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+		},
+		AzureClients: AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Values: map[string]string{
+					auth.SubscriptionID: "123",
+				},
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet:        infrav1.VnetSpec{Name: "my-vnet", ResourceGroup: "my-rg"},
+					APIServerLB: infrav1.LoadBalancerSpec{LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{Type: infrav1.Internal}},
+				},
+			},
+		},
+	}
+
+	g.Expect(clusterScope.IsIngressEnabled()).To(BeFalse())
+	g.Expect(clusterScope.SubnetSpecs()).To(BeEmpty())
+
+	clusterScope.AzureCluster.Spec.NetworkSpec.Ingress = &infrav1.IngressSpec{
+		Subnet: infrav1.SubnetSpec{
+			Name:            "my-ingress-subnet",
+			SubnetClassSpec: infrav1.SubnetClassSpec{CIDRBlocks: []string{"10.255.254.0/24"}, Role: infrav1.SubnetIngress},
+			SecurityGroup: infrav1.SecurityGroup{
+				Name: "my-ingress-nsg",
+				SecurityGroupClass: infrav1.SecurityGroupClass{
+					SecurityRules: infrav1.SecurityRules{{
+						Name:      "deny_ftp",
+						Priority:  1000,
+						Protocol:  infrav1.SecurityGroupProtocolTCP,
+						Direction: infrav1.SecurityRuleDirectionInbound,
+					}},
+				},
+			},
+		},
+		Ports: []int32{80, 443},
+	}
+
+	subnetSpecs := clusterScope.SubnetSpecs()
+	g.Expect(subnetSpecs).To(HaveLen(1))
+	g.Expect(subnetSpecs[0].ResourceName()).To(Equal("my-ingress-subnet"))
+	g.Expect(clusterScope.PublicIPSpecs()).To(BeEmpty())
+
+	nsgSpecs := clusterScope.NSGSpecs()
+	g.Expect(nsgSpecs).To(HaveLen(1))
+	g.Expect(nsgSpecs[0].Name).To(Equal("my-ingress-nsg"))
+	g.Expect(nsgSpecs[0].SecurityRules).To(HaveLen(3))
+	g.Expect(nsgSpecs[0].SecurityRules[1].Name).To(Equal("allow_ingress_80"))
+	g.Expect(*nsgSpecs[0].SecurityRules[1].Source).To(Equal("*"))
+	g.Expect(nsgSpecs[0].SecurityRules[2].Priority).To(Equal(int32(2101)))
+	g.Expect(*nsgSpecs[0].SecurityRules[2].DestinationPorts).To(Equal("443"))
+
+	clusterScope.Ingress().AllowedSourceCIDRs = []string{"203.0.113.0/24", "198.51.100.0/24"}
+	clusterScope.Ingress().PublicIP = &infrav1.PublicIPSpec{Name: "my-ingress-pip"}
+
+	nsgSpecs = clusterScope.NSGSpecs()
+	g.Expect(nsgSpecs[0].SecurityRules).To(HaveLen(5))
+	g.Expect(nsgSpecs[0].SecurityRules[4].Name).To(Equal("allow_ingress_443_1"))
+	g.Expect(*nsgSpecs[0].SecurityRules[4].Source).To(Equal("198.51.100.0/24"))
+	g.Expect(clusterScope.PublicIPSpecs()).To(Equal([]azure.PublicIPSpec{{Name: "my-ingress-pip"}}))
+}
+
+func TestPodSubnetSpecs(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+		},
+		AzureClients: AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Values: map[string]string{
+					auth.SubscriptionID: "123",
+				},
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{Name: "my-vnet", ResourceGroup: "my-rg"},
+					Subnets: infrav1.Subnets{
+						{
+							Name:            "node-subnet",
+							SubnetClassSpec: infrav1.SubnetClassSpec{CIDRBlocks: []string{"10.1.0.0/16"}, Role: infrav1.SubnetNode},
+							SecurityGroup:   infrav1.SecurityGroup{Name: "node-nsg"},
+							RouteTable:      infrav1.RouteTable{Name: "node-routetable"},
+							NatGateway:      infrav1.NatGateway{Name: "node-natgw"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(clusterScope.IsPodSubnetEnabled()).To(BeFalse())
+	g.Expect(clusterScope.SubnetSpecs()).To(HaveLen(1))
+
+	clusterScope.AzureCluster.Spec.NetworkSpec.PodSubnet = &infrav1.PodSubnetSpec{
+		Name:              "pod-subnet",
+		CIDRBlocks:        []string{"10.128.0.0/16"},
+		MaxPodsPerNode:    30,
+		MaxNodes:          100,
+		ServiceDelegation: "Microsoft.ContainerService/managedClusters",
+	}
+
+	subnetSpecs := clusterScope.SubnetSpecs()
+	g.Expect(subnetSpecs).To(HaveLen(2))
+	g.Expect(subnetSpecs[1]).To(Equal(&subnets.SubnetSpec{
+		Name:              "pod-subnet",
+		ResourceGroup:     "my-rg",
+		SubscriptionID:    "123",
+		CIDRs:             []string{"10.128.0.0/16"},
+		VNetName:          "my-vnet",
+		VNetResourceGroup: "my-rg",
+		IsVNetManaged:     true,
+		SecurityGroupName: "node-nsg",
+		RouteTableName:    "node-routetable",
+		NatGatewayName:    "node-natgw",
+		Role:              infrav1.SubnetPod,
+		ServiceDelegation: "Microsoft.ContainerService/managedClusters",
+	}))
+
+	clusterScope.UpdateSubnetID("pod-subnet", "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/pod-subnet")
+	g.Expect(clusterScope.AzureCluster.Status.PodSubnetID).To(Equal("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/pod-subnet"))
+	g.Expect(clusterScope.Subnet("node-subnet").ID).To(BeEmpty())
+}
+
+func TestNetworkInterfaceSecurityGroupSpecs(t *testing.T) {
+	g := NewWithT(t)
+
+	sshRule := infrav1.SecurityRule{
+		Name:             "allow_ssh",
+		Priority:         2200,
+		Protocol:         infrav1.SecurityGroupProtocolTCP,
+		Direction:        infrav1.SecurityRuleDirectionInbound,
+		DestinationPorts: to.StringPtr("22"),
+	}
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+		},
+		AzureClients: AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Values: map[string]string{
+					auth.SubscriptionID: "123",
+				},
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{Name: "my-vnet", ResourceGroup: "my-rg"},
+					Subnets: infrav1.Subnets{
+						{
+							Name:            "cp-subnet",
+							SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetControlPlane},
+							SecurityGroup: infrav1.SecurityGroup{
+								Name:               "cp-nsg",
+								SecurityGroupClass: infrav1.SecurityGroupClass{SecurityRules: infrav1.SecurityRules{sshRule}},
+							},
+						},
+						{
+							Name:            "node-subnet",
+							SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetNode},
+							SecurityGroup:   infrav1.SecurityGroup{Name: "node-nsg"},
+						},
+					},
+					NetworkInterfaceSecurityGroups: &infrav1.NetworkInterfaceSecurityGroups{
+						Attachment:   infrav1.SecurityGroupAttachmentNetworkInterface,
+						ControlPlane: infrav1.SecurityGroup{Name: "cp-nic-nsg"},
+						Node: infrav1.SecurityGroup{
+							Name: "node-nic-nsg",
+							SecurityGroupClass: infrav1.SecurityGroupClass{
+								AllowInboundFrom: []infrav1.InboundTrafficIntent{{Source: "10.0.0.0/8", Ports: []string{"10250"}}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// The control plane security group gets the rules of the control plane subnet, the node security group only its own.
+	nsgSpecs := clusterScope.NSGSpecs()
+	g.Expect(nsgSpecs).To(HaveLen(2))
+	g.Expect(nsgSpecs[0].Name).To(Equal("cp-nic-nsg"))
+	g.Expect(nsgSpecs[0].NetworkInterfaceRole).To(Equal(infrav1.ControlPlane))
+	g.Expect(nsgSpecs[0].SecurityRules).To(HaveLen(2))
+	g.Expect(nsgSpecs[0].SecurityRules[0].Name).To(Equal(azure.LoadBalancerProbeSecurityRuleName))
+	g.Expect(nsgSpecs[0].SecurityRules[1]).To(Equal(sshRule))
+	g.Expect(nsgSpecs[1].Name).To(Equal("node-nic-nsg"))
+	g.Expect(nsgSpecs[1].NetworkInterfaceRole).To(Equal(infrav1.Node))
+	g.Expect(nsgSpecs[1].SecurityRules).To(HaveLen(1))
+	g.Expect(nsgSpecs[1].SecurityRules[0].Name).To(Equal(infrav1.IntentSecurityRuleNamePrefix + "0_0"))
+
+	// The subnets have no security group.
+	for _, subnetSpec := range clusterScope.SubnetSpecs() {
+		g.Expect(subnetSpec.(*subnets.SubnetSpec).SecurityGroupName).To(BeEmpty())
+	}
+	g.Expect(clusterScope.NetworkInterfaceSecurityGroupName(infrav1.Node)).To(Equal("node-nic-nsg"))
+
+	clusterScope.AzureCluster.Spec.NetworkSpec.NetworkInterfaceSecurityGroups.Attachment = infrav1.SecurityGroupAttachmentSubnetAndNetworkInterface
+	nsgSpecs = clusterScope.NSGSpecs()
+	g.Expect(nsgSpecs).To(HaveLen(4))
+	g.Expect(nsgSpecs[0].Name).To(Equal("cp-nsg"))
+	g.Expect(nsgSpecs[0].NetworkInterfaceRole).To(BeEmpty())
+	g.Expect(nsgSpecs[1].Name).To(Equal("node-nsg"))
+	subnetSpecs := clusterScope.SubnetSpecs()
+	g.Expect(subnetSpecs[0].(*subnets.SubnetSpec).SecurityGroupName).To(Equal("cp-nsg"))
+	g.Expect(subnetSpecs[1].(*subnets.SubnetSpec).SecurityGroupName).To(Equal("node-nsg"))
+
+	// No security group is attached to the network interfaces in a custom virtual network.
+	clusterScope.AzureCluster.Spec.NetworkSpec.Vnet.ID = "my-vnet-id"
+	g.Expect(clusterScope.NetworkInterfaceSecurityGroupName(infrav1.Node)).To(BeEmpty())
+
+	clusterScope.SetNetworkInterfaceSecurityGroupID(infrav1.Node, "node-nic-nsg-id")
+	g.Expect(clusterScope.AzureCluster.Status.NetworkInterfaceSecurityGroupIDs).To(Equal(map[string]string{infrav1.Node: "node-nic-nsg-id"}))
+}
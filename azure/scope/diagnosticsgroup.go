@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/version"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// DiagnosticsGroupScope is the scope of the diagnostics resource group of a cluster. It describes the diagnostics
+// resource group to the group service, so that it reconciles it as it does the resource group of the cluster, and
+// records what it reports in the DiagnosticsResourceGroupReady condition of the cluster instead.
+type DiagnosticsGroupScope struct {
+	*ClusterScope
+}
+
+// NewDiagnosticsGroupScope creates the scope of the diagnostics resource group of a cluster.
+func NewDiagnosticsGroupScope(clusterScope *ClusterScope) *DiagnosticsGroupScope {
+	return &DiagnosticsGroupScope{ClusterScope: clusterScope}
+}
+
+// GroupSpec returns the resource group spec of the diagnostics resource group.
+func (s *DiagnosticsGroupScope) GroupSpec() azure.ResourceSpecGetter {
+	group := s.DiagnosticsResourceGroup()
+	return &groups.GroupSpec{
+		Name:            group.Name,
+		Location:        s.DiagnosticsResourceGroupLocation(),
+		ClusterName:     s.ClusterName(),
+		ProviderVersion: version.Get().Marker(),
+		AdditionalTags:  s.AdditionalTags(),
+	}
+}
+
+// DiagnosticsResourceGroupLocation returns the location of the diagnostics resource group, which defaults to the
+// location of the cluster.
+func (s *DiagnosticsGroupScope) DiagnosticsResourceGroupLocation() string {
+	if location := s.DiagnosticsResourceGroup().Location; location != "" {
+		return location
+	}
+	return s.Location()
+}
+
+// SetResourceGroupLocation records the location of the diagnostics resource group reported by Azure in the
+// AzureCluster status.
+func (s *DiagnosticsGroupScope) SetResourceGroupLocation(location string) {
+	s.AzureCluster.Status.DiagnosticsResourceGroupLocation = location
+}
+
+// SetResourceGroupTags does nothing: the resources of the cluster inherit the tags of the resource group of the
+// cluster only.
+func (s *DiagnosticsGroupScope) SetResourceGroupTags(_ infrav1.Tags) {}
+
+// UpdatePutStatus updates the DiagnosticsResourceGroupReady condition of the cluster.
+func (s *DiagnosticsGroupScope) UpdatePutStatus(_ clusterv1.ConditionType, service string, err error) {
+	s.ClusterScope.UpdatePutStatus(infrav1.DiagnosticsResourceGroupReadyCondition, "diagnostics "+service, err)
+}
+
+// UpdateDeleteStatus updates the DiagnosticsResourceGroupReady condition of the cluster.
+func (s *DiagnosticsGroupScope) UpdateDeleteStatus(_ clusterv1.ConditionType, service string, err error) {
+	s.ClusterScope.UpdateDeleteStatus(infrav1.DiagnosticsResourceGroupReadyCondition, "diagnostics "+service, err)
+}
+
+// UpdatePatchStatus updates the DiagnosticsResourceGroupReady condition of the cluster.
+func (s *DiagnosticsGroupScope) UpdatePatchStatus(_ clusterv1.ConditionType, service string, err error) {
+	s.ClusterScope.UpdatePatchStatus(infrav1.DiagnosticsResourceGroupReadyCondition, "diagnostics "+service, err)
+}
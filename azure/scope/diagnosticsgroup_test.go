@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loganalytics"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func newDiagnosticsGroupTestScope() *DiagnosticsGroupScope {
+	return NewDiagnosticsGroupScope(&ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+					Location: "eastus",
+				},
+				LogAnalyticsWorkspace:    &infrav1.LogAnalyticsWorkspace{Name: "my-workspace"},
+				DiagnosticsResourceGroup: &infrav1.DiagnosticsResourceGroup{Name: "my-diagnostics-rg"},
+			},
+		},
+	})
+}
+
+func TestDiagnosticsGroupSpec(t *testing.T) {
+	g := NewWithT(t)
+
+	s := newDiagnosticsGroupTestScope()
+	groupSpec, ok := s.GroupSpec().(*groups.GroupSpec)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(groupSpec.Name).To(Equal("my-diagnostics-rg"))
+	g.Expect(groupSpec.Location).To(Equal("eastus"))
+	g.Expect(groupSpec.ClusterName).To(Equal("my-cluster"))
+
+	s.AzureCluster.Spec.DiagnosticsResourceGroup.Location = "westeurope"
+	g.Expect(s.GroupSpec().(*groups.GroupSpec).Location).To(Equal("westeurope"))
+}
+
+func TestDiagnosticsGroupWorkspaceSpec(t *testing.T) {
+	g := NewWithT(t)
+
+	s := newDiagnosticsGroupTestScope()
+	spec, err := s.ClusterScope.LogAnalyticsWorkspaceSpec()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(spec.(*loganalytics.WorkspaceSpec).ResourceGroup).To(Equal("my-diagnostics-rg"))
+
+	s.AzureCluster.Spec.DiagnosticsResourceGroup = nil
+	spec, err = s.ClusterScope.LogAnalyticsWorkspaceSpec()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(spec.(*loganalytics.WorkspaceSpec).ResourceGroup).To(Equal("my-rg"))
+}
+
+func TestDiagnosticsGroupStatus(t *testing.T) {
+	g := NewWithT(t)
+
+	s := newDiagnosticsGroupTestScope()
+	s.SetResourceGroupLocation("eastus")
+	s.SetResourceGroupTags(infrav1.Tags{"team": "diagnostics"})
+	s.UpdatePutStatus(infrav1.ResourceGroupReadyCondition, "group", nil)
+
+	g.Expect(s.ReportedDiagnosticsResourceGroupLocation()).To(Equal("eastus"))
+	g.Expect(s.AzureCluster.Status.ResourceGroupLocation).To(BeEmpty())
+	g.Expect(s.resourceGroupTags).To(BeNil())
+	g.Expect(conditions.IsTrue(s.AzureCluster, infrav1.DiagnosticsResourceGroupReadyCondition)).To(BeTrue())
+	g.Expect(conditions.Has(s.AzureCluster, infrav1.ResourceGroupReadyCondition)).To(BeFalse())
+}
@@ -71,11 +71,16 @@ var _ CredentialsProvider = (*ManagedControlPlaneCredentialsProvider)(nil)
 
 // NewAzureClusterCredentialsProvider creates a new AzureClusterCredentialsProvider from the supplied inputs.
 func NewAzureClusterCredentialsProvider(ctx context.Context, kubeClient client.Client, azureCluster *infrav1.AzureCluster) (*AzureClusterCredentialsProvider, error) {
-	if azureCluster.Spec.IdentityRef == nil {
+	return NewAzureClusterIdentityCredentialsProvider(ctx, kubeClient, azureCluster, azureCluster.Spec.IdentityRef)
+}
+
+// NewAzureClusterIdentityCredentialsProvider creates a new AzureClusterCredentialsProvider for an AzureClusterIdentity
+// of the AzureCluster, e.g. the identity of the tenant of a peered virtual network.
+func NewAzureClusterIdentityCredentialsProvider(ctx context.Context, kubeClient client.Client, azureCluster *infrav1.AzureCluster, ref *corev1.ObjectReference) (*AzureClusterCredentialsProvider, error) {
+	if ref == nil {
 		return nil, errors.New("failed to generate new AzureClusterCredentialsProvider from empty identityName")
 	}
 
-	ref := azureCluster.Spec.IdentityRef
 	// if the namespace isn't specified then assume it's in the same namespace as the AzureCluster
 	namespace := ref.Namespace
 	if namespace == "" {
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"sort"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// InternalLoadBalancersScope is the scope of the internal load balancers fronting services of a cluster. It describes
+// them to the load balancer service apart from the load balancers of the API server and of the outbound traffic, so
+// that they don't interfere with them, and records what it reports in the InternalLoadBalancersReady condition
+// instead.
+type InternalLoadBalancersScope struct {
+	*ClusterScope
+	// stale maps the names of the load balancers removed from the spec to their resource group. When set, the scope
+	// describes them in place of the load balancers of the spec, so that they are deleted.
+	stale map[string]string
+}
+
+// NewInternalLoadBalancersScope creates the scope of the internal load balancers fronting services of a cluster.
+func NewInternalLoadBalancersScope(clusterScope *ClusterScope) *InternalLoadBalancersScope {
+	return &InternalLoadBalancersScope{ClusterScope: clusterScope}
+}
+
+// NewStaleInternalLoadBalancersScope creates the scope of the internal load balancers removed from the spec of a
+// cluster, given by name with their resource group.
+func NewStaleInternalLoadBalancersScope(clusterScope *ClusterScope, stale map[string]string) *InternalLoadBalancersScope {
+	return &InternalLoadBalancersScope{ClusterScope: clusterScope, stale: stale}
+}
+
+// LBSpecs returns the specs of the internal load balancers fronting services of the cluster.
+func (s *InternalLoadBalancersScope) LBSpecs() []azure.ResourceSpecGetter {
+	if s.stale == nil {
+		return s.InternalLBSpecs()
+	}
+	names := make([]string, 0, len(s.stale))
+	for name := range s.stale {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	specs := make([]azure.ResourceSpecGetter, 0, len(names))
+	for _, name := range names {
+		specs = append(specs, &loadbalancers.LBSpec{
+			Name:           name,
+			ResourceGroup:  s.stale[name],
+			SubscriptionID: s.SubscriptionID(),
+			ClusterName:    s.ClusterName(),
+			Role:           infrav1.InternalServiceRole,
+		})
+	}
+	return specs
+}
+
+// GlobalLBSpec returns nil: the internal load balancers fronting services are never backends of the cross-region load
+// balancer.
+func (s *InternalLoadBalancersScope) GlobalLBSpec() azure.ResourceSpecGetter {
+	return nil
+}
+
+// SetLoadBalancerTier does nothing: the status of the cluster reports the tiers of the load balancers of the API server
+// and of the outbound traffic.
+func (s *InternalLoadBalancersScope) SetLoadBalancerTier(_ string, _ infrav1.LoadBalancerTier) {}
+
+// UpdatePutStatus updates the InternalLoadBalancersReady condition of the cluster.
+func (s *InternalLoadBalancersScope) UpdatePutStatus(_ clusterv1.ConditionType, service string, err error) {
+	s.ClusterScope.UpdatePutStatus(infrav1.InternalLoadBalancersReadyCondition, "internal "+service, err)
+}
+
+// UpdateDeleteStatus updates the InternalLoadBalancersReady condition of the cluster. Deleting the load balancers
+// removed from the spec is part of reconciling the ones of the spec, it is reported as such.
+func (s *InternalLoadBalancersScope) UpdateDeleteStatus(_ clusterv1.ConditionType, service string, err error) {
+	if s.stale != nil {
+		s.ClusterScope.UpdatePutStatus(infrav1.InternalLoadBalancersReadyCondition, "internal "+service, err)
+		return
+	}
+	s.ClusterScope.UpdateDeleteStatus(infrav1.InternalLoadBalancersReadyCondition, "internal "+service, err)
+}
+
+// UpdatePatchStatus updates the InternalLoadBalancersReady condition of the cluster.
+func (s *InternalLoadBalancersScope) UpdatePatchStatus(_ clusterv1.ConditionType, service string, err error) {
+	s.ClusterScope.UpdatePatchStatus(infrav1.InternalLoadBalancersReadyCondition, "internal "+service, err)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func newInternalLoadBalancersTestScope() *ClusterScope {
+	return &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", UID: "uid-1"},
+		},
+		AzureClients: AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Values: map[string]string{
+					auth.SubscriptionID: "123",
+				},
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+					Location: "eastus",
+				},
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{Name: "my-vnet", ResourceGroup: "my-vnet-rg"},
+					InternalLoadBalancers: []infrav1.InternalLoadBalancerSpec{
+						{
+							Name:       "ingress-lb",
+							SubnetName: "my-node-subnet",
+							PrivateIP:  "10.1.0.10",
+							Rules:      []infrav1.InternalLoadBalancerRule{{Name: "https", Protocol: infrav1.LoadBalancerRuleProtocolTCP, FrontendPort: 443, BackendPort: 30443}},
+							Probe:      infrav1.InternalLoadBalancerProbe{Protocol: infrav1.ProbeProtocolTCP, Port: 30443},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestInternalLBSpecs(t *testing.T) {
+	g := NewWithT(t)
+	clusterScope := newInternalLoadBalancersTestScope()
+
+	g.Expect(clusterScope.InternalLBSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&loadbalancers.LBSpec{
+			Name:              "ingress-lb",
+			ResourceGroup:     "my-rg",
+			SubscriptionID:    "123",
+			ClusterName:       "my-cluster",
+			ClusterUID:        "uid-1",
+			Location:          "eastus",
+			VNetName:          "my-vnet",
+			VNetResourceGroup: "my-vnet-rg",
+			SubnetName:        "my-node-subnet",
+			FrontendIPConfigs: []infrav1.FrontendIP{
+				{Name: "ingress-lb-frontEnd", FrontendIPClass: infrav1.FrontendIPClass{PrivateIPAddress: "10.1.0.10"}},
+			},
+			Type:            infrav1.Internal,
+			SKU:             infrav1.SKUStandard,
+			Role:            infrav1.InternalServiceRole,
+			BackendPoolName: "ingress-lb-backendPool",
+			ServiceRules:    []infrav1.InternalLoadBalancerRule{{Name: "https", Protocol: infrav1.LoadBalancerRuleProtocolTCP, FrontendPort: 443, BackendPort: 30443}},
+			ServiceProbe:    &infrav1.InternalLoadBalancerProbe{Protocol: infrav1.ProbeProtocolTCP, Port: 30443},
+			AdditionalTags:  infrav1.Tags{},
+		},
+	}))
+	g.Expect(clusterScope.InternalLBAddressPoolIDs("my-node-subnet")).To(ConsistOf(
+		"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/ingress-lb/backendAddressPools/ingress-lb-backendPool",
+	))
+	g.Expect(clusterScope.InternalLBAddressPoolIDs("my-other-subnet")).To(BeEmpty())
+}
+
+func TestHasInternalLoadBalancers(t *testing.T) {
+	g := NewWithT(t)
+	clusterScope := newInternalLoadBalancersTestScope()
+	g.Expect(clusterScope.HasInternalLoadBalancers()).To(BeTrue())
+
+	clusterScope.AzureCluster.Spec.NetworkSpec.InternalLoadBalancers = nil
+	g.Expect(clusterScope.HasInternalLoadBalancers()).To(BeFalse())
+
+	g.Expect(clusterScope.UpdateAnnotationJSON(azure.InternalLoadBalancersLastAppliedAnnotation, map[string]interface{}{"ingress-lb": "my-rg"})).To(Succeed())
+	g.Expect(clusterScope.HasInternalLoadBalancers()).To(BeTrue())
+
+	g.Expect(clusterScope.UpdateAnnotationJSON(azure.InternalLoadBalancersLastAppliedAnnotation, map[string]interface{}{})).To(Succeed())
+	g.Expect(clusterScope.HasInternalLoadBalancers()).To(BeFalse())
+}
+
+func TestStaleInternalLoadBalancersScope(t *testing.T) {
+	g := NewWithT(t)
+	clusterScope := newInternalLoadBalancersTestScope()
+	s := NewStaleInternalLoadBalancersScope(clusterScope, map[string]string{"old-lb": "old-rg", "dns-lb": "my-rg"})
+
+	g.Expect(s.LBSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&loadbalancers.LBSpec{Name: "dns-lb", ResourceGroup: "my-rg", SubscriptionID: "123", ClusterName: "my-cluster", Role: infrav1.InternalServiceRole},
+		&loadbalancers.LBSpec{Name: "old-lb", ResourceGroup: "old-rg", SubscriptionID: "123", ClusterName: "my-cluster", Role: infrav1.InternalServiceRole},
+	}))
+	g.Expect(s.GlobalLBSpec()).To(BeNil())
+
+	s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, "loadbalancers", errors.New("internal error"))
+	g.Expect(conditions.Has(clusterScope.AzureCluster, infrav1.LoadBalancersReadyCondition)).To(BeFalse())
+	g.Expect(conditions.GetReason(clusterScope.AzureCluster, infrav1.InternalLoadBalancersReadyCondition)).To(Equal(infrav1.FailedReason))
+
+	internalScope := NewInternalLoadBalancersScope(clusterScope)
+	internalScope.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, "loadbalancers", nil)
+	g.Expect(conditions.IsTrue(clusterScope.AzureCluster, infrav1.InternalLoadBalancersReadyCondition)).To(BeTrue())
+	internalScope.SetLoadBalancerTier("ingress-lb", infrav1.LoadBalancerTierRegional)
+	g.Expect(clusterScope.AzureCluster.Status.LoadBalancerTiers).To(BeNil())
+}
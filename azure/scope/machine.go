@@ -27,6 +27,7 @@ import (
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
@@ -35,6 +36,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/inboundnatrules"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachines"
 	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
@@ -122,6 +124,18 @@ func (m *MachineScope) InitMachineCache(ctx context.Context) error {
 			return err
 		}
 
+		// Default images are published per Kubernetes version, so check that the one of the requested version exists
+		// in the location before trying to create the VM.
+		if m.AzureMachine.Spec.Image == nil {
+			imageCache, err := virtualmachineimages.GetCache(m, m.Location())
+			if err != nil {
+				return err
+			}
+			if err := imageCache.ValidateReferenceImage(ctx, m.cache.VMImage); err != nil {
+				return errors.Wrap(err, "failed to validate default image")
+			}
+		}
+
 		skuCache, err := resourceskus.GetCache(m, m.Location())
 		if err != nil {
 			return err
@@ -193,6 +207,26 @@ func (m *MachineScope) PublicIPSpecs() []azure.PublicIPSpec {
 	return spec
 }
 
+// StalePublicIPNames returns nil: the public IP of a machine is deleted with the machine.
+func (m *MachineScope) StalePublicIPNames() []string {
+	return nil
+}
+
+// SetPublicIPZones is a no-op: the zones of the public IP of a machine are not reported in the AzureMachine status.
+func (m *MachineScope) SetPublicIPZones(name string, zones []string) {}
+
+// SetControlPlaneEgressIPs is a no-op for the public IP of a machine, which is never the control plane egress.
+func (m *MachineScope) SetControlPlaneEgressIPs(ips []string) {}
+
+// SetPublicIPPrefixAllocations is a no-op: the public IP of a machine is never allocated from a public IP prefix.
+func (m *MachineScope) SetPublicIPPrefixAllocations(allocations map[string]string) {}
+
+// IsSSHNATRuleEnabled returns true if SSH to the control plane machines goes through the SSH NAT rule of the API server
+// LB, rather than an inbound NAT rule per machine.
+func (m *MachineScope) IsSSHNATRuleEnabled() bool {
+	return m.APIServerLB() != nil && m.APIServerLB().SSHNATRule != nil && !m.IsAPIServerPrivate()
+}
+
 // InboundNatSpecs returns the inbound NAT specs.
 func (m *MachineScope) InboundNatSpecs(portsInUse map[int32]struct{}) []azure.ResourceSpecGetter {
 	// The existing inbound NAT rules are needed in order to find an available SSH port for each new inbound NAT rule.
@@ -238,8 +272,15 @@ func (m *MachineScope) NICSpecs() []azure.ResourceSpecGetter {
 			spec.InternalLBName = m.APIServerLBName()
 			spec.InternalLBAddressPoolName = m.APIServerLBPoolName(m.APIServerLBName())
 		} else {
-			spec.PublicLBNATRuleName = m.Name()
+			if !m.IsSSHNATRuleEnabled() {
+				spec.PublicLBNATRuleName = m.Name()
+			}
 			spec.PublicLBAddressPoolName = m.APIServerLBPoolName(m.APIServerLBName())
+			// The internal frontend of a public API server LB is held by a companion internal LB sharing the backend.
+			if m.APIServerLB() != nil && m.APIServerLB().InternalFrontendIP != nil {
+				spec.InternalLBName = infrav1.GenerateInternalFrontendLBName(m.APIServerLBName())
+				spec.InternalLBAddressPoolName = m.APIServerLBPoolName(spec.InternalLBName)
+			}
 		}
 	}
 
@@ -253,6 +294,18 @@ func (m *MachineScope) NICSpecs() []azure.ResourceSpecGetter {
 		spec.PublicIPName = azure.GenerateNodePublicIPName(m.Name())
 	}
 
+	if m.Role() == infrav1.Node {
+		spec.ServiceLBAddressPoolIDs = m.InternalLBAddressPoolIDs(m.AzureMachine.Spec.SubnetName)
+	}
+
+	for _, asg := range m.ApplicationSecurityGroups() {
+		if asg.Role == infrav1.SubnetRole(m.Role()) {
+			spec.ApplicationSecurityGroupIDs = append(spec.ApplicationSecurityGroupIDs, azure.ApplicationSecurityGroupID(m.SubscriptionID(), m.ResourceGroup(), asg.Name))
+		}
+	}
+
+	spec.SecurityGroupName = m.NetworkInterfaceSecurityGroupName(m.Role())
+
 	if m.cache != nil {
 		spec.SKU = &m.cache.VMSKU
 	}
@@ -363,6 +416,26 @@ func (m *MachineScope) IsControlPlane() bool {
 	return util.IsControlPlaneMachine(m.Machine)
 }
 
+// ConnectionDrainTimeout returns how long the deletion of a control plane machine waits for the connections of the API
+// server load balancer to drain, zero for the other machines or when connection draining isn't configured.
+func (m *MachineScope) ConnectionDrainTimeout() time.Duration {
+	if !m.IsControlPlane() || m.APIServerLB() == nil || m.APIServerLB().ConnectionDraining == nil {
+		return 0
+	}
+	return m.APIServerLB().ConnectionDraining.Timeout.Duration
+}
+
+// DrainStartedAt returns the time the machine was removed from the backend pools of the API server load balancer, if
+// any.
+func (m *MachineScope) DrainStartedAt() *metav1.Time {
+	return m.AzureMachine.Status.DrainStartedAt
+}
+
+// SetDrainStartedAt stores the time the machine was removed from the backend pools of the API server load balancer.
+func (m *MachineScope) SetDrainStartedAt(startedAt metav1.Time) {
+	m.AzureMachine.Status.DrainStartedAt = &startedAt
+}
+
 // Role returns the machine role from the labels.
 func (m *MachineScope) Role() string {
 	if util.IsControlPlaneMachine(m.Machine) {
@@ -396,6 +469,11 @@ func (m *MachineScope) AvailabilitySetSpec() azure.ResourceSpecGetter {
 		return nil
 	}
 
+	// The availability set of the control plane, when the cluster has one, is reconciled with the cluster.
+	if m.IsControlPlane() && m.ControlPlaneAvailabilitySetID() != "" {
+		return nil
+	}
+
 	spec := &availabilitysets.AvailabilitySetSpec{
 		Name:           availabilitySetName,
 		ResourceGroup:  m.ResourceGroup(),
@@ -438,6 +516,9 @@ func (m *MachineScope) AvailabilitySet() (string, bool) {
 // AvailabilitySetID returns the availability set for this machine, or "" if there is no availability set.
 func (m *MachineScope) AvailabilitySetID() string {
 	var asID string
+	if m.IsControlPlane() && m.ControlPlaneAvailabilitySetID() != "" {
+		return m.ControlPlaneAvailabilitySetID()
+	}
 	if asName, ok := m.AvailabilitySet(); ok {
 		asID = azure.AvailabilitySetID(m.SubscriptionID(), m.ResourceGroup(), asName)
 	}
@@ -585,6 +666,15 @@ func (m *MachineScope) AdditionalTags() infrav1.Tags {
 	return tags
 }
 
+// ExpectedEnvironment returns the value of the environment tag the existing Azure resources of the machine must have to
+// be adopted or deleted, which is the one of its cluster, if any.
+func (m *MachineScope) ExpectedEnvironment() string {
+	if envScope, ok := m.ClusterScoper.(interface{ ExpectedEnvironment() string }); ok {
+		return envScope.ExpectedEnvironment()
+	}
+	return ""
+}
+
 // GetBootstrapData returns the bootstrap data from the secret in the Machine's bootstrap.dataSecretName.
 func (m *MachineScope) GetBootstrapData(ctx context.Context) (string, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.MachineScope.GetBootstrapData")
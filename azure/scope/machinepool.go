@@ -125,6 +125,8 @@ func (m *MachinePoolScope) ScaleSetSpec() azure.ScaleSetSpec {
 		SpotVMOptions:                m.AzureMachinePool.Spec.Template.SpotVMOptions,
 		FailureDomains:               m.MachinePool.Spec.FailureDomains,
 		TerminateNotificationTimeout: m.AzureMachinePool.Spec.Template.TerminateNotificationTimeout,
+		SecurityGroupName:            m.NetworkInterfaceSecurityGroupName(infrav1.Node),
+		ServiceLBAddressPoolIDs:      m.InternalLBAddressPoolIDs(m.AzureMachinePool.Spec.Template.SubnetName),
 	}
 }
 
@@ -57,6 +57,9 @@ type ManagedControlPlaneScopeParams struct {
 	InfraMachinePool *infrav1exp.AzureManagedMachinePool
 	MachinePool      *expv1.MachinePool
 	PatchTarget      conditions.Setter
+	// DefaultTags are applied to all the Azure resources of the cluster, beneath the tags of the cluster. They default
+	// to the tags of the DefaultTagsEnvVar environment variable.
+	DefaultTags infrav1.Tags
 }
 
 // NewManagedControlPlaneScope creates a new Scope from the supplied parameters.
@@ -88,6 +91,11 @@ func NewManagedControlPlaneScope(ctx context.Context, params ManagedControlPlane
 		}
 	}
 
+	tags, err := defaultTags(params.DefaultTags)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load default tags")
+	}
+
 	helper, err := patch.NewHelper(params.PatchTarget, params.Client)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to init patch helper")
@@ -102,6 +110,7 @@ func NewManagedControlPlaneScope(ctx context.Context, params ManagedControlPlane
 		InfraMachinePool: params.InfraMachinePool,
 		PatchTarget:      params.PatchTarget,
 		patchHelper:      helper,
+		defaultTags:      tags,
 	}, nil
 }
 
@@ -119,6 +128,8 @@ type ManagedControlPlaneScope struct {
 	PatchTarget      conditions.Setter
 
 	AllNodePools []infrav1exp.AzureManagedMachinePool
+
+	defaultTags infrav1.Tags
 }
 
 // ResourceGroup returns the managed control plane's resource group.
@@ -155,12 +166,18 @@ func (s *ManagedControlPlaneScope) AvailabilitySetEnabled() bool {
 	return false // not applicable for a managed control plane
 }
 
+// ControlPlaneAvailabilitySetID is always empty for a managed control plane.
+func (s *ManagedControlPlaneScope) ControlPlaneAvailabilitySetID() string {
+	return "" // not applicable for a managed control plane
+}
+
 // AdditionalTags returns AdditionalTags from the ControlPlane spec.
 func (s *ManagedControlPlaneScope) AdditionalTags() infrav1.Tags {
 	tags := make(infrav1.Tags)
-	if s.ControlPlane.Spec.AdditionalTags != nil {
-		tags = s.ControlPlane.Spec.AdditionalTags.DeepCopy()
-	}
+	// Start with the default tags...
+	tags.Merge(s.defaultTags)
+	// ... and merge in the control plane's
+	tags.Merge(s.ControlPlane.Spec.AdditionalTags)
 	return tags
 }
 
@@ -228,18 +245,29 @@ func (s *ManagedControlPlaneScope) GroupSpec() azure.ResourceSpecGetter {
 	}
 }
 
+// SetResourceGroupLocation is a no-op for managed clusters, whose status doesn't report the location of the resource
+// group.
+func (s *ManagedControlPlaneScope) SetResourceGroupLocation(_ string) {}
+
+// SetResourceGroupTags is a no-op for managed clusters, whose resources don't inherit the tags of the resource group.
+func (s *ManagedControlPlaneScope) SetResourceGroupTags(_ infrav1.Tags) {}
+
 // VNetSpec returns the virtual network spec.
 func (s *ManagedControlPlaneScope) VNetSpec() azure.ResourceSpecGetter {
 	return &virtualnetworks.VNetSpec{
 		ResourceGroup:  s.Vnet().ResourceGroup,
 		Name:           s.Vnet().Name,
 		CIDRs:          s.Vnet().CIDRBlocks,
+		DNSServers:     s.Vnet().DNSServers,
 		Location:       s.Location(),
 		ClusterName:    s.ClusterName(),
 		AdditionalTags: s.AdditionalTags(),
 	}
 }
 
+// SetDNSServers is a no-op for managed clusters, the custom DNS servers are not reported in status.
+func (s *ManagedControlPlaneScope) SetDNSServers(_ []string) {}
+
 // ControlPlaneRouteTable returns the cluster controlplane routetable.
 func (s *ManagedControlPlaneScope) ControlPlaneRouteTable() infrav1.RouteTable {
 	return infrav1.RouteTable{}
@@ -298,6 +326,24 @@ func (s *ManagedControlPlaneScope) UpdateSubnetCIDRs(_ string, _ []string) {
 	// no-op
 }
 
+// UpdateSubnetAvailableIPs stores the number of available IP addresses of the subnet with the same name.
+// This is not used when using a managed control plane.
+func (s *ManagedControlPlaneScope) UpdateSubnetAvailableIPs(_ string, _ int32) {
+	// no-op
+}
+
+// SetSubnetIPsAvailable marks the subnets as having enough available IP addresses.
+// This is not used when using a managed control plane.
+func (s *ManagedControlPlaneScope) SetSubnetIPsAvailable() {
+	// no-op
+}
+
+// SetSubnetIPsNotAvailable marks the subnets as running out of available IP addresses.
+// This is not used when using a managed control plane.
+func (s *ManagedControlPlaneScope) SetSubnetIPsNotAvailable(_ string, _ clusterv1.ConditionSeverity, _ string, _ ...interface{}) {
+	// no-op
+}
+
 // UpdateSubnetIDs updates the subnet IDs for the subnet with the same name.
 // This is not used when using a managed control plane.
 func (s *ManagedControlPlaneScope) UpdateSubnetID(_ string, _ string) {
@@ -375,6 +421,26 @@ func (s *ManagedControlPlaneScope) OutboundPoolName(_ string) string {
 	return "aksOutboundBackendPool" // hard-coded in aks
 }
 
+// ApplicationSecurityGroups returns the application security groups of the cluster.
+// Currently always empty as managed clusters do not support application security groups.
+func (s *ManagedControlPlaneScope) ApplicationSecurityGroups() []infrav1.ApplicationSecurityGroup {
+	return nil
+}
+
+// NetworkInterfaceSecurityGroupName returns the name of the security group of the network interfaces of the machines
+// of a role.
+// Currently always empty as managed clusters do not support network interface security groups.
+func (s *ManagedControlPlaneScope) NetworkInterfaceSecurityGroupName(role string) string {
+	return ""
+}
+
+// InternalLBAddressPoolIDs returns the IDs of the backend pools of the internal load balancers fronting services of
+// the cluster that the nodes of a subnet are added to.
+// Currently always empty as managed clusters do not support internal load balancers fronting services.
+func (s *ManagedControlPlaneScope) InternalLBAddressPoolIDs(_ string) []string {
+	return nil
+}
+
 // GetPrivateDNSZoneName returns the Private DNS Zone from the spec or generate it from cluster name.
 // Currently always empty as managed control planes do not currently implement private clusters.
 func (s *ManagedControlPlaneScope) GetPrivateDNSZoneName() string {
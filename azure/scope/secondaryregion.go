@@ -0,0 +1,230 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
+	"sigs.k8s.io/cluster-api-provider-azure/version"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// SecondaryRegionScope is the scope of the network of the secondary region of a cluster. It describes the secondary
+// region to the virtual network, subnet and security group services, so that they reconcile its network as they do the
+// network of the cluster, and records what they report in the SecondaryNetwork status of the cluster instead.
+type SecondaryRegionScope struct {
+	*ClusterScope
+	region *infrav1.SecondaryRegionSpec
+}
+
+// NewSecondaryRegionScope creates the scope of the network of the secondary region of a cluster.
+func NewSecondaryRegionScope(clusterScope *ClusterScope) *SecondaryRegionScope {
+	return &SecondaryRegionScope{ClusterScope: clusterScope, region: clusterScope.AzureCluster.Spec.SecondaryRegion}
+}
+
+// NewStaleSecondaryRegionScope creates the scope of the network of a secondary region removed from the spec of a
+// cluster, as it was last reconciled, so that it can be deleted.
+func NewStaleSecondaryRegionScope(clusterScope *ClusterScope, region *infrav1.SecondaryRegionSpec) *SecondaryRegionScope {
+	return &SecondaryRegionScope{ClusterScope: clusterScope, region: region}
+}
+
+// Location returns the secondary region, which defaults to the region paired with the location of the cluster.
+func (s *SecondaryRegionScope) Location() string {
+	if location := s.region.Location; location != "" {
+		return location
+	}
+	return s.AzureCluster.Status.PairedRegion
+}
+
+// Vnet returns the virtual network of the secondary region.
+func (s *SecondaryRegionScope) Vnet() *infrav1.VnetSpec {
+	return &s.region.Vnet
+}
+
+// IsVnetManaged returns true if the virtual network of the secondary region is managed.
+func (s *SecondaryRegionScope) IsVnetManaged() bool {
+	return s.Vnet().IsManaged(s.ClusterName())
+}
+
+// VNetSpec returns the virtual network spec of the secondary region.
+func (s *SecondaryRegionScope) VNetSpec() azure.ResourceSpecGetter {
+	return &virtualnetworks.VNetSpec{
+		ResourceGroup:   s.Vnet().ResourceGroup,
+		Name:            s.Vnet().Name,
+		CIDRs:           s.Vnet().CIDRBlocks,
+		DNSServers:      s.Vnet().DNSServers,
+		Location:        s.Location(),
+		ClusterName:     s.ClusterName(),
+		ProviderVersion: version.Get().Marker(),
+		AdditionalTags:  s.AdditionalTags(),
+		PodCIDRs:        s.PodCIDRs(),
+		ServiceCIDRs:    s.ServiceCIDRs(),
+	}
+}
+
+// SetDNSServers does nothing: the status of the cluster reports the DNS servers of the virtual network of the cluster.
+func (s *SecondaryRegionScope) SetDNSServers(_ []string) {}
+
+// SubnetSpecs returns the subnet specs of the secondary region.
+func (s *SecondaryRegionScope) SubnetSpecs() []azure.ResourceSpecGetter {
+	secondarySubnets := s.region.Subnets
+	subnetSpecs := make([]azure.ResourceSpecGetter, 0, len(secondarySubnets))
+	for _, subnet := range secondarySubnets {
+		subnetSpecs = append(subnetSpecs, &subnets.SubnetSpec{
+			Name:              subnet.Name,
+			ResourceGroup:     s.ResourceGroup(),
+			SubscriptionID:    s.SubscriptionID(),
+			CIDRs:             subnet.CIDRBlocks,
+			VNetName:          s.Vnet().Name,
+			VNetResourceGroup: s.Vnet().ResourceGroup,
+			IsVNetManaged:     s.IsVnetManaged(),
+			SecurityGroupName: subnet.SecurityGroupName,
+			Role:              subnet.Role,
+		})
+	}
+	return subnetSpecs
+}
+
+// UpdateSubnetID records the ID of a subnet of the secondary region in the status of the cluster.
+func (s *SecondaryRegionScope) UpdateSubnetID(name string, id string) {
+	status := s.networkStatus()
+	if status.SubnetIDs == nil {
+		status.SubnetIDs = make(map[string]string)
+	}
+	status.SubnetIDs[name] = id
+}
+
+// UpdateSubnetCIDRs does nothing: the CIDR blocks of the subnets of the secondary region are the ones of the spec.
+func (s *SecondaryRegionScope) UpdateSubnetCIDRs(_ string, _ []string) {}
+
+// UpdateSubnetAvailableIPs does nothing: no machine runs in the secondary region until a failover.
+func (s *SecondaryRegionScope) UpdateSubnetAvailableIPs(_ string, _ int32) {}
+
+// SetSubnetIPsAvailable does nothing: the SubnetIPsAvailable condition reports the subnets of the cluster.
+func (s *SecondaryRegionScope) SetSubnetIPsAvailable() {}
+
+// SetSubnetIPsNotAvailable does nothing: the SubnetIPsAvailable condition reports the subnets of the cluster.
+func (s *SecondaryRegionScope) SetSubnetIPsNotAvailable(_ string, _ clusterv1.ConditionSeverity, _ string, _ ...interface{}) {
+}
+
+// AnnotationJSON returns a map[string]interface from a JSON annotation, the subnets of the secondary region being
+// tracked in their own annotation.
+func (s *SecondaryRegionScope) AnnotationJSON(annotation string) (map[string]interface{}, error) {
+	return s.ClusterScope.AnnotationJSON(secondaryAnnotation(annotation))
+}
+
+// UpdateAnnotationJSON updates the `annotation` with `content`, the subnets of the secondary region being tracked in
+// their own annotation.
+func (s *SecondaryRegionScope) UpdateAnnotationJSON(annotation string, content map[string]interface{}) error {
+	return s.ClusterScope.UpdateAnnotationJSON(secondaryAnnotation(annotation), content)
+}
+
+// secondaryAnnotation returns the annotation tracking the resources of the secondary region in place of the one tracking
+// the resources of the cluster.
+func secondaryAnnotation(annotation string) string {
+	if annotation == azure.SubnetsLastAppliedAnnotation {
+		return azure.SecondarySubnetsLastAppliedAnnotation
+	}
+	return annotation
+}
+
+// NSGSpecs returns the security group specs of the subnets of the secondary region. Each one has the security rules of
+// the first subnet of the same role of the cluster, so that the machines of a failover are reachable as in the cluster,
+// except the rules referencing application security groups, which can't be used across regions.
+func (s *SecondaryRegionScope) NSGSpecs() []azure.NSGSpec {
+	secondarySubnets := s.region.Subnets
+	nsgSpecs := make([]azure.NSGSpec, 0, len(secondarySubnets))
+	for _, subnet := range secondarySubnets {
+		var securityRules infrav1.SecurityRules
+		for _, primary := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+			if primary.Role == subnet.Role {
+				securityRules = withoutApplicationSecurityGroupRules(s.subnetSecurityRules(primary))
+				break
+			}
+		}
+		nsgSpecs = append(nsgSpecs, azure.NSGSpec{
+			Name:          subnet.SecurityGroupName,
+			SecurityRules: securityRules,
+		})
+	}
+	return nsgSpecs
+}
+
+// withoutApplicationSecurityGroupRules returns the security rules that don't reference application security groups.
+func withoutApplicationSecurityGroupRules(securityRules infrav1.SecurityRules) infrav1.SecurityRules {
+	rules := make(infrav1.SecurityRules, 0, len(securityRules))
+	for _, rule := range securityRules {
+		if len(rule.SourceApplicationSecurityGroups) > 0 || len(rule.DestinationApplicationSecurityGroups) > 0 {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// SetNetworkInterfaceSecurityGroupID does nothing: the security groups of the secondary region are only attached to its
+// subnets.
+func (s *SecondaryRegionScope) SetNetworkInterfaceSecurityGroupID(_, _ string) {}
+
+// UpdatePutStatus updates the SecondaryNetworkReady condition of the cluster for a resource of the secondary region.
+func (s *SecondaryRegionScope) UpdatePutStatus(_ clusterv1.ConditionType, service string, err error) {
+	s.ClusterScope.UpdatePutStatus(infrav1.SecondaryNetworkReadyCondition, "secondary "+service, err)
+}
+
+// UpdateDeleteStatus updates the SecondaryNetworkReady condition of the cluster for a resource of the secondary region.
+func (s *SecondaryRegionScope) UpdateDeleteStatus(_ clusterv1.ConditionType, service string, err error) {
+	s.ClusterScope.UpdateDeleteStatus(infrav1.SecondaryNetworkReadyCondition, "secondary "+service, err)
+}
+
+// UpdatePatchStatus updates the SecondaryNetworkReady condition of the cluster for a resource of the secondary region.
+func (s *SecondaryRegionScope) UpdatePatchStatus(_ clusterv1.ConditionType, service string, err error) {
+	s.ClusterScope.UpdatePatchStatus(infrav1.SecondaryNetworkReadyCondition, "secondary "+service, err)
+}
+
+// UpdateNetworkStatus records the location and the virtual network of the secondary region in the status of the
+// cluster, once reconciled.
+func (s *SecondaryRegionScope) UpdateNetworkStatus() {
+	status := s.networkStatus()
+	status.Location = s.Location()
+	status.VnetID = s.Vnet().ID
+}
+
+// UpdateLastApplied records the secondary region, in its location, in an annotation of the cluster, so that its network
+// can be deleted once the secondary region is removed from the spec.
+func (s *SecondaryRegionScope) UpdateLastApplied() error {
+	region := s.region.DeepCopy()
+	region.Location = s.Location()
+	b, err := json.Marshal(region)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the secondary region")
+	}
+	s.SetAnnotation(azure.SecondaryRegionLastAppliedAnnotation, string(b))
+	return nil
+}
+
+// networkStatus returns the status of the network of the secondary region, initializing it when needed.
+func (s *SecondaryRegionScope) networkStatus() *infrav1.SecondaryNetworkStatus {
+	if s.AzureCluster.Status.SecondaryNetwork == nil {
+		s.AzureCluster.Status.SecondaryNetwork = &infrav1.SecondaryNetworkStatus{}
+	}
+	return s.AzureCluster.Status.SecondaryNetwork
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func newSecondaryRegionTestScope() *SecondaryRegionScope {
+	return NewSecondaryRegionScope(&ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+		},
+		AzureClients: AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Values: map[string]string{
+					auth.SubscriptionID: "123",
+				},
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+					Location: "eastus",
+				},
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{Name: "my-vnet", ResourceGroup: "my-rg"},
+					Subnets: infrav1.Subnets{
+						{
+							Name: "my-node-subnet",
+							SecurityGroup: infrav1.SecurityGroup{
+								Name: "my-node-nsg",
+								SecurityGroupClass: infrav1.SecurityGroupClass{
+									SecurityRules: infrav1.SecurityRules{
+										{Name: "allow_ssh", Protocol: infrav1.SecurityGroupProtocolTCP, Direction: infrav1.SecurityRuleDirectionInbound, Priority: 2200, DestinationPorts: to.StringPtr("22")},
+										{Name: "allow_web", Protocol: infrav1.SecurityGroupProtocolTCP, Direction: infrav1.SecurityRuleDirectionInbound, Priority: 2201, DestinationPorts: to.StringPtr("443"), SourceApplicationSecurityGroups: []string{"web"}},
+									},
+								},
+							},
+							SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetNode, CIDRBlocks: []string{"10.1.0.0/16"}},
+						},
+					},
+				},
+				SecondaryRegion: &infrav1.SecondaryRegionSpec{
+					Vnet: infrav1.VnetSpec{
+						Name:          "my-secondary-vnet",
+						ResourceGroup: "my-rg",
+						VnetClassSpec: infrav1.VnetClassSpec{CIDRBlocks: []string{"10.0.0.0/8"}},
+					},
+					Subnets: []infrav1.SecondarySubnetSpec{
+						{
+							Name:              "my-secondary-node-subnet",
+							SecurityGroupName: "my-secondary-node-nsg",
+							SubnetClassSpec:   infrav1.SubnetClassSpec{Role: infrav1.SubnetNode, CIDRBlocks: []string{"10.1.0.0/16"}},
+						},
+					},
+				},
+			},
+			Status: infrav1.AzureClusterStatus{
+				PairedRegion: "westus",
+			},
+		},
+	})
+}
+
+func TestSecondaryRegionLocation(t *testing.T) {
+	g := NewWithT(t)
+
+	s := newSecondaryRegionTestScope()
+	g.Expect(s.Location()).To(Equal("westus"))
+	g.Expect(s.ClusterScope.Location()).To(Equal("eastus"))
+
+	s.AzureCluster.Spec.SecondaryRegion.Location = "centralus"
+	g.Expect(s.Location()).To(Equal("centralus"))
+}
+
+func TestSecondaryRegionNetworkSpecs(t *testing.T) {
+	g := NewWithT(t)
+
+	s := newSecondaryRegionTestScope()
+	g.Expect(s.IsVnetManaged()).To(BeTrue())
+
+	vnetSpec, ok := s.VNetSpec().(*virtualnetworks.VNetSpec)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(vnetSpec.Name).To(Equal("my-secondary-vnet"))
+	g.Expect(vnetSpec.Location).To(Equal("westus"))
+	g.Expect(vnetSpec.CIDRs).To(Equal([]string{"10.0.0.0/8"}))
+
+	g.Expect(s.SubnetSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&subnets.SubnetSpec{
+			Name:              "my-secondary-node-subnet",
+			ResourceGroup:     "my-rg",
+			SubscriptionID:    "123",
+			CIDRs:             []string{"10.1.0.0/16"},
+			VNetName:          "my-secondary-vnet",
+			VNetResourceGroup: "my-rg",
+			IsVNetManaged:     true,
+			SecurityGroupName: "my-secondary-node-nsg",
+			Role:              infrav1.SubnetNode,
+		},
+	}))
+
+	// The rule referencing an application security group of the region of the cluster isn't copied.
+	nsgSpecs := s.NSGSpecs()
+	g.Expect(nsgSpecs).To(HaveLen(1))
+	g.Expect(nsgSpecs[0].Name).To(Equal("my-secondary-node-nsg"))
+	g.Expect(nsgSpecs[0].SecurityRules).To(HaveLen(1))
+	g.Expect(nsgSpecs[0].SecurityRules[0].Name).To(Equal("allow_ssh"))
+}
+
+func TestSecondaryRegionStatus(t *testing.T) {
+	g := NewWithT(t)
+
+	s := newSecondaryRegionTestScope()
+	s.SetDNSServers([]string{"10.0.0.4"})
+	s.UpdateSubnetID("my-secondary-node-subnet", "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-secondary-vnet/subnets/my-secondary-node-subnet")
+	s.Vnet().ID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-secondary-vnet"
+	s.UpdateNetworkStatus()
+	s.UpdatePutStatus(infrav1.VNetReadyCondition, "virtualnetwork", nil)
+
+	g.Expect(s.AzureCluster.Status.DNSServers).To(BeNil())
+	g.Expect(s.AzureCluster.Status.SecondaryNetwork).To(Equal(&infrav1.SecondaryNetworkStatus{
+		Location: "westus",
+		VnetID:   "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-secondary-vnet",
+		SubnetIDs: map[string]string{
+			"my-secondary-node-subnet": "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-secondary-vnet/subnets/my-secondary-node-subnet",
+		},
+	}))
+	g.Expect(conditions.IsTrue(s.AzureCluster, infrav1.SecondaryNetworkReadyCondition)).To(BeTrue())
+	g.Expect(conditions.Has(s.AzureCluster, infrav1.VNetReadyCondition)).To(BeFalse())
+
+	s.ClearSecondaryNetworkStatus()
+	g.Expect(s.AzureCluster.Status.SecondaryNetwork).To(BeNil())
+	g.Expect(conditions.Has(s.AzureCluster, infrav1.SecondaryNetworkReadyCondition)).To(BeFalse())
+}
+
+func TestSecondaryRegionLastApplied(t *testing.T) {
+	g := NewWithT(t)
+
+	s := newSecondaryRegionTestScope()
+	lastApplied, err := s.SecondaryRegionLastApplied()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(lastApplied).To(BeNil())
+
+	// The secondary region is recorded in its location, the paired region, so that its network can be deleted once it
+	// is removed from the spec.
+	g.Expect(s.UpdateLastApplied()).To(Succeed())
+	s.AzureCluster.Spec.SecondaryRegion = nil
+	s.AzureCluster.Status.PairedRegion = ""
+	lastApplied, err = s.SecondaryRegionLastApplied()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(lastApplied.Location).To(Equal("westus"))
+
+	stale := NewStaleSecondaryRegionScope(s.ClusterScope, lastApplied)
+	g.Expect(stale.Location()).To(Equal("westus"))
+	g.Expect(stale.Vnet().Name).To(Equal("my-secondary-vnet"))
+	g.Expect(stale.SubnetSpecs()).To(HaveLen(1))
+	g.Expect(stale.NSGSpecs()[0].Name).To(Equal("my-secondary-node-nsg"))
+
+	s.ClearSecondaryRegionLastApplied()
+	lastApplied, err = s.SecondaryRegionLastApplied()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(lastApplied).To(BeNil())
+}
+
+func TestSecondaryRegionAnnotations(t *testing.T) {
+	g := NewWithT(t)
+
+	s := newSecondaryRegionTestScope()
+	g.Expect(s.UpdateAnnotationJSON(azure.SubnetsLastAppliedAnnotation, map[string]interface{}{"my-secondary-node-subnet": "my-secondary-vnet"})).To(Succeed())
+
+	g.Expect(s.AzureCluster.GetAnnotations()).To(HaveKey(azure.SecondarySubnetsLastAppliedAnnotation))
+	g.Expect(s.AzureCluster.GetAnnotations()).NotTo(HaveKey(azure.SubnetsLastAppliedAnnotation))
+	lastApplied, err := s.AnnotationJSON(azure.SubnetsLastAppliedAnnotation)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(lastApplied).To(Equal(map[string]interface{}{"my-secondary-node-subnet": "my-secondary-vnet"}))
+}
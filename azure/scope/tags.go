@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+// DefaultTagsEnvVar is the environment variable of the controller holding the tags applied by default to the Azure
+// resources of every cluster, as comma-separated key=value pairs, e.g. "costCenter=1234,owner=platform".
+const DefaultTagsEnvVar = "AZURE_DEFAULT_TAGS"
+
+// defaultTags returns the given default tags, or the default tags of the DefaultTagsEnvVar environment variable if
+// none is given.
+func defaultTags(tags infrav1.Tags) (infrav1.Tags, error) {
+	if tags != nil {
+		return tags.DeepCopy(), nil
+	}
+	return parseTags(os.Getenv(DefaultTagsEnvVar))
+}
+
+// parseTags parses comma-separated key=value pairs into tags.
+func parseTags(value string) (infrav1.Tags, error) {
+	tags := make(infrav1.Tags)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		key := strings.TrimSpace(kv[0])
+		if len(kv) != 2 || key == "" {
+			return nil, errors.Errorf("invalid tag %q, expected key=value", pair)
+		}
+		tags[key] = strings.TrimSpace(kv[1])
+	}
+	return tags, nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    infrav1.Tags
+		wantErr bool
+	}{
+		{
+			name:  "no tags",
+			value: "",
+			want:  infrav1.Tags{},
+		},
+		{
+			name:  "several tags",
+			value: "costCenter=1234, owner=platform,empty=",
+			want:  infrav1.Tags{"costCenter": "1234", "owner": "platform", "empty": ""},
+		},
+		{
+			name:  "value with an equal sign",
+			value: "query=a=b",
+			want:  infrav1.Tags{"query": "a=b"},
+		},
+		{
+			name:    "missing value",
+			value:   "costCenter",
+			wantErr: true,
+		},
+		{
+			name:    "missing key",
+			value:   "=1234",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			tags, err := parseTags(tc.value)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(tags).To(Equal(tc.want))
+			}
+		})
+	}
+}
+
+func TestDefaultTags(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(DefaultTagsEnvVar, "costCenter=1234")
+
+	tags, err := defaultTags(nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tags).To(Equal(infrav1.Tags{"costCenter": "1234"}))
+
+	tags, err = defaultTags(infrav1.Tags{"owner": "platform"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tags).To(Equal(infrav1.Tags{"owner": "platform"}))
+}
+
+func TestAdditionalTagsPrecedence(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+					AdditionalTags: infrav1.Tags{"owner": "team-a", "environment": "staging"},
+				},
+			},
+		},
+		defaultTags: infrav1.Tags{"costCenter": "1234", "owner": "platform", "environment": "dev"},
+	}
+	machineScope := &MachineScope{
+		ClusterScoper: clusterScope,
+		AzureMachine: &infrav1.AzureMachine{
+			Spec: infrav1.AzureMachineSpec{
+				AdditionalTags: infrav1.Tags{"environment": "prod"},
+			},
+		},
+	}
+
+	g.Expect(clusterScope.AdditionalTags()).To(Equal(infrav1.Tags{
+		"costCenter":  "1234",
+		"owner":       "team-a",
+		"environment": "staging",
+	}))
+	g.Expect(machineScope.AdditionalTags()).To(Equal(infrav1.Tags{
+		"costCenter":  "1234",
+		"owner":       "team-a",
+		"environment": "prod",
+		infrav1.ClusterAzureCloudProviderTagKey("my-cluster"): string(infrav1.ResourceLifecycleOwned),
+	}))
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationsecuritygroups
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const serviceName = "applicationsecuritygroups"
+
+// ASGScope defines the scope interface for an application security groups service.
+type ASGScope interface {
+	azure.ClusterDescriber
+	azure.AsyncStatusUpdater
+	ApplicationSecurityGroupSpecs() []azure.ResourceSpecGetter
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope ASGScope
+	async.Getter
+	async.Reconciler
+}
+
+// New creates a new application security groups service.
+func New(scope ASGScope) *Service {
+	client := newClient(scope)
+	return &Service{
+		Scope:      scope,
+		Getter:     client,
+		Reconciler: async.New(scope, client, client),
+	}
+}
+
+// Reconcile gets/creates the application security groups of the cluster.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "applicationsecuritygroups.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	specs := s.Scope.ApplicationSecurityGroupSpecs()
+	if len(specs) == 0 {
+		log.V(4).Info("skipping application security groups reconcile, no application security groups are configured")
+		return nil
+	}
+
+	// We go through the list of ASGSpecs to reconcile each one, independently of the resultingErr of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (ie. error creating) -> operationNotDoneError (ie. creating in progress) -> no error (ie. created)
+	var resultingErr error
+	for _, asgSpec := range specs {
+		if _, err := s.CreateResource(ctx, asgSpec, serviceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resultingErr == nil {
+				resultingErr = err
+			}
+		}
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, resultingErr)
+	return resultingErr
+}
+
+// Delete deletes the application security groups managed by capz. Adopted groups are left untouched.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "applicationsecuritygroups.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	specs := s.Scope.ApplicationSecurityGroupSpecs()
+	if len(specs) == 0 {
+		log.V(4).Info("skipping application security groups deletion, no application security groups are configured")
+		return nil
+	}
+
+	var resultingErr error
+	for _, asgSpec := range specs {
+		if err := s.deleteIfOwned(ctx, asgSpec); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resultingErr == nil {
+				resultingErr = err
+			}
+		}
+	}
+
+	s.Scope.UpdateDeleteStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, resultingErr)
+	return resultingErr
+}
+
+// deleteIfOwned deletes the application security group if it exists and is managed by capz.
+func (s *Service) deleteIfOwned(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "applicationsecuritygroups.Service.deleteIfOwned")
+	defer done()
+
+	existing, err := s.Get(ctx, spec)
+	if azure.ResourceNotFound(err) {
+		// already deleted or doesn't exist.
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to get application security group %s in resource group %s", spec.ResourceName(), spec.ResourceGroupName())
+	}
+
+	asg, ok := existing.(network.ApplicationSecurityGroup)
+	if !ok {
+		return errors.Errorf("%T is not a network.ApplicationSecurityGroup", existing)
+	}
+
+	if !converters.MapToTags(asg.Tags).HasOwned(s.Scope.ClusterName()) {
+		log.V(2).Info("skipping deletion of unmanaged application security group", "application security group", spec.ResourceName())
+		return nil
+	}
+
+	return s.DeleteResource(ctx, spec, serviceName)
+}
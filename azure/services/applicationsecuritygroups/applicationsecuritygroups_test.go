@@ -0,0 +1,210 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationsecuritygroups
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups/mock_applicationsecuritygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakeControlPlaneASGSpec = ASGSpec{
+		Name:          "control-plane-asg",
+		ResourceGroup: "my-rg",
+		Location:      "westus",
+		ClusterName:   "my-cluster",
+	}
+	fakeNodeASGSpec = ASGSpec{
+		Name:          "node-asg",
+		ResourceGroup: "my-rg",
+		Location:      "westus",
+		ClusterName:   "my-cluster",
+	}
+
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+	notFoundError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not Found")
+
+	ownedASG = network.ApplicationSecurityGroup{
+		Name: to.StringPtr("control-plane-asg"),
+		Tags: map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned")},
+	}
+	unmanagedASG = network.ApplicationSecurityGroup{
+		Name: to.StringPtr("node-asg"),
+	}
+)
+
+func TestReconcileApplicationSecurityGroups(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no application security groups are configured",
+			expectedError: "",
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "create application security groups",
+			expectedError: "",
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeControlPlaneASGSpec, &fakeNodeASGSpec})
+				r.CreateResource(gomockinternal.AContext(), &fakeControlPlaneASGSpec, serviceName).Return(ownedASG, nil)
+				r.CreateResource(gomockinternal.AContext(), &fakeNodeASGSpec, serviceName).Return(unmanagedASG, nil)
+				s.UpdatePutStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "fail to create an application security group",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeControlPlaneASGSpec, &fakeNodeASGSpec})
+				r.CreateResource(gomockinternal.AContext(), &fakeControlPlaneASGSpec, serviceName).Return(nil, internalError)
+				r.CreateResource(gomockinternal.AContext(), &fakeNodeASGSpec, serviceName).Return(unmanagedASG, nil)
+				s.UpdatePutStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_applicationsecuritygroups.NewMockASGScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteApplicationSecurityGroups(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no application security groups are configured",
+			expectedError: "",
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "delete owned and skip unmanaged application security groups",
+			expectedError: "",
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeControlPlaneASGSpec, &fakeNodeASGSpec})
+				g.Get(gomockinternal.AContext(), &fakeControlPlaneASGSpec).Return(ownedASG, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &fakeControlPlaneASGSpec, serviceName).Return(nil)
+				g.Get(gomockinternal.AContext(), &fakeNodeASGSpec).Return(unmanagedASG, nil)
+				s.ClusterName().Return("my-cluster")
+				s.UpdateDeleteStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "application security groups already deleted",
+			expectedError: "",
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeControlPlaneASGSpec})
+				g.Get(gomockinternal.AContext(), &fakeControlPlaneASGSpec).Return(nil, notFoundError)
+				s.UpdateDeleteStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "fail to get application security group",
+			expectedError: "failed to get application security group control-plane-asg in resource group my-rg: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeControlPlaneASGSpec})
+				g.Get(gomockinternal.AContext(), &fakeControlPlaneASGSpec).Return(nil, internalError)
+				s.UpdateDeleteStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, gomock.Any())
+			},
+		},
+		{
+			name:          "fail to delete owned application security group",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_applicationsecuritygroups.MockASGScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ApplicationSecurityGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeControlPlaneASGSpec})
+				g.Get(gomockinternal.AContext(), &fakeControlPlaneASGSpec).Return(ownedASG, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &fakeControlPlaneASGSpec, serviceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.ApplicationSecurityGroupsReadyCondition, serviceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_applicationsecuritygroups.NewMockASGScope(mockCtrl)
+			getterMock := mock_async.NewMockGetter(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), getterMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Getter:     getterMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -34,20 +34,24 @@ type Service struct {
 	Scope FutureScope
 	Creator
 	Deleter
+
+	notFoundRetry azure.NotFoundRetry
 }
 
 // New creates a new async service.
 func New(scope FutureScope, createClient Creator, deleteClient Deleter) *Service {
 	return &Service{
-		Scope:   scope,
-		Creator: createClient,
-		Deleter: deleteClient,
+		Scope:         scope,
+		Creator:       createClient,
+		Deleter:       deleteClient,
+		notFoundRetry: azure.GetNotFoundRetry(),
 	}
 }
 
 // processOngoingOperation is a helper function that will process an ongoing operation to check if it is done.
-// If it is not done, it will return a transient error.
-func processOngoingOperation(ctx context.Context, scope FutureScope, client FutureHandler, resourceName string, serviceName string) (result interface{}, err error) {
+// If it is not done, it will return a transient error. The result of a completed creation is fetched with the given
+// retries, as ARM may not return the resource right after its creation.
+func processOngoingOperation(ctx context.Context, scope FutureScope, client FutureHandler, resourceName string, serviceName string, notFoundRetry azure.NotFoundRetry) (result interface{}, err error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "async.Service.processOngoingOperation")
 	defer done()
 
@@ -78,7 +82,14 @@ func processOngoingOperation(ctx context.Context, scope FutureScope, client Futu
 
 	// Resource has been created/deleted/updated.
 	log.V(2).Info("long running operation has completed", "service", serviceName, "resource", resourceName)
-	result, err = client.Result(ctx, sdkFuture, future.Type)
+	if future.Type == infrav1.PutFuture {
+		err = notFoundRetry.Do(ctx, func(ctx context.Context) error {
+			result, err = client.Result(ctx, sdkFuture, future.Type)
+			return err
+		})
+	} else {
+		result, err = client.Result(ctx, sdkFuture, future.Type)
+	}
 	if err == nil {
 		scope.DeleteLongRunningOperationState(resourceName, serviceName)
 	}
@@ -96,7 +107,7 @@ func (s *Service) CreateResource(ctx context.Context, spec azure.ResourceSpecGet
 	// Check if there is an ongoing long running operation.
 	future := s.Scope.GetLongRunningOperationState(resourceName, serviceName)
 	if future != nil {
-		return processOngoingOperation(ctx, s.Scope, s.Creator, resourceName, serviceName)
+		return processOngoingOperation(ctx, s.Scope, s.Creator, resourceName, serviceName, s.notFoundRetry)
 	}
 
 	// Get the resource if it already exists, and use it to construct the desired resource parameters.
@@ -106,6 +117,9 @@ func (s *Service) CreateResource(ctx context.Context, spec azure.ResourceSpecGet
 	} else if err == nil {
 		existingResource = existing
 		log.V(2).Info("successfully got existing resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+		if err := azure.CheckEnvironment(s.Scope, existingResource, "adopt", resourceName, rgName, serviceName); err != nil {
+			return nil, err
+		}
 	}
 
 	// Construct parameters using the resource spec and information from the existing resource, if there is one.
@@ -136,6 +150,32 @@ func (s *Service) CreateResource(ctx context.Context, spec azure.ResourceSpecGet
 	return result, nil
 }
 
+// AuditResource reports how the existing resource differs from its spec, without creating or updating it. The
+// resource drifted if the parameters of the spec, computed from the existing resource the same way CreateResource
+// does, ask for an update.
+func AuditResource(ctx context.Context, getter Getter, spec azure.ResourceSpecGetter, resourceType string) ([]azure.Drift, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "async.AuditResource")
+	defer done()
+
+	resourceName := spec.ResourceName()
+	rgName := spec.ResourceGroupName()
+
+	existing, err := getter.Get(ctx, spec)
+	if azure.ResourceNotFound(err) {
+		return []azure.Drift{{Type: azure.DriftMissing, ResourceType: resourceType, Name: resourceName}}, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to get existing resource %s/%s", rgName, resourceName)
+	}
+
+	parameters, err := spec.Parameters(existing)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get desired parameters for resource %s/%s", rgName, resourceName)
+	} else if parameters == nil {
+		return nil, nil
+	}
+	return []azure.Drift{{Type: azure.DriftModified, ResourceType: resourceType, Name: resourceName, Detail: "an update is needed to match the spec"}}, nil
+}
+
 // DeleteResource implements the logic for deleting a resource Asynchronously.
 func (s *Service) DeleteResource(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) (err error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "async.Service.DeleteResource")
@@ -147,10 +187,22 @@ func (s *Service) DeleteResource(ctx context.Context, spec azure.ResourceSpecGet
 	// Check if there is an ongoing long running operation.
 	future := s.Scope.GetLongRunningOperationState(resourceName, serviceName)
 	if future != nil {
-		_, err := processOngoingOperation(ctx, s.Scope, s.Deleter, resourceName, serviceName)
+		_, err := processOngoingOperation(ctx, s.Scope, s.Deleter, resourceName, serviceName, s.notFoundRetry)
 		return err
 	}
 
+	// Make sure the resource belongs to the expected environment before deleting it.
+	if azure.ExpectedEnvironment(s.Scope) != "" {
+		existing, err := s.Creator.Get(ctx, spec)
+		if err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrapf(err, "failed to get existing resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+		} else if err == nil {
+			if err := azure.CheckEnvironment(s.Scope, existing, "delete", resourceName, rgName, serviceName); err != nil {
+				return err
+			}
+		}
+	}
+
 	// No long running operation is active, so delete the resource.
 	log.V(2).Info("deleting resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
 	sdkFuture, err := s.Deleter.DeleteAsync(ctx, spec)
@@ -25,9 +25,11 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
 	"github.com/Azure/go-autorest/autorest"
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
@@ -124,6 +126,33 @@ func TestProcessOngoingOperation(t *testing.T) {
 				c.Result(gomockinternal.AContext(), gomock.AssignableToTypeOf(&azureautorest.Future{}), infrav1.DeleteFuture).Return(&fakeExistingResource, nil)
 			},
 		},
+		{
+			name:           "created resource is not visible yet",
+			expectedError:  "",
+			expectedResult: &fakeExistingResource,
+			resourceName:   "test-resource",
+			serviceName:    "test-service",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockFutureHandlerMockRecorder) {
+				s.GetLongRunningOperationState("test-resource", "test-service").Return(&validCreateFuture)
+				c.IsDone(gomockinternal.AContext(), gomock.AssignableToTypeOf(&azureautorest.Future{})).Return(true, nil)
+				gomock.InOrder(
+					c.Result(gomockinternal.AContext(), gomock.AssignableToTypeOf(&azureautorest.Future{}), infrav1.PutFuture).Return(nil, fakeNotFoundError),
+					c.Result(gomockinternal.AContext(), gomock.AssignableToTypeOf(&azureautorest.Future{}), infrav1.PutFuture).Return(&fakeExistingResource, nil),
+				)
+				s.DeleteLongRunningOperationState("test-resource", "test-service")
+			},
+		},
+		{
+			name:          "created resource is still not found after the last retry",
+			expectedError: "Not Found",
+			resourceName:  "test-resource",
+			serviceName:   "test-service",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockFutureHandlerMockRecorder) {
+				s.GetLongRunningOperationState("test-resource", "test-service").Return(&validCreateFuture)
+				c.IsDone(gomockinternal.AContext(), gomock.AssignableToTypeOf(&azureautorest.Future{})).Return(true, nil)
+				c.Result(gomockinternal.AContext(), gomock.AssignableToTypeOf(&azureautorest.Future{}), infrav1.PutFuture).Times(2).Return(nil, fakeNotFoundError)
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -139,7 +168,7 @@ func TestProcessOngoingOperation(t *testing.T) {
 
 			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
 
-			result, err := processOngoingOperation(context.TODO(), scopeMock, clientMock, tc.resourceName, tc.serviceName)
+			result, err := processOngoingOperation(context.TODO(), scopeMock, clientMock, tc.resourceName, tc.serviceName, azure.NotFoundRetry{Attempts: 2})
 			if tc.expectedError != "" {
 				g.Expect(err).To(HaveOccurred())
 				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
@@ -295,6 +324,82 @@ func TestCreateResource(t *testing.T) {
 	}
 }
 
+// TestAuditResource tests the AuditResource function.
+func TestAuditResource(t *testing.T) {
+	testcases := []struct {
+		name           string
+		expectedError  string
+		expectedDrifts []azure.Drift
+		expect         func(g *mock_async.MockGetterMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder)
+	}{
+		{
+			name: "resource is up to date",
+			expect: func(g *mock_async.MockGetterMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				g.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(&fakeExistingResource, nil)
+				r.Parameters(&fakeExistingResource).Return(nil, nil)
+			},
+		},
+		{
+			name: "resource needs an update",
+			expectedDrifts: []azure.Drift{
+				{Type: azure.DriftModified, ResourceType: "TestResource", Name: "test-resource", Detail: "an update is needed to match the spec"},
+			},
+			expect: func(g *mock_async.MockGetterMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				g.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(&fakeExistingResource, nil)
+				r.Parameters(&fakeExistingResource).Return(&fakeResourceParameters, nil)
+			},
+		},
+		{
+			name: "resource is missing",
+			expectedDrifts: []azure.Drift{
+				{Type: azure.DriftMissing, ResourceType: "TestResource", Name: "test-resource"},
+			},
+			expect: func(g *mock_async.MockGetterMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				g.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(nil, fakeNotFoundError)
+			},
+		},
+		{
+			name:          "error occurs while getting the resource",
+			expectedError: "failed to get existing resource test-group/test-resource",
+			expect: func(g *mock_async.MockGetterMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				g.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(nil, fakeInternalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			getterMock := mock_async.NewMockGetter(mockCtrl)
+			specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+
+			tc.expect(getterMock.EXPECT(), specMock.EXPECT())
+
+			drifts, err := AuditResource(context.TODO(), getterMock, specMock, "TestResource")
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(drifts).To(Equal(tc.expectedDrifts))
+			}
+		})
+	}
+}
+
 // TestDeleteResource tests the DeleteResource function.
 func TestDeleteResource(t *testing.T) {
 	testcases := []struct {
@@ -386,3 +491,158 @@ func TestDeleteResource(t *testing.T) {
 		})
 	}
 }
+
+// environmentScope is a FutureScope that expects the resources to belong to an environment.
+type environmentScope struct {
+	*mock_async.MockFutureScope
+	environment string
+}
+
+func (s environmentScope) ExpectedEnvironment() string {
+	return s.environment
+}
+
+func (s environmentScope) ClusterName() string {
+	return "test-cluster"
+}
+
+// TestEnvironmentGuardrail tests that resources of another environment are never adopted or deleted.
+func TestEnvironmentGuardrail(t *testing.T) {
+	devResource := resources.GenericResource{Location: to.StringPtr("westus"), Tags: map[string]*string{"environment": to.StringPtr("dev")}}
+	prodResource := resources.GenericResource{Location: to.StringPtr("westus"), Tags: map[string]*string{"environment": to.StringPtr("prod")}}
+	untaggedResource := resources.GenericResource{Location: to.StringPtr("westus")}
+	ownedUntaggedResource := resources.GenericResource{Location: to.StringPtr("westus"), Tags: map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned")}}
+	untrackedResource := resources.GenericResource{Name: to.StringPtr("test-resource")}
+
+	testcases := []struct {
+		name           string
+		delete         bool
+		expectedError  string
+		expectedResult interface{}
+		expect         func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, d *mock_async.MockDeleterMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder)
+	}{
+		{
+			name:           "create a new resource",
+			expectedResult: devResource,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, d *mock_async.MockDeleterMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				s.GetLongRunningOperationState("test-resource", "test-service").Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(nil, fakeNotFoundError)
+				r.Parameters(nil).Return(devResource, nil)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{}), devResource).Return(devResource, nil, nil)
+			},
+		},
+		{
+			name:           "update a resource of the expected environment",
+			expectedResult: devResource,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, d *mock_async.MockDeleterMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				s.GetLongRunningOperationState("test-resource", "test-service").Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(devResource, nil)
+				r.Parameters(devResource).Return(devResource, nil)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{}), devResource).Return(devResource, nil, nil)
+			},
+		},
+		{
+			name:           "update a resource that doesn't support tags",
+			expectedResult: untrackedResource,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, d *mock_async.MockDeleterMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				s.GetLongRunningOperationState("test-resource", "test-service").Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(untrackedResource, nil)
+				r.Parameters(untrackedResource).Return(untrackedResource, nil)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{}), untrackedResource).Return(untrackedResource, nil, nil)
+			},
+		},
+		{
+			name:          "refuse to adopt a resource of another environment",
+			expectedError: `refusing to adopt resource test-group/test-resource (service: test-service): its environment tag is "prod", expected "dev"`,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, d *mock_async.MockDeleterMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				s.GetLongRunningOperationState("test-resource", "test-service").Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(prodResource, nil)
+			},
+		},
+		{
+			name:          "refuse to adopt a resource without environment tag",
+			expectedError: `refusing to adopt resource test-group/test-resource (service: test-service): its environment tag is "", expected "dev"`,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, d *mock_async.MockDeleterMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				s.GetLongRunningOperationState("test-resource", "test-service").Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(&untaggedResource, nil)
+			},
+		},
+		{
+			name:           "update a resource owned by the cluster created before the environment was expected",
+			expectedResult: devResource,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, d *mock_async.MockDeleterMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				s.GetLongRunningOperationState("test-resource", "test-service").Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(ownedUntaggedResource, nil)
+				r.Parameters(ownedUntaggedResource).Return(devResource, nil)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{}), devResource).Return(devResource, nil, nil)
+			},
+		},
+		{
+			name:   "delete a resource of the expected environment",
+			delete: true,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, d *mock_async.MockDeleterMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				s.GetLongRunningOperationState("test-resource", "test-service").Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(devResource, nil)
+				d.DeleteAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(nil, nil)
+			},
+		},
+		{
+			name:          "refuse to delete a resource of another environment",
+			delete:        true,
+			expectedError: `refusing to delete resource test-group/test-resource (service: test-service): its environment tag is "prod", expected "dev"`,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, d *mock_async.MockDeleterMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				s.GetLongRunningOperationState("test-resource", "test-service").Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(prodResource, nil)
+			},
+		},
+		{
+			name:   "delete a resource that doesn't exist",
+			delete: true,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, d *mock_async.MockDeleterMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				s.GetLongRunningOperationState("test-resource", "test-service").Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(nil, fakeNotFoundError)
+				d.DeleteAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(nil, fakeNotFoundError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+			creatorMock := mock_async.NewMockCreator(mockCtrl)
+			deleterMock := mock_async.NewMockDeleter(mockCtrl)
+			specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+			specMock.EXPECT().ResourceName().Return("test-resource").AnyTimes()
+			specMock.EXPECT().ResourceGroupName().Return("test-group").AnyTimes()
+
+			tc.expect(scopeMock.EXPECT(), creatorMock.EXPECT(), deleterMock.EXPECT(), specMock.EXPECT())
+
+			s := New(environmentScope{MockFutureScope: scopeMock, environment: "dev"}, creatorMock, deleterMock)
+			var result interface{}
+			var err error
+			if tc.delete {
+				err = s.DeleteResource(context.TODO(), specMock, "test-service")
+			} else {
+				result, err = s.CreateResource(context.TODO(), specMock, "test-service")
+			}
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+				var reconcileErr azure.ReconcileError
+				g.Expect(errors.As(err, &reconcileErr)).To(BeTrue())
+				g.Expect(reconcileErr.IsTerminal()).To(BeTrue())
+			} else if !tc.delete {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(result).To(Equal(tc.expectedResult))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
@@ -98,9 +99,11 @@ func (s *Service) Delete(ctx context.Context) error {
 			if !ok {
 				resultingErr = errors.Errorf("%T is not a compute.AvailabilitySet", existingSet)
 			} else {
-				// only delete when the availability set does not have any vms
+				// only delete when the availability set does not have any vms, and was created by CAPZ
 				if availabilitySet.AvailabilitySetProperties != nil && availabilitySet.VirtualMachines != nil && len(*availabilitySet.VirtualMachines) > 0 {
 					log.V(2).Info("skip deleting availability set with VMs", "availability set", setSpec.ResourceName())
+				} else if !converters.MapToTags(availabilitySet.Tags).HasOwned(s.Scope.ClusterName()) {
+					log.V(2).Info("skip deleting availability set not owned by the cluster", "availability set", setSpec.ResourceName())
 				} else {
 					resultingErr = s.DeleteResource(ctx, setSpec, serviceName)
 				}
@@ -64,6 +64,11 @@ var (
 	internalError  = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error")
 	parameterError = errors.Errorf("some error with parameters")
 	notFoundError  = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found")
+	fakeOwnedSet   = compute.AvailabilitySet{
+		Tags: map[string]*string{
+			"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned"),
+		},
+	}
 	fakeSetWithVMs = compute.AvailabilitySet{
 		AvailabilitySetProperties: &compute.AvailabilitySetProperties{
 			VirtualMachines: &[]compute.SubResource{
@@ -155,8 +160,9 @@ func TestDeleteAvailabilitySets(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_availabilitysets.MockAvailabilitySetScopeMockRecorder, m *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.AvailabilitySetSpec().Return(&fakeSetSpec)
+				s.ClusterName().AnyTimes().Return("test-cluster")
 				gomock.InOrder(
-					m.Get(gomockinternal.AContext(), &fakeSetSpec).Return(compute.AvailabilitySet{}, nil),
+					m.Get(gomockinternal.AContext(), &fakeSetSpec).Return(fakeOwnedSet, nil),
 					r.DeleteResource(gomockinternal.AContext(), &fakeSetSpec, serviceName).Return(nil),
 					s.UpdateDeleteStatus(infrav1.AvailabilitySetReadyCondition, serviceName, nil),
 				)
@@ -175,8 +181,9 @@ func TestDeleteAvailabilitySets(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_availabilitysets.MockAvailabilitySetScopeMockRecorder, m *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.AvailabilitySetSpec().Return(&fakeSetSpecMissing)
+				s.ClusterName().AnyTimes().Return("test-cluster")
 				gomock.InOrder(
-					m.Get(gomockinternal.AContext(), &fakeSetSpecMissing).Return(compute.AvailabilitySet{}, nil),
+					m.Get(gomockinternal.AContext(), &fakeSetSpecMissing).Return(fakeOwnedSet, nil),
 					r.DeleteResource(gomockinternal.AContext(), &fakeSetSpecMissing, serviceName).Return(nil),
 					s.UpdateDeleteStatus(infrav1.AvailabilitySetReadyCondition, serviceName, nil),
 				)
@@ -193,6 +200,18 @@ func TestDeleteAvailabilitySets(t *testing.T) {
 				)
 			},
 		},
+		{
+			name:          "noop if availability set is not owned by the cluster",
+			expectedError: "",
+			expect: func(s *mock_availabilitysets.MockAvailabilitySetScopeMockRecorder, m *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.AvailabilitySetSpec().Return(&fakeSetSpec)
+				s.ClusterName().AnyTimes().Return("test-cluster")
+				gomock.InOrder(
+					m.Get(gomockinternal.AContext(), &fakeSetSpec).Return(compute.AvailabilitySet{}, nil),
+					s.UpdateDeleteStatus(infrav1.AvailabilitySetReadyCondition, serviceName, nil),
+				)
+			},
+		},
 		{
 			name:          "availability set not found",
 			expectedError: "",
@@ -231,8 +250,9 @@ func TestDeleteAvailabilitySets(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(s *mock_availabilitysets.MockAvailabilitySetScopeMockRecorder, m *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.AvailabilitySetSpec().Return(&fakeSetSpec)
+				s.ClusterName().AnyTimes().Return("test-cluster")
 				gomock.InOrder(
-					m.Get(gomockinternal.AContext(), &fakeSetSpec).Return(compute.AvailabilitySet{}, nil),
+					m.Get(gomockinternal.AContext(), &fakeSetSpec).Return(fakeOwnedSet, nil),
 					r.DeleteResource(gomockinternal.AContext(), &fakeSetSpec, serviceName).Return(internalError),
 					s.UpdateDeleteStatus(infrav1.AvailabilitySetReadyCondition, serviceName, internalError),
 				)
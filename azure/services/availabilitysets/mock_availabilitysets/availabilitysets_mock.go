@@ -193,6 +193,20 @@ func (mr *MockAvailabilitySetScopeMockRecorder) ClusterName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterName", reflect.TypeOf((*MockAvailabilitySetScope)(nil).ClusterName))
 }
 
+// ControlPlaneAvailabilitySetID mocks base method.
+func (m *MockAvailabilitySetScope) ControlPlaneAvailabilitySetID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ControlPlaneAvailabilitySetID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ControlPlaneAvailabilitySetID indicates an expected call of ControlPlaneAvailabilitySetID.
+func (mr *MockAvailabilitySetScopeMockRecorder) ControlPlaneAvailabilitySetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControlPlaneAvailabilitySetID", reflect.TypeOf((*MockAvailabilitySetScope)(nil).ControlPlaneAvailabilitySetID))
+}
+
 // DeleteLongRunningOperationState mocks base method.
 func (m *MockAvailabilitySetScope) DeleteLongRunningOperationState(arg0, arg1 string) {
 	m.ctrl.T.Helper()
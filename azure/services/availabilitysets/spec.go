@@ -23,6 +23,7 @@ import (
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 )
@@ -35,6 +36,10 @@ type AvailabilitySetSpec struct {
 	Location       string
 	SKU            *resourceskus.SKU
 	AdditionalTags infrav1.Tags
+	// FaultDomainCount is the number of fault domains of the availability set, the maximum of the location when nil.
+	FaultDomainCount *int32
+	// UpdateDomainCount is the number of update domains of the availability set, the default of Azure when nil.
+	UpdateDomainCount *int32
 }
 
 // ResourceName returns the name of the availability set.
@@ -76,13 +81,21 @@ func (s *AvailabilitySetSpec) Parameters(existing interface{}) (params interface
 		return nil, errors.Wrapf(err, "unable to parse availability set fault domain count")
 	}
 	faultDomainCount = to.Int32Ptr(int32(count))
+	if s.FaultDomainCount != nil {
+		if int64(*s.FaultDomainCount) > count {
+			return nil, azure.WithTerminalError(errors.Errorf("availability set %s can't have %d fault domains, the maximum in location %s is %d",
+				s.Name, *s.FaultDomainCount, s.Location, count))
+		}
+		faultDomainCount = s.FaultDomainCount
+	}
 
 	asParams := compute.AvailabilitySet{
 		Sku: &compute.Sku{
 			Name: to.StringPtr(string(compute.AvailabilitySetSkuTypesAligned)),
 		},
 		AvailabilitySetProperties: &compute.AvailabilitySetProperties{
-			PlatformFaultDomainCount: faultDomainCount,
+			PlatformFaultDomainCount:  faultDomainCount,
+			PlatformUpdateDomainCount: s.UpdateDomainCount,
 		},
 		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
 			ClusterName: s.ClusterName,
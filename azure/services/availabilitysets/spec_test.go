@@ -34,6 +34,25 @@ var (
 		SKU:            &resourceskus.SKU{},
 		AdditionalTags: map[string]string{},
 	}
+	fakeSetSpecWithDomainCounts = AvailabilitySetSpec{
+		Name:              "test-as",
+		ResourceGroup:     "test-rg",
+		ClusterName:       "test-cluster",
+		Location:          "test-location",
+		SKU:               &fakeSku,
+		AdditionalTags:    map[string]string{},
+		FaultDomainCount:  to.Int32Ptr(2),
+		UpdateDomainCount: to.Int32Ptr(10),
+	}
+	fakeSetSpecTooManyFaultDomains = AvailabilitySetSpec{
+		Name:             "test-as",
+		ResourceGroup:    "test-rg",
+		ClusterName:      "test-cluster",
+		Location:         "test-location",
+		SKU:              &fakeSku,
+		AdditionalTags:   map[string]string{},
+		FaultDomainCount: to.Int32Ptr(4),
+	}
 )
 
 func TestParameters(t *testing.T) {
@@ -72,6 +91,35 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "get parameters with domain counts",
+			spec:     &fakeSetSpecWithDomainCounts,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.AvailabilitySet{}))
+				g.Expect(result.(compute.AvailabilitySet).PlatformFaultDomainCount).To(Equal(to.Int32Ptr(2)))
+				g.Expect(result.(compute.AvailabilitySet).PlatformUpdateDomainCount).To(Equal(to.Int32Ptr(10)))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "error when fault domain count exceeds the maximum of the location",
+			spec:     &fakeSetSpecTooManyFaultDomains,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: availability set test-as can't have 4 fault domains, the maximum in location test-location is 3. Object will not be requeued",
+		},
+		{
+			name:     "existing availability set is adopted as is",
+			spec:     &fakeSetSpecWithDomainCounts,
+			existing: compute.AvailabilitySet{},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -109,6 +109,20 @@ func (mr *MockBastionScopeMockRecorder) AdditionalTags() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdditionalTags", reflect.TypeOf((*MockBastionScope)(nil).AdditionalTags))
 }
 
+// ApplicationSecurityGroups mocks base method.
+func (m *MockBastionScope) ApplicationSecurityGroups() []v1beta1.ApplicationSecurityGroup {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroups")
+	ret0, _ := ret[0].([]v1beta1.ApplicationSecurityGroup)
+	return ret0
+}
+
+// ApplicationSecurityGroups indicates an expected call of ApplicationSecurityGroups.
+func (mr *MockBastionScopeMockRecorder) ApplicationSecurityGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroups", reflect.TypeOf((*MockBastionScope)(nil).ApplicationSecurityGroups))
+}
+
 // Authorizer mocks base method.
 func (m *MockBastionScope) Authorizer() autorest.Authorizer {
 	m.ctrl.T.Helper()
@@ -235,6 +249,20 @@ func (mr *MockBastionScopeMockRecorder) ClusterName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterName", reflect.TypeOf((*MockBastionScope)(nil).ClusterName))
 }
 
+// ControlPlaneAvailabilitySetID mocks base method.
+func (m *MockBastionScope) ControlPlaneAvailabilitySetID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ControlPlaneAvailabilitySetID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ControlPlaneAvailabilitySetID indicates an expected call of ControlPlaneAvailabilitySetID.
+func (mr *MockBastionScopeMockRecorder) ControlPlaneAvailabilitySetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControlPlaneAvailabilitySetID", reflect.TypeOf((*MockBastionScope)(nil).ControlPlaneAvailabilitySetID))
+}
+
 // ControlPlaneRouteTable mocks base method.
 func (m *MockBastionScope) ControlPlaneRouteTable() v1beta1.RouteTable {
 	m.ctrl.T.Helper()
@@ -331,6 +359,20 @@ func (mr *MockBastionScopeMockRecorder) HashKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockBastionScope)(nil).HashKey))
 }
 
+// InternalLBAddressPoolIDs mocks base method.
+func (m *MockBastionScope) InternalLBAddressPoolIDs(arg0 string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InternalLBAddressPoolIDs", arg0)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// InternalLBAddressPoolIDs indicates an expected call of InternalLBAddressPoolIDs.
+func (mr *MockBastionScopeMockRecorder) InternalLBAddressPoolIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InternalLBAddressPoolIDs", reflect.TypeOf((*MockBastionScope)(nil).InternalLBAddressPoolIDs), arg0)
+}
+
 // IsAPIServerPrivate mocks base method.
 func (m *MockBastionScope) IsAPIServerPrivate() bool {
 	m.ctrl.T.Helper()
@@ -387,6 +429,20 @@ func (mr *MockBastionScopeMockRecorder) Location() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Location", reflect.TypeOf((*MockBastionScope)(nil).Location))
 }
 
+// NetworkInterfaceSecurityGroupName mocks base method.
+func (m *MockBastionScope) NetworkInterfaceSecurityGroupName(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkInterfaceSecurityGroupName", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// NetworkInterfaceSecurityGroupName indicates an expected call of NetworkInterfaceSecurityGroupName.
+func (mr *MockBastionScopeMockRecorder) NetworkInterfaceSecurityGroupName(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkInterfaceSecurityGroupName", reflect.TypeOf((*MockBastionScope)(nil).NetworkInterfaceSecurityGroupName), arg0)
+}
+
 // NodeSubnets mocks base method.
 func (m *MockBastionScope) NodeSubnets() []v1beta1.SubnetSpec {
 	m.ctrl.T.Helper()
@@ -0,0 +1,303 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package budgets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// apiVersion is the API version of the Cost Management budgets.
+	apiVersion = "2021-10-01"
+	// startDateFormat is the format of the start date of the time period of a budget.
+	startDateFormat = "2006-01-02T15:04:05Z"
+)
+
+// BudgetScope defines the scope interface for a budgets service.
+type BudgetScope interface {
+	azure.Authorizer
+	ResourceGroup() string
+	ClusterName() string
+	Budget() *infrav1.BudgetSpec
+	BudgetID() string
+	SetBudgetID(string)
+	SetBudgetReady()
+	SetBudgetNotReady(reason string, severity clusterv1.ConditionSeverity, messageFormat string, messageArgs ...interface{})
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope BudgetScope
+	client
+	now func() time.Time
+}
+
+// New creates a new service.
+func New(scope BudgetScope) *Service {
+	return &Service{
+		Scope:  scope,
+		client: newClient(scope),
+		now:    time.Now,
+	}
+}
+
+// budgetProperties are the properties of a Cost Management budget.
+type budgetProperties struct {
+	Category      string                        `json:"category"`
+	Amount        float64                       `json:"amount"`
+	TimeGrain     string                        `json:"timeGrain"`
+	TimePeriod    budgetTimePeriod              `json:"timePeriod"`
+	Notifications map[string]budgetNotification `json:"notifications"`
+}
+
+// budgetTimePeriod is the time period of a budget. A budget without an end date is renewed indefinitely.
+type budgetTimePeriod struct {
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate,omitempty"`
+}
+
+// budgetNotification is a notification of a budget.
+type budgetNotification struct {
+	Enabled       bool     `json:"enabled"`
+	Operator      string   `json:"operator"`
+	Threshold     float64  `json:"threshold"`
+	ThresholdType string   `json:"thresholdType,omitempty"`
+	ContactEmails []string `json:"contactEmails"`
+	ContactRoles  []string `json:"contactRoles,omitempty"`
+	ContactGroups []string `json:"contactGroups,omitempty"`
+}
+
+// Reconcile creates or updates the budget of the spec in the resource group of the cluster, and deletes the budget
+// previously created by CAPZ when it is renamed or removed from the spec. A lack of permission to manage the budget is
+// reported in the BudgetReady condition and doesn't block the reconciliation of the cluster.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "budgets.Service.Reconcile")
+	defer done()
+
+	spec := s.Scope.Budget()
+	if spec == nil {
+		if s.Scope.BudgetID() == "" {
+			return nil
+		}
+		log.V(2).Info("deleting budget", "budget", s.Scope.BudgetID())
+		if err := s.client.DeleteByID(ctx, s.Scope.BudgetID(), apiVersion); err != nil && !azure.ResourceNotFound(err) {
+			return s.handleError(err, "failed to delete budget %s", s.Scope.BudgetID())
+		}
+		s.Scope.SetBudgetID("")
+		return nil
+	}
+
+	name := s.name(spec)
+	id := s.budgetID(name)
+	if previous := s.Scope.BudgetID(); previous != "" && !strings.EqualFold(previous, id) {
+		log.V(2).Info("deleting renamed budget", "budget", previous)
+		if err := s.client.DeleteByID(ctx, previous, apiVersion); err != nil && !azure.ResourceNotFound(err) {
+			return s.handleError(err, "failed to delete budget %s", previous)
+		}
+		s.Scope.SetBudgetID("")
+	}
+
+	var existing *budgetProperties
+	resource, err := s.client.GetByID(ctx, id, apiVersion)
+	switch {
+	case err == nil:
+		existing, err = decodeProperties(resource.Properties)
+		if err != nil {
+			return s.handleError(err, "failed to decode budget %s", name)
+		}
+	case !azure.ResourceNotFound(err):
+		return s.handleError(err, "failed to get budget %s", name)
+	}
+
+	desired := s.properties(spec, existing)
+	if existing == nil || !isUpToDate(*existing, desired) {
+		log.V(2).Info("creating or updating budget", "budget", name)
+		if err := s.client.CreateOrUpdateByID(ctx, id, apiVersion, resources.GenericResource{Properties: desired}); err != nil {
+			return s.handleError(err, "failed to create or update budget %s", name)
+		}
+		log.V(2).Info("successfully created or updated budget", "budget", name)
+	}
+
+	s.Scope.SetBudgetID(id)
+	s.Scope.SetBudgetReady()
+	return nil
+}
+
+// Delete deletes the budget created by CAPZ in the resource group of the cluster.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "budgets.Service.Delete")
+	defer done()
+
+	id := s.Scope.BudgetID()
+	if id == "" {
+		spec := s.Scope.Budget()
+		if spec == nil {
+			return nil
+		}
+		id = s.budgetID(s.name(spec))
+	}
+
+	log.V(2).Info("deleting budget", "budget", id)
+	// a missing resource group is reported as not found too, its budgets are already gone.
+	if err := s.client.DeleteByID(ctx, id, apiVersion); err != nil && !azure.ResourceNotFound(err) {
+		return errors.Wrapf(forbiddenError(err, s.Scope.ResourceGroup()), "failed to delete budget %s", id)
+	}
+	s.Scope.SetBudgetID("")
+	return nil
+}
+
+// handleError reports a reconcile error in the BudgetReady condition. A lack of permission isn't expected to resolve
+// by retrying, so it isn't returned.
+func (s *Service) handleError(err error, messageFormat string, messageArgs ...interface{}) error {
+	err = errors.Wrapf(forbiddenError(err, s.Scope.ResourceGroup()), messageFormat, messageArgs...)
+	if azure.ResourceForbidden(err) {
+		s.Scope.SetBudgetNotReady(infrav1.BudgetForbiddenReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+		return nil
+	}
+	s.Scope.SetBudgetNotReady(infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+	return err
+}
+
+// forbiddenError explains which permission is missing when Azure denies the management of budgets.
+func forbiddenError(err error, resourceGroup string) error {
+	if !azure.ResourceForbidden(err) {
+		return err
+	}
+	return errors.Wrapf(err, "the identity of the cluster is not allowed to manage the budgets of resource group %s, "+
+		"it requires the Microsoft.Consumption/budgets/write permission, e.g. from the Cost Management Contributor role", resourceGroup)
+}
+
+// name returns the name of the budget of the spec, which defaults to the name of the cluster.
+func (s *Service) name(spec *infrav1.BudgetSpec) string {
+	if spec.Name != "" {
+		return spec.Name
+	}
+	return s.Scope.ClusterName()
+}
+
+// budgetID returns the resource ID of the budget of the resource group of the cluster with the given name.
+func (s *Service) budgetID(name string) string {
+	return fmt.Sprintf("%s/providers/Microsoft.Consumption/budgets/%s", azure.ResourceGroupID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup()), name)
+}
+
+// properties returns the properties of the budget of the spec. The time period of an existing budget is kept, as
+// Azure doesn't allow to move its start date, and a new budget starts on the first day of the current month.
+func (s *Service) properties(spec *infrav1.BudgetSpec, existing *budgetProperties) budgetProperties {
+	timeGrain := spec.TimeGrain
+	if timeGrain == "" {
+		timeGrain = infrav1.BudgetTimeGrainMonthly
+	}
+
+	timePeriod := budgetTimePeriod{}
+	if existing != nil && existing.TimePeriod.StartDate != "" {
+		timePeriod = existing.TimePeriod
+	} else {
+		now := s.now().UTC()
+		timePeriod.StartDate = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).Format(startDateFormat)
+	}
+
+	notifications := make(map[string]budgetNotification, len(spec.Notifications))
+	for _, notification := range spec.Notifications {
+		contactEmails := notification.ContactEmails
+		if contactEmails == nil {
+			contactEmails = []string{}
+		}
+		notifications[fmt.Sprintf("actual_GreaterThan_%d_Percent", notification.Threshold)] = budgetNotification{
+			Enabled:       true,
+			Operator:      "GreaterThan",
+			Threshold:     float64(notification.Threshold),
+			ThresholdType: "Actual",
+			ContactEmails: contactEmails,
+			ContactRoles:  notification.ContactRoles,
+			ContactGroups: notification.ContactGroups,
+		}
+	}
+
+	return budgetProperties{
+		Category:      "Cost",
+		Amount:        float64(spec.Amount),
+		TimeGrain:     string(timeGrain),
+		TimePeriod:    timePeriod,
+		Notifications: notifications,
+	}
+}
+
+// decodeProperties decodes the properties of a budget returned as a generic resource.
+func decodeProperties(properties interface{}) (*budgetProperties, error) {
+	data, err := json.Marshal(properties)
+	if err != nil {
+		return nil, err
+	}
+	decoded := &budgetProperties{}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// isUpToDate returns true if the existing budget has the desired amount, time grain and notifications.
+func isUpToDate(existing, desired budgetProperties) bool {
+	if existing.Amount != desired.Amount || !strings.EqualFold(existing.TimeGrain, desired.TimeGrain) ||
+		len(existing.Notifications) != len(desired.Notifications) {
+		return false
+	}
+	for key, want := range desired.Notifications {
+		got, ok := findNotification(existing.Notifications, key)
+		if !ok || !got.Enabled || !strings.EqualFold(got.Operator, want.Operator) || got.Threshold != want.Threshold ||
+			!strings.EqualFold(got.ThresholdType, want.ThresholdType) ||
+			!equalContacts(got.ContactEmails, want.ContactEmails) ||
+			!equalContacts(got.ContactRoles, want.ContactRoles) ||
+			!equalContacts(got.ContactGroups, want.ContactGroups) {
+			return false
+		}
+	}
+	return true
+}
+
+// findNotification returns the notification with the given key, which Azure may return in a different case.
+func findNotification(notifications map[string]budgetNotification, key string) (budgetNotification, bool) {
+	for k, notification := range notifications {
+		if strings.EqualFold(k, key) {
+			return notification, true
+		}
+	}
+	return budgetNotification{}, false
+}
+
+// equalContacts returns true if both lists have the same contacts in the same order, in any case.
+func equalContacts(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,302 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package budgets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/budgets/mock_budgets"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	fakeBudgetID      = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Consumption/budgets/my-cluster"
+	fakeTeamBudgetID  = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Consumption/budgets/team-budget"
+	fakeStartDate     = "2022-09-01T00:00:00Z"
+	fakeOldStartDate  = "2022-01-01T00:00:00Z"
+	fakeActionGroupID = "/subscriptions/123/resourceGroups/monitoring/providers/Microsoft.Insights/actionGroups/oncall"
+)
+
+var (
+	fakeBudget = infrav1.BudgetSpec{
+		Amount: 1000,
+		Notifications: []infrav1.BudgetNotification{
+			{Threshold: 80, ContactEmails: []string{"team@example.com"}},
+			{Threshold: 100, ContactGroups: []string{fakeActionGroupID}},
+		},
+	}
+
+	notFound  = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not Found")
+	forbidden = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusForbidden}, "AuthorizationFailed")
+)
+
+// desiredBudget returns the budget created for fakeBudget.
+func desiredBudget(amount float64, startDate string) budgetProperties {
+	return budgetProperties{
+		Category:   "Cost",
+		Amount:     amount,
+		TimeGrain:  "Monthly",
+		TimePeriod: budgetTimePeriod{StartDate: startDate},
+		Notifications: map[string]budgetNotification{
+			"actual_GreaterThan_80_Percent": {
+				Enabled:       true,
+				Operator:      "GreaterThan",
+				Threshold:     80,
+				ThresholdType: "Actual",
+				ContactEmails: []string{"team@example.com"},
+			},
+			"actual_GreaterThan_100_Percent": {
+				Enabled:       true,
+				Operator:      "GreaterThan",
+				Threshold:     100,
+				ThresholdType: "Actual",
+				ContactEmails: []string{},
+				ContactGroups: []string{fakeActionGroupID},
+			},
+		},
+	}
+}
+
+// existingBudget returns the budget as returned by Azure, with its properties decoded as generic JSON.
+func existingBudget(g *WithT, properties budgetProperties) resources.GenericResource {
+	data, err := json.Marshal(properties)
+	g.Expect(err).NotTo(HaveOccurred())
+	var decoded map[string]interface{}
+	g.Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+	return resources.GenericResource{Properties: decoded}
+}
+
+func expectScope(s *mock_budgets.MockBudgetScopeMockRecorder) {
+	s.ResourceGroup().Return("my-rg").AnyTimes()
+	s.SubscriptionID().Return("123").AnyTimes()
+	s.ClusterName().Return("my-cluster").AnyTimes()
+}
+
+func TestReconcileBudget(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(g *WithT, s *mock_budgets.MockBudgetScopeMockRecorder, m *mock_budgets.MockclientMockRecorder)
+	}{
+		{
+			name: "no budget",
+			expect: func(g *WithT, s *mock_budgets.MockBudgetScopeMockRecorder, m *mock_budgets.MockclientMockRecorder) {
+				s.Budget().Return(nil)
+				s.BudgetID().Return("")
+			},
+		},
+		{
+			name: "create budget starting on the first day of the month",
+			expect: func(g *WithT, s *mock_budgets.MockBudgetScopeMockRecorder, m *mock_budgets.MockclientMockRecorder) {
+				expectScope(s)
+				s.Budget().Return(&fakeBudget)
+				s.BudgetID().Return("")
+				m.GetByID(gomockinternal.AContext(), fakeBudgetID, apiVersion).Return(resources.GenericResource{}, notFound)
+				m.CreateOrUpdateByID(gomockinternal.AContext(), fakeBudgetID, apiVersion,
+					gomockinternal.DiffEq(resources.GenericResource{Properties: desiredBudget(1000, fakeStartDate)})).Return(nil)
+				s.SetBudgetID(fakeBudgetID)
+				s.SetBudgetReady()
+			},
+		},
+		{
+			name: "budget is up to date",
+			expect: func(g *WithT, s *mock_budgets.MockBudgetScopeMockRecorder, m *mock_budgets.MockclientMockRecorder) {
+				expectScope(s)
+				s.Budget().Return(&fakeBudget)
+				s.BudgetID().Return(fakeBudgetID)
+				m.GetByID(gomockinternal.AContext(), fakeBudgetID, apiVersion).Return(existingBudget(g, desiredBudget(1000, fakeOldStartDate)), nil)
+				s.SetBudgetID(fakeBudgetID)
+				s.SetBudgetReady()
+			},
+		},
+		{
+			name: "update budget with a different amount and keep its start date",
+			expect: func(g *WithT, s *mock_budgets.MockBudgetScopeMockRecorder, m *mock_budgets.MockclientMockRecorder) {
+				expectScope(s)
+				s.Budget().Return(&fakeBudget)
+				s.BudgetID().Return(fakeBudgetID)
+				m.GetByID(gomockinternal.AContext(), fakeBudgetID, apiVersion).Return(existingBudget(g, desiredBudget(500, fakeOldStartDate)), nil)
+				m.CreateOrUpdateByID(gomockinternal.AContext(), fakeBudgetID, apiVersion,
+					gomockinternal.DiffEq(resources.GenericResource{Properties: desiredBudget(1000, fakeOldStartDate)})).Return(nil)
+				s.SetBudgetID(fakeBudgetID)
+				s.SetBudgetReady()
+			},
+		},
+		{
+			name: "delete renamed budget",
+			expect: func(g *WithT, s *mock_budgets.MockBudgetScopeMockRecorder, m *mock_budgets.MockclientMockRecorder) {
+				expectScope(s)
+				budget := fakeBudget
+				budget.Name = "team-budget"
+				s.Budget().Return(&budget)
+				s.BudgetID().Return(fakeBudgetID)
+				m.DeleteByID(gomockinternal.AContext(), fakeBudgetID, apiVersion).Return(nil)
+				s.SetBudgetID("")
+				m.GetByID(gomockinternal.AContext(), fakeTeamBudgetID, apiVersion).Return(resources.GenericResource{}, notFound)
+				m.CreateOrUpdateByID(gomockinternal.AContext(), fakeTeamBudgetID, apiVersion, gomock.Any()).Return(nil)
+				s.SetBudgetID(fakeTeamBudgetID)
+				s.SetBudgetReady()
+			},
+		},
+		{
+			name: "delete budget removed from the spec",
+			expect: func(g *WithT, s *mock_budgets.MockBudgetScopeMockRecorder, m *mock_budgets.MockclientMockRecorder) {
+				expectScope(s)
+				s.Budget().Return(nil)
+				s.BudgetID().Return(fakeBudgetID).AnyTimes()
+				m.DeleteByID(gomockinternal.AContext(), fakeBudgetID, apiVersion).Return(nil)
+				s.SetBudgetID("")
+			},
+		},
+		{
+			name: "not allowed to manage budgets",
+			expect: func(g *WithT, s *mock_budgets.MockBudgetScopeMockRecorder, m *mock_budgets.MockclientMockRecorder) {
+				expectScope(s)
+				s.Budget().Return(&fakeBudget)
+				s.BudgetID().Return("")
+				m.GetByID(gomockinternal.AContext(), fakeBudgetID, apiVersion).Return(resources.GenericResource{}, forbidden)
+				s.SetBudgetNotReady(infrav1.BudgetForbiddenReason, clusterv1.ConditionSeverityWarning, "%s",
+					"failed to get budget my-cluster: the identity of the cluster is not allowed to manage the budgets of resource group my-rg, "+
+						"it requires the Microsoft.Consumption/budgets/write permission, e.g. from the Cost Management Contributor role: #: AuthorizationFailed: StatusCode=403")
+			},
+		},
+		{
+			name:          "failed to create budget",
+			expectedError: "failed to create or update budget my-cluster: #: Internal Server Error: StatusCode=500",
+			expect: func(g *WithT, s *mock_budgets.MockBudgetScopeMockRecorder, m *mock_budgets.MockclientMockRecorder) {
+				expectScope(s)
+				s.Budget().Return(&fakeBudget)
+				s.BudgetID().Return("")
+				m.GetByID(gomockinternal.AContext(), fakeBudgetID, apiVersion).Return(resources.GenericResource{}, notFound)
+				m.CreateOrUpdateByID(gomockinternal.AContext(), fakeBudgetID, apiVersion, gomock.Any()).
+					Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error"))
+				s.SetBudgetNotReady(infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s", gomock.Any())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_budgets.NewMockBudgetScope(mockCtrl)
+			clientMock := mock_budgets.NewMockclient(mockCtrl)
+
+			tc.expect(g, scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+				now: func() time.Time {
+					return time.Date(2022, time.September, 14, 10, 30, 0, 0, time.UTC)
+				},
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteBudget(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_budgets.MockBudgetScopeMockRecorder, m *mock_budgets.MockclientMockRecorder)
+	}{
+		{
+			name: "no budget",
+			expect: func(s *mock_budgets.MockBudgetScopeMockRecorder, m *mock_budgets.MockclientMockRecorder) {
+				s.BudgetID().Return("")
+				s.Budget().Return(nil)
+			},
+		},
+		{
+			name: "delete budget",
+			expect: func(s *mock_budgets.MockBudgetScopeMockRecorder, m *mock_budgets.MockclientMockRecorder) {
+				expectScope(s)
+				s.BudgetID().Return(fakeBudgetID)
+				m.DeleteByID(gomockinternal.AContext(), fakeBudgetID, apiVersion).Return(nil)
+				s.SetBudgetID("")
+			},
+		},
+		{
+			name: "delete budget of the spec that was never reconciled",
+			expect: func(s *mock_budgets.MockBudgetScopeMockRecorder, m *mock_budgets.MockclientMockRecorder) {
+				expectScope(s)
+				s.BudgetID().Return("")
+				s.Budget().Return(&fakeBudget)
+				m.DeleteByID(gomockinternal.AContext(), fakeBudgetID, apiVersion).Return(notFound)
+				s.SetBudgetID("")
+			},
+		},
+		{
+			name:          "not allowed to delete budget",
+			expectedError: "failed to delete budget " + fakeBudgetID + ": the identity of the cluster is not allowed to manage the budgets of resource group my-rg",
+			expect: func(s *mock_budgets.MockBudgetScopeMockRecorder, m *mock_budgets.MockclientMockRecorder) {
+				expectScope(s)
+				s.BudgetID().Return(fakeBudgetID)
+				m.DeleteByID(gomockinternal.AContext(), fakeBudgetID, apiVersion).Return(forbidden)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_budgets.NewMockBudgetScope(mockCtrl)
+			clientMock := mock_budgets.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
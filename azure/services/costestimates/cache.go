@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package costestimates
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// priceCacheTTL is the duration after which the cached prices of a region are reloaded. Retail prices rarely
+	// change, and are the same for all the clusters of a region.
+	priceCacheTTL = 24 * time.Hour
+	// failedLookupTTL is the duration during which a failed lookup of the prices of a region is reported again rather
+	// than retried, so that an unreachable Azure Retail Prices API doesn't slow down every reconciliation.
+	failedLookupTTL = 10 * time.Minute
+)
+
+// regionPrices are the cached prices of a region, or the error of their last lookup.
+type regionPrices struct {
+	prices   []retailPrice
+	err      error
+	loadedAt time.Time
+}
+
+// priceLookup is an ongoing lookup of the prices of a region, which the concurrent reconciles needing them wait for
+// rather than looking them up again.
+type priceLookup struct {
+	done   chan struct{}
+	prices []retailPrice
+	err    error
+}
+
+// priceCache caches the retail prices of each region. It is shared across the concurrent reconciles of all the
+// clusters. The lock only guards the maps, the prices are looked up without holding it.
+type priceCache struct {
+	mu       sync.Mutex
+	regions  map[string]regionPrices
+	inflight map[string]*priceLookup
+}
+
+// defaultPriceCache is the price cache shared by all the services.
+var defaultPriceCache = newPriceCache()
+
+// newPriceCache creates an empty price cache.
+func newPriceCache() *priceCache {
+	return &priceCache{
+		regions:  make(map[string]regionPrices),
+		inflight: make(map[string]*priceLookup),
+	}
+}
+
+// get returns the prices of the products of the meters in a region, from the cache unless they expired. Only one
+// lookup of the prices of a region runs at a time, the other callers wait for its result.
+func (c *priceCache) get(ctx context.Context, client client, region string, now time.Time) ([]retailPrice, error) {
+	key := strings.ToLower(region)
+
+	c.mu.Lock()
+	if cached, ok := c.regions[key]; ok {
+		ttl := priceCacheTTL
+		if cached.err != nil {
+			ttl = failedLookupTTL
+		}
+		if now.Sub(cached.loadedAt) < ttl {
+			c.mu.Unlock()
+			return cached.prices, cached.err
+		}
+	}
+	if lookup, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-lookup.done:
+			return lookup.prices, lookup.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	lookup := &priceLookup{done: make(chan struct{})}
+	c.inflight[key] = lookup
+	c.mu.Unlock()
+
+	lookup.prices, lookup.err = client.ListPrices(ctx, key, productNames())
+
+	c.mu.Lock()
+	// a lookup interrupted by the context of its caller says nothing about the Azure Retail Prices API.
+	if ctx.Err() == nil {
+		c.regions[key] = regionPrices{prices: lookup.prices, err: lookup.err, loadedAt: now}
+	}
+	delete(c.inflight, key)
+	c.mu.Unlock()
+	close(lookup.done)
+
+	return lookup.prices, lookup.err
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package costestimates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const (
+	// retailPricesURL is the endpoint of the Azure Retail Prices API, which is public and unauthenticated.
+	retailPricesURL = "https://prices.azure.com/api/retail/prices"
+	// requestTimeout bounds each request to the Azure Retail Prices API, so that an unreachable API doesn't hold the
+	// reconciliation of the cluster.
+	requestTimeout = 10 * time.Second
+	// maxPages bounds the number of pages followed through the next page links of the Azure Retail Prices API.
+	maxPages = 20
+)
+
+// retailPrice is a price of the Azure Retail Prices API.
+type retailPrice struct {
+	CurrencyCode     string  `json:"currencyCode"`
+	RetailPrice      float64 `json:"retailPrice"`
+	TierMinimumUnits float64 `json:"tierMinimumUnits"`
+	ProductName      string  `json:"productName"`
+	MeterName        string  `json:"meterName"`
+	UnitOfMeasure    string  `json:"unitOfMeasure"`
+}
+
+// retailPricesPage is a page of prices of the Azure Retail Prices API.
+type retailPricesPage struct {
+	Items        []retailPrice `json:"Items"`
+	NextPageLink string        `json:"NextPageLink"`
+}
+
+// client lists the retail prices of a region.
+type client interface {
+	ListPrices(ctx context.Context, region string, productNames []string) ([]retailPrice, error)
+}
+
+// pricesClient is a client of the Azure Retail Prices API.
+type pricesClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+var _ client = (*pricesClient)(nil)
+
+// newClient creates a new client of the Azure Retail Prices API.
+func newClient() *pricesClient {
+	return &pricesClient{
+		httpClient: &http.Client{Timeout: requestTimeout},
+		baseURL:    retailPricesURL,
+	}
+}
+
+// ListPrices lists the consumption prices of the products of a region, in US dollars.
+func (c *pricesClient) ListPrices(ctx context.Context, region string, productNames []string) ([]retailPrice, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "costestimates.pricesClient.ListPrices")
+	defer done()
+
+	products := make([]string, 0, len(productNames))
+	for _, name := range productNames {
+		products = append(products, fmt.Sprintf("productName eq '%s'", name))
+	}
+	query := url.Values{}
+	query.Set("$filter", fmt.Sprintf("armRegionName eq '%s' and priceType eq 'Consumption' and (%s)", region, strings.Join(products, " or ")))
+	link := c.baseURL + "?" + query.Encode()
+
+	var prices []retailPrice
+	for page := 0; link != ""; page++ {
+		if page == maxPages {
+			return nil, errors.Errorf("the retail prices of region %s span more than %d pages", region, maxPages)
+		}
+		result, err := c.getPage(ctx, link)
+		if err != nil {
+			return nil, err
+		}
+		prices = append(prices, result.Items...)
+		link = result.NextPageLink
+	}
+	return prices, nil
+}
+
+// getPage gets a page of prices of the Azure Retail Prices API.
+func (c *pricesClient) getPage(ctx context.Context, link string) (*retailPricesPage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the retail prices request")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the retail prices")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to get the retail prices: unexpected status %s", resp.Status)
+	}
+	page := &retailPricesPage{}
+	if err := json.NewDecoder(resp.Body).Decode(page); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the retail prices")
+	}
+	return page, nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package costestimates
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestListPrices(t *testing.T) {
+	g := NewWithT(t)
+
+	var filters []string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"Items": [{"currencyCode": "USD", "retailPrice": 0.19, "productName": "Azure Bastion", "meterName": "Basic Gateway", "unitOfMeasure": "1 Hour"}], "NextPageLink": null}`)
+			return
+		}
+		filters = append(filters, r.URL.Query().Get("$filter"))
+		fmt.Fprintf(w, `{"Items": [{"currencyCode": "USD", "retailPrice": 0.005, "productName": "IP Addresses", "meterName": "Standard IPv4 Static Public IP", "unitOfMeasure": "1 Hour"}], "NextPageLink": "%s?page=2"}`, server.URL)
+	}))
+	defer server.Close()
+
+	c := &pricesClient{httpClient: server.Client(), baseURL: server.URL}
+	prices, err := c.ListPrices(context.TODO(), "westeurope", []string{"IP Addresses", "Azure Bastion"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(filters).To(ConsistOf("armRegionName eq 'westeurope' and priceType eq 'Consumption' and (productName eq 'IP Addresses' or productName eq 'Azure Bastion')"))
+	g.Expect(prices).To(Equal([]retailPrice{
+		{CurrencyCode: "USD", RetailPrice: 0.005, ProductName: "IP Addresses", MeterName: "Standard IPv4 Static Public IP", UnitOfMeasure: "1 Hour"},
+		{CurrencyCode: "USD", RetailPrice: 0.19, ProductName: "Azure Bastion", MeterName: "Basic Gateway", UnitOfMeasure: "1 Hour"},
+	}))
+}
+
+func TestListPricesError(t *testing.T) {
+	g := NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c := &pricesClient{httpClient: server.Client(), baseURL: server.URL}
+	_, err := c.ListPrices(context.TODO(), "westeurope", []string{"IP Addresses"})
+	g.Expect(err).To(MatchError("failed to get the retail prices: unexpected status 429 Too Many Requests"))
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package costestimates
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const (
+	// hoursPerMonth is the number of hours of a month used by the Azure pricing calculator.
+	hoursPerMonth = 730
+	// currency is the currency of the prices of the Azure Retail Prices API when no other currency is requested.
+	currency = "USD"
+	// hourlyUnit is the unit of measure of hourly prices.
+	hourlyUnit = "1 Hour"
+)
+
+// meter identifies the hourly price of a kind of resource in the Azure Retail Prices API.
+type meter struct {
+	kind        string
+	productName string
+	meterName   string
+}
+
+var (
+	standardLoadBalancerMeter = meter{kind: "Standard Load Balancer", productName: "Load Balancer", meterName: "Standard Included LB Rules and Outbound Rules"}
+	publicIPv4Meter           = meter{kind: "Standard Public IPv4 Address", productName: "IP Addresses", meterName: "Standard IPv4 Static Public IP"}
+	publicIPv6Meter           = meter{kind: "Standard Public IPv6 Address", productName: "IP Addresses", meterName: "Standard IPv6 Static Public IP"}
+	natGatewayMeter           = meter{kind: "NAT Gateway", productName: "NAT Gateway", meterName: "Standard Gateway"}
+	bastionMeter              = meter{kind: "Basic Bastion", productName: "Azure Bastion", meterName: "Basic Gateway"}
+
+	// meters are the meters of the estimate, in the order of its breakdown.
+	meters = []meter{standardLoadBalancerMeter, publicIPv4Meter, publicIPv6Meter, natGatewayMeter, bastionMeter}
+)
+
+// CostEstimateScope defines the scope interface for a cost estimates service.
+type CostEstimateScope interface {
+	Location() string
+	LBSpecs() []azure.ResourceSpecGetter
+	InternalLBSpecs() []azure.ResourceSpecGetter
+	PublicIPSpecs() []azure.PublicIPSpec
+	NatGatewaySpecs() []azure.ResourceSpecGetter
+	IsAzureBastionEnabled() bool
+	SetCostEstimate(*infrav1.CostEstimate)
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope CostEstimateScope
+	client
+	cache *priceCache
+	now   func() time.Time
+}
+
+// New creates a new service.
+func New(scope CostEstimateScope) *Service {
+	return &Service{
+		Scope:  scope,
+		client: newClient(),
+		cache:  defaultPriceCache,
+		now:    time.Now,
+	}
+}
+
+// Reconcile estimates the monthly cost of the network resources of the cluster.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "costestimates.Service.Reconcile")
+	defer done()
+
+	return s.EstimateCost(ctx)
+}
+
+// Delete is a no-op as the cost estimate doesn't create any Azure resource.
+func (s *Service) Delete(ctx context.Context) error {
+	_, _, done := tele.StartSpanWithLogger(ctx, "costestimates.Service.Delete")
+	defer done()
+
+	return nil
+}
+
+// EstimateCost estimates the monthly cost of the load balancers, public IPs, NAT gateways and bastion of the cluster
+// from the retail prices of its region, and records it in the status of the cluster. The estimate is omitted when the
+// prices can't be retrieved, and the error is returned for the caller to report.
+func (s *Service) EstimateCost(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "costestimates.Service.EstimateCost")
+	defer done()
+
+	estimate, err := s.estimate(ctx, s.resourceCounts())
+	if err != nil {
+		s.Scope.SetCostEstimate(nil)
+		return errors.Wrapf(err, "failed to estimate the monthly cost of the cluster in region %s", s.Scope.Location())
+	}
+	log.V(4).Info("estimated the monthly cost of the cluster", "amount", estimate.MonthlyAmount, "currency", estimate.Currency)
+	s.Scope.SetCostEstimate(estimate)
+	return nil
+}
+
+// resourceCounts counts the resources of the cluster that are charged hourly, by meter. The resources managed outside
+// of the cluster, i.e. the shared API server load balancer, and the cross-region load balancer and its global public
+// IP, whose prices don't depend on the region of the cluster, aren't counted.
+func (s *Service) resourceCounts() map[meter]int32 {
+	counts := make(map[meter]int32)
+	for _, spec := range append(s.Scope.LBSpecs(), s.Scope.InternalLBSpecs()...) {
+		lbSpec, ok := spec.(*loadbalancers.LBSpec)
+		if !ok || lbSpec.Shared != nil || lbSpec.SKU != infrav1.SKUStandard {
+			continue
+		}
+		counts[standardLoadBalancerMeter]++
+	}
+	for _, ip := range s.Scope.PublicIPSpecs() {
+		switch {
+		case ip.IsGlobal:
+			continue
+		case ip.IsIPv6:
+			counts[publicIPv6Meter]++
+		default:
+			counts[publicIPv4Meter]++
+		}
+	}
+	if n := len(s.Scope.NatGatewaySpecs()); n > 0 {
+		counts[natGatewayMeter] = int32(n)
+	}
+	if s.Scope.IsAzureBastionEnabled() {
+		counts[bastionMeter] = 1
+	}
+	return counts
+}
+
+// estimate computes the estimate of the monthly cost of the resources counted by meter.
+func (s *Service) estimate(ctx context.Context, counts map[meter]int32) (*infrav1.CostEstimate, error) {
+	estimate := &infrav1.CostEstimate{Currency: currency}
+	if len(counts) == 0 {
+		estimate.MonthlyAmount = formatAmount(0)
+		return estimate, nil
+	}
+
+	prices, err := s.cache.get(ctx, s.client, s.Scope.Location(), s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	var total float64
+	for _, m := range meters {
+		count, ok := counts[m]
+		if !ok {
+			continue
+		}
+		hourly, err := hourlyPrice(prices, m)
+		if err != nil {
+			return nil, err
+		}
+		amount := hourly * float64(count) * hoursPerMonth
+		total += amount
+		estimate.Resources = append(estimate.Resources, infrav1.ResourceCostEstimate{
+			Kind:          m.kind,
+			Count:         count,
+			MonthlyAmount: formatAmount(amount),
+		})
+	}
+	estimate.MonthlyAmount = formatAmount(total)
+	return estimate, nil
+}
+
+// hourlyPrice returns the hourly price of the first tier of a meter.
+func hourlyPrice(prices []retailPrice, m meter) (float64, error) {
+	for _, price := range prices {
+		if strings.EqualFold(price.ProductName, m.productName) && strings.EqualFold(price.MeterName, m.meterName) &&
+			price.UnitOfMeasure == hourlyUnit && price.TierMinimumUnits == 0 && strings.EqualFold(price.CurrencyCode, currency) {
+			return price.RetailPrice, nil
+		}
+	}
+	return 0, errors.Errorf("no hourly retail price found for meter %q of product %q", m.meterName, m.productName)
+}
+
+// productNames returns the names of the products of the meters of the estimate.
+func productNames() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range meters {
+		if !seen[m.productName] {
+			seen[m.productName] = true
+			names = append(names, m.productName)
+		}
+	}
+	return names
+}
+
+// formatAmount formats an amount with two decimals.
+func formatAmount(amount float64) string {
+	return fmt.Sprintf("%.2f", amount)
+}
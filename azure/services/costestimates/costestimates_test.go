@@ -0,0 +1,289 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package costestimates
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
+)
+
+var (
+	fakeNow = time.Date(2022, 9, 15, 12, 0, 0, 0, time.UTC)
+
+	fakePrices = []retailPrice{
+		{CurrencyCode: "USD", RetailPrice: 0.025, ProductName: "Load Balancer", MeterName: "Standard Included LB Rules and Outbound Rules", UnitOfMeasure: "1 Hour"},
+		{CurrencyCode: "USD", RetailPrice: 0.01, ProductName: "Load Balancer", MeterName: "Standard Overage LB Rules and Outbound Rules", UnitOfMeasure: "1 Hour"},
+		{CurrencyCode: "USD", RetailPrice: 0.005, ProductName: "IP Addresses", MeterName: "Standard IPv4 Static Public IP", UnitOfMeasure: "1 Hour"},
+		{CurrencyCode: "USD", RetailPrice: 0.004, ProductName: "IP Addresses", MeterName: "Standard IPv6 Static Public IP", UnitOfMeasure: "1 Hour"},
+		{CurrencyCode: "USD", RetailPrice: 0.045, ProductName: "NAT Gateway", MeterName: "Standard Gateway", UnitOfMeasure: "1 Hour"},
+		{CurrencyCode: "USD", RetailPrice: 0.045, ProductName: "NAT Gateway", MeterName: "Standard Data Processed", UnitOfMeasure: "1 GB"},
+		{CurrencyCode: "USD", RetailPrice: 0.19, ProductName: "Azure Bastion", MeterName: "Basic Gateway", UnitOfMeasure: "1 Hour"},
+		{CurrencyCode: "USD", RetailPrice: 0, ProductName: "Azure Bastion", MeterName: "Basic Data Transfer Out", UnitOfMeasure: "1 GB"},
+	}
+)
+
+// fakeScope is a CostEstimateScope with fixed resources.
+type fakeScope struct {
+	lbs         []azure.ResourceSpecGetter
+	internalLBs []azure.ResourceSpecGetter
+	publicIPs   []azure.PublicIPSpec
+	natGateways []azure.ResourceSpecGetter
+	bastion     bool
+	estimate    *infrav1.CostEstimate
+	estimateSet bool
+}
+
+func (f *fakeScope) Location() string                            { return "westeurope" }
+func (f *fakeScope) LBSpecs() []azure.ResourceSpecGetter         { return f.lbs }
+func (f *fakeScope) InternalLBSpecs() []azure.ResourceSpecGetter { return f.internalLBs }
+func (f *fakeScope) PublicIPSpecs() []azure.PublicIPSpec         { return f.publicIPs }
+func (f *fakeScope) NatGatewaySpecs() []azure.ResourceSpecGetter { return f.natGateways }
+func (f *fakeScope) IsAzureBastionEnabled() bool                 { return f.bastion }
+
+func (f *fakeScope) SetCostEstimate(estimate *infrav1.CostEstimate) {
+	f.estimate = estimate
+	f.estimateSet = true
+}
+
+// fakeClient is a client returning fixed prices, which counts its calls.
+type fakeClient struct {
+	prices []retailPrice
+	err    error
+	calls  int
+}
+
+func (f *fakeClient) ListPrices(_ context.Context, _ string, _ []string) ([]retailPrice, error) {
+	f.calls++
+	return f.prices, f.err
+}
+
+func TestEstimateCost(t *testing.T) {
+	testcases := []struct {
+		name          string
+		scope         *fakeScope
+		client        *fakeClient
+		expected      *infrav1.CostEstimate
+		expectedError string
+	}{
+		{
+			name: "the hourly prices of the resources are summed over a month",
+			scope: &fakeScope{
+				lbs: []azure.ResourceSpecGetter{
+					&loadbalancers.LBSpec{Name: "my-cluster-public-lb", SKU: infrav1.SKUStandard},
+					&loadbalancers.LBSpec{Name: "my-cluster-outbound-lb", SKU: infrav1.SKUStandard},
+				},
+				internalLBs: []azure.ResourceSpecGetter{
+					&loadbalancers.LBSpec{Name: "my-ilb", SKU: infrav1.SKUStandard},
+				},
+				publicIPs: []azure.PublicIPSpec{
+					{Name: "pip-my-cluster-apiserver"},
+					{Name: "pip-my-cluster-node-outbound"},
+					{Name: "pip-my-cluster-node-outbound-v6", IsIPv6: true},
+					{Name: "pip-my-bastion"},
+				},
+				natGateways: []azure.ResourceSpecGetter{
+					&natgateways.NatGatewaySpec{Name: "my-natgw"},
+				},
+				bastion: true,
+			},
+			client: &fakeClient{prices: fakePrices},
+			expected: &infrav1.CostEstimate{
+				MonthlyAmount: "240.17",
+				Currency:      "USD",
+				Resources: []infrav1.ResourceCostEstimate{
+					{Kind: "Standard Load Balancer", Count: 3, MonthlyAmount: "54.75"},
+					{Kind: "Standard Public IPv4 Address", Count: 3, MonthlyAmount: "10.95"},
+					{Kind: "Standard Public IPv6 Address", Count: 1, MonthlyAmount: "2.92"},
+					{Kind: "NAT Gateway", Count: 1, MonthlyAmount: "32.85"},
+					{Kind: "Basic Bastion", Count: 1, MonthlyAmount: "138.70"},
+				},
+			},
+		},
+		{
+			name: "shared load balancers and global public IPs are not counted",
+			scope: &fakeScope{
+				lbs: []azure.ResourceSpecGetter{
+					&loadbalancers.LBSpec{Name: "shared-lb", SKU: infrav1.SKUStandard, Shared: &infrav1.SharedLoadBalancer{}},
+				},
+				publicIPs: []azure.PublicIPSpec{
+					{Name: "pip-my-cluster-global", IsGlobal: true},
+					{Name: "pip-my-cluster-node-outbound"},
+				},
+			},
+			client: &fakeClient{prices: fakePrices},
+			expected: &infrav1.CostEstimate{
+				MonthlyAmount: "3.65",
+				Currency:      "USD",
+				Resources: []infrav1.ResourceCostEstimate{
+					{Kind: "Standard Public IPv4 Address", Count: 1, MonthlyAmount: "3.65"},
+				},
+			},
+		},
+		{
+			name:     "the prices aren't retrieved when the cluster has no resource charged hourly",
+			scope:    &fakeScope{},
+			client:   &fakeClient{err: errors.New("unexpected call")},
+			expected: &infrav1.CostEstimate{MonthlyAmount: "0.00", Currency: "USD"},
+		},
+		{
+			name: "the estimate is omitted when the prices can't be retrieved",
+			scope: &fakeScope{
+				publicIPs: []azure.PublicIPSpec{{Name: "pip-my-cluster-apiserver"}},
+			},
+			client:        &fakeClient{err: errors.New("connection refused")},
+			expectedError: "failed to estimate the monthly cost of the cluster in region westeurope: connection refused",
+		},
+		{
+			name: "the estimate is omitted when the price of a resource is missing",
+			scope: &fakeScope{
+				publicIPs: []azure.PublicIPSpec{{Name: "pip-my-cluster-apiserver"}},
+				bastion:   true,
+			},
+			client: &fakeClient{prices: []retailPrice{
+				{CurrencyCode: "USD", RetailPrice: 0.005, ProductName: "IP Addresses", MeterName: "Standard IPv4 Static Public IP", UnitOfMeasure: "1 Hour"},
+			}},
+			expectedError: `no hourly retail price found for meter "Basic Gateway" of product "Azure Bastion"`,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			s := &Service{
+				Scope:  tc.scope,
+				client: tc.client,
+				cache:  newPriceCache(),
+				now:    func() time.Time { return fakeNow },
+			}
+
+			err := s.EstimateCost(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(tc.scope.estimateSet).To(BeTrue())
+			g.Expect(tc.scope.estimate).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestPriceCache(t *testing.T) {
+	g := NewWithT(t)
+
+	client := &fakeClient{prices: fakePrices}
+	cache := newPriceCache()
+
+	prices, err := cache.get(context.TODO(), client, "westeurope", fakeNow)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(prices).To(Equal(fakePrices))
+	g.Expect(client.calls).To(Equal(1))
+
+	// the prices of a region are cached, whatever the case of its name.
+	_, err = cache.get(context.TODO(), client, "WestEurope", fakeNow.Add(time.Hour))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(client.calls).To(Equal(1))
+
+	// each region has its own prices.
+	_, err = cache.get(context.TODO(), client, "eastus", fakeNow)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(client.calls).To(Equal(2))
+
+	// expired prices are reloaded.
+	_, err = cache.get(context.TODO(), client, "westeurope", fakeNow.Add(priceCacheTTL))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(client.calls).To(Equal(3))
+
+	// a failed lookup is reported again until it expires, sooner than the prices.
+	failing := &fakeClient{err: errors.New("connection refused")}
+	_, err = cache.get(context.TODO(), failing, "northeurope", fakeNow)
+	g.Expect(err).To(MatchError("connection refused"))
+	_, err = cache.get(context.TODO(), failing, "northeurope", fakeNow.Add(time.Minute))
+	g.Expect(err).To(MatchError("connection refused"))
+	g.Expect(failing.calls).To(Equal(1))
+	_, err = cache.get(context.TODO(), failing, "northeurope", fakeNow.Add(failedLookupTTL))
+	g.Expect(err).To(MatchError("connection refused"))
+	g.Expect(failing.calls).To(Equal(2))
+}
+
+// blockingClient is a client whose lookups wait until they are released, which counts its calls.
+type blockingClient struct {
+	started chan struct{}
+	release chan struct{}
+	calls   int32
+}
+
+func (b *blockingClient) ListPrices(_ context.Context, _ string, _ []string) ([]retailPrice, error) {
+	atomic.AddInt32(&b.calls, 1)
+	b.started <- struct{}{}
+	<-b.release
+	return fakePrices, nil
+}
+
+func TestPriceCacheConcurrentLookups(t *testing.T) {
+	g := NewWithT(t)
+
+	blocking := &blockingClient{started: make(chan struct{}), release: make(chan struct{})}
+	cache := newPriceCache()
+
+	var wg sync.WaitGroup
+	results := make([][]retailPrice, 2)
+	for i := range results {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			prices, err := cache.get(context.TODO(), blocking, "westeurope", fakeNow)
+			g.Expect(err).NotTo(HaveOccurred())
+			results[i] = prices
+		}()
+	}
+	<-blocking.started
+
+	// the prices of other regions are looked up while the lookup of a region is ongoing.
+	client := &fakeClient{prices: fakePrices}
+	_, err := cache.get(context.TODO(), client, "eastus", fakeNow)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(client.calls).To(Equal(1))
+
+	// a caller waiting for the ongoing lookup of a region gives up with its context.
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	_, err = cache.get(ctx, client, "westeurope", fakeNow)
+	g.Expect(err).To(MatchError(context.Canceled))
+	g.Expect(client.calls).To(Equal(1))
+
+	// the concurrent callers share the result of a single lookup.
+	close(blocking.release)
+	wg.Wait()
+	g.Expect(atomic.LoadInt32(&blocking.calls)).To(Equal(int32(1)))
+	g.Expect(results).To(Equal([][]retailPrice{fakePrices, fakePrices}))
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnosticsettings
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2021-07-01-preview/insights"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// client wraps go-sdk.
+type client interface {
+	Get(context.Context, string, string) (insights.DiagnosticSettingsResource, error)
+	CreateOrUpdate(context.Context, string, string, insights.DiagnosticSettingsResource) (insights.DiagnosticSettingsResource, error)
+	Delete(context.Context, string, string) error
+	CheckExistenceByID(context.Context, string, string) (bool, error)
+}
+
+// azureClient contains the Azure go-sdk Client.
+type azureClient struct {
+	diagnosticsettings insights.DiagnosticSettingsClient
+	resources          resources.Client
+}
+
+var _ client = (*azureClient)(nil)
+
+// newClient creates a new diagnostic settings client from subscription ID.
+func newClient(auth azure.Authorizer) *azureClient {
+	return &azureClient{
+		diagnosticsettings: newDiagnosticSettingsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		resources:          newResourcesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+	}
+}
+
+// newDiagnosticSettingsClient creates a new diagnostic settings client from subscription ID.
+func newDiagnosticSettingsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) insights.DiagnosticSettingsClient {
+	diagnosticSettingsClient := insights.NewDiagnosticSettingsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&diagnosticSettingsClient.Client, authorizer)
+	return diagnosticSettingsClient
+}
+
+// newResourcesClient creates a new resources client from subscription ID.
+func newResourcesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) resources.Client {
+	resourcesClient := resources.NewClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&resourcesClient.Client, authorizer)
+	return resourcesClient
+}
+
+// Get gets the diagnostic setting of a resource.
+func (ac *azureClient) Get(ctx context.Context, resourceID, name string) (insights.DiagnosticSettingsResource, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "diagnosticsettings.AzureClient.Get")
+	defer done()
+
+	return ac.diagnosticsettings.Get(ctx, resourceID, name)
+}
+
+// CreateOrUpdate creates or updates the diagnostic setting of a resource.
+func (ac *azureClient) CreateOrUpdate(ctx context.Context, resourceID, name string, parameters insights.DiagnosticSettingsResource) (insights.DiagnosticSettingsResource, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "diagnosticsettings.AzureClient.CreateOrUpdate")
+	defer done()
+
+	return ac.diagnosticsettings.CreateOrUpdate(ctx, resourceID, parameters, name)
+}
+
+// Delete deletes the diagnostic setting of a resource.
+func (ac *azureClient) Delete(ctx context.Context, resourceID, name string) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "diagnosticsettings.AzureClient.Delete")
+	defer done()
+
+	_, err := ac.diagnosticsettings.Delete(ctx, resourceID, name)
+	return err
+}
+
+// CheckExistenceByID checks whether a resource exists and can be read by the identity of the cluster.
+func (ac *azureClient) CheckExistenceByID(ctx context.Context, resourceID, apiVersion string) (bool, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "diagnosticsettings.AzureClient.CheckExistenceByID")
+	defer done()
+
+	resp, err := ac.resources.CheckExistenceByID(ctx, resourceID, apiVersion)
+	if err != nil {
+		return false, err
+	}
+	return resp.StatusCode != http.StatusNotFound, nil
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnosticsettings
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2021-07-01-preview/insights"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const (
+	// workspaceAPIVersion, storageAccountAPIVersion and eventHubAPIVersion are the API versions used to check that the
+	// destinations of a diagnostic setting exist.
+	workspaceAPIVersion      = "2021-06-01"
+	storageAccountAPIVersion = "2021-04-01"
+	eventHubAPIVersion       = "2021-11-01"
+)
+
+// DiagnosticSettingsScope defines the scope interface for a diagnostic settings service.
+type DiagnosticSettingsScope interface {
+	azure.Authorizer
+	DiagnosticSettingsSpecs() []azure.DiagnosticSettingsSpec
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope DiagnosticSettingsScope
+	client
+}
+
+// New creates a new service.
+func New(scope DiagnosticSettingsScope) *Service {
+	return &Service{
+		Scope:  scope,
+		client: newClient(scope),
+	}
+}
+
+// Reconcile creates or updates the diagnostic settings of the resources, and removes them when they are disabled.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "diagnosticsettings.Service.Reconcile")
+	defer done()
+
+	for _, spec := range s.Scope.DiagnosticSettingsSpecs() {
+		existing, err := s.client.Get(ctx, spec.ResourceID, spec.Name)
+		if err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrapf(err, "failed to get diagnostic setting %s of %s", spec.Name, spec.ResourceID)
+		}
+		found := err == nil
+
+		if spec.Settings == nil {
+			if !found {
+				continue
+			}
+			log.V(2).Info("deleting diagnostic setting", "diagnostic setting", spec.Name, "resource", spec.ResourceID)
+			if err := s.client.Delete(ctx, spec.ResourceID, spec.Name); err != nil && !azure.ResourceNotFound(err) {
+				return errors.Wrapf(err, "failed to delete diagnostic setting %s of %s", spec.Name, spec.ResourceID)
+			}
+			continue
+		}
+
+		desired := parameters(spec.Settings)
+		if found && isUpToDate(existing.DiagnosticSettings, desired) {
+			continue
+		}
+
+		if err := s.validateDestinations(ctx, spec.Settings); err != nil {
+			return err
+		}
+
+		log.V(2).Info("creating or updating diagnostic setting", "diagnostic setting", spec.Name, "resource", spec.ResourceID)
+		if _, err := s.client.CreateOrUpdate(ctx, spec.ResourceID, spec.Name, insights.DiagnosticSettingsResource{DiagnosticSettings: desired}); err != nil {
+			return errors.Wrapf(err, "failed to create or update diagnostic setting %s of %s", spec.Name, spec.ResourceID)
+		}
+		log.V(2).Info("successfully created or updated diagnostic setting", "diagnostic setting", spec.Name, "resource", spec.ResourceID)
+	}
+	return nil
+}
+
+// Delete removes the diagnostic settings of the resources, as Azure keeps them after the resources are deleted and
+// applies them again to new resources of the same name.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "diagnosticsettings.Service.Delete")
+	defer done()
+
+	for _, spec := range s.Scope.DiagnosticSettingsSpecs() {
+		log.V(2).Info("deleting diagnostic setting", "diagnostic setting", spec.Name, "resource", spec.ResourceID)
+		if err := s.client.Delete(ctx, spec.ResourceID, spec.Name); err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrapf(err, "failed to delete diagnostic setting %s of %s", spec.Name, spec.ResourceID)
+		}
+	}
+	return nil
+}
+
+// validateDestinations checks that the workspace, storage account and Event Hub authorization rule and event hub of the
+// diagnostic setting exist and can be read by the identity of the cluster.
+func (s *Service) validateDestinations(ctx context.Context, settings *infrav1.DiagnosticSettings) error {
+	destinations := map[string]string{}
+	if settings.WorkspaceID != "" {
+		destinations[settings.WorkspaceID] = workspaceAPIVersion
+	}
+	if settings.StorageAccountID != "" {
+		destinations[settings.StorageAccountID] = storageAccountAPIVersion
+	}
+	if settings.EventHubAuthorizationRuleID != "" {
+		destinations[settings.EventHubAuthorizationRuleID] = eventHubAPIVersion
+		if settings.EventHubName != "" {
+			destinations[eventHubID(settings.EventHubAuthorizationRuleID, settings.EventHubName)] = eventHubAPIVersion
+		}
+	}
+	for id, apiVersion := range destinations {
+		exists, err := s.client.CheckExistenceByID(ctx, id, apiVersion)
+		if err != nil {
+			return errors.Wrapf(err, "failed to access diagnostic setting destination %s", id)
+		}
+		if !exists {
+			return errors.Errorf("diagnostic setting destination %s does not exist", id)
+		}
+	}
+	return nil
+}
+
+// parameters returns the properties of the diagnostic setting.
+func parameters(settings *infrav1.DiagnosticSettings) *insights.DiagnosticSettings {
+	properties := &insights.DiagnosticSettings{}
+	if settings.WorkspaceID != "" {
+		properties.WorkspaceID = to.StringPtr(settings.WorkspaceID)
+	}
+	if settings.StorageAccountID != "" {
+		properties.StorageAccountID = to.StringPtr(settings.StorageAccountID)
+	}
+	if settings.EventHubAuthorizationRuleID != "" {
+		properties.EventHubAuthorizationRuleID = to.StringPtr(settings.EventHubAuthorizationRuleID)
+	}
+	if settings.EventHubName != "" {
+		properties.EventHubName = to.StringPtr(settings.EventHubName)
+	}
+	logs := make([]insights.LogSettings, 0, len(settings.LogCategories))
+	for _, category := range settings.LogCategories {
+		logs = append(logs, insights.LogSettings{Category: to.StringPtr(category), Enabled: to.BoolPtr(true)})
+	}
+	properties.Logs = &logs
+	metrics := make([]insights.MetricSettings, 0, len(settings.MetricCategories))
+	for _, category := range settings.MetricCategories {
+		metrics = append(metrics, insights.MetricSettings{Category: to.StringPtr(category), Enabled: to.BoolPtr(true)})
+	}
+	properties.Metrics = &metrics
+	return properties
+}
+
+// isUpToDate returns true if the existing diagnostic setting sends the desired categories to the desired destinations.
+func isUpToDate(existing, desired *insights.DiagnosticSettings) bool {
+	if existing == nil {
+		return false
+	}
+	return strings.EqualFold(to.String(existing.WorkspaceID), to.String(desired.WorkspaceID)) &&
+		strings.EqualFold(to.String(existing.StorageAccountID), to.String(desired.StorageAccountID)) &&
+		strings.EqualFold(to.String(existing.EventHubAuthorizationRuleID), to.String(desired.EventHubAuthorizationRuleID)) &&
+		strings.EqualFold(to.String(existing.EventHubName), to.String(desired.EventHubName)) &&
+		reflect.DeepEqual(enabledLogCategories(existing.Logs), enabledLogCategories(desired.Logs)) &&
+		reflect.DeepEqual(enabledMetricCategories(existing.Metrics), enabledMetricCategories(desired.Metrics))
+}
+
+// eventHubID returns the resource ID of the event hub of the namespace of an authorization rule.
+func eventHubID(authorizationRuleID, name string) string {
+	namespaceID := authorizationRuleID
+	if i := strings.LastIndex(strings.ToLower(authorizationRuleID), "/authorizationrules/"); i >= 0 {
+		namespaceID = authorizationRuleID[:i]
+	}
+	return namespaceID + "/eventhubs/" + name
+}
+
+func enabledLogCategories(logs *[]insights.LogSettings) []string {
+	categories := []string{}
+	if logs == nil {
+		return categories
+	}
+	for _, log := range *logs {
+		if to.Bool(log.Enabled) {
+			categories = append(categories, to.String(log.Category))
+		}
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+func enabledMetricCategories(metrics *[]insights.MetricSettings) []string {
+	categories := []string{}
+	if metrics == nil {
+		return categories
+	}
+	for _, metric := range *metrics {
+		if to.Bool(metric.Enabled) {
+			categories = append(categories, to.String(metric.Category))
+		}
+	}
+	sort.Strings(categories)
+	return categories
+}
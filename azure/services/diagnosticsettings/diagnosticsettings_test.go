@@ -0,0 +1,236 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnosticsettings
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2021-07-01-preview/insights"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/diagnosticsettings/mock_diagnosticsettings"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+const (
+	fakeLBID        = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-lb"
+	fakeWorkspaceID = "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.OperationalInsights/workspaces/shared-workspace"
+	fakeStorageID   = "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.Storage/storageAccounts/sharedlogs"
+	fakeNamespaceID = "/subscriptions/123/resourceGroups/siem-rg/providers/Microsoft.EventHub/namespaces/siem"
+	fakeRuleID      = fakeNamespaceID + "/authorizationRules/RootManageSharedAccessKey"
+	fakeEventHubID  = fakeNamespaceID + "/eventhubs/nsg-logs"
+)
+
+var (
+	fakeSettings = infrav1.DiagnosticSettings{
+		WorkspaceID:      fakeWorkspaceID,
+		LogCategories:    []string{"LoadBalancerAlertEvent"},
+		MetricCategories: []string{"AllMetrics"},
+	}
+	fakeDiagnosticSetting = insights.DiagnosticSettingsResource{
+		DiagnosticSettings: &insights.DiagnosticSettings{
+			WorkspaceID: to.StringPtr(fakeWorkspaceID),
+			Logs:        &[]insights.LogSettings{{Category: to.StringPtr("LoadBalancerAlertEvent"), Enabled: to.BoolPtr(true)}},
+			Metrics:     &[]insights.MetricSettings{{Category: to.StringPtr("AllMetrics"), Enabled: to.BoolPtr(true)}},
+		},
+	}
+	fakeEventHubSettings = infrav1.DiagnosticSettings{
+		EventHubAuthorizationRuleID: fakeRuleID,
+		EventHubName:                "nsg-logs",
+		LogCategories:               []string{"NetworkSecurityGroupEvent"},
+	}
+	fakeEventHubDiagnosticSetting = insights.DiagnosticSettingsResource{
+		DiagnosticSettings: &insights.DiagnosticSettings{
+			EventHubAuthorizationRuleID: to.StringPtr(fakeRuleID),
+			EventHubName:                to.StringPtr("nsg-logs"),
+			Logs:                        &[]insights.LogSettings{{Category: to.StringPtr("NetworkSecurityGroupEvent"), Enabled: to.BoolPtr(true)}},
+			Metrics:                     &[]insights.MetricSettings{},
+		},
+	}
+	notFoundError  = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found")
+	forbiddenError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusForbidden}, "AuthorizationFailed")
+)
+
+func TestReconcileDiagnosticSettings(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_diagnosticsettings.MockDiagnosticSettingsScopeMockRecorder, m *mock_diagnosticsettings.MockclientMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "create diagnostic setting",
+			expectedError: "",
+			expect: func(s *mock_diagnosticsettings.MockDiagnosticSettingsScopeMockRecorder, m *mock_diagnosticsettings.MockclientMockRecorder) {
+				s.DiagnosticSettingsSpecs().Return([]azure.DiagnosticSettingsSpec{{Name: "my-cluster-diagnostics", ResourceID: fakeLBID, Settings: &fakeSettings}})
+				gomock.InOrder(
+					m.Get(gomockinternal.AContext(), fakeLBID, "my-cluster-diagnostics").Return(insights.DiagnosticSettingsResource{}, notFoundError),
+					m.CheckExistenceByID(gomockinternal.AContext(), fakeWorkspaceID, workspaceAPIVersion).Return(true, nil),
+					m.CreateOrUpdate(gomockinternal.AContext(), fakeLBID, "my-cluster-diagnostics", fakeDiagnosticSetting),
+				)
+			},
+		},
+		{
+			name:          "diagnostic setting is up to date",
+			expectedError: "",
+			expect: func(s *mock_diagnosticsettings.MockDiagnosticSettingsScopeMockRecorder, m *mock_diagnosticsettings.MockclientMockRecorder) {
+				s.DiagnosticSettingsSpecs().Return([]azure.DiagnosticSettingsSpec{{Name: "my-cluster-diagnostics", ResourceID: fakeLBID, Settings: &fakeSettings}})
+				m.Get(gomockinternal.AContext(), fakeLBID, "my-cluster-diagnostics").Return(fakeDiagnosticSetting, nil)
+			},
+		},
+		{
+			name:          "update diagnostic setting with a new destination",
+			expectedError: "",
+			expect: func(s *mock_diagnosticsettings.MockDiagnosticSettingsScopeMockRecorder, m *mock_diagnosticsettings.MockclientMockRecorder) {
+				settings := fakeSettings
+				settings.StorageAccountID = fakeStorageID
+				updated := insights.DiagnosticSettingsResource{DiagnosticSettings: &insights.DiagnosticSettings{
+					WorkspaceID:      to.StringPtr(fakeWorkspaceID),
+					StorageAccountID: to.StringPtr(fakeStorageID),
+					Logs:             fakeDiagnosticSetting.Logs,
+					Metrics:          fakeDiagnosticSetting.Metrics,
+				}}
+				s.DiagnosticSettingsSpecs().Return([]azure.DiagnosticSettingsSpec{{Name: "my-cluster-diagnostics", ResourceID: fakeLBID, Settings: &settings}})
+				m.Get(gomockinternal.AContext(), fakeLBID, "my-cluster-diagnostics").Return(fakeDiagnosticSetting, nil)
+				m.CheckExistenceByID(gomockinternal.AContext(), fakeWorkspaceID, workspaceAPIVersion).Return(true, nil)
+				m.CheckExistenceByID(gomockinternal.AContext(), fakeStorageID, storageAccountAPIVersion).Return(true, nil)
+				m.CreateOrUpdate(gomockinternal.AContext(), fakeLBID, "my-cluster-diagnostics", updated)
+			},
+		},
+		{
+			name:          "destination does not exist",
+			expectedError: "diagnostic setting destination " + fakeWorkspaceID + " does not exist",
+			expect: func(s *mock_diagnosticsettings.MockDiagnosticSettingsScopeMockRecorder, m *mock_diagnosticsettings.MockclientMockRecorder) {
+				s.DiagnosticSettingsSpecs().Return([]azure.DiagnosticSettingsSpec{{Name: "my-cluster-diagnostics", ResourceID: fakeLBID, Settings: &fakeSettings}})
+				gomock.InOrder(
+					m.Get(gomockinternal.AContext(), fakeLBID, "my-cluster-diagnostics").Return(insights.DiagnosticSettingsResource{}, notFoundError),
+					m.CheckExistenceByID(gomockinternal.AContext(), fakeWorkspaceID, workspaceAPIVersion).Return(false, nil),
+				)
+			},
+		},
+		{
+			name:          "create diagnostic setting streaming to an event hub",
+			expectedError: "",
+			expect: func(s *mock_diagnosticsettings.MockDiagnosticSettingsScopeMockRecorder, m *mock_diagnosticsettings.MockclientMockRecorder) {
+				s.DiagnosticSettingsSpecs().Return([]azure.DiagnosticSettingsSpec{{Name: "my-cluster-diagnostics", ResourceID: fakeLBID, Settings: &fakeEventHubSettings}})
+				m.Get(gomockinternal.AContext(), fakeLBID, "my-cluster-diagnostics").Return(insights.DiagnosticSettingsResource{}, notFoundError)
+				m.CheckExistenceByID(gomockinternal.AContext(), fakeRuleID, eventHubAPIVersion).Return(true, nil)
+				m.CheckExistenceByID(gomockinternal.AContext(), fakeEventHubID, eventHubAPIVersion).Return(true, nil)
+				m.CreateOrUpdate(gomockinternal.AContext(), fakeLBID, "my-cluster-diagnostics", fakeEventHubDiagnosticSetting)
+			},
+		},
+		{
+			name:          "diagnostic setting streaming to an event hub is up to date",
+			expectedError: "",
+			expect: func(s *mock_diagnosticsettings.MockDiagnosticSettingsScopeMockRecorder, m *mock_diagnosticsettings.MockclientMockRecorder) {
+				s.DiagnosticSettingsSpecs().Return([]azure.DiagnosticSettingsSpec{{Name: "my-cluster-diagnostics", ResourceID: fakeLBID, Settings: &fakeEventHubSettings}})
+				m.Get(gomockinternal.AContext(), fakeLBID, "my-cluster-diagnostics").Return(fakeEventHubDiagnosticSetting, nil)
+			},
+		},
+		{
+			name:          "event hub authorization rule is not accessible",
+			expectedError: "failed to access diagnostic setting destination " + fakeRuleID + ": #: AuthorizationFailed: StatusCode=403",
+			expect: func(s *mock_diagnosticsettings.MockDiagnosticSettingsScopeMockRecorder, m *mock_diagnosticsettings.MockclientMockRecorder) {
+				settings := fakeEventHubSettings
+				settings.EventHubName = ""
+				s.DiagnosticSettingsSpecs().Return([]azure.DiagnosticSettingsSpec{{Name: "my-cluster-diagnostics", ResourceID: fakeLBID, Settings: &settings}})
+				gomock.InOrder(
+					m.Get(gomockinternal.AContext(), fakeLBID, "my-cluster-diagnostics").Return(insights.DiagnosticSettingsResource{}, notFoundError),
+					m.CheckExistenceByID(gomockinternal.AContext(), fakeRuleID, eventHubAPIVersion).Return(false, forbiddenError),
+				)
+			},
+		},
+		{
+			name:          "event hub does not exist",
+			expectedError: "diagnostic setting destination " + fakeEventHubID + " does not exist",
+			expect: func(s *mock_diagnosticsettings.MockDiagnosticSettingsScopeMockRecorder, m *mock_diagnosticsettings.MockclientMockRecorder) {
+				s.DiagnosticSettingsSpecs().Return([]azure.DiagnosticSettingsSpec{{Name: "my-cluster-diagnostics", ResourceID: fakeLBID, Settings: &fakeEventHubSettings}})
+				m.Get(gomockinternal.AContext(), fakeLBID, "my-cluster-diagnostics").Return(insights.DiagnosticSettingsResource{}, notFoundError)
+				m.CheckExistenceByID(gomockinternal.AContext(), fakeRuleID, eventHubAPIVersion).Return(true, nil).MaxTimes(1)
+				m.CheckExistenceByID(gomockinternal.AContext(), fakeEventHubID, eventHubAPIVersion).Return(false, nil)
+			},
+		},
+		{
+			name:          "remove disabled diagnostic setting",
+			expectedError: "",
+			expect: func(s *mock_diagnosticsettings.MockDiagnosticSettingsScopeMockRecorder, m *mock_diagnosticsettings.MockclientMockRecorder) {
+				s.DiagnosticSettingsSpecs().Return([]azure.DiagnosticSettingsSpec{{Name: "my-cluster-diagnostics", ResourceID: fakeLBID}})
+				gomock.InOrder(
+					m.Get(gomockinternal.AContext(), fakeLBID, "my-cluster-diagnostics").Return(fakeDiagnosticSetting, nil),
+					m.Delete(gomockinternal.AContext(), fakeLBID, "my-cluster-diagnostics"),
+				)
+			},
+		},
+		{
+			name:          "disabled diagnostic setting does not exist",
+			expectedError: "",
+			expect: func(s *mock_diagnosticsettings.MockDiagnosticSettingsScopeMockRecorder, m *mock_diagnosticsettings.MockclientMockRecorder) {
+				s.DiagnosticSettingsSpecs().Return([]azure.DiagnosticSettingsSpec{{Name: "my-cluster-diagnostics", ResourceID: fakeLBID}})
+				m.Get(gomockinternal.AContext(), fakeLBID, "my-cluster-diagnostics").Return(insights.DiagnosticSettingsResource{}, notFoundError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_diagnosticsettings.NewMockDiagnosticSettingsScope(mockCtrl)
+			clientMock := mock_diagnosticsettings.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteDiagnosticSettings(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scopeMock := mock_diagnosticsettings.NewMockDiagnosticSettingsScope(mockCtrl)
+	clientMock := mock_diagnosticsettings.NewMockclient(mockCtrl)
+
+	scopeMock.EXPECT().DiagnosticSettingsSpecs().Return([]azure.DiagnosticSettingsSpec{{Name: "my-cluster-diagnostics", ResourceID: fakeLBID, Settings: &fakeSettings}})
+	clientMock.EXPECT().Delete(gomockinternal.AContext(), fakeLBID, "my-cluster-diagnostics").Return(notFoundError)
+
+	s := &Service{
+		Scope:  scopeMock,
+		client: clientMock,
+	}
+	g.Expect(s.Delete(context.TODO())).To(Succeed())
+}
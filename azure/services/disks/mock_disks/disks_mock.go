@@ -179,6 +179,20 @@ func (mr *MockDiskScopeMockRecorder) ClusterName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterName", reflect.TypeOf((*MockDiskScope)(nil).ClusterName))
 }
 
+// ControlPlaneAvailabilitySetID mocks base method.
+func (m *MockDiskScope) ControlPlaneAvailabilitySetID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ControlPlaneAvailabilitySetID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ControlPlaneAvailabilitySetID indicates an expected call of ControlPlaneAvailabilitySetID.
+func (mr *MockDiskScopeMockRecorder) ControlPlaneAvailabilitySetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControlPlaneAvailabilitySetID", reflect.TypeOf((*MockDiskScope)(nil).ControlPlaneAvailabilitySetID))
+}
+
 // DeleteLongRunningOperationState mocks base method.
 func (m *MockDiskScope) DeleteLongRunningOperationState(arg0, arg1 string) {
 	m.ctrl.T.Helper()
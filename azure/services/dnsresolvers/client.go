@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsresolvers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// client wraps go-sdk. The version of the SDK in use has no DNS Private Resolver client, so the resolvers and the
+// links of their forwarding rulesets are managed as generic resources.
+type client interface {
+	CheckExistenceByID(context.Context, string, string) (bool, error)
+	GetByID(context.Context, string, string) (resources.GenericResource, error)
+	CreateOrUpdateByID(context.Context, string, string, resources.GenericResource) error
+	DeleteByID(context.Context, string, string) error
+}
+
+// azureClient contains the Azure go-sdk Client.
+type azureClient struct {
+	resources resources.Client
+}
+
+var _ client = (*azureClient)(nil)
+
+// newClient creates a new DNS Private Resolver client from subscription ID.
+func newClient(auth azure.Authorizer) *azureClient {
+	return &azureClient{
+		resources: newResourcesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+	}
+}
+
+// newResourcesClient creates a new resources client from subscription ID.
+func newResourcesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) resources.Client {
+	resourcesClient := resources.NewClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&resourcesClient.Client, authorizer)
+	return resourcesClient
+}
+
+// CheckExistenceByID checks whether a resource exists and can be read by the identity of the cluster.
+func (ac *azureClient) CheckExistenceByID(ctx context.Context, resourceID, apiVersion string) (bool, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "dnsresolvers.AzureClient.CheckExistenceByID")
+	defer done()
+
+	resp, err := ac.resources.CheckExistenceByID(ctx, resourceID, apiVersion)
+	if err != nil {
+		return false, err
+	}
+	return resp.StatusCode != http.StatusNotFound, nil
+}
+
+// GetByID returns a resource.
+func (ac *azureClient) GetByID(ctx context.Context, resourceID, apiVersion string) (resources.GenericResource, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "dnsresolvers.AzureClient.GetByID")
+	defer done()
+
+	return ac.resources.GetByID(ctx, resourceID, apiVersion)
+}
+
+// CreateOrUpdateByID creates or updates a resource.
+func (ac *azureClient) CreateOrUpdateByID(ctx context.Context, resourceID, apiVersion string, parameters resources.GenericResource) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "dnsresolvers.AzureClient.CreateOrUpdateByID")
+	defer done()
+
+	future, err := ac.resources.CreateOrUpdateByID(ctx, resourceID, apiVersion, parameters)
+	if err != nil {
+		return err
+	}
+	if err := future.WaitForCompletionRef(ctx, ac.resources.Client); err != nil {
+		return err
+	}
+	_, err = future.Result(ac.resources)
+	return err
+}
+
+// DeleteByID deletes a resource.
+func (ac *azureClient) DeleteByID(ctx context.Context, resourceID, apiVersion string) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "dnsresolvers.AzureClient.DeleteByID")
+	defer done()
+
+	future, err := ac.resources.DeleteByID(ctx, resourceID, apiVersion)
+	if err != nil {
+		return err
+	}
+	if err := future.WaitForCompletionRef(ctx, ac.resources.Client); err != nil {
+		return err
+	}
+	_, err = future.Result(ac.resources)
+	return err
+}
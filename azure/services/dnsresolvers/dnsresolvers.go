@@ -0,0 +1,214 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsresolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const (
+	serviceName = "dnsresolvers"
+	// apiVersion is the API version of the DNS Private Resolver resources.
+	apiVersion = "2022-07-01"
+)
+
+// DNSPrivateResolverScope defines the scope interface for a DNS Private Resolver service.
+type DNSPrivateResolverScope interface {
+	azure.ClusterDescriber
+	azure.AsyncStatusUpdater
+	DNSPrivateResolverSpec() *azure.DNSPrivateResolverSpec
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope DNSPrivateResolverScope
+	client
+}
+
+// New creates a new DNS Private Resolver service.
+func New(scope DNSPrivateResolverScope) *Service {
+	return &Service{
+		Scope:  scope,
+		client: newClient(scope),
+	}
+}
+
+// Reconcile checks that the DNS Private Resolver and its forwarding rulesets exist, and links the rulesets to the
+// virtual network. The resolver and the rulesets are shared and never modified.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "dnsresolvers.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	spec := s.Scope.DNSPrivateResolverSpec()
+	if spec == nil {
+		log.V(4).Info("skipping DNS private resolver reconcile, no DNS private resolver is configured")
+		return nil
+	}
+
+	err := s.reconcileLinks(ctx, spec)
+	s.Scope.UpdatePutStatus(infrav1.DNSPrivateResolverReadyCondition, serviceName, err)
+	return err
+}
+
+func (s *Service) reconcileLinks(ctx context.Context, spec *azure.DNSPrivateResolverSpec) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "dnsresolvers.Service.reconcileLinks")
+	defer done()
+
+	if err := s.checkExistence(ctx, spec.ResolverID); err != nil {
+		return err
+	}
+
+	for _, rulesetID := range spec.ForwardingRulesetIDs {
+		if err := s.checkExistence(ctx, rulesetID); err != nil {
+			return err
+		}
+
+		linkID := virtualNetworkLinkID(rulesetID, spec.LinkName)
+		existing, err := s.client.GetByID(ctx, linkID, apiVersion)
+		switch {
+		case err == nil:
+			if linkedVNetID := virtualNetworkID(existing); !strings.EqualFold(linkedVNetID, spec.VNetID) {
+				return azure.WithTerminalError(errors.Errorf("link %s of DNS forwarding ruleset %s already exists for virtual network %s", spec.LinkName, rulesetID, linkedVNetID))
+			}
+			log.V(4).Info("DNS forwarding ruleset is already linked to the virtual network", "ruleset", rulesetID)
+			continue
+		case !azure.ResourceNotFound(err):
+			return errors.Wrapf(err, "failed to get link %s of DNS forwarding ruleset %s", spec.LinkName, rulesetID)
+		}
+
+		log.V(2).Info("linking DNS forwarding ruleset to the virtual network", "ruleset", rulesetID, "link", spec.LinkName)
+		if err := s.client.CreateOrUpdateByID(ctx, linkID, apiVersion, s.virtualNetworkLink(spec)); err != nil {
+			return errors.Wrapf(err, "failed to link DNS forwarding ruleset %s to virtual network %s", rulesetID, spec.VNetID)
+		}
+		log.V(2).Info("successfully linked DNS forwarding ruleset to the virtual network", "ruleset", rulesetID, "link", spec.LinkName)
+	}
+
+	return nil
+}
+
+// Delete deletes the links of the forwarding rulesets to the virtual network that were created by capz. The DNS
+// Private Resolver and its rulesets are shared, they are never deleted.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "dnsresolvers.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	spec := s.Scope.DNSPrivateResolverSpec()
+	if spec == nil {
+		log.V(4).Info("skipping DNS private resolver deletion, no DNS private resolver is configured")
+		return nil
+	}
+
+	err := s.deleteLinks(ctx, spec)
+	s.Scope.UpdateDeleteStatus(infrav1.DNSPrivateResolverReadyCondition, serviceName, err)
+	return err
+}
+
+func (s *Service) deleteLinks(ctx context.Context, spec *azure.DNSPrivateResolverSpec) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "dnsresolvers.Service.deleteLinks")
+	defer done()
+
+	for _, rulesetID := range spec.ForwardingRulesetIDs {
+		linkID := virtualNetworkLinkID(rulesetID, spec.LinkName)
+		existing, err := s.client.GetByID(ctx, linkID, apiVersion)
+		if azure.ResourceNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to get link %s of DNS forwarding ruleset %s", spec.LinkName, rulesetID)
+		}
+
+		if !metadata(existing).HasOwned(s.Scope.ClusterName()) {
+			log.V(2).Info("skipping deletion of unmanaged DNS forwarding ruleset link", "ruleset", rulesetID, "link", spec.LinkName)
+			continue
+		}
+
+		log.V(2).Info("deleting DNS forwarding ruleset link", "ruleset", rulesetID, "link", spec.LinkName)
+		if err := s.client.DeleteByID(ctx, linkID, apiVersion); err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrapf(err, "failed to delete link %s of DNS forwarding ruleset %s", spec.LinkName, rulesetID)
+		}
+	}
+
+	return nil
+}
+
+// checkExistence checks that a resource referenced by the cluster exists and can be read by the identity of the cluster.
+func (s *Service) checkExistence(ctx context.Context, resourceID string) error {
+	exists, err := s.client.CheckExistenceByID(ctx, resourceID, apiVersion)
+	if err != nil {
+		return errors.Wrapf(err, "failed to access %s", resourceID)
+	}
+	if !exists {
+		return azure.WithTerminalError(errors.Errorf("%s does not exist", resourceID))
+	}
+	return nil
+}
+
+// virtualNetworkLink returns the link of a forwarding ruleset to the virtual network. Links have metadata instead of
+// tags, which records that the link is owned by the cluster.
+func (s *Service) virtualNetworkLink(spec *azure.DNSPrivateResolverSpec) resources.GenericResource {
+	return resources.GenericResource{
+		Properties: map[string]interface{}{
+			"virtualNetwork": map[string]interface{}{
+				"id": spec.VNetID,
+			},
+			"metadata": map[string]interface{}{
+				infrav1.ClusterTagKey(s.Scope.ClusterName()): string(infrav1.ResourceLifecycleOwned),
+			},
+		},
+	}
+}
+
+// virtualNetworkLinkID returns the resource ID of a link of a forwarding ruleset.
+func virtualNetworkLinkID(rulesetID, linkName string) string {
+	return fmt.Sprintf("%s/virtualNetworkLinks/%s", strings.TrimSuffix(rulesetID, "/"), linkName)
+}
+
+// virtualNetworkID returns the resource ID of the virtual network of a forwarding ruleset link.
+func virtualNetworkID(link resources.GenericResource) string {
+	properties, _ := link.Properties.(map[string]interface{})
+	vnet, _ := properties["virtualNetwork"].(map[string]interface{})
+	id, _ := vnet["id"].(string)
+	return id
+}
+
+// metadata returns the metadata of a forwarding ruleset link.
+func metadata(link resources.GenericResource) infrav1.Tags {
+	tags := infrav1.Tags{}
+	properties, _ := link.Properties.(map[string]interface{})
+	values, _ := properties["metadata"].(map[string]interface{})
+	for key, value := range values {
+		if s, ok := value.(string); ok {
+			tags[key] = s
+		}
+	}
+	return tags
+}
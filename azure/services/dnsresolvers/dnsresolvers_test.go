@@ -0,0 +1,246 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsresolvers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/dnsresolvers/mock_dnsresolvers"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+const (
+	fakeResolverID = "/subscriptions/456/resourceGroups/hub-rg/providers/Microsoft.Network/dnsResolvers/hub-resolver"
+	fakeRulesetID  = "/subscriptions/456/resourceGroups/hub-rg/providers/Microsoft.Network/dnsForwardingRulesets/onprem"
+	fakeLinkID     = fakeRulesetID + "/virtualNetworkLinks/my-vnet-link"
+	fakeVNetID     = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"
+)
+
+var (
+	fakeSpec = azure.DNSPrivateResolverSpec{
+		ResolverID:           fakeResolverID,
+		ForwardingRulesetIDs: []string{fakeRulesetID},
+		VNetID:               fakeVNetID,
+		LinkName:             "my-vnet-link",
+	}
+	fakeLink = resources.GenericResource{
+		Properties: map[string]interface{}{
+			"virtualNetwork": map[string]interface{}{
+				"id": fakeVNetID,
+			},
+			"metadata": map[string]interface{}{
+				"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned",
+			},
+		},
+	}
+	notFoundError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found")
+)
+
+func TestReconcileDNSPrivateResolver(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_dnsresolvers.MockDNSPrivateResolverScopeMockRecorder, m *mock_dnsresolvers.MockclientMockRecorder)
+		expectedError string
+		terminal      bool
+	}{
+		{
+			name:          "no DNS private resolver",
+			expectedError: "",
+			expect: func(s *mock_dnsresolvers.MockDNSPrivateResolverScopeMockRecorder, m *mock_dnsresolvers.MockclientMockRecorder) {
+				s.DNSPrivateResolverSpec().Return(nil)
+			},
+		},
+		{
+			name:          "link forwarding ruleset to the virtual network",
+			expectedError: "",
+			expect: func(s *mock_dnsresolvers.MockDNSPrivateResolverScopeMockRecorder, m *mock_dnsresolvers.MockclientMockRecorder) {
+				s.DNSPrivateResolverSpec().Return(&fakeSpec)
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				gomock.InOrder(
+					m.CheckExistenceByID(gomockinternal.AContext(), fakeResolverID, apiVersion).Return(true, nil),
+					m.CheckExistenceByID(gomockinternal.AContext(), fakeRulesetID, apiVersion).Return(true, nil),
+					m.GetByID(gomockinternal.AContext(), fakeLinkID, apiVersion).Return(resources.GenericResource{}, notFoundError),
+					m.CreateOrUpdateByID(gomockinternal.AContext(), fakeLinkID, apiVersion, gomockinternal.DiffEq(fakeLink)),
+				)
+				s.UpdatePutStatus(infrav1.DNSPrivateResolverReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "forwarding ruleset is already linked",
+			expectedError: "",
+			expect: func(s *mock_dnsresolvers.MockDNSPrivateResolverScopeMockRecorder, m *mock_dnsresolvers.MockclientMockRecorder) {
+				s.DNSPrivateResolverSpec().Return(&fakeSpec)
+				m.CheckExistenceByID(gomockinternal.AContext(), fakeResolverID, apiVersion).Return(true, nil)
+				m.CheckExistenceByID(gomockinternal.AContext(), fakeRulesetID, apiVersion).Return(true, nil)
+				m.GetByID(gomockinternal.AContext(), fakeLinkID, apiVersion).Return(fakeLink, nil)
+				s.UpdatePutStatus(infrav1.DNSPrivateResolverReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "DNS private resolver does not exist",
+			expectedError: "reconcile error that cannot be recovered occurred: " + fakeResolverID + " does not exist. Object will not be requeued",
+			terminal:      true,
+			expect: func(s *mock_dnsresolvers.MockDNSPrivateResolverScopeMockRecorder, m *mock_dnsresolvers.MockclientMockRecorder) {
+				s.DNSPrivateResolverSpec().Return(&fakeSpec)
+				m.CheckExistenceByID(gomockinternal.AContext(), fakeResolverID, apiVersion).Return(false, nil)
+				s.UpdatePutStatus(infrav1.DNSPrivateResolverReadyCondition, serviceName, gomock.Any())
+			},
+		},
+		{
+			name:          "link exists for another virtual network",
+			expectedError: "reconcile error that cannot be recovered occurred: link my-vnet-link of DNS forwarding ruleset " + fakeRulesetID + " already exists for virtual network /subscriptions/789/resourceGroups/other-rg/providers/Microsoft.Network/virtualNetworks/my-vnet. Object will not be requeued",
+			terminal:      true,
+			expect: func(s *mock_dnsresolvers.MockDNSPrivateResolverScopeMockRecorder, m *mock_dnsresolvers.MockclientMockRecorder) {
+				s.DNSPrivateResolverSpec().Return(&fakeSpec)
+				m.CheckExistenceByID(gomockinternal.AContext(), fakeResolverID, apiVersion).Return(true, nil)
+				m.CheckExistenceByID(gomockinternal.AContext(), fakeRulesetID, apiVersion).Return(true, nil)
+				m.GetByID(gomockinternal.AContext(), fakeLinkID, apiVersion).Return(resources.GenericResource{
+					Properties: map[string]interface{}{
+						"virtualNetwork": map[string]interface{}{
+							"id": "/subscriptions/789/resourceGroups/other-rg/providers/Microsoft.Network/virtualNetworks/my-vnet",
+						},
+					},
+				}, nil)
+				s.UpdatePutStatus(infrav1.DNSPrivateResolverReadyCondition, serviceName, gomock.Any())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_dnsresolvers.NewMockDNSPrivateResolverScope(mockCtrl)
+			clientMock := mock_dnsresolvers.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+				var reconcileError azure.ReconcileError
+				g.Expect(errors.As(err, &reconcileError) && reconcileError.IsTerminal()).To(Equal(tc.terminal))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteDNSPrivateResolver(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_dnsresolvers.MockDNSPrivateResolverScopeMockRecorder, m *mock_dnsresolvers.MockclientMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "no DNS private resolver",
+			expectedError: "",
+			expect: func(s *mock_dnsresolvers.MockDNSPrivateResolverScopeMockRecorder, m *mock_dnsresolvers.MockclientMockRecorder) {
+				s.DNSPrivateResolverSpec().Return(nil)
+			},
+		},
+		{
+			name:          "delete owned link",
+			expectedError: "",
+			expect: func(s *mock_dnsresolvers.MockDNSPrivateResolverScopeMockRecorder, m *mock_dnsresolvers.MockclientMockRecorder) {
+				s.DNSPrivateResolverSpec().Return(&fakeSpec)
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				gomock.InOrder(
+					m.GetByID(gomockinternal.AContext(), fakeLinkID, apiVersion).Return(fakeLink, nil),
+					m.DeleteByID(gomockinternal.AContext(), fakeLinkID, apiVersion),
+				)
+				s.UpdateDeleteStatus(infrav1.DNSPrivateResolverReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "skip link not owned by the cluster",
+			expectedError: "",
+			expect: func(s *mock_dnsresolvers.MockDNSPrivateResolverScopeMockRecorder, m *mock_dnsresolvers.MockclientMockRecorder) {
+				s.DNSPrivateResolverSpec().Return(&fakeSpec)
+				s.ClusterName().AnyTimes().Return("other-cluster")
+				m.GetByID(gomockinternal.AContext(), fakeLinkID, apiVersion).Return(fakeLink, nil)
+				s.UpdateDeleteStatus(infrav1.DNSPrivateResolverReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "link is already deleted",
+			expectedError: "",
+			expect: func(s *mock_dnsresolvers.MockDNSPrivateResolverScopeMockRecorder, m *mock_dnsresolvers.MockclientMockRecorder) {
+				s.DNSPrivateResolverSpec().Return(&fakeSpec)
+				m.GetByID(gomockinternal.AContext(), fakeLinkID, apiVersion).Return(resources.GenericResource{}, notFoundError)
+				s.UpdateDeleteStatus(infrav1.DNSPrivateResolverReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "link deletion fails",
+			expectedError: "failed to delete link my-vnet-link of DNS forwarding ruleset " + fakeRulesetID + ": #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_dnsresolvers.MockDNSPrivateResolverScopeMockRecorder, m *mock_dnsresolvers.MockclientMockRecorder) {
+				s.DNSPrivateResolverSpec().Return(&fakeSpec)
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.GetByID(gomockinternal.AContext(), fakeLinkID, apiVersion).Return(fakeLink, nil)
+				m.DeleteByID(gomockinternal.AContext(), fakeLinkID, apiVersion).Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error"))
+				s.UpdateDeleteStatus(infrav1.DNSPrivateResolverReadyCondition, serviceName, gomock.Any())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_dnsresolvers.NewMockDNSPrivateResolverScope(mockCtrl)
+			clientMock := mock_dnsresolvers.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package galleryimages
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-04-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// client wraps go-sdk.
+type client interface {
+	GetVersion(ctx context.Context, subscriptionID, resourceGroupName, galleryName, imageName, versionName string) (compute.GalleryImageVersion, error)
+	ListVersions(ctx context.Context, subscriptionID, resourceGroupName, galleryName, imageName string) ([]compute.GalleryImageVersion, error)
+}
+
+// azureClient contains the Azure go-sdk Client. The gallery may be in another subscription than the cluster, so the
+// go-sdk client is created for the subscription of each request.
+type azureClient struct {
+	baseURI    string
+	authorizer autorest.Authorizer
+}
+
+var _ client = (*azureClient)(nil)
+
+// newClient creates a new gallery image versions client.
+func newClient(auth azure.Authorizer) *azureClient {
+	return &azureClient{
+		baseURI:    auth.BaseURI(),
+		authorizer: auth.Authorizer(),
+	}
+}
+
+// newGalleryImageVersionsClient creates a new gallery image versions client from subscription ID.
+func newGalleryImageVersionsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.GalleryImageVersionsClient {
+	versionsClient := compute.NewGalleryImageVersionsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&versionsClient.Client, authorizer)
+	return versionsClient
+}
+
+// GetVersion returns a gallery image version along with its replication status.
+func (ac *azureClient) GetVersion(ctx context.Context, subscriptionID, resourceGroupName, galleryName, imageName, versionName string) (compute.GalleryImageVersion, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "galleryimages.AzureClient.GetVersion")
+	defer done()
+
+	versionsClient := newGalleryImageVersionsClient(subscriptionID, ac.baseURI, ac.authorizer)
+	return versionsClient.Get(ctx, resourceGroupName, galleryName, imageName, versionName, compute.ReplicationStatusTypesReplicationStatus)
+}
+
+// ListVersions returns the versions of a gallery image definition.
+func (ac *azureClient) ListVersions(ctx context.Context, subscriptionID, resourceGroupName, galleryName, imageName string) ([]compute.GalleryImageVersion, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "galleryimages.AzureClient.ListVersions")
+	defer done()
+
+	versionsClient := newGalleryImageVersionsClient(subscriptionID, ac.baseURI, ac.authorizer)
+	itr, err := versionsClient.ListByGalleryImageComplete(ctx, resourceGroupName, galleryName, imageName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list gallery image versions")
+	}
+
+	var versions []compute.GalleryImageVersion
+	for ; itr.NotDone(); err = itr.NextWithContext(ctx) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate gallery image versions [%w]", err)
+		}
+		versions = append(versions, itr.Value())
+	}
+	return versions, nil
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package galleryimages
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-04-01/compute"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// GalleryImageScope defines the scope interface for a gallery images service.
+type GalleryImageScope interface {
+	azure.Authorizer
+	Location() string
+	GalleryImage() *infrav1.GalleryImage
+	SetGalleryImageID(string)
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope GalleryImageScope
+	client
+}
+
+// New creates a new service.
+func New(scope GalleryImageScope) *Service {
+	return &Service{
+		Scope:  scope,
+		client: newClient(scope),
+	}
+}
+
+// Reconcile resolves the gallery image version of the spec, checks that it is replicated to the location of the
+// cluster, and publishes its resource ID in the status.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "galleryimages.Service.Reconcile")
+	defer done()
+
+	image := s.Scope.GalleryImage()
+	if image == nil {
+		s.Scope.SetGalleryImageID("")
+		return nil
+	}
+
+	gallery, err := azureautorest.ParseResourceID(image.ID)
+	if err != nil {
+		return azure.WithTerminalError(errors.Wrapf(err, "invalid gallery ID %s", image.ID))
+	}
+
+	versionName := image.Version
+	if versionName == "" || versionName == infrav1.LatestGalleryImageVersion {
+		versions, err := s.client.ListVersions(ctx, gallery.SubscriptionID, gallery.ResourceGroup, gallery.ResourceName, image.ImageDefinition)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list the versions of image %s of gallery %s", image.ImageDefinition, image.ID)
+		}
+		versionName = latestVersion(versions)
+		if versionName == "" {
+			return errors.Errorf("image %s of gallery %s has no version available as latest", image.ImageDefinition, image.ID)
+		}
+	}
+
+	version, err := s.client.GetVersion(ctx, gallery.SubscriptionID, gallery.ResourceGroup, gallery.ResourceName, image.ImageDefinition, versionName)
+	if azure.ResourceNotFound(err) {
+		return errors.Wrapf(err, "version %s of image %s of gallery %s was not found", versionName, image.ImageDefinition, image.ID)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to get version %s of image %s of gallery %s", versionName, image.ImageDefinition, image.ID)
+	}
+
+	if err := checkReplication(version, s.Scope.Location()); err != nil {
+		return errors.Wrapf(err, "version %s of image %s of gallery %s can't be used in location %s", versionName, image.ImageDefinition, image.ID, s.Scope.Location())
+	}
+
+	log.V(4).Info("resolved gallery image version", "image", image.ImageDefinition, "version", versionName)
+	s.Scope.SetGalleryImageID(to.String(version.ID))
+	return nil
+}
+
+// Delete is a no-op as the gallery image isn't managed by the cluster.
+func (s *Service) Delete(ctx context.Context) error {
+	_, _, done := tele.StartSpanWithLogger(ctx, "galleryimages.Service.Delete")
+	defer done()
+
+	return nil
+}
+
+// latestVersion returns the name of the highest version that Azure would pick as latest: it is provisioned and not
+// excluded from latest.
+func latestVersion(versions []compute.GalleryImageVersion) string {
+	var (
+		latest     semver.Version
+		latestName string
+	)
+	for _, version := range versions {
+		if version.GalleryImageVersionProperties == nil || version.ProvisioningState != compute.ProvisioningState3Succeeded {
+			continue
+		}
+		if profile := version.PublishingProfile; profile != nil && to.Bool(profile.ExcludeFromLatest) {
+			continue
+		}
+		v, err := semver.ParseTolerant(to.String(version.Name))
+		if err != nil {
+			continue
+		}
+		if latestName == "" || v.GT(latest) {
+			latest, latestName = v, to.String(version.Name)
+		}
+	}
+	return latestName
+}
+
+// checkReplication returns an error if the gallery image version isn't fully replicated to the location.
+func checkReplication(version compute.GalleryImageVersion, location string) error {
+	properties := version.GalleryImageVersionProperties
+	if properties == nil || properties.ReplicationStatus == nil || properties.ReplicationStatus.Summary == nil {
+		return errors.New("its replication status is unknown")
+	}
+
+	var regions []string
+	for _, status := range *properties.ReplicationStatus.Summary {
+		region := to.String(status.Region)
+		regions = append(regions, region)
+		if normalizeLocation(region) != normalizeLocation(location) {
+			continue
+		}
+		switch status.State {
+		case compute.ReplicationStateCompleted:
+			return nil
+		case compute.ReplicationStateFailed:
+			return errors.Errorf("its replication failed: %s", to.String(status.Details))
+		default:
+			return errors.Errorf("it is still being replicated, in state %s", status.State)
+		}
+	}
+
+	sort.Strings(regions)
+	return errors.Errorf("it is not replicated to the location, only to %s: add the location to the target regions of the image version",
+		strings.Join(regions, ", "))
+}
+
+// normalizeLocation returns the name of a location from its display name, e.g. westus2 for "West US 2".
+func normalizeLocation(location string) string {
+	return strings.ToLower(strings.ReplaceAll(location, " ", ""))
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package galleryimages
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-04-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/galleryimages/mock_galleryimages"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+const (
+	// the gallery is in another subscription than the cluster.
+	fakeGalleryID = "/subscriptions/456/resourceGroups/images/providers/Microsoft.Compute/galleries/capz"
+	fakeVersionID = fakeGalleryID + "/images/ubuntu-2004/versions/1.22.4"
+)
+
+var notFound = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found")
+
+// fakeVersion returns a provisioned gallery image version, replicated to the given regions.
+func fakeVersion(name string, excludeFromLatest bool, regions ...compute.RegionalReplicationStatus) compute.GalleryImageVersion {
+	return compute.GalleryImageVersion{
+		ID:   to.StringPtr(fakeGalleryID + "/images/ubuntu-2004/versions/" + name),
+		Name: to.StringPtr(name),
+		GalleryImageVersionProperties: &compute.GalleryImageVersionProperties{
+			ProvisioningState: compute.ProvisioningState3Succeeded,
+			PublishingProfile: &compute.GalleryImageVersionPublishingProfile{ExcludeFromLatest: to.BoolPtr(excludeFromLatest)},
+			ReplicationStatus: &compute.ReplicationStatus{Summary: &regions},
+		},
+	}
+}
+
+func replicated(region string, state compute.ReplicationState) compute.RegionalReplicationStatus {
+	return compute.RegionalReplicationStatus{Region: to.StringPtr(region), State: state}
+}
+
+func TestReconcileGalleryImage(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_galleryimages.MockGalleryImageScopeMockRecorder, m *mock_galleryimages.MockclientMockRecorder)
+	}{
+		{
+			name: "no gallery image",
+			expect: func(s *mock_galleryimages.MockGalleryImageScopeMockRecorder, m *mock_galleryimages.MockclientMockRecorder) {
+				s.GalleryImage().Return(nil)
+				s.SetGalleryImageID("")
+			},
+		},
+		{
+			name: "fixed version replicated to the location",
+			expect: func(s *mock_galleryimages.MockGalleryImageScopeMockRecorder, m *mock_galleryimages.MockclientMockRecorder) {
+				s.GalleryImage().Return(&infrav1.GalleryImage{ID: fakeGalleryID, ImageDefinition: "ubuntu-2004", Version: "1.22.4"})
+				s.Location().Return("westus2").AnyTimes()
+				m.GetVersion(gomockinternal.AContext(), "456", "images", "capz", "ubuntu-2004", "1.22.4").
+					Return(fakeVersion("1.22.4", false, replicated("East US", compute.ReplicationStateCompleted), replicated("West US 2", compute.ReplicationStateCompleted)), nil)
+				s.SetGalleryImageID(fakeVersionID)
+			},
+		},
+		{
+			name: "latest version skips the versions that are excluded from latest or not provisioned",
+			expect: func(s *mock_galleryimages.MockGalleryImageScopeMockRecorder, m *mock_galleryimages.MockclientMockRecorder) {
+				s.GalleryImage().Return(&infrav1.GalleryImage{ID: fakeGalleryID, ImageDefinition: "ubuntu-2004", Version: infrav1.LatestGalleryImageVersion})
+				s.Location().Return("westus2").AnyTimes()
+				failed := fakeVersion("1.23.1", false)
+				failed.ProvisioningState = compute.ProvisioningState3Failed
+				m.ListVersions(gomockinternal.AContext(), "456", "images", "capz", "ubuntu-2004").Return([]compute.GalleryImageVersion{
+					fakeVersion("1.21.7", false),
+					fakeVersion("1.22.4", false),
+					fakeVersion("1.22.10", true),
+					failed,
+				}, nil)
+				m.GetVersion(gomockinternal.AContext(), "456", "images", "capz", "ubuntu-2004", "1.22.4").
+					Return(fakeVersion("1.22.4", false, replicated("West US 2", compute.ReplicationStateCompleted)), nil)
+				s.SetGalleryImageID(fakeVersionID)
+			},
+		},
+		{
+			name:          "no version available as latest",
+			expectedError: "image ubuntu-2004 of gallery " + fakeGalleryID + " has no version available as latest",
+			expect: func(s *mock_galleryimages.MockGalleryImageScopeMockRecorder, m *mock_galleryimages.MockclientMockRecorder) {
+				s.GalleryImage().Return(&infrav1.GalleryImage{ID: fakeGalleryID, ImageDefinition: "ubuntu-2004", Version: infrav1.LatestGalleryImageVersion})
+				m.ListVersions(gomockinternal.AContext(), "456", "images", "capz", "ubuntu-2004").Return([]compute.GalleryImageVersion{fakeVersion("1.22.10", true)}, nil)
+			},
+		},
+		{
+			name:          "version not found",
+			expectedError: "version 1.22.4 of image ubuntu-2004 of gallery " + fakeGalleryID + " was not found",
+			expect: func(s *mock_galleryimages.MockGalleryImageScopeMockRecorder, m *mock_galleryimages.MockclientMockRecorder) {
+				s.GalleryImage().Return(&infrav1.GalleryImage{ID: fakeGalleryID, ImageDefinition: "ubuntu-2004", Version: "1.22.4"})
+				m.GetVersion(gomockinternal.AContext(), "456", "images", "capz", "ubuntu-2004", "1.22.4").Return(compute.GalleryImageVersion{}, notFound)
+			},
+		},
+		{
+			name:          "version not replicated to the location",
+			expectedError: "can't be used in location westus2: it is not replicated to the location, only to East US, West Europe",
+			expect: func(s *mock_galleryimages.MockGalleryImageScopeMockRecorder, m *mock_galleryimages.MockclientMockRecorder) {
+				s.GalleryImage().Return(&infrav1.GalleryImage{ID: fakeGalleryID, ImageDefinition: "ubuntu-2004", Version: "1.22.4"})
+				s.Location().Return("westus2").AnyTimes()
+				m.GetVersion(gomockinternal.AContext(), "456", "images", "capz", "ubuntu-2004", "1.22.4").
+					Return(fakeVersion("1.22.4", false, replicated("West Europe", compute.ReplicationStateCompleted), replicated("East US", compute.ReplicationStateCompleted)), nil)
+			},
+		},
+		{
+			name:          "version still being replicated to the location",
+			expectedError: "it is still being replicated, in state Replicating",
+			expect: func(s *mock_galleryimages.MockGalleryImageScopeMockRecorder, m *mock_galleryimages.MockclientMockRecorder) {
+				s.GalleryImage().Return(&infrav1.GalleryImage{ID: fakeGalleryID, ImageDefinition: "ubuntu-2004", Version: "1.22.4"})
+				s.Location().Return("westus2").AnyTimes()
+				m.GetVersion(gomockinternal.AContext(), "456", "images", "capz", "ubuntu-2004", "1.22.4").
+					Return(fakeVersion("1.22.4", false, replicated("West US 2", compute.ReplicationStateReplicating)), nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_galleryimages.NewMockGalleryImageScope(mockCtrl)
+			clientMock := mock_galleryimages.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
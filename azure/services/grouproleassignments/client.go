@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grouproleassignments
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2019-03-01/authorization/mgmt/authorization"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// client wraps go-sdk.
+type client interface {
+	List(context.Context, string) ([]authorization.RoleAssignment, error)
+	Create(context.Context, string, string, authorization.RoleAssignmentCreateParameters) (authorization.RoleAssignment, error)
+	Delete(context.Context, string, string) error
+}
+
+// azureClient contains the Azure go-sdk Client.
+type azureClient struct {
+	roleassignments authorization.RoleAssignmentsClient
+}
+
+var _ client = (*azureClient)(nil)
+
+// newClient creates a new role assignments client from subscription ID.
+func newClient(auth azure.Authorizer) *azureClient {
+	return &azureClient{
+		roleassignments: newRoleAssignmentsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+	}
+}
+
+// newRoleAssignmentsClient creates a new role assignments client from subscription ID.
+func newRoleAssignmentsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) authorization.RoleAssignmentsClient {
+	roleAssignmentsClient := authorization.NewRoleAssignmentsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&roleAssignmentsClient.Client, authorizer)
+	return roleAssignmentsClient
+}
+
+// List returns the role assignments in effect in a resource group, i.e. the ones scoped to the resource group and to
+// the subscription and management groups it belongs to.
+func (ac *azureClient) List(ctx context.Context, resourceGroupName string) ([]authorization.RoleAssignment, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "grouproleassignments.AzureClient.List")
+	defer done()
+
+	itr, err := ac.roleassignments.ListForResourceGroupComplete(ctx, resourceGroupName, "atScope()")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list role assignments in the resource group")
+	}
+
+	var assignments []authorization.RoleAssignment
+	for ; itr.NotDone(); err = itr.NextWithContext(ctx) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate role assignments [%w]", err)
+		}
+		assignments = append(assignments, itr.Value())
+	}
+	return assignments, nil
+}
+
+// Create creates a role assignment.
+func (ac *azureClient) Create(ctx context.Context, scope string, name string, parameters authorization.RoleAssignmentCreateParameters) (authorization.RoleAssignment, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "grouproleassignments.AzureClient.Create")
+	defer done()
+
+	return ac.roleassignments.Create(ctx, scope, name, parameters)
+}
+
+// Delete deletes a role assignment.
+func (ac *azureClient) Delete(ctx context.Context, scope string, name string) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "grouproleassignments.AzureClient.Delete")
+	defer done()
+
+	_, err := ac.roleassignments.Delete(ctx, scope, name)
+	return err
+}
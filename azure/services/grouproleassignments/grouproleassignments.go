@@ -0,0 +1,257 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grouproleassignments
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2019-03-01/authorization/mgmt/authorization"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// GroupRoleAssignmentsScope defines the scope interface for a resource group role assignments service.
+type GroupRoleAssignmentsScope interface {
+	azure.Authorizer
+	ResourceGroup() string
+	ClusterName() string
+	RoleAssignments() []infrav1.RoleAssignment
+	RoleAssignmentIDs() map[string]string
+	SetRoleAssignmentIDs(map[string]string)
+	SetRoleAssignmentsReady()
+	SetRoleAssignmentsNotReady(reason string, severity clusterv1.ConditionSeverity, messageFormat string, messageArgs ...interface{})
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope GroupRoleAssignmentsScope
+	client
+}
+
+// New creates a new service.
+func New(scope GroupRoleAssignmentsScope) *Service {
+	return &Service{
+		Scope:  scope,
+		client: newClient(scope),
+	}
+}
+
+// Reconcile assigns the roles of the spec on the resource group of the cluster, and removes the assignments created
+// by CAPZ that are no longer in the spec. An assignment of the same role to the same principal that is already in
+// effect is adopted as is. A lack of permission to manage the assignments is reported in the RoleAssignmentsReady
+// condition and doesn't block the reconciliation of the cluster.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "grouproleassignments.Service.Reconcile")
+	defer done()
+
+	desired := s.Scope.RoleAssignments()
+	if len(desired) == 0 && len(s.Scope.RoleAssignmentIDs()) == 0 {
+		return nil
+	}
+
+	existing, err := s.client.List(ctx, s.Scope.ResourceGroup())
+	if err != nil {
+		return s.handleError(err, "failed to list role assignments of resource group %s", s.Scope.ResourceGroup())
+	}
+
+	ids := make(map[string]string, len(desired))
+	wanted := make(map[string]struct{}, len(desired))
+	for _, assignment := range desired {
+		id, err := s.reconcileAssignment(ctx, assignment, existing)
+		if err != nil {
+			return s.handleError(err, "failed to reconcile role assignment %s", assignment.Name)
+		}
+		ids[assignment.Name] = id
+		wanted[s.ownedName(assignment.PrincipalID, assignment.RoleDefinitionID)] = struct{}{}
+	}
+
+	for _, assignment := range s.ownedAssignments(existing) {
+		if _, ok := wanted[to.String(assignment.Name)]; ok {
+			continue
+		}
+		log.V(2).Info("deleting role assignment", "role assignment", to.String(assignment.Name))
+		if err := s.client.Delete(ctx, s.scope(), to.String(assignment.Name)); err != nil && !azure.ResourceNotFound(err) {
+			return s.handleError(err, "failed to delete role assignment %s", to.String(assignment.Name))
+		}
+	}
+
+	s.Scope.SetRoleAssignmentIDs(ids)
+	if len(desired) > 0 {
+		s.Scope.SetRoleAssignmentsReady()
+	}
+	return nil
+}
+
+// Delete removes the role assignments created by CAPZ from the resource group of the cluster. Adopted assignments are
+// left in place.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "grouproleassignments.Service.Delete")
+	defer done()
+
+	if len(s.Scope.RoleAssignments()) == 0 && len(s.Scope.RoleAssignmentIDs()) == 0 {
+		return nil
+	}
+
+	existing, err := s.client.List(ctx, s.Scope.ResourceGroup())
+	if azure.ResourceNotFound(err) {
+		// the resource group and its assignments are already gone.
+		s.Scope.SetRoleAssignmentIDs(nil)
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(forbiddenError(err, s.Scope.ResourceGroup()), "failed to list role assignments of resource group %s", s.Scope.ResourceGroup())
+	}
+
+	for _, assignment := range s.ownedAssignments(existing) {
+		log.V(2).Info("deleting role assignment", "role assignment", to.String(assignment.Name))
+		if err := s.client.Delete(ctx, s.scope(), to.String(assignment.Name)); err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrapf(forbiddenError(err, s.Scope.ResourceGroup()), "failed to delete role assignment %s", to.String(assignment.Name))
+		}
+	}
+	s.Scope.SetRoleAssignmentIDs(nil)
+	return nil
+}
+
+// reconcileAssignment creates the role assignment, unless an assignment of the same role to the same principal is
+// already in effect, and returns the ID of the assignment in effect. Role assignments can't be updated: an entry of
+// the spec assigning another role or principal gets a new assignment, and the previous one is deleted.
+func (s *Service) reconcileAssignment(ctx context.Context, assignment infrav1.RoleAssignment, existing []authorization.RoleAssignment) (string, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "grouproleassignments.Service.reconcileAssignment")
+	defer done()
+
+	if found := findAssignment(existing, assignment); found != nil {
+		if to.String(found.Name) != s.ownedName(assignment.PrincipalID, assignment.RoleDefinitionID) {
+			log.V(4).Info("adopting existing role assignment", "role assignment", assignment.Name, "existing", to.String(found.ID))
+		}
+		return to.String(found.ID), nil
+	}
+
+	log.V(2).Info("creating role assignment", "role assignment", assignment.Name)
+	params := authorization.RoleAssignmentCreateParameters{
+		Properties: &authorization.RoleAssignmentProperties{
+			RoleDefinitionID: to.StringPtr(s.roleDefinitionID(assignment.RoleDefinitionID)),
+			PrincipalID:      to.StringPtr(assignment.PrincipalID),
+		},
+	}
+	result, err := s.client.Create(ctx, s.scope(), s.ownedName(assignment.PrincipalID, assignment.RoleDefinitionID), params)
+	if azure.ResourceConflict(err) {
+		// the same role was assigned to the principal since the assignments were listed, under another name.
+		refreshed, listErr := s.client.List(ctx, s.Scope.ResourceGroup())
+		if listErr != nil {
+			return "", listErr
+		}
+		if found := findAssignment(refreshed, assignment); found != nil {
+			log.V(4).Info("adopting existing role assignment", "role assignment", assignment.Name, "existing", to.String(found.ID))
+			return to.String(found.ID), nil
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	log.V(2).Info("successfully created role assignment", "role assignment", assignment.Name)
+	return to.String(result.ID), nil
+}
+
+// handleError reports a reconcile error in the RoleAssignmentsReady condition. A lack of permission isn't expected
+// to resolve by retrying, so it isn't returned.
+func (s *Service) handleError(err error, messageFormat string, messageArgs ...interface{}) error {
+	err = errors.Wrapf(forbiddenError(err, s.Scope.ResourceGroup()), messageFormat, messageArgs...)
+	if azure.ResourceForbidden(err) {
+		s.Scope.SetRoleAssignmentsNotReady(infrav1.RoleAssignmentForbiddenReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+		return nil
+	}
+	s.Scope.SetRoleAssignmentsNotReady(infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+	return err
+}
+
+// forbiddenError explains which permission is missing when Azure denies the management of role assignments.
+func forbiddenError(err error, resourceGroup string) error {
+	if !azure.ResourceForbidden(err) {
+		return err
+	}
+	return errors.Wrapf(err, "the identity of the cluster is not allowed to manage the role assignments of resource group %s, "+
+		"it requires the Microsoft.Authorization/roleAssignments/write permission, e.g. from the User Access Administrator role", resourceGroup)
+}
+
+// scope returns the resource ID of the resource group of the cluster, the scope of its role assignments.
+func (s *Service) scope() string {
+	return azure.ResourceGroupID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup())
+}
+
+// roleDefinitionID returns the resource ID of a role definition given by its name or its resource ID.
+func (s *Service) roleDefinitionID(id string) string {
+	if strings.HasPrefix(strings.ToLower(id), "/subscriptions/") {
+		return id
+	}
+	return fmt.Sprintf("/subscriptions/%s/providers/Microsoft.Authorization/roleDefinitions/%s", s.Scope.SubscriptionID(), roleDefinitionName(id))
+}
+
+// ownedName returns the name of the role assignment of a role to a principal created by CAPZ for the cluster. Role
+// assignments have no tags nor metadata, the name, a GUID derived from the cluster, the principal and the role, tells
+// the assignments of the cluster apart.
+func (s *Service) ownedName(principalID, roleDefinitionID string) string {
+	key := strings.ToLower(strings.Join([]string{s.scope(), s.Scope.ClusterName(), principalID, roleDefinitionName(roleDefinitionID)}, "/"))
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(key)).String()
+}
+
+// isOwned returns true if the role assignment was created by CAPZ for the cluster.
+func (s *Service) isOwned(assignment authorization.RoleAssignment) bool {
+	if assignment.Properties == nil || !strings.EqualFold(to.String(assignment.Properties.Scope), s.scope()) {
+		return false
+	}
+	return to.String(assignment.Name) == s.ownedName(to.String(assignment.Properties.PrincipalID), to.String(assignment.Properties.RoleDefinitionID))
+}
+
+// ownedAssignments returns the role assignments created by CAPZ for the cluster.
+func (s *Service) ownedAssignments(existing []authorization.RoleAssignment) []authorization.RoleAssignment {
+	var owned []authorization.RoleAssignment
+	for _, assignment := range existing {
+		if s.isOwned(assignment) {
+			owned = append(owned, assignment)
+		}
+	}
+	return owned
+}
+
+// findAssignment returns a role assignment in effect in the resource group of the role of the spec to its principal,
+// if any.
+func findAssignment(existing []authorization.RoleAssignment, assignment infrav1.RoleAssignment) *authorization.RoleAssignment {
+	for i := range existing {
+		properties := existing[i].Properties
+		if properties == nil {
+			continue
+		}
+		if strings.EqualFold(to.String(properties.PrincipalID), assignment.PrincipalID) &&
+			strings.EqualFold(roleDefinitionName(to.String(properties.RoleDefinitionID)), roleDefinitionName(assignment.RoleDefinitionID)) {
+			return &existing[i]
+		}
+	}
+	return nil
+}
+
+// roleDefinitionName returns the name, i.e. the GUID, of a role definition given by its name or its resource ID.
+func roleDefinitionName(id string) string {
+	return path.Base(id)
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grouproleassignments
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2019-03-01/authorization/mgmt/authorization"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/grouproleassignments/mock_grouproleassignments"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	fakeScope       = "/subscriptions/123/resourceGroups/my-rg"
+	fakePrincipalID = "5d4c9a3e-6b9f-4a1e-9c4d-3f2b1a0e9d8c"
+	contributor     = "b24988ac-6180-42a0-ab88-20f7382dd24c"
+	reader          = "acdd72a7-3385-48ef-bd42-f606fba81ae7"
+	// the names of the assignments of the roles to fakePrincipalID created for my-cluster.
+	ownedContributorName = "0c5f92ed-a9aa-5c45-950e-cb054be1823b"
+	ownedReaderName      = "1a22d116-1de8-5249-bb77-9a138623db91"
+)
+
+var (
+	fakeContributorAssignment = infrav1.RoleAssignment{
+		Name:             "cloud-provider",
+		PrincipalID:      fakePrincipalID,
+		RoleDefinitionID: contributor,
+	}
+
+	forbidden = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusForbidden}, "AuthorizationFailed")
+	conflict  = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusConflict}, "RoleAssignmentExists")
+)
+
+// existingAssignment is a role assignment as returned by Azure.
+func existingAssignment(scope, name, role string) authorization.RoleAssignment {
+	return authorization.RoleAssignment{
+		ID:   to.StringPtr(scope + "/providers/Microsoft.Authorization/roleAssignments/" + name),
+		Name: to.StringPtr(name),
+		Properties: &authorization.RoleAssignmentPropertiesWithScope{
+			Scope:            to.StringPtr(scope),
+			RoleDefinitionID: to.StringPtr("/subscriptions/123/providers/Microsoft.Authorization/roleDefinitions/" + role),
+			PrincipalID:      to.StringPtr(fakePrincipalID),
+		},
+	}
+}
+
+// desiredContributorAssignment is the role assignment created for fakeContributorAssignment.
+func desiredContributorAssignment() authorization.RoleAssignmentCreateParameters {
+	return authorization.RoleAssignmentCreateParameters{
+		Properties: &authorization.RoleAssignmentProperties{
+			RoleDefinitionID: to.StringPtr("/subscriptions/123/providers/Microsoft.Authorization/roleDefinitions/" + contributor),
+			PrincipalID:      to.StringPtr(fakePrincipalID),
+		},
+	}
+}
+
+func expectScope(s *mock_grouproleassignments.MockGroupRoleAssignmentsScopeMockRecorder) {
+	s.ResourceGroup().Return("my-rg").AnyTimes()
+	s.SubscriptionID().Return("123").AnyTimes()
+	s.ClusterName().Return("my-cluster").AnyTimes()
+}
+
+func TestReconcileGroupRoleAssignments(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_grouproleassignments.MockGroupRoleAssignmentsScopeMockRecorder, m *mock_grouproleassignments.MockclientMockRecorder)
+	}{
+		{
+			name: "no role assignments",
+			expect: func(s *mock_grouproleassignments.MockGroupRoleAssignmentsScopeMockRecorder, m *mock_grouproleassignments.MockclientMockRecorder) {
+				s.RoleAssignments().Return(nil)
+				s.RoleAssignmentIDs().Return(nil)
+			},
+		},
+		{
+			name: "create role assignment",
+			expect: func(s *mock_grouproleassignments.MockGroupRoleAssignmentsScopeMockRecorder, m *mock_grouproleassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.RoleAssignments().Return([]infrav1.RoleAssignment{fakeContributorAssignment})
+				m.List(gomockinternal.AContext(), "my-rg").Return(nil, nil)
+				m.Create(gomockinternal.AContext(), fakeScope, ownedContributorName, gomockinternal.DiffEq(desiredContributorAssignment())).
+					Return(existingAssignment(fakeScope, ownedContributorName, contributor), nil)
+				s.SetRoleAssignmentIDs(map[string]string{"cloud-provider": fakeScope + "/providers/Microsoft.Authorization/roleAssignments/" + ownedContributorName})
+				s.SetRoleAssignmentsReady()
+			},
+		},
+		{
+			name: "owned role assignment already exists",
+			expect: func(s *mock_grouproleassignments.MockGroupRoleAssignmentsScopeMockRecorder, m *mock_grouproleassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.RoleAssignments().Return([]infrav1.RoleAssignment{fakeContributorAssignment})
+				m.List(gomockinternal.AContext(), "my-rg").Return([]authorization.RoleAssignment{existingAssignment(fakeScope, ownedContributorName, contributor)}, nil)
+				s.SetRoleAssignmentIDs(map[string]string{"cloud-provider": fakeScope + "/providers/Microsoft.Authorization/roleAssignments/" + ownedContributorName})
+				s.SetRoleAssignmentsReady()
+			},
+		},
+		{
+			name: "adopt inherited assignment of the same role to the same principal",
+			expect: func(s *mock_grouproleassignments.MockGroupRoleAssignmentsScopeMockRecorder, m *mock_grouproleassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.RoleAssignments().Return([]infrav1.RoleAssignment{fakeContributorAssignment})
+				m.List(gomockinternal.AContext(), "my-rg").Return([]authorization.RoleAssignment{existingAssignment("/subscriptions/123", "platform-contributor", contributor)}, nil)
+				s.SetRoleAssignmentIDs(map[string]string{"cloud-provider": "/subscriptions/123/providers/Microsoft.Authorization/roleAssignments/platform-contributor"})
+				s.SetRoleAssignmentsReady()
+			},
+		},
+		{
+			name: "adopt assignment of the same role to the same principal created concurrently",
+			expect: func(s *mock_grouproleassignments.MockGroupRoleAssignmentsScopeMockRecorder, m *mock_grouproleassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.RoleAssignments().Return([]infrav1.RoleAssignment{fakeContributorAssignment})
+				gomock.InOrder(
+					m.List(gomockinternal.AContext(), "my-rg").Return(nil, nil),
+					m.Create(gomockinternal.AContext(), fakeScope, ownedContributorName, gomock.Any()).Return(authorization.RoleAssignment{}, conflict),
+					m.List(gomockinternal.AContext(), "my-rg").Return([]authorization.RoleAssignment{existingAssignment(fakeScope, "other", contributor)}, nil),
+				)
+				s.SetRoleAssignmentIDs(map[string]string{"cloud-provider": fakeScope + "/providers/Microsoft.Authorization/roleAssignments/other"})
+				s.SetRoleAssignmentsReady()
+			},
+		},
+		{
+			name: "replace owned role assignment of another role",
+			expect: func(s *mock_grouproleassignments.MockGroupRoleAssignmentsScopeMockRecorder, m *mock_grouproleassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.RoleAssignments().Return([]infrav1.RoleAssignment{fakeContributorAssignment})
+				m.List(gomockinternal.AContext(), "my-rg").Return([]authorization.RoleAssignment{existingAssignment(fakeScope, ownedReaderName, reader)}, nil)
+				gomock.InOrder(
+					m.Create(gomockinternal.AContext(), fakeScope, ownedContributorName, gomockinternal.DiffEq(desiredContributorAssignment())).
+						Return(existingAssignment(fakeScope, ownedContributorName, contributor), nil),
+					m.Delete(gomockinternal.AContext(), fakeScope, ownedReaderName).Return(nil),
+				)
+				s.SetRoleAssignmentIDs(map[string]string{"cloud-provider": fakeScope + "/providers/Microsoft.Authorization/roleAssignments/" + ownedContributorName})
+				s.SetRoleAssignmentsReady()
+			},
+		},
+		{
+			name: "delete owned role assignment removed from the spec and keep the others",
+			expect: func(s *mock_grouproleassignments.MockGroupRoleAssignmentsScopeMockRecorder, m *mock_grouproleassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.RoleAssignments().Return(nil)
+				s.RoleAssignmentIDs().Return(map[string]string{"cloud-provider": fakeScope + "/providers/Microsoft.Authorization/roleAssignments/" + ownedContributorName})
+				m.List(gomockinternal.AContext(), "my-rg").Return([]authorization.RoleAssignment{
+					existingAssignment(fakeScope, ownedContributorName, contributor),
+					existingAssignment(fakeScope, "other", reader),
+				}, nil)
+				m.Delete(gomockinternal.AContext(), fakeScope, ownedContributorName).Return(nil)
+				s.SetRoleAssignmentIDs(map[string]string{})
+			},
+		},
+		{
+			name: "not allowed to assign roles",
+			expect: func(s *mock_grouproleassignments.MockGroupRoleAssignmentsScopeMockRecorder, m *mock_grouproleassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.RoleAssignments().Return([]infrav1.RoleAssignment{fakeContributorAssignment})
+				m.List(gomockinternal.AContext(), "my-rg").Return(nil, nil)
+				m.Create(gomockinternal.AContext(), fakeScope, ownedContributorName, gomock.Any()).Return(authorization.RoleAssignment{}, forbidden)
+				s.SetRoleAssignmentsNotReady(infrav1.RoleAssignmentForbiddenReason, clusterv1.ConditionSeverityWarning, "%s",
+					"failed to reconcile role assignment cloud-provider: the identity of the cluster is not allowed to manage the role assignments of resource group my-rg, "+
+						"it requires the Microsoft.Authorization/roleAssignments/write permission, e.g. from the User Access Administrator role: #: AuthorizationFailed: StatusCode=403")
+			},
+		},
+		{
+			name:          "failed to list role assignments",
+			expectedError: "failed to list role assignments of resource group my-rg: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_grouproleassignments.MockGroupRoleAssignmentsScopeMockRecorder, m *mock_grouproleassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.RoleAssignments().Return([]infrav1.RoleAssignment{fakeContributorAssignment})
+				m.List(gomockinternal.AContext(), "my-rg").Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error"))
+				s.SetRoleAssignmentsNotReady(infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s", gomock.Any())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_grouproleassignments.NewMockGroupRoleAssignmentsScope(mockCtrl)
+			clientMock := mock_grouproleassignments.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteGroupRoleAssignments(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_grouproleassignments.MockGroupRoleAssignmentsScopeMockRecorder, m *mock_grouproleassignments.MockclientMockRecorder)
+	}{
+		{
+			name: "no role assignments",
+			expect: func(s *mock_grouproleassignments.MockGroupRoleAssignmentsScopeMockRecorder, m *mock_grouproleassignments.MockclientMockRecorder) {
+				s.RoleAssignments().Return(nil)
+				s.RoleAssignmentIDs().Return(nil)
+			},
+		},
+		{
+			name: "delete owned role assignments only",
+			expect: func(s *mock_grouproleassignments.MockGroupRoleAssignmentsScopeMockRecorder, m *mock_grouproleassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.RoleAssignments().Return([]infrav1.RoleAssignment{fakeContributorAssignment})
+				m.List(gomockinternal.AContext(), "my-rg").Return([]authorization.RoleAssignment{
+					existingAssignment(fakeScope, ownedContributorName, contributor),
+					existingAssignment(fakeScope, "other", reader),
+					existingAssignment("/subscriptions/123", ownedReaderName, reader),
+				}, nil)
+				m.Delete(gomockinternal.AContext(), fakeScope, ownedContributorName).Return(nil)
+				s.SetRoleAssignmentIDs(nil)
+			},
+		},
+		{
+			name: "resource group already deleted",
+			expect: func(s *mock_grouproleassignments.MockGroupRoleAssignmentsScopeMockRecorder, m *mock_grouproleassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.RoleAssignments().Return([]infrav1.RoleAssignment{fakeContributorAssignment})
+				m.List(gomockinternal.AContext(), "my-rg").Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not Found"))
+				s.SetRoleAssignmentIDs(nil)
+			},
+		},
+		{
+			name:          "not allowed to delete role assignments",
+			expectedError: "failed to delete role assignment " + ownedContributorName + ": the identity of the cluster is not allowed to manage the role assignments of resource group my-rg",
+			expect: func(s *mock_grouproleassignments.MockGroupRoleAssignmentsScopeMockRecorder, m *mock_grouproleassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.RoleAssignments().Return([]infrav1.RoleAssignment{fakeContributorAssignment})
+				m.List(gomockinternal.AContext(), "my-rg").Return([]authorization.RoleAssignment{existingAssignment(fakeScope, ownedContributorName, contributor)}, nil)
+				m.Delete(gomockinternal.AContext(), fakeScope, ownedContributorName).Return(forbidden)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_grouproleassignments.NewMockGroupRoleAssignmentsScope(mockCtrl)
+			clientMock := mock_grouproleassignments.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
@@ -44,6 +45,8 @@ type GroupScope interface {
 	azure.AsyncStatusUpdater
 	GroupSpec() azure.ResourceSpecGetter
 	ClusterName() string
+	SetResourceGroupLocation(location string)
+	SetResourceGroupTags(tags infrav1.Tags)
 }
 
 // New creates a new service.
@@ -66,7 +69,17 @@ func (s *Service) Reconcile(ctx context.Context) error {
 
 	groupSpec := s.Scope.GroupSpec()
 
-	_, err := s.CreateResource(ctx, groupSpec, serviceName)
+	result, err := s.CreateResource(ctx, groupSpec, serviceName)
+	if err == nil && result != nil {
+		group, ok := result.(resources.Group)
+		if !ok {
+			err = errors.Errorf("%T is not a resources.Group", result)
+		} else {
+			// The location of an existing resource group may differ from the one in the spec.
+			s.Scope.SetResourceGroupLocation(to.String(group.Location))
+			s.Scope.SetResourceGroupTags(converters.MapToTags(group.Tags))
+		}
+	}
 	s.Scope.UpdatePutStatus(infrav1.ResourceGroupReadyCondition, serviceName, err)
 	return err
 }
@@ -54,6 +54,12 @@ var (
 		Properties: &resources.GroupProperties{},
 		Tags:       map[string]*string{"foo": to.StringPtr("bar")},
 	}
+	sampleBYOGroupInHomeRegion = resources.Group{
+		Name:       to.StringPtr("test-group"),
+		Location:   to.StringPtr("home-location"),
+		Properties: &resources.GroupProperties{},
+		Tags:       map[string]*string{"foo": to.StringPtr("bar")},
+	}
 )
 
 func TestReconcileGroups(t *testing.T) {
@@ -71,6 +77,17 @@ func TestReconcileGroups(t *testing.T) {
 				s.UpdatePutStatus(infrav1.ResourceGroupReadyCondition, serviceName, nil)
 			},
 		},
+		{
+			name:          "existing group in another location",
+			expectedError: "",
+			expect: func(s *mock_groups.MockGroupScopeMockRecorder, m *mock_groups.MockclientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.GroupSpec().Return(&fakeGroupSpec)
+				r.CreateResource(gomockinternal.AContext(), &fakeGroupSpec, serviceName).Return(sampleBYOGroupInHomeRegion, nil)
+				s.SetResourceGroupLocation("home-location")
+				s.SetResourceGroupTags(infrav1.Tags{"foo": "bar"})
+				s.UpdatePutStatus(infrav1.ResourceGroupReadyCondition, serviceName, nil)
+			},
+		},
 		{
 			name:          "create resource group fails",
 			expectedError: "#: Internal Server Error: StatusCode=500",
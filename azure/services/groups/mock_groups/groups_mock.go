@@ -203,6 +203,30 @@ func (mr *MockGroupScopeMockRecorder) SetLongRunningOperationState(arg0 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLongRunningOperationState", reflect.TypeOf((*MockGroupScope)(nil).SetLongRunningOperationState), arg0)
 }
 
+// SetResourceGroupLocation mocks base method.
+func (m *MockGroupScope) SetResourceGroupLocation(location string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetResourceGroupLocation", location)
+}
+
+// SetResourceGroupLocation indicates an expected call of SetResourceGroupLocation.
+func (mr *MockGroupScopeMockRecorder) SetResourceGroupLocation(location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetResourceGroupLocation", reflect.TypeOf((*MockGroupScope)(nil).SetResourceGroupLocation), location)
+}
+
+// SetResourceGroupTags mocks base method.
+func (m *MockGroupScope) SetResourceGroupTags(tags v1beta1.Tags) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetResourceGroupTags", tags)
+}
+
+// SetResourceGroupTags indicates an expected call of SetResourceGroupTags.
+func (mr *MockGroupScopeMockRecorder) SetResourceGroupTags(tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetResourceGroupTags", reflect.TypeOf((*MockGroupScope)(nil).SetResourceGroupTags), tags)
+}
+
 // SubscriptionID mocks base method.
 func (m *MockGroupScope) SubscriptionID() string {
 	m.ctrl.T.Helper()
@@ -19,16 +19,18 @@ package groups
 import (
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
 	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 )
 
 // GroupSpec defines the specification for a Resource Group.
 type GroupSpec struct {
-	Name           string
-	Location       string
-	ClusterName    string
-	AdditionalTags infrav1.Tags
+	Name            string
+	Location        string
+	ClusterName     string
+	ProviderVersion string
+	AdditionalTags  infrav1.Tags
 }
 
 // ResourceName returns the name of the group.
@@ -50,19 +52,31 @@ func (s *GroupSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the group.
 func (s *GroupSpec) Parameters(existing interface{}) (params interface{}, err error) {
 	if existing != nil {
-		// rg already exists, nothing to update.
-		// Note that rg tags are updated separately using tags service.
-		return nil, nil
+		existingGroup, ok := existing.(resources.Group)
+		if !ok {
+			return nil, errors.Errorf("%T is not a resources.Group", existing)
+		}
+		// Only the provider version tag of a rg managed by capz is kept up to date.
+		// Note that the other rg tags are updated separately using tags service.
+		tags := converters.MapToTags(existingGroup.Tags)
+		if !tags.HasOwned(s.ClusterName) || tags.HasProviderVersion(s.ProviderVersion) {
+			// rg already exists, nothing to update.
+			return nil, nil
+		}
+		tags[infrav1.ProviderVersionTagKey()] = s.ProviderVersion
+		existingGroup.Tags = converters.TagsToMap(tags)
+		return existingGroup, nil
 	}
 	return resources.Group{
 		Location: to.StringPtr(s.Location),
 		// We create only CAPZ default tags. User defined additional tags
 		// are created and updated using tags service.
 		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
-			ClusterName: s.ClusterName,
-			Lifecycle:   infrav1.ResourceLifecycleOwned,
-			Name:        to.StringPtr(s.Name),
-			Role:        to.StringPtr(infrav1.CommonRole),
+			ClusterName:     s.ClusterName,
+			Lifecycle:       infrav1.ResourceLifecycleOwned,
+			Name:            to.StringPtr(s.Name),
+			Role:            to.StringPtr(infrav1.CommonRole),
+			ProviderVersion: s.ProviderVersion,
 		})),
 	}, nil
 }
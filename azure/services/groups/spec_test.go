@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package groups
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestParameters(t *testing.T) {
+	withProviderVersion := func(group resources.Group, version string) resources.Group {
+		tags := map[string]*string{}
+		for k, v := range group.Tags {
+			tags[k] = v
+		}
+		tags[infrav1.ProviderVersionTagKey()] = to.StringPtr(version)
+		group.Tags = tags
+		return group
+	}
+
+	testcases := []struct {
+		name            string
+		providerVersion string
+		existing        interface{}
+		expect          func(g *WithT, result interface{})
+	}{
+		{
+			name:            "new group is tagged with the provider version",
+			providerVersion: "v1.2.0",
+			existing:        nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(resources.Group{}))
+				g.Expect(result.(resources.Group).Tags).To(HaveKeyWithValue(infrav1.ProviderVersionTagKey(), to.StringPtr("v1.2.0")))
+			},
+		},
+		{
+			name:     "new group isn't tagged without a provider version",
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(resources.Group{}))
+				g.Expect(result.(resources.Group).Tags).NotTo(HaveKey(infrav1.ProviderVersionTagKey()))
+			},
+		},
+		{
+			name:            "managed group with up to date provider version",
+			providerVersion: "v1.2.0",
+			existing:        withProviderVersion(sampleManagedGroup, "v1.2.0"),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "managed group is left untouched without a provider version",
+			existing: withProviderVersion(sampleManagedGroup, "v1.1.0"),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:            "managed group with outdated provider version",
+			providerVersion: "v1.2.0",
+			existing:        withProviderVersion(sampleManagedGroup, "v1.1.0"),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(resources.Group{}))
+				group := result.(resources.Group)
+				g.Expect(group.Tags).To(HaveKeyWithValue(infrav1.ProviderVersionTagKey(), to.StringPtr("v1.2.0")))
+				g.Expect(group.Tags).To(HaveKeyWithValue(infrav1.ClusterTagKey("test-cluster"), to.StringPtr(string(infrav1.ResourceLifecycleOwned))))
+			},
+		},
+		{
+			name:            "unmanaged group is left untouched",
+			providerVersion: "v1.2.0",
+			existing:        sampleBYOGroup,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			spec := fakeGroupSpec
+			spec.ProviderVersion = tc.providerVersion
+			result, err := spec.Parameters(tc.existing)
+			g.Expect(err).NotTo(HaveOccurred())
+			tc.expect(g, result)
+		})
+	}
+}
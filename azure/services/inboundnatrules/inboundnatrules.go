@@ -35,6 +35,7 @@ type InboundNatScope interface {
 	azure.AsyncStatusUpdater
 	APIServerLBName() string
 	InboundNatSpecs(map[int32]struct{}) []azure.ResourceSpecGetter
+	IsSSHNATRuleEnabled() bool
 }
 
 // Service provides operations on Azure resources.
@@ -69,6 +70,14 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
+	// The SSH NAT rule of the API server LB replaces the rule of each machine. The rules of the existing machines are
+	// kept until the machines are deleted.
+	if s.Scope.IsSSHNATRuleEnabled() {
+		log.V(4).Info("Skipping InboundNatRule reconciliation as the API server LB has an SSH NAT rule")
+		s.Scope.UpdatePutStatus(infrav1.InboundNATRulesReadyCondition, serviceName, nil)
+		return nil
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
 	defer cancel()
 
@@ -81,6 +90,10 @@ func (s *Service) Reconcile(ctx context.Context) error {
 
 	portsInUse := make(map[int32]struct{})
 	for _, rule := range existingRules {
+		// Rules mapping a frontend port range have no frontend port.
+		if rule.InboundNatRulePropertiesFormat == nil || rule.FrontendPort == nil {
+			continue
+		}
 		portsInUse[*rule.InboundNatRulePropertiesFormat.FrontendPort] = struct{}{} // Mark frontend port as in use
 	}
 
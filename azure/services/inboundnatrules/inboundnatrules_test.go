@@ -100,6 +100,7 @@ func TestReconcileInboundNATRule(t *testing.T) {
 				r *mock_async.MockReconcilerMockRecorder) {
 				s.ResourceGroup().AnyTimes().Return(fakeGroupName)
 				s.APIServerLBName().AnyTimes().Return(fakeLBName)
+				s.IsSSHNATRuleEnabled().Return(false)
 				m.List(gomockinternal.AContext(), fakeGroupName, fakeLBName).Return(noExistingRules, nil)
 				s.InboundNatSpecs(noPortsInUse).Return([]azure.ResourceSpecGetter{&fakeNatSpecWithNoExisting})
 				gomock.InOrder(
@@ -116,6 +117,7 @@ func TestReconcileInboundNATRule(t *testing.T) {
 				r *mock_async.MockReconcilerMockRecorder) {
 				s.ResourceGroup().AnyTimes().Return(fakeGroupName)
 				s.APIServerLBName().AnyTimes().Return("my-lb")
+				s.IsSSHNATRuleEnabled().Return(false)
 				m.List(gomockinternal.AContext(), fakeGroupName, "my-lb").Return(fakeExistingRules, nil)
 				s.InboundNatSpecs(somePortsInUse).Return([]azure.ResourceSpecGetter{&fakeNatSpec})
 				gomock.InOrder(
@@ -134,6 +136,41 @@ func TestReconcileInboundNATRule(t *testing.T) {
 				s.UpdatePutStatus(infrav1.InboundNATRulesReadyCondition, serviceName, nil)
 			},
 		},
+		{
+			name:          "SSH NAT rule of the API server LB, NAT rule reconciliation is skipped",
+			expectedError: "",
+			expect: func(s *mock_inboundnatrules.MockInboundNatScopeMockRecorder,
+				m *mock_inboundnatrules.MockclientMockRecorder,
+				r *mock_async.MockReconcilerMockRecorder) {
+				s.APIServerLBName().AnyTimes().Return("my-lb")
+				s.IsSSHNATRuleEnabled().Return(true)
+				s.UpdatePutStatus(infrav1.InboundNATRulesReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "NAT rule successfully created with an existing frontend port range rule",
+			expectedError: "",
+			expect: func(s *mock_inboundnatrules.MockInboundNatScopeMockRecorder,
+				m *mock_inboundnatrules.MockclientMockRecorder,
+				r *mock_async.MockReconcilerMockRecorder) {
+				s.ResourceGroup().AnyTimes().Return(fakeGroupName)
+				s.APIServerLBName().AnyTimes().Return("my-lb")
+				s.IsSSHNATRuleEnabled().Return(false)
+				m.List(gomockinternal.AContext(), fakeGroupName, "my-lb").Return(append([]network.InboundNatRule{
+					{
+						Name: pointer.StringPtr("NATRuleSSH"),
+						InboundNatRulePropertiesFormat: &network.InboundNatRulePropertiesFormat{
+							BackendPort: to.Int32Ptr(22),
+						},
+					},
+				}, fakeExistingRules...), nil)
+				s.InboundNatSpecs(somePortsInUse).Return([]azure.ResourceSpecGetter{&fakeNatSpec})
+				gomock.InOrder(
+					r.CreateResource(gomockinternal.AContext(), &fakeNatSpec, serviceName).Return(nil, nil),
+					s.UpdatePutStatus(infrav1.InboundNATRulesReadyCondition, serviceName, nil),
+				)
+			},
+		},
 		{
 			name:          "fail to get existing rules",
 			expectedError: "failed to get existing NAT rules: #: Internal Server Error: StatusCode=500",
@@ -142,6 +179,7 @@ func TestReconcileInboundNATRule(t *testing.T) {
 				r *mock_async.MockReconcilerMockRecorder) {
 				s.ResourceGroup().AnyTimes().Return(fakeGroupName)
 				s.APIServerLBName().AnyTimes().Return("my-lb")
+				s.IsSSHNATRuleEnabled().Return(false)
 				m.List(gomockinternal.AContext(), fakeGroupName, "my-lb").Return(nil, internalError)
 				s.UpdatePutStatus(infrav1.InboundNATRulesReadyCondition, serviceName, gomockinternal.ErrStrEq("failed to get existing NAT rules: #: Internal Server Error: StatusCode=500"))
 			},
@@ -154,6 +192,7 @@ func TestReconcileInboundNATRule(t *testing.T) {
 				r *mock_async.MockReconcilerMockRecorder) {
 				s.ResourceGroup().AnyTimes().Return(fakeGroupName)
 				s.APIServerLBName().AnyTimes().Return("my-lb")
+				s.IsSSHNATRuleEnabled().Return(false)
 				m.List(gomockinternal.AContext(), fakeGroupName, "my-lb").Return(fakeExistingRules, nil)
 				s.InboundNatSpecs(somePortsInUse).Return([]azure.ResourceSpecGetter{&fakeNatSpec})
 				gomock.InOrder(
@@ -193,6 +193,20 @@ func (mr *MockInboundNatScopeMockRecorder) ClusterName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterName", reflect.TypeOf((*MockInboundNatScope)(nil).ClusterName))
 }
 
+// ControlPlaneAvailabilitySetID mocks base method.
+func (m *MockInboundNatScope) ControlPlaneAvailabilitySetID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ControlPlaneAvailabilitySetID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ControlPlaneAvailabilitySetID indicates an expected call of ControlPlaneAvailabilitySetID.
+func (mr *MockInboundNatScopeMockRecorder) ControlPlaneAvailabilitySetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControlPlaneAvailabilitySetID", reflect.TypeOf((*MockInboundNatScope)(nil).ControlPlaneAvailabilitySetID))
+}
+
 // DeleteLongRunningOperationState mocks base method.
 func (m *MockInboundNatScope) DeleteLongRunningOperationState(arg0, arg1 string) {
 	m.ctrl.T.Helper()
@@ -261,6 +275,20 @@ func (mr *MockInboundNatScopeMockRecorder) InboundNatSpecs(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InboundNatSpecs", reflect.TypeOf((*MockInboundNatScope)(nil).InboundNatSpecs), arg0)
 }
 
+// IsSSHNATRuleEnabled mocks base method.
+func (m *MockInboundNatScope) IsSSHNATRuleEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSSHNATRuleEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsSSHNATRuleEnabled indicates an expected call of IsSSHNATRuleEnabled.
+func (mr *MockInboundNatScopeMockRecorder) IsSSHNATRuleEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSSHNATRuleEnabled", reflect.TypeOf((*MockInboundNatScope)(nil).IsSSHNATRuleEnabled))
+}
+
 // Location mocks base method.
 func (m *MockInboundNatScope) Location() string {
 	m.ctrl.T.Helper()
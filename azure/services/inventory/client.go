@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// client wraps go-sdk.
+type client interface {
+	ListByTag(context.Context, string, string) ([]resources.GenericResourceExpanded, error)
+}
+
+// azureClient contains the Azure go-sdk Client.
+type azureClient struct {
+	resources resources.Client
+}
+
+var _ client = (*azureClient)(nil)
+
+// newClient creates a new resources client from subscription ID.
+func newClient(auth azure.Authorizer) *azureClient {
+	c := newResourcesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+	return &azureClient{c}
+}
+
+// newResourcesClient creates a new resources client from subscription ID.
+func newResourcesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) resources.Client {
+	resourcesClient := resources.NewClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&resourcesClient.Client, authorizer)
+	return resourcesClient
+}
+
+// ListByTag lists the resources of a resource group that have a tag of the given name, whatever its value.
+func (ac *azureClient) ListByTag(ctx context.Context, resourceGroupName, tagName string) ([]resources.GenericResourceExpanded, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "inventory.AzureClient.ListByTag")
+	defer done()
+
+	itr, err := ac.resources.ListByResourceGroupComplete(ctx, resourceGroupName, fmt.Sprintf("tagName eq '%s'", tagName), "", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the resources of the resource group")
+	}
+
+	var found []resources.GenericResourceExpanded
+	for ; itr.NotDone(); err = itr.NextWithContext(ctx) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate resources [%w]", err)
+		}
+		found = append(found, itr.Value())
+	}
+	return found, nil
+}
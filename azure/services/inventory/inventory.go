@@ -0,0 +1,132 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// Ownership tells whether a resource of the inventory is managed by CAPZ or adopted from an existing one.
+type Ownership string
+
+const (
+	// OwnershipManaged is the ownership of the resources created by CAPZ, tagged as owned by the cluster.
+	OwnershipManaged = Ownership("managed")
+	// OwnershipAdopted is the ownership of the existing resources the cluster uses, which CAPZ never deletes.
+	OwnershipAdopted = Ownership("adopted")
+)
+
+// Resource is an Azure resource of the inventory of a cluster.
+type Resource struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Ownership Ownership `json:"ownership"`
+}
+
+// InventoryScope defines the scope interface for an inventory service.
+type InventoryScope interface {
+	azure.Authorizer
+	ClusterName() string
+	ResourceGroup() string
+	AdoptedResourceIDs() []string
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope InventoryScope
+	client
+}
+
+// New creates a new service.
+func New(scope InventoryScope) *Service {
+	return &Service{
+		Scope:  scope,
+		client: newClient(scope),
+	}
+}
+
+// Inventory returns the Azure resources of the cluster, sorted by ID. They are the resources of the resource group of
+// the cluster tagged with the cluster name, found by a single query, and the existing resources referenced by the
+// AzureCluster, e.g. a custom virtual network and its subnets. A resource is managed when it is tagged as owned by the
+// cluster, adopted otherwise. Nothing is modified.
+func (s *Service) Inventory(ctx context.Context) ([]Resource, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "inventory.Service.Inventory")
+	defer done()
+
+	tagged, err := s.client.ListByTag(ctx, s.Scope.ResourceGroup(), infrav1.ClusterTagKey(s.Scope.ClusterName()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the tagged resources of the cluster")
+	}
+
+	// Resource IDs are case insensitive, and Azure doesn't always preserve the case of the IDs it was given.
+	byID := make(map[string]Resource)
+	for _, resource := range tagged {
+		ownership := OwnershipAdopted
+		if converters.MapToTags(resource.Tags).HasOwned(s.Scope.ClusterName()) {
+			ownership = OwnershipManaged
+		}
+		byID[strings.ToLower(to.String(resource.ID))] = Resource{
+			ID:        to.String(resource.ID),
+			Type:      to.String(resource.Type),
+			Ownership: ownership,
+		}
+	}
+	for _, id := range s.Scope.AdoptedResourceIDs() {
+		if _, ok := byID[strings.ToLower(id)]; !ok {
+			byID[strings.ToLower(id)] = Resource{
+				ID:        id,
+				Type:      resourceType(id),
+				Ownership: OwnershipAdopted,
+			}
+		}
+	}
+
+	inventory := make([]Resource, 0, len(byID))
+	for _, resource := range byID {
+		inventory = append(inventory, resource)
+	}
+	sort.Slice(inventory, func(i, j int) bool {
+		return strings.ToLower(inventory[i].ID) < strings.ToLower(inventory[j].ID)
+	})
+	return inventory, nil
+}
+
+// resourceType returns the type of a resource from its ID, e.g. "Microsoft.Network/virtualNetworks/subnets" for a
+// subnet, as listed by Azure.
+func resourceType(id string) string {
+	parts := strings.Split(strings.Trim(id, "/"), "/")
+	for i := len(parts) - 2; i >= 0; i-- {
+		if !strings.EqualFold(parts[i], "providers") {
+			continue
+		}
+		types := []string{parts[i+1]}
+		for j := i + 2; j < len(parts); j += 2 {
+			types = append(types, parts[j])
+		}
+		return strings.Join(types, "/")
+	}
+	return ""
+}
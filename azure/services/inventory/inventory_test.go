@@ -0,0 +1,146 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/inventory/mock_inventory"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+const (
+	fakeVnetID   = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"
+	fakeSubnetID = fakeVnetID + "/subnets/my-subnet"
+	fakeNSGID    = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/my-nsg"
+	fakeLBID     = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-lb"
+)
+
+func TestInventory(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_inventory.MockInventoryScopeMockRecorder, m *mock_inventory.MockclientMockRecorder)
+		expected      []Resource
+		expectedError string
+	}{
+		{
+			name: "resources tagged as owned are managed",
+			expect: func(s *mock_inventory.MockInventoryScopeMockRecorder, m *mock_inventory.MockclientMockRecorder) {
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.ResourceGroup().Return("my-rg")
+				s.AdoptedResourceIDs().Return(nil)
+				m.ListByTag(gomockinternal.AContext(), "my-rg", "sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster").Return([]resources.GenericResourceExpanded{
+					{
+						ID:   to.StringPtr(fakeNSGID),
+						Type: to.StringPtr("Microsoft.Network/networkSecurityGroups"),
+						Tags: map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned")},
+					},
+					{
+						ID:   to.StringPtr(fakeLBID),
+						Type: to.StringPtr("Microsoft.Network/loadBalancers"),
+						Tags: map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned")},
+					},
+				}, nil)
+			},
+			expected: []Resource{
+				{ID: fakeLBID, Type: "Microsoft.Network/loadBalancers", Ownership: OwnershipManaged},
+				{ID: fakeNSGID, Type: "Microsoft.Network/networkSecurityGroups", Ownership: OwnershipManaged},
+			},
+		},
+		{
+			name: "resources shared with the cluster and custom vnet are adopted",
+			expect: func(s *mock_inventory.MockInventoryScopeMockRecorder, m *mock_inventory.MockclientMockRecorder) {
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.ResourceGroup().Return("my-rg")
+				s.AdoptedResourceIDs().Return([]string{fakeVnetID, fakeSubnetID})
+				m.ListByTag(gomockinternal.AContext(), "my-rg", "sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster").Return([]resources.GenericResourceExpanded{
+					{
+						ID:   to.StringPtr(fakeNSGID),
+						Type: to.StringPtr("Microsoft.Network/networkSecurityGroups"),
+						Tags: map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("shared")},
+					},
+				}, nil)
+			},
+			expected: []Resource{
+				{ID: fakeNSGID, Type: "Microsoft.Network/networkSecurityGroups", Ownership: OwnershipAdopted},
+				{ID: fakeVnetID, Type: "Microsoft.Network/virtualNetworks", Ownership: OwnershipAdopted},
+				{ID: fakeSubnetID, Type: "Microsoft.Network/virtualNetworks/subnets", Ownership: OwnershipAdopted},
+			},
+		},
+		{
+			name: "adopted resource found by the tag query isn't listed twice",
+			expect: func(s *mock_inventory.MockInventoryScopeMockRecorder, m *mock_inventory.MockclientMockRecorder) {
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.ResourceGroup().Return("my-rg")
+				s.AdoptedResourceIDs().Return([]string{fakeVnetID})
+				m.ListByTag(gomockinternal.AContext(), "my-rg", "sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster").Return([]resources.GenericResourceExpanded{
+					{
+						ID:   to.StringPtr("/subscriptions/123/resourcegroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"),
+						Type: to.StringPtr("Microsoft.Network/virtualNetworks"),
+						Tags: map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("shared")},
+					},
+				}, nil)
+			},
+			expected: []Resource{
+				{ID: "/subscriptions/123/resourcegroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet", Type: "Microsoft.Network/virtualNetworks", Ownership: OwnershipAdopted},
+			},
+		},
+		{
+			name: "fail to list the tagged resources",
+			expect: func(s *mock_inventory.MockInventoryScopeMockRecorder, m *mock_inventory.MockclientMockRecorder) {
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.ResourceGroup().Return("my-rg")
+				m.ListByTag(gomockinternal.AContext(), "my-rg", "sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster").Return(nil, errors.New("#: Internal Server Error: StatusCode=500"))
+			},
+			expectedError: "failed to list the tagged resources of the cluster: #: Internal Server Error: StatusCode=500",
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_inventory.NewMockInventoryScope(mockCtrl)
+			clientMock := mock_inventory.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			inventory, err := s.Inventory(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(inventory).To(Equal(tc.expected))
+			}
+		})
+	}
+}
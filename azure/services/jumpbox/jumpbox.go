@@ -0,0 +1,132 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jumpbox
+
+import (
+	"context"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachines"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const serviceName = "jumpbox"
+
+// JumpboxScope defines the scope interface for a jumpbox service.
+type JumpboxScope interface {
+	azure.ClusterDescriber
+	azure.AsyncStatusUpdater
+	JumpboxNICSpec() azure.ResourceSpecGetter
+	JumpboxVMSpec() *virtualmachines.VMSpec
+	JumpboxPublicIPName() string
+	SetJumpboxIP(string)
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope            JumpboxScope
+	nicReconciler    async.Reconciler
+	vmReconciler     async.Reconciler
+	publicIPsClient  publicips.Client
+	resourceSKUCache *resourceskus.Cache
+}
+
+// New creates a new service.
+func New(scope JumpboxScope, skuCache *resourceskus.Cache) *Service {
+	nicClient := networkinterfaces.NewClient(scope)
+	vmClient := virtualmachines.NewClient(scope)
+	return &Service{
+		Scope:            scope,
+		nicReconciler:    async.New(scope, nicClient, nicClient),
+		vmReconciler:     async.New(scope, vmClient, vmClient),
+		publicIPsClient:  publicips.NewClient(scope),
+		resourceSKUCache: skuCache,
+	}
+}
+
+// Reconcile gets/creates the jumpbox virtual machine and its network interface.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "jumpbox.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	vmSpec := s.Scope.JumpboxVMSpec()
+	if vmSpec == nil {
+		return nil
+	}
+
+	err := s.reconcileJumpbox(ctx, &VMSpec{VMSpec: *vmSpec})
+	s.Scope.UpdatePutStatus(infrav1.JumpboxReadyCondition, serviceName, err)
+	return err
+}
+
+func (s *Service) reconcileJumpbox(ctx context.Context, vmSpec *VMSpec) error {
+	sku, err := s.resourceSKUCache.Get(ctx, vmSpec.Size, resourceskus.VirtualMachines)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get VM SKU %s in compute api", vmSpec.Size)
+	}
+	vmSpec.SKU = sku
+
+	if _, err := s.nicReconciler.CreateResource(ctx, s.Scope.JumpboxNICSpec(), serviceName); err != nil {
+		return err
+	}
+
+	if _, err := s.vmReconciler.CreateResource(ctx, vmSpec, serviceName); err != nil {
+		return err
+	}
+
+	publicIP, err := s.publicIPsClient.Get(ctx, s.Scope.ResourceGroup(), s.Scope.JumpboxPublicIPName())
+	if err != nil {
+		return errors.Wrap(err, "failed to get jumpbox public IP")
+	}
+	s.Scope.SetJumpboxIP(to.String(publicIP.IPAddress))
+
+	return nil
+}
+
+// Delete deletes the jumpbox virtual machine and its network interface.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "jumpbox.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	vmSpec := s.Scope.JumpboxVMSpec()
+	if vmSpec == nil {
+		return nil
+	}
+
+	// The network interface can only be deleted once it's detached from the virtual machine.
+	err := s.vmReconciler.DeleteResource(ctx, &VMSpec{VMSpec: *vmSpec}, serviceName)
+	if err == nil {
+		err = s.nicReconciler.DeleteResource(ctx, s.Scope.JumpboxNICSpec(), serviceName)
+	}
+
+	s.Scope.UpdateDeleteStatus(infrav1.JumpboxReadyCondition, serviceName, err)
+	return err
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jumpbox
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-04-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/jumpbox/mock_jumpbox"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips/mock_publicips"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachines"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakeNICSpec = networkinterfaces.NICSpec{
+		Name:          "my-jumpbox-nic",
+		ResourceGroup: "my-rg",
+		SubnetName:    "my-jumpbox-subnet",
+		PublicIPName:  "my-jumpbox-pip",
+	}
+	fakeSKU = compute.ResourceSku{
+		Name: to.StringPtr("Standard_B2s"),
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{Name: to.StringPtr(resourceskus.VCPUs), Value: to.StringPtr("2")},
+			{Name: to.StringPtr(resourceskus.MemoryGB), Value: to.StringPtr("4")},
+		},
+	}
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error")
+)
+
+func fakeVMSpec() *virtualmachines.VMSpec {
+	return &virtualmachines.VMSpec{
+		Name:          "my-jumpbox",
+		ResourceGroup: "my-rg",
+		Size:          "Standard_B2s",
+	}
+}
+
+func TestReconcileJumpbox(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_jumpbox.MockJumpboxScopeMockRecorder, nic *mock_async.MockReconcilerMockRecorder, vm *mock_async.MockReconcilerMockRecorder, pip *mock_publicips.MockClientMockRecorder)
+	}{
+		{
+			name:          "no jumpbox configured",
+			expectedError: "",
+			expect: func(s *mock_jumpbox.MockJumpboxScopeMockRecorder, nic *mock_async.MockReconcilerMockRecorder, vm *mock_async.MockReconcilerMockRecorder, pip *mock_publicips.MockClientMockRecorder) {
+				s.JumpboxVMSpec().Return(nil)
+			},
+		},
+		{
+			name:          "jumpbox successfully created",
+			expectedError: "",
+			expect: func(s *mock_jumpbox.MockJumpboxScopeMockRecorder, nic *mock_async.MockReconcilerMockRecorder, vm *mock_async.MockReconcilerMockRecorder, pip *mock_publicips.MockClientMockRecorder) {
+				s.JumpboxVMSpec().Return(fakeVMSpec())
+				s.JumpboxNICSpec().Return(&fakeNICSpec)
+				nic.CreateResource(gomockinternal.AContext(), &fakeNICSpec, serviceName).Return(nil, nil)
+				vm.CreateResource(gomockinternal.AContext(), gomock.AssignableToTypeOf(&VMSpec{}), serviceName).Return(nil, nil)
+				s.ResourceGroup().Return("my-rg")
+				s.JumpboxPublicIPName().Return("my-jumpbox-pip")
+				pip.Get(gomockinternal.AContext(), "my-rg", "my-jumpbox-pip").Return(network.PublicIPAddress{
+					PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{IPAddress: to.StringPtr("203.0.113.10")},
+				}, nil)
+				s.SetJumpboxIP("203.0.113.10")
+				s.UpdatePutStatus(infrav1.JumpboxReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "fail to create the network interface",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_jumpbox.MockJumpboxScopeMockRecorder, nic *mock_async.MockReconcilerMockRecorder, vm *mock_async.MockReconcilerMockRecorder, pip *mock_publicips.MockClientMockRecorder) {
+				s.JumpboxVMSpec().Return(fakeVMSpec())
+				s.JumpboxNICSpec().Return(&fakeNICSpec)
+				nic.CreateResource(gomockinternal.AContext(), &fakeNICSpec, serviceName).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.JumpboxReadyCondition, serviceName, internalError)
+			},
+		},
+		{
+			name:          "fail to create the virtual machine",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_jumpbox.MockJumpboxScopeMockRecorder, nic *mock_async.MockReconcilerMockRecorder, vm *mock_async.MockReconcilerMockRecorder, pip *mock_publicips.MockClientMockRecorder) {
+				s.JumpboxVMSpec().Return(fakeVMSpec())
+				s.JumpboxNICSpec().Return(&fakeNICSpec)
+				nic.CreateResource(gomockinternal.AContext(), &fakeNICSpec, serviceName).Return(nil, nil)
+				vm.CreateResource(gomockinternal.AContext(), gomock.AssignableToTypeOf(&VMSpec{}), serviceName).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.JumpboxReadyCondition, serviceName, internalError)
+			},
+		},
+		{
+			name:          "unknown VM size",
+			expectedError: "failed to get VM SKU Standard_Unknown in compute api: reconcile error that cannot be recovered occurred: resource sku with name 'Standard_Unknown' and category 'virtualMachines' not found in location 'westus'. Object will not be requeued",
+			expect: func(s *mock_jumpbox.MockJumpboxScopeMockRecorder, nic *mock_async.MockReconcilerMockRecorder, vm *mock_async.MockReconcilerMockRecorder, pip *mock_publicips.MockClientMockRecorder) {
+				spec := fakeVMSpec()
+				spec.Size = "Standard_Unknown"
+				s.JumpboxVMSpec().Return(spec)
+				s.UpdatePutStatus(infrav1.JumpboxReadyCondition, serviceName, gomockinternal.ErrStrEq("failed to get VM SKU Standard_Unknown in compute api: reconcile error that cannot be recovered occurred: resource sku with name 'Standard_Unknown' and category 'virtualMachines' not found in location 'westus'. Object will not be requeued"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_jumpbox.NewMockJumpboxScope(mockCtrl)
+			nicMock := mock_async.NewMockReconciler(mockCtrl)
+			vmMock := mock_async.NewMockReconciler(mockCtrl)
+			publicIPsMock := mock_publicips.NewMockClient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), nicMock.EXPECT(), vmMock.EXPECT(), publicIPsMock.EXPECT())
+
+			s := &Service{
+				Scope:            scopeMock,
+				nicReconciler:    nicMock,
+				vmReconciler:     vmMock,
+				publicIPsClient:  publicIPsMock,
+				resourceSKUCache: resourceskus.NewStaticCache([]compute.ResourceSku{fakeSKU}, "westus"),
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteJumpbox(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_jumpbox.MockJumpboxScopeMockRecorder, nic *mock_async.MockReconcilerMockRecorder, vm *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "no jumpbox configured",
+			expectedError: "",
+			expect: func(s *mock_jumpbox.MockJumpboxScopeMockRecorder, nic *mock_async.MockReconcilerMockRecorder, vm *mock_async.MockReconcilerMockRecorder) {
+				s.JumpboxVMSpec().Return(nil)
+			},
+		},
+		{
+			name:          "jumpbox successfully deleted",
+			expectedError: "",
+			expect: func(s *mock_jumpbox.MockJumpboxScopeMockRecorder, nic *mock_async.MockReconcilerMockRecorder, vm *mock_async.MockReconcilerMockRecorder) {
+				s.JumpboxVMSpec().Return(fakeVMSpec())
+				vm.DeleteResource(gomockinternal.AContext(), &VMSpec{VMSpec: *fakeVMSpec()}, serviceName).Return(nil)
+				s.JumpboxNICSpec().Return(&fakeNICSpec)
+				nic.DeleteResource(gomockinternal.AContext(), &fakeNICSpec, serviceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.JumpboxReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "virtual machine deletion fails",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_jumpbox.MockJumpboxScopeMockRecorder, nic *mock_async.MockReconcilerMockRecorder, vm *mock_async.MockReconcilerMockRecorder) {
+				s.JumpboxVMSpec().Return(fakeVMSpec())
+				vm.DeleteResource(gomockinternal.AContext(), &VMSpec{VMSpec: *fakeVMSpec()}, serviceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.JumpboxReadyCondition, serviceName, internalError)
+			},
+		},
+		{
+			name:          "network interface deletion fails",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_jumpbox.MockJumpboxScopeMockRecorder, nic *mock_async.MockReconcilerMockRecorder, vm *mock_async.MockReconcilerMockRecorder) {
+				s.JumpboxVMSpec().Return(fakeVMSpec())
+				vm.DeleteResource(gomockinternal.AContext(), &VMSpec{VMSpec: *fakeVMSpec()}, serviceName).Return(nil)
+				s.JumpboxNICSpec().Return(&fakeNICSpec)
+				nic.DeleteResource(gomockinternal.AContext(), &fakeNICSpec, serviceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.JumpboxReadyCondition, serviceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_jumpbox.NewMockJumpboxScope(mockCtrl)
+			nicMock := mock_async.NewMockReconciler(mockCtrl)
+			vmMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), nicMock.EXPECT(), vmMock.EXPECT())
+
+			s := &Service{
+				Scope:         scopeMock,
+				nicReconciler: nicMock,
+				vmReconciler:  vmMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
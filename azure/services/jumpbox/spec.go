@@ -0,0 +1,49 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jumpbox
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-04-01/compute"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachines"
+)
+
+// VMSpec defines the specification for the jumpbox virtual machine. It extends the machine VMSpec so that the OS disk
+// and the network interface are deleted together with the virtual machine.
+type VMSpec struct {
+	virtualmachines.VMSpec
+}
+
+// Parameters returns the parameters for the jumpbox virtual machine.
+func (s *VMSpec) Parameters(existing interface{}) (params interface{}, err error) {
+	params, err = s.VMSpec.Parameters(existing)
+	if err != nil || params == nil {
+		return params, err
+	}
+
+	vm, ok := params.(compute.VirtualMachine)
+	if !ok {
+		return nil, errors.Errorf("%T is not a compute.VirtualMachine", params)
+	}
+
+	vm.StorageProfile.OsDisk.DeleteOption = compute.DiskDeleteOptionTypesDelete
+	for i := range *vm.NetworkProfile.NetworkInterfaces {
+		(*vm.NetworkProfile.NetworkInterfaces)[i].DeleteOption = compute.DeleteOptionsDelete
+	}
+
+	return vm, nil
+}
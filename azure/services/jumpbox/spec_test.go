@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jumpbox
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-04-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachines"
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name     string
+		spec     *VMSpec
+		existing interface{}
+		expect   func(g *WithT, result interface{})
+	}{
+		{
+			name: "jumpbox already exists",
+			spec: &VMSpec{VMSpec: virtualmachines.VMSpec{Name: "my-jumpbox"}},
+			existing: compute.VirtualMachine{
+				Name: to.StringPtr("my-jumpbox"),
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "OS disk and network interface are deleted with the jumpbox",
+			spec: &VMSpec{
+				VMSpec: virtualmachines.VMSpec{
+					Name:          "my-jumpbox",
+					ResourceGroup: "my-rg",
+					Location:      "westus",
+					ClusterName:   "my-cluster",
+					Role:          infrav1.Bastion,
+					NICIDs:        []string{"my-jumpbox-nic-id"},
+					SSHKeyData:    "c3NoLXJzYSBBQUFB",
+					Size:          "Standard_B2s",
+					OSDisk: infrav1.OSDisk{
+						OSType:     "Linux",
+						DiskSizeGB: to.Int32Ptr(30),
+					},
+					Image: &infrav1.Image{ID: to.StringPtr("my-image")},
+					SKU:   resourceskus.SKU(fakeSKU),
+				},
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				vm := result.(compute.VirtualMachine)
+				g.Expect(vm.StorageProfile.OsDisk.DeleteOption).To(Equal(compute.DiskDeleteOptionTypesDelete))
+				g.Expect(*vm.NetworkProfile.NetworkInterfaces).To(HaveLen(1))
+				g.Expect((*vm.NetworkProfile.NetworkInterfaces)[0].DeleteOption).To(Equal(compute.DeleteOptionsDelete))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			g.Expect(err).NotTo(HaveOccurred())
+			tc.expect(g, result)
+		})
+	}
+}
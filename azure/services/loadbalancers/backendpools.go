@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-04-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// reconcileScaleSetBackendPool attaches the scale sets of the cluster to the backend pool of the node outbound load
+// balancer. The virtual machines of an AzureMachine join the pool through their network interface, which remains the
+// default, while the instances of a scale set join it through the primary IP configuration of the scale set model: a
+// scale set created before the pool was configured is attached to it here. The update isn't waited for, and a scale
+// set with an operation in progress is attached on a later reconcile.
+func (s *Service) reconcileScaleSetBackendPool(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "loadbalancers.Service.reconcileScaleSetBackendPool")
+	defer done()
+
+	lbSpec, ok := spec.(*LBSpec)
+	if !ok || lbSpec.Role != infrav1.NodeOutboundRole || lbSpec.BackendPoolName == "" {
+		return nil
+	}
+
+	scaleSets, err := s.ListScaleSets(ctx, lbSpec.ResourceGroup)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the scale sets of resource group %s", lbSpec.ResourceGroup)
+	}
+
+	poolID := azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, lbSpec.BackendPoolName)
+	for _, vmss := range scaleSets {
+		if !converters.MapToTags(vmss.Tags).HasOwned(lbSpec.ClusterName) {
+			continue
+		}
+		if vmss.VirtualMachineScaleSetProperties == nil || to.String(vmss.ProvisioningState) != string(compute.ProvisioningState1Succeeded) {
+			log.V(4).Info("skipping scale set with an operation in progress", "scale set", to.String(vmss.Name))
+			continue
+		}
+		networkProfile, err := backendPoolNetworkProfile(vmss, poolID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to compute the network profile of scale set %s", to.String(vmss.Name))
+		} else if networkProfile == nil {
+			continue
+		}
+
+		log.V(2).Info("attaching scale set to the backend pool of the load balancer", "scale set", to.String(vmss.Name), "load balancer", lbSpec.Name, "backend pool", lbSpec.BackendPoolName)
+		update := compute.VirtualMachineScaleSetUpdate{
+			VirtualMachineScaleSetUpdateProperties: &compute.VirtualMachineScaleSetUpdateProperties{
+				VirtualMachineProfile: &compute.VirtualMachineScaleSetUpdateVMProfile{
+					NetworkProfile: networkProfile,
+				},
+			},
+		}
+		if err := s.UpdateScaleSetAsync(ctx, lbSpec.ResourceGroup, to.String(vmss.Name), update); err != nil {
+			return errors.Wrapf(err, "failed to attach scale set %s to backend pool %s of load balancer %s", to.String(vmss.Name), lbSpec.BackendPoolName, lbSpec.Name)
+		}
+	}
+
+	return nil
+}
+
+// backendPoolNetworkProfile returns the network profile of the scale set with its primary IP configuration attached to
+// the backend pool, keeping the pools it is already attached to, e.g. by the cloud provider. It returns nil if the
+// scale set is already attached to the pool or has no primary IP configuration. The scale set is left untouched: the
+// network profile is a copy.
+func backendPoolNetworkProfile(vmss compute.VirtualMachineScaleSet, poolID string) (*compute.VirtualMachineScaleSetUpdateNetworkProfile, error) {
+	if vmss.VirtualMachineScaleSetProperties == nil || vmss.VirtualMachineProfile == nil || vmss.VirtualMachineProfile.NetworkProfile == nil {
+		return nil, nil
+	}
+
+	jsonData, err := json.Marshal(vmss.VirtualMachineProfile.NetworkProfile)
+	if err != nil {
+		return nil, err
+	}
+	var profile compute.VirtualMachineScaleSetUpdateNetworkProfile
+	if err := json.Unmarshal(jsonData, &profile); err != nil {
+		return nil, err
+	}
+
+	ipConfig := primaryIPConfiguration(profile)
+	if ipConfig == nil {
+		return nil, nil
+	}
+	var pools []compute.SubResource
+	if ipConfig.LoadBalancerBackendAddressPools != nil {
+		pools = *ipConfig.LoadBalancerBackendAddressPools
+	}
+	for _, pool := range pools {
+		if strings.EqualFold(to.String(pool.ID), poolID) {
+			return nil, nil
+		}
+	}
+	pools = append(pools, compute.SubResource{ID: to.StringPtr(poolID)})
+	ipConfig.LoadBalancerBackendAddressPools = &pools
+
+	return &profile, nil
+}
+
+// primaryIPConfiguration returns the primary IP configuration of the primary network interface configuration of the
+// network profile, or nil if there is none.
+func primaryIPConfiguration(profile compute.VirtualMachineScaleSetUpdateNetworkProfile) *compute.VirtualMachineScaleSetUpdateIPConfigurationProperties {
+	if profile.NetworkInterfaceConfigurations == nil {
+		return nil
+	}
+	for _, nicConfig := range *profile.NetworkInterfaceConfigurations {
+		if nicConfig.VirtualMachineScaleSetUpdateNetworkConfigurationProperties == nil || !to.Bool(nicConfig.Primary) || nicConfig.IPConfigurations == nil {
+			continue
+		}
+		for _, ipConfig := range *nicConfig.IPConfigurations {
+			if ipConfig.VirtualMachineScaleSetUpdateIPConfigurationProperties != nil && to.Bool(ipConfig.Primary) {
+				return ipConfig.VirtualMachineScaleSetUpdateIPConfigurationProperties
+			}
+		}
+	}
+	return nil
+}
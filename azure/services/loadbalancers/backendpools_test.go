@@ -0,0 +1,196 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-04-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers/mock_loadbalancers"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+const (
+	fakeOutboundPoolID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster/backendAddressPools/my-cluster-outboundBackendPool"
+	fakeKubernetesPool = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/kubernetes/backendAddressPools/kubernetes"
+)
+
+// newFakeScaleSet returns a scale set of the cluster whose primary IP configuration is attached to the given pools.
+func newFakeScaleSet(name string, owned bool, provisioningState string, pools ...string) compute.VirtualMachineScaleSet {
+	tags := map[string]*string{}
+	if owned {
+		tags["sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster"] = to.StringPtr("owned")
+	}
+	backendPools := make([]compute.SubResource, len(pools))
+	for i, pool := range pools {
+		backendPools[i] = compute.SubResource{ID: to.StringPtr(pool)}
+	}
+	return compute.VirtualMachineScaleSet{
+		Name: to.StringPtr(name),
+		Tags: tags,
+		VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{
+			ProvisioningState: to.StringPtr(provisioningState),
+			VirtualMachineProfile: &compute.VirtualMachineScaleSetVMProfile{
+				NetworkProfile: &compute.VirtualMachineScaleSetNetworkProfile{
+					NetworkInterfaceConfigurations: &[]compute.VirtualMachineScaleSetNetworkConfiguration{
+						{
+							Name: to.StringPtr(name + "-netconfig"),
+							VirtualMachineScaleSetNetworkConfigurationProperties: &compute.VirtualMachineScaleSetNetworkConfigurationProperties{
+								Primary: to.BoolPtr(true),
+								IPConfigurations: &[]compute.VirtualMachineScaleSetIPConfiguration{
+									{
+										Name: to.StringPtr(name + "-ipconfig"),
+										VirtualMachineScaleSetIPConfigurationProperties: &compute.VirtualMachineScaleSetIPConfigurationProperties{
+											Primary:                         to.BoolPtr(true),
+											LoadBalancerBackendAddressPools: &backendPools,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// attachedPools returns the backend pools of the primary IP configuration of a scale set update.
+func attachedPools(update compute.VirtualMachineScaleSetUpdate) []string {
+	ipConfig := primaryIPConfiguration(*update.VirtualMachineProfile.NetworkProfile)
+	var pools []string
+	for _, pool := range *ipConfig.LoadBalancerBackendAddressPools {
+		pools = append(pools, to.String(pool.ID))
+	}
+	return pools
+}
+
+func TestReconcileScaleSetBackendPool(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          LBSpec
+		expectedError string
+		expect        func(m *mock_loadbalancers.MockScaleSetClientMockRecorder)
+	}{
+		{
+			name: "scale set created before the backend pool is attached to it, keeping the pools of the cloud provider",
+			spec: fakeNodeOutboundLBSpec,
+			expect: func(m *mock_loadbalancers.MockScaleSetClientMockRecorder) {
+				m.ListScaleSets(gomockinternal.AContext(), "my-rg").Return([]compute.VirtualMachineScaleSet{
+					newFakeScaleSet("my-vmss", true, string(compute.ProvisioningState1Succeeded), fakeKubernetesPool),
+				}, nil)
+				m.UpdateScaleSetAsync(gomockinternal.AContext(), "my-rg", "my-vmss", gomock.Any()).DoAndReturn(
+					func(_ context.Context, _, _ string, update compute.VirtualMachineScaleSetUpdate) error {
+						NewWithT(t).Expect(attachedPools(update)).To(Equal([]string{fakeKubernetesPool, fakeOutboundPoolID}))
+						return nil
+					})
+			},
+		},
+		{
+			name: "scale set already attached to the backend pool is left untouched",
+			spec: fakeNodeOutboundLBSpec,
+			expect: func(m *mock_loadbalancers.MockScaleSetClientMockRecorder) {
+				m.ListScaleSets(gomockinternal.AContext(), "my-rg").Return([]compute.VirtualMachineScaleSet{
+					newFakeScaleSet("my-vmss", true, string(compute.ProvisioningState1Succeeded), strings.ToUpper(fakeOutboundPoolID)),
+				}, nil)
+			},
+		},
+		{
+			name: "scale sets not owned by the cluster or being updated are skipped",
+			spec: fakeNodeOutboundLBSpec,
+			expect: func(m *mock_loadbalancers.MockScaleSetClientMockRecorder) {
+				m.ListScaleSets(gomockinternal.AContext(), "my-rg").Return([]compute.VirtualMachineScaleSet{
+					newFakeScaleSet("other-vmss", false, string(compute.ProvisioningState1Succeeded)),
+					newFakeScaleSet("updating-vmss", true, string(compute.ProvisioningState1Updating)),
+					{Name: to.StringPtr("empty-vmss"), Tags: map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned")}},
+				}, nil)
+			},
+		},
+		{
+			name:   "machines join the backend pools of other load balancers through their network interface",
+			spec:   fakePublicAPILBSpec,
+			expect: func(m *mock_loadbalancers.MockScaleSetClientMockRecorder) {},
+		},
+		{
+			name:          "fail to list the scale sets",
+			spec:          fakeNodeOutboundLBSpec,
+			expectedError: "failed to list the scale sets of resource group my-rg: #: Internal Server Error: StatusCode=500",
+			expect: func(m *mock_loadbalancers.MockScaleSetClientMockRecorder) {
+				m.ListScaleSets(gomockinternal.AContext(), "my-rg").Return(nil, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scaleSetMock := mock_loadbalancers.NewMockScaleSetClient(mockCtrl)
+			tc.expect(scaleSetMock.EXPECT())
+
+			s := &Service{
+				Scope:          mock_loadbalancers.NewMockLBScope(mockCtrl),
+				Reconciler:     mock_async.NewMockReconciler(mockCtrl),
+				ScaleSetClient: scaleSetMock,
+			}
+			err := s.reconcileScaleSetBackendPool(context.TODO(), &tc.spec)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestBackendPoolNetworkProfile(t *testing.T) {
+	g := NewWithT(t)
+
+	vmss := newFakeScaleSet("my-vmss", true, string(compute.ProvisioningState1Succeeded), fakeKubernetesPool)
+	profile, err := backendPoolNetworkProfile(vmss, fakeOutboundPoolID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(attachedPools(compute.VirtualMachineScaleSetUpdate{
+		VirtualMachineScaleSetUpdateProperties: &compute.VirtualMachineScaleSetUpdateProperties{
+			VirtualMachineProfile: &compute.VirtualMachineScaleSetUpdateVMProfile{NetworkProfile: profile},
+		},
+	})).To(Equal([]string{fakeKubernetesPool, fakeOutboundPoolID}))
+
+	// The scale set itself is not modified.
+	ipConfigs := *(*vmss.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations)[0].IPConfigurations
+	g.Expect(*ipConfigs[0].LoadBalancerBackendAddressPools).To(HaveLen(1))
+
+	// A scale set without a network profile, or without a primary IP configuration, is not attached.
+	profile, err = backendPoolNetworkProfile(compute.VirtualMachineScaleSet{VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{}}, fakeOutboundPoolID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(profile).To(BeNil())
+	noPrimary := newFakeScaleSet("my-vmss", true, string(compute.ProvisioningState1Succeeded))
+	(*(*noPrimary.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations)[0].IPConfigurations)[0].Primary = nil
+	profile, err = backendPoolNetworkProfile(noPrimary, fakeOutboundPoolID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(profile).To(BeNil())
+}
@@ -20,7 +20,9 @@ import (
 	"context"
 	"encoding/json"
 
-	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-04-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-03-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/resourcehealth/mgmt/2017-07-01/resourcehealth"
 	"github.com/Azure/go-autorest/autorest"
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/pkg/errors"
@@ -32,13 +34,49 @@ import (
 
 // azureClient contains the Azure go-sdk Client.
 type azureClient struct {
-	loadbalancers network.LoadBalancersClient
+	loadbalancers        network.LoadBalancersClient
+	virtualnetworks      network.VirtualNetworksClient
+	availabilityStatuses resourcehealth.AvailabilityStatusesClient
+	publicips            network.PublicIPAddressesClient
+	scalesets            compute.VirtualMachineScaleSetsClient
 }
 
 // newClient creates a new load balancer client from subscription ID.
 func newClient(auth azure.Authorizer) *azureClient {
 	c := newLoadBalancersClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
-	return &azureClient{c}
+	v := newVirtualNetworksClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+	a := newAvailabilityStatusesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+	p := newPublicIPAddressesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+	ss := newVirtualMachineScaleSetsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+	return &azureClient{c, v, a, p, ss}
+}
+
+// newVirtualMachineScaleSetsClient creates a new scale sets client from subscription ID.
+func newVirtualMachineScaleSetsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.VirtualMachineScaleSetsClient {
+	scaleSetsClient := compute.NewVirtualMachineScaleSetsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&scaleSetsClient.Client, authorizer)
+	return scaleSetsClient
+}
+
+// newPublicIPAddressesClient creates a new public IP addresses client from subscription ID.
+func newPublicIPAddressesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) network.PublicIPAddressesClient {
+	publicIPsClient := network.NewPublicIPAddressesClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&publicIPsClient.Client, authorizer)
+	return publicIPsClient
+}
+
+// newAvailabilityStatusesClient creates a new availability statuses client from subscription ID.
+func newAvailabilityStatusesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) resourcehealth.AvailabilityStatusesClient {
+	availabilityStatusesClient := resourcehealth.NewAvailabilityStatusesClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&availabilityStatusesClient.Client, authorizer)
+	return availabilityStatusesClient
+}
+
+// newVirtualNetworksClient creates a new virtual networks client from subscription ID.
+func newVirtualNetworksClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) network.VirtualNetworksClient {
+	virtualNetworksClient := network.NewVirtualNetworksClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&virtualNetworksClient.Client, authorizer)
+	return virtualNetworksClient
 }
 
 // newLoadbalancersClient creates a new load balancer client from subscription ID.
@@ -56,6 +94,58 @@ func (ac *azureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (
 	return ac.loadbalancers.Get(ctx, spec.ResourceGroupName(), spec.ResourceName(), "")
 }
 
+// CheckIPAddressAvailability checks whether a private IP address is available in a virtual network.
+func (ac *azureClient) CheckIPAddressAvailability(ctx context.Context, resourceGroup, vnetName, ipAddress string) (network.IPAddressAvailabilityResult, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "loadbalancers.azureClient.CheckIPAddressAvailability")
+	defer done()
+
+	return ac.virtualnetworks.CheckIPAddressAvailability(ctx, resourceGroup, vnetName, ipAddress)
+}
+
+// GetAvailabilityStatus gets the current availability status Azure Resource Health reports for a load balancer.
+func (ac *azureClient) GetAvailabilityStatus(ctx context.Context, resourceID string) (resourcehealth.AvailabilityStatus, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "loadbalancers.azureClient.GetAvailabilityStatus")
+	defer done()
+
+	return ac.availabilityStatuses.GetByResource(ctx, resourceID, "", "")
+}
+
+// GetPublicIP gets the specified public IP address.
+func (ac *azureClient) GetPublicIP(ctx context.Context, resourceGroup, name string) (network.PublicIPAddress, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "loadbalancers.azureClient.GetPublicIP")
+	defer done()
+
+	return ac.publicips.Get(ctx, resourceGroup, name, "")
+}
+
+// ListScaleSets lists the scale sets of a resource group.
+func (ac *azureClient) ListScaleSets(ctx context.Context, resourceGroup string) ([]compute.VirtualMachineScaleSet, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "loadbalancers.azureClient.ListScaleSets")
+	defer done()
+
+	itr, err := ac.scalesets.ListComplete(ctx, resourceGroup)
+	if err != nil {
+		return nil, err
+	}
+	var scaleSets []compute.VirtualMachineScaleSet
+	for ; itr.NotDone(); err = itr.NextWithContext(ctx) {
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to iterate scale sets")
+		}
+		scaleSets = append(scaleSets, itr.Value())
+	}
+	return scaleSets, nil
+}
+
+// UpdateScaleSetAsync sends an update of a scale set model to Azure, without waiting for the update to complete.
+func (ac *azureClient) UpdateScaleSetAsync(ctx context.Context, resourceGroup, name string, update compute.VirtualMachineScaleSetUpdate) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "loadbalancers.azureClient.UpdateScaleSetAsync")
+	defer done()
+
+	_, err := ac.scalesets.Update(ctx, resourceGroup, name, update)
+	return err
+}
+
 // CreateOrUpdateAsync creates or updates a load balancer asynchronously.
 // It sends a PUT request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
 // progress of the operation.
@@ -175,3 +265,118 @@ func (ac *azureClient) Result(ctx context.Context, future azureautorest.FutureAP
 		return nil, errors.Errorf("unknown future type %q", futureType)
 	}
 }
+
+// publicIPsClient contains the Azure go-sdk Client for the public IPs of the frontends of load balancers.
+type publicIPsClient struct {
+	publicips network.PublicIPAddressesClient
+}
+
+// newPublicIPsClient creates a new public IPs client from subscription ID.
+func newPublicIPsClient(auth azure.Authorizer) *publicIPsClient {
+	return &publicIPsClient{newPublicIPAddressesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())}
+}
+
+// Get gets the specified public IP address.
+func (pc *publicIPsClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "loadbalancers.publicIPsClient.Get")
+	defer done()
+
+	return pc.publicips.Get(ctx, spec.ResourceGroupName(), spec.ResourceName(), "")
+}
+
+// CreateOrUpdateAsync creates or updates a public IP address asynchronously.
+func (pc *publicIPsClient) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "loadbalancers.publicIPsClient.CreateOrUpdateAsync")
+	defer done()
+
+	publicIP, ok := parameters.(network.PublicIPAddress)
+	if !ok {
+		return nil, nil, errors.Errorf("%T is not a network.PublicIPAddress", parameters)
+	}
+
+	createFuture, err := pc.publicips.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.ResourceName(), publicIP)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+	defer cancel()
+
+	err = createFuture.WaitForCompletionRef(ctx, pc.publicips.Client)
+	if err != nil {
+		// if an error occurs, return the future.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return nil, &createFuture, err
+	}
+
+	result, err = createFuture.Result(pc.publicips)
+	// if the operation completed, return a nil future
+	return result, nil, err
+}
+
+// DeleteAsync deletes a public IP address asynchronously.
+func (pc *publicIPsClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "loadbalancers.publicIPsClient.DeleteAsync")
+	defer done()
+
+	deleteFuture, err := pc.publicips.Delete(ctx, spec.ResourceGroupName(), spec.ResourceName())
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+	defer cancel()
+
+	err = deleteFuture.WaitForCompletionRef(ctx, pc.publicips.Client)
+	if err != nil {
+		// if an error occurs, return the future.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return &deleteFuture, err
+	}
+	_, err = deleteFuture.Result(pc.publicips)
+	// if the operation completed, return a nil future.
+	return nil, err
+}
+
+// IsDone returns true if the long-running operation has completed.
+func (pc *publicIPsClient) IsDone(ctx context.Context, future azureautorest.FutureAPI) (isDone bool, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "loadbalancers.publicIPsClient.IsDone")
+	defer done()
+
+	isDone, err = future.DoneWithContext(ctx, pc.publicips)
+	if err != nil {
+		return false, errors.Wrap(err, "failed checking if the operation was complete")
+	}
+
+	return isDone, nil
+}
+
+// Result fetches the result of a long-running operation future.
+func (pc *publicIPsClient) Result(ctx context.Context, future azureautorest.FutureAPI, futureType string) (result interface{}, err error) {
+	_, _, done := tele.StartSpanWithLogger(ctx, "loadbalancers.publicIPsClient.Result")
+	defer done()
+
+	if future == nil {
+		return nil, errors.Errorf("cannot get result from nil future")
+	}
+
+	switch futureType {
+	case infrav1.PutFuture:
+		var createFuture *network.PublicIPAddressesCreateOrUpdateFuture
+		jsonData, err := future.MarshalJSON()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal future")
+		}
+		if err := json.Unmarshal(jsonData, &createFuture); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal future data")
+		}
+		return createFuture.Result(pc.publicips)
+
+	case infrav1.DeleteFuture:
+		// Delete does not return a result public IP address
+		return nil, nil
+
+	default:
+		return nil, errors.Errorf("unknown future type %q", futureType)
+	}
+}
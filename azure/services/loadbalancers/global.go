@@ -0,0 +1,213 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-03-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// frontendIPConfigIDRegexp matches the resource ID of the frontend IP configuration of a load balancer.
+var frontendIPConfigIDRegexp = regexp.MustCompile(`(?i)^/subscriptions/([^/]+)/resourceGroups/([^/]+)/providers/Microsoft.Network/loadBalancers/([^/]+)/frontendIPConfigurations/([^/]+)$`)
+
+// GlobalLBSpec defines the specification for a cross-region load balancer, whose backends are the frontends of
+// regional load balancers.
+type GlobalLBSpec struct {
+	Name            string
+	ResourceGroup   string
+	SubscriptionID  string
+	ClusterName     string
+	Location        string
+	FrontendName    string
+	PublicIPName    string
+	BackendPoolName string
+	// Backends are the resource IDs of the frontend IP configurations of the regional load balancers.
+	Backends       []string
+	APIServerPort  int32
+	AdditionalTags map[string]string
+}
+
+// frontendIPConfig is a frontend IP configuration of a load balancer, parsed from its resource ID.
+type frontendIPConfig struct {
+	SubscriptionID   string
+	ResourceGroup    string
+	LoadBalancerName string
+	Name             string
+}
+
+// parseFrontendIPConfigID parses the resource ID of the frontend IP configuration of a load balancer.
+func parseFrontendIPConfigID(id string) (frontendIPConfig, error) {
+	matches := frontendIPConfigIDRegexp.FindStringSubmatch(id)
+	if matches == nil {
+		return frontendIPConfig{}, errors.Errorf("%s is not the resource ID of the frontend IP configuration of a load balancer", id)
+	}
+	return frontendIPConfig{
+		SubscriptionID:   matches[1],
+		ResourceGroup:    matches[2],
+		LoadBalancerName: matches[3],
+		Name:             matches[4],
+	}, nil
+}
+
+// ResourceName returns the name of the cross-region load balancer.
+func (s *GlobalLBSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *GlobalLBSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for load balancers.
+func (s *GlobalLBSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the cross-region load balancer.
+func (s *GlobalLBSpec) Parameters(existing interface{}) (parameters interface{}, err error) {
+	backendAddresses, err := s.backendAddresses()
+	if err != nil {
+		return nil, err
+	}
+
+	var etag *string
+	if existing != nil {
+		existingLB, ok := existing.(network.LoadBalancer)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.LoadBalancer", existing)
+		}
+		if s.isUpToDate(existingLB) {
+			// load balancer already exists with all the backends
+			return nil, nil
+		}
+		// We append the existing LB etag to the header to ensure we only apply the updates if the LB has not been modified.
+		etag = existingLB.Etag
+	}
+
+	frontendID := azure.FrontendIPConfigID(s.SubscriptionID, s.ResourceGroup, s.Name, s.FrontendName)
+	backendPoolID := azure.AddressPoolID(s.SubscriptionID, s.ResourceGroup, s.Name, s.BackendPoolName)
+
+	// Cross-region load balancers don't support health probes: a regional load balancer is removed from the rotation
+	// when the health probes of its own backends fail.
+	return network.LoadBalancer{
+		Etag: etag,
+		Sku: &network.LoadBalancerSku{
+			Name: network.LoadBalancerSkuNameStandard,
+			Tier: network.LoadBalancerSkuTierGlobal,
+		},
+		Location: to.StringPtr(s.Location),
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Role:        to.StringPtr(infrav1.APIServerRole),
+			Additional:  s.AdditionalTags,
+		})),
+		LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: &[]network.FrontendIPConfiguration{
+				{
+					Name: to.StringPtr(s.FrontendName),
+					FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+						PublicIPAddress: &network.PublicIPAddress{
+							ID: to.StringPtr(azure.PublicIPID(s.SubscriptionID, s.ResourceGroup, s.PublicIPName)),
+						},
+					},
+				},
+			},
+			BackendAddressPools: &[]network.BackendAddressPool{
+				{
+					Name: to.StringPtr(s.BackendPoolName),
+					BackendAddressPoolPropertiesFormat: &network.BackendAddressPoolPropertiesFormat{
+						LoadBalancerBackendAddresses: &backendAddresses,
+					},
+				},
+			},
+			LoadBalancingRules: &[]network.LoadBalancingRule{
+				{
+					Name: to.StringPtr(lbRuleHTTPS),
+					LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+						Protocol:                network.TransportProtocolTCP,
+						FrontendPort:            to.Int32Ptr(s.APIServerPort),
+						BackendPort:             to.Int32Ptr(s.APIServerPort),
+						EnableFloatingIP:        to.BoolPtr(false),
+						LoadDistribution:        network.LoadDistributionDefault,
+						FrontendIPConfiguration: &network.SubResource{ID: to.StringPtr(frontendID)},
+						BackendAddressPool:      &network.SubResource{ID: to.StringPtr(backendPoolID)},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// backendAddresses returns the backend addresses of the backend pool, one for each regional frontend.
+func (s *GlobalLBSpec) backendAddresses() ([]network.LoadBalancerBackendAddress, error) {
+	addresses := make([]network.LoadBalancerBackendAddress, 0, len(s.Backends))
+	for _, backend := range s.Backends {
+		frontend, err := parseFrontendIPConfigID(backend)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, network.LoadBalancerBackendAddress{
+			Name: to.StringPtr(fmt.Sprintf("%s-%s", frontend.LoadBalancerName, frontend.Name)),
+			LoadBalancerBackendAddressPropertiesFormat: &network.LoadBalancerBackendAddressPropertiesFormat{
+				LoadBalancerFrontendIPConfiguration: &network.SubResource{ID: to.StringPtr(backend)},
+			},
+		})
+	}
+	return addresses, nil
+}
+
+// isUpToDate returns true if the existing cross-region load balancer has the API server rule and exactly the wanted
+// backends.
+func (s *GlobalLBSpec) isUpToDate(existing network.LoadBalancer) bool {
+	props := existing.LoadBalancerPropertiesFormat
+	if props == nil || props.LoadBalancingRules == nil || !lbRuleExists(*props.LoadBalancingRules, network.LoadBalancingRule{Name: to.StringPtr(lbRuleHTTPS)}) {
+		return false
+	}
+
+	var existingBackends []string
+	if props.BackendAddressPools != nil {
+		for _, pool := range *props.BackendAddressPools {
+			if to.String(pool.Name) != s.BackendPoolName || pool.BackendAddressPoolPropertiesFormat == nil || pool.LoadBalancerBackendAddresses == nil {
+				continue
+			}
+			for _, address := range *pool.LoadBalancerBackendAddresses {
+				if address.LoadBalancerBackendAddressPropertiesFormat != nil && address.LoadBalancerFrontendIPConfiguration != nil {
+					existingBackends = append(existingBackends, strings.ToLower(to.String(address.LoadBalancerFrontendIPConfiguration.ID)))
+				}
+			}
+		}
+	}
+
+	wantedBackends := make([]string, 0, len(s.Backends))
+	for _, backend := range s.Backends {
+		wantedBackends = append(wantedBackends, strings.ToLower(backend))
+	}
+	sort.Strings(existingBackends)
+	sort.Strings(wantedBackends)
+	return strings.Join(existingBackends, ",") == strings.Join(wantedBackends, ",")
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-03-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+)
+
+func TestGlobalLBParameters(t *testing.T) {
+	extraBackendLBSpec := fakeGlobalLBSpec
+	extraBackendLBSpec.Backends = append([]string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/another-lb/frontendIPConfigurations/another-lb-frontEnd"}, fakeGlobalLBSpec.Backends...)
+
+	invalidBackendLBSpec := fakeGlobalLBSpec
+	invalidBackendLBSpec.Backends = []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-publicip"}
+
+	testcases := []struct {
+		name          string
+		spec          *GlobalLBSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "cross-region load balancer does not exist",
+			spec:     &fakeGlobalLBSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(newSampleGlobalLB(nil)))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "cross-region load balancer exists with all the backends",
+			spec:     &fakeGlobalLBSpec,
+			existing: newSampleGlobalLB(to.StringPtr("fake-etag")),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "cross-region load balancer exists with a missing backend",
+			spec:     &extraBackendLBSpec,
+			existing: newSampleGlobalLB(to.StringPtr("fake-etag")),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				lb := result.(network.LoadBalancer)
+				g.Expect(lb.Etag).To(Equal(to.StringPtr("fake-etag")))
+				g.Expect(*(*lb.BackendAddressPools)[0].LoadBalancerBackendAddresses).To(HaveLen(3))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "backend is not a load balancer frontend",
+			spec:     &invalidBackendLBSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-publicip is not the resource ID of the frontend IP configuration of a load balancer",
+		},
+		{
+			name:     "existing is not a load balancer",
+			spec:     &fakeGlobalLBSpec,
+			existing: "foo",
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "string is not a network.LoadBalancer",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
+
+func newSampleGlobalLB(etag *string) network.LoadBalancer {
+	return network.LoadBalancer{
+		Etag: etag,
+		Sku: &network.LoadBalancerSku{
+			Name: network.LoadBalancerSkuNameStandard,
+			Tier: network.LoadBalancerSkuTierGlobal,
+		},
+		Location: to.StringPtr("eastus2"),
+		Tags: map[string]*string{
+			"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+			"sigs.k8s.io_cluster-api-provider-azure_role":               to.StringPtr("apiserver"),
+		},
+		LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: &[]network.FrontendIPConfiguration{
+				{
+					Name: to.StringPtr("my-global-lb-frontEnd"),
+					FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+						PublicIPAddress: &network.PublicIPAddress{
+							ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-global-publicip"),
+						},
+					},
+				},
+			},
+			BackendAddressPools: &[]network.BackendAddressPool{
+				{
+					Name: to.StringPtr("my-global-lb-backendPool"),
+					BackendAddressPoolPropertiesFormat: &network.BackendAddressPoolPropertiesFormat{
+						LoadBalancerBackendAddresses: &[]network.LoadBalancerBackendAddress{
+							{
+								Name: to.StringPtr("my-publiclb-my-publiclb-frontEnd"),
+								LoadBalancerBackendAddressPropertiesFormat: &network.LoadBalancerBackendAddressPropertiesFormat{
+									LoadBalancerFrontendIPConfiguration: &network.SubResource{
+										ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/frontendIPConfigurations/my-publiclb-frontEnd"),
+									},
+								},
+							},
+							{
+								Name: to.StringPtr("other-lb-other-lb-frontEnd"),
+								LoadBalancerBackendAddressPropertiesFormat: &network.LoadBalancerBackendAddressPropertiesFormat{
+									LoadBalancerFrontendIPConfiguration: &network.SubResource{
+										ID: to.StringPtr("/subscriptions/456/resourceGroups/other-rg/providers/Microsoft.Network/loadBalancers/other-lb/frontendIPConfigurations/other-lb-frontEnd"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			LoadBalancingRules: &[]network.LoadBalancingRule{
+				{
+					Name: to.StringPtr(lbRuleHTTPS),
+					LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+						Protocol:         network.TransportProtocolTCP,
+						FrontendPort:     to.Int32Ptr(6443),
+						BackendPort:      to.Int32Ptr(6443),
+						EnableFloatingIP: to.BoolPtr(false),
+						LoadDistribution: network.LoadDistributionDefault,
+						FrontendIPConfiguration: &network.SubResource{
+							ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-global-lb/frontendIPConfigurations/my-global-lb-frontEnd"),
+						},
+						BackendAddressPool: &network.SubResource{
+							ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-global-lb/backendAddressPools/my-global-lb-backendPool"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
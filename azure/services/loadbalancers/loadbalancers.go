@@ -18,19 +18,34 @@ package loadbalancers
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-04-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-03-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/resourcehealth/mgmt/2017-07-01/resourcehealth"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 const (
-	serviceName = "loadbalancers"
-	tcpProbe    = "TCPProbe"
-	lbRuleHTTPS = "LBRuleHTTPS"
-	outboundNAT = "OutboundNATAllProtocols"
+	serviceName   = "loadbalancers"
+	tcpProbe      = "TCPProbe"
+	httpsProbe    = "HTTPSProbe"
+	serviceProbe  = "ServiceProbe"
+	lbRuleHTTPS   = "LBRuleHTTPS"
+	lbRuleHAPorts = "LBRuleHAPorts"
+	outboundNAT   = "OutboundNATAllProtocols"
+	natRuleSSH    = "NATRuleSSH"
+	// publicIPServiceName is the service name of the public IPs of the Basic SKU deleted to recreate a load balancer.
+	publicIPServiceName = "publicips"
 )
 
 // LBScope defines the scope interface for a load balancer service.
@@ -38,20 +53,64 @@ type LBScope interface {
 	azure.ClusterScoper
 	azure.AsyncStatusUpdater
 	LBSpecs() []azure.ResourceSpecGetter
+	GlobalLBSpec() azure.ResourceSpecGetter
+	StaleLBSpecs() []azure.ResourceSpecGetter
+	SetLoadBalancerTier(name string, tier infrav1.LoadBalancerTier)
+	SetGlobalLBBackendHealth(health map[string]infrav1.BackendHealthState)
+	SetGlobalLBBackendsHealthy()
+	SetGlobalLBBackendsNotHealthy(reason string, severity clusterv1.ConditionSeverity, messageFormat string, messageArgs ...interface{})
+}
+
+// IPAddressChecker checks whether private IP addresses of a virtual network are available.
+type IPAddressChecker interface {
+	CheckIPAddressAvailability(ctx context.Context, resourceGroup, vnetName, ipAddress string) (network.IPAddressAvailabilityResult, error)
+}
+
+// HealthGetter gets the health Azure Resource Health reports for the regional load balancers behind a cross-region
+// load balancer.
+type HealthGetter interface {
+	GetAvailabilityStatus(ctx context.Context, resourceID string) (resourcehealth.AvailabilityStatus, error)
+}
+
+// PublicIPClient gets the public IPs of the frontends of a load balancer, to recreate those Azure can't update in
+// place.
+type PublicIPClient interface {
+	GetPublicIP(ctx context.Context, resourceGroup, name string) (network.PublicIPAddress, error)
+}
+
+// ScaleSetClient lists the scale sets of the cluster and updates their model, to attach the instances of the scale
+// sets to the backend pool of a load balancer.
+type ScaleSetClient interface {
+	ListScaleSets(ctx context.Context, resourceGroup string) ([]compute.VirtualMachineScaleSet, error)
+	UpdateScaleSetAsync(ctx context.Context, resourceGroup, name string, update compute.VirtualMachineScaleSetUpdate) error
 }
 
 // Service provides operations on Azure resources.
 type Service struct {
 	Scope LBScope
 	async.Reconciler
+	async.Getter
+	IPAddressChecker
+	HealthGetter
+	PublicIPClient
+	ScaleSetClient
+	// publicIPs deletes the public IPs of the Basic SKU of the frontends of a load balancer being recreated.
+	publicIPs async.Reconciler
 }
 
 // New creates a new service.
 func New(scope LBScope) *Service {
 	client := newClient(scope)
+	publicIPsClient := newPublicIPsClient(scope)
 	return &Service{
-		Scope:      scope,
-		Reconciler: async.New(scope, client, client),
+		Scope:            scope,
+		Reconciler:       async.New(scope, client, client),
+		Getter:           client,
+		IPAddressChecker: client,
+		HealthGetter:     client,
+		PublicIPClient:   client,
+		ScaleSetClient:   client,
+		publicIPs:        async.New(scope, publicIPsClient, publicIPsClient),
 	}
 }
 
@@ -68,17 +127,517 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
 	var result error
 	for _, lbSpec := range s.Scope.LBSpecs() {
-		if _, err := s.CreateResource(ctx, lbSpec, serviceName); err != nil {
+		err := s.validate(ctx, lbSpec)
+		if err == nil {
+			var lb interface{}
+			lb, err = s.CreateResource(ctx, lbSpec, serviceName)
+			s.setLoadBalancerTier(lbSpec, lb)
+		}
+		if err == nil {
+			err = s.reconcileScaleSetBackendPool(ctx, lbSpec)
+		}
+		if err != nil {
 			if !azure.IsOperationNotDoneError(err) || result == nil {
 				result = err
 			}
 		}
 	}
+	if err := s.deleteStaleLBs(ctx); err != nil {
+		if !azure.IsOperationNotDoneError(err) || result == nil {
+			result = err
+		}
+	}
 
 	s.Scope.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, result)
+	if result != nil {
+		return result
+	}
+
+	return s.reconcileGlobalLB(ctx)
+}
+
+// validate checks that a load balancer can be created or updated from its spec, recreating it first if it must change
+// SKU. The existing load balancer is fetched once for all the validations, only if one of them needs it.
+func (s *Service) validate(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "loadbalancers.Service.validate")
+	defer done()
+
+	lbSpec, ok := spec.(*LBSpec)
+	if !ok {
+		return nil
+	}
+
+	var existing *network.LoadBalancer
+	if lbSpec.Shared != nil || lbSpec.ClusterUID != "" || lbSpec.ForceRecreate || lbSpec.Type == infrav1.Internal {
+		result, err := s.Get(ctx, lbSpec)
+		if err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrapf(err, "failed to get load balancer %s", lbSpec.Name)
+		}
+		if err == nil {
+			lb, ok := result.(network.LoadBalancer)
+			if !ok {
+				return errors.Errorf("%T is not a network.LoadBalancer", result)
+			}
+			existing = &lb
+		}
+	}
+
+	if err := s.validateClusterUID(ctx, lbSpec, existing); err != nil {
+		return err
+	}
+	if err := s.recreateBasicSKU(ctx, lbSpec, existing); err != nil {
+		return err
+	}
+	if err := s.validatePrivateIPAddresses(ctx, lbSpec, existing); err != nil {
+		return err
+	}
+	return s.validateSharedLB(ctx, lbSpec, existing)
+}
+
+// validateClusterUID checks that an existing load balancer of the same name belongs to this cluster rather than to a
+// previous cluster of the same name, which can be left behind in a resource group that isn't deleted with its cluster.
+// A load balancer tagged with another UID is reported as a conflict rather than silently reused, and is never deleted.
+// The UID a cluster tags its resources with is kept in its status when it is moved or restored from a backup; if the
+// status is lost, the load balancer tagged with the previous UID of the cluster is adopted, and tagged with the
+// current UID when reconciled.
+func (s *Service) validateClusterUID(ctx context.Context, lbSpec *LBSpec, lb *network.LoadBalancer) error {
+	_, log, done := tele.StartSpanWithLogger(ctx, "loadbalancers.Service.validateClusterUID")
+	defer done()
+
+	if lb == nil || lbSpec.Shared != nil || lbSpec.ClusterUID == "" {
+		return nil
+	}
+
+	uid := otherClusterUID(*lb, lbSpec)
+	if uid == "" {
+		if lbSpec.PreviousClusterUID != "" && to.String(lb.Tags[infrav1.ClusterUIDTagKey()]) == lbSpec.PreviousClusterUID {
+			log.Info("adopting load balancer of the previous UID of the cluster", "load balancer", lbSpec.Name, "previous cluster UID", lbSpec.PreviousClusterUID)
+		}
+		return nil
+	}
+
+	return azure.WithTerminalError(errors.Errorf("load balancer %s belongs to another cluster with UID %s, it can't be used by cluster %s with UID %s: "+
+		"delete the load balancer if its cluster was deleted, or annotate the Cluster with %s=%s if the cluster was moved or restored",
+		lbSpec.Name, uid, lbSpec.ClusterName, lbSpec.ClusterUID, azure.PreviousClusterUIDAnnotation, uid))
+}
+
+// otherClusterUID returns the UID of the other cluster an existing load balancer is tagged with, or an empty string if
+// it belongs to the cluster of the spec. A load balancer without the tag was created before the tag existed, it is
+// tagged when reconciled. The previous UID of the cluster only belongs to it for a load balancer it owns.
+func otherClusterUID(lb network.LoadBalancer, lbSpec *LBSpec) string {
+	tags := converters.MapToTags(lb.Tags)
+	uid := tags[infrav1.ClusterUIDTagKey()]
+	switch {
+	case uid == "", uid == lbSpec.ClusterUID:
+		return ""
+	case uid == lbSpec.PreviousClusterUID && tags.HasOwned(lbSpec.ClusterName):
+		return ""
+	default:
+		return uid
+	}
+}
+
+// deleteStaleLBs deletes the load balancers of the cluster that were removed from the spec, e.g. the internal load
+// balancer of the internal frontend of the API server load balancer once the internal frontend is removed. A load
+// balancer whose backend pools still hold network interfaces can't be deleted: it is deleted on a later reconcile, once
+// the machines using it are replaced.
+func (s *Service) deleteStaleLBs(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "loadbalancers.Service.deleteStaleLBs")
+	defer done()
+
+	var result error
+	for _, lbSpec := range s.Scope.StaleLBSpecs() {
+		existing, err := s.Get(ctx, lbSpec)
+		if azure.ResourceNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Wrapf(err, "failed to get load balancer %s", lbSpec.ResourceName())
+		}
+		lb, ok := existing.(network.LoadBalancer)
+		if !ok {
+			return errors.Errorf("%T is not a network.LoadBalancer", existing)
+		}
+		if !converters.MapToTags(lb.Tags).HasOwned(s.Scope.ClusterName()) {
+			continue
+		}
+		if backendPoolsInUse(lb) {
+			log.Info("skipping deletion of stale load balancer until its backend pools are drained", "load balancer", lbSpec.ResourceName())
+			continue
+		}
+
+		log.Info("deleting stale load balancer removed from the spec", "load balancer", lbSpec.ResourceName())
+		if err := s.DeleteResource(ctx, lbSpec, serviceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || result == nil {
+				result = err
+			}
+		}
+	}
 	return result
 }
 
+// deleteLB deletes a load balancer of the cluster, unless a load balancer of the same name tagged with the UID of
+// another cluster exists: it is left in place for the cluster it belongs to, as Reconcile refuses to adopt it.
+func (s *Service) deleteLB(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "loadbalancers.Service.deleteLB")
+	defer done()
+
+	if lbSpec, ok := spec.(*LBSpec); ok && lbSpec.ClusterUID != "" {
+		existing, err := s.Get(ctx, lbSpec)
+		if err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrapf(err, "failed to get load balancer %s", lbSpec.Name)
+		}
+		if lb, ok := existing.(network.LoadBalancer); ok {
+			if uid := otherClusterUID(lb, lbSpec); uid != "" {
+				log.Info("skipping deletion of load balancer belonging to another cluster", "load balancer", lbSpec.Name, "cluster UID", uid)
+				return nil
+			}
+		}
+	}
+	return s.DeleteResource(ctx, spec, serviceName)
+}
+
+// backendPoolsInUse returns true if a backend pool of the load balancer holds IP configurations of network interfaces.
+func backendPoolsInUse(lb network.LoadBalancer) bool {
+	if lb.LoadBalancerPropertiesFormat == nil || lb.BackendAddressPools == nil {
+		return false
+	}
+	for _, pool := range *lb.BackendAddressPools {
+		if pool.BackendAddressPoolPropertiesFormat != nil && pool.BackendIPConfigurations != nil && len(*pool.BackendIPConfigurations) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// recreateBasicSKU deletes an existing load balancer of the Basic SKU, and the public IPs of the Basic SKU of its
+// frontends, when the load balancer is to be recreated with the Standard SKU: Azure can't upgrade them in place. It
+// returns a transient error to requeue once they are deleted, the public IPs are then recreated before the load
+// balancer. Without ForceRecreate nothing is deleted, and the SKU mismatch is reported when the load balancer is
+// updated.
+func (s *Service) recreateBasicSKU(ctx context.Context, lbSpec *LBSpec, lb *network.LoadBalancer) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "loadbalancers.Service.recreateBasicSKU")
+	defer done()
+
+	if !lbSpec.ForceRecreate || lbSpec.Shared != nil {
+		return nil
+	}
+
+	basicLB := lb != nil && lb.Sku != nil && lb.Sku.Name == network.LoadBalancerSkuNameBasic
+
+	var basicIPNames []string
+	for _, frontend := range lbSpec.FrontendIPConfigs {
+		if frontend.PublicIP == nil {
+			continue
+		}
+		ip, err := s.GetPublicIP(ctx, lbSpec.ResourceGroup, frontend.PublicIP.Name)
+		if azure.ResourceNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Wrapf(err, "failed to get public IP %s of load balancer %s", frontend.PublicIP.Name, lbSpec.Name)
+		}
+		if ip.Sku == nil || ip.Sku.Name != network.PublicIPAddressSkuNameBasic {
+			continue
+		}
+		// Bring-your-own public IPs are never deleted, nor is the load balancer which couldn't be recreated with them.
+		if !converters.MapToTags(ip.Tags).HasOwned(lbSpec.ClusterName) {
+			return azure.WithTerminalError(errors.Errorf("public IP %s of load balancer %s has the Basic SKU and isn't owned by the cluster, so it can't be recreated with the %s SKU: migrate it manually", frontend.PublicIP.Name, lbSpec.Name, lbSpec.SKU))
+		}
+		basicIPNames = append(basicIPNames, frontend.PublicIP.Name)
+	}
+	if !basicLB && len(basicIPNames) == 0 {
+		return nil
+	}
+
+	// The public IPs can only be deleted once the load balancer doesn't use them anymore.
+	if basicLB {
+		log.Info("deleting load balancer of the Basic SKU to recreate it with the Standard SKU", "load balancer", lbSpec.Name)
+		if err := s.DeleteResource(ctx, lbSpec, serviceName); err != nil {
+			return errors.Wrapf(err, "failed to delete load balancer %s of the Basic SKU", lbSpec.Name)
+		}
+	}
+	for _, ipName := range basicIPNames {
+		log.Info("deleting public IP of the Basic SKU to recreate it with the Standard SKU", "load balancer", lbSpec.Name, "public ip", ipName)
+		if err := s.publicIPs.DeleteResource(ctx, &publicIPSpec{Name: ipName, ResourceGroup: lbSpec.ResourceGroup}, publicIPServiceName); err != nil {
+			return errors.Wrapf(err, "failed to delete public IP %s of the Basic SKU of load balancer %s", ipName, lbSpec.Name)
+		}
+	}
+
+	return azure.WithTransientError(errors.Errorf("load balancer %s is being recreated with the %s SKU", lbSpec.Name, lbSpec.SKU), reconciler.DefaultReconcilerRequeue)
+}
+
+// skuChangeError returns the error reporting that an existing load balancer has another SKU than its spec, with how to
+// migrate it: Azure can't change the SKU of a load balancer, nor of its public IPs, in place.
+func skuChangeError(lbSpec LBSpec, existingSKU string) error {
+	switch {
+	case lbSpec.Shared != nil:
+		return errors.Errorf("shared load balancer %s has the %s SKU instead of %s, it must be migrated to the %s SKU by its owner", lbSpec.Name, existingSKU, lbSpec.SKU, lbSpec.SKU)
+	case lbSpec.Role == infrav1.InternalServiceRole:
+		return errors.Errorf("load balancer %s has the %s SKU instead of %s, which Azure can't change in place: delete the load balancer for it to be recreated with the %s SKU", lbSpec.Name, existingSKU, lbSpec.SKU, lbSpec.SKU)
+	default:
+		return errors.Errorf("load balancer %s has the %s SKU instead of %s, which Azure can't change in place: set forceRecreate on the load balancer for it to be deleted and recreated with the %s SKU along with the public IPs of its frontends, which get new addresses, or migrate them manually", lbSpec.Name, existingSKU, lbSpec.SKU, lbSpec.SKU)
+	}
+}
+
+// validatePrivateIPAddresses checks that the static private IPs of the frontends of an internal load balancer are
+// available in its virtual network, unless the load balancer already holds them, so that a conflict is reported
+// clearly rather than by a failed update of the load balancer.
+func (s *Service) validatePrivateIPAddresses(ctx context.Context, lbSpec *LBSpec, lb *network.LoadBalancer) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "loadbalancers.Service.validatePrivateIPAddresses")
+	defer done()
+
+	if lbSpec.Type != infrav1.Internal {
+		return nil
+	}
+
+	held := make(map[string]struct{})
+	if lb != nil && lb.LoadBalancerPropertiesFormat != nil && lb.FrontendIPConfigurations != nil {
+		for _, frontend := range *lb.FrontendIPConfigurations {
+			if frontend.FrontendIPConfigurationPropertiesFormat != nil && frontend.PrivateIPAddress != nil {
+				held[*frontend.PrivateIPAddress] = struct{}{}
+			}
+		}
+	}
+
+	for _, frontend := range lbSpec.FrontendIPConfigs {
+		if frontend.PrivateIPAddress == "" {
+			continue
+		}
+		if _, ok := held[frontend.PrivateIPAddress]; ok {
+			log.V(4).Info("private IP is already held by the load balancer", "load balancer", lbSpec.Name, "private IP", frontend.PrivateIPAddress)
+			continue
+		}
+		availability, err := s.CheckIPAddressAvailability(ctx, lbSpec.VNetResourceGroup, lbSpec.VNetName, frontend.PrivateIPAddress)
+		if err != nil {
+			return errors.Wrapf(err, "failed to check the availability of private IP %s in virtual network %s", frontend.PrivateIPAddress, lbSpec.VNetName)
+		}
+		if availability.Available != nil && !*availability.Available {
+			msg := fmt.Sprintf("private IP %s of frontend %s of load balancer %s is already in use in virtual network %s", frontend.PrivateIPAddress, frontend.Name, lbSpec.Name, lbSpec.VNetName)
+			if availability.AvailableIPAddresses != nil && len(*availability.AvailableIPAddresses) != 0 {
+				msg += fmt.Sprintf(", available private IPs include %s", strings.Join(*availability.AvailableIPAddresses, ", "))
+			}
+			return azure.WithTerminalError(errors.New(msg))
+		}
+	}
+
+	return nil
+}
+
+// validateSharedLB checks that a shared load balancer exists with the frontend of the cluster, and that the frontend
+// port of the cluster isn't already used on this frontend by the rules of the other clusters sharing it.
+func (s *Service) validateSharedLB(ctx context.Context, lbSpec *LBSpec, lb *network.LoadBalancer) error {
+	_, _, done := tele.StartSpanWithLogger(ctx, "loadbalancers.Service.validateSharedLB")
+	defer done()
+
+	if lbSpec.Shared == nil {
+		return nil
+	}
+	if lb == nil {
+		return azure.WithTerminalError(errors.Errorf("shared load balancer %s not found in resource group %s, it must be created before the cluster", lbSpec.Name, lbSpec.ResourceGroup))
+	}
+
+	_, frontendIDs := getFrontendIPConfigs(*lbSpec)
+	for i, frontendID := range frontendIDs {
+		if !frontendExists(*lb, to.String(frontendID.ID)) {
+			return azure.WithTerminalError(errors.Errorf("frontend %s not found in shared load balancer %s", lbSpec.FrontendIPConfigs[i].Name, lbSpec.Name))
+		}
+	}
+
+	if lb.LoadBalancerPropertiesFormat == nil || lb.LoadBalancingRules == nil {
+		return nil
+	}
+	port := frontendPort(*lbSpec)
+	for _, rule := range *lb.LoadBalancingRules {
+		if rule.LoadBalancingRulePropertiesFormat == nil || rule.FrontendIPConfiguration == nil || to.String(rule.Name) == clusterResourceName(*lbSpec, lbRuleHTTPS) {
+			continue
+		}
+		if rule.Protocol == network.TransportProtocolUDP || to.Int32(rule.FrontendPort) != port {
+			continue
+		}
+		for _, frontendID := range frontendIDs {
+			if strings.EqualFold(to.String(rule.FrontendIPConfiguration.ID), to.String(frontendID.ID)) {
+				return azure.WithTerminalError(errors.Errorf("frontend port %d of shared load balancer %s is already used by load balancing rule %s", port, lbSpec.Name, to.String(rule.Name)))
+			}
+		}
+	}
+
+	return nil
+}
+
+// frontendExists returns true if the load balancer has a frontend with the given ID.
+func frontendExists(lb network.LoadBalancer, id string) bool {
+	if lb.LoadBalancerPropertiesFormat == nil || lb.FrontendIPConfigurations == nil {
+		return false
+	}
+	for _, frontend := range *lb.FrontendIPConfigurations {
+		if strings.EqualFold(to.String(frontend.ID), id) {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcileGlobalLB creates or updates the cross-region load balancer of the cluster, once the regional load balancers
+// it fronts are ready. The load balancer is only created when it is configured: it's opt-in.
+func (s *Service) reconcileGlobalLB(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "loadbalancers.Service.reconcileGlobalLB")
+	defer done()
+
+	globalLBSpec := s.Scope.GlobalLBSpec()
+	if globalLBSpec == nil {
+		log.V(4).Info("skipping cross-region load balancer reconcile, no cross-region load balancer is configured")
+		return nil
+	}
+
+	err := s.validateGlobalLBBackends(ctx, globalLBSpec)
+	if err == nil {
+		var result interface{}
+		result, err = s.CreateResource(ctx, globalLBSpec, serviceName)
+		s.setLoadBalancerTier(globalLBSpec, result)
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.GlobalLoadBalancerReadyCondition, serviceName, err)
+	if err != nil {
+		return err
+	}
+
+	s.reconcileGlobalLBBackendHealth(ctx, globalLBSpec)
+	return nil
+}
+
+// reconcileGlobalLBBackendHealth aggregates the health Azure Resource Health reports for the regional load balancers
+// behind the cross-region load balancer in the GlobalLoadBalancerBackendsHealthy condition. The cross-region load
+// balancer only sends traffic to the regions whose load balancers are available, as their health probes tell, so the
+// global frontend silently drops the traffic when none is. Failures to query Resource Health are not errors: the
+// health of the region is reported as unknown.
+func (s *Service) reconcileGlobalLBBackendHealth(ctx context.Context, spec azure.ResourceSpecGetter) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "loadbalancers.Service.reconcileGlobalLBBackendHealth")
+	defer done()
+
+	globalLBSpec, ok := spec.(*GlobalLBSpec)
+	if !ok {
+		return
+	}
+
+	health := make(map[string]infrav1.BackendHealthState, len(globalLBSpec.Backends))
+	var unhealthy []string
+	for _, backend := range globalLBSpec.Backends {
+		frontend, err := parseFrontendIPConfigID(backend)
+		if err != nil {
+			health[backend] = infrav1.BackendHealthUnknown
+			continue
+		}
+		lbID := azure.LoadBalancerID(frontend.SubscriptionID, frontend.ResourceGroup, frontend.LoadBalancerName)
+		status, err := s.GetAvailabilityStatus(ctx, lbID)
+		if err != nil {
+			log.V(2).Info("failed to get the health of cross-region load balancer backend", "backend", backend, "error", err.Error())
+			health[backend] = infrav1.BackendHealthUnknown
+			continue
+		}
+		health[backend] = backendHealthState(status)
+		if health[backend] == infrav1.BackendUnhealthy {
+			log.V(2).Info("cross-region load balancer backend is unhealthy", "backend", backend, "summary", to.String(status.Properties.Summary))
+			unhealthy = append(unhealthy, backend)
+		}
+	}
+	s.Scope.SetGlobalLBBackendHealth(health)
+
+	switch {
+	case len(unhealthy) > 0 && len(unhealthy) == len(globalLBSpec.Backends):
+		s.Scope.SetGlobalLBBackendsNotHealthy(infrav1.AllBackendsUnhealthyReason, clusterv1.ConditionSeverityError,
+			"all the regional backends of cross-region load balancer %s are unhealthy, its frontend has no region to send traffic to: %s", globalLBSpec.Name, strings.Join(unhealthy, ", "))
+	case len(unhealthy) > 0:
+		s.Scope.SetGlobalLBBackendsNotHealthy(infrav1.UnhealthyBackendsReason, clusterv1.ConditionSeverityWarning,
+			"%d of the %d regional backends of cross-region load balancer %s are unhealthy: %s", len(unhealthy), len(globalLBSpec.Backends), globalLBSpec.Name, strings.Join(unhealthy, ", "))
+	default:
+		s.Scope.SetGlobalLBBackendsHealthy()
+	}
+}
+
+// backendHealthState returns the health of a regional load balancer from the availability status Azure Resource Health
+// reports for it.
+func backendHealthState(status resourcehealth.AvailabilityStatus) infrav1.BackendHealthState {
+	if status.Properties == nil {
+		return infrav1.BackendHealthUnknown
+	}
+	switch status.Properties.AvailabilityState {
+	case resourcehealth.Available:
+		return infrav1.BackendHealthy
+	case resourcehealth.Unavailable:
+		return infrav1.BackendUnhealthy
+	default:
+		return infrav1.BackendHealthUnknown
+	}
+}
+
+// setLoadBalancerTier records the tier Azure reports for a load balancer that was created or is up to date.
+func (s *Service) setLoadBalancerTier(spec azure.ResourceSpecGetter, result interface{}) {
+	lb, ok := result.(network.LoadBalancer)
+	if !ok || lb.Sku == nil || lb.Sku.Tier == "" {
+		return
+	}
+	s.Scope.SetLoadBalancerTier(spec.ResourceName(), infrav1.LoadBalancerTier(lb.Sku.Tier))
+}
+
+// validateGlobalLBBackends checks that the backends of the cross-region load balancer in the subscription of the
+// cluster are frontends of Standard regional load balancers, the only ones Azure supports as backends, with a health
+// probe on their API server rule. The backends in other subscriptions can't be read with the credentials of the
+// cluster and are left for Azure to validate.
+func (s *Service) validateGlobalLBBackends(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "loadbalancers.Service.validateGlobalLBBackends")
+	defer done()
+
+	globalLBSpec, ok := spec.(*GlobalLBSpec)
+	if !ok {
+		return errors.Errorf("%T is not a loadbalancers.GlobalLBSpec", spec)
+	}
+
+	for _, backend := range globalLBSpec.Backends {
+		frontend, err := parseFrontendIPConfigID(backend)
+		if err != nil {
+			return azure.WithTerminalError(err)
+		}
+		if !strings.EqualFold(frontend.SubscriptionID, s.Scope.SubscriptionID()) {
+			log.V(4).Info("skipping validation of cross-region load balancer backend in another subscription", "backend", backend)
+			continue
+		}
+
+		existing, err := s.Get(ctx, &LBSpec{Name: frontend.LoadBalancerName, ResourceGroup: frontend.ResourceGroup})
+		if err != nil {
+			return errors.Wrapf(err, "failed to get load balancer %s of cross-region load balancer backend %s", frontend.LoadBalancerName, backend)
+		}
+		lb, ok := existing.(network.LoadBalancer)
+		if !ok {
+			return errors.Errorf("%T is not a network.LoadBalancer", existing)
+		}
+		if lb.Sku == nil || lb.Sku.Name != network.LoadBalancerSkuNameStandard || lb.Sku.Tier == network.LoadBalancerSkuTierGlobal {
+			return azure.WithTerminalError(errors.Errorf("backend %s of cross-region load balancer %s is not the frontend of a Standard regional load balancer", backend, globalLBSpec.Name))
+		}
+		if !hasProbedRule(lb, backend, globalLBSpec.APIServerPort) {
+			return azure.WithTerminalError(errors.Errorf("backend %s of cross-region load balancer %s has no load balancing rule with a health probe for port %d, "+
+				"the cross-region load balancer relies on it to take the region out of rotation when it is unhealthy", backend, globalLBSpec.Name, globalLBSpec.APIServerPort))
+		}
+	}
+
+	return nil
+}
+
+// hasProbedRule returns true if the load balancer has a load balancing rule with a health probe for the port on the
+// frontend with the given ID, which is what the cross-region load balancer derives the health of the frontend from.
+func hasProbedRule(lb network.LoadBalancer, frontendID string, port int32) bool {
+	if lb.LoadBalancerPropertiesFormat == nil || lb.LoadBalancingRules == nil {
+		return false
+	}
+	for _, rule := range *lb.LoadBalancingRules {
+		if rule.LoadBalancingRulePropertiesFormat == nil || rule.FrontendIPConfiguration == nil || rule.Probe == nil {
+			continue
+		}
+		if strings.EqualFold(to.String(rule.FrontendIPConfiguration.ID), frontendID) && to.Int32(rule.FrontendPort) == port {
+			return true
+		}
+	}
+	return false
+}
+
 // Delete deletes the public load balancer with the provided name.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "loadbalancers.Service.Delete")
@@ -87,18 +646,39 @@ func (s *Service) Delete(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
 	defer cancel()
 
+	// The cross-region load balancer references the frontends of the regional load balancers, it is deleted first.
+	if globalLBSpec := s.Scope.GlobalLBSpec(); globalLBSpec != nil {
+		err := s.DeleteResource(ctx, globalLBSpec, serviceName)
+		s.Scope.UpdateDeleteStatus(infrav1.GlobalLoadBalancerReadyCondition, serviceName, err)
+		if err != nil {
+			return err
+		}
+	}
+
 	var result error
 
 	// We go through the list of LBSpecs to delete each one, independently of the result of the previous one.
 	// If multiple errors occur, we return the most pressing one.
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
 	for _, lbSpec := range s.Scope.LBSpecs() {
-		if err := s.DeleteResource(ctx, lbSpec, serviceName); err != nil {
+		var err error
+		// A shared load balancer is never deleted, only the configuration of the cluster is removed from it.
+		if spec, ok := lbSpec.(*LBSpec); ok && spec.Shared != nil {
+			_, err = s.CreateResource(ctx, &sharedLBCleanupSpec{LBSpec: spec}, serviceName)
+		} else {
+			err = s.deleteLB(ctx, lbSpec)
+		}
+		if err != nil {
 			if !azure.IsOperationNotDoneError(err) || result == nil {
 				result = err
 			}
 		}
 	}
+	if err := s.deleteStaleLBs(ctx); err != nil {
+		if !azure.IsOperationNotDoneError(err) || result == nil {
+			result = err
+		}
+	}
 	s.Scope.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, result)
 	return result
 }
@@ -21,6 +21,8 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-03-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/resourcehealth/mgmt/2017-07-01/resourcehealth"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/golang/mock/gomock"
@@ -30,6 +32,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers/mock_loadbalancers"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 var (
@@ -66,6 +69,8 @@ var (
 		Role:                 infrav1.APIServerRole,
 		Type:                 infrav1.Internal,
 		SKU:                  infrav1.SKUStandard,
+		VNetName:             "my-vnet",
+		VNetResourceGroup:    "my-vnet-rg",
 		SubnetName:           "my-cp-subnet",
 		BackendPoolName:      "my-private-lb-backendPool",
 		IdleTimeoutInMinutes: to.Int32Ptr(4),
@@ -80,6 +85,30 @@ var (
 		APIServerPort: 6443,
 	}
 
+	fakeSharedAPILBSpec = LBSpec{
+		Name:                 "shared-lb",
+		ResourceGroup:        "my-rg",
+		SubscriptionID:       "123",
+		ClusterName:          "my-cluster",
+		Location:             "my-location",
+		Role:                 infrav1.APIServerRole,
+		Type:                 infrav1.Public,
+		SKU:                  infrav1.SKUStandard,
+		BackendPoolName:      "my-cluster-backendPool",
+		IdleTimeoutInMinutes: to.Int32Ptr(4),
+		FrontendIPConfigs: []infrav1.FrontendIP{
+			{
+				Name: "shared-lb-frontEnd",
+				PublicIP: &infrav1.PublicIPSpec{
+					Name:    "shared-ip",
+					DNSName: "shared.mydomain.com",
+				},
+			},
+		},
+		APIServerPort: 6443,
+		Shared:        &infrav1.SharedLoadBalancer{FrontendPort: 6444},
+	}
+
 	fakeNodeOutboundLBSpec = LBSpec{
 		Name:                 "my-cluster",
 		ResourceGroup:        "my-rg",
@@ -101,19 +130,90 @@ var (
 		},
 	}
 
+	fakeGlobalLBSpec = GlobalLBSpec{
+		Name:            "my-global-lb",
+		ResourceGroup:   "my-rg",
+		SubscriptionID:  "123",
+		ClusterName:     "my-cluster",
+		Location:        "eastus2",
+		FrontendName:    "my-global-lb-frontEnd",
+		PublicIPName:    "my-global-publicip",
+		BackendPoolName: "my-global-lb-backendPool",
+		Backends: []string{
+			"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/frontendIPConfigurations/my-publiclb-frontEnd",
+			"/subscriptions/456/resourceGroups/other-rg/providers/Microsoft.Network/loadBalancers/other-lb/frontendIPConfigurations/other-lb-frontEnd",
+		},
+		APIServerPort: 6443,
+	}
+
+	fakeRegionalLB = network.LoadBalancer{
+		Name: to.StringPtr("my-publiclb"),
+		Sku: &network.LoadBalancerSku{
+			Name: network.LoadBalancerSkuNameStandard,
+			Tier: network.LoadBalancerSkuTierRegional,
+		},
+		LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+			LoadBalancingRules: &[]network.LoadBalancingRule{
+				{
+					Name: to.StringPtr(lbRuleHTTPS),
+					LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+						FrontendPort: to.Int32Ptr(6443),
+						FrontendIPConfiguration: &network.SubResource{
+							ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/frontendIPConfigurations/my-publiclb-frontEnd"),
+						},
+						Probe: &network.SubResource{
+							ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/probes/TCPProbe"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	fakeUnprobedRegionalLB = network.LoadBalancer{
+		Name: to.StringPtr("my-publiclb"),
+		Sku: &network.LoadBalancerSku{
+			Name: network.LoadBalancerSkuNameStandard,
+			Tier: network.LoadBalancerSkuTierRegional,
+		},
+	}
+
+	publicLBID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb"
+	otherLBID  = "/subscriptions/456/resourceGroups/other-rg/providers/Microsoft.Network/loadBalancers/other-lb"
+
+	fakeInternalLB = network.LoadBalancer{
+		Name: to.StringPtr("my-private-lb"),
+		LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: &[]network.FrontendIPConfiguration{
+				{
+					Name: to.StringPtr("my-private-lb-frontEnd"),
+					FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+						PrivateIPAddress: to.StringPtr("10.0.0.10"),
+					},
+				},
+			},
+		},
+	}
+
+	fakeBasicLB = network.LoadBalancer{
+		Name: to.StringPtr("my-publiclb"),
+		Sku:  &network.LoadBalancerSku{Name: network.LoadBalancerSkuNameBasic},
+	}
+
 	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error")
+	notFoundError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found")
 )
 
 func TestReconcileLoadBalancer(t *testing.T) {
 	testcases := []struct {
 		name          string
 		expectedError string
-		expect        func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expect        func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder)
 	}{
 		{
 			name:          "fail to create a public LB",
 			expectedError: "#: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
 				r.CreateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil, internalError)
 				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, internalError)
@@ -122,39 +222,290 @@ func TestReconcileLoadBalancer(t *testing.T) {
 		{
 			name:          "create public apiserver LB",
 			expectedError: "",
-			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
 				r.CreateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil, nil)
 				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.GlobalLBSpec().Return(nil)
+			},
+		},
+		{
+			name:          "record the tier of the public apiserver LB",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
+				r.CreateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(network.LoadBalancer{
+					Sku: &network.LoadBalancerSku{Name: network.LoadBalancerSkuNameStandard, Tier: network.LoadBalancerSkuTierRegional},
+				}, nil)
+				s.SetLoadBalancerTier(fakePublicAPILBSpec.Name, infrav1.LoadBalancerTierRegional)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.GlobalLBSpec().Return(nil)
 			},
 		},
 		{
 			name:          "create internal apiserver LB",
 			expectedError: "",
-			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakeInternalAPILBSpec})
+				m.Get(gomockinternal.AContext(), &fakeInternalAPILBSpec).Return(nil, notFoundError)
+				c.CheckIPAddressAvailability(gomockinternal.AContext(), "my-vnet-rg", "my-vnet", "10.0.0.10").Return(network.IPAddressAvailabilityResult{Available: to.BoolPtr(true)}, nil)
+				r.CreateResource(gomockinternal.AContext(), &fakeInternalAPILBSpec, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.GlobalLBSpec().Return(nil)
+			},
+		},
+		{
+			name:          "update internal apiserver LB holding its private IP",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakeInternalAPILBSpec})
+				m.Get(gomockinternal.AContext(), &fakeInternalAPILBSpec).Return(fakeInternalLB, nil)
 				r.CreateResource(gomockinternal.AContext(), &fakeInternalAPILBSpec, serviceName).Return(nil, nil)
 				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.GlobalLBSpec().Return(nil)
+			},
+		},
+		{
+			name:          "get the existing LB once for all the validations",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
+				spec := fakeInternalAPILBSpec
+				spec.ClusterUID = "uid-2"
+				spec.ForceRecreate = true
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&spec})
+				m.Get(gomockinternal.AContext(), &spec).Return(fakeInternalLB, nil).Times(1)
+				r.CreateResource(gomockinternal.AContext(), &spec, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.GlobalLBSpec().Return(nil)
+			},
+		},
+		{
+			name:          "fail to create internal apiserver LB with a private IP in use",
+			expectedError: "reconcile error that cannot be recovered occurred: private IP 10.0.0.10 of frontend my-private-lb-frontEnd of load balancer my-private-lb is already in use in virtual network my-vnet, available private IPs include 10.0.0.11, 10.0.0.12. Object will not be requeued",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakeInternalAPILBSpec})
+				m.Get(gomockinternal.AContext(), &fakeInternalAPILBSpec).Return(nil, notFoundError)
+				c.CheckIPAddressAvailability(gomockinternal.AContext(), "my-vnet-rg", "my-vnet", "10.0.0.10").Return(network.IPAddressAvailabilityResult{
+					Available:            to.BoolPtr(false),
+					AvailableIPAddresses: &[]string{"10.0.0.11", "10.0.0.12"},
+				}, nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, gomock.Any())
+			},
+		},
+		{
+			name:          "add the cluster to a shared apiserver LB",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakeSharedAPILBSpec})
+				m.Get(gomockinternal.AContext(), &fakeSharedAPILBSpec).Return(newSharedLB(false), nil)
+				r.CreateResource(gomockinternal.AContext(), &fakeSharedAPILBSpec, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.GlobalLBSpec().Return(nil)
+			},
+		},
+		{
+			name:          "fail to add the cluster to a shared apiserver LB that doesn't exist",
+			expectedError: "reconcile error that cannot be recovered occurred: shared load balancer shared-lb not found in resource group my-rg, it must be created before the cluster. Object will not be requeued",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakeSharedAPILBSpec})
+				m.Get(gomockinternal.AContext(), &fakeSharedAPILBSpec).Return(nil, notFoundError)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, gomock.Any())
+			},
+		},
+		{
+			name:          "fail to add the cluster to a shared apiserver LB without its frontend",
+			expectedError: "reconcile error that cannot be recovered occurred: frontend shared-lb-frontEnd not found in shared load balancer shared-lb. Object will not be requeued",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
+				lb := newSharedLB(false)
+				lb.FrontendIPConfigurations = &[]network.FrontendIPConfiguration{}
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakeSharedAPILBSpec})
+				m.Get(gomockinternal.AContext(), &fakeSharedAPILBSpec).Return(lb, nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, gomock.Any())
+			},
+		},
+		{
+			name:          "fail to add the cluster to a shared apiserver LB on a port used by another cluster",
+			expectedError: "reconcile error that cannot be recovered occurred: frontend port 6443 of shared load balancer shared-lb is already used by load balancing rule other-cluster-LBRuleHTTPS. Object will not be requeued",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
+				spec := fakeSharedAPILBSpec
+				spec.Shared = &infrav1.SharedLoadBalancer{FrontendPort: 6443}
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&spec})
+				m.Get(gomockinternal.AContext(), &spec).Return(newSharedLB(false), nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, gomock.Any())
 			},
 		},
 		{
 			name:          "create node outbound LB",
 			expectedError: "",
-			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakeNodeOutboundLBSpec})
 				r.CreateResource(gomockinternal.AContext(), &fakeNodeOutboundLBSpec, serviceName).Return(nil, nil)
 				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.GlobalLBSpec().Return(nil)
+			},
+		},
+		{
+			name:          "update the LB of the cluster tagged with its UID",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
+				spec := newRecreatedClusterLBSpec()
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{spec})
+				m.Get(gomockinternal.AContext(), spec).Return(newClusterUIDLB("uid-2", true), nil)
+				r.CreateResource(gomockinternal.AContext(), spec, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.GlobalLBSpec().Return(nil)
+			},
+		},
+		{
+			name:          "adopt the LB tagged with the previous UID of a moved cluster",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
+				spec := newRecreatedClusterLBSpec()
+				spec.PreviousClusterUID = "uid-1"
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{spec})
+				m.Get(gomockinternal.AContext(), spec).Return(newClusterUIDLB("uid-1", true), nil)
+				r.CreateResource(gomockinternal.AContext(), spec, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.GlobalLBSpec().Return(nil)
+			},
+		},
+		{
+			// The UID of a cluster changes when it is moved to another management cluster: if its status is lost
+			// with the UID its resources are tagged with, the LB it owns is reported as a conflict, in the
+			// LoadBalancersReady condition, and never deleted.
+			name: "report the LB of a moved cluster as a conflict without deleting it",
+			expectedError: "reconcile error that cannot be recovered occurred: load balancer my-cluster belongs to another cluster with UID uid-1, it can't be used by cluster my-cluster with UID uid-2: " +
+				"delete the load balancer if its cluster was deleted, or annotate the Cluster with azure.cluster.x-k8s.io/previous-cluster-uid=uid-1 if the cluster was moved or restored. Object will not be requeued",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
+				spec := newRecreatedClusterLBSpec()
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{spec})
+				m.Get(gomockinternal.AContext(), spec).Return(newClusterUIDLB("uid-1", true), nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, gomockinternal.ErrStrEq("reconcile error that cannot be recovered occurred: load balancer my-cluster belongs to another cluster with UID uid-1, it can't be used by cluster my-cluster with UID uid-2: "+
+					"delete the load balancer if its cluster was deleted, or annotate the Cluster with azure.cluster.x-k8s.io/previous-cluster-uid=uid-1 if the cluster was moved or restored. Object will not be requeued"))
+			},
+		},
+		{
+			name: "fail to use an LB of another cluster not owned by the cluster",
+			expectedError: "reconcile error that cannot be recovered occurred: load balancer my-cluster belongs to another cluster with UID uid-1, it can't be used by cluster my-cluster with UID uid-2: " +
+				"delete the load balancer if its cluster was deleted, or annotate the Cluster with azure.cluster.x-k8s.io/previous-cluster-uid=uid-1 if the cluster was moved or restored. Object will not be requeued",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
+				spec := newRecreatedClusterLBSpec()
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{spec})
+				m.Get(gomockinternal.AContext(), spec).Return(newClusterUIDLB("uid-1", false), nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, gomock.Any())
 			},
 		},
 		{
 			name:          "create multiple LBs",
 			expectedError: "",
-			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec, &fakeInternalAPILBSpec, &fakeNodeOutboundLBSpec})
 				r.CreateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil, nil)
+				m.Get(gomockinternal.AContext(), &fakeInternalAPILBSpec).Return(fakeInternalLB, nil)
 				r.CreateResource(gomockinternal.AContext(), &fakeInternalAPILBSpec, serviceName).Return(nil, nil)
 				r.CreateResource(gomockinternal.AContext(), &fakeNodeOutboundLBSpec, serviceName).Return(nil, nil)
 				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.GlobalLBSpec().Return(nil)
+			},
+		},
+		{
+			name:          "create cross-region LB",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
+				r.CreateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.GlobalLBSpec().Return(&fakeGlobalLBSpec)
+				s.SubscriptionID().AnyTimes().Return("123")
+				m.Get(gomockinternal.AContext(), &LBSpec{Name: "my-publiclb", ResourceGroup: "my-rg"}).Return(fakeRegionalLB, nil)
+				r.CreateResource(gomockinternal.AContext(), &fakeGlobalLBSpec, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.GlobalLoadBalancerReadyCondition, serviceName, nil)
+				h.GetAvailabilityStatus(gomockinternal.AContext(), publicLBID).Return(newAvailabilityStatus(resourcehealth.Available), nil)
+				h.GetAvailabilityStatus(gomockinternal.AContext(), otherLBID).Return(newAvailabilityStatus(resourcehealth.Available), nil)
+				s.SetGlobalLBBackendHealth(map[string]infrav1.BackendHealthState{
+					fakeGlobalLBSpec.Backends[0]: infrav1.BackendHealthy,
+					fakeGlobalLBSpec.Backends[1]: infrav1.BackendHealthy,
+				})
+				s.SetGlobalLBBackendsHealthy()
+			},
+		},
+		{
+			name:          "report some unhealthy cross-region LB backends",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
+				r.CreateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.GlobalLBSpec().Return(&fakeGlobalLBSpec)
+				s.SubscriptionID().AnyTimes().Return("123")
+				m.Get(gomockinternal.AContext(), &LBSpec{Name: "my-publiclb", ResourceGroup: "my-rg"}).Return(fakeRegionalLB, nil)
+				r.CreateResource(gomockinternal.AContext(), &fakeGlobalLBSpec, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.GlobalLoadBalancerReadyCondition, serviceName, nil)
+				h.GetAvailabilityStatus(gomockinternal.AContext(), publicLBID).Return(newAvailabilityStatus(resourcehealth.Unavailable), nil)
+				h.GetAvailabilityStatus(gomockinternal.AContext(), otherLBID).Return(resourcehealth.AvailabilityStatus{}, internalError)
+				s.SetGlobalLBBackendHealth(map[string]infrav1.BackendHealthState{
+					fakeGlobalLBSpec.Backends[0]: infrav1.BackendUnhealthy,
+					fakeGlobalLBSpec.Backends[1]: infrav1.BackendHealthUnknown,
+				})
+				s.SetGlobalLBBackendsNotHealthy(infrav1.UnhealthyBackendsReason, clusterv1.ConditionSeverityWarning, gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:          "report all cross-region LB backends unhealthy",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
+				r.CreateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.GlobalLBSpec().Return(&fakeGlobalLBSpec)
+				s.SubscriptionID().AnyTimes().Return("123")
+				m.Get(gomockinternal.AContext(), &LBSpec{Name: "my-publiclb", ResourceGroup: "my-rg"}).Return(fakeRegionalLB, nil)
+				r.CreateResource(gomockinternal.AContext(), &fakeGlobalLBSpec, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.GlobalLoadBalancerReadyCondition, serviceName, nil)
+				h.GetAvailabilityStatus(gomockinternal.AContext(), publicLBID).Return(newAvailabilityStatus(resourcehealth.Unavailable), nil)
+				h.GetAvailabilityStatus(gomockinternal.AContext(), otherLBID).Return(newAvailabilityStatus(resourcehealth.Unavailable), nil)
+				s.SetGlobalLBBackendHealth(map[string]infrav1.BackendHealthState{
+					fakeGlobalLBSpec.Backends[0]: infrav1.BackendUnhealthy,
+					fakeGlobalLBSpec.Backends[1]: infrav1.BackendUnhealthy,
+				})
+				s.SetGlobalLBBackendsNotHealthy(infrav1.AllBackendsUnhealthyReason, clusterv1.ConditionSeverityError,
+					"all the regional backends of cross-region load balancer %s are unhealthy, its frontend has no region to send traffic to: %s",
+					"my-global-lb", fakeGlobalLBSpec.Backends[0]+", "+fakeGlobalLBSpec.Backends[1])
+			},
+		},
+		{
+			name:          "fail to create cross-region LB with a backend without a health probe",
+			expectedError: "reconcile error that cannot be recovered occurred: backend /subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/frontendIPConfigurations/my-publiclb-frontEnd of cross-region load balancer my-global-lb has no load balancing rule with a health probe for port 6443, the cross-region load balancer relies on it to take the region out of rotation when it is unhealthy. Object will not be requeued",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
+				r.CreateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.GlobalLBSpec().Return(&fakeGlobalLBSpec)
+				s.SubscriptionID().AnyTimes().Return("123")
+				m.Get(gomockinternal.AContext(), &LBSpec{Name: "my-publiclb", ResourceGroup: "my-rg"}).Return(fakeUnprobedRegionalLB, nil)
+				s.UpdatePutStatus(infrav1.GlobalLoadBalancerReadyCondition, serviceName, gomock.Any())
+			},
+		},
+		{
+			name:          "fail to create cross-region LB with a Basic backend",
+			expectedError: "reconcile error that cannot be recovered occurred: backend /subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/frontendIPConfigurations/my-publiclb-frontEnd of cross-region load balancer my-global-lb is not the frontend of a Standard regional load balancer. Object will not be requeued",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
+				r.CreateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.GlobalLBSpec().Return(&fakeGlobalLBSpec)
+				s.SubscriptionID().AnyTimes().Return("123")
+				m.Get(gomockinternal.AContext(), &LBSpec{Name: "my-publiclb", ResourceGroup: "my-rg"}).Return(fakeBasicLB, nil)
+				s.UpdatePutStatus(infrav1.GlobalLoadBalancerReadyCondition, serviceName, gomock.Any())
+			},
+		},
+		{
+			name:          "skip cross-region LB when regional LBs are not ready",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, c *mock_loadbalancers.MockIPAddressCheckerMockRecorder, h *mock_loadbalancers.MockHealthGetterMockRecorder) {
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
+				r.CreateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, internalError)
 			},
 		},
 	}
@@ -170,12 +521,193 @@ func TestReconcileLoadBalancer(t *testing.T) {
 
 			scopeMock := mock_loadbalancers.NewMockLBScope(mockCtrl)
 			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			getterMock := mock_async.NewMockGetter(mockCtrl)
+			checkerMock := mock_loadbalancers.NewMockIPAddressChecker(mockCtrl)
+			healthMock := mock_loadbalancers.NewMockHealthGetter(mockCtrl)
+			scaleSetMock := mock_loadbalancers.NewMockScaleSetClient(mockCtrl)
 
-			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), getterMock.EXPECT(), checkerMock.EXPECT(), healthMock.EXPECT())
+			scopeMock.EXPECT().StaleLBSpecs().AnyTimes()
+			scaleSetMock.EXPECT().ListScaleSets(gomockinternal.AContext(), gomock.Any()).AnyTimes().Return(nil, nil)
 
 			s := &Service{
-				Scope:      scopeMock,
-				Reconciler: asyncMock,
+				Scope:            scopeMock,
+				Reconciler:       asyncMock,
+				Getter:           getterMock,
+				IPAddressChecker: checkerMock,
+				HealthGetter:     healthMock,
+				ScaleSetClient:   scaleSetMock,
+			}
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+// newAvailabilityStatus returns an availability status of Azure Resource Health in the given state.
+func newAvailabilityStatus(state resourcehealth.AvailabilityStateValues) resourcehealth.AvailabilityStatus {
+	return resourcehealth.AvailabilityStatus{
+		Properties: &resourcehealth.AvailabilityStatusProperties{
+			AvailabilityState: state,
+			Summary:           to.StringPtr("summary"),
+		},
+	}
+}
+
+// newRecreatedClusterLBSpec returns the spec of the node outbound LB of a cluster recreated with the UID uid-2.
+func newRecreatedClusterLBSpec() *LBSpec {
+	spec := fakeNodeOutboundLBSpec
+	spec.ClusterUID = "uid-2"
+	return &spec
+}
+
+// newClusterUIDLB returns the node outbound LB of a cluster of the same name with the given UID.
+func newClusterUIDLB(uid string, owned bool) network.LoadBalancer {
+	tags := map[string]*string{infrav1.ClusterUIDTagKey(): to.StringPtr(uid)}
+	if owned {
+		tags[infrav1.ClusterTagKey("my-cluster")] = to.StringPtr(string(infrav1.ResourceLifecycleOwned))
+	}
+	return network.LoadBalancer{Name: to.StringPtr("my-cluster"), Tags: tags}
+}
+
+func TestReconcileLoadBalancerBasicSKU(t *testing.T) {
+	forceRecreateLBSpec := fakePublicAPILBSpec
+	forceRecreateLBSpec.ForceRecreate = true
+
+	basicIP := network.PublicIPAddress{
+		Name: to.StringPtr("my-publicip"),
+		Sku:  &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameBasic},
+		Tags: map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned")},
+	}
+	unownedBasicIP := network.PublicIPAddress{
+		Name: to.StringPtr("my-publicip"),
+		Sku:  &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameBasic},
+	}
+	basicIPSpec := &publicIPSpec{Name: "my-publicip", ResourceGroup: "my-rg"}
+	standardIP := network.PublicIPAddress{
+		Name: to.StringPtr("my-publicip"),
+		Sku:  &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameStandard},
+	}
+	standardLB := network.LoadBalancer{
+		Name: to.StringPtr("my-publiclb"),
+		Sku:  &network.LoadBalancerSku{Name: network.LoadBalancerSkuNameStandard},
+	}
+	skuChangeErr := azure.WithTerminalError(skuChangeError(fakePublicAPILBSpec, string(network.LoadBalancerSkuNameBasic)))
+
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, p *mock_loadbalancers.MockPublicIPClientMockRecorder, ip *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "report a load balancer of the Basic SKU without deleting it",
+			expectedError: skuChangeErr.Error(),
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, p *mock_loadbalancers.MockPublicIPClientMockRecorder, ip *mock_async.MockReconcilerMockRecorder) {
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
+				r.CreateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil, skuChangeErr)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, skuChangeErr)
+			},
+		},
+		{
+			name:          "delete a load balancer of the Basic SKU and its public IP to recreate them",
+			expectedError: "load balancer my-publiclb is being recreated with the Standard SKU. Object will be requeued after 15s",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, p *mock_loadbalancers.MockPublicIPClientMockRecorder, ip *mock_async.MockReconcilerMockRecorder) {
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&forceRecreateLBSpec})
+				m.Get(gomockinternal.AContext(), &forceRecreateLBSpec).Return(fakeBasicLB, nil)
+				p.GetPublicIP(gomockinternal.AContext(), "my-rg", "my-publicip").Return(basicIP, nil)
+				gomock.InOrder(
+					r.DeleteResource(gomockinternal.AContext(), &forceRecreateLBSpec, serviceName).Return(nil),
+					ip.DeleteResource(gomockinternal.AContext(), basicIPSpec, publicIPServiceName).Return(nil),
+				)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, gomock.Any())
+			},
+		},
+		{
+			name:          "delete a leftover public IP of the Basic SKU to recreate it",
+			expectedError: "load balancer my-publiclb is being recreated with the Standard SKU. Object will be requeued after 15s",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, p *mock_loadbalancers.MockPublicIPClientMockRecorder, ip *mock_async.MockReconcilerMockRecorder) {
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&forceRecreateLBSpec})
+				m.Get(gomockinternal.AContext(), &forceRecreateLBSpec).Return(nil, notFoundError)
+				p.GetPublicIP(gomockinternal.AContext(), "my-rg", "my-publicip").Return(basicIP, nil)
+				ip.DeleteResource(gomockinternal.AContext(), basicIPSpec, publicIPServiceName).Return(nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, gomock.Any())
+			},
+		},
+		{
+			name:          "report a bring-your-own public IP of the Basic SKU without deleting it or its load balancer",
+			expectedError: "reconcile error that cannot be recovered occurred: public IP my-publicip of load balancer my-publiclb has the Basic SKU and isn't owned by the cluster, so it can't be recreated with the Standard SKU: migrate it manually. Object will not be requeued",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, p *mock_loadbalancers.MockPublicIPClientMockRecorder, ip *mock_async.MockReconcilerMockRecorder) {
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&forceRecreateLBSpec})
+				m.Get(gomockinternal.AContext(), &forceRecreateLBSpec).Return(fakeBasicLB, nil)
+				p.GetPublicIP(gomockinternal.AContext(), "my-rg", "my-publicip").Return(unownedBasicIP, nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, gomock.Any())
+			},
+		},
+		{
+			name:          "fail to delete a public IP of the Basic SKU",
+			expectedError: "failed to delete public IP my-publicip of the Basic SKU of load balancer my-publiclb: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, p *mock_loadbalancers.MockPublicIPClientMockRecorder, ip *mock_async.MockReconcilerMockRecorder) {
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&forceRecreateLBSpec})
+				m.Get(gomockinternal.AContext(), &forceRecreateLBSpec).Return(nil, notFoundError)
+				p.GetPublicIP(gomockinternal.AContext(), "my-rg", "my-publicip").Return(basicIP, nil)
+				ip.DeleteResource(gomockinternal.AContext(), basicIPSpec, publicIPServiceName).Return(internalError)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, gomock.Any())
+			},
+		},
+		{
+			name:          "fail to delete a load balancer of the Basic SKU",
+			expectedError: "failed to delete load balancer my-publiclb of the Basic SKU: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, p *mock_loadbalancers.MockPublicIPClientMockRecorder, ip *mock_async.MockReconcilerMockRecorder) {
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&forceRecreateLBSpec})
+				m.Get(gomockinternal.AContext(), &forceRecreateLBSpec).Return(fakeBasicLB, nil)
+				p.GetPublicIP(gomockinternal.AContext(), "my-rg", "my-publicip").Return(basicIP, nil)
+				r.DeleteResource(gomockinternal.AContext(), &forceRecreateLBSpec, serviceName).Return(internalError)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, gomock.Any())
+			},
+		},
+		{
+			name:          "update a load balancer of the Standard SKU allowed to be recreated",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder, p *mock_loadbalancers.MockPublicIPClientMockRecorder, ip *mock_async.MockReconcilerMockRecorder) {
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&forceRecreateLBSpec})
+				m.Get(gomockinternal.AContext(), &forceRecreateLBSpec).Return(standardLB, nil)
+				p.GetPublicIP(gomockinternal.AContext(), "my-rg", "my-publicip").Return(standardIP, nil)
+				r.CreateResource(gomockinternal.AContext(), &forceRecreateLBSpec, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.GlobalLBSpec().Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_loadbalancers.NewMockLBScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			getterMock := mock_async.NewMockGetter(mockCtrl)
+			publicIPMock := mock_loadbalancers.NewMockPublicIPClient(mockCtrl)
+			publicIPsAsyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), getterMock.EXPECT(), publicIPMock.EXPECT(), publicIPsAsyncMock.EXPECT())
+			scopeMock.EXPECT().StaleLBSpecs().AnyTimes()
+
+			s := &Service{
+				Scope:          scopeMock,
+				Reconciler:     asyncMock,
+				Getter:         getterMock,
+				PublicIPClient: publicIPMock,
+				publicIPs:      publicIPsAsyncMock,
 			}
 			err := s.Reconcile(context.TODO())
 			if tc.expectedError != "" {
@@ -192,12 +724,13 @@ func TestDeleteLoadBalancer(t *testing.T) {
 	testcases := []struct {
 		name          string
 		expectedError string
-		expect        func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expect        func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder)
 	}{
 		{
 			name:          "delete a load balancer",
 			expectedError: "",
-			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder) {
+				s.GlobalLBSpec().Return(nil)
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
 				r.DeleteResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil)
 				s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
@@ -206,7 +739,8 @@ func TestDeleteLoadBalancer(t *testing.T) {
 		{
 			name:          "delete multiple load balancers",
 			expectedError: "",
-			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder) {
+				s.GlobalLBSpec().Return(nil)
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec, &fakeInternalAPILBSpec, &fakeNodeOutboundLBSpec})
 				r.DeleteResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil)
 				r.DeleteResource(gomockinternal.AContext(), &fakeInternalAPILBSpec, serviceName).Return(nil)
@@ -214,10 +748,93 @@ func TestDeleteLoadBalancer(t *testing.T) {
 				s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
 			},
 		},
+		{
+			name:          "delete the load balancer tagged with the UID of the cluster",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder) {
+				spec := newRecreatedClusterLBSpec()
+				s.GlobalLBSpec().Return(nil)
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{spec})
+				m.Get(gomockinternal.AContext(), spec).Return(newClusterUIDLB("uid-2", true), nil)
+				r.DeleteResource(gomockinternal.AContext(), spec, serviceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "skip the deletion of a load balancer of the same name tagged with the UID of another cluster",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder) {
+				spec := newRecreatedClusterLBSpec()
+				s.GlobalLBSpec().Return(nil)
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{spec})
+				m.Get(gomockinternal.AContext(), spec).Return(newClusterUIDLB("uid-1", true), nil)
+				s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "delete the load balancer tagged with the previous UID of a moved cluster",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder) {
+				spec := newRecreatedClusterLBSpec()
+				spec.PreviousClusterUID = "uid-1"
+				s.GlobalLBSpec().Return(nil)
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{spec})
+				m.Get(gomockinternal.AContext(), spec).Return(newClusterUIDLB("uid-1", true), nil)
+				r.DeleteResource(gomockinternal.AContext(), spec, serviceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "delete a load balancer already deleted",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder) {
+				spec := newRecreatedClusterLBSpec()
+				s.GlobalLBSpec().Return(nil)
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{spec})
+				m.Get(gomockinternal.AContext(), spec).Return(nil, notFoundError)
+				r.DeleteResource(gomockinternal.AContext(), spec, serviceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "remove the cluster from a shared load balancer",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder) {
+				s.GlobalLBSpec().Return(nil)
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakeSharedAPILBSpec, &fakeNodeOutboundLBSpec})
+				r.CreateResource(gomockinternal.AContext(), &sharedLBCleanupSpec{LBSpec: &fakeSharedAPILBSpec}, serviceName).Return(nil, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeNodeOutboundLBSpec, serviceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "delete cross-region load balancer first",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder) {
+				gomock.InOrder(
+					s.GlobalLBSpec().Return(&fakeGlobalLBSpec),
+					r.DeleteResource(gomockinternal.AContext(), &fakeGlobalLBSpec, serviceName).Return(nil),
+					s.UpdateDeleteStatus(infrav1.GlobalLoadBalancerReadyCondition, serviceName, nil),
+					s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec}),
+					r.DeleteResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil),
+					s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil),
+				)
+			},
+		},
+		{
+			name:          "cross-region load balancer deletion fails",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder) {
+				s.GlobalLBSpec().Return(&fakeGlobalLBSpec)
+				r.DeleteResource(gomockinternal.AContext(), &fakeGlobalLBSpec, serviceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.GlobalLoadBalancerReadyCondition, serviceName, internalError)
+			},
+		},
 		{
 			name:          "load balancer deletion fails",
 			expectedError: "#: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder) {
+				s.GlobalLBSpec().Return(nil)
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
 				r.DeleteResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(internalError)
 				s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, internalError)
@@ -236,12 +853,15 @@ func TestDeleteLoadBalancer(t *testing.T) {
 
 			scopeMock := mock_loadbalancers.NewMockLBScope(mockCtrl)
 			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			getterMock := mock_async.NewMockGetter(mockCtrl)
 
-			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), getterMock.EXPECT())
+			scopeMock.EXPECT().StaleLBSpecs().AnyTimes()
 
 			s := &Service{
 				Scope:      scopeMock,
 				Reconciler: asyncMock,
+				Getter:     getterMock,
 			}
 
 			err := s.Delete(context.TODO())
@@ -254,3 +874,115 @@ func TestDeleteLoadBalancer(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteStaleLBs(t *testing.T) {
+	staleLBSpec := &LBSpec{
+		Name:           "my-publiclb-internal",
+		ResourceGroup:  "my-rg",
+		SubscriptionID: "123",
+		ClusterName:    "my-cluster",
+	}
+	ownedTags := map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned")}
+
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder)
+	}{
+		{
+			name: "delete the internal load balancer of a removed internal frontend",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder) {
+				s.StaleLBSpecs().Return([]azure.ResourceSpecGetter{staleLBSpec})
+				m.Get(gomockinternal.AContext(), staleLBSpec).Return(network.LoadBalancer{
+					Name: to.StringPtr("my-publiclb-internal"),
+					Tags: ownedTags,
+					LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+						BackendAddressPools: &[]network.BackendAddressPool{
+							{
+								Name:                               to.StringPtr("my-publiclb-internal-backendPool"),
+								BackendAddressPoolPropertiesFormat: &network.BackendAddressPoolPropertiesFormat{},
+							},
+						},
+					},
+				}, nil)
+				r.DeleteResource(gomockinternal.AContext(), staleLBSpec, serviceName).Return(nil)
+			},
+		},
+		{
+			name: "stale load balancer already deleted",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder) {
+				s.StaleLBSpecs().Return([]azure.ResourceSpecGetter{staleLBSpec})
+				m.Get(gomockinternal.AContext(), staleLBSpec).Return(nil, notFoundError)
+			},
+		},
+		{
+			name: "stale load balancer not owned by the cluster is left untouched",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder) {
+				s.StaleLBSpecs().Return([]azure.ResourceSpecGetter{staleLBSpec})
+				m.Get(gomockinternal.AContext(), staleLBSpec).Return(network.LoadBalancer{Name: to.StringPtr("my-publiclb-internal")}, nil)
+			},
+		},
+		{
+			name: "stale load balancer still used by network interfaces is deleted later",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder) {
+				s.StaleLBSpecs().Return([]azure.ResourceSpecGetter{staleLBSpec})
+				m.Get(gomockinternal.AContext(), staleLBSpec).Return(network.LoadBalancer{
+					Name: to.StringPtr("my-publiclb-internal"),
+					Tags: ownedTags,
+					LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+						BackendAddressPools: &[]network.BackendAddressPool{
+							{
+								Name: to.StringPtr("my-publiclb-internal-backendPool"),
+								BackendAddressPoolPropertiesFormat: &network.BackendAddressPoolPropertiesFormat{
+									BackendIPConfigurations: &[]network.InterfaceIPConfiguration{
+										{ID: to.StringPtr("my-cp-nic-ipconfig")},
+									},
+								},
+							},
+						},
+					},
+				}, nil)
+			},
+		},
+		{
+			name:          "fail to get the stale load balancer",
+			expectedError: "failed to get load balancer my-publiclb-internal: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_async.MockGetterMockRecorder) {
+				s.StaleLBSpecs().Return([]azure.ResourceSpecGetter{staleLBSpec})
+				m.Get(gomockinternal.AContext(), staleLBSpec).Return(nil, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_loadbalancers.NewMockLBScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			getterMock := mock_async.NewMockGetter(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), getterMock.EXPECT())
+			scopeMock.EXPECT().ClusterName().Return("my-cluster").AnyTimes()
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+				Getter:     getterMock,
+			}
+
+			err := s.deleteStaleLBs(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
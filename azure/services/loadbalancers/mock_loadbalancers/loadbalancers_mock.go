@@ -21,8 +21,12 @@ limitations under the License.
 package mock_loadbalancers
 
 import (
+	context "context"
 	reflect "reflect"
 
+	compute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-04-01/compute"
+	network "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-03-01/network"
+	resourcehealth "github.com/Azure/azure-sdk-for-go/services/resourcehealth/mgmt/2017-07-01/resourcehealth"
 	autorest "github.com/Azure/go-autorest/autorest"
 	gomock "github.com/golang/mock/gomock"
 	v1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -109,6 +113,20 @@ func (mr *MockLBScopeMockRecorder) AdditionalTags() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdditionalTags", reflect.TypeOf((*MockLBScope)(nil).AdditionalTags))
 }
 
+// ApplicationSecurityGroups mocks base method.
+func (m *MockLBScope) ApplicationSecurityGroups() []v1beta1.ApplicationSecurityGroup {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroups")
+	ret0, _ := ret[0].([]v1beta1.ApplicationSecurityGroup)
+	return ret0
+}
+
+// ApplicationSecurityGroups indicates an expected call of ApplicationSecurityGroups.
+func (mr *MockLBScopeMockRecorder) ApplicationSecurityGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroups", reflect.TypeOf((*MockLBScope)(nil).ApplicationSecurityGroups))
+}
+
 // Authorizer mocks base method.
 func (m *MockLBScope) Authorizer() autorest.Authorizer {
 	m.ctrl.T.Helper()
@@ -221,6 +239,20 @@ func (mr *MockLBScopeMockRecorder) ClusterName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterName", reflect.TypeOf((*MockLBScope)(nil).ClusterName))
 }
 
+// ControlPlaneAvailabilitySetID mocks base method.
+func (m *MockLBScope) ControlPlaneAvailabilitySetID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ControlPlaneAvailabilitySetID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ControlPlaneAvailabilitySetID indicates an expected call of ControlPlaneAvailabilitySetID.
+func (mr *MockLBScopeMockRecorder) ControlPlaneAvailabilitySetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControlPlaneAvailabilitySetID", reflect.TypeOf((*MockLBScope)(nil).ControlPlaneAvailabilitySetID))
+}
+
 // ControlPlaneRouteTable mocks base method.
 func (m *MockLBScope) ControlPlaneRouteTable() v1beta1.RouteTable {
 	m.ctrl.T.Helper()
@@ -303,6 +335,20 @@ func (mr *MockLBScopeMockRecorder) GetPrivateDNSZoneName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateDNSZoneName", reflect.TypeOf((*MockLBScope)(nil).GetPrivateDNSZoneName))
 }
 
+// GlobalLBSpec mocks base method.
+func (m *MockLBScope) GlobalLBSpec() azure.ResourceSpecGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GlobalLBSpec")
+	ret0, _ := ret[0].(azure.ResourceSpecGetter)
+	return ret0
+}
+
+// GlobalLBSpec indicates an expected call of GlobalLBSpec.
+func (mr *MockLBScopeMockRecorder) GlobalLBSpec() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GlobalLBSpec", reflect.TypeOf((*MockLBScope)(nil).GlobalLBSpec))
+}
+
 // HashKey mocks base method.
 func (m *MockLBScope) HashKey() string {
 	m.ctrl.T.Helper()
@@ -317,6 +363,20 @@ func (mr *MockLBScopeMockRecorder) HashKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockLBScope)(nil).HashKey))
 }
 
+// InternalLBAddressPoolIDs mocks base method.
+func (m *MockLBScope) InternalLBAddressPoolIDs(arg0 string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InternalLBAddressPoolIDs", arg0)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// InternalLBAddressPoolIDs indicates an expected call of InternalLBAddressPoolIDs.
+func (mr *MockLBScopeMockRecorder) InternalLBAddressPoolIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InternalLBAddressPoolIDs", reflect.TypeOf((*MockLBScope)(nil).InternalLBAddressPoolIDs), arg0)
+}
+
 // IsAPIServerPrivate mocks base method.
 func (m *MockLBScope) IsAPIServerPrivate() bool {
 	m.ctrl.T.Helper()
@@ -387,6 +447,20 @@ func (mr *MockLBScopeMockRecorder) Location() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Location", reflect.TypeOf((*MockLBScope)(nil).Location))
 }
 
+// NetworkInterfaceSecurityGroupName mocks base method.
+func (m *MockLBScope) NetworkInterfaceSecurityGroupName(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkInterfaceSecurityGroupName", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// NetworkInterfaceSecurityGroupName indicates an expected call of NetworkInterfaceSecurityGroupName.
+func (mr *MockLBScopeMockRecorder) NetworkInterfaceSecurityGroupName(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkInterfaceSecurityGroupName", reflect.TypeOf((*MockLBScope)(nil).NetworkInterfaceSecurityGroupName), arg0)
+}
+
 // NodeSubnets mocks base method.
 func (m *MockLBScope) NodeSubnets() []v1beta1.SubnetSpec {
 	m.ctrl.T.Helper()
@@ -443,6 +517,59 @@ func (mr *MockLBScopeMockRecorder) ResourceGroup() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceGroup", reflect.TypeOf((*MockLBScope)(nil).ResourceGroup))
 }
 
+// SetGlobalLBBackendHealth mocks base method.
+func (m *MockLBScope) SetGlobalLBBackendHealth(health map[string]v1beta1.BackendHealthState) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetGlobalLBBackendHealth", health)
+}
+
+// SetGlobalLBBackendHealth indicates an expected call of SetGlobalLBBackendHealth.
+func (mr *MockLBScopeMockRecorder) SetGlobalLBBackendHealth(health interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGlobalLBBackendHealth", reflect.TypeOf((*MockLBScope)(nil).SetGlobalLBBackendHealth), health)
+}
+
+// SetGlobalLBBackendsHealthy mocks base method.
+func (m *MockLBScope) SetGlobalLBBackendsHealthy() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetGlobalLBBackendsHealthy")
+}
+
+// SetGlobalLBBackendsHealthy indicates an expected call of SetGlobalLBBackendsHealthy.
+func (mr *MockLBScopeMockRecorder) SetGlobalLBBackendsHealthy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGlobalLBBackendsHealthy", reflect.TypeOf((*MockLBScope)(nil).SetGlobalLBBackendsHealthy))
+}
+
+// SetGlobalLBBackendsNotHealthy mocks base method.
+func (m *MockLBScope) SetGlobalLBBackendsNotHealthy(reason string, severity v1beta10.ConditionSeverity, messageFormat string, messageArgs ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{reason, severity, messageFormat}
+	for _, a := range messageArgs {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "SetGlobalLBBackendsNotHealthy", varargs...)
+}
+
+// SetGlobalLBBackendsNotHealthy indicates an expected call of SetGlobalLBBackendsNotHealthy.
+func (mr *MockLBScopeMockRecorder) SetGlobalLBBackendsNotHealthy(reason, severity, messageFormat interface{}, messageArgs ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{reason, severity, messageFormat}, messageArgs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGlobalLBBackendsNotHealthy", reflect.TypeOf((*MockLBScope)(nil).SetGlobalLBBackendsNotHealthy), varargs...)
+}
+
+// SetLoadBalancerTier mocks base method.
+func (m *MockLBScope) SetLoadBalancerTier(name string, tier v1beta1.LoadBalancerTier) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLoadBalancerTier", name, tier)
+}
+
+// SetLoadBalancerTier indicates an expected call of SetLoadBalancerTier.
+func (mr *MockLBScopeMockRecorder) SetLoadBalancerTier(name, tier interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLoadBalancerTier", reflect.TypeOf((*MockLBScope)(nil).SetLoadBalancerTier), name, tier)
+}
+
 // SetLongRunningOperationState mocks base method.
 func (m *MockLBScope) SetLongRunningOperationState(arg0 *v1beta1.Future) {
 	m.ctrl.T.Helper()
@@ -495,6 +622,20 @@ func (mr *MockLBScopeMockRecorder) Subnets() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subnets", reflect.TypeOf((*MockLBScope)(nil).Subnets))
 }
 
+// StaleLBSpecs mocks base method.
+func (m *MockLBScope) StaleLBSpecs() []azure.ResourceSpecGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StaleLBSpecs")
+	ret0, _ := ret[0].([]azure.ResourceSpecGetter)
+	return ret0
+}
+
+// StaleLBSpecs indicates an expected call of StaleLBSpecs.
+func (mr *MockLBScopeMockRecorder) StaleLBSpecs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StaleLBSpecs", reflect.TypeOf((*MockLBScope)(nil).StaleLBSpecs))
+}
+
 // SubscriptionID mocks base method.
 func (m *MockLBScope) SubscriptionID() string {
 	m.ctrl.T.Helper()
@@ -572,3 +713,169 @@ func (mr *MockLBScopeMockRecorder) Vnet() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vnet", reflect.TypeOf((*MockLBScope)(nil).Vnet))
 }
+
+// MockIPAddressChecker is a mock of IPAddressChecker interface.
+type MockIPAddressChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPAddressCheckerMockRecorder
+}
+
+// MockIPAddressCheckerMockRecorder is the mock recorder for MockIPAddressChecker.
+type MockIPAddressCheckerMockRecorder struct {
+	mock *MockIPAddressChecker
+}
+
+// NewMockIPAddressChecker creates a new mock instance.
+func NewMockIPAddressChecker(ctrl *gomock.Controller) *MockIPAddressChecker {
+	mock := &MockIPAddressChecker{ctrl: ctrl}
+	mock.recorder = &MockIPAddressCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPAddressChecker) EXPECT() *MockIPAddressCheckerMockRecorder {
+	return m.recorder
+}
+
+// CheckIPAddressAvailability mocks base method.
+func (m *MockIPAddressChecker) CheckIPAddressAvailability(ctx context.Context, resourceGroup, vnetName, ipAddress string) (network.IPAddressAvailabilityResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckIPAddressAvailability", ctx, resourceGroup, vnetName, ipAddress)
+	ret0, _ := ret[0].(network.IPAddressAvailabilityResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckIPAddressAvailability indicates an expected call of CheckIPAddressAvailability.
+func (mr *MockIPAddressCheckerMockRecorder) CheckIPAddressAvailability(ctx, resourceGroup, vnetName, ipAddress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIPAddressAvailability", reflect.TypeOf((*MockIPAddressChecker)(nil).CheckIPAddressAvailability), ctx, resourceGroup, vnetName, ipAddress)
+}
+
+// MockHealthGetter is a mock of HealthGetter interface.
+type MockHealthGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthGetterMockRecorder
+}
+
+// MockHealthGetterMockRecorder is the mock recorder for MockHealthGetter.
+type MockHealthGetterMockRecorder struct {
+	mock *MockHealthGetter
+}
+
+// NewMockHealthGetter creates a new mock instance.
+func NewMockHealthGetter(ctrl *gomock.Controller) *MockHealthGetter {
+	mock := &MockHealthGetter{ctrl: ctrl}
+	mock.recorder = &MockHealthGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthGetter) EXPECT() *MockHealthGetterMockRecorder {
+	return m.recorder
+}
+
+// GetAvailabilityStatus mocks base method.
+func (m *MockHealthGetter) GetAvailabilityStatus(ctx context.Context, resourceID string) (resourcehealth.AvailabilityStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailabilityStatus", ctx, resourceID)
+	ret0, _ := ret[0].(resourcehealth.AvailabilityStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailabilityStatus indicates an expected call of GetAvailabilityStatus.
+func (mr *MockHealthGetterMockRecorder) GetAvailabilityStatus(ctx, resourceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailabilityStatus", reflect.TypeOf((*MockHealthGetter)(nil).GetAvailabilityStatus), ctx, resourceID)
+}
+
+// MockPublicIPClient is a mock of PublicIPClient interface.
+type MockPublicIPClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublicIPClientMockRecorder
+}
+
+// MockPublicIPClientMockRecorder is the mock recorder for MockPublicIPClient.
+type MockPublicIPClientMockRecorder struct {
+	mock *MockPublicIPClient
+}
+
+// NewMockPublicIPClient creates a new mock instance.
+func NewMockPublicIPClient(ctrl *gomock.Controller) *MockPublicIPClient {
+	mock := &MockPublicIPClient{ctrl: ctrl}
+	mock.recorder = &MockPublicIPClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublicIPClient) EXPECT() *MockPublicIPClientMockRecorder {
+	return m.recorder
+}
+
+// GetPublicIP mocks base method.
+func (m *MockPublicIPClient) GetPublicIP(ctx context.Context, resourceGroup, name string) (network.PublicIPAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicIP", ctx, resourceGroup, name)
+	ret0, _ := ret[0].(network.PublicIPAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicIP indicates an expected call of GetPublicIP.
+func (mr *MockPublicIPClientMockRecorder) GetPublicIP(ctx, resourceGroup, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicIP", reflect.TypeOf((*MockPublicIPClient)(nil).GetPublicIP), ctx, resourceGroup, name)
+}
+
+// MockScaleSetClient is a mock of ScaleSetClient interface.
+type MockScaleSetClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockScaleSetClientMockRecorder
+}
+
+// MockScaleSetClientMockRecorder is the mock recorder for MockScaleSetClient.
+type MockScaleSetClientMockRecorder struct {
+	mock *MockScaleSetClient
+}
+
+// NewMockScaleSetClient creates a new mock instance.
+func NewMockScaleSetClient(ctrl *gomock.Controller) *MockScaleSetClient {
+	mock := &MockScaleSetClient{ctrl: ctrl}
+	mock.recorder = &MockScaleSetClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScaleSetClient) EXPECT() *MockScaleSetClientMockRecorder {
+	return m.recorder
+}
+
+// ListScaleSets mocks base method.
+func (m *MockScaleSetClient) ListScaleSets(ctx context.Context, resourceGroup string) ([]compute.VirtualMachineScaleSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScaleSets", ctx, resourceGroup)
+	ret0, _ := ret[0].([]compute.VirtualMachineScaleSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScaleSets indicates an expected call of ListScaleSets.
+func (mr *MockScaleSetClientMockRecorder) ListScaleSets(ctx, resourceGroup interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScaleSets", reflect.TypeOf((*MockScaleSetClient)(nil).ListScaleSets), ctx, resourceGroup)
+}
+
+// UpdateScaleSetAsync mocks base method.
+func (m *MockScaleSetClient) UpdateScaleSetAsync(ctx context.Context, resourceGroup, name string, update compute.VirtualMachineScaleSetUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScaleSetAsync", ctx, resourceGroup, name, update)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateScaleSetAsync indicates an expected call of UpdateScaleSetAsync.
+func (mr *MockScaleSetClientMockRecorder) UpdateScaleSetAsync(ctx, resourceGroup, name, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScaleSetAsync", reflect.TypeOf((*MockScaleSetClient)(nil).UpdateScaleSetAsync), ctx, resourceGroup, name, update)
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package loadbalancers
 
 import (
-	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-03-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -31,10 +34,13 @@ type LBSpec struct {
 	ResourceGroup        string
 	SubscriptionID       string
 	ClusterName          string
+	ClusterUID           string
+	PreviousClusterUID   string
 	Location             string
 	Role                 string
 	Type                 infrav1.LBType
 	SKU                  infrav1.SKU
+	Tier                 infrav1.LoadBalancerTier
 	VNetName             string
 	VNetResourceGroup    string
 	SubnetName           string
@@ -42,7 +48,21 @@ type LBSpec struct {
 	FrontendIPConfigs    []infrav1.FrontendIP
 	APIServerPort        int32
 	IdleTimeoutInMinutes *int32
-	AdditionalTags       map[string]string
+	EnableTCPReset       *bool
+	HealthProbe          *infrav1.HealthProbe
+	HAPorts              *infrav1.HAPorts
+	Shared               *infrav1.SharedLoadBalancer
+	SSHNATRule           *infrav1.SSHNATRule
+	ServiceRules         []infrav1.InternalLoadBalancerRule
+	ServiceProbe         *infrav1.InternalLoadBalancerProbe
+	ServiceProbes        []infrav1.InternalLoadBalancerProbe
+	// DisableOutboundRule is true when the egress of the backends goes through the NAT gateway of their subnet,
+	// which takes precedence over an outbound rule.
+	DisableOutboundRule bool
+	// ForceRecreate is true when an existing load balancer of the Basic SKU, and the public IPs of its frontends, are
+	// deleted to be recreated with the Standard SKU rather than reported as an error.
+	ForceRecreate  bool
+	AdditionalTags map[string]string
 }
 
 // ResourceName returns the name of the load balancer.
@@ -64,6 +84,8 @@ func (s *LBSpec) OwnerResourceName() string {
 func (s *LBSpec) Parameters(existing interface{}) (parameters interface{}, err error) {
 	var (
 		etag                *string
+		tags                map[string]*string
+		inboundNatRules     *[]network.InboundNatRule
 		frontendIDs         []network.SubResource
 		frontendIPConfigs   = make([]network.FrontendIPConfiguration, 0)
 		loadBalancingRules  = make([]network.LoadBalancingRule, 0)
@@ -78,25 +100,60 @@ func (s *LBSpec) Parameters(existing interface{}) (parameters interface{}, err e
 			return nil, errors.Errorf("%T is not a network.LoadBalancer", existing)
 		}
 		// LB already exists
+		// The tier of a load balancer can't be changed, the load balancer would have to be recreated.
+		if s.Tier != "" && existingLB.Sku != nil && existingLB.Sku.Tier != "" && !strings.EqualFold(string(existingLB.Sku.Tier), string(s.Tier)) {
+			return nil, azure.WithTerminalError(errors.Errorf("load balancer %s is of the %s tier instead of %s, the tier of a load balancer can't be changed", s.Name, existingLB.Sku.Tier, s.Tier))
+		}
+		// Nor can its SKU, e.g. from Basic to Standard: the load balancer and the public IPs of its frontends are deleted
+		// beforehand to be recreated when ForceRecreate is set.
+		if s.SKU != "" && existingLB.Sku != nil && existingLB.Sku.Name != "" && !strings.EqualFold(string(existingLB.Sku.Name), string(s.SKU)) {
+			return nil, azure.WithTerminalError(skuChangeError(*s, string(existingLB.Sku.Name)))
+		}
 		// We append the existing LB etag to the header to ensure we only apply the updates if the LB has not been modified.
 		etag = existingLB.Etag
 		update := false
 
+		// A shared load balancer is managed outside of the cluster: its frontends and tags are kept, and it is only
+		// tagged as shared with the cluster.
+		if s.Shared != nil {
+			tags = existingLB.Tags
+			if !isSharedWithCluster(tags, s.ClusterName) {
+				update = true
+				tags = withSharedClusterTag(tags, s.ClusterName)
+			}
+			inboundNatRules = existingLB.InboundNatRules
+		}
+
 		// merge existing LB properties with desired properties
 		frontendIPConfigs = *existingLB.FrontendIPConfigurations
 		wantedIPs, wantedFrontendIDs := getFrontendIPConfigs(*s)
-		for _, ip := range wantedIPs {
-			if !ipExists(frontendIPConfigs, ip) {
-				update = true
-				frontendIPConfigs = append(frontendIPConfigs, ip)
+		if s.Shared == nil {
+			for _, ip := range wantedIPs {
+				if !ipExists(frontendIPConfigs, ip) {
+					update = true
+					frontendIPConfigs = append(frontendIPConfigs, ip)
+				}
 			}
 		}
 
 		loadBalancingRules = *existingLB.LoadBalancingRules
+		// An HA ports rule can't share its frontend with the API server port rule, the rule that isn't wanted anymore
+		// is removed in the same update as the wanted one is added.
+		if rules, removed := removeLBRule(loadBalancingRules, unwantedAPIServerLBRuleName(*s)); removed {
+			update = true
+			loadBalancingRules = rules
+		}
 		for _, rule := range getLoadBalancingRules(*s, wantedFrontendIDs) {
 			if !lbRuleExists(loadBalancingRules, rule) {
 				update = true
 				loadBalancingRules = append(loadBalancingRules, rule)
+			} else {
+				if updateLBRuleProbe(loadBalancingRules, rule) {
+					update = true
+				}
+				if updateLBRuleTCPReset(loadBalancingRules, rule) {
+					update = true
+				}
 			}
 		}
 
@@ -109,6 +166,12 @@ func (s *LBSpec) Parameters(existing interface{}) (parameters interface{}, err e
 		}
 
 		outboundRules = *existingLB.OutboundRules
+		if s.DisableOutboundRule {
+			if rules, removed := removeOutboundRule(outboundRules, outboundNAT); removed {
+				update = true
+				outboundRules = rules
+			}
+		}
 		for _, rule := range getOutboundRules(*s, wantedFrontendIDs) {
 			if !outboundRuleExists(outboundRules, rule) {
 				update = true
@@ -124,40 +187,187 @@ func (s *LBSpec) Parameters(existing interface{}) (parameters interface{}, err e
 			}
 		}
 
+		// The rules and the probe of a load balancer fronting a service are those of the spec: the changed rules are
+		// updated and the removed ones are removed.
+		if s.Role == infrav1.InternalServiceRole {
+			wantedRules, wantedProbes := getLoadBalancingRules(*s, wantedFrontendIDs), getProbes(*s)
+			if !serviceRulesMatch(*existingLB.LoadBalancingRules, wantedRules) || !serviceProbesMatch(*existingLB.Probes, wantedProbes) {
+				update = true
+			}
+			loadBalancingRules, probes = wantedRules, wantedProbes
+		}
+
+		// The inbound NAT rules are only sent when the SSH NAT rule is wanted or has to be removed, the rules of each
+		// control plane machine are kept with it.
+		wantedNATRule := getSSHNATRule(*s, wantedFrontendIDs)
+		if wantedNATRule != nil || sshNATRuleExists(existingLB.InboundNatRules) {
+			rules, changed := withSSHNATRule(existingLB.InboundNatRules, wantedNATRule)
+			if changed {
+				update = true
+			}
+			inboundNatRules = &rules
+		}
+
+		// A load balancer created before the cluster UID tag existed is tagged with it.
+		if s.Shared == nil && s.ClusterUID != "" && to.String(existingLB.Tags[infrav1.ClusterUIDTagKey()]) != s.ClusterUID {
+			update = true
+		}
+
 		if !update {
 			// load balancer already exists with all required defaults
 			return nil, nil
 		}
 	} else {
+		if s.Shared != nil {
+			return nil, azure.WithTerminalError(errors.Errorf("shared load balancer %s not found in resource group %s", s.Name, s.ResourceGroup))
+		}
 		frontendIPConfigs, frontendIDs = getFrontendIPConfigs(*s)
 		loadBalancingRules = getLoadBalancingRules(*s, frontendIDs)
 		backendAddressPools = getBackendAddressPools(*s)
 		outboundRules = getOutboundRules(*s, frontendIDs)
 		probes = getProbes(*s)
+		if rule := getSSHNATRule(*s, frontendIDs); rule != nil {
+			inboundNatRules = &[]network.InboundNatRule{*rule}
+		}
 	}
 
-	lb := network.LoadBalancer{
-		Etag:     etag,
-		Sku:      &network.LoadBalancerSku{Name: converters.SKUtoSDK(s.SKU)},
-		Location: to.StringPtr(s.Location),
-		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+	if tags == nil {
+		tags = converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
 			ClusterName: s.ClusterName,
+			ClusterUID:  s.ClusterUID,
 			Lifecycle:   infrav1.ResourceLifecycleOwned,
 			Role:        to.StringPtr(s.Role),
 			Additional:  s.AdditionalTags,
-		})),
+		}))
+	}
+
+	lb := network.LoadBalancer{
+		Etag:     etag,
+		Sku:      &network.LoadBalancerSku{Name: converters.SKUtoSDK(s.SKU), Tier: network.LoadBalancerSkuTier(s.Tier)},
+		Location: to.StringPtr(s.Location),
+		Tags:     tags,
 		LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
 			FrontendIPConfigurations: &frontendIPConfigs,
 			BackendAddressPools:      &backendAddressPools,
 			OutboundRules:            &outboundRules,
 			Probes:                   &probes,
 			LoadBalancingRules:       &loadBalancingRules,
+			InboundNatRules:          inboundNatRules,
 		},
 	}
 
 	return lb, nil
 }
 
+// sharedLBCleanupSpec defines the removal of the load balancing rules, probes and backend pool of a cluster from a
+// shared load balancer, which is left in place for the other clusters sharing it.
+type sharedLBCleanupSpec struct {
+	*LBSpec
+}
+
+// Parameters returns the parameters of the shared load balancer without the rules, probes and backend pool of the
+// cluster, or nil if they were already removed.
+func (s *sharedLBCleanupSpec) Parameters(existing interface{}) (parameters interface{}, err error) {
+	if existing == nil {
+		return nil, nil
+	}
+	existingLB, ok := existing.(network.LoadBalancer)
+	if !ok {
+		return nil, errors.Errorf("%T is not a network.LoadBalancer", existing)
+	}
+	if existingLB.LoadBalancerPropertiesFormat == nil {
+		return nil, nil
+	}
+
+	update := false
+	loadBalancingRules := make([]network.LoadBalancingRule, 0)
+	if existingLB.LoadBalancingRules != nil {
+		for _, rule := range *existingLB.LoadBalancingRules {
+			name := to.String(rule.Name)
+			if name == clusterResourceName(*s.LBSpec, lbRuleHTTPS) || name == clusterResourceName(*s.LBSpec, lbRuleHAPorts) {
+				update = true
+				continue
+			}
+			loadBalancingRules = append(loadBalancingRules, rule)
+		}
+	}
+	probes := make([]network.Probe, 0)
+	if existingLB.Probes != nil {
+		for _, probe := range *existingLB.Probes {
+			name := to.String(probe.Name)
+			if name == clusterResourceName(*s.LBSpec, tcpProbe) || name == clusterResourceName(*s.LBSpec, httpsProbe) {
+				update = true
+				continue
+			}
+			probes = append(probes, probe)
+		}
+	}
+	backendAddressPools := make([]network.BackendAddressPool, 0)
+	if existingLB.BackendAddressPools != nil {
+		for _, pool := range *existingLB.BackendAddressPools {
+			if to.String(pool.Name) == s.BackendPoolName {
+				update = true
+				continue
+			}
+			backendAddressPools = append(backendAddressPools, pool)
+		}
+	}
+	tags := make(map[string]*string, len(existingLB.Tags))
+	for k, v := range existingLB.Tags {
+		if k == infrav1.ClusterTagKey(s.ClusterName) {
+			update = true
+			continue
+		}
+		tags[k] = v
+	}
+
+	if !update {
+		return nil, nil
+	}
+
+	return network.LoadBalancer{
+		Etag:     existingLB.Etag,
+		Sku:      existingLB.Sku,
+		Location: existingLB.Location,
+		Tags:     tags,
+		LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: existingLB.FrontendIPConfigurations,
+			BackendAddressPools:      &backendAddressPools,
+			OutboundRules:            existingLB.OutboundRules,
+			Probes:                   &probes,
+			LoadBalancingRules:       &loadBalancingRules,
+			InboundNatRules:          existingLB.InboundNatRules,
+		},
+	}, nil
+}
+
+// publicIPSpec defines a public IP of the Basic SKU of a frontend of a load balancer, which is only ever deleted to
+// recreate the load balancer with the Standard SKU.
+type publicIPSpec struct {
+	Name          string
+	ResourceGroup string
+}
+
+// ResourceName returns the name of the public IP.
+func (s *publicIPSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group of the public IP.
+func (s *publicIPSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for public IPs.
+func (s *publicIPSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns nil, the public IP is recreated by the public IPs service.
+func (s *publicIPSpec) Parameters(existing interface{}) (parameters interface{}, err error) {
+	return nil, nil
+}
+
 func getFrontendIPConfigs(lbSpec LBSpec) ([]network.FrontendIPConfiguration, []network.SubResource) {
 	frontendIPConfigurations := make([]network.FrontendIPConfiguration, 0)
 	frontendIDs := make([]network.SubResource, 0)
@@ -190,7 +400,8 @@ func getFrontendIPConfigs(lbSpec LBSpec) ([]network.FrontendIPConfiguration, []n
 }
 
 func getOutboundRules(lbSpec LBSpec, frontendIDs []network.SubResource) []network.OutboundRule {
-	if lbSpec.Type == infrav1.Internal {
+	// The outbound SNAT ports of a shared frontend can't be divided between the clusters sharing it.
+	if lbSpec.Type == infrav1.Internal || lbSpec.Shared != nil || lbSpec.DisableOutboundRule {
 		return []network.OutboundRule{}
 	}
 	return []network.OutboundRule{
@@ -216,15 +427,39 @@ func getLoadBalancingRules(lbSpec LBSpec, frontendIDs []network.SubResource) []n
 		if len(frontendIDs) != 0 {
 			frontendIPConfig = frontendIDs[0]
 		}
+		if isHAPortsRule(lbSpec) {
+			return []network.LoadBalancingRule{
+				{
+					Name: to.StringPtr(clusterResourceName(lbSpec, lbRuleHAPorts)),
+					LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+						Protocol:                network.TransportProtocolAll,
+						FrontendPort:            to.Int32Ptr(0),
+						BackendPort:             to.Int32Ptr(0),
+						IdleTimeoutInMinutes:    lbSpec.IdleTimeoutInMinutes,
+						EnableTCPReset:          lbSpec.EnableTCPReset,
+						EnableFloatingIP:        to.BoolPtr(false),
+						LoadDistribution:        network.LoadDistributionDefault,
+						FrontendIPConfiguration: &frontendIPConfig,
+						BackendAddressPool: &network.SubResource{
+							ID: to.StringPtr(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, lbSpec.BackendPoolName)),
+						},
+						Probe: &network.SubResource{
+							ID: to.StringPtr(azure.ProbeID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, apiServerProbeName(lbSpec))),
+						},
+					},
+				},
+			}
+		}
 		return []network.LoadBalancingRule{
 			{
-				Name: to.StringPtr(lbRuleHTTPS),
+				Name: to.StringPtr(clusterResourceName(lbSpec, lbRuleHTTPS)),
 				LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
 					DisableOutboundSnat:     to.BoolPtr(true),
 					Protocol:                network.TransportProtocolTCP,
-					FrontendPort:            to.Int32Ptr(lbSpec.APIServerPort),
+					FrontendPort:            to.Int32Ptr(frontendPort(lbSpec)),
 					BackendPort:             to.Int32Ptr(lbSpec.APIServerPort),
 					IdleTimeoutInMinutes:    lbSpec.IdleTimeoutInMinutes,
+					EnableTCPReset:          lbSpec.EnableTCPReset,
 					EnableFloatingIP:        to.BoolPtr(false),
 					LoadDistribution:        network.LoadDistributionDefault,
 					FrontendIPConfiguration: &frontendIPConfig,
@@ -232,15 +467,61 @@ func getLoadBalancingRules(lbSpec LBSpec, frontendIDs []network.SubResource) []n
 						ID: to.StringPtr(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, lbSpec.BackendPoolName)),
 					},
 					Probe: &network.SubResource{
-						ID: to.StringPtr(azure.ProbeID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, tcpProbe)),
+						ID: to.StringPtr(azure.ProbeID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, apiServerProbeName(lbSpec))),
 					},
 				},
 			},
 		}
 	}
+	if lbSpec.Role == infrav1.InternalServiceRole && len(frontendIDs) != 0 {
+		rules := make([]network.LoadBalancingRule, 0, len(lbSpec.ServiceRules))
+		for _, rule := range lbSpec.ServiceRules {
+			rules = append(rules, network.LoadBalancingRule{
+				Name: to.StringPtr(rule.Name),
+				LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+					Protocol:                network.TransportProtocol(rule.Protocol),
+					FrontendPort:            to.Int32Ptr(rule.FrontendPort),
+					BackendPort:             to.Int32Ptr(rule.BackendPort),
+					EnableFloatingIP:        to.BoolPtr(false),
+					LoadDistribution:        network.LoadDistributionDefault,
+					FrontendIPConfiguration: &frontendIDs[0],
+					BackendAddressPool: &network.SubResource{
+						ID: to.StringPtr(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, lbSpec.BackendPoolName)),
+					},
+					Probe: &network.SubResource{
+						ID: to.StringPtr(azure.ProbeID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, serviceRuleProbeName(lbSpec, rule))),
+					},
+				},
+			})
+		}
+		return rules
+	}
 	return []network.LoadBalancingRule{}
 }
 
+// getSSHNATRule returns the inbound NAT rule of version 2 forwarding the frontend port range of the SSH NAT rule to the
+// SSH port of the machines of the backend pool, or nil if the load balancer has no SSH NAT rule.
+func getSSHNATRule(lbSpec LBSpec, frontendIDs []network.SubResource) *network.InboundNatRule {
+	if lbSpec.SSHNATRule == nil || lbSpec.Type != infrav1.Public || len(frontendIDs) == 0 {
+		return nil
+	}
+	return &network.InboundNatRule{
+		Name: to.StringPtr(natRuleSSH),
+		InboundNatRulePropertiesFormat: &network.InboundNatRulePropertiesFormat{
+			FrontendIPConfiguration: &frontendIDs[0],
+			BackendAddressPool: &network.SubResource{
+				ID: to.StringPtr(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, lbSpec.BackendPoolName)),
+			},
+			Protocol:               network.TransportProtocolTCP,
+			FrontendPortRangeStart: to.Int32Ptr(lbSpec.SSHNATRule.FrontendPortRangeStart),
+			FrontendPortRangeEnd:   to.Int32Ptr(lbSpec.SSHNATRule.FrontendPortRangeEnd),
+			BackendPort:            to.Int32Ptr(22),
+			IdleTimeoutInMinutes:   to.Int32Ptr(4),
+			EnableFloatingIP:       to.BoolPtr(false),
+		},
+	}
+}
+
 func getBackendAddressPools(lbSpec LBSpec) []network.BackendAddressPool {
 	return []network.BackendAddressPool{
 		{
@@ -251,9 +532,24 @@ func getBackendAddressPools(lbSpec LBSpec) []network.BackendAddressPool {
 
 func getProbes(lbSpec LBSpec) []network.Probe {
 	if lbSpec.Role == infrav1.APIServerRole {
+		if isHTTPSProbe(lbSpec) {
+			// The probe doesn't validate the certificate of the API server, so the self-signed certificate is accepted.
+			return []network.Probe{
+				{
+					Name: to.StringPtr(clusterResourceName(lbSpec, httpsProbe)),
+					ProbePropertiesFormat: &network.ProbePropertiesFormat{
+						Protocol:          network.ProbeProtocolHTTPS,
+						Port:              to.Int32Ptr(lbSpec.APIServerPort),
+						RequestPath:       to.StringPtr(lbSpec.HealthProbe.RequestPath),
+						IntervalInSeconds: to.Int32Ptr(15),
+						NumberOfProbes:    to.Int32Ptr(4),
+					},
+				},
+			}
+		}
 		return []network.Probe{
 			{
-				Name: to.StringPtr(tcpProbe),
+				Name: to.StringPtr(clusterResourceName(lbSpec, tcpProbe)),
 				ProbePropertiesFormat: &network.ProbePropertiesFormat{
 					Protocol:          network.ProbeProtocolTCP,
 					Port:              to.Int32Ptr(lbSpec.APIServerPort),
@@ -263,9 +559,174 @@ func getProbes(lbSpec LBSpec) []network.Probe {
 			},
 		}
 	}
+	if lbSpec.Role == infrav1.InternalServiceRole {
+		probes := make([]network.Probe, 0, len(lbSpec.ServiceProbes))
+		for _, serviceProbe := range serviceProbes(lbSpec) {
+			probe := network.Probe{
+				Name: to.StringPtr(serviceProbe.Name),
+				ProbePropertiesFormat: &network.ProbePropertiesFormat{
+					Protocol:          network.ProbeProtocol(serviceProbe.Protocol),
+					Port:              to.Int32Ptr(serviceProbe.Port),
+					IntervalInSeconds: to.Int32Ptr(valueOrDefault(serviceProbe.IntervalInSeconds, infrav1.DefaultProbeIntervalInSeconds)),
+					NumberOfProbes:    to.Int32Ptr(valueOrDefault(serviceProbe.NumberOfProbes, infrav1.DefaultNumberOfProbes)),
+				},
+			}
+			if serviceProbe.Protocol != infrav1.ProbeProtocolTCP {
+				probe.RequestPath = to.StringPtr(serviceProbe.RequestPath)
+			}
+			probes = append(probes, probe)
+		}
+		return probes
+	}
 	return []network.Probe{}
 }
 
+// serviceProbes returns the health probes of a load balancer fronting a service: its named probes, or else its probe
+// shared by all the rules.
+func serviceProbes(lbSpec LBSpec) []infrav1.InternalLoadBalancerProbe {
+	if len(lbSpec.ServiceProbes) > 0 {
+		return lbSpec.ServiceProbes
+	}
+	if lbSpec.ServiceProbe == nil {
+		return nil
+	}
+	probe := *lbSpec.ServiceProbe
+	probe.Name = serviceProbe
+	return []infrav1.InternalLoadBalancerProbe{probe}
+}
+
+// serviceRuleProbeName returns the name of the health probe a rule of a load balancer fronting a service is bound to.
+func serviceRuleProbeName(lbSpec LBSpec, rule infrav1.InternalLoadBalancerRule) string {
+	switch {
+	case len(lbSpec.ServiceProbes) == 0:
+		return serviceProbe
+	case rule.ProbeName != "":
+		return rule.ProbeName
+	default:
+		return lbSpec.ServiceProbes[0].Name
+	}
+}
+
+// valueOrDefault returns the value, or the default value when it is unset.
+func valueOrDefault(value, defaultValue int32) int32 {
+	if value == 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// serviceRulesMatch returns true if the existing load balancing rules are the wanted rules of a load balancer fronting
+// a service.
+func serviceRulesMatch(existing, wanted []network.LoadBalancingRule) bool {
+	if len(existing) != len(wanted) {
+		return false
+	}
+	existingRules := make(map[string]network.LoadBalancingRule, len(existing))
+	for _, rule := range existing {
+		existingRules[to.String(rule.Name)] = rule
+	}
+	for _, rule := range wanted {
+		r, ok := existingRules[to.String(rule.Name)]
+		if !ok || r.LoadBalancingRulePropertiesFormat == nil || r.Probe == nil {
+			return false
+		}
+		if !strings.EqualFold(string(r.Protocol), string(rule.Protocol)) ||
+			to.Int32(r.FrontendPort) != to.Int32(rule.FrontendPort) ||
+			to.Int32(r.BackendPort) != to.Int32(rule.BackendPort) ||
+			!strings.EqualFold(to.String(r.Probe.ID), to.String(rule.Probe.ID)) {
+			return false
+		}
+	}
+	return true
+}
+
+// serviceProbesMatch returns true if the existing probes are the wanted probes of a load balancer fronting a service.
+func serviceProbesMatch(existing, wanted []network.Probe) bool {
+	if len(existing) != len(wanted) {
+		return false
+	}
+	for i, probe := range wanted {
+		p := existing[i]
+		if to.String(p.Name) != to.String(probe.Name) || p.ProbePropertiesFormat == nil {
+			return false
+		}
+		if !strings.EqualFold(string(p.Protocol), string(probe.Protocol)) ||
+			to.Int32(p.Port) != to.Int32(probe.Port) ||
+			to.String(p.RequestPath) != to.String(probe.RequestPath) ||
+			to.Int32(p.IntervalInSeconds) != to.Int32(probe.IntervalInSeconds) ||
+			to.Int32(p.NumberOfProbes) != to.Int32(probe.NumberOfProbes) {
+			return false
+		}
+	}
+	return true
+}
+
+// isHTTPSProbe returns true if the API server should be probed with HTTPS requests.
+// HTTPS probes are only supported by Standard load balancers, other SKUs fall back to a TCP probe since an HTTP probe
+// can't reach the TLS port of the API server.
+func isHTTPSProbe(lbSpec LBSpec) bool {
+	return lbSpec.HealthProbe != nil && lbSpec.HealthProbe.Protocol == infrav1.ProbeProtocolHTTPS && lbSpec.SKU == infrav1.SKUStandard
+}
+
+// isHAPortsRule returns true if the API server load balancer should forward all the ports and protocols with an HA
+// ports rule. HA ports rules are only supported by internal Standard load balancers.
+func isHAPortsRule(lbSpec LBSpec) bool {
+	return lbSpec.HAPorts != nil && lbSpec.HAPorts.Enabled && lbSpec.Type == infrav1.Internal && lbSpec.SKU == infrav1.SKUStandard
+}
+
+// unwantedAPIServerLBRuleName returns the name of the API server load balancing rule that conflicts with the wanted one:
+// the API server port rule when HA ports are enabled, and the HA ports rule otherwise.
+func unwantedAPIServerLBRuleName(lbSpec LBSpec) string {
+	if lbSpec.Role != infrav1.APIServerRole {
+		return ""
+	}
+	if isHAPortsRule(lbSpec) {
+		return clusterResourceName(lbSpec, lbRuleHTTPS)
+	}
+	return clusterResourceName(lbSpec, lbRuleHAPorts)
+}
+
+// apiServerProbeName returns the name of the health probe used by the API server load balancing rule.
+func apiServerProbeName(lbSpec LBSpec) string {
+	if isHTTPSProbe(lbSpec) {
+		return clusterResourceName(lbSpec, httpsProbe)
+	}
+	return clusterResourceName(lbSpec, tcpProbe)
+}
+
+// clusterResourceName returns the name of a rule or probe of the load balancer. The names of the rules and probes of
+// a shared load balancer are prefixed with the name of the cluster, so that each cluster only manages its own.
+func clusterResourceName(lbSpec LBSpec, name string) string {
+	if lbSpec.Shared == nil {
+		return name
+	}
+	return fmt.Sprintf("%s-%s", lbSpec.ClusterName, name)
+}
+
+// frontendPort returns the frontend port of the API server load balancing rule: the port of the cluster on a shared
+// load balancer, and the API server port otherwise.
+func frontendPort(lbSpec LBSpec) int32 {
+	if lbSpec.Shared != nil {
+		return lbSpec.Shared.FrontendPort
+	}
+	return lbSpec.APIServerPort
+}
+
+// isSharedWithCluster returns true if the tags of a shared load balancer mark it as shared with the cluster.
+func isSharedWithCluster(tags map[string]*string, clusterName string) bool {
+	return to.String(tags[infrav1.ClusterTagKey(clusterName)]) == string(infrav1.ResourceLifecycleShared)
+}
+
+// withSharedClusterTag returns a copy of the tags of a shared load balancer marking it as shared with the cluster.
+func withSharedClusterTag(tags map[string]*string, clusterName string) map[string]*string {
+	sharedTags := make(map[string]*string, len(tags)+1)
+	for k, v := range tags {
+		sharedTags[k] = v
+	}
+	sharedTags[infrav1.ClusterTagKey(clusterName)] = to.StringPtr(string(infrav1.ResourceLifecycleShared))
+	return sharedTags
+}
+
 func probeExists(probes []network.Probe, probe network.Probe) bool {
 	for _, p := range probes {
 		if to.String(p.Name) == to.String(probe.Name) {
@@ -284,6 +745,61 @@ func outboundRuleExists(rules []network.OutboundRule, rule network.OutboundRule)
 	return false
 }
 
+// removeOutboundRule returns the outbound rules without the rule with the given name, and whether it was found.
+func removeOutboundRule(rules []network.OutboundRule, name string) ([]network.OutboundRule, bool) {
+	for i, r := range rules {
+		if to.String(r.Name) == name {
+			return append(rules[:i:i], rules[i+1:]...), true
+		}
+	}
+	return rules, false
+}
+
+// sshNATRuleExists returns true if the inbound NAT rules include the SSH NAT rule.
+func sshNATRuleExists(rules *[]network.InboundNatRule) bool {
+	if rules == nil {
+		return false
+	}
+	for _, r := range *rules {
+		if to.String(r.Name) == natRuleSSH {
+			return true
+		}
+	}
+	return false
+}
+
+// withSSHNATRule returns the inbound NAT rules with the SSH NAT rule added, updated to the wanted port range, or
+// removed if it isn't wanted, and whether the rules changed.
+func withSSHNATRule(existing *[]network.InboundNatRule, wanted *network.InboundNatRule) ([]network.InboundNatRule, bool) {
+	rules := make([]network.InboundNatRule, 0)
+	found, changed := false, false
+	if existing != nil {
+		for _, r := range *existing {
+			if to.String(r.Name) != natRuleSSH {
+				rules = append(rules, r)
+				continue
+			}
+			found = true
+			switch {
+			case wanted == nil:
+				changed = true
+			case r.InboundNatRulePropertiesFormat == nil ||
+				to.Int32(r.FrontendPortRangeStart) != to.Int32(wanted.FrontendPortRangeStart) ||
+				to.Int32(r.FrontendPortRangeEnd) != to.Int32(wanted.FrontendPortRangeEnd):
+				changed = true
+				rules = append(rules, *wanted)
+			default:
+				rules = append(rules, r)
+			}
+		}
+	}
+	if !found && wanted != nil {
+		changed = true
+		rules = append(rules, *wanted)
+	}
+	return rules, changed
+}
+
 func poolExists(pools []network.BackendAddressPool, pool network.BackendAddressPool) bool {
 	for _, p := range pools {
 		if to.String(p.Name) == to.String(pool.Name) {
@@ -302,6 +818,57 @@ func lbRuleExists(rules []network.LoadBalancingRule, rule network.LoadBalancingR
 	return false
 }
 
+// removeLBRule removes the rule with the given name from the rules. It returns true if the rule was found.
+func removeLBRule(rules []network.LoadBalancingRule, name string) ([]network.LoadBalancingRule, bool) {
+	if name == "" {
+		return rules, false
+	}
+	for i, r := range rules {
+		if to.String(r.Name) == name {
+			return append(rules[:i:i], rules[i+1:]...), true
+		}
+	}
+	return rules, false
+}
+
+// updateLBRuleProbe makes the existing rule with the same name as the desired rule use the probe of the desired rule.
+// It returns true if the existing rule was updated.
+func updateLBRuleProbe(rules []network.LoadBalancingRule, rule network.LoadBalancingRule) bool {
+	if rule.LoadBalancingRulePropertiesFormat == nil || rule.Probe == nil {
+		return false
+	}
+	for _, r := range rules {
+		if to.String(r.Name) != to.String(rule.Name) || r.LoadBalancingRulePropertiesFormat == nil {
+			continue
+		}
+		if r.Probe != nil && strings.EqualFold(to.String(r.Probe.ID), to.String(rule.Probe.ID)) {
+			return false
+		}
+		r.Probe = rule.Probe
+		return true
+	}
+	return false
+}
+
+// updateLBRuleTCPReset makes the existing rule with the same name as the desired rule use the TCP reset setting of the
+// desired rule, if it has one. It returns true if the existing rule was updated.
+func updateLBRuleTCPReset(rules []network.LoadBalancingRule, rule network.LoadBalancingRule) bool {
+	if rule.LoadBalancingRulePropertiesFormat == nil || rule.EnableTCPReset == nil {
+		return false
+	}
+	for _, r := range rules {
+		if to.String(r.Name) != to.String(rule.Name) || r.LoadBalancingRulePropertiesFormat == nil {
+			continue
+		}
+		if to.Bool(r.EnableTCPReset) == to.Bool(rule.EnableTCPReset) {
+			return false
+		}
+		r.EnableTCPReset = rule.EnableTCPReset
+		return true
+	}
+	return false
+}
+
 func ipExists(configs []network.FrontendIPConfiguration, config network.FrontendIPConfiguration) bool {
 	for _, ip := range configs {
 		if to.String(ip.Name) == to.String(config.Name) {
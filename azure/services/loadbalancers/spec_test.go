@@ -19,7 +19,7 @@ package loadbalancers
 import (
 	"testing"
 
-	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-03-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
 	. "github.com/onsi/gomega"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -60,7 +60,125 @@ func getExistingLBWithMissingOutboundRules() network.LoadBalancer {
 	return existingLB
 }
 
+func getExistingLBWithHTTPSProbe() network.LoadBalancer {
+	existingLB := newSamplePublicAPIServerLB(false, false, false, false, false)
+	(*existingLB.LoadBalancingRules)[0].Probe = &network.SubResource{
+		ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/probes/HTTPSProbe"),
+	}
+	probes := append(*existingLB.Probes, network.Probe{
+		Name: to.StringPtr(httpsProbe),
+		ProbePropertiesFormat: &network.ProbePropertiesFormat{
+			Protocol:          network.ProbeProtocolHTTPS,
+			Port:              to.Int32Ptr(6443),
+			RequestPath:       to.StringPtr("/readyz"),
+			IntervalInSeconds: to.Int32Ptr(15),
+			NumberOfProbes:    to.Int32Ptr(4),
+		},
+	})
+	existingLB.Probes = &probes
+
+	return existingLB
+}
+
+func getExistingLBWithSSHNATRule(start, end int32) network.LoadBalancer {
+	existingLB := newSamplePublicAPIServerLB(false, false, false, false, false)
+	existingLB.InboundNatRules = &[]network.InboundNatRule{
+		{
+			Name: to.StringPtr("my-machine-1"),
+			InboundNatRulePropertiesFormat: &network.InboundNatRulePropertiesFormat{
+				FrontendPort: to.Int32Ptr(22),
+				BackendPort:  to.Int32Ptr(22),
+			},
+		},
+		{
+			Name: to.StringPtr(natRuleSSH),
+			InboundNatRulePropertiesFormat: &network.InboundNatRulePropertiesFormat{
+				FrontendIPConfiguration: &network.SubResource{
+					ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/frontendIPConfigurations/my-publiclb-frontEnd"),
+				},
+				BackendAddressPool: &network.SubResource{
+					ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/backendAddressPools/my-publiclb-backendPool"),
+				},
+				Protocol:               network.TransportProtocolTCP,
+				FrontendPortRangeStart: to.Int32Ptr(start),
+				FrontendPortRangeEnd:   to.Int32Ptr(end),
+				BackendPort:            to.Int32Ptr(22),
+				IdleTimeoutInMinutes:   to.Int32Ptr(4),
+				EnableFloatingIP:       to.BoolPtr(false),
+			},
+		},
+	}
+
+	return existingLB
+}
+
+func getExistingInternalLBWithHAPortsRule() network.LoadBalancer {
+	existingLB := newDefaultInternalAPIServerLB()
+	existingLB.LoadBalancingRules = &[]network.LoadBalancingRule{
+		{
+			Name: to.StringPtr(lbRuleHAPorts),
+			LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+				Protocol:             network.TransportProtocolAll,
+				FrontendPort:         to.Int32Ptr(0),
+				BackendPort:          to.Int32Ptr(0),
+				IdleTimeoutInMinutes: to.Int32Ptr(4),
+				EnableFloatingIP:     to.BoolPtr(false),
+				LoadDistribution:     network.LoadDistributionDefault,
+				FrontendIPConfiguration: &network.SubResource{
+					ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-private-lb/frontendIPConfigurations/my-private-lb-frontEnd"),
+				},
+				BackendAddressPool: &network.SubResource{
+					ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-private-lb/backendAddressPools/my-private-lb-backendPool"),
+				},
+				Probe: &network.SubResource{
+					ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-private-lb/probes/TCPProbe"),
+				},
+			},
+		},
+	}
+
+	return existingLB
+}
+
+func getExistingLBWithTCPReset() network.LoadBalancer {
+	existingLB := newSamplePublicAPIServerLB(false, false, false, false, false)
+	(*existingLB.LoadBalancingRules)[0].EnableTCPReset = to.BoolPtr(true)
+
+	return existingLB
+}
+
 func TestParameters(t *testing.T) {
+	httpsProbeLBSpec := fakePublicAPILBSpec
+	httpsProbeLBSpec.HealthProbe = &infrav1.HealthProbe{
+		Protocol:    infrav1.ProbeProtocolHTTPS,
+		RequestPath: "/readyz",
+	}
+
+	haPortsLBSpec := fakeInternalAPILBSpec
+	haPortsLBSpec.HAPorts = &infrav1.HAPorts{Enabled: true}
+
+	natGatewayEgressLBSpec := fakePublicAPILBSpec
+	natGatewayEgressLBSpec.DisableOutboundRule = true
+
+	sshNATRuleLBSpec := fakePublicAPILBSpec
+	sshNATRuleLBSpec.SSHNATRule = &infrav1.SSHNATRule{FrontendPortRangeStart: 50000, FrontendPortRangeEnd: 50100}
+
+	tcpResetLBSpec := fakePublicAPILBSpec
+	tcpResetLBSpec.EnableTCPReset = to.BoolPtr(true)
+
+	regionalLBSpec := fakePublicAPILBSpec
+	regionalLBSpec.Tier = infrav1.LoadBalancerTierRegional
+	globalTierLB := newSamplePublicAPIServerLB(false, false, false, false, false)
+	globalTierLB.Sku = &network.LoadBalancerSku{Name: network.LoadBalancerSkuNameStandard, Tier: network.LoadBalancerSkuTierGlobal}
+
+	basicSKULB := newSamplePublicAPIServerLB(false, false, false, false, false)
+	basicSKULB.Sku = &network.LoadBalancerSku{Name: network.LoadBalancerSkuNameBasic}
+
+	clusterUIDLBSpec := fakeNodeOutboundLBSpec
+	clusterUIDLBSpec.ClusterUID = "uid-1"
+	clusterUIDLB := newDefaultNodeOutboundLB()
+	clusterUIDLB.Tags[infrav1.ClusterUIDTagKey()] = to.StringPtr("uid-1")
+
 	testcases := []struct {
 		name          string
 		spec          *LBSpec
@@ -77,6 +195,24 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "public API load balancer exists in another tier",
+			spec:     &regionalLBSpec,
+			existing: globalTierLB,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: load balancer my-publiclb is of the Global tier instead of Regional, the tier of a load balancer can't be changed. Object will not be requeued",
+		},
+		{
+			name:     "public API load balancer exists with the Basic SKU",
+			spec:     &fakePublicAPILBSpec,
+			existing: basicSKULB,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: load balancer my-publiclb has the Basic SKU instead of Standard, which Azure can't change in place: set forceRecreate on the load balancer for it to be deleted and recreated with the Standard SKU along with the public IPs of its frontends, which get new addresses, or migrate them manually. Object will not be requeued",
+		},
 		{
 			name:     "internal API load balancer with all expected values",
 			spec:     &fakeInternalAPILBSpec,
@@ -95,6 +231,101 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "node outbound load balancer exists with all expected values and the cluster UID tag",
+			spec:     &clusterUIDLBSpec,
+			existing: clusterUIDLB,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "node outbound load balancer exists without the cluster UID tag",
+			spec:     &clusterUIDLBSpec,
+			existing: newDefaultNodeOutboundLB(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				g.Expect(result.(network.LoadBalancer).Tags).To(HaveKeyWithValue(infrav1.ClusterUIDTagKey(), to.StringPtr("uid-1")))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "public API load balancer exists with a TCP probe instead of an HTTPS probe",
+			spec:     &httpsProbeLBSpec,
+			existing: newSamplePublicAPIServerLB(false, false, false, false, false),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				g.Expect(result.(network.LoadBalancer)).To(Equal(getExistingLBWithHTTPSProbe()))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "public API load balancer exists with the expected HTTPS probe",
+			spec:     &httpsProbeLBSpec,
+			existing: getExistingLBWithHTTPSProbe(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "public API load balancer exists without TCP reset on its rule",
+			spec:     &tcpResetLBSpec,
+			existing: newSamplePublicAPIServerLB(false, false, false, false, false),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				g.Expect(result.(network.LoadBalancer)).To(Equal(getExistingLBWithTCPReset()))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "public API load balancer exists with TCP reset on its rule",
+			spec:     &tcpResetLBSpec,
+			existing: getExistingLBWithTCPReset(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "public API load balancer exists with TCP reset on its rule while it isn't configured",
+			spec:     &fakePublicAPILBSpec,
+			existing: getExistingLBWithTCPReset(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "internal API load balancer exists with an API server port rule instead of an HA ports rule",
+			spec:     &haPortsLBSpec,
+			existing: newDefaultInternalAPIServerLB(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				g.Expect(result.(network.LoadBalancer)).To(Equal(getExistingInternalLBWithHAPortsRule()))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "internal API load balancer exists with the expected HA ports rule",
+			spec:     &haPortsLBSpec,
+			existing: getExistingInternalLBWithHAPortsRule(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "internal API load balancer exists with an HA ports rule that is not wanted anymore",
+			spec:     &fakeInternalAPILBSpec,
+			existing: getExistingInternalLBWithHAPortsRule(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				g.Expect(result.(network.LoadBalancer)).To(Equal(newDefaultInternalAPIServerLB()))
+			},
+			expectedError: "",
+		},
 		{
 			name:     "load balancer exists with missing frontend IP configs",
 			spec:     &fakePublicAPILBSpec,
@@ -145,6 +376,98 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "public API load balancer exists with an outbound rule while the control plane egress goes through a NAT gateway",
+			spec:     &natGatewayEgressLBSpec,
+			existing: newSamplePublicAPIServerLB(false, false, false, false, false),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				expected := newSamplePublicAPIServerLB(false, false, false, false, false)
+				expected.OutboundRules = &[]network.OutboundRule{}
+				g.Expect(result.(network.LoadBalancer)).To(Equal(expected))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "public API load balancer exists without outbound rule while the control plane egress goes through a NAT gateway",
+			spec:     &natGatewayEgressLBSpec,
+			existing: getExistingLBWithMissingOutboundRules(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "public API load balancer exists without the wanted SSH NAT rule",
+			spec:     &sshNATRuleLBSpec,
+			existing: newSamplePublicAPIServerLB(false, false, false, false, false),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				expected := getExistingLBWithSSHNATRule(50000, 50100)
+				expected.InboundNatRules = &[]network.InboundNatRule{(*expected.InboundNatRules)[1]}
+				g.Expect(result.(network.LoadBalancer)).To(Equal(expected))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "public API load balancer exists with the wanted SSH NAT rule",
+			spec:     &sshNATRuleLBSpec,
+			existing: getExistingLBWithSSHNATRule(50000, 50100),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "public API load balancer exists with an SSH NAT rule of another port range",
+			spec:     &sshNATRuleLBSpec,
+			existing: getExistingLBWithSSHNATRule(40000, 40100),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				g.Expect(result.(network.LoadBalancer)).To(Equal(getExistingLBWithSSHNATRule(50000, 50100)))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "public API load balancer exists with an SSH NAT rule that is not wanted anymore",
+			spec:     &fakePublicAPILBSpec,
+			existing: getExistingLBWithSSHNATRule(50000, 50100),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				expected := getExistingLBWithSSHNATRule(50000, 50100)
+				expected.InboundNatRules = &[]network.InboundNatRule{(*expected.InboundNatRules)[0]}
+				g.Expect(result.(network.LoadBalancer)).To(Equal(expected))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "shared load balancer without the configuration of the cluster",
+			spec:     &fakeSharedAPILBSpec,
+			existing: newSharedLB(false),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				g.Expect(result.(network.LoadBalancer)).To(Equal(newSharedLB(true)))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "shared load balancer with the configuration of the cluster",
+			spec:     &fakeSharedAPILBSpec,
+			existing: newSharedLB(true),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "shared load balancer not found",
+			spec:     &fakeSharedAPILBSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: shared load balancer shared-lb not found in resource group my-rg. Object will not be requeued",
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -374,3 +697,393 @@ func newDefaultInternalAPIServerLB() network.LoadBalancer {
 		},
 	}
 }
+
+// newSharedLB returns a load balancer shared with another cluster, and with the cluster of fakeSharedAPILBSpec if
+// withCluster is true.
+func newSharedLB(withCluster bool) network.LoadBalancer {
+	lbID := "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/shared-lb"
+	lb := network.LoadBalancer{
+		Etag: to.StringPtr("fake-etag"),
+		Tags: map[string]*string{
+			"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": to.StringPtr("shared"),
+			"owner": to.StringPtr("platform-team"),
+		},
+		Sku:      &network.LoadBalancerSku{Name: network.LoadBalancerSkuNameStandard},
+		Location: to.StringPtr("my-location"),
+		LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: &[]network.FrontendIPConfiguration{
+				{
+					ID:   to.StringPtr(lbID + "/frontendIPConfigurations/shared-lb-frontEnd"),
+					Name: to.StringPtr("shared-lb-frontEnd"),
+					FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+						PublicIPAddress: &network.PublicIPAddress{
+							ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/shared-ip"),
+						},
+					},
+				},
+			},
+			BackendAddressPools: &[]network.BackendAddressPool{
+				{
+					Name: to.StringPtr("other-cluster-backendPool"),
+				},
+			},
+			LoadBalancingRules: &[]network.LoadBalancingRule{
+				{
+					Name: to.StringPtr("other-cluster-LBRuleHTTPS"),
+					LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+						Protocol:     network.TransportProtocolTCP,
+						FrontendPort: to.Int32Ptr(6443),
+						BackendPort:  to.Int32Ptr(6443),
+						FrontendIPConfiguration: &network.SubResource{
+							ID: to.StringPtr(lbID + "/frontendIPConfigurations/shared-lb-frontEnd"),
+						},
+						BackendAddressPool: &network.SubResource{
+							ID: to.StringPtr(lbID + "/backendAddressPools/other-cluster-backendPool"),
+						},
+						Probe: &network.SubResource{
+							ID: to.StringPtr(lbID + "/probes/other-cluster-TCPProbe"),
+						},
+					},
+				},
+			},
+			OutboundRules: &[]network.OutboundRule{},
+			Probes: &[]network.Probe{
+				{
+					Name: to.StringPtr("other-cluster-TCPProbe"),
+					ProbePropertiesFormat: &network.ProbePropertiesFormat{
+						Protocol: network.ProbeProtocolTCP,
+						Port:     to.Int32Ptr(6443),
+					},
+				},
+			},
+			InboundNatRules: &[]network.InboundNatRule{
+				{
+					Name: to.StringPtr("other-cluster-control-plane-0"),
+				},
+			},
+		},
+	}
+	if !withCluster {
+		return lb
+	}
+
+	lb.Tags["sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster"] = to.StringPtr("shared")
+	pools := append(*lb.BackendAddressPools, network.BackendAddressPool{
+		Name: to.StringPtr("my-cluster-backendPool"),
+	})
+	lb.BackendAddressPools = &pools
+	rules := append(*lb.LoadBalancingRules, network.LoadBalancingRule{
+		Name: to.StringPtr("my-cluster-LBRuleHTTPS"),
+		LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+			DisableOutboundSnat:  to.BoolPtr(true),
+			Protocol:             network.TransportProtocolTCP,
+			FrontendPort:         to.Int32Ptr(6444),
+			BackendPort:          to.Int32Ptr(6443),
+			IdleTimeoutInMinutes: to.Int32Ptr(4),
+			EnableFloatingIP:     to.BoolPtr(false),
+			LoadDistribution:     network.LoadDistributionDefault,
+			FrontendIPConfiguration: &network.SubResource{
+				ID: to.StringPtr(lbID + "/frontendIPConfigurations/shared-lb-frontEnd"),
+			},
+			BackendAddressPool: &network.SubResource{
+				ID: to.StringPtr(lbID + "/backendAddressPools/my-cluster-backendPool"),
+			},
+			Probe: &network.SubResource{
+				ID: to.StringPtr(lbID + "/probes/my-cluster-TCPProbe"),
+			},
+		},
+	})
+	lb.LoadBalancingRules = &rules
+	probes := append(*lb.Probes, network.Probe{
+		Name: to.StringPtr("my-cluster-TCPProbe"),
+		ProbePropertiesFormat: &network.ProbePropertiesFormat{
+			Protocol:          network.ProbeProtocolTCP,
+			Port:              to.Int32Ptr(6443),
+			IntervalInSeconds: to.Int32Ptr(15),
+			NumberOfProbes:    to.Int32Ptr(4),
+		},
+	})
+	lb.Probes = &probes
+	return lb
+}
+
+func TestSharedLBCleanupParameters(t *testing.T) {
+	testcases := []struct {
+		name     string
+		existing interface{}
+		expect   func(g *WithT, result interface{})
+	}{
+		{
+			name:     "shared load balancer with the configuration of the cluster",
+			existing: newSharedLB(true),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				g.Expect(result.(network.LoadBalancer)).To(Equal(newSharedLB(false)))
+			},
+		},
+		{
+			name:     "shared load balancer without the configuration of the cluster",
+			existing: newSharedLB(false),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "shared load balancer not found",
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := (&sharedLBCleanupSpec{LBSpec: &fakeSharedAPILBSpec}).Parameters(tc.existing)
+			g.Expect(err).NotTo(HaveOccurred())
+			tc.expect(g, result)
+		})
+	}
+}
+
+func TestInternalServiceLBParameters(t *testing.T) {
+	serviceLBSpec := LBSpec{
+		Name:              "my-ingress-lb",
+		ResourceGroup:     "my-rg",
+		SubscriptionID:    "123",
+		ClusterName:       "my-cluster",
+		Location:          "my-location",
+		Role:              infrav1.InternalServiceRole,
+		Type:              infrav1.Internal,
+		SKU:               infrav1.SKUStandard,
+		VNetName:          "my-vnet",
+		VNetResourceGroup: "my-vnet-rg",
+		SubnetName:        "my-node-subnet",
+		BackendPoolName:   "my-ingress-lb-backendPool",
+		FrontendIPConfigs: []infrav1.FrontendIP{
+			{
+				Name: "my-ingress-lb-frontEnd",
+				FrontendIPClass: infrav1.FrontendIPClass{
+					PrivateIPAddress: "10.1.0.100",
+				},
+			},
+		},
+		ServiceRules: []infrav1.InternalLoadBalancerRule{
+			{Name: "http", Protocol: infrav1.LoadBalancerRuleProtocolTCP, FrontendPort: 80, BackendPort: 30080},
+			{Name: "https", Protocol: infrav1.LoadBalancerRuleProtocolTCP, FrontendPort: 443, BackendPort: 30443},
+		},
+		ServiceProbe: &infrav1.InternalLoadBalancerProbe{Protocol: infrav1.ProbeProtocolHTTP, Port: 30080, RequestPath: "/healthz"},
+	}
+
+	g := NewWithT(t)
+	created, err := serviceLBSpec.Parameters(nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(created).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+	lb := created.(network.LoadBalancer)
+	g.Expect(*lb.LoadBalancingRules).To(HaveLen(2))
+	g.Expect(lb.Tags[infrav1.NameAzureClusterAPIRole]).To(Equal(to.StringPtr(infrav1.InternalServiceRole)))
+	rule := (*lb.LoadBalancingRules)[1]
+	g.Expect(to.String(rule.Name)).To(Equal("https"))
+	g.Expect(rule.Protocol).To(Equal(network.TransportProtocolTCP))
+	g.Expect(to.Int32(rule.FrontendPort)).To(Equal(int32(443)))
+	g.Expect(to.Int32(rule.BackendPort)).To(Equal(int32(30443)))
+	g.Expect(to.String(rule.FrontendIPConfiguration.ID)).To(Equal("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-ingress-lb/frontendIPConfigurations/my-ingress-lb-frontEnd"))
+	g.Expect(to.String(rule.Probe.ID)).To(Equal("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-ingress-lb/probes/ServiceProbe"))
+	g.Expect(*lb.Probes).To(HaveLen(1))
+	g.Expect((*lb.Probes)[0].Protocol).To(Equal(network.ProbeProtocolHTTP))
+	g.Expect(to.String((*lb.Probes)[0].RequestPath)).To(Equal("/healthz"))
+	g.Expect(*lb.OutboundRules).To(BeEmpty())
+
+	removedRuleSpec := serviceLBSpec
+	removedRuleSpec.ServiceRules = serviceLBSpec.ServiceRules[:1]
+
+	changedRuleSpec := serviceLBSpec
+	changedRuleSpec.ServiceRules = []infrav1.InternalLoadBalancerRule{
+		serviceLBSpec.ServiceRules[0],
+		{Name: "https", Protocol: infrav1.LoadBalancerRuleProtocolTCP, FrontendPort: 443, BackendPort: 31443},
+	}
+
+	tcpProbeSpec := serviceLBSpec
+	tcpProbeSpec.ServiceProbe = &infrav1.InternalLoadBalancerProbe{Protocol: infrav1.ProbeProtocolTCP, Port: 30080}
+
+	testcases := []struct {
+		name   string
+		spec   *LBSpec
+		expect func(g *WithT, result interface{})
+	}{
+		{
+			name: "internal service load balancer exists with all expected values",
+			spec: &serviceLBSpec,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "internal service load balancer exists with a rule removed from the spec",
+			spec: &removedRuleSpec,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				updated := result.(network.LoadBalancer)
+				g.Expect(*updated.LoadBalancingRules).To(HaveLen(1))
+				g.Expect(to.String((*updated.LoadBalancingRules)[0].Name)).To(Equal("http"))
+			},
+		},
+		{
+			name: "internal service load balancer exists with a rule of another backend port",
+			spec: &changedRuleSpec,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				updated := result.(network.LoadBalancer)
+				g.Expect(*updated.LoadBalancingRules).To(HaveLen(2))
+				g.Expect(to.Int32((*updated.LoadBalancingRules)[1].BackendPort)).To(Equal(int32(31443)))
+			},
+		},
+		{
+			name: "internal service load balancer exists with a probe of another protocol",
+			spec: &tcpProbeSpec,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				updated := result.(network.LoadBalancer)
+				g.Expect(*updated.Probes).To(HaveLen(1))
+				g.Expect((*updated.Probes)[0].Protocol).To(Equal(network.ProbeProtocolTCP))
+				g.Expect((*updated.Probes)[0].RequestPath).To(BeNil())
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			result, err := tc.spec.Parameters(lb)
+			g.Expect(err).NotTo(HaveOccurred())
+			tc.expect(g, result)
+		})
+	}
+}
+
+func TestInternalServiceLBParametersWithNamedProbes(t *testing.T) {
+	serviceLBSpec := LBSpec{
+		Name:              "my-ingress-lb",
+		ResourceGroup:     "my-rg",
+		SubscriptionID:    "123",
+		ClusterName:       "my-cluster",
+		Location:          "my-location",
+		Role:              infrav1.InternalServiceRole,
+		Type:              infrav1.Internal,
+		SKU:               infrav1.SKUStandard,
+		VNetName:          "my-vnet",
+		VNetResourceGroup: "my-vnet-rg",
+		SubnetName:        "my-node-subnet",
+		BackendPoolName:   "my-ingress-lb-backendPool",
+		FrontendIPConfigs: []infrav1.FrontendIP{
+			{
+				Name: "my-ingress-lb-frontEnd",
+				FrontendIPClass: infrav1.FrontendIPClass{
+					PrivateIPAddress: "10.1.0.100",
+				},
+			},
+		},
+		ServiceRules: []infrav1.InternalLoadBalancerRule{
+			{Name: "http", Protocol: infrav1.LoadBalancerRuleProtocolTCP, FrontendPort: 80, BackendPort: 30080, ProbeName: "liveness"},
+			{Name: "https", Protocol: infrav1.LoadBalancerRuleProtocolTCP, FrontendPort: 443, BackendPort: 30443, ProbeName: "readiness"},
+		},
+		ServiceProbes: []infrav1.InternalLoadBalancerProbe{
+			{Name: "liveness", Protocol: infrav1.ProbeProtocolTCP, Port: 30080},
+			{Name: "readiness", Protocol: infrav1.ProbeProtocolHTTPS, Port: 30443, RequestPath: "/readyz", IntervalInSeconds: 5, NumberOfProbes: 2},
+		},
+	}
+
+	g := NewWithT(t)
+	created, err := serviceLBSpec.Parameters(nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(created).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+	lb := created.(network.LoadBalancer)
+	g.Expect(*lb.Probes).To(HaveLen(2))
+	liveness, readiness := (*lb.Probes)[0], (*lb.Probes)[1]
+	g.Expect(to.String(liveness.Name)).To(Equal("liveness"))
+	g.Expect(liveness.Protocol).To(Equal(network.ProbeProtocolTCP))
+	g.Expect(liveness.RequestPath).To(BeNil())
+	g.Expect(to.Int32(liveness.IntervalInSeconds)).To(Equal(int32(infrav1.DefaultProbeIntervalInSeconds)))
+	g.Expect(to.Int32(liveness.NumberOfProbes)).To(Equal(int32(infrav1.DefaultNumberOfProbes)))
+	g.Expect(to.String(readiness.Name)).To(Equal("readiness"))
+	g.Expect(readiness.Protocol).To(Equal(network.ProbeProtocolHTTPS))
+	g.Expect(to.String(readiness.RequestPath)).To(Equal("/readyz"))
+	g.Expect(to.Int32(readiness.IntervalInSeconds)).To(Equal(int32(5)))
+	g.Expect(to.Int32(readiness.NumberOfProbes)).To(Equal(int32(2)))
+	g.Expect(*lb.LoadBalancingRules).To(HaveLen(2))
+	g.Expect(to.String((*lb.LoadBalancingRules)[0].Probe.ID)).To(Equal("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-ingress-lb/probes/liveness"))
+	g.Expect(to.String((*lb.LoadBalancingRules)[1].Probe.ID)).To(Equal("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-ingress-lb/probes/readiness"))
+
+	reboundRuleSpec := serviceLBSpec
+	reboundRuleSpec.ServiceRules = []infrav1.InternalLoadBalancerRule{
+		serviceLBSpec.ServiceRules[0],
+		{Name: "https", Protocol: infrav1.LoadBalancerRuleProtocolTCP, FrontendPort: 443, BackendPort: 30443, ProbeName: "liveness"},
+	}
+
+	removedProbeSpec := reboundRuleSpec
+	removedProbeSpec.ServiceProbes = serviceLBSpec.ServiceProbes[:1]
+
+	changedIntervalSpec := serviceLBSpec
+	changedIntervalSpec.ServiceProbes = []infrav1.InternalLoadBalancerProbe{
+		serviceLBSpec.ServiceProbes[0],
+		{Name: "readiness", Protocol: infrav1.ProbeProtocolHTTPS, Port: 30443, RequestPath: "/readyz", IntervalInSeconds: 10, NumberOfProbes: 2},
+	}
+
+	testcases := []struct {
+		name   string
+		spec   *LBSpec
+		expect func(g *WithT, result interface{})
+	}{
+		{
+			name: "internal service load balancer exists with all expected probes",
+			spec: &serviceLBSpec,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "internal service load balancer exists with a rule bound to another probe",
+			spec: &reboundRuleSpec,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				updated := result.(network.LoadBalancer)
+				g.Expect(*updated.LoadBalancingRules).To(HaveLen(2))
+				g.Expect(to.String((*updated.LoadBalancingRules)[1].Probe.ID)).To(HaveSuffix("/probes/liveness"))
+			},
+		},
+		{
+			name: "internal service load balancer exists with a probe removed from the spec",
+			spec: &removedProbeSpec,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				updated := result.(network.LoadBalancer)
+				g.Expect(*updated.Probes).To(HaveLen(1))
+				g.Expect(to.String((*updated.Probes)[0].Name)).To(Equal("liveness"))
+			},
+		},
+		{
+			name: "internal service load balancer exists with a probe of another interval",
+			spec: &changedIntervalSpec,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				updated := result.(network.LoadBalancer)
+				g.Expect(*updated.Probes).To(HaveLen(2))
+				g.Expect(to.Int32((*updated.Probes)[1].IntervalInSeconds)).To(Equal(int32(10)))
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			result, err := tc.spec.Parameters(lb)
+			g.Expect(err).NotTo(HaveOccurred())
+			tc.expect(g, result)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package locations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2021-01-01/subscriptions"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/cache/ttllru"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// Cache loads the locations available to a subscription, along with their metadata, e.g. their paired regions.
+type Cache struct {
+	client Client
+
+	// mu synchronizes the access to data, as the cache is shared across concurrent reconciles.
+	mu sync.Mutex
+
+	// data is the cached location information from Azure.
+	data []subscriptions.Location
+}
+
+// LocationNotAvailableError is returned when a location is not a region available to the subscription.
+type LocationNotAvailableError struct {
+	Location string
+}
+
+// Error returns the error string.
+func (e LocationNotAvailableError) Error() string {
+	return fmt.Sprintf("location %s is not available to the subscription", e.Location)
+}
+
+// IsLocationNotAvailable returns true if the error reports a location that is not available to the subscription, as
+// opposed to a failure to list the locations.
+func IsLocationNotAvailable(err error) bool {
+	return errors.As(err, &LocationNotAvailableError{})
+}
+
+// Cacher describes the ability to get and to add items to cache.
+type Cacher interface {
+	Get(key interface{}) (value interface{}, ok bool)
+	Add(key interface{}, value interface{}) bool
+}
+
+var (
+	doOnce      sync.Once
+	clientCache Cacher
+)
+
+// GetCache either creates a new locations cache or returns an existing one based on the Authorizer HashKey().
+func GetCache(auth azure.Authorizer) (*Cache, error) {
+	var err error
+	doOnce.Do(func() {
+		clientCache, err = ttllru.New(128, 24*time.Hour)
+	})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating LRU cache for locations cache")
+	}
+
+	key := auth.HashKey()
+	c, ok := clientCache.Get(key)
+	if ok {
+		return c.(*Cache), nil
+	}
+
+	c = &Cache{client: NewClient(auth)}
+	_ = clientCache.Add(key, c)
+	return c.(*Cache), nil
+}
+
+// NewStaticCache initializes a cache with data and no ability to refresh. Used for testing.
+func NewStaticCache(data []subscriptions.Location) *Cache {
+	return &Cache{
+		data: data,
+	}
+}
+
+func (c *Cache) refresh(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "locations.Cache.refresh")
+	defer done()
+
+	data, err := c.client.List(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to refresh locations cache")
+	}
+
+	c.data = data
+
+	return nil
+}
+
+// GetPairedRegion returns the name of the region paired with the location for disaster recovery, or an empty string
+// if the location has no paired region.
+func (c *Cache) GetPairedRegion(ctx context.Context, location string) (string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "locations.Cache.GetPairedRegion")
+	defer done()
+
+	l, err := c.get(ctx, location)
+	if err != nil {
+		return "", err
+	}
+
+	if l.Metadata == nil || l.Metadata.PairedRegion == nil {
+		return "", nil
+	}
+	for _, pair := range *l.Metadata.PairedRegion {
+		if name := to.String(pair.Name); name != "" {
+			return name, nil
+		}
+	}
+	return "", nil
+}
+
+// ValidateLocation returns an error if the location is not a region available to the subscription.
+func (c *Cache) ValidateLocation(ctx context.Context, location string) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "locations.Cache.ValidateLocation")
+	defer done()
+
+	_, err := c.get(ctx, location)
+	return err
+}
+
+// load returns the cached data, loading it from Azure first if it was never loaded.
+func (c *Cache) load(ctx context.Context) ([]subscriptions.Location, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.data == nil {
+		if err := c.refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return c.data, nil
+}
+
+// get returns the location with the given name, refreshing the cache if it's empty.
+func (c *Cache) get(ctx context.Context, location string) (subscriptions.Location, error) {
+	data, err := c.load(ctx)
+	if err != nil {
+		return subscriptions.Location{}, err
+	}
+
+	for _, l := range data {
+		if strings.EqualFold(to.String(l.Name), location) {
+			return l, nil
+		}
+	}
+
+	return subscriptions.Location{}, LocationNotAvailableError{Location: location}
+}
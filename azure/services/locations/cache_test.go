@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package locations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2021-01-01/subscriptions"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+func TestCacheGetPairedRegion(t *testing.T) {
+	cache := NewStaticCache([]subscriptions.Location{
+		{
+			Name: to.StringPtr("eastus"),
+			Metadata: &subscriptions.LocationMetadata{
+				PairedRegion: &[]subscriptions.PairedRegion{{Name: to.StringPtr("westus")}},
+			},
+		},
+		{
+			Name:     to.StringPtr("qatarcentral"),
+			Metadata: &subscriptions.LocationMetadata{},
+		},
+		{
+			Name: to.StringPtr("israelcentral"),
+			Metadata: &subscriptions.LocationMetadata{
+				PairedRegion: &[]subscriptions.PairedRegion{},
+			},
+		},
+	})
+
+	tests := []struct {
+		name     string
+		location string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "region with a pair",
+			location: "EastUS",
+			want:     "westus",
+		},
+		{
+			name:     "region without metadata about a pair",
+			location: "qatarcentral",
+			want:     "",
+		},
+		{
+			name:     "region without a pair",
+			location: "israelcentral",
+			want:     "",
+		},
+		{
+			name:     "unknown region",
+			location: "moon",
+			wantErr:  true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			pairedRegion, err := cache.GetPairedRegion(context.TODO(), tc.location)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(pairedRegion).To(Equal(tc.want))
+			}
+		})
+	}
+}
+
+func TestCacheValidateLocation(t *testing.T) {
+	cache := NewStaticCache([]subscriptions.Location{
+		{Name: to.StringPtr("eastus")},
+		{Name: to.StringPtr("westeurope")},
+	})
+
+	tests := []struct {
+		name     string
+		location string
+		wantErr  bool
+	}{
+		{
+			name:     "available region",
+			location: "westeurope",
+		},
+		{
+			name:     "available region with a different case",
+			location: "EastUS",
+		},
+		{
+			name:     "unknown region",
+			location: "moon",
+			wantErr:  true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := cache.ValidateLocation(context.TODO(), tc.location)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(IsLocationNotAvailable(err)).To(BeTrue())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+// fakeClient is a locations client failing to list the locations.
+type fakeClient struct {
+	err error
+}
+
+func (c fakeClient) List(context.Context) ([]subscriptions.Location, error) {
+	return nil, c.err
+}
+
+func TestCacheValidateLocationListError(t *testing.T) {
+	g := NewWithT(t)
+
+	cache := &Cache{client: fakeClient{err: errors.New("could not list locations: throttled")}}
+	err := cache.ValidateLocation(context.TODO(), "eastus")
+	g.Expect(err).To(MatchError("failed to refresh locations cache: could not list locations: throttled"))
+	g.Expect(IsLocationNotAvailable(err)).To(BeFalse())
+}
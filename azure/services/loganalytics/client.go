@@ -0,0 +1,193 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loganalytics
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Azure/azure-sdk-for-go/services/operationalinsights/mgmt/2020-08-01/operationalinsights"
+	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// SharedKeysGetter gets the shared keys of a Log Analytics workspace.
+type SharedKeysGetter interface {
+	GetPrimarySharedKey(ctx context.Context, spec azure.ResourceSpecGetter) (string, error)
+}
+
+// azureClient contains the Azure go-sdk Client.
+type azureClient struct {
+	workspaces operationalinsights.WorkspacesClient
+	sharedKeys operationalinsights.SharedKeysClient
+}
+
+var _ SharedKeysGetter = (*azureClient)(nil)
+
+// newClient creates a new Log Analytics workspaces client from subscription ID.
+func newClient(auth azure.Authorizer) *azureClient {
+	return &azureClient{
+		workspaces: newWorkspacesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		sharedKeys: newSharedKeysClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+	}
+}
+
+// newWorkspacesClient creates a new Log Analytics workspaces client from subscription ID.
+func newWorkspacesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) operationalinsights.WorkspacesClient {
+	workspacesClient := operationalinsights.NewWorkspacesClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&workspacesClient.Client, authorizer)
+	return workspacesClient
+}
+
+// newSharedKeysClient creates a new Log Analytics shared keys client from subscription ID.
+func newSharedKeysClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) operationalinsights.SharedKeysClient {
+	sharedKeysClient := operationalinsights.NewSharedKeysClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&sharedKeysClient.Client, authorizer)
+	return sharedKeysClient
+}
+
+// Get gets the specified Log Analytics workspace.
+func (ac *azureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "loganalytics.azureClient.Get")
+	defer done()
+
+	return ac.workspaces.Get(ctx, spec.ResourceGroupName(), spec.ResourceName())
+}
+
+// GetPrimarySharedKey gets the primary shared key of the specified Log Analytics workspace.
+func (ac *azureClient) GetPrimarySharedKey(ctx context.Context, spec azure.ResourceSpecGetter) (string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "loganalytics.azureClient.GetPrimarySharedKey")
+	defer done()
+
+	keys, err := ac.sharedKeys.GetSharedKeys(ctx, spec.ResourceGroupName(), spec.ResourceName())
+	if err != nil {
+		return "", err
+	}
+	if keys.PrimarySharedKey == nil {
+		return "", errors.Errorf("Log Analytics workspace %s has no primary shared key", spec.ResourceName())
+	}
+	return *keys.PrimarySharedKey, nil
+}
+
+// CreateOrUpdateAsync creates or updates a Log Analytics workspace asynchronously.
+// It sends a PUT request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
+// progress of the operation.
+func (ac *azureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "loganalytics.azureClient.CreateOrUpdateAsync")
+	defer done()
+
+	workspace, ok := parameters.(operationalinsights.Workspace)
+	if !ok {
+		return nil, nil, errors.Errorf("%T is not an operationalinsights.Workspace", parameters)
+	}
+
+	createFuture, err := ac.workspaces.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.ResourceName(), workspace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+	defer cancel()
+
+	err = createFuture.WaitForCompletionRef(ctx, ac.workspaces.Client)
+	if err != nil {
+		// if an error occurs, return the future.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return nil, &createFuture, err
+	}
+
+	result, err = createFuture.Result(ac.workspaces)
+	// if the operation completed, return a nil future
+	return result, nil, err
+}
+
+// DeleteAsync deletes a Log Analytics workspace asynchronously. DeleteAsync sends a DELETE
+// request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
+// progress of the operation.
+func (ac *azureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "loganalytics.azureClient.DeleteAsync")
+	defer done()
+
+	deleteFuture, err := ac.workspaces.Delete(ctx, spec.ResourceGroupName(), spec.ResourceName(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+	defer cancel()
+
+	err = deleteFuture.WaitForCompletionRef(ctx, ac.workspaces.Client)
+	if err != nil {
+		// if an error occurs, return the future.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return &deleteFuture, err
+	}
+	_, err = deleteFuture.Result(ac.workspaces)
+	// if the operation completed, return a nil future.
+	return nil, err
+}
+
+// IsDone returns true if the long-running operation has completed.
+func (ac *azureClient) IsDone(ctx context.Context, future azureautorest.FutureAPI) (isDone bool, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "loganalytics.azureClient.IsDone")
+	defer done()
+
+	isDone, err = future.DoneWithContext(ctx, ac.workspaces)
+	if err != nil {
+		return false, errors.Wrap(err, "failed checking if the operation was complete")
+	}
+
+	return isDone, nil
+}
+
+// Result fetches the result of a long-running operation future.
+func (ac *azureClient) Result(ctx context.Context, future azureautorest.FutureAPI, futureType string) (result interface{}, err error) {
+	_, _, done := tele.StartSpanWithLogger(ctx, "loganalytics.azureClient.Result")
+	defer done()
+
+	if future == nil {
+		return nil, errors.Errorf("cannot get result from nil future")
+	}
+
+	switch futureType {
+	case infrav1.PutFuture:
+		// Marshal and Unmarshal the future to put it into the correct future type so we can access the Result function.
+		// Unfortunately the FutureAPI can't be casted directly to WorkspacesCreateOrUpdateFuture because it is a azureautorest.Future, which doesn't implement the Result function. See PR #1686 for discussion on alternatives.
+		// It was converted back to a generic azureautorest.Future from the CAPZ infrav1.Future type stored in Status: https://github.com/kubernetes-sigs/cluster-api-provider-azure/blob/main/azure/converters/futures.go#L49.
+		var createFuture *operationalinsights.WorkspacesCreateOrUpdateFuture
+		jsonData, err := future.MarshalJSON()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal future")
+		}
+		if err := json.Unmarshal(jsonData, &createFuture); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal future data")
+		}
+		return createFuture.Result(ac.workspaces)
+
+	case infrav1.DeleteFuture:
+		// Delete does not return a result workspace
+		return nil, nil
+
+	default:
+		return nil, errors.Errorf("unknown future type %q", futureType)
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loganalytics
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/operationalinsights/mgmt/2020-08-01/operationalinsights"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const serviceName = "loganalytics"
+
+// WorkspaceScope defines the scope interface for a Log Analytics workspace service.
+type WorkspaceScope interface {
+	azure.ClusterDescriber
+	azure.AsyncStatusUpdater
+	LogAnalyticsWorkspaceSpec() (azure.ResourceSpecGetter, error)
+	LogAnalyticsSharedKeyRequired() bool
+	SetLogAnalyticsWorkspaceStatus(id, workspaceID string)
+	SetLogAnalyticsSharedKey(key string)
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope WorkspaceScope
+	async.Getter
+	async.Reconciler
+	SharedKeysGetter
+}
+
+// New creates a new Log Analytics workspace service.
+func New(scope WorkspaceScope) *Service {
+	client := newClient(scope)
+	return &Service{
+		Scope:            scope,
+		Getter:           client,
+		Reconciler:       async.New(scope, client, client),
+		SharedKeysGetter: client,
+	}
+}
+
+// Reconcile gets/creates the Log Analytics workspace of the cluster and records it in the cluster status.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "loganalytics.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	spec, err := s.Scope.LogAnalyticsWorkspaceSpec()
+	if err != nil {
+		return errors.Wrap(err, "failed to get Log Analytics workspace spec")
+	} else if spec == nil {
+		log.V(4).Info("skipping Log Analytics workspace reconcile, no workspace is configured")
+		return nil
+	}
+
+	result, err := s.CreateResource(ctx, spec, serviceName)
+	if err == nil && result != nil {
+		workspace, ok := result.(operationalinsights.Workspace)
+		if !ok {
+			err = errors.Errorf("%T is not an operationalinsights.Workspace", result)
+		} else {
+			var workspaceID string
+			if workspace.WorkspaceProperties != nil {
+				workspaceID = to.String(workspace.CustomerID)
+			}
+			s.Scope.SetLogAnalyticsWorkspaceStatus(to.String(workspace.ID), workspaceID)
+
+			if s.Scope.LogAnalyticsSharedKeyRequired() {
+				key, keyErr := s.GetPrimarySharedKey(ctx, spec)
+				if keyErr != nil {
+					err = errors.Wrapf(keyErr, "failed to get shared key of Log Analytics workspace %s", spec.ResourceName())
+				} else {
+					s.Scope.SetLogAnalyticsSharedKey(key)
+				}
+			}
+		}
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.LogAnalyticsWorkspaceReadyCondition, serviceName, err)
+	return err
+}
+
+// Delete deletes the Log Analytics workspace if it is managed by capz. Referenced workspaces are left untouched.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "loganalytics.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	spec, err := s.Scope.LogAnalyticsWorkspaceSpec()
+	if err != nil {
+		return errors.Wrap(err, "failed to get Log Analytics workspace spec")
+	} else if spec == nil {
+		log.V(4).Info("skipping Log Analytics workspace deletion, no workspace is configured")
+		return nil
+	}
+
+	err = s.deleteIfOwned(ctx, spec)
+	s.Scope.UpdateDeleteStatus(infrav1.LogAnalyticsWorkspaceReadyCondition, serviceName, err)
+	return err
+}
+
+// deleteIfOwned deletes the Log Analytics workspace if it exists and is managed by capz.
+func (s *Service) deleteIfOwned(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "loganalytics.Service.deleteIfOwned")
+	defer done()
+
+	existing, err := s.Get(ctx, spec)
+	if azure.ResourceNotFound(err) {
+		// already deleted or doesn't exist.
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to get Log Analytics workspace %s in resource group %s", spec.ResourceName(), spec.ResourceGroupName())
+	}
+
+	workspace, ok := existing.(operationalinsights.Workspace)
+	if !ok {
+		return errors.Errorf("%T is not an operationalinsights.Workspace", existing)
+	}
+
+	if !converters.MapToTags(workspace.Tags).HasOwned(s.Scope.ClusterName()) {
+		log.V(2).Info("skipping deletion of unmanaged Log Analytics workspace", "workspace", spec.ResourceName())
+		return nil
+	}
+
+	return s.DeleteResource(ctx, spec, serviceName)
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loganalytics
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/operationalinsights/mgmt/2020-08-01/operationalinsights"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loganalytics/mock_loganalytics"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakeWorkspaceSpec = WorkspaceSpec{
+		Name:          "my-cluster-workspace",
+		ResourceGroup: "my-rg",
+		Location:      "westus",
+		ClusterName:   "my-cluster",
+	}
+	fakeExternalWorkspaceSpec = WorkspaceSpec{
+		Name:          "shared-workspace",
+		ResourceGroup: "shared-rg",
+		ClusterName:   "my-cluster",
+		External:      true,
+	}
+
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+	notFoundError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not Found")
+
+	ownedWorkspace = operationalinsights.Workspace{
+		ID:   to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.OperationalInsights/workspaces/my-cluster-workspace"),
+		Name: to.StringPtr("my-cluster-workspace"),
+		Tags: map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned")},
+		WorkspaceProperties: &operationalinsights.WorkspaceProperties{
+			CustomerID: to.StringPtr("00000000-0000-0000-0000-000000000001"),
+		},
+	}
+	sharedWorkspace = operationalinsights.Workspace{
+		ID:   to.StringPtr("/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.OperationalInsights/workspaces/shared-workspace"),
+		Name: to.StringPtr("shared-workspace"),
+		WorkspaceProperties: &operationalinsights.WorkspaceProperties{
+			CustomerID: to.StringPtr("00000000-0000-0000-0000-000000000002"),
+		},
+	}
+)
+
+func TestReconcileLogAnalyticsWorkspace(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_loganalytics.MockWorkspaceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, k *mock_loganalytics.MockSharedKeysGetterMockRecorder)
+	}{
+		{
+			name:          "noop if no workspace is configured",
+			expectedError: "",
+			expect: func(s *mock_loganalytics.MockWorkspaceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, k *mock_loganalytics.MockSharedKeysGetterMockRecorder) {
+				s.LogAnalyticsWorkspaceSpec().Return(nil, nil)
+			},
+		},
+		{
+			name:          "create workspace",
+			expectedError: "",
+			expect: func(s *mock_loganalytics.MockWorkspaceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, k *mock_loganalytics.MockSharedKeysGetterMockRecorder) {
+				s.LogAnalyticsWorkspaceSpec().Return(&fakeWorkspaceSpec, nil)
+				r.CreateResource(gomockinternal.AContext(), &fakeWorkspaceSpec, serviceName).Return(ownedWorkspace, nil)
+				s.SetLogAnalyticsWorkspaceStatus(*ownedWorkspace.ID, "00000000-0000-0000-0000-000000000001")
+				s.LogAnalyticsSharedKeyRequired().Return(false)
+				s.UpdatePutStatus(infrav1.LogAnalyticsWorkspaceReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "adopt shared workspace and store its shared key",
+			expectedError: "",
+			expect: func(s *mock_loganalytics.MockWorkspaceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, k *mock_loganalytics.MockSharedKeysGetterMockRecorder) {
+				s.LogAnalyticsWorkspaceSpec().Return(&fakeExternalWorkspaceSpec, nil)
+				r.CreateResource(gomockinternal.AContext(), &fakeExternalWorkspaceSpec, serviceName).Return(sharedWorkspace, nil)
+				s.SetLogAnalyticsWorkspaceStatus(*sharedWorkspace.ID, "00000000-0000-0000-0000-000000000002")
+				s.LogAnalyticsSharedKeyRequired().Return(true)
+				k.GetPrimarySharedKey(gomockinternal.AContext(), &fakeExternalWorkspaceSpec).Return("secret-key", nil)
+				s.SetLogAnalyticsSharedKey("secret-key")
+				s.UpdatePutStatus(infrav1.LogAnalyticsWorkspaceReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "fail to get shared key",
+			expectedError: "failed to get shared key of Log Analytics workspace shared-workspace: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_loganalytics.MockWorkspaceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, k *mock_loganalytics.MockSharedKeysGetterMockRecorder) {
+				s.LogAnalyticsWorkspaceSpec().Return(&fakeExternalWorkspaceSpec, nil)
+				r.CreateResource(gomockinternal.AContext(), &fakeExternalWorkspaceSpec, serviceName).Return(sharedWorkspace, nil)
+				s.SetLogAnalyticsWorkspaceStatus(*sharedWorkspace.ID, "00000000-0000-0000-0000-000000000002")
+				s.LogAnalyticsSharedKeyRequired().Return(true)
+				k.GetPrimarySharedKey(gomockinternal.AContext(), &fakeExternalWorkspaceSpec).Return("", internalError)
+				s.UpdatePutStatus(infrav1.LogAnalyticsWorkspaceReadyCondition, serviceName, gomockinternal.ErrStrEq("failed to get shared key of Log Analytics workspace shared-workspace: #: Internal Server Error: StatusCode=500"))
+			},
+		},
+		{
+			name:          "fail to create workspace",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_loganalytics.MockWorkspaceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, k *mock_loganalytics.MockSharedKeysGetterMockRecorder) {
+				s.LogAnalyticsWorkspaceSpec().Return(&fakeWorkspaceSpec, nil)
+				r.CreateResource(gomockinternal.AContext(), &fakeWorkspaceSpec, serviceName).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.LogAnalyticsWorkspaceReadyCondition, serviceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_loganalytics.NewMockWorkspaceScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			keysMock := mock_loganalytics.NewMockSharedKeysGetter(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), keysMock.EXPECT())
+
+			s := &Service{
+				Scope:            scopeMock,
+				Reconciler:       asyncMock,
+				SharedKeysGetter: keysMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteLogAnalyticsWorkspace(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_loganalytics.MockWorkspaceScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no workspace is configured",
+			expectedError: "",
+			expect: func(s *mock_loganalytics.MockWorkspaceScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.LogAnalyticsWorkspaceSpec().Return(nil, nil)
+			},
+		},
+		{
+			name:          "delete owned workspace",
+			expectedError: "",
+			expect: func(s *mock_loganalytics.MockWorkspaceScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.LogAnalyticsWorkspaceSpec().Return(&fakeWorkspaceSpec, nil)
+				g.Get(gomockinternal.AContext(), &fakeWorkspaceSpec).Return(ownedWorkspace, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &fakeWorkspaceSpec, serviceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.LogAnalyticsWorkspaceReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "skip shared workspace",
+			expectedError: "",
+			expect: func(s *mock_loganalytics.MockWorkspaceScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.LogAnalyticsWorkspaceSpec().Return(&fakeExternalWorkspaceSpec, nil)
+				g.Get(gomockinternal.AContext(), &fakeExternalWorkspaceSpec).Return(sharedWorkspace, nil)
+				s.ClusterName().Return("my-cluster")
+				s.UpdateDeleteStatus(infrav1.LogAnalyticsWorkspaceReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "workspace already deleted",
+			expectedError: "",
+			expect: func(s *mock_loganalytics.MockWorkspaceScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.LogAnalyticsWorkspaceSpec().Return(&fakeWorkspaceSpec, nil)
+				g.Get(gomockinternal.AContext(), &fakeWorkspaceSpec).Return(nil, notFoundError)
+				s.UpdateDeleteStatus(infrav1.LogAnalyticsWorkspaceReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "fail to delete owned workspace",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_loganalytics.MockWorkspaceScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.LogAnalyticsWorkspaceSpec().Return(&fakeWorkspaceSpec, nil)
+				g.Get(gomockinternal.AContext(), &fakeWorkspaceSpec).Return(ownedWorkspace, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &fakeWorkspaceSpec, serviceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.LogAnalyticsWorkspaceReadyCondition, serviceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_loganalytics.NewMockWorkspaceScope(mockCtrl)
+			getterMock := mock_async.NewMockGetter(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), getterMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Getter:     getterMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loganalytics
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/operationalinsights/mgmt/2020-08-01/operationalinsights"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// WorkspaceSpec defines the specification for a Log Analytics workspace.
+type WorkspaceSpec struct {
+	Name            string
+	ResourceGroup   string
+	Location        string
+	ClusterName     string
+	RetentionInDays *int32
+	// External is true when the workspace is referenced by ID, in which case it is never created.
+	External       bool
+	AdditionalTags infrav1.Tags
+}
+
+// ResourceName returns the name of the Log Analytics workspace.
+func (s *WorkspaceSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *WorkspaceSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for Log Analytics workspaces.
+func (s *WorkspaceSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the Log Analytics workspace.
+func (s *WorkspaceSpec) Parameters(existing interface{}) (params interface{}, err error) {
+	if existing != nil {
+		if _, ok := existing.(operationalinsights.Workspace); !ok {
+			return nil, errors.Errorf("%T is not an operationalinsights.Workspace", existing)
+		}
+		// workspace already exists, existing workspaces are adopted as is.
+		return nil, nil
+	}
+
+	if s.External {
+		return nil, errors.Errorf("Log Analytics workspace %s not found in resource group %s", s.Name, s.ResourceGroup)
+	}
+
+	return operationalinsights.Workspace{
+		Location: to.StringPtr(s.Location),
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        to.StringPtr(s.Name),
+			Additional:  s.AdditionalTags,
+		})),
+		WorkspaceProperties: &operationalinsights.WorkspaceProperties{
+			Sku: &operationalinsights.WorkspaceSku{
+				Name: operationalinsights.WorkspaceSkuNameEnumPerGB2018,
+			},
+			RetentionInDays: s.RetentionInDays,
+		},
+	}, nil
+}
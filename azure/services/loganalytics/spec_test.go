@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loganalytics
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/operationalinsights/mgmt/2020-08-01/operationalinsights"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *WorkspaceSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "workspace does not exist",
+			spec: &WorkspaceSpec{
+				Name:            "my-cluster-workspace",
+				ResourceGroup:   "my-rg",
+				Location:        "westus",
+				ClusterName:     "my-cluster",
+				RetentionInDays: to.Int32Ptr(60),
+				AdditionalTags:  infrav1.Tags{"foo": "bar"},
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(operationalinsights.Workspace{
+					Location: to.StringPtr("westus"),
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"Name": to.StringPtr("my-cluster-workspace"),
+						"foo":  to.StringPtr("bar"),
+					},
+					WorkspaceProperties: &operationalinsights.WorkspaceProperties{
+						Sku: &operationalinsights.WorkspaceSku{
+							Name: operationalinsights.WorkspaceSkuNameEnumPerGB2018,
+						},
+						RetentionInDays: to.Int32Ptr(60),
+					},
+				}))
+			},
+		},
+		{
+			name:     "workspace already exists",
+			spec:     &fakeWorkspaceSpec,
+			existing: ownedWorkspace,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:          "referenced workspace does not exist",
+			spec:          &fakeExternalWorkspaceSpec,
+			existing:      nil,
+			expectedError: "Log Analytics workspace shared-workspace not found in resource group shared-rg",
+		},
+		{
+			name:          "existing is not a workspace",
+			spec:          &fakeWorkspaceSpec,
+			existing:      struct{}{},
+			expectedError: "struct {} is not an operationalinsights.Workspace",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				tc.expect(g, result)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package managedclusters
 import (
 	"context"
 	"fmt"
+	"math"
 	"net"
 	"time"
 
@@ -27,10 +28,12 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 	infrav1alpha4 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/util/maps"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -52,10 +55,19 @@ type ManagedClusterScope interface {
 	SetKubeConfigData([]byte)
 }
 
+// KubeconfigRetry configures how long the kubeconfig of a managed cluster is polled for while its API server isn't reachable yet.
+type KubeconfigRetry struct {
+	// Interval is the wait before the first retry, doubled after each failed attempt.
+	Interval time.Duration
+	// Timeout is the maximum time spent retrying before the reconcile is requeued. Zero disables retries.
+	Timeout time.Duration
+}
+
 // Service provides operations on azure resources.
 type Service struct {
 	Scope ManagedClusterScope
 	Client
+	kubeconfigRetry KubeconfigRetry
 }
 
 func convertToResourceReferences(resources []string) *[]containerservice.ResourceReference {
@@ -138,10 +150,15 @@ func computeDiffOfNormalizedClusters(managedCluster containerservice.ManagedClus
 }
 
 // New creates a new service.
-func New(scope ManagedClusterScope) *Service {
+// A zero Interval of kubeconfigRetry is defaulted, a zero Timeout disables retries.
+func New(scope ManagedClusterScope, kubeconfigRetry KubeconfigRetry) *Service {
+	if kubeconfigRetry.Interval <= 0 {
+		kubeconfigRetry.Interval = reconciler.DefaultKubeconfigRetryInterval
+	}
 	return &Service{
-		Scope:  scope,
-		Client: NewClient(scope),
+		Scope:           scope,
+		Client:          NewClient(scope),
+		kubeconfigRetry: kubeconfigRetry,
 	}
 }
 
@@ -343,15 +360,68 @@ func (s *Service) Reconcile(ctx context.Context) error {
 
 	// Update kubeconfig data
 	// Always fetch credentials in case of rotation
-	kubeConfigData, err := s.Client.GetCredentials(ctx, s.Scope.ResourceGroup(), s.Scope.ClusterName())
+	kubeConfigData, err := s.getCredentials(ctx)
 	if err != nil {
-		return errors.Wrap(err, "failed to get credentials for managed cluster")
+		return err
 	}
 	s.Scope.SetKubeConfigData(kubeConfigData)
 
 	return nil
 }
 
+// getCredentials fetches the kubeconfig of the managed cluster, retrying with an exponential backoff
+// while the API server of a freshly created cluster isn't reachable yet. Only transient errors are retried.
+func (s *Service) getCredentials(ctx context.Context) ([]byte, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "managedclusters.Service.getCredentials")
+	defer done()
+
+	if s.kubeconfigRetry.Timeout <= 0 {
+		kubeConfigData, err := s.Client.GetCredentials(ctx, s.Scope.ResourceGroup(), s.Scope.ClusterName())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get credentials for managed cluster")
+		}
+		return kubeConfigData, nil
+	}
+
+	retryCtx, cancel := context.WithTimeout(ctx, s.kubeconfigRetry.Timeout)
+	defer cancel()
+
+	backoff := wait.Backoff{
+		Duration: s.kubeconfigRetry.Interval,
+		Factor:   2,
+		Jitter:   0.1,
+		Steps:    math.MaxInt32,
+		Cap:      s.kubeconfigRetry.Timeout,
+	}
+	var (
+		kubeConfigData []byte
+		lastErr        error
+	)
+	errNotRetryable := errors.New("not retryable")
+	if err := wait.ExponentialBackoffWithContext(retryCtx, backoff, func() (bool, error) {
+		kubeConfigData, lastErr = s.Client.GetCredentials(retryCtx, s.Scope.ResourceGroup(), s.Scope.ClusterName())
+		switch {
+		case lastErr == nil:
+			return true, nil
+		case azure.ResourceTransientError(lastErr):
+			log.V(4).Info("managed cluster credentials not available yet, retrying", "error", lastErr.Error())
+			return false, nil
+		default:
+			return false, errNotRetryable
+		}
+	}); err != nil {
+		if errors.Is(err, errNotRetryable) {
+			return nil, errors.Wrap(lastErr, "failed to get credentials for managed cluster")
+		}
+		if lastErr == nil {
+			lastErr = err
+		}
+		return nil, azure.WithTransientError(errors.Wrapf(lastErr, "failed to get credentials for managed cluster after %s", s.kubeconfigRetry.Timeout), reconciler.DefaultReconcilerRequeue)
+	}
+
+	return kubeConfigData, nil
+}
+
 // Delete deletes the managed cluster.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "managedclusters.Service.Delete")
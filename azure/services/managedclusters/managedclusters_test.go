@@ -20,15 +20,18 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/managedclusters/mock_managedclusters"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 )
 
 func TestReconcile(t *testing.T) {
@@ -174,3 +177,108 @@ func TestReconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCredentials(t *testing.T) {
+	notReachable := autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 503}, "Service Unavailable")
+
+	testcases := []struct {
+		name            string
+		retry           KubeconfigRetry
+		expect          func(m *mock_managedclusters.MockClientMockRecorder)
+		expectedError   string
+		expectTransient bool
+	}{
+		{
+			name:  "credentials are available right away",
+			retry: KubeconfigRetry{Interval: time.Millisecond, Timeout: time.Second},
+			expect: func(m *mock_managedclusters.MockClientMockRecorder) {
+				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return([]byte("kubeconfig"), nil)
+			},
+		},
+		{
+			name:  "credentials become available after retrying",
+			retry: KubeconfigRetry{Interval: time.Millisecond, Timeout: time.Second},
+			expect: func(m *mock_managedclusters.MockClientMockRecorder) {
+				gomock.InOrder(
+					m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return(nil, notReachable).Times(2),
+					m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return([]byte("kubeconfig"), nil),
+				)
+			},
+		},
+		{
+			name:  "credentials are not available before the timeout",
+			retry: KubeconfigRetry{Interval: time.Millisecond, Timeout: 20 * time.Millisecond},
+			expect: func(m *mock_managedclusters.MockClientMockRecorder) {
+				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return(nil, notReachable).MinTimes(1)
+			},
+			expectedError:   "failed to get credentials for managed cluster after 20ms",
+			expectTransient: true,
+		},
+		{
+			name:  "non-transient error is not retried",
+			retry: KubeconfigRetry{Interval: time.Millisecond, Timeout: time.Second},
+			expect: func(m *mock_managedclusters.MockClientMockRecorder) {
+				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 403}, "Forbidden"))
+			},
+			expectedError: "failed to get credentials for managed cluster: #: Forbidden: StatusCode=403",
+		},
+		{
+			name:  "no retry when disabled",
+			retry: KubeconfigRetry{},
+			expect: func(m *mock_managedclusters.MockClientMockRecorder) {
+				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return(nil, notReachable)
+			},
+			expectedError: "failed to get credentials for managed cluster",
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_managedclusters.NewMockManagedClusterScope(mockCtrl)
+			clientMock := mock_managedclusters.NewMockClient(mockCtrl)
+
+			scopeMock.EXPECT().ResourceGroup().AnyTimes().Return("my-rg")
+			scopeMock.EXPECT().ClusterName().AnyTimes().Return("my-managedcluster")
+			tc.expect(clientMock.EXPECT())
+
+			s := &Service{
+				Scope:           scopeMock,
+				Client:          clientMock,
+				kubeconfigRetry: tc.retry,
+			}
+
+			kubeConfigData, err := s.getCredentials(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(HavePrefix(tc.expectedError))
+				if tc.expectTransient {
+					var reconcileErr azure.ReconcileError
+					g.Expect(errors.As(err, &reconcileErr)).To(BeTrue())
+					g.Expect(reconcileErr.IsTransient()).To(BeTrue())
+				}
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(kubeConfigData).To(Equal([]byte("kubeconfig")))
+		})
+	}
+}
+
+func TestNewKubeconfigRetry(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scopeMock := mock_managedclusters.NewMockManagedClusterScope(mockCtrl)
+	scopeMock.EXPECT().SubscriptionID().AnyTimes().Return("123")
+	scopeMock.EXPECT().BaseURI().AnyTimes().Return("")
+	scopeMock.EXPECT().Authorizer().AnyTimes().Return(nil)
+
+	s := New(scopeMock, KubeconfigRetry{})
+	g.Expect(s.kubeconfigRetry.Timeout).To(BeZero())
+	g.Expect(s.kubeconfigRetry.Interval).To(Equal(reconciler.DefaultKubeconfigRetryInterval))
+}
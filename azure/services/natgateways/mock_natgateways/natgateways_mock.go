@@ -109,6 +109,20 @@ func (mr *MockNatGatewayScopeMockRecorder) AdditionalTags() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdditionalTags", reflect.TypeOf((*MockNatGatewayScope)(nil).AdditionalTags))
 }
 
+// ApplicationSecurityGroups mocks base method.
+func (m *MockNatGatewayScope) ApplicationSecurityGroups() []v1beta1.ApplicationSecurityGroup {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroups")
+	ret0, _ := ret[0].([]v1beta1.ApplicationSecurityGroup)
+	return ret0
+}
+
+// ApplicationSecurityGroups indicates an expected call of ApplicationSecurityGroups.
+func (mr *MockNatGatewayScopeMockRecorder) ApplicationSecurityGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroups", reflect.TypeOf((*MockNatGatewayScope)(nil).ApplicationSecurityGroups))
+}
+
 // Authorizer mocks base method.
 func (m *MockNatGatewayScope) Authorizer() autorest.Authorizer {
 	m.ctrl.T.Helper()
@@ -221,6 +235,20 @@ func (mr *MockNatGatewayScopeMockRecorder) ClusterName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterName", reflect.TypeOf((*MockNatGatewayScope)(nil).ClusterName))
 }
 
+// ControlPlaneAvailabilitySetID mocks base method.
+func (m *MockNatGatewayScope) ControlPlaneAvailabilitySetID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ControlPlaneAvailabilitySetID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ControlPlaneAvailabilitySetID indicates an expected call of ControlPlaneAvailabilitySetID.
+func (mr *MockNatGatewayScopeMockRecorder) ControlPlaneAvailabilitySetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControlPlaneAvailabilitySetID", reflect.TypeOf((*MockNatGatewayScope)(nil).ControlPlaneAvailabilitySetID))
+}
+
 // ControlPlaneRouteTable mocks base method.
 func (m *MockNatGatewayScope) ControlPlaneRouteTable() v1beta1.RouteTable {
 	m.ctrl.T.Helper()
@@ -317,6 +345,20 @@ func (mr *MockNatGatewayScopeMockRecorder) HashKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockNatGatewayScope)(nil).HashKey))
 }
 
+// InternalLBAddressPoolIDs mocks base method.
+func (m *MockNatGatewayScope) InternalLBAddressPoolIDs(arg0 string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InternalLBAddressPoolIDs", arg0)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// InternalLBAddressPoolIDs indicates an expected call of InternalLBAddressPoolIDs.
+func (mr *MockNatGatewayScopeMockRecorder) InternalLBAddressPoolIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InternalLBAddressPoolIDs", reflect.TypeOf((*MockNatGatewayScope)(nil).InternalLBAddressPoolIDs), arg0)
+}
+
 // IsAPIServerPrivate mocks base method.
 func (m *MockNatGatewayScope) IsAPIServerPrivate() bool {
 	m.ctrl.T.Helper()
@@ -387,6 +429,20 @@ func (mr *MockNatGatewayScopeMockRecorder) NatGatewaySpecs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NatGatewaySpecs", reflect.TypeOf((*MockNatGatewayScope)(nil).NatGatewaySpecs))
 }
 
+// NetworkInterfaceSecurityGroupName mocks base method.
+func (m *MockNatGatewayScope) NetworkInterfaceSecurityGroupName(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkInterfaceSecurityGroupName", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// NetworkInterfaceSecurityGroupName indicates an expected call of NetworkInterfaceSecurityGroupName.
+func (mr *MockNatGatewayScopeMockRecorder) NetworkInterfaceSecurityGroupName(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkInterfaceSecurityGroupName", reflect.TypeOf((*MockNatGatewayScope)(nil).NetworkInterfaceSecurityGroupName), arg0)
+}
+
 // NodeSubnets mocks base method.
 func (m *MockNatGatewayScope) NodeSubnets() []v1beta1.SubnetSpec {
 	m.ctrl.T.Helper()
@@ -48,11 +48,11 @@ var (
 		Name: "my-vnet",
 	}
 	natGatewaySpec1 = NatGatewaySpec{
-		Name:           "my-node-natgateway-1",
-		ResourceGroup:  "my-rg",
-		SubscriptionID: "my-sub",
-		Location:       "westus",
-		NatGatewayIP:   infrav1.PublicIPSpec{Name: "pip-node-subnet"},
+		Name:              "my-node-natgateway-1",
+		ResourceGroup:     "my-rg",
+		SubscriptionID:    "my-sub",
+		Location:          "westus",
+		NatGatewayIPNames: []string{"pip-node-subnet"},
 	}
 	natGateway1 = network.NatGateway{
 		ID: to.StringPtr("/subscriptions/my-sub/resourceGroups/my-rg/providers/Microsoft.Network/natGateways/my-node-natgateway-1"),
@@ -21,7 +21,6 @@ import (
 	autorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
-	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 )
 
@@ -31,7 +30,12 @@ type NatGatewaySpec struct {
 	ResourceGroup  string
 	SubscriptionID string
 	Location       string
-	NatGatewayIP   infrav1.PublicIPSpec
+	// NatGatewayIPNames are the names of the public IPs attached to the NAT gateway.
+	NatGatewayIPNames []string
+	// NatGatewayIPPrefixName is the name of the public IP prefix attached to the NAT gateway, if any.
+	NatGatewayIPPrefixName string
+	// IdleTimeoutInMinutes is the idle timeout of the outbound connections, if any.
+	IdleTimeoutInMinutes *int32
 }
 
 // ResourceName returns the name of the NAT gateway.
@@ -57,42 +61,85 @@ func (s *NatGatewaySpec) Parameters(existing interface{}) (params interface{}, e
 			return nil, errors.Errorf("%T is not a network.NatGateway", existing)
 		}
 
-		if hasPublicIP(existingNatGateway, s.NatGatewayIP.Name) {
+		if s.isUpToDate(existingNatGateway) {
 			// Skip update for NAT gateway as it exists with expected values
 			return nil, nil
 		}
 	}
 
+	// The public IPs, public IP prefixes and idle timeout of a NAT gateway can all be updated in place.
+	publicIPs := make([]network.SubResource, len(s.NatGatewayIPNames))
+	for i, name := range s.NatGatewayIPNames {
+		publicIPs[i] = network.SubResource{
+			ID: to.StringPtr(azure.PublicIPID(s.SubscriptionID, s.ResourceGroupName(), name)),
+		}
+	}
+	publicIPPrefixes := []network.SubResource{}
+	if s.NatGatewayIPPrefixName != "" {
+		publicIPPrefixes = append(publicIPPrefixes, network.SubResource{
+			ID: to.StringPtr(azure.PublicIPPrefixID(s.SubscriptionID, s.ResourceGroupName(), s.NatGatewayIPPrefixName)),
+		})
+	}
+
 	natGatewayToCreate := network.NatGateway{
 		Name:     to.StringPtr(s.Name),
 		Location: to.StringPtr(s.Location),
 		Sku:      &network.NatGatewaySku{Name: network.NatGatewaySkuNameStandard},
 		NatGatewayPropertiesFormat: &network.NatGatewayPropertiesFormat{
-			PublicIPAddresses: &[]network.SubResource{
-				{
-					ID: to.StringPtr(azure.PublicIPID(s.SubscriptionID, s.ResourceGroupName(), s.NatGatewayIP.Name)),
-				},
-			},
+			PublicIPAddresses:    &publicIPs,
+			PublicIPPrefixes:     &publicIPPrefixes,
+			IdleTimeoutInMinutes: s.IdleTimeoutInMinutes,
 		},
 	}
 
 	return natGatewayToCreate, nil
 }
 
-func hasPublicIP(natGateway network.NatGateway, publicIPName string) bool {
-	// We must have a non-nil, non-"empty" PublicIPAddresses
-	if !(natGateway.PublicIPAddresses != nil && len(*natGateway.PublicIPAddresses) > 0) {
+// isUpToDate returns true if the existing NAT gateway has exactly the expected public IPs and public IP prefix,
+// and the expected idle timeout.
+func (s *NatGatewaySpec) isUpToDate(existing network.NatGateway) bool {
+	if existing.NatGatewayPropertiesFormat == nil {
+		return false
+	}
+	if !sameResourceNames(existing.PublicIPAddresses, s.NatGatewayIPNames) {
+		return false
+	}
+	var prefixNames []string
+	if s.NatGatewayIPPrefixName != "" {
+		prefixNames = []string{s.NatGatewayIPPrefixName}
+	}
+	if !sameResourceNames(existing.PublicIPPrefixes, prefixNames) {
+		return false
+	}
+	if s.IdleTimeoutInMinutes != nil && to.Int32(existing.IdleTimeoutInMinutes) != *s.IdleTimeoutInMinutes {
 		return false
 	}
+	return true
+}
 
-	for _, publicIP := range *natGateway.PublicIPAddresses {
-		resource, err := autorest.ParseResourceID(*publicIP.ID)
-		if err != nil {
-			continue
+// sameResourceNames returns true if the sub resources reference exactly the resources with the given names.
+func sameResourceNames(resources *[]network.SubResource, names []string) bool {
+	var existingNames []string
+	if resources != nil {
+		for _, resource := range *resources {
+			parsed, err := autorest.ParseResourceID(to.String(resource.ID))
+			if err != nil {
+				return false
+			}
+			existingNames = append(existingNames, parsed.ResourceName)
 		}
-		if resource.ResourceName == publicIPName {
-			return true
+	}
+	if len(existingNames) != len(names) {
+		return false
+	}
+	expected := make(map[string]bool, len(names))
+	for _, name := range names {
+		expected[name] = true
+	}
+	for _, name := range existingNames {
+		if !expected[name] {
+			return false
 		}
 	}
-	return false
+	return true
 }
@@ -0,0 +1,206 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package natgateways
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+)
+
+func TestParameters(t *testing.T) {
+	prefixSpec := NatGatewaySpec{
+		Name:                   "my-node-natgateway-1",
+		ResourceGroup:          "my-rg",
+		SubscriptionID:         "my-sub",
+		Location:               "westus",
+		NatGatewayIPNames:      []string{"pip-node-subnet"},
+		NatGatewayIPPrefixName: "my-natgw-prefix",
+	}
+	multipleIPsSpec := NatGatewaySpec{
+		Name:                 "my-node-natgateway-1",
+		ResourceGroup:        "my-rg",
+		SubscriptionID:       "my-sub",
+		Location:             "westus",
+		NatGatewayIPNames:    []string{"pip-node-subnet", "pip-node-subnet-1"},
+		IdleTimeoutInMinutes: to.Int32Ptr(10),
+	}
+	publicIPs := &[]network.SubResource{
+		{ID: to.StringPtr("/subscriptions/my-sub/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/pip-node-subnet")},
+	}
+	multiplePublicIPs := &[]network.SubResource{
+		{ID: to.StringPtr("/subscriptions/my-sub/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/pip-node-subnet")},
+		{ID: to.StringPtr("/subscriptions/my-sub/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/pip-node-subnet-1")},
+	}
+	publicIPPrefixes := &[]network.SubResource{
+		{ID: to.StringPtr("/subscriptions/my-sub/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-natgw-prefix")},
+	}
+
+	testcases := []struct {
+		name          string
+		spec          *NatGatewaySpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "NAT gateway does not exist",
+			spec:     &natGatewaySpec1,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.NatGateway{
+					Name:     to.StringPtr("my-node-natgateway-1"),
+					Location: to.StringPtr("westus"),
+					Sku:      &network.NatGatewaySku{Name: network.NatGatewaySkuNameStandard},
+					NatGatewayPropertiesFormat: &network.NatGatewayPropertiesFormat{
+						PublicIPAddresses: publicIPs,
+						PublicIPPrefixes:  &[]network.SubResource{},
+					},
+				}))
+			},
+		},
+		{
+			name:     "NAT gateway with a public IP prefix does not exist",
+			spec:     &prefixSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.NatGateway{
+					Name:     to.StringPtr("my-node-natgateway-1"),
+					Location: to.StringPtr("westus"),
+					Sku:      &network.NatGatewaySku{Name: network.NatGatewaySkuNameStandard},
+					NatGatewayPropertiesFormat: &network.NatGatewayPropertiesFormat{
+						PublicIPAddresses: publicIPs,
+						PublicIPPrefixes:  publicIPPrefixes,
+					},
+				}))
+			},
+		},
+		{
+			name: "NAT gateway exists without the public IP prefix",
+			spec: &prefixSpec,
+			existing: network.NatGateway{
+				NatGatewayPropertiesFormat: &network.NatGatewayPropertiesFormat{
+					PublicIPAddresses: publicIPs,
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.NatGateway{}))
+				g.Expect(result.(network.NatGateway).PublicIPPrefixes).To(Equal(publicIPPrefixes))
+			},
+		},
+		{
+			name: "NAT gateway exists with the public IP and public IP prefix",
+			spec: &prefixSpec,
+			existing: network.NatGateway{
+				NatGatewayPropertiesFormat: &network.NatGatewayPropertiesFormat{
+					PublicIPAddresses: publicIPs,
+					PublicIPPrefixes:  publicIPPrefixes,
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "NAT gateway exists with the public IP prefix to detach",
+			spec: &natGatewaySpec1,
+			existing: network.NatGateway{
+				NatGatewayPropertiesFormat: &network.NatGatewayPropertiesFormat{
+					PublicIPAddresses: publicIPs,
+					PublicIPPrefixes:  publicIPPrefixes,
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.NatGateway{}))
+				g.Expect(result.(network.NatGateway).PublicIPPrefixes).To(Equal(&[]network.SubResource{}))
+			},
+		},
+		{
+			name: "NAT gateway exists with fewer public IPs and the default idle timeout",
+			spec: &multipleIPsSpec,
+			existing: network.NatGateway{
+				NatGatewayPropertiesFormat: &network.NatGatewayPropertiesFormat{
+					PublicIPAddresses:    publicIPs,
+					IdleTimeoutInMinutes: to.Int32Ptr(4),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.NatGateway{
+					Name:     to.StringPtr("my-node-natgateway-1"),
+					Location: to.StringPtr("westus"),
+					Sku:      &network.NatGatewaySku{Name: network.NatGatewaySkuNameStandard},
+					NatGatewayPropertiesFormat: &network.NatGatewayPropertiesFormat{
+						PublicIPAddresses:    multiplePublicIPs,
+						PublicIPPrefixes:     &[]network.SubResource{},
+						IdleTimeoutInMinutes: to.Int32Ptr(10),
+					},
+				}))
+			},
+		},
+		{
+			name: "NAT gateway exists with a different idle timeout",
+			spec: &multipleIPsSpec,
+			existing: network.NatGateway{
+				NatGatewayPropertiesFormat: &network.NatGatewayPropertiesFormat{
+					PublicIPAddresses:    multiplePublicIPs,
+					IdleTimeoutInMinutes: to.Int32Ptr(4),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.NatGateway{}))
+				g.Expect(result.(network.NatGateway).IdleTimeoutInMinutes).To(Equal(to.Int32Ptr(10)))
+			},
+		},
+		{
+			name: "NAT gateway exists with the expected public IPs and idle timeout",
+			spec: &multipleIPsSpec,
+			existing: network.NatGateway{
+				NatGatewayPropertiesFormat: &network.NatGatewayPropertiesFormat{
+					PublicIPAddresses:    multiplePublicIPs,
+					IdleTimeoutInMinutes: to.Int32Ptr(10),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:          "existing is not a NAT gateway",
+			spec:          &prefixSpec,
+			existing:      struct{}{},
+			expectedError: "struct {} is not a network.NatGateway",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				tc.expect(g, result)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package networkinterfaces
 import (
 	"context"
 
+	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
@@ -77,6 +78,31 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	return result
 }
 
+// DetachFromBackendPools removes the network interfaces from the backend pools of their load balancers, so that the
+// load balancers stop sending new connections to the machine. The network interfaces that don't exist are ignored.
+func (s *Service) DetachFromBackendPools(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "networkinterfaces.Service.DetachFromBackendPools")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	var result error
+	for _, spec := range s.Scope.NICSpecs() {
+		nicSpec, ok := spec.(*NICSpec)
+		if !ok {
+			return errors.Errorf("%T is not a networkinterfaces.NICSpec", spec)
+		}
+		if _, err := s.CreateResource(ctx, &BackendPoolsDetachSpec{NICSpec: nicSpec}, serviceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || result == nil {
+				result = err
+			}
+		}
+	}
+
+	return result
+}
+
 // Delete deletes the network interface with the provided name.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "networkinterfaces.Service.Delete")
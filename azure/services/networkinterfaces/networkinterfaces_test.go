@@ -128,6 +128,60 @@ func TestReconcileNetworkInterface(t *testing.T) {
 	}
 }
 
+func TestDetachNetworkInterfaceFromBackendPools(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_networkinterfaces.MockNICScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "successfully detach multiple network interfaces",
+			expectedError: "",
+			expect: func(s *mock_networkinterfaces.MockNICScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.NICSpecs().Return([]azure.ResourceSpecGetter{&fakeNICSpec1, &fakeNICSpec2})
+				r.CreateResource(gomockinternal.AContext(), &BackendPoolsDetachSpec{NICSpec: &fakeNICSpec1}, serviceName).Return(nil, nil)
+				r.CreateResource(gomockinternal.AContext(), &BackendPoolsDetachSpec{NICSpec: &fakeNICSpec2}, serviceName).Return(nil, nil)
+			},
+		},
+		{
+			name:          "network interface detach fails",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_networkinterfaces.MockNICScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.NICSpecs().Return([]azure.ResourceSpecGetter{&fakeNICSpec1, &fakeNICSpec2})
+				r.CreateResource(gomockinternal.AContext(), &BackendPoolsDetachSpec{NICSpec: &fakeNICSpec1}, serviceName).Return(nil, internalError)
+				r.CreateResource(gomockinternal.AContext(), &BackendPoolsDetachSpec{NICSpec: &fakeNICSpec2}, serviceName).Return(nil, nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_networkinterfaces.NewMockNICScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.DetachFromBackendPools(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(Equal(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestDeleteNetworkInterface(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -26,25 +26,28 @@ import (
 
 // NICSpec defines the specification for a Network Interface.
 type NICSpec struct {
-	Name                      string
-	ResourceGroup             string
-	Location                  string
-	SubscriptionID            string
-	MachineName               string
-	SubnetName                string
-	VNetName                  string
-	VNetResourceGroup         string
-	StaticIPAddress           string
-	PublicLBName              string
-	PublicLBAddressPoolName   string
-	PublicLBNATRuleName       string
-	InternalLBName            string
-	InternalLBAddressPoolName string
-	PublicIPName              string
-	AcceleratedNetworking     *bool
-	IPv6Enabled               bool
-	EnableIPForwarding        bool
-	SKU                       *resourceskus.SKU
+	Name                        string
+	ResourceGroup               string
+	Location                    string
+	SubscriptionID              string
+	MachineName                 string
+	SubnetName                  string
+	VNetName                    string
+	VNetResourceGroup           string
+	StaticIPAddress             string
+	PublicLBName                string
+	PublicLBAddressPoolName     string
+	PublicLBNATRuleName         string
+	InternalLBName              string
+	InternalLBAddressPoolName   string
+	PublicIPName                string
+	AcceleratedNetworking       *bool
+	IPv6Enabled                 bool
+	EnableIPForwarding          bool
+	SKU                         *resourceskus.SKU
+	ApplicationSecurityGroupIDs []string
+	SecurityGroupName           string
+	ServiceLBAddressPoolIDs     []string
 }
 
 // ResourceName returns the name of the network interface.
@@ -107,6 +110,9 @@ func (s *NICSpec) Parameters(existing interface{}) (parameters interface{}, err
 				ID: to.StringPtr(azure.AddressPoolID(s.SubscriptionID, s.ResourceGroup, s.InternalLBName, s.InternalLBAddressPoolName)),
 			})
 	}
+	for _, id := range s.ServiceLBAddressPoolIDs {
+		backendAddressPools = append(backendAddressPools, network.BackendAddressPool{ID: to.StringPtr(id)})
+	}
 	nicConfig.LoadBalancerBackendAddressPools = &backendAddressPools
 
 	if s.PublicIPName != "" {
@@ -115,6 +121,14 @@ func (s *NICSpec) Parameters(existing interface{}) (parameters interface{}, err
 		}
 	}
 
+	if len(s.ApplicationSecurityGroupIDs) > 0 {
+		asgs := make([]network.ApplicationSecurityGroup, len(s.ApplicationSecurityGroupIDs))
+		for i, id := range s.ApplicationSecurityGroupIDs {
+			asgs[i] = network.ApplicationSecurityGroup{ID: to.StringPtr(id)}
+		}
+		nicConfig.ApplicationSecurityGroups = &asgs
+	}
+
 	if s.AcceleratedNetworking == nil {
 		// set accelerated networking to the capability of the VMSize
 		if s.SKU == nil {
@@ -145,12 +159,55 @@ func (s *NICSpec) Parameters(existing interface{}) (parameters interface{}, err
 		ipConfigurations = append(ipConfigurations, ipv6Config)
 	}
 
-	return network.Interface{
+	nic := network.Interface{
 		Location: to.StringPtr(s.Location),
 		InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
 			EnableAcceleratedNetworking: s.AcceleratedNetworking,
 			IPConfigurations:            &ipConfigurations,
 			EnableIPForwarding:          to.BoolPtr(s.EnableIPForwarding),
 		},
-	}, nil
+	}
+
+	if s.SecurityGroupName != "" {
+		nic.NetworkSecurityGroup = &network.SecurityGroup{
+			ID: to.StringPtr(azure.SecurityGroupID(s.SubscriptionID, s.ResourceGroup, s.SecurityGroupName)),
+		}
+	}
+
+	return nic, nil
+}
+
+// BackendPoolsDetachSpec removes an existing network interface from the load balancer backend pools of its IP
+// configurations, leaving the rest of the network interface as is.
+type BackendPoolsDetachSpec struct {
+	*NICSpec
+}
+
+// Parameters returns the existing network interface without backend pools, or nil when it doesn't exist or isn't in
+// any backend pool.
+func (s *BackendPoolsDetachSpec) Parameters(existing interface{}) (parameters interface{}, err error) {
+	if existing == nil {
+		return nil, nil
+	}
+	nic, ok := existing.(network.Interface)
+	if !ok {
+		return nil, errors.Errorf("%T is not a network.Interface", existing)
+	}
+	if nic.InterfacePropertiesFormat == nil || nic.IPConfigurations == nil {
+		return nil, nil
+	}
+
+	detached := false
+	for _, ipConfig := range *nic.IPConfigurations {
+		if ipConfig.InterfaceIPConfigurationPropertiesFormat == nil || ipConfig.LoadBalancerBackendAddressPools == nil || len(*ipConfig.LoadBalancerBackendAddressPools) == 0 {
+			continue
+		}
+		ipConfig.LoadBalancerBackendAddressPools = &[]network.BackendAddressPool{}
+		detached = true
+	}
+	if !detached {
+		return nil, nil
+	}
+
+	return nic, nil
 }
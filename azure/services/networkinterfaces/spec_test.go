@@ -149,6 +149,44 @@ var (
 		SKU:                   &fakeSku,
 		EnableIPForwarding:    true,
 	}
+
+	fakeApplicationSecurityGroupsNICSpec = NICSpec{
+		Name:                        "my-net-interface",
+		ResourceGroup:               "my-rg",
+		Location:                    "fake-location",
+		SubscriptionID:              "123",
+		MachineName:                 "azure-test1",
+		SubnetName:                  "my-subnet",
+		VNetName:                    "my-vnet",
+		VNetResourceGroup:           "my-rg",
+		AcceleratedNetworking:       to.BoolPtr(false),
+		ApplicationSecurityGroupIDs: []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/node-asg"},
+	}
+
+	fakeSecurityGroupNICSpec = NICSpec{
+		Name:                  "my-net-interface",
+		ResourceGroup:         "my-rg",
+		Location:              "fake-location",
+		SubscriptionID:        "123",
+		MachineName:           "azure-test1",
+		SubnetName:            "my-subnet",
+		VNetName:              "my-vnet",
+		VNetResourceGroup:     "my-rg",
+		AcceleratedNetworking: to.BoolPtr(false),
+		SecurityGroupName:     "node-nic-nsg",
+	}
+	fakeServiceLBNICSpec = NICSpec{
+		Name:                    "my-net-interface",
+		ResourceGroup:           "my-rg",
+		Location:                "fake-location",
+		SubscriptionID:          "123",
+		MachineName:             "azure-test1",
+		SubnetName:              "my-subnet",
+		VNetName:                "my-vnet",
+		VNetResourceGroup:       "my-rg",
+		AcceleratedNetworking:   to.BoolPtr(false),
+		ServiceLBAddressPoolIDs: []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/ingress-lb/backendAddressPools/ingress-lb-backendPool"},
+	}
 )
 
 func TestParameters(t *testing.T) {
@@ -336,6 +374,90 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "get parameters for network interface with application security groups",
+			spec:     &fakeApplicationSecurityGroupsNICSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.Interface{}))
+				g.Expect(result.(network.Interface)).To(Equal(network.Interface{
+					Location: to.StringPtr("fake-location"),
+					InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+						EnableAcceleratedNetworking: to.BoolPtr(false),
+						EnableIPForwarding:          to.BoolPtr(false),
+						IPConfigurations: &[]network.InterfaceIPConfiguration{
+							{
+								Name: to.StringPtr("pipConfig"),
+								InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+									LoadBalancerBackendAddressPools: &[]network.BackendAddressPool{},
+									PrivateIPAllocationMethod:       network.IPAllocationMethodDynamic,
+									Subnet:                          &network.Subnet{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet")},
+									ApplicationSecurityGroups: &[]network.ApplicationSecurityGroup{
+										{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/node-asg")},
+									},
+								},
+							},
+						},
+					},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "get parameters for network interface with a security group",
+			spec:     &fakeSecurityGroupNICSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.Interface{}))
+				g.Expect(result.(network.Interface)).To(Equal(network.Interface{
+					Location: to.StringPtr("fake-location"),
+					InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+						EnableAcceleratedNetworking: to.BoolPtr(false),
+						EnableIPForwarding:          to.BoolPtr(false),
+						NetworkSecurityGroup:        &network.SecurityGroup{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/node-nic-nsg")},
+						IPConfigurations: &[]network.InterfaceIPConfiguration{
+							{
+								Name: to.StringPtr("pipConfig"),
+								InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+									LoadBalancerBackendAddressPools: &[]network.BackendAddressPool{},
+									PrivateIPAllocationMethod:       network.IPAllocationMethodDynamic,
+									Subnet:                          &network.Subnet{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet")},
+								},
+							},
+						},
+					},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "get parameters for network interface in the backend pool of an internal service load balancer",
+			spec:     &fakeServiceLBNICSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.Interface{}))
+				g.Expect(result.(network.Interface)).To(Equal(network.Interface{
+					Location: to.StringPtr("fake-location"),
+					InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+						EnableAcceleratedNetworking: to.BoolPtr(false),
+						EnableIPForwarding:          to.BoolPtr(false),
+						IPConfigurations: &[]network.InterfaceIPConfiguration{
+							{
+								Name: to.StringPtr("pipConfig"),
+								InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+									LoadBalancerBackendAddressPools: &[]network.BackendAddressPool{
+										{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/ingress-lb/backendAddressPools/ingress-lb-backendPool")},
+									},
+									PrivateIPAllocationMethod: network.IPAllocationMethodDynamic,
+									Subnet:                    &network.Subnet{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet")},
+								},
+							},
+						},
+					},
+				}))
+			},
+			expectedError: "",
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -354,3 +476,76 @@ func TestParameters(t *testing.T) {
 		})
 	}
 }
+
+func TestBackendPoolsDetachParameters(t *testing.T) {
+	nicInBackendPool := func() network.Interface {
+		return network.Interface{
+			Name: to.StringPtr("my-net-interface"),
+			InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+				IPConfigurations: &[]network.InterfaceIPConfiguration{
+					{
+						Name: to.StringPtr("pipConfig"),
+						InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+							LoadBalancerBackendAddressPools: &[]network.BackendAddressPool{
+								{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-public-lb/backendAddressPools/my-public-lb-backendPool")},
+							},
+							LoadBalancerInboundNatRules: &[]network.InboundNatRule{
+								{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-public-lb/inboundNatRules/azure-test1")},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	detachedNIC := nicInBackendPool()
+	(*detachedNIC.IPConfigurations)[0].LoadBalancerBackendAddressPools = &[]network.BackendAddressPool{}
+
+	testcases := []struct {
+		name          string
+		existing      interface{}
+		expected      interface{}
+		expectedError string
+	}{
+		{
+			name:     "network interface doesn't exist",
+			existing: nil,
+			expected: nil,
+		},
+		{
+			name:     "network interface in a backend pool",
+			existing: nicInBackendPool(),
+			expected: detachedNIC,
+		},
+		{
+			name:     "network interface in no backend pool",
+			existing: detachedNIC,
+			expected: nil,
+		},
+		{
+			name:          "existing resource isn't a network interface",
+			existing:      struct{}{},
+			expectedError: "struct {} is not a network.Interface",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			spec := &BackendPoolsDetachSpec{NICSpec: &fakeMissingSKUNICSpec}
+			result, err := spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.expected == nil {
+				g.Expect(result).To(BeNil())
+			} else {
+				g.Expect(result).To(Equal(tc.expected))
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkwatchers
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// verifyIPFlowTimeout is the time to wait for Network Watcher to complete an IP flow verification.
+const verifyIPFlowTimeout = 90 * time.Second
+
+// client wraps go-sdk.
+type client interface {
+	GetSubnet(context.Context, string, string, string) (network.Subnet, error)
+	GetInterface(context.Context, string, string) (network.Interface, error)
+	VerifyIPFlow(context.Context, string, string, network.VerificationIPFlowParameters) (network.VerificationIPFlowResult, error)
+}
+
+// azureClient contains the Azure go-sdk Client.
+type azureClient struct {
+	subnets    network.SubnetsClient
+	interfaces network.InterfacesClient
+	watchers   network.WatchersClient
+}
+
+var _ client = (*azureClient)(nil)
+
+// newClient creates a new network watchers client from subscription ID.
+func newClient(auth azure.Authorizer) *azureClient {
+	return &azureClient{
+		subnets:    newSubnetsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		interfaces: newInterfacesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		watchers:   newWatchersClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+	}
+}
+
+// newSubnetsClient creates a new subnets client from subscription ID.
+func newSubnetsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) network.SubnetsClient {
+	subnetsClient := network.NewSubnetsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&subnetsClient.Client, authorizer)
+	return subnetsClient
+}
+
+// newInterfacesClient creates a new network interfaces client from subscription ID.
+func newInterfacesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) network.InterfacesClient {
+	interfacesClient := network.NewInterfacesClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&interfacesClient.Client, authorizer)
+	return interfacesClient
+}
+
+// newWatchersClient creates a new network watchers client from subscription ID.
+func newWatchersClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) network.WatchersClient {
+	watchersClient := network.NewWatchersClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&watchersClient.Client, authorizer)
+	return watchersClient
+}
+
+// GetSubnet gets the specified subnet.
+func (ac *azureClient) GetSubnet(ctx context.Context, resourceGroupName, vnetName, subnetName string) (network.Subnet, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "networkwatchers.AzureClient.GetSubnet")
+	defer done()
+
+	return ac.subnets.Get(ctx, resourceGroupName, vnetName, subnetName, "")
+}
+
+// GetInterface gets the specified network interface.
+func (ac *azureClient) GetInterface(ctx context.Context, resourceGroupName, nicName string) (network.Interface, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "networkwatchers.AzureClient.GetInterface")
+	defer done()
+
+	return ac.interfaces.Get(ctx, resourceGroupName, nicName, "")
+}
+
+// VerifyIPFlow verifies with Network Watcher whether a packet is allowed or denied to or from a virtual machine, and
+// waits for the verification to complete.
+func (ac *azureClient) VerifyIPFlow(ctx context.Context, resourceGroupName, watcherName string, parameters network.VerificationIPFlowParameters) (network.VerificationIPFlowResult, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "networkwatchers.AzureClient.VerifyIPFlow")
+	defer done()
+
+	future, err := ac.watchers.VerifyIPFlow(ctx, resourceGroupName, watcherName, parameters)
+	if err != nil {
+		return network.VerificationIPFlowResult{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, verifyIPFlowTimeout)
+	defer cancel()
+
+	if err := future.WaitForCompletionRef(ctx, ac.watchers.Client); err != nil {
+		return network.VerificationIPFlowResult{}, err
+	}
+	return future.Result(ac.watchers)
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkwatchers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// NetworkWatcherScope defines the scope interface for a network watchers service.
+type NetworkWatcherScope interface {
+	azure.Authorizer
+	OutboundConnectivityCheckSpecs() []azure.OutboundConnectivityCheckSpec
+	SetOutboundConnectivityVerified()
+	SetOutboundConnectivityNotVerified(reason string, severity clusterv1.ConditionSeverity, messageFormat string, messageArgs ...interface{})
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope NetworkWatcherScope
+	client
+}
+
+// New creates a new service.
+func New(scope NetworkWatcherScope) *Service {
+	return &Service{
+		Scope:  scope,
+		client: newClient(scope),
+	}
+}
+
+// Reconcile verifies with Network Watcher that the node subnets are allowed to reach the destination of the outbound
+// connectivity check, and reports the result in the OutboundConnectivityVerified condition. A failed or denied
+// verification is a warning: it never returns an error.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "networkwatchers.Service.Reconcile")
+	defer done()
+
+	specs := s.Scope.OutboundConnectivityCheckSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+	destination := fmt.Sprintf("%s %s:%d", specs[0].Protocol, specs[0].DestinationIP, specs[0].Port)
+
+	var denied, failed, waiting []string
+	for _, spec := range specs {
+		result, err := s.verifySubnet(ctx, spec)
+		switch {
+		case err != nil:
+			log.V(2).Info("failed to verify outbound connectivity", "subnet", spec.SubnetName, "destination", destination, "error", err.Error())
+			failed = append(failed, fmt.Sprintf("subnet %s: %s", spec.SubnetName, err.Error()))
+		case result == nil:
+			waiting = append(waiting, spec.SubnetName)
+		case result.Access == network.AccessDeny:
+			log.V(2).Info("outbound connectivity is denied", "subnet", spec.SubnetName, "destination", destination, "rule", to.String(result.RuleName))
+			denied = append(denied, fmt.Sprintf("subnet %s by rule %s", spec.SubnetName, to.String(result.RuleName)))
+		}
+	}
+
+	switch {
+	case len(denied) > 0:
+		s.Scope.SetOutboundConnectivityNotVerified(infrav1.OutboundConnectivityDeniedReason, clusterv1.ConditionSeverityWarning,
+			"outbound traffic to %s is denied for %s", destination, strings.Join(denied, ", "))
+	case len(failed) > 0:
+		s.Scope.SetOutboundConnectivityNotVerified(infrav1.OutboundConnectivityCheckFailedReason, clusterv1.ConditionSeverityWarning,
+			"failed to verify outbound traffic to %s: %s", destination, strings.Join(failed, "; "))
+	case len(waiting) > 0:
+		s.Scope.SetOutboundConnectivityNotVerified(infrav1.WaitingForNodesReason, clusterv1.ConditionSeverityInfo,
+			"waiting for a virtual machine in subnets %s to verify outbound traffic to %s", strings.Join(waiting, ", "), destination)
+	default:
+		s.Scope.SetOutboundConnectivityVerified()
+	}
+
+	return nil
+}
+
+// Delete is a no-op as the outbound connectivity check doesn't create any Azure resource.
+func (s *Service) Delete(ctx context.Context) error {
+	_, _, done := tele.StartSpanWithLogger(ctx, "networkwatchers.Service.Delete")
+	defer done()
+
+	return nil
+}
+
+// verifySubnet verifies the outbound traffic from the first virtual machine found in the subnet of the spec. It
+// returns a nil result when the subnet doesn't have a virtual machine yet.
+func (s *Service) verifySubnet(ctx context.Context, spec azure.OutboundConnectivityCheckSpec) (*network.VerificationIPFlowResult, error) {
+	subnet, err := s.client.GetSubnet(ctx, spec.VNetResourceGroup, spec.VNetName, spec.SubnetName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get subnet %s", spec.SubnetName)
+	}
+	if subnet.SubnetPropertiesFormat == nil || subnet.IPConfigurations == nil {
+		return nil, nil
+	}
+
+	for _, ipConfig := range *subnet.IPConfigurations {
+		resourceGroup, nicName, ipConfigName, ok := parseNICIPConfigurationID(to.String(ipConfig.ID))
+		if !ok {
+			continue
+		}
+
+		nic, err := s.client.GetInterface(ctx, resourceGroup, nicName)
+		if azure.ResourceNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "failed to get network interface %s", nicName)
+		}
+
+		vmID, privateIP := nicAddress(nic, ipConfigName)
+		if vmID == "" || privateIP == "" {
+			continue
+		}
+
+		result, err := s.client.VerifyIPFlow(ctx, spec.NetworkWatcherResourceGroup, spec.NetworkWatcherName, network.VerificationIPFlowParameters{
+			TargetResourceID: to.StringPtr(vmID),
+			Direction:        network.DirectionOutbound,
+			Protocol:         network.IPFlowProtocol(spec.Protocol),
+			LocalPort:        to.StringPtr("*"),
+			RemotePort:       to.StringPtr(strconv.Itoa(int(spec.Port))),
+			LocalIPAddress:   to.StringPtr(privateIP),
+			RemoteIPAddress:  to.StringPtr(spec.DestinationIP),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to verify IP flow with network watcher %s", spec.NetworkWatcherName)
+		}
+		return &result, nil
+	}
+
+	return nil, nil
+}
+
+// parseNICIPConfigurationID returns the resource group, network interface and IP configuration names of a
+// standalone network interface IP configuration ID. Other IP configurations of a subnet, e.g. the ones of a scale
+// set instance or of a private endpoint, are not matched.
+func parseNICIPConfigurationID(id string) (resourceGroup, nicName, ipConfigName string, ok bool) {
+	// /subscriptions/<sub>/resourceGroups/<rg>/providers/Microsoft.Network/networkInterfaces/<nic>/ipConfigurations/<ipconfig>
+	parts := strings.Split(id, "/")
+	if len(parts) != 11 ||
+		!strings.EqualFold(parts[3], "resourceGroups") ||
+		!strings.EqualFold(parts[6], "Microsoft.Network") ||
+		!strings.EqualFold(parts[7], "networkInterfaces") ||
+		!strings.EqualFold(parts[9], "ipConfigurations") {
+		return "", "", "", false
+	}
+	return parts[4], parts[8], parts[10], true
+}
+
+// nicAddress returns the ID of the virtual machine a network interface is attached to, and the private IP address
+// of the named IP configuration of the network interface.
+func nicAddress(nic network.Interface, ipConfigName string) (vmID, privateIP string) {
+	if nic.InterfacePropertiesFormat == nil || nic.VirtualMachine == nil || nic.IPConfigurations == nil {
+		return "", ""
+	}
+	for _, ipConfig := range *nic.IPConfigurations {
+		if strings.EqualFold(to.String(ipConfig.Name), ipConfigName) && ipConfig.InterfaceIPConfigurationPropertiesFormat != nil {
+			return to.String(nic.VirtualMachine.ID), to.String(ipConfig.PrivateIPAddress)
+		}
+	}
+	return "", ""
+}
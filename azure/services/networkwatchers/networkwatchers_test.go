@@ -0,0 +1,190 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkwatchers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkwatchers/mock_networkwatchers"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	fakeVMID           = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm"
+	fakeIPConfigID     = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkInterfaces/my-vm-nic/ipConfigurations/pipConfig"
+	fakeVMSSIPConfigID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachineScaleSets/my-vmss/virtualMachines/0/networkInterfaces/my-vmss-nic/ipConfigurations/ipconfig1"
+)
+
+var (
+	fakeSpec = azure.OutboundConnectivityCheckSpec{
+		VNetResourceGroup:           "my-rg",
+		VNetName:                    "my-vnet",
+		SubnetName:                  "node-subnet",
+		DestinationIP:               "20.37.158.0",
+		Port:                        443,
+		Protocol:                    "TCP",
+		NetworkWatcherName:          "NetworkWatcher_westus2",
+		NetworkWatcherResourceGroup: "NetworkWatcherRG",
+	}
+	fakeSubnet = network.Subnet{
+		SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+			IPConfigurations: &[]network.IPConfiguration{
+				{ID: to.StringPtr(fakeVMSSIPConfigID)},
+				{ID: to.StringPtr(fakeIPConfigID)},
+			},
+		},
+	}
+	fakeNIC = network.Interface{
+		InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+			VirtualMachine: &network.SubResource{ID: to.StringPtr(fakeVMID)},
+			IPConfigurations: &[]network.InterfaceIPConfiguration{
+				{
+					Name: to.StringPtr("pipConfig"),
+					InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+						PrivateIPAddress: to.StringPtr("10.1.0.4"),
+					},
+				},
+			},
+		},
+	}
+	fakeParameters = network.VerificationIPFlowParameters{
+		TargetResourceID: to.StringPtr(fakeVMID),
+		Direction:        network.DirectionOutbound,
+		Protocol:         network.IPFlowProtocolTCP,
+		LocalPort:        to.StringPtr("*"),
+		RemotePort:       to.StringPtr("443"),
+		LocalIPAddress:   to.StringPtr("10.1.0.4"),
+		RemoteIPAddress:  to.StringPtr("20.37.158.0"),
+	}
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+)
+
+func TestReconcileNetworkWatchers(t *testing.T) {
+	testcases := []struct {
+		name   string
+		expect func(s *mock_networkwatchers.MockNetworkWatcherScopeMockRecorder, m *mock_networkwatchers.MockclientMockRecorder)
+	}{
+		{
+			name: "no outbound connectivity check",
+			expect: func(s *mock_networkwatchers.MockNetworkWatcherScopeMockRecorder, m *mock_networkwatchers.MockclientMockRecorder) {
+				s.OutboundConnectivityCheckSpecs().Return(nil)
+			},
+		},
+		{
+			name: "outbound traffic is allowed",
+			expect: func(s *mock_networkwatchers.MockNetworkWatcherScopeMockRecorder, m *mock_networkwatchers.MockclientMockRecorder) {
+				s.OutboundConnectivityCheckSpecs().Return([]azure.OutboundConnectivityCheckSpec{fakeSpec})
+				gomock.InOrder(
+					m.GetSubnet(gomockinternal.AContext(), "my-rg", "my-vnet", "node-subnet").Return(fakeSubnet, nil),
+					m.GetInterface(gomockinternal.AContext(), "my-rg", "my-vm-nic").Return(fakeNIC, nil),
+					m.VerifyIPFlow(gomockinternal.AContext(), "NetworkWatcherRG", "NetworkWatcher_westus2", fakeParameters).Return(network.VerificationIPFlowResult{
+						Access:   network.AccessAllow,
+						RuleName: to.StringPtr("defaultSecurityRules/AllowInternetOutBound"),
+					}, nil),
+					s.SetOutboundConnectivityVerified(),
+				)
+			},
+		},
+		{
+			name: "outbound traffic is denied",
+			expect: func(s *mock_networkwatchers.MockNetworkWatcherScopeMockRecorder, m *mock_networkwatchers.MockclientMockRecorder) {
+				s.OutboundConnectivityCheckSpecs().Return([]azure.OutboundConnectivityCheckSpec{fakeSpec})
+				gomock.InOrder(
+					m.GetSubnet(gomockinternal.AContext(), "my-rg", "my-vnet", "node-subnet").Return(fakeSubnet, nil),
+					m.GetInterface(gomockinternal.AContext(), "my-rg", "my-vm-nic").Return(fakeNIC, nil),
+					m.VerifyIPFlow(gomockinternal.AContext(), "NetworkWatcherRG", "NetworkWatcher_westus2", fakeParameters).Return(network.VerificationIPFlowResult{
+						Access:   network.AccessDeny,
+						RuleName: to.StringPtr("securityRules/deny-internet"),
+					}, nil),
+					s.SetOutboundConnectivityNotVerified(infrav1.OutboundConnectivityDeniedReason, clusterv1.ConditionSeverityWarning,
+						"outbound traffic to %s is denied for %s", "TCP 20.37.158.0:443", "subnet node-subnet by rule securityRules/deny-internet"),
+				)
+			},
+		},
+		{
+			name: "network watcher fails",
+			expect: func(s *mock_networkwatchers.MockNetworkWatcherScopeMockRecorder, m *mock_networkwatchers.MockclientMockRecorder) {
+				s.OutboundConnectivityCheckSpecs().Return([]azure.OutboundConnectivityCheckSpec{fakeSpec})
+				gomock.InOrder(
+					m.GetSubnet(gomockinternal.AContext(), "my-rg", "my-vnet", "node-subnet").Return(fakeSubnet, nil),
+					m.GetInterface(gomockinternal.AContext(), "my-rg", "my-vm-nic").Return(fakeNIC, nil),
+					m.VerifyIPFlow(gomockinternal.AContext(), "NetworkWatcherRG", "NetworkWatcher_westus2", fakeParameters).Return(network.VerificationIPFlowResult{}, internalError),
+					s.SetOutboundConnectivityNotVerified(infrav1.OutboundConnectivityCheckFailedReason, clusterv1.ConditionSeverityWarning,
+						"failed to verify outbound traffic to %s: %s", "TCP 20.37.158.0:443", gomock.Any()),
+				)
+			},
+		},
+		{
+			name: "subnet has no virtual machine yet",
+			expect: func(s *mock_networkwatchers.MockNetworkWatcherScopeMockRecorder, m *mock_networkwatchers.MockclientMockRecorder) {
+				s.OutboundConnectivityCheckSpecs().Return([]azure.OutboundConnectivityCheckSpec{fakeSpec})
+				gomock.InOrder(
+					m.GetSubnet(gomockinternal.AContext(), "my-rg", "my-vnet", "node-subnet").Return(network.Subnet{SubnetPropertiesFormat: &network.SubnetPropertiesFormat{}}, nil),
+					s.SetOutboundConnectivityNotVerified(infrav1.WaitingForNodesReason, clusterv1.ConditionSeverityInfo,
+						"waiting for a virtual machine in subnets %s to verify outbound traffic to %s", "node-subnet", "TCP 20.37.158.0:443"),
+				)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_networkwatchers.NewMockNetworkWatcherScope(mockCtrl)
+			clientMock := mock_networkwatchers.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			g.Expect(s.Reconcile(context.TODO())).To(Succeed())
+		})
+	}
+}
+
+func TestParseNICIPConfigurationID(t *testing.T) {
+	g := NewWithT(t)
+
+	resourceGroup, nicName, ipConfigName, ok := parseNICIPConfigurationID(fakeIPConfigID)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(resourceGroup).To(Equal("my-rg"))
+	g.Expect(nicName).To(Equal("my-vm-nic"))
+	g.Expect(ipConfigName).To(Equal("pipConfig"))
+
+	_, _, _, ok = parseNICIPConfigurationID(fakeVMSSIPConfigID)
+	g.Expect(ok).To(BeFalse())
+
+	_, _, _, ok = parseNICIPConfigurationID("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-lb/frontendIPConfigurations/my-frontend")
+	g.Expect(ok).To(BeFalse())
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policyassignments
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-09-01/policy"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// client wraps go-sdk.
+type client interface {
+	List(context.Context, string) ([]policy.Assignment, error)
+	Create(context.Context, string, string, policy.Assignment) (policy.Assignment, error)
+	Delete(context.Context, string, string) error
+}
+
+// azureClient contains the Azure go-sdk Client.
+type azureClient struct {
+	assignments policy.AssignmentsClient
+}
+
+var _ client = (*azureClient)(nil)
+
+// newClient creates a new policy assignments client from subscription ID.
+func newClient(auth azure.Authorizer) *azureClient {
+	return &azureClient{
+		assignments: newAssignmentsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+	}
+}
+
+// newAssignmentsClient creates a new policy assignments client from subscription ID.
+func newAssignmentsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) policy.AssignmentsClient {
+	assignmentsClient := policy.NewAssignmentsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&assignmentsClient.Client, authorizer)
+	return assignmentsClient
+}
+
+// List returns the policy assignments in effect in a resource group, i.e. the ones assigned to the resource group and
+// to the subscription and management groups it belongs to.
+func (ac *azureClient) List(ctx context.Context, resourceGroupName string) ([]policy.Assignment, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "policyassignments.AzureClient.List")
+	defer done()
+
+	itr, err := ac.assignments.ListForResourceGroupComplete(ctx, resourceGroupName, "atScope()")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list policy assignments in the resource group")
+	}
+
+	var assignments []policy.Assignment
+	for ; itr.NotDone(); err = itr.NextWithContext(ctx) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate policy assignments [%w]", err)
+		}
+		assignments = append(assignments, itr.Value())
+	}
+	return assignments, nil
+}
+
+// Create creates or updates a policy assignment.
+func (ac *azureClient) Create(ctx context.Context, scope string, name string, assignment policy.Assignment) (policy.Assignment, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "policyassignments.AzureClient.Create")
+	defer done()
+
+	return ac.assignments.Create(ctx, scope, name, assignment)
+}
+
+// Delete deletes a policy assignment.
+func (ac *azureClient) Delete(ctx context.Context, scope string, name string) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "policyassignments.AzureClient.Delete")
+	defer done()
+
+	_, err := ac.assignments.Delete(ctx, scope, name)
+	return err
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policyassignments
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-09-01/policy"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// PolicyAssignmentsScope defines the scope interface for a policy assignments service.
+type PolicyAssignmentsScope interface {
+	azure.Authorizer
+	ResourceGroup() string
+	ClusterName() string
+	PolicyAssignments() []infrav1.PolicyAssignment
+	PolicyAssignmentIDs() map[string]string
+	SetPolicyAssignmentIDs(map[string]string)
+	SetPolicyAssignmentsReady()
+	SetPolicyAssignmentsNotReady(reason string, severity clusterv1.ConditionSeverity, messageFormat string, messageArgs ...interface{})
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope PolicyAssignmentsScope
+	client
+}
+
+// New creates a new service.
+func New(scope PolicyAssignmentsScope) *Service {
+	return &Service{
+		Scope:  scope,
+		client: newClient(scope),
+	}
+}
+
+// Reconcile assigns the policies of the spec to the resource group of the cluster, and removes the assignments
+// created by CAPZ that are no longer in the spec. An assignment that already exists, with the same name or of the same
+// definition, is adopted as is. A lack of permission to manage the assignments is reported in the
+// PolicyAssignmentsReady condition and doesn't block the reconciliation of the cluster.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "policyassignments.Service.Reconcile")
+	defer done()
+
+	desired := s.Scope.PolicyAssignments()
+	if len(desired) == 0 && len(s.Scope.PolicyAssignmentIDs()) == 0 {
+		return nil
+	}
+
+	existing, err := s.client.List(ctx, s.Scope.ResourceGroup())
+	if err != nil {
+		return s.handleError(err, "failed to list policy assignments of resource group %s", s.Scope.ResourceGroup())
+	}
+
+	ids := make(map[string]string, len(desired))
+	for _, assignment := range desired {
+		id, err := s.reconcileAssignment(ctx, assignment, existing)
+		if err != nil {
+			return s.handleError(err, "failed to reconcile policy assignment %s", assignment.Name)
+		}
+		ids[assignment.Name] = id
+	}
+
+	for _, assignment := range s.ownedAssignments(existing) {
+		if _, ok := ids[to.String(assignment.Name)]; ok {
+			continue
+		}
+		log.V(2).Info("deleting policy assignment", "policy assignment", to.String(assignment.Name))
+		if err := s.client.Delete(ctx, s.scope(), to.String(assignment.Name)); err != nil && !azure.ResourceNotFound(err) {
+			return s.handleError(err, "failed to delete policy assignment %s", to.String(assignment.Name))
+		}
+	}
+
+	s.Scope.SetPolicyAssignmentIDs(ids)
+	if len(desired) > 0 {
+		s.Scope.SetPolicyAssignmentsReady()
+	}
+	return nil
+}
+
+// Delete removes the policy assignments created by CAPZ from the resource group of the cluster. Adopted assignments
+// are left in place.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "policyassignments.Service.Delete")
+	defer done()
+
+	if len(s.Scope.PolicyAssignments()) == 0 && len(s.Scope.PolicyAssignmentIDs()) == 0 {
+		return nil
+	}
+
+	existing, err := s.client.List(ctx, s.Scope.ResourceGroup())
+	if azure.ResourceNotFound(err) {
+		// the resource group and its assignments are already gone.
+		s.Scope.SetPolicyAssignmentIDs(nil)
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(forbiddenError(err, s.Scope.ResourceGroup()), "failed to list policy assignments of resource group %s", s.Scope.ResourceGroup())
+	}
+
+	for _, assignment := range s.ownedAssignments(existing) {
+		log.V(2).Info("deleting policy assignment", "policy assignment", to.String(assignment.Name))
+		if err := s.client.Delete(ctx, s.scope(), to.String(assignment.Name)); err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrapf(forbiddenError(err, s.Scope.ResourceGroup()), "failed to delete policy assignment %s", to.String(assignment.Name))
+		}
+	}
+	s.Scope.SetPolicyAssignmentIDs(nil)
+	return nil
+}
+
+// reconcileAssignment creates or updates the policy assignment, unless an assignment with the same name or of the
+// same definition already exists and isn't owned by the cluster, and returns the ID of the assignment in effect.
+func (s *Service) reconcileAssignment(ctx context.Context, assignment infrav1.PolicyAssignment, existing []policy.Assignment) (string, error) {
+	_, log, done := tele.StartSpanWithLogger(ctx, "policyassignments.Service.reconcileAssignment")
+	defer done()
+
+	desired, err := s.parameters(assignment)
+	if err != nil {
+		return "", err
+	}
+
+	if found := s.findByName(existing, assignment.Name); found != nil {
+		if !s.isOwned(*found) {
+			if !strings.EqualFold(definitionID(*found), assignment.PolicyDefinitionID) {
+				return "", azure.WithTerminalError(errors.Errorf("policy assignment %s already exists in resource group %s with policy definition %s",
+					assignment.Name, s.Scope.ResourceGroup(), definitionID(*found)))
+			}
+			log.V(4).Info("adopting existing policy assignment", "policy assignment", assignment.Name)
+			return to.String(found.ID), nil
+		}
+		if isUpToDate(*found, desired) {
+			return to.String(found.ID), nil
+		}
+	} else if found := s.findByDefinition(existing, assignment.PolicyDefinitionID); found != nil {
+		log.V(4).Info("adopting existing policy assignment of the same policy definition", "policy assignment", assignment.Name, "existing", to.String(found.ID))
+		return to.String(found.ID), nil
+	}
+
+	log.V(2).Info("creating or updating policy assignment", "policy assignment", assignment.Name)
+	result, err := s.client.Create(ctx, s.scope(), assignment.Name, desired)
+	if err != nil {
+		return "", err
+	}
+	log.V(2).Info("successfully created or updated policy assignment", "policy assignment", assignment.Name)
+	return to.String(result.ID), nil
+}
+
+// handleError reports a reconcile error in the PolicyAssignmentsReady condition. A lack of permission isn't expected
+// to resolve by retrying, so it isn't returned.
+func (s *Service) handleError(err error, messageFormat string, messageArgs ...interface{}) error {
+	err = errors.Wrapf(forbiddenError(err, s.Scope.ResourceGroup()), messageFormat, messageArgs...)
+	if azure.ResourceForbidden(err) {
+		s.Scope.SetPolicyAssignmentsNotReady(infrav1.PolicyAssignmentForbiddenReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+		return nil
+	}
+	s.Scope.SetPolicyAssignmentsNotReady(infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+	return err
+}
+
+// forbiddenError explains which permission is missing when Azure denies the management of policy assignments.
+func forbiddenError(err error, resourceGroup string) error {
+	if !azure.ResourceForbidden(err) {
+		return err
+	}
+	return errors.Wrapf(err, "the identity of the cluster is not allowed to manage the policy assignments of resource group %s, "+
+		"it requires the Microsoft.Authorization/policyAssignments/write permission, e.g. from the Resource Policy Contributor role", resourceGroup)
+}
+
+// parameters returns the policy assignment to create for the spec, marked as owned by the cluster in its metadata.
+func (s *Service) parameters(assignment infrav1.PolicyAssignment) (policy.Assignment, error) {
+	parameters := make(map[string]*policy.ParameterValuesValue, len(assignment.Parameters))
+	for name, value := range assignment.Parameters {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+			return policy.Assignment{}, azure.WithTerminalError(errors.Wrapf(err, "invalid value of parameter %s of policy assignment %s", name, assignment.Name))
+		}
+		parameters[name] = &policy.ParameterValuesValue{Value: decoded}
+	}
+
+	enforcementMode := policy.Default
+	if assignment.EnforcementMode == infrav1.PolicyEnforcementModeDoNotEnforce {
+		enforcementMode = policy.DoNotEnforce
+	}
+
+	properties := &policy.AssignmentProperties{
+		PolicyDefinitionID: to.StringPtr(assignment.PolicyDefinitionID),
+		Scope:              to.StringPtr(s.scope()),
+		Parameters:         parameters,
+		EnforcementMode:    enforcementMode,
+		Metadata: map[string]interface{}{
+			infrav1.ClusterTagKey(s.Scope.ClusterName()): string(infrav1.ResourceLifecycleOwned),
+		},
+	}
+	if assignment.DisplayName != "" {
+		properties.DisplayName = to.StringPtr(assignment.DisplayName)
+	}
+	return policy.Assignment{AssignmentProperties: properties}, nil
+}
+
+// scope returns the resource ID of the resource group of the cluster, the scope of its policy assignments.
+func (s *Service) scope() string {
+	return azure.ResourceGroupID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup())
+}
+
+// isAtScope returns true if the policy assignment is assigned to the resource group of the cluster rather than
+// inherited from its subscription or management group.
+func (s *Service) isAtScope(assignment policy.Assignment) bool {
+	return assignment.AssignmentProperties != nil && strings.EqualFold(to.String(assignment.Scope), s.scope())
+}
+
+// isOwned returns true if the policy assignment was created by CAPZ for the cluster.
+func (s *Service) isOwned(assignment policy.Assignment) bool {
+	if !s.isAtScope(assignment) {
+		return false
+	}
+	metadata, ok := assignment.Metadata.(map[string]interface{})
+	return ok && metadata[infrav1.ClusterTagKey(s.Scope.ClusterName())] == string(infrav1.ResourceLifecycleOwned)
+}
+
+// ownedAssignments returns the policy assignments created by CAPZ for the cluster.
+func (s *Service) ownedAssignments(existing []policy.Assignment) []policy.Assignment {
+	var owned []policy.Assignment
+	for _, assignment := range existing {
+		if s.isOwned(assignment) {
+			owned = append(owned, assignment)
+		}
+	}
+	return owned
+}
+
+// findByName returns the policy assignment of the resource group with the given name, if any.
+func (s *Service) findByName(existing []policy.Assignment, name string) *policy.Assignment {
+	for i := range existing {
+		if s.isAtScope(existing[i]) && strings.EqualFold(to.String(existing[i].Name), name) {
+			return &existing[i]
+		}
+	}
+	return nil
+}
+
+// findByDefinition returns a policy assignment in effect in the resource group, and not owned by the cluster, of the
+// given policy definition, if any.
+func (s *Service) findByDefinition(existing []policy.Assignment, policyDefinitionID string) *policy.Assignment {
+	for i := range existing {
+		if !s.isOwned(existing[i]) && strings.EqualFold(definitionID(existing[i]), policyDefinitionID) {
+			return &existing[i]
+		}
+	}
+	return nil
+}
+
+// definitionID returns the ID of the policy definition or initiative of the policy assignment.
+func definitionID(assignment policy.Assignment) string {
+	if assignment.AssignmentProperties == nil {
+		return ""
+	}
+	return to.String(assignment.PolicyDefinitionID)
+}
+
+// isUpToDate returns true if the existing policy assignment has the desired definition, display name, parameters and
+// enforcement mode.
+func isUpToDate(existing, desired policy.Assignment) bool {
+	if existing.AssignmentProperties == nil {
+		return false
+	}
+	existingMode := existing.EnforcementMode
+	if existingMode == "" {
+		existingMode = policy.Default
+	}
+	return strings.EqualFold(to.String(existing.PolicyDefinitionID), to.String(desired.PolicyDefinitionID)) &&
+		to.String(existing.DisplayName) == to.String(desired.DisplayName) &&
+		existingMode == desired.EnforcementMode &&
+		reflect.DeepEqual(parameterValues(existing.Parameters), parameterValues(desired.Parameters))
+}
+
+func parameterValues(parameters map[string]*policy.ParameterValuesValue) map[string]interface{} {
+	values := make(map[string]interface{}, len(parameters))
+	for name, parameter := range parameters {
+		if parameter != nil {
+			values[name] = parameter.Value
+		}
+	}
+	return values
+}
@@ -0,0 +1,315 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policyassignments
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-09-01/policy"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/policyassignments/mock_policyassignments"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	fakeScope               = "/subscriptions/123/resourceGroups/my-rg"
+	fakeLocationsDefinition = "/providers/Microsoft.Authorization/policyDefinitions/e56962a6-4747-49cd-b67b-bf8b01975c4c"
+	fakeBaselineDefinition  = "/providers/Microsoft.Management/managementGroups/platform/providers/Microsoft.Authorization/policySetDefinitions/baseline"
+	fakeLocationsID         = fakeScope + "/providers/Microsoft.Authorization/policyAssignments/allowed-locations"
+	fakeBaselineID          = "/providers/Microsoft.Management/managementGroups/platform/providers/Microsoft.Authorization/policyAssignments/baseline"
+)
+
+var (
+	fakeLocationsAssignment = infrav1.PolicyAssignment{
+		Name:               "allowed-locations",
+		PolicyDefinitionID: fakeLocationsDefinition,
+		Parameters:         map[string]string{"listOfAllowedLocations": `["eastus"]`},
+	}
+	fakeBaselineAssignment = infrav1.PolicyAssignment{
+		Name:               "baseline",
+		PolicyDefinitionID: fakeBaselineDefinition,
+	}
+	ownedMetadata = map[string]interface{}{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned"}
+
+	forbidden = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusForbidden}, "AuthorizationFailed")
+)
+
+// desiredLocationsAssignment is the policy assignment created for fakeLocationsAssignment.
+func desiredLocationsAssignment() policy.Assignment {
+	return policy.Assignment{
+		AssignmentProperties: &policy.AssignmentProperties{
+			PolicyDefinitionID: to.StringPtr(fakeLocationsDefinition),
+			Scope:              to.StringPtr(fakeScope),
+			Parameters:         map[string]*policy.ParameterValuesValue{"listOfAllowedLocations": {Value: []interface{}{"eastus"}}},
+			EnforcementMode:    policy.Default,
+			Metadata:           ownedMetadata,
+		},
+	}
+}
+
+// existingLocationsAssignment is the policy assignment of fakeLocationsAssignment as returned by Azure.
+func existingLocationsAssignment(metadata interface{}, locations ...interface{}) policy.Assignment {
+	return policy.Assignment{
+		ID:   to.StringPtr(fakeLocationsID),
+		Name: to.StringPtr("allowed-locations"),
+		AssignmentProperties: &policy.AssignmentProperties{
+			PolicyDefinitionID: to.StringPtr(fakeLocationsDefinition),
+			Scope:              to.StringPtr(fakeScope),
+			Parameters:         map[string]*policy.ParameterValuesValue{"listOfAllowedLocations": {Value: locations}},
+			EnforcementMode:    policy.Default,
+			Metadata:           metadata,
+		},
+	}
+}
+
+func expectScope(s *mock_policyassignments.MockPolicyAssignmentsScopeMockRecorder) {
+	s.ResourceGroup().Return("my-rg").AnyTimes()
+	s.SubscriptionID().Return("123").AnyTimes()
+	s.ClusterName().Return("my-cluster").AnyTimes()
+}
+
+func TestReconcilePolicyAssignments(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_policyassignments.MockPolicyAssignmentsScopeMockRecorder, m *mock_policyassignments.MockclientMockRecorder)
+	}{
+		{
+			name: "no policy assignments",
+			expect: func(s *mock_policyassignments.MockPolicyAssignmentsScopeMockRecorder, m *mock_policyassignments.MockclientMockRecorder) {
+				s.PolicyAssignments().Return(nil)
+				s.PolicyAssignmentIDs().Return(nil)
+			},
+		},
+		{
+			name: "create policy assignment",
+			expect: func(s *mock_policyassignments.MockPolicyAssignmentsScopeMockRecorder, m *mock_policyassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.PolicyAssignments().Return([]infrav1.PolicyAssignment{fakeLocationsAssignment})
+				m.List(gomockinternal.AContext(), "my-rg").Return(nil, nil)
+				m.Create(gomockinternal.AContext(), fakeScope, "allowed-locations", gomockinternal.DiffEq(desiredLocationsAssignment())).
+					Return(existingLocationsAssignment(ownedMetadata, "eastus"), nil)
+				s.SetPolicyAssignmentIDs(map[string]string{"allowed-locations": fakeLocationsID})
+				s.SetPolicyAssignmentsReady()
+			},
+		},
+		{
+			name: "owned policy assignment is up to date",
+			expect: func(s *mock_policyassignments.MockPolicyAssignmentsScopeMockRecorder, m *mock_policyassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.PolicyAssignments().Return([]infrav1.PolicyAssignment{fakeLocationsAssignment})
+				m.List(gomockinternal.AContext(), "my-rg").Return([]policy.Assignment{existingLocationsAssignment(ownedMetadata, "eastus")}, nil)
+				s.SetPolicyAssignmentIDs(map[string]string{"allowed-locations": fakeLocationsID})
+				s.SetPolicyAssignmentsReady()
+			},
+		},
+		{
+			name: "update owned policy assignment with different parameters",
+			expect: func(s *mock_policyassignments.MockPolicyAssignmentsScopeMockRecorder, m *mock_policyassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.PolicyAssignments().Return([]infrav1.PolicyAssignment{fakeLocationsAssignment})
+				m.List(gomockinternal.AContext(), "my-rg").Return([]policy.Assignment{existingLocationsAssignment(ownedMetadata, "eastus", "westus2")}, nil)
+				m.Create(gomockinternal.AContext(), fakeScope, "allowed-locations", gomockinternal.DiffEq(desiredLocationsAssignment())).
+					Return(existingLocationsAssignment(ownedMetadata, "eastus"), nil)
+				s.SetPolicyAssignmentIDs(map[string]string{"allowed-locations": fakeLocationsID})
+				s.SetPolicyAssignmentsReady()
+			},
+		},
+		{
+			name: "adopt existing policy assignment with the same name",
+			expect: func(s *mock_policyassignments.MockPolicyAssignmentsScopeMockRecorder, m *mock_policyassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.PolicyAssignments().Return([]infrav1.PolicyAssignment{fakeLocationsAssignment})
+				m.List(gomockinternal.AContext(), "my-rg").Return([]policy.Assignment{existingLocationsAssignment(nil, "eastus", "westus2")}, nil)
+				s.SetPolicyAssignmentIDs(map[string]string{"allowed-locations": fakeLocationsID})
+				s.SetPolicyAssignmentsReady()
+			},
+		},
+		{
+			name: "adopt inherited policy assignment of the same definition",
+			expect: func(s *mock_policyassignments.MockPolicyAssignmentsScopeMockRecorder, m *mock_policyassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.PolicyAssignments().Return([]infrav1.PolicyAssignment{fakeBaselineAssignment})
+				m.List(gomockinternal.AContext(), "my-rg").Return([]policy.Assignment{
+					{
+						ID:   to.StringPtr(fakeBaselineID),
+						Name: to.StringPtr("platform-baseline"),
+						AssignmentProperties: &policy.AssignmentProperties{
+							PolicyDefinitionID: to.StringPtr(fakeBaselineDefinition),
+							Scope:              to.StringPtr("/providers/Microsoft.Management/managementGroups/platform"),
+						},
+					},
+				}, nil)
+				s.SetPolicyAssignmentIDs(map[string]string{"baseline": fakeBaselineID})
+				s.SetPolicyAssignmentsReady()
+			},
+		},
+		{
+			name:          "existing policy assignment with the same name of another definition",
+			expectedError: "policy assignment allowed-locations already exists in resource group my-rg with policy definition " + fakeBaselineDefinition,
+			expect: func(s *mock_policyassignments.MockPolicyAssignmentsScopeMockRecorder, m *mock_policyassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.PolicyAssignments().Return([]infrav1.PolicyAssignment{fakeLocationsAssignment})
+				existing := existingLocationsAssignment(nil)
+				existing.PolicyDefinitionID = to.StringPtr(fakeBaselineDefinition)
+				m.List(gomockinternal.AContext(), "my-rg").Return([]policy.Assignment{existing}, nil)
+				s.SetPolicyAssignmentsNotReady(infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s", gomock.Any())
+			},
+		},
+		{
+			name: "delete owned policy assignment removed from the spec",
+			expect: func(s *mock_policyassignments.MockPolicyAssignmentsScopeMockRecorder, m *mock_policyassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.PolicyAssignments().Return(nil)
+				s.PolicyAssignmentIDs().Return(map[string]string{"allowed-locations": fakeLocationsID})
+				m.List(gomockinternal.AContext(), "my-rg").Return([]policy.Assignment{existingLocationsAssignment(ownedMetadata, "eastus")}, nil)
+				m.Delete(gomockinternal.AContext(), fakeScope, "allowed-locations").Return(nil)
+				s.SetPolicyAssignmentIDs(map[string]string{})
+			},
+		},
+		{
+			name: "not allowed to assign policies",
+			expect: func(s *mock_policyassignments.MockPolicyAssignmentsScopeMockRecorder, m *mock_policyassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.PolicyAssignments().Return([]infrav1.PolicyAssignment{fakeLocationsAssignment})
+				m.List(gomockinternal.AContext(), "my-rg").Return(nil, nil)
+				m.Create(gomockinternal.AContext(), fakeScope, "allowed-locations", gomock.Any()).Return(policy.Assignment{}, forbidden)
+				s.SetPolicyAssignmentsNotReady(infrav1.PolicyAssignmentForbiddenReason, clusterv1.ConditionSeverityWarning, "%s",
+					"failed to reconcile policy assignment allowed-locations: the identity of the cluster is not allowed to manage the policy assignments of resource group my-rg, "+
+						"it requires the Microsoft.Authorization/policyAssignments/write permission, e.g. from the Resource Policy Contributor role: #: AuthorizationFailed: StatusCode=403")
+			},
+		},
+		{
+			name:          "failed to list policy assignments",
+			expectedError: "failed to list policy assignments of resource group my-rg: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_policyassignments.MockPolicyAssignmentsScopeMockRecorder, m *mock_policyassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.PolicyAssignments().Return([]infrav1.PolicyAssignment{fakeLocationsAssignment})
+				m.List(gomockinternal.AContext(), "my-rg").Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error"))
+				s.SetPolicyAssignmentsNotReady(infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s", gomock.Any())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_policyassignments.NewMockPolicyAssignmentsScope(mockCtrl)
+			clientMock := mock_policyassignments.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeletePolicyAssignments(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_policyassignments.MockPolicyAssignmentsScopeMockRecorder, m *mock_policyassignments.MockclientMockRecorder)
+	}{
+		{
+			name: "no policy assignments",
+			expect: func(s *mock_policyassignments.MockPolicyAssignmentsScopeMockRecorder, m *mock_policyassignments.MockclientMockRecorder) {
+				s.PolicyAssignments().Return(nil)
+				s.PolicyAssignmentIDs().Return(nil)
+			},
+		},
+		{
+			name: "delete owned policy assignments only",
+			expect: func(s *mock_policyassignments.MockPolicyAssignmentsScopeMockRecorder, m *mock_policyassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.PolicyAssignments().Return([]infrav1.PolicyAssignment{fakeLocationsAssignment, fakeBaselineAssignment})
+				adopted := existingLocationsAssignment(nil)
+				adopted.Name = to.StringPtr("baseline")
+				m.List(gomockinternal.AContext(), "my-rg").Return([]policy.Assignment{existingLocationsAssignment(ownedMetadata, "eastus"), adopted}, nil)
+				m.Delete(gomockinternal.AContext(), fakeScope, "allowed-locations").Return(nil)
+				s.SetPolicyAssignmentIDs(nil)
+			},
+		},
+		{
+			name: "resource group already deleted",
+			expect: func(s *mock_policyassignments.MockPolicyAssignmentsScopeMockRecorder, m *mock_policyassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.PolicyAssignments().Return([]infrav1.PolicyAssignment{fakeLocationsAssignment})
+				m.List(gomockinternal.AContext(), "my-rg").Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not Found"))
+				s.SetPolicyAssignmentIDs(nil)
+			},
+		},
+		{
+			name:          "not allowed to delete policy assignments",
+			expectedError: "failed to delete policy assignment allowed-locations: the identity of the cluster is not allowed to manage the policy assignments of resource group my-rg",
+			expect: func(s *mock_policyassignments.MockPolicyAssignmentsScopeMockRecorder, m *mock_policyassignments.MockclientMockRecorder) {
+				expectScope(s)
+				s.PolicyAssignments().Return([]infrav1.PolicyAssignment{fakeLocationsAssignment})
+				m.List(gomockinternal.AContext(), "my-rg").Return([]policy.Assignment{existingLocationsAssignment(ownedMetadata, "eastus")}, nil)
+				m.Delete(gomockinternal.AContext(), fakeScope, "allowed-locations").Return(forbidden)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_policyassignments.NewMockPolicyAssignmentsScope(mockCtrl)
+			clientMock := mock_policyassignments.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -28,6 +28,8 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
+const serviceName = "privatedns"
+
 // Scope defines the scope interface for a private dns service.
 type Scope interface {
 	azure.ClusterDescriber
@@ -56,7 +58,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	zoneSpec := s.Scope.PrivateDNSSpec()
 	if zoneSpec != nil {
 		// Skip the reconciliation of private DNS zone which is not managed by capz.
-		isManaged, err := s.isPrivateDNSManaged(ctx, s.Scope.ResourceGroup(), zoneSpec.ZoneName)
+		isManaged, err := s.isPrivateDNSManaged(ctx, s.Scope.ResourceGroup(), zoneSpec.ZoneName, "adopt")
 		if err != nil && !azure.ResourceNotFound(err) {
 			return errors.Wrapf(err, "could not get private DNS zone state of %s in resource group %s", zoneSpec.ZoneName, s.Scope.ResourceGroup())
 		}
@@ -88,7 +90,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		log.V(2).Info("successfully created private DNS zone", "private dns zone", zoneSpec.ZoneName)
 		for _, linkSpec := range zoneSpec.Links {
 			// If the virtual network link is not managed by capz, skip its reconciliation
-			isVnetLinkManaged, err := s.isVnetLinkManaged(ctx, s.Scope.ResourceGroup(), zoneSpec.ZoneName, linkSpec.LinkName)
+			isVnetLinkManaged, err := s.isVnetLinkManaged(ctx, s.Scope.ResourceGroup(), zoneSpec.ZoneName, linkSpec.LinkName, "adopt")
 			if err != nil && !azure.ResourceNotFound(err) {
 				return errors.Wrapf(err, "could not get vnet link state of %s in resource group %s", zoneSpec.ZoneName, s.Scope.ResourceGroup())
 			}
@@ -160,7 +162,7 @@ func (s *Service) Delete(ctx context.Context) error {
 	if zoneSpec != nil {
 		for _, linkSpec := range zoneSpec.Links {
 			// If the virtual network link is not managed by capz, skip its removal
-			isVnetLinkManaged, err := s.isVnetLinkManaged(ctx, s.Scope.ResourceGroup(), zoneSpec.ZoneName, linkSpec.LinkName)
+			isVnetLinkManaged, err := s.isVnetLinkManaged(ctx, s.Scope.ResourceGroup(), zoneSpec.ZoneName, linkSpec.LinkName, "delete")
 			if err != nil && !azure.ResourceNotFound(err) {
 				return errors.Wrapf(err, "could not get vnet link state of %s in resource group %s", zoneSpec.ZoneName, s.Scope.ResourceGroup())
 			}
@@ -175,7 +177,7 @@ func (s *Service) Delete(ctx context.Context) error {
 			}
 		}
 		// Skip the deletion of private DNS zone which is not managed by capz.
-		isManaged, err := s.isPrivateDNSManaged(ctx, s.Scope.ResourceGroup(), zoneSpec.ZoneName)
+		isManaged, err := s.isPrivateDNSManaged(ctx, s.Scope.ResourceGroup(), zoneSpec.ZoneName, "delete")
 		if err != nil && !azure.ResourceNotFound(err) {
 			return errors.Wrapf(err, "could not get private DNS zone state of %s in resource group %s", zoneSpec.ZoneName, s.Scope.ResourceGroup())
 		}
@@ -199,23 +201,31 @@ func (s *Service) Delete(ctx context.Context) error {
 }
 
 // isPrivateDNSManaged returns true if the private DNS has an owned tag with the cluster name as value,
-// meaning that the DNS lifecycle is managed.
-func (s *Service) isPrivateDNSManaged(ctx context.Context, resourceGroup, zoneName string) (bool, error) {
+// meaning that the DNS lifecycle is managed. A managed private DNS zone of another environment than the expected one
+// is reported as an error rather than acted on.
+func (s *Service) isPrivateDNSManaged(ctx context.Context, resourceGroup, zoneName, action string) (bool, error) {
 	zone, err := s.client.GetZone(ctx, resourceGroup, zoneName)
 	if err != nil {
 		return false, err
 	}
 	tags := converters.MapToTags(zone.Tags)
-	return tags.HasOwned(s.Scope.ClusterName()), nil
+	if !tags.HasOwned(s.Scope.ClusterName()) {
+		return false, nil
+	}
+	return true, azure.CheckEnvironment(s.Scope, zone, action, zoneName, resourceGroup, serviceName)
 }
 
 // isVnetLinkManaged returns true if the vnet link has an owned tag with the cluster name as value,
-// meaning that the vnet link lifecycle is managed.
-func (s *Service) isVnetLinkManaged(ctx context.Context, resourceGroupName, zoneName, vnetLinkName string) (bool, error) {
-	zone, err := s.client.GetLink(ctx, resourceGroupName, zoneName, vnetLinkName)
+// meaning that the vnet link lifecycle is managed. A managed vnet link of another environment than the expected one
+// is reported as an error rather than acted on.
+func (s *Service) isVnetLinkManaged(ctx context.Context, resourceGroupName, zoneName, vnetLinkName, action string) (bool, error) {
+	link, err := s.client.GetLink(ctx, resourceGroupName, zoneName, vnetLinkName)
 	if err != nil {
 		return false, err
 	}
-	tags := converters.MapToTags(zone.Tags)
-	return tags.HasOwned(s.Scope.ClusterName()), nil
+	tags := converters.MapToTags(link.Tags)
+	if !tags.HasOwned(s.Scope.ClusterName()) {
+		return false, nil
+	}
+	return true, azure.CheckEnvironment(s.Scope, link, action, vnetLinkName, resourceGroupName, serviceName)
 }
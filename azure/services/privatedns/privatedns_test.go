@@ -955,3 +955,113 @@ func TestDeletePrivateDNS(t *testing.T) {
 		})
 	}
 }
+
+// environmentScope is a Scope that expects the resources to belong to the dev environment.
+type environmentScope struct {
+	*mock_privatedns.MockScope
+}
+
+func (s environmentScope) ExpectedEnvironment() string {
+	return "dev"
+}
+
+func TestDeletePrivateDNSEnvironment(t *testing.T) {
+	ownedTags := func(env string) map[string]*string {
+		return map[string]*string{
+			"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+			"environment": to.StringPtr(env),
+		}
+	}
+
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_privatedns.MockScopeMockRecorder, m *mock_privatedns.MockclientMockRecorder)
+	}{
+		{
+			name: "delete the dns zone and vnet links of the expected environment",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, m *mock_privatedns.MockclientMockRecorder) {
+				s.PrivateDNSSpec().Return(&azure.PrivateDNSSpec{
+					ZoneName: "my-dns-zone",
+					Links:    []azure.PrivateDNSLinkSpec{{VNetName: "my-vnet", VNetResourceGroup: "vnet-rg", LinkName: "my-link"}},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.GetLink(gomockinternal.AContext(), "my-rg", "my-dns-zone", "my-link").Return(privatedns.VirtualNetworkLink{
+					Location: to.StringPtr("global"),
+					Tags:     ownedTags("dev"),
+				}, nil)
+				m.DeleteLink(gomockinternal.AContext(), "my-rg", "my-dns-zone", "my-link")
+				m.GetZone(gomockinternal.AContext(), "my-rg", "my-dns-zone").Return(privatedns.PrivateZone{
+					Location: to.StringPtr("global"),
+					Tags:     ownedTags("dev"),
+				}, nil)
+				m.DeleteZone(gomockinternal.AContext(), "my-rg", "my-dns-zone")
+			},
+		},
+		{
+			name:          "refuse to delete a dns zone of another environment",
+			expectedError: `refusing to delete resource my-rg/my-dns-zone (service: privatedns): its environment tag is "prod", expected "dev"`,
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, m *mock_privatedns.MockclientMockRecorder) {
+				s.PrivateDNSSpec().Return(&azure.PrivateDNSSpec{
+					ZoneName: "my-dns-zone",
+					Links:    []azure.PrivateDNSLinkSpec{{VNetName: "my-vnet", VNetResourceGroup: "vnet-rg", LinkName: "my-link"}},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.GetLink(gomockinternal.AContext(), "my-rg", "my-dns-zone", "my-link").Return(privatedns.VirtualNetworkLink{
+					Location: to.StringPtr("global"),
+					Tags:     ownedTags("dev"),
+				}, nil)
+				m.DeleteLink(gomockinternal.AContext(), "my-rg", "my-dns-zone", "my-link")
+				m.GetZone(gomockinternal.AContext(), "my-rg", "my-dns-zone").Return(privatedns.PrivateZone{
+					Location: to.StringPtr("global"),
+					Tags:     ownedTags("prod"),
+				}, nil)
+			},
+		},
+		{
+			name:          "refuse to delete a vnet link of another environment",
+			expectedError: `refusing to delete resource my-rg/my-link (service: privatedns): its environment tag is "prod", expected "dev"`,
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, m *mock_privatedns.MockclientMockRecorder) {
+				s.PrivateDNSSpec().Return(&azure.PrivateDNSSpec{
+					ZoneName: "my-dns-zone",
+					Links:    []azure.PrivateDNSLinkSpec{{VNetName: "my-vnet", VNetResourceGroup: "vnet-rg", LinkName: "my-link"}},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.GetLink(gomockinternal.AContext(), "my-rg", "my-dns-zone", "my-link").Return(privatedns.VirtualNetworkLink{
+					Location: to.StringPtr("global"),
+					Tags:     ownedTags("prod"),
+				}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_privatedns.NewMockScope(mockCtrl)
+			clientMock := mock_privatedns.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  environmentScope{MockScope: scopeMock},
+				client: clientMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedError)))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privateendpoints
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const (
+	serviceName          = "privateendpoints"
+	zoneGroupServiceName = "privatednszonegroups"
+)
+
+// PrivateEndpointScope defines the scope interface for a private endpoints service.
+type PrivateEndpointScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	ResourceGroup() string
+	PrivateEndpointSpecs() []azure.ResourceSpecGetter
+	PrivateEndpointIPs() map[string]string
+	SetPrivateEndpointIPs(map[string]string)
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope PrivateEndpointScope
+	async.Reconciler
+	// zoneGroups reconciles the private DNS zone groups of the private endpoints.
+	zoneGroups async.Reconciler
+	// subnets gets the subnets the private endpoints are in.
+	subnets async.Getter
+}
+
+// New creates a new service.
+func New(scope PrivateEndpointScope) *Service {
+	client := newClient(scope)
+	zoneGroupsClient := newZoneGroupsClient(scope)
+	return &Service{
+		Scope:      scope,
+		Reconciler: async.New(scope, client, client),
+		zoneGroups: async.New(scope, zoneGroupsClient, zoneGroupsClient),
+		subnets:    subnets.NewClient(scope),
+	}
+}
+
+// Reconcile gets/creates/updates the private endpoints and their private DNS zone groups, records their private IP
+// in the status, and deletes the private endpoints that were removed from the spec.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "privateendpoints.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	specs := s.Scope.PrivateEndpointSpecs()
+	previousIPs := s.Scope.PrivateEndpointIPs()
+	if len(specs) == 0 && len(previousIPs) == 0 {
+		return nil
+	}
+
+	// We go through the list of PrivateEndpointSpecs to reconcile each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
+	var resultingErr error
+	recordErr := func(err error) {
+		if !azure.IsOperationNotDoneError(err) || resultingErr == nil {
+			resultingErr = err
+		}
+	}
+
+	ips := make(map[string]string, len(specs))
+	for _, spec := range specs {
+		endpointSpec, ok := spec.(*PrivateEndpointSpec)
+		if !ok {
+			return errors.Errorf("%T is not a PrivateEndpointSpec", spec)
+		}
+		if ip, ok := previousIPs[endpointSpec.Name]; ok {
+			ips[endpointSpec.Name] = ip
+		}
+
+		if err := s.checkSubnet(ctx, endpointSpec); err != nil {
+			recordErr(err)
+			continue
+		}
+
+		result, err := s.CreateResource(ctx, endpointSpec, serviceName)
+		if err != nil {
+			recordErr(err)
+			continue
+		}
+		endpoint, ok := result.(network.PrivateEndpoint)
+		if !ok {
+			// Return out of loop since this would be an unexpected fatal error
+			resultingErr = errors.Errorf("created resource %T is not a network.PrivateEndpoint", result)
+			break
+		}
+		if ip := privateIP(endpoint); ip != "" {
+			ips[endpointSpec.Name] = ip
+		}
+
+		if endpointSpec.PrivateDNSZoneID != "" {
+			zoneGroupSpec := &PrivateDNSZoneGroupSpec{
+				PrivateEndpointName: endpointSpec.Name,
+				ResourceGroup:       endpointSpec.ResourceGroup,
+				PrivateDNSZoneID:    endpointSpec.PrivateDNSZoneID,
+			}
+			if _, err := s.zoneGroups.CreateResource(ctx, zoneGroupSpec, zoneGroupServiceName); err != nil {
+				recordErr(err)
+			}
+		}
+	}
+
+	// Private endpoints that are no longer in the spec are deleted, along with their DNS records.
+	for name, ip := range previousIPs {
+		if containsSpec(specs, name) {
+			continue
+		}
+		log.V(2).Info("deleting private endpoint removed from the spec", "private endpoint", name)
+		if err := s.DeleteResource(ctx, &PrivateEndpointSpec{Name: name, ResourceGroup: s.Scope.ResourceGroup()}, serviceName); err != nil {
+			recordErr(err)
+			ips[name] = ip
+		}
+	}
+
+	s.Scope.SetPrivateEndpointIPs(ips)
+	s.Scope.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, serviceName, resultingErr)
+	return resultingErr
+}
+
+// Delete deletes the private endpoints, along with their private DNS zone groups and DNS records.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privateendpoints.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	specs := s.Scope.PrivateEndpointSpecs()
+	if len(specs) == 0 && len(s.Scope.PrivateEndpointIPs()) == 0 {
+		return nil
+	}
+
+	var resultingErr error
+
+	// We go through the list of PrivateEndpointSpecs to delete each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
+	for _, spec := range specs {
+		if err := s.DeleteResource(ctx, spec, serviceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resultingErr == nil {
+				resultingErr = err
+			}
+		}
+	}
+	if resultingErr == nil {
+		s.Scope.SetPrivateEndpointIPs(nil)
+	}
+	s.Scope.UpdateDeleteStatus(infrav1.PrivateEndpointsReadyCondition, serviceName, resultingErr)
+	return resultingErr
+}
+
+// checkSubnet returns an error if the subnet of the private endpoint has its private endpoint network policies
+// enabled, as Azure then refuses to create private endpoints in it.
+func (s *Service) checkSubnet(ctx context.Context, spec *PrivateEndpointSpec) error {
+	result, err := s.subnets.Get(ctx, &subnets.SubnetSpec{
+		Name:              spec.SubnetName,
+		VNetName:          spec.VNetName,
+		VNetResourceGroup: spec.VNetResourceGroup,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get subnet %s of private endpoint %s", spec.SubnetName, spec.Name)
+	}
+	subnet, ok := result.(network.Subnet)
+	if !ok {
+		return errors.Errorf("%T is not a network.Subnet", result)
+	}
+	if subnet.SubnetPropertiesFormat != nil && subnet.PrivateEndpointNetworkPolicies == network.VirtualNetworkPrivateEndpointNetworkPoliciesEnabled {
+		return errors.Errorf("subnet %s of private endpoint %s has its private endpoint network policies enabled, "+
+			"they must be disabled for the subnet to host private endpoints", spec.SubnetName, spec.Name)
+	}
+	return nil
+}
+
+// privateIP returns the private IP of a private endpoint, as reported in its DNS configurations.
+func privateIP(endpoint network.PrivateEndpoint) string {
+	if endpoint.PrivateEndpointProperties == nil || endpoint.CustomDNSConfigs == nil {
+		return ""
+	}
+	for _, config := range *endpoint.CustomDNSConfigs {
+		if ips := to.StringSlice(config.IPAddresses); len(ips) > 0 {
+			return ips[0]
+		}
+	}
+	return ""
+}
+
+// containsSpec returns true if one of the private endpoint specs has the given name.
+func containsSpec(specs []azure.ResourceSpecGetter, name string) bool {
+	for _, spec := range specs {
+		if spec.ResourceName() == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privateendpoints
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints/mock_privateendpoints"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	storageEndpointSpec = PrivateEndpointSpec{
+		Name:              "my-storage-pe",
+		ResourceGroup:     "my-rg",
+		SubscriptionID:    "my-sub",
+		Location:          "westus",
+		ClusterName:       "my-cluster",
+		VNetName:          "my-vnet",
+		VNetResourceGroup: "my-rg",
+		SubnetName:        "node-subnet",
+		ResourceID:        "/subscriptions/my-sub/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/mystorage",
+		GroupID:           "blob",
+		PrivateDNSZoneID:  "/subscriptions/my-sub/resourceGroups/my-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net",
+	}
+	storageZoneGroupSpec = PrivateDNSZoneGroupSpec{
+		PrivateEndpointName: "my-storage-pe",
+		ResourceGroup:       "my-rg",
+		PrivateDNSZoneID:    "/subscriptions/my-sub/resourceGroups/my-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net",
+	}
+	nodeSubnetSpec = subnets.SubnetSpec{
+		Name:              "node-subnet",
+		VNetName:          "my-vnet",
+		VNetResourceGroup: "my-rg",
+	}
+	nodeSubnet = network.Subnet{
+		SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+			PrivateEndpointNetworkPolicies: network.VirtualNetworkPrivateEndpointNetworkPoliciesDisabled,
+		},
+	}
+	storageEndpoint = network.PrivateEndpoint{
+		PrivateEndpointProperties: &network.PrivateEndpointProperties{
+			CustomDNSConfigs: &[]network.CustomDNSConfigPropertiesFormat{
+				{
+					Fqdn:        to.StringPtr("mystorage.blob.core.windows.net"),
+					IPAddresses: &[]string{"10.1.0.4"},
+				},
+			},
+		},
+	}
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+)
+
+func TestReconcilePrivateEndpoints(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, z *mock_async.MockReconcilerMockRecorder, sn *mock_async.MockGetterMockRecorder)
+	}{
+		{
+			name:          "no private endpoints",
+			expectedError: "",
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, z *mock_async.MockReconcilerMockRecorder, sn *mock_async.MockGetterMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{})
+				s.PrivateEndpointIPs()
+			},
+		},
+		{
+			name:          "private endpoint and private DNS zone group created successfully",
+			expectedError: "",
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, z *mock_async.MockReconcilerMockRecorder, sn *mock_async.MockGetterMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&storageEndpointSpec})
+				s.PrivateEndpointIPs()
+				sn.Get(gomockinternal.AContext(), &nodeSubnetSpec).Return(nodeSubnet, nil)
+				r.CreateResource(gomockinternal.AContext(), &storageEndpointSpec, serviceName).Return(storageEndpoint, nil)
+				z.CreateResource(gomockinternal.AContext(), &storageZoneGroupSpec, zoneGroupServiceName).Return(network.PrivateDNSZoneGroup{}, nil)
+				s.SetPrivateEndpointIPs(map[string]string{"my-storage-pe": "10.1.0.4"})
+				s.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "subnet has its private endpoint network policies enabled",
+			expectedError: "subnet node-subnet of private endpoint my-storage-pe has its private endpoint network policies enabled, they must be disabled for the subnet to host private endpoints",
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, z *mock_async.MockReconcilerMockRecorder, sn *mock_async.MockGetterMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&storageEndpointSpec})
+				s.PrivateEndpointIPs()
+				sn.Get(gomockinternal.AContext(), &nodeSubnetSpec).Return(network.Subnet{
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+						PrivateEndpointNetworkPolicies: network.VirtualNetworkPrivateEndpointNetworkPoliciesEnabled,
+					},
+				}, nil)
+				s.SetPrivateEndpointIPs(map[string]string{})
+				s.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, serviceName, gomock.Any())
+			},
+		},
+		{
+			name:          "fail to create a private endpoint",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, z *mock_async.MockReconcilerMockRecorder, sn *mock_async.MockGetterMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&storageEndpointSpec})
+				s.PrivateEndpointIPs().Return(map[string]string{"my-storage-pe": "10.1.0.4"})
+				sn.Get(gomockinternal.AContext(), &nodeSubnetSpec).Return(nodeSubnet, nil)
+				r.CreateResource(gomockinternal.AContext(), &storageEndpointSpec, serviceName).Return(nil, internalError)
+				s.SetPrivateEndpointIPs(map[string]string{"my-storage-pe": "10.1.0.4"})
+				s.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, serviceName, internalError)
+			},
+		},
+		{
+			name:          "private endpoint removed from the spec is deleted",
+			expectedError: "",
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, z *mock_async.MockReconcilerMockRecorder, sn *mock_async.MockGetterMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{})
+				s.PrivateEndpointIPs().Return(map[string]string{"my-storage-pe": "10.1.0.4"})
+				s.ResourceGroup().Return("my-rg")
+				r.DeleteResource(gomockinternal.AContext(), &PrivateEndpointSpec{Name: "my-storage-pe", ResourceGroup: "my-rg"}, serviceName).Return(nil)
+				s.SetPrivateEndpointIPs(map[string]string{})
+				s.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "result is not a private endpoint",
+			expectedError: "created resource string is not a network.PrivateEndpoint",
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, z *mock_async.MockReconcilerMockRecorder, sn *mock_async.MockGetterMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&storageEndpointSpec})
+				s.PrivateEndpointIPs()
+				sn.Get(gomockinternal.AContext(), &nodeSubnetSpec).Return(nodeSubnet, nil)
+				r.CreateResource(gomockinternal.AContext(), &storageEndpointSpec, serviceName).Return("not a private endpoint", nil)
+				s.SetPrivateEndpointIPs(map[string]string{})
+				s.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, serviceName, gomockinternal.ErrStrEq("created resource string is not a network.PrivateEndpoint"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_privateendpoints.NewMockPrivateEndpointScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			zoneGroupsMock := mock_async.NewMockReconciler(mockCtrl)
+			subnetsMock := mock_async.NewMockGetter(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), zoneGroupsMock.EXPECT(), subnetsMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+				zoneGroups: zoneGroupsMock,
+				subnets:    subnetsMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeletePrivateEndpoints(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "no private endpoints",
+			expectedError: "",
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{})
+				s.PrivateEndpointIPs()
+			},
+		},
+		{
+			name:          "private endpoint deleted successfully",
+			expectedError: "",
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&storageEndpointSpec})
+				r.DeleteResource(gomockinternal.AContext(), &storageEndpointSpec, serviceName).Return(nil)
+				s.SetPrivateEndpointIPs(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateEndpointsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "private endpoint deletion fails",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&storageEndpointSpec})
+				r.DeleteResource(gomockinternal.AContext(), &storageEndpointSpec, serviceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.PrivateEndpointsReadyCondition, serviceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_privateendpoints.NewMockPrivateEndpointScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privateendpoints
+
+import (
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// PrivateEndpointSpec defines the specification for a private endpoint.
+type PrivateEndpointSpec struct {
+	Name              string
+	ResourceGroup     string
+	SubscriptionID    string
+	Location          string
+	ClusterName       string
+	VNetName          string
+	VNetResourceGroup string
+	SubnetName        string
+	// ResourceID is the resource ID of the resource the private endpoint connects to.
+	ResourceID string
+	// GroupID is the sub-resource of the resource the private endpoint connects to.
+	GroupID string
+	// PrivateDNSZoneID is the resource ID of the private DNS zone of the private endpoint, if any.
+	PrivateDNSZoneID string
+	AdditionalTags   infrav1.Tags
+}
+
+// ResourceName returns the name of the private endpoint.
+func (s *PrivateEndpointSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *PrivateEndpointSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for private endpoints.
+func (s *PrivateEndpointSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the private endpoint.
+func (s *PrivateEndpointSpec) Parameters(existing interface{}) (params interface{}, err error) {
+	if existing != nil {
+		existingEndpoint, ok := existing.(network.PrivateEndpoint)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.PrivateEndpoint", existing)
+		}
+
+		if s.isUpToDate(existingEndpoint) {
+			// Skip update for private endpoint as it exists with expected values
+			return nil, nil
+		}
+	}
+
+	return network.PrivateEndpoint{
+		Name:     to.StringPtr(s.Name),
+		Location: to.StringPtr(s.Location),
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        to.StringPtr(s.Name),
+			Additional:  s.AdditionalTags,
+		})),
+		PrivateEndpointProperties: &network.PrivateEndpointProperties{
+			Subnet: &network.Subnet{
+				ID: to.StringPtr(azure.SubnetID(s.SubscriptionID, s.VNetResourceGroup, s.VNetName, s.SubnetName)),
+			},
+			PrivateLinkServiceConnections: &[]network.PrivateLinkServiceConnection{
+				{
+					Name: to.StringPtr(s.Name),
+					PrivateLinkServiceConnectionProperties: &network.PrivateLinkServiceConnectionProperties{
+						PrivateLinkServiceID: to.StringPtr(s.ResourceID),
+						GroupIds:             &[]string{s.GroupID},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// isUpToDate returns true if the existing private endpoint connects to the expected resource and group ID.
+func (s *PrivateEndpointSpec) isUpToDate(existing network.PrivateEndpoint) bool {
+	if existing.PrivateEndpointProperties == nil || existing.PrivateLinkServiceConnections == nil {
+		return false
+	}
+	for _, connection := range *existing.PrivateLinkServiceConnections {
+		if connection.PrivateLinkServiceConnectionProperties == nil || !strings.EqualFold(to.String(connection.PrivateLinkServiceID), s.ResourceID) {
+			continue
+		}
+		for _, groupID := range to.StringSlice(connection.GroupIds) {
+			if strings.EqualFold(groupID, s.GroupID) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PrivateDNSZoneGroupSpec defines the specification for the private DNS zone group of a private endpoint. It has the
+// name of its private endpoint, and Azure manages the DNS record of the private endpoint in the zone.
+type PrivateDNSZoneGroupSpec struct {
+	PrivateEndpointName string
+	ResourceGroup       string
+	PrivateDNSZoneID    string
+}
+
+// ResourceName returns the name of the private DNS zone group.
+func (s *PrivateDNSZoneGroupSpec) ResourceName() string {
+	return s.PrivateEndpointName
+}
+
+// ResourceGroupName returns the name of the resource group of the private endpoint.
+func (s *PrivateDNSZoneGroupSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the name of the private endpoint that owns the private DNS zone group.
+func (s *PrivateDNSZoneGroupSpec) OwnerResourceName() string {
+	return s.PrivateEndpointName
+}
+
+// Parameters returns the parameters for the private DNS zone group.
+func (s *PrivateDNSZoneGroupSpec) Parameters(existing interface{}) (params interface{}, err error) {
+	if existing != nil {
+		existingGroup, ok := existing.(network.PrivateDNSZoneGroup)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.PrivateDNSZoneGroup", existing)
+		}
+
+		if existingGroup.PrivateDNSZoneGroupPropertiesFormat != nil && existingGroup.PrivateDNSZoneConfigs != nil {
+			for _, config := range *existingGroup.PrivateDNSZoneConfigs {
+				if config.PrivateDNSZonePropertiesFormat != nil && strings.EqualFold(to.String(config.PrivateDNSZoneID), s.PrivateDNSZoneID) {
+					// Skip update for private DNS zone group as it exists with expected values
+					return nil, nil
+				}
+			}
+		}
+	}
+
+	return network.PrivateDNSZoneGroup{
+		Name: to.StringPtr(s.PrivateEndpointName),
+		PrivateDNSZoneGroupPropertiesFormat: &network.PrivateDNSZoneGroupPropertiesFormat{
+			PrivateDNSZoneConfigs: &[]network.PrivateDNSZoneConfig{
+				{
+					Name: to.StringPtr(zoneConfigName(s.PrivateDNSZoneID)),
+					PrivateDNSZonePropertiesFormat: &network.PrivateDNSZonePropertiesFormat{
+						PrivateDNSZoneID: to.StringPtr(s.PrivateDNSZoneID),
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// zoneConfigName returns a name for the configuration of a private DNS zone, made of the name of the zone, e.g.
+// privatelink-blob-core-windows-net for privatelink.blob.core.windows.net.
+func zoneConfigName(zoneID string) string {
+	name := zoneID[strings.LastIndex(zoneID, "/")+1:]
+	return strings.ReplaceAll(name, ".", "-")
+}
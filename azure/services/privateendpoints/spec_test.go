@@ -0,0 +1,140 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privateendpoints
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+)
+
+func TestParameters(t *testing.T) {
+	connections := func(resourceID string, groupID string) *[]network.PrivateLinkServiceConnection {
+		return &[]network.PrivateLinkServiceConnection{
+			{
+				Name: to.StringPtr("my-storage-pe"),
+				PrivateLinkServiceConnectionProperties: &network.PrivateLinkServiceConnectionProperties{
+					PrivateLinkServiceID: to.StringPtr(resourceID),
+					GroupIds:             &[]string{groupID},
+				},
+			},
+		}
+	}
+
+	testcases := []struct {
+		name          string
+		spec          *PrivateEndpointSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "private endpoint does not exist",
+			spec:     &storageEndpointSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.PrivateEndpoint{
+					Name:     to.StringPtr("my-storage-pe"),
+					Location: to.StringPtr("westus"),
+					Tags: map[string]*string{
+						"Name": to.StringPtr("my-storage-pe"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+					},
+					PrivateEndpointProperties: &network.PrivateEndpointProperties{
+						Subnet: &network.Subnet{
+							ID: to.StringPtr("/subscriptions/my-sub/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/node-subnet"),
+						},
+						PrivateLinkServiceConnections: connections(storageEndpointSpec.ResourceID, "blob"),
+					},
+				}))
+			},
+		},
+		{
+			name: "private endpoint exists with the expected resource and group ID",
+			spec: &storageEndpointSpec,
+			existing: network.PrivateEndpoint{
+				PrivateEndpointProperties: &network.PrivateEndpointProperties{
+					PrivateLinkServiceConnections: connections(storageEndpointSpec.ResourceID, "blob"),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "private endpoint exists with another group ID",
+			spec: &storageEndpointSpec,
+			existing: network.PrivateEndpoint{
+				PrivateEndpointProperties: &network.PrivateEndpointProperties{
+					PrivateLinkServiceConnections: connections(storageEndpointSpec.ResourceID, "file"),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.PrivateEndpoint{}))
+				g.Expect(result.(network.PrivateEndpoint).PrivateLinkServiceConnections).To(Equal(connections(storageEndpointSpec.ResourceID, "blob")))
+			},
+		},
+		{
+			name:          "existing is not a private endpoint",
+			spec:          &storageEndpointSpec,
+			existing:      struct{}{},
+			expectedError: "struct {} is not a network.PrivateEndpoint",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				tc.expect(g, result)
+			}
+		})
+	}
+}
+
+func TestPrivateDNSZoneGroupParameters(t *testing.T) {
+	g := NewWithT(t)
+
+	result, err := storageZoneGroupSpec.Parameters(nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(network.PrivateDNSZoneGroup{
+		Name: to.StringPtr("my-storage-pe"),
+		PrivateDNSZoneGroupPropertiesFormat: &network.PrivateDNSZoneGroupPropertiesFormat{
+			PrivateDNSZoneConfigs: &[]network.PrivateDNSZoneConfig{
+				{
+					Name: to.StringPtr("privatelink-blob-core-windows-net"),
+					PrivateDNSZonePropertiesFormat: &network.PrivateDNSZonePropertiesFormat{
+						PrivateDNSZoneID: to.StringPtr(storageZoneGroupSpec.PrivateDNSZoneID),
+					},
+				},
+			},
+		},
+	}))
+
+	result, err = storageZoneGroupSpec.Parameters(result)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(BeNil())
+}
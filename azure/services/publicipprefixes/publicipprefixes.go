@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicipprefixes
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const serviceName = "publicipprefixes"
+
+// PublicIPPrefixScope defines the scope interface for a public IP prefixes service.
+type PublicIPPrefixScope interface {
+	azure.ClusterDescriber
+	azure.AsyncStatusUpdater
+	PublicIPPrefixSpecs() []azure.ResourceSpecGetter
+	SetNatGatewayIPPrefix(name, ipPrefix string)
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope PublicIPPrefixScope
+	async.Getter
+	async.Reconciler
+}
+
+// New creates a new public IP prefixes service.
+func New(scope PublicIPPrefixScope) *Service {
+	client := newClient(scope)
+	return &Service{
+		Scope:      scope,
+		Getter:     client,
+		Reconciler: async.New(scope, client, client),
+	}
+}
+
+// Reconcile gets/creates the public IP prefixes of the cluster and stores their allocated address ranges in the
+// cluster status.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "publicipprefixes.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	specs := s.Scope.PublicIPPrefixSpecs()
+	if len(specs) == 0 {
+		log.V(4).Info("skipping public IP prefixes reconcile, no public IP prefixes are configured")
+		return nil
+	}
+
+	// We go through the list of PublicIPPrefixSpecs to reconcile each one, independently of the resultingErr of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (ie. error creating) -> operationNotDoneError (ie. creating in progress) -> no error (ie. created)
+	var resultingErr error
+	for _, prefixSpec := range specs {
+		result, err := s.CreateResource(ctx, prefixSpec, serviceName)
+		if err != nil {
+			if !azure.IsOperationNotDoneError(err) || resultingErr == nil {
+				resultingErr = err
+			}
+			continue
+		}
+
+		if prefix, ok := result.(network.PublicIPPrefix); ok && prefix.PublicIPPrefixPropertiesFormat != nil && prefix.IPPrefix != nil {
+			s.Scope.SetNatGatewayIPPrefix(prefixSpec.ResourceName(), *prefix.IPPrefix)
+		}
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.PublicIPPrefixesReadyCondition, serviceName, resultingErr)
+	return resultingErr
+}
+
+// Delete deletes the public IP prefixes managed by capz. Referenced prefixes are left untouched.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "publicipprefixes.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	specs := s.Scope.PublicIPPrefixSpecs()
+	if len(specs) == 0 {
+		log.V(4).Info("skipping public IP prefixes deletion, no public IP prefixes are configured")
+		return nil
+	}
+
+	var resultingErr error
+	for _, prefixSpec := range specs {
+		if err := s.deleteIfOwned(ctx, prefixSpec); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resultingErr == nil {
+				resultingErr = err
+			}
+		}
+	}
+
+	s.Scope.UpdateDeleteStatus(infrav1.PublicIPPrefixesReadyCondition, serviceName, resultingErr)
+	return resultingErr
+}
+
+// deleteIfOwned deletes the public IP prefix if it exists and is managed by capz.
+func (s *Service) deleteIfOwned(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "publicipprefixes.Service.deleteIfOwned")
+	defer done()
+
+	existing, err := s.Get(ctx, spec)
+	if azure.ResourceNotFound(err) {
+		// already deleted or doesn't exist.
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to get public IP prefix %s in resource group %s", spec.ResourceName(), spec.ResourceGroupName())
+	}
+
+	prefix, ok := existing.(network.PublicIPPrefix)
+	if !ok {
+		return errors.Errorf("%T is not a network.PublicIPPrefix", existing)
+	}
+
+	if !converters.MapToTags(prefix.Tags).HasOwned(s.Scope.ClusterName()) {
+		log.V(2).Info("skipping deletion of unmanaged public IP prefix", "public IP prefix", spec.ResourceName())
+		return nil
+	}
+
+	return s.DeleteResource(ctx, spec, serviceName)
+}
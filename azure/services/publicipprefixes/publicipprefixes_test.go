@@ -0,0 +1,221 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicipprefixes
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicipprefixes/mock_publicipprefixes"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakePrefixSpec = PublicIPPrefixSpec{
+		Name:          "my-natgw-prefix",
+		ResourceGroup: "my-rg",
+		Location:      "westus",
+		ClusterName:   "my-cluster",
+		PrefixLength:  31,
+	}
+	fakeExistingPrefixSpec = PublicIPPrefixSpec{
+		Name:          "shared-prefix",
+		ResourceGroup: "my-rg",
+		Location:      "westus",
+		ClusterName:   "my-cluster",
+		PrefixLength:  31,
+	}
+
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+	notFoundError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not Found")
+
+	ownedPrefix = network.PublicIPPrefix{
+		Name: to.StringPtr("my-natgw-prefix"),
+		Tags: map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned")},
+		PublicIPPrefixPropertiesFormat: &network.PublicIPPrefixPropertiesFormat{
+			IPPrefix: to.StringPtr("20.1.2.4/31"),
+		},
+	}
+	unmanagedPrefix = network.PublicIPPrefix{
+		Name: to.StringPtr("shared-prefix"),
+		PublicIPPrefixPropertiesFormat: &network.PublicIPPrefixPropertiesFormat{
+			IPPrefix: to.StringPtr("20.9.8.0/28"),
+		},
+	}
+)
+
+func TestReconcilePublicIPPrefixes(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no public IP prefixes are configured",
+			expectedError: "",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "create and reference public IP prefixes",
+			expectedError: "",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{&fakePrefixSpec, &fakeExistingPrefixSpec})
+				r.CreateResource(gomockinternal.AContext(), &fakePrefixSpec, serviceName).Return(ownedPrefix, nil)
+				s.SetNatGatewayIPPrefix("my-natgw-prefix", "20.1.2.4/31")
+				r.CreateResource(gomockinternal.AContext(), &fakeExistingPrefixSpec, serviceName).Return(unmanagedPrefix, nil)
+				s.SetNatGatewayIPPrefix("shared-prefix", "20.9.8.0/28")
+				s.UpdatePutStatus(infrav1.PublicIPPrefixesReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "fail to create a public IP prefix",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{&fakePrefixSpec, &fakeExistingPrefixSpec})
+				r.CreateResource(gomockinternal.AContext(), &fakePrefixSpec, serviceName).Return(nil, internalError)
+				r.CreateResource(gomockinternal.AContext(), &fakeExistingPrefixSpec, serviceName).Return(unmanagedPrefix, nil)
+				s.SetNatGatewayIPPrefix("shared-prefix", "20.9.8.0/28")
+				s.UpdatePutStatus(infrav1.PublicIPPrefixesReadyCondition, serviceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_publicipprefixes.NewMockPublicIPPrefixScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeletePublicIPPrefixes(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no public IP prefixes are configured",
+			expectedError: "",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "delete owned and skip referenced public IP prefixes",
+			expectedError: "",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{&fakePrefixSpec, &fakeExistingPrefixSpec})
+				g.Get(gomockinternal.AContext(), &fakePrefixSpec).Return(ownedPrefix, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &fakePrefixSpec, serviceName).Return(nil)
+				g.Get(gomockinternal.AContext(), &fakeExistingPrefixSpec).Return(unmanagedPrefix, nil)
+				s.ClusterName().Return("my-cluster")
+				s.UpdateDeleteStatus(infrav1.PublicIPPrefixesReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "public IP prefix already deleted",
+			expectedError: "",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{&fakePrefixSpec})
+				g.Get(gomockinternal.AContext(), &fakePrefixSpec).Return(nil, notFoundError)
+				s.UpdateDeleteStatus(infrav1.PublicIPPrefixesReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "fail to get public IP prefix",
+			expectedError: "failed to get public IP prefix my-natgw-prefix in resource group my-rg: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{&fakePrefixSpec})
+				g.Get(gomockinternal.AContext(), &fakePrefixSpec).Return(nil, internalError)
+				s.UpdateDeleteStatus(infrav1.PublicIPPrefixesReadyCondition, serviceName, gomock.Any())
+			},
+		},
+		{
+			name:          "fail to delete owned public IP prefix",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{&fakePrefixSpec})
+				g.Get(gomockinternal.AContext(), &fakePrefixSpec).Return(ownedPrefix, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &fakePrefixSpec, serviceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.PublicIPPrefixesReadyCondition, serviceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_publicipprefixes.NewMockPublicIPPrefixScope(mockCtrl)
+			getterMock := mock_async.NewMockGetter(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), getterMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Getter:     getterMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
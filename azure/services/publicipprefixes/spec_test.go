@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicipprefixes
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *PublicIPPrefixSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "public IP prefix does not exist",
+			spec: &PublicIPPrefixSpec{
+				Name:           "my-natgw-prefix",
+				ResourceGroup:  "my-rg",
+				Location:       "westus",
+				ClusterName:    "my-cluster",
+				PrefixLength:   30,
+				AdditionalTags: infrav1.Tags{"foo": "bar"},
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.PublicIPPrefix{
+					Name:     to.StringPtr("my-natgw-prefix"),
+					Location: to.StringPtr("westus"),
+					Sku: &network.PublicIPPrefixSku{
+						Name: network.PublicIPPrefixSkuNameStandard,
+					},
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"Name": to.StringPtr("my-natgw-prefix"),
+						"foo":  to.StringPtr("bar"),
+					},
+					PublicIPPrefixPropertiesFormat: &network.PublicIPPrefixPropertiesFormat{
+						PublicIPAddressVersion: network.IPVersionIPv4,
+						PrefixLength:           to.Int32Ptr(30),
+					},
+				}))
+			},
+		},
+		{
+			name:     "public IP prefix already exists",
+			spec:     &fakePrefixSpec,
+			existing: ownedPrefix,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:          "existing is not a public IP prefix",
+			spec:          &fakePrefixSpec,
+			existing:      struct{}{},
+			expectedError: "struct {} is not a network.PublicIPPrefix",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				tc.expect(g, result)
+			}
+		})
+	}
+}
@@ -18,9 +18,11 @@ package publicips
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -28,13 +30,18 @@ import (
 // Client wraps go-sdk.
 type Client interface {
 	Get(context.Context, string, string) (network.PublicIPAddress, error)
+	List(context.Context, string) ([]network.PublicIPAddress, error)
 	CreateOrUpdate(context.Context, string, string, network.PublicIPAddress) error
 	Delete(context.Context, string, string) error
+	GetPrefix(context.Context, string, string, string) (network.PublicIPPrefix, error)
 }
 
-// AzureClient contains the Azure go-sdk Client.
+// AzureClient contains the Azure go-sdk Client. A public IP prefix may be in another subscription than the cluster, so
+// its go-sdk client is created for the subscription of each request.
 type AzureClient struct {
-	publicips network.PublicIPAddressesClient
+	publicips  network.PublicIPAddressesClient
+	baseURI    string
+	authorizer autorest.Authorizer
 }
 
 var _ Client = &AzureClient{}
@@ -42,7 +49,11 @@ var _ Client = &AzureClient{}
 // NewClient creates a new public IP client from subscription ID.
 func NewClient(auth azure.Authorizer) *AzureClient {
 	c := newPublicIPAddressesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
-	return &AzureClient{c}
+	return &AzureClient{
+		publicips:  c,
+		baseURI:    auth.BaseURI(),
+		authorizer: auth.Authorizer(),
+	}
 }
 
 // newPublicIPAddressesClient creates a new public IP client from subscription ID.
@@ -52,6 +63,13 @@ func newPublicIPAddressesClient(subscriptionID string, baseURI string, authorize
 	return publicIPsClient
 }
 
+// newPublicIPPrefixesClient creates a new public IP prefix client from subscription ID.
+func newPublicIPPrefixesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) network.PublicIPPrefixesClient {
+	prefixesClient := network.NewPublicIPPrefixesClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&prefixesClient.Client, authorizer)
+	return prefixesClient
+}
+
 // Get gets the specified public IP address in a specified resource group.
 func (ac *AzureClient) Get(ctx context.Context, resourceGroupName, ipName string) (network.PublicIPAddress, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "publicips.AzureClient.Get")
@@ -60,6 +78,26 @@ func (ac *AzureClient) Get(ctx context.Context, resourceGroupName, ipName string
 	return ac.publicips.Get(ctx, resourceGroupName, ipName, "")
 }
 
+// List returns all public IP addresses in a resource group.
+func (ac *AzureClient) List(ctx context.Context, resourceGroupName string) ([]network.PublicIPAddress, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "publicips.AzureClient.List")
+	defer done()
+
+	itr, err := ac.publicips.ListComplete(ctx, resourceGroupName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list public IPs in the resource group")
+	}
+
+	var ips []network.PublicIPAddress
+	for ; itr.NotDone(); err = itr.NextWithContext(ctx) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate public IPs [%w]", err)
+		}
+		ips = append(ips, itr.Value())
+	}
+	return ips, nil
+}
+
 // CreateOrUpdate creates or updates a static or dynamic public IP address.
 func (ac *AzureClient) CreateOrUpdate(ctx context.Context, resourceGroupName string, ipName string, ip network.PublicIPAddress) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "publicips.AzureClient.CreateOrUpdate")
@@ -93,3 +131,12 @@ func (ac *AzureClient) Delete(ctx context.Context, resourceGroupName, ipName str
 	_, err = future.Result(ac.publicips)
 	return err
 }
+
+// GetPrefix gets the specified public IP prefix, along with the public IP addresses allocated from it.
+func (ac *AzureClient) GetPrefix(ctx context.Context, subscriptionID, resourceGroupName, prefixName string) (network.PublicIPPrefix, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "publicips.AzureClient.GetPrefix")
+	defer done()
+
+	prefixesClient := newPublicIPPrefixesClient(subscriptionID, ac.baseURI, ac.authorizer)
+	return prefixesClient.Get(ctx, resourceGroupName, prefixName, "")
+}
@@ -93,3 +93,33 @@ func (mr *MockClientMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockClient)(nil).Get), arg0, arg1, arg2)
 }
+
+// GetPrefix mocks base method.
+func (m *MockClient) GetPrefix(arg0 context.Context, arg1, arg2, arg3 string) (network.PublicIPPrefix, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrefix", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(network.PublicIPPrefix)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrefix indicates an expected call of GetPrefix.
+func (mr *MockClientMockRecorder) GetPrefix(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrefix", reflect.TypeOf((*MockClient)(nil).GetPrefix), arg0, arg1, arg2, arg3)
+}
+
+// List mocks base method.
+func (m *MockClient) List(arg0 context.Context, arg1 string) ([]network.PublicIPAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]network.PublicIPAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockClientMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockClient)(nil).List), arg0, arg1)
+}
@@ -178,6 +178,20 @@ func (mr *MockPublicIPScopeMockRecorder) ClusterName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterName", reflect.TypeOf((*MockPublicIPScope)(nil).ClusterName))
 }
 
+// ControlPlaneAvailabilitySetID mocks base method.
+func (m *MockPublicIPScope) ControlPlaneAvailabilitySetID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ControlPlaneAvailabilitySetID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ControlPlaneAvailabilitySetID indicates an expected call of ControlPlaneAvailabilitySetID.
+func (mr *MockPublicIPScopeMockRecorder) ControlPlaneAvailabilitySetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControlPlaneAvailabilitySetID", reflect.TypeOf((*MockPublicIPScope)(nil).ControlPlaneAvailabilitySetID))
+}
+
 // FailureDomains mocks base method.
 func (m *MockPublicIPScope) FailureDomains() []string {
 	m.ctrl.T.Helper()
@@ -248,6 +262,56 @@ func (mr *MockPublicIPScopeMockRecorder) ResourceGroup() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceGroup", reflect.TypeOf((*MockPublicIPScope)(nil).ResourceGroup))
 }
 
+// SetControlPlaneEgressIPs mocks base method.
+func (m *MockPublicIPScope) SetControlPlaneEgressIPs(ips []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetControlPlaneEgressIPs", ips)
+}
+
+// SetControlPlaneEgressIPs indicates an expected call of SetControlPlaneEgressIPs.
+func (mr *MockPublicIPScopeMockRecorder) SetControlPlaneEgressIPs(ips interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetControlPlaneEgressIPs", reflect.TypeOf((*MockPublicIPScope)(nil).SetControlPlaneEgressIPs), ips)
+}
+
+// SetPublicIPPrefixAllocations mocks base method.
+func (m *MockPublicIPScope) SetPublicIPPrefixAllocations(allocations map[string]string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPublicIPPrefixAllocations", allocations)
+}
+
+// SetPublicIPPrefixAllocations indicates an expected call of SetPublicIPPrefixAllocations.
+func (mr *MockPublicIPScopeMockRecorder) SetPublicIPPrefixAllocations(allocations interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPublicIPPrefixAllocations", reflect.TypeOf((*MockPublicIPScope)(nil).SetPublicIPPrefixAllocations), allocations)
+}
+
+// SetPublicIPZones mocks base method.
+func (m *MockPublicIPScope) SetPublicIPZones(name string, zones []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPublicIPZones", name, zones)
+}
+
+// SetPublicIPZones indicates an expected call of SetPublicIPZones.
+func (mr *MockPublicIPScopeMockRecorder) SetPublicIPZones(name, zones interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPublicIPZones", reflect.TypeOf((*MockPublicIPScope)(nil).SetPublicIPZones), name, zones)
+}
+
+// StalePublicIPNames mocks base method.
+func (m *MockPublicIPScope) StalePublicIPNames() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StalePublicIPNames")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// StalePublicIPNames indicates an expected call of StalePublicIPNames.
+func (mr *MockPublicIPScopeMockRecorder) StalePublicIPNames() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StalePublicIPNames", reflect.TypeOf((*MockPublicIPScope)(nil).StalePublicIPNames))
+}
+
 // SubscriptionID mocks base method.
 func (m *MockPublicIPScope) SubscriptionID() string {
 	m.ctrl.T.Helper()
@@ -18,14 +18,18 @@ package publicips
 
 import (
 	"context"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -33,19 +37,39 @@ import (
 type PublicIPScope interface {
 	azure.ClusterDescriber
 	PublicIPSpecs() []azure.PublicIPSpec
+	StalePublicIPNames() []string
+	SetPublicIPZones(name string, zones []string)
+	SetControlPlaneEgressIPs(ips []string)
+	SetPublicIPPrefixAllocations(allocations map[string]string)
 }
 
+const (
+	serviceName = "publicips"
+	// defaultAddressPollAttempts is the number of times the API server public IP is fetched before giving up on
+	// waiting for its address to be assigned in a given reconcile loop.
+	defaultAddressPollAttempts = 5
+	// defaultAddressPollInterval is the wait between two fetches of the API server public IP.
+	defaultAddressPollInterval = 2 * time.Second
+)
+
 // Service provides operations on Azure resources.
 type Service struct {
 	Scope PublicIPScope
 	Client
+
+	addressPollAttempts int
+	addressPollInterval time.Duration
+	notFoundRetry       azure.NotFoundRetry
 }
 
 // New creates a new service.
 func New(scope PublicIPScope) *Service {
 	return &Service{
-		Scope:  scope,
-		Client: NewClient(scope),
+		Scope:               scope,
+		Client:              NewClient(scope),
+		addressPollAttempts: defaultAddressPollAttempts,
+		addressPollInterval: defaultAddressPollInterval,
+		notFoundRetry:       azure.GetNotFoundRetry(),
 	}
 }
 
@@ -54,6 +78,8 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "publicips.Service.Reconcile")
 	defer done()
 
+	var apiServerIPName string
+	var egressIPNames, prefixIPNames []string
 	for _, ip := range s.Scope.PublicIPSpecs() {
 		log.V(2).Info("creating public IP", "public ip", ip.Name)
 
@@ -72,6 +98,41 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			}
 		}
 
+		location := s.Scope.Location()
+		if ip.Location != "" {
+			location = ip.Location
+		}
+
+		sku := &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameStandard}
+		var zones []string
+		if ip.IsGlobal {
+			sku.Tier = network.PublicIPAddressSkuTierGlobal
+		} else {
+			var err error
+			if zones, err = s.zones(ip); err != nil {
+				return err
+			}
+		}
+
+		var prefix *network.SubResource
+		if ip.IPPrefixID != "" {
+			if err := s.validatePrefix(ctx, ip); err != nil {
+				return err
+			}
+			prefix = &network.SubResource{ID: to.StringPtr(ip.IPPrefixID)}
+			prefixIPNames = append(prefixIPNames, ip.Name)
+		}
+
+		if err := s.checkEnvironment(ctx, ip.Name, "adopt"); err != nil {
+			return err
+		}
+
+		// tag the public IP with its role so it can be found by role, e.g. the API server endpoint
+		var role *string
+		if ip.Role != "" {
+			role = to.StringPtr(ip.Role)
+		}
+
 		err := s.Client.CreateOrUpdate(
 			ctx,
 			s.Scope.ResourceGroup(),
@@ -81,61 +142,336 @@ func (s *Service) Reconcile(ctx context.Context) error {
 					ClusterName: s.Scope.ClusterName(),
 					Lifecycle:   infrav1.ResourceLifecycleOwned,
 					Name:        to.StringPtr(ip.Name),
+					Role:        role,
 					Additional:  s.Scope.AdditionalTags(),
 				})),
-				Sku:      &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameStandard},
+				Sku:      sku,
 				Name:     to.StringPtr(ip.Name),
-				Location: to.StringPtr(s.Scope.Location()),
+				Location: to.StringPtr(location),
 				PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
 					PublicIPAddressVersion:   addressVersion,
 					PublicIPAllocationMethod: network.IPAllocationMethodStatic,
 					DNSSettings:              dnsSettings,
+					IPTags:                   ipTags(ip),
+					PublicIPPrefix:           prefix,
 				},
-				Zones: to.StringSlicePtr(s.Scope.FailureDomains()),
+				Zones: to.StringSlicePtr(zones),
 			},
 		)
 
 		if err != nil {
-			return errors.Wrap(err, "cannot create public IP")
+			if !s.isBasicSKU(ctx, ip.Name) {
+				return errors.Wrap(err, "cannot create public IP")
+			}
+			if !ip.ForceRecreate {
+				return azure.WithTerminalError(errors.Errorf("public IP %s has the Basic SKU, which Azure can't change to the Standard SKU in place: set forceRecreate on its load balancer for it to be deleted and recreated with the Standard SKU, which assigns it a new address, or migrate it manually", ip.Name))
+			}
+			// the load balancer using the public IP is deleted first, the public IP is then recreated
+			log.V(2).Info("skipping public IP of the Basic SKU until its load balancer is recreated", "public ip", ip.Name)
+			continue
 		}
+		s.Scope.SetPublicIPZones(ip.Name, zones)
 
 		log.V(2).Info("successfully created public IP", "public ip", ip.Name)
+		switch ip.Role {
+		case infrav1.APIServerRole:
+			apiServerIPName = ip.Name
+		case infrav1.ControlPlaneEgressRole:
+			egressIPNames = append(egressIPNames, ip.Name)
+		}
+	}
+
+	if err := s.reconcileControlPlaneEgressIPs(ctx, egressIPNames); err != nil {
+		return err
+	}
+	if err := s.reconcilePublicIPPrefixAllocations(ctx, prefixIPNames); err != nil {
+		return err
+	}
+	if err := s.deleteStaleIPs(ctx); err != nil {
+		return err
+	}
+
+	// the control plane endpoint must not be reported before it can be reached
+	if apiServerIPName != "" {
+		return s.waitForIPAddress(ctx, apiServerIPName)
+	}
+
+	return nil
+}
+
+// deleteStaleIPs deletes the public IPs owned by the cluster that are no longer in the spec, e.g. the public IPs of a
+// NAT gateway whose public IP count was lowered, so they aren't left behind. A public IP that is still attached, e.g.
+// to the NAT gateway until the NAT gateway is updated, is deleted on a later reconcile.
+func (s *Service) deleteStaleIPs(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "publicips.Service.deleteStaleIPs")
+	defer done()
+
+	staleNames := s.Scope.StalePublicIPNames()
+	if len(staleNames) == 0 {
+		return nil
+	}
+	stale := make(map[string]bool, len(staleNames))
+	for _, name := range staleNames {
+		stale[name] = true
+	}
+
+	ips, err := s.Client.List(ctx, s.Scope.ResourceGroup())
+	if err != nil {
+		return errors.Wrapf(err, "failed to list public IPs in resource group %s", s.Scope.ResourceGroup())
+	}
+	for _, ip := range ips {
+		ipName := to.String(ip.Name)
+		if !stale[ipName] || !converters.MapToTags(ip.Tags).HasOwned(s.Scope.ClusterName()) {
+			continue
+		}
+		if ip.PublicIPAddressPropertiesFormat != nil && (ip.IPConfiguration != nil || ip.NatGateway != nil) {
+			log.V(4).Info("skipping deletion of stale public IP still in use", "public ip", ipName)
+			continue
+		}
+		if err := azure.CheckEnvironment(s.Scope, ip, "delete", ipName, s.Scope.ResourceGroup(), serviceName); err != nil {
+			return err
+		}
+		log.V(2).Info("deleting stale public IP", "public ip", ipName)
+		if err := s.Client.Delete(ctx, s.Scope.ResourceGroup(), ipName); err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrapf(err, "failed to delete stale public IP %s in resource group %s", ipName, s.Scope.ResourceGroup())
+		}
+	}
+	return nil
+}
+
+// ipTags returns the IP tags to create a public IP with: the IP tags of its spec and, for the Internet routing
+// preference, the RoutingPreference IP tag Azure represents it with.
+func ipTags(ip azure.PublicIPSpec) *[]network.IPTag {
+	var tags []network.IPTag
+	for _, tag := range ip.IPTags {
+		tags = append(tags, network.IPTag{
+			IPTagType: to.StringPtr(string(tag.Type)),
+			Tag:       to.StringPtr(tag.Tag),
+		})
+	}
+	if ip.RoutingPreference == infrav1.RoutingPreferenceInternet {
+		tags = append(tags, network.IPTag{
+			IPTagType: to.StringPtr(string(infrav1.IPTagTypeRoutingPreference)),
+			Tag:       to.StringPtr(string(infrav1.RoutingPreferenceInternet)),
+		})
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	return &tags
+}
+
+// zones returns the availability zones to create a public IP in: the zones of its spec, which must be failure domains
+// of the location, or all the failure domains of the location for a zone-redundant public IP.
+func (s *Service) zones(ip azure.PublicIPSpec) ([]string, error) {
+	failureDomains := s.Scope.FailureDomains()
+	if len(ip.Zones) == 0 {
+		sort.Strings(failureDomains)
+		return failureDomains, nil
+	}
+
+	available := make(map[string]struct{}, len(failureDomains))
+	for _, fd := range failureDomains {
+		available[fd] = struct{}{}
+	}
+	for _, zone := range ip.Zones {
+		if _, ok := available[zone]; !ok {
+			return nil, errors.Errorf("zone %s of public IP %s is not available in location %s", zone, ip.Name, s.Scope.Location())
+		}
+	}
+	return ip.Zones, nil
+}
+
+// reconcileControlPlaneEgressIPs reports the addresses of the public IPs of the control plane NAT gateway, so that
+// the egress of the control plane can be allowlisted.
+func (s *Service) reconcileControlPlaneEgressIPs(ctx context.Context, ipNames []string) error {
+	var addresses []string
+	for _, ipName := range ipNames {
+		ip, err := s.getCreatedIP(ctx, ipName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get public IP %s", ipName)
+		}
+		if ip.PublicIPAddressPropertiesFormat != nil && to.String(ip.IPAddress) != "" {
+			addresses = append(addresses, to.String(ip.IPAddress))
+		}
+	}
+	s.Scope.SetControlPlaneEgressIPs(addresses)
+	return nil
+}
+
+// validatePrefix validates that the public IP prefix of a public IP can allocate its address: the prefix must have the
+// Standard SKU and the tier of the public IP, and a free address unless the public IP is already allocated from it.
+func (s *Service) validatePrefix(ctx context.Context, ip azure.PublicIPSpec) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "publicips.Service.validatePrefix")
+	defer done()
+
+	resource, err := azureautorest.ParseResourceID(ip.IPPrefixID)
+	if err != nil {
+		return azure.WithTerminalError(errors.Wrapf(err, "invalid public IP prefix ID %s", ip.IPPrefixID))
+	}
+	prefix, err := s.Client.GetPrefix(ctx, resource.SubscriptionID, resource.ResourceGroup, resource.ResourceName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get public IP prefix %s", ip.IPPrefixID)
+	}
+
+	tier := network.PublicIPPrefixSkuTierRegional
+	if ip.IsGlobal {
+		tier = network.PublicIPPrefixSkuTierGlobal
+	}
+	if prefix.Sku == nil || prefix.Sku.Name != network.PublicIPPrefixSkuNameStandard {
+		return errors.Errorf("public IP prefix %s of public IP %s doesn't have the Standard SKU", ip.IPPrefixID, ip.Name)
+	}
+	prefixTier := prefix.Sku.Tier
+	if prefixTier == "" {
+		prefixTier = network.PublicIPPrefixSkuTierRegional
+	}
+	if prefixTier != tier {
+		return errors.Errorf("public IP prefix %s of public IP %s has the %s tier, not the %s tier", ip.IPPrefixID, ip.Name, prefixTier, tier)
 	}
 
+	if prefix.PublicIPPrefixPropertiesFormat == nil || prefix.PrefixLength == nil {
+		return errors.Errorf("public IP prefix %s of public IP %s has no length yet", ip.IPPrefixID, ip.Name)
+	}
+	var allocated int
+	if prefix.PublicIPAddresses != nil {
+		for _, address := range *prefix.PublicIPAddresses {
+			if parsed, err := azureautorest.ParseResourceID(to.String(address.ID)); err == nil &&
+				strings.EqualFold(parsed.ResourceGroup, s.Scope.ResourceGroup()) && strings.EqualFold(parsed.ResourceName, ip.Name) {
+				// the public IP is already allocated from the prefix
+				return nil
+			}
+		}
+		allocated = len(*prefix.PublicIPAddresses)
+	}
+	if allocated >= 1<<(32-*prefix.PrefixLength) {
+		return errors.Errorf("public IP prefix %s of public IP %s has no free address left", ip.IPPrefixID, ip.Name)
+	}
 	return nil
 }
 
+// reconcilePublicIPPrefixAllocations reports the addresses of the public IPs allocated from public IP prefixes.
+func (s *Service) reconcilePublicIPPrefixAllocations(ctx context.Context, ipNames []string) error {
+	var allocations map[string]string
+	for _, ipName := range ipNames {
+		ip, err := s.getCreatedIP(ctx, ipName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get public IP %s", ipName)
+		}
+		if ip.PublicIPAddressPropertiesFormat != nil && to.String(ip.IPAddress) != "" {
+			if allocations == nil {
+				allocations = make(map[string]string, len(ipNames))
+			}
+			allocations[ipName] = to.String(ip.IPAddress)
+		}
+	}
+	s.Scope.SetPublicIPPrefixAllocations(allocations)
+	return nil
+}
+
+// waitForIPAddress fetches the public IP until Azure has assigned it an address, for a bounded number of attempts.
+// It returns a transient error to requeue if the address is still not assigned after the last attempt.
+func (s *Service) waitForIPAddress(ctx context.Context, ipName string) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "publicips.Service.waitForIPAddress")
+	defer done()
+
+	for attempt := 1; ; attempt++ {
+		ip, err := s.getCreatedIP(ctx, ipName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get public IP %s", ipName)
+		}
+		if ip.PublicIPAddressPropertiesFormat != nil && to.String(ip.IPAddress) != "" {
+			return nil
+		}
+		if attempt >= s.addressPollAttempts {
+			return azure.WithTransientError(errors.Errorf("public IP %s has no address assigned yet", ipName), reconciler.DefaultReconcilerRequeue)
+		}
+
+		log.V(2).Info("waiting for public IP address to be assigned", "public ip", ipName, "attempt", attempt)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.addressPollInterval):
+		}
+	}
+}
+
+// isBasicSKU returns true if the public IP exists with the Basic SKU, which can't be updated to the Standard SKU: it
+// tells why the public IP failed to be updated.
+func (s *Service) isBasicSKU(ctx context.Context, ipName string) bool {
+	ip, err := s.Client.Get(ctx, s.Scope.ResourceGroup(), ipName)
+	return err == nil && ip.Sku != nil && ip.Sku.Name == network.PublicIPAddressSkuNameBasic
+}
+
+// getCreatedIP fetches a public IP that was just created, retrying while Azure doesn't return it yet.
+func (s *Service) getCreatedIP(ctx context.Context, ipName string) (ip network.PublicIPAddress, err error) {
+	err = s.notFoundRetry.Do(ctx, func(ctx context.Context) error {
+		ip, err = s.Client.Get(ctx, s.Scope.ResourceGroup(), ipName)
+		return err
+	})
+	return ip, err
+}
+
 // Delete deletes the public IP with the provided scope.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "publicips.Service.Delete")
 	defer done()
 
 	for _, ip := range s.Scope.PublicIPSpecs() {
-		managed, err := s.isIPManaged(ctx, ip.Name)
+		ipName := ip.Name
+		managed, err := s.isIPManaged(ctx, ipName)
 		if err != nil && !azure.ResourceNotFound(err) {
 			return errors.Wrap(err, "could not get public IP management state")
 		}
 
+		// the public IP may have been created under a different name, e.g. before the spec was changed,
+		// so look it up by its role tag instead.
+		if azure.ResourceNotFound(err) && ip.Role != "" {
+			ipName, err = s.findIPByRole(ctx, ip.Role)
+			if err != nil {
+				return errors.Wrapf(err, "could not find public IP with role %s", ip.Role)
+			}
+			managed = ipName != ""
+		}
+
 		if !managed {
 			log.V(2).Info("Skipping IP deletion for unmanaged public IP", "public ip", ip.Name)
 			continue
 		}
 
-		log.V(2).Info("deleting public IP", "public ip", ip.Name)
-		err = s.Client.Delete(ctx, s.Scope.ResourceGroup(), ip.Name)
+		if err := s.checkEnvironment(ctx, ipName, "delete"); err != nil {
+			return err
+		}
+
+		log.V(2).Info("deleting public IP", "public ip", ipName)
+		err = s.Client.Delete(ctx, s.Scope.ResourceGroup(), ipName)
 		if err != nil && azure.ResourceNotFound(err) {
 			// already deleted
 			continue
 		}
 		if err != nil {
-			return errors.Wrapf(err, "failed to delete public IP %s in resource group %s", ip.Name, s.Scope.ResourceGroup())
+			return errors.Wrapf(err, "failed to delete public IP %s in resource group %s", ipName, s.Scope.ResourceGroup())
 		}
 
-		log.V(2).Info("deleted public IP", "public ip", ip.Name)
+		log.V(2).Info("deleted public IP", "public ip", ipName)
 	}
 	return nil
 }
 
+// checkEnvironment returns a terminal error if an existing public IP, about to be adopted or deleted, belongs to
+// another environment than the one expected by the scope. It is only fetched when an environment is expected.
+func (s *Service) checkEnvironment(ctx context.Context, ipName, action string) error {
+	if azure.ExpectedEnvironment(s.Scope) == "" {
+		return nil
+	}
+	existing, err := s.Client.Get(ctx, s.Scope.ResourceGroup(), ipName)
+	if azure.ResourceNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to get public IP %s", ipName)
+	}
+	return azure.CheckEnvironment(s.Scope, existing, action, ipName, s.Scope.ResourceGroup(), serviceName)
+}
+
 // isIPManaged returns true if the IP has an owned tag with the cluster name as value,
 // meaning that the IP's lifecycle is managed.
 func (s *Service) isIPManaged(ctx context.Context, ipName string) (bool, error) {
@@ -149,3 +485,22 @@ func (s *Service) isIPManaged(ctx context.Context, ipName string) (bool, error)
 	tags := converters.MapToTags(ip.Tags)
 	return tags.HasOwned(s.Scope.ClusterName()), nil
 }
+
+// findIPByRole returns the name of the public IP owned by the cluster and tagged with the given role,
+// or an empty string if there is none.
+func (s *Service) findIPByRole(ctx context.Context, role string) (string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "publicips.Service.findIPByRole")
+	defer done()
+
+	ips, err := s.Client.List(ctx, s.Scope.ResourceGroup())
+	if err != nil {
+		return "", err
+	}
+	for _, ip := range ips {
+		tags := converters.MapToTags(ip.Tags)
+		if tags.HasOwned(s.Scope.ClusterName()) && tags.GetRole() == role {
+			return to.String(ip.Name), nil
+		}
+	}
+	return "", nil
+}
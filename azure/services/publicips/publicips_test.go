@@ -20,6 +20,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
 	"github.com/Azure/go-autorest/autorest"
@@ -52,6 +53,7 @@ func TestReconcilePublicIP(t *testing.T) {
 					{
 						Name:    "my-publicip",
 						DNSName: "fakedns.mydomain.io",
+						Role:    infrav1.APIServerRole,
 					},
 					{
 						Name:    "my-publicip-2",
@@ -70,7 +72,10 @@ func TestReconcilePublicIP(t *testing.T) {
 				s.ClusterName().AnyTimes().Return("my-cluster")
 				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
 				s.Location().AnyTimes().Return("testlocation")
+				s.SetControlPlaneEgressIPs(gomock.Nil())
+				s.SetPublicIPPrefixAllocations(gomock.Nil())
 				s.FailureDomains().AnyTimes().Return([]string{"1,2,3"})
+				s.SetPublicIPZones(gomock.Any(), []string{"1,2,3"}).AnyTimes()
 				gomock.InOrder(
 					m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-publicip", gomockinternal.DiffEq(network.PublicIPAddress{
 						Name:     to.StringPtr("my-publicip"),
@@ -79,6 +84,7 @@ func TestReconcilePublicIP(t *testing.T) {
 						Tags: map[string]*string{
 							"Name": to.StringPtr("my-publicip"),
 							"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+							"sigs.k8s.io_cluster-api-provider-azure_role":               to.StringPtr("apiserver"),
 						},
 						PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
 							PublicIPAddressVersion:   network.IPVersionIPv4,
@@ -140,9 +146,437 @@ func TestReconcilePublicIP(t *testing.T) {
 						},
 						Zones: to.StringSlicePtr([]string{"1,2,3"}),
 					})).Times(1),
+					m.Get(gomockinternal.AContext(), "my-rg", "my-publicip").Return(network.PublicIPAddress{
+						Name: to.StringPtr("my-publicip"),
+						PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+							IPAddress: to.StringPtr("20.1.2.3"),
+						},
+					}, nil),
+				)
+			},
+		},
+		{
+			name:          "API server public IP is assigned an address on the second attempt",
+			expectedError: "",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name:    "my-publicip",
+						DNSName: "fakedns.mydomain.io",
+						Role:    infrav1.APIServerRole,
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("testlocation")
+				s.SetControlPlaneEgressIPs(gomock.Nil())
+				s.SetPublicIPPrefixAllocations(gomock.Nil())
+				s.FailureDomains().AnyTimes().Return([]string{"1,2,3"})
+				s.SetPublicIPZones(gomock.Any(), []string{"1,2,3"}).AnyTimes()
+				gomock.InOrder(
+					m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-publicip", gomock.AssignableToTypeOf(network.PublicIPAddress{})),
+					m.Get(gomockinternal.AContext(), "my-rg", "my-publicip").Return(network.PublicIPAddress{
+						Name:                            to.StringPtr("my-publicip"),
+						PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{},
+					}, nil),
+					m.Get(gomockinternal.AContext(), "my-rg", "my-publicip").Return(network.PublicIPAddress{
+						Name: to.StringPtr("my-publicip"),
+						PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+							IPAddress: to.StringPtr("20.1.2.3"),
+						},
+					}, nil),
+				)
+			},
+		},
+		{
+			name:          "API server public IP is not assigned an address yet",
+			expectedError: "public IP my-publicip has no address assigned yet. Object will be requeued after 15s",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name:    "my-publicip",
+						DNSName: "fakedns.mydomain.io",
+						Role:    infrav1.APIServerRole,
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("testlocation")
+				s.SetControlPlaneEgressIPs(gomock.Nil())
+				s.SetPublicIPPrefixAllocations(gomock.Nil())
+				s.FailureDomains().AnyTimes().Return([]string{"1,2,3"})
+				s.SetPublicIPZones(gomock.Any(), []string{"1,2,3"}).AnyTimes()
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-publicip", gomock.AssignableToTypeOf(network.PublicIPAddress{}))
+				m.Get(gomockinternal.AContext(), "my-rg", "my-publicip").Return(network.PublicIPAddress{
+					Name:                            to.StringPtr("my-publicip"),
+					PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{},
+				}, nil).Times(2)
+			},
+		},
+		{
+			name:          "can create a zonal public IP",
+			expectedError: "",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name:  "my-publicip",
+						Zones: []string{"2"},
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("testlocation")
+				s.SetControlPlaneEgressIPs(gomock.Nil())
+				s.SetPublicIPPrefixAllocations(gomock.Nil())
+				s.FailureDomains().Return([]string{"3", "1", "2"})
+				gomock.InOrder(
+					m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-publicip", gomockinternal.DiffEq(network.PublicIPAddress{
+						Name:     to.StringPtr("my-publicip"),
+						Sku:      &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameStandard},
+						Location: to.StringPtr("testlocation"),
+						Tags: map[string]*string{
+							"Name": to.StringPtr("my-publicip"),
+							"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						},
+						PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+							PublicIPAddressVersion:   network.IPVersionIPv4,
+							PublicIPAllocationMethod: network.IPAllocationMethodStatic,
+						},
+						Zones: to.StringSlicePtr([]string{"2"}),
+					})),
+					s.SetPublicIPZones("my-publicip", []string{"2"}),
+				)
+			},
+		},
+		{
+			name:          "can create a public IP with IP tags and the internet routing preference",
+			expectedError: "",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name:              "my-publicip",
+						Zones:             []string{"2"},
+						IPTags:            []infrav1.IPTag{{Type: infrav1.IPTagTypeFirstPartyUsage, Tag: "/NonProd"}},
+						RoutingPreference: infrav1.RoutingPreferenceInternet,
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("testlocation")
+				s.SetControlPlaneEgressIPs(gomock.Nil())
+				s.SetPublicIPPrefixAllocations(gomock.Nil())
+				s.FailureDomains().Return([]string{"1", "2", "3"})
+				gomock.InOrder(
+					m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-publicip", gomockinternal.DiffEq(network.PublicIPAddress{
+						Name:     to.StringPtr("my-publicip"),
+						Sku:      &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameStandard},
+						Location: to.StringPtr("testlocation"),
+						Tags: map[string]*string{
+							"Name": to.StringPtr("my-publicip"),
+							"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						},
+						PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+							PublicIPAddressVersion:   network.IPVersionIPv4,
+							PublicIPAllocationMethod: network.IPAllocationMethodStatic,
+							IPTags: &[]network.IPTag{
+								{IPTagType: to.StringPtr("FirstPartyUsage"), Tag: to.StringPtr("/NonProd")},
+								{IPTagType: to.StringPtr("RoutingPreference"), Tag: to.StringPtr("Internet")},
+							},
+						},
+						Zones: to.StringSlicePtr([]string{"2"}),
+					})),
+					s.SetPublicIPZones("my-publicip", []string{"2"}),
 				)
 			},
 		},
+		{
+			name:          "can create a global public IP in another location",
+			expectedError: "",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name:     "my-global-publicip",
+						DNSName:  "fakedns.mydomain.io",
+						Location: "eastus2",
+						IsGlobal: true,
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("testlocation")
+				s.SetControlPlaneEgressIPs(gomock.Nil())
+				s.SetPublicIPPrefixAllocations(gomock.Nil())
+				gomock.InOrder(
+					m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-global-publicip", gomockinternal.DiffEq(network.PublicIPAddress{
+						Name:     to.StringPtr("my-global-publicip"),
+						Sku:      &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameStandard, Tier: network.PublicIPAddressSkuTierGlobal},
+						Location: to.StringPtr("eastus2"),
+						Tags: map[string]*string{
+							"Name": to.StringPtr("my-global-publicip"),
+							"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						},
+						PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+							PublicIPAddressVersion:   network.IPVersionIPv4,
+							PublicIPAllocationMethod: network.IPAllocationMethodStatic,
+							DNSSettings: &network.PublicIPAddressDNSSettings{
+								DomainNameLabel: to.StringPtr("fakedns"),
+								Fqdn:            to.StringPtr("fakedns.mydomain.io"),
+							},
+						},
+						Zones: to.StringSlicePtr(nil),
+					})),
+					s.SetPublicIPZones("my-global-publicip", nil),
+				)
+			},
+		},
+		{
+			name:          "reports the addresses of the control plane egress public IPs",
+			expectedError: "",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name: "my-cp-natgw-ip",
+						Role: infrav1.ControlPlaneEgressRole,
+					},
+					{
+						Name: "my-cp-natgw-ip-1",
+						Role: infrav1.ControlPlaneEgressRole,
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("testlocation")
+				s.FailureDomains().AnyTimes().Return([]string{"1,2,3"})
+				s.SetPublicIPZones(gomock.Any(), []string{"1,2,3"}).AnyTimes()
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-cp-natgw-ip", gomockinternal.DiffEq(network.PublicIPAddress{
+					Name:     to.StringPtr("my-cp-natgw-ip"),
+					Sku:      &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameStandard},
+					Location: to.StringPtr("testlocation"),
+					Tags: map[string]*string{
+						"Name": to.StringPtr("my-cp-natgw-ip"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_role":               to.StringPtr("controlPlaneEgress"),
+					},
+					PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+						PublicIPAddressVersion:   network.IPVersionIPv4,
+						PublicIPAllocationMethod: network.IPAllocationMethodStatic,
+					},
+					Zones: to.StringSlicePtr([]string{"1,2,3"}),
+				}))
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-cp-natgw-ip-1", gomock.AssignableToTypeOf(network.PublicIPAddress{}))
+				m.Get(gomockinternal.AContext(), "my-rg", "my-cp-natgw-ip").Return(network.PublicIPAddress{
+					Name: to.StringPtr("my-cp-natgw-ip"),
+					PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+						IPAddress: to.StringPtr("20.1.2.3"),
+					},
+				}, nil)
+				m.Get(gomockinternal.AContext(), "my-rg", "my-cp-natgw-ip-1").Return(network.PublicIPAddress{
+					Name: to.StringPtr("my-cp-natgw-ip-1"),
+					PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+						IPAddress: to.StringPtr("20.1.2.4"),
+					},
+				}, nil)
+				s.SetControlPlaneEgressIPs([]string{"20.1.2.3", "20.1.2.4"})
+				s.SetPublicIPPrefixAllocations(gomock.Nil())
+			},
+		},
+		{
+			name:          "retries the read of a control plane egress public IP not visible yet after its creation",
+			expectedError: "",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name: "my-cp-natgw-ip",
+						Role: infrav1.ControlPlaneEgressRole,
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("testlocation")
+				s.FailureDomains().AnyTimes().Return([]string{"1,2,3"})
+				s.SetPublicIPZones(gomock.Any(), []string{"1,2,3"}).AnyTimes()
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-cp-natgw-ip", gomock.AssignableToTypeOf(network.PublicIPAddress{}))
+				gomock.InOrder(
+					m.Get(gomockinternal.AContext(), "my-rg", "my-cp-natgw-ip").Return(network.PublicIPAddress{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found")),
+					m.Get(gomockinternal.AContext(), "my-rg", "my-cp-natgw-ip").Return(network.PublicIPAddress{
+						Name: to.StringPtr("my-cp-natgw-ip"),
+						PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+							IPAddress: to.StringPtr("20.1.2.3"),
+						},
+					}, nil),
+				)
+				s.SetControlPlaneEgressIPs([]string{"20.1.2.3"})
+				s.SetPublicIPPrefixAllocations(gomock.Nil())
+			},
+		},
+		{
+			name:          "API server public IP still not found after the last retry",
+			expectedError: "failed to get public IP my-publicip: #: Not Found: StatusCode=404",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name: "my-publicip",
+						Role: infrav1.APIServerRole,
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("testlocation")
+				s.FailureDomains().AnyTimes().Return([]string{"1,2,3"})
+				s.SetPublicIPZones(gomock.Any(), []string{"1,2,3"}).AnyTimes()
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-publicip", gomock.AssignableToTypeOf(network.PublicIPAddress{}))
+				s.SetControlPlaneEgressIPs(nil)
+				s.SetPublicIPPrefixAllocations(gomock.Nil())
+				m.Get(gomockinternal.AContext(), "my-rg", "my-publicip").Times(2).Return(network.PublicIPAddress{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found"))
+			},
+		},
+		{
+			name:          "allocates a public IP from a public IP prefix",
+			expectedError: "",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name:       "my-outbound-ip",
+						IPPrefixID: "/subscriptions/456/resourceGroups/prefix-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix",
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("testlocation")
+				s.FailureDomains().AnyTimes().Return([]string{"1"})
+				s.SetPublicIPZones("my-outbound-ip", []string{"1"})
+				m.GetPrefix(gomockinternal.AContext(), "456", "prefix-rg", "my-prefix").Return(network.PublicIPPrefix{
+					Sku: &network.PublicIPPrefixSku{Name: network.PublicIPPrefixSkuNameStandard, Tier: network.PublicIPPrefixSkuTierRegional},
+					PublicIPPrefixPropertiesFormat: &network.PublicIPPrefixPropertiesFormat{
+						PrefixLength: to.Int32Ptr(31),
+						PublicIPAddresses: &[]network.ReferencedPublicIPAddress{
+							{ID: to.StringPtr("/subscriptions/456/resourceGroups/other-rg/providers/Microsoft.Network/publicIPAddresses/other-ip")},
+						},
+					},
+				}, nil)
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-outbound-ip", gomockinternal.DiffEq(network.PublicIPAddress{
+					Name:     to.StringPtr("my-outbound-ip"),
+					Sku:      &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameStandard},
+					Location: to.StringPtr("testlocation"),
+					Tags: map[string]*string{
+						"Name": to.StringPtr("my-outbound-ip"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+					},
+					PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+						PublicIPAddressVersion:   network.IPVersionIPv4,
+						PublicIPAllocationMethod: network.IPAllocationMethodStatic,
+						PublicIPPrefix:           &network.SubResource{ID: to.StringPtr("/subscriptions/456/resourceGroups/prefix-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix")},
+					},
+					Zones: to.StringSlicePtr([]string{"1"}),
+				}))
+				s.SetControlPlaneEgressIPs(gomock.Nil())
+				m.Get(gomockinternal.AContext(), "my-rg", "my-outbound-ip").Return(network.PublicIPAddress{
+					Name: to.StringPtr("my-outbound-ip"),
+					PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+						IPAddress: to.StringPtr("20.1.2.5"),
+					},
+				}, nil)
+				s.SetPublicIPPrefixAllocations(map[string]string{"my-outbound-ip": "20.1.2.5"})
+			},
+		},
+		{
+			name:          "public IP prefix has no free address left",
+			expectedError: "public IP prefix /subscriptions/456/resourceGroups/prefix-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix of public IP my-outbound-ip has no free address left",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name:       "my-outbound-ip",
+						IPPrefixID: "/subscriptions/456/resourceGroups/prefix-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix",
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("testlocation")
+				s.FailureDomains().AnyTimes().Return([]string{"1"})
+				m.GetPrefix(gomockinternal.AContext(), "456", "prefix-rg", "my-prefix").Return(network.PublicIPPrefix{
+					Sku: &network.PublicIPPrefixSku{Name: network.PublicIPPrefixSkuNameStandard},
+					PublicIPPrefixPropertiesFormat: &network.PublicIPPrefixPropertiesFormat{
+						PrefixLength: to.Int32Ptr(32),
+						PublicIPAddresses: &[]network.ReferencedPublicIPAddress{
+							{ID: to.StringPtr("/subscriptions/456/resourceGroups/other-rg/providers/Microsoft.Network/publicIPAddresses/other-ip")},
+						},
+					},
+				}, nil)
+			},
+		},
+		{
+			name:          "public IP already allocated from a full public IP prefix",
+			expectedError: "",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name:       "my-outbound-ip",
+						IPPrefixID: "/subscriptions/456/resourceGroups/prefix-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix",
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("testlocation")
+				s.FailureDomains().AnyTimes().Return([]string{"1"})
+				s.SetPublicIPZones("my-outbound-ip", []string{"1"})
+				m.GetPrefix(gomockinternal.AContext(), "456", "prefix-rg", "my-prefix").Return(network.PublicIPPrefix{
+					Sku: &network.PublicIPPrefixSku{Name: network.PublicIPPrefixSkuNameStandard},
+					PublicIPPrefixPropertiesFormat: &network.PublicIPPrefixPropertiesFormat{
+						PrefixLength: to.Int32Ptr(32),
+						PublicIPAddresses: &[]network.ReferencedPublicIPAddress{
+							{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-outbound-ip")},
+						},
+					},
+				}, nil)
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-outbound-ip", gomock.AssignableToTypeOf(network.PublicIPAddress{}))
+				s.SetControlPlaneEgressIPs(gomock.Nil())
+				m.Get(gomockinternal.AContext(), "my-rg", "my-outbound-ip").Return(network.PublicIPAddress{
+					Name: to.StringPtr("my-outbound-ip"),
+					PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+						IPAddress: to.StringPtr("20.1.2.6"),
+					},
+				}, nil)
+				s.SetPublicIPPrefixAllocations(map[string]string{"my-outbound-ip": "20.1.2.6"})
+			},
+		},
+		{
+			name:          "public IP prefix without the Standard SKU",
+			expectedError: "public IP prefix /subscriptions/456/resourceGroups/prefix-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix of public IP my-outbound-ip doesn't have the Standard SKU",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name:       "my-outbound-ip",
+						IPPrefixID: "/subscriptions/456/resourceGroups/prefix-rg/providers/Microsoft.Network/publicIPPrefixes/my-prefix",
+					},
+				})
+				s.Location().AnyTimes().Return("testlocation")
+				s.FailureDomains().AnyTimes().Return([]string{"1"})
+				m.GetPrefix(gomockinternal.AContext(), "456", "prefix-rg", "my-prefix").Return(network.PublicIPPrefix{}, nil)
+			},
+		},
+		{
+			name:          "zone of public IP is not available in the location",
+			expectedError: "zone 4 of public IP my-publicip is not available in location testlocation",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name:  "my-publicip",
+						Zones: []string{"4"},
+					},
+				})
+				s.Location().AnyTimes().Return("testlocation")
+				s.FailureDomains().Return([]string{"1", "2", "3"})
+			},
+		},
 		{
 			name:          "fail to create a public IP",
 			expectedError: "cannot create public IP: #: Internal Server Error: StatusCode=500",
@@ -159,6 +593,99 @@ func TestReconcilePublicIP(t *testing.T) {
 				s.Location().AnyTimes().Return("testlocation")
 				s.FailureDomains().Times(1)
 				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-publicip", gomock.AssignableToTypeOf(network.PublicIPAddress{})).Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+				m.Get(gomockinternal.AContext(), "my-rg", "my-publicip").Return(network.PublicIPAddress{
+					Sku: &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameStandard},
+				}, nil)
+			},
+		},
+		{
+			name:          "fail to update a public IP of the Basic SKU",
+			expectedError: "reconcile error that cannot be recovered occurred: public IP my-publicip has the Basic SKU, which Azure can't change to the Standard SKU in place: set forceRecreate on its load balancer for it to be deleted and recreated with the Standard SKU, which assigns it a new address, or migrate it manually. Object will not be requeued",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name:    "my-publicip",
+						DNSName: "fakedns.mydomain.io",
+						Role:    infrav1.APIServerRole,
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("testlocation")
+				s.FailureDomains().Times(1)
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-publicip", gomock.AssignableToTypeOf(network.PublicIPAddress{})).Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 400}, "Bad Request"))
+				m.Get(gomockinternal.AContext(), "my-rg", "my-publicip").Return(network.PublicIPAddress{
+					Sku: &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameBasic},
+				}, nil)
+			},
+		},
+		{
+			name:          "skip a public IP of the Basic SKU recreated with its load balancer",
+			expectedError: "",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name:          "my-publicip",
+						DNSName:       "fakedns.mydomain.io",
+						Role:          infrav1.APIServerRole,
+						ForceRecreate: true,
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("testlocation")
+				s.FailureDomains().Times(1)
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-publicip", gomock.AssignableToTypeOf(network.PublicIPAddress{})).Return(autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 400}, "Bad Request"))
+				m.Get(gomockinternal.AContext(), "my-rg", "my-publicip").Return(network.PublicIPAddress{
+					Sku: &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameBasic},
+				}, nil)
+				s.SetControlPlaneEgressIPs(nil)
+				s.SetPublicIPPrefixAllocations(nil)
+			},
+		},
+		{
+			name:          "delete the unattached public IPs of the cluster left behind by a lowered NAT gateway public IP count",
+			expectedError: "",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name: "my-natgw-ip",
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				s.AdditionalTags().AnyTimes().Return(infrav1.Tags{})
+				s.Location().AnyTimes().Return("testlocation")
+				s.FailureDomains().AnyTimes().Return([]string{})
+				s.SetPublicIPZones("my-natgw-ip", gomock.Any())
+				s.SetControlPlaneEgressIPs(gomock.Nil())
+				s.SetPublicIPPrefixAllocations(gomock.Nil())
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "my-natgw-ip", gomock.AssignableToTypeOf(network.PublicIPAddress{}))
+				s.StalePublicIPNames().Return([]string{"my-natgw-ip-1", "my-natgw-ip-2", "my-natgw-ip-3"})
+				m.List(gomockinternal.AContext(), "my-rg").Return([]network.PublicIPAddress{
+					{
+						Name: to.StringPtr("my-natgw-ip"),
+						Tags: map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned")},
+					},
+					{
+						Name:                            to.StringPtr("my-natgw-ip-1"),
+						Tags:                            map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned")},
+						PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{},
+					},
+					{
+						Name: to.StringPtr("my-natgw-ip-2"),
+						Tags: map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned")},
+						PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+							NatGateway: &network.NatGateway{ID: to.StringPtr("my-natgw")},
+						},
+					},
+					{
+						Name: to.StringPtr("my-natgw-ip-3"),
+					},
+				}, nil)
+				m.Delete(gomockinternal.AContext(), "my-rg", "my-natgw-ip-1")
 			},
 		},
 	}
@@ -175,10 +702,14 @@ func TestReconcilePublicIP(t *testing.T) {
 			clientMock := mock_publicips.NewMockClient(mockCtrl)
 
 			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+			scopeMock.EXPECT().StalePublicIPNames().AnyTimes().Return(nil)
 
 			s := &Service{
-				Scope:  scopeMock,
-				Client: clientMock,
+				Scope:               scopeMock,
+				Client:              clientMock,
+				addressPollAttempts: 2,
+				addressPollInterval: time.Millisecond,
+				notFoundRetry:       azure.NotFoundRetry{Attempts: 2},
 			}
 
 			err := s.Reconcile(context.TODO())
@@ -255,6 +786,61 @@ func TestDeletePublicIP(t *testing.T) {
 				m.Delete(gomockinternal.AContext(), "my-rg", "my-publicip-2")
 			},
 		},
+		{
+			name:          "renamed API server public ip is found by its role tag",
+			expectedError: "",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name: "my-cluster-apiserver-ip",
+						Role: infrav1.APIServerRole,
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-cluster-apiserver-ip").Return(network.PublicIPAddress{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.List(gomockinternal.AContext(), "my-rg").Return([]network.PublicIPAddress{
+					{
+						Name: to.StringPtr("my-cluster-node-outbound-ip"),
+						Tags: map[string]*string{
+							"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+							"sigs.k8s.io_cluster-api-provider-azure_role":               to.StringPtr("nodeOutbound"),
+						},
+					},
+					{
+						Name: to.StringPtr("other-cluster-apiserver-ip"),
+						Tags: map[string]*string{
+							"sigs.k8s.io_cluster-api-provider-azure_cluster_other-cluster": to.StringPtr("owned"),
+							"sigs.k8s.io_cluster-api-provider-azure_role":                  to.StringPtr("apiserver"),
+						},
+					},
+					{
+						Name: to.StringPtr("my-old-apiserver-ip"),
+						Tags: map[string]*string{
+							"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+							"sigs.k8s.io_cluster-api-provider-azure_role":               to.StringPtr("apiserver"),
+						},
+					},
+				}, nil)
+				m.Delete(gomockinternal.AContext(), "my-rg", "my-old-apiserver-ip")
+			},
+		},
+		{
+			name:          "no public ip with the role tag is left",
+			expectedError: "",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				s.PublicIPSpecs().Return([]azure.PublicIPSpec{
+					{
+						Name: "my-cluster-apiserver-ip",
+						Role: infrav1.APIServerRole,
+					},
+				})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-cluster-apiserver-ip").Return(network.PublicIPAddress{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.List(gomockinternal.AContext(), "my-rg").Return(nil, nil)
+			},
+		},
 		{
 			name:          "public ip deletion fails",
 			expectedError: "failed to delete public IP my-publicip in resource group my-rg: #: Internal Server Error: StatusCode=500",
@@ -337,3 +923,80 @@ func TestDeletePublicIP(t *testing.T) {
 		})
 	}
 }
+
+// environmentPublicIPScope is a PublicIPScope that expects the resources to belong to the dev environment.
+type environmentPublicIPScope struct {
+	*mock_publicips.MockPublicIPScope
+}
+
+func (s environmentPublicIPScope) ExpectedEnvironment() string {
+	return "dev"
+}
+
+func TestDeletePublicIPEnvironment(t *testing.T) {
+	ownedIP := func(environment string) network.PublicIPAddress {
+		tags := map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned")}
+		if environment != "" {
+			tags["environment"] = to.StringPtr(environment)
+		}
+		return network.PublicIPAddress{Name: to.StringPtr("my-publicip"), Location: to.StringPtr("westus"), Tags: tags}
+	}
+
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder)
+	}{
+		{
+			name: "delete a public IP of the expected environment",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				m.Get(gomockinternal.AContext(), "my-rg", "my-publicip").Return(ownedIP("dev"), nil).Times(2)
+				m.Delete(gomockinternal.AContext(), "my-rg", "my-publicip")
+			},
+		},
+		{
+			name: "delete a public IP of the cluster created before the environment was expected",
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				m.Get(gomockinternal.AContext(), "my-rg", "my-publicip").Return(ownedIP(""), nil).Times(2)
+				m.Delete(gomockinternal.AContext(), "my-rg", "my-publicip")
+			},
+		},
+		{
+			name:          "refuse to delete a public IP of another environment",
+			expectedError: `refusing to delete resource my-rg/my-publicip (service: publicips): its environment tag is "prod", expected "dev"`,
+			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_publicips.MockClientMockRecorder) {
+				m.Get(gomockinternal.AContext(), "my-rg", "my-publicip").Return(ownedIP("prod"), nil).Times(2)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_publicips.NewMockPublicIPScope(mockCtrl)
+			clientMock := mock_publicips.NewMockClient(mockCtrl)
+			scopeMock.EXPECT().PublicIPSpecs().Return([]azure.PublicIPSpec{{Name: "my-publicip"}})
+			scopeMock.EXPECT().ResourceGroup().AnyTimes().Return("my-rg")
+			scopeMock.EXPECT().ClusterName().AnyTimes().Return("my-cluster")
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  environmentPublicIPScope{MockPublicIPScope: scopeMock},
+				Client: clientMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedError)))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registrations
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/cache/ttllru"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const (
+	// registeredState is the registration state of the resource providers and features that can be used.
+	registeredState = "Registered"
+
+	// registrationRequeue is how long to wait for the registrations to complete.
+	registrationRequeue = time.Minute
+
+	// unregisteredRequeue is how long to wait for the requirements to be registered out of band.
+	unregisteredRequeue = 5 * time.Minute
+
+	// registeredTTL is the duration after which a registered requirement is checked again, as it can be unregistered
+	// out of band.
+	registeredTTL = 1 * time.Hour
+)
+
+// Requirement is a resource provider, or a feature of a resource provider when Feature is set, that must be
+// registered in the subscription of a cluster.
+type Requirement struct {
+	// Namespace is the namespace of the resource provider, e.g. Microsoft.Network.
+	Namespace string
+	// Feature is the name of the feature of the resource provider, if any.
+	Feature string
+}
+
+// String returns the namespace of the resource provider, followed by the name of the feature if any.
+func (r Requirement) String() string {
+	if r.Feature == "" {
+		return r.Namespace
+	}
+	return r.Namespace + "/" + r.Feature
+}
+
+// Cache checks the resource providers and features registered in a subscription. Only the registered ones are
+// cached, for the cache TTL, the others are checked again on each validation until they are registered.
+type Cache struct {
+	client         Client
+	subscriptionID string
+
+	// ttl is the duration after which a registered requirement is checked again.
+	ttl time.Duration
+
+	mu sync.Mutex
+	// registered holds when the requirements known to be registered were checked, by their lowercase name.
+	registered map[string]time.Time
+}
+
+// Cacher describes the ability to get and to add items to cache.
+type Cacher interface {
+	Get(key interface{}) (value interface{}, ok bool)
+	Add(key interface{}, value interface{}) bool
+}
+
+var (
+	doOnce      sync.Once
+	clientCache Cacher
+)
+
+// GetCache either creates a new registrations cache or returns an existing one based on the Authorizer HashKey().
+func GetCache(auth azure.Authorizer) (*Cache, error) {
+	var err error
+	doOnce.Do(func() {
+		clientCache, err = ttllru.New(128, 24*time.Hour)
+	})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating LRU cache for registrations cache")
+	}
+
+	key := auth.HashKey()
+	c, ok := clientCache.Get(key)
+	if ok {
+		return c.(*Cache), nil
+	}
+
+	c = newCache(NewClient(auth), auth.SubscriptionID())
+	_ = clientCache.Add(key, c)
+	return c.(*Cache), nil
+}
+
+func newCache(client Client, subscriptionID string) *Cache {
+	return &Cache{
+		client:         client,
+		subscriptionID: subscriptionID,
+		ttl:            registeredTTL,
+		registered:     map[string]time.Time{},
+	}
+}
+
+// Validate returns a transient error listing the requirements that aren't registered in the subscription, with the
+// commands registering them. When register is true, the requirements that aren't registered nor being registered are
+// registered, and a transient error is returned until all the registrations complete. A requirement whose registration
+// state can't be read because of a transient Azure error, e.g. throttling, or because the identity isn't authorized to
+// read it, is assumed to be registered rather than blocking the cluster: a missing registration then fails the creation
+// of the resources instead.
+func (c *Cache) Validate(ctx context.Context, requirements []Requirement, register bool) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "registrations.Cache.Validate")
+	defer done()
+
+	var unregistered []string
+	for _, requirement := range requirements {
+		registered, state, err := c.isRegistered(ctx, requirement)
+		if err != nil && (azure.ResourceTransientError(err) || azure.ResourceForbidden(err)) {
+			log.V(2).Info("skipping the registration check", "registration", requirement.String(), "subscriptionID", c.subscriptionID, "error", err.Error())
+			continue
+		} else if err != nil {
+			return err
+		}
+		if registered {
+			continue
+		}
+		unregistered = append(unregistered, requirement.String())
+
+		if !register || strings.EqualFold(state, "Registering") || strings.EqualFold(state, "Pending") {
+			continue
+		}
+		log.Info("registering in the subscription", "registration", requirement.String(), "subscriptionID", c.subscriptionID, "state", state)
+		if requirement.Feature == "" {
+			err = c.client.RegisterProvider(ctx, requirement.Namespace)
+		} else {
+			err = c.client.RegisterFeature(ctx, requirement.Namespace, requirement.Feature)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(unregistered) == 0 {
+		return nil
+	}
+	if register {
+		return azure.WithTransientError(errors.Errorf("waiting for the registration of %s in subscription %s to complete",
+			strings.Join(unregistered, ", "), c.subscriptionID), registrationRequeue)
+	}
+	return azure.WithTransientError(errors.Errorf("%s must be registered in subscription %s: register the resource providers with "+
+		"\"az provider register --namespace <namespace>\" and the features with \"az feature register --namespace <namespace> --name <feature>\", "+
+		"or start the controller with --register-resource-providers to register them automatically",
+		strings.Join(unregistered, ", "), c.subscriptionID), unregisteredRequeue)
+}
+
+// isRegistered returns whether the requirement is registered, and its registration state when it isn't known to be.
+func (c *Cache) isRegistered(ctx context.Context, requirement Requirement) (bool, string, error) {
+	key := strings.ToLower(requirement.String())
+
+	c.mu.Lock()
+	checkedAt, registered := c.registered[key]
+	c.mu.Unlock()
+	if registered && time.Since(checkedAt) < c.ttl {
+		return true, registeredState, nil
+	}
+
+	var (
+		state string
+		err   error
+	)
+	if requirement.Feature == "" {
+		state, err = c.client.GetProviderState(ctx, requirement.Namespace)
+	} else {
+		state, err = c.client.GetFeatureState(ctx, requirement.Namespace, requirement.Feature)
+	}
+	if err != nil {
+		return false, "", errors.Wrapf(err, "failed to get the registration state of %s", requirement)
+	}
+	if !strings.EqualFold(state, registeredState) {
+		c.mu.Lock()
+		delete(c.registered, key)
+		c.mu.Unlock()
+		return false, state, nil
+	}
+
+	c.mu.Lock()
+	c.registered[key] = time.Now()
+	c.mu.Unlock()
+	return true, state, nil
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registrations
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/registrations/mock_registrations"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	networkProvider = Requirement{Namespace: "Microsoft.Network"}
+	computeProvider = Requirement{Namespace: "Microsoft.Compute"}
+	byoipFeature    = Requirement{Namespace: "Microsoft.Network", Feature: "AllowBringYourOwnPublicIpAddress"}
+
+	throttledError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusTooManyRequests}, "Too Many Requests")
+	forbiddenError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusForbidden}, "AuthorizationFailed")
+)
+
+func TestCacheValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		register      bool
+		expect        func(c *mock_registrations.MockClientMockRecorder)
+		expectedError string
+		transient     bool
+	}{
+		{
+			name: "all registered",
+			expect: func(c *mock_registrations.MockClientMockRecorder) {
+				c.GetProviderState(gomockinternal.AContext(), "Microsoft.Network").Return("Registered", nil)
+				c.GetProviderState(gomockinternal.AContext(), "Microsoft.Compute").Return("Registered", nil)
+				c.GetFeatureState(gomockinternal.AContext(), "Microsoft.Network", "AllowBringYourOwnPublicIpAddress").Return("Registered", nil)
+			},
+		},
+		{
+			name: "unregistered provider and feature",
+			expect: func(c *mock_registrations.MockClientMockRecorder) {
+				c.GetProviderState(gomockinternal.AContext(), "Microsoft.Network").Return("Registered", nil)
+				c.GetProviderState(gomockinternal.AContext(), "Microsoft.Compute").Return("NotRegistered", nil)
+				c.GetFeatureState(gomockinternal.AContext(), "Microsoft.Network", "AllowBringYourOwnPublicIpAddress").Return("NotRegistered", nil)
+			},
+			expectedError: "Microsoft.Compute, Microsoft.Network/AllowBringYourOwnPublicIpAddress must be registered in subscription 123: " +
+				"register the resource providers with \"az provider register --namespace <namespace>\" and the features with " +
+				"\"az feature register --namespace <namespace> --name <feature>\", or start the controller with --register-resource-providers to register them automatically",
+			transient: true,
+		},
+		{
+			name:     "unregistered provider and feature are registered",
+			register: true,
+			expect: func(c *mock_registrations.MockClientMockRecorder) {
+				c.GetProviderState(gomockinternal.AContext(), "Microsoft.Network").Return("Registered", nil)
+				c.GetProviderState(gomockinternal.AContext(), "Microsoft.Compute").Return("NotRegistered", nil)
+				c.RegisterProvider(gomockinternal.AContext(), "Microsoft.Compute").Return(nil)
+				c.GetFeatureState(gomockinternal.AContext(), "Microsoft.Network", "AllowBringYourOwnPublicIpAddress").Return("NotRegistered", nil)
+				c.RegisterFeature(gomockinternal.AContext(), "Microsoft.Network", "AllowBringYourOwnPublicIpAddress").Return(nil)
+			},
+			expectedError: "waiting for the registration of Microsoft.Compute, Microsoft.Network/AllowBringYourOwnPublicIpAddress in subscription 123 to complete",
+			transient:     true,
+		},
+		{
+			name:     "registrations in progress aren't registered again",
+			register: true,
+			expect: func(c *mock_registrations.MockClientMockRecorder) {
+				c.GetProviderState(gomockinternal.AContext(), "Microsoft.Network").Return("Registered", nil)
+				c.GetProviderState(gomockinternal.AContext(), "Microsoft.Compute").Return("Registering", nil)
+				c.GetFeatureState(gomockinternal.AContext(), "Microsoft.Network", "AllowBringYourOwnPublicIpAddress").Return("Pending", nil)
+			},
+			expectedError: "waiting for the registration of Microsoft.Compute, Microsoft.Network/AllowBringYourOwnPublicIpAddress in subscription 123 to complete",
+			transient:     true,
+		},
+		{
+			name: "registration state can't be read",
+			expect: func(c *mock_registrations.MockClientMockRecorder) {
+				c.GetProviderState(gomockinternal.AContext(), "Microsoft.Network").Return("", errors.New("unexpected error"))
+			},
+			expectedError: "failed to get the registration state of Microsoft.Network: unexpected error",
+		},
+		{
+			name: "registration state not read because of a transient error is skipped",
+			expect: func(c *mock_registrations.MockClientMockRecorder) {
+				c.GetProviderState(gomockinternal.AContext(), "Microsoft.Network").Return("", throttledError)
+				c.GetProviderState(gomockinternal.AContext(), "Microsoft.Compute").Return("Registered", nil)
+				c.GetFeatureState(gomockinternal.AContext(), "Microsoft.Network", "AllowBringYourOwnPublicIpAddress").Return("", throttledError)
+			},
+		},
+		{
+			name: "registration state not read because the identity isn't authorized is skipped",
+			expect: func(c *mock_registrations.MockClientMockRecorder) {
+				c.GetProviderState(gomockinternal.AContext(), "Microsoft.Network").Return("", forbiddenError)
+				c.GetProviderState(gomockinternal.AContext(), "Microsoft.Compute").Return("Registered", nil)
+				c.GetFeatureState(gomockinternal.AContext(), "Microsoft.Network", "AllowBringYourOwnPublicIpAddress").Return("", forbiddenError)
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			clientMock := mock_registrations.NewMockClient(mockCtrl)
+			tc.expect(clientMock.EXPECT())
+
+			c := newCache(clientMock, "123")
+			err := c.Validate(context.TODO(), []Requirement{networkProvider, computeProvider, byoipFeature}, tc.register)
+			if tc.expectedError == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(MatchError(ContainSubstring(tc.expectedError)))
+			var reconcileError azure.ReconcileError
+			g.Expect(errors.As(err, &reconcileError) && reconcileError.IsTransient()).To(Equal(tc.transient))
+		})
+	}
+}
+
+func TestCacheValidateCachesRegistrations(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	clientMock := mock_registrations.NewMockClient(mockCtrl)
+	// The registered provider is only checked once, the unregistered one on each validation.
+	clientMock.EXPECT().GetProviderState(gomockinternal.AContext(), "Microsoft.Network").Return("Registered", nil).Times(1)
+	clientMock.EXPECT().GetProviderState(gomockinternal.AContext(), "Microsoft.Compute").Return("NotRegistered", nil).Times(1)
+	clientMock.EXPECT().GetProviderState(gomockinternal.AContext(), "Microsoft.Compute").Return("Registered", nil).Times(1)
+
+	c := newCache(clientMock, "123")
+	g.Expect(c.Validate(context.TODO(), []Requirement{networkProvider, computeProvider}, false)).To(MatchError(ContainSubstring("Microsoft.Compute must be registered")))
+	g.Expect(c.Validate(context.TODO(), []Requirement{networkProvider, computeProvider}, false)).To(Succeed())
+	g.Expect(c.Validate(context.TODO(), []Requirement{networkProvider, computeProvider}, false)).To(Succeed())
+}
+
+func TestCacheValidateExpiresRegistrations(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	clientMock := mock_registrations.NewMockClient(mockCtrl)
+	// The registered provider is checked again once the cache TTL has elapsed, and is no longer cached once it has
+	// been unregistered.
+	clientMock.EXPECT().GetProviderState(gomockinternal.AContext(), "Microsoft.Network").Return("Registered", nil).Times(1)
+	clientMock.EXPECT().GetProviderState(gomockinternal.AContext(), "Microsoft.Network").Return("NotRegistered", nil).Times(2)
+
+	c := newCache(clientMock, "123")
+	g.Expect(c.Validate(context.TODO(), []Requirement{networkProvider}, false)).To(Succeed())
+	g.Expect(c.Validate(context.TODO(), []Requirement{networkProvider}, false)).To(Succeed())
+
+	c.registered["microsoft.network"] = time.Now().Add(-2 * registeredTTL)
+	g.Expect(c.Validate(context.TODO(), []Requirement{networkProvider}, false)).To(MatchError(ContainSubstring("Microsoft.Network must be registered")))
+	g.Expect(c.Validate(context.TODO(), []Requirement{networkProvider}, false)).To(MatchError(ContainSubstring("Microsoft.Network must be registered")))
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registrations
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2021-07-01/features"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// Client wraps go-sdk.
+type Client interface {
+	GetProviderState(ctx context.Context, namespace string) (string, error)
+	RegisterProvider(ctx context.Context, namespace string) error
+	GetFeatureState(ctx context.Context, namespace, feature string) (string, error)
+	RegisterFeature(ctx context.Context, namespace, feature string) error
+}
+
+// AzureClient contains the Azure go-sdk Client.
+type AzureClient struct {
+	providers resources.ProvidersClient
+	features  features.Client
+}
+
+var _ Client = &AzureClient{}
+
+// NewClient creates a new resource provider registrations client from subscription ID.
+func NewClient(auth azure.Authorizer) *AzureClient {
+	return &AzureClient{
+		providers: newProvidersClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		features:  newFeaturesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+	}
+}
+
+// newProvidersClient creates a new resource providers client from subscription ID.
+func newProvidersClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) resources.ProvidersClient {
+	c := resources.NewProvidersClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&c.Client, authorizer)
+	return c
+}
+
+// newFeaturesClient creates a new features client from subscription ID.
+func newFeaturesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) features.Client {
+	c := features.NewClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&c.Client, authorizer)
+	return c
+}
+
+// GetProviderState returns the registration state of a resource provider in the subscription, e.g. Registered.
+func (ac *AzureClient) GetProviderState(ctx context.Context, namespace string) (string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "registrations.AzureClient.GetProviderState")
+	defer done()
+
+	provider, err := ac.providers.Get(ctx, namespace, "")
+	if err != nil {
+		return "", errors.Wrapf(err, "could not get resource provider %s", namespace)
+	}
+	return to.String(provider.RegistrationState), nil
+}
+
+// RegisterProvider registers a resource provider in the subscription.
+func (ac *AzureClient) RegisterProvider(ctx context.Context, namespace string) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "registrations.AzureClient.RegisterProvider")
+	defer done()
+
+	if _, err := ac.providers.Register(ctx, namespace); err != nil {
+		return errors.Wrapf(err, "could not register resource provider %s", namespace)
+	}
+	return nil
+}
+
+// GetFeatureState returns the registration state of a feature of a resource provider in the subscription, e.g.
+// Registered.
+func (ac *AzureClient) GetFeatureState(ctx context.Context, namespace, feature string) (string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "registrations.AzureClient.GetFeatureState")
+	defer done()
+
+	result, err := ac.features.Get(ctx, namespace, feature)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not get feature %s/%s", namespace, feature)
+	}
+	if result.Properties == nil {
+		return "", nil
+	}
+	return to.String(result.Properties.State), nil
+}
+
+// RegisterFeature registers a feature of a resource provider in the subscription.
+func (ac *AzureClient) RegisterFeature(ctx context.Context, namespace, feature string) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "registrations.AzureClient.RegisterFeature")
+	defer done()
+
+	if _, err := ac.features.Register(ctx, namespace, feature); err != nil {
+		return errors.Wrapf(err, "could not register feature %s/%s", namespace, feature)
+	}
+	return nil
+}
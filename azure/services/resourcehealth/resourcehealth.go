@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcehealth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/resourcehealth/mgmt/2017-07-01/resourcehealth"
+	"github.com/Azure/go-autorest/autorest/to"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// ResourceHealthScope defines the scope interface for a resource health service.
+type ResourceHealthScope interface {
+	azure.Authorizer
+	ResourceHealthResourceIDs() []string
+	SetResourcesHealthy()
+	SetResourcesNotHealthy(reason string, severity clusterv1.ConditionSeverity, messageFormat string, messageArgs ...interface{})
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope ResourceHealthScope
+	client
+}
+
+// New creates a new service.
+func New(scope ResourceHealthScope) *Service {
+	return &Service{
+		Scope:  scope,
+		client: newClient(scope),
+	}
+}
+
+// Reconcile gets the availability statuses Azure Resource Health reports for the key resources of the cluster, and
+// reports the unavailable ones in the ResourcesHealthy condition. Platform issues and failures to query Resource
+// Health are warnings: it never returns an error.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "resourcehealth.Service.Reconcile")
+	defer done()
+
+	resourceIDs := s.Scope.ResourceHealthResourceIDs()
+	if len(resourceIDs) == 0 {
+		return nil
+	}
+
+	var unhealthy, failed []string
+	for _, id := range resourceIDs {
+		status, err := s.client.GetByResource(ctx, id)
+		if err != nil {
+			log.V(2).Info("failed to get resource health", "resource", id, "error", err.Error())
+			failed = append(failed, fmt.Sprintf("%s: %s", id, err.Error()))
+			continue
+		}
+		if !isUnhealthy(status) {
+			continue
+		}
+		log.V(2).Info("resource is reported as unhealthy", "resource", id, "state", status.Properties.AvailabilityState, "summary", to.String(status.Properties.Summary))
+		unhealthy = append(unhealthy, fmt.Sprintf("%s is %s: %s", id, status.Properties.AvailabilityState, to.String(status.Properties.Summary)))
+	}
+
+	switch {
+	case len(unhealthy) > 0:
+		s.Scope.SetResourcesNotHealthy(infrav1.UnhealthyResourcesReason, clusterv1.ConditionSeverityWarning,
+			"Azure Resource Health reports unhealthy resources: %s", strings.Join(unhealthy, "; "))
+	case len(failed) > 0:
+		s.Scope.SetResourcesNotHealthy(infrav1.ResourceHealthCheckFailedReason, clusterv1.ConditionSeverityInfo,
+			"failed to get the health of resources: %s", strings.Join(failed, "; "))
+	default:
+		s.Scope.SetResourcesHealthy()
+	}
+
+	return nil
+}
+
+// Delete is a no-op as the resource health check doesn't create any Azure resource.
+func (s *Service) Delete(ctx context.Context) error {
+	_, _, done := tele.StartSpanWithLogger(ctx, "resourcehealth.Service.Delete")
+	defer done()
+
+	return nil
+}
+
+// isUnhealthy returns true if Resource Health reports an issue with a resource. A resource whose health is unknown
+// isn't unhealthy, e.g. it hasn't reported any health signal yet.
+func isUnhealthy(status resourcehealth.AvailabilityStatus) bool {
+	if status.Properties == nil {
+		return false
+	}
+	switch status.Properties.AvailabilityState {
+	case resourcehealth.Available, resourcehealth.Unknown, "":
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcehealth
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resourcehealth/mgmt/2017-07-01/resourcehealth"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourcehealth/mock_resourcehealth"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	fakeAPIServerLBID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster-public-lb"
+	fakeNodeLBID      = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster"
+)
+
+func availabilityStatus(state resourcehealth.AvailabilityStateValues, summary string) resourcehealth.AvailabilityStatus {
+	return resourcehealth.AvailabilityStatus{
+		Properties: &resourcehealth.AvailabilityStatusProperties{
+			AvailabilityState: state,
+			Summary:           to.StringPtr(summary),
+		},
+	}
+}
+
+func TestReconcileResourceHealth(t *testing.T) {
+	testcases := []struct {
+		name   string
+		expect func(s *mock_resourcehealth.MockResourceHealthScopeMockRecorder, m *mock_resourcehealth.MockclientMockRecorder)
+	}{
+		{
+			name: "no resources",
+			expect: func(s *mock_resourcehealth.MockResourceHealthScopeMockRecorder, m *mock_resourcehealth.MockclientMockRecorder) {
+				s.ResourceHealthResourceIDs().Return(nil)
+			},
+		},
+		{
+			name: "all resources are available or unknown",
+			expect: func(s *mock_resourcehealth.MockResourceHealthScopeMockRecorder, m *mock_resourcehealth.MockclientMockRecorder) {
+				s.ResourceHealthResourceIDs().Return([]string{fakeAPIServerLBID, fakeNodeLBID})
+				m.GetByResource(gomockinternal.AContext(), fakeAPIServerLBID).Return(availabilityStatus(resourcehealth.Available, "This load balancer is running normally."), nil)
+				m.GetByResource(gomockinternal.AContext(), fakeNodeLBID).Return(availabilityStatus(resourcehealth.Unknown, "We are currently unable to determine the health of this load balancer."), nil)
+				s.SetResourcesHealthy()
+			},
+		},
+		{
+			name: "a resource is unavailable",
+			expect: func(s *mock_resourcehealth.MockResourceHealthScopeMockRecorder, m *mock_resourcehealth.MockclientMockRecorder) {
+				s.ResourceHealthResourceIDs().Return([]string{fakeAPIServerLBID, fakeNodeLBID})
+				m.GetByResource(gomockinternal.AContext(), fakeAPIServerLBID).Return(availabilityStatus(resourcehealth.Unavailable, "The data path of this load balancer is down."), nil)
+				m.GetByResource(gomockinternal.AContext(), fakeNodeLBID).Return(resourcehealth.AvailabilityStatus{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusForbidden}, "Forbidden"))
+				s.SetResourcesNotHealthy(infrav1.UnhealthyResourcesReason, clusterv1.ConditionSeverityWarning,
+					"Azure Resource Health reports unhealthy resources: %s", fakeAPIServerLBID+" is Unavailable: The data path of this load balancer is down.")
+			},
+		},
+		{
+			name: "resource health can't be retrieved",
+			expect: func(s *mock_resourcehealth.MockResourceHealthScopeMockRecorder, m *mock_resourcehealth.MockclientMockRecorder) {
+				s.ResourceHealthResourceIDs().Return([]string{fakeAPIServerLBID})
+				m.GetByResource(gomockinternal.AContext(), fakeAPIServerLBID).Return(resourcehealth.AvailabilityStatus{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusForbidden}, "Forbidden"))
+				s.SetResourcesNotHealthy(infrav1.ResourceHealthCheckFailedReason, clusterv1.ConditionSeverityInfo,
+					"failed to get the health of resources: %s", fakeAPIServerLBID+": #: Forbidden: StatusCode=403")
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_resourcehealth.NewMockResourceHealthScope(mockCtrl)
+			clientMock := mock_resourcehealth.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			g.Expect(s.Reconcile(context.TODO())).To(Succeed())
+		})
+	}
+}
@@ -31,20 +31,29 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-// Cache loads resource SKUs at the beginning of reconcile to expose
-// features available on compute resources. It exposes convenience
-// functionality for trawling Azure SKU capabilities. It may be adapted
-// to periodically refresh data in the background.
+// Cache loads resource SKUs on first use to expose features available
+// on compute resources. It exposes convenience functionality for
+// trawling Azure SKU capabilities. A cache is shared by all the scopes
+// of the same identity and location, and its data is reloaded from
+// Azure once it is older than the cache TTL.
 type Cache struct {
 	client Client
 
 	// location is the Azure location for which this cache stores sku info.
-	// we do lookup once per reconcile for the given cluster/location.
 	location string
 
+	// ttl is the duration after which data is reloaded. It is zero for the static caches, whose data never expires:
+	// the caches loaded from Azure get the positive TTL set with SetCacheTTL.
+	ttl time.Duration
+
+	// mu synchronizes the access to data, as the cache is shared across concurrent reconciles.
+	mu sync.Mutex
+
 	// data is the cached sku information from Azure.
-	// synchronization required if data is cached across reconcile calls, (i.e., refreshed in background as Runnable via mgr.Add(...))
 	data []compute.ResourceSku
+
+	// refreshedAt is the time data was last loaded from Azure.
+	refreshedAt time.Time
 }
 
 // Cacher describes the ability to get and to add items to cache.
@@ -56,25 +65,42 @@ type Cacher interface {
 // NewCacheFunc allows for mocking out the underlying client.
 type NewCacheFunc func(azure.Authorizer, string) *Cache
 
+// DefaultCacheTTL is the default duration after which the cached resource SKUs of a location are reloaded from Azure.
+const DefaultCacheTTL = 24 * time.Hour
+
 var (
 	_           Client = &AzureClient{}
 	doOnce      sync.Once
 	clientCache Cacher
+	cacheTTL    = DefaultCacheTTL
 )
 
+// SetCacheTTL sets the duration after which the cached resource SKUs of a location are reloaded from Azure.
+// It must be called before any cache is created. The TTL must be positive, as the caches loaded from Azure always
+// expire.
+func SetCacheTTL(ttl time.Duration) error {
+	if ttl <= 0 {
+		return errors.Errorf("invalid resource SKU cache TTL %s, expected a positive duration", ttl)
+	}
+	cacheTTL = ttl
+	return nil
+}
+
 // newCache instantiates a cache and initializes its contents.
 func newCache(auth azure.Authorizer, location string) *Cache {
 	return &Cache{
 		client:   NewClient(auth),
 		location: location,
+		ttl:      cacheTTL,
 	}
 }
 
 // GetCache either creates a new SKUs cache or returns an existing one based on the location + Authorizer HashKey().
+// A cache is never shared across identities: its data is loaded with the credentials of the identity that created it.
 func GetCache(auth azure.Authorizer, location string) (*Cache, error) {
 	var err error
 	doOnce.Do(func() {
-		clientCache, err = ttllru.New(128, 24*time.Hour)
+		clientCache, err = ttllru.New(128, cacheTTL)
 	})
 
 	if err != nil {
@@ -110,10 +136,28 @@ func (c *Cache) refresh(ctx context.Context, location string) error {
 	}
 
 	c.data = data
+	c.refreshedAt = time.Now()
 
 	return nil
 }
 
+// load returns the cached data, reloading it from Azure first if it was never loaded or is expired.
+func (c *Cache) load(ctx context.Context) ([]compute.ResourceSku, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.data != nil && (c.ttl == 0 || time.Since(c.refreshedAt) < c.ttl) {
+		cacheHits.WithLabelValues(c.location).Inc()
+		return c.data, nil
+	}
+
+	cacheMisses.WithLabelValues(c.location).Inc()
+	if err := c.refresh(ctx, c.location); err != nil {
+		return nil, err
+	}
+	return c.data, nil
+}
+
 // Get returns a resource SKU with the provided name and category. It
 // returns an error if we could not find a match. We should consider
 // enhancing this function to handle restrictions (e.g. SKU not
@@ -123,13 +167,12 @@ func (c *Cache) Get(ctx context.Context, name string, kind ResourceType) (SKU, e
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.Get")
 	defer done()
 
-	if c.data == nil {
-		if err := c.refresh(ctx, c.location); err != nil {
-			return SKU{}, err
-		}
+	data, err := c.load(ctx)
+	if err != nil {
+		return SKU{}, err
 	}
 
-	for _, sku := range c.data {
+	for _, sku := range data {
 		if sku.Name != nil && *sku.Name == name {
 			return SKU(sku), nil
 		}
@@ -142,14 +185,13 @@ func (c *Cache) Map(ctx context.Context, mapFn func(sku SKU)) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.Map")
 	defer done()
 
-	if c.data == nil {
-		if err := c.refresh(ctx, c.location); err != nil {
-			return err
-		}
+	data, err := c.load(ctx)
+	if err != nil {
+		return err
 	}
 
-	for i := range c.data {
-		val := SKU(c.data[i])
+	for i := range data {
+		val := SKU(data[i])
 		mapFn(val)
 	}
 
@@ -19,11 +19,17 @@ package resourceskus
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-04-01/compute"
 	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus/mock_resourceskus"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 )
 
 func TestCacheGet(t *testing.T) {
@@ -394,3 +400,50 @@ func TestCacheGetZonesWithVMSize(t *testing.T) {
 		})
 	}
 }
+
+func TestCacheRefresh(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	skus := []compute.ResourceSku{{Name: to.StringPtr("foo"), ResourceType: to.StringPtr("bar")}}
+	client := mock_resourceskus.NewMockClient(mockCtrl)
+	client.EXPECT().List(gomockinternal.AContext(), "location eq 'refresh-test'").Return(skus, nil).Times(2)
+
+	cache := &Cache{
+		client:   client,
+		location: "refresh-test",
+		ttl:      time.Hour,
+	}
+	hits := testutil.ToFloat64(cacheHits.WithLabelValues("refresh-test"))
+	misses := testutil.ToFloat64(cacheMisses.WithLabelValues("refresh-test"))
+
+	// The first lookup loads the SKUs from Azure.
+	_, err := cache.Get(context.Background(), "foo", "bar")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(testutil.ToFloat64(cacheMisses.WithLabelValues("refresh-test"))).To(Equal(misses + 1))
+
+	// The next lookups are served from the cache until it expires.
+	_, err = cache.Get(context.Background(), "foo", "bar")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cache.Map(context.Background(), func(SKU) {})).To(Succeed())
+	g.Expect(testutil.ToFloat64(cacheHits.WithLabelValues("refresh-test"))).To(Equal(hits + 2))
+
+	// An expired cache is reloaded from Azure.
+	cache.refreshedAt = time.Now().Add(-2 * time.Hour)
+	_, err = cache.Get(context.Background(), "foo", "bar")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(testutil.ToFloat64(cacheMisses.WithLabelValues("refresh-test"))).To(Equal(misses + 2))
+	g.Expect(time.Since(cache.refreshedAt)).To(BeNumerically("<", time.Minute))
+}
+
+func TestSetCacheTTL(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(SetCacheTTL(0)).To(MatchError("invalid resource SKU cache TTL 0s, expected a positive duration"))
+	g.Expect(cacheTTL).To(Equal(DefaultCacheTTL))
+
+	defer func() { cacheTTL = DefaultCacheTTL }()
+	g.Expect(SetCacheTTL(time.Hour)).To(Succeed())
+	g.Expect(cacheTTL).To(Equal(time.Hour))
+}
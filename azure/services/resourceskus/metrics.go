@@ -0,0 +1,44 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceskus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	cacheHits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "capz_resource_sku_cache_hits_total",
+			Help: "Number of resource SKU lookups served from the cache, by location.",
+		},
+		[]string{"location"},
+	)
+	cacheMisses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "capz_resource_sku_cache_misses_total",
+			Help: "Number of resource SKU lookups that loaded the SKUs from Azure because the cache was empty or expired, by location.",
+		},
+		[]string{"location"},
+	)
+)
+
+func init() {
+	// Register the cache metrics with the controller-runtime registry, which is served on the metrics endpoint of the manager.
+	metrics.Registry.MustRegister(cacheHits, cacheMisses)
+}
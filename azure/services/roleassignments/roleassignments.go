@@ -31,7 +31,10 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const azureBuiltInContributorID = "b24988ac-6180-42a0-ab88-20f7382dd24c"
+const (
+	azureBuiltInContributorID = "b24988ac-6180-42a0-ab88-20f7382dd24c"
+	serviceName               = "roleassignments"
+)
 
 // RoleAssignmentScope defines the scope interface for a role assignment service.
 type RoleAssignmentScope interface {
@@ -93,6 +96,9 @@ func (s *Service) reconcileVM(ctx context.Context, roleSpec azure.RoleAssignment
 	if !ok {
 		return errors.Errorf("%T is not a compute.VirtualMachine", resultVMIface)
 	}
+	if err := azure.CheckEnvironment(s.Scope, resultVM, "assign a role to", spec.Name, spec.ResourceGroup, serviceName); err != nil {
+		return err
+	}
 
 	err = s.assignRole(ctx, roleSpec.Name, resultVM.Identity.PrincipalID)
 	if err != nil {
@@ -108,10 +114,14 @@ func (s *Service) reconcileVMSS(ctx context.Context, roleSpec azure.RoleAssignme
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "roleassignments.Service.reconcileVMSS")
 	defer done()
 
-	resultVMSS, err := s.virtualMachineScaleSetClient.Get(ctx, s.Scope.ResourceGroup(), roleSpec.MachineName)
+	resourceGroup := s.Scope.ResourceGroup()
+	resultVMSS, err := s.virtualMachineScaleSetClient.Get(ctx, resourceGroup, roleSpec.MachineName)
 	if err != nil {
 		return errors.Wrap(err, "cannot get VMSS to assign role to system assigned identity")
 	}
+	if err := azure.CheckEnvironment(s.Scope, resultVMSS, "assign a role to", roleSpec.MachineName, resourceGroup, serviceName); err != nil {
+		return err
+	}
 
 	err = s.assignRole(ctx, roleSpec.Name, resultVMSS.Identity.PrincipalID)
 	if err != nil {
@@ -139,6 +139,49 @@ func TestReconcileRoleAssignmentsVM(t *testing.T) {
 		})
 	}
 }
+
+// environmentRoleAssignmentScope is a RoleAssignmentScope that expects the resources to belong to the dev environment.
+type environmentRoleAssignmentScope struct {
+	*mock_roleassignments.MockRoleAssignmentScope
+}
+
+func (s environmentRoleAssignmentScope) ExpectedEnvironment() string {
+	return "dev"
+}
+
+func TestReconcileRoleAssignmentsVMEnvironment(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scopeMock := mock_roleassignments.NewMockRoleAssignmentScope(mockCtrl)
+	clientMock := mock_roleassignments.NewMockclient(mockCtrl)
+	vmGetterMock := mock_async.NewMockGetter(mockCtrl)
+
+	scopeMock.EXPECT().ResourceGroup().Return("my-rg")
+	scopeMock.EXPECT().RoleAssignmentSpecs().Return([]azure.RoleAssignmentSpec{
+		{
+			MachineName:  "test-vm",
+			ResourceType: azure.VirtualMachine,
+		},
+	})
+	vmGetterMock.EXPECT().Get(gomockinternal.AContext(), &fakeVMSpec).Return(compute.VirtualMachine{
+		Location: to.StringPtr("westus"),
+		Tags:     map[string]*string{"environment": to.StringPtr("prod")},
+		Identity: &compute.VirtualMachineIdentity{
+			PrincipalID: to.StringPtr("000"),
+		},
+	}, nil)
+
+	s := &Service{
+		Scope:                 environmentRoleAssignmentScope{MockRoleAssignmentScope: scopeMock},
+		client:                clientMock,
+		virtualMachinesGetter: vmGetterMock,
+	}
+
+	err := s.Reconcile(context.TODO())
+	g.Expect(err).To(MatchError(ContainSubstring(`refusing to assign a role to resource my-rg/test-vm (service: roleassignments): its environment tag is "prod", expected "dev"`)))
+}
+
 func TestReconcileRoleAssignmentsVMSS(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -20,13 +20,20 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 )
 
+// defaultRouteAddressPrefix is the address prefix of the default route of a route table.
+const defaultRouteAddressPrefix = "0.0.0.0/0"
+
 // RouteTableSpec defines the specification for a route table.
 type RouteTableSpec struct {
 	Name          string
 	ResourceGroup string
 	Location      string
+	// FirewallPrivateIP is the private IP of the firewall the default route of the route table sends the traffic to.
+	// The route table has no such route when empty.
+	FirewallPrivateIP string
 }
 
 // ResourceName returns the name of the route table.
@@ -47,15 +54,60 @@ func (s *RouteTableSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the route table.
 func (s *RouteTableSpec) Parameters(existing interface{}) (params interface{}, err error) {
 	if existing != nil {
-		if _, ok := existing.(network.RouteTable); !ok {
+		existingRT, ok := existing.(network.RouteTable)
+		if !ok {
 			return nil, errors.Errorf("%T is not a network.RouteTable", existing)
 		}
 		// route table already exists
-		// currently don't support specifying your own routes via spec.
-		return nil, nil
+		// currently don't support specifying your own routes via spec, only the firewall route is reconciled.
+		var routes []network.Route
+		var firewallRoute *network.Route
+		if existingRT.RouteTablePropertiesFormat != nil && existingRT.Routes != nil {
+			for i, route := range *existingRT.Routes {
+				if to.String(route.Name) == azure.FirewallRouteName {
+					firewallRoute = &(*existingRT.Routes)[i]
+					continue
+				}
+				routes = append(routes, route)
+			}
+		}
+		switch {
+		case s.FirewallPrivateIP == "" && firewallRoute == nil:
+			return nil, nil
+		case s.FirewallPrivateIP != "" && firewallRoute != nil && isFirewallRoute(*firewallRoute, s.FirewallPrivateIP):
+			return nil, nil
+		case s.FirewallPrivateIP != "":
+			routes = append(routes, s.firewallRoute())
+		}
+		existingRT.Routes = &routes
+		return existingRT, nil
+	}
+	properties := &network.RouteTablePropertiesFormat{}
+	if s.FirewallPrivateIP != "" {
+		properties.Routes = &[]network.Route{s.firewallRoute()}
 	}
 	return network.RouteTable{
 		Location:                   to.StringPtr(s.Location),
-		RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{},
+		RouteTablePropertiesFormat: properties,
 	}, nil
 }
+
+// firewallRoute returns the default route sending the traffic to the firewall.
+func (s *RouteTableSpec) firewallRoute() network.Route {
+	return network.Route{
+		Name: to.StringPtr(azure.FirewallRouteName),
+		RoutePropertiesFormat: &network.RoutePropertiesFormat{
+			AddressPrefix:    to.StringPtr(defaultRouteAddressPrefix),
+			NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+			NextHopIPAddress: to.StringPtr(s.FirewallPrivateIP),
+		},
+	}
+}
+
+// isFirewallRoute returns whether a route is the default route sending the traffic to the firewall.
+func isFirewallRoute(route network.Route, firewallPrivateIP string) bool {
+	return route.RoutePropertiesFormat != nil &&
+		to.String(route.AddressPrefix) == defaultRouteAddressPrefix &&
+		route.NextHopType == network.RouteNextHopTypeVirtualAppliance &&
+		to.String(route.NextHopIPAddress) == firewallPrivateIP
+}
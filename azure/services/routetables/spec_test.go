@@ -0,0 +1,186 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routetables
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+)
+
+func TestParameters(t *testing.T) {
+	firewallSpec := RouteTableSpec{
+		Name:              "my-node-routetable",
+		ResourceGroup:     "my-rg",
+		Location:          "westus",
+		FirewallPrivateIP: "10.100.0.4",
+	}
+	firewallRoute := network.Route{
+		Name: to.StringPtr("default_to_firewall"),
+		RoutePropertiesFormat: &network.RoutePropertiesFormat{
+			AddressPrefix:    to.StringPtr("0.0.0.0/0"),
+			NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+			NextHopIPAddress: to.StringPtr("10.100.0.4"),
+		},
+	}
+	otherRoute := network.Route{
+		Name: to.StringPtr("to-onprem"),
+		RoutePropertiesFormat: &network.RoutePropertiesFormat{
+			AddressPrefix: to.StringPtr("192.168.0.0/16"),
+			NextHopType:   network.RouteNextHopTypeVirtualNetworkGateway,
+		},
+	}
+
+	testcases := []struct {
+		name          string
+		spec          *RouteTableSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "route table does not exist",
+			spec: &RouteTableSpec{
+				Name:          "my-node-routetable",
+				ResourceGroup: "my-rg",
+				Location:      "westus",
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.RouteTable{
+					Location:                   to.StringPtr("westus"),
+					RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{},
+				}))
+			},
+		},
+		{
+			name:     "route table with a firewall route does not exist",
+			spec:     &firewallSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.RouteTable{
+					Location: to.StringPtr("westus"),
+					RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+						Routes: &[]network.Route{firewallRoute},
+					},
+				}))
+			},
+		},
+		{
+			name: "route table exists without a firewall route",
+			spec: &RouteTableSpec{
+				Name:          "my-node-routetable",
+				ResourceGroup: "my-rg",
+				Location:      "westus",
+			},
+			existing: network.RouteTable{
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &[]network.Route{otherRoute},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "route table exists without the firewall route",
+			spec: &firewallSpec,
+			existing: network.RouteTable{
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &[]network.Route{otherRoute},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.RouteTable{}))
+				g.Expect(result.(network.RouteTable).Routes).To(Equal(&[]network.Route{otherRoute, firewallRoute}))
+			},
+		},
+		{
+			name: "route table exists with the firewall route",
+			spec: &firewallSpec,
+			existing: network.RouteTable{
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &[]network.Route{firewallRoute, otherRoute},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "route table exists with a route to another firewall",
+			spec: &RouteTableSpec{
+				Name:              "my-node-routetable",
+				ResourceGroup:     "my-rg",
+				Location:          "westus",
+				FirewallPrivateIP: "10.100.0.5",
+			},
+			existing: network.RouteTable{
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &[]network.Route{firewallRoute},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.RouteTable{}))
+				routes := *result.(network.RouteTable).Routes
+				g.Expect(routes).To(HaveLen(1))
+				g.Expect(routes[0].NextHopIPAddress).To(Equal(to.StringPtr("10.100.0.5")))
+			},
+		},
+		{
+			name: "route table exists with a firewall route to remove",
+			spec: &RouteTableSpec{
+				Name:          "my-node-routetable",
+				ResourceGroup: "my-rg",
+				Location:      "westus",
+			},
+			existing: network.RouteTable{
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &[]network.Route{otherRoute, firewallRoute},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.RouteTable{}))
+				g.Expect(result.(network.RouteTable).Routes).To(Equal(&[]network.Route{otherRoute}))
+			},
+		},
+		{
+			name:          "existing is not a route table",
+			spec:          &firewallSpec,
+			existing:      struct{}{},
+			expectedError: "struct {} is not a network.RouteTable",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				tc.expect(g, result)
+			}
+		})
+	}
+}
@@ -391,6 +391,9 @@ func (s *Service) buildVMSSFromSpec(ctx context.Context, vmssSpec azure.ScaleSet
 				})
 		}
 	}
+	for _, id := range vmssSpec.ServiceLBAddressPoolIDs {
+		backendAddressPools = append(backendAddressPools, compute.SubResource{ID: to.StringPtr(id)})
+	}
 
 	osProfile, err := s.generateOSProfile(ctx, vmssSpec)
 	if err != nil {
@@ -489,6 +492,15 @@ func (s *Service) buildVMSSFromSpec(ctx context.Context, vmssSpec azure.ScaleSet
 		}
 	}
 
+	if vmssSpec.SecurityGroupName != "" {
+		nicConfigs := *vmss.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations
+		for i := range nicConfigs {
+			nicConfigs[i].NetworkSecurityGroup = &compute.SubResource{
+				ID: to.StringPtr(azure.SecurityGroupID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), vmssSpec.SecurityGroupName)),
+			}
+		}
+	}
+
 	tags := infrav1.Build(infrav1.BuildParams{
 		ClusterName: s.Scope.ClusterName(),
 		Lifecycle:   infrav1.ResourceLifecycleOwned,
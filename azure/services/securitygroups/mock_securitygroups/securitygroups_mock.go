@@ -108,6 +108,20 @@ func (mr *MockNSGScopeMockRecorder) AdditionalTags() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdditionalTags", reflect.TypeOf((*MockNSGScope)(nil).AdditionalTags))
 }
 
+// ApplicationSecurityGroups mocks base method.
+func (m *MockNSGScope) ApplicationSecurityGroups() []v1beta1.ApplicationSecurityGroup {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroups")
+	ret0, _ := ret[0].([]v1beta1.ApplicationSecurityGroup)
+	return ret0
+}
+
+// ApplicationSecurityGroups indicates an expected call of ApplicationSecurityGroups.
+func (mr *MockNSGScopeMockRecorder) ApplicationSecurityGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroups", reflect.TypeOf((*MockNSGScope)(nil).ApplicationSecurityGroups))
+}
+
 // Authorizer mocks base method.
 func (m *MockNSGScope) Authorizer() autorest.Authorizer {
 	m.ctrl.T.Helper()
@@ -220,6 +234,20 @@ func (mr *MockNSGScopeMockRecorder) ClusterName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterName", reflect.TypeOf((*MockNSGScope)(nil).ClusterName))
 }
 
+// ControlPlaneAvailabilitySetID mocks base method.
+func (m *MockNSGScope) ControlPlaneAvailabilitySetID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ControlPlaneAvailabilitySetID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ControlPlaneAvailabilitySetID indicates an expected call of ControlPlaneAvailabilitySetID.
+func (mr *MockNSGScopeMockRecorder) ControlPlaneAvailabilitySetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControlPlaneAvailabilitySetID", reflect.TypeOf((*MockNSGScope)(nil).ControlPlaneAvailabilitySetID))
+}
+
 // ControlPlaneRouteTable mocks base method.
 func (m *MockNSGScope) ControlPlaneRouteTable() v1beta1.RouteTable {
 	m.ctrl.T.Helper()
@@ -290,6 +318,20 @@ func (mr *MockNSGScopeMockRecorder) HashKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockNSGScope)(nil).HashKey))
 }
 
+// InternalLBAddressPoolIDs mocks base method.
+func (m *MockNSGScope) InternalLBAddressPoolIDs(arg0 string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InternalLBAddressPoolIDs", arg0)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// InternalLBAddressPoolIDs indicates an expected call of InternalLBAddressPoolIDs.
+func (mr *MockNSGScopeMockRecorder) InternalLBAddressPoolIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InternalLBAddressPoolIDs", reflect.TypeOf((*MockNSGScope)(nil).InternalLBAddressPoolIDs), arg0)
+}
+
 // IsAPIServerPrivate mocks base method.
 func (m *MockNSGScope) IsAPIServerPrivate() bool {
 	m.ctrl.T.Helper()
@@ -360,6 +402,20 @@ func (mr *MockNSGScopeMockRecorder) NSGSpecs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NSGSpecs", reflect.TypeOf((*MockNSGScope)(nil).NSGSpecs))
 }
 
+// NetworkInterfaceSecurityGroupName mocks base method.
+func (m *MockNSGScope) NetworkInterfaceSecurityGroupName(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkInterfaceSecurityGroupName", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// NetworkInterfaceSecurityGroupName indicates an expected call of NetworkInterfaceSecurityGroupName.
+func (mr *MockNSGScopeMockRecorder) NetworkInterfaceSecurityGroupName(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkInterfaceSecurityGroupName", reflect.TypeOf((*MockNSGScope)(nil).NetworkInterfaceSecurityGroupName), arg0)
+}
+
 // NodeSubnets mocks base method.
 func (m *MockNSGScope) NodeSubnets() []v1beta1.SubnetSpec {
 	m.ctrl.T.Helper()
@@ -416,6 +472,18 @@ func (mr *MockNSGScopeMockRecorder) ResourceGroup() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceGroup", reflect.TypeOf((*MockNSGScope)(nil).ResourceGroup))
 }
 
+// SetNetworkInterfaceSecurityGroupID mocks base method.
+func (m *MockNSGScope) SetNetworkInterfaceSecurityGroupID(role, id string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetNetworkInterfaceSecurityGroupID", role, id)
+}
+
+// SetNetworkInterfaceSecurityGroupID indicates an expected call of SetNetworkInterfaceSecurityGroupID.
+func (mr *MockNSGScopeMockRecorder) SetNetworkInterfaceSecurityGroupID(role, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetworkInterfaceSecurityGroupID", reflect.TypeOf((*MockNSGScope)(nil).SetNetworkInterfaceSecurityGroupID), role, id)
+}
+
 // SetSubnet mocks base method.
 func (m *MockNSGScope) SetSubnet(arg0 v1beta1.SubnetSpec) {
 	m.ctrl.T.Helper()
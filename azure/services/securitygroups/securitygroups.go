@@ -23,16 +23,24 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
+const (
+	serviceName = "securitygroups"
+	// maxSecurityRules is the maximum number of security rules of a network security group allowed by Azure.
+	maxSecurityRules = 1000
+)
+
 // NSGScope defines the scope interface for a security groups service.
 type NSGScope interface {
 	azure.ClusterDescriber
 	azure.NetworkDescriber
 	NSGSpecs() []azure.NSGSpec
+	SetNetworkInterfaceSecurityGroupID(role, id string)
 }
 
 // Service provides operations on Azure resources.
@@ -59,9 +67,18 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
-	for _, nsgSpec := range s.Scope.NSGSpecs() {
+	nsgSpecs := s.Scope.NSGSpecs()
+	// The rule count is checked before any security group is updated, so none is left half reconciled.
+	for _, nsgSpec := range nsgSpecs {
+		if err := validateSecurityRuleCount(nsgSpec); err != nil {
+			return azure.WithTerminalError(err)
+		}
+	}
+
+	for _, nsgSpec := range nsgSpecs {
 		securityRules := make([]network.SecurityRule, 0)
 		var etag *string
+		var tags map[string]*string
 
 		existingNSG, err := s.client.Get(ctx, s.Scope.ResourceGroup(), nsgSpec.Name)
 		switch {
@@ -69,28 +86,30 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			return errors.Wrapf(err, "failed to get NSG %s in %s", nsgSpec.Name, s.Scope.ResourceGroup())
 		case err == nil:
 			// security group already exists
+			if err := azure.CheckEnvironment(s.Scope, existingNSG, "adopt", nsgSpec.Name, s.Scope.ResourceGroup(), serviceName); err != nil {
+				return err
+			}
 			// We append the existing NSG etag to the header to ensure we only apply the updates if the NSG has not been modified.
 			etag = existingNSG.Etag
 			// Check if the expected rules are present
-			update := false
-			securityRules = *existingNSG.SecurityRules
-			for _, rule := range nsgSpec.SecurityRules {
-				sdkRule := converters.SecurityRuleToSDK(rule)
-				if !ruleExists(securityRules, sdkRule) {
-					update = true
-					securityRules = append(securityRules, sdkRule)
-				}
-			}
+			var update bool
+			securityRules, tags, update = s.updatedSecurityGroup(existingNSG, nsgSpec)
 			if !update {
 				// Skip update for NSG as the required default rules are present
-				log.V(2).Info("security group exists and no default rules are missing, skipping update", "security group", nsgSpec.Name)
+				log.V(2).Info("security group exists and no default rules nor tags are missing, skipping update", "security group", nsgSpec.Name)
+				s.setNetworkInterfaceSecurityGroupID(nsgSpec)
 				continue
 			}
 		default:
 			log.V(2).Info("creating security group", "security group", nsgSpec.Name)
 			for _, rule := range nsgSpec.SecurityRules {
-				securityRules = append(securityRules, converters.SecurityRuleToSDK(rule))
+				securityRules = append(securityRules, s.securityRuleToSDK(rule))
 			}
+			tags, _ = securityGroupTags(nil, nsgSpec.Tags)
+		}
+		if len(securityRules) > maxSecurityRules {
+			return azure.WithTerminalError(errors.Errorf("security group %s would have %d security rules, including %d rules not managed by CAPZ, which exceeds the limit of %d security rules per security group",
+				nsgSpec.Name, len(securityRules), len(securityRules)-len(nsgSpec.SecurityRules), maxSecurityRules))
 		}
 		sg := network.SecurityGroup{
 			Location: to.StringPtr(s.Scope.Location()),
@@ -98,6 +117,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 				SecurityRules: &securityRules,
 			},
 			Etag: etag,
+			Tags: tags,
 		}
 		err = s.client.CreateOrUpdate(ctx, s.Scope.ResourceGroup(), nsgSpec.Name, sg)
 		if err != nil {
@@ -105,10 +125,179 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		}
 
 		log.V(2).Info("successfully created or updated security group", "security group", nsgSpec.Name)
+		s.setNetworkInterfaceSecurityGroupID(nsgSpec)
 	}
 	return nil
 }
 
+// updatedSecurityGroup returns the security rules and tags the existing security group should have to match the spec,
+// and whether they differ from the existing ones. The rules of the existing security group that are not managed by
+// CAPZ are kept.
+func (s *Service) updatedSecurityGroup(existingNSG network.SecurityGroup, nsgSpec azure.NSGSpec) ([]network.SecurityRule, map[string]*string, bool) {
+	var existingRules []network.SecurityRule
+	if existingNSG.SecurityGroupPropertiesFormat != nil && existingNSG.SecurityRules != nil {
+		existingRules = *existingNSG.SecurityRules
+	}
+	securityRules := s.withoutStaleIntentRules(existingRules, nsgSpec.SecurityRules)
+	tags, tagsChanged := securityGroupTags(existingNSG.Tags, nsgSpec.Tags)
+	update := len(securityRules) != len(existingRules) || tagsChanged
+	for _, rule := range nsgSpec.SecurityRules {
+		sdkRule := s.securityRuleToSDK(rule)
+		if !ruleExists(securityRules, sdkRule) {
+			update = true
+			securityRules = append(securityRules, sdkRule)
+		} else if updateRuleDescription(securityRules, sdkRule) {
+			update = true
+		}
+	}
+	return securityRules, tags, update
+}
+
+// validateSecurityRuleCount returns an error if the security rules of a security group, the ones of the spec and the
+// generated ones, exceed the number of security rules Azure allows in a security group.
+func validateSecurityRuleCount(nsgSpec azure.NSGSpec) error {
+	if len(nsgSpec.SecurityRules) <= maxSecurityRules {
+		return nil
+	}
+	generated := 0
+	for _, rule := range nsgSpec.SecurityRules {
+		if strings.HasPrefix(rule.Name, infrav1.IntentSecurityRuleNamePrefix) || strings.HasPrefix(rule.Name, infrav1.EgressSecurityRuleNamePrefix) {
+			generated++
+		}
+	}
+	return errors.Errorf("security group %s has %d security rules, %d of them generated from its inbound traffic intents and egress policy, which exceeds the limit of %d security rules per security group",
+		nsgSpec.Name, len(nsgSpec.SecurityRules), generated, maxSecurityRules)
+}
+
+// securityGroupTags returns the tags of the existing security group with the tags of the spec, and whether the tags of
+// the spec were missing or had another value. The other tags of the security group, e.g. added by Azure Policy, are
+// kept.
+func securityGroupTags(existing map[string]*string, tags infrav1.Tags) (map[string]*string, bool) {
+	if len(tags) == 0 {
+		return existing, false
+	}
+	merged := make(map[string]*string, len(existing)+len(tags))
+	for key, value := range existing {
+		merged[key] = value
+	}
+	changed := false
+	for key, value := range tags {
+		if current, ok := existing[key]; !ok || to.String(current) != value {
+			changed = true
+		}
+		merged[key] = to.StringPtr(value)
+	}
+	return merged, changed
+}
+
+// setNetworkInterfaceSecurityGroupID records the ID of a security group attached to the network interfaces of the
+// machines of a role.
+func (s *Service) setNetworkInterfaceSecurityGroupID(nsgSpec azure.NSGSpec) {
+	if nsgSpec.NetworkInterfaceRole == "" {
+		return
+	}
+	s.Scope.SetNetworkInterfaceSecurityGroupID(nsgSpec.NetworkInterfaceRole, azure.SecurityGroupID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), nsgSpec.Name))
+}
+
+// Audit reports how the live network security groups differ from the spec, without modifying them.
+func (s *Service) Audit(ctx context.Context) ([]azure.Drift, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "securitygroups.Service.Audit")
+	defer done()
+
+	if !s.Scope.IsVnetManaged() {
+		log.V(4).Info("Skipping network security group audit in custom VNet mode")
+		return nil, nil
+	}
+
+	var drifts []azure.Drift
+	for _, nsgSpec := range s.Scope.NSGSpecs() {
+		existingNSG, err := s.client.Get(ctx, s.Scope.ResourceGroup(), nsgSpec.Name)
+		if azure.ResourceNotFound(err) {
+			drifts = append(drifts, azure.Drift{Type: azure.DriftMissing, ResourceType: "NetworkSecurityGroup", Name: nsgSpec.Name})
+			continue
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "failed to get NSG %s in %s", nsgSpec.Name, s.Scope.ResourceGroup())
+		}
+
+		if _, _, update := s.updatedSecurityGroup(existingNSG, nsgSpec); update {
+			drifts = append(drifts, azure.Drift{Type: azure.DriftModified, ResourceType: "NetworkSecurityGroup", Name: nsgSpec.Name, Detail: "security rules or tags differ from the spec"})
+		}
+	}
+
+	return drifts, nil
+}
+
+// securityRuleToSDK converts a CAPZ security rule to an Azure network security rule, resolving the
+// application security groups it references to the groups of the cluster resource group.
+func (s *Service) securityRuleToSDK(rule infrav1.SecurityRule) network.SecurityRule {
+	sdkRule := converters.SecurityRuleToSDK(rule)
+	sdkRule.SourceApplicationSecurityGroups = s.applicationSecurityGroupRefs(rule.SourceApplicationSecurityGroups)
+	sdkRule.DestinationApplicationSecurityGroups = s.applicationSecurityGroupRefs(rule.DestinationApplicationSecurityGroups)
+	return sdkRule
+}
+
+func (s *Service) applicationSecurityGroupRefs(names []string) *[]network.ApplicationSecurityGroup {
+	if len(names) == 0 {
+		return nil
+	}
+	asgs := make([]network.ApplicationSecurityGroup, len(names))
+	for i, name := range names {
+		asgs[i] = network.ApplicationSecurityGroup{
+			ID: to.StringPtr(azure.ApplicationSecurityGroupID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), name)),
+		}
+	}
+	return &asgs
+}
+
+// withoutStaleIntentRules returns the existing security rules without the rules generated from inbound traffic intents
+// and egress policies that are no longer expected as is, e.g. after an intent was removed or reordered, so that they
+// are replaced by the expected ones.
+func (s *Service) withoutStaleIntentRules(existing []network.SecurityRule, expected infrav1.SecurityRules) []network.SecurityRule {
+	expectedIntentRules := make(map[string]network.SecurityRule, len(expected))
+	for _, rule := range expected {
+		if isGeneratedRuleName(rule.Name) {
+			expectedIntentRules[strings.ToLower(rule.Name)] = s.securityRuleToSDK(rule)
+		}
+	}
+
+	rules := make([]network.SecurityRule, 0, len(existing))
+	for _, rule := range existing {
+		name := to.String(rule.Name)
+		if isGeneratedRuleName(name) {
+			expectedRule, ok := expectedIntentRules[strings.ToLower(name)]
+			if !ok || !isIntentRuleUpToDate(rule, expectedRule) {
+				continue
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// isGeneratedRuleName returns true if the security rule is generated from an inbound traffic intent, from an egress
+// policy or from the intra-cluster traffic.
+func isGeneratedRuleName(name string) bool {
+	name = strings.ToLower(name)
+	return strings.HasPrefix(name, infrav1.IntentSecurityRuleNamePrefix) || strings.HasPrefix(name, infrav1.EgressSecurityRuleNamePrefix) ||
+		strings.HasPrefix(name, infrav1.IntraClusterSecurityRuleNamePrefix)
+}
+
+// isIntentRuleUpToDate returns true if the existing security rule generated from an inbound traffic intent or from an
+// egress policy matches the expected one.
+func isIntentRuleUpToDate(existing, expected network.SecurityRule) bool {
+	if existing.SecurityRulePropertiesFormat == nil {
+		return false
+	}
+	return strings.EqualFold(to.String(existing.SourceAddressPrefix), to.String(expected.SourceAddressPrefix)) &&
+		strings.EqualFold(to.String(existing.DestinationAddressPrefix), to.String(expected.DestinationAddressPrefix)) &&
+		strings.EqualFold(to.String(existing.DestinationPortRange), to.String(expected.DestinationPortRange)) &&
+		existing.Protocol == expected.Protocol &&
+		existing.Access == expected.Access &&
+		existing.Direction == expected.Direction &&
+		to.Int32(existing.Priority) == to.Int32(expected.Priority) &&
+		to.String(existing.Description) == to.String(expected.Description)
+}
+
 func ruleExists(rules []network.SecurityRule, rule network.SecurityRule) bool {
 	for _, existingRule := range rules {
 		if !strings.EqualFold(to.String(existingRule.Name), to.String(rule.Name)) {
@@ -132,6 +321,22 @@ func ruleExists(rules []network.SecurityRule, rule network.SecurityRule) bool {
 	return false
 }
 
+// updateRuleDescription sets the expected description on the existing security rule of the same name, so that the
+// descriptions of the rules follow the spec. It returns true if the description was changed.
+func updateRuleDescription(rules []network.SecurityRule, rule network.SecurityRule) bool {
+	for i := range rules {
+		if !strings.EqualFold(to.String(rules[i].Name), to.String(rule.Name)) || rules[i].SecurityRulePropertiesFormat == nil {
+			continue
+		}
+		if to.String(rules[i].Description) == to.String(rule.Description) {
+			return false
+		}
+		rules[i].Description = rule.Description
+		return true
+	}
+	return false
+}
+
 // Delete deletes the network security group with the provided name.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "securitygroups.Service.Delete")
@@ -143,6 +348,19 @@ func (s *Service) Delete(ctx context.Context) error {
 	}
 
 	for _, nsgSpec := range s.Scope.NSGSpecs() {
+		// Make sure the security group belongs to the expected environment before deleting it.
+		if azure.ExpectedEnvironment(s.Scope) != "" {
+			existingNSG, err := s.client.Get(ctx, s.Scope.ResourceGroup(), nsgSpec.Name)
+			if azure.ResourceNotFound(err) {
+				continue
+			} else if err != nil {
+				return errors.Wrapf(err, "failed to get NSG %s in %s", nsgSpec.Name, s.Scope.ResourceGroup())
+			}
+			if err := azure.CheckEnvironment(s.Scope, existingNSG, "delete", nsgSpec.Name, s.Scope.ResourceGroup(), serviceName); err != nil {
+				return err
+			}
+		}
+
 		log.V(2).Info("deleting security group", "security group", nsgSpec.Name)
 		err := s.client.Delete(ctx, s.Scope.ResourceGroup(), nsgSpec.Name)
 		if err != nil && azure.ResourceNotFound(err) {
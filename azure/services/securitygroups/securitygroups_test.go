@@ -18,7 +18,10 @@ package securitygroups
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
@@ -28,6 +31,7 @@ import (
 	. "github.com/onsi/gomega"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups/mock_securitygroups"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 )
@@ -220,6 +224,430 @@ func TestReconcileSecurityGroups(t *testing.T) {
 					Name: to.StringPtr("nsg-two"),
 				}, nil)
 			},
+		}, {
+			name: "outdated description of an existing security rule is updated",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				s.NSGSpecs().Return([]azure.NSGSpec{
+					{
+						Name: "nsg-one",
+						SecurityRules: infrav1.SecurityRules{
+							{
+								Name:             "allow_https",
+								Description:      "Allow HTTPS from the office, ticket SEC-42",
+								Protocol:         infrav1.SecurityGroupProtocolTCP,
+								Priority:         400,
+								SourcePorts:      to.StringPtr("*"),
+								DestinationPorts: to.StringPtr("443"),
+								Source:           to.StringPtr("*"),
+								Destination:      to.StringPtr("*"),
+								Direction:        infrav1.SecurityRuleDirectionInbound,
+							},
+						},
+					},
+				})
+				s.IsVnetManaged().AnyTimes().Return(true)
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("test-location")
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").Return(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{
+							{
+								SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
+									Description:              to.StringPtr("Allow HTTPS"),
+									Protocol:                 network.SecurityRuleProtocolTCP,
+									SourcePortRange:          to.StringPtr("*"),
+									DestinationPortRange:     to.StringPtr("443"),
+									SourceAddressPrefix:      to.StringPtr("*"),
+									DestinationAddressPrefix: to.StringPtr("*"),
+									Priority:                 to.Int32Ptr(400),
+									Access:                   network.SecurityRuleAccessAllow,
+									Direction:                network.SecurityRuleDirectionInbound,
+								},
+								Name: to.StringPtr("allow_https"),
+							},
+						},
+					},
+					Etag: to.StringPtr("test-etag"),
+					Name: to.StringPtr("nsg-one"),
+				}, nil)
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "nsg-one", gomockinternal.DiffEq(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{
+							{
+								SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
+									Description:              to.StringPtr("Allow HTTPS from the office, ticket SEC-42"),
+									Protocol:                 network.SecurityRuleProtocolTCP,
+									SourcePortRange:          to.StringPtr("*"),
+									DestinationPortRange:     to.StringPtr("443"),
+									SourceAddressPrefix:      to.StringPtr("*"),
+									DestinationAddressPrefix: to.StringPtr("*"),
+									Priority:                 to.Int32Ptr(400),
+									Access:                   network.SecurityRuleAccessAllow,
+									Direction:                network.SecurityRuleDirectionInbound,
+								},
+								Name: to.StringPtr("allow_https"),
+							},
+						},
+					},
+					Etag:     to.StringPtr("test-etag"),
+					Location: to.StringPtr("test-location"),
+				}))
+			},
+		}, {
+			name: "missing compliance tags are added to an existing security group",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				s.NSGSpecs().Return([]azure.NSGSpec{
+					{
+						Name:          "nsg-one",
+						SecurityRules: infrav1.SecurityRules{},
+						Tags:          infrav1.Tags{"dataClassification": "confidential", "complianceScope": "pci"},
+					},
+				})
+				s.IsVnetManaged().AnyTimes().Return(true)
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("test-location")
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").Return(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{},
+					},
+					Etag: to.StringPtr("test-etag"),
+					Name: to.StringPtr("nsg-one"),
+					Tags: map[string]*string{"dataClassification": to.StringPtr("public"), "policy": to.StringPtr("inherited")},
+				}, nil)
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "nsg-one", gomockinternal.DiffEq(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{},
+					},
+					Etag:     to.StringPtr("test-etag"),
+					Location: to.StringPtr("test-location"),
+					Tags: map[string]*string{
+						"dataClassification": to.StringPtr("confidential"),
+						"complianceScope":    to.StringPtr("pci"),
+						"policy":             to.StringPtr("inherited"),
+					},
+				}))
+			},
+		}, {
+			name: "security group with up to date compliance tags is not updated",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				s.NSGSpecs().Return([]azure.NSGSpec{
+					{
+						Name:          "nsg-one",
+						SecurityRules: infrav1.SecurityRules{},
+						Tags:          infrav1.Tags{"dataClassification": "confidential"},
+					},
+				})
+				s.IsVnetManaged().AnyTimes().Return(true)
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").Return(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{},
+					},
+					Name: to.StringPtr("nsg-one"),
+					Tags: map[string]*string{"dataClassification": to.StringPtr("confidential"), "policy": to.StringPtr("inherited")},
+				}, nil)
+			},
+		}, {
+			name: "missing load balancer probe rule is added to an existing security group",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				probeRule := infrav1.SecurityRule{
+					Name:             azure.LoadBalancerProbeSecurityRuleName,
+					Description:      "Allow Azure Load Balancer health probes",
+					Protocol:         infrav1.SecurityGroupProtocolTCP,
+					Priority:         azure.LoadBalancerProbeSecurityRulePriority,
+					SourcePorts:      to.StringPtr("*"),
+					DestinationPorts: to.StringPtr("6443"),
+					Source:           to.StringPtr(azure.AzureLoadBalancerServiceTag),
+					Destination:      to.StringPtr("*"),
+					Direction:        infrav1.SecurityRuleDirectionInbound,
+				}
+				existingRule := network.SecurityRule{
+					SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
+						Description:              to.StringPtr("Allow K8s API Server"),
+						Protocol:                 network.SecurityRuleProtocolTCP,
+						SourcePortRange:          to.StringPtr("*"),
+						DestinationPortRange:     to.StringPtr("6443"),
+						SourceAddressPrefix:      to.StringPtr("*"),
+						DestinationAddressPrefix: to.StringPtr("*"),
+						Priority:                 to.Int32Ptr(2201),
+						Access:                   network.SecurityRuleAccessAllow,
+						Direction:                network.SecurityRuleDirectionInbound,
+					},
+					Name: to.StringPtr("allow_apiserver"),
+				}
+				s.NSGSpecs().Return([]azure.NSGSpec{{Name: "nsg-cp", SecurityRules: infrav1.SecurityRules{probeRule}}})
+				s.IsVnetManaged().Return(true)
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("test-location")
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-cp").Return(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{existingRule},
+					},
+					Etag: to.StringPtr("test-etag"),
+					Name: to.StringPtr("nsg-cp"),
+				}, nil)
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "nsg-cp", gomockinternal.DiffEq(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{existingRule, converters.SecurityRuleToSDK(probeRule)},
+					},
+					Etag:     to.StringPtr("test-etag"),
+					Location: to.StringPtr("test-location"),
+				}))
+			},
+		}, {
+			name: "stale rules generated from inbound traffic intents are replaced",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				intentRule := infrav1.SecurityRule{
+					Name:             "allow_inbound_from_0_0",
+					Description:      "Allow HTTPS from the office",
+					Protocol:         infrav1.SecurityGroupProtocolTCP,
+					Priority:         3000,
+					SourcePorts:      to.StringPtr("*"),
+					DestinationPorts: to.StringPtr("443"),
+					Source:           to.StringPtr("192.168.0.0/16"),
+					Destination:      to.StringPtr("*"),
+					Direction:        infrav1.SecurityRuleDirectionInbound,
+				}
+				staleRule := converters.SecurityRuleToSDK(intentRule)
+				staleRule.SourceAddressPrefix = to.StringPtr("10.0.0.0/8")
+				removedRule := converters.SecurityRuleToSDK(intentRule)
+				removedRule.Name = to.StringPtr("allow_inbound_from_1_0")
+				removedRule.Priority = to.Int32Ptr(3001)
+				userRule := network.SecurityRule{
+					SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
+						Description:              to.StringPtr("Allow K8s API Server"),
+						Protocol:                 network.SecurityRuleProtocolTCP,
+						SourcePortRange:          to.StringPtr("*"),
+						DestinationPortRange:     to.StringPtr("6443"),
+						SourceAddressPrefix:      to.StringPtr("*"),
+						DestinationAddressPrefix: to.StringPtr("*"),
+						Priority:                 to.Int32Ptr(2201),
+						Access:                   network.SecurityRuleAccessAllow,
+						Direction:                network.SecurityRuleDirectionInbound,
+					},
+					Name: to.StringPtr("allow_apiserver"),
+				}
+				s.NSGSpecs().Return([]azure.NSGSpec{{Name: "nsg-node", SecurityRules: infrav1.SecurityRules{intentRule}}})
+				s.IsVnetManaged().Return(true)
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("test-location")
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-node").Return(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{userRule, staleRule, removedRule},
+					},
+					Etag: to.StringPtr("test-etag"),
+					Name: to.StringPtr("nsg-node"),
+				}, nil)
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "nsg-node", gomockinternal.DiffEq(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{userRule, converters.SecurityRuleToSDK(intentRule)},
+					},
+					Etag:     to.StringPtr("test-etag"),
+					Location: to.StringPtr("test-location"),
+				}))
+			},
+		}, {
+			name: "rules generated from an egress policy are removed when the policy changes",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				denyRule := converters.SecurityRuleToSDK(infrav1.SecurityRule{
+					Name:             "egress_deny_all",
+					Description:      "Deny the outbound traffic not allowed explicitly",
+					Protocol:         infrav1.SecurityGroupProtocolAll,
+					Priority:         4096,
+					SourcePorts:      to.StringPtr("*"),
+					DestinationPorts: to.StringPtr("*"),
+					Source:           to.StringPtr("*"),
+					Destination:      to.StringPtr("*"),
+					Direction:        infrav1.SecurityRuleDirectionOutbound,
+					Action:           infrav1.SecurityRuleActionDeny,
+				})
+				userRule := network.SecurityRule{
+					SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
+						Description:              to.StringPtr("Allow K8s API Server"),
+						Protocol:                 network.SecurityRuleProtocolTCP,
+						SourcePortRange:          to.StringPtr("*"),
+						DestinationPortRange:     to.StringPtr("6443"),
+						SourceAddressPrefix:      to.StringPtr("*"),
+						DestinationAddressPrefix: to.StringPtr("*"),
+						Priority:                 to.Int32Ptr(2201),
+						Access:                   network.SecurityRuleAccessAllow,
+						Direction:                network.SecurityRuleDirectionInbound,
+					},
+					Name: to.StringPtr("allow_apiserver"),
+				}
+				s.NSGSpecs().Return([]azure.NSGSpec{{Name: "nsg-node"}})
+				s.IsVnetManaged().Return(true)
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("test-location")
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-node").Return(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{userRule, denyRule},
+					},
+					Etag: to.StringPtr("test-etag"),
+					Name: to.StringPtr("nsg-node"),
+				}, nil)
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "nsg-node", gomockinternal.DiffEq(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{userRule},
+					},
+					Etag:     to.StringPtr("test-etag"),
+					Location: to.StringPtr("test-location"),
+				}))
+			},
+		}, {
+			name: "intra-cluster rules are kept when up to date and replaced when their address range changes",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				inboundRule := infrav1.SecurityRule{
+					Name:             "allow_intra_cluster_inbound_0",
+					Description:      "Allow intra-cluster traffic from 10.0.0.0/8",
+					Protocol:         infrav1.SecurityGroupProtocolAll,
+					Priority:         2300,
+					SourcePorts:      to.StringPtr("*"),
+					DestinationPorts: to.StringPtr("*"),
+					Source:           to.StringPtr("10.0.0.0/8"),
+					Destination:      to.StringPtr("*"),
+					Direction:        infrav1.SecurityRuleDirectionInbound,
+				}
+				outboundRule := infrav1.SecurityRule{
+					Name:             "allow_intra_cluster_outbound_0",
+					Description:      "Allow intra-cluster traffic to 10.0.0.0/8",
+					Protocol:         infrav1.SecurityGroupProtocolAll,
+					Priority:         2300,
+					SourcePorts:      to.StringPtr("*"),
+					DestinationPorts: to.StringPtr("*"),
+					Source:           to.StringPtr("*"),
+					Destination:      to.StringPtr("10.0.0.0/8"),
+					Direction:        infrav1.SecurityRuleDirectionOutbound,
+				}
+				staleOutboundRule := converters.SecurityRuleToSDK(outboundRule)
+				staleOutboundRule.Description = to.StringPtr("Allow intra-cluster traffic to 10.1.0.0/16")
+				staleOutboundRule.DestinationAddressPrefix = to.StringPtr("10.1.0.0/16")
+				s.NSGSpecs().Return([]azure.NSGSpec{{Name: "nsg-node", SecurityRules: infrav1.SecurityRules{inboundRule, outboundRule}}})
+				s.IsVnetManaged().Return(true)
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("test-location")
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-node").Return(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{converters.SecurityRuleToSDK(inboundRule), staleOutboundRule},
+					},
+					Etag: to.StringPtr("test-etag"),
+					Name: to.StringPtr("nsg-node"),
+				}, nil)
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "nsg-node", gomockinternal.DiffEq(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{converters.SecurityRuleToSDK(inboundRule), converters.SecurityRuleToSDK(outboundRule)},
+					},
+					Etag:     to.StringPtr("test-etag"),
+					Location: to.StringPtr("test-location"),
+				}))
+			},
+		}, {
+			name: "up-to-date intra-cluster rules are left as is",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				inboundRule := infrav1.SecurityRule{
+					Name:             "allow_intra_cluster_inbound_0",
+					Description:      "Allow intra-cluster traffic from 10.0.0.0/8",
+					Protocol:         infrav1.SecurityGroupProtocolAll,
+					Priority:         2300,
+					SourcePorts:      to.StringPtr("*"),
+					DestinationPorts: to.StringPtr("*"),
+					Source:           to.StringPtr("10.0.0.0/8"),
+					Destination:      to.StringPtr("*"),
+					Direction:        infrav1.SecurityRuleDirectionInbound,
+				}
+				s.NSGSpecs().Return([]azure.NSGSpec{{Name: "nsg-node", SecurityRules: infrav1.SecurityRules{inboundRule}}})
+				s.IsVnetManaged().Return(true)
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-node").Return(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{converters.SecurityRuleToSDK(inboundRule)},
+					},
+					Etag: to.StringPtr("test-etag"),
+					Name: to.StringPtr("nsg-node"),
+				}, nil)
+			},
+		}, {
+			name: "security group rules referencing application security groups",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				s.NSGSpecs().Return([]azure.NSGSpec{
+					{
+						Name: "nsg-one",
+						SecurityRules: infrav1.SecurityRules{
+							{
+								Name:                                 "allow-kubelet",
+								Description:                          "allow kubelet from control plane",
+								Protocol:                             infrav1.SecurityGroupProtocolTCP,
+								Priority:                             400,
+								SourcePorts:                          to.StringPtr("*"),
+								DestinationPorts:                     to.StringPtr("10250"),
+								SourceApplicationSecurityGroups:      []string{"control-plane-asg"},
+								DestinationApplicationSecurityGroups: []string{"node-asg"},
+								Direction:                            infrav1.SecurityRuleDirectionInbound,
+							},
+						},
+					},
+				})
+				s.IsVnetManaged().Return(true)
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("test-location")
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").Return(network.SecurityGroup{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "nsg-one", gomockinternal.DiffEq(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{
+							{
+								SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
+									Description:          to.StringPtr("allow kubelet from control plane"),
+									SourcePortRange:      to.StringPtr("*"),
+									DestinationPortRange: to.StringPtr("10250"),
+									SourceApplicationSecurityGroups: &[]network.ApplicationSecurityGroup{
+										{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/control-plane-asg")},
+									},
+									DestinationApplicationSecurityGroups: &[]network.ApplicationSecurityGroup{
+										{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/node-asg")},
+									},
+									Protocol:  "Tcp",
+									Direction: "Inbound",
+									Access:    "Allow",
+									Priority:  to.Int32Ptr(400),
+								},
+								Name: to.StringPtr("allow-kubelet"),
+							},
+						},
+					},
+					Etag:     nil,
+					Location: to.StringPtr("test-location"),
+				}))
+			},
+		}, {
+			name: "security groups of the network interfaces are recorded in the status",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				s.NSGSpecs().Return([]azure.NSGSpec{
+					{
+						Name:                 "controlplane-nic-nsg",
+						SecurityRules:        infrav1.SecurityRules{},
+						NetworkInterfaceRole: infrav1.ControlPlane,
+					},
+					{
+						Name:                 "node-nic-nsg",
+						SecurityRules:        infrav1.SecurityRules{},
+						NetworkInterfaceRole: infrav1.Node,
+					},
+				})
+				s.IsVnetManaged().Return(true)
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("test-location")
+				m.Get(gomockinternal.AContext(), "my-rg", "controlplane-nic-nsg").Return(network.SecurityGroup{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "controlplane-nic-nsg", gomock.Any())
+				s.SetNetworkInterfaceSecurityGroupID(infrav1.ControlPlane, "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/controlplane-nic-nsg")
+				m.Get(gomockinternal.AContext(), "my-rg", "node-nic-nsg").Return(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{},
+					},
+				}, nil)
+				s.SetNetworkInterfaceSecurityGroupID(infrav1.Node, "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/node-nic-nsg")
+			},
 		}, {
 			name: "skipping network security group reconcile in custom VNet mode",
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
@@ -250,6 +678,181 @@ func TestReconcileSecurityGroups(t *testing.T) {
 	}
 }
 
+func TestReconcileSecurityGroupsRuleLimit(t *testing.T) {
+	rules := func(prefix string, count int) infrav1.SecurityRules {
+		securityRules := make(infrav1.SecurityRules, count)
+		for i := range securityRules {
+			securityRules[i] = infrav1.SecurityRule{
+				Name:             fmt.Sprintf("%s%d", prefix, i),
+				Protocol:         infrav1.SecurityGroupProtocolTCP,
+				Priority:         int32(100 + i),
+				SourcePorts:      to.StringPtr("*"),
+				DestinationPorts: to.StringPtr(strconv.Itoa(1024 + i)),
+				Source:           to.StringPtr("*"),
+				Destination:      to.StringPtr("*"),
+				Direction:        infrav1.SecurityRuleDirectionInbound,
+			}
+		}
+		return securityRules
+	}
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder)
+		expectedError string
+	}{
+		{
+			name: "security group at the limit",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				s.IsVnetManaged().Return(true)
+				s.NSGSpecs().Return([]azure.NSGSpec{{Name: "nsg-one", SecurityRules: rules("rule_", maxSecurityRules)}})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.Location().AnyTimes().Return("test-location")
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").Return(network.SecurityGroup{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				m.CreateOrUpdate(gomockinternal.AContext(), "my-rg", "nsg-one", gomock.Any())
+			},
+		},
+		{
+			name: "user and generated rules over the limit",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				s.IsVnetManaged().Return(true)
+				s.NSGSpecs().Return([]azure.NSGSpec{
+					{Name: "nsg-one", SecurityRules: rules("rule_", 2)},
+					{Name: "nsg-two", SecurityRules: append(rules("rule_", 995), rules(infrav1.IntentSecurityRuleNamePrefix, 6)...)},
+				})
+			},
+			expectedError: "security group nsg-two has 1001 security rules, 6 of them generated from its inbound traffic intents and egress policy, which exceeds the limit of 1000 security rules per security group",
+		},
+		{
+			name: "rules not managed by CAPZ push an existing security group over the limit",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				s.IsVnetManaged().Return(true)
+				s.NSGSpecs().Return([]azure.NSGSpec{{Name: "nsg-one", SecurityRules: rules("rule_", 990)}})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				existingRules := make([]network.SecurityRule, 0, 20)
+				for _, rule := range rules("external_", 20) {
+					existingRules = append(existingRules, converters.SecurityRuleToSDK(rule))
+				}
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").Return(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{SecurityRules: &existingRules},
+				}, nil)
+			},
+			expectedError: "security group nsg-one would have 1010 security rules, including 20 rules not managed by CAPZ, which exceeds the limit of 1000 security rules per security group",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_securitygroups.NewMockNSGScope(mockCtrl)
+			clientMock := mock_securitygroups.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+				var reconcileError azure.ReconcileError
+				g.Expect(errors.As(err, &reconcileError)).To(BeTrue())
+				g.Expect(reconcileError.IsTerminal()).To(BeTrue())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestAuditSecurityGroups(t *testing.T) {
+	sshRule := infrav1.SecurityRule{
+		Name:             "allow_ssh",
+		Protocol:         infrav1.SecurityGroupProtocolTCP,
+		Priority:         2200,
+		SourcePorts:      to.StringPtr("*"),
+		DestinationPorts: to.StringPtr("22"),
+		Source:           to.StringPtr("*"),
+		Destination:      to.StringPtr("*"),
+		Direction:        infrav1.SecurityRuleDirectionInbound,
+	}
+	testcases := []struct {
+		name           string
+		expect         func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder)
+		expectedDrifts []azure.Drift
+	}{
+		{
+			name: "security groups match the spec",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				s.IsVnetManaged().Return(true)
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.NSGSpecs().Return([]azure.NSGSpec{{Name: "nsg-one", SecurityRules: infrav1.SecurityRules{sshRule}}})
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").Return(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{converters.SecurityRuleToSDK(sshRule)},
+					},
+				}, nil)
+			},
+		},
+		{
+			name: "missing security group and security group with a missing rule",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				s.IsVnetManaged().Return(true)
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				s.NSGSpecs().Return([]azure.NSGSpec{
+					{Name: "nsg-one", SecurityRules: infrav1.SecurityRules{sshRule}},
+					{Name: "nsg-two"},
+				})
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").Return(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{{Name: to.StringPtr("allow_rdp")}},
+					},
+				}, nil)
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-two").Return(network.SecurityGroup{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+			},
+			expectedDrifts: []azure.Drift{
+				{Type: azure.DriftModified, ResourceType: "NetworkSecurityGroup", Name: "nsg-one", Detail: "security rules or tags differ from the spec"},
+				{Type: azure.DriftMissing, ResourceType: "NetworkSecurityGroup", Name: "nsg-two"},
+			},
+		},
+		{
+			name: "skipping network security group audit in custom VNet mode",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				s.IsVnetManaged().Return(false)
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_securitygroups.NewMockNSGScope(mockCtrl)
+			clientMock := mock_securitygroups.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			drifts, err := s.Audit(context.TODO())
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(drifts).To(Equal(tc.expectedDrifts))
+		})
+	}
+}
+
 func TestDeleteSecurityGroups(t *testing.T) {
 	testcases := []struct {
 		name   string
@@ -335,3 +938,90 @@ func TestDeleteSecurityGroups(t *testing.T) {
 		})
 	}
 }
+
+// environmentNSGScope is an NSGScope that expects the resources to belong to the dev environment.
+type environmentNSGScope struct {
+	*mock_securitygroups.MockNSGScope
+}
+
+func (s environmentNSGScope) ExpectedEnvironment() string {
+	return "dev"
+}
+
+func TestSecurityGroupsEnvironment(t *testing.T) {
+	devNSG := network.SecurityGroup{Location: to.StringPtr("westus"), Tags: map[string]*string{"environment": to.StringPtr("dev")}}
+	prodNSG := network.SecurityGroup{Location: to.StringPtr("westus"), Tags: map[string]*string{"environment": to.StringPtr("prod")}}
+
+	testcases := []struct {
+		name          string
+		delete        bool
+		expectedError string
+		expect        func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder)
+	}{
+		{
+			name:          "refuse to adopt a security group of another environment",
+			expectedError: `refusing to adopt resource my-rg/nsg-one (service: securitygroups): its environment tag is "prod", expected "dev"`,
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				s.IsVnetManaged().Return(true)
+				s.NSGSpecs().Return([]azure.NSGSpec{{Name: "nsg-one"}})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").Return(prodNSG, nil)
+			},
+		},
+		{
+			name:   "delete a security group of the expected environment",
+			delete: true,
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				s.IsVnetManaged().Return(true)
+				s.NSGSpecs().Return([]azure.NSGSpec{{Name: "nsg-one"}, {Name: "nsg-two"}})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").Return(devNSG, nil)
+				m.Delete(gomockinternal.AContext(), "my-rg", "nsg-one")
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-two").Return(network.SecurityGroup{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+			},
+		},
+		{
+			name:          "refuse to delete a security group of another environment",
+			delete:        true,
+			expectedError: `refusing to delete resource my-rg/nsg-one (service: securitygroups): its environment tag is "prod", expected "dev"`,
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, m *mock_securitygroups.MockclientMockRecorder) {
+				s.IsVnetManaged().Return(true)
+				s.NSGSpecs().Return([]azure.NSGSpec{{Name: "nsg-one"}})
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "nsg-one").Return(prodNSG, nil)
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_securitygroups.NewMockNSGScope(mockCtrl)
+			clientMock := mock_securitygroups.NewMockclient(mockCtrl)
+			scopeMock.EXPECT().ClusterName().Return("my-cluster").AnyTimes()
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  environmentNSGScope{MockNSGScope: scopeMock},
+				client: clientMock,
+			}
+
+			var err error
+			if tc.delete {
+				err = s.Delete(context.TODO())
+			} else {
+				err = s.Reconcile(context.TODO())
+			}
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedError)))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
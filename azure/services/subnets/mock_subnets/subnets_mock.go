@@ -109,6 +109,35 @@ func (mr *MockSubnetScopeMockRecorder) AdditionalTags() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdditionalTags", reflect.TypeOf((*MockSubnetScope)(nil).AdditionalTags))
 }
 
+// AnnotationJSON mocks base method.
+func (m *MockSubnetScope) AnnotationJSON(arg0 string) (map[string]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnnotationJSON", arg0)
+	ret0, _ := ret[0].(map[string]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnnotationJSON indicates an expected call of AnnotationJSON.
+func (mr *MockSubnetScopeMockRecorder) AnnotationJSON(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnnotationJSON", reflect.TypeOf((*MockSubnetScope)(nil).AnnotationJSON), arg0)
+}
+
+// ApplicationSecurityGroups mocks base method.
+func (m *MockSubnetScope) ApplicationSecurityGroups() []v1beta1.ApplicationSecurityGroup {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroups")
+	ret0, _ := ret[0].([]v1beta1.ApplicationSecurityGroup)
+	return ret0
+}
+
+// ApplicationSecurityGroups indicates an expected call of ApplicationSecurityGroups.
+func (mr *MockSubnetScopeMockRecorder) ApplicationSecurityGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroups", reflect.TypeOf((*MockSubnetScope)(nil).ApplicationSecurityGroups))
+}
+
 // Authorizer mocks base method.
 func (m *MockSubnetScope) Authorizer() autorest.Authorizer {
 	m.ctrl.T.Helper()
@@ -221,6 +250,20 @@ func (mr *MockSubnetScopeMockRecorder) ClusterName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterName", reflect.TypeOf((*MockSubnetScope)(nil).ClusterName))
 }
 
+// ControlPlaneAvailabilitySetID mocks base method.
+func (m *MockSubnetScope) ControlPlaneAvailabilitySetID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ControlPlaneAvailabilitySetID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ControlPlaneAvailabilitySetID indicates an expected call of ControlPlaneAvailabilitySetID.
+func (mr *MockSubnetScopeMockRecorder) ControlPlaneAvailabilitySetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControlPlaneAvailabilitySetID", reflect.TypeOf((*MockSubnetScope)(nil).ControlPlaneAvailabilitySetID))
+}
+
 // ControlPlaneRouteTable mocks base method.
 func (m *MockSubnetScope) ControlPlaneRouteTable() v1beta1.RouteTable {
 	m.ctrl.T.Helper()
@@ -317,6 +360,20 @@ func (mr *MockSubnetScopeMockRecorder) HashKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockSubnetScope)(nil).HashKey))
 }
 
+// InternalLBAddressPoolIDs mocks base method.
+func (m *MockSubnetScope) InternalLBAddressPoolIDs(arg0 string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InternalLBAddressPoolIDs", arg0)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// InternalLBAddressPoolIDs indicates an expected call of InternalLBAddressPoolIDs.
+func (mr *MockSubnetScopeMockRecorder) InternalLBAddressPoolIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InternalLBAddressPoolIDs", reflect.TypeOf((*MockSubnetScope)(nil).InternalLBAddressPoolIDs), arg0)
+}
+
 // IsAPIServerPrivate mocks base method.
 func (m *MockSubnetScope) IsAPIServerPrivate() bool {
 	m.ctrl.T.Helper()
@@ -373,6 +430,20 @@ func (mr *MockSubnetScopeMockRecorder) Location() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Location", reflect.TypeOf((*MockSubnetScope)(nil).Location))
 }
 
+// NetworkInterfaceSecurityGroupName mocks base method.
+func (m *MockSubnetScope) NetworkInterfaceSecurityGroupName(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkInterfaceSecurityGroupName", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// NetworkInterfaceSecurityGroupName indicates an expected call of NetworkInterfaceSecurityGroupName.
+func (mr *MockSubnetScopeMockRecorder) NetworkInterfaceSecurityGroupName(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkInterfaceSecurityGroupName", reflect.TypeOf((*MockSubnetScope)(nil).NetworkInterfaceSecurityGroupName), arg0)
+}
+
 // NodeSubnets mocks base method.
 func (m *MockSubnetScope) NodeSubnets() []v1beta1.SubnetSpec {
 	m.ctrl.T.Helper()
@@ -453,6 +524,35 @@ func (mr *MockSubnetScopeMockRecorder) SetSubnet(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnet", reflect.TypeOf((*MockSubnetScope)(nil).SetSubnet), arg0)
 }
 
+// SetSubnetIPsAvailable mocks base method.
+func (m *MockSubnetScope) SetSubnetIPsAvailable() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetIPsAvailable")
+}
+
+// SetSubnetIPsAvailable indicates an expected call of SetSubnetIPsAvailable.
+func (mr *MockSubnetScopeMockRecorder) SetSubnetIPsAvailable() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetIPsAvailable", reflect.TypeOf((*MockSubnetScope)(nil).SetSubnetIPsAvailable))
+}
+
+// SetSubnetIPsNotAvailable mocks base method.
+func (m *MockSubnetScope) SetSubnetIPsNotAvailable(arg0 string, arg1 v1beta10.ConditionSeverity, arg2 string, arg3 ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "SetSubnetIPsNotAvailable", varargs...)
+}
+
+// SetSubnetIPsNotAvailable indicates an expected call of SetSubnetIPsNotAvailable.
+func (mr *MockSubnetScopeMockRecorder) SetSubnetIPsNotAvailable(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetIPsNotAvailable", reflect.TypeOf((*MockSubnetScope)(nil).SetSubnetIPsNotAvailable), varargs...)
+}
+
 // Subnet mocks base method.
 func (m *MockSubnetScope) Subnet(arg0 string) v1beta1.SubnetSpec {
 	m.ctrl.T.Helper()
@@ -523,6 +623,20 @@ func (mr *MockSubnetScopeMockRecorder) TenantID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockSubnetScope)(nil).TenantID))
 }
 
+// UpdateAnnotationJSON mocks base method.
+func (m *MockSubnetScope) UpdateAnnotationJSON(arg0 string, arg1 map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnnotationJSON", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAnnotationJSON indicates an expected call of UpdateAnnotationJSON.
+func (mr *MockSubnetScopeMockRecorder) UpdateAnnotationJSON(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnnotationJSON", reflect.TypeOf((*MockSubnetScope)(nil).UpdateAnnotationJSON), arg0, arg1)
+}
+
 // UpdateDeleteStatus mocks base method.
 func (m *MockSubnetScope) UpdateDeleteStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
 	m.ctrl.T.Helper()
@@ -559,6 +673,18 @@ func (mr *MockSubnetScopeMockRecorder) UpdatePutStatus(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePutStatus", reflect.TypeOf((*MockSubnetScope)(nil).UpdatePutStatus), arg0, arg1, arg2)
 }
 
+// UpdateSubnetAvailableIPs mocks base method.
+func (m *MockSubnetScope) UpdateSubnetAvailableIPs(arg0 string, arg1 int32) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateSubnetAvailableIPs", arg0, arg1)
+}
+
+// UpdateSubnetAvailableIPs indicates an expected call of UpdateSubnetAvailableIPs.
+func (mr *MockSubnetScopeMockRecorder) UpdateSubnetAvailableIPs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubnetAvailableIPs", reflect.TypeOf((*MockSubnetScope)(nil).UpdateSubnetAvailableIPs), arg0, arg1)
+}
+
 // UpdateSubnetCIDRs mocks base method.
 func (m *MockSubnetScope) UpdateSubnetCIDRs(arg0 string, arg1 []string) {
 	m.ctrl.T.Helper()
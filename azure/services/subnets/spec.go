@@ -17,6 +17,10 @@ limitations under the License.
 package subnets
 
 import (
+	"math"
+	"net"
+	"strings"
+
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
@@ -37,6 +41,13 @@ type SubnetSpec struct {
 	SecurityGroupName string
 	Role              infrav1.SubnetRole
 	NatGatewayName    string
+	FreeIPsThreshold  *int32
+	// DisablePrivateEndpointNetworkPolicies disables the private endpoint network policies of the subnet, so that it
+	// can host private endpoints.
+	DisablePrivateEndpointNetworkPolicies bool
+	// ServiceDelegation is the service the subnet is delegated to, e.g. Microsoft.ContainerService/managedClusters
+	// for the pod subnet of Azure CNI.
+	ServiceDelegation string
 }
 
 // ResourceName returns the name of the subnet.
@@ -57,11 +68,35 @@ func (s *SubnetSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the subnet.
 func (s *SubnetSpec) Parameters(existing interface{}) (parameters interface{}, err error) {
 	if existing != nil {
-		_, ok := existing.(network.Subnet)
+		existingSubnet, ok := existing.(network.Subnet)
 		if !ok {
 			return nil, errors.Errorf("%T is not a network.Subnet", existing)
 		}
 
+		// The private endpoint network policies of a subnet of a managed vnet are disabled, and its delegation added,
+		// in place, the rest of the existing subnet is kept as is.
+		if !s.IsVNetManaged || existingSubnet.SubnetPropertiesFormat == nil {
+			return nil, nil
+		}
+		var update bool
+		if s.DisablePrivateEndpointNetworkPolicies &&
+			existingSubnet.PrivateEndpointNetworkPolicies != network.VirtualNetworkPrivateEndpointNetworkPoliciesDisabled {
+			existingSubnet.PrivateEndpointNetworkPolicies = network.VirtualNetworkPrivateEndpointNetworkPoliciesDisabled
+			update = true
+		}
+		if s.ServiceDelegation != "" && !s.isDelegated(existingSubnet) {
+			delegations := []network.Delegation{}
+			if existingSubnet.Delegations != nil {
+				delegations = *existingSubnet.Delegations
+			}
+			delegations = append(delegations, s.delegation())
+			existingSubnet.Delegations = &delegations
+			update = true
+		}
+		if update {
+			return existingSubnet, nil
+		}
+
 		return nil, nil
 	}
 
@@ -80,6 +115,14 @@ func (s *SubnetSpec) Parameters(existing interface{}) (parameters interface{}, e
 		}
 	}
 
+	if s.DisablePrivateEndpointNetworkPolicies {
+		subnetProperties.PrivateEndpointNetworkPolicies = network.VirtualNetworkPrivateEndpointNetworkPoliciesDisabled
+	}
+
+	if s.ServiceDelegation != "" {
+		subnetProperties.Delegations = &[]network.Delegation{s.delegation()}
+	}
+
 	if s.RouteTableName != "" {
 		subnetProperties.RouteTable = &network.RouteTable{
 			ID: to.StringPtr(azure.RouteTableID(s.SubscriptionID, s.ResourceGroup, s.RouteTableName)),
@@ -102,3 +145,73 @@ func (s *SubnetSpec) Parameters(existing interface{}) (parameters interface{}, e
 		SubnetPropertiesFormat: &subnetProperties,
 	}, nil
 }
+
+// delegation returns the delegation of the subnet to its service.
+func (s *SubnetSpec) delegation() network.Delegation {
+	return network.Delegation{
+		Name: to.StringPtr(strings.ReplaceAll(s.ServiceDelegation, "/", ".")),
+		ServiceDelegationPropertiesFormat: &network.ServiceDelegationPropertiesFormat{
+			ServiceName: to.StringPtr(s.ServiceDelegation),
+		},
+	}
+}
+
+// isDelegated returns true if the existing subnet is delegated to the service of the spec.
+func (s *SubnetSpec) isDelegated(existing network.Subnet) bool {
+	if existing.SubnetPropertiesFormat == nil || existing.Delegations == nil {
+		return false
+	}
+	for _, delegation := range *existing.Delegations {
+		if delegation.ServiceDelegationPropertiesFormat != nil &&
+			strings.EqualFold(to.String(delegation.ServiceName), s.ServiceDelegation) {
+			return true
+		}
+	}
+	return false
+}
+
+// azureReservedIPs is the number of IP addresses Azure reserves in each address prefix of a subnet: the network
+// address, the default gateway, two addresses mapping the Azure DNS IPs and the broadcast address.
+const azureReservedIPs = 5
+
+// availableIPs returns the number of IP addresses that are neither reserved by Azure nor allocated in the subnet,
+// capped to the maximum of an int32 for the address prefixes too large to run out of addresses, e.g. IPv6 ones.
+func availableIPs(subnet network.Subnet) (int32, error) {
+	props := subnet.SubnetPropertiesFormat
+	if props == nil {
+		return 0, nil
+	}
+
+	var prefixes []string
+	if props.AddressPrefix != nil {
+		prefixes = []string{to.String(props.AddressPrefix)}
+	} else if props.AddressPrefixes != nil {
+		prefixes = to.StringSlice(props.AddressPrefixes)
+	}
+
+	var total int64
+	for _, prefix := range prefixes {
+		_, ipNet, err := net.ParseCIDR(prefix)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to parse address prefix %s of subnet %s", prefix, to.String(subnet.Name))
+		}
+		ones, bits := ipNet.Mask.Size()
+		if bits-ones >= 31 {
+			return math.MaxInt32, nil
+		}
+		if size := int64(1) << uint(bits-ones); size > azureReservedIPs {
+			total += size - azureReservedIPs
+		}
+	}
+
+	if props.IPConfigurations != nil {
+		total -= int64(len(*props.IPConfigurations))
+	}
+	switch {
+	case total < 0:
+		return 0, nil
+	case total > math.MaxInt32:
+		return math.MaxInt32, nil
+	}
+	return int32(total), nil
+}
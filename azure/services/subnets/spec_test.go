@@ -17,6 +17,7 @@ limitations under the License.
 package subnets
 
 import (
+	"math"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
@@ -193,6 +194,116 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "existing subnet of a managed vnet hosting private endpoints has its network policies enabled",
+			spec: &SubnetSpec{
+				Name:                                  "my-subnet-1",
+				IsVNetManaged:                         true,
+				DisablePrivateEndpointNetworkPolicies: true,
+			},
+			existing: network.Subnet{
+				SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+					AddressPrefix:                  to.StringPtr("10.0.0.0/16"),
+					PrivateEndpointNetworkPolicies: network.VirtualNetworkPrivateEndpointNetworkPoliciesEnabled,
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.Subnet{
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+						AddressPrefix:                  to.StringPtr("10.0.0.0/16"),
+						PrivateEndpointNetworkPolicies: network.VirtualNetworkPrivateEndpointNetworkPoliciesDisabled,
+					},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "new pod subnet is delegated",
+			spec: &SubnetSpec{
+				Name:              "my-pod-subnet",
+				CIDRs:             []string{"10.128.0.0/16"},
+				IsVNetManaged:     true,
+				ServiceDelegation: "Microsoft.ContainerService/managedClusters",
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.Subnet{
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+						AddressPrefix: to.StringPtr("10.128.0.0/16"),
+						Delegations: &[]network.Delegation{{
+							Name: to.StringPtr("Microsoft.ContainerService.managedClusters"),
+							ServiceDelegationPropertiesFormat: &network.ServiceDelegationPropertiesFormat{
+								ServiceName: to.StringPtr("Microsoft.ContainerService/managedClusters"),
+							},
+						}},
+					},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "existing pod subnet of a managed vnet is delegated in place",
+			spec: &SubnetSpec{
+				Name:              "my-pod-subnet",
+				IsVNetManaged:     true,
+				ServiceDelegation: "Microsoft.ContainerService/managedClusters",
+			},
+			existing: network.Subnet{
+				SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+					AddressPrefix: to.StringPtr("10.128.0.0/16"),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.Subnet{
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+						AddressPrefix: to.StringPtr("10.128.0.0/16"),
+						Delegations: &[]network.Delegation{{
+							Name: to.StringPtr("Microsoft.ContainerService.managedClusters"),
+							ServiceDelegationPropertiesFormat: &network.ServiceDelegationPropertiesFormat{
+								ServiceName: to.StringPtr("Microsoft.ContainerService/managedClusters"),
+							},
+						}},
+					},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "existing pod subnet already delegated is not updated",
+			spec: &SubnetSpec{
+				Name:              "my-pod-subnet",
+				IsVNetManaged:     true,
+				ServiceDelegation: "Microsoft.ContainerService/managedClusters",
+			},
+			existing: network.Subnet{
+				SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+					AddressPrefix: to.StringPtr("10.128.0.0/16"),
+					Delegations: &[]network.Delegation{{
+						Name: to.StringPtr("aks-delegation"),
+						ServiceDelegationPropertiesFormat: &network.ServiceDelegationPropertiesFormat{
+							ServiceName: to.StringPtr("Microsoft.ContainerService/managedClusters"),
+						},
+					}},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "existing subnet of a custom vnet hosting private endpoints is not updated",
+			spec: &SubnetSpec{
+				Name:                                  "my-subnet-1",
+				IsVNetManaged:                         false,
+				DisablePrivateEndpointNetworkPolicies: true,
+			},
+			existing: fakeSubnetNotManaged,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -211,3 +322,87 @@ func TestParameters(t *testing.T) {
 		})
 	}
 }
+
+func TestAvailableIPs(t *testing.T) {
+	testcases := []struct {
+		name          string
+		subnet        network.Subnet
+		expected      int32
+		expectedError string
+	}{
+		{
+			name:     "subnet without properties",
+			subnet:   network.Subnet{},
+			expected: 0,
+		},
+		{
+			name: "empty /24 subnet",
+			subnet: network.Subnet{
+				SubnetPropertiesFormat: &network.SubnetPropertiesFormat{AddressPrefix: to.StringPtr("10.0.0.0/24")},
+			},
+			expected: 251,
+		},
+		{
+			name: "/29 subnet, the smallest Azure supports, with allocated addresses",
+			subnet: network.Subnet{
+				SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+					AddressPrefix:    to.StringPtr("10.0.0.0/29"),
+					IPConfigurations: &[]network.IPConfiguration{{ID: to.StringPtr("ipconfig-1")}, {ID: to.StringPtr("ipconfig-2")}},
+				},
+			},
+			expected: 1,
+		},
+		{
+			name: "exhausted subnet",
+			subnet: network.Subnet{
+				SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+					AddressPrefix: to.StringPtr("10.0.0.0/29"),
+					IPConfigurations: &[]network.IPConfiguration{
+						{ID: to.StringPtr("ipconfig-1")}, {ID: to.StringPtr("ipconfig-2")}, {ID: to.StringPtr("ipconfig-3")}, {ID: to.StringPtr("ipconfig-4")},
+					},
+				},
+			},
+			expected: 0,
+		},
+		{
+			name: "5 addresses are reserved in each address prefix",
+			subnet: network.Subnet{
+				SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+					AddressPrefixes:  &[]string{"10.0.0.0/24", "10.0.1.0/28"},
+					IPConfigurations: &[]network.IPConfiguration{{ID: to.StringPtr("ipconfig-1")}},
+				},
+			},
+			expected: 251 + 11 - 1,
+		},
+		{
+			name: "IPv6 address prefix",
+			subnet: network.Subnet{
+				SubnetPropertiesFormat: &network.SubnetPropertiesFormat{AddressPrefixes: &[]string{"10.0.0.0/16", "2001:1234:5678:9abd::/64"}},
+			},
+			expected: math.MaxInt32,
+		},
+		{
+			name: "invalid address prefix",
+			subnet: network.Subnet{
+				Name:                   to.StringPtr("my-subnet"),
+				SubnetPropertiesFormat: &network.SubnetPropertiesFormat{AddressPrefix: to.StringPtr("10.0.0.0")},
+			},
+			expectedError: "failed to parse address prefix 10.0.0.0 of subnet my-subnet: invalid CIDR address: 10.0.0.0",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			available, err := availableIPs(tc.subnet)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(available).To(Equal(tc.expected))
+			}
+		})
+	}
+}
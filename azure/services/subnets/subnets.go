@@ -18,6 +18,10 @@ package subnets
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
@@ -27,6 +31,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 const serviceName = "subnets"
@@ -37,13 +42,22 @@ type SubnetScope interface {
 	azure.AsyncStatusUpdater
 	UpdateSubnetID(string, string)
 	UpdateSubnetCIDRs(string, []string)
+	UpdateSubnetAvailableIPs(string, int32)
+	SetSubnetIPsAvailable()
+	SetSubnetIPsNotAvailable(string, clusterv1.ConditionSeverity, string, ...interface{})
 	SubnetSpecs() []azure.ResourceSpecGetter
+	AnnotationJSON(string) (map[string]interface{}, error)
+	UpdateAnnotationJSON(string, map[string]interface{}) error
 }
 
+// errSubnetInUse is returned for a stale subnet that can't be deleted yet because resources are still attached to it.
+var errSubnetInUse = errors.New("subnet is in use")
+
 // Service provides operations on Azure resources.
 type Service struct {
 	Scope SubnetScope
 	async.Reconciler
+	async.Getter
 }
 
 // New creates a new service.
@@ -52,23 +66,48 @@ func New(scope SubnetScope) *Service {
 	return &Service{
 		Scope:      scope,
 		Reconciler: async.New(scope, Client, Client),
+		Getter:     Client,
 	}
 }
 
 // Reconcile gets/creates/updates a subnet.
 func (s *Service) Reconcile(ctx context.Context) error {
-	ctx, _, done := tele.StartSpanWithLogger(ctx, "subnets.Service.Reconcile")
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "subnets.Service.Reconcile")
 	defer done()
 
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
 	defer cancel()
 
+	// The subnets of a vnet can't be created or updated concurrently: they are child resources of the vnet, and ARM
+	// rejects an operation on a subnet with a conflict while another operation on the same vnet is in progress.
+	// Subnets are thus created one at a time: once an operation is in progress, the remaining subnets are only read to
+	// update their status, and the ones that don't exist yet are created in the next reconcile loops. Conflicts, e.g. with
+	// operations started outside of capz, are retried.
 	// We go through the list of SubnetSpecs to reconcile each one, independently of the result of the previous one.
 	// If multiple errors occur, we return the most pressing one.
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
 	var resultErr error
-	for _, subnetSpec := range s.Scope.SubnetSpecs() {
-		result, err := s.CreateResource(ctx, subnetSpec, serviceName)
+	var operationInProgress bool
+	var checkedCapacity bool
+	var lowSubnets []string
+	subnetSpecs := s.Scope.SubnetSpecs()
+	for _, subnetSpec := range subnetSpecs {
+		var result interface{}
+		var err error
+		if operationInProgress {
+			result, err = s.Get(ctx, subnetSpec)
+			if azure.ResourceNotFound(err) {
+				continue
+			}
+		} else {
+			result, err = s.CreateResource(ctx, subnetSpec, serviceName)
+			if azure.IsOperationNotDoneError(err) {
+				operationInProgress = true
+			} else if azure.ResourceConflict(err) {
+				operationInProgress = true
+				err = azure.WithTransientError(err, reconciler.DefaultReconcilerRequeue)
+			}
+		}
 		if err != nil {
 			if !azure.IsOperationNotDoneError(err) || resultErr == nil {
 				resultErr = err
@@ -87,13 +126,164 @@ func (s *Service) Reconcile(ctx context.Context) error {
 
 			s.Scope.UpdateSubnetID(subnetSpec.ResourceName(), to.String(subnet.ID))
 			s.Scope.UpdateSubnetCIDRs(subnetSpec.ResourceName(), addresses)
+
+			// The capacity check is informational only: a failure to compute it doesn't fail the reconciliation.
+			available, err := availableIPs(subnet)
+			if err != nil {
+				log.Error(err, "failed to compute the available IP addresses of the subnet", "subnet", subnetSpec.ResourceName())
+				continue
+			}
+			s.Scope.UpdateSubnetAvailableIPs(subnetSpec.ResourceName(), available)
+			if spec, ok := subnetSpec.(*SubnetSpec); ok && spec.FreeIPsThreshold != nil {
+				checkedCapacity = true
+				if available < *spec.FreeIPsThreshold {
+					log.Info("subnet is running out of IP addresses", "subnet", spec.Name, "availableIPs", available, "threshold", *spec.FreeIPsThreshold)
+					lowSubnets = append(lowSubnets, spec.Name)
+				}
+			}
 		}
 	}
 
+	if len(lowSubnets) > 0 {
+		s.Scope.SetSubnetIPsNotAvailable(infrav1.SubnetIPsLowReason, clusterv1.ConditionSeverityWarning, "subnets %s have fewer available IP addresses than their free IPs threshold", strings.Join(lowSubnets, ", "))
+	} else if checkedCapacity {
+		s.Scope.SetSubnetIPsAvailable()
+	}
+
+	// Stale subnets are only deleted once all the subnets of the spec are reconciled, for the same reason they are
+	// created one at a time.
+	if resultErr == nil && !operationInProgress {
+		resultErr = s.deleteStaleSubnets(ctx, subnetSpecs)
+	}
+
 	s.Scope.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, resultErr)
 	return resultErr
 }
 
+// deleteStaleSubnets deletes the subnets of a managed virtual network that were removed from the spec since they were
+// last reconciled. A subnet that resources are still attached to is skipped until they are drained, it is retried in
+// the next reconcile loops. The subnets that weren't created by capz are never tracked, and thus never deleted.
+func (s *Service) deleteStaleSubnets(ctx context.Context, subnetSpecs []azure.ResourceSpecGetter) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "subnets.Service.deleteStaleSubnets")
+	defer done()
+
+	if !s.Scope.IsVnetManaged() {
+		return nil
+	}
+
+	lastApplied, err := s.Scope.AnnotationJSON(azure.SubnetsLastAppliedAnnotation)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the last applied subnets")
+	}
+
+	tracked := make(map[string]interface{})
+	for _, subnetSpec := range subnetSpecs {
+		tracked[subnetSpec.ResourceName()] = subnetSpec.OwnerResourceName()
+	}
+
+	var stale []string
+	for name := range lastApplied {
+		if _, ok := tracked[name]; !ok {
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(stale)
+
+	var result error
+	for _, name := range stale {
+		vnet := s.Scope.Vnet()
+		if lastApplied[name] != vnet.Name {
+			// the subnet was tracked in another virtual network, it isn't managed anymore.
+			continue
+		}
+		if result != nil {
+			// the subnets of a vnet can't be deleted concurrently, the remaining ones are deleted in the next loops.
+			tracked[name] = lastApplied[name]
+			continue
+		}
+
+		err := s.deleteStaleSubnet(ctx, &SubnetSpec{Name: name, VNetName: vnet.Name, VNetResourceGroup: vnet.ResourceGroup})
+		switch {
+		case err == nil:
+			log.V(2).Info("deleted stale subnet", "subnet", name)
+			continue
+		case errors.Is(err, errSubnetInUse):
+			log.Info("skipping deletion of stale subnet until it is drained", "subnet", name, "reason", err.Error())
+		default:
+			result = err
+		}
+		tracked[name] = lastApplied[name]
+	}
+
+	if !reflect.DeepEqual(tracked, lastApplied) {
+		if err := s.Scope.UpdateAnnotationJSON(azure.SubnetsLastAppliedAnnotation, tracked); err != nil {
+			return errors.Wrap(err, "failed to update the last applied subnets")
+		}
+	}
+
+	return result
+}
+
+// deleteStaleSubnet deletes a subnet removed from the spec, unless resources, e.g. network interfaces or private
+// endpoints, are still attached to it: it returns an errSubnetInUse error in that case.
+func (s *Service) deleteStaleSubnet(ctx context.Context, spec *SubnetSpec) error {
+	existing, err := s.Get(ctx, spec)
+	if azure.ResourceNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to get stale subnet %s", spec.Name)
+	}
+
+	subnet, ok := existing.(network.Subnet)
+	if !ok {
+		return errors.Errorf("%T is not a network.Subnet", existing)
+	}
+	if usage := subnetUsage(subnet); len(usage) != 0 {
+		return errors.Wrapf(errSubnetInUse, "subnet %s is still used by %s", spec.Name, strings.Join(usage, ", "))
+	}
+
+	return s.DeleteResource(ctx, spec, serviceName)
+}
+
+// subnetUsage describes the resources attached to a subnet, it is empty if the subnet can be deleted.
+func subnetUsage(subnet network.Subnet) []string {
+	if subnet.SubnetPropertiesFormat == nil {
+		return nil
+	}
+
+	var usage []string
+	if subnet.IPConfigurations != nil && len(*subnet.IPConfigurations) != 0 {
+		usage = append(usage, fmt.Sprintf("%d IP configurations", len(*subnet.IPConfigurations)))
+	}
+	if subnet.PrivateEndpoints != nil && len(*subnet.PrivateEndpoints) != 0 {
+		usage = append(usage, fmt.Sprintf("%d private endpoints", len(*subnet.PrivateEndpoints)))
+	}
+	if subnet.ServiceAssociationLinks != nil && len(*subnet.ServiceAssociationLinks) != 0 {
+		usage = append(usage, fmt.Sprintf("%d service association links", len(*subnet.ServiceAssociationLinks)))
+	}
+	if subnet.ResourceNavigationLinks != nil && len(*subnet.ResourceNavigationLinks) != 0 {
+		usage = append(usage, fmt.Sprintf("%d resource navigation links", len(*subnet.ResourceNavigationLinks)))
+	}
+	return usage
+}
+
+// Audit reports how the live subnets differ from the spec, without modifying them.
+func (s *Service) Audit(ctx context.Context) ([]azure.Drift, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "subnets.Service.Audit")
+	defer done()
+
+	var drifts []azure.Drift
+	for _, subnetSpec := range s.Scope.SubnetSpecs() {
+		subnetDrifts, err := async.AuditResource(ctx, s.Getter, subnetSpec, "Subnet")
+		if err != nil {
+			return nil, err
+		}
+		drifts = append(drifts, subnetDrifts...)
+	}
+
+	return drifts, nil
+}
+
 // Delete deletes the subnet with the provided name.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "subnets.Service.Delete")
@@ -18,6 +18,7 @@ package subnets
 
 import (
 	"context"
+	"math"
 	"net/http"
 	"testing"
 
@@ -31,6 +32,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets/mock_subnets"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 var (
@@ -63,6 +65,34 @@ var (
 		},
 	}
 
+	fakeSubnetSpecWithThreshold = SubnetSpec{
+		Name:              "my-subnet-1",
+		ResourceGroup:     "my-rg",
+		SubscriptionID:    "123",
+		CIDRs:             []string{"10.0.0.0/16"},
+		IsVNetManaged:     true,
+		VNetName:          "my-vnet",
+		VNetResourceGroup: "my-rg",
+		RouteTableName:    "my-subnet_route_table",
+		SecurityGroupName: "my-sg-1",
+		Role:              infrav1.SubnetNode,
+		FreeIPsThreshold:  to.Int32Ptr(10),
+	}
+
+	// fakeSmallSubnet is a /28 subnet: 16 addresses, 5 reserved by Azure and 3 allocated to NICs, leaving 8 available.
+	fakeSmallSubnet = network.Subnet{
+		ID:   to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet-1"),
+		Name: to.StringPtr("my-subnet-1"),
+		SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+			AddressPrefix: to.StringPtr("10.0.0.0/28"),
+			IPConfigurations: &[]network.IPConfiguration{
+				{ID: to.StringPtr("nic-1-ipconfig")},
+				{ID: to.StringPtr("nic-2-ipconfig")},
+				{ID: to.StringPtr("nic-3-ipconfig")},
+			},
+		},
+	}
+
 	fakeSubnetSpec2 = SubnetSpec{
 		Name:              "my-subnet-2",
 		ResourceGroup:     "my-rg",
@@ -157,79 +187,217 @@ var (
 		},
 	}
 
+	fakeStaleSubnetSpec = SubnetSpec{
+		Name:              "my-stale-subnet",
+		VNetName:          "my-vnet",
+		VNetResourceGroup: "my-rg",
+	}
+
+	fakeStaleSubnet = network.Subnet{
+		Name:                   to.StringPtr("my-stale-subnet"),
+		SubnetPropertiesFormat: &network.SubnetPropertiesFormat{AddressPrefix: to.StringPtr("10.2.0.0/16")},
+	}
+
 	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error")
+	notFoundError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not Found")
+	conflictError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 409}, "Conflict")
+	notDoneError  = azure.NewOperationNotDoneError(&infrav1.Future{})
 )
 
 func TestReconcileSubnets(t *testing.T) {
 	testcases := []struct {
 		name          string
 		expectedError string
-		expect        func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expect        func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder)
 	}{
 		{
 			name:          "create subnet",
 			expectedError: "",
-			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1})
 
 				r.CreateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(fakeSubnet1, nil)
 				s.UpdateSubnetID(fakeSubnetSpec1.Name, to.String(fakeSubnet1.ID))
 				s.UpdateSubnetCIDRs(fakeSubnetSpec1.Name, []string{to.String(fakeSubnet1.AddressPrefix)})
+				s.UpdateSubnetAvailableIPs(fakeSubnetSpec1.Name, int32(65531))
 
+				s.IsVnetManaged().Return(true)
+				s.AnnotationJSON(azure.SubnetsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.UpdateAnnotationJSON(azure.SubnetsLastAppliedAnnotation, map[string]interface{}{"my-subnet-1": "my-vnet"})
 				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
 			},
 		},
 		{
 			name:          "create multiple subnets",
 			expectedError: "",
-			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeSubnetSpec2})
 
 				r.CreateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(fakeSubnet1, nil)
 				s.UpdateSubnetID(fakeSubnetSpec1.Name, to.String(fakeSubnet1.ID))
 				s.UpdateSubnetCIDRs(fakeSubnetSpec1.Name, []string{to.String(fakeSubnet1.AddressPrefix)})
+				s.UpdateSubnetAvailableIPs(fakeSubnetSpec1.Name, int32(65531))
 
 				r.CreateResource(gomockinternal.AContext(), &fakeSubnetSpec2, serviceName).Return(fakeSubnet2, nil)
 				s.UpdateSubnetID(fakeSubnetSpec2.Name, to.String(fakeSubnet2.ID))
 				s.UpdateSubnetCIDRs(fakeSubnetSpec2.Name, []string{to.String(fakeSubnet2.AddressPrefix)})
+				s.UpdateSubnetAvailableIPs(fakeSubnetSpec2.Name, int32(65531))
 
+				s.IsVnetManaged().Return(true)
+				s.AnnotationJSON(azure.SubnetsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.UpdateAnnotationJSON(azure.SubnetsLastAppliedAnnotation, gomock.Any())
 				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
 			},
 		},
 		{
 			name:          "create ipv6 subnet",
 			expectedError: "",
-			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeIpv6SubnetSpec})
 
 				r.CreateResource(gomockinternal.AContext(), &fakeIpv6SubnetSpec, serviceName).Return(fakeIpv6Subnet, nil)
 				s.UpdateSubnetID(fakeIpv6SubnetSpec.Name, to.String(fakeIpv6Subnet.ID))
 				s.UpdateSubnetCIDRs(fakeIpv6SubnetSpec.Name, to.StringSlice(fakeIpv6Subnet.AddressPrefixes))
+				s.UpdateSubnetAvailableIPs(fakeIpv6SubnetSpec.Name, int32(math.MaxInt32))
 
+				s.IsVnetManaged().Return(true)
+				s.AnnotationJSON(azure.SubnetsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.UpdateAnnotationJSON(azure.SubnetsLastAppliedAnnotation, gomock.Any())
 				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
 			},
 		},
 		{
 			name:          "create multiple ipv6 subnets",
 			expectedError: "",
-			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeIpv6SubnetSpec, &fakeIpv6SubnetSpecCP})
 
 				r.CreateResource(gomockinternal.AContext(), &fakeIpv6SubnetSpec, serviceName).Return(fakeIpv6Subnet, nil)
 				s.UpdateSubnetID(fakeIpv6SubnetSpec.Name, to.String(fakeIpv6Subnet.ID))
 				s.UpdateSubnetCIDRs(fakeIpv6SubnetSpec.Name, to.StringSlice(fakeIpv6Subnet.AddressPrefixes))
+				s.UpdateSubnetAvailableIPs(fakeIpv6SubnetSpec.Name, int32(math.MaxInt32))
 
 				r.CreateResource(gomockinternal.AContext(), &fakeIpv6SubnetSpecCP, serviceName).Return(fakeIpv6SubnetCP, nil)
 				s.UpdateSubnetID(fakeIpv6SubnetSpecCP.Name, to.String(fakeIpv6SubnetCP.ID))
 				s.UpdateSubnetCIDRs(fakeIpv6SubnetSpecCP.Name, to.StringSlice(fakeIpv6SubnetCP.AddressPrefixes))
+				s.UpdateSubnetAvailableIPs(fakeIpv6SubnetSpecCP.Name, int32(math.MaxInt32))
+
+				s.IsVnetManaged().Return(true)
+				s.AnnotationJSON(azure.SubnetsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.UpdateAnnotationJSON(azure.SubnetsLastAppliedAnnotation, gomock.Any())
+				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "subnet with enough available IPs",
+			expectedError: "",
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpecWithThreshold})
+
+				r.CreateResource(gomockinternal.AContext(), &fakeSubnetSpecWithThreshold, serviceName).Return(fakeSubnet1, nil)
+				s.UpdateSubnetID(fakeSubnetSpecWithThreshold.Name, to.String(fakeSubnet1.ID))
+				s.UpdateSubnetCIDRs(fakeSubnetSpecWithThreshold.Name, []string{to.String(fakeSubnet1.AddressPrefix)})
+				s.UpdateSubnetAvailableIPs(fakeSubnetSpecWithThreshold.Name, int32(65531))
+				s.SetSubnetIPsAvailable()
+
+				s.IsVnetManaged().Return(true)
+				s.AnnotationJSON(azure.SubnetsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.UpdateAnnotationJSON(azure.SubnetsLastAppliedAnnotation, gomock.Any())
+				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "subnet running out of IPs",
+			expectedError: "",
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpecWithThreshold, &fakeSubnetSpec2})
+
+				r.CreateResource(gomockinternal.AContext(), &fakeSubnetSpecWithThreshold, serviceName).Return(fakeSmallSubnet, nil)
+				s.UpdateSubnetID(fakeSubnetSpecWithThreshold.Name, to.String(fakeSmallSubnet.ID))
+				s.UpdateSubnetCIDRs(fakeSubnetSpecWithThreshold.Name, []string{to.String(fakeSmallSubnet.AddressPrefix)})
+				s.UpdateSubnetAvailableIPs(fakeSubnetSpecWithThreshold.Name, int32(8))
+
+				r.CreateResource(gomockinternal.AContext(), &fakeSubnetSpec2, serviceName).Return(fakeSubnet2, nil)
+				s.UpdateSubnetID(fakeSubnetSpec2.Name, to.String(fakeSubnet2.ID))
+				s.UpdateSubnetCIDRs(fakeSubnetSpec2.Name, []string{to.String(fakeSubnet2.AddressPrefix)})
+				s.UpdateSubnetAvailableIPs(fakeSubnetSpec2.Name, int32(65531))
+
+				s.SetSubnetIPsNotAvailable(infrav1.SubnetIPsLowReason, clusterv1.ConditionSeverityWarning, gomock.Any(), []interface{}{"my-subnet-1"})
+				s.IsVnetManaged().Return(true)
+				s.AnnotationJSON(azure.SubnetsLastAppliedAnnotation).Return(map[string]interface{}{}, nil)
+				s.UpdateAnnotationJSON(azure.SubnetsLastAppliedAnnotation, gomock.Any())
+				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "delete stale empty subnet",
+			expectedError: "",
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1})
+
+				r.CreateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(fakeSubnet1, nil)
+				s.UpdateSubnetID(fakeSubnetSpec1.Name, to.String(fakeSubnet1.ID))
+				s.UpdateSubnetCIDRs(fakeSubnetSpec1.Name, []string{to.String(fakeSubnet1.AddressPrefix)})
+				s.UpdateSubnetAvailableIPs(fakeSubnetSpec1.Name, int32(65531))
+
+				s.IsVnetManaged().Return(true)
+				s.AnnotationJSON(azure.SubnetsLastAppliedAnnotation).Return(map[string]interface{}{"my-subnet-1": "my-vnet", "my-stale-subnet": "my-vnet"}, nil)
+				s.Vnet().Return(&infrav1.VnetSpec{Name: "my-vnet", ResourceGroup: "my-rg"})
+				g.Get(gomockinternal.AContext(), &fakeStaleSubnetSpec).Return(fakeStaleSubnet, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeStaleSubnetSpec, serviceName).Return(nil)
+				s.UpdateAnnotationJSON(azure.SubnetsLastAppliedAnnotation, map[string]interface{}{"my-subnet-1": "my-vnet"})
+
+				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "skip deletion of stale subnet in use",
+			expectedError: "",
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1})
+
+				r.CreateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(fakeSubnet1, nil)
+				s.UpdateSubnetID(fakeSubnetSpec1.Name, to.String(fakeSubnet1.ID))
+				s.UpdateSubnetCIDRs(fakeSubnetSpec1.Name, []string{to.String(fakeSubnet1.AddressPrefix)})
+				s.UpdateSubnetAvailableIPs(fakeSubnetSpec1.Name, int32(65531))
+
+				s.IsVnetManaged().Return(true)
+				s.AnnotationJSON(azure.SubnetsLastAppliedAnnotation).Return(map[string]interface{}{"my-subnet-1": "my-vnet", "my-stale-subnet": "my-vnet"}, nil)
+				s.Vnet().Return(&infrav1.VnetSpec{Name: "my-vnet", ResourceGroup: "my-rg"})
+				g.Get(gomockinternal.AContext(), &fakeStaleSubnetSpec).Return(network.Subnet{
+					Name: to.StringPtr("my-stale-subnet"),
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+						IPConfigurations: &[]network.IPConfiguration{{ID: to.StringPtr("my-nic-ipconfig")}},
+					},
+				}, nil)
 
 				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
 			},
 		},
+		{
+			name:          "fail to delete stale subnet",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1})
+
+				r.CreateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(fakeSubnet1, nil)
+				s.UpdateSubnetID(fakeSubnetSpec1.Name, to.String(fakeSubnet1.ID))
+				s.UpdateSubnetCIDRs(fakeSubnetSpec1.Name, []string{to.String(fakeSubnet1.AddressPrefix)})
+				s.UpdateSubnetAvailableIPs(fakeSubnetSpec1.Name, int32(65531))
+
+				s.IsVnetManaged().Return(true)
+				s.AnnotationJSON(azure.SubnetsLastAppliedAnnotation).Return(map[string]interface{}{"my-subnet-1": "my-vnet", "my-stale-subnet": "my-vnet"}, nil)
+				s.Vnet().Return(&infrav1.VnetSpec{Name: "my-vnet", ResourceGroup: "my-rg"})
+				g.Get(gomockinternal.AContext(), &fakeStaleSubnetSpec).Return(fakeStaleSubnet, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeStaleSubnetSpec, serviceName).Return(internalError)
+
+				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, internalError)
+			},
+		},
 		{
 			name:          "fail to create subnet",
 			expectedError: "#: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1})
 				r.CreateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(nil, internalError)
 				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, internalError)
@@ -238,17 +406,60 @@ func TestReconcileSubnets(t *testing.T) {
 		{
 			name:          "fail to create subnets",
 			expectedError: "#: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeSubnetSpec2})
 				r.CreateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(nil, internalError)
 
 				r.CreateResource(gomockinternal.AContext(), &fakeSubnetSpec2, serviceName).Return(fakeSubnet2, nil)
 				s.UpdateSubnetID(fakeSubnetSpec2.Name, to.String(fakeSubnet2.ID))
 				s.UpdateSubnetCIDRs(fakeSubnetSpec2.Name, []string{to.String(fakeSubnet2.AddressPrefix)})
+				s.UpdateSubnetAvailableIPs(fakeSubnetSpec2.Name, int32(65531))
 
 				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, internalError)
 			},
 		},
+		{
+			name:          "create multiple subnets one at a time",
+			expectedError: "operation type  on Azure resource / is not done",
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeSubnetSpec2, &fakeIpv6SubnetSpec})
+				r.CreateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(nil, notDoneError)
+
+				g.Get(gomockinternal.AContext(), &fakeSubnetSpec2).Return(nil, notFoundError)
+
+				g.Get(gomockinternal.AContext(), &fakeIpv6SubnetSpec).Return(fakeIpv6Subnet, nil)
+				s.UpdateSubnetID(fakeIpv6SubnetSpec.Name, to.String(fakeIpv6Subnet.ID))
+				s.UpdateSubnetCIDRs(fakeIpv6SubnetSpec.Name, to.StringSlice(fakeIpv6Subnet.AddressPrefixes))
+				s.UpdateSubnetAvailableIPs(fakeIpv6SubnetSpec.Name, int32(math.MaxInt32))
+
+				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, notDoneError)
+			},
+		},
+		{
+			name:          "fail to get subnet while another subnet is being created",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeSubnetSpec2})
+				r.CreateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(nil, notDoneError)
+				g.Get(gomockinternal.AContext(), &fakeSubnetSpec2).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, internalError)
+			},
+		},
+		{
+			name:          "retry subnet creation on vnet conflict",
+			expectedError: "#: Conflict: StatusCode=409. Object will be requeued after 15s",
+			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeSubnetSpec2})
+				r.CreateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(nil, conflictError)
+
+				g.Get(gomockinternal.AContext(), &fakeSubnetSpec2).Return(fakeSubnet2, nil)
+				s.UpdateSubnetID(fakeSubnetSpec2.Name, to.String(fakeSubnet2.ID))
+				s.UpdateSubnetCIDRs(fakeSubnetSpec2.Name, []string{to.String(fakeSubnet2.AddressPrefix)})
+				s.UpdateSubnetAvailableIPs(fakeSubnetSpec2.Name, int32(65531))
+
+				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, gomock.Any())
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -261,12 +472,14 @@ func TestReconcileSubnets(t *testing.T) {
 			defer mockCtrl.Finish()
 			scopeMock := mock_subnets.NewMockSubnetScope(mockCtrl)
 			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			getterMock := mock_async.NewMockGetter(mockCtrl)
 
-			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), getterMock.EXPECT())
 
 			s := &Service{
 				Scope:      scopeMock,
 				Reconciler: asyncMock,
+				Getter:     getterMock,
 			}
 
 			err := s.Reconcile(context.TODO())
@@ -18,8 +18,10 @@ package tags
 
 import (
 	"context"
+	"reflect"
 
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
@@ -27,6 +29,8 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
+const serviceName = "tags"
+
 // TagScope defines the scope interface for a tags service.
 type TagScope interface {
 	azure.Authorizer
@@ -55,6 +59,10 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "tags.Service.Reconcile")
 	defer done()
 
+	// The tags resources inherit from their resource group are tracked in an annotation shared by all of them, which
+	// is only updated once they all are. Updating it for each resource would lose the tags to delete from the others.
+	inheritedLastApplied := make(map[string]map[string]interface{})
+	inheritedDesired := make(map[string]map[string]interface{})
 	for _, tagsSpec := range s.Scope.TagsSpecs() {
 		existingTags, err := s.client.GetAtScope(ctx, tagsSpec.Scope)
 		if err != nil {
@@ -70,33 +78,43 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			continue
 		}
 
-		lastAppliedTags, err := s.Scope.AnnotationJSON(tagsSpec.Annotation)
-		if err != nil {
+		if err := s.checkEnvironment(tagsSpec.Scope, tags); err != nil {
 			return err
 		}
-		changed, createdOrUpdated, deleted, newAnnotation := tagsChanged(lastAppliedTags, tagsSpec.Tags, tags)
-		if changed {
-			log.V(2).Info("Updating tags")
-			if len(createdOrUpdated) > 0 {
-				createdOrUpdatedTags := make(map[string]*string)
-				for k, v := range createdOrUpdated {
-					createdOrUpdatedTags[k] = to.StringPtr(v)
-				}
 
-				if _, err := s.client.UpdateAtScope(ctx, tagsSpec.Scope, resources.TagsPatchResource{Operation: "Merge", Properties: &resources.Tags{Tags: createdOrUpdatedTags}}); err != nil {
-					return errors.Wrap(err, "cannot update tags")
+		if tagsSpec.Inherited {
+			lastAppliedTags, ok := inheritedLastApplied[tagsSpec.Annotation]
+			if !ok {
+				lastAppliedTags, err = s.Scope.AnnotationJSON(tagsSpec.Annotation)
+				if err != nil {
+					return err
 				}
+				inheritedLastApplied[tagsSpec.Annotation] = lastAppliedTags
 			}
-
-			if len(deleted) > 0 {
-				deletedTags := make(map[string]*string)
-				for k, v := range deleted {
-					deletedTags[k] = to.StringPtr(v)
+			createdOrUpdated, deleted := inheritedTagsChanged(lastAppliedTags, tagsSpec.Tags, tags)
+			if len(createdOrUpdated) > 0 || len(deleted) > 0 {
+				log.V(2).Info("Updating inherited tags", "scope", tagsSpec.Scope)
+				if err := s.updateTags(ctx, tagsSpec.Scope, createdOrUpdated, deleted); err != nil {
+					return err
 				}
+			}
+			newAnnotation := make(map[string]interface{}, len(tagsSpec.Tags))
+			for k, v := range tagsSpec.Tags {
+				newAnnotation[k] = v
+			}
+			inheritedDesired[tagsSpec.Annotation] = newAnnotation
+			continue
+		}
 
-				if _, err := s.client.UpdateAtScope(ctx, tagsSpec.Scope, resources.TagsPatchResource{Operation: "Delete", Properties: &resources.Tags{Tags: deletedTags}}); err != nil {
-					return errors.Wrap(err, "cannot update tags")
-				}
+		lastAppliedTags, err := s.Scope.AnnotationJSON(tagsSpec.Annotation)
+		if err != nil {
+			return err
+		}
+		changed, createdOrUpdated, deleted, newAnnotation := tagsChanged(lastAppliedTags, tagsSpec.Tags, tags)
+		if changed {
+			log.V(2).Info("Updating tags")
+			if err := s.updateTags(ctx, tagsSpec.Scope, createdOrUpdated, deleted); err != nil {
+				return err
 			}
 
 			// We also need to update the annotation if anything changed.
@@ -106,6 +124,50 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			log.V(2).Info("successfully updated tags")
 		}
 	}
+
+	for annotation, newAnnotation := range inheritedDesired {
+		if reflect.DeepEqual(newAnnotation, inheritedLastApplied[annotation]) {
+			continue
+		}
+		if err := s.Scope.UpdateAnnotationJSON(annotation, newAnnotation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkEnvironment returns an error if the resource at a scope belongs to another environment than the expected one.
+func (s *Service) checkEnvironment(scope string, tags map[string]*string) error {
+	resourceName, rgName := scope, ""
+	if resource, err := azureautorest.ParseResourceID(scope); err == nil {
+		resourceName, rgName = resource.ResourceName, resource.ResourceGroup
+	}
+	return azure.CheckEnvironmentTags(s.Scope, tags, "update the tags of", resourceName, rgName, serviceName)
+}
+
+// updateTags merges the created or updated tags into the tags at a scope, and deletes the deleted ones.
+func (s *Service) updateTags(ctx context.Context, scope string, createdOrUpdated, deleted map[string]string) error {
+	if len(createdOrUpdated) > 0 {
+		createdOrUpdatedTags := make(map[string]*string)
+		for k, v := range createdOrUpdated {
+			createdOrUpdatedTags[k] = to.StringPtr(v)
+		}
+
+		if _, err := s.client.UpdateAtScope(ctx, scope, resources.TagsPatchResource{Operation: "Merge", Properties: &resources.Tags{Tags: createdOrUpdatedTags}}); err != nil {
+			return errors.Wrap(err, "cannot update tags")
+		}
+	}
+
+	if len(deleted) > 0 {
+		deletedTags := make(map[string]*string)
+		for k, v := range deleted {
+			deletedTags[k] = to.StringPtr(v)
+		}
+
+		if _, err := s.client.UpdateAtScope(ctx, scope, resources.TagsPatchResource{Operation: "Delete", Properties: &resources.Tags{Tags: deletedTags}}); err != nil {
+			return errors.Wrap(err, "cannot update tags")
+		}
+	}
 	return nil
 }
 
@@ -121,6 +183,35 @@ func (s *Service) Delete(ctx context.Context) error {
 	return nil
 }
 
+// inheritedTagsChanged determines which inherited tags to delete and which to add. Inherited tags only fill the gaps
+// in the current tags: a tag with another value than the one last inherited was set on the resource itself and is
+// kept, and a tag no longer inherited is only deleted when it still has the value last inherited.
+func inheritedTagsChanged(lastAppliedTags map[string]interface{}, desiredTags map[string]string, currentTags map[string]*string) (map[string]string, map[string]string) {
+	createdOrUpdated := map[string]string{}
+	deleted := map[string]string{}
+
+	for t, v := range desiredTags {
+		current, ok := currentTags[t]
+		switch {
+		case !ok:
+			createdOrUpdated[t] = v
+		case *current != v && lastAppliedTags[t] == *current:
+			createdOrUpdated[t] = v
+		}
+	}
+
+	for t, v := range lastAppliedTags {
+		if _, ok := desiredTags[t]; ok {
+			continue
+		}
+		if current, ok := currentTags[t]; ok && v == *current {
+			deleted[t] = *current
+		}
+	}
+
+	return createdOrUpdated, deleted
+}
+
 // tagsChanged determines which tags to delete and which to add.
 func tagsChanged(lastAppliedTags map[string]interface{}, desiredTags map[string]string, currentTags map[string]*string) (bool, map[string]string, map[string]string, map[string]interface{}) {
 	// Bool tracking if we found any changed state.
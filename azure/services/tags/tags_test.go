@@ -149,6 +149,78 @@ func TestReconcileTags(t *testing.T) {
 				)
 			},
 		},
+		{
+			name:          "inherited tags fill the gaps of the resources and are tracked once",
+			expectedError: "",
+			expect: func(s *mock_tags.MockTagScopeMockRecorder, m *mock_tags.MockclientMockRecorder) {
+				s.ClusterName().AnyTimes().Return("test-cluster")
+				gomock.InOrder(
+					s.TagsSpecs().Return([]azure.TagsSpec{
+						{
+							Scope:      "/sub/123/vnet",
+							Tags:       map[string]string{"costCenter": "1234", "team": "infra"},
+							Annotation: "inherited-annotation",
+							Inherited:  true,
+						},
+						{
+							Scope:      "/sub/123/lb",
+							Tags:       map[string]string{"costCenter": "1234", "team": "infra"},
+							Annotation: "inherited-annotation",
+							Inherited:  true,
+						},
+					}),
+					m.GetAtScope(gomockinternal.AContext(), "/sub/123/vnet").Return(resources.TagsResource{Properties: &resources.Tags{
+						Tags: map[string]*string{
+							"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned"),
+							"costCenter": to.StringPtr("0000"),
+							"owner":      to.StringPtr("ops"),
+						},
+					}}, nil),
+					s.AnnotationJSON("inherited-annotation").Return(map[string]interface{}{"costCenter": "0000", "owner": "ops"}, nil),
+					m.UpdateAtScope(gomockinternal.AContext(), "/sub/123/vnet", resources.TagsPatchResource{
+						Operation: "Merge",
+						Properties: &resources.Tags{
+							Tags: map[string]*string{
+								"costCenter": to.StringPtr("1234"),
+								"team":       to.StringPtr("infra"),
+							},
+						},
+					}),
+					m.UpdateAtScope(gomockinternal.AContext(), "/sub/123/vnet", resources.TagsPatchResource{
+						Operation: "Delete",
+						Properties: &resources.Tags{
+							Tags: map[string]*string{
+								"owner": to.StringPtr("ops"),
+							},
+						},
+					}),
+					m.GetAtScope(gomockinternal.AContext(), "/sub/123/lb").Return(resources.TagsResource{Properties: &resources.Tags{
+						Tags: map[string]*string{
+							"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned"),
+							"costCenter": to.StringPtr("5678"),
+							"owner":      to.StringPtr("ops"),
+						},
+					}}, nil),
+					m.UpdateAtScope(gomockinternal.AContext(), "/sub/123/lb", resources.TagsPatchResource{
+						Operation: "Merge",
+						Properties: &resources.Tags{
+							Tags: map[string]*string{
+								"team": to.StringPtr("infra"),
+							},
+						},
+					}),
+					m.UpdateAtScope(gomockinternal.AContext(), "/sub/123/lb", resources.TagsPatchResource{
+						Operation: "Delete",
+						Properties: &resources.Tags{
+							Tags: map[string]*string{
+								"owner": to.StringPtr("ops"),
+							},
+						},
+					}),
+					s.UpdateAnnotationJSON("inherited-annotation", map[string]interface{}{"costCenter": "1234", "team": "infra"}),
+				)
+			},
+		},
 		{
 			name:          "error getting existing tags",
 			expectedError: "failed to get existing tags: #: Internal Server Error: StatusCode=500",
@@ -250,6 +322,138 @@ func TestReconcileTags(t *testing.T) {
 	}
 }
 
+// environmentTagScope is a TagScope that expects the resources to belong to the dev environment.
+type environmentTagScope struct {
+	*mock_tags.MockTagScope
+}
+
+func (s environmentTagScope) ExpectedEnvironment() string {
+	return "dev"
+}
+
+func TestReconcileTagsEnvironment(t *testing.T) {
+	const scope = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"
+
+	testcases := []struct {
+		name          string
+		environment   *string
+		expectedError string
+	}{
+		{
+			name:        "update the tags of a resource of the expected environment",
+			environment: to.StringPtr("dev"),
+		},
+		{
+			name: "update the tags of a resource owned by the cluster created before the environment was expected",
+		},
+		{
+			name:          "refuse to update the tags of a resource of another environment",
+			environment:   to.StringPtr("prod"),
+			expectedError: `refusing to update the tags of resource my-rg/my-vnet (service: tags): its environment tag is "prod", expected "dev"`,
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_tags.NewMockTagScope(mockCtrl)
+			clientMock := mock_tags.NewMockclient(mockCtrl)
+
+			existingTags := map[string]*string{
+				"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": to.StringPtr("owned"),
+				"key": to.StringPtr("value"),
+			}
+			if tc.environment != nil {
+				existingTags["environment"] = tc.environment
+			}
+			scopeMock.EXPECT().ClusterName().AnyTimes().Return("test-cluster")
+			scopeMock.EXPECT().TagsSpecs().Return([]azure.TagsSpec{
+				{
+					Scope:      scope,
+					Tags:       map[string]string{"key": "value"},
+					Annotation: "my-annotation",
+				},
+			})
+			clientMock.EXPECT().GetAtScope(gomockinternal.AContext(), scope).Return(resources.TagsResource{Properties: &resources.Tags{
+				Tags: existingTags,
+			}}, nil)
+			if tc.expectedError == "" {
+				scopeMock.EXPECT().AnnotationJSON("my-annotation").Return(map[string]interface{}{"key": "value"}, nil)
+			}
+
+			s := &Service{
+				Scope:  environmentTagScope{MockTagScope: scopeMock},
+				client: clientMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedError)))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestInheritedTagsChanged(t *testing.T) {
+	var tests = map[string]struct {
+		lastAppliedTags          map[string]interface{}
+		desiredTags              map[string]string
+		currentTags              map[string]*string
+		expectedCreatedOrUpdated map[string]string
+		expectedDeleted          map[string]string
+	}{
+		"inherited tag is missing": {
+			desiredTags:              map[string]string{"foo": "hello"},
+			currentTags:              map[string]*string{},
+			expectedCreatedOrUpdated: map[string]string{"foo": "hello"},
+			expectedDeleted:          map[string]string{},
+		},
+		"tag set on the resource takes precedence": {
+			desiredTags:              map[string]string{"foo": "hello"},
+			currentTags:              map[string]*string{"foo": to.StringPtr("mine")},
+			expectedCreatedOrUpdated: map[string]string{},
+			expectedDeleted:          map[string]string{},
+		},
+		"inherited tag value changed": {
+			lastAppliedTags:          map[string]interface{}{"foo": "hello"},
+			desiredTags:              map[string]string{"foo": "goodbye"},
+			currentTags:              map[string]*string{"foo": to.StringPtr("hello")},
+			expectedCreatedOrUpdated: map[string]string{"foo": "goodbye"},
+			expectedDeleted:          map[string]string{},
+		},
+		"inherited tag overridden on the resource since": {
+			lastAppliedTags:          map[string]interface{}{"foo": "hello"},
+			desiredTags:              map[string]string{"foo": "goodbye"},
+			currentTags:              map[string]*string{"foo": to.StringPtr("mine")},
+			expectedCreatedOrUpdated: map[string]string{},
+			expectedDeleted:          map[string]string{},
+		},
+		"tag no longer inherited": {
+			lastAppliedTags:          map[string]interface{}{"foo": "hello", "bar": "world"},
+			desiredTags:              map[string]string{},
+			currentTags:              map[string]*string{"foo": to.StringPtr("hello"), "bar": to.StringPtr("mine")},
+			expectedCreatedOrUpdated: map[string]string{},
+			expectedDeleted:          map[string]string{"foo": "hello"},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			createdOrUpdated, deleted := inheritedTagsChanged(test.lastAppliedTags, test.desiredTags, test.currentTags)
+			g.Expect(createdOrUpdated).To(Equal(test.expectedCreatedOrUpdated))
+			g.Expect(deleted).To(Equal(test.expectedDeleted))
+		})
+	}
+}
+
 func TestTagsChanged(t *testing.T) {
 	g := NewWithT(t)
 
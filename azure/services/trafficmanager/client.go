@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trafficmanager
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/trafficmanager/mgmt/2018-04-01/trafficmanager"
+	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// client wraps go-sdk operations on Traffic Manager endpoints.
+type client interface {
+	DeleteEndpoint(ctx context.Context, resourceGroupName, profileName, endpointName string) error
+}
+
+// azureClient contains the Azure go-sdk Client.
+type azureClient struct {
+	profiles  trafficmanager.ProfilesClient
+	endpoints trafficmanager.EndpointsClient
+}
+
+var _ client = (*azureClient)(nil)
+
+// newClient creates a new Traffic Manager client from subscription ID.
+func newClient(auth azure.Authorizer) *azureClient {
+	return &azureClient{
+		profiles:  newProfilesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		endpoints: newEndpointsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+	}
+}
+
+// newProfilesClient creates a new Traffic Manager profiles client from subscription ID.
+func newProfilesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) trafficmanager.ProfilesClient {
+	profilesClient := trafficmanager.NewProfilesClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&profilesClient.Client, authorizer)
+	return profilesClient
+}
+
+// newEndpointsClient creates a new Traffic Manager endpoints client from subscription ID.
+func newEndpointsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) trafficmanager.EndpointsClient {
+	endpointsClient := trafficmanager.NewEndpointsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&endpointsClient.Client, authorizer)
+	return endpointsClient
+}
+
+// Get gets the specified Traffic Manager profile.
+func (ac *azureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "trafficmanager.azureClient.Get")
+	defer done()
+
+	return ac.profiles.Get(ctx, spec.ResourceGroupName(), spec.ResourceName())
+}
+
+// CreateOrUpdateAsync creates or updates a Traffic Manager profile.
+// Traffic Manager operations are synchronous so a nil future is always returned.
+func (ac *azureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "trafficmanager.azureClient.CreateOrUpdateAsync")
+	defer done()
+
+	profile, ok := parameters.(trafficmanager.Profile)
+	if !ok {
+		return nil, nil, errors.Errorf("%T is not a trafficmanager.Profile", parameters)
+	}
+
+	result, err = ac.profiles.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.ResourceName(), profile)
+	return result, nil, err
+}
+
+// DeleteAsync deletes a Traffic Manager profile.
+// Traffic Manager operations are synchronous so a nil future is always returned.
+func (ac *azureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "trafficmanager.azureClient.DeleteAsync")
+	defer done()
+
+	_, err = ac.profiles.Delete(ctx, spec.ResourceGroupName(), spec.ResourceName())
+	return nil, err
+}
+
+// DeleteEndpoint deletes an external endpoint from a Traffic Manager profile.
+func (ac *azureClient) DeleteEndpoint(ctx context.Context, resourceGroupName, profileName, endpointName string) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "trafficmanager.azureClient.DeleteEndpoint")
+	defer done()
+
+	_, err := ac.endpoints.Delete(ctx, resourceGroupName, profileName, externalEndpointTypeName, endpointName)
+	return err
+}
+
+// IsDone returns true if the long-running operation has completed.
+// Traffic Manager operations are synchronous so there is never an ongoing operation.
+func (ac *azureClient) IsDone(ctx context.Context, future azureautorest.FutureAPI) (isDone bool, err error) {
+	return true, nil
+}
+
+// Result fetches the result of a long-running operation future.
+// Result is a no-op for Traffic Manager profiles as no operation returns a future.
+func (ac *azureClient) Result(ctx context.Context, future azureautorest.FutureAPI, futureType string) (result interface{}, err error) {
+	return nil, nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trafficmanager
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/trafficmanager/mgmt/2018-04-01/trafficmanager"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// externalEndpointType is the resource type of Traffic Manager endpoints pointing at a DNS name.
+	externalEndpointType = "Microsoft.Network/trafficManagerProfiles/externalEndpoints"
+	// externalEndpointTypeName is the endpoint type used by the endpoints API.
+	externalEndpointTypeName = "ExternalEndpoints"
+	// profileLocation is the location of Traffic Manager profiles, which are global resources.
+	profileLocation = "global"
+	dnsTTLInSeconds = 30
+)
+
+// TrafficManagerSpec defines the specification for a Traffic Manager profile.
+type TrafficManagerSpec struct {
+	Name           string
+	ResourceGroup  string
+	ClusterName    string
+	DNSPrefix      string
+	RoutingMethod  infrav1.TrafficRoutingMethod
+	Location       string
+	MonitorPort    int32
+	Endpoints      []clusterv1.APIEndpoint
+	AdditionalTags infrav1.Tags
+}
+
+// ResourceName returns the name of the Traffic Manager profile.
+func (s *TrafficManagerSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *TrafficManagerSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for Traffic Manager profiles.
+func (s *TrafficManagerSpec) OwnerResourceName() string {
+	return ""
+}
+
+// EndpointNames returns the names of the profile endpoints managed for the cluster.
+func (s *TrafficManagerSpec) EndpointNames() []string {
+	names := make([]string, 0, len(s.Endpoints))
+	for _, endpoint := range s.Endpoints {
+		names = append(names, endpointName(endpoint))
+	}
+	return names
+}
+
+// Parameters returns the parameters for the Traffic Manager profile.
+func (s *TrafficManagerSpec) Parameters(existing interface{}) (params interface{}, err error) {
+	desired := s.endpoints()
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.ClusterName,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        to.StringPtr(s.Name),
+		Role:        to.StringPtr(infrav1.APIServerRole),
+		Additional:  s.AdditionalTags,
+	})
+
+	if existing != nil {
+		existingProfile, ok := existing.(trafficmanager.Profile)
+		if !ok {
+			return nil, errors.Errorf("%T is not a trafficmanager.Profile", existing)
+		}
+
+		var existingEndpoints []trafficmanager.Endpoint
+		if existingProfile.ProfileProperties != nil && existingProfile.Endpoints != nil {
+			existingEndpoints = *existingProfile.Endpoints
+		}
+		if existingProfile.ProfileProperties != nil && existingProfile.TrafficRoutingMethod == trafficmanager.TrafficRoutingMethod(s.RoutingMethod) &&
+			hasEndpoints(existingEndpoints, desired) {
+			// Skip update for the profile as it exists with expected values.
+			return nil, nil
+		}
+
+		// Keep the endpoints that are not managed for this cluster, e.g. when adopting an existing profile.
+		for _, endpoint := range existingEndpoints {
+			if findEndpoint(desired, to.String(endpoint.Name)) == nil {
+				desired = append(desired, endpoint)
+			}
+		}
+		// Preserve the tags of an existing profile so that adopted profiles are not marked as owned.
+		tags = converters.MapToTags(existingProfile.Tags)
+	}
+
+	return trafficmanager.Profile{
+		Name:     to.StringPtr(s.Name),
+		Location: to.StringPtr(profileLocation),
+		Tags:     converters.TagsToMap(tags),
+		ProfileProperties: &trafficmanager.ProfileProperties{
+			ProfileStatus:        trafficmanager.ProfileStatusEnabled,
+			TrafficRoutingMethod: trafficmanager.TrafficRoutingMethod(s.RoutingMethod),
+			DNSConfig: &trafficmanager.DNSConfig{
+				RelativeName: to.StringPtr(s.DNSPrefix),
+				TTL:          to.Int64Ptr(dnsTTLInSeconds),
+			},
+			MonitorConfig: &trafficmanager.MonitorConfig{
+				Protocol: trafficmanager.TCP,
+				Port:     to.Int64Ptr(int64(s.MonitorPort)),
+			},
+			Endpoints: &desired,
+		},
+	}, nil
+}
+
+// endpoints returns the desired external endpoints of the profile, one per regional API server endpoint.
+func (s *TrafficManagerSpec) endpoints() []trafficmanager.Endpoint {
+	endpoints := make([]trafficmanager.Endpoint, 0, len(s.Endpoints))
+	for i, apiEndpoint := range s.Endpoints {
+		props := &trafficmanager.EndpointProperties{
+			Target:         to.StringPtr(apiEndpoint.Host),
+			EndpointStatus: trafficmanager.EndpointStatusEnabled,
+		}
+		switch s.RoutingMethod {
+		case infrav1.TrafficRoutingMethodPerformance:
+			props.EndpointLocation = to.StringPtr(s.Location)
+		default:
+			props.Priority = to.Int64Ptr(int64(i + 1))
+		}
+		endpoints = append(endpoints, trafficmanager.Endpoint{
+			Name:               to.StringPtr(endpointName(apiEndpoint)),
+			Type:               to.StringPtr(externalEndpointType),
+			EndpointProperties: props,
+		})
+	}
+	return endpoints
+}
+
+// endpointName returns the name of the profile endpoint for an API server endpoint.
+func endpointName(endpoint clusterv1.APIEndpoint) string {
+	return fmt.Sprintf("%s-%d", strings.ReplaceAll(endpoint.Host, ".", "-"), endpoint.Port)
+}
+
+func findEndpoint(endpoints []trafficmanager.Endpoint, name string) *trafficmanager.Endpoint {
+	for i := range endpoints {
+		if to.String(endpoints[i].Name) == name {
+			return &endpoints[i]
+		}
+	}
+	return nil
+}
+
+// hasEndpoints returns true if all the desired endpoints exist with the same target.
+func hasEndpoints(existing []trafficmanager.Endpoint, desired []trafficmanager.Endpoint) bool {
+	for _, endpoint := range desired {
+		found := findEndpoint(existing, to.String(endpoint.Name))
+		if found == nil || found.EndpointProperties == nil || to.String(found.Target) != to.String(endpoint.Target) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trafficmanager
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/trafficmanager/mgmt/2018-04-01/trafficmanager"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+var (
+	fakeProfileSpec = TrafficManagerSpec{
+		Name:          "my-cluster-tm",
+		ResourceGroup: "my-rg",
+		ClusterName:   "my-cluster",
+		DNSPrefix:     "my-cluster",
+		RoutingMethod: infrav1.TrafficRoutingMethodPriority,
+		Location:      "westus",
+		MonitorPort:   6443,
+		Endpoints: []clusterv1.APIEndpoint{
+			{Host: "my-cluster-westus.westus.cloudapp.azure.com", Port: 6443},
+			{Host: "my-cluster-eastus.eastus.cloudapp.azure.com", Port: 6443},
+		},
+	}
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *TrafficManagerSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "new profile with priority routing",
+			spec:     &fakeProfileSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(trafficmanager.Profile{}))
+				profile := result.(trafficmanager.Profile)
+				g.Expect(profile.Location).To(Equal(to.StringPtr("global")))
+				g.Expect(profile.DNSConfig.RelativeName).To(Equal(to.StringPtr("my-cluster")))
+				g.Expect(profile.MonitorConfig.Port).To(Equal(to.Int64Ptr(6443)))
+				g.Expect(profile.TrafficRoutingMethod).To(Equal(trafficmanager.Priority))
+				g.Expect(*profile.Endpoints).To(HaveLen(2))
+				g.Expect((*profile.Endpoints)[0].Name).To(Equal(to.StringPtr("my-cluster-westus-westus-cloudapp-azure-com-6443")))
+				g.Expect((*profile.Endpoints)[0].Target).To(Equal(to.StringPtr("my-cluster-westus.westus.cloudapp.azure.com")))
+				g.Expect((*profile.Endpoints)[0].Priority).To(Equal(to.Int64Ptr(1)))
+				g.Expect((*profile.Endpoints)[1].Priority).To(Equal(to.Int64Ptr(2)))
+				g.Expect(profile.Tags).To(HaveKeyWithValue("sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster", to.StringPtr("owned")))
+			},
+		},
+		{
+			name: "new profile with performance routing",
+			spec: &TrafficManagerSpec{
+				Name:          "my-cluster-tm",
+				ResourceGroup: "my-rg",
+				ClusterName:   "my-cluster",
+				DNSPrefix:     "my-cluster",
+				RoutingMethod: infrav1.TrafficRoutingMethodPerformance,
+				Location:      "westus",
+				MonitorPort:   6443,
+				Endpoints:     []clusterv1.APIEndpoint{{Host: "my-cluster-westus.westus.cloudapp.azure.com", Port: 6443}},
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(trafficmanager.Profile{}))
+				profile := result.(trafficmanager.Profile)
+				g.Expect(profile.TrafficRoutingMethod).To(Equal(trafficmanager.Performance))
+				g.Expect((*profile.Endpoints)[0].EndpointLocation).To(Equal(to.StringPtr("westus")))
+				g.Expect((*profile.Endpoints)[0].Priority).To(BeNil())
+			},
+		},
+		{
+			name: "existing profile is up to date",
+			spec: &fakeProfileSpec,
+			existing: trafficmanager.Profile{
+				Name: to.StringPtr("my-cluster-tm"),
+				ProfileProperties: &trafficmanager.ProfileProperties{
+					TrafficRoutingMethod: trafficmanager.Priority,
+					Endpoints: &[]trafficmanager.Endpoint{
+						{
+							Name: to.StringPtr("my-cluster-westus-westus-cloudapp-azure-com-6443"),
+							EndpointProperties: &trafficmanager.EndpointProperties{
+								Target: to.StringPtr("my-cluster-westus.westus.cloudapp.azure.com"),
+							},
+						},
+						{
+							Name: to.StringPtr("my-cluster-eastus-eastus-cloudapp-azure-com-6443"),
+							EndpointProperties: &trafficmanager.EndpointProperties{
+								Target: to.StringPtr("my-cluster-eastus.eastus.cloudapp.azure.com"),
+							},
+						},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "existing unmanaged profile keeps its endpoints and tags",
+			spec: &fakeProfileSpec,
+			existing: trafficmanager.Profile{
+				Name: to.StringPtr("my-cluster-tm"),
+				Tags: map[string]*string{"foo": to.StringPtr("bar")},
+				ProfileProperties: &trafficmanager.ProfileProperties{
+					TrafficRoutingMethod: trafficmanager.Priority,
+					Endpoints: &[]trafficmanager.Endpoint{
+						{
+							Name: to.StringPtr("other-endpoint"),
+							EndpointProperties: &trafficmanager.EndpointProperties{
+								Target: to.StringPtr("other.example.com"),
+							},
+						},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(trafficmanager.Profile{}))
+				profile := result.(trafficmanager.Profile)
+				g.Expect(*profile.Endpoints).To(HaveLen(3))
+				g.Expect((*profile.Endpoints)[2].Name).To(Equal(to.StringPtr("other-endpoint")))
+				g.Expect(profile.Tags).To(Equal(map[string]*string{"foo": to.StringPtr("bar")}))
+			},
+		},
+		{
+			name:          "existing is not a profile",
+			spec:          &fakeProfileSpec,
+			existing:      "not a profile",
+			expectedError: "string is not a trafficmanager.Profile",
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
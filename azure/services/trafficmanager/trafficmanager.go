@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trafficmanager
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/trafficmanager/mgmt/2018-04-01/trafficmanager"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const serviceName = "trafficmanager"
+
+// TrafficManagerScope defines the scope interface for a Traffic Manager service.
+type TrafficManagerScope interface {
+	azure.ClusterDescriber
+	azure.AsyncStatusUpdater
+	TrafficManagerSpec() azure.ResourceSpecGetter
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope TrafficManagerScope
+	async.Getter
+	async.Reconciler
+	client
+}
+
+// New creates a new Traffic Manager service.
+func New(scope TrafficManagerScope) *Service {
+	client := newClient(scope)
+	return &Service{
+		Scope:      scope,
+		Getter:     client,
+		Reconciler: async.New(scope, client, client),
+		client:     client,
+	}
+}
+
+// Reconcile creates or updates the Traffic Manager profile of the cluster.
+// The profile is only created when the Traffic Manager is configured: it's opt-in.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "trafficmanager.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	profileSpec := s.Scope.TrafficManagerSpec()
+	if profileSpec == nil {
+		log.V(4).Info("skipping traffic manager reconcile, no traffic manager is configured")
+		return nil
+	}
+
+	_, err := s.CreateResource(ctx, profileSpec, serviceName)
+	if azure.ResourceConflict(err) {
+		// The DNS prefix of a profile must be unique across all of Azure, retrying won't help.
+		err = azure.WithTerminalError(errors.Wrapf(err, "traffic manager profile %s conflicts with an existing profile, make sure its DNS prefix is globally unique", profileSpec.ResourceName()))
+	}
+
+	s.Scope.UpdatePutStatus(infrav1.TrafficManagerReadyCondition, serviceName, err)
+	return err
+}
+
+// Delete deletes the Traffic Manager profile if it is managed by capz. When the profile is not managed,
+// only the endpoints of the cluster are removed from it.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "trafficmanager.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	profileSpec := s.Scope.TrafficManagerSpec()
+	if profileSpec == nil {
+		log.V(4).Info("skipping traffic manager deletion, no traffic manager is configured")
+		return nil
+	}
+
+	existing, err := s.Get(ctx, profileSpec)
+	if err != nil {
+		if azure.ResourceNotFound(err) {
+			// already deleted or doesn't exist, cleanup status and return.
+			s.Scope.UpdateDeleteStatus(infrav1.TrafficManagerReadyCondition, serviceName, nil)
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get traffic manager profile %s in resource group %s", profileSpec.ResourceName(), profileSpec.ResourceGroupName())
+	}
+
+	profile, ok := existing.(trafficmanager.Profile)
+	if !ok {
+		return errors.Errorf("%T is not a trafficmanager.Profile", existing)
+	}
+
+	if converters.MapToTags(profile.Tags).HasOwned(s.Scope.ClusterName()) {
+		err = s.DeleteResource(ctx, profileSpec, serviceName)
+	} else {
+		log.V(2).Info("traffic manager profile is not managed, removing cluster endpoints only", "profile", profileSpec.ResourceName())
+		err = s.deleteEndpoints(ctx, profileSpec)
+	}
+
+	s.Scope.UpdateDeleteStatus(infrav1.TrafficManagerReadyCondition, serviceName, err)
+	return err
+}
+
+// deleteEndpoints removes the endpoints of the cluster from an unmanaged Traffic Manager profile.
+func (s *Service) deleteEndpoints(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	profileSpec, ok := spec.(*TrafficManagerSpec)
+	if !ok {
+		return errors.Errorf("%T is not a TrafficManagerSpec", spec)
+	}
+	for _, name := range profileSpec.EndpointNames() {
+		if err := s.DeleteEndpoint(ctx, profileSpec.ResourceGroupName(), profileSpec.ResourceName(), name); err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrapf(err, "failed to delete endpoint %s from traffic manager profile %s", name, profileSpec.ResourceName())
+		}
+	}
+	return nil
+}
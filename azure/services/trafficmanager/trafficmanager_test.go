@@ -0,0 +1,215 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trafficmanager
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/trafficmanager/mgmt/2018-04-01/trafficmanager"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/trafficmanager/mock_trafficmanager"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+	conflictError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusConflict}, "Conflict")
+	notFoundError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not Found")
+
+	ownedProfile = trafficmanager.Profile{
+		Name: to.StringPtr("my-cluster-tm"),
+		Tags: map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned")},
+	}
+	unmanagedProfile = trafficmanager.Profile{
+		Name: to.StringPtr("my-cluster-tm"),
+	}
+)
+
+func TestReconcileTrafficManager(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_trafficmanager.MockTrafficManagerScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no traffic manager is configured",
+			expectedError: "",
+			expect: func(s *mock_trafficmanager.MockTrafficManagerScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.TrafficManagerSpec().Return(nil)
+			},
+		},
+		{
+			name:          "create traffic manager profile",
+			expectedError: "",
+			expect: func(s *mock_trafficmanager.MockTrafficManagerScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.TrafficManagerSpec().Return(&fakeProfileSpec)
+				r.CreateResource(gomockinternal.AContext(), &fakeProfileSpec, serviceName).Return(ownedProfile, nil)
+				s.UpdatePutStatus(infrav1.TrafficManagerReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "fail to create traffic manager profile",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_trafficmanager.MockTrafficManagerScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.TrafficManagerSpec().Return(&fakeProfileSpec)
+				r.CreateResource(gomockinternal.AContext(), &fakeProfileSpec, serviceName).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.TrafficManagerReadyCondition, serviceName, internalError)
+			},
+		},
+		{
+			name:          "dns prefix conflict is a terminal error",
+			expectedError: "reconcile error that cannot be recovered occurred: traffic manager profile my-cluster-tm conflicts with an existing profile, make sure its DNS prefix is globally unique: #: Conflict: StatusCode=409. Object will not be requeued",
+			expect: func(s *mock_trafficmanager.MockTrafficManagerScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.TrafficManagerSpec().Return(&fakeProfileSpec)
+				r.CreateResource(gomockinternal.AContext(), &fakeProfileSpec, serviceName).Return(nil, conflictError)
+				s.UpdatePutStatus(infrav1.TrafficManagerReadyCondition, serviceName, gomock.Any())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_trafficmanager.NewMockTrafficManagerScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteTrafficManager(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_trafficmanager.MockTrafficManagerScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_trafficmanager.MockclientMockRecorder)
+	}{
+		{
+			name:          "noop if no traffic manager is configured",
+			expectedError: "",
+			expect: func(s *mock_trafficmanager.MockTrafficManagerScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_trafficmanager.MockclientMockRecorder) {
+				s.TrafficManagerSpec().Return(nil)
+			},
+		},
+		{
+			name:          "profile already deleted",
+			expectedError: "",
+			expect: func(s *mock_trafficmanager.MockTrafficManagerScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_trafficmanager.MockclientMockRecorder) {
+				s.TrafficManagerSpec().Return(&fakeProfileSpec)
+				g.Get(gomockinternal.AContext(), &fakeProfileSpec).Return(nil, notFoundError)
+				s.UpdateDeleteStatus(infrav1.TrafficManagerReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "delete owned profile",
+			expectedError: "",
+			expect: func(s *mock_trafficmanager.MockTrafficManagerScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_trafficmanager.MockclientMockRecorder) {
+				s.TrafficManagerSpec().Return(&fakeProfileSpec)
+				g.Get(gomockinternal.AContext(), &fakeProfileSpec).Return(ownedProfile, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &fakeProfileSpec, serviceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.TrafficManagerReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "remove endpoints from unmanaged profile",
+			expectedError: "",
+			expect: func(s *mock_trafficmanager.MockTrafficManagerScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_trafficmanager.MockclientMockRecorder) {
+				s.TrafficManagerSpec().Return(&fakeProfileSpec)
+				g.Get(gomockinternal.AContext(), &fakeProfileSpec).Return(unmanagedProfile, nil)
+				s.ClusterName().Return("my-cluster")
+				c.DeleteEndpoint(gomockinternal.AContext(), "my-rg", "my-cluster-tm", "my-cluster-westus-westus-cloudapp-azure-com-6443").Return(nil)
+				c.DeleteEndpoint(gomockinternal.AContext(), "my-rg", "my-cluster-tm", "my-cluster-eastus-eastus-cloudapp-azure-com-6443").Return(notFoundError)
+				s.UpdateDeleteStatus(infrav1.TrafficManagerReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "fail to get profile",
+			expectedError: "failed to get traffic manager profile my-cluster-tm in resource group my-rg: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_trafficmanager.MockTrafficManagerScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_trafficmanager.MockclientMockRecorder) {
+				s.TrafficManagerSpec().Return(&fakeProfileSpec)
+				g.Get(gomockinternal.AContext(), &fakeProfileSpec).Return(nil, internalError)
+			},
+		},
+		{
+			name:          "fail to delete owned profile",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_trafficmanager.MockTrafficManagerScopeMockRecorder, g *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_trafficmanager.MockclientMockRecorder) {
+				s.TrafficManagerSpec().Return(&fakeProfileSpec)
+				g.Get(gomockinternal.AContext(), &fakeProfileSpec).Return(ownedProfile, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &fakeProfileSpec, serviceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.TrafficManagerReadyCondition, serviceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_trafficmanager.NewMockTrafficManagerScope(mockCtrl)
+			getterMock := mock_async.NewMockGetter(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			clientMock := mock_trafficmanager.NewMockclient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), getterMock.EXPECT(), asyncMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Getter:     getterMock,
+				Reconciler: asyncMock,
+				client:     clientMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualmachineimages
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/cache/ttllru"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// Cache loads the image SKUs of Marketplace offers on first use. A cache is shared by all
+// the scopes of the same subscription and location, and the SKUs of an offer are reloaded
+// from Azure once they are older than the cache TTL.
+type Cache struct {
+	client Client
+
+	// location is the Azure location for which this cache stores image info.
+	location string
+
+	// ttl is the duration after which data is reloaded. The data never expires if ttl is zero.
+	ttl time.Duration
+
+	// mu synchronizes the access to data, as the cache is shared across concurrent reconciles.
+	mu sync.Mutex
+
+	// data maps the "<publisher>/<offer>" key of an offer to its image SKUs.
+	data map[string]offerSKUs
+}
+
+// offerSKUs are the cached image SKUs of an offer.
+type offerSKUs struct {
+	skus        []string
+	refreshedAt time.Time
+}
+
+// Cacher describes the ability to get and to add items to cache.
+type Cacher interface {
+	Get(key interface{}) (value interface{}, ok bool)
+	Add(key interface{}, value interface{}) bool
+}
+
+// cacheTTL is the duration after which the cached image SKUs of an offer are reloaded from Azure.
+// Reference images are published along with new Kubernetes releases, so it is kept short.
+const cacheTTL = 1 * time.Hour
+
+var (
+	doOnce      sync.Once
+	clientCache Cacher
+
+	// referenceImageSKU matches the SKUs of the reference images, e.g. k8s-1dot22dot4-ubuntu-2004.
+	referenceImageSKU = regexp.MustCompile(`^k8s-(\d+)dot(\d+)dot(\d+)-(.+)$`)
+)
+
+// newCache instantiates a cache.
+func newCache(auth azure.Authorizer, location string) *Cache {
+	return &Cache{
+		client:   NewClient(auth),
+		location: location,
+		ttl:      cacheTTL,
+		data:     make(map[string]offerSKUs),
+	}
+}
+
+// GetCache either creates a new image cache or returns an existing one based on the subscription and the location.
+func GetCache(auth azure.Authorizer, location string) (*Cache, error) {
+	var err error
+	doOnce.Do(func() {
+		clientCache, err = ttllru.New(128, cacheTTL)
+	})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating LRU cache for VM images cache")
+	}
+
+	key := auth.SubscriptionID() + "_" + location
+	c, ok := clientCache.Get(key)
+	if ok {
+		return c.(*Cache), nil
+	}
+
+	c = newCache(auth, location)
+	_ = clientCache.Add(key, c)
+	return c.(*Cache), nil
+}
+
+// NewStaticCache initializes a cache with data and no ability to refresh. The data maps the
+// "<publisher>/<offer>" key of an offer to its image SKUs. Used for testing.
+func NewStaticCache(data map[string][]string, location string) *Cache {
+	c := &Cache{
+		location: location,
+		data:     make(map[string]offerSKUs, len(data)),
+	}
+	for key, skus := range data {
+		c.data[key] = offerSKUs{skus: skus}
+	}
+	return c
+}
+
+// SKUs returns the image SKUs of a Marketplace offer available in the location of the cache.
+func (c *Cache) SKUs(ctx context.Context, publisher, offer string) ([]string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachineimages.Cache.SKUs")
+	defer done()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := publisher + "/" + offer
+	if cached, ok := c.data[key]; ok && (c.ttl == 0 || time.Since(cached.refreshedAt) < c.ttl) {
+		return cached.skus, nil
+	}
+
+	skus, err := c.client.ListSKUs(ctx, c.location, publisher, offer)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to refresh VM image cache")
+	}
+
+	c.data[key] = offerSKUs{skus: skus, refreshedAt: time.Now()}
+	return skus, nil
+}
+
+// ValidateReferenceImage checks that the SKU of a reference image is published in the location of the cache.
+// When it is not, the returned error lists the Kubernetes versions that have a reference image of the same
+// operating system in the location.
+func (c *Cache) ValidateReferenceImage(ctx context.Context, image *infrav1.Image) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachineimages.Cache.ValidateReferenceImage")
+	defer done()
+
+	if image == nil || image.Marketplace == nil {
+		return nil
+	}
+	publisher, offer, sku := image.Marketplace.Publisher, image.Marketplace.Offer, image.Marketplace.SKU
+
+	skus, err := c.SKUs(ctx, publisher, offer)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range skus {
+		if strings.EqualFold(s, sku) {
+			return nil
+		}
+	}
+
+	version, osFlavor := parseReferenceImageSKU(sku)
+	if version == nil {
+		return errors.Errorf("image SKU %s of offer %s/%s is not available in location %s", sku, publisher, offer, c.location)
+	}
+
+	var versions semver.Versions
+	for _, s := range skus {
+		if v, flavor := parseReferenceImageSKU(s); v != nil && flavor == osFlavor {
+			versions = append(versions, *v)
+		}
+	}
+	if len(versions) == 0 {
+		return errors.Errorf("no reference image of Kubernetes version %s is available in location %s: offer %s/%s has no %s image",
+			version, c.location, publisher, offer, osFlavor)
+	}
+	sort.Sort(versions)
+
+	available := make([]string, len(versions))
+	for i, v := range versions {
+		available[i] = v.String()
+	}
+	return errors.Errorf("no reference image of Kubernetes version %s is available in location %s: image SKU %s of offer %s/%s was not found, "+
+		"use one of the available versions (%s) or specify a custom image", version, c.location, sku, publisher, offer, strings.Join(available, ", "))
+}
+
+// parseReferenceImageSKU returns the Kubernetes version and the operating system of a reference image SKU,
+// or a nil version if the SKU isn't the one of a reference image.
+func parseReferenceImageSKU(sku string) (*semver.Version, string) {
+	m := referenceImageSKU.FindStringSubmatch(sku)
+	if m == nil {
+		return nil, ""
+	}
+	v, err := semver.Parse(fmt.Sprintf("%s.%s.%s", m[1], m[2], m[3]))
+	if err != nil {
+		return nil, ""
+	}
+	return &v, m[4]
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualmachineimages
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages/mock_virtualmachineimages"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+func TestCacheSKUs(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	client := mock_virtualmachineimages.NewMockClient(mockCtrl)
+	client.EXPECT().ListSKUs(gomockinternal.AContext(), "westus2", "cncf-upstream", "capi").Return([]string{"k8s-1dot22dot4-ubuntu-2004"}, nil).Times(2)
+
+	c := &Cache{
+		client:   client,
+		location: "westus2",
+		ttl:      time.Hour,
+		data:     make(map[string]offerSKUs),
+	}
+
+	for i := 0; i < 2; i++ {
+		skus, err := c.SKUs(context.TODO(), "cncf-upstream", "capi")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(skus).To(Equal([]string{"k8s-1dot22dot4-ubuntu-2004"}))
+	}
+
+	// Expired SKUs are listed again.
+	c.data["cncf-upstream/capi"] = offerSKUs{skus: c.data["cncf-upstream/capi"].skus, refreshedAt: time.Now().Add(-2 * time.Hour)}
+	_, err := c.SKUs(context.TODO(), "cncf-upstream", "capi")
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestCacheSKUsError(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	client := mock_virtualmachineimages.NewMockClient(mockCtrl)
+	client.EXPECT().ListSKUs(gomockinternal.AContext(), "westus2", "cncf-upstream", "capi").Return(nil, errors.New("boom"))
+
+	c := &Cache{
+		client:   client,
+		location: "westus2",
+		data:     make(map[string]offerSKUs),
+	}
+
+	_, err := c.SKUs(context.TODO(), "cncf-upstream", "capi")
+	g.Expect(err).To(MatchError("failed to refresh VM image cache: boom"))
+	g.Expect(c.data).To(BeEmpty())
+}
+
+func TestValidateReferenceImage(t *testing.T) {
+	skus := map[string][]string{
+		"cncf-upstream/capi": {
+			"k8s-1dot21dot7-ubuntu-2004",
+			"k8s-1dot22dot10-ubuntu-2004",
+			"k8s-1dot22dot4-ubuntu-2004",
+			"k8s-1dot22dot4-ubuntu-1804",
+		},
+		"cncf-upstream/capi-windows": {
+			"k8s-1dot22dot4-windows-2019-containerd",
+		},
+	}
+
+	marketplace := func(offer, sku string) *infrav1.Image {
+		return &infrav1.Image{
+			Marketplace: &infrav1.AzureMarketplaceImage{
+				Publisher: "cncf-upstream",
+				Offer:     offer,
+				SKU:       sku,
+				Version:   "latest",
+			},
+		}
+	}
+
+	testcases := []struct {
+		name        string
+		image       *infrav1.Image
+		expectedErr string
+	}{
+		{
+			name:  "available image",
+			image: marketplace("capi", "k8s-1dot22dot4-ubuntu-2004"),
+		},
+		{
+			name:  "image that isn't from the Marketplace",
+			image: &infrav1.Image{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/images/my-image")},
+		},
+		{
+			name:  "no image",
+			image: nil,
+		},
+		{
+			name:  "missing image lists the versions of the same operating system",
+			image: marketplace("capi", "k8s-1dot22dot99-ubuntu-2004"),
+			expectedErr: "no reference image of Kubernetes version 1.22.99 is available in location westus2: image SKU k8s-1dot22dot99-ubuntu-2004 " +
+				"of offer cncf-upstream/capi was not found, use one of the available versions (1.21.7, 1.22.4, 1.22.10) or specify a custom image",
+		},
+		{
+			name:        "missing operating system",
+			image:       marketplace("capi-windows", "k8s-1dot22dot4-windows-2019"),
+			expectedErr: "no reference image of Kubernetes version 1.22.4 is available in location westus2: offer cncf-upstream/capi-windows has no windows-2019 image",
+		},
+		{
+			name:        "missing image that isn't a reference image",
+			image:       marketplace("capi", "custom"),
+			expectedErr: "image SKU custom of offer cncf-upstream/capi is not available in location westus2",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			err := NewStaticCache(skus, "westus2").ValidateReferenceImage(context.TODO(), tc.image)
+			if tc.expectedErr != "" {
+				g.Expect(err).To(MatchError(tc.expectedErr))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualmachineimages
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-04-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// Client wraps go-sdk.
+type Client interface {
+	ListSKUs(ctx context.Context, location, publisher, offer string) ([]string, error)
+}
+
+// AzureClient contains the Azure go-sdk Client.
+type AzureClient struct {
+	images compute.VirtualMachineImagesClient
+}
+
+var _ Client = &AzureClient{}
+
+// NewClient creates a new VM images client from subscription ID.
+func NewClient(auth azure.Authorizer) *AzureClient {
+	return &AzureClient{
+		images: newVirtualMachineImagesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+	}
+}
+
+// newVirtualMachineImagesClient creates a new VM images client from subscription ID.
+func newVirtualMachineImagesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.VirtualMachineImagesClient {
+	c := compute.NewVirtualMachineImagesClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&c.Client, authorizer)
+	return c
+}
+
+// ListSKUs returns the names of the SKUs of a Marketplace offer available in a location.
+func (ac *AzureClient) ListSKUs(ctx context.Context, location, publisher, offer string) ([]string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachineimages.AzureClient.ListSKUs")
+	defer done()
+
+	res, err := ac.images.ListSkus(ctx, location, publisher, offer)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not list image skus of offer %s/%s", publisher, offer)
+	}
+
+	var skus []string
+	if res.Value != nil {
+		for _, sku := range *res.Value {
+			skus = append(skus, to.String(sku.Name))
+		}
+	}
+
+	return skus, nil
+}
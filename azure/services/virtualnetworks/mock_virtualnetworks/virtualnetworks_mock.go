@@ -177,6 +177,18 @@ func (mr *MockVNetScopeMockRecorder) HashKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockVNetScope)(nil).HashKey))
 }
 
+// SetDNSServers mocks base method.
+func (m *MockVNetScope) SetDNSServers(arg0 []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDNSServers", arg0)
+}
+
+// SetDNSServers indicates an expected call of SetDNSServers.
+func (mr *MockVNetScopeMockRecorder) SetDNSServers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDNSServers", reflect.TypeOf((*MockVNetScope)(nil).SetDNSServers), arg0)
+}
+
 // SetLongRunningOperationState mocks base method.
 func (m *MockVNetScope) SetLongRunningOperationState(arg0 *v1beta1.Future) {
 	m.ctrl.T.Helper()
@@ -17,22 +17,33 @@ limitations under the License.
 package virtualnetworks
 
 import (
+	"net"
+	"strings"
+
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 )
 
 // VNetSpec defines the specification for a Virtual Network.
 type VNetSpec struct {
-	ResourceGroup  string
-	Name           string
-	CIDRs          []string
-	Location       string
-	ClusterName    string
-	AdditionalTags infrav1.Tags
+	ResourceGroup   string
+	Name            string
+	CIDRs           []string
+	DNSServers      []string
+	Location        string
+	ClusterName     string
+	ProviderVersion string
+	AdditionalTags  infrav1.Tags
+	PodCIDRs        []string
+	ServiceCIDRs    []string
 }
 
+// maxTagValueLength is the maximum length of the value of an Azure tag.
+const maxTagValueLength = 256
+
 // ResourceName returns the name of the vnet.
 func (s *VNetSpec) ResourceName() string {
 	return s.Name
@@ -51,22 +62,117 @@ func (s *VNetSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the vnet.
 func (s *VNetSpec) Parameters(existing interface{}) (interface{}, error) {
 	if existing != nil {
-		// vnet already exists, nothing to update.
-		return nil, nil
+		existingVnet, ok := existing.(network.VirtualNetwork)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.VirtualNetwork", existing)
+		}
+		// Only the DNS servers of a vnet managed by capz are kept up to date, a pre-existing vnet is left untouched.
+		if !converters.MapToTags(existingVnet.Tags).HasOwned(s.ClusterName) || existingVnet.VirtualNetworkPropertiesFormat == nil {
+			return nil, nil
+		}
+		tags := converters.MapToTags(existingVnet.Tags)
+		if dnsServersEqual(existingVnet.DhcpOptions, s.DNSServers) && tags.HasProviderVersion(s.ProviderVersion) && s.hasCIDRTags(tags) {
+			// vnet already exists, nothing to update.
+			return nil, nil
+		}
+		existingVnet.DhcpOptions = s.dhcpOptions()
+		if s.ProviderVersion != "" {
+			tags[infrav1.ProviderVersionTagKey()] = s.ProviderVersion
+		}
+		s.setCIDRTags(tags)
+		existingVnet.Tags = converters.TagsToMap(tags)
+		return existingVnet, nil
 	}
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName:     s.ClusterName,
+		Lifecycle:       infrav1.ResourceLifecycleOwned,
+		Name:            to.StringPtr(s.Name),
+		Role:            to.StringPtr(infrav1.CommonRole),
+		ProviderVersion: s.ProviderVersion,
+		Additional:      s.AdditionalTags,
+	})
+	s.setCIDRTags(tags)
 	return network.VirtualNetwork{
-		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
-			ClusterName: s.ClusterName,
-			Lifecycle:   infrav1.ResourceLifecycleOwned,
-			Name:        to.StringPtr(s.Name),
-			Role:        to.StringPtr(infrav1.CommonRole),
-			Additional:  s.AdditionalTags,
-		})),
+		Tags:     converters.TagsToMap(tags),
 		Location: to.StringPtr(s.Location),
 		VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
 			AddressSpace: &network.AddressSpace{
 				AddressPrefixes: &s.CIDRs,
 			},
+			DhcpOptions: s.dhcpOptions(),
 		},
 	}, nil
 }
+
+// cidrTags returns the tags recording the pod and service CIDRs of the cluster on the vnet. A tag with an empty value
+// is not set: the cluster networking spec has no well-formed CIDR of that kind.
+func (s *VNetSpec) cidrTags() infrav1.Tags {
+	return infrav1.Tags{
+		infrav1.PodCIDRsTagKey():     cidrsTagValue(s.PodCIDRs),
+		infrav1.ServiceCIDRsTagKey(): cidrsTagValue(s.ServiceCIDRs),
+	}
+}
+
+// hasCIDRTags returns true if the tags record the pod and service CIDRs of the cluster.
+func (s *VNetSpec) hasCIDRTags(tags infrav1.Tags) bool {
+	for key, value := range s.cidrTags() {
+		if tags[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// setCIDRTags records the pod and service CIDRs of the cluster in the tags, and removes the tags of the CIDRs no
+// longer in the cluster networking spec.
+func (s *VNetSpec) setCIDRTags(tags infrav1.Tags) {
+	for key, value := range s.cidrTags() {
+		if value == "" {
+			delete(tags, key)
+		} else {
+			tags[key] = value
+		}
+	}
+}
+
+// cidrsTagValue returns the well-formed CIDRs as a comma-separated tag value, or an empty string if there is none or
+// if they don't fit in a tag.
+func cidrsTagValue(cidrs []string) string {
+	var valid []string
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err == nil {
+			valid = append(valid, cidr)
+		}
+	}
+	value := strings.Join(valid, ",")
+	if len(value) > maxTagValueLength {
+		return ""
+	}
+	return value
+}
+
+// dhcpOptions returns the DHCP options of the vnet. An empty list of DNS servers reverts the vnet to the Azure-provided DNS.
+func (s *VNetSpec) dhcpOptions() *network.DhcpOptions {
+	dnsServers := make([]string, len(s.DNSServers))
+	copy(dnsServers, s.DNSServers)
+	return &network.DhcpOptions{
+		DNSServers: &dnsServers,
+	}
+}
+
+// dnsServersEqual returns true if the DHCP options of the vnet contain exactly the given DNS servers, in the same order.
+func dnsServersEqual(options *network.DhcpOptions, dnsServers []string) bool {
+	var existing []string
+	if options != nil {
+		existing = to.StringSlice(options.DNSServers)
+	}
+	if len(existing) != len(dnsServers) {
+		return false
+	}
+	for i := range existing {
+		if existing[i] != dnsServers[i] {
+			return false
+		}
+	}
+	return true
+}
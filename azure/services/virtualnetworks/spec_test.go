@@ -0,0 +1,210 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualnetworks
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestParameters(t *testing.T) {
+	withDNSServers := func(vnet network.VirtualNetwork, dnsServers ...string) network.VirtualNetwork {
+		vnet.VirtualNetworkPropertiesFormat = &network.VirtualNetworkPropertiesFormat{
+			AddressSpace: &network.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/8"}},
+			DhcpOptions:  &network.DhcpOptions{DNSServers: &dnsServers},
+		}
+		return vnet
+	}
+
+	withProviderVersion := func(vnet network.VirtualNetwork, version string) network.VirtualNetwork {
+		tags := map[string]*string{}
+		for k, v := range vnet.Tags {
+			tags[k] = v
+		}
+		tags[infrav1.ProviderVersionTagKey()] = to.StringPtr(version)
+		vnet.Tags = tags
+		return vnet
+	}
+
+	withCIDRTags := func(vnet network.VirtualNetwork, podCIDRs, serviceCIDRs string) network.VirtualNetwork {
+		tags := map[string]*string{}
+		for k, v := range vnet.Tags {
+			tags[k] = v
+		}
+		tags[infrav1.PodCIDRsTagKey()] = to.StringPtr(podCIDRs)
+		tags[infrav1.ServiceCIDRsTagKey()] = to.StringPtr(serviceCIDRs)
+		vnet.Tags = tags
+		return vnet
+	}
+
+	testcases := []struct {
+		name            string
+		dnsServers      []string
+		providerVersion string
+		podCIDRs        []string
+		serviceCIDRs    []string
+		existing        interface{}
+		expect          func(g *WithT, result interface{})
+	}{
+		{
+			name:       "new vnet with custom DNS servers",
+			dnsServers: []string{"10.0.0.4"},
+			existing:   nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.VirtualNetwork{}))
+				g.Expect(to.StringSlice(result.(network.VirtualNetwork).DhcpOptions.DNSServers)).To(Equal([]string{"10.0.0.4"}))
+			},
+		},
+		{
+			name:       "managed vnet with up to date DNS servers",
+			dnsServers: []string{"10.0.0.4"},
+			existing:   withDNSServers(managedVnet, "10.0.0.4"),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:       "managed vnet with outdated DNS servers",
+			dnsServers: []string{"10.0.0.4", "10.0.0.5"},
+			existing:   withDNSServers(managedVnet, "10.0.0.4"),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.VirtualNetwork{}))
+				vnet := result.(network.VirtualNetwork)
+				g.Expect(to.StringSlice(vnet.DhcpOptions.DNSServers)).To(Equal([]string{"10.0.0.4", "10.0.0.5"}))
+				g.Expect(to.StringSlice(vnet.AddressSpace.AddressPrefixes)).To(Equal([]string{"10.0.0.0/8"}))
+			},
+		},
+		{
+			name:       "managed vnet reverts to Azure-provided DNS when the list is cleared",
+			dnsServers: nil,
+			existing:   withDNSServers(managedVnet, "10.0.0.4"),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.VirtualNetwork{}))
+				g.Expect(*result.(network.VirtualNetwork).DhcpOptions.DNSServers).To(BeEmpty())
+			},
+		},
+		{
+			name:            "new vnet is tagged with the provider version",
+			providerVersion: "v1.2.0",
+			existing:        nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.VirtualNetwork{}))
+				g.Expect(result.(network.VirtualNetwork).Tags).To(HaveKeyWithValue(infrav1.ProviderVersionTagKey(), to.StringPtr("v1.2.0")))
+			},
+		},
+		{
+			name:            "managed vnet with up to date provider version",
+			dnsServers:      []string{"10.0.0.4"},
+			providerVersion: "v1.2.0",
+			existing:        withProviderVersion(withDNSServers(managedVnet, "10.0.0.4"), "v1.2.0"),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:            "managed vnet with outdated provider version",
+			dnsServers:      []string{"10.0.0.4"},
+			providerVersion: "v1.2.0",
+			existing:        withProviderVersion(withDNSServers(managedVnet, "10.0.0.4"), "v1.1.0"),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.VirtualNetwork{}))
+				vnet := result.(network.VirtualNetwork)
+				g.Expect(vnet.Tags).To(HaveKeyWithValue(infrav1.ProviderVersionTagKey(), to.StringPtr("v1.2.0")))
+				g.Expect(to.StringSlice(vnet.DhcpOptions.DNSServers)).To(Equal([]string{"10.0.0.4"}))
+			},
+		},
+		{
+			name:            "custom vnet provider version is left untouched",
+			providerVersion: "v1.2.0",
+			existing:        withProviderVersion(withDNSServers(customVnet, "192.168.0.10"), "v1.1.0"),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:         "new vnet is tagged with the well-formed pod and service CIDRs",
+			podCIDRs:     []string{"192.168.0.0/16", "fd00::/48"},
+			serviceCIDRs: []string{"10.96.0.0"},
+			existing:     nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.VirtualNetwork{}))
+				tags := result.(network.VirtualNetwork).Tags
+				g.Expect(tags).To(HaveKeyWithValue(infrav1.PodCIDRsTagKey(), to.StringPtr("192.168.0.0/16,fd00::/48")))
+				g.Expect(tags).NotTo(HaveKey(infrav1.ServiceCIDRsTagKey()))
+			},
+		},
+		{
+			name:         "managed vnet with up to date CIDR tags",
+			podCIDRs:     []string{"192.168.0.0/16"},
+			serviceCIDRs: []string{"10.96.0.0/12"},
+			existing:     withCIDRTags(withDNSServers(managedVnet), "192.168.0.0/16", "10.96.0.0/12"),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:         "managed vnet CIDR tags follow the cluster networking spec",
+			podCIDRs:     []string{"172.16.0.0/16"},
+			serviceCIDRs: nil,
+			existing:     withCIDRTags(withDNSServers(managedVnet), "192.168.0.0/16", "10.96.0.0/12"),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.VirtualNetwork{}))
+				tags := result.(network.VirtualNetwork).Tags
+				g.Expect(tags).To(HaveKeyWithValue(infrav1.PodCIDRsTagKey(), to.StringPtr("172.16.0.0/16")))
+				g.Expect(tags).NotTo(HaveKey(infrav1.ServiceCIDRsTagKey()))
+			},
+		},
+		{
+			name:         "custom vnet is not tagged with the CIDRs",
+			podCIDRs:     []string{"192.168.0.0/16"},
+			serviceCIDRs: []string{"10.96.0.0/12"},
+			existing:     withDNSServers(customVnet),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:       "custom vnet DNS servers are left untouched",
+			dnsServers: []string{"10.0.0.4"},
+			existing:   withDNSServers(customVnet, "192.168.0.10"),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			spec := fakeVNetSpec
+			spec.DNSServers = tc.dnsServers
+			spec.ProviderVersion = tc.providerVersion
+			spec.PodCIDRs = tc.podCIDRs
+			spec.ServiceCIDRs = tc.serviceCIDRs
+			result, err := spec.Parameters(tc.existing)
+			g.Expect(err).NotTo(HaveOccurred())
+			tc.expect(g, result)
+		})
+	}
+}
@@ -39,6 +39,7 @@ type VNetScope interface {
 	Vnet() *infrav1.VnetSpec
 	VNetSpec() azure.ResourceSpecGetter
 	ClusterName() string
+	SetDNSServers([]string)
 }
 
 // Service provides operations on Azure resources.
@@ -83,10 +84,24 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			prefixes = to.StringSlice(existingVnet.VirtualNetworkPropertiesFormat.AddressSpace.AddressPrefixes)
 		}
 		vnet.CIDRBlocks = prefixes
+
+		var dnsServers []string
+		if existingVnet.VirtualNetworkPropertiesFormat != nil && existingVnet.VirtualNetworkPropertiesFormat.DhcpOptions != nil {
+			dnsServers = to.StringSlice(existingVnet.VirtualNetworkPropertiesFormat.DhcpOptions.DNSServers)
+		}
+		s.Scope.SetDNSServers(dnsServers)
 	}
 	return err
 }
 
+// Audit reports how the live virtual network differs from the spec, without modifying it.
+func (s *Service) Audit(ctx context.Context) ([]azure.Drift, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualnetworks.Service.Audit")
+	defer done()
+
+	return async.AuditResource(ctx, s.Getter, s.Scope.VNetSpec(), "VirtualNetwork")
+}
+
 // Delete deletes the virtual network if it is managed by capz.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "virtualnetworks.Service.Delete")
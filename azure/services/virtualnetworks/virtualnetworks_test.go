@@ -76,6 +76,23 @@ func TestReconcileVnet(t *testing.T) {
 				s.UpdatePutStatus(infrav1.VNetReadyCondition, serviceName, nil)
 			},
 		},
+		{
+			name:          "existing vnet with custom DNS servers, should report them in status",
+			expectedError: "",
+			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.VNetSpec().Return(&fakeVNetSpec)
+				r.CreateResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(network.VirtualNetwork{
+					ID: managedVnet.ID,
+					VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+						AddressSpace: &network.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/8"}},
+						DhcpOptions:  &network.DhcpOptions{DNSServers: &[]string{"10.0.0.4"}},
+					},
+				}, nil)
+				s.UpdatePutStatus(infrav1.VNetReadyCondition, serviceName, nil)
+				s.Vnet().Return(&infrav1.VnetSpec{})
+				s.SetDNSServers([]string{"10.0.0.4"})
+			},
+		},
 		{
 			name:          "create vnet fails, should return an error",
 			expectedError: internalError.Error(),
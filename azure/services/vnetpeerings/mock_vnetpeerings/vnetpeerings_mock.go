@@ -21,10 +21,12 @@ limitations under the License.
 package mock_vnetpeerings
 
 import (
+	context "context"
 	reflect "reflect"
 
 	autorest "github.com/Azure/go-autorest/autorest"
 	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/core/v1"
 	v1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	azure "sigs.k8s.io/cluster-api-provider-azure/azure"
 	v1beta10 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -175,6 +177,23 @@ func (mr *MockVnetPeeringScopeMockRecorder) SetLongRunningOperationState(arg0 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLongRunningOperationState", reflect.TypeOf((*MockVnetPeeringScope)(nil).SetLongRunningOperationState), arg0)
 }
 
+// SetVnetPeeringNotReady mocks base method.
+func (m *MockVnetPeeringScope) SetVnetPeeringNotReady(reason string, severity v1beta10.ConditionSeverity, messageFormat string, messageArgs ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{reason, severity, messageFormat}
+	for _, a := range messageArgs {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "SetVnetPeeringNotReady", varargs...)
+}
+
+// SetVnetPeeringNotReady indicates an expected call of SetVnetPeeringNotReady.
+func (mr *MockVnetPeeringScopeMockRecorder) SetVnetPeeringNotReady(reason, severity, messageFormat interface{}, messageArgs ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{reason, severity, messageFormat}, messageArgs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVnetPeeringNotReady", reflect.TypeOf((*MockVnetPeeringScope)(nil).SetVnetPeeringNotReady), varargs...)
+}
+
 // SubscriptionID mocks base method.
 func (m *MockVnetPeeringScope) SubscriptionID() string {
 	m.ctrl.T.Helper()
@@ -189,6 +208,21 @@ func (mr *MockVnetPeeringScopeMockRecorder) SubscriptionID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionID", reflect.TypeOf((*MockVnetPeeringScope)(nil).SubscriptionID))
 }
 
+// TenantAuthorizer mocks base method.
+func (m *MockVnetPeeringScope) TenantAuthorizer(ctx context.Context, tenantID, subscriptionID string, identityRef *v1.ObjectReference) (azure.Authorizer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TenantAuthorizer", ctx, tenantID, subscriptionID, identityRef)
+	ret0, _ := ret[0].(azure.Authorizer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TenantAuthorizer indicates an expected call of TenantAuthorizer.
+func (mr *MockVnetPeeringScopeMockRecorder) TenantAuthorizer(ctx, tenantID, subscriptionID, identityRef interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantAuthorizer", reflect.TypeOf((*MockVnetPeeringScope)(nil).TenantAuthorizer), ctx, tenantID, subscriptionID, identityRef)
+}
+
 // TenantID mocks base method.
 func (m *MockVnetPeeringScope) TenantID() string {
 	m.ctrl.T.Helper()
@@ -20,6 +20,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 )
 
@@ -31,6 +32,13 @@ type VnetPeeringSpec struct {
 	RemoteVnetName      string
 	PeeringName         string
 	SubscriptionID      string
+	// SourceTenantID, SourceSubscriptionID and IdentityRef are set when the source virtual network belongs to another
+	// tenant, whose identity creates the peering.
+	SourceTenantID       string
+	SourceSubscriptionID string
+	IdentityRef          *corev1.ObjectReference
+	// CrossTenant is true for both sides of a peering with a virtual network of another tenant.
+	CrossTenant bool
 }
 
 // ResourceName returns the name of the virtual network peering.
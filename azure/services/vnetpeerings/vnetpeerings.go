@@ -18,27 +18,40 @@ package vnetpeerings
 
 import (
 	"context"
+	"strings"
+	"time"
 
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 const serviceName = "vnetpeerings"
 
+// partialPeeringRequeue is how long to wait before retrying the side of a peering with another tenant that the
+// identity of its tenant isn't allowed to create.
+const partialPeeringRequeue = 5 * time.Minute
+
 // VnetPeeringScope defines the scope interface for a subnet service.
 type VnetPeeringScope interface {
 	azure.Authorizer
 	azure.AsyncStatusUpdater
 	VnetPeeringSpecs() []azure.ResourceSpecGetter
+	TenantAuthorizer(ctx context.Context, tenantID, subscriptionID string, identityRef *corev1.ObjectReference) (azure.Authorizer, error)
+	SetVnetPeeringNotReady(reason string, severity clusterv1.ConditionSeverity, messageFormat string, messageArgs ...interface{})
 }
 
 // Service provides operations on Azure resources.
 type Service struct {
 	Scope VnetPeeringScope
 	async.Reconciler
+	// tenantReconciler creates the reconciler of the peerings of the virtual networks of another tenant.
+	tenantReconciler func(auth azure.Authorizer) async.Reconciler
 }
 
 // New creates a new service.
@@ -47,12 +60,16 @@ func New(scope VnetPeeringScope) *Service {
 	return &Service{
 		Scope:      scope,
 		Reconciler: async.New(scope, Client, Client),
+		tenantReconciler: func(auth azure.Authorizer) async.Reconciler {
+			tenantClient := NewClient(auth)
+			return async.New(scope, tenantClient, tenantClient)
+		},
 	}
 }
 
 // Reconcile gets/creates/updates a peering.
 func (s *Service) Reconcile(ctx context.Context) error {
-	ctx, _, done := tele.StartSpanWithLogger(ctx, "vnetpeerings.Service.Reconcile")
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "vnetpeerings.Service.Reconcile")
 	defer done()
 
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
@@ -62,21 +79,38 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	// If multiple errors occur, we return the most pressing one.
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
 	var result error
+	var partial []string
 	for _, peeringSpec := range s.Scope.VnetPeeringSpecs() {
-		if _, err := s.CreateResource(ctx, peeringSpec, serviceName); err != nil {
+		peeringReconciler, err := s.reconcilerFor(ctx, peeringSpec)
+		if err == nil {
+			_, err = peeringReconciler.CreateResource(ctx, peeringSpec, serviceName)
+		}
+		if err != nil {
+			// Only one side of a peering with another tenant may be allowed to the identities, the other side is
+			// retried until its tenant grants the permission.
+			if isCrossTenant(peeringSpec) && azure.ResourceForbidden(err) {
+				log.V(2).Info("not allowed to create the peering of a virtual network of another tenant", "peering", peeringSpec.ResourceName(), "error", err.Error())
+				partial = append(partial, peeringSpec.ResourceName())
+				continue
+			}
 			if !azure.IsOperationNotDoneError(err) || result == nil {
 				result = err
 			}
 		}
 	}
 
+	if result == nil && len(partial) > 0 {
+		s.Scope.SetVnetPeeringNotReady(infrav1.VnetPeeringPartialReason, clusterv1.ConditionSeverityWarning,
+			"the identities are not allowed to create peerings %s with the virtual networks of another tenant", strings.Join(partial, ", "))
+		return azure.WithTransientError(errors.Errorf("peerings %s are not created yet", strings.Join(partial, ", ")), partialPeeringRequeue)
+	}
 	s.Scope.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, serviceName, result)
 	return result
 }
 
 // Delete deletes the peering with the provided name.
 func (s *Service) Delete(ctx context.Context) error {
-	ctx, _, done := tele.StartSpanWithLogger(ctx, "vnetpeerings.Service.Delete")
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "vnetpeerings.Service.Delete")
 	defer done()
 
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
@@ -88,7 +122,17 @@ func (s *Service) Delete(ctx context.Context) error {
 	// If multiple errors occur, we return the most pressing one.
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
 	for _, peeringSpec := range s.Scope.VnetPeeringSpecs() {
-		if err := s.DeleteResource(ctx, peeringSpec, serviceName); err != nil {
+		peeringReconciler, err := s.reconcilerFor(ctx, peeringSpec)
+		if err == nil {
+			err = peeringReconciler.DeleteResource(ctx, peeringSpec, serviceName)
+		}
+		if err != nil {
+			// The side of a peering with another tenant that the identities were never allowed to create is left to
+			// that tenant, so that it doesn't block the deletion of the cluster.
+			if isCrossTenant(peeringSpec) && azure.ResourceForbidden(err) {
+				log.V(2).Info("not allowed to delete the peering of a virtual network of another tenant, skipping", "peering", peeringSpec.ResourceName())
+				continue
+			}
 			if !azure.IsOperationNotDoneError(err) || result == nil {
 				result = err
 			}
@@ -97,3 +141,26 @@ func (s *Service) Delete(ctx context.Context) error {
 	s.Scope.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, serviceName, result)
 	return result
 }
+
+// reconcilerFor returns the reconciler of a peering, using the identity of the tenant of its source virtual network
+// when it belongs to another tenant.
+func (s *Service) reconcilerFor(ctx context.Context, spec azure.ResourceSpecGetter) (async.Reconciler, error) {
+	peering, ok := spec.(*VnetPeeringSpec)
+	if !ok || peering.SourceTenantID == "" {
+		return s.Reconciler, nil
+	}
+	if peering.IdentityRef == nil {
+		return nil, azure.WithTerminalError(errors.Errorf("an AzureClusterIdentity of tenant %s is required to create peering %s", peering.SourceTenantID, peering.PeeringName))
+	}
+	auth, err := s.Scope.TenantAuthorizer(ctx, peering.SourceTenantID, peering.SourceSubscriptionID, peering.IdentityRef)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the credentials of tenant %s to create peering %s", peering.SourceTenantID, peering.PeeringName)
+	}
+	return s.tenantReconciler(auth), nil
+}
+
+// isCrossTenant returns true if the spec is a side of a peering with a virtual network of another tenant.
+func isCrossTenant(spec azure.ResourceSpecGetter) bool {
+	peering, ok := spec.(*VnetPeeringSpec)
+	return ok && peering.CrossTenant
+}
@@ -25,11 +25,15 @@ import (
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings/mock_vnetpeerings"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 var (
@@ -362,3 +366,138 @@ func TestDeleteVnetPeerings(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileCrossTenantVnetPeerings(t *testing.T) {
+	identityRef := &corev1.ObjectReference{Name: "tenant2-identity", Namespace: "default"}
+	peering1ToTenant2 := VnetPeeringSpec{
+		PeeringName:         "vnet1-to-vnet5",
+		SourceVnetName:      "vnet1",
+		SourceResourceGroup: "group1",
+		RemoteVnetName:      "vnet5",
+		RemoteResourceGroup: "group5",
+		SubscriptionID:      "sub2",
+		CrossTenant:         true,
+	}
+	peeringTenant2To1 := VnetPeeringSpec{
+		PeeringName:          "vnet5-to-vnet1",
+		SourceVnetName:       "vnet5",
+		SourceResourceGroup:  "group5",
+		RemoteVnetName:       "vnet1",
+		RemoteResourceGroup:  "group1",
+		SubscriptionID:       "sub1",
+		SourceTenantID:       "tenant2",
+		SourceSubscriptionID: "sub2",
+		IdentityRef:          identityRef,
+		CrossTenant:          true,
+	}
+	crossTenantSpecs := []azure.ResourceSpecGetter{&peering1ToTenant2, &peeringTenant2To1}
+	forbiddenError := autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 403}, "Forbidden")
+
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r, tr *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name: "peering from the remote virtual network is created with the identity of its tenant",
+			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r, tr *mock_async.MockReconcilerMockRecorder) {
+				p.VnetPeeringSpecs().Return(crossTenantSpecs)
+				r.CreateResource(gomockinternal.AContext(), &peering1ToTenant2, serviceName).Return(&peering1ToTenant2, nil)
+				p.TenantAuthorizer(gomockinternal.AContext(), "tenant2", "sub2", identityRef).Return(nil, nil)
+				tr.CreateResource(gomockinternal.AContext(), &peeringTenant2To1, serviceName).Return(&peeringTenant2To1, nil)
+				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "missing identity of the remote tenant",
+			expectedError: "failed to get the credentials of tenant tenant2 to create peering vnet5-to-vnet1: failed to init credentials provider",
+			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r, tr *mock_async.MockReconcilerMockRecorder) {
+				p.VnetPeeringSpecs().Return(crossTenantSpecs)
+				r.CreateResource(gomockinternal.AContext(), &peering1ToTenant2, serviceName).Return(&peering1ToTenant2, nil)
+				p.TenantAuthorizer(gomockinternal.AContext(), "tenant2", "sub2", identityRef).Return(nil, errors.New("failed to init credentials provider"))
+				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, serviceName, gomock.Any())
+			},
+		},
+		{
+			name:          "peering from the remote virtual network is not allowed yet",
+			expectedError: "peerings vnet5-to-vnet1 are not created yet. Object will be requeued after 5m0s",
+			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r, tr *mock_async.MockReconcilerMockRecorder) {
+				p.VnetPeeringSpecs().Return(crossTenantSpecs)
+				r.CreateResource(gomockinternal.AContext(), &peering1ToTenant2, serviceName).Return(&peering1ToTenant2, nil)
+				p.TenantAuthorizer(gomockinternal.AContext(), "tenant2", "sub2", identityRef).Return(nil, nil)
+				tr.CreateResource(gomockinternal.AContext(), &peeringTenant2To1, serviceName).Return(nil, forbiddenError)
+				p.SetVnetPeeringNotReady(infrav1.VnetPeeringPartialReason, clusterv1.ConditionSeverityWarning,
+					"the identities are not allowed to create peerings %s with the virtual networks of another tenant", "vnet5-to-vnet1")
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_vnetpeerings.NewMockVnetPeeringScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			tenantAsyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), tenantAsyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+				tenantReconciler: func(azure.Authorizer) async.Reconciler {
+					return tenantAsyncMock
+				},
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteCrossTenantVnetPeerings(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scopeMock := mock_vnetpeerings.NewMockVnetPeeringScope(mockCtrl)
+	asyncMock := mock_async.NewMockReconciler(mockCtrl)
+	tenantAsyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+	identityRef := &corev1.ObjectReference{Name: "tenant2-identity"}
+	peering := VnetPeeringSpec{
+		PeeringName:          "vnet5-to-vnet1",
+		SourceVnetName:       "vnet5",
+		SourceResourceGroup:  "group5",
+		RemoteVnetName:       "vnet1",
+		RemoteResourceGroup:  "group1",
+		SubscriptionID:       "sub1",
+		SourceTenantID:       "tenant2",
+		SourceSubscriptionID: "sub2",
+		IdentityRef:          identityRef,
+		CrossTenant:          true,
+	}
+	forbiddenError := autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 403}, "Forbidden")
+	scopeMock.EXPECT().VnetPeeringSpecs().Return([]azure.ResourceSpecGetter{&peering})
+	scopeMock.EXPECT().TenantAuthorizer(gomockinternal.AContext(), "tenant2", "sub2", identityRef).Return(nil, nil)
+	tenantAsyncMock.EXPECT().DeleteResource(gomockinternal.AContext(), &peering, serviceName).Return(forbiddenError)
+	scopeMock.EXPECT().UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, serviceName, nil)
+
+	s := &Service{
+		Scope:      scopeMock,
+		Reconciler: asyncMock,
+		tenantReconciler: func(azure.Authorizer) async.Reconciler {
+			return tenantAsyncMock
+		},
+	}
+	g.Expect(s.Delete(context.TODO())).To(Succeed())
+}
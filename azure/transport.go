@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/cookiejar"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/tracing"
+	"github.com/pkg/errors"
+)
+
+// DefaultMinTLSVersion is the default minimum TLS version of the connections to the Azure APIs.
+const DefaultMinTLSVersion = "1.2"
+
+var (
+	tlsVersions = map[string]uint16{
+		"1.2": tls.VersionTLS12,
+		"1.3": tls.VersionTLS13,
+	}
+
+	minTLSVersion uint16 = tls.VersionTLS12
+	senderOnce    sync.Once
+	sender        autorest.Sender
+)
+
+// SetMinTLSVersion sets the minimum TLS version of the connections to the Azure APIs, either 1.2 or 1.3.
+// It must be called before any Azure client is created.
+func SetMinTLSVersion(version string) error {
+	v, ok := tlsVersions[version]
+	if !ok {
+		return errors.Errorf("unsupported minimum TLS version %q, expected 1.2 or 1.3", version)
+	}
+	minTLSVersion = v
+	return nil
+}
+
+// defaultSender returns the Sender shared by all the Azure clients, so that they reuse their connections.
+func defaultSender() autorest.Sender {
+	senderOnce.Do(func() {
+		sender = newSender(minTLSVersion)
+	})
+	return sender
+}
+
+// newSender returns an http.Client whose transport enforces the minimum TLS version. It mirrors the default Sender of
+// go-autorest, which only enforces TLS 1.2.
+func newSender(minVersion uint16) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion:    minVersion,
+		Renegotiation: tls.RenegotiateNever,
+	}
+	var roundTripper http.RoundTripper = transport
+	if tracing.IsEnabled() {
+		roundTripper = tracing.NewTransport(transport)
+	}
+	j, _ := cookiejar.New(nil)
+	return &http.Client{Jar: j, Transport: roundTripper}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSetMinTLSVersion(t *testing.T) {
+	g := NewWithT(t)
+	defer func() { minTLSVersion = tls.VersionTLS12 }()
+
+	g.Expect(SetMinTLSVersion("1.3")).To(Succeed())
+	g.Expect(minTLSVersion).To(Equal(uint16(tls.VersionTLS13)))
+	g.Expect(SetMinTLSVersion("1.1")).NotTo(Succeed())
+	g.Expect(minTLSVersion).To(Equal(uint16(tls.VersionTLS13)))
+}
+
+func TestNewSenderMinTLSVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		minVersion uint16
+	}{
+		{
+			name:       "TLS 1.2",
+			minVersion: tls.VersionTLS12,
+		},
+		{
+			name:       "TLS 1.3",
+			minVersion: tls.VersionTLS13,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			transport, ok := newSender(tc.minVersion).Transport.(*http.Transport)
+			g.Expect(ok).To(BeTrue())
+			g.Expect(transport.TLSClientConfig.MinVersion).To(Equal(tc.minVersion))
+			g.Expect(transport.Proxy).NotTo(BeNil())
+		})
+	}
+}
@@ -28,6 +28,25 @@ type PublicIPSpec struct {
 	Name    string
 	DNSName string
 	IsIPv6  bool
+	// Role is the Cluster API role the public IP is tagged with, e.g. apiserver.
+	Role string
+	// Zones are the availability zones to create the public IP in. The public IP is zone-redundant when empty.
+	Zones []string
+	// Location is the location to create the public IP in, the location of the cluster when empty.
+	Location string
+	// IsGlobal is true for the global tier public IP of a cross-region load balancer, which has no zones.
+	IsGlobal bool
+	// IPTags are the IP tags of the public IP.
+	IPTags []infrav1.IPTag
+	// RoutingPreference is how the traffic of the public IP is routed, through the Microsoft network when empty.
+	RoutingPreference infrav1.RoutingPreference
+	// IPPrefixID is the resource ID of the existing public IP prefix to allocate the address of the public IP from.
+	IPPrefixID string
+	// DiagnosticSettings is the diagnostic setting of the public IP, which has none when nil.
+	DiagnosticSettings *infrav1.DiagnosticSettings
+	// ForceRecreate is true when an existing public IP of the Basic SKU is recreated with its load balancer, rather
+	// than reported as an error.
+	ForceRecreate bool
 }
 
 // RoleAssignmentSpec defines the specification for a Role Assignment.
@@ -54,6 +73,13 @@ const (
 type NSGSpec struct {
 	Name          string
 	SecurityRules infrav1.SecurityRules
+	// NetworkInterfaceRole is the role of the machines whose network interfaces the security group is attached to,
+	// or empty for the security group of a subnet.
+	NetworkInterfaceRole string
+	// DiagnosticSettings is the diagnostic setting of the security group, which has none when nil.
+	DiagnosticSettings *infrav1.DiagnosticSettings
+	// Tags are the tags of the security group, e.g. the compliance metadata of its subnet.
+	Tags infrav1.Tags
 }
 
 // ScaleSetSpec defines the specification for a Scale Set.
@@ -76,6 +102,8 @@ type ScaleSetSpec struct {
 	SecurityProfile              *infrav1.SecurityProfile
 	SpotVMOptions                *infrav1.SpotVMOptions
 	FailureDomains               []string
+	SecurityGroupName            string
+	ServiceLBAddressPoolIDs      []string
 }
 
 // TagsSpec defines the specification for a set of tags.
@@ -86,6 +114,29 @@ type TagsSpec struct {
 	// The last applied tags are used to find out which tags are being managed by CAPZ
 	// and if any has to be deleted by comparing it with the new desired tags
 	Annotation string
+	// Inherited is true for the tags a resource inherits from its resource group. They only fill the gaps in the tags
+	// of the resource: the tags set on the resource itself are kept.
+	Inherited bool
+}
+
+// DiagnosticSettingsSpec defines the specification for the diagnostic setting of a resource.
+type DiagnosticSettingsSpec struct {
+	Name       string
+	ResourceID string
+	// Settings is the desired diagnostic setting of the resource. The diagnostic setting is removed when nil.
+	Settings *infrav1.DiagnosticSettings
+}
+
+// OutboundConnectivityCheckSpec defines the specification for the outbound connectivity check of a node subnet.
+type OutboundConnectivityCheckSpec struct {
+	VNetResourceGroup           string
+	VNetName                    string
+	SubnetName                  string
+	DestinationIP               string
+	Port                        int32
+	Protocol                    string
+	NetworkWatcherName          string
+	NetworkWatcherResourceGroup string
 }
 
 // PrivateDNSSpec defines the specification for a private DNS zone.
@@ -102,6 +153,15 @@ type PrivateDNSLinkSpec struct {
 	LinkName          string
 }
 
+// DNSPrivateResolverSpec defines the specification for the links of the forwarding rulesets of an existing DNS Private
+// Resolver to a virtual network.
+type DNSPrivateResolverSpec struct {
+	ResolverID           string
+	ForwardingRulesetIDs []string
+	VNetID               string
+	LinkName             string
+}
+
 // ExtensionSpec defines the specification for a VM or VMScaleSet extension.
 type ExtensionSpec struct {
 	Name              string
@@ -0,0 +1,59 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// AuditNetwork compares the live network of the cluster against its spec and reports any drift.
+// It is read-only: no Azure resource is created, updated or deleted.
+func AuditNetwork(ctx context.Context, clusterScope *scope.ClusterScope) (*azure.NetworkAuditReport, error) {
+	return auditNetwork(ctx,
+		virtualnetworks.New(clusterScope),
+		subnets.New(clusterScope),
+		securitygroups.New(clusterScope),
+	)
+}
+
+// networkAuditor reports how the live configuration of a network service differs from the spec.
+type networkAuditor interface {
+	Audit(ctx context.Context) ([]azure.Drift, error)
+}
+
+func auditNetwork(ctx context.Context, auditors ...networkAuditor) (*azure.NetworkAuditReport, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.auditNetwork")
+	defer done()
+
+	report := &azure.NetworkAuditReport{}
+	for _, auditor := range auditors {
+		drifts, err := auditor.Audit(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to audit network")
+		}
+		report.Drifts = append(report.Drifts, drifts...)
+	}
+	return report, nil
+}
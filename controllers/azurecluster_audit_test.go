@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+type fakeAuditor struct {
+	drifts []azure.Drift
+	err    error
+}
+
+func (f fakeAuditor) Audit(_ context.Context) ([]azure.Drift, error) {
+	return f.drifts, f.err
+}
+
+func TestAuditNetwork(t *testing.T) {
+	vnetDrift := azure.Drift{Type: azure.DriftModified, ResourceType: "VirtualNetwork", Name: "my-vnet", Detail: "address space is [10.1.0.0/16], expected [10.0.0.0/16]"}
+	subnetDrift := azure.Drift{Type: azure.DriftMissing, ResourceType: "Subnet", Name: "node-subnet"}
+
+	testcases := []struct {
+		name          string
+		auditors      []networkAuditor
+		expectedDrift []azure.Drift
+		expectedError string
+	}{
+		{
+			name:     "no drift",
+			auditors: []networkAuditor{fakeAuditor{}, fakeAuditor{}},
+		},
+		{
+			name:          "drifts of all auditors are aggregated",
+			auditors:      []networkAuditor{fakeAuditor{drifts: []azure.Drift{vnetDrift}}, fakeAuditor{drifts: []azure.Drift{subnetDrift}}},
+			expectedDrift: []azure.Drift{vnetDrift, subnetDrift},
+		},
+		{
+			name:          "audit fails",
+			auditors:      []networkAuditor{fakeAuditor{err: errors.New("boom")}, fakeAuditor{drifts: []azure.Drift{subnetDrift}}},
+			expectedError: "failed to audit network: boom",
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			report, err := auditNetwork(context.TODO(), tc.auditors...)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(report.Drifts).To(Equal(tc.expectedDrift))
+			g.Expect(report.HasDrift()).To(Equal(len(tc.expectedDrift) > 0))
+		})
+	}
+}
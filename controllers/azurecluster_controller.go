@@ -32,6 +32,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -47,9 +48,23 @@ import (
 // AzureClusterReconciler reconciles an AzureCluster object.
 type AzureClusterReconciler struct {
 	client.Client
-	Recorder                  record.EventRecorder
-	ReconcileTimeout          time.Duration
-	WatchFilterValue          string
+	Recorder             record.EventRecorder
+	ReconcileTimeout     time.Duration
+	WatchFilterValue     string
+	ExpectedEnvironment  string
+	MaxReconcileAttempts int32
+	// AllowedCostCenters are the values the costCenter tag of the clusters must have one of, if any.
+	AllowedCostCenters []string
+	// RequiredSecurityGroupTags are the tags the network security groups of the clusters must have a value for, if any.
+	RequiredSecurityGroupTags []string
+	// RegisterResourceProviders registers the resource providers and features the clusters require in their
+	// subscription when they aren't registered yet.
+	RegisterResourceProviders bool
+	// PhaseTimeouts are the maximum durations of the resource group, network and load balancer phases of a reconcile,
+	// within the ReconcileTimeout of the whole reconcile loop.
+	PhaseTimeouts reconciler.PhaseTimeouts
+	// RetryClassifier classifies the reconcile errors, azure.DefaultRetryClassifier is used when it is nil.
+	RetryClassifier           azure.RetryClassifier
 	createAzureClusterService azureClusterServiceCreator
 }
 
@@ -111,6 +126,8 @@ func (acr *AzureClusterReconciler) SetupWithManager(ctx context.Context, mgr ctr
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azuremachinetemplates;azuremachinetemplates/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azureclusteridentities;azureclusteridentities/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=list;
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
 
 // Reconcile idempotently gets, creates, and updates a cluster.
 func (acr *AzureClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
@@ -174,9 +191,14 @@ func (acr *AzureClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	// Create the scope.
 	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
-		Client:       acr.Client,
-		Cluster:      cluster,
-		AzureCluster: azureCluster,
+		Client:                    acr.Client,
+		Cluster:                   cluster,
+		AzureCluster:              azureCluster,
+		ExpectedEnvironment:       acr.ExpectedEnvironment,
+		AllowedCostCenters:        acr.AllowedCostCenters,
+		RequiredSecurityGroupTags: acr.RequiredSecurityGroupTags,
+		RegisterResourceProviders: acr.RegisterResourceProviders,
+		PhaseTimeouts:             acr.PhaseTimeouts,
 	})
 	if err != nil {
 		err = errors.Errorf("failed to create scope: %+v", err)
@@ -209,17 +231,27 @@ func (acr *AzureClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 
 	// If the AzureCluster doesn't have our finalizer, add it.
 	controllerutil.AddFinalizer(azureCluster, infrav1.ClusterFinalizer)
-	// Register the finalizer immediately to avoid orphaning Azure resources on delete
+	// Record the UID the Azure resources of the cluster are tagged with before any of them is created.
+	clusterScope.SetClusterUID()
+	// Compute the effective configuration once, before any service reads the spec. The defaults are normally
+	// set by the mutating webhook, applying them again is a no-op that doesn't overwrite user-set values.
+	azureCluster.Default()
+	// Register the finalizer and the defaults immediately to avoid orphaning Azure resources on delete
 	if err := clusterScope.PatchObject(ctx); err != nil {
 		return reconcile.Result{}, err
 	}
 
+	for _, warning := range clusterScope.EgressPolicyWarnings() {
+		log.Info(fmt.Sprintf("WARNING, %s", warning))
+		acr.Recorder.Eventf(azureCluster, corev1.EventTypeWarning, "EgressPolicy", warning)
+	}
+
 	acs, err := acr.createAzureClusterService(clusterScope)
 	if err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "failed to create a new AzureClusterReconciler")
 	}
 
-	if err := acs.Reconcile(ctx); err != nil {
+	if err := classifyReconcileError(log, acr.RetryClassifier, acs.Reconcile(ctx)); err != nil {
 		// Handle terminal & transient errors
 		var reconcileError azure.ReconcileError
 		if errors.As(err, &reconcileError) {
@@ -227,6 +259,9 @@ func (acr *AzureClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 				acr.Recorder.Eventf(clusterScope.AzureCluster, corev1.EventTypeWarning, "ReconcileErrror", errors.Wrapf(err, "failed to reconcile AzureCluster").Error())
 				log.Error(err, "failed to reconcile AzureCluster", "name", clusterScope.ClusterName())
 				conditions.MarkFalse(azureCluster, infrav1.NetworkInfrastructureReadyCondition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "")
+				if acr.recordFailedReconcileAttempt(azureCluster, err, capierrors.InvalidConfigurationClusterError) {
+					log.Error(err, "giving up reconciling AzureCluster", "name", clusterScope.ClusterName(), "attempts", azureCluster.Status.FailedReconcileAttempts)
+				}
 				return reconcile.Result{}, nil
 			}
 			if reconcileError.IsTransient() {
@@ -244,13 +279,16 @@ func (acr *AzureClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 		return reconcile.Result{}, wrappedErr
 	}
 
+	resetFailedReconcileAttempts(azureCluster)
+
 	// Set APIEndpoints so the Cluster API Cluster Controller can pull them
 	if azureCluster.Spec.ControlPlaneEndpoint.Host == "" {
 		azureCluster.Spec.ControlPlaneEndpoint.Host = clusterScope.APIServerHost()
 	}
 	if azureCluster.Spec.ControlPlaneEndpoint.Port == 0 {
-		azureCluster.Spec.ControlPlaneEndpoint.Port = clusterScope.APIServerPort()
+		azureCluster.Spec.ControlPlaneEndpoint.Port = clusterScope.APIServerLBPort()
 	}
+	clusterScope.SetControlPlaneEndpoints(clusterScope.APIServerEndpoints()...)
 
 	// No errors, so mark us ready so the Cluster API Cluster Controller can pull it
 	azureCluster.Status.Ready = true
@@ -259,6 +297,35 @@ func (acr *AzureClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 	return reconcile.Result{}, nil
 }
 
+// recordFailedReconcileAttempt counts a reconciliation of the cluster that failed with a terminal error and, once the
+// number of consecutive failures exceeds MaxReconcileAttempts, sets the failure reason and message of the cluster. It
+// returns whether the controller gave up on the cluster. Other errors, e.g. throttling or an outage of Azure, are
+// expected to resolve themselves and aren't counted. A zero MaxReconcileAttempts retries indefinitely.
+func (acr *AzureClusterReconciler) recordFailedReconcileAttempt(azureCluster *infrav1.AzureCluster, err error, reason capierrors.ClusterStatusError) bool {
+	var reconcileError azure.ReconcileError
+	if !errors.As(err, &reconcileError) || !reconcileError.IsTerminal() {
+		return false
+	}
+
+	azureCluster.Status.FailedReconcileAttempts++
+	if acr.MaxReconcileAttempts <= 0 || azureCluster.Status.FailedReconcileAttempts <= acr.MaxReconcileAttempts {
+		return false
+	}
+
+	message := fmt.Sprintf("reconciliation failed %d times in a row: %s", azureCluster.Status.FailedReconcileAttempts, err.Error())
+	azureCluster.Status.FailureReason = &reason
+	azureCluster.Status.FailureMessage = &message
+	acr.Recorder.Eventf(azureCluster, corev1.EventTypeWarning, "ReconcileAttemptsExceeded", message)
+	return true
+}
+
+// resetFailedReconcileAttempts resets the count of failed reconcile attempts of the cluster, and the failure it led to.
+func resetFailedReconcileAttempts(azureCluster *infrav1.AzureCluster) {
+	azureCluster.Status.FailedReconcileAttempts = 0
+	azureCluster.Status.FailureReason = nil
+	azureCluster.Status.FailureMessage = nil
+}
+
 func (acr *AzureClusterReconciler) reconcileDelete(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureClusterReconciler.reconcileDelete")
 	defer done()
@@ -18,13 +18,19 @@ package controllers
 
 import (
 	"context"
+	"errors"
+	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/internal/test"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	capierrors "sigs.k8s.io/cluster-api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -51,3 +57,79 @@ var _ = Describe("AzureClusterReconciler", func() {
 		})
 	})
 })
+
+func TestAzureClusterRecordFailedReconcileAttempt(t *testing.T) {
+	terminalErr := azure.WithTerminalError(errors.New("location not found"))
+	transientErr := azure.WithTransientError(errors.New("too many requests"), time.Minute)
+
+	tests := []struct {
+		name         string
+		maxAttempts  int32
+		err          error
+		failures     int
+		wantAttempts int32
+		wantGivenUp  bool
+	}{
+		{
+			name:         "unlimited attempts",
+			err:          terminalErr,
+			failures:     10,
+			wantAttempts: 10,
+		},
+		{
+			name:         "failures within the limit",
+			maxAttempts:  3,
+			err:          terminalErr,
+			failures:     3,
+			wantAttempts: 3,
+		},
+		{
+			name:         "failures beyond the limit",
+			maxAttempts:  3,
+			err:          terminalErr,
+			failures:     4,
+			wantAttempts: 4,
+			wantGivenUp:  true,
+		},
+		{
+			name:        "transient failures are not counted",
+			maxAttempts: 3,
+			err:         transientErr,
+			failures:    10,
+		},
+		{
+			name:        "unclassified failures are not counted",
+			maxAttempts: 3,
+			err:         errors.New("internal server error"),
+			failures:    10,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			acr := &AzureClusterReconciler{
+				Recorder:             record.NewFakeRecorder(10),
+				MaxReconcileAttempts: tc.maxAttempts,
+			}
+			azureCluster := &infrav1.AzureCluster{}
+
+			var givenUp bool
+			for i := 0; i < tc.failures; i++ {
+				givenUp = acr.recordFailedReconcileAttempt(azureCluster, tc.err, capierrors.InvalidConfigurationClusterError)
+			}
+
+			g.Expect(givenUp).To(Equal(tc.wantGivenUp))
+			g.Expect(azureCluster.Status.FailedReconcileAttempts).To(Equal(tc.wantAttempts))
+			if tc.wantGivenUp {
+				g.Expect(azureCluster.Status.FailureReason).To(Equal(capierrors.ClusterStatusErrorPtr(capierrors.InvalidConfigurationClusterError)))
+				g.Expect(azureCluster.Status.FailureMessage).NotTo(BeNil())
+				g.Expect(*azureCluster.Status.FailureMessage).To(ContainSubstring("location not found"))
+			} else {
+				g.Expect(azureCluster.Status.FailureReason).To(BeNil())
+				g.Expect(azureCluster.Status.FailureMessage).To(BeNil())
+			}
+		})
+	}
+}
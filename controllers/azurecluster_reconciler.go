@@ -18,43 +18,101 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-04-01/compute"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/component-base/featuregate"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/availabilitysets"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/budgets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/costestimates"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/diagnosticsettings"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/dnsresolvers"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/galleryimages"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/grouproleassignments"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/inventory"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/jumpbox"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/locations"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loganalytics"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkwatchers"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/policyassignments"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicipprefixes"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/registrations"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourcehealth"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/trafficmanager"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 // azureClusterService is the reconciler called by the AzureCluster controller.
 type azureClusterService struct {
-	scope            *scope.ClusterScope
-	groupsSvc        azure.Reconciler
-	vnetSvc          azure.Reconciler
-	securityGroupSvc azure.Reconciler
-	routeTableSvc    azure.Reconciler
-	subnetsSvc       azure.Reconciler
-	publicIPSvc      azure.Reconciler
-	loadBalancerSvc  azure.Reconciler
-	privateDNSSvc    azure.Reconciler
-	bastionSvc       azure.Reconciler
-	skuCache         *resourceskus.Cache
-	natGatewaySvc    azure.Reconciler
-	peeringsSvc      azure.Reconciler
-	tagsSvc          azure.Reconciler
+	scope              *scope.ClusterScope
+	groupsSvc          azure.Reconciler
+	diagGroupSvc       azure.Reconciler
+	vnetSvc            azure.Reconciler
+	securityGroupSvc   azure.Reconciler
+	asgSvc             azure.Reconciler
+	routeTableSvc      azure.Reconciler
+	subnetsSvc         azure.Reconciler
+	publicIPSvc        azure.Reconciler
+	ipPrefixSvc        azure.Reconciler
+	loadBalancerSvc    azure.Reconciler
+	trafficMgrSvc      azure.Reconciler
+	privateDNSSvc      azure.Reconciler
+	dnsResolverSvc     azure.Reconciler
+	bastionSvc         azure.Reconciler
+	jumpboxSvc         azure.Reconciler
+	skuCache           *resourceskus.Cache
+	locationsCache     *locations.Cache
+	registrations      *registrations.Cache
+	natGatewaySvc      azure.Reconciler
+	peeringsSvc        azure.Reconciler
+	tagsSvc            azure.Reconciler
+	logAnalyticsSvc    azure.Reconciler
+	diagSettingsSvc    azure.Reconciler
+	networkWatchSvc    azure.Reconciler
+	healthSvc          azure.Reconciler
+	policySvc          azure.Reconciler
+	roleAssignmentSvc  azure.Reconciler
+	budgetSvc          azure.Reconciler
+	costEstimateSvc    azure.Reconciler
+	galleryImageSvc    azure.Reconciler
+	privateEndpointSvc azure.Reconciler
+	availabilitySetSvc azure.Reconciler
+	inventorySvc       *inventory.Service
+	secondaryNetSvc    azure.Reconciler
+	internalLBSvc      azure.Reconciler
+	// staleSecondaryNetSvc returns the services of the network of a secondary region removed from the spec, as it was
+	// last reconciled.
+	staleSecondaryNetSvc func(region *infrav1.SecondaryRegionSpec) azure.Reconciler
 }
 
 // newAzureClusterService populates all the services based on input scope.
@@ -64,21 +122,56 @@ func newAzureClusterService(scope *scope.ClusterScope) (*azureClusterService, er
 		return nil, errors.Wrap(err, "failed creating a NewCache")
 	}
 
+	locationsCache, err := locations.GetCache(scope)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating a locations cache")
+	}
+
+	registrationsCache, err := registrations.GetCache(scope)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating a registrations cache")
+	}
+
 	return &azureClusterService{
-		scope:            scope,
-		groupsSvc:        groups.New(scope),
-		vnetSvc:          virtualnetworks.New(scope),
-		securityGroupSvc: securitygroups.New(scope),
-		routeTableSvc:    routetables.New(scope),
-		natGatewaySvc:    natgateways.New(scope),
-		subnetsSvc:       subnets.New(scope),
-		publicIPSvc:      publicips.New(scope),
-		loadBalancerSvc:  loadbalancers.New(scope),
-		privateDNSSvc:    privatedns.New(scope),
-		bastionSvc:       bastionhosts.New(scope),
-		skuCache:         skuCache,
-		peeringsSvc:      vnetpeerings.New(scope),
-		tagsSvc:          tags.New(scope),
+		scope:              scope,
+		groupsSvc:          groups.New(scope),
+		diagGroupSvc:       newDiagnosticsGroupService(scope),
+		vnetSvc:            virtualnetworks.New(scope),
+		securityGroupSvc:   securitygroups.New(scope),
+		asgSvc:             applicationsecuritygroups.New(scope),
+		routeTableSvc:      routetables.New(scope),
+		natGatewaySvc:      natgateways.New(scope),
+		subnetsSvc:         subnets.New(scope),
+		publicIPSvc:        publicips.New(scope),
+		ipPrefixSvc:        publicipprefixes.New(scope),
+		loadBalancerSvc:    loadbalancers.New(scope),
+		trafficMgrSvc:      trafficmanager.New(scope),
+		privateDNSSvc:      privatedns.New(scope),
+		dnsResolverSvc:     dnsresolvers.New(scope),
+		bastionSvc:         bastionhosts.New(scope),
+		jumpboxSvc:         jumpbox.New(scope, skuCache),
+		skuCache:           skuCache,
+		locationsCache:     locationsCache,
+		registrations:      registrationsCache,
+		peeringsSvc:        vnetpeerings.New(scope),
+		tagsSvc:            tags.New(scope),
+		logAnalyticsSvc:    loganalytics.New(scope),
+		diagSettingsSvc:    diagnosticsettings.New(scope),
+		networkWatchSvc:    networkwatchers.New(scope),
+		healthSvc:          resourcehealth.New(scope),
+		policySvc:          policyassignments.New(scope),
+		roleAssignmentSvc:  grouproleassignments.New(scope),
+		budgetSvc:          budgets.New(scope),
+		costEstimateSvc:    costestimates.New(scope),
+		galleryImageSvc:    galleryimages.New(scope),
+		privateEndpointSvc: privateendpoints.New(scope),
+		availabilitySetSvc: availabilitysets.New(scope, skuCache),
+		inventorySvc:       inventory.New(scope),
+		secondaryNetSvc:    newSecondaryNetworkService(scope),
+		internalLBSvc:      newInternalLoadBalancersService(scope),
+		staleSecondaryNetSvc: func(region *infrav1.SecondaryRegionSpec) azure.Reconciler {
+			return newStaleSecondaryNetworkService(scope, region)
+		},
 	}, nil
 }
 
@@ -93,109 +186,920 @@ func (s *azureClusterService) Reconcile(ctx context.Context) error {
 		return errors.Wrap(err, "failed to get availability zones")
 	}
 
+	s.setPairedRegion(ctx)
+
 	s.scope.SetDNSName()
 	s.scope.SetControlPlaneSecurityRules()
+	s.scope.SetGeneratedSecurityRules()
 
-	if err := s.groupsSvc.Reconcile(ctx); err != nil {
-		return errors.Wrap(err, "failed to reconcile resource group")
+	return s.reconcileSteps(ctx, s.steps())
+}
+
+// reconcileSteps reconciles the steps in order. The failed reconcile attempts of the cluster are reset whenever all the
+// steps of a phase are reconciled, since the reconciliation made progress.
+func (s *azureClusterService) reconcileSteps(ctx context.Context, steps []serviceStep) error {
+	var reconciled []serviceStep
+	for _, step := range steps {
+		// In NetworkOnly mode the other resources are provided by the system managing the rest of the cluster.
+		if step.clusterOnly && s.scope.IsNetworkOnly() {
+			continue
+		}
+		reconciled = append(reconciled, step)
 	}
 
-	if err := s.vnetSvc.Reconcile(ctx); err != nil {
-		return errors.Wrap(err, "failed to reconcile virtual network")
+	phases := newPhaseDeadlines(s.scope.PhaseTimeouts())
+	for i, step := range reconciled {
+		if err := phases.run(ctx, step.phase, step.svc.Reconcile); err != nil {
+			return errors.Wrapf(err, "failed to reconcile %s", step.resource)
+		}
+		if step.phase != "" && (i == len(reconciled)-1 || reconciled[i+1].phase != step.phase) {
+			resetFailedReconcileAttempts(s.scope.AzureCluster)
+		}
 	}
 
-	if err := s.securityGroupSvc.Reconcile(ctx); err != nil {
-		return errors.Wrap(err, "failed to reconcile network security group")
+	return nil
+}
+
+// steps returns the services of the cluster in the order they are reconciled: a resource comes after the resources
+// it references. New kinds of resources are added to the cluster by adding their service to this list, with the
+// kinds of resources that reference them, and thus must be deleted first, as dependents.
+func (s *azureClusterService) steps() []serviceStep {
+	return []serviceStep{
+		// The cost center is checked before any resource is provisioned, so none is created with a wrong one.
+		{resource: "cost center", svc: reconcileFunc(s.validateCostCenter)},
+		// The tags of the security groups are checked for the same reason, before any of them is created.
+		{resource: "security group tags", svc: reconcileFunc(s.validateSecurityGroupTags)},
+		// The registrations are checked before any resource is provisioned, so the cluster isn't left half created.
+		{resource: "resource provider registrations", svc: reconcileFunc(s.validateRegistrations)},
+		{resource: "resource group location", svc: reconcileFunc(s.validateResourceGroupLocation), clusterOnly: true},
+		{resource: "default spot policy", svc: reconcileFunc(s.reconcileDefaultSpotPolicy), clusterOnly: true},
+		{resource: "auto-shutdown schedule", svc: reconcileFunc(s.reconcileAutoShutdownSchedule), clusterOnly: true},
+		{resource: "control plane etcd disks", svc: reconcileFunc(s.validateControlPlaneEtcdDisks), clusterOnly: true},
+		// The gallery image is only read, it isn't managed by the cluster.
+		{resource: "gallery image", svc: s.galleryImageSvc, clusterOnly: true, noDelete: true},
+		// The resource group is deleted with all its resources, see Delete.
+		{resource: "resource group", svc: s.groupsSvc, phase: phaseResourceGroup, clusterOnly: true, noDelete: true},
+		{resource: "diagnostics resource group", svc: stepFuncs{reconcile: s.reconcileDiagnosticsResourceGroup, delete: s.deleteDiagnosticsResourceGroup}, phase: phaseResourceGroup, clusterOnly: true, dependents: []string{"Log Analytics workspace"}},
+		{resource: "policy assignments", svc: gatedService{gate: feature.PolicyAssignments, svc: s.policySvc}, clusterOnly: true},
+		{resource: "role assignments", svc: s.roleAssignmentSvc, clusterOnly: true},
+		{resource: "budget", svc: gatedService{gate: feature.Budgets, svc: s.budgetSvc}, clusterOnly: true},
+		{resource: "availability set", svc: stepFuncs{reconcile: s.reconcileAvailabilitySet, delete: s.deleteAvailabilitySet}, clusterOnly: true},
+		{resource: "virtual network", svc: s.vnetSvc, phase: phaseNetwork, dependents: []string{"private dns", "DNS private resolver links", "peerings", "subnet"}},
+		{resource: "application security groups", svc: s.asgSvc, phase: phaseNetwork, dependents: []string{"jumpbox", "network security group"}},
+		{resource: "network security group", svc: s.securityGroupSvc, phase: phaseNetwork, dependents: []string{"subnet", "diagnostic settings"}},
+		{resource: "route table", svc: s.routeTableSvc, phase: phaseNetwork, dependents: []string{"subnet"}},
+		{resource: "public IP", svc: s.publicIPSvc, phase: phaseNetwork, dependents: []string{"jumpbox", "bastion", "traffic manager", "load balancer", "NAT gateway", "diagnostic settings"}},
+		{resource: "public IP prefix", svc: s.ipPrefixSvc, phase: phaseNetwork, dependents: []string{"NAT gateway"}},
+		{resource: "NAT gateway", svc: s.natGatewaySvc, phase: phaseNetwork, dependents: []string{"subnet"}},
+		{resource: "subnet", svc: s.subnetsSvc, phase: phaseNetwork, dependents: []string{"jumpbox", "bastion", "load balancer", "internal load balancers", "private endpoints"}},
+		{resource: "peerings", svc: s.peeringsSvc, phase: phaseNetwork},
+		{resource: "secondary region network", svc: stepFuncs{reconcile: s.reconcileSecondaryNetwork, delete: s.deleteSecondaryNetwork}, phase: phaseNetwork},
+		{resource: "private endpoints", svc: s.privateEndpointSvc},
+		{resource: "load balancer", svc: s.loadBalancerSvc, phase: phaseLoadBalancer, dependents: []string{"diagnostic settings"}},
+		{resource: "internal load balancers", svc: stepFuncs{reconcile: s.reconcileInternalLoadBalancers, delete: s.deleteInternalLoadBalancers}, phase: phaseLoadBalancer},
+		{resource: "diagnostic settings", svc: s.diagSettingsSvc},
+		{resource: "traffic manager", svc: s.trafficMgrSvc},
+		{resource: "DNS private resolver links", svc: s.dnsResolverSvc},
+		{resource: "private dns", svc: s.privateDNSSvc},
+		{resource: "bastion", svc: s.bastionSvc},
+		{resource: "jumpbox", svc: s.jumpboxSvc, clusterOnly: true},
+		{resource: "Log Analytics workspace", svc: s.logAnalyticsSvc, clusterOnly: true},
+		{resource: "Log Analytics shared key secret", svc: reconcileFunc(s.reconcileLogAnalyticsSharedKey), clusterOnly: true},
+		{resource: "outbound connectivity check", svc: reconcileFunc(s.verifyOutboundConnectivity)},
+		{resource: "resource health", svc: reconcileFunc(s.checkResourceHealth)},
+		{resource: "cost estimate", svc: reconcileFunc(s.estimateCost)},
+		// Tags are removed with the resources they are applied to.
+		{resource: "tags", svc: s.tagsSvc, clusterOnly: true, noDelete: true},
+		// The inventory ConfigMap is garbage collected with the AzureCluster that owns it.
+		{resource: "inventory", svc: reconcileFunc(s.reconcileInventory), noDelete: true},
 	}
+}
 
-	if err := s.routeTableSvc.Reconcile(ctx); err != nil {
-		return errors.Wrap(err, "failed to reconcile route table")
+// verifyOutboundConnectivity runs the outbound connectivity check of the cluster. Issues are reported in the
+// OutboundConnectivityVerified condition and never block the reconciliation of the cluster.
+func (s *azureClusterService) verifyOutboundConnectivity(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.verifyOutboundConnectivity")
+	defer done()
+
+	if !feature.Gates.Enabled(feature.OutboundConnectivityCheck) {
+		return nil
+	}
+	if err := s.networkWatchSvc.Reconcile(ctx); err != nil {
+		log.Error(err, "failed to verify outbound connectivity")
 	}
+	return nil
+}
 
-	if err := s.publicIPSvc.Reconcile(ctx); err != nil {
-		return errors.Wrap(err, "failed to reconcile public IP")
+// checkResourceHealth reports the health of the key resources of the cluster from Azure Resource Health. Issues are
+// reported in the ResourcesHealthy condition and never block the reconciliation of the cluster.
+func (s *azureClusterService) checkResourceHealth(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.checkResourceHealth")
+	defer done()
+
+	if !feature.Gates.Enabled(feature.ResourceHealth) {
+		return nil
+	}
+	if err := s.healthSvc.Reconcile(ctx); err != nil {
+		log.Error(err, "failed to check resource health")
 	}
+	return nil
+}
 
-	if err := s.natGatewaySvc.Reconcile(ctx); err != nil {
-		return errors.Wrapf(err, "failed to reconcile NAT gateway")
+// estimateCost reports an estimate of the monthly cost of the network resources of the cluster in its status. The
+// estimate is omitted when the retail prices of the region can't be retrieved, which never blocks the reconciliation
+// of the cluster.
+func (s *azureClusterService) estimateCost(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.estimateCost")
+	defer done()
+
+	if !feature.Gates.Enabled(feature.CostEstimation) {
+		s.scope.SetCostEstimate(nil)
+		return nil
+	}
+	if err := s.costEstimateSvc.Reconcile(ctx); err != nil {
+		log.Error(err, "failed to estimate the monthly cost of the cluster")
 	}
+	return nil
+}
 
-	if err := s.subnetsSvc.Reconcile(ctx); err != nil {
-		return errors.Wrapf(err, "failed to reconcile subnet")
+// Delete reconciles all the services in a predetermined order.
+func (s *azureClusterService) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.Delete")
+	defer done()
+
+	if s.scope.IsDeleteProtected() {
+		return azure.WithTerminalError(errors.Errorf("cluster %s is protected from deletion by the %s annotation, remove the annotation to delete it",
+			s.scope.ClusterName(), azure.DeleteProtectionAnnotation))
 	}
 
-	if err := s.peeringsSvc.Reconcile(ctx); err != nil {
-		return errors.Wrap(err, "failed to reconcile peerings")
+	if err := s.waitForDeleteGracePeriod(ctx); err != nil {
+		return err
 	}
 
-	if err := s.loadBalancerSvc.Reconcile(ctx); err != nil {
-		return errors.Wrap(err, "failed to reconcile load balancer")
+	// In NetworkOnly mode the resource group is never deleted, even when it is owned by the cluster.
+	if s.scope.IsNetworkOnly() {
+		return s.deleteResources(ctx)
 	}
 
-	if err := s.privateDNSSvc.Reconcile(ctx); err != nil {
-		return errors.Wrap(err, "failed to reconcile private dns")
+	phases := newPhaseDeadlines(s.scope.PhaseTimeouts())
+	// The diagnostics resources aren't in the resource group of the cluster, they aren't deleted with it.
+	if err := phases.run(ctx, phaseResourceGroup, s.deleteDiagnosticsResources); err != nil {
+		return errors.Wrap(err, "failed to delete diagnostics resources")
+	}
+	if err := phases.run(ctx, phaseResourceGroup, s.groupsSvc.Delete); err != nil {
+		if errors.Is(err, azure.ErrNotOwned) {
+			return s.deleteResources(ctx)
+		}
+		return errors.Wrap(err, "failed to delete resource group")
 	}
 
-	if err := s.bastionSvc.Reconcile(ctx); err != nil {
-		return errors.Wrap(err, "failed to reconcile bastion")
+	return nil
+}
+
+// deleteResources deletes the resources of the cluster one by one, for a resource group that isn't deleted with them.
+// Azure rejects the deletion of a resource still referenced by another one, e.g. of a public IP used by a load balancer,
+// so the resources are deleted in the reverse order of their reconciliation, which is checked against the dependents
+// of each step. Each service only deletes the resources owned by the cluster, as identified by their tags, and an
+// ongoing deletion is returned as an error to requeue before the next step. In NetworkOnly mode the steps that aren't
+// reconciled aren't deleted either.
+func (s *azureClusterService) deleteResources(ctx context.Context) error {
+	steps := s.steps()
+	reversed := make([]serviceStep, 0, len(steps))
+	for i := len(steps) - 1; i >= 0; i-- {
+		if !steps[i].noDelete {
+			reversed = append(reversed, steps[i])
+		}
 	}
 
-	if err := s.tagsSvc.Reconcile(ctx); err != nil {
-		return errors.Wrap(err, "unable to update tags")
+	ordered, err := orderDeletionSteps(reversed)
+	if err != nil {
+		return err
+	}
+
+	phases := newPhaseDeadlines(s.scope.PhaseTimeouts())
+	for _, step := range ordered {
+		if step.clusterOnly && s.scope.IsNetworkOnly() {
+			continue
+		}
+		if err := phases.run(ctx, step.phase, step.svc.Delete); err != nil {
+			return errors.Wrapf(err, "failed to delete %s", step.resource)
+		}
 	}
 
 	return nil
 }
 
-// Delete reconciles all the services in a predetermined order.
-func (s *azureClusterService) Delete(ctx context.Context) error {
-	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.Delete")
-	defer done()
+// serviceStep reconciles and deletes a kind of resource of the cluster.
+type serviceStep struct {
+	resource string
+	svc      azure.Reconciler
+	// dependents are the kinds of resources that reference this one, and must be deleted before it.
+	dependents []string
+	// clusterOnly steps aren't reconciled in NetworkOnly mode.
+	clusterOnly bool
+	// noDelete steps are skipped when deleting the resources of the cluster one by one.
+	noDelete bool
+	// phase is the phase of the reconciliation whose timeout bounds the step, if any.
+	phase reconcilePhase
+}
 
-	if err := s.groupsSvc.Delete(ctx); err != nil {
-		if errors.Is(err, azure.ErrNotOwned) {
-			if err := s.bastionSvc.Delete(ctx); err != nil {
-				return errors.Wrap(err, "failed to delete bastion")
-			}
+// reconcilePhase is a group of steps whose reconciliation, or deletion, is bounded by a common timeout.
+type reconcilePhase string
 
-			if err := s.privateDNSSvc.Delete(ctx); err != nil {
-				return errors.Wrap(err, "failed to delete private dns")
-			}
+const (
+	phaseResourceGroup reconcilePhase = "resource group"
+	phaseNetwork       reconcilePhase = "network"
+	phaseLoadBalancer  reconcilePhase = "load balancer"
+)
 
-			if err := s.loadBalancerSvc.Delete(ctx); err != nil {
-				return errors.Wrap(err, "failed to delete load balancer")
-			}
+// phaseDeadlines bounds the steps of each phase of a reconciliation with the timeout of the phase, counted from the
+// start of its first step.
+type phaseDeadlines struct {
+	timeouts  reconciler.PhaseTimeouts
+	deadlines map[reconcilePhase]time.Time
+}
 
-			if err := s.peeringsSvc.Delete(ctx); err != nil {
-				return errors.Wrap(err, "failed to delete peerings")
-			}
+func newPhaseDeadlines(timeouts reconciler.PhaseTimeouts) *phaseDeadlines {
+	return &phaseDeadlines{timeouts: timeouts, deadlines: make(map[reconcilePhase]time.Time)}
+}
 
-			if err := s.subnetsSvc.Delete(ctx); err != nil {
-				return errors.Wrap(err, "failed to delete subnet")
-			}
+// timeout returns the timeout of a phase, zero for the steps that aren't part of a phase.
+func (p *phaseDeadlines) timeout(phase reconcilePhase) time.Duration {
+	switch phase {
+	case phaseResourceGroup:
+		return p.timeouts.ResourceGroup
+	case phaseNetwork:
+		return p.timeouts.Network
+	case phaseLoadBalancer:
+		return p.timeouts.LoadBalancer
+	default:
+		return 0
+	}
+}
 
-			if err := s.natGatewaySvc.Delete(ctx); err != nil {
-				return errors.Wrapf(err, "failed to delete NAT gateway")
-			}
+// run runs a step of a phase before the deadline of the phase. A step interrupted by the deadline returns a transient
+// error to requeue, so that the slow operation is resumed by the next reconciliation.
+func (p *phaseDeadlines) run(ctx context.Context, phase reconcilePhase, step func(context.Context) error) error {
+	timeout := p.timeout(phase)
+	if timeout <= 0 {
+		return step(ctx)
+	}
 
-			if err := s.publicIPSvc.Delete(ctx); err != nil {
-				return errors.Wrapf(err, "failed to delete public IP")
-			}
+	deadline, ok := p.deadlines[phase]
+	if !ok {
+		deadline = time.Now().Add(timeout)
+		p.deadlines[phase] = deadline
+	}
+	phaseCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	err := step(phaseCtx)
+	if errors.Is(phaseCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		return azure.WithTransientError(errors.Errorf("%s phase exceeded its timeout of %s", phase, timeout), reconciler.DefaultReconcilerRequeue)
+	}
+	return err
+}
+
+// reconcileFunc is a step of the reconciliation that has nothing to delete.
+type reconcileFunc func(ctx context.Context) error
+
+// Reconcile runs the step.
+func (f reconcileFunc) Reconcile(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Delete does nothing.
+func (f reconcileFunc) Delete(_ context.Context) error {
+	return nil
+}
+
+// stepFuncs is a step of the reconciliation made of functions, for the steps that prepare or follow up on the
+// reconciliation of their service.
+type stepFuncs struct {
+	reconcile func(ctx context.Context) error
+	delete    func(ctx context.Context) error
+}
 
-			if err := s.routeTableSvc.Delete(ctx); err != nil {
-				return errors.Wrap(err, "failed to delete route table")
+// Reconcile runs the reconcile function of the step.
+func (f stepFuncs) Reconcile(ctx context.Context) error {
+	return f.reconcile(ctx)
+}
+
+// Delete runs the delete function of the step.
+func (f stepFuncs) Delete(ctx context.Context) error {
+	return f.delete(ctx)
+}
+
+// gatedService is a step that is only reconciled and deleted when its feature gate is enabled.
+type gatedService struct {
+	gate featuregate.Feature
+	svc  azure.Reconciler
+}
+
+// Reconcile reconciles the service if its feature gate is enabled.
+func (g gatedService) Reconcile(ctx context.Context) error {
+	if !feature.Gates.Enabled(g.gate) {
+		return nil
+	}
+	return g.svc.Reconcile(ctx)
+}
+
+// Delete deletes the resources of the service if its feature gate is enabled.
+func (g gatedService) Delete(ctx context.Context) error {
+	if !feature.Gates.Enabled(g.gate) {
+		return nil
+	}
+	return g.svc.Delete(ctx)
+}
+
+// orderDeletionSteps sorts the deletion steps topologically, so that each step comes after the steps deleting its
+// dependents. Independent steps keep their relative order.
+func orderDeletionSteps(steps []serviceStep) ([]serviceStep, error) {
+	known := make(map[string]bool, len(steps))
+	for _, step := range steps {
+		known[step.resource] = true
+	}
+	for _, step := range steps {
+		for _, dependent := range step.dependents {
+			if !known[dependent] {
+				return nil, errors.Errorf("%s depends on the deletion of unknown resource %s", step.resource, dependent)
 			}
+		}
+	}
 
-			if err := s.securityGroupSvc.Delete(ctx); err != nil {
-				return errors.Wrap(err, "failed to delete network security group")
+	ordered := make([]serviceStep, 0, len(steps))
+	deleted := make(map[string]bool, len(steps))
+	remaining := steps
+	for len(remaining) > 0 {
+		next := -1
+		for i, step := range remaining {
+			ready := true
+			for _, dependent := range step.dependents {
+				if !deleted[dependent] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				next = i
+				break
 			}
+		}
+		if next < 0 {
+			return nil, errors.Errorf("cyclic dependency between the deletions of %s", remaining[0].resource)
+		}
+
+		ordered = append(ordered, remaining[next])
+		deleted[remaining[next].resource] = true
+		remaining = append(remaining[:next:next], remaining[next+1:]...)
+	}
+
+	return ordered, nil
+}
+
+// setPairedRegion records the region paired with the location of the cluster, for disaster recovery planning. The
+// paired region is informational: failing to get it doesn't fail the reconcile, it is retried on the next one.
+func (s *azureClusterService) setPairedRegion(ctx context.Context) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.setPairedRegion")
+	defer done()
+
+	pairedRegion, err := s.locationsCache.GetPairedRegion(ctx, s.scope.Location())
+	if err != nil {
+		log.Error(err, "failed to get paired region", "location", s.scope.Location())
+		return
+	}
+
+	s.scope.SetPairedRegion(pairedRegion)
+}
+
+// validateResourceGroupLocation checks that the location of the resource group is available to the subscription when
+// it's not the location of the cluster, which is already checked when getting its paired region. Only a location that
+// isn't available is terminal, a failure to list the locations is retried.
+func (s *azureClusterService) validateResourceGroupLocation(ctx context.Context) error {
+	location := s.scope.ResourceGroupLocation()
+	if strings.EqualFold(location, s.scope.Location()) {
+		return nil
+	}
+
+	if err := s.locationsCache.ValidateLocation(ctx, location); locations.IsLocationNotAvailable(err) {
+		return azure.WithTerminalError(err)
+	} else if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// newDiagnosticsGroupService creates the group service of the diagnostics resource group of a cluster.
+func newDiagnosticsGroupService(clusterScope *scope.ClusterScope) *groups.Service {
+	return groups.New(scope.NewDiagnosticsGroupScope(clusterScope))
+}
 
-			if err := s.vnetSvc.Delete(ctx); err != nil {
-				return errors.Wrap(err, "failed to delete virtual network")
+// reconcileDiagnosticsResourceGroup reconciles the resource group of the diagnostics resources of the cluster, if
+// any. An existing resource group is adopted, but must be in the configured location.
+func (s *azureClusterService) reconcileDiagnosticsResourceGroup(ctx context.Context) error {
+	if s.scope.DiagnosticsResourceGroup() == nil {
+		return nil
+	}
+
+	location := scope.NewDiagnosticsGroupScope(s.scope).DiagnosticsResourceGroupLocation()
+	if !strings.EqualFold(location, s.scope.Location()) {
+		if err := s.locationsCache.ValidateLocation(ctx, location); locations.IsLocationNotAvailable(err) {
+			return azure.WithTerminalError(err)
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if err := s.diagGroupSvc.Reconcile(ctx); err != nil {
+		return err
+	}
+
+	if reported := s.scope.ReportedDiagnosticsResourceGroupLocation(); reported != "" && !strings.EqualFold(reported, location) {
+		return azure.WithTerminalError(errors.Errorf("diagnostics resource group %s is in location %s instead of %s",
+			s.scope.DiagnosticsResourceGroup().Name, reported, location))
+	}
+	return nil
+}
+
+// deleteDiagnosticsResourceGroup deletes the resource group of the diagnostics resources of the cluster, if any and
+// if it was created by CAPZ.
+func (s *azureClusterService) deleteDiagnosticsResourceGroup(ctx context.Context) error {
+	if s.scope.DiagnosticsResourceGroup() == nil {
+		return nil
+	}
+	if err := s.diagGroupSvc.Delete(ctx); err != nil && !errors.Is(err, azure.ErrNotOwned) {
+		return err
+	}
+	return nil
+}
+
+// deleteDiagnosticsResources deletes the diagnostics resources of the cluster placed in a diagnostics resource group,
+// the Log Analytics workspace first in case the resource group is adopted, and then the resource group.
+func (s *azureClusterService) deleteDiagnosticsResources(ctx context.Context) error {
+	if s.scope.DiagnosticsResourceGroup() == nil {
+		return nil
+	}
+	if err := s.logAnalyticsSvc.Delete(ctx); err != nil {
+		return errors.Wrap(err, "failed to delete Log Analytics workspace")
+	}
+	return s.deleteDiagnosticsResourceGroup(ctx)
+}
+
+// validateCostCenter fails the reconciliation of a cluster whose costCenter tag isn't allowed. The tag is applied to
+// all the resources of the cluster with the other additional tags.
+func (s *azureClusterService) validateCostCenter(_ context.Context) error {
+	if err := s.scope.ValidateCostCenter(); err != nil {
+		return azure.WithTerminalError(err)
+	}
+
+	return nil
+}
+
+// validateSecurityGroupTags fails the reconciliation of a cluster with a network security group missing one of the
+// required tags.
+func (s *azureClusterService) validateSecurityGroupTags(_ context.Context) error {
+	if err := s.scope.ValidateSecurityGroupTags(); err != nil {
+		return azure.WithTerminalError(err)
+	}
+
+	return nil
+}
+
+// validateRegistrations checks the resource providers and features the cluster requires are registered in its
+// subscription, and registers them when the controller is allowed to.
+func (s *azureClusterService) validateRegistrations(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.validateRegistrations")
+	defer done()
+
+	return s.registrations.Validate(ctx, s.scope.RequiredRegistrations(), s.scope.RegisterResourceProviders())
+}
+
+// reconcileDefaultSpotPolicy validates the default Spot VM policy of the cluster, as it may not have gone through the
+// webhooks, and publishes it in the AzureCluster status for the machine actuators. No Azure resource is involved.
+func (s *azureClusterService) reconcileDefaultSpotPolicy(_ context.Context) error {
+	if errs := infrav1.ValidateSpotPolicy(s.scope.DefaultSpotPolicy(), field.NewPath("spec", "defaultSpotPolicy")); len(errs) > 0 {
+		return azure.WithTerminalError(errors.Wrap(errs.ToAggregate(), "invalid default spot policy"))
+	}
+
+	s.scope.SetDefaultSpotPolicy(s.scope.DefaultSpotPolicy())
+
+	return nil
+}
+
+// reconcileAutoShutdownSchedule validates the auto-shutdown schedule of the cluster, as it may not have gone through the
+// webhooks, and publishes it in the AzureCluster status for the machine actuators. No Azure resource is involved, the
+// schedule is only applied as a tag to the resources of the cluster.
+func (s *azureClusterService) reconcileAutoShutdownSchedule(_ context.Context) error {
+	if errs := infrav1.ValidateAutoShutdownSchedule(s.scope.AutoShutdownSchedule(), field.NewPath("spec", "autoShutdown")); len(errs) > 0 {
+		return azure.WithTerminalError(errors.Wrap(errs.ToAggregate(), "invalid auto-shutdown schedule"))
+	}
+
+	s.scope.SetAutoShutdownSchedule(s.scope.AutoShutdownSchedule())
+
+	return nil
+}
+
+// validateControlPlaneEtcdDisks checks that the VM size of the control plane machines supports their etcd data disks in
+// every availability zone of the control plane, so that the creation of the machines doesn't fail later on, and
+// records the compatibility in the AzureCluster status. The zones are those of the failure domains of the cluster,
+// as the subnets of Azure span all the zones of their location.
+func (s *azureClusterService) validateControlPlaneEtcdDisks(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.validateControlPlaneEtcdDisks")
+	defer done()
+
+	etcdDisks := s.scope.ControlPlaneEtcdDisks()
+	if etcdDisks == nil {
+		s.scope.ClearControlPlaneEtcdDisksStatus()
+		return nil
+	}
+
+	sku, err := s.skuCache.Get(ctx, etcdDisks.VMSize, resourceskus.VirtualMachines)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get VM size %s of the control plane in compute api", etcdDisks.VMSize)
+	}
+
+	zones := s.scope.FailureDomains()
+	sort.Strings(zones)
+	if len(zones) == 0 {
+		// The machine actuator only attaches ultra disks to zonal machines.
+		s.scope.SetControlPlaneEtcdDisksNotCompatible(infrav1.EtcdDisksNotSupportedReason, clusterv1.ConditionSeverityError,
+			"location %s has no availability zones", s.scope.Location())
+		return azure.WithTerminalError(errors.Errorf("%s etcd disks require availability zones, which location %s doesn't have", etcdDisks.StorageAccountType, s.scope.Location()))
+	}
+
+	var unsupported []string
+	for _, zone := range zones {
+		if !sku.HasLocationCapability(resourceskus.UltraSSDAvailable, s.scope.Location(), zone) {
+			unsupported = append(unsupported, zone)
+		}
+	}
+	if len(unsupported) > 0 {
+		s.scope.SetControlPlaneEtcdDisksNotCompatible(infrav1.EtcdDisksNotSupportedReason, clusterv1.ConditionSeverityError,
+			"VM size %s doesn't support %s disks in zones %s", etcdDisks.VMSize, etcdDisks.StorageAccountType, strings.Join(unsupported, ", "))
+		return azure.WithTerminalError(errors.Errorf("VM size %s doesn't support %s etcd disks in zones %s of location %s: select another VM size or location",
+			etcdDisks.VMSize, etcdDisks.StorageAccountType, strings.Join(unsupported, ", "), s.scope.Location()))
+	}
+
+	s.scope.SetControlPlaneEtcdDisksCompatible(zones)
+	return nil
+}
+
+// reconcileLogAnalyticsSharedKey stores the shared key of the Log Analytics workspace in the secret configured in the
+// AzureCluster spec and references that secret in the AzureCluster status.
+func (s *azureClusterService) reconcileLogAnalyticsSharedKey(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.reconcileLogAnalyticsSharedKey")
+	defer done()
+
+	sharedKey := s.scope.LogAnalyticsSharedKey()
+	if sharedKey == "" {
+		return nil
+	}
+	sharedKeySecret := s.scope.MakeEmptyLogAnalyticsSharedKeySecret()
+
+	// Always update the key in case of rotation
+	if _, err := controllerutil.CreateOrUpdate(ctx, s.scope.Client, &sharedKeySecret, func() error {
+		sharedKeySecret.Data = map[string][]byte{
+			infrav1.LogAnalyticsSharedKeySecretKey: []byte(sharedKey),
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "failed to store Log Analytics shared key secret")
+	}
+
+	s.scope.SetLogAnalyticsSharedKeySecretRef(&corev1.SecretReference{
+		Name:      sharedKeySecret.Name,
+		Namespace: sharedKeySecret.Namespace,
+	})
+	return nil
+}
+
+// reconcileAvailabilitySet reconciles the availability set of the control plane, for its high availability in the
+// locations without availability zones, and records its ID in the status for the machine actuator.
+func (s *azureClusterService) reconcileAvailabilitySet(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.reconcileAvailabilitySet")
+	defer done()
+
+	spec := s.scope.AvailabilitySetSpec()
+	if spec == nil {
+		s.scope.SetControlPlaneAvailabilitySetID("")
+		return nil
+	}
+
+	sku, err := s.skuCache.Get(ctx, string(compute.AvailabilitySetSkuTypesAligned), resourceskus.AvailabilitySets)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get availability set SKU %s in compute api", string(compute.AvailabilitySetSkuTypesAligned))
+	}
+	s.scope.SetAvailabilitySetSKU(&sku)
+
+	if err := s.availabilitySetSvc.Reconcile(ctx); err != nil {
+		return err
+	}
+
+	s.scope.SetControlPlaneAvailabilitySetID(azure.AvailabilitySetID(s.scope.SubscriptionID(), spec.ResourceGroupName(), spec.ResourceName()))
+	return nil
+}
+
+// deleteAvailabilitySet deletes the availability set of the control plane, once empty, if it was created by CAPZ.
+func (s *azureClusterService) deleteAvailabilitySet(ctx context.Context) error {
+	if s.scope.AvailabilitySetSpec() == nil {
+		return nil
+	}
+	return s.availabilitySetSvc.Delete(ctx)
+}
+
+// reconcileSecondaryNetwork reconciles the network of the secondary region of the cluster, if any, for a warm standby.
+// The network of a secondary region removed from the spec is deleted.
+func (s *azureClusterService) reconcileSecondaryNetwork(ctx context.Context) error {
+	if s.scope.SecondaryRegion() == nil {
+		return s.deleteStaleSecondaryNetwork(ctx)
+	}
+	return s.secondaryNetSvc.Reconcile(ctx)
+}
+
+// deleteSecondaryNetwork deletes the network of the secondary region of the cluster, if any, or the network of a
+// secondary region removed from the spec that wasn't deleted yet.
+func (s *azureClusterService) deleteSecondaryNetwork(ctx context.Context) error {
+	if s.scope.SecondaryRegion() == nil {
+		return s.deleteStaleSecondaryNetwork(ctx)
+	}
+	return s.secondaryNetSvc.Delete(ctx)
+}
+
+// deleteStaleSecondaryNetwork deletes the network of the secondary region last reconciled, once the secondary region is
+// removed from the spec, and removes it from the status of the cluster. Only the resources owned by the cluster are
+// deleted, as when the cluster is deleted.
+func (s *azureClusterService) deleteStaleSecondaryNetwork(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.deleteStaleSecondaryNetwork")
+	defer done()
+
+	lastApplied, err := s.scope.SecondaryRegionLastApplied()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the last applied secondary region")
+	}
+	if lastApplied != nil {
+		if err := s.staleSecondaryNetSvc(lastApplied).Delete(ctx); err != nil {
+			return errors.Wrap(err, "failed to delete the network of the secondary region removed from the spec")
+		}
+		s.scope.ClearSecondaryRegionLastApplied()
+	}
+
+	s.scope.ClearSecondaryNetworkStatus()
+	return nil
+}
+
+// secondaryNetworkService reconciles the network of the secondary region of a cluster with the network services of
+// the cluster, on the scope of the secondary region.
+type secondaryNetworkService struct {
+	scope            *scope.SecondaryRegionScope
+	vnetSvc          azure.Reconciler
+	securityGroupSvc azure.Reconciler
+	subnetsSvc       azure.Reconciler
+}
+
+// newSecondaryNetworkService creates the services of the network of the secondary region of a cluster.
+func newSecondaryNetworkService(clusterScope *scope.ClusterScope) *secondaryNetworkService {
+	return newSecondaryRegionNetworkService(scope.NewSecondaryRegionScope(clusterScope))
+}
+
+// newStaleSecondaryNetworkService creates the services of the network of a secondary region removed from the spec of a
+// cluster, as it was last reconciled.
+func newStaleSecondaryNetworkService(clusterScope *scope.ClusterScope, region *infrav1.SecondaryRegionSpec) *secondaryNetworkService {
+	return newSecondaryRegionNetworkService(scope.NewStaleSecondaryRegionScope(clusterScope, region))
+}
+
+// newSecondaryRegionNetworkService creates the network services of a cluster on the scope of a secondary region.
+func newSecondaryRegionNetworkService(secondaryScope *scope.SecondaryRegionScope) *secondaryNetworkService {
+	return &secondaryNetworkService{
+		scope:            secondaryScope,
+		vnetSvc:          virtualnetworks.New(secondaryScope),
+		securityGroupSvc: securitygroups.New(secondaryScope),
+		subnetsSvc:       subnets.New(secondaryScope),
+	}
+}
+
+// Reconcile reconciles the virtual network, the security groups and the subnets of the secondary region, and records
+// them in the status of the cluster. The load balancers and public IPs of a failover aren't created in the secondary
+// region.
+func (s *secondaryNetworkService) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.secondaryNetworkService.Reconcile")
+	defer done()
+
+	if s.scope.Location() == "" {
+		return azure.WithTerminalError(errors.Errorf("location %s has no paired region, the location of the secondary region must be set", s.scope.ClusterScope.Location()))
+	}
+
+	// The secondary region is recorded before any of its resources is created, so that they are deleted once it is
+	// removed from the spec.
+	if err := s.scope.UpdateLastApplied(); err != nil {
+		return err
+	}
+
+	if err := s.vnetSvc.Reconcile(ctx); err != nil {
+		return errors.Wrap(err, "failed to reconcile the virtual network of the secondary region")
+	}
+	if err := s.securityGroupSvc.Reconcile(ctx); err != nil {
+		return errors.Wrap(err, "failed to reconcile the security groups of the secondary region")
+	}
+	if err := s.subnetsSvc.Reconcile(ctx); err != nil {
+		return errors.Wrap(err, "failed to reconcile the subnets of the secondary region")
+	}
+
+	s.scope.UpdateNetworkStatus()
+	return nil
+}
+
+// Delete deletes the subnets, the security groups and the virtual network of the secondary region, in the reverse order
+// of their reconciliation.
+func (s *secondaryNetworkService) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.secondaryNetworkService.Delete")
+	defer done()
+
+	if err := s.subnetsSvc.Delete(ctx); err != nil {
+		return errors.Wrap(err, "failed to delete the subnets of the secondary region")
+	}
+	if err := s.securityGroupSvc.Delete(ctx); err != nil {
+		return errors.Wrap(err, "failed to delete the security groups of the secondary region")
+	}
+	if err := s.vnetSvc.Delete(ctx); err != nil {
+		return errors.Wrap(err, "failed to delete the virtual network of the secondary region")
+	}
+	return nil
+}
+
+// reconcileInternalLoadBalancers reconciles the internal load balancers fronting services of the cluster, if any.
+func (s *azureClusterService) reconcileInternalLoadBalancers(ctx context.Context) error {
+	if !s.scope.HasInternalLoadBalancers() {
+		return nil
+	}
+	return s.internalLBSvc.Reconcile(ctx)
+}
+
+// deleteInternalLoadBalancers deletes the internal load balancers fronting services of the cluster, if any.
+func (s *azureClusterService) deleteInternalLoadBalancers(ctx context.Context) error {
+	if !s.scope.HasInternalLoadBalancers() {
+		return nil
+	}
+	return s.internalLBSvc.Delete(ctx)
+}
+
+// internalLoadBalancersService reconciles the internal load balancers fronting services of a cluster with the load
+// balancer service, on the scope of the internal load balancers, and deletes the ones removed from the spec.
+type internalLoadBalancersService struct {
+	scope *scope.ClusterScope
+	lbSvc azure.Reconciler
+	// staleLBSvc returns the load balancer service of the load balancers removed from the spec, given by name with
+	// their resource group.
+	staleLBSvc func(stale map[string]string) azure.Reconciler
+}
+
+// newInternalLoadBalancersService creates the services of the internal load balancers fronting services of a cluster.
+func newInternalLoadBalancersService(clusterScope *scope.ClusterScope) *internalLoadBalancersService {
+	return &internalLoadBalancersService{
+		scope: clusterScope,
+		lbSvc: loadbalancers.New(scope.NewInternalLoadBalancersScope(clusterScope)),
+		staleLBSvc: func(stale map[string]string) azure.Reconciler {
+			return loadbalancers.New(scope.NewStaleInternalLoadBalancersScope(clusterScope, stale))
+		},
+	}
+}
+
+// Reconcile creates or updates the internal load balancers of the spec, then deletes the ones removed from the spec
+// since they were last reconciled. The load balancers are tracked in an annotation until they are deleted.
+func (s *internalLoadBalancersService) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.internalLoadBalancersService.Reconcile")
+	defer done()
+
+	if err := s.lbSvc.Reconcile(ctx); err != nil {
+		return err
+	}
+
+	lastApplied, err := s.scope.AnnotationJSON(azure.InternalLoadBalancersLastAppliedAnnotation)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the last applied internal load balancers")
+	}
+	tracked := make(map[string]interface{}, len(s.scope.InternalLoadBalancers()))
+	for _, lb := range s.scope.InternalLoadBalancers() {
+		tracked[lb.Name] = s.scope.ResourceGroup()
+	}
+
+	var result error
+	if stale := s.staleLoadBalancers(lastApplied, tracked); len(stale) != 0 {
+		if err := s.staleLBSvc(stale).Delete(ctx); err != nil {
+			result = errors.Wrap(err, "failed to delete the internal load balancers removed from the spec")
+			for name, resourceGroup := range stale {
+				tracked[name] = resourceGroup
 			}
+		}
+	}
+
+	if !reflect.DeepEqual(tracked, lastApplied) {
+		if err := s.scope.UpdateAnnotationJSON(azure.InternalLoadBalancersLastAppliedAnnotation, tracked); err != nil {
+			return errors.Wrap(err, "failed to update the last applied internal load balancers")
+		}
+	}
+
+	return result
+}
+
+// Delete deletes the internal load balancers of the spec, and the ones removed from the spec that weren't deleted yet.
+func (s *internalLoadBalancersService) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.internalLoadBalancersService.Delete")
+	defer done()
+
+	if err := s.lbSvc.Delete(ctx); err != nil {
+		return err
+	}
+
+	lastApplied, err := s.scope.AnnotationJSON(azure.InternalLoadBalancersLastAppliedAnnotation)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the last applied internal load balancers")
+	}
+	tracked := make(map[string]interface{}, len(s.scope.InternalLoadBalancers()))
+	for _, lb := range s.scope.InternalLoadBalancers() {
+		tracked[lb.Name] = s.scope.ResourceGroup()
+	}
+	if stale := s.staleLoadBalancers(lastApplied, tracked); len(stale) != 0 {
+		if err := s.staleLBSvc(stale).Delete(ctx); err != nil {
+			return errors.Wrap(err, "failed to delete the internal load balancers removed from the spec")
+		}
+	}
+	return nil
+}
+
+// staleLoadBalancers returns the load balancers last applied that aren't tracked anymore, by name with their resource
+// group.
+func (s *internalLoadBalancersService) staleLoadBalancers(lastApplied, tracked map[string]interface{}) map[string]string {
+	stale := make(map[string]string)
+	for name, resourceGroup := range lastApplied {
+		if _, ok := tracked[name]; ok {
+			continue
+		}
+		if rg, ok := resourceGroup.(string); ok && rg != "" {
+			stale[name] = rg
 		} else {
-			return errors.Wrap(err, "failed to delete resource group")
+			stale[name] = s.scope.ResourceGroup()
+		}
+	}
+	return stale
+}
+
+// reconcileInventory writes the inventory of the Azure resources of the cluster to the ConfigMap configured in the
+// AzureCluster spec, e.g. for audits. It is read from Azure on every reconciliation, so it follows the resources
+// created, adopted and deleted by the other steps.
+func (s *azureClusterService) reconcileInventory(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.reconcileInventory")
+	defer done()
+
+	if s.scope.InventoryConfigMapName() == "" {
+		return nil
+	}
+
+	resources, err := s.inventorySvc.Inventory(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(resources)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the inventory")
+	}
+
+	configMap := s.scope.MakeEmptyInventoryConfigMap()
+	if _, err := controllerutil.CreateOrUpdate(ctx, s.scope.Client, &configMap, func() error {
+		configMap.Data = map[string]string{
+			infrav1.InventoryConfigMapKey: string(data),
 		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "failed to store the inventory ConfigMap")
+	}
+
+	return nil
+}
+
+// waitForDeleteGracePeriod returns a transient error until the delete grace period of the cluster has elapsed,
+// counting from the first delete attempt.
+func (s *azureClusterService) waitForDeleteGracePeriod(ctx context.Context) error {
+	_, log, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.waitForDeleteGracePeriod")
+	defer done()
+
+	gracePeriod := s.scope.DeleteGracePeriod()
+	if gracePeriod <= 0 {
+		return nil
+	}
+
+	requestedAt := s.scope.DeletionRequestedAt()
+	if requestedAt == nil {
+		now := metav1.Now()
+		s.scope.SetDeletionRequestedAt(now)
+		requestedAt = &now
+	}
+
+	remaining := gracePeriod - time.Since(requestedAt.Time)
+	if remaining > 0 {
+		log.Info("WARNING: Azure resources of the cluster will be deleted once the delete grace period elapses",
+			"deleteGracePeriod", gracePeriod.String(), "deletionRequestedAt", requestedAt.String(), "remaining", remaining.Round(time.Second).String())
+		return azure.WithTransientError(errors.Errorf("waiting %s for the delete grace period to elapse", remaining.Round(time.Second)), remaining)
 	}
 
 	return nil
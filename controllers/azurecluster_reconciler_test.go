@@ -19,80 +19,151 @@ package controllers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-04-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2021-01-01/subscriptions"
+	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/locations"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
-type expect func(grp *mock_azure.MockReconcilerMockRecorder, vnet *mock_azure.MockReconcilerMockRecorder, sg *mock_azure.MockReconcilerMockRecorder, rt *mock_azure.MockReconcilerMockRecorder, sn *mock_azure.MockReconcilerMockRecorder, natg *mock_azure.MockReconcilerMockRecorder, pip *mock_azure.MockReconcilerMockRecorder, lb *mock_azure.MockReconcilerMockRecorder, dns *mock_azure.MockReconcilerMockRecorder, bastion *mock_azure.MockReconcilerMockRecorder, peer *mock_azure.MockReconcilerMockRecorder)
+type expect func(grp *mock_azure.MockReconcilerMockRecorder, vnet *mock_azure.MockReconcilerMockRecorder, sg *mock_azure.MockReconcilerMockRecorder, rt *mock_azure.MockReconcilerMockRecorder, sn *mock_azure.MockReconcilerMockRecorder, natg *mock_azure.MockReconcilerMockRecorder, pip *mock_azure.MockReconcilerMockRecorder, lb *mock_azure.MockReconcilerMockRecorder, dns *mock_azure.MockReconcilerMockRecorder, bastion *mock_azure.MockReconcilerMockRecorder, peer *mock_azure.MockReconcilerMockRecorder, tm *mock_azure.MockReconcilerMockRecorder, asg *mock_azure.MockReconcilerMockRecorder, jumpbox *mock_azure.MockReconcilerMockRecorder, ipPrefix *mock_azure.MockReconcilerMockRecorder, law *mock_azure.MockReconcilerMockRecorder, diag *mock_azure.MockReconcilerMockRecorder, resolver *mock_azure.MockReconcilerMockRecorder, pe *mock_azure.MockReconcilerMockRecorder, ra *mock_azure.MockReconcilerMockRecorder)
 
 func TestAzureClusterReconcilerDelete(t *testing.T) {
 	cases := map[string]struct {
+		reconcileMode infrav1.ReconcileMode
 		expectedError string
 		expect        expect
 	}{
 		"Resource Group is deleted successfully": {
 			expectedError: "",
-			expect: func(grp *mock_azure.MockReconcilerMockRecorder, vnet *mock_azure.MockReconcilerMockRecorder, sg *mock_azure.MockReconcilerMockRecorder, rt *mock_azure.MockReconcilerMockRecorder, sn *mock_azure.MockReconcilerMockRecorder, natg *mock_azure.MockReconcilerMockRecorder, pip *mock_azure.MockReconcilerMockRecorder, lb *mock_azure.MockReconcilerMockRecorder, dns *mock_azure.MockReconcilerMockRecorder, bastion *mock_azure.MockReconcilerMockRecorder, peer *mock_azure.MockReconcilerMockRecorder) {
+			expect: func(grp *mock_azure.MockReconcilerMockRecorder, vnet *mock_azure.MockReconcilerMockRecorder, sg *mock_azure.MockReconcilerMockRecorder, rt *mock_azure.MockReconcilerMockRecorder, sn *mock_azure.MockReconcilerMockRecorder, natg *mock_azure.MockReconcilerMockRecorder, pip *mock_azure.MockReconcilerMockRecorder, lb *mock_azure.MockReconcilerMockRecorder, dns *mock_azure.MockReconcilerMockRecorder, bastion *mock_azure.MockReconcilerMockRecorder, peer *mock_azure.MockReconcilerMockRecorder, tm *mock_azure.MockReconcilerMockRecorder, asg *mock_azure.MockReconcilerMockRecorder, jumpbox *mock_azure.MockReconcilerMockRecorder, ipPrefix *mock_azure.MockReconcilerMockRecorder, law *mock_azure.MockReconcilerMockRecorder, diag *mock_azure.MockReconcilerMockRecorder, resolver *mock_azure.MockReconcilerMockRecorder, pe *mock_azure.MockReconcilerMockRecorder, ra *mock_azure.MockReconcilerMockRecorder) {
 				gomock.InOrder(
 					grp.Delete(gomockinternal.AContext()).Return(nil))
 			},
 		},
 		"Resource Group delete fails": {
 			expectedError: "failed to delete resource group: internal error",
-			expect: func(grp *mock_azure.MockReconcilerMockRecorder, vnet *mock_azure.MockReconcilerMockRecorder, sg *mock_azure.MockReconcilerMockRecorder, rt *mock_azure.MockReconcilerMockRecorder, sn *mock_azure.MockReconcilerMockRecorder, natg *mock_azure.MockReconcilerMockRecorder, pip *mock_azure.MockReconcilerMockRecorder, lb *mock_azure.MockReconcilerMockRecorder, dns *mock_azure.MockReconcilerMockRecorder, bastion *mock_azure.MockReconcilerMockRecorder, peer *mock_azure.MockReconcilerMockRecorder) {
+			expect: func(grp *mock_azure.MockReconcilerMockRecorder, vnet *mock_azure.MockReconcilerMockRecorder, sg *mock_azure.MockReconcilerMockRecorder, rt *mock_azure.MockReconcilerMockRecorder, sn *mock_azure.MockReconcilerMockRecorder, natg *mock_azure.MockReconcilerMockRecorder, pip *mock_azure.MockReconcilerMockRecorder, lb *mock_azure.MockReconcilerMockRecorder, dns *mock_azure.MockReconcilerMockRecorder, bastion *mock_azure.MockReconcilerMockRecorder, peer *mock_azure.MockReconcilerMockRecorder, tm *mock_azure.MockReconcilerMockRecorder, asg *mock_azure.MockReconcilerMockRecorder, jumpbox *mock_azure.MockReconcilerMockRecorder, ipPrefix *mock_azure.MockReconcilerMockRecorder, law *mock_azure.MockReconcilerMockRecorder, diag *mock_azure.MockReconcilerMockRecorder, resolver *mock_azure.MockReconcilerMockRecorder, pe *mock_azure.MockReconcilerMockRecorder, ra *mock_azure.MockReconcilerMockRecorder) {
 				gomock.InOrder(
 					grp.Delete(gomockinternal.AContext()).Return(errors.New("internal error")))
 			},
 		},
 		"Resource Group not owned by cluster": {
 			expectedError: "",
-			expect: func(grp *mock_azure.MockReconcilerMockRecorder, vnet *mock_azure.MockReconcilerMockRecorder, sg *mock_azure.MockReconcilerMockRecorder, rt *mock_azure.MockReconcilerMockRecorder, sn *mock_azure.MockReconcilerMockRecorder, natg *mock_azure.MockReconcilerMockRecorder, pip *mock_azure.MockReconcilerMockRecorder, lb *mock_azure.MockReconcilerMockRecorder, dns *mock_azure.MockReconcilerMockRecorder, bastion *mock_azure.MockReconcilerMockRecorder, peer *mock_azure.MockReconcilerMockRecorder) {
+			expect: func(grp *mock_azure.MockReconcilerMockRecorder, vnet *mock_azure.MockReconcilerMockRecorder, sg *mock_azure.MockReconcilerMockRecorder, rt *mock_azure.MockReconcilerMockRecorder, sn *mock_azure.MockReconcilerMockRecorder, natg *mock_azure.MockReconcilerMockRecorder, pip *mock_azure.MockReconcilerMockRecorder, lb *mock_azure.MockReconcilerMockRecorder, dns *mock_azure.MockReconcilerMockRecorder, bastion *mock_azure.MockReconcilerMockRecorder, peer *mock_azure.MockReconcilerMockRecorder, tm *mock_azure.MockReconcilerMockRecorder, asg *mock_azure.MockReconcilerMockRecorder, jumpbox *mock_azure.MockReconcilerMockRecorder, ipPrefix *mock_azure.MockReconcilerMockRecorder, law *mock_azure.MockReconcilerMockRecorder, diag *mock_azure.MockReconcilerMockRecorder, resolver *mock_azure.MockReconcilerMockRecorder, pe *mock_azure.MockReconcilerMockRecorder, ra *mock_azure.MockReconcilerMockRecorder) {
 				gomock.InOrder(
 					grp.Delete(gomockinternal.AContext()).Return(azure.ErrNotOwned),
+					law.Delete(gomockinternal.AContext()),
+					jumpbox.Delete(gomockinternal.AContext()),
 					bastion.Delete(gomockinternal.AContext()),
 					dns.Delete(gomockinternal.AContext()),
+					resolver.Delete(gomockinternal.AContext()),
+					tm.Delete(gomockinternal.AContext()),
+					diag.Delete(gomockinternal.AContext()),
 					lb.Delete(gomockinternal.AContext()),
+					pe.Delete(gomockinternal.AContext()),
 					peer.Delete(gomockinternal.AContext()),
 					sn.Delete(gomockinternal.AContext()),
 					natg.Delete(gomockinternal.AContext()),
+					ipPrefix.Delete(gomockinternal.AContext()),
 					pip.Delete(gomockinternal.AContext()),
 					rt.Delete(gomockinternal.AContext()),
 					sg.Delete(gomockinternal.AContext()),
+					asg.Delete(gomockinternal.AContext()),
 					vnet.Delete(gomockinternal.AContext()),
+					ra.Delete(gomockinternal.AContext()),
+				)
+			},
+		},
+		"Resource Group and cluster only resources are not deleted in NetworkOnly mode": {
+			reconcileMode: infrav1.ReconcileModeNetworkOnly,
+			expectedError: "",
+			expect: func(grp *mock_azure.MockReconcilerMockRecorder, vnet *mock_azure.MockReconcilerMockRecorder, sg *mock_azure.MockReconcilerMockRecorder, rt *mock_azure.MockReconcilerMockRecorder, sn *mock_azure.MockReconcilerMockRecorder, natg *mock_azure.MockReconcilerMockRecorder, pip *mock_azure.MockReconcilerMockRecorder, lb *mock_azure.MockReconcilerMockRecorder, dns *mock_azure.MockReconcilerMockRecorder, bastion *mock_azure.MockReconcilerMockRecorder, peer *mock_azure.MockReconcilerMockRecorder, tm *mock_azure.MockReconcilerMockRecorder, asg *mock_azure.MockReconcilerMockRecorder, jumpbox *mock_azure.MockReconcilerMockRecorder, ipPrefix *mock_azure.MockReconcilerMockRecorder, law *mock_azure.MockReconcilerMockRecorder, diag *mock_azure.MockReconcilerMockRecorder, resolver *mock_azure.MockReconcilerMockRecorder, pe *mock_azure.MockReconcilerMockRecorder, ra *mock_azure.MockReconcilerMockRecorder) {
+				gomock.InOrder(
+					bastion.Delete(gomockinternal.AContext()),
+					dns.Delete(gomockinternal.AContext()),
+					resolver.Delete(gomockinternal.AContext()),
+					tm.Delete(gomockinternal.AContext()),
+					diag.Delete(gomockinternal.AContext()),
+					lb.Delete(gomockinternal.AContext()),
+					pe.Delete(gomockinternal.AContext()),
+					peer.Delete(gomockinternal.AContext()),
+					sn.Delete(gomockinternal.AContext()),
+					natg.Delete(gomockinternal.AContext()),
+					ipPrefix.Delete(gomockinternal.AContext()),
+					pip.Delete(gomockinternal.AContext()),
+					rt.Delete(gomockinternal.AContext()),
+					sg.Delete(gomockinternal.AContext()),
+					asg.Delete(gomockinternal.AContext()),
+					vnet.Delete(gomockinternal.AContext()),
+				)
+			},
+		},
+		"Jumpbox delete fails": {
+			expectedError: "failed to delete jumpbox: some error happened",
+			expect: func(grp *mock_azure.MockReconcilerMockRecorder, vnet *mock_azure.MockReconcilerMockRecorder, sg *mock_azure.MockReconcilerMockRecorder, rt *mock_azure.MockReconcilerMockRecorder, sn *mock_azure.MockReconcilerMockRecorder, pip *mock_azure.MockReconcilerMockRecorder, natg *mock_azure.MockReconcilerMockRecorder, lb *mock_azure.MockReconcilerMockRecorder, dns *mock_azure.MockReconcilerMockRecorder, bastion *mock_azure.MockReconcilerMockRecorder, peer *mock_azure.MockReconcilerMockRecorder, tm *mock_azure.MockReconcilerMockRecorder, asg *mock_azure.MockReconcilerMockRecorder, jumpbox *mock_azure.MockReconcilerMockRecorder, ipPrefix *mock_azure.MockReconcilerMockRecorder, law *mock_azure.MockReconcilerMockRecorder, diag *mock_azure.MockReconcilerMockRecorder, resolver *mock_azure.MockReconcilerMockRecorder, pe *mock_azure.MockReconcilerMockRecorder, ra *mock_azure.MockReconcilerMockRecorder) {
+				gomock.InOrder(
+					grp.Delete(gomockinternal.AContext()).Return(azure.ErrNotOwned),
+					law.Delete(gomockinternal.AContext()),
+					jumpbox.Delete(gomockinternal.AContext()).Return(errors.New("some error happened")),
 				)
 			},
 		},
 		"Load Balancer delete fails": {
 			expectedError: "failed to delete load balancer: some error happened",
-			expect: func(grp *mock_azure.MockReconcilerMockRecorder, vnet *mock_azure.MockReconcilerMockRecorder, sg *mock_azure.MockReconcilerMockRecorder, rt *mock_azure.MockReconcilerMockRecorder, sn *mock_azure.MockReconcilerMockRecorder, pip *mock_azure.MockReconcilerMockRecorder, natg *mock_azure.MockReconcilerMockRecorder, lb *mock_azure.MockReconcilerMockRecorder, dns *mock_azure.MockReconcilerMockRecorder, bastion *mock_azure.MockReconcilerMockRecorder, peer *mock_azure.MockReconcilerMockRecorder) {
+			expect: func(grp *mock_azure.MockReconcilerMockRecorder, vnet *mock_azure.MockReconcilerMockRecorder, sg *mock_azure.MockReconcilerMockRecorder, rt *mock_azure.MockReconcilerMockRecorder, sn *mock_azure.MockReconcilerMockRecorder, pip *mock_azure.MockReconcilerMockRecorder, natg *mock_azure.MockReconcilerMockRecorder, lb *mock_azure.MockReconcilerMockRecorder, dns *mock_azure.MockReconcilerMockRecorder, bastion *mock_azure.MockReconcilerMockRecorder, peer *mock_azure.MockReconcilerMockRecorder, tm *mock_azure.MockReconcilerMockRecorder, asg *mock_azure.MockReconcilerMockRecorder, jumpbox *mock_azure.MockReconcilerMockRecorder, ipPrefix *mock_azure.MockReconcilerMockRecorder, law *mock_azure.MockReconcilerMockRecorder, diag *mock_azure.MockReconcilerMockRecorder, resolver *mock_azure.MockReconcilerMockRecorder, pe *mock_azure.MockReconcilerMockRecorder, ra *mock_azure.MockReconcilerMockRecorder) {
 				gomock.InOrder(
 					grp.Delete(gomockinternal.AContext()).Return(azure.ErrNotOwned),
+					law.Delete(gomockinternal.AContext()),
+					jumpbox.Delete(gomockinternal.AContext()),
 					bastion.Delete(gomockinternal.AContext()),
 					dns.Delete(gomockinternal.AContext()),
+					resolver.Delete(gomockinternal.AContext()),
+					tm.Delete(gomockinternal.AContext()),
+					diag.Delete(gomockinternal.AContext()),
 					lb.Delete(gomockinternal.AContext()).Return(errors.New("some error happened")),
 				)
 			},
 		},
 		"Route table delete fails": {
 			expectedError: "failed to delete route table: some error happened",
-			expect: func(grp *mock_azure.MockReconcilerMockRecorder, vnet *mock_azure.MockReconcilerMockRecorder, sg *mock_azure.MockReconcilerMockRecorder, rt *mock_azure.MockReconcilerMockRecorder, sn *mock_azure.MockReconcilerMockRecorder, pip *mock_azure.MockReconcilerMockRecorder, natg *mock_azure.MockReconcilerMockRecorder, lb *mock_azure.MockReconcilerMockRecorder, dns *mock_azure.MockReconcilerMockRecorder, bastion *mock_azure.MockReconcilerMockRecorder, peer *mock_azure.MockReconcilerMockRecorder) {
+			expect: func(grp *mock_azure.MockReconcilerMockRecorder, vnet *mock_azure.MockReconcilerMockRecorder, sg *mock_azure.MockReconcilerMockRecorder, rt *mock_azure.MockReconcilerMockRecorder, sn *mock_azure.MockReconcilerMockRecorder, pip *mock_azure.MockReconcilerMockRecorder, natg *mock_azure.MockReconcilerMockRecorder, lb *mock_azure.MockReconcilerMockRecorder, dns *mock_azure.MockReconcilerMockRecorder, bastion *mock_azure.MockReconcilerMockRecorder, peer *mock_azure.MockReconcilerMockRecorder, tm *mock_azure.MockReconcilerMockRecorder, asg *mock_azure.MockReconcilerMockRecorder, jumpbox *mock_azure.MockReconcilerMockRecorder, ipPrefix *mock_azure.MockReconcilerMockRecorder, law *mock_azure.MockReconcilerMockRecorder, diag *mock_azure.MockReconcilerMockRecorder, resolver *mock_azure.MockReconcilerMockRecorder, pe *mock_azure.MockReconcilerMockRecorder, ra *mock_azure.MockReconcilerMockRecorder) {
 				gomock.InOrder(
 					grp.Delete(gomockinternal.AContext()).Return(azure.ErrNotOwned),
+					law.Delete(gomockinternal.AContext()),
+					jumpbox.Delete(gomockinternal.AContext()),
 					bastion.Delete(gomockinternal.AContext()),
 					dns.Delete(gomockinternal.AContext()),
+					resolver.Delete(gomockinternal.AContext()),
+					tm.Delete(gomockinternal.AContext()),
+					diag.Delete(gomockinternal.AContext()),
 					lb.Delete(gomockinternal.AContext()),
+					pe.Delete(gomockinternal.AContext()),
 					peer.Delete(gomockinternal.AContext()),
 					sn.Delete(gomockinternal.AContext()),
 					pip.Delete(gomockinternal.AContext()),
+					ipPrefix.Delete(gomockinternal.AContext()),
 					natg.Delete(gomockinternal.AContext()),
 					rt.Delete(gomockinternal.AContext()).Return(errors.New("some error happened")),
 				)
@@ -119,30 +190,1079 @@ func TestAzureClusterReconcilerDelete(t *testing.T) {
 			dnsMock := mock_azure.NewMockReconciler(mockCtrl)
 			bastionMock := mock_azure.NewMockReconciler(mockCtrl)
 			peeringsMock := mock_azure.NewMockReconciler(mockCtrl)
+			trafficMgrMock := mock_azure.NewMockReconciler(mockCtrl)
+			asgMock := mock_azure.NewMockReconciler(mockCtrl)
+			jumpboxMock := mock_azure.NewMockReconciler(mockCtrl)
+			ipPrefixMock := mock_azure.NewMockReconciler(mockCtrl)
+			logAnalyticsMock := mock_azure.NewMockReconciler(mockCtrl)
+			diagnosticSettingsMock := mock_azure.NewMockReconciler(mockCtrl)
+			dnsResolverMock := mock_azure.NewMockReconciler(mockCtrl)
+			privateEndpointsMock := mock_azure.NewMockReconciler(mockCtrl)
+			roleAssignmentsMock := mock_azure.NewMockReconciler(mockCtrl)
 
-			tc.expect(groupsMock.EXPECT(), vnetMock.EXPECT(), sgMock.EXPECT(), rtMock.EXPECT(), subnetsMock.EXPECT(), natGatewaysMock.EXPECT(), publicIPMock.EXPECT(), lbMock.EXPECT(), dnsMock.EXPECT(), bastionMock.EXPECT(), peeringsMock.EXPECT())
+			tc.expect(groupsMock.EXPECT(), vnetMock.EXPECT(), sgMock.EXPECT(), rtMock.EXPECT(), subnetsMock.EXPECT(), natGatewaysMock.EXPECT(), publicIPMock.EXPECT(), lbMock.EXPECT(), dnsMock.EXPECT(), bastionMock.EXPECT(), peeringsMock.EXPECT(), trafficMgrMock.EXPECT(), asgMock.EXPECT(), jumpboxMock.EXPECT(), ipPrefixMock.EXPECT(), logAnalyticsMock.EXPECT(), diagnosticSettingsMock.EXPECT(), dnsResolverMock.EXPECT(), privateEndpointsMock.EXPECT(), roleAssignmentsMock.EXPECT())
 
 			s := &azureClusterService{
 				scope: &scope.ClusterScope{
+					Cluster: &clusterv1.Cluster{},
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							ReconcileMode: tc.reconcileMode,
+						},
+					},
+				},
+				groupsSvc:          groupsMock,
+				vnetSvc:            vnetMock,
+				securityGroupSvc:   sgMock,
+				asgSvc:             asgMock,
+				routeTableSvc:      rtMock,
+				natGatewaySvc:      natGatewaysMock,
+				subnetsSvc:         subnetsMock,
+				publicIPSvc:        publicIPMock,
+				ipPrefixSvc:        ipPrefixMock,
+				loadBalancerSvc:    lbMock,
+				trafficMgrSvc:      trafficMgrMock,
+				privateDNSSvc:      dnsMock,
+				bastionSvc:         bastionMock,
+				jumpboxSvc:         jumpboxMock,
+				peeringsSvc:        peeringsMock,
+				logAnalyticsSvc:    logAnalyticsMock,
+				diagSettingsSvc:    diagnosticSettingsMock,
+				dnsResolverSvc:     dnsResolverMock,
+				privateEndpointSvc: privateEndpointsMock,
+				roleAssignmentSvc:  roleAssignmentsMock,
+				skuCache:           resourceskus.NewStaticCache([]compute.ResourceSku{}, ""),
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+// fakeDeleter records its deletion and, like Azure, fails to delete a resource that is still referenced.
+type fakeDeleter struct {
+	resource   string
+	referrers  []string
+	deletedLog *[]string
+}
+
+func (f *fakeDeleter) Reconcile(_ context.Context) error {
+	return nil
+}
+
+func (f *fakeDeleter) Delete(_ context.Context) error {
+	for _, referrer := range f.referrers {
+		found := false
+		for _, deleted := range *f.deletedLog {
+			found = found || deleted == referrer
+		}
+		if !found {
+			return fmt.Errorf("%s is still referenced by %s", f.resource, referrer)
+		}
+	}
+	*f.deletedLog = append(*f.deletedLog, f.resource)
+	return nil
+}
+
+func TestOrderDeletionSteps(t *testing.T) {
+	g := NewWithT(t)
+
+	var deletedLog []string
+	newStep := func(resource string, dependents ...string) serviceStep {
+		return serviceStep{
+			resource:   resource,
+			svc:        &fakeDeleter{resource: resource, referrers: dependents, deletedLog: &deletedLog},
+			dependents: dependents,
+		}
+	}
+
+	// Listed in reverse order, deleting the public IP before the load balancer using it would fail.
+	steps := []serviceStep{
+		newStep("virtual network", "subnet"),
+		newStep("public IP", "load balancer"),
+		newStep("network security group", "subnet"),
+		newStep("subnet", "load balancer"),
+		newStep("load balancer", "inbound NAT rules"),
+		newStep("inbound NAT rules"),
+	}
+	g.Expect(steps[1].svc.Delete(context.TODO())).To(MatchError("public IP is still referenced by load balancer"))
+
+	ordered, err := orderDeletionSteps(steps)
+	g.Expect(err).NotTo(HaveOccurred())
+	for _, step := range ordered {
+		g.Expect(step.svc.Delete(context.TODO())).To(Succeed())
+	}
+	g.Expect(deletedLog).To(Equal([]string{"inbound NAT rules", "load balancer", "public IP", "subnet", "virtual network", "network security group"}))
+
+	_, err = orderDeletionSteps([]serviceStep{newStep("subnet", "load balancer"), newStep("load balancer", "subnet")})
+	g.Expect(err).To(MatchError("cyclic dependency between the deletions of subnet"))
+
+	_, err = orderDeletionSteps([]serviceStep{newStep("public IP", "load balancer")})
+	g.Expect(err).To(MatchError("public IP depends on the deletion of unknown resource load balancer"))
+}
+
+func TestServiceSteps(t *testing.T) {
+	g := NewWithT(t)
+
+	steps := (&azureClusterService{}).steps()
+
+	// The resources are deleted in the reverse order of their reconciliation, so a resource must be reconciled
+	// before its dependents, which reference it.
+	reconciled := map[string]int{}
+	for i, step := range steps {
+		g.Expect(reconciled).NotTo(HaveKey(step.resource), "duplicate step %s", step.resource)
+		reconciled[step.resource] = i
+	}
+	for i, step := range steps {
+		for _, dependent := range step.dependents {
+			g.Expect(reconciled).To(HaveKey(dependent), "%s depends on unknown step %s", step.resource, dependent)
+			g.Expect(reconciled[dependent]).To(BeNumerically(">", i), "%s must be reconciled after %s", dependent, step.resource)
+		}
+	}
+}
+
+func TestPhaseDeadlines(t *testing.T) {
+	g := NewWithT(t)
+
+	waitForDeadline := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	succeed := func(ctx context.Context) error {
+		return nil
+	}
+
+	phases := newPhaseDeadlines(reconciler.PhaseTimeouts{
+		ResourceGroup: time.Hour,
+		Network:       10 * time.Millisecond,
+		LoadBalancer:  time.Hour,
+	})
+	err := phases.run(context.TODO(), phaseNetwork, waitForDeadline)
+	g.Expect(err).To(MatchError(ContainSubstring("network phase exceeded its timeout of 10ms")))
+	var reconcileErr azure.ReconcileError
+	g.Expect(errors.As(err, &reconcileErr)).To(BeTrue())
+	g.Expect(reconcileErr.IsTransient()).To(BeTrue())
+
+	// The timeout is shared by all the steps of the phase, not reset for each of them.
+	g.Expect(phases.run(context.TODO(), phaseNetwork, succeed)).To(MatchError(ContainSubstring("network phase exceeded its timeout of 10ms")))
+
+	// The other phases, and the steps that aren't part of a phase, have their own timeouts.
+	g.Expect(phases.run(context.TODO(), phaseLoadBalancer, succeed)).To(Succeed())
+	g.Expect(phases.run(context.TODO(), "", succeed)).To(Succeed())
+
+	// The timeout of the whole reconcile loop isn't reported as the timeout of the phase.
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Millisecond)
+	defer cancel()
+	g.Expect(newPhaseDeadlines(reconciler.PhaseTimeouts{}.Defaulted()).run(ctx, phaseResourceGroup, waitForDeadline)).To(MatchError(context.DeadlineExceeded))
+}
+
+func TestAzureClusterReconcileStepsResetsFailedAttempts(t *testing.T) {
+	succeed := reconcileFunc(func(ctx context.Context) error {
+		return nil
+	})
+	fail := reconcileFunc(func(ctx context.Context) error {
+		return azure.WithTerminalError(errors.New("location not found"))
+	})
+
+	tests := []struct {
+		name         string
+		steps        []serviceStep
+		wantAttempts int32
+		wantGivenUp  bool
+	}{
+		{
+			name: "a phase succeeds and the next one fails",
+			steps: []serviceStep{
+				{resource: "resource group", svc: succeed, phase: phaseResourceGroup},
+				{resource: "virtual network", svc: succeed, phase: phaseNetwork},
+				{resource: "subnet", svc: fail, phase: phaseNetwork},
+			},
+			wantAttempts: 1,
+		},
+		{
+			name: "the first phase fails",
+			steps: []serviceStep{
+				{resource: "cost center", svc: succeed},
+				{resource: "resource group", svc: succeed, phase: phaseResourceGroup},
+				{resource: "diagnostics resource group", svc: fail, phase: phaseResourceGroup},
+			},
+			wantAttempts: 3,
+			wantGivenUp:  true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			acr := &AzureClusterReconciler{
+				Recorder:             record.NewFakeRecorder(10),
+				MaxReconcileAttempts: 2,
+			}
+			azureCluster := &infrav1.AzureCluster{
+				Status: infrav1.AzureClusterStatus{FailedReconcileAttempts: 2},
+			}
+			s := &azureClusterService{scope: &scope.ClusterScope{AzureCluster: azureCluster}}
+
+			err := s.reconcileSteps(context.TODO(), tc.steps)
+			g.Expect(err).To(MatchError(ContainSubstring("location not found")))
+			g.Expect(acr.recordFailedReconcileAttempt(azureCluster, err, capierrors.InvalidConfigurationClusterError)).To(Equal(tc.wantGivenUp))
+			g.Expect(azureCluster.Status.FailedReconcileAttempts).To(Equal(tc.wantAttempts))
+		})
+	}
+}
+
+func TestAzureClusterReconcilerDeleteGracePeriod(t *testing.T) {
+	cases := map[string]struct {
+		gracePeriod         *metav1.Duration
+		deletionRequestedAt *metav1.Time
+		expectDelete        bool
+	}{
+		"no grace period": {
+			expectDelete: true,
+		},
+		"first delete attempt is delayed": {
+			gracePeriod:  &metav1.Duration{Duration: time.Hour},
+			expectDelete: false,
+		},
+		"delete is delayed until the grace period elapses": {
+			gracePeriod:         &metav1.Duration{Duration: time.Hour},
+			deletionRequestedAt: &metav1.Time{Time: time.Now().Add(-30 * time.Minute)},
+			expectDelete:        false,
+		},
+		"grace period has elapsed": {
+			gracePeriod:         &metav1.Duration{Duration: time.Hour},
+			deletionRequestedAt: &metav1.Time{Time: time.Now().Add(-2 * time.Hour)},
+			expectDelete:        true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			groupsMock := mock_azure.NewMockReconciler(mockCtrl)
+			if tc.expectDelete {
+				groupsMock.EXPECT().Delete(gomockinternal.AContext()).Return(nil)
+			}
+
+			azureCluster := &infrav1.AzureCluster{
+				Spec:   infrav1.AzureClusterSpec{DeleteGracePeriod: tc.gracePeriod},
+				Status: infrav1.AzureClusterStatus{DeletionRequestedAt: tc.deletionRequestedAt},
+			}
+			s := &azureClusterService{
+				scope: &scope.ClusterScope{
+					Cluster:      &clusterv1.Cluster{},
+					AzureCluster: azureCluster,
+				},
+				groupsSvc: groupsMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectDelete {
+				g.Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			var reconcileError azure.ReconcileError
+			g.Expect(errors.As(err, &reconcileError)).To(BeTrue())
+			g.Expect(reconcileError.IsTransient()).To(BeTrue())
+			g.Expect(reconcileError.RequeueAfter()).To(BeNumerically("<=", time.Hour))
+			g.Expect(azureCluster.Status.DeletionRequestedAt).NotTo(BeNil())
+		})
+	}
+}
+
+func TestAzureClusterReconcilerDeleteProtection(t *testing.T) {
+	cases := map[string]struct {
+		annotations  map[string]string
+		expectDelete bool
+	}{
+		"delete is refused with the delete-protection annotation": {
+			annotations: map[string]string{azure.DeleteProtectionAnnotation: ""},
+		},
+		"delete is allowed without the delete-protection annotation": {
+			annotations:  map[string]string{"foo": "bar"},
+			expectDelete: true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			groupsMock := mock_azure.NewMockReconciler(mockCtrl)
+			if tc.expectDelete {
+				groupsMock.EXPECT().Delete(gomockinternal.AContext()).Return(nil)
+			}
+
+			s := &azureClusterService{
+				scope: &scope.ClusterScope{
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Annotations: tc.annotations},
+					},
 					AzureCluster: &infrav1.AzureCluster{},
 				},
-				groupsSvc:        groupsMock,
-				vnetSvc:          vnetMock,
-				securityGroupSvc: sgMock,
-				routeTableSvc:    rtMock,
-				natGatewaySvc:    natGatewaysMock,
-				subnetsSvc:       subnetsMock,
-				publicIPSvc:      publicIPMock,
-				loadBalancerSvc:  lbMock,
-				privateDNSSvc:    dnsMock,
-				bastionSvc:       bastionMock,
-				peeringsSvc:      peeringsMock,
-				skuCache:         resourceskus.NewStaticCache([]compute.ResourceSku{}, ""),
+				groupsSvc: groupsMock,
 			}
 
 			err := s.Delete(context.TODO())
+			if tc.expectDelete {
+				g.Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			var reconcileError azure.ReconcileError
+			g.Expect(errors.As(err, &reconcileError)).To(BeTrue())
+			g.Expect(reconcileError.IsTerminal()).To(BeTrue())
+			g.Expect(err).To(MatchError(ContainSubstring("remove the annotation")))
+		})
+	}
+}
+
+func TestAzureClusterSetPairedRegion(t *testing.T) {
+	g := NewWithT(t)
+
+	azureCluster := &infrav1.AzureCluster{
+		Spec: infrav1.AzureClusterSpec{
+			AzureClusterClassSpec: infrav1.AzureClusterClassSpec{Location: "eastus"},
+		},
+	}
+	s := &azureClusterService{
+		scope: &scope.ClusterScope{
+			AzureCluster: azureCluster,
+		},
+		locationsCache: locations.NewStaticCache([]subscriptions.Location{
+			{
+				Name: to.StringPtr("eastus"),
+				Metadata: &subscriptions.LocationMetadata{
+					PairedRegion: &[]subscriptions.PairedRegion{{Name: to.StringPtr("westus")}},
+				},
+			},
+		}),
+	}
+
+	s.setPairedRegion(context.TODO())
+	g.Expect(azureCluster.Status.PairedRegion).To(Equal("westus"))
+
+	// The paired region is informational, failing to get it leaves the status untouched.
+	azureCluster.Spec.Location = "centralus"
+	s.setPairedRegion(context.TODO())
+	g.Expect(azureCluster.Status.PairedRegion).To(Equal("westus"))
+}
+
+func TestAzureClusterReconcileSecondaryNetwork(t *testing.T) {
+	removedRegion := `{"location":"westus","vnet":{"name":"my-secondary-vnet"}}`
+	tests := []struct {
+		name                string
+		secondaryRegion     *infrav1.SecondaryRegionSpec
+		pairedRegion        string
+		lastApplied         string
+		expect              func(vnet, sg, sn *mock_azure.MockReconcilerMockRecorder)
+		expectedError       string
+		expectedStatus      *infrav1.SecondaryNetworkStatus
+		expectedLastApplied string
+	}{
+		{
+			name:           "single-region cluster",
+			expect:         func(vnet, sg, sn *mock_azure.MockReconcilerMockRecorder) {},
+			expectedStatus: nil,
+		},
+		{
+			name:            "secondary network in the paired region",
+			secondaryRegion: &infrav1.SecondaryRegionSpec{Vnet: infrav1.VnetSpec{Name: "my-secondary-vnet", ID: "my-secondary-vnet-id"}},
+			pairedRegion:    "westus",
+			expect: func(vnet, sg, sn *mock_azure.MockReconcilerMockRecorder) {
+				gomock.InOrder(
+					vnet.Reconcile(gomockinternal.AContext()),
+					sg.Reconcile(gomockinternal.AContext()),
+					sn.Reconcile(gomockinternal.AContext()),
+				)
+			},
+			expectedStatus:      &infrav1.SecondaryNetworkStatus{Location: "westus", VnetID: "my-secondary-vnet-id"},
+			expectedLastApplied: `{"location":"westus","vnet":{"id":"my-secondary-vnet-id","name":"my-secondary-vnet"}}`,
+		},
+		{
+			name:        "network of a secondary region removed from the spec is deleted",
+			lastApplied: removedRegion,
+			expect: func(vnet, sg, sn *mock_azure.MockReconcilerMockRecorder) {
+				gomock.InOrder(
+					sn.Delete(gomockinternal.AContext()),
+					sg.Delete(gomockinternal.AContext()),
+					vnet.Delete(gomockinternal.AContext()),
+				)
+			},
+			expectedStatus: nil,
+		},
+		{
+			name:        "network of a secondary region removed from the spec is tracked until deleted",
+			lastApplied: removedRegion,
+			expect: func(vnet, sg, sn *mock_azure.MockReconcilerMockRecorder) {
+				gomock.InOrder(
+					sn.Delete(gomockinternal.AContext()),
+					sg.Delete(gomockinternal.AContext()),
+					vnet.Delete(gomockinternal.AContext()).Return(azure.WithTransientError(azure.NewOperationNotDoneError(&infrav1.Future{}), 15*time.Second)),
+				)
+			},
+			expectedError:       "failed to delete the network of the secondary region removed from the spec",
+			expectedLastApplied: removedRegion,
+		},
+		{
+			name:            "secondary network without location",
+			secondaryRegion: &infrav1.SecondaryRegionSpec{Vnet: infrav1.VnetSpec{Name: "my-secondary-vnet"}},
+			expect:          func(vnet, sg, sn *mock_azure.MockReconcilerMockRecorder) {},
+			expectedError:   "location eastus has no paired region, the location of the secondary region must be set",
+		},
+		{
+			name:            "security groups of the secondary network fail",
+			secondaryRegion: &infrav1.SecondaryRegionSpec{Location: "centralus", Vnet: infrav1.VnetSpec{Name: "my-secondary-vnet"}},
+			expect: func(vnet, sg, sn *mock_azure.MockReconcilerMockRecorder) {
+				gomock.InOrder(
+					vnet.Reconcile(gomockinternal.AContext()),
+					sg.Reconcile(gomockinternal.AContext()).Return(errors.New("internal error")),
+				)
+			},
+			expectedError:       "failed to reconcile the security groups of the secondary region: internal error",
+			expectedLastApplied: `{"location":"centralus","vnet":{"name":"my-secondary-vnet"}}`,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			vnetMock := mock_azure.NewMockReconciler(mockCtrl)
+			sgMock := mock_azure.NewMockReconciler(mockCtrl)
+			subnetsMock := mock_azure.NewMockReconciler(mockCtrl)
+			tc.expect(vnetMock.EXPECT(), sgMock.EXPECT(), subnetsMock.EXPECT())
+
+			azureCluster := &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}},
+				Spec: infrav1.AzureClusterSpec{
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{Location: "eastus"},
+					SecondaryRegion:       tc.secondaryRegion,
+				},
+				Status: infrav1.AzureClusterStatus{
+					PairedRegion:     tc.pairedRegion,
+					SecondaryNetwork: &infrav1.SecondaryNetworkStatus{Location: "northeurope"},
+				},
+			}
+			if tc.lastApplied != "" {
+				azureCluster.Annotations[azure.SecondaryRegionLastAppliedAnnotation] = tc.lastApplied
+			}
+			clusterScope := &scope.ClusterScope{AzureCluster: azureCluster}
+			s := &azureClusterService{
+				scope: clusterScope,
+				secondaryNetSvc: &secondaryNetworkService{
+					scope:            scope.NewSecondaryRegionScope(clusterScope),
+					vnetSvc:          vnetMock,
+					securityGroupSvc: sgMock,
+					subnetsSvc:       subnetsMock,
+				},
+				staleSecondaryNetSvc: func(region *infrav1.SecondaryRegionSpec) azure.Reconciler {
+					g.Expect(region.Vnet.Name).To(Equal("my-secondary-vnet"))
+					return &secondaryNetworkService{
+						scope:            scope.NewStaleSecondaryRegionScope(clusterScope, region),
+						vnetSvc:          vnetMock,
+						securityGroupSvc: sgMock,
+						subnetsSvc:       subnetsMock,
+					}
+				},
+			}
+
+			err := s.reconcileSecondaryNetwork(context.TODO())
+			if tc.expectedLastApplied != "" {
+				g.Expect(azureCluster.Annotations).To(HaveKeyWithValue(azure.SecondaryRegionLastAppliedAnnotation, tc.expectedLastApplied))
+			} else {
+				g.Expect(azureCluster.Annotations).NotTo(HaveKey(azure.SecondaryRegionLastAppliedAnnotation))
+			}
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedError)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(azureCluster.Status.SecondaryNetwork).To(Equal(tc.expectedStatus))
+		})
+	}
+}
+
+func TestAzureClusterReconcileInternalLoadBalancers(t *testing.T) {
+	ingressLB := infrav1.InternalLoadBalancerSpec{Name: "ingress-lb", SubnetName: "node-subnet", PrivateIP: "10.1.0.10"}
+	tests := []struct {
+		name                string
+		internalLBs         []infrav1.InternalLoadBalancerSpec
+		lastApplied         string
+		expect              func(lb, stale *mock_azure.MockReconcilerMockRecorder)
+		expectedStale       map[string]string
+		expectedError       string
+		expectedLastApplied string
+	}{
+		{
+			name:   "no internal load balancers",
+			expect: func(lb, stale *mock_azure.MockReconcilerMockRecorder) {},
+		},
+		{
+			name:        "new internal load balancer",
+			internalLBs: []infrav1.InternalLoadBalancerSpec{ingressLB},
+			expect: func(lb, stale *mock_azure.MockReconcilerMockRecorder) {
+				lb.Reconcile(gomockinternal.AContext())
+			},
+			expectedLastApplied: `{"ingress-lb":"my-rg"}`,
+		},
+		{
+			name:        "internal load balancer removed from the spec",
+			internalLBs: []infrav1.InternalLoadBalancerSpec{ingressLB},
+			lastApplied: `{"ingress-lb":"my-rg","old-lb":"old-rg"}`,
+			expect: func(lb, stale *mock_azure.MockReconcilerMockRecorder) {
+				gomock.InOrder(
+					lb.Reconcile(gomockinternal.AContext()),
+					stale.Delete(gomockinternal.AContext()),
+				)
+			},
+			expectedStale:       map[string]string{"old-lb": "old-rg"},
+			expectedLastApplied: `{"ingress-lb":"my-rg"}`,
+		},
+		{
+			name:        "all internal load balancers removed from the spec",
+			lastApplied: `{"ingress-lb":"my-rg"}`,
+			expect: func(lb, stale *mock_azure.MockReconcilerMockRecorder) {
+				gomock.InOrder(
+					lb.Reconcile(gomockinternal.AContext()),
+					stale.Delete(gomockinternal.AContext()),
+				)
+			},
+			expectedStale:       map[string]string{"ingress-lb": "my-rg"},
+			expectedLastApplied: `{}`,
+		},
+		{
+			name:        "internal load balancer removed from the spec fails to delete",
+			lastApplied: `{"old-lb":"old-rg"}`,
+			expect: func(lb, stale *mock_azure.MockReconcilerMockRecorder) {
+				gomock.InOrder(
+					lb.Reconcile(gomockinternal.AContext()),
+					stale.Delete(gomockinternal.AContext()).Return(errors.New("internal error")),
+				)
+			},
+			expectedStale:       map[string]string{"old-lb": "old-rg"},
+			expectedError:       "failed to delete the internal load balancers removed from the spec: internal error",
+			expectedLastApplied: `{"old-lb":"old-rg"}`,
+		},
+		{
+			name:        "internal load balancer fails to reconcile",
+			internalLBs: []infrav1.InternalLoadBalancerSpec{ingressLB},
+			lastApplied: `{"old-lb":"old-rg"}`,
+			expect: func(lb, stale *mock_azure.MockReconcilerMockRecorder) {
+				lb.Reconcile(gomockinternal.AContext()).Return(errors.New("internal error"))
+			},
+			expectedError:       "internal error",
+			expectedLastApplied: `{"old-lb":"old-rg"}`,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			lbMock := mock_azure.NewMockReconciler(mockCtrl)
+			staleMock := mock_azure.NewMockReconciler(mockCtrl)
+			tc.expect(lbMock.EXPECT(), staleMock.EXPECT())
+
+			azureCluster := &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					ResourceGroup: "my-rg",
+					NetworkSpec:   infrav1.NetworkSpec{InternalLoadBalancers: tc.internalLBs},
+				},
+			}
+			if tc.lastApplied != "" {
+				azureCluster.Annotations = map[string]string{azure.InternalLoadBalancersLastAppliedAnnotation: tc.lastApplied}
+			}
+			clusterScope := &scope.ClusterScope{AzureCluster: azureCluster}
+			var stale map[string]string
+			s := &azureClusterService{
+				scope: clusterScope,
+				internalLBSvc: &internalLoadBalancersService{
+					scope: clusterScope,
+					lbSvc: lbMock,
+					staleLBSvc: func(names map[string]string) azure.Reconciler {
+						stale = names
+						return staleMock
+					},
+				},
+			}
+
+			err := s.reconcileInternalLoadBalancers(context.TODO())
 			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedError)))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(stale).To(Equal(tc.expectedStale))
+			g.Expect(azureCluster.Annotations[azure.InternalLoadBalancersLastAppliedAnnotation]).To(Equal(tc.expectedLastApplied))
+		})
+	}
+}
+
+func TestAzureClusterValidateResourceGroupLocation(t *testing.T) {
+	tests := []struct {
+		name                  string
+		resourceGroupLocation string
+		wantErr               bool
+	}{
+		{
+			name:                  "resource group in the location of the cluster",
+			resourceGroupLocation: "",
+		},
+		{
+			name:                  "resource group in another available location",
+			resourceGroupLocation: "westeurope",
+		},
+		{
+			name:                  "resource group in an unknown location",
+			resourceGroupLocation: "moon",
+			wantErr:               true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := &azureClusterService{
+				scope: &scope.ClusterScope{
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{Location: "eastus"},
+							ResourceGroupLocation: tc.resourceGroupLocation,
+						},
+					},
+				},
+				locationsCache: locations.NewStaticCache([]subscriptions.Location{
+					{Name: to.StringPtr("eastus")},
+					{Name: to.StringPtr("westeurope")},
+				}),
+			}
+
+			err := s.validateResourceGroupLocation(context.TODO())
+			if tc.wantErr {
+				var reconcileError azure.ReconcileError
+				g.Expect(errors.As(err, &reconcileError)).To(BeTrue())
+				g.Expect(reconcileError.IsTerminal()).To(BeTrue())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestAzureClusterValidateControlPlaneEtcdDisks(t *testing.T) {
+	ultraSSDSKU := compute.ResourceSku{
+		Name:         to.StringPtr("Standard_D4s_v3"),
+		ResourceType: to.StringPtr(string(resourceskus.VirtualMachines)),
+		LocationInfo: &[]compute.ResourceSkuLocationInfo{
+			{
+				Location: to.StringPtr("eastus"),
+				Zones:    &[]string{"1", "2", "3"},
+				ZoneDetails: &[]compute.ResourceSkuZoneDetails{
+					{
+						Name: &[]string{"1", "2"},
+						Capabilities: &[]compute.ResourceSkuCapabilities{
+							{Name: to.StringPtr(resourceskus.UltraSSDAvailable), Value: to.StringPtr("True")},
+						},
+					},
+				},
+			},
+		},
+	}
+	ultraSSD := &infrav1.EtcdDisks{StorageAccountType: "UltraSSD_LRS", VMSize: "Standard_D4s_v3"}
+	tests := []struct {
+		name           string
+		etcdDisks      *infrav1.EtcdDisks
+		failureDomains []string
+		wantErr        string
+		wantZones      []string
+		wantCondition  *clusterv1.Condition
+	}{
+		{
+			name:           "etcd disks not checked",
+			failureDomains: []string{"1", "2", "3"},
+		},
+		{
+			name:           "ultra disks supported in every zone of the control plane",
+			etcdDisks:      ultraSSD,
+			failureDomains: []string{"2", "1"},
+			wantZones:      []string{"1", "2"},
+			wantCondition:  conditions.TrueCondition(infrav1.ControlPlaneEtcdDisksCompatibleCondition),
+		},
+		{
+			name:           "ultra disks not supported in a zone of the control plane",
+			etcdDisks:      ultraSSD,
+			failureDomains: []string{"1", "2", "3"},
+			wantErr:        "VM size Standard_D4s_v3 doesn't support UltraSSD_LRS etcd disks in zones 3 of location eastus",
+			wantCondition: conditions.FalseCondition(infrav1.ControlPlaneEtcdDisksCompatibleCondition, infrav1.EtcdDisksNotSupportedReason,
+				clusterv1.ConditionSeverityError, "VM size Standard_D4s_v3 doesn't support UltraSSD_LRS disks in zones 3"),
+		},
+		{
+			name:      "location without availability zones",
+			etcdDisks: ultraSSD,
+			wantErr:   "UltraSSD_LRS etcd disks require availability zones, which location eastus doesn't have",
+			wantCondition: conditions.FalseCondition(infrav1.ControlPlaneEtcdDisksCompatibleCondition, infrav1.EtcdDisksNotSupportedReason,
+				clusterv1.ConditionSeverityError, "location eastus has no availability zones"),
+		},
+		{
+			name:           "unknown VM size",
+			etcdDisks:      &infrav1.EtcdDisks{StorageAccountType: "UltraSSD_LRS", VMSize: "Standard_Unknown"},
+			failureDomains: []string{"1"},
+			wantErr:        "failed to get VM size Standard_Unknown of the control plane",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterScope := &scope.ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{Location: "eastus"},
+						ControlPlaneEtcdDisks: tc.etcdDisks,
+					},
+				},
+			}
+			for _, zone := range tc.failureDomains {
+				clusterScope.SetFailureDomain(zone, clusterv1.FailureDomainSpec{ControlPlane: true})
+			}
+			s := &azureClusterService{
+				scope:    clusterScope,
+				skuCache: resourceskus.NewStaticCache([]compute.ResourceSku{ultraSSDSKU}, "eastus"),
+			}
+
+			err := s.validateControlPlaneEtcdDisks(context.TODO())
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(clusterScope.AzureCluster.Status.ControlPlaneEtcdDiskZones).To(Equal(tc.wantZones))
+			condition := conditions.Get(clusterScope.AzureCluster, infrav1.ControlPlaneEtcdDisksCompatibleCondition)
+			if tc.wantCondition == nil {
+				g.Expect(condition).To(BeNil())
+			} else {
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Status).To(Equal(tc.wantCondition.Status))
+				g.Expect(condition.Reason).To(Equal(tc.wantCondition.Reason))
+				g.Expect(condition.Message).To(Equal(tc.wantCondition.Message))
+			}
+		})
+	}
+}
+
+func TestAzureClusterReconcileDefaultSpotPolicy(t *testing.T) {
+	maxPrice := resource.MustParse("0.05")
+	tests := []struct {
+		name    string
+		policy  *infrav1.SpotPolicy
+		wantErr bool
+	}{
+		{
+			name: "no default spot policy",
+		},
+		{
+			name:   "valid default spot policy",
+			policy: &infrav1.SpotPolicy{MaxPrice: &maxPrice, EvictionPolicy: infrav1.SpotEvictionPolicyDelete},
+		},
+		{
+			name:    "invalid default spot policy",
+			policy:  &infrav1.SpotPolicy{EvictionPolicy: "Hibernate"},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			azureCluster := &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					DefaultSpotPolicy: tc.policy,
+				},
+			}
+			s := &azureClusterService{
+				scope: &scope.ClusterScope{
+					AzureCluster: azureCluster,
+				},
+			}
+
+			err := s.reconcileDefaultSpotPolicy(context.TODO())
+			if tc.wantErr {
+				var reconcileError azure.ReconcileError
+				g.Expect(errors.As(err, &reconcileError)).To(BeTrue())
+				g.Expect(reconcileError.IsTerminal()).To(BeTrue())
+				g.Expect(azureCluster.Status.DefaultSpotPolicy).To(BeNil())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(azureCluster.Status.DefaultSpotPolicy).To(Equal(tc.policy))
+			}
+		})
+	}
+}
+
+func TestAzureClusterReconcileAutoShutdownSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule *infrav1.AutoShutdownSchedule
+		wantErr  bool
+	}{
+		{
+			name: "no auto-shutdown schedule",
+		},
+		{
+			name:     "valid auto-shutdown schedule",
+			schedule: &infrav1.AutoShutdownSchedule{Time: "19:30", TimeZone: "Europe/Paris"},
+		},
+		{
+			name:     "invalid auto-shutdown schedule",
+			schedule: &infrav1.AutoShutdownSchedule{Time: "7:30PM"},
+			wantErr:  true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			azureCluster := &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					AutoShutdown: tc.schedule,
+				},
+			}
+			s := &azureClusterService{
+				scope: &scope.ClusterScope{
+					AzureCluster: azureCluster,
+				},
+			}
+
+			err := s.reconcileAutoShutdownSchedule(context.TODO())
+			if tc.wantErr {
+				var reconcileError azure.ReconcileError
+				g.Expect(errors.As(err, &reconcileError)).To(BeTrue())
+				g.Expect(reconcileError.IsTerminal()).To(BeTrue())
+				g.Expect(azureCluster.Status.AutoShutdown).To(BeNil())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(azureCluster.Status.AutoShutdown).To(Equal(tc.schedule))
+			}
+		})
+	}
+}
+
+func TestAzureClusterReconcileLogAnalyticsSharedKey(t *testing.T) {
+	g := NewWithT(t)
+	scheme := setupScheme(g)
+	kubeclient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	azureCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+		Spec: infrav1.AzureClusterSpec{
+			LogAnalyticsWorkspace: &infrav1.LogAnalyticsWorkspace{
+				Name:                "my-cluster-workspace",
+				SharedKeySecretName: "my-cluster-workspace-key",
+			},
+		},
+	}
+	clusterScope := &scope.ClusterScope{
+		Client:       kubeclient,
+		Cluster:      &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"}},
+		AzureCluster: azureCluster,
+	}
+	s := &azureClusterService{scope: clusterScope}
+
+	// no shared key was retrieved, nothing to store.
+	g.Expect(s.reconcileLogAnalyticsSharedKey(context.TODO())).To(Succeed())
+	g.Expect(azureCluster.Status.LogAnalyticsWorkspace).To(BeNil())
+
+	for _, key := range []string{"first-key", "rotated-key"} {
+		clusterScope.SetLogAnalyticsSharedKey(key)
+		g.Expect(s.reconcileLogAnalyticsSharedKey(context.TODO())).To(Succeed())
+
+		secret := &corev1.Secret{}
+		g.Expect(kubeclient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "my-cluster-workspace-key"}, secret)).To(Succeed())
+		g.Expect(secret.Data).To(Equal(map[string][]byte{infrav1.LogAnalyticsSharedKeySecretKey: []byte(key)}))
+		g.Expect(azureCluster.Status.LogAnalyticsWorkspace.SharedKeySecretRef).To(Equal(&corev1.SecretReference{Namespace: "default", Name: "my-cluster-workspace-key"}))
+	}
+}
+
+func TestAzureClusterReconcileDiagnosticsResourceGroup(t *testing.T) {
+	tests := []struct {
+		name             string
+		diagnosticsGroup *infrav1.DiagnosticsResourceGroup
+		reportedLocation string
+		reconciles       bool
+		reconcileErr     error
+		wantErr          string
+		wantTerminal     bool
+	}{
+		{
+			name: "no diagnostics resource group",
+		},
+		{
+			name:             "diagnostics resource group in the location of the cluster",
+			diagnosticsGroup: &infrav1.DiagnosticsResourceGroup{Name: "my-diagnostics-rg"},
+			reportedLocation: "eastus",
+			reconciles:       true,
+		},
+		{
+			name:             "diagnostics resource group in another available location",
+			diagnosticsGroup: &infrav1.DiagnosticsResourceGroup{Name: "my-diagnostics-rg", Location: "westeurope"},
+			reportedLocation: "westeurope",
+			reconciles:       true,
+		},
+		{
+			name:             "diagnostics resource group in an unknown location",
+			diagnosticsGroup: &infrav1.DiagnosticsResourceGroup{Name: "my-diagnostics-rg", Location: "moon"},
+			wantErr:          "location moon is not available",
+			wantTerminal:     true,
+		},
+		{
+			name:             "existing diagnostics resource group in another location",
+			diagnosticsGroup: &infrav1.DiagnosticsResourceGroup{Name: "my-diagnostics-rg"},
+			reportedLocation: "westeurope",
+			reconciles:       true,
+			wantErr:          "diagnostics resource group my-diagnostics-rg is in location westeurope instead of eastus",
+			wantTerminal:     true,
+		},
+		{
+			name:             "diagnostics resource group reconcile fails",
+			diagnosticsGroup: &infrav1.DiagnosticsResourceGroup{Name: "my-diagnostics-rg"},
+			reconciles:       true,
+			reconcileErr:     errors.New("internal error"),
+			wantErr:          "internal error",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			azureCluster := &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					AzureClusterClassSpec:    infrav1.AzureClusterClassSpec{Location: "eastus"},
+					DiagnosticsResourceGroup: tc.diagnosticsGroup,
+				},
+			}
+			diagGroupMock := mock_azure.NewMockReconciler(mockCtrl)
+			if tc.reconciles {
+				diagGroupMock.EXPECT().Reconcile(gomockinternal.AContext()).DoAndReturn(func(_ context.Context) error {
+					azureCluster.Status.DiagnosticsResourceGroupLocation = tc.reportedLocation
+					return tc.reconcileErr
+				})
+			}
+
+			s := &azureClusterService{
+				scope: &scope.ClusterScope{
+					Cluster:      &clusterv1.Cluster{},
+					AzureCluster: azureCluster,
+				},
+				diagGroupSvc: diagGroupMock,
+				locationsCache: locations.NewStaticCache([]subscriptions.Location{
+					{Name: to.StringPtr("eastus")},
+					{Name: to.StringPtr("westeurope")},
+				}),
+			}
+
+			err := s.reconcileDiagnosticsResourceGroup(context.TODO())
+			if tc.wantErr != "" {
 				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.wantErr))
+				var reconcileError azure.ReconcileError
+				g.Expect(errors.As(err, &reconcileError) && reconcileError.IsTerminal()).To(Equal(tc.wantTerminal))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestAzureClusterDeleteDiagnosticsResources(t *testing.T) {
+	tests := []struct {
+		name          string
+		expect        func(grp, diagGrp, law *mock_azure.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name: "diagnostics resources are deleted before the resource group",
+			expect: func(grp, diagGrp, law *mock_azure.MockReconcilerMockRecorder) {
+				gomock.InOrder(
+					law.Delete(gomockinternal.AContext()),
+					diagGrp.Delete(gomockinternal.AContext()),
+					grp.Delete(gomockinternal.AContext()),
+				)
+			},
+		},
+		{
+			name: "diagnostics resource group not owned by cluster",
+			expect: func(grp, diagGrp, law *mock_azure.MockReconcilerMockRecorder) {
+				gomock.InOrder(
+					law.Delete(gomockinternal.AContext()),
+					diagGrp.Delete(gomockinternal.AContext()).Return(azure.ErrNotOwned),
+					grp.Delete(gomockinternal.AContext()),
+				)
+			},
+		},
+		{
+			name: "diagnostics resource group delete fails",
+			expect: func(grp, diagGrp, law *mock_azure.MockReconcilerMockRecorder) {
+				gomock.InOrder(
+					law.Delete(gomockinternal.AContext()),
+					diagGrp.Delete(gomockinternal.AContext()).Return(errors.New("internal error")),
+				)
+			},
+			expectedError: "failed to delete diagnostics resources: internal error",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			groupsMock := mock_azure.NewMockReconciler(mockCtrl)
+			diagGroupMock := mock_azure.NewMockReconciler(mockCtrl)
+			logAnalyticsMock := mock_azure.NewMockReconciler(mockCtrl)
+			tc.expect(groupsMock.EXPECT(), diagGroupMock.EXPECT(), logAnalyticsMock.EXPECT())
+
+			s := &azureClusterService{
+				scope: &scope.ClusterScope{
+					Cluster: &clusterv1.Cluster{},
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							DiagnosticsResourceGroup: &infrav1.DiagnosticsResourceGroup{Name: "my-diagnostics-rg"},
+						},
+					},
+				},
+				groupsSvc:       groupsMock,
+				diagGroupSvc:    diagGroupMock,
+				logAnalyticsSvc: logAnalyticsMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
 				g.Expect(err).To(MatchError(tc.expectedError))
 			} else {
 				g.Expect(err).NotTo(HaveOccurred())
@@ -69,14 +69,23 @@ func TestAzureJSONPoolReconciler(t *testing.T) {
 		Spec: infrav1.AzureClusterSpec{
 			AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
 				SubscriptionID: "123",
+				Location:       "westus2",
 			},
+			ResourceGroup: "my-rg",
 			NetworkSpec: infrav1.NetworkSpec{
+				Vnet: infrav1.VnetSpec{
+					Name:          "my-vnet",
+					ResourceGroup: "my-rg",
+				},
 				Subnets: infrav1.Subnets{
 					{
 						Name: "node",
 						SubnetClassSpec: infrav1.SubnetClassSpec{
 							Role: infrav1.SubnetNode,
 						},
+						SecurityGroup: infrav1.SecurityGroup{
+							Name: "node-nsg",
+						},
 					},
 				},
 			},
@@ -48,9 +48,14 @@ import (
 // AzureMachineReconciler reconciles an AzureMachine object.
 type AzureMachineReconciler struct {
 	client.Client
-	Recorder                  record.EventRecorder
-	ReconcileTimeout          time.Duration
-	WatchFilterValue          string
+	Recorder         record.EventRecorder
+	ReconcileTimeout time.Duration
+	WatchFilterValue string
+	// ExpectedEnvironment is the value of the environment tag the existing Azure resources of the machines must have to
+	// be adopted or deleted, if any.
+	ExpectedEnvironment string
+	// RetryClassifier classifies the reconcile errors, azure.DefaultRetryClassifier is used when it is nil.
+	RetryClassifier           azure.RetryClassifier
 	createAzureMachineService azureMachineServiceCreator
 }
 
@@ -199,9 +204,10 @@ func (amr *AzureMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	// Create the cluster scope
 	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
-		Client:       amr.Client,
-		Cluster:      cluster,
-		AzureCluster: azureCluster,
+		Client:              amr.Client,
+		Cluster:             cluster,
+		AzureCluster:        azureCluster,
+		ExpectedEnvironment: amr.ExpectedEnvironment,
 	})
 	if err != nil {
 		amr.Recorder.Eventf(azureCluster, corev1.EventTypeWarning, "Error creating the cluster scope", err.Error())
@@ -289,7 +295,7 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 		return reconcile.Result{}, errors.Wrap(err, "failed to create azure machine service")
 	}
 
-	if err := ams.Reconcile(ctx); err != nil {
+	if err := classifyReconcileError(log, amr.RetryClassifier, ams.Reconcile(ctx)); err != nil {
 		// This means that a VM was created and managed by this controller, but is not present anymore.
 		// In this case, we mark it as failed and leave it to MHC for remediation
 		if errors.As(err, &azure.VMDeletedError{}) {
@@ -18,8 +18,11 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/availabilitysets"
@@ -47,7 +50,14 @@ type azureMachineService struct {
 	tagsSvc              azure.Reconciler
 	vmExtensionsSvc      azure.Reconciler
 	availabilitySetsSvc  azure.Reconciler
+	backendPoolsDetacher backendPoolsDetacher
 	skuCache             *resourceskus.Cache
+	clock                clock.Clock
+}
+
+// backendPoolsDetacher removes a machine from the backend pools of its load balancers.
+type backendPoolsDetacher interface {
+	DetachFromBackendPools(ctx context.Context) error
 }
 
 var _ azure.Reconciler = (*azureMachineService)(nil)
@@ -59,10 +69,11 @@ func newAzureMachineService(machineScope *scope.MachineScope) (*azureMachineServ
 		return nil, errors.Wrap(err, "failed creating a NewCache")
 	}
 
+	networkInterfacesSvc := networkinterfaces.New(machineScope, cache)
 	return &azureMachineService{
 		scope:                machineScope,
 		inboundNatRulesSvc:   inboundnatrules.New(machineScope),
-		networkInterfacesSvc: networkinterfaces.New(machineScope, cache),
+		networkInterfacesSvc: networkInterfacesSvc,
 		virtualMachinesSvc:   virtualmachines.New(machineScope),
 		roleAssignmentsSvc:   roleassignments.New(machineScope),
 		disksSvc:             disks.New(machineScope),
@@ -70,7 +81,9 @@ func newAzureMachineService(machineScope *scope.MachineScope) (*azureMachineServ
 		tagsSvc:              tags.New(machineScope),
 		vmExtensionsSvc:      vmextensions.New(machineScope),
 		availabilitySetsSvc:  availabilitysets.New(machineScope, cache),
+		backendPoolsDetacher: networkInterfacesSvc,
 		skuCache:             cache,
+		clock:                clock.RealClock{},
 	}, nil
 }
 
@@ -123,6 +136,10 @@ func (s *azureMachineService) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureMachineService.Delete")
 	defer done()
 
+	if err := s.drainLoadBalancerConnections(ctx); err != nil {
+		return errors.Wrap(err, "failed to drain the connections of the API server load balancer")
+	}
+
 	if err := s.virtualMachinesSvc.Delete(ctx); err != nil {
 		return errors.Wrap(err, "failed to delete machine")
 	}
@@ -149,3 +166,35 @@ func (s *azureMachineService) Delete(ctx context.Context) error {
 
 	return nil
 }
+
+// drainLoadBalancerConnections removes a control plane machine from the backend pools of the API server load balancer,
+// then returns a transient error until the connection drain timeout has elapsed, counting from the removal, so that
+// the established connections complete before the VM is deleted.
+func (s *azureMachineService) drainLoadBalancerConnections(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.azureMachineService.drainLoadBalancerConnections")
+	defer done()
+
+	timeout := s.scope.ConnectionDrainTimeout()
+	if timeout <= 0 {
+		return nil
+	}
+
+	startedAt := s.scope.DrainStartedAt()
+	if startedAt == nil {
+		if err := s.backendPoolsDetacher.DetachFromBackendPools(ctx); err != nil {
+			return errors.Wrap(err, "failed to remove the machine from the backend pools")
+		}
+		now := metav1.NewTime(s.clock.Now())
+		s.scope.SetDrainStartedAt(now)
+		startedAt = &now
+	}
+
+	remaining := timeout - s.clock.Since(startedAt.Time)
+	if remaining > 0 {
+		log.V(2).Info("waiting for the connections of the API server load balancer to drain",
+			"timeout", timeout.String(), "drainStartedAt", startedAt.String(), "remaining", remaining.Round(time.Second).String())
+		return azure.WithTransientError(errors.Errorf("waiting %s for the connections to drain", remaining.Round(time.Second)), remaining)
+	}
+
+	return nil
+}
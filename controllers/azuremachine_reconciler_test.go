@@ -0,0 +1,149 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// fakeBackendPoolsDetacher counts the removals of the machine from the backend pools.
+type fakeBackendPoolsDetacher struct {
+	detached int
+	err      error
+}
+
+func (f *fakeBackendPoolsDetacher) DetachFromBackendPools(_ context.Context) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.detached++
+	return nil
+}
+
+func TestAzureMachineServiceDrainLoadBalancerConnections(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	clusterScope := mock_azure.NewMockClusterScoper(mockCtrl)
+	clusterScope.EXPECT().APIServerLB().Return(&infrav1.LoadBalancerSpec{
+		LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+			ConnectionDraining: &infrav1.ConnectionDraining{Timeout: metav1.Duration{Duration: 2 * time.Minute}},
+		},
+	}).AnyTimes()
+
+	fakeClock := clocktesting.NewFakeClock(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC))
+	detacher := &fakeBackendPoolsDetacher{}
+	s := &azureMachineService{
+		scope: &scope.MachineScope{
+			ClusterScoper: clusterScope,
+			Machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{clusterv1.MachineControlPlaneLabelName: ""}},
+			},
+			AzureMachine: &infrav1.AzureMachine{},
+		},
+		backendPoolsDetacher: detacher,
+		clock:                fakeClock,
+	}
+
+	// The machine is removed from the backend pools and the deletion is requeued until the drain timeout elapses.
+	err := s.drainLoadBalancerConnections(context.TODO())
+	var reconcileError azure.ReconcileError
+	g.Expect(errors.As(err, &reconcileError)).To(BeTrue())
+	g.Expect(reconcileError.IsTransient()).To(BeTrue())
+	g.Expect(reconcileError.RequeueAfter()).To(Equal(2 * time.Minute))
+	g.Expect(detacher.detached).To(Equal(1))
+	g.Expect(s.scope.DrainStartedAt()).To(Equal(&metav1.Time{Time: fakeClock.Now()}))
+
+	fakeClock.Step(90 * time.Second)
+	err = s.drainLoadBalancerConnections(context.TODO())
+	g.Expect(errors.As(err, &reconcileError)).To(BeTrue())
+	g.Expect(reconcileError.IsTransient()).To(BeTrue())
+	g.Expect(reconcileError.RequeueAfter()).To(Equal(30 * time.Second))
+	g.Expect(err.Error()).To(ContainSubstring("waiting 30s for the connections to drain"))
+	// The machine is only removed from the backend pools once.
+	g.Expect(detacher.detached).To(Equal(1))
+
+	fakeClock.Step(30 * time.Second)
+	g.Expect(s.drainLoadBalancerConnections(context.TODO())).To(Succeed())
+	g.Expect(detacher.detached).To(Equal(1))
+}
+
+func TestAzureMachineServiceDrainLoadBalancerConnectionsSkipped(t *testing.T) {
+	drainingLB := &infrav1.LoadBalancerSpec{
+		LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+			ConnectionDraining: &infrav1.ConnectionDraining{Timeout: metav1.Duration{Duration: 2 * time.Minute}},
+		},
+	}
+	tests := []struct {
+		name         string
+		apiServerLB  *infrav1.LoadBalancerSpec
+		controlPlane bool
+	}{
+		{
+			name:         "control plane machine without connection draining",
+			apiServerLB:  &infrav1.LoadBalancerSpec{},
+			controlPlane: true,
+		},
+		{
+			name:         "worker machine",
+			apiServerLB:  drainingLB,
+			controlPlane: false,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			clusterScope := mock_azure.NewMockClusterScoper(mockCtrl)
+			clusterScope.EXPECT().APIServerLB().Return(tc.apiServerLB).AnyTimes()
+			machine := &clusterv1.Machine{}
+			if tc.controlPlane {
+				machine.Labels = map[string]string{clusterv1.MachineControlPlaneLabelName: ""}
+			}
+			detacher := &fakeBackendPoolsDetacher{err: errors.New("unexpected detach")}
+			s := &azureMachineService{
+				scope: &scope.MachineScope{
+					ClusterScoper: clusterScope,
+					Machine:       machine,
+					AzureMachine:  &infrav1.AzureMachine{},
+				},
+				backendPoolsDetacher: detacher,
+				clock:                clocktesting.NewFakeClock(time.Now()),
+			}
+
+			g.Expect(s.drainLoadBalancerConnections(context.TODO())).To(Succeed())
+			g.Expect(s.scope.DrainStartedAt()).To(BeNil())
+		})
+	}
+}
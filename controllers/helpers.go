@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -189,10 +190,17 @@ func GetCloudProviderSecret(d azure.ClusterScoper, namespace, name string, owner
 			return nil, errors.New("expected a non-empty userIdentityID")
 		}
 		controlPlaneConfig, workerNodeConfig = userAssignedIdentityCloudProviderConfig(d, userIdentityID)
-	case infrav1.VMIdentityNone:
+	default:
 		controlPlaneConfig, workerNodeConfig = newCloudProviderConfig(d)
 	}
 
+	if err := controlPlaneConfig.validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid control plane cloud provider config")
+	}
+	if err := workerNodeConfig.validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid worker node cloud provider config")
+	}
+
 	controlPlaneData, err := json.MarshalIndent(controlPlaneConfig, "", "    ")
 	if err != nil {
 		return nil, errors.Wrap(err, "failed control plane json marshal")
@@ -238,6 +246,11 @@ func userAssignedIdentityCloudProviderConfig(d azure.ClusterScoper, identityID s
 
 func newCloudProviderConfig(d azure.ClusterScoper) (controlPlaneConfig *CloudProviderConfig, workerConfig *CloudProviderConfig) {
 	subnet := getOneNodeSubnet(d)
+	// The cloud provider opens the ports of the load balancer services in the security group of the nodes.
+	securityGroupName := subnet.SecurityGroup.Name
+	if name := d.NetworkInterfaceSecurityGroupName(infrav1.Node); name != "" {
+		securityGroupName = name
+	}
 	return (&CloudProviderConfig{
 			Cloud:                        d.CloudEnvironment(),
 			AadClientID:                  d.ClientID(),
@@ -245,7 +258,7 @@ func newCloudProviderConfig(d azure.ClusterScoper) (controlPlaneConfig *CloudPro
 			TenantID:                     d.TenantID(),
 			SubscriptionID:               d.SubscriptionID(),
 			ResourceGroup:                d.ResourceGroup(),
-			SecurityGroupName:            subnet.SecurityGroup.Name,
+			SecurityGroupName:            securityGroupName,
 			SecurityGroupResourceGroup:   d.Vnet().ResourceGroup,
 			Location:                     d.Location(),
 			VMType:                       "vmss",
@@ -253,6 +266,7 @@ func newCloudProviderConfig(d azure.ClusterScoper) (controlPlaneConfig *CloudPro
 			VnetResourceGroup:            d.Vnet().ResourceGroup,
 			SubnetName:                   subnet.Name,
 			RouteTableName:               subnet.RouteTable.Name,
+			LoadBalancerName:             d.OutboundLBName(infrav1.Node),
 			LoadBalancerSku:              "Standard",
 			MaximumLoadBalancerRuleCount: 250,
 			UseManagedIdentityExtension:  false,
@@ -265,7 +279,7 @@ func newCloudProviderConfig(d azure.ClusterScoper) (controlPlaneConfig *CloudPro
 			TenantID:                     d.TenantID(),
 			SubscriptionID:               d.SubscriptionID(),
 			ResourceGroup:                d.ResourceGroup(),
-			SecurityGroupName:            subnet.SecurityGroup.Name,
+			SecurityGroupName:            securityGroupName,
 			SecurityGroupResourceGroup:   d.Vnet().ResourceGroup,
 			Location:                     d.Location(),
 			VMType:                       "vmss",
@@ -273,6 +287,7 @@ func newCloudProviderConfig(d azure.ClusterScoper) (controlPlaneConfig *CloudPro
 			VnetResourceGroup:            d.Vnet().ResourceGroup,
 			SubnetName:                   subnet.Name,
 			RouteTableName:               subnet.RouteTable.Name,
+			LoadBalancerName:             d.OutboundLBName(infrav1.Node),
 			LoadBalancerSku:              "Standard",
 			MaximumLoadBalancerRuleCount: 250,
 			UseManagedIdentityExtension:  false,
@@ -366,6 +381,7 @@ type CloudProviderConfig struct {
 	VnetResourceGroup            string `json:"vnetResourceGroup"`
 	SubnetName                   string `json:"subnetName"`
 	RouteTableName               string `json:"routeTableName"`
+	LoadBalancerName             string `json:"loadBalancerName,omitempty"`
 	LoadBalancerSku              string `json:"loadBalancerSku"`
 	MaximumLoadBalancerRuleCount int    `json:"maximumLoadBalancerRuleCount"`
 	UseManagedIdentityExtension  bool   `json:"useManagedIdentityExtension"`
@@ -375,6 +391,36 @@ type CloudProviderConfig struct {
 	BackOffConfig
 }
 
+// validate returns an error listing the fields the cloud provider requires but that are not populated,
+// so that an incomplete config is never handed to the bootstrap process.
+func (cpc *CloudProviderConfig) validate() error {
+	required := [][2]string{
+		{"tenantId", cpc.TenantID},
+		{"subscriptionId", cpc.SubscriptionID},
+		{"resourceGroup", cpc.ResourceGroup},
+		{"location", cpc.Location},
+		{"vnetName", cpc.VnetName},
+		{"vnetResourceGroup", cpc.VnetResourceGroup},
+		{"subnetName", cpc.SubnetName},
+		{"securityGroupName", cpc.SecurityGroupName},
+	}
+	if !cpc.UseManagedIdentityExtension {
+		// Service principal auth needs the credentials of the service principal.
+		required = append(required, [2]string{"aadClientId", cpc.AadClientID}, [2]string{"aadClientSecret", cpc.AadClientSecret})
+	}
+
+	var missing []string
+	for _, field := range required {
+		if field[1] == "" {
+			missing = append(missing, field[0])
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Errorf("missing required fields: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 // CloudProviderRateLimitConfig represents the rate limiting configurations in azure cloud provider config.
 // See: https://kubernetes-sigs.github.io/cloud-provider-azure/install/configs/#per-client-rate-limiting.
 // This is a copy of the struct used in cloud-provider-azure: https://github.com/kubernetes-sigs/cloud-provider-azure/blob/d585c2031925b39c925624302f22f8856e29e352/pkg/provider/azure_ratelimit.go#L25
@@ -571,7 +617,8 @@ func ShouldDeleteIndividualResources(ctx context.Context, clusterScope *scope.Cl
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.ShouldDeleteIndividualResources")
 	defer done()
 
-	if clusterScope.Cluster.DeletionTimestamp.IsZero() {
+	// In NetworkOnly mode the resource group is never deleted.
+	if clusterScope.Cluster.DeletionTimestamp.IsZero() || clusterScope.IsNetworkOnly() {
 		return true
 	}
 	grpSvc := groups.New(clusterScope)
@@ -597,3 +644,30 @@ func GetClusterIdentityFromRef(ctx context.Context, c client.Client, azureCluste
 	}
 	return nil, nil
 }
+
+// classifyReconcileError classifies the error of a reconciliation with the classifier, or with the default one when
+// it is nil. An ignored error is dropped, so that the reconciliation carries on as successful, and an error classified
+// as terminal is wrapped in a terminal ReconcileError. Long-running operations in progress and VMs deleted out of band
+// are handled by the controllers and never classified.
+func classifyReconcileError(log logr.Logger, classify azure.RetryClassifier, err error) error {
+	if err == nil || azure.IsOperationNotDoneError(err) || errors.As(err, &azure.VMDeletedError{}) {
+		return err
+	}
+	if classify == nil {
+		classify = azure.DefaultRetryClassifier
+	}
+
+	switch classify(err) {
+	case azure.RetryClassificationIgnore:
+		log.V(2).Info("ignoring reconcile error", "error", err.Error())
+		return nil
+	case azure.RetryClassificationTerminal:
+		var reconcileError azure.ReconcileError
+		if errors.As(err, &reconcileError) && reconcileError.IsTerminal() {
+			return err
+		}
+		return azure.WithTerminalError(err)
+	default:
+		return err
+	}
+}
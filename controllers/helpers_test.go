@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
@@ -28,6 +29,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,6 +37,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/internal/test/mock_log"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -181,6 +184,73 @@ func TestGetCloudProviderConfig(t *testing.T) {
 	}
 }
 
+func TestCloudProviderConfigValidate(t *testing.T) {
+	valid := func() CloudProviderConfig {
+		return CloudProviderConfig{
+			TenantID:          "fooTenant",
+			SubscriptionID:    "baz",
+			AadClientID:       "fooClient",
+			AadClientSecret:   "fooSecret",
+			ResourceGroup:     "bar",
+			SecurityGroupName: "foo-node-nsg",
+			Location:          "bar",
+			VnetName:          "foo-vnet",
+			VnetResourceGroup: "bar",
+			SubnetName:        "foo-node-subnet",
+		}
+	}
+
+	cases := map[string]struct {
+		config      func() CloudProviderConfig
+		expectedErr string
+	}{
+		"service principal config is valid": {
+			config: valid,
+		},
+		"managed identity config doesn't need service principal credentials": {
+			config: func() CloudProviderConfig {
+				c := valid()
+				c.AadClientID = ""
+				c.AadClientSecret = ""
+				c.UseManagedIdentityExtension = true
+				return c
+			},
+		},
+		"service principal config needs service principal credentials": {
+			config: func() CloudProviderConfig {
+				c := valid()
+				c.AadClientSecret = ""
+				return c
+			},
+			expectedErr: "missing required fields: aadClientSecret",
+		},
+		"all missing fields are reported": {
+			config: func() CloudProviderConfig {
+				c := valid()
+				c.VnetName = ""
+				c.SubnetName = ""
+				c.SecurityGroupName = ""
+				return c
+			},
+			expectedErr: "missing required fields: vnetName, subnetName, securityGroupName",
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := tc.config()
+			err := config.validate()
+			if tc.expectedErr != "" {
+				g.Expect(err).To(MatchError(tc.expectedErr))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestReconcileAzureSecret(t *testing.T) {
 	g := NewWithT(t)
 
@@ -461,6 +531,7 @@ const (
     "vnetResourceGroup": "bar",
     "subnetName": "foo-node-subnet",
     "routeTableName": "foo-node-routetable",
+    "loadBalancerName": "foo",
     "loadBalancerSku": "Standard",
     "maximumLoadBalancerRuleCount": 250,
     "useManagedIdentityExtension": false,
@@ -482,6 +553,7 @@ const (
     "vnetResourceGroup": "bar",
     "subnetName": "foo-node-subnet",
     "routeTableName": "foo-node-routetable",
+    "loadBalancerName": "foo",
     "loadBalancerSku": "Standard",
     "maximumLoadBalancerRuleCount": 250,
     "useManagedIdentityExtension": false,
@@ -501,6 +573,7 @@ const (
     "vnetResourceGroup": "bar",
     "subnetName": "foo-node-subnet",
     "routeTableName": "foo-node-routetable",
+    "loadBalancerName": "foo",
     "loadBalancerSku": "Standard",
     "maximumLoadBalancerRuleCount": 250,
     "useManagedIdentityExtension": true,
@@ -519,6 +592,7 @@ const (
     "vnetResourceGroup": "bar",
     "subnetName": "foo-node-subnet",
     "routeTableName": "foo-node-routetable",
+    "loadBalancerName": "foo",
     "loadBalancerSku": "Standard",
     "maximumLoadBalancerRuleCount": 250,
     "useManagedIdentityExtension": true,
@@ -538,6 +612,7 @@ const (
     "vnetResourceGroup": "bar",
     "subnetName": "foo-node-subnet",
     "routeTableName": "foo-node-routetable",
+    "loadBalancerName": "foo",
     "loadBalancerSku": "Standard",
     "maximumLoadBalancerRuleCount": 250,
     "useManagedIdentityExtension": true,
@@ -557,6 +632,7 @@ const (
     "vnetResourceGroup": "bar",
     "subnetName": "foo-node-subnet",
     "routeTableName": "foo-node-routetable",
+    "loadBalancerName": "foo",
     "loadBalancerSku": "Standard",
     "maximumLoadBalancerRuleCount": 250,
     "useManagedIdentityExtension": true,
@@ -578,6 +654,7 @@ const (
     "vnetResourceGroup": "custom-vnet-resource-group",
     "subnetName": "foo-node-subnet",
     "routeTableName": "foo-node-routetable",
+    "loadBalancerName": "foo",
     "loadBalancerSku": "Standard",
     "maximumLoadBalancerRuleCount": 250,
     "useManagedIdentityExtension": false,
@@ -598,6 +675,7 @@ const (
     "vnetResourceGroup": "custom-vnet-resource-group",
     "subnetName": "foo-node-subnet",
     "routeTableName": "foo-node-routetable",
+    "loadBalancerName": "foo",
     "loadBalancerSku": "Standard",
     "maximumLoadBalancerRuleCount": 250,
     "useManagedIdentityExtension": false,
@@ -618,6 +696,7 @@ const (
     "vnetResourceGroup": "bar",
     "subnetName": "foo-node-subnet",
     "routeTableName": "foo-node-routetable",
+    "loadBalancerName": "foo",
     "loadBalancerSku": "Standard",
     "maximumLoadBalancerRuleCount": 250,
     "useManagedIdentityExtension": false,
@@ -643,6 +722,7 @@ const (
     "vnetResourceGroup": "bar",
     "subnetName": "foo-node-subnet",
     "routeTableName": "foo-node-routetable",
+    "loadBalancerName": "foo",
     "loadBalancerSku": "Standard",
     "maximumLoadBalancerRuleCount": 250,
     "useManagedIdentityExtension": false,
@@ -668,6 +748,7 @@ const (
     "vnetResourceGroup": "bar",
     "subnetName": "foo-node-subnet",
     "routeTableName": "foo-node-routetable",
+    "loadBalancerName": "foo",
     "loadBalancerSku": "Standard",
     "maximumLoadBalancerRuleCount": 250,
     "useManagedIdentityExtension": false,
@@ -679,3 +760,64 @@ const (
     "cloudProviderBackoffJitter": 1.2000000000000002
 }`
 )
+
+func TestClassifyReconcileError(t *testing.T) {
+	notDone := azure.WithTransientError(azure.NewOperationNotDoneError(&infrav1.Future{}), time.Minute)
+	classifyAs := func(classification azure.RetryClassification) azure.RetryClassifier {
+		return func(error) azure.RetryClassification { return classification }
+	}
+
+	tests := []struct {
+		name       string
+		classifier azure.RetryClassifier
+		err        error
+		expect     func(g *WithT, err error)
+	}{
+		{
+			name: "no error",
+			expect: func(g *WithT, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+			},
+		},
+		{
+			name: "default classifier retries a plain error",
+			err:  errors.New("some error"),
+			expect: func(g *WithT, err error) {
+				g.Expect(err).To(MatchError("some error"))
+			},
+		},
+		{
+			name:       "error classified as ignored",
+			classifier: classifyAs(azure.RetryClassificationIgnore),
+			err:        errors.New("some error"),
+			expect: func(g *WithT, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+			},
+		},
+		{
+			name:       "error classified as terminal",
+			classifier: classifyAs(azure.RetryClassificationTerminal),
+			err:        errors.New("some error"),
+			expect: func(g *WithT, err error) {
+				var reconcileError azure.ReconcileError
+				g.Expect(errors.As(err, &reconcileError)).To(BeTrue())
+				g.Expect(reconcileError.IsTerminal()).To(BeTrue())
+			},
+		},
+		{
+			name:       "operation in progress is never classified",
+			classifier: classifyAs(azure.RetryClassificationIgnore),
+			err:        notDone,
+			expect: func(g *WithT, err error) {
+				g.Expect(err).To(Equal(notDone))
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			tc.expect(g, classifyReconcileError(logr.Discard(), tc.classifier, tc.err))
+		})
+	}
+}
@@ -45,11 +45,11 @@ type azureManagedControlPlaneService struct {
 }
 
 // newAzureManagedControlPlaneReconciler populates all the services based on input scope.
-func newAzureManagedControlPlaneReconciler(scope *scope.ManagedControlPlaneScope) *azureManagedControlPlaneService {
+func newAzureManagedControlPlaneReconciler(scope *scope.ManagedControlPlaneScope, kubeconfigRetry managedclusters.KubeconfigRetry) *azureManagedControlPlaneService {
 	return &azureManagedControlPlaneService{
 		kubeclient:         scope.Client,
 		scope:              scope,
-		managedClustersSvc: managedclusters.New(scope),
+		managedClustersSvc: managedclusters.New(scope, kubeconfigRetry),
 		groupsSvc:          groups.New(scope),
 		vnetSvc:            virtualnetworks.New(scope),
 		subnetsSvc:         subnets.New(scope),
@@ -34,6 +34,31 @@ const (
 	// owner: @alexeldeib
 	// alpha: v0.4
 	AKS featuregate.Feature = "AKS"
+
+	// OutboundConnectivityCheck is the feature gate for verifying the outbound connectivity of the node subnets with
+	// Azure Network Watcher.
+	// alpha: v1.2
+	OutboundConnectivityCheck featuregate.Feature = "OutboundConnectivityCheck"
+
+	// ResourceHealth is the feature gate for reporting the health of the resources of the clusters from Azure Resource
+	// Health, which requires the identity of the clusters to read it.
+	// alpha: v1.2
+	ResourceHealth featuregate.Feature = "ResourceHealth"
+
+	// PolicyAssignments is the feature gate for assigning Azure Policy definitions to the resource groups of the
+	// clusters, which requires the identity of the clusters to be allowed to assign policies.
+	// alpha: v1.2
+	PolicyAssignments featuregate.Feature = "PolicyAssignments"
+
+	// Budgets is the feature gate for creating Azure Cost Management budgets for the resource groups of the
+	// clusters, which requires the identity of the clusters to be allowed to manage budgets.
+	// alpha: v1.2
+	Budgets featuregate.Feature = "Budgets"
+
+	// CostEstimation is the feature gate for estimating the monthly cost of the network resources of the clusters
+	// from the Azure Retail Prices API.
+	// alpha: v1.2
+	CostEstimation featuregate.Feature = "CostEstimation"
 )
 
 func init() {
@@ -44,5 +69,10 @@ func init() {
 // To add a new feature, define a key for it above and add it here.
 var defaultCAPZFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	// Every feature should be initiated here:
-	AKS: {Default: false, PreRelease: featuregate.Alpha},
+	AKS:                       {Default: false, PreRelease: featuregate.Alpha},
+	OutboundConnectivityCheck: {Default: false, PreRelease: featuregate.Alpha},
+	ResourceHealth:            {Default: false, PreRelease: featuregate.Alpha},
+	PolicyAssignments:         {Default: false, PreRelease: featuregate.Alpha},
+	Budgets:                   {Default: false, PreRelease: featuregate.Alpha},
+	CostEstimation:            {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -24,6 +24,9 @@ import (
 	_ "net/http/pprof"
 	"os"
 	"time"
+	// The time zones of the auto-shutdown schedules are validated against the IANA database embedded in the binary,
+	// as the image of the manager may not have one.
+	_ "time/tzdata"
 
 	// +kubebuilder:scaffold:imports
 	aadpodv1 "github.com/Azure/aad-pod-identity/pkg/apis/aadpodidentity/v1"
@@ -39,6 +42,9 @@ import (
 	infrav1alpha3 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 	infrav1alpha4 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha4"
 	infrav1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/managedclusters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/controllers"
 	infrav1alpha3exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1alpha3"
 	infrav1alpha4exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1alpha4"
@@ -114,6 +120,20 @@ var (
 	healthAddr                         string
 	webhookPort                        int
 	reconcileTimeout                   time.Duration
+	phaseTimeouts                      reconciler.PhaseTimeouts
+	kubeconfigRetryInterval            time.Duration
+	kubeconfigRetryTimeout             time.Duration
+	expectedEnvironment                string
+	allowedCostCenters                 []string
+	requiredSecurityGroupTags          []string
+	registerResourceProviders          bool
+	maxReconcileAttempts               int
+	minTLSVersion                      string
+	enableAzureRequestLogging          bool
+	azureRequestLogVerbosity           int
+	skuCacheTTL                        time.Duration
+	notFoundRetryAttempts              int
+	notFoundRetryInterval              time.Duration
 	enableTracing                      bool
 )
 
@@ -234,6 +254,108 @@ func InitFlags(fs *pflag.FlagSet) {
 		"The maximum duration a reconcile loop can run (e.g. 90m)",
 	)
 
+	fs.DurationVar(&phaseTimeouts.ResourceGroup,
+		"resource-group-timeout",
+		reconciler.DefaultResourceGroupTimeout,
+		"The maximum duration a reconcile loop can spend reconciling or deleting the resource group of a cluster, after which it is requeued (e.g. 60m)",
+	)
+
+	fs.DurationVar(&phaseTimeouts.Network,
+		"network-timeout",
+		reconciler.DefaultNetworkTimeout,
+		"The maximum duration a reconcile loop can spend reconciling or deleting the network resources of a cluster, after which it is requeued (e.g. 15m)",
+	)
+
+	fs.DurationVar(&phaseTimeouts.LoadBalancer,
+		"load-balancer-timeout",
+		reconciler.DefaultLoadBalancerTimeout,
+		"The maximum duration a reconcile loop can spend reconciling or deleting the load balancers of a cluster, after which it is requeued (e.g. 10m)",
+	)
+
+	fs.DurationVar(&kubeconfigRetryInterval,
+		"kubeconfig-retry-interval",
+		reconciler.DefaultKubeconfigRetryInterval,
+		"The initial wait before retrying to fetch the kubeconfig of a managed cluster whose API server isn't reachable yet, doubled after each attempt (e.g. 2s)",
+	)
+
+	fs.DurationVar(&kubeconfigRetryTimeout,
+		"kubeconfig-retry-timeout",
+		reconciler.DefaultKubeconfigRetryTimeout,
+		"The maximum duration spent retrying to fetch the kubeconfig of a managed cluster before requeueing, 0 disables retries (e.g. 30s)",
+	)
+
+	fs.StringVar(
+		&expectedEnvironment,
+		"expected-environment",
+		"",
+		fmt.Sprintf("Value of the %q tag (e.g. dev or prod) that the existing Azure resources of the clusters and machines must have for the controller to adopt or delete them. It is also applied to the resources the controller creates. Untagged resources owned by a cluster are accepted. If unspecified, resources are not checked.", azure.EnvironmentTagKey),
+	)
+
+	fs.StringSliceVar(
+		&allowedCostCenters,
+		"allowed-cost-centers",
+		nil,
+		fmt.Sprintf("Comma-separated list of the values allowed for the %q tag of the clusters. If specified, clusters without one of them are not reconciled.", azure.CostCenterTagKey),
+	)
+
+	fs.StringSliceVar(
+		&requiredSecurityGroupTags,
+		"required-security-group-tags",
+		nil,
+		"Comma-separated list of the tags (e.g. dataClassification) each network security group of the clusters must have a value for. If specified, clusters with a security group missing one of them are not reconciled.",
+	)
+
+	fs.BoolVar(
+		&registerResourceProviders,
+		"register-resource-providers",
+		false,
+		"Register the resource providers and features the clusters require in their subscription when they aren't registered yet. If unspecified, clusters requiring unregistered ones are not reconciled until they are registered.",
+	)
+
+	fs.IntVar(&maxReconcileAttempts,
+		"max-reconcile-attempts",
+		0,
+		"The number of consecutive reconciliations of an AzureCluster failing with a terminal error after which it is marked as failed. If unspecified or zero, clusters are never marked as failed.",
+	)
+
+	fs.StringVar(
+		&minTLSVersion,
+		"azure-min-tls-version",
+		azure.DefaultMinTLSVersion,
+		"The minimum TLS version of the connections to the Azure APIs, either 1.2 or 1.3",
+	)
+
+	fs.BoolVar(
+		&enableAzureRequestLogging,
+		"enable-azure-request-logging",
+		false,
+		"Enable the logging of the method, URL, status and request IDs of the requests to the Azure APIs. Credentials are redacted and bodies are never logged.",
+	)
+
+	fs.IntVar(&azureRequestLogVerbosity,
+		"azure-request-log-verbosity",
+		azure.DefaultRequestLogVerbosity,
+		"The log verbosity at which the requests to the Azure APIs are logged when enable-azure-request-logging is set. Their redacted headers are logged one level above.",
+	)
+
+	fs.DurationVar(&skuCacheTTL,
+		"sku-cache-ttl",
+		resourceskus.DefaultCacheTTL,
+		"The duration after which the resource SKUs and zones cached for each identity and location are reloaded from Azure (e.g. 24h)",
+	)
+
+	fs.IntVar(&notFoundRetryAttempts,
+		"azure-not-found-retry-attempts",
+		azure.DefaultNotFoundRetryAttempts,
+		"The number of reads of an Azure resource that was just created before a not found response is considered genuine rather than eventual consistency. Set to 1 to disable the retries.",
+	)
+
+	fs.DurationVar(&notFoundRetryInterval,
+		"azure-not-found-retry-interval",
+		azure.DefaultNotFoundRetryInterval,
+		"The wait between two reads of an Azure resource that was just created and isn't found yet (e.g. 1s)",
+	)
+
 	fs.BoolVar(
 		&enableTracing,
 		"enable-tracing",
@@ -251,6 +373,26 @@ func main() {
 
 	ctrl.SetLogger(klogr.New())
 
+	if err := azure.SetMinTLSVersion(minTLSVersion); err != nil {
+		setupLog.Error(err, "invalid minimum TLS version")
+		os.Exit(1)
+	}
+
+	if err := azure.SetRequestLogging(enableAzureRequestLogging, azureRequestLogVerbosity); err != nil {
+		setupLog.Error(err, "invalid Azure request logging configuration")
+		os.Exit(1)
+	}
+
+	if err := resourceskus.SetCacheTTL(skuCacheTTL); err != nil {
+		setupLog.Error(err, "invalid resource SKU cache TTL")
+		os.Exit(1)
+	}
+
+	if err := azure.SetNotFoundRetry(notFoundRetryAttempts, notFoundRetryInterval); err != nil {
+		setupLog.Error(err, "invalid Azure not found retry configuration")
+		os.Exit(1)
+	}
+
 	if watchNamespace != "" {
 		setupLog.Info("Watching cluster-api objects only in namespace for reconciliation", "namespace", watchNamespace)
 	}
@@ -326,11 +468,13 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 	if err != nil {
 		setupLog.Error(err, "failed to build machineCache ReconcileCache")
 	}
-	if err := controllers.NewAzureMachineReconciler(mgr.GetClient(),
+	azureMachineReconciler := controllers.NewAzureMachineReconciler(mgr.GetClient(),
 		mgr.GetEventRecorderFor("azuremachine-reconciler"),
 		reconcileTimeout,
 		watchFilterValue,
-	).SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureMachineConcurrency}, Cache: machineCache}); err != nil {
+	)
+	azureMachineReconciler.ExpectedEnvironment = expectedEnvironment
+	if err := azureMachineReconciler.SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureMachineConcurrency}, Cache: machineCache}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AzureMachine")
 		os.Exit(1)
 	}
@@ -339,12 +483,19 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 	if err != nil {
 		setupLog.Error(err, "failed to build clusterCache ReconcileCache")
 	}
-	if err := controllers.NewAzureClusterReconciler(
+	azureClusterReconciler := controllers.NewAzureClusterReconciler(
 		mgr.GetClient(),
 		mgr.GetEventRecorderFor("azurecluster-reconciler"),
 		reconcileTimeout,
 		watchFilterValue,
-	).SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureClusterConcurrency}, Cache: clusterCache}); err != nil {
+	)
+	azureClusterReconciler.ExpectedEnvironment = expectedEnvironment
+	azureClusterReconciler.AllowedCostCenters = allowedCostCenters
+	azureClusterReconciler.RequiredSecurityGroupTags = requiredSecurityGroupTags
+	azureClusterReconciler.RegisterResourceProviders = registerResourceProviders
+	azureClusterReconciler.PhaseTimeouts = phaseTimeouts
+	azureClusterReconciler.MaxReconcileAttempts = int32(maxReconcileAttempts)
+	if err := azureClusterReconciler.SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureClusterConcurrency}, Cache: clusterCache}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AzureCluster")
 		os.Exit(1)
 	}
@@ -463,6 +614,10 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 				Recorder:         mgr.GetEventRecorderFor("azuremanagedcontrolplane-reconciler"),
 				ReconcileTimeout: reconcileTimeout,
 				WatchFilterValue: watchFilterValue,
+				KubeconfigRetry: managedclusters.KubeconfigRetry{
+					Interval: kubeconfigRetryInterval,
+					Timeout:  kubeconfigRetryTimeout,
+				},
 			}).SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureClusterConcurrency}, Cache: mcpCache}); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "AzureManagedControlPlane")
 				os.Exit(1)
@@ -518,6 +673,8 @@ func registerWebhooks(mgr manager.Manager) {
 		}
 	}
 
+	mgr.GetWebhookServer().Register("/validate-cluster-x-k8s-io-v1beta1-cluster", infrav1beta1.NewClusterDeleteProtectionWebhook())
+
 	if feature.Gates.Enabled(feature.AKS) {
 		hookServer := mgr.GetWebhookServer()
 		hookServer.Register("/mutate-infrastructure-cluster-x-k8s-io-v1beta1-azuremanagedmachinepool", webhook.NewMutatingWebhook(
@@ -31,6 +31,17 @@ const (
 	DefaultAzureCallTimeout = 2 * time.Second
 	// DefaultReconcilerRequeue is the default value for the reconcile retry.
 	DefaultReconcilerRequeue = 15 * time.Second
+	// DefaultKubeconfigRetryInterval is the default wait before retrying to fetch the kubeconfig of a cluster whose API server isn't reachable yet.
+	DefaultKubeconfigRetryInterval = 2 * time.Second
+	// DefaultKubeconfigRetryTimeout is the default maximum time spent retrying to fetch the kubeconfig of a cluster before requeueing.
+	DefaultKubeconfigRetryTimeout = 30 * time.Second
+	// DefaultResourceGroupTimeout is the default maximum duration of the resource group phase of a cluster reconcile,
+	// which includes the deletion of the resource group and of all its resources.
+	DefaultResourceGroupTimeout = 60 * time.Minute
+	// DefaultNetworkTimeout is the default maximum duration of the network phase of a cluster reconcile.
+	DefaultNetworkTimeout = 15 * time.Minute
+	// DefaultLoadBalancerTimeout is the default maximum duration of the load balancer phase of a cluster reconcile.
+	DefaultLoadBalancerTimeout = 10 * time.Minute
 )
 
 // DefaultedLoopTimeout will default the timeout if it is zero-valued.
@@ -41,3 +52,29 @@ func DefaultedLoopTimeout(timeout time.Duration) time.Duration {
 
 	return timeout
 }
+
+// PhaseTimeouts are the maximum durations of the phases of a cluster reconcile, each a group of Azure services that
+// are reconciled or deleted one after the other, so that slow phases can be given more time than fast ones without
+// raising the timeout of the whole reconcile loop.
+type PhaseTimeouts struct {
+	// ResourceGroup bounds the reconciliation and the deletion of the resource group.
+	ResourceGroup time.Duration
+	// Network bounds the virtual network, security groups, route tables, public IPs, NAT gateways, subnets and peerings.
+	Network time.Duration
+	// LoadBalancer bounds the load balancers.
+	LoadBalancer time.Duration
+}
+
+// Defaulted returns the phase timeouts with the zero-valued ones defaulted.
+func (t PhaseTimeouts) Defaulted() PhaseTimeouts {
+	if t.ResourceGroup <= 0 {
+		t.ResourceGroup = DefaultResourceGroupTimeout
+	}
+	if t.Network <= 0 {
+		t.Network = DefaultNetworkTimeout
+	}
+	if t.LoadBalancer <= 0 {
+		t.LoadBalancer = DefaultLoadBalancerTimeout
+	}
+	return t
+}
@@ -56,3 +56,18 @@ func TestDefaultedTimeout(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultedPhaseTimeouts(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(reconciler.PhaseTimeouts{}.Defaulted()).To(gomega.Equal(reconciler.PhaseTimeouts{
+		ResourceGroup: reconciler.DefaultResourceGroupTimeout,
+		Network:       reconciler.DefaultNetworkTimeout,
+		LoadBalancer:  reconciler.DefaultLoadBalancerTimeout,
+	}))
+	g.Expect(reconciler.PhaseTimeouts{Network: 5 * time.Minute, LoadBalancer: -1}.Defaulted()).To(gomega.Equal(reconciler.PhaseTimeouts{
+		ResourceGroup: reconciler.DefaultResourceGroupTimeout,
+		Network:       5 * time.Minute,
+		LoadBalancer:  reconciler.DefaultLoadBalancerTimeout,
+	}))
+}
@@ -66,3 +66,12 @@ func Get() Info {
 func (info Info) String() string {
 	return info.GitVersion
 }
+
+// Marker returns a deterministic marker of the build: the semantic version, or the git commit
+// for builds that were not versioned. It's empty when neither was set at build time.
+func (info Info) Marker() string {
+	if info.GitVersion != "" {
+		return info.GitVersion
+	}
+	return info.GitCommit
+}